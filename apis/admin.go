@@ -3,9 +3,11 @@ package apis
 import (
 	"log"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/dbx"
 	"github.com/pocketbase/pocketbase/core"
 	"github.com/pocketbase/pocketbase/daos"
 	"github.com/pocketbase/pocketbase/forms"
@@ -29,8 +31,17 @@ func bindAdminApi(app core.App, rg *echo.Group) {
 	subGroup.POST("/request-password-reset", api.requestPasswordReset)
 	subGroup.POST("/confirm-password-reset", api.confirmPasswordReset)
 	subGroup.POST("/auth-refresh", api.authRefresh, RequireAdminAuth())
+	subGroup.POST("/otp/enroll", api.otpEnroll, RequireAdminAuth())
+	subGroup.POST("/otp/verify", api.otpVerify, RequireAdminAuth())
+	subGroup.POST("/otp/disable", api.otpDisable, RequireAdminAuth())
+	subGroup.POST(
+		"/impersonate/:collection/:recordId",
+		api.impersonate,
+		RequireAdminAuth(),
+		LoadCollectionContext(app, models.CollectionTypeAuth),
+	)
 	subGroup.GET("", api.list, RequireAdminAuth())
-	subGroup.POST("", api.create, RequireAdminAuthOnlyIfAny(app))
+	subGroup.POST("", api.create, RequireAdminAuthOnlyIfAny(app), idempotent(app, newIdempotencyStore()))
 	subGroup.GET("/:id", api.view, RequireAdminAuth())
 	subGroup.PATCH("/:id", api.update, RequireAdminAuth())
 	subGroup.DELETE("/:id", api.delete, RequireAdminAuth())
@@ -48,6 +59,7 @@ func (api *adminApi) authResponse(c echo.Context, admin *models.Admin) error {
 
 	event := new(core.AdminAuthEvent)
 	event.HttpContext = c
+	event.RequestInfo = RequestInfo(c)
 	event.Admin = admin
 	event.Token = token
 
@@ -75,6 +87,7 @@ func (api *adminApi) authRefresh(c echo.Context) error {
 
 	event := new(core.AdminAuthRefreshEvent)
 	event.HttpContext = c
+	event.RequestInfo = RequestInfo(c)
 	event.Admin = admin
 
 	handlerErr := api.app.OnAdminBeforeAuthRefreshRequest().Trigger(event, func(e *core.AdminAuthRefreshEvent) error {
@@ -90,6 +103,179 @@ func (api *adminApi) authRefresh(c echo.Context) error {
 	return handlerErr
 }
 
+//	@Summary		Admin TOTP enroll
+//	@Description	Generates a new TOTP secret for the authorized admin.
+//	@Description	The two-factor authentication is enabled after a successful /admins/otp/verify request.
+//	@Tags			Admin
+//	@Produce		json
+//	@Security		AdminAuth
+//	@Success		200	{string}	string	"The TOTP secret and its otpauth:// key URI"
+//	@Failure		400	{string}	string	"Failed to enroll the two-factor authentication."
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Router			/admins/otp/enroll [post]
+func (api *adminApi) otpEnroll(c echo.Context) error {
+	admin, _ := c.Get(ContextAdminKey).(*models.Admin)
+	if admin == nil {
+		return NewNotFoundError("Missing auth admin context.", nil)
+	}
+
+	form := forms.NewAdminOtpEnroll(api.app, admin)
+
+	uri, err := form.Submit()
+	if err != nil {
+		return NewBadRequestError("Failed to enroll the two-factor authentication.", err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"secret": admin.OtpSecret,
+		"uri":    uri,
+	})
+}
+
+//	@Summary		Admin TOTP verify
+//	@Description	Verifies the TOTP enrollment of the authorized admin with a one-time code and enables the two-factor authentication.
+//	@Description	Returns the recovery codes (they are shown only once).
+//	@Tags			Admin
+//	@Accept			json
+//	@Produce		json
+//	@Security		AdminAuth
+//	@Param			body	body		forms.AdminOtpVerify	true	"One-time code"
+//	@Success		200		{string}	string					"The generated recovery codes"
+//	@Failure		400		{string}	string					"Failed to verify the two-factor authentication."
+//	@Failure		401		{string}	string					"The request requires admin authorization token to be set."
+//	@Router			/admins/otp/verify [post]
+func (api *adminApi) otpVerify(c echo.Context) error {
+	admin, _ := c.Get(ContextAdminKey).(*models.Admin)
+	if admin == nil {
+		return NewNotFoundError("Missing auth admin context.", nil)
+	}
+
+	form := forms.NewAdminOtpVerify(api.app, admin)
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	recoveryCodes, err := form.Submit()
+	if err != nil {
+		return NewBadRequestError("Failed to verify the two-factor authentication.", err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{
+		"recoveryCodes": recoveryCodes,
+	})
+}
+
+//	@Summary		Admin TOTP disable
+//	@Description	Disables the two-factor authentication of the authorized admin.
+//	@Tags			Admin
+//	@Accept			json
+//	@Security		AdminAuth
+//	@Param			body	body		forms.AdminOtpDisable	true	"One-time or recovery code"
+//	@Success		204		"The two-factor authentication was disabled"
+//	@Failure		400		{string}	string	"Failed to disable the two-factor authentication."
+//	@Failure		401		{string}	string	"The request requires admin authorization token to be set."
+//	@Router			/admins/otp/disable [post]
+func (api *adminApi) otpDisable(c echo.Context) error {
+	admin, _ := c.Get(ContextAdminKey).(*models.Admin)
+	if admin == nil {
+		return NewNotFoundError("Missing auth admin context.", nil)
+	}
+
+	form := forms.NewAdminOtpDisable(api.app, admin)
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	if err := form.Submit(); err != nil {
+		return NewBadRequestError("Failed to disable the two-factor authentication.", err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+//	@Summary		Admin impersonate
+//	@Description	Generates a short-lived auth token of the specified auth record
+//	@Description	(eg. to debug user specific API rules issues).
+//	@Tags			Admin
+//	@Accept			json
+//	@Produce		json
+//	@Security		AdminAuth
+//	@Param			collection	path		string					true	"Auth collection name or id"
+//	@Param			recordId	path		string					true	"Auth record id"
+//	@Param			body		body		forms.AdminImpersonate	false	"Token duration in seconds"
+//	@Success		200			{string}	string					"Auth token and record"
+//	@Failure		400			{string}	string					"Failed to impersonate the auth record."
+//	@Failure		401			{string}	string					"The request requires admin authorization token to be set."
+//	@Failure		404			{string}	string					"Not found."
+//	@Router			/admins/impersonate/{collection}/{recordId} [post]
+func (api *adminApi) impersonate(c echo.Context) error {
+	admin, _ := c.Get(ContextAdminKey).(*models.Admin)
+	if admin == nil {
+		return NewNotFoundError("Missing auth admin context.", nil)
+	}
+
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("", nil)
+	}
+
+	record, err := api.app.Dao().FindRecordById(collection.Id, c.PathParam("recordId"))
+	if err != nil || record == nil {
+		return NewNotFoundError("", err)
+	}
+
+	form := forms.NewAdminImpersonate(api.app, record)
+
+	// load request
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	event := new(core.AdminImpersonateEvent)
+	event.HttpContext = c
+	event.RequestInfo = RequestInfo(c)
+	event.Admin = admin
+	event.Record = record
+
+	token, submitErr := form.Submit(func(next forms.InterceptorNextFunc[*models.Record]) forms.InterceptorNextFunc[*models.Record] {
+		return func(m *models.Record) error {
+			event.Record = m
+
+			return api.app.OnAdminBeforeImpersonateRequest().Trigger(event, func(e *core.AdminImpersonateEvent) error {
+				return next(e.Record)
+			})
+		}
+	})
+
+	if submitErr != nil {
+		return NewBadRequestError("Failed to impersonate the auth record.", submitErr)
+	}
+
+	// the token generation was stopped by a before hook
+	// (it is expected to have written its own response)
+	if token == "" {
+		return nil
+	}
+
+	event.Token = token
+
+	// allow always returning the email address of the impersonated account
+	event.Record.IgnoreEmailVisibility(true)
+
+	if err := c.JSON(http.StatusOK, map[string]any{
+		"token":  event.Token,
+		"record": event.Record,
+	}); err != nil {
+		return err
+	}
+
+	if err := api.app.OnAdminAfterImpersonateRequest().Trigger(event); err != nil && api.app.IsDebug() {
+		log.Println(err)
+	}
+
+	return nil
+}
+
 // swagger:forms AdminLogin
 type AdminLogin struct {
 	app core.App
@@ -97,6 +283,7 @@ type AdminLogin struct {
 
 	Identity string `form:"identity" json:"identity"`
 	Password string `form:"password" json:"password"`
+	OtpCode  string `form:"otpCode" json:"otpCode"`
 }
 
 // swagger:forms AdminPasswordResetRequest
@@ -135,6 +322,7 @@ type Admin struct {
 	TokenKey        string         `db:"tokenKey" json:"-"`
 	PasswordHash    string         `db:"passwordHash" json:"-"`
 	LastResetSentAt types.DateTime `db:"lastResetSentAt" json:"-"`
+	OtpEnabled      bool           `db:"otpEnabled" json:"otpEnabled"`
 }
 
 // swagger:forms AdminCreateForm
@@ -183,6 +371,7 @@ func (api *adminApi) authWithPassword(c echo.Context) error {
 
 	event := new(core.AdminAuthWithPasswordEvent)
 	event.HttpContext = c
+	event.RequestInfo = RequestInfo(c)
 	event.Password = form.Password
 	event.Identity = form.Identity
 
@@ -230,6 +419,7 @@ func (api *adminApi) requestPasswordReset(c echo.Context) error {
 
 	event := new(core.AdminRequestPasswordResetEvent)
 	event.HttpContext = c
+	event.RequestInfo = RequestInfo(c)
 
 	submitErr := form.Submit(func(next forms.InterceptorNextFunc[*models.Admin]) forms.InterceptorNextFunc[*models.Admin] {
 		return func(Admin *models.Admin) error {
@@ -281,6 +471,7 @@ func (api *adminApi) confirmPasswordReset(c echo.Context) error {
 
 	event := new(core.AdminConfirmPasswordResetEvent)
 	event.HttpContext = c
+	event.RequestInfo = RequestInfo(c)
 
 	_, submitErr := form.Submit(func(next forms.InterceptorNextFunc[*models.Admin]) forms.InterceptorNextFunc[*models.Admin] {
 		return func(admin *models.Admin) error {
@@ -315,18 +506,28 @@ func (api *adminApi) confirmPasswordReset(c echo.Context) error {
 //	@Param			updated	query		string	false	"Дата обновления администратора"
 //	@Param			name	query		string	false	"Имя администратора"
 //	@Param			email	query		string	false	"Email администратора"
+//	@Param			q		query		string	false	"Строка поиска по email администратора"
 //	@Success		200		{array}		Admin
 //	@Failure		400		{string}	string	"Failed to authenticate."
 //	@Router			/admins [get]
 func (api *adminApi) list(c echo.Context) error {
 	fieldResolver := search.NewSimpleFieldResolver(
-		"id", "created", "updated", "name", "email",
+		"id", "created", "updated", "name", "email", "avatar", "lastResetSentAt",
 	)
 
+	query := api.app.Dao().AdminQuery()
+
+	// free-text search (all words must match)
+	//
+	// note: admins don't have a name column so only the email is matched
+	if q := strings.TrimSpace(c.QueryParam("q")); q != "" {
+		query.AndWhere(dbx.Like("email", strings.Fields(q)...))
+	}
+
 	admins := []*models.Admin{}
 
 	result, err := search.NewProvider(fieldResolver).
-		Query(api.app.Dao().AdminQuery()).
+		Query(query).
 		ParseAndExec(c.QueryParams().Encode(), &admins)
 
 	if err != nil {
@@ -335,6 +536,7 @@ func (api *adminApi) list(c echo.Context) error {
 
 	event := new(core.AdminsListEvent)
 	event.HttpContext = c
+	event.RequestInfo = RequestInfo(c)
 	event.Admins = admins
 	event.Result = result
 
@@ -350,6 +552,7 @@ func (api *adminApi) list(c echo.Context) error {
 //	@Produce		json
 //	@Param			id	path		string	true	"Идентификатор администратора"
 //	@Success		200	{object}	Admin
+//	@Header			200	{string}	ETag	"Версия администратора (для заголовка If-Match)"
 //	@Failure		400	{string}	string	"Failed to authenticate."
 //	@Router			/admins/{id} [get]
 func (api *adminApi) view(c echo.Context) error {
@@ -365,9 +568,12 @@ func (api *adminApi) view(c echo.Context) error {
 
 	event := new(core.AdminViewEvent)
 	event.HttpContext = c
+	event.RequestInfo = RequestInfo(c)
 	event.Admin = admin
 
 	return api.app.OnAdminViewRequest().Trigger(event, func(e *core.AdminViewEvent) error {
+		setETag(e.HttpContext, e.Admin)
+
 		return e.HttpContext.JSON(http.StatusOK, e.Admin)
 	})
 }
@@ -378,8 +584,12 @@ func (api *adminApi) view(c echo.Context) error {
 //	@Accept			json
 //	@Produce		json
 //	@Param			admin	body		AdminCreateForm	true	"Данные для создания администратора"
+//	@Param			Idempotency-Key	header	string	false	"Ключ идемпотентности для безопасного повтора запроса"
 //	@Success		200		{object}	Admin
+//	@Header			200		{string}	Idempotent-Replayed	"true, если ответ является повтором сохраненного ответа"
 //	@Failure		400		{string}	string	"Failed to authenticate."
+//	@Failure		409		{string}	string	"A request with the same Idempotency-Key is still in progress."
+//	@Failure		422		{string}	string	"The Idempotency-Key was already used with a different request."
 //	@Router			/admins [post]
 func (api *adminApi) create(c echo.Context) error {
 	admin := &models.Admin{}
@@ -393,6 +603,7 @@ func (api *adminApi) create(c echo.Context) error {
 
 	event := new(core.AdminCreateEvent)
 	event.HttpContext = c
+	event.RequestInfo = RequestInfo(c)
 	event.Admin = admin
 
 	// create the admin
@@ -426,9 +637,12 @@ func (api *adminApi) create(c echo.Context) error {
 //	@Produce		json
 //	@Param			id		path		string			true	"Идентификатор администратора"
 //	@Param			admin	body		AdminUpdateForm	true	"Данные для обновления администратора"
+//	@Param			If-Match	header	string	false	"ETag просмотренной версии (обновление отклоняется, если администратор был изменен)"
 //	@Success		200		{object}	Admin
+//	@Header			200		{string}	ETag	"Новая версия администратора"
 //	@Failure		400		{string}	string	"Failed to authenticate."
 //	@Failure		400		{string}	string	"Not found"
+//	@Failure		409		{string}	string	"The resource was modified by another request."
 //	@Router			/admins/{id} [patch]
 func (api *adminApi) update(c echo.Context) error {
 	id := c.PathParam("id")
@@ -450,6 +664,7 @@ func (api *adminApi) update(c echo.Context) error {
 
 	event := new(core.AdminUpdateEvent)
 	event.HttpContext = c
+	event.RequestInfo = RequestInfo(c)
 	event.Admin = admin
 
 	// update the admin
@@ -458,10 +673,17 @@ func (api *adminApi) update(c echo.Context) error {
 			event.Admin = m
 
 			return api.app.OnAdminBeforeUpdateRequest().Trigger(event, func(e *core.AdminUpdateEvent) error {
-				if err := next(e.Admin); err != nil {
-					return NewBadRequestError("Failed to update admin.", err)
+				// the If-Match check and the admin save share the same transaction
+				if err := submitIfMatch(e.HttpContext, api.app.Dao(), form, func(txDao *daos.Dao) (models.Model, error) {
+					return txDao.FindAdminById(admin.Id)
+				}, func() error {
+					return next(e.Admin)
+				}); err != nil {
+					return newETagMismatchError(err, "Failed to update admin.")
 				}
 
+				setETag(e.HttpContext, e.Admin)
+
 				return e.HttpContext.JSON(http.StatusOK, e.Admin)
 			})
 		}
@@ -497,6 +719,7 @@ func (api *adminApi) delete(c echo.Context) error {
 
 	event := new(core.AdminDeleteEvent)
 	event.HttpContext = c
+	event.RequestInfo = RequestInfo(c)
 	event.Admin = admin
 
 	handlerErr := api.app.OnAdminBeforeDeleteRequest().Trigger(event, func(e *core.AdminDeleteEvent) error {
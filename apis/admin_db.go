@@ -0,0 +1,72 @@
+package apis
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/querystats"
+)
+
+const (
+	defaultSlowQueriesLimit int = 20
+	maxSlowQueriesLimit     int = 100
+)
+
+// swagger:models SlowQueryItem
+type SlowQueryItem struct {
+	*querystats.Stat
+
+	IndexSuggestions []*models.IndexSuggestion `json:"indexSuggestions"`
+}
+
+// bindAdminDbApi registers the admin db insights api endpoints.
+func bindAdminDbApi(app core.App, rg *echo.Group) {
+	api := adminDbApi{app: app}
+
+	subGroup := rg.Group("/admin/db", RequireAdminAuth())
+	subGroup.GET("/slow-queries", api.slowQueries)
+}
+
+type adminDbApi struct {
+	app core.App
+}
+
+//	@Summary		List slow queries
+//	@Description	Returns the slowest recently executed db queries (grouped by their normalized SQL and sorted by p95 duration) with missing index suggestions. The queries are tracked only when enabled in the slowQueries settings and exceed its threshold
+//	@Tags			Admin
+//	@Produce		json
+//	@Param			limit	query	int	false	"Max number of returned queries (default 20, max 100)"
+//	@Security		AdminAuth
+//	@Success		200	{array}		SlowQueryItem
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Router			/admin/db/slow-queries [get]
+func (api *adminDbApi) slowQueries(c echo.Context) error {
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	if limit <= 0 {
+		limit = defaultSlowQueriesLimit
+	} else if limit > maxSlowQueriesLimit {
+		limit = maxSlowQueriesLimit
+	}
+
+	stats := api.app.QueryStats().Slowest(limit)
+
+	result := make([]*SlowQueryItem, len(stats))
+
+	for i, stat := range stats {
+		suggestions, err := api.app.Dao().SuggestIndexes(stat.Query)
+		if err != nil {
+			// not all statements can be explained (eg. DDL statements)
+			suggestions = []*models.IndexSuggestion{}
+		}
+
+		result[i] = &SlowQueryItem{
+			Stat:             stat,
+			IndexSuggestions: suggestions,
+		}
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
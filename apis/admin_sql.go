@@ -0,0 +1,314 @@
+package apis
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// swagger:models SqlConsoleRunRequest
+type SqlConsoleRunRequest struct {
+	Query   string         `json:"query"`
+	Params  map[string]any `json:"params"`
+	Explain bool           `json:"explain"`
+	Write   bool           `json:"write"`
+}
+
+// swagger:models SqlQueryRunRequest
+type SqlQueryRunRequest struct {
+	Params  map[string]any `json:"params"`
+	Explain bool           `json:"explain"`
+	Write   bool           `json:"write"`
+}
+
+// bindAdminSqlApi registers the admin SQL console api endpoints.
+//
+//	@todo	add hooks once the app hooks api restructuring is finalized
+func bindAdminSqlApi(app core.App, rg *echo.Group) {
+	api := adminSqlApi{app: app}
+
+	subGroup := rg.Group(
+		"/admin/sql",
+		ActivityLogger(app),
+		RequireAdminAuth(),
+		requireSqlConsoleSuperadmin(app),
+	)
+
+	subGroup.POST("", api.run)
+	subGroup.GET("/queries", api.listQueries)
+	subGroup.POST("/queries", api.createQuery)
+	subGroup.GET("/queries/:id", api.viewQuery)
+	subGroup.PATCH("/queries/:id", api.updateQuery)
+	subGroup.DELETE("/queries/:id", api.deleteQuery)
+	subGroup.POST("/queries/:id/run", api.runQuery)
+}
+
+// requireSqlConsoleSuperadmin middleware requires the SQL console to be
+// enabled and the authorized admin to be listed in its superadmins.
+func requireSqlConsoleSuperadmin(app core.App) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !app.Settings().SqlConsole.Enabled {
+				return NewForbiddenError("The SQL console is disabled.", nil)
+			}
+
+			admin, _ := c.Get(ContextAdminKey).(*models.Admin)
+			if admin == nil || !app.Settings().SqlConsole.IsSuperadmin(admin.Id) {
+				return NewForbiddenError("Only superadmins can access the SQL console.", nil)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+type adminSqlApi struct {
+	app core.App
+}
+
+//	@Summary		Run SQL query
+//	@Description	Executes a single SQL statement (read-only by default) against the app database. Every execution is stored in the audit logs
+//	@Tags			SqlConsole
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body	SqlConsoleRunRequest	true	"Query data"
+//	@Security		AdminAuth
+//	@Success		200	{object}	forms.SqlConsoleResult
+//	@Failure		400	{string}	string	"Failed to execute the query."
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Failure		403	{string}	string	"Only superadmins can access the SQL console."
+//	@Router			/admin/sql [post]
+func (api *adminSqlApi) run(c echo.Context) error {
+	form := forms.NewSqlConsoleRun(api.app)
+	form.SetContext(c.Request().Context())
+
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	return api.submitRun(c, form, "")
+}
+
+//	@Summary		Run saved SQL query
+//	@Description	Executes the saved SQL query with the provided params
+//	@Tags			SqlConsole
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path	string				true	"Saved query id"
+//	@Param			body	body	SqlQueryRunRequest	false	"Query params"
+//	@Security		AdminAuth
+//	@Success		200	{object}	forms.SqlConsoleResult
+//	@Failure		400	{string}	string	"Failed to execute the query."
+//	@Failure		403	{string}	string	"Only superadmins can access the SQL console."
+//	@Failure		404	{string}	string	"The requested resource wasn't found."
+//	@Router			/admin/sql/queries/{id}/run [post]
+func (api *adminSqlApi) runQuery(c echo.Context) error {
+	sqlQuery, err := api.app.Dao().FindSqlQueryById(c.PathParam("id"))
+	if err != nil || sqlQuery == nil {
+		return NewNotFoundError("", err)
+	}
+
+	form := forms.NewSqlConsoleRun(api.app)
+	form.SetContext(c.Request().Context())
+
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	// always use the saved query statement
+	form.Query = sqlQuery.Query
+
+	return api.submitRun(c, form, sqlQuery.Id)
+}
+
+func (api *adminSqlApi) submitRun(c echo.Context, form *forms.SqlConsoleRun, sqlQueryId string) error {
+	// audit meta stored with the request log
+	meta := types.JsonMap{
+		"sqlQuery":   form.Query,
+		"sqlParams":  form.Params,
+		"sqlExplain": form.Explain,
+		"sqlWrite":   form.Write,
+	}
+	if sqlQueryId != "" {
+		meta["sqlQueryId"] = sqlQueryId
+	}
+
+	auditLog := &models.AuditLog{
+		Action:     models.AuditActionExecute,
+		Resource:   models.AuditResourceSqlConsole,
+		ResourceId: sqlQueryId,
+		Actor:      models.RequestAuthAdmin,
+		UserIp:     clientIp(c),
+		Url:        c.Request().URL.RequestURI(),
+		Changes:    meta,
+	}
+
+	if admin, _ := c.Get(ContextAdminKey).(*models.Admin); admin != nil {
+		meta["adminId"] = admin.Id
+		auditLog.ActorId = admin.Id
+	}
+	c.Set(ContextActivityMetaKey, meta)
+
+	// unlike the request logs, the SQL console executions are always
+	// audited and the query is not executed if the audit log can't be saved
+	if err := api.app.LogsDao().SaveAuditLog(auditLog); err != nil {
+		return NewBadRequestError("Failed to save the SQL console audit log.", err)
+	}
+
+	submitErr := form.Submit(func(next forms.InterceptorNextFunc[*forms.SqlConsoleResult]) forms.InterceptorNextFunc[*forms.SqlConsoleResult] {
+		return func(result *forms.SqlConsoleResult) error {
+			if err := next(result); err != nil {
+				return NewBadRequestError("Failed to execute the query.", err)
+			}
+
+			meta["sqlRows"] = len(result.Rows)
+			meta["sqlRowsAffected"] = result.RowsAffected
+
+			return c.JSON(http.StatusOK, result)
+		}
+	})
+
+	// store the execution outcome
+	if submitErr != nil {
+		meta["sqlError"] = submitErr.Error()
+	}
+	if err := api.app.LogsDao().SaveAuditLog(auditLog); err != nil && api.app.IsDebug() {
+		log.Println("SQL console audit log save failed:", err)
+	}
+
+	return submitErr
+}
+
+//	@Summary		List saved SQL queries
+//	@Description	Returns a paginated saved SQL queries list
+//	@Tags			SqlConsole
+//	@Produce		json
+//	@Param			page	query	int		false	"Page number"
+//	@Param			perPage	query	int		false	"Items per page"
+//	@Param			sort	query	string	false	"Sort fields"
+//	@Param			filter	query	string	false	"Filter expression"
+//	@Security		AdminAuth
+//	@Success		200	{object}	search.Result
+//	@Failure		400	{string}	string	"Something went wrong while processing your request."
+//	@Failure		403	{string}	string	"Only superadmins can access the SQL console."
+//	@Router			/admin/sql/queries [get]
+func (api *adminSqlApi) listQueries(c echo.Context) error {
+	fieldResolver := search.NewSimpleFieldResolver(
+		"id", "created", "updated", "name", "description", "query",
+	)
+
+	queries := []*models.SqlQuery{}
+
+	result, err := search.NewProvider(fieldResolver).
+		Query(api.app.Dao().SqlQueryQuery()).
+		ParseAndExec(c.QueryParams().Encode(), &queries)
+
+	if err != nil {
+		return NewBadRequestError("", err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+//	@Summary		View saved SQL query
+//	@Description	Returns a single saved SQL query by its id
+//	@Tags			SqlConsole
+//	@Produce		json
+//	@Param			id	path	string	true	"Saved query id"
+//	@Security		AdminAuth
+//	@Success		200	{object}	models.SqlQuery
+//	@Failure		403	{string}	string	"Only superadmins can access the SQL console."
+//	@Failure		404	{string}	string	"The requested resource wasn't found."
+//	@Router			/admin/sql/queries/{id} [get]
+func (api *adminSqlApi) viewQuery(c echo.Context) error {
+	sqlQuery, err := api.app.Dao().FindSqlQueryById(c.PathParam("id"))
+	if err != nil || sqlQuery == nil {
+		return NewNotFoundError("", err)
+	}
+
+	return c.JSON(http.StatusOK, sqlQuery)
+}
+
+//	@Summary		Create saved SQL query
+//	@Description	Creates a new saved (optionally parameterized) SQL query
+//	@Tags			SqlConsole
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body	forms.SqlQueryUpsert	true	"Saved query data"
+//	@Security		AdminAuth
+//	@Success		200	{object}	models.SqlQuery
+//	@Failure		400	{string}	string	"Failed to create saved query."
+//	@Failure		403	{string}	string	"Only superadmins can access the SQL console."
+//	@Router			/admin/sql/queries [post]
+func (api *adminSqlApi) createQuery(c echo.Context) error {
+	return api.upsertQuery(c, &models.SqlQuery{})
+}
+
+//	@Summary		Update saved SQL query
+//	@Description	Updates the saved SQL query with the specified id
+//	@Tags			SqlConsole
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path	string					true	"Saved query id"
+//	@Param			body	body	forms.SqlQueryUpsert	true	"Saved query data"
+//	@Security		AdminAuth
+//	@Success		200	{object}	models.SqlQuery
+//	@Failure		400	{string}	string	"Failed to update saved query."
+//	@Failure		403	{string}	string	"Only superadmins can access the SQL console."
+//	@Failure		404	{string}	string	"The requested resource wasn't found."
+//	@Router			/admin/sql/queries/{id} [patch]
+func (api *adminSqlApi) updateQuery(c echo.Context) error {
+	sqlQuery, err := api.app.Dao().FindSqlQueryById(c.PathParam("id"))
+	if err != nil || sqlQuery == nil {
+		return NewNotFoundError("", err)
+	}
+
+	return api.upsertQuery(c, sqlQuery)
+}
+
+func (api *adminSqlApi) upsertQuery(c echo.Context, sqlQuery *models.SqlQuery) error {
+	form := forms.NewSqlQueryUpsert(api.app, sqlQuery)
+
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("Failed to load the submitted data due to invalid formatting.", err)
+	}
+
+	return form.Submit(func(next forms.InterceptorNextFunc[*models.SqlQuery]) forms.InterceptorNextFunc[*models.SqlQuery] {
+		return func(m *models.SqlQuery) error {
+			if err := next(m); err != nil {
+				return NewBadRequestError("Failed to save the query.", err)
+			}
+
+			return c.JSON(http.StatusOK, m)
+		}
+	})
+}
+
+//	@Summary		Delete saved SQL query
+//	@Description	Deletes the saved SQL query with the specified id
+//	@Tags			SqlConsole
+//	@Param			id	path	string	true	"Saved query id"
+//	@Security		AdminAuth
+//	@Success		204	"No Content"
+//	@Failure		400	{string}	string	"Failed to delete saved query."
+//	@Failure		403	{string}	string	"Only superadmins can access the SQL console."
+//	@Failure		404	{string}	string	"The requested resource wasn't found."
+//	@Router			/admin/sql/queries/{id} [delete]
+func (api *adminSqlApi) deleteQuery(c echo.Context) error {
+	sqlQuery, err := api.app.Dao().FindSqlQueryById(c.PathParam("id"))
+	if err != nil || sqlQuery == nil {
+		return NewNotFoundError("", err)
+	}
+
+	if err := api.app.Dao().DeleteSqlQuery(sqlQuery); err != nil {
+		return NewBadRequestError("Failed to delete saved query.", err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,98 @@
+package apis
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/ratelimit"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// swagger:models AnalyticsQueryRequest
+type AnalyticsQueryRequest struct {
+	Query  string         `json:"query"`
+	Params map[string]any `json:"params"`
+}
+
+// bindAnalyticsApi registers the analytics api endpoints.
+func bindAnalyticsApi(app core.App, rg *echo.Group) {
+	api := analyticsApi{app: app, quotas: ratelimit.New()}
+
+	subGroup := rg.Group("/analytics", ActivityLogger(app), RequireAdminAuth())
+	subGroup.POST("/query", api.query)
+}
+
+type analyticsApi struct {
+	app    core.App
+	quotas *ratelimit.Limiter
+}
+
+//	@Summary		Run analytics query
+//	@Description	Executes a single read-only SQL statement against a periodically refreshed snapshot of the app database (not the live file)
+//	@Description	Each admin could execute up to "quotaQueries" queries per "quotaWindow" (see the analytics settings), including the failed ones
+//	@Tags			Analytics
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body	AnalyticsQueryRequest	true	"Query data"
+//	@Security		AdminAuth
+//	@Success		200	{object}	forms.AnalyticsQueryResult
+//	@Header			200	{int}		X-Quota-Remaining	"The number of queries left in the current quota window"
+//	@Failure		400	{string}	string	"Failed to execute the query."
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Failure		403	{string}	string	"The analytics queries are not enabled."
+//	@Failure		429	{string}	string	"The analytics query quota was exceeded."
+//	@Router			/analytics/query [post]
+func (api *analyticsApi) query(c echo.Context) error {
+	config := api.app.Settings().Analytics
+	if !config.Enabled {
+		return NewForbiddenError("The analytics queries are not enabled.", nil)
+	}
+
+	admin, _ := c.Get(ContextAdminKey).(*models.Admin)
+	if admin == nil {
+		return NewUnauthorizedError("", nil)
+	}
+
+	allowed, usage := api.quotas.Allow(admin.Id, config.QuotaQueries, time.Duration(config.QuotaWindow)*time.Second)
+	if config.QuotaQueries > 0 {
+		header := c.Response().Header()
+		header.Set("X-Quota-Limit", strconv.Itoa(usage.Limit))
+		header.Set("X-Quota-Remaining", strconv.Itoa(usage.Remaining))
+		header.Set("X-Quota-Reset", strconv.FormatInt(usage.ResetAt.Unix(), 10))
+	}
+	if !allowed {
+		return NewApiError(http.StatusTooManyRequests, "The analytics query quota was exceeded.", nil)
+	}
+
+	form := forms.NewAnalyticsQuery(api.app)
+	form.SetContext(c.Request().Context())
+
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	// audit meta stored with the request log
+	meta := types.JsonMap{
+		"analyticsQuery":  form.Query,
+		"analyticsParams": form.Params,
+		"adminId":         admin.Id,
+	}
+	c.Set(ContextActivityMetaKey, meta)
+
+	return form.Submit(func(next forms.InterceptorNextFunc[*forms.AnalyticsQueryResult]) forms.InterceptorNextFunc[*forms.AnalyticsQueryResult] {
+		return func(result *forms.AnalyticsQueryResult) error {
+			if err := next(result); err != nil {
+				return NewBadRequestError("Failed to execute the query.", err)
+			}
+
+			meta["analyticsRows"] = len(result.Rows)
+
+			return c.JSON(http.StatusOK, result)
+		}
+	})
+}
@@ -0,0 +1,108 @@
+package apis_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func enableAnalytics(quotaQueries int) func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+	return func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+		app.Settings().Analytics.Enabled = true
+		app.Settings().Analytics.QuotaQueries = quotaQueries
+	}
+}
+
+func TestAnalyticsQuery(t *testing.T) {
+	scenarios := []tests.ApiScenario{
+		{
+			Name:            "unauthorized",
+			Method:          http.MethodPost,
+			Url:             "/api/analytics/query",
+			Body:            strings.NewReader(`{"query":"SELECT 1"}`),
+			BeforeTestFunc:  enableAnalytics(10),
+			ExpectedStatus:  401,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "authorized as admin + disabled analytics",
+			Method: http.MethodPost,
+			Url:    "/api/analytics/query",
+			Body:   strings.NewReader(`{"query":"SELECT 1"}`),
+			RequestHeaders: map[string]string{
+				"Authorization": testSuperadminToken,
+			},
+			ExpectedStatus:  403,
+			ExpectedContent: []string{`"message":"The analytics queries are not enabled."`},
+		},
+		{
+			Name:   "authorized as admin + write query",
+			Method: http.MethodPost,
+			Url:    "/api/analytics/query",
+			Body:   strings.NewReader(`{"query":"DELETE FROM demo2"}`),
+			RequestHeaders: map[string]string{
+				"Authorization": testSuperadminToken,
+			},
+			BeforeTestFunc: enableAnalytics(10),
+			ExpectedStatus: 400,
+			ExpectedContent: []string{
+				`"query":{"code":"validation_sql_console_read_only"`,
+			},
+		},
+		{
+			Name:   "authorized as admin + read query",
+			Method: http.MethodPost,
+			Url:    "/api/analytics/query",
+			Body:   strings.NewReader(`{"query":"SELECT id FROM demo2 WHERE title = {:title}","params":{"title":"test1"}}`),
+			RequestHeaders: map[string]string{
+				"Authorization": testSuperadminToken,
+			},
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				enableAnalytics(10)(t, app, e)
+
+				expectResponseHeaders(t, e, map[string]string{
+					"X-Quota-Limit":     "10",
+					"X-Quota-Remaining": "9",
+				})
+			},
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"columns":["id"]`,
+				`"rows":[["llvuca81nly1qls"]]`,
+				`"snapshot":"20`,
+			},
+		},
+		{
+			Name:   "authorized as admin + exceeded quota",
+			Method: http.MethodPost,
+			Url:    "/api/analytics/query",
+			Body:   strings.NewReader(`{"query":"SELECT 1"}`),
+			RequestHeaders: map[string]string{
+				"Authorization": testSuperadminToken,
+			},
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				enableAnalytics(1)(t, app, e)
+
+				// consume the admin quota
+				req := httptest.NewRequest(http.MethodPost, "/api/analytics/query", strings.NewReader(`{"query":"SELECT 1"}`))
+				req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+				req.Header.Set("Authorization", testSuperadminToken)
+				rec := httptest.NewRecorder()
+				e.ServeHTTP(rec, req)
+				if rec.Code != http.StatusOK {
+					t.Fatalf("Expected the first query to succeed, got %d (%s)", rec.Code, rec.Body.String())
+				}
+			},
+			ExpectedStatus:  429,
+			ExpectedContent: []string{`"message":"The analytics query quota was exceeded."`},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
@@ -0,0 +1,138 @@
+package apis
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+// List of the api key scopes of the builtin api endpoints
+// (see [RequireApiKeyAuth()]).
+const (
+	ApiKeyScopeUsers = "users"
+	ApiKeyScopeDocs  = "docs"
+)
+
+// bindApiKeyApi registers the api keys api endpoints.
+func bindApiKeyApi(app core.App, rg *echo.Group) {
+	api := apiKeyApi{app: app}
+
+	subGroup := rg.Group("/api-keys", ActivityLogger(app), RequireAdminAuth())
+	subGroup.GET("", api.list)
+	subGroup.POST("", api.create)
+	subGroup.GET("/:id", api.view)
+	subGroup.DELETE("/:id", api.revoke)
+}
+
+type apiKeyApi struct {
+	app core.App
+}
+
+//	@Summary		List api keys
+//	@Description	Returns a paginated api keys list (the plain keys are never returned)
+//	@Tags			ApiKeys
+//	@Produce		json
+//	@Param			page	query	int		false	"Page number"
+//	@Param			perPage	query	int		false	"Items per page"
+//	@Param			sort	query	string	false	"Sort fields"
+//	@Param			filter	query	string	false	"Filter expression"
+//	@Security		AdminAuth
+//	@Success		200	{object}	search.Result{items=[]models.ApiKey}
+//	@Failure		400	{string}	string	"Something went wrong while processing your request."
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Router			/api-keys [get]
+func (api *apiKeyApi) list(c echo.Context) error {
+	fieldResolver := search.NewSimpleFieldResolver(
+		"id", "created", "updated", "name", "prefix", "adminId",
+		"collectionId", "recordId", "expires", "lastUsed",
+	)
+
+	apiKeys := []*models.ApiKey{}
+
+	result, err := search.NewProvider(fieldResolver).
+		Query(api.app.Dao().ApiKeyQuery()).
+		ParseAndExec(c.QueryParams().Encode(), &apiKeys)
+
+	if err != nil {
+		return NewBadRequestError("", err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+//	@Summary		View api key
+//	@Description	Returns a single api key by its id
+//	@Tags			ApiKeys
+//	@Produce		json
+//	@Param			id	path	string	true	"Api key id"
+//	@Security		AdminAuth
+//	@Success		200	{object}	models.ApiKey
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Failure		404	{string}	string	"The requested resource wasn't found."
+//	@Router			/api-keys/{id} [get]
+func (api *apiKeyApi) view(c echo.Context) error {
+	apiKey, err := api.app.Dao().FindApiKeyById(c.PathParam("id"))
+	if err != nil || apiKey == nil {
+		return NewNotFoundError("", err)
+	}
+
+	return c.JSON(http.StatusOK, apiKey)
+}
+
+//	@Summary		Create api key
+//	@Description	Creates a new scoped api key acting as the current admin or as the specified auth record (the plain key is returned only once)
+//	@Tags			ApiKeys
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body	forms.ApiKeyCreate	true	"Api key data"
+//	@Security		AdminAuth
+//	@Success		200	{object}	forms.ApiKeyCreateResult
+//	@Failure		400	{string}	string	"Failed to create the api key."
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Router			/api-keys [post]
+func (api *apiKeyApi) create(c echo.Context) error {
+	admin, _ := c.Get(ContextAdminKey).(*models.Admin)
+
+	form := forms.NewApiKeyCreate(api.app, admin)
+
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("Failed to load the submitted data due to invalid formatting.", err)
+	}
+
+	return form.Submit(func(next forms.InterceptorNextFunc[*forms.ApiKeyCreateResult]) forms.InterceptorNextFunc[*forms.ApiKeyCreateResult] {
+		return func(result *forms.ApiKeyCreateResult) error {
+			if err := next(result); err != nil {
+				return NewBadRequestError("Failed to create the api key.", err)
+			}
+
+			return c.JSON(http.StatusOK, result)
+		}
+	})
+}
+
+//	@Summary		Revoke api key
+//	@Description	Deletes the api key with the specified id
+//	@Tags			ApiKeys
+//	@Param			id	path	string	true	"Api key id"
+//	@Security		AdminAuth
+//	@Success		204	"No Content"
+//	@Failure		400	{string}	string	"Failed to revoke the api key."
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Failure		404	{string}	string	"The requested resource wasn't found."
+//	@Router			/api-keys/{id} [delete]
+func (api *apiKeyApi) revoke(c echo.Context) error {
+	apiKey, err := api.app.Dao().FindApiKeyById(c.PathParam("id"))
+	if err != nil || apiKey == nil {
+		return NewNotFoundError("", err)
+	}
+
+	if err := api.app.Dao().DeleteApiKey(apiKey); err != nil {
+		return NewBadRequestError("Failed to revoke the api key.", err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
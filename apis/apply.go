@@ -0,0 +1,133 @@
+package apis
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/spf13/cast"
+)
+
+// swagger:models ApplyResult
+type ApplyResult struct {
+	Plan    *forms.DeclarativePlan `json:"plan"`
+	Applied bool                   `json:"applied"`
+}
+
+// bindApplyApi registers the declarative state apply api endpoints.
+func bindApplyApi(app core.App, rg *echo.Group) {
+	api := applyApi{app: app}
+
+	subGroup := rg.Group("/apply", ActivityLogger(app), RequireAdminAuth())
+	subGroup.POST("", api.apply, requireApprovalFunc(app, api.applyApprovalActions))
+	subGroup.GET("/plan", api.plan)
+}
+
+type applyApi struct {
+	app core.App
+}
+
+//	@Summary		Применение декларативного состояния
+//	@Description	Приводит коллекции, администраторов и указанные настройки к желаемому состоянию из документа и возвращает план изменений. С параметром dryRun изменения не применяются
+//	@Tags			Settings
+//	@Accept			json
+//	@Produce		json
+//	@Param			dryRun	query	bool					false	"Только построить план без применения"
+//	@Param			body	body	forms.DeclarativeApply	true	"Документ желаемого состояния"
+//	@Security		AdminAuth
+//	@Success		200	{object}	ApplyResult
+//	@Failure		400	{string}	string	"Failed to apply the declarative state."
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Router			/apply [post]
+func (api *applyApi) apply(c echo.Context) error {
+	form := forms.NewDeclarativeApply(api.app)
+
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("Failed to load the submitted data due to invalid formatting.", err)
+	}
+
+	if cast.ToBool(c.QueryParam("dryRun")) {
+		if err := form.Validate(); err != nil {
+			return NewBadRequestError("Failed to plan the declarative state.", err)
+		}
+
+		plan, err := form.Plan()
+		if err != nil {
+			return NewBadRequestError("Failed to plan the declarative state.", err)
+		}
+
+		return c.JSON(http.StatusOK, &ApplyResult{Plan: plan})
+	}
+
+	return form.Submit(func(next forms.InterceptorNextFunc[*forms.DeclarativePlan]) forms.InterceptorNextFunc[*forms.DeclarativePlan] {
+		return func(plan *forms.DeclarativePlan) error {
+			if err := next(plan); err != nil {
+				return NewBadRequestError("Failed to apply the declarative state.", err)
+			}
+
+			return c.JSON(http.StatusOK, &ApplyResult{Plan: plan, Applied: true})
+		}
+	})
+}
+
+// applyApprovalActions returns the approval actions of the changes
+// planned by a declarative apply request body (the settings changes
+// and the collection deletions).
+func (api *applyApi) applyApprovalActions(c echo.Context, body []byte) []string {
+	if cast.ToBool(c.QueryParam("dryRun")) {
+		return nil
+	}
+
+	form := forms.NewDeclarativeApply(api.app)
+
+	// invalid bodies are reported by the apply handler
+	if err := json.Unmarshal(body, form); err != nil {
+		return nil
+	}
+
+	plan, err := form.Plan()
+	if err != nil {
+		return nil
+	}
+
+	actions := []string{}
+
+	if len(plan.Settings) > 0 {
+		actions = append(actions, settings.ApprovalActionSettingsUpdate)
+	}
+
+	for _, change := range plan.Collections {
+		if change.Action == forms.DeclarativeActionDelete {
+			actions = append(actions, settings.ApprovalActionCollectionDelete)
+			break
+		}
+	}
+
+	return actions
+}
+
+//	@Summary		Обнаружение дрейфа состояния
+//	@Description	Возвращает план изменений между последним примененным декларативным документом и текущим состоянием приложения (изменения, сделанные в обход документа)
+//	@Tags			Settings
+//	@Produce		json
+//	@Security		AdminAuth
+//	@Success		200	{object}	ApplyResult
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Failure		404	{string}	string	"No declarative state has been applied yet."
+//	@Router			/apply/plan [get]
+func (api *applyApi) plan(c echo.Context) error {
+	form, err := forms.NewDeclarativeApplyFromState(api.app)
+	if err != nil {
+		return NewNotFoundError("No declarative state has been applied yet.", err)
+	}
+
+	plan, err := form.Plan()
+	if err != nil {
+		return NewBadRequestError("Failed to plan the declarative state.", err)
+	}
+
+	return c.JSON(http.StatusOK, &ApplyResult{Plan: plan})
+}
@@ -0,0 +1,298 @@
+package apis
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tokens"
+	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// approvedRequestKey is the request context key of the replayed
+// approved requests (see [approvalApi.approve]).
+type approvedRequestKey struct{}
+
+// bindApprovalApi registers the approval requests api endpoints.
+func bindApprovalApi(app core.App, rg *echo.Group) {
+	api := approvalApi{app: app}
+
+	subGroup := rg.Group("/approvals", ActivityLogger(app), RequireAdminAuth())
+	subGroup.GET("", api.list)
+	subGroup.GET("/:id", api.view)
+	subGroup.POST("/:id/approve", api.approve)
+	subGroup.POST("/:id/reject", api.reject)
+}
+
+type approvalApi struct {
+	app core.App
+}
+
+//	@Summary		Список запросов на подтверждение
+//	@Description	Возвращает список запросов на подтверждение чувствительных действий администраторов (по умолчанию сначала новые)
+//	@Tags			Approvals
+//	@Produce		json
+//	@Param			page	query	int		false	"Номер страницы"
+//	@Param			perPage	query	int		false	"Количество элементов на странице"
+//	@Param			sort	query	string	false	"Сортировка"
+//	@Param			filter	query	string	false	"Фильтр"
+//	@Security		AdminAuth
+//	@Success		200	{object}	SearchResult{items=[]models.Approval}
+//	@Failure		400	{string}	string	"Something went wrong while processing your request."
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Router			/approvals [get]
+func (api *approvalApi) list(c echo.Context) error {
+	fieldResolver := search.NewSimpleFieldResolver(
+		"id", "created", "updated", "action", "method", "url", "status",
+		"requestedBy", "decidedBy", "expires", "decidedAt", "responseStatus",
+	)
+
+	approvals := []*models.Approval{}
+
+	searchProvider := search.NewProvider(fieldResolver).
+		Query(api.app.Dao().ApprovalQuery())
+
+	if c.QueryParam(search.SortQueryParam) == "" {
+		searchProvider.AddSort(search.SortField{Name: "created", Direction: search.SortDesc})
+	}
+
+	result, err := searchProvider.ParseAndExec(c.QueryParams().Encode(), &approvals)
+	if err != nil {
+		return NewBadRequestError("", err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+//	@Summary		Запрос на подтверждение
+//	@Description	Возвращает запрос на подтверждение по его ID
+//	@Tags			Approvals
+//	@Produce		json
+//	@Param			id	path	string	true	"ID запроса на подтверждение"
+//	@Security		AdminAuth
+//	@Success		200	{object}	models.Approval
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Failure		404	{string}	string	"The requested resource wasn't found."
+//	@Router			/approvals/{id} [get]
+func (api *approvalApi) view(c echo.Context) error {
+	approval, err := api.app.Dao().FindApprovalById(c.PathParam("id"))
+	if err != nil || approval == nil {
+		return NewNotFoundError("", err)
+	}
+
+	return c.JSON(http.StatusOK, approval)
+}
+
+//	@Summary		Подтверждение запроса
+//	@Description	Подтверждает и выполняет отложенное действие от имени запросившего администратора. Подтверждающий администратор должен отличаться от запросившего
+//	@Tags			Approvals
+//	@Produce		json
+//	@Param			id	path	string	true	"ID запроса на подтверждение"
+//	@Security		AdminAuth
+//	@Success		200	{object}	models.Approval
+//	@Failure		400	{string}	string	"The approval request is no longer pending."
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Failure		403	{string}	string	"The approval request must be confirmed by a different admin."
+//	@Failure		404	{string}	string	"The requested resource wasn't found."
+//	@Router			/approvals/{id}/approve [post]
+func (api *approvalApi) approve(c echo.Context) error {
+	approval, err := api.findPending(c)
+	if err != nil {
+		return err
+	}
+
+	admin, _ := c.Get(ContextAdminKey).(*models.Admin)
+
+	if approval.RequestedBy == admin.Id {
+		return NewForbiddenError("The approval request must be confirmed by a different admin.", nil)
+	}
+
+	requester, err := api.app.Dao().FindAdminById(approval.RequestedBy)
+	if err != nil {
+		return NewBadRequestError("The admin who requested the approval no longer exists.", err)
+	}
+
+	token, err := tokens.NewAdminAuthToken(api.app, requester)
+	if err != nil {
+		return NewBadRequestError("Failed to execute the approved request.", err)
+	}
+
+	// atomically mark the approval as decided before the execution
+	// to prevent running the same request more than once
+	if err := api.decide(approval, models.ApprovalStatusApproved, admin.Id); err != nil {
+		return err
+	}
+
+	// replay the original request on behalf of its requester
+	ctx := context.WithValue(c.Request().Context(), approvedRequestKey{}, approval.Id)
+	req, err := http.NewRequestWithContext(ctx, approval.Method, approval.Url, bytes.NewBufferString(approval.Body))
+	if err != nil {
+		return NewBadRequestError("Failed to execute the approved request.", err)
+	}
+	req.RemoteAddr = c.Request().RemoteAddr
+	req.Header.Set("Authorization", token)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c.Echo().ServeHTTP(rec, req)
+
+	if rec.Code >= 400 {
+		approval.Status = models.ApprovalStatusFailed
+	}
+	approval.ResponseStatus = rec.Code
+	approval.Response = rec.Body.String()
+	if err := api.app.Dao().SaveApproval(approval); err != nil {
+		return NewBadRequestError("Failed to save the approval request.", err)
+	}
+
+	return c.JSON(http.StatusOK, approval)
+}
+
+//	@Summary		Отклонение запроса
+//	@Description	Отклоняет отложенное действие (запросивший администратор также может отменить свой запрос)
+//	@Tags			Approvals
+//	@Produce		json
+//	@Param			id	path	string	true	"ID запроса на подтверждение"
+//	@Security		AdminAuth
+//	@Success		200	{object}	models.Approval
+//	@Failure		400	{string}	string	"The approval request is no longer pending."
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Failure		404	{string}	string	"The requested resource wasn't found."
+//	@Router			/approvals/{id}/reject [post]
+func (api *approvalApi) reject(c echo.Context) error {
+	approval, err := api.findPending(c)
+	if err != nil {
+		return err
+	}
+
+	admin, _ := c.Get(ContextAdminKey).(*models.Admin)
+
+	if err := api.decide(approval, models.ApprovalStatusRejected, admin.Id); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, approval)
+}
+
+// findPending loads the pending approval request from the "id" path parameter.
+//
+// Expired pending approvals are marked as such and returned as error.
+func (api *approvalApi) findPending(c echo.Context) (*models.Approval, error) {
+	approval, err := api.app.Dao().FindApprovalById(c.PathParam("id"))
+	if err != nil || approval == nil {
+		return nil, NewNotFoundError("", err)
+	}
+
+	if approval.Status != models.ApprovalStatusPending {
+		return nil, NewBadRequestError("The approval request is no longer pending.", nil)
+	}
+
+	if approval.IsExpired() {
+		approval.Status = models.ApprovalStatusExpired
+		if err := api.app.Dao().SaveApproval(approval); err != nil {
+			return nil, NewBadRequestError("Failed to save the approval request.", err)
+		}
+
+		return nil, NewBadRequestError("The approval request has expired.", nil)
+	}
+
+	return approval, nil
+}
+
+// decide changes the status of the pending approval request, failing
+// if it was already decided by a concurrent request.
+func (api *approvalApi) decide(approval *models.Approval, status string, decidedBy string) error {
+	err := api.app.Dao().DecideApproval(approval, status, decidedBy)
+
+	if errors.Is(err, daos.ErrApprovalNotPending) {
+		return NewBadRequestError("The approval request is no longer pending.", nil)
+	}
+
+	if err != nil {
+		return NewBadRequestError("Failed to save the approval request.", err)
+	}
+
+	return nil
+}
+
+// -------------------------------------------------------------------
+
+// requireApproval middleware holds the admin requests of the provided
+// action (if configured in the approvals settings) and instead creates
+// a pending approval request that a second admin must confirm.
+//
+// This middleware is expected to be registered after RequireAdminAuth.
+func requireApproval(app core.App, action string) echo.MiddlewareFunc {
+	return requireApprovalFunc(app, func(c echo.Context, body []byte) []string {
+		return []string{action}
+	})
+}
+
+// requireApprovalFunc is similar to [requireApproval] but resolves
+// the approval actions of the request dynamically from its raw body
+// (eg. a collections import only when it deletes collections).
+//
+// The request is held with the first resolved action that requires an approval.
+func requireApprovalFunc(app core.App, resolveActions func(c echo.Context, body []byte) []string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			config := app.Settings().Approvals
+
+			if !config.Enabled || c.Request().Context().Value(approvedRequestKey{}) != nil {
+				return next(c)
+			}
+
+			admin, _ := c.Get(ContextAdminKey).(*models.Admin)
+			if admin == nil {
+				return NewUnauthorizedError("The request requires admin authorization token to be set.", nil)
+			}
+
+			body, err := io.ReadAll(c.Request().Body)
+			if err != nil {
+				return NewBadRequestError("Failed to read the request body.", err)
+			}
+
+			// restore the body for the next handlers
+			c.Request().Body = io.NopCloser(bytes.NewReader(body))
+
+			var action string
+			for _, a := range resolveActions(c, body) {
+				if config.IsRequired(a) {
+					action = a
+					break
+				}
+			}
+
+			if action == "" {
+				return next(c)
+			}
+
+			expires, _ := types.ParseDateTime(time.Now().Add(time.Duration(config.Duration) * time.Second))
+
+			approval := &models.Approval{
+				Action:      action,
+				Method:      c.Request().Method,
+				Url:         c.Request().URL.RequestURI(),
+				Body:        string(body),
+				Status:      models.ApprovalStatusPending,
+				RequestedBy: admin.Id,
+				Expires:     expires,
+			}
+
+			if err := app.Dao().SaveApproval(approval); err != nil {
+				return NewBadRequestError("Failed to create the approval request.", err)
+			}
+
+			return c.JSON(http.StatusAccepted, approval)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package apis
+
+import (
+	"log"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tools/jsondiff"
+	"github.com/pocketbase/pocketbase/tools/types"
+	"github.com/spf13/cast"
+)
+
+// auditOldStateKey is the request context key with the state
+// of the audited resource before its update.
+const auditOldStateKey string = "auditOldState"
+
+// bindAuditHooks registers the request hooks that collect the admins,
+// collections and settings changes into the request context
+// (they are persisted by the [ActivityLogger] middleware).
+func bindAuditHooks(app core.App) {
+	// admins
+	// ---
+	app.OnAdminAfterCreateRequest().Add(func(e *core.AdminCreateEvent) error {
+		return addAuditLog(e.HttpContext, models.AuditActionCreate, models.AuditResourceAdmins, e.Admin.Id, nil, e.Admin)
+	})
+	app.OnAdminBeforeUpdateRequest().Add(func(e *core.AdminUpdateEvent) error {
+		// e.Admin is already populated with the submitted data
+		old, err := app.Dao().FindAdminById(e.Admin.Id)
+		if err == nil {
+			e.HttpContext.Set(auditOldStateKey, old)
+		}
+		return nil
+	})
+	app.OnAdminAfterUpdateRequest().Add(func(e *core.AdminUpdateEvent) error {
+		old, _ := e.HttpContext.Get(auditOldStateKey).(*models.Admin)
+		if old == nil {
+			return nil
+		}
+
+		return addAuditLog(e.HttpContext, models.AuditActionUpdate, models.AuditResourceAdmins, e.Admin.Id, old, e.Admin)
+	})
+	app.OnAdminAfterDeleteRequest().Add(func(e *core.AdminDeleteEvent) error {
+		return addAuditLog(e.HttpContext, models.AuditActionDelete, models.AuditResourceAdmins, e.Admin.Id, e.Admin, nil)
+	})
+
+	// collections
+	// ---
+	app.OnCollectionAfterCreateRequest().Add(func(e *core.CollectionCreateEvent) error {
+		return addAuditLog(e.HttpContext, models.AuditActionCreate, models.AuditResourceCollections, e.Collection.Id, nil, e.Collection)
+	})
+	app.OnCollectionBeforeUpdateRequest().Add(func(e *core.CollectionUpdateEvent) error {
+		// e.Collection is already populated with the submitted data
+		old, err := app.Dao().FindCollectionByNameOrId(e.Collection.Id)
+		if err == nil {
+			e.HttpContext.Set(auditOldStateKey, old)
+		}
+		return nil
+	})
+	app.OnCollectionAfterUpdateRequest().Add(func(e *core.CollectionUpdateEvent) error {
+		old, _ := e.HttpContext.Get(auditOldStateKey).(*models.Collection)
+		if old == nil {
+			return nil
+		}
+
+		return addAuditLog(e.HttpContext, models.AuditActionUpdate, models.AuditResourceCollections, e.Collection.Id, old, e.Collection)
+	})
+	app.OnCollectionAfterDeleteRequest().Add(func(e *core.CollectionDeleteEvent) error {
+		return addAuditLog(e.HttpContext, models.AuditActionDelete, models.AuditResourceCollections, e.Collection.Id, e.Collection, nil)
+	})
+
+	// settings
+	// ---
+	app.OnSettingsBeforeUpdateRequest().Add(func(e *core.SettingsUpdateEvent) error {
+		old, err := app.Settings().Clone()
+		if err == nil {
+			e.HttpContext.Set(auditOldStateKey, old)
+		}
+		return nil
+	})
+	app.OnSettingsAfterUpdateRequest().Add(func(e *core.SettingsUpdateEvent) error {
+		old, _ := e.HttpContext.Get(auditOldStateKey).(*settings.Settings)
+		if old == nil {
+			return nil
+		}
+
+		// the settings diff takes care to mask the secrets
+		diff, err := old.Diff(app.Settings())
+		if err != nil {
+			return err
+		}
+
+		if len(diff) == 0 {
+			return nil // nothing has changed
+		}
+
+		changes := types.JsonMap{}
+		for path, change := range diff {
+			changes[path] = change
+		}
+
+		appendAuditLog(e.HttpContext, &models.AuditLog{
+			Action:   models.AuditActionUpdate,
+			Resource: models.AuditResourceSettings,
+			Changes:  changes,
+		})
+
+		return nil
+	})
+}
+
+// addAuditLog registers in the request context a new audit log
+// with the changed fields between the old and new model state
+// (either of them could be nil on create or delete).
+func addAuditLog(c echo.Context, action string, resource string, resourceId string, old any, new any) error {
+	if c == nil {
+		return nil
+	}
+
+	diff, err := jsondiff.Diff(old, new)
+	if err != nil {
+		return err
+	}
+
+	changes := types.JsonMap{}
+	for path, change := range diff {
+		changes[path] = change
+	}
+
+	// the updated timestamp changes on every save
+	if action == models.AuditActionUpdate {
+		delete(changes, "updated")
+	}
+
+	// the admin password and token key are not serialized
+	oldAdmin, _ := old.(*models.Admin)
+	newAdmin, _ := new.(*models.Admin)
+	if oldAdmin != nil && newAdmin != nil && oldAdmin.PasswordHash != newAdmin.PasswordHash {
+		changes["password"] = jsondiff.Change{Old: settings.SecretMask, New: settings.SecretMask}
+	}
+
+	if action == models.AuditActionUpdate && len(changes) == 0 {
+		return nil // nothing has changed
+	}
+
+	appendAuditLog(c, &models.AuditLog{
+		Action:     action,
+		Resource:   resource,
+		ResourceId: resourceId,
+		Changes:    changes,
+	})
+
+	return nil
+}
+
+func appendAuditLog(c echo.Context, auditLog *models.AuditLog) {
+	auditLogs, _ := c.Get(ContextAuditLogsKey).([]*models.AuditLog)
+
+	c.Set(ContextAuditLogsKey, append(auditLogs, auditLog))
+}
+
+// saveAuditLogs persists the collected request audit logs
+// (if any) together with the request actor information.
+func saveAuditLogs(app core.App, c echo.Context) {
+	auditLogs, _ := c.Get(ContextAuditLogsKey).([]*models.AuditLog)
+	if len(auditLogs) == 0 {
+		return
+	}
+
+	maxDays := app.Settings().Logs.AuditMaxDays
+	if maxDays == 0 {
+		return // audit logs are disabled
+	}
+
+	actor := models.RequestAuthGuest
+	var actorId string
+	if record, _ := c.Get(ContextAuthRecordKey).(*models.Record); record != nil {
+		actor = models.RequestAuthRecord
+		actorId = record.Id
+	} else if admin, _ := c.Get(ContextAdminKey).(*models.Admin); admin != nil {
+		actor = models.RequestAuthAdmin
+		actorId = admin.Id
+	}
+
+	for _, auditLog := range auditLogs {
+		auditLog.Actor = actor
+		auditLog.ActorId = actorId
+		auditLog.UserIp = clientIp(c)
+		auditLog.Url = c.Request().URL.RequestURI()
+
+		if err := app.LogsDao().SaveAuditLog(auditLog); err != nil && app.IsDebug() {
+			log.Println("Audit log save failed:", err)
+		}
+	}
+
+	// delete old audit logs
+	// ---
+	now := time.Now()
+	lastDeletedAt := cast.ToTime(app.Cache().Get("lastAuditLogsDeletedAt"))
+
+	if now.Sub(lastDeletedAt) > 24*time.Hour {
+		err := app.LogsDao().DeleteOldAuditLogs(now.AddDate(0, 0, -1*maxDays))
+		if err == nil {
+			app.Cache().Set("lastAuditLogsDeletedAt", now)
+		} else if app.IsDebug() {
+			log.Println("Audit logs delete failed:", err)
+		}
+	}
+}
@@ -12,6 +12,9 @@ import (
 	"github.com/pocketbase/pocketbase/core"
 	"github.com/pocketbase/pocketbase/forms"
 	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tools/filesystem"
+	"github.com/pocketbase/pocketbase/tools/rest"
 	"github.com/pocketbase/pocketbase/tools/types"
 	"github.com/spf13/cast"
 )
@@ -33,6 +36,11 @@ type BackupCreateRequest struct {
 	Name string `form:"name" json:"name"`
 }
 
+// swagger:models BackupRotateKeyRequest
+type BackupRotateKeyRequest struct {
+	NewKey string `form:"newKey" json:"newKey"`
+}
+
 // bindBackupApi registers the file api endpoints and the corresponding handlers.
 //
 //	@todo	add hooks once the app hooks api restructuring is finalized
@@ -43,9 +51,12 @@ func bindBackupApi(app core.App, rg *echo.Group) {
 
 	subGroup.GET("", api.list, RequireAdminAuth())
 	subGroup.POST("", api.create, RequireAdminAuth())
+	subGroup.POST("/upload", api.upload, RequireAdminAuth())
+	subGroup.GET("/status", api.status, RequireAdminAuth())
+	subGroup.POST("/rotate-key", api.rotateKey, RequireAdminAuth(), requireApproval(app, settings.ApprovalActionSettingsUpdate))
 	subGroup.GET("/:key", api.download)
 	subGroup.DELETE("/:key", api.delete, RequireAdminAuth())
-	subGroup.POST("/:key/restore", api.restore, RequireAdminAuth())
+	subGroup.POST("/:key/restore", api.restore, RequireAdminAuth(), requireApproval(app, settings.ApprovalActionBackupRestore))
 }
 
 type backupApi struct {
@@ -124,6 +135,88 @@ func (api *backupApi) create(c echo.Context) error {
 	})
 }
 
+//	@Summary		Загрузка архива резервной копии
+//	@Description	Загружает созданный вне приложения zip архив резервной копии (в том числе зашифрованный) в хранилище резервных копий. Архив сохраняется под исходным именем файла
+//	@Tags			Backups
+//	@Accept			multipart/form-data
+//	@Param			file	formData	file	true	"Zip архив резервной копии"
+//	@Security		AdminAuth
+//	@Success		204	"No Content"
+//	@Failure		400	{string}	string	"Failed to upload backup."
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Router			/backups/upload [post]
+func (api *backupApi) upload(c echo.Context) error {
+	files, err := rest.FindUploadedFiles(c.Request(), "file")
+	if err != nil {
+		return NewBadRequestError("Missing or invalid uploaded file.", err)
+	}
+
+	form := forms.NewBackupUpload(api.app)
+	form.File = files[0]
+
+	return form.Submit(func(next forms.InterceptorNextFunc[*filesystem.File]) forms.InterceptorNextFunc[*filesystem.File] {
+		return func(file *filesystem.File) error {
+			if err := next(file); err != nil {
+				return NewBadRequestError("Failed to upload backup.", err)
+			}
+
+			return c.NoContent(http.StatusNoContent)
+		}
+	})
+}
+
+//	@Summary		Статус резервного копирования
+//	@Description	Возвращает прогресс последней запущенной операции создания или восстановления резервной копии (статус "idle", если операции еще не запускались). Изменения прогресса также отправляются администраторам, подписанным на realtime топик PB_BACKUPS
+//	@Tags			Backups
+//	@Produce		json
+//	@Security		AdminAuth
+//	@Success		200	{object}	core.BackupProgress
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Router			/backups/status [get]
+func (api *backupApi) status(c echo.Context) error {
+	progress, ok := api.app.Cache().Get(core.CacheKeyBackupProgress).(core.BackupProgress)
+	if !ok {
+		progress = core.BackupProgress{Status: core.BackupStatusIdle}
+	}
+
+	return c.JSON(http.StatusOK, progress)
+}
+
+//	@Summary		Смена ключа шифрования резервных копий
+//	@Description	Перешифровывает все зашифрованные резервные копии новым ключом и сохраняет его в настройках
+//	@Tags			Backups
+//	@Accept			json
+//	@Param			body	body	BackupRotateKeyRequest	true	"Новый ключ шифрования (32 символа)"
+//	@Security		AdminAuth
+//	@Success		204	"No Content"
+//	@Failure		400	{string}	string	"Failed to rotate the backups encryption key."
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Router			/backups/rotate-key [post]
+func (api *backupApi) rotateKey(c echo.Context) error {
+	if api.app.Cache().Has(core.CacheKeyActiveBackup) {
+		return NewBadRequestError("Try again later - another backup/restore process has already been started", nil)
+	}
+
+	form := forms.NewBackupRotateKey(api.app)
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	if admin, _ := c.Get(ContextAdminKey).(*models.Admin); admin != nil {
+		form.SetAdmin(admin)
+	}
+
+	return form.Submit(func(next forms.InterceptorNextFunc[string]) forms.InterceptorNextFunc[string] {
+		return func(newKey string) error {
+			if err := next(newKey); err != nil {
+				return NewBadRequestError("Failed to rotate the backups encryption key.", err)
+			}
+
+			return c.NoContent(http.StatusNoContent)
+		}
+	})
+}
+
 //	@Summary		Загрузка резервной копии
 //	@Description	Загружает резервную копию по указанному ключу
 //	@Tags			Backups
@@ -14,6 +14,7 @@ import (
 	"github.com/labstack/echo/v5"
 	"github.com/labstack/echo/v5/middleware"
 	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/ratelimit"
 	"github.com/pocketbase/pocketbase/tools/rest"
 	"github.com/pocketbase/pocketbase/ui"
 	"github.com/spf13/cast"
@@ -29,6 +30,7 @@ func InitApi(app core.App) (*echo.Echo, error) {
 	e.JSONSerializer = &rest.Serializer{
 		FieldsParam: "fields",
 	}
+	e.IPExtractor = clientIpExtractor(app)
 
 	// configure a custom router
 	e.ResetRouterCreator(func(ec *echo.Echo) echo.Router {
@@ -45,9 +47,14 @@ func InitApi(app core.App) (*echo.Echo, error) {
 		},
 	}))
 	e.Use(middleware.Recover())
-	e.Use(middleware.Secure())
+	e.Use(collectMetrics(app))
+	e.Use(securityHeaders(app))
 	e.Use(LoadAuthContext(app))
 
+	limiter := ratelimit.New()
+	rateLimitOverrides := newRateLimitOverrides(app)
+	e.Use(rateLimit(app, limiter, rateLimitOverrides))
+
 	// custom error handler
 	e.HTTPErrorHandler = func(c echo.Context, err error) {
 		if c.Response().Committed {
@@ -80,6 +87,7 @@ func InitApi(app core.App) (*echo.Echo, error) {
 
 		event := new(core.ApiErrorEvent)
 		event.HttpContext = c
+		event.RequestInfo = RequestInfo(c)
 		event.Error = apiErr
 
 		// send error response
@@ -103,19 +111,54 @@ func InitApi(app core.App) (*echo.Echo, error) {
 	// admin ui routes
 	bindStaticAdminUI(app, e)
 
+	// public token signing keys
+	bindJwksApi(app, e)
+
+	// users and groups provisioning
+	bindScimApi(app, e)
+
+	// collect the admin mutations audit logs
+	bindAuditHooks(app)
+
+	// meter the billable events
+	bindUsageHooks(app)
+
 	// default routes
 	api := e.Group("/api")
 	bindSettingsApi(app, api)
 	bindAdminApi(app, api)
 	bindCollectionApi(app, api)
 	bindRecordCrudApi(app, api)
+	bindRecordTransactionApi(app, api)
 	bindRecordAuthApi(app, api)
 	bindFileApi(app, api)
 	bindRealtimeApi(app, api)
 	bindLogsApi(app, api)
 	bindHealthApi(app, api)
+	bindSwaggerApi(app, api)
 	bindBackupApi(app, api)
+	bindSearchSyncApi(app, api)
+	bindAdminSqlApi(app, api)
+	bindAdminDbApi(app, api)
+	bindAnalyticsApi(app, api)
 	bindUsersApi(app, api)
+	bindRateLimitApi(app, api, limiter)
+	bindFeatureFlagApi(app, api)
+	bindExportApi(app, api)
+	bindApiKeyApi(app, api)
+	bindSignedRequestApi(app, api)
+	bindApprovalApi(app, api)
+	bindApplyApi(app, api)
+	bindStorageApi(app, api)
+	bindSecurityApi(app, api)
+	bindMetricsApi(app, api)
+	bindOrgApi(app, api)
+	bindBillingApi(app, api)
+	bindPublicFormApi(app, api)
+	bindGraphqlApi(app, api)
+	bindCommentApi(app, api)
+	bindWebhookApi(app, api)
+	bindScheduledExportApi(app, api)
 
 	// trigger the custom BeforeServe hook for the created api router
 	// allowing users to further adjust its options or register new routes
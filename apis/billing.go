@@ -0,0 +1,251 @@
+package apis
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/filesystem"
+	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/pocketbase/pocketbase/tools/stripe"
+)
+
+// bindBillingApi registers the billing usage and Stripe webhook api endpoints.
+func bindBillingApi(app core.App, rg *echo.Group) {
+	api := billingApi{app: app}
+
+	subGroup := rg.Group("/billing", ActivityLogger(app))
+	subGroup.POST("/stripe/webhook", api.stripeWebhook)
+	subGroup.GET("/usage", api.listUsage, RequireAdminAuth())
+	subGroup.GET("/usage/me", api.myUsage, RequireRecordAuth())
+}
+
+type billingApi struct {
+	app core.App
+}
+
+//	@Summary		List usage counters
+//	@Description	Returns a paginated list with the monthly billable events counters
+//	@Tags			Billing
+//	@Produce		json
+//	@Param			page	query	int		false	"Page number"
+//	@Param			perPage	query	int		false	"Items per page"
+//	@Param			sort	query	string	false	"Sort fields"
+//	@Param			filter	query	string	false	"Filter expression"
+//	@Security		AdminAuth
+//	@Success		200	{object}	search.Result{items=[]models.Usage}
+//	@Failure		400	{string}	string	"Something went wrong while processing your request."
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Router			/billing/usage [get]
+func (api *billingApi) listUsage(c echo.Context) error {
+	fieldResolver := search.NewSimpleFieldResolver(
+		"id", "created", "updated", "period", "metric", "orgId", "collectionId", "recordId", "value",
+	)
+
+	counters := []*models.Usage{}
+
+	result, err := search.NewProvider(fieldResolver).
+		Query(api.app.Dao().UsageQuery()).
+		ParseAndExec(c.QueryParams().Encode(), &counters)
+
+	if err != nil {
+		return NewBadRequestError("", err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+//	@Summary		View own usage
+//	@Description	Returns the billable events totals of the authorized record for the specified month (default to the current one)
+//	@Tags			Billing
+//	@Produce		json
+//	@Param			period	query	string	false	"Usage month in YYYY-MM format"
+//	@Security		RecordAuth
+//	@Success		200	{object}	models.UsageSummary
+//	@Failure		400	{string}	string	"Invalid usage period."
+//	@Failure		401	{string}	string	"The request requires valid record authorization token to be set."
+//	@Router			/billing/usage/me [get]
+func (api *billingApi) myUsage(c echo.Context) error {
+	authRecord, _ := c.Get(ContextAuthRecordKey).(*models.Record)
+	if authRecord == nil {
+		return NewUnauthorizedError("", nil)
+	}
+
+	period, err := usagePeriodParam(c)
+	if err != nil {
+		return err
+	}
+
+	summary, err := api.app.Dao().FindUsageSummary(period, dbx.HashExp{
+		"collectionId": authRecord.Collection().Id,
+		"recordId":     authRecord.Id,
+	})
+	if err != nil {
+		return NewBadRequestError("", err)
+	}
+
+	return c.JSON(http.StatusOK, summary)
+}
+
+//	@Summary		Stripe webhook
+//	@Description	Receives the signed Stripe webhook events and updates the subscription state of the billing Stripe collection auth records
+//	@Description	(handles "checkout.session.completed" and "customer.subscription.*" events; the rest are skipped)
+//	@Tags			Billing
+//	@Accept			json
+//	@Produce		json
+//	@Param			Stripe-Signature	header	string	true	"Stripe event signature"
+//	@Success		200	{object}	forms.StripeWebhookResult
+//	@Failure		400	{string}	string	"Invalid Stripe webhook signature."
+//	@Failure		404	{string}	string	"The requested resource wasn't found."
+//	@Router			/billing/stripe/webhook [post]
+func (api *billingApi) stripeWebhook(c echo.Context) error {
+	secret := api.app.Secrets().Resolve(api.app.Settings().Billing.StripeWebhookSecret)
+	if secret == "" {
+		return NewNotFoundError("", nil)
+	}
+
+	payload, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return NewBadRequestError("Failed to read the request body.", err)
+	}
+
+	header := c.Request().Header.Get(stripe.SignatureHeader)
+
+	if err := stripe.VerifySignature(payload, header, secret, stripe.DefaultTolerance, time.Now()); err != nil {
+		return NewBadRequestError("Invalid Stripe webhook signature.", err)
+	}
+
+	form := forms.NewStripeWebhook(api.app)
+
+	if err := json.Unmarshal(payload, &form.Event); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	return form.Submit(func(next forms.InterceptorNextFunc[*forms.StripeWebhookResult]) forms.InterceptorNextFunc[*forms.StripeWebhookResult] {
+		return func(result *forms.StripeWebhookResult) error {
+			if err := next(result); err != nil {
+				return NewBadRequestError("Failed to process the Stripe event.", err)
+			}
+
+			return c.JSON(http.StatusOK, result)
+		}
+	})
+}
+
+// usagePeriodParam returns the "period" query parameter
+// (default to the current month period).
+func usagePeriodParam(c echo.Context) (string, error) {
+	period := c.QueryParam("period")
+	if period == "" {
+		return models.UsagePeriod(time.Now()), nil
+	}
+
+	if _, err := time.Parse(models.UsagePeriodFormat, period); err != nil {
+		return "", NewBadRequestError("Invalid usage period.", err)
+	}
+
+	return period, nil
+}
+
+// -------------------------------------------------------------------
+
+// bindUsageHooks registers the request hooks that meter the billable
+// events of the auth records and their active organization
+// (if enabled in the app Settings().Billing.Metering).
+func bindUsageHooks(app core.App) {
+	app.OnRecordAfterCreateRequest().Add(func(e *core.RecordCreateEvent) error {
+		authRecord, _ := e.HttpContext.Get(ContextAuthRecordKey).(*models.Record)
+
+		trackUsage(app, e.HttpContext, authRecord, models.UsageMetricRecordsCreated, 1)
+		trackUsage(app, e.HttpContext, authRecord, models.UsageMetricStorageBytes, uploadedFilesSize(e.UploadedFiles))
+
+		return nil
+	})
+
+	app.OnRecordAfterUpdateRequest().Add(func(e *core.RecordUpdateEvent) error {
+		authRecord, _ := e.HttpContext.Get(ContextAuthRecordKey).(*models.Record)
+
+		trackUsage(app, e.HttpContext, authRecord, models.UsageMetricStorageBytes, uploadedFilesSize(e.UploadedFiles))
+
+		return nil
+	})
+
+	app.OnRecordAuthRequest().Add(func(e *core.RecordAuthEvent) error {
+		trackUsage(app, e.HttpContext, e.Record, models.UsageMetricActiveUsers, 1)
+
+		return nil
+	})
+}
+
+// trackUsage registers the billable event value in the usage
+// counter of the provided auth record (nil for guests and admins).
+//
+// The active users are counted only once per period.
+//
+// Metering failures don't interrupt the request and are only logged in debug.
+func trackUsage(app core.App, c echo.Context, authRecord *models.Record, metric string, value int64) {
+	if !app.Settings().Billing.Metering || value <= 0 {
+		return
+	}
+
+	usage := &models.Usage{
+		Period: models.UsagePeriod(time.Now()),
+		Metric: metric,
+		Value:  value,
+	}
+
+	if authRecord != nil {
+		usage.CollectionId = authRecord.Collection().Id
+		usage.RecordId = authRecord.Id
+		usage.OrgId = requestOrgId(app, c, authRecord)
+	}
+
+	var err error
+	if metric == models.UsageMetricActiveUsers {
+		err = app.Dao().EnsureUsage(usage)
+	} else {
+		err = app.Dao().IncrementUsage(usage)
+	}
+
+	if err != nil && app.IsDebug() {
+		log.Println("failed to track the "+metric+" usage:", err)
+	}
+}
+
+// requestOrgId returns the id of the active organization of the auth
+// record (the [models.OrgRequestHeader] one or its oldest membership).
+func requestOrgId(app core.App, c echo.Context, authRecord *models.Record) string {
+	var member *models.OrgMember
+
+	if orgId := c.Request().Header.Get(models.OrgRequestHeader); orgId != "" {
+		member, _ = app.Dao().FindOrgMemberByRecord(orgId, authRecord)
+	} else {
+		member, _ = app.Dao().FindFirstOrgMemberByRecord(authRecord)
+	}
+
+	if member == nil {
+		return ""
+	}
+
+	return member.OrgId
+}
+
+// uploadedFilesSize returns the total size of the uploaded files.
+func uploadedFilesSize(uploaded map[string][]*filesystem.File) int64 {
+	var total int64
+
+	for _, files := range uploaded {
+		for _, f := range files {
+			total += f.Size
+		}
+	}
+
+	return total
+}
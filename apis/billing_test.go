@@ -0,0 +1,449 @@
+package apis_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/stripe"
+)
+
+const testStripeWebhookSecret = "whsec_test"
+
+// createTestUsage creates usage counters for the current and previous
+// periods of the 4q1xlclmfloku33 and oap640cot4yru2s users.
+func createTestUsage(t *testing.T, app *tests.TestApp) {
+	period := models.UsagePeriod(time.Now())
+
+	counters := []*models.Usage{
+		{Period: period, Metric: models.UsageMetricRecordsCreated, OrgId: "org_acme0000001", CollectionId: "_pb_users_auth_", RecordId: "4q1xlclmfloku33", Value: 3},
+		{Period: period, Metric: models.UsageMetricStorageBytes, OrgId: "org_acme0000001", CollectionId: "_pb_users_auth_", RecordId: "4q1xlclmfloku33", Value: 100},
+		{Period: period, Metric: models.UsageMetricActiveUsers, OrgId: "org_acme0000001", CollectionId: "_pb_users_auth_", RecordId: "4q1xlclmfloku33", Value: 1},
+		{Period: period, Metric: models.UsageMetricRecordsCreated, OrgId: "org_acme0000001", CollectionId: "_pb_users_auth_", RecordId: "oap640cot4yru2s", Value: 2},
+		{Period: "2023-01", Metric: models.UsageMetricRecordsCreated, OrgId: "org_acme0000001", CollectionId: "_pb_users_auth_", RecordId: "4q1xlclmfloku33", Value: 7},
+	}
+
+	for _, usage := range counters {
+		if err := app.Dao().IncrementUsage(usage); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestBillingUsageList(t *testing.T) {
+	scenarios := []tests.ApiScenario{
+		{
+			Name:            "unauthorized",
+			Method:          http.MethodGet,
+			Url:             "/api/billing/usage",
+			ExpectedStatus:  401,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "authorized as auth record",
+			Method: http.MethodGet,
+			Url:    "/api/billing/usage",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgOwnerToken,
+			},
+			ExpectedStatus:  401,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "authorized as admin + filter",
+			Method: http.MethodGet,
+			Url:    "/api/billing/usage?filter=" + "recordId%3D%274q1xlclmfloku33%27&sort=value",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgAdminToken,
+			},
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				createTestUsage(t, app)
+			},
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"totalItems":4`,
+				`"metric":"activeUsers"`,
+				`"value":100`,
+				`"period":"2023-01"`,
+			},
+			NotExpectedContent: []string{
+				`"recordId":"oap640cot4yru2s"`,
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
+
+func TestBillingUsageMe(t *testing.T) {
+	scenarios := []tests.ApiScenario{
+		{
+			Name:            "unauthorized",
+			Method:          http.MethodGet,
+			Url:             "/api/billing/usage/me",
+			ExpectedStatus:  401,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "authorized as admin",
+			Method: http.MethodGet,
+			Url:    "/api/billing/usage/me",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgAdminToken,
+			},
+			ExpectedStatus:  401,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "invalid period",
+			Method: http.MethodGet,
+			Url:    "/api/billing/usage/me?period=2023-13",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgOwnerToken,
+			},
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "current period",
+			Method: http.MethodGet,
+			Url:    "/api/billing/usage/me",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgOwnerToken,
+			},
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				createTestUsage(t, app)
+			},
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"period":"` + models.UsagePeriod(time.Now()) + `"`,
+				`"recordsCreated":3`,
+				`"storageBytes":100`,
+				`"activeUsers":1`,
+			},
+		},
+		{
+			Name:   "previous period",
+			Method: http.MethodGet,
+			Url:    "/api/billing/usage/me?period=2023-01",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgOwnerToken,
+			},
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				createTestUsage(t, app)
+			},
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"period":"2023-01"`,
+				`"recordsCreated":7`,
+				`"storageBytes":0`,
+				`"activeUsers":0`,
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
+
+func TestBillingUsageHooks(t *testing.T) {
+	formData, mp, err := tests.MockMultipartData(map[string]string{
+		"title": "title_test",
+	}, "files")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// checkUsage checks the current period totals of the counters with the specified record id
+	checkUsage := func(t *testing.T, app *tests.TestApp, orgId string, recordId string, expected map[string]int64) {
+		counters := []*models.Usage{}
+
+		err := app.Dao().UsageQuery().
+			AndWhere(dbx.HashExp{"period": models.UsagePeriod(time.Now()), "recordId": recordId}).
+			All(&counters)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(counters) != len(expected) {
+			t.Fatalf("Expected %d counters, got %d", len(expected), len(counters))
+		}
+
+		for _, c := range counters {
+			if c.OrgId != orgId {
+				t.Errorf("Expected %s counter orgId %q, got %q", c.Metric, orgId, c.OrgId)
+			}
+			if c.Value != expected[c.Metric] {
+				t.Errorf("Expected %s counter value %d, got %d", c.Metric, expected[c.Metric], c.Value)
+			}
+		}
+	}
+
+	enableMetering := func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+		createTestOrgs(t, app)
+		app.Settings().Billing.Metering = true
+	}
+
+	scenarios := []tests.ApiScenario{
+		{
+			Name:   "disabled metering",
+			Method: http.MethodPost,
+			Url:    "/api/collections/users/auth-with-password",
+			Body:   strings.NewReader(`{"identity":"test2_username","password":"1234567890"}`),
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				createTestOrgs(t, app)
+			},
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				checkUsage(t, app, "", "oap640cot4yru2s", map[string]int64{})
+			},
+			ExpectedStatus:  200,
+			ExpectedContent: []string{`"token":"`},
+			ExpectedEvents: map[string]int{
+				"OnRecordBeforeAuthWithPasswordRequest": 1,
+				"OnRecordAfterAuthWithPasswordRequest":  1,
+				"OnRecordAuthRequest":                   1,
+			},
+		},
+		{
+			Name:           "active user",
+			Method:         http.MethodPost,
+			Url:            "/api/collections/users/auth-with-password",
+			Body:           strings.NewReader(`{"identity":"test2_username","password":"1234567890"}`),
+			BeforeTestFunc: enableMetering,
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				checkUsage(t, app, "org_acme0000001", "oap640cot4yru2s", map[string]int64{
+					models.UsageMetricActiveUsers: 1,
+				})
+			},
+			ExpectedStatus:  200,
+			ExpectedContent: []string{`"token":"`},
+			ExpectedEvents: map[string]int{
+				"OnRecordBeforeAuthWithPasswordRequest": 1,
+				"OnRecordAfterAuthWithPasswordRequest":  1,
+				"OnRecordAuthRequest":                   1,
+			},
+		},
+		{
+			Name:   "record created by auth record with unknown org header",
+			Method: http.MethodPost,
+			Url:    "/api/collections/demo4/records",
+			Body: strings.NewReader(`{
+				"title":"test123",
+				"rel_one_no_cascade_required":"7nwo8tuiatetxdm",
+				"rel_many_no_cascade_required":["7nwo8tuiatetxdm","lcl9d87w22ml6jy"]
+			}`),
+			RequestHeaders: map[string]string{
+				"Authorization":         testOrgOwnerToken,
+				models.OrgRequestHeader: "org_other000001",
+			},
+			BeforeTestFunc: enableMetering,
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				checkUsage(t, app, "", "4q1xlclmfloku33", map[string]int64{
+					models.UsageMetricRecordsCreated: 1,
+				})
+			},
+			ExpectedStatus:  200,
+			ExpectedContent: []string{`"title":"test123"`},
+			ExpectedEvents: map[string]int{
+				"OnRecordBeforeCreateRequest": 1,
+				"OnRecordAfterCreateRequest":  1,
+				"OnModelBeforeCreate":         1,
+				"OnModelAfterCreate":          1,
+			},
+		},
+		{
+			Name:   "record created by auth record",
+			Method: http.MethodPost,
+			Url:    "/api/collections/demo4/records",
+			Body: strings.NewReader(`{
+				"title":"test123",
+				"rel_one_no_cascade_required":"7nwo8tuiatetxdm",
+				"rel_many_no_cascade_required":["7nwo8tuiatetxdm","lcl9d87w22ml6jy"]
+			}`),
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgOwnerToken,
+			},
+			BeforeTestFunc: enableMetering,
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				checkUsage(t, app, "org_acme0000001", "4q1xlclmfloku33", map[string]int64{
+					models.UsageMetricRecordsCreated: 1,
+				})
+			},
+			ExpectedStatus:  200,
+			ExpectedContent: []string{`"title":"test123"`},
+			ExpectedEvents: map[string]int{
+				"OnRecordBeforeCreateRequest": 1,
+				"OnRecordAfterCreateRequest":  1,
+				"OnModelBeforeCreate":         1,
+				"OnModelAfterCreate":          1,
+			},
+		},
+		{
+			Name:   "record with files created by admin",
+			Method: http.MethodPost,
+			Url:    "/api/collections/demo3/records",
+			Body:   formData,
+			RequestHeaders: map[string]string{
+				"Content-Type":  mp.FormDataContentType(),
+				"Authorization": testOrgAdminToken,
+			},
+			BeforeTestFunc: enableMetering,
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				checkUsage(t, app, "", "", map[string]int64{
+					models.UsageMetricRecordsCreated: 1,
+					models.UsageMetricStorageBytes:   4,
+				})
+			},
+			ExpectedStatus:  200,
+			ExpectedContent: []string{`"files":["`},
+			ExpectedEvents: map[string]int{
+				"OnRecordBeforeCreateRequest": 1,
+				"OnRecordAfterCreateRequest":  1,
+				"OnModelBeforeCreate":         1,
+				"OnModelAfterCreate":          1,
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
+
+func TestBillingStripeWebhook(t *testing.T) {
+	// setupStripe adds the Stripe subscription fields to the users
+	// collection and enables the Stripe webhook receiver
+	setupStripe := func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+		collection, err := app.Dao().FindCollectionByNameOrId("users")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		collection.Schema.AddField(&schema.SchemaField{Name: "stripeCustomerId", Type: schema.FieldTypeText})
+		collection.Schema.AddField(&schema.SchemaField{Name: "subscriptionStatus", Type: schema.FieldTypeText})
+
+		if err := app.Dao().SaveCollection(collection); err != nil {
+			t.Fatal(err)
+		}
+
+		app.Settings().Billing.StripeWebhookSecret = testStripeWebhookSecret
+		app.Settings().Billing.StripeCollection = "users"
+
+		app.ResetEventCalls()
+	}
+
+	checkoutPayload := `{"id":"evt_test1","type":"checkout.session.completed","data":{"object":{"id":"cs_test","customer":"cus_test","client_reference_id":"4q1xlclmfloku33"}}}`
+	unsupportedPayload := `{"id":"evt_test2","type":"invoice.paid","data":{"object":{"id":"in_test"}}}`
+
+	scenarios := []tests.ApiScenario{
+		{
+			Name:   "disabled webhook receiver",
+			Method: http.MethodPost,
+			Url:    "/api/billing/stripe/webhook",
+			Body:   strings.NewReader(checkoutPayload),
+			RequestHeaders: map[string]string{
+				stripe.SignatureHeader: stripe.SignatureHeaderValue([]byte(checkoutPayload), time.Now(), testStripeWebhookSecret),
+			},
+			ExpectedStatus:  404,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:           "missing signature",
+			Method:         http.MethodPost,
+			Url:            "/api/billing/stripe/webhook",
+			Body:           strings.NewReader(checkoutPayload),
+			BeforeTestFunc: setupStripe,
+			ExpectedStatus: 400,
+			ExpectedContent: []string{
+				`"message":"Invalid Stripe webhook signature."`,
+			},
+		},
+		{
+			Name:   "invalid signature secret",
+			Method: http.MethodPost,
+			Url:    "/api/billing/stripe/webhook",
+			Body:   strings.NewReader(checkoutPayload),
+			RequestHeaders: map[string]string{
+				stripe.SignatureHeader: stripe.SignatureHeaderValue([]byte(checkoutPayload), time.Now(), "whsec_other"),
+			},
+			BeforeTestFunc: setupStripe,
+			ExpectedStatus: 400,
+			ExpectedContent: []string{
+				`"message":"Invalid Stripe webhook signature."`,
+			},
+		},
+		{
+			Name:   "expired signature",
+			Method: http.MethodPost,
+			Url:    "/api/billing/stripe/webhook",
+			Body:   strings.NewReader(checkoutPayload),
+			RequestHeaders: map[string]string{
+				stripe.SignatureHeader: stripe.SignatureHeaderValue([]byte(checkoutPayload), time.Now().Add(-time.Hour), testStripeWebhookSecret),
+			},
+			BeforeTestFunc: setupStripe,
+			ExpectedStatus: 400,
+			ExpectedContent: []string{
+				`"message":"Invalid Stripe webhook signature."`,
+			},
+		},
+		{
+			Name:   "unsupported event",
+			Method: http.MethodPost,
+			Url:    "/api/billing/stripe/webhook",
+			Body:   strings.NewReader(unsupportedPayload),
+			RequestHeaders: map[string]string{
+				stripe.SignatureHeader: stripe.SignatureHeaderValue([]byte(unsupportedPayload), time.Now(), testStripeWebhookSecret),
+			},
+			BeforeTestFunc: setupStripe,
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"eventId":"evt_test2"`,
+				`"action":"skipped"`,
+				`"reason":"unsupported event type"`,
+			},
+		},
+		{
+			Name:   "checkout session completed",
+			Method: http.MethodPost,
+			Url:    "/api/billing/stripe/webhook",
+			Body:   strings.NewReader(checkoutPayload),
+			RequestHeaders: map[string]string{
+				stripe.SignatureHeader: stripe.SignatureHeaderValue([]byte(checkoutPayload), time.Now(), testStripeWebhookSecret),
+			},
+			BeforeTestFunc: setupStripe,
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				record, err := app.Dao().FindRecordById("users", "4q1xlclmfloku33")
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if v := record.GetString("stripeCustomerId"); v != "cus_test" {
+					t.Fatalf("Expected stripeCustomerId %q, got %q", "cus_test", v)
+				}
+			},
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"eventId":"evt_test1"`,
+				`"action":"updated"`,
+				`"recordId":"4q1xlclmfloku33"`,
+			},
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeUpdate": 1,
+				"OnModelAfterUpdate":  1,
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
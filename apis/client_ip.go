@@ -0,0 +1,117 @@
+package apis
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models/settings"
+)
+
+// clientIpExtractor returns an [echo.IPExtractor] that resolves
+// the client ip based on the current app trusted proxy settings.
+func clientIpExtractor(app core.App) echo.IPExtractor {
+	return func(r *http.Request) string {
+		return resolveClientIp(r, app.Settings().TrustedProxy)
+	}
+}
+
+// clientIp returns the client ip of the current request resolved with the
+// echo IPExtractor (if registered, see [clientIpExtractor]) or otherwise
+// from the request remote address.
+func clientIp(c echo.Context) string {
+	if e := c.Echo(); e != nil && e.IPExtractor != nil {
+		return c.RealIP()
+	}
+
+	remoteIp, _, _ := net.SplitHostPort(c.Request().RemoteAddr)
+
+	return remoteIp
+}
+
+// isTrustedProxyRequest reports whether the provided request
+// was sent by one of the configured trusted proxies.
+//
+// It always returns false if there are no configured proxy headers.
+func isTrustedProxyRequest(r *http.Request, config settings.TrustedProxyConfig) bool {
+	isTrusted := trustedProxyMatcher(config)
+	if isTrusted == nil {
+		return false
+	}
+
+	remoteIp, _, _ := net.SplitHostPort(r.RemoteAddr)
+
+	return isTrusted(remoteIp)
+}
+
+// trustedProxyMatcher returns a function that checks whether an ip
+// is in the configured trusted proxy ranges (or loopback/private
+// if no ranges are set).
+//
+// It returns nil if there are no configured proxy headers or
+// the configured ranges are invalid.
+func trustedProxyMatcher(config settings.TrustedProxyConfig) func(rawIp string) bool {
+	if !config.Enabled() {
+		return nil
+	}
+
+	ranges, err := config.ParseRanges()
+	if err != nil {
+		return nil
+	}
+
+	return func(rawIp string) bool {
+		ip := net.ParseIP(rawIp)
+		if ip == nil {
+			return false
+		}
+
+		if len(ranges) == 0 {
+			return ip.IsLoopback() || ip.IsPrivate()
+		}
+
+		for _, r := range ranges {
+			if r.Contains(ip) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// resolveClientIp resolves the client ip of the provided request
+// from the configured trusted proxy headers.
+//
+// The headers are checked only if the request is from a trusted proxy and
+// the multiple ips lists (eg. X-Forwarded-For) are checked from right to left
+// skipping the trusted proxies since the leftmost values could be spoofed.
+//
+// If there are no configured headers, the request remote address is returned.
+func resolveClientIp(r *http.Request, config settings.TrustedProxyConfig) string {
+	remoteIp, _, _ := net.SplitHostPort(r.RemoteAddr)
+
+	isTrusted := trustedProxyMatcher(config)
+	if isTrusted == nil || !isTrusted(remoteIp) {
+		return remoteIp
+	}
+
+	for _, header := range config.Headers {
+		ips := strings.Split(r.Header.Get(header), ",")
+
+		for i := len(ips) - 1; i >= 0; i-- {
+			ip := strings.TrimSpace(ips[i])
+			if net.ParseIP(ip) == nil {
+				break // invalid or missing header value
+			}
+
+			if i == 0 || !isTrusted(ip) {
+				return ip
+			}
+		}
+	}
+
+	return remoteIp
+}
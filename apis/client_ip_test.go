@@ -0,0 +1,121 @@
+package apis_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/apis"
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestClientIpResolution(t *testing.T) {
+	scenarios := []struct {
+		name       string
+		config     settings.TrustedProxyConfig
+		remoteAddr string
+		headers    map[string]string
+		expected   string
+	}{
+		{
+			"no configured headers",
+			settings.TrustedProxyConfig{},
+			"8.8.8.8:1234",
+			map[string]string{"X-Forwarded-For": "1.1.1.1, 2.2.2.2", "X-Real-IP": "3.3.3.3"},
+			"8.8.8.8",
+		},
+		{
+			"untrusted remote address",
+			settings.TrustedProxyConfig{Headers: []string{"X-Forwarded-For"}},
+			"8.8.8.8:1234",
+			map[string]string{"X-Forwarded-For": "1.1.1.1"},
+			"8.8.8.8",
+		},
+		{
+			"private remote address (default trusted ranges)",
+			settings.TrustedProxyConfig{Headers: []string{"X-Forwarded-For"}},
+			"10.0.0.5:1234",
+			map[string]string{"X-Forwarded-For": "1.1.1.1"},
+			"1.1.1.1",
+		},
+		{
+			"spoofed leftmost value",
+			settings.TrustedProxyConfig{Headers: []string{"X-Forwarded-For"}},
+			"10.0.0.5:1234",
+			map[string]string{"X-Forwarded-For": "6.6.6.6, 1.1.1.1, 10.0.0.4"},
+			"1.1.1.1",
+		},
+		{
+			"all trusted hops",
+			settings.TrustedProxyConfig{Headers: []string{"X-Forwarded-For"}},
+			"10.0.0.5:1234",
+			map[string]string{"X-Forwarded-For": "192.168.1.2, 10.0.0.4"},
+			"192.168.1.2",
+		},
+		{
+			"custom trusted ranges",
+			settings.TrustedProxyConfig{
+				Headers: []string{"CF-Connecting-IP"},
+				Ranges:  []string{"173.245.48.0/20", "9.9.9.9"},
+			},
+			"9.9.9.9:1234",
+			map[string]string{"CF-Connecting-IP": "1.1.1.1"},
+			"1.1.1.1",
+		},
+		{
+			"remote address outside of the custom trusted ranges",
+			settings.TrustedProxyConfig{
+				Headers: []string{"CF-Connecting-IP"},
+				Ranges:  []string{"173.245.48.0/20"},
+			},
+			"10.0.0.5:1234",
+			map[string]string{"CF-Connecting-IP": "1.1.1.1"},
+			"10.0.0.5",
+		},
+		{
+			"headers order",
+			settings.TrustedProxyConfig{Headers: []string{"CF-Connecting-IP", "X-Forwarded-For"}},
+			"127.0.0.1:1234",
+			map[string]string{"X-Forwarded-For": "2.2.2.2"},
+			"2.2.2.2",
+		},
+		{
+			"invalid header value",
+			settings.TrustedProxyConfig{Headers: []string{"X-Forwarded-For"}},
+			"127.0.0.1:1234",
+			map[string]string{"X-Forwarded-For": "invalid"},
+			"127.0.0.1",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			app.Settings().TrustedProxy = s.config
+
+			e, err := apis.InitApi(app)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = s.remoteAddr
+			for k, v := range s.headers {
+				req.Header.Set(k, v)
+			}
+
+			c := e.NewContext(req, httptest.NewRecorder())
+
+			if ip := apis.RequestInfo(c).Ip; ip != s.expected {
+				t.Fatalf("Expected ip %q, got %q", s.expected, ip)
+			}
+
+			if ip := c.RealIP(); ip != s.expected {
+				t.Fatalf("Expected RealIP %q, got %q", s.expected, ip)
+			}
+		})
+	}
+}
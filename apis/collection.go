@@ -1,16 +1,20 @@
 package apis
 
 import (
+	"encoding/json"
 	"log"
 	"net/http"
 	"time"
 
 	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/dbx"
 	"github.com/pocketbase/pocketbase/core"
 	"github.com/pocketbase/pocketbase/daos"
 	"github.com/pocketbase/pocketbase/forms"
 	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/settings"
 	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/spf13/cast"
 )
 
 // swagger:models CollectionCreateRequest
@@ -116,8 +120,12 @@ func bindCollectionApi(app core.App, rg *echo.Group) {
 	subGroup.POST("", api.create)
 	subGroup.GET("/:collection", api.view)
 	subGroup.PATCH("/:collection", api.update)
-	subGroup.DELETE("/:collection", api.delete)
-	subGroup.PUT("/import", api.bulkImport)
+	subGroup.DELETE("/:collection", api.delete, requireApproval(app, settings.ApprovalActionCollectionDelete))
+	subGroup.POST("/:collection/refresh", api.refresh)
+	subGroup.GET("/:collection/validate-data", api.validateData)
+	subGroup.PUT("/import", api.bulkImport, requireApprovalFunc(app, api.importApprovalActions))
+	subGroup.GET("/diff", api.diff)
+	subGroup.GET("/export", api.export)
 }
 
 type collectionApi struct {
@@ -154,6 +162,7 @@ func (api *collectionApi) list(c echo.Context) error {
 
 	event := new(core.CollectionsListEvent)
 	event.HttpContext = c
+	event.RequestInfo = RequestInfo(c)
 	event.Collections = collections
 	event.Result = result
 
@@ -170,6 +179,7 @@ func (api *collectionApi) list(c echo.Context) error {
 //	@Param			collection	path	string	true	"Имя или ID коллекции"
 //	@Security		AdminAuth
 //	@Success		200	{object}	Collection	"OK"
+//	@Header			200	{string}	ETag		"Версия коллекции (для заголовка If-Match)"
 //	@Failure		400	{string}	string		"Failed to authenticate."
 //	@Failure		404	{string}	string		"Not found."
 //	@Router			/collections/{collection} [get]
@@ -181,9 +191,12 @@ func (api *collectionApi) view(c echo.Context) error {
 
 	event := new(core.CollectionViewEvent)
 	event.HttpContext = c
+	event.RequestInfo = RequestInfo(c)
 	event.Collection = collection
 
 	return api.app.OnCollectionViewRequest().Trigger(event, func(e *core.CollectionViewEvent) error {
+		setETag(e.HttpContext, e.Collection)
+
 		return e.HttpContext.JSON(http.StatusOK, e.Collection)
 	})
 }
@@ -210,6 +223,7 @@ func (api *collectionApi) create(c echo.Context) error {
 
 	event := new(core.CollectionCreateEvent)
 	event.HttpContext = c
+	event.RequestInfo = RequestInfo(c)
 	event.Collection = collection
 
 	// create the collection
@@ -243,10 +257,13 @@ func (api *collectionApi) create(c echo.Context) error {
 //	@Produce		json
 //	@Param			collection	path	string					true	"Имя или ID коллекции"
 //	@Param			body		body	CollectionCreateRequest	true	"Данные для обновления коллекции"
+//	@Param			If-Match	header	string					false	"ETag просмотренной версии (обновление отклоняется, если коллекция была изменена)"
 //	@Security		AdminAuth
 //	@Success		200	{object}	Collection	"OK"
+//	@Header			200	{string}	ETag		"Новая версия коллекции"
 //	@Failure		400	{string}	string		"Failed to authenticate."
 //	@Failure		404	{string}	string		"Not found."
+//	@Failure		409	{string}	string		"The resource was modified by another request."
 //	@Router			/collections/{collection} [patch]
 func (api *collectionApi) update(c echo.Context) error {
 	collection, err := api.app.Dao().FindCollectionByNameOrId(c.PathParam("collection"))
@@ -263,6 +280,7 @@ func (api *collectionApi) update(c echo.Context) error {
 
 	event := new(core.CollectionUpdateEvent)
 	event.HttpContext = c
+	event.RequestInfo = RequestInfo(c)
 	event.Collection = collection
 
 	// update the collection
@@ -271,10 +289,17 @@ func (api *collectionApi) update(c echo.Context) error {
 			event.Collection = m
 
 			return api.app.OnCollectionBeforeUpdateRequest().Trigger(event, func(e *core.CollectionUpdateEvent) error {
-				if err := next(e.Collection); err != nil {
-					return NewBadRequestError("Failed to update the collection.", err)
+				// the If-Match check and the collection save share the same transaction
+				if err := submitIfMatch(e.HttpContext, api.app.Dao(), form, func(txDao *daos.Dao) (models.Model, error) {
+					return txDao.FindCollectionByNameOrId(collection.Id)
+				}, func() error {
+					return next(e.Collection)
+				}); err != nil {
+					return newETagMismatchError(err, "Failed to update the collection.")
 				}
 
+				setETag(e.HttpContext, e.Collection)
+
 				return e.HttpContext.JSON(http.StatusOK, e.Collection)
 			})
 		}
@@ -305,6 +330,7 @@ func (api *collectionApi) delete(c echo.Context) error {
 
 	event := new(core.CollectionDeleteEvent)
 	event.HttpContext = c
+	event.RequestInfo = RequestInfo(c)
 	event.Collection = collection
 
 	handlerErr := api.app.OnCollectionBeforeDeleteRequest().Trigger(event, func(e *core.CollectionDeleteEvent) error {
@@ -324,14 +350,88 @@ func (api *collectionApi) delete(c echo.Context) error {
 	return handlerErr
 }
 
+//	@Summary		Обновить материализованное представление
+//	@Description	Перезаполняет таблицу материализованной view коллекции актуальными результатами ее запроса
+//	@Tags			Collections
+//	@Param			collection	path	string	true	"Имя или ID коллекции"
+//	@Security		AdminAuth
+//	@Success		204	"No Content"
+//	@Failure		400	{string}	string	"Failed to refresh the materialized view."
+//	@Failure		404	{string}	string	"Not found."
+//	@Router			/collections/{collection}/refresh [post]
+func (api *collectionApi) refresh(c echo.Context) error {
+	collection, err := api.app.Dao().FindCollectionByNameOrId(c.PathParam("collection"))
+	if err != nil || collection == nil {
+		return NewNotFoundError("", err)
+	}
+
+	if !collection.IsView() || !collection.ViewOptions().Materialized {
+		return NewBadRequestError("Only materialized view collections can be refreshed.", nil)
+	}
+
+	if err := api.app.Dao().RefreshMaterializedView(collection); err != nil {
+		return NewBadRequestError("Failed to refresh the materialized view.", err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+//	@Summary		Проверить данные коллекции
+//	@Description	Проверяет сохраненные записи коллекции на соответствие текущей схеме (несовпадение типов после старых импортов, ссылки на удаленные записи, недопустимые значения select)
+//	@Description	Возвращает постраничный список найденных проблем с предлагаемыми исправленными значениями (suggestedValue)
+//	@Tags			Collections
+//	@Param			collection	path	string	true	"Имя или ID коллекции"
+//	@Param			page		query	int		false	"Номер страницы"
+//	@Param			perPage		query	int		false	"Количество проблем на странице"
+//	@Security		AdminAuth
+//	@Produce		json
+//	@Success		200	{object}	search.Result{items=[]models.RecordDataProblem}
+//	@Failure		400	{string}	string	"Failed to validate the collection data."
+//	@Failure		404	{string}	string	"Not found."
+//	@Router			/collections/{collection}/validate-data [get]
+func (api *collectionApi) validateData(c echo.Context) error {
+	collection, err := api.app.Dao().FindCollectionByNameOrId(c.PathParam("collection"))
+	if err != nil || collection == nil {
+		return NewNotFoundError("", err)
+	}
+
+	if collection.IsView() {
+		return NewBadRequestError("View collections data can't be validated.", nil)
+	}
+
+	page := &SearchResultPage{}
+	if err := c.Bind(page); err != nil {
+		return NewBadRequestError("Invalid pagination parameters.", err)
+	}
+	page.Normalize()
+
+	problems, err := api.app.Dao().FindRecordDataProblems(collection)
+	if err != nil {
+		return NewBadRequestError("Failed to validate the collection data.", err)
+	}
+
+	items := []*models.RecordDataProblem{}
+	if offset := page.Offset(); offset < len(problems) {
+		end := offset + page.PerPage
+		if end > len(problems) {
+			end = len(problems)
+		}
+		items = problems[offset:end]
+	}
+
+	return c.JSON(http.StatusOK, page.Result(items, int64(len(problems))))
+}
+
 //	@Summary		Импортировать коллекции
-//	@Description	Импортирует коллекции из переданных данных
+//	@Description	Импортирует коллекции из переданных данных. С параметром dryRun импорт полностью выполняется и проверяется, но откатывается, а в ответе возвращается план изменений
 //	@Tags			Collections
 //	@Security		AdminAuth
 //	@Accept			json
 //	@Produce		json
+//	@Param			dryRun	query	bool						false	"Только проверить импорт и построить план без применения"
 //	@Param			body	body	CollectionsImportRequest	true	"Данные для импорта коллекций"
 //	@Success		204		"No Content"
+//	@Success		200		{object}	forms.CollectionsImportPlan	"План изменений (dryRun)"
 //	@Failure		400		{string}	string	"Failed to authenticate."
 //	@Router			/collections/import [post]
 func (api *collectionApi) bulkImport(c echo.Context) error {
@@ -342,8 +442,19 @@ func (api *collectionApi) bulkImport(c echo.Context) error {
 		return NewBadRequestError("Failed to load the submitted data due to invalid formatting.", err)
 	}
 
+	// note: the import hooks are not triggered since nothing is persisted
+	if cast.ToBool(c.QueryParam("dryRun")) {
+		plan, err := form.DryRun()
+		if err != nil {
+			return NewBadRequestError("Failed to validate the submitted collections.", err)
+		}
+
+		return c.JSON(http.StatusOK, plan)
+	}
+
 	event := new(core.CollectionsImportEvent)
 	event.HttpContext = c
+	event.RequestInfo = RequestInfo(c)
 	event.Collections = form.Collections
 
 	// import collections
@@ -369,3 +480,88 @@ func (api *collectionApi) bulkImport(c echo.Context) error {
 
 	return submitErr
 }
+
+// importApprovalActions returns the approval actions of a collections
+// import request body (the import requires a "collection_delete"
+// approval only when it deletes existing collections).
+func (api *collectionApi) importApprovalActions(c echo.Context, body []byte) []string {
+	if cast.ToBool(c.QueryParam("dryRun")) {
+		return nil
+	}
+
+	form := forms.NewCollectionsImport(api.app)
+
+	// invalid bodies are reported by the import handler
+	if err := json.Unmarshal(body, form); err != nil || !form.DeleteMissing {
+		return nil
+	}
+
+	plan, err := form.Plan()
+	if err != nil || len(plan.Deleted) == 0 {
+		return nil
+	}
+
+	return []string{settings.ApprovalActionCollectionDelete}
+}
+
+//	@Summary		Сравнить коллекции
+//	@Description	Возвращает изменения на уровне полей между переданными коллекциями и текущей схемой базы данных, не применяя их
+//	@Tags			Collections
+//	@Security		AdminAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		CollectionsImportRequest	true	"Коллекции для сравнения"
+//	@Success		200		{array}		forms.CollectionDiff		"Список изменений"
+//	@Failure		400		{string}	string						"Failed to resolve the collections diff."
+//	@Router			/collections/diff [get]
+func (api *collectionApi) diff(c echo.Context) error {
+	form := forms.NewCollectionsDiff(api.app)
+
+	// load request data
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("Failed to load the submitted data due to invalid formatting.", err)
+	}
+
+	result, err := form.Diff()
+	if err != nil {
+		return NewBadRequestError("Failed to resolve the collections diff.", err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// CollectionsExport defines the collections export response
+// (it has the same format as the collections import request body).
+type CollectionsExport struct {
+	Collections []*models.Collection `json:"collections"`
+}
+
+//	@Summary		Экспортировать коллекции
+//	@Description	Возвращает все коллекции (включая индексы и правила доступа) в формате, принимаемом /collections/import
+//	@Tags			Collections
+//	@Security		AdminAuth
+//	@Produce		json
+//	@Param			type	query		string				false	"Тип коллекции (base, auth, view)"
+//	@Param			prefix	query		string				false	"Префикс названия коллекции"
+//	@Success		200		{object}	CollectionsExport	"Экспортированные коллекции"
+//	@Failure		400		{string}	string				"Failed to export the collections."
+//	@Router			/collections/export [get]
+func (api *collectionApi) export(c echo.Context) error {
+	query := api.app.Dao().CollectionQuery().OrderBy("created ASC")
+
+	if collectionType := c.QueryParam("type"); collectionType != "" {
+		query.AndWhere(dbx.HashExp{"type": collectionType})
+	}
+
+	if prefix := c.QueryParam("prefix"); prefix != "" {
+		query.AndWhere(dbx.Like("name", prefix).Match(false, true))
+	}
+
+	result := &CollectionsExport{Collections: []*models.Collection{}}
+
+	if err := query.All(&result.Collections); err != nil {
+		return NewBadRequestError("Failed to export the collections.", err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
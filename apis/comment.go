@@ -0,0 +1,320 @@
+package apis
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+// bindCommentApi registers the record comment threads api endpoints.
+func bindCommentApi(app core.App, rg *echo.Group) {
+	api := commentApi{app: app}
+
+	subGroup := rg.Group("/comments/:collection/:recordId", ActivityLogger(app), LoadCollectionContext(app))
+	subGroup.GET("", api.list)
+	subGroup.POST("", api.create, RequireRecordAuth())
+	subGroup.PATCH("/:id", api.update, RequireRecordAuth())
+	subGroup.DELETE("/:id", api.delete, RequireAdminOrRecordAuth())
+	subGroup.POST("/:id/moderate", api.moderate, RequireAdminAuth())
+}
+
+type commentApi struct {
+	app core.App
+}
+
+// findThread returns the comments thread owner record from the current
+// request path and its collection comments settings.
+//
+// The thread is accessible only if the comments are enabled for the
+// record collection and the record could be viewed by the current
+// admin or auth record (aka. its collection ViewRule is satisfied).
+func (api *commentApi) findThread(c echo.Context) (*models.Record, settings.CommentsCollectionConfig, error) {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return nil, settings.CommentsCollectionConfig{}, NewNotFoundError("", "Missing collection context.")
+	}
+
+	config, ok := api.app.Settings().Comments.Collection(collection.Name)
+	if !ok {
+		return nil, config, NewNotFoundError("", nil)
+	}
+
+	requestData := RequestData(c)
+
+	if requestData.Admin == nil && collection.ViewRule == nil {
+		// only admins can access if the rule is nil
+		return nil, config, NewNotFoundError("", nil)
+	}
+
+	ruleFunc := func(q *dbx.SelectQuery) error {
+		if requestData.Admin == nil && collection.ViewRule != nil && *collection.ViewRule != "" {
+			resolver := resolvers.NewRecordFieldResolver(api.app.Dao(), collection, requestData, true)
+			expr, err := search.FilterData(*collection.ViewRule).BuildExpr(resolver)
+			if err != nil {
+				return err
+			}
+			resolver.UpdateQuery(q)
+			q.AndWhere(expr)
+		}
+		return nil
+	}
+
+	record, err := api.app.Dao().FindRecordById(collection.Id, c.PathParam("recordId"), ruleFunc)
+	if err != nil {
+		return nil, config, NewNotFoundError("", err)
+	}
+
+	return record, config, nil
+}
+
+// findComment returns the thread comment from the current request path.
+func (api *commentApi) findComment(c echo.Context, record *models.Record) (*models.Comment, error) {
+	comment, err := api.app.Dao().FindRecordCommentById(record, c.PathParam("id"))
+	if err != nil {
+		return nil, NewNotFoundError("", err)
+	}
+
+	return comment, nil
+}
+
+//	@Summary		List record comments
+//	@Description	Returns a paginated list with the comments of the record thread (sorted by their created date by default).
+//	@Description	Auth records see only the approved comments and their own ones.
+//	@Tags			Comments
+//	@Produce		json
+//	@Param			collection	path	string	true	"Collection name or id"
+//	@Param			recordId	path	string	true	"Thread record id"
+//	@Param			page		query	int		false	"Page number"
+//	@Param			perPage		query	int		false	"Items per page"
+//	@Param			sort		query	string	false	"Sort fields"
+//	@Param			filter		query	string	false	"Filter expression"
+//	@Security		AdminAuth
+//	@Security		RecordAuth
+//	@Success		200	{object}	search.Result{items=[]models.Comment}
+//	@Failure		400	{string}	string	"Something went wrong while processing your request."
+//	@Failure		404	{string}	string	"The requested resource wasn't found."
+//	@Router			/comments/{collection}/{recordId} [get]
+func (api *commentApi) list(c echo.Context) error {
+	record, _, err := api.findThread(c)
+	if err != nil {
+		return err
+	}
+
+	fieldResolver := search.NewSimpleFieldResolver(
+		"id", "created", "updated", "parentId", "authorCollectionId", "authorId", "status",
+	)
+
+	query := api.app.Dao().RecordCommentsQuery(record)
+
+	if admin, _ := c.Get(ContextAdminKey).(*models.Admin); admin == nil {
+		visible := []dbx.Expression{dbx.HashExp{"status": models.CommentStatusApproved}}
+
+		if authRecord, _ := c.Get(ContextAuthRecordKey).(*models.Record); authRecord != nil {
+			visible = append(visible, dbx.HashExp{
+				"authorCollectionId": authRecord.Collection().Id,
+				"authorId":           authRecord.Id,
+			})
+		}
+
+		query.AndWhere(dbx.Or(visible...))
+	}
+
+	searchProvider := search.NewProvider(fieldResolver).Query(query)
+
+	if c.QueryParam(search.SortQueryParam) == "" {
+		searchProvider.AddSort(search.SortField{Name: "created", Direction: search.SortAsc})
+	}
+
+	comments := []*models.Comment{}
+
+	result, err := searchProvider.ParseAndExec(c.QueryParams().Encode(), &comments)
+	if err != nil {
+		return NewBadRequestError("", err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+//	@Summary		Create record comment
+//	@Description	Adds a new comment (or a reply if parentId is set) to the record thread from the authorized record.
+//	@Description	The "@username" mentions of the author collection records are resolved and stored in the comment mentions list.
+//	@Tags			Comments
+//	@Accept			json
+//	@Produce		json
+//	@Param			collection	path	string				true	"Collection name or id"
+//	@Param			recordId	path	string				true	"Thread record id"
+//	@Param			body		body	forms.CommentUpsert	true	"Comment data"
+//	@Security		RecordAuth
+//	@Success		200	{object}	models.Comment
+//	@Failure		400	{string}	string	"Failed to create the comment."
+//	@Failure		401	{string}	string	"The request requires valid record authorization token to be set."
+//	@Failure		404	{string}	string	"The requested resource wasn't found."
+//	@Router			/comments/{collection}/{recordId} [post]
+func (api *commentApi) create(c echo.Context) error {
+	record, config, err := api.findThread(c)
+	if err != nil {
+		return err
+	}
+
+	authRecord, _ := c.Get(ContextAuthRecordKey).(*models.Record)
+	if authRecord == nil {
+		return NewUnauthorizedError("", nil)
+	}
+
+	comment := &models.Comment{
+		CollectionId:       record.Collection().Id,
+		RecordId:           record.Id,
+		AuthorCollectionId: authRecord.Collection().Id,
+		AuthorId:           authRecord.Id,
+	}
+
+	form := forms.NewCommentUpsert(api.app, config, comment)
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	return form.Submit(func(next forms.InterceptorNextFunc[*models.Comment]) forms.InterceptorNextFunc[*models.Comment] {
+		return func(comment *models.Comment) error {
+			if err := next(comment); err != nil {
+				return NewBadRequestError("Failed to create the comment.", err)
+			}
+
+			return c.JSON(http.StatusOK, comment)
+		}
+	})
+}
+
+//	@Summary		Update record comment
+//	@Description	Updates the message of an own comment (the edited comments of moderated threads are sent back to moderation)
+//	@Tags			Comments
+//	@Accept			json
+//	@Produce		json
+//	@Param			collection	path	string				true	"Collection name or id"
+//	@Param			recordId	path	string				true	"Thread record id"
+//	@Param			id			path	string				true	"Comment id"
+//	@Param			body		body	forms.CommentUpsert	true	"Comment data"
+//	@Security		RecordAuth
+//	@Success		200	{object}	models.Comment
+//	@Failure		400	{string}	string	"Failed to update the comment."
+//	@Failure		401	{string}	string	"The request requires valid record authorization token to be set."
+//	@Failure		403	{string}	string	"Only the comment author can perform this action."
+//	@Failure		404	{string}	string	"The requested resource wasn't found."
+//	@Router			/comments/{collection}/{recordId}/{id} [patch]
+func (api *commentApi) update(c echo.Context) error {
+	record, config, err := api.findThread(c)
+	if err != nil {
+		return err
+	}
+
+	comment, err := api.findComment(c, record)
+	if err != nil {
+		return err
+	}
+
+	authRecord, _ := c.Get(ContextAuthRecordKey).(*models.Record)
+	if !comment.IsAuthor(authRecord) {
+		return NewForbiddenError("Only the comment author can perform this action.", nil)
+	}
+
+	form := forms.NewCommentUpsert(api.app, config, comment)
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	return form.Submit(func(next forms.InterceptorNextFunc[*models.Comment]) forms.InterceptorNextFunc[*models.Comment] {
+		return func(comment *models.Comment) error {
+			if err := next(comment); err != nil {
+				return NewBadRequestError("Failed to update the comment.", err)
+			}
+
+			return c.JSON(http.StatusOK, comment)
+		}
+	})
+}
+
+//	@Summary		Delete record comment
+//	@Description	Deletes a comment and all its replies (auth records could delete only their own comments)
+//	@Tags			Comments
+//	@Param			collection	path	string	true	"Collection name or id"
+//	@Param			recordId	path	string	true	"Thread record id"
+//	@Param			id			path	string	true	"Comment id"
+//	@Security		AdminAuth
+//	@Security		RecordAuth
+//	@Success		204	"No Content"
+//	@Failure		400	{string}	string	"Failed to delete the comment."
+//	@Failure		401	{string}	string	"The request requires admin or record authorization token to be set."
+//	@Failure		403	{string}	string	"Only admins and the comment author can perform this action."
+//	@Failure		404	{string}	string	"The requested resource wasn't found."
+//	@Router			/comments/{collection}/{recordId}/{id} [delete]
+func (api *commentApi) delete(c echo.Context) error {
+	record, _, err := api.findThread(c)
+	if err != nil {
+		return err
+	}
+
+	comment, err := api.findComment(c, record)
+	if err != nil {
+		return err
+	}
+
+	admin, _ := c.Get(ContextAdminKey).(*models.Admin)
+	authRecord, _ := c.Get(ContextAuthRecordKey).(*models.Record)
+	if admin == nil && !comment.IsAuthor(authRecord) {
+		return NewForbiddenError("Only admins and the comment author can perform this action.", nil)
+	}
+
+	if err := api.app.Dao().DeleteComment(comment); err != nil {
+		return NewBadRequestError("Failed to delete the comment.", err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+//	@Summary		Moderate record comment
+//	@Description	Changes the moderation status of a comment (only the approved comments are visible to everyone with access to the thread)
+//	@Tags			Comments
+//	@Accept			json
+//	@Produce		json
+//	@Param			collection	path	string					true	"Collection name or id"
+//	@Param			recordId	path	string					true	"Thread record id"
+//	@Param			id			path	string					true	"Comment id"
+//	@Param			body		body	forms.CommentModerate	true	"Moderation status"
+//	@Security		AdminAuth
+//	@Success		200	{object}	models.Comment
+//	@Failure		400	{string}	string	"Failed to moderate the comment."
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Failure		404	{string}	string	"The requested resource wasn't found."
+//	@Router			/comments/{collection}/{recordId}/{id}/moderate [post]
+func (api *commentApi) moderate(c echo.Context) error {
+	record, _, err := api.findThread(c)
+	if err != nil {
+		return err
+	}
+
+	comment, err := api.findComment(c, record)
+	if err != nil {
+		return err
+	}
+
+	form := forms.NewCommentModerate(api.app, comment)
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	return form.Submit(func(next forms.InterceptorNextFunc[*models.Comment]) forms.InterceptorNextFunc[*models.Comment] {
+		return func(comment *models.Comment) error {
+			if err := next(comment); err != nil {
+				return NewBadRequestError("Failed to moderate the comment.", err)
+			}
+
+			return c.JSON(http.StatusOK, comment)
+		}
+	})
+}
@@ -0,0 +1,415 @@
+package apis_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/apis"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/subscriptions"
+)
+
+// setupTestComments enables the demo2 and demo3 comment threads and
+// creates an approved comment (owner), a pending comment (member) and
+// an approved reply (member) in the demo2 "achvryl401bhse3" thread.
+func setupTestComments(moderated bool) func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+	return func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+		app.Settings().Comments.Collections = map[string]settings.CommentsCollectionConfig{
+			"demo2": {Moderated: moderated, MaxLength: 100},
+			"demo3": {},
+		}
+
+		comments := []*models.Comment{
+			{AuthorId: "4q1xlclmfloku33", Message: "approved", Status: models.CommentStatusApproved},
+			{AuthorId: "oap640cot4yru2s", Message: "pending", Status: models.CommentStatusPending},
+			{AuthorId: "oap640cot4yru2s", Message: "reply", Status: models.CommentStatusApproved, ParentId: "cmtapproved0001"},
+		}
+
+		for i, comment := range comments {
+			comment.Id = []string{"cmtapproved0001", "cmtpending00001", "cmtreply0000001"}[i]
+			comment.MarkAsNew()
+			comment.CollectionId = "sz5l5z67tg7gku0"
+			comment.RecordId = "achvryl401bhse3"
+			comment.AuthorCollectionId = "_pb_users_auth_"
+
+			if err := app.Dao().SaveComment(comment); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		app.ResetEventCalls()
+	}
+}
+
+func TestCommentsList(t *testing.T) {
+	scenarios := []tests.ApiScenario{
+		{
+			Name:            "comments not enabled for the collection",
+			Method:          http.MethodGet,
+			Url:             "/api/comments/demo1/84nmscqy84lsi1t",
+			BeforeTestFunc:  setupTestComments(false),
+			ExpectedStatus:  404,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:            "missing thread record",
+			Method:          http.MethodGet,
+			Url:             "/api/comments/demo2/missing",
+			BeforeTestFunc:  setupTestComments(false),
+			ExpectedStatus:  404,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "thread record not satisfying the view rule",
+			Method: http.MethodGet,
+			Url:    "/api/comments/demo3/1tmknxy2868d869",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgOwnerToken,
+			},
+			BeforeTestFunc:  setupTestComments(false),
+			ExpectedStatus:  404,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:           "guest",
+			Method:         http.MethodGet,
+			Url:            "/api/comments/demo2/achvryl401bhse3",
+			BeforeTestFunc: setupTestComments(false),
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"totalItems":2`,
+				`"id":"cmtapproved0001"`,
+				`"id":"cmtreply0000001"`,
+			},
+			NotExpectedContent: []string{
+				`"id":"cmtpending00001"`,
+			},
+		},
+		{
+			Name:   "comment author (own pending comments included)",
+			Method: http.MethodGet,
+			Url:    "/api/comments/demo2/achvryl401bhse3?filter=parentId=''",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgMemberToken,
+			},
+			BeforeTestFunc: setupTestComments(false),
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"totalItems":2`,
+				`"id":"cmtapproved0001"`,
+				`"id":"cmtpending00001"`,
+			},
+			NotExpectedContent: []string{
+				`"id":"cmtreply0000001"`,
+			},
+		},
+		{
+			Name:   "admin",
+			Method: http.MethodGet,
+			Url:    "/api/comments/demo2/achvryl401bhse3?filter=status='pending'",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgAdminToken,
+			},
+			BeforeTestFunc: setupTestComments(false),
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"totalItems":1`,
+				`"id":"cmtpending00001"`,
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
+
+func TestCommentCreate(t *testing.T) {
+	scenarios := []tests.ApiScenario{
+		{
+			Name:            "guest",
+			Method:          http.MethodPost,
+			Url:             "/api/comments/demo2/achvryl401bhse3",
+			Body:            strings.NewReader(`{"message":"test"}`),
+			BeforeTestFunc:  setupTestComments(false),
+			ExpectedStatus:  401,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "admin",
+			Method: http.MethodPost,
+			Url:    "/api/comments/demo2/achvryl401bhse3",
+			Body:   strings.NewReader(`{"message":"test"}`),
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgAdminToken,
+			},
+			BeforeTestFunc:  setupTestComments(false),
+			ExpectedStatus:  401,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "invalid data",
+			Method: http.MethodPost,
+			Url:    "/api/comments/demo2/achvryl401bhse3",
+			Body:   strings.NewReader(`{"message":"` + strings.Repeat("a", 101) + `","parentId":"missing"}`),
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgMemberToken,
+			},
+			BeforeTestFunc: setupTestComments(false),
+			ExpectedStatus: 400,
+			ExpectedContent: []string{
+				`"message":{"code":"validation_length_out_of_range"`,
+				`"parentId":{"code":"validation_invalid_comment_parent"`,
+			},
+		},
+		{
+			Name:   "unmoderated reply with mentions",
+			Method: http.MethodPost,
+			Url:    "/api/comments/demo2/achvryl401bhse3",
+			Body:   strings.NewReader(`{"message":"Thanks @users75657!","parentId":"cmtapproved0001"}`),
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgMemberToken,
+			},
+			BeforeTestFunc: setupTestComments(false),
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"collectionId":"sz5l5z67tg7gku0"`,
+				`"recordId":"achvryl401bhse3"`,
+				`"parentId":"cmtapproved0001"`,
+				`"authorCollectionId":"_pb_users_auth_"`,
+				`"authorId":"oap640cot4yru2s"`,
+				`"status":"approved"`,
+				`"mentions":["4q1xlclmfloku33"]`,
+			},
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeCreate": 1,
+				"OnModelAfterCreate":  1,
+			},
+		},
+		{
+			Name:   "moderated",
+			Method: http.MethodPost,
+			Url:    "/api/comments/demo2/achvryl401bhse3",
+			Body:   strings.NewReader(`{"message":"test"}`),
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgMemberToken,
+			},
+			BeforeTestFunc: setupTestComments(true),
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"status":"pending"`,
+				`"mentions":[]`,
+			},
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeCreate": 1,
+				"OnModelAfterCreate":  1,
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
+
+func TestCommentUpdate(t *testing.T) {
+	scenarios := []tests.ApiScenario{
+		{
+			Name:   "not the author",
+			Method: http.MethodPatch,
+			Url:    "/api/comments/demo2/achvryl401bhse3/cmtapproved0001",
+			Body:   strings.NewReader(`{"message":"test"}`),
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgMemberToken,
+			},
+			BeforeTestFunc:  setupTestComments(false),
+			ExpectedStatus:  403,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "comment from another thread",
+			Method: http.MethodPatch,
+			Url:    "/api/comments/demo2/0yxhwia2amd8gec/cmtapproved0001",
+			Body:   strings.NewReader(`{"message":"test"}`),
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgOwnerToken,
+			},
+			BeforeTestFunc:  setupTestComments(false),
+			ExpectedStatus:  404,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "author in moderated thread",
+			Method: http.MethodPatch,
+			Url:    "/api/comments/demo2/achvryl401bhse3/cmtapproved0001",
+			Body:   strings.NewReader(`{"message":"edited"}`),
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgOwnerToken,
+			},
+			BeforeTestFunc: setupTestComments(true),
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"id":"cmtapproved0001"`,
+				`"message":"edited"`,
+				`"status":"pending"`,
+			},
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeUpdate": 1,
+				"OnModelAfterUpdate":  1,
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
+
+func TestCommentDelete(t *testing.T) {
+	scenarios := []tests.ApiScenario{
+		{
+			Name:   "not the author",
+			Method: http.MethodDelete,
+			Url:    "/api/comments/demo2/achvryl401bhse3/cmtapproved0001",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgMemberToken,
+			},
+			BeforeTestFunc:  setupTestComments(false),
+			ExpectedStatus:  403,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "author",
+			Method: http.MethodDelete,
+			Url:    "/api/comments/demo2/achvryl401bhse3/cmtpending00001",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgMemberToken,
+			},
+			BeforeTestFunc: setupTestComments(false),
+			ExpectedStatus: 204,
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeDelete": 1,
+				"OnModelAfterDelete":  1,
+			},
+		},
+		{
+			Name:   "admin (with replies)",
+			Method: http.MethodDelete,
+			Url:    "/api/comments/demo2/achvryl401bhse3/cmtapproved0001",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgAdminToken,
+			},
+			BeforeTestFunc: setupTestComments(false),
+			ExpectedStatus: 204,
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeDelete": 2,
+				"OnModelAfterDelete":  2,
+			},
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				if _, err := app.Dao().FindCommentById("cmtreply0000001"); err == nil {
+					t.Fatal("Expected the reply to be deleted")
+				}
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
+
+func TestCommentModerate(t *testing.T) {
+	guestClient := subscriptions.NewDefaultClient()
+	guestClient.Subscribe(apis.RealtimeCommentsTopicPrefix + "demo2/achvryl401bhse3")
+
+	authorClient := subscriptions.NewDefaultClient()
+	authorClient.Subscribe(apis.RealtimeCommentsTopicPrefix + "sz5l5z67tg7gku0/achvryl401bhse3")
+
+	scenarios := []tests.ApiScenario{
+		{
+			Name:   "auth record",
+			Method: http.MethodPost,
+			Url:    "/api/comments/demo2/achvryl401bhse3/cmtpending00001/moderate",
+			Body:   strings.NewReader(`{"status":"approved"}`),
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgOwnerToken,
+			},
+			BeforeTestFunc:  setupTestComments(true),
+			ExpectedStatus:  401,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "invalid status",
+			Method: http.MethodPost,
+			Url:    "/api/comments/demo2/achvryl401bhse3/cmtpending00001/moderate",
+			Body:   strings.NewReader(`{"status":"unknown"}`),
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgAdminToken,
+			},
+			BeforeTestFunc:  setupTestComments(true),
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"status":{"code":"validation_in_invalid"`},
+		},
+		{
+			Name:   "rejecting approved comment",
+			Method: http.MethodPost,
+			Url:    "/api/comments/demo2/achvryl401bhse3/cmtapproved0001/moderate",
+			Body:   strings.NewReader(`{"status":"rejected"}`),
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgAdminToken,
+			},
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				setupTestComments(true)(t, app, e)
+
+				author, err := app.Dao().FindRecordById("users", "4q1xlclmfloku33")
+				if err != nil {
+					t.Fatal(err)
+				}
+				authorClient.Set(apis.ContextAuthRecordKey, author)
+
+				app.SubscriptionsBroker().Register(guestClient)
+				app.SubscriptionsBroker().Register(authorClient)
+			},
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"id":"cmtapproved0001"`,
+				`"status":"rejected"`,
+			},
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeUpdate": 1,
+				"OnModelAfterUpdate":  1,
+			},
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				expectations := []struct {
+					client   subscriptions.Client
+					expected []string
+				}{
+					{guestClient, []string{`"action":"delete"`, `"id":"cmtapproved0001"`, `"message":""`}},
+					{authorClient, []string{`"action":"update"`, `"status":"rejected"`, `"message":"approved"`}},
+				}
+
+				for i, s := range expectations {
+					select {
+					case msg := <-s.client.Channel():
+						for _, str := range s.expected {
+							if !strings.Contains(msg.Data, str) {
+								t.Fatalf("[%d] Expected %q in message %+v", i, str, msg)
+							}
+						}
+					case <-time.After(time.Second):
+						t.Fatalf("[%d] Expected comment message", i)
+					}
+				}
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
@@ -16,101 +16,67 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/admins": {
+        "/.well-known/jwks.json": {
             "get": {
-                "description": "Возвращает список администраторов с возможностью поиска и сортировки",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Returns the public keys for local verification of the RS256/EdDSA signed admin and auth record tokens",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Admin"
-                ],
-                "summary": "Получение списка администраторов",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Идентификатор администратора",
-                        "name": "id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Дата создания администратора",
-                        "name": "created",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Дата обновления администратора",
-                        "name": "updated",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Имя администратора",
-                        "name": "name",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Email администратора",
-                        "name": "email",
-                        "in": "query"
-                    }
+                    "Settings"
                 ],
+                "summary": "JSON Web Key Set",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/apis.Admin"
-                            }
+                            "$ref": "#/definitions/apis.JwksResponse"
                         }
                     },
                     "400": {
-                        "description": "Failed to authenticate.",
+                        "description": "Failed to load the token signing keys.",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Создает нового администратора",
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/admin/db/slow-queries": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
                 ],
+                "description": "Returns the slowest recently executed db queries (grouped by their normalized SQL and sorted by p95 duration) with missing index suggestions. The queries are tracked only when enabled in the slowQueries settings and exceed its threshold",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Admin"
                 ],
-                "summary": "Создание администратора",
+                "summary": "List slow queries",
                 "parameters": [
                     {
-                        "description": "Данные для создания администратора",
-                        "name": "admin",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/apis.AdminCreateForm"
-                        }
+                        "type": "integer",
+                        "description": "Max number of returned queries (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/apis.Admin"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/apis.SlowQueryItem"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Failed to authenticate.",
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
                         "schema": {
                             "type": "string"
                         }
@@ -118,44 +84,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admins/auth-refresh": {
+        "/admin/sql": {
             "post": {
-                "description": "Refreshes the admin authentication.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Admin"
-                ],
-                "summary": "Admin Authentication Refresh",
-                "parameters": [
+                "security": [
                     {
-                        "type": "string",
-                        "description": "Access token",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
+                        "AdminAuth": []
                     }
                 ],
-                "responses": {
-                    "200": {
-                        "description": "Successful operation",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "404": {
-                        "description": "Missing auth admin context",
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                }
-            }
-        },
-        "/admins/auth-with-password": {
-            "post": {
-                "description": "Выполняет аутентификацию администратора с использованием пароля",
+                "description": "Executes a single SQL statement (read-only by default) against the app database. Every execution is stored in the audit logs",
                 "consumes": [
                     "application/json"
                 ],
@@ -163,29 +99,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin"
+                    "SqlConsole"
                 ],
-                "summary": "Аутентификация администратора с использованием пароля",
+                "summary": "Run SQL query",
                 "parameters": [
                     {
-                        "description": "Данные аутентификации администратора",
-                        "name": "adminLogin",
+                        "description": "Query data",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/apis.AdminLogin"
+                            "$ref": "#/definitions/apis.SqlConsoleRunRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successful operation",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/forms.SqlConsoleResult"
                         }
                     },
                     "400": {
-                        "description": "Failed to authenticate.",
+                        "description": "Failed to execute the query.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Only superadmins can access the SQL console.",
                         "schema": {
                             "type": "string"
                         }
@@ -193,46 +141,75 @@ const docTemplate = `{
                 }
             }
         },
-        "/admins/confirm-password-reset": {
-            "post": {
-                "description": "Подтверждает сброс пароля администратора",
-                "consumes": [
-                    "application/json"
+        "/admin/sql/queries": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
                 ],
+                "description": "Returns a paginated saved SQL queries list",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Admin"
+                    "SqlConsole"
                 ],
-                "summary": "Подтверждение сброса пароля администратора",
+                "summary": "List saved SQL queries",
                 "parameters": [
                     {
-                        "description": "Данные подтверждения сброса пароля администратора",
-                        "name": "passwordResetConfirm",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/apis.AdminPasswordResetConfirm"
-                        }
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page",
+                        "name": "perPage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort fields",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter expression",
+                        "name": "filter",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/search.Result"
+                        }
                     },
                     "400": {
-                        "description": "Failed to authenticate.",
+                        "description": "Something went wrong while processing your request.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Only superadmins can access the SQL console.",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
-            }
-        },
-        "/admins/request-password-reset": {
+            },
             "post": {
-                "description": "Отправляет запрос на сброс пароля администратора",
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Creates a new saved (optionally parameterized) SQL query",
                 "consumes": [
                     "application/json"
                 ],
@@ -240,26 +217,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin"
+                    "SqlConsole"
                 ],
-                "summary": "Запрос на сброс пароля администратора",
+                "summary": "Create saved SQL query",
                 "parameters": [
                     {
-                        "description": "Данные запроса на сброс пароля администратора",
-                        "name": "passwordResetRequest",
+                        "description": "Saved query data",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/apis.AdminPasswordResetRequest"
+                            "$ref": "#/definitions/forms.SqlQueryUpsert"
                         }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SqlQuery"
+                        }
                     },
                     "400": {
-                        "description": "Failed to authenticate.",
+                        "description": "Failed to create saved query.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Only superadmins can access the SQL console.",
                         "schema": {
                             "type": "string"
                         }
@@ -267,23 +253,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/admins/{id}": {
+        "/admin/sql/queries/{id}": {
             "get": {
-                "description": "Возвращает информацию об указанном администраторе по его идентификатору",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
                 ],
+                "description": "Returns a single saved SQL query by its id",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Admin"
+                    "SqlConsole"
                 ],
-                "summary": "Просмотр администратора",
+                "summary": "View saved SQL query",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Идентификатор администратора",
+                        "description": "Saved query id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -293,11 +281,17 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/apis.Admin"
+                            "$ref": "#/definitions/models.SqlQuery"
                         }
                     },
-                    "400": {
-                        "description": "Failed to authenticate.",
+                    "403": {
+                        "description": "Only superadmins can access the SQL console.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "The requested resource wasn't found.",
                         "schema": {
                             "type": "string"
                         }
@@ -305,18 +299,20 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Удаляет указанного администратора по его идентификатору",
-                "produces": [
-                    "text/plain"
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
                 ],
+                "description": "Deletes the saved SQL query with the specified id",
                 "tags": [
-                    "Admin"
+                    "SqlConsole"
                 ],
-                "summary": "Удаление администратора",
+                "summary": "Delete saved SQL query",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Идентификатор администратора",
+                        "description": "Saved query id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -327,7 +323,19 @@ const docTemplate = `{
                         "description": "No Content"
                     },
                     "400": {
-                        "description": "Failed to authenticate.",
+                        "description": "Failed to delete saved query.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Only superadmins can access the SQL console.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "The requested resource wasn't found.",
                         "schema": {
                             "type": "string"
                         }
@@ -335,7 +343,12 @@ const docTemplate = `{
                 }
             },
             "patch": {
-                "description": "Обновляет информацию об указанном администраторе по его идентификатору",
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Updates the saved SQL query with the specified id",
                 "consumes": [
                     "application/json"
                 ],
@@ -343,24 +356,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin"
+                    "SqlConsole"
                 ],
-                "summary": "Обновление администратора",
+                "summary": "Update saved SQL query",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Идентификатор администратора",
+                        "description": "Saved query id",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Данные для обновления администратора",
-                        "name": "admin",
+                        "description": "Saved query data",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/apis.AdminUpdateForm"
+                            "$ref": "#/definitions/forms.SqlQueryUpsert"
                         }
                     }
                 ],
@@ -368,189 +381,86 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/apis.Admin"
+                            "$ref": "#/definitions/models.SqlQuery"
                         }
                     },
                     "400": {
-                        "description": "Not found",
+                        "description": "Failed to update saved query.",
                         "schema": {
                             "type": "string"
                         }
-                    }
-                }
-            }
-        },
-        "/backups": {
-            "get": {
-                "security": [
-                    {
-                        "AdminAuth": []
-                    }
-                ],
-                "description": "Возвращает список доступных резервных копий",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Backups"
-                ],
-                "summary": "Получение списка резервных копий",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "403": {
+                        "description": "Only superadmins can access the SQL console.",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/apis.BackupFileInfo"
-                            }
+                            "type": "string"
                         }
                     },
-                    "400": {
-                        "description": "Failed to authenticate.",
+                    "404": {
+                        "description": "The requested resource wasn't found.",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/admin/sql/queries/{id}/run": {
             "post": {
                 "security": [
                     {
                         "AdminAuth": []
                     }
                 ],
-                "description": "Создает новую резервную копию",
+                "description": "Executes the saved SQL query with the provided params",
                 "consumes": [
                     "application/json"
                 ],
-                "tags": [
-                    "Backups"
-                ],
-                "summary": "Создание резервной копии",
-                "parameters": [
-                    {
-                        "description": "Данные для создания резервной копии",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/apis.BackupCreateRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "400": {
-                        "description": "Failed to authenticate.",
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                }
-            }
-        },
-        "/backups/{key}": {
-            "get": {
-                "security": [
-                    {
-                        "AdminAuth": []
-                    }
+                "produces": [
+                    "application/json"
                 ],
-                "description": "Загружает резервную копию по указанному ключу",
                 "tags": [
-                    "Backups"
+                    "SqlConsole"
                 ],
-                "summary": "Загрузка резервной копии",
+                "summary": "Run saved SQL query",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Ключ резервной копии",
-                        "name": "key",
+                        "description": "Saved query id",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Токен доступа",
-                        "name": "token",
-                        "in": "query",
-                        "required": true
+                        "description": "Query params",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/apis.SqlQueryRunRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/forms.SqlConsoleResult"
+                        }
                     },
                     "400": {
-                        "description": "Not exists.",
+                        "description": "Failed to execute the query.",
                         "schema": {
                             "type": "string"
                         }
-                    }
-                }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "AdminAuth": []
-                    }
-                ],
-                "description": "Удаляет резервную копию по указанному ключу",
-                "tags": [
-                    "Backups"
-                ],
-                "summary": "Удаление резервной копии",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Ключ резервной копии",
-                        "name": "key",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "No Content"
                     },
-                    "400": {
-                        "description": "Failed to authenticate.",
+                    "403": {
+                        "description": "Only superadmins can access the SQL console.",
                         "schema": {
                             "type": "string"
                         }
-                    }
-                }
-            }
-        },
-        "/backups/{key}/restore": {
-            "post": {
-                "security": [
-                    {
-                        "AdminAuth": []
-                    }
-                ],
-                "description": "Запускает процесс восстановления резервной копии по указанному ключу",
-                "tags": [
-                    "Backups"
-                ],
-                "summary": "Восстановление резервной копии",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Ключ резервной копии",
-                        "name": "key",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "No Content"
                     },
-                    "400": {
-                        "description": "Failed to authenticate.",
+                    "404": {
+                        "description": "The requested resource wasn't found.",
                         "schema": {
                             "type": "string"
                         }
@@ -558,53 +468,54 @@ const docTemplate = `{
                 }
             }
         },
-        "/collections": {
+        "/admins": {
             "get": {
-                "security": [
-                    {
-                        "AdminAuth": []
-                    }
+                "description": "Возвращает список администраторов с возможностью поиска и сортировки",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
                 ],
-                "description": "Возвращает список коллекций с возможностью фильтрации и сортировки",
                 "tags": [
-                    "Collections"
+                    "Admin"
                 ],
-                "summary": "Получить список коллекций",
+                "summary": "Получение списка администраторов",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID коллекции",
+                        "description": "Идентификатор администратора",
                         "name": "id",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Дата создания коллекции в формате ISO8601",
+                        "description": "Дата создания администратора",
                         "name": "created",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Дата обновления коллекции в формате ISO8601",
+                        "description": "Дата обновления администратора",
                         "name": "updated",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Название коллекции",
+                        "description": "Имя администратора",
                         "name": "name",
                         "in": "query"
                     },
                     {
-                        "type": "boolean",
-                        "description": "Системная коллекция",
-                        "name": "system",
+                        "type": "string",
+                        "description": "Email администратора",
+                        "name": "email",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Тип коллекции",
-                        "name": "type",
+                        "description": "Строка поиска по email администратора",
+                        "name": "q",
                         "in": "query"
                     }
                 ],
@@ -612,7 +523,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/apis.SearchResult"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/apis.Admin"
+                            }
                         }
                     },
                     "400": {
@@ -624,12 +538,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "security": [
-                    {
-                        "AdminAuth": []
-                    }
-                ],
-                "description": "Создает новую коллекцию",
+                "description": "Создает нового администратора",
                 "consumes": [
                     "application/json"
                 ],
@@ -637,25 +546,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Collections"
+                    "Admin"
                 ],
-                "summary": "Создать коллекцию",
+                "summary": "Создание администратора",
                 "parameters": [
                     {
-                        "description": "Данные для создания коллекции",
-                        "name": "collection",
+                        "description": "Данные для создания администратора",
+                        "name": "admin",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/apis.CollectionCreateRequest"
+                            "$ref": "#/definitions/apis.AdminCreateForm"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности для безопасного повтора запроса",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/apis.Collection"
+                            "$ref": "#/definitions/apis.Admin"
+                        },
+                        "headers": {
+                            "Idempotent-Replayed": {
+                                "type": "string",
+                                "description": "true, если ответ является повтором сохраненного ответа"
+                            }
                         }
                     },
                     "400": {
@@ -663,60 +584,60 @@ const docTemplate = `{
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    "409": {
+                        "description": "A request with the same Idempotency-Key is still in progress.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "The Idempotency-Key was already used with a different request.",
+                        "schema": {
+                            "type": "string"
+                        }
                     }
                 }
             }
         },
-        "/collections/import": {
+        "/admins/auth-refresh": {
             "post": {
-                "security": [
-                    {
-                        "AdminAuth": []
-                    }
-                ],
-                "description": "Импортирует коллекции из переданных данных",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Refreshes the admin authentication.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Collections"
+                    "Admin"
                 ],
-                "summary": "Импортировать коллекции",
+                "summary": "Admin Authentication Refresh",
                 "parameters": [
                     {
-                        "description": "Данные для импорта коллекций",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/apis.CollectionsImportRequest"
-                        }
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "400": {
-                        "description": "Failed to authenticate.",
+                    "200": {
+                        "description": "Successful operation",
                         "schema": {
                             "type": "string"
                         }
-                    }
-                }
+                    },
+                    "404": {
+                        "description": "Missing auth admin context",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
             }
         },
-        "/collections/{collection}": {
-            "get": {
-                "security": [
-                    {
-                        "AdminAuth": []
-                    }
-                ],
-                "description": "Возвращает информацию о коллекции по ее имени или ID",
+        "/admins/auth-with-password": {
+            "post": {
+                "description": "Выполняет аутентификацию администратора с использованием пароля",
                 "consumes": [
                     "application/json"
                 ],
@@ -724,23 +645,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Collections"
+                    "Admin"
                 ],
-                "summary": "Просмотреть коллекцию",
+                "summary": "Аутентификация администратора с использованием пароля",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Имя или ID коллекции",
-                        "name": "collection",
-                        "in": "path",
-                        "required": true
+                        "description": "Данные аутентификации администратора",
+                        "name": "adminLogin",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apis.AdminLogin"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Successful operation",
                         "schema": {
-                            "$ref": "#/definitions/apis.Collection"
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -748,54 +671,55 @@ const docTemplate = `{
                         "schema": {
                             "type": "string"
                         }
-                    },
-                    "404": {
-                        "description": "Not found.",
-                        "schema": {
-                            "type": "string"
-                        }
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "AdminAuth": []
-                    }
+            }
+        },
+        "/admins/confirm-password-reset": {
+            "post": {
+                "description": "Подтверждает сброс пароля администратора",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
                 ],
-                "description": "Удаляет коллекцию по ее имени или ID",
                 "tags": [
-                    "Collections"
+                    "Admin"
                 ],
-                "summary": "Удалить коллекцию",
+                "summary": "Подтверждение сброса пароля администратора",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Имя или ID коллекции",
-                        "name": "collection",
-                        "in": "path",
-                        "required": true
+                        "description": "Данные подтверждения сброса пароля администратора",
+                        "name": "passwordResetConfirm",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apis.AdminPasswordResetConfirm"
+                        }
                     }
                 ],
                 "responses": {
                     "204": {
                         "description": "No Content"
                     },
-                    "404": {
-                        "description": "Not found.",
+                    "400": {
+                        "description": "Failed to authenticate.",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/admins/impersonate/{collection}/{recordId}": {
+            "post": {
                 "security": [
                     {
                         "AdminAuth": []
                     }
                 ],
-                "description": "Обновляет информацию о коллекции по ее имени или ID",
+                "description": "Generates a short-lived auth token of the specified auth record\n(eg. to debug user specific API rules issues).",
                 "consumes": [
                     "application/json"
                 ],
@@ -803,36 +727,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Collections"
+                    "Admin"
                 ],
-                "summary": "Обновить коллекцию",
+                "summary": "Admin impersonate",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Имя или ID коллекции",
+                        "description": "Auth collection name or id",
                         "name": "collection",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Данные для обновления коллекции",
+                        "type": "string",
+                        "description": "Auth record id",
+                        "name": "recordId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Token duration in seconds",
                         "name": "body",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/apis.CollectionCreateRequest"
+                            "$ref": "#/definitions/forms.AdminImpersonate"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Auth token and record",
                         "schema": {
-                            "$ref": "#/definitions/apis.Collection"
+                            "type": "string"
                         }
                     },
                     "400": {
-                        "description": "Failed to authenticate.",
+                        "description": "Failed to impersonate the auth record.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
                         "schema": {
                             "type": "string"
                         }
@@ -846,33 +782,44 @@ const docTemplate = `{
                 }
             }
         },
-        "/collections/{collection}/auth-methods": {
-            "get": {
+        "/admins/otp/disable": {
+            "post": {
                 "security": [
                     {
                         "AdminAuth": []
                     }
                 ],
-                "description": "Возвращает доступные методы аутентификации для указанной коллекции",
+                "description": "Disables the two-factor authentication of the authorized admin.",
+                "consumes": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Record Auth"
+                    "Admin"
                 ],
-                "summary": "Получить методы аутентификации",
+                "summary": "Admin TOTP disable",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Идентификатор коллекции",
-                        "name": "collection",
-                        "in": "path",
-                        "required": true
+                        "description": "One-time or recovery code",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/forms.AdminOtpDisable"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "методы аутентификации успешно получены"
+                    "204": {
+                        "description": "The two-factor authentication was disabled"
                     },
-                    "404": {
-                        "description": "Not found.",
+                    "400": {
+                        "description": "Failed to disable the two-factor authentication.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
                         "schema": {
                             "type": "string"
                         }
@@ -880,24 +827,36 @@ const docTemplate = `{
                 }
             }
         },
-        "/collections/{collection}/auth-refresh": {
+        "/admins/otp/enroll": {
             "post": {
                 "security": [
                     {
-                        "Auth": []
+                        "AdminAuth": []
                     }
                 ],
-                "description": "Обновляет данные аутентификации для указанной записи",
+                "description": "Generates a new TOTP secret for the authorized admin.\nThe two-factor authentication is enabled after a successful /admins/otp/verify request.",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Record Auth"
+                    "Admin"
                 ],
-                "summary": "Обновить данные аутентификации",
+                "summary": "Admin TOTP enroll",
                 "responses": {
                     "200": {
-                        "description": "данные аутентификации успешно обновленны"
+                        "description": "The TOTP secret and its otpauth:// key URI",
+                        "schema": {
+                            "type": "string"
+                        }
                     },
-                    "404": {
-                        "description": "Not found.",
+                    "400": {
+                        "description": "Failed to enroll the two-factor authentication.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
                         "schema": {
                             "type": "string"
                         }
@@ -905,14 +864,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/collections/{collection}/auth-with-oauth2": {
+        "/admins/otp/verify": {
             "post": {
                 "security": [
                     {
-                        "Auth": []
+                        "AdminAuth": []
                     }
                 ],
-                "description": "Выполняет аутентификацию с использованием протокола OAuth2 для указанной коллекции",
+                "description": "Verifies the TOTP enrollment of the authorized admin with a one-time code and enables the two-factor authentication.\nReturns the recovery codes (they are shown only once).",
                 "consumes": [
                     "application/json"
                 ],
@@ -920,30 +879,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Record Auth"
+                    "Admin"
                 ],
-                "summary": "Аутентификация с использованием OAuth2",
+                "summary": "Admin TOTP verify",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Идентификатор коллекции",
-                        "name": "collection",
-                        "in": "path",
-                        "required": true
+                        "description": "One-time code",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/forms.AdminOtpVerify"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "аутентификация с использованием OAuth2 успешна"
+                        "description": "The generated recovery codes",
+                        "schema": {
+                            "type": "string"
+                        }
                     },
                     "400": {
-                        "description": "Failed to authenticate.",
+                        "description": "Failed to verify the two-factor authentication.",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Not found.",
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
                         "schema": {
                             "type": "string"
                         }
@@ -951,14 +915,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/collections/{collection}/auth-with-password": {
+        "/admins/request-password-reset": {
             "post": {
-                "security": [
-                    {
-                        "Auth": []
-                    }
-                ],
-                "description": "Выполняет аутентификацию с использованием пароля для указанной коллекции",
+                "description": "Отправляет запрос на сброс пароля администратора",
                 "consumes": [
                     "application/json"
                 ],
@@ -966,45 +925,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Record Auth"
+                    "Admin"
                 ],
-                "summary": "Аутентификация с использованием пароля",
+                "summary": "Запрос на сброс пароля администратора",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Идентификатор коллекции",
-                        "name": "collection",
-                        "in": "path",
-                        "required": true
+                        "description": "Данные запроса на сброс пароля администратора",
+                        "name": "passwordResetRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apis.AdminPasswordResetRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Аутентификация с использованием пароля успешна"
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Failed to authenticate.",
                         "schema": {
                             "type": "string"
                         }
-                    },
-                    "404": {
-                        "description": "Not found.",
-                        "schema": {
-                            "type": "string"
-                        }
                     }
                 }
             }
         },
-        "/collections/{collection}/confirm-email-change": {
-            "post": {
-                "security": [
-                    {
-                        "Auth": []
-                    }
-                ],
-                "description": "Подтверждает изменение электронной почты для указанной коллекции",
+        "/admins/{id}": {
+            "get": {
+                "description": "Возвращает информацию об указанном администраторе по его идентификатору",
                 "consumes": [
                     "application/json"
                 ],
@@ -1012,45 +962,71 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Record Auth"
+                    "Admin"
                 ],
-                "summary": "Подтверждение изменения электронной почты",
+                "summary": "Просмотр администратора",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Идентификатор коллекции",
-                        "name": "collection",
+                        "description": "Идентификатор администратора",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Подтверждение изменения электронной почты успешно"
-                    },
-                    "400": {
-                        "description": "Failed to authenticate.",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/apis.Admin"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Версия администратора (для заголовка If-Match)"
+                            }
                         }
                     },
-                    "404": {
-                        "description": "Not found.",
+                    "400": {
+                        "description": "Failed to authenticate.",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
-            }
-        },
-        "/collections/{collection}/confirm-password-reset": {
-            "post": {
-                "security": [
+            },
+            "delete": {
+                "description": "Удаляет указанного администратора по его идентификатору",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Удаление администратора",
+                "parameters": [
                     {
-                        "Auth": []
+                        "type": "string",
+                        "description": "Идентификатор администратора",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
-                "description": "Подтверждает сброс пароля для указанной коллекции",
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Failed to authenticate.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Обновляет информацию об указанном администраторе по его идентификатору",
                 "consumes": [
                     "application/json"
                 ],
@@ -1058,30 +1034,54 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Record Auth"
+                    "Admin"
                 ],
-                "summary": "Подтверждение сброса пароля",
+                "summary": "Обновление администратора",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Идентификатор коллекции",
-                        "name": "collection",
+                        "description": "Идентификатор администратора",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Данные для обновления администратора",
+                        "name": "admin",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apis.AdminUpdateForm"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag просмотренной версии (обновление отклоняется, если администратор был изменен)",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Подтверждение сброса пароля успешно"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Admin"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Новая версия администратора"
+                            }
+                        }
                     },
                     "400": {
-                        "description": "Failed to authenticate.",
+                        "description": "Not found",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Not found.",
+                    "409": {
+                        "description": "The resource was modified by another request.",
                         "schema": {
                             "type": "string"
                         }
@@ -1089,14 +1089,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/collections/{collection}/confirm-verification": {
+        "/analytics/query": {
             "post": {
                 "security": [
                     {
-                        "Auth": []
+                        "AdminAuth": []
                     }
                 ],
-                "description": "Подтверждает верификацию для указанной коллекции",
+                "description": "Executes a single read-only SQL statement against a periodically refreshed snapshot of the app database (not the live file)\nEach admin could execute up to \"quotaQueries\" queries per \"quotaWindow\" (see the analytics settings), including the failed ones",
                 "consumes": [
                     "application/json"
                 ],
@@ -1104,30 +1104,53 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Record Auth"
+                    "Analytics"
                 ],
-                "summary": "Подтверждение верификации",
+                "summary": "Run analytics query",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Идентификатор коллекции",
-                        "name": "collection",
-                        "in": "path",
-                        "required": true
+                        "description": "Query data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apis.AnalyticsQueryRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Подтверждение верификации успешно"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/forms.AnalyticsQueryResult"
+                        },
+                        "headers": {
+                            "X-Quota-Remaining": {
+                                "type": "int",
+                                "description": "The number of queries left in the current quota window"
+                            }
+                        }
                     },
                     "400": {
-                        "description": "Failed to authenticate.",
+                        "description": "Failed to execute the query.",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Not found.",
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "The analytics queries are not enabled.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "The analytics query quota was exceeded.",
                         "schema": {
                             "type": "string"
                         }
@@ -1135,45 +1158,77 @@ const docTemplate = `{
                 }
             }
         },
-        "/collections/{collection}/records": {
+        "/api-keys": {
             "get": {
                 "security": [
                     {
-                        "Auth": []
+                        "AdminAuth": []
                     }
                 ],
-                "description": "Возвращает список записей из указанной коллекции",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Returns a paginated api keys list (the plain keys are never returned)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Record"
+                    "ApiKeys"
                 ],
-                "summary": "Получение списка записей",
+                "summary": "List api keys",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page",
+                        "name": "perPage",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "Идентификатор коллекции",
-                        "name": "collection",
-                        "in": "path",
-                        "required": true
+                        "description": "Sort fields",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter expression",
+                        "name": "filter",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Получение списка записей успешно"
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/search.Result"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ApiKey"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
                     },
                     "400": {
-                        "description": "Failed to authenticate.",
+                        "description": "Something went wrong while processing your request.",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Not found.",
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
                         "schema": {
                             "type": "string"
                         }
@@ -1183,10 +1238,10 @@ const docTemplate = `{
             "post": {
                 "security": [
                     {
-                        "Auth": []
+                        "AdminAuth": []
                     }
                 ],
-                "description": "Создает новую запись в указанной коллекции",
+                "description": "Creates a new scoped api key acting as the current admin or as the specified auth record (the plain key is returned only once)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1194,39 +1249,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Record"
+                    "ApiKeys"
                 ],
-                "summary": "Создание записи",
+                "summary": "Create api key",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Идентификатор коллекции",
-                        "name": "collection",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Данные для создания записи",
+                        "description": "Api key data",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/apis.CreateRecordRequest"
+                            "$ref": "#/definitions/forms.ApiKeyCreate"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Создание записи успешно"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/forms.ApiKeyCreateResult"
+                        }
                     },
                     "400": {
-                        "description": "Failed to authenticate.",
+                        "description": "Failed to create the api key.",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Not found.",
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
                         "schema": {
                             "type": "string"
                         }
@@ -1234,35 +1285,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/collections/{collection}/records/{id}": {
+        "/api-keys/{id}": {
             "get": {
                 "security": [
                     {
-                        "Auth": []
+                        "AdminAuth": []
                     }
                 ],
-                "description": "Возвращает информацию о указанной записи из указанной коллекции",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Returns a single api key by its id",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Record"
+                    "ApiKeys"
                 ],
-                "summary": "Просмотр записи",
+                "summary": "View api key",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Идентификатор коллекции",
-                        "name": "collection",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Идентификатор записи",
+                        "description": "Api key id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1270,16 +1311,19 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Просмотр записи успешен"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiKey"
+                        }
                     },
-                    "400": {
-                        "description": "Failed to authenticate.",
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Not found.",
+                        "description": "The requested resource wasn't found.",
                         "schema": {
                             "type": "string"
                         }
@@ -1289,61 +1333,56 @@ const docTemplate = `{
             "delete": {
                 "security": [
                     {
-                        "Auth": []
+                        "AdminAuth": []
                     }
                 ],
-                "description": "Удаляет указанную запись из указанной коллекции",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Deletes the api key with the specified id",
                 "tags": [
-                    "Record"
+                    "ApiKeys"
                 ],
-                "summary": "Удаление записи",
+                "summary": "Revoke api key",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Идентификатор коллекции",
-                        "name": "collection",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Идентификатор записи",
+                        "description": "Api key id",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Удаление записи успешно"
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
-                        "description": "Failed to authenticate.",
+                        "description": "Failed to revoke the api key.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Not found.",
+                        "description": "The requested resource wasn't found.",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/apply": {
+            "post": {
                 "security": [
                     {
-                        "Auth": []
+                        "AdminAuth": []
                     }
                 ],
-                "description": "Обновляет информацию о указанной записи в указанной коллекции",
+                "description": "Приводит коллекции, администраторов и указанные настройки к желаемому состоянию из документа и возвращает план изменений. С параметром dryRun изменения не применяются",
                 "consumes": [
                     "application/json"
                 ],
@@ -1351,46 +1390,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Record"
+                    "Settings"
                 ],
-                "summary": "Обновление записи",
+                "summary": "Применение декларативного состояния",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Идентификатор коллекции",
-                        "name": "collection",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Идентификатор записи",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "boolean",
+                        "description": "Только построить план без применения",
+                        "name": "dryRun",
+                        "in": "query"
                     },
                     {
-                        "description": "Данные для обновления записи",
+                        "description": "Документ желаемого состояния",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/apis.CreateRecordRequest"
+                            "$ref": "#/definitions/forms.DeclarativeApply"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Обновление записи успешно"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/apis.ApplyResult"
+                        }
                     },
                     "400": {
-                        "description": "Failed to authenticate.",
+                        "description": "Failed to apply the declarative state.",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Not found.",
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
                         "schema": {
                             "type": "string"
                         }
@@ -1398,61 +1432,36 @@ const docTemplate = `{
                 }
             }
         },
-        "/collections/{collection}/records/{id}/external-auths": {
+        "/apply/plan": {
             "get": {
                 "security": [
                     {
-                        "Auth": []
+                        "AdminAuth": []
                     }
                 ],
-                "description": "Возвращает список внешних аутентификаций для указанной записи в указанной коллекции",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Возвращает план изменений между последним примененным декларативным документом и текущим состоянием приложения (изменения, сделанные в обход документа)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Record Auth"
+                    "Settings"
                 ],
-                "summary": "Получение внешних аутентификаций записи",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Идентификатор коллекции",
-                        "name": "collection",
-                        "in": "path",
-                        "required": true
+                "summary": "Обнаружение дрейфа состояния",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/apis.ApplyResult"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "Идентификатор записи",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "array",
-                                "items": {
-                                    "$ref": "#/definitions/apis.ExternalAuthResponse"
-                                }
-                            }
-                        }
-                    },
-                    "400": {
-                        "description": "Failed to authenticate.",
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Not found.",
+                        "description": "No declarative state has been applied yet.",
                         "schema": {
                             "type": "string"
                         }
@@ -1460,59 +1469,77 @@ const docTemplate = `{
                 }
             }
         },
-        "/collections/{collection}/records/{id}/external-auths/{provider}": {
-            "delete": {
+        "/approvals": {
+            "get": {
                 "security": [
                     {
-                        "Auth": []
+                        "AdminAuth": []
                     }
                 ],
-                "description": "Отвязывает указанную внешнюю аутентификацию от указанной записи в указанной коллекции",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Возвращает список запросов на подтверждение чувствительных действий администраторов (по умолчанию сначала новые)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Record Auth"
+                    "Approvals"
                 ],
-                "summary": "Отвязывание внешней аутентификации",
+                "summary": "Список запросов на подтверждение",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Идентификатор коллекции",
-                        "name": "collection",
-                        "in": "path",
-                        "required": true
+                        "type": "integer",
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество элементов на странице",
+                        "name": "perPage",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Идентификатор записи",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Сортировка",
+                        "name": "sort",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Провайдер внешней аутентификации",
-                        "name": "provider",
-                        "in": "path",
-                        "required": true
+                        "description": "Фильтр",
+                        "name": "filter",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Отвязывание внешней аутентификации успешно"
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.SearchResult"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Approval"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
                     },
                     "400": {
-                        "description": "Failed to authenticate.",
+                        "description": "Something went wrong while processing your request.",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Not found.",
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
                         "schema": {
                             "type": "string"
                         }
@@ -1520,45 +1547,45 @@ const docTemplate = `{
                 }
             }
         },
-        "/collections/{collection}/request-email-change": {
-            "post": {
+        "/approvals/{id}": {
+            "get": {
                 "security": [
                     {
-                        "Auth": []
+                        "AdminAuth": []
                     }
                 ],
-                "description": "Отправляет запрос на изменение электронной почты для указанной коллекции",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Возвращает запрос на подтверждение по его ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Record Auth"
+                    "Approvals"
                 ],
-                "summary": "Запрос изменения электронной почты",
+                "summary": "Запрос на подтверждение",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Идентификатор коллекции",
-                        "name": "collection",
+                        "description": "ID запроса на подтверждение",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Запрос изменения электронной почты успешен"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Approval"
+                        }
                     },
-                    "400": {
-                        "description": "Failed to authenticate.",
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Not found.",
+                        "description": "The requested resource wasn't found.",
                         "schema": {
                             "type": "string"
                         }
@@ -1566,45 +1593,57 @@ const docTemplate = `{
                 }
             }
         },
-        "/collections/{collection}/request-password-reset": {
+        "/approvals/{id}/approve": {
             "post": {
                 "security": [
                     {
-                        "Auth": []
+                        "AdminAuth": []
                     }
                 ],
-                "description": "Отправляет запрос на сброс пароля для указанной коллекции",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Подтверждает и выполняет отложенное действие от имени запросившего администратора. Подтверждающий администратор должен отличаться от запросившего",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Record Auth"
+                    "Approvals"
                 ],
-                "summary": "Запрос сброса пароля",
+                "summary": "Подтверждение запроса",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Идентификатор коллекции",
-                        "name": "collection",
+                        "description": "ID запроса на подтверждение",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Запрос сброса пароля успешнен"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Approval"
+                        }
                     },
                     "400": {
-                        "description": "Failed to authenticate.",
+                        "description": "The approval request is no longer pending.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "The approval request must be confirmed by a different admin.",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Not found.",
+                        "description": "The requested resource wasn't found.",
                         "schema": {
                             "type": "string"
                         }
@@ -1612,45 +1651,51 @@ const docTemplate = `{
                 }
             }
         },
-        "/collections/{collection}/request-verification": {
+        "/approvals/{id}/reject": {
             "post": {
                 "security": [
                     {
-                        "Auth": []
+                        "AdminAuth": []
                     }
                 ],
-                "description": "Отправляет запрос на верификацию для указанной коллекции",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Отклоняет отложенное действие (запросивший администратор также может отменить свой запрос)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Record Auth"
+                    "Approvals"
                 ],
-                "summary": "Запрос верификации",
+                "summary": "Отклонение запроса",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Идентификатор коллекции",
-                        "name": "collection",
+                        "description": "ID запроса на подтверждение",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Запрос верификации успешен"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Approval"
+                        }
                     },
                     "400": {
-                        "description": "Failed to authenticate.",
+                        "description": "The approval request is no longer pending.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Not found.",
+                        "description": "The requested resource wasn't found.",
                         "schema": {
                             "type": "string"
                         }
@@ -1658,29 +1703,29 @@ const docTemplate = `{
                 }
             }
         },
-        "/files/token": {
-            "post": {
+        "/backups": {
+            "get": {
                 "security": [
                     {
                         "AdminAuth": []
-                    },
-                    {
-                        "RecordAuth": []
                     }
                 ],
-                "description": "Генерирует токен для доступа к файлу",
+                "description": "Возвращает список доступных резервных копий",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Files"
+                    "Backups"
                 ],
-                "summary": "Генерировать токен файла",
+                "summary": "Получение списка резервных копий",
                 "responses": {
                     "200": {
-                        "description": "Successful operation",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/apis.BackupFileInfo"
+                            }
                         }
                     },
                     "400": {
@@ -1690,149 +1735,157 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/files/{collection}/{recordId}/{filename}": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "AdminAuth": []
-                    },
-                    {
-                        "RecordAuth": []
                     }
                 ],
-                "description": "Загружает файл",
+                "description": "Создает новую резервную копию",
+                "consumes": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Files"
+                    "Backups"
                 ],
-                "summary": "Загрузить файл",
+                "summary": "Создание резервной копии",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Идентификатор коллекции",
-                        "name": "collection",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Идентификатор записи",
-                        "name": "recordId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Имя файла",
-                        "name": "filename",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Токен доступа к файлу",
-                        "name": "token",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Размер эскиза (если применимо)",
-                        "name": "thumb",
-                        "in": "query"
+                        "description": "Данные для создания резервной копии",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apis.BackupCreateRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Файл загружен"
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Failed to authenticate.",
                         "schema": {
                             "type": "string"
                         }
+                    }
+                }
+            }
+        },
+        "/backups/rotate-key": {
+            "post": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Перешифровывает все зашифрованные резервные копии новым ключом и сохраняет его в настройках",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Backups"
+                ],
+                "summary": "Смена ключа шифрования резервных копий",
+                "parameters": [
+                    {
+                        "description": "Новый ключ шифрования (32 символа)",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apis.BackupRotateKeyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
                     },
-                    "403": {
-                        "description": "Not exists.",
+                    "400": {
+                        "description": "Failed to rotate the backups encryption key.",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Not found.",
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
-            },
-            "head": {
+            }
+        },
+        "/backups/status": {
+            "get": {
                 "security": [
                     {
                         "AdminAuth": []
-                    },
-                    {
-                        "RecordAuth": []
                     }
                 ],
-                "description": "Загружает файл",
-                "tags": [
-                    "Files"
+                "description": "Возвращает прогресс последней запущенной операции создания или восстановления резервной копии (статус \"idle\", если операции еще не запускались). Изменения прогресса также отправляются администраторам, подписанным на realtime топик PB_BACKUPS",
+                "produces": [
+                    "application/json"
                 ],
-                "summary": "Загрузить файл",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Идентификатор коллекции",
-                        "name": "collection",
-                        "in": "path",
-                        "required": true
+                "tags": [
+                    "Backups"
+                ],
+                "summary": "Статус резервного копирования",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/core.BackupProgress"
+                        }
                     },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/backups/upload": {
+            "post": {
+                "security": [
                     {
-                        "type": "string",
-                        "description": "Идентификатор записи",
-                        "name": "recordId",
-                        "in": "path",
-                        "required": true
-                    },
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Загружает созданный вне приложения zip архив резервной копии (в том числе зашифрованный) в хранилище резервных копий. Архив сохраняется под исходным именем файла",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "tags": [
+                    "Backups"
+                ],
+                "summary": "Загрузка архива резервной копии",
+                "parameters": [
                     {
-                        "type": "string",
-                        "description": "Имя файла",
-                        "name": "filename",
-                        "in": "path",
+                        "type": "file",
+                        "description": "Zip архив резервной копии",
+                        "name": "file",
+                        "in": "formData",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Токен доступа к файлу",
-                        "name": "token",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Размер эскиза (если применимо)",
-                        "name": "thumb",
-                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Файл загружен"
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
-                        "description": "Failed to authenticate.",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "403": {
-                        "description": "Not exists.",
+                        "description": "Failed to upload backup.",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Not found.",
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
                         "schema": {
                             "type": "string"
                         }
@@ -1840,104 +1893,103 @@ const docTemplate = `{
                 }
             }
         },
-        "/realtime": {
+        "/backups/{key}": {
             "get": {
                 "security": [
                     {
                         "AdminAuth": []
                     }
                 ],
-                "description": "Устанавливает соединение в реальном времени",
+                "description": "Загружает резервную копию по указанному ключу",
                 "tags": [
-                    "Realtime"
+                    "Backups"
+                ],
+                "summary": "Загрузка резервной копии",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ключ резервной копии",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Токен доступа",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
                 ],
-                "summary": "Установить соединение в реальном времени",
                 "responses": {
                     "200": {
-                        "description": "Соединение установлено"
+                        "description": "OK"
                     },
                     "400": {
-                        "description": "Failed to authenticate.",
+                        "description": "Not exists.",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
             },
-            "post": {
+            "delete": {
                 "security": [
                     {
                         "AdminAuth": []
                     }
                 ],
-                "description": "Устанавливает подписки для клиента в реальном времени",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Удаляет резервную копию по указанному ключу",
                 "tags": [
-                    "Realtime"
+                    "Backups"
                 ],
-                "summary": "Установить подписки в реальном времени",
+                "summary": "Удаление резервной копии",
                 "parameters": [
                     {
-                        "description": "Данные подписок",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/apis.RealtimeSubscribeForm"
-                        }
+                        "type": "string",
+                        "description": "Ключ резервной копии",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "204": {
-                        "description": "Подписки успешно установлены"
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Failed to authenticate.",
                         "schema": {
                             "type": "string"
                         }
-                    },
-                    "403": {
-                        "description": "Not exists.",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "404": {
-                        "description": "Not found.",
-                        "schema": {
-                            "type": "string"
-                        }
                     }
                 }
             }
         },
-        "/settings": {
-            "get": {
+        "/backups/{key}/restore": {
+            "post": {
                 "security": [
                     {
                         "AdminAuth": []
                     }
                 ],
-                "description": "Возвращает список всех настроек",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Запускает процесс восстановления резервной копии по указанному ключу",
                 "tags": [
-                    "Settings"
+                    "Backups"
+                ],
+                "summary": "Восстановление резервной копии",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ключ резервной копии",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Получение списка настроек",
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/apis.Settings"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Failed to authenticate.",
@@ -1946,14 +1998,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/batch": {
+            "post": {
                 "security": [
                     {
-                        "AdminAuth": []
+                        "Auth": []
                     }
                 ],
-                "description": "Обновляет указанные настройки",
+                "description": "Выполняет операции создания, изменения и удаления записей разных коллекций в одной транзакции с проверкой правил доступа для каждой операции (при ошибке любой операции все изменения откатываются, а ответ содержит результаты выполненных операций и ошибку последней)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1961,41 +2015,51 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Settings"
+                    "Record"
                 ],
-                "summary": "Обновление настроек",
+                "summary": "Пакетное выполнение операций",
                 "parameters": [
                     {
-                        "description": "Данные для обновления настроек",
+                        "description": "Список операций",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/apis.UpdateSettingsRequest"
+                            "$ref": "#/definitions/apis.BatchRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Обновление настроек успешно"
+                        "description": "Результаты в порядке операций",
+                        "schema": {
+                            "$ref": "#/definitions/apis.BatchResponse"
+                        }
                     },
                     "400": {
-                        "description": "Failed to authenticate.",
+                        "description": "Результаты выполненных операций (изменения откачены)",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/apis.BatchResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Результаты выполненных операций (изменения откачены)",
+                        "schema": {
+                            "$ref": "#/definitions/apis.BatchResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Результаты выполненных операций (изменения откачены)",
+                        "schema": {
+                            "$ref": "#/definitions/apis.BatchResponse"
                         }
                     }
                 }
             }
         },
-        "/settings/apple/generate-client-secret": {
+        "/billing/stripe/webhook": {
             "post": {
-                "security": [
-                    {
-                        "AdminAuth": []
-                    }
-                ],
-                "description": "Генерирует секретный ключ для использования при авторизации Apple",
+                "description": "Receives the signed Stripe webhook events and updates the subscription state of the billing Stripe collection auth records\n(handles \"checkout.session.completed\" and \"customer.subscription.*\" events; the rest are skipped)",
                 "consumes": [
                     "application/json"
                 ],
@@ -2003,15 +2067,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Settings"
+                    "Billing"
+                ],
+                "summary": "Stripe webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stripe event signature",
+                        "name": "Stripe-Signature",
+                        "in": "header",
+                        "required": true
+                    }
                 ],
-                "summary": "Генерация секретного ключа для авторизации Apple",
                 "responses": {
                     "200": {
-                        "description": "Генерация секретного ключа для авторизации Apple успешно"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/forms.StripeWebhookResult"
+                        }
                     },
                     "400": {
-                        "description": "Failed to authenticate.",
+                        "description": "Invalid Stripe webhook signature.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "The requested resource wasn't found.",
                         "schema": {
                             "type": "string"
                         }
@@ -2019,41 +2101,77 @@ const docTemplate = `{
                 }
             }
         },
-        "/settings/test/email": {
-            "post": {
+        "/billing/usage": {
+            "get": {
                 "security": [
                     {
                         "AdminAuth": []
                     }
                 ],
-                "description": "Проверяет настройки для отправки электронной почты",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Returns a paginated list with the monthly billable events counters",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Settings"
+                    "Billing"
                 ],
-                "summary": "Тестирование настроек для электронной почты",
+                "summary": "List usage counters",
                 "parameters": [
                     {
-                        "description": "Данные для тестирования настроек электронной почты",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/apis.TestEmailSettingsRequest"
-                        }
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page",
+                        "name": "perPage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort fields",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter expression",
+                        "name": "filter",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Тестирование настроек для электронной почты успешно"
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/search.Result"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Usage"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
                     },
                     "400": {
-                        "description": "Failed to authenticate.",
+                        "description": "Something went wrong while processing your request.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
                         "schema": {
                             "type": "string"
                         }
@@ -2061,14 +2179,123 @@ const docTemplate = `{
                 }
             }
         },
-        "/settings/test/s3": {
-            "post": {
+        "/billing/usage/me": {
+            "get": {
                 "security": [
                     {
-                        "AdminAuth": []
+                        "RecordAuth": []
                     }
                 ],
-                "description": "Проверяет настройки для хранилища S3",
+                "description": "Returns the billable events totals of the authorized record for the specified month (default to the current one)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Billing"
+                ],
+                "summary": "View own usage",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Usage month in YYYY-MM format",
+                        "name": "period",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.UsageSummary"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid usage period.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires valid record authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Возвращает список коллекций с возможностью фильтрации и сортировки",
+                "tags": [
+                    "Collections"
+                ],
+                "summary": "Получить список коллекций",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID коллекции",
+                        "name": "id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Дата создания коллекции в формате ISO8601",
+                        "name": "created",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Дата обновления коллекции в формате ISO8601",
+                        "name": "updated",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Название коллекции",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Системная коллекция",
+                        "name": "system",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Тип коллекции",
+                        "name": "type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/apis.SearchResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to authenticate.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Создает новую коллекцию",
                 "consumes": [
                     "application/json"
                 ],
@@ -2076,23 +2303,173 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Settings"
+                    "Collections"
                 ],
-                "summary": "Тестирование настроек для хранилища S3",
+                "summary": "Создать коллекцию",
                 "parameters": [
                     {
-                        "description": "Данные для тестирования настроек S3",
+                        "description": "Данные для создания коллекции",
+                        "name": "collection",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apis.CollectionCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Collection"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to authenticate.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/diff": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Возвращает изменения на уровне полей между переданными коллекциями и текущей схемой базы данных, не применяя их",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Collections"
+                ],
+                "summary": "Сравнить коллекции",
+                "parameters": [
+                    {
+                        "description": "Коллекции для сравнения",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/apis.TestS3SettingsRequest"
+                            "$ref": "#/definitions/apis.CollectionsImportRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Тестирование настроек для хранилища S3 успешно"
+                        "description": "Список изменений",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/forms.CollectionDiff"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to resolve the collections diff.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/export": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Возвращает все коллекции (включая индексы и правила доступа) в формате, принимаемом /collections/import",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Collections"
+                ],
+                "summary": "Экспортировать коллекции",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Тип коллекции (base, auth, view)",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Префикс названия коллекции",
+                        "name": "prefix",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Экспортированные коллекции",
+                        "schema": {
+                            "$ref": "#/definitions/apis.CollectionsExport"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to export the collections.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/import": {
+            "post": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Импортирует коллекции из переданных данных. С параметром dryRun импорт полностью выполняется и проверяется, но откатывается, а в ответе возвращается план изменений",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Collections"
+                ],
+                "summary": "Импортировать коллекции",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Только проверить импорт и построить план без применения",
+                        "name": "dryRun",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Данные для импорта коллекций",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apis.CollectionsImportRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "План изменений (dryRun)",
+                        "schema": {
+                            "$ref": "#/definitions/forms.CollectionsImportPlan"
+                        }
+                    },
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Failed to authenticate.",
@@ -2102,761 +2479,13060 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "apis.Admin": {
-            "type": "object",
-            "properties": {
-                "avatar": {
-                    "type": "integer"
-                },
-                "created": {
-                    "type": "object"
-                },
-                "email": {
+        },
+        "/collections/{collection}": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Возвращает информацию о коллекции по ее имени или ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Collections"
+                ],
+                "summary": "Просмотреть коллекцию",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Имя или ID коллекции",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Collection"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Версия коллекции (для заголовка If-Match)"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to authenticate.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Удаляет коллекцию по ее имени или ID",
+                "tags": [
+                    "Collections"
+                ],
+                "summary": "Удалить коллекцию",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Имя или ID коллекции",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Обновляет информацию о коллекции по ее имени или ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Collections"
+                ],
+                "summary": "Обновить коллекцию",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Имя или ID коллекции",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные для обновления коллекции",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apis.CollectionCreateRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag просмотренной версии (обновление отклоняется, если коллекция была изменена)",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Collection"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Новая версия коллекции"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to authenticate.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "The resource was modified by another request.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{collection}/auth-methods": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Возвращает доступные методы аутентификации для указанной коллекции",
+                "tags": [
+                    "Record Auth"
+                ],
+                "summary": "Получить методы аутентификации",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор коллекции",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "методы аутентификации успешно получены"
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{collection}/auth-refresh": {
+            "post": {
+                "security": [
+                    {
+                        "Auth": []
+                    }
+                ],
+                "description": "Обновляет данные аутентификации для указанной записи",
+                "tags": [
+                    "Record Auth"
+                ],
+                "summary": "Обновить данные аутентификации",
+                "responses": {
+                    "200": {
+                        "description": "данные аутентификации успешно обновленны"
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{collection}/auth-with-oauth2": {
+            "post": {
+                "security": [
+                    {
+                        "Auth": []
+                    }
+                ],
+                "description": "Выполняет аутентификацию с использованием протокола OAuth2 для указанной коллекции",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Record Auth"
+                ],
+                "summary": "Аутентификация с использованием OAuth2",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор коллекции",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "аутентификация с использованием OAuth2 успешна"
+                    },
+                    "400": {
+                        "description": "Failed to authenticate.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{collection}/auth-with-password": {
+            "post": {
+                "security": [
+                    {
+                        "Auth": []
+                    }
+                ],
+                "description": "Выполняет аутентификацию с использованием пароля для указанной коллекции",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Record Auth"
+                ],
+                "summary": "Аутентификация с использованием пароля",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор коллекции",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Аутентификация с использованием пароля успешна"
+                    },
+                    "400": {
+                        "description": "Failed to authenticate.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{collection}/confirm-email-change": {
+            "post": {
+                "security": [
+                    {
+                        "Auth": []
+                    }
+                ],
+                "description": "Подтверждает изменение электронной почты для указанной коллекции",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Record Auth"
+                ],
+                "summary": "Подтверждение изменения электронной почты",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор коллекции",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Подтверждение изменения электронной почты успешно"
+                    },
+                    "400": {
+                        "description": "Failed to authenticate.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{collection}/confirm-password-reset": {
+            "post": {
+                "security": [
+                    {
+                        "Auth": []
+                    }
+                ],
+                "description": "Подтверждает сброс пароля для указанной коллекции",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Record Auth"
+                ],
+                "summary": "Подтверждение сброса пароля",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор коллекции",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Подтверждение сброса пароля успешно"
+                    },
+                    "400": {
+                        "description": "Failed to authenticate.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{collection}/confirm-verification": {
+            "post": {
+                "security": [
+                    {
+                        "Auth": []
+                    }
+                ],
+                "description": "Подтверждает верификацию для указанной коллекции",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Record Auth"
+                ],
+                "summary": "Подтверждение верификации",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор коллекции",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Подтверждение верификации успешно"
+                    },
+                    "400": {
+                        "description": "Failed to authenticate.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{collection}/queries": {
+            "get": {
+                "security": [
+                    {
+                        "Auth": []
+                    }
+                ],
+                "description": "Возвращает сохраненные запросы коллекции, доступные текущему клиенту (общие и собственные; администраторам - все). Требуется доступ к списку записей (ListRule)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Record"
+                ],
+                "summary": "Список сохраненных запросов",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор коллекции",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.SavedQuery"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Only admins can perform this action.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    },
+                    {
+                        "RecordAuth": []
+                    }
+                ],
+                "description": "Сохраняет именованный набор параметров filter/sort/fields/expand для списка записей коллекции. Владельцем запроса становится текущий администратор/запись авторизации\nОбщие (shared) запросы доступны всем, у кого есть доступ к списку записей (ListRule)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Record"
+                ],
+                "summary": "Создание сохраненного запроса",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор коллекции",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Параметры сохраненного запроса",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apis.SavedQueryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SavedQuery"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to save the query.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin or record authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Only admins can perform this action.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{collection}/queries/{name}": {
+            "get": {
+                "security": [
+                    {
+                        "Auth": []
+                    }
+                ],
+                "description": "Возвращает сохраненный запрос коллекции по его имени",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Record"
+                ],
+                "summary": "Сохраненный запрос",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор коллекции",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя сохраненного запроса",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SavedQuery"
+                        }
+                    },
+                    "403": {
+                        "description": "Only admins can perform this action.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    },
+                    {
+                        "RecordAuth": []
+                    }
+                ],
+                "description": "Удаляет сохраненный запрос коллекции. Доступно владельцу запроса и администраторам",
+                "tags": [
+                    "Record"
+                ],
+                "summary": "Удаление сохраненного запроса",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор коллекции",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя сохраненного запроса",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Failed to delete the query.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin or record authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Only admins and the query owner can perform this action.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    },
+                    {
+                        "RecordAuth": []
+                    }
+                ],
+                "description": "Обновляет сохраненный запрос коллекции. Доступно владельцу запроса и администраторам",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Record"
+                ],
+                "summary": "Изменение сохраненного запроса",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор коллекции",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя сохраненного запроса",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Параметры сохраненного запроса",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apis.SavedQueryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SavedQuery"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to save the query.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin or record authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Only admins and the query owner can perform this action.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{collection}/queries/{name}/run": {
+            "get": {
+                "security": [
+                    {
+                        "Auth": []
+                    }
+                ],
+                "description": "Возвращает список записей коллекции с параметрами сохраненного запроса. Фильтр из параметров запроса объединяется с сохраненным фильтром (AND), а sort/fields/expand из параметров запроса заменяют сохраненные значения\nПравило доступа к списку записей (ListRule) применяется как и для обычного списка",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Record"
+                ],
+                "summary": "Выполнение сохраненного запроса",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор коллекции",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя сохраненного запроса",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество записей на странице",
+                        "name": "perPage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Дополнительный фильтр записей",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Сортировка записей",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Возвращаемые поля",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Раскрываемые связи",
+                        "name": "expand",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/search.Result"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter parameters.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Only admins can perform this action.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{collection}/records": {
+            "get": {
+                "security": [
+                    {
+                        "Auth": []
+                    }
+                ],
+                "description": "Возвращает постраничный список записей из указанной коллекции с учетом правила доступа к списку",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Record"
+                ],
+                "summary": "Получение списка записей",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор коллекции",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер страницы (по умолчанию 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество записей на странице (по умолчанию 30)",
+                        "name": "perPage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Сортировка (например -created,id)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Фильтр записей",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Не подсчитывать общее количество записей (totalItems и totalPages будут равны -1)",
+                        "name": "skipTotal",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Использовать кэшированное (приблизительное) общее количество записей",
+                        "name": "approxTotal",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Полнотекстовый поиск по индексируемым полям коллекции (по умолчанию результаты сортируются по релевантности)",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Поиск ближайших записей в формате {geoPointField}:{lat},{lng} (по умолчанию результаты сортируются по расстоянию)",
+                        "name": "near",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Максимальное расстояние в метрах от точки near",
+                        "name": "within",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Связи для раскрытия (через запятую)",
+                        "name": "expand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Вернуть объяснение правила доступа вместо выполнения действия (только для админов)",
+                        "name": "explainRules",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Запись авторизации для объяснения правила в формате {collection}:{id}",
+                        "name": "explainAuth",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Язык локализованных полей (например de-AT, с учетом цепочки резервных языков)",
+                        "name": "locale",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.SearchResult"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Record"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "Cache-Control": {
+                                "type": "string",
+                                "description": "Подсказки кэширования (если для коллекции заданы настройки кэширования)"
+                            },
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT или MISS (при включенном серверном кэше для гостевых запросов)"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter parameters.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Only admins can perform this action.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Auth": []
+                    }
+                ],
+                "description": "Создает новую запись в указанной коллекции",
+                "consumes": [
+                    "application/json",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Record"
+                ],
+                "summary": "Создание записи",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор коллекции",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные для создания записи",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apis.CreateRecordRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Связи для раскрытия (через запятую)",
+                        "name": "expand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности для безопасного повтора запроса",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Record"
+                        },
+                        "headers": {
+                            "Idempotent-Replayed": {
+                                "type": "string",
+                                "description": "true, если ответ является повтором сохраненного ответа"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to create record.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Only admins can perform this action.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "A request with the same Idempotency-Key is still in progress.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "The Idempotency-Key was already used with a different request.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{collection}/records/import": {
+            "post": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Создает (или обновляет при совпадении id) записи коллекции в одной транзакции. Перед валидацией к каждой строке последовательно применяются шаги преобразования полей:\ntrim, lowercase, date (разбор даты по format в формате Go, например \"02.01.2006\"), lookup (замена значения на id записи коллекции collection с lookupField равным значению) и default (value для пустых полей)\nПри ошибке любой строки все изменения откатываются, а ошибка возвращается по ключу rows.{индекс строки}",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Record"
+                ],
+                "summary": "Импорт записей",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор коллекции",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Импортируемые строки и шаги преобразования",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apis.RecordsImportRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Record"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to import the records.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Missing collection context.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{collection}/records/sample": {
+            "get": {
+                "security": [
+                    {
+                        "Auth": []
+                    }
+                ],
+                "description": "Возвращает случайную (или стратифицированную по значению поля) выборку записей из указанной коллекции с учетом правила доступа к списку",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Record"
+                ],
+                "summary": "Выборка записей",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор коллекции",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Размер выборки (по умолчанию 100, максимум 1000)",
+                        "name": "n",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Стратегия выборки: random или stratified:{field}",
+                        "name": "strategy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Фильтр записей",
+                        "name": "filter",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/apis.RecordsSampleResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid sampling strategy.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Only admins can perform this action.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{collection}/records/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Auth": []
+                    }
+                ],
+                "description": "Возвращает информацию о указанной записи из указанной коллекции",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Record"
+                ],
+                "summary": "Просмотр записи",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор коллекции",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Идентификатор записи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Связи для раскрытия (через запятую)",
+                        "name": "expand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Вернуть объяснение правила доступа вместо выполнения действия (только для админов)",
+                        "name": "explainRules",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Запись авторизации для объяснения правила в формате {collection}:{id}",
+                        "name": "explainAuth",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Язык локализованных полей (например de-AT, с учетом цепочки резервных языков)",
+                        "name": "locale",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Record"
+                        },
+                        "headers": {
+                            "Cache-Control": {
+                                "type": "string",
+                                "description": "Подсказки кэширования (если для коллекции заданы настройки кэширования)"
+                            },
+                            "ETag": {
+                                "type": "string",
+                                "description": "Версия записи (для заголовка If-Match)"
+                            },
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT или MISS (при включенном серверном кэше для гостевых запросов)"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid locale.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Only admins can perform this action.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Auth": []
+                    }
+                ],
+                "description": "Удаляет указанную запись из указанной коллекции (или перемещает ее в корзину, если для коллекции включено мягкое удаление)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Record"
+                ],
+                "summary": "Удаление записи",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор коллекции",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Идентификатор записи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Вернуть объяснение правила доступа вместо выполнения действия (только для админов)",
+                        "name": "explainRules",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Запись авторизации для объяснения правила в формате {collection}:{id}",
+                        "name": "explainAuth",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Failed to delete record. Make sure that the record is not part of a required relation reference.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Only admins can perform this action.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "Auth": []
+                    }
+                ],
+                "description": "Обновляет информацию о указанной записи в указанной коллекции",
+                "consumes": [
+                    "application/json",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Record"
+                ],
+                "summary": "Обновление записи",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор коллекции",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Идентификатор записи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные для обновления записи",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apis.CreateRecordRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Связи для раскрытия (через запятую)",
+                        "name": "expand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Вернуть объяснение правила доступа вместо выполнения действия (только для админов)",
+                        "name": "explainRules",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Запись авторизации для объяснения правила в формате {collection}:{id}",
+                        "name": "explainAuth",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag просмотренной версии (обновление отклоняется, если запись была изменена)",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Record"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Новая версия записи"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to update record.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Only admins can perform this action.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "The resource was modified by another request.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{collection}/records/{id}/external-auths": {
+            "get": {
+                "security": [
+                    {
+                        "Auth": []
+                    }
+                ],
+                "description": "Возвращает список внешних аутентификаций для указанной записи в указанной коллекции",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Record Auth"
+                ],
+                "summary": "Получение внешних аутентификаций записи",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор коллекции",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Идентификатор записи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/apis.ExternalAuthResponse"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to authenticate.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{collection}/records/{id}/external-auths/{provider}": {
+            "delete": {
+                "security": [
+                    {
+                        "Auth": []
+                    }
+                ],
+                "description": "Отвязывает указанную внешнюю аутентификацию от указанной записи в указанной коллекции",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Record Auth"
+                ],
+                "summary": "Отвязывание внешней аутентификации",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор коллекции",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Идентификатор записи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Провайдер внешней аутентификации",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Отвязывание внешней аутентификации успешно"
+                    },
+                    "400": {
+                        "description": "Failed to authenticate.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{collection}/records/{id}/increment": {
+            "post": {
+                "security": [
+                    {
+                        "Auth": []
+                    }
+                ],
+                "description": "Увеличивает (или уменьшает при отрицательном amount) значения числовых полей записи на стороне сервера одним UPDATE запросом, поэтому параллельные запросы не перезаписывают друг друга (лайки, остатки и т.п.)\nИтоговое значение ограничивается min/max запроса и min/max опциями поля схемы. Доступ проверяется правилом обновления коллекции (@request.data содержит ожидаемые итоговые значения полей)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Record"
+                ],
+                "summary": "Атомарное увеличение числовых полей записи",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор коллекции",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Идентификатор записи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Поля для увеличения",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apis.RecordIncrementRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Связи для раскрытия (через запятую)",
+                        "name": "expand",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Record"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to increment the record fields.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Only admins can perform this action.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{collection}/records/{id}/lock": {
+            "get": {
+                "security": [
+                    {
+                        "Auth": []
+                    }
+                ],
+                "description": "Возвращает активную (не истекшую) блокировку редактирования записи. Доступно всем, кто может просматривать запись (ViewRule)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Record"
+                ],
+                "summary": "Текущая блокировка записи",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор коллекции",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Идентификатор записи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RecordLock"
+                        }
+                    },
+                    "403": {
+                        "description": "Only admins can perform this action.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "The record is not locked.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    },
+                    {
+                        "RecordAuth": []
+                    }
+                ],
+                "description": "Выдает краткосрочную аренду (lease) на редактирование записи или продлевает уже выданную текущему администратору/записи авторизации. Блокировка рекомендательная (не запрещает обновление записи) и снимается автоматически по истечении срока\nИзменения блокировки доставляются клиентам в реальном времени по подписке \"PB_LOCKS/{collection}/{recordId}\". Требуется доступ к обновлению записи (UpdateRule)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Record"
+                ],
+                "summary": "Блокировка записи для редактирования",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор коллекции",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Идентификатор записи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Длительность аренды в секундах (по умолчанию 60, максимум 600)",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/apis.RecordLockRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RecordLock"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to lock the record.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin or record authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Only admins can perform this action.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "The record is locked by another client.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    },
+                    {
+                        "RecordAuth": []
+                    }
+                ],
+                "description": "Освобождает блокировку редактирования записи. Доступно держателю блокировки и администраторам (принудительное снятие)",
+                "tags": [
+                    "Record"
+                ],
+                "summary": "Снятие блокировки записи",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор коллекции",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Идентификатор записи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Failed to unlock the record.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin or record authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Only admins and the lock holder can perform this action.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "The record is not locked.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{collection}/records/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "Auth": []
+                    }
+                ],
+                "description": "Восстанавливает мягко удаленную запись из корзины коллекции (доступно тем, кто может удалять записи согласно правилу удаления)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Record"
+                ],
+                "summary": "Восстановление записи",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор коллекции",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Идентификатор записи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Связи для раскрытия (через запятую)",
+                        "name": "expand",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Record"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to restore record.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Only admins can perform this action.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{collection}/records/{id}/revisions": {
+            "get": {
+                "security": [
+                    {
+                        "Auth": []
+                    }
+                ],
+                "description": "Возвращает постраничный список сохраненных версий записи (доступно тем, кто может обновлять запись согласно правилу обновления)\nКаждая версия содержит значения полей записи до соответствующего обновления",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Record"
+                ],
+                "summary": "История версий записи",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор коллекции",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Идентификатор записи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер страницы (по умолчанию 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество версий на странице (по умолчанию 30)",
+                        "name": "perPage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Сортировка (по умолчанию по номеру версии, сначала последние)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Фильтр версий",
+                        "name": "filter",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.SearchResult"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.RecordRevision"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "The collection doesn't have versioning enabled.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Only admins can perform this action.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{collection}/records/{id}/revisions/{rev}/revert": {
+            "post": {
+                "security": [
+                    {
+                        "Auth": []
+                    }
+                ],
+                "description": "Восстанавливает значения полей записи из указанной версии (доступно тем, кто может обновлять запись согласно правилу обновления)\nФайловые поля не откатываются; текущее состояние записи сохраняется как новая версия",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Record"
+                ],
+                "summary": "Откат записи к версии",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор коллекции",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Идентификатор записи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер версии",
+                        "name": "rev",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Связи для раскрытия (через запятую)",
+                        "name": "expand",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Record"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Новая версия записи"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to revert record.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Only admins can perform this action.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{collection}/records/{id}/share": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    },
+                    {
+                        "RecordAuth": []
+                    }
+                ],
+                "description": "Возвращает ACL записи (владелец и пользователи/группы с доступом). Доступно администраторам и владельцам записи",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Record"
+                ],
+                "summary": "Список доступов к записи",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор коллекции",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Идентификатор записи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.RecordShare"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin or record authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Only admins and the record owners can manage the record shares.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    },
+                    {
+                        "RecordAuth": []
+                    }
+                ],
+                "description": "Предоставляет пользователю или группе доступ к записи (view, edit или owner) или изменяет уровень существующего доступа. Группа - это запись, на которую ссылаются поля-связи auth записей ее участников",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Record"
+                ],
+                "summary": "Предоставление доступа к записи",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор коллекции",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Идентификатор записи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные доступа",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apis.RecordShareRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RecordShare"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to share the record.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin or record authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Only admins and the record owners can manage the record shares.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{collection}/records/{id}/share/{shareId}": {
+            "delete": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    },
+                    {
+                        "RecordAuth": []
+                    }
+                ],
+                "description": "Удаляет указанный доступ из ACL записи",
+                "tags": [
+                    "Record"
+                ],
+                "summary": "Отзыв доступа к записи",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор коллекции",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Идентификатор записи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Идентификатор доступа",
+                        "name": "shareId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Failed to delete the record share.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin or record authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Only admins and the record owners can manage the record shares.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{collection}/refresh": {
+            "post": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Перезаполняет таблицу материализованной view коллекции актуальными результатами ее запроса",
+                "tags": [
+                    "Collections"
+                ],
+                "summary": "Обновить материализованное представление",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Имя или ID коллекции",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Failed to refresh the materialized view.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{collection}/request-email-change": {
+            "post": {
+                "security": [
+                    {
+                        "Auth": []
+                    }
+                ],
+                "description": "Отправляет запрос на изменение электронной почты для указанной коллекции",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Record Auth"
+                ],
+                "summary": "Запрос изменения электронной почты",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор коллекции",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Запрос изменения электронной почты успешен"
+                    },
+                    "400": {
+                        "description": "Failed to authenticate.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{collection}/request-password-reset": {
+            "post": {
+                "security": [
+                    {
+                        "Auth": []
+                    }
+                ],
+                "description": "Отправляет запрос на сброс пароля для указанной коллекции",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Record Auth"
+                ],
+                "summary": "Запрос сброса пароля",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор коллекции",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Запрос сброса пароля успешнен"
+                    },
+                    "400": {
+                        "description": "Failed to authenticate.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{collection}/request-verification": {
+            "post": {
+                "security": [
+                    {
+                        "Auth": []
+                    }
+                ],
+                "description": "Отправляет запрос на верификацию для указанной коллекции",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Record Auth"
+                ],
+                "summary": "Запрос верификации",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор коллекции",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Запрос верификации успешен"
+                    },
+                    "400": {
+                        "description": "Failed to authenticate.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{collection}/rules/test": {
+            "post": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Проверяет, разрешают ли правила доступа коллекции указанную операцию для гипотетической записи авторизации (запись не создается)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Record"
+                ],
+                "summary": "Тестирование правил доступа",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор коллекции",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Операция и данные симулируемого запроса",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apis.RulesTestRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/apis.RulesTestResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to test the collection rules.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{collection}/translations": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Возвращает количество переведенных и отсутствующих значений каждого локализованного поля коллекции по языкам",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Record"
+                ],
+                "summary": "Статус переводов коллекции",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор коллекции",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Языки через запятую (по умолчанию поддерживаемые языки приложения)",
+                        "name": "locales",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/apis.TranslationStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid locale.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{collection}/trash": {
+            "get": {
+                "security": [
+                    {
+                        "Auth": []
+                    }
+                ],
+                "description": "Возвращает постраничный список мягко удаленных записей коллекции (доступно тем, кто может удалять записи согласно правилу удаления)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Record"
+                ],
+                "summary": "Корзина коллекции",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор коллекции",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер страницы (по умолчанию 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество записей на странице (по умолчанию 30)",
+                        "name": "perPage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Сортировка (по умолчанию по дате удаления, сначала последние)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Фильтр записей",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Не подсчитывать общее количество записей (totalItems и totalPages будут равны -1)",
+                        "name": "skipTotal",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.SearchResult"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Record"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "The collection doesn't have soft delete enabled.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Only admins can perform this action.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{collection}/validate-data": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Проверяет сохраненные записи коллекции на соответствие текущей схеме (несовпадение типов после старых импортов, ссылки на удаленные записи, недопустимые значения select)\nВозвращает постраничный список найденных проблем с предлагаемыми исправленными значениями (suggestedValue)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Collections"
+                ],
+                "summary": "Проверить данные коллекции",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Имя или ID коллекции",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество проблем на странице",
+                        "name": "perPage",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/search.Result"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.RecordDataProblem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to validate the collection data.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/comments/{collection}/{recordId}": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    },
+                    {
+                        "RecordAuth": []
+                    }
+                ],
+                "description": "Returns a paginated list with the comments of the record thread (sorted by their created date by default).\nAuth records see only the approved comments and their own ones.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "List record comments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection name or id",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Thread record id",
+                        "name": "recordId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page",
+                        "name": "perPage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort fields",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter expression",
+                        "name": "filter",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/search.Result"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Comment"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Something went wrong while processing your request.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "The requested resource wasn't found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "RecordAuth": []
+                    }
+                ],
+                "description": "Adds a new comment (or a reply if parentId is set) to the record thread from the authorized record.\nThe \"@username\" mentions of the author collection records are resolved and stored in the comment mentions list.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "Create record comment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection name or id",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Thread record id",
+                        "name": "recordId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Comment data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/forms.CommentUpsert"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Comment"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to create the comment.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires valid record authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "The requested resource wasn't found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/comments/{collection}/{recordId}/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    },
+                    {
+                        "RecordAuth": []
+                    }
+                ],
+                "description": "Deletes a comment and all its replies (auth records could delete only their own comments)",
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "Delete record comment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection name or id",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Thread record id",
+                        "name": "recordId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comment id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Failed to delete the comment.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin or record authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Only admins and the comment author can perform this action.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "The requested resource wasn't found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "RecordAuth": []
+                    }
+                ],
+                "description": "Updates the message of an own comment (the edited comments of moderated threads are sent back to moderation)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "Update record comment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection name or id",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Thread record id",
+                        "name": "recordId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comment id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Comment data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/forms.CommentUpsert"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Comment"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to update the comment.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires valid record authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Only the comment author can perform this action.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "The requested resource wasn't found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/comments/{collection}/{recordId}/{id}/moderate": {
+            "post": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Changes the moderation status of a comment (only the approved comments are visible to everyone with access to the thread)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "Moderate record comment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection name or id",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Thread record id",
+                        "name": "recordId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comment id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Moderation status",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/forms.CommentModerate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Comment"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to moderate the comment.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "The requested resource wasn't found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/docs": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Возвращает Swagger UI страницу с документацией API",
+                "produces": [
+                    "text/html"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Документация API",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ключ доступа к документации или API ключ с доступом docs (если не используется авторизация администратора)",
+                        "name": "X-API-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "HTML",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token or valid api docs key to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/docs/openapi.json": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Возвращает OpenAPI (Swagger 2.0) спецификацию, дополненную моделями и маршрутами записей каждой доступной коллекции",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Спецификация API",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ключ доступа к документации или API ключ с доступом docs (если не используется авторизация администратора)",
+                        "name": "X-API-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token or valid api docs key to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/docs/openapi.yaml": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Возвращает OpenAPI (Swagger 2.0) спецификацию в формате YAML для генераторов клиентов (openapi-generator, swagger-codegen)",
+                "produces": [
+                    "application/yaml"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Спецификация API в формате YAML",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ключ доступа к документации или API ключ с доступом docs (если не используется авторизация администратора)",
+                        "name": "X-API-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "YAML",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token or valid api docs key to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/export/anonymized-bundle": {
+            "post": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Exports the records of the selected collections (all if empty) anonymized with the specified settings anonymization profile",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Export"
+                ],
+                "summary": "Export anonymized bundle",
+                "parameters": [
+                    {
+                        "description": "Export options",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/forms.AnonymizedBundleExport"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/forms.AnonymizedBundle"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to export the anonymized bundle.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/files/s3-events": {
+            "post": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Registers the objects uploaded directly to the storage bucket as record file values\n(and unregisters the removed ones). The object keys must follow the \"{collectionId}/{recordId}/{filename}\" format.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Files"
+                ],
+                "summary": "Ingest S3 event notifications",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File field name (required if the collection has more than one file field)",
+                        "name": "field",
+                        "in": "query"
+                    },
+                    {
+                        "description": "S3 event notification",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/forms.S3EventsIngest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/forms.S3EventIngestResult"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to process the S3 events.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid S3 events token.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "The S3 events ingestion is disabled.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/files/token": {
+            "post": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    },
+                    {
+                        "RecordAuth": []
+                    }
+                ],
+                "description": "Генерирует токен для доступа к файлу",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Files"
+                ],
+                "summary": "Генерировать токен файла",
+                "responses": {
+                    "200": {
+                        "description": "Successful operation",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to authenticate.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/files/uploads": {
+            "post": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    },
+                    {
+                        "RecordAuth": []
+                    }
+                ],
+                "description": "Creates a new TUS resumable upload for a record file field. The target is specified with the \"collection\", \"recordId\", \"field\" and \"filename\" Upload-Metadata keys.\nRequires access to update the record (UpdateRule). Incomplete uploads are deleted 24 hours after their last received chunk.",
+                "tags": [
+                    "Files"
+                ],
+                "summary": "Create resumable upload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "TUS protocol version (1.0.0)",
+                        "name": "Tus-Resumable",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Total file size in bytes",
+                        "name": "Upload-Length",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated base64 encoded key-value pairs",
+                        "name": "Upload-Metadata",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "The upload url"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to create the upload.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin or record authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Only admins can perform this action.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "Unsupported TUS protocol version.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "options": {
+                "description": "Returns the supported TUS protocol version and extensions",
+                "tags": [
+                    "Files"
+                ],
+                "summary": "Resumable uploads capabilities",
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "headers": {
+                            "Tus-Extension": {
+                                "type": "string",
+                                "description": "Supported TUS protocol extensions"
+                            },
+                            "Tus-Version": {
+                                "type": "string",
+                                "description": "Supported TUS protocol versions"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/files/uploads/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    },
+                    {
+                        "RecordAuth": []
+                    }
+                ],
+                "description": "Terminates the specified upload and deletes its received bytes",
+                "tags": [
+                    "Files"
+                ],
+                "summary": "Delete resumable upload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Upload id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "TUS protocol version (1.0.0)",
+                        "name": "Tus-Resumable",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Failed to delete the upload.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin or record authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "Unsupported TUS protocol version.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "head": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    },
+                    {
+                        "RecordAuth": []
+                    }
+                ],
+                "description": "Returns the number of the already received bytes of the specified upload",
+                "tags": [
+                    "Files"
+                ],
+                "summary": "Resumable upload offset",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Upload id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "TUS protocol version (1.0.0)",
+                        "name": "Tus-Resumable",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "headers": {
+                            "Upload-Length": {
+                                "type": "int",
+                                "description": "Total file size in bytes"
+                            },
+                            "Upload-Offset": {
+                                "type": "int",
+                                "description": "Number of the received bytes"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin or record authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "Unsupported TUS protocol version.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    },
+                    {
+                        "RecordAuth": []
+                    }
+                ],
+                "description": "Appends the request body to the specified upload starting from the Upload-Offset header position.\nOnce all bytes are received the file is attached to the target record file field and the upload is deleted.",
+                "consumes": [
+                    "application/offset+octet-stream"
+                ],
+                "tags": [
+                    "Files"
+                ],
+                "summary": "Upload chunk",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Upload id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "TUS protocol version (1.0.0)",
+                        "name": "Tus-Resumable",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Position of the chunk (must match the number of the already received bytes)",
+                        "name": "Upload-Offset",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "headers": {
+                            "Upload-Offset": {
+                                "type": "int",
+                                "description": "Number of the received bytes"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to attach the uploaded file.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin or record authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "The upload offset doesn't match.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "Unsupported TUS protocol version.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported upload chunk content type.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/files/warm-thumbs": {
+            "post": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Enqueues a background generation of the missing thumbs for the existing image files of the collection",
+                "tags": [
+                    "Files"
+                ],
+                "summary": "Warm up thumbs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection name or id",
+                        "name": "collection",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated thumb sizes (default to all allowed file field thumb sizes)",
+                        "name": "sizes",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Failed to warm up the collection thumbs.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "The requested resource wasn't found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/files/{collection}/{recordId}/{filename}": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    },
+                    {
+                        "RecordAuth": []
+                    }
+                ],
+                "description": "Загружает файл",
+                "tags": [
+                    "Files"
+                ],
+                "summary": "Загрузить файл",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор коллекции",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Идентификатор записи",
+                        "name": "recordId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя файла",
+                        "name": "filename",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Токен доступа к файлу",
+                        "name": "token",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Размер эскиза (если применимо)",
+                        "name": "thumb",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Произвольный размер изображения в формате WxH, WxHt, WxHb или WxHf (не более fileServe.imageTransformMaxDimension)",
+                        "name": "resize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Формат изображения: jpeg, png или gif (по умолчанию формат исходного файла)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Качество jpeg изображения (1-100)",
+                        "name": "quality",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Файл загружен"
+                    },
+                    "400": {
+                        "description": "Failed to authenticate.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Not exists.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "head": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    },
+                    {
+                        "RecordAuth": []
+                    }
+                ],
+                "description": "Загружает файл",
+                "tags": [
+                    "Files"
+                ],
+                "summary": "Загрузить файл",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор коллекции",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Идентификатор записи",
+                        "name": "recordId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя файла",
+                        "name": "filename",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Токен доступа к файлу",
+                        "name": "token",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Размер эскиза (если применимо)",
+                        "name": "thumb",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Произвольный размер изображения в формате WxH, WxHt, WxHb или WxHf (не более fileServe.imageTransformMaxDimension)",
+                        "name": "resize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Формат изображения: jpeg, png или gif (по умолчанию формат исходного файла)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Качество jpeg изображения (1-100)",
+                        "name": "quality",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Файл загружен"
+                    },
+                    "400": {
+                        "description": "Failed to authenticate.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Not exists.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/files/{collection}/{recordId}/{filename}/signed-url": {
+            "get": {
+                "description": "Возвращает ограниченный по времени pre-signed URL для загрузки файла (или его эскиза) напрямую из S3 хранилища в обход приложения\nДля защищенных файлов требуется токен доступа к файлу. Время жизни ссылки задается настройкой fileServe.signedUrlDuration (по умолчанию 5 минут)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Files"
+                ],
+                "summary": "Pre-signed URL файла",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор коллекции",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Идентификатор записи",
+                        "name": "recordId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя файла",
+                        "name": "filename",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Токен доступа к файлу",
+                        "name": "token",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Размер эскиза (если применимо)",
+                        "name": "thumb",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/apis.FileSignedUrlResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "The files storage doesn't support pre-signed urls.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions to access the file resource.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/flags": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Returns a paginated feature flags list",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FeatureFlags"
+                ],
+                "summary": "List feature flags",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page",
+                        "name": "perPage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort fields",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter expression",
+                        "name": "filter",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/search.Result"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.FeatureFlag"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Something went wrong while processing your request.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Creates a new feature flag with its targeting rules",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FeatureFlags"
+                ],
+                "summary": "Create feature flag",
+                "parameters": [
+                    {
+                        "description": "Feature flag data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/forms.FeatureFlagUpsert"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.FeatureFlag"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to create the feature flag.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/flags/evaluate": {
+            "get": {
+                "description": "Evaluates the feature flags for the requesting auth record (or guest) and returns a map with the flag key and its state (unknown keys are evaluated as disabled)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FeatureFlags"
+                ],
+                "summary": "Evaluate feature flags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma separated list of flag keys (all flags if empty)",
+                        "name": "keys",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "boolean"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to load the feature flags.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/flags/{key}": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Returns a single feature flag by its key",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FeatureFlags"
+                ],
+                "summary": "View feature flag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Feature flag key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.FeatureFlag"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "The requested resource wasn't found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Deletes the feature flag with the specified key",
+                "tags": [
+                    "FeatureFlags"
+                ],
+                "summary": "Delete feature flag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Feature flag key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Failed to delete the feature flag.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "The requested resource wasn't found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Updates the feature flag with the specified key",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FeatureFlags"
+                ],
+                "summary": "Update feature flag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Feature flag key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Feature flag data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/forms.FeatureFlagUpsert"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.FeatureFlag"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to update the feature flag.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "The requested resource wasn't found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/forms/{name}/submit": {
+            "post": {
+                "description": "Creates a new record in the public form collection from the form allowlisted fields\n(with optional honeypot, captcha and per client IP submissions limit protections).\nAccepts json, multipart and url encoded bodies and redirects to the form redirect url (if set).",
+                "consumes": [
+                    "application/json",
+                    "application/x-www-form-urlencoded"
+                ],
+                "tags": [
+                    "Forms"
+                ],
+                "summary": "Submit public form",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Public form name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "303": {
+                        "description": "Redirect to the form redirect url"
+                    },
+                    "400": {
+                        "description": "Failed to submit the form.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "The requested resource wasn't found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many submissions.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/graphql": {
+            "post": {
+                "security": [
+                    {
+                        "Auth": []
+                    }
+                ],
+                "description": "Executes a GraphQL query or mutation generated from the collections schema (the collection API rules are applied the same way as in the records api)\nQueries: {collection}(id, expand) and {collection}List(filter, sort, page, perPage, expand).\nMutations: create{Collection}(data, expand), update{Collection}(id, data, expand) and delete{Collection}(id).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "GraphQL"
+                ],
+                "summary": "Execute GraphQL operation",
+                "parameters": [
+                    {
+                        "description": "GraphQL request (POST)",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/graphql.Request"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "GraphQL query document (GET)",
+                        "name": "query",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Operation name (GET)",
+                        "name": "operationName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "JSON encoded variables (GET)",
+                        "name": "variables",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/graphql.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/graphql.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "The requested resource wasn't found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/graphql/schema": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Returns the GraphQL schema (SDL) generated from the current collections",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "GraphQL"
+                ],
+                "summary": "GraphQL schema",
+                "responses": {
+                    "200": {
+                        "description": "GraphQL schema definition",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "The requested resource wasn't found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/limits": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Возвращает список индивидуальных лимитов запросов с текущим потреблением",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Список переопределений лимитов",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество элементов на странице",
+                        "name": "perPage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Сортировка",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Фильтр",
+                        "name": "filter",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.SearchResult"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/apis.RateLimitStatus"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Something went wrong while processing your request.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/limits/{identityId}": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Возвращает действующий лимит запросов и текущее потребление указанной идентичности (администратора, пользователя, API ключа или IP адреса)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Лимит идентичности",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор администратора, записи, API ключа или IP адрес",
+                        "name": "identityId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/apis.RateLimitStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Создает или обновляет индивидуальный лимит запросов указанной идентичности поверх глобальных настроек (0 запросов означает отсутствие ограничения)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Переопределение лимита",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор администратора, записи, API ключа или IP адрес",
+                        "name": "identityId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные лимита",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/forms.RateLimitUpsert"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/apis.RateLimitStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to save the rate limit override.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Удаляет индивидуальный лимит запросов указанной идентичности (начинают действовать глобальные настройки)",
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Удаление переопределения лимита",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор администратора, записи, API ключа или IP адрес",
+                        "name": "identityId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Failed to delete the rate limit override.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "The requested resource wasn't found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/logs/audit": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Returns a paginated audit logs list",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Logs"
+                ],
+                "summary": "List audit logs",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page",
+                        "name": "perPage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort fields",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter expression",
+                        "name": "filter",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/search.Result"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.AuditLog"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Something went wrong while processing your request.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/logs/audit/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Returns a single audit log by its id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Logs"
+                ],
+                "summary": "View audit log",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Audit log id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.AuditLog"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "The requested resource wasn't found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/logs/export": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Streams all request logs matching the filter as csv, ndjson (newline delimited json) or parquet file",
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/vnd.apache.parquet"
+                ],
+                "tags": [
+                    "Logs"
+                ],
+                "summary": "Export request logs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Export format (csv, ndjson or parquet, default to csv)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort fields (default to -rowid)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter expression",
+                        "name": "filter",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Invalid export parameters.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/logs/requests": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Returns a paginated request logs list",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Logs"
+                ],
+                "summary": "List request logs",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page",
+                        "name": "perPage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort fields",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter expression",
+                        "name": "filter",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/search.Result"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Request"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Something went wrong while processing your request.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/logs/requests/stats": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Returns the hourly grouped request logs statistics",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Logs"
+                ],
+                "summary": "Request logs stats",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter expression",
+                        "name": "filter",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/daos.RequestsStatsItem"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to generate requests stats.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/logs/requests/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Returns a single request log by its id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Logs"
+                ],
+                "summary": "View request log",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Request log id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Request"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "The requested resource wasn't found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/metrics": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Returns the request counts, latencies and status codes per route and the db query durations in the Prometheus text format",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Request metrics",
+                "responses": {
+                    "200": {
+                        "description": "Prometheus metrics",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token or valid metrics token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/orgs": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    },
+                    {
+                        "RecordAuth": []
+                    }
+                ],
+                "description": "Returns a paginated organizations list (auth records see only the organizations they are member of)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orgs"
+                ],
+                "summary": "List organizations",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page",
+                        "name": "perPage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort fields",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter expression",
+                        "name": "filter",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/search.Result"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Org"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Something went wrong while processing your request.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin or record authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    },
+                    {
+                        "RecordAuth": []
+                    }
+                ],
+                "description": "Creates a new organization. The authenticated record (if any) becomes its owner",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orgs"
+                ],
+                "summary": "Create organization",
+                "parameters": [
+                    {
+                        "description": "Organization data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/forms.OrgUpsert"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Org"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to create the organization.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin or record authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/orgs/invites": {
+            "get": {
+                "security": [
+                    {
+                        "RecordAuth": []
+                    }
+                ],
+                "description": "Returns the pending organization invites of the authenticated record verified email",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orgs"
+                ],
+                "summary": "List my organization invites",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.OrgInvite"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to load the organization invites.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires valid record authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/orgs/invites/{inviteId}/accept": {
+            "post": {
+                "security": [
+                    {
+                        "RecordAuth": []
+                    }
+                ],
+                "description": "Joins the authenticated record to the invite organization. The record email must be verified and match the invite email",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orgs"
+                ],
+                "summary": "Accept organization invite",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Invite id",
+                        "name": "inviteId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.OrgMember"
+                        }
+                    },
+                    "400": {
+                        "description": "The organization invite has expired.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires valid record authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Only auth records with verified email could accept organization invites.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "The requested resource wasn't found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/orgs/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    },
+                    {
+                        "RecordAuth": []
+                    }
+                ],
+                "description": "Returns a single organization by its id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orgs"
+                ],
+                "summary": "View organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Org"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin or record authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "The requested resource wasn't found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    },
+                    {
+                        "RecordAuth": []
+                    }
+                ],
+                "description": "Deletes the organization with the specified id together with its members and invites. Requires organization owner role",
+                "tags": [
+                    "Orgs"
+                ],
+                "summary": "Delete organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Failed to delete the organization.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin or record authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "The organization member role is not allowed to perform this action.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "The requested resource wasn't found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    },
+                    {
+                        "RecordAuth": []
+                    }
+                ],
+                "description": "Updates the organization with the specified id. Requires at least organization admin role",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orgs"
+                ],
+                "summary": "Update organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Organization data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/forms.OrgUpsert"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Org"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to update the organization.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin or record authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "The organization member role is not allowed to perform this action.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "The requested resource wasn't found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/orgs/{id}/invites": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    },
+                    {
+                        "RecordAuth": []
+                    }
+                ],
+                "description": "Returns all pending invites of the organization. Requires at least organization admin role",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orgs"
+                ],
+                "summary": "List organization invites",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.OrgInvite"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to load the organization invites.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin or record authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "The organization member role is not allowed to perform this action.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "The requested resource wasn't found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    },
+                    {
+                        "RecordAuth": []
+                    }
+                ],
+                "description": "Invites an email address to join the organization (or renews the existing invite). Requires at least organization admin role (only owners could invite owners)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orgs"
+                ],
+                "summary": "Invite organization member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Invite data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/forms.OrgInviteUpsert"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.OrgInvite"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to invite the organization member.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin or record authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "The organization member role is not allowed to perform this action.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "The requested resource wasn't found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/orgs/{id}/invites/{inviteId}": {
+            "delete": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    },
+                    {
+                        "RecordAuth": []
+                    }
+                ],
+                "description": "Revokes a pending organization invite. Requires at least organization admin role",
+                "tags": [
+                    "Orgs"
+                ],
+                "summary": "Delete organization invite",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Invite id",
+                        "name": "inviteId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Failed to delete the organization invite.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin or record authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "The organization member role is not allowed to perform this action.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "The requested resource wasn't found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/orgs/{id}/members": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    },
+                    {
+                        "RecordAuth": []
+                    }
+                ],
+                "description": "Returns all members of the organization",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orgs"
+                ],
+                "summary": "List organization members",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.OrgMember"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to load the organization members.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin or record authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "The requested resource wasn't found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/orgs/{id}/members/{memberId}": {
+            "delete": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    },
+                    {
+                        "RecordAuth": []
+                    }
+                ],
+                "description": "Removes a member from the organization. Requires at least organization admin role, except when members leave the organization themselves",
+                "tags": [
+                    "Orgs"
+                ],
+                "summary": "Remove organization member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Member id",
+                        "name": "memberId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "The organization must have at least one owner.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin or record authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "The organization member role is not allowed to perform this action.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "The requested resource wasn't found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    },
+                    {
+                        "RecordAuth": []
+                    }
+                ],
+                "description": "Changes the role of an organization member. Requires at least organization admin role (only owners could manage the owner role)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orgs"
+                ],
+                "summary": "Update organization member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Member id",
+                        "name": "memberId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Member data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/forms.OrgMemberUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.OrgMember"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to update the organization member.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin or record authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "The organization member role is not allowed to perform this action.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "The requested resource wasn't found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/orgs/{id}/usage": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    },
+                    {
+                        "RecordAuth": []
+                    }
+                ],
+                "description": "Returns the organization billable events totals for the specified month (default to the current one). Requires at least organization admin role",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orgs"
+                ],
+                "summary": "View organization usage",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Usage month in YYYY-MM format",
+                        "name": "period",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.UsageSummary"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid usage period.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin or record authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "The organization member role is not allowed to perform this action.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "The requested resource wasn't found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/realtime": {
+            "get": {
+                "description": "Открывает SSE (text/event-stream) соединение. Каждое событие отправляется в формате \"id:{eventId}\\nevent:{name}\\ndata:{json}\\n\\n\".\nПротокол:\n1. после подключения отправляется событие \"PB_CONNECT\" с идентификатором клиента (RealtimeConnectMessage);\n2. клиент устанавливает свои подписки через POST /api/realtime (с заголовком Authorization для доступа к закрытым записям);\n3. изменения записей доставляются как события с именем подписки (\"{collection}/*\", \"{collection}/{id}\") и данными RealtimeRecordMessage,\nизменения комментариев - как \"PB_COMMENTS/{collection}/{recordId}\" с данными RealtimeCommentMessage, блокировки записей - как \"PB_LOCKS/{collection}/{recordId}\" с данными RealtimeRecordLockMessage, прогресс резервного копирования - как \"PB_BACKUPS\" (только для администраторов).\nПри переподключении можно передать идентификатор последнего полученного события (заголовок Last-Event-ID или параметр lastEventId), чтобы получить пропущенные события после установки подписок.\nНеактивное соединение закрывается через 5 минут.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Realtime"
+                ],
+                "summary": "Установить соединение в реальном времени",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор последнего полученного события",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Идентификатор последнего полученного события (для клиентов без поддержки заголовков)",
+                        "name": "lastEventId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Поток событий (первое событие - PB_CONNECT)",
+                        "schema": {
+                            "$ref": "#/definitions/apis.RealtimeConnectMessage"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    },
+                    {
+                        "RecordAuth": []
+                    }
+                ],
+                "description": "Заменяет подписки клиента, подключенного через GET /api/realtime (clientId из события PB_CONNECT).\nАвторизация запроса (администратор или запись) сохраняется для клиента и используется для проверки правил доступа ListRule/ViewRule при доставке событий.\nПустой список подписок отменяет все подписки клиента.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Realtime"
+                ],
+                "summary": "Установить подписки в реальном времени",
+                "parameters": [
+                    {
+                        "description": "Данные подписок",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apis.RealtimeSubscribeForm"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Подписки успешно установлены"
+                    },
+                    "400": {
+                        "description": "Something went wrong while processing your request.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "The current and the previous request authorization don't match.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Missing or invalid client id.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/realtime/broadcast": {
+            "post": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Отправляет пользовательское сообщение всем клиентам, подписанным на указанную тему",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Realtime"
+                ],
+                "summary": "Опубликовать сообщение в реальном времени",
+                "parameters": [
+                    {
+                        "description": "Данные сообщения",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/forms.RealtimeBroadcast"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/forms.RealtimeBroadcastResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to broadcast the message.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid realtime broadcast token.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/realtime/clients": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Возвращает список подключенных клиентов в реальном времени с их подписками и временем подключения",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Realtime"
+                ],
+                "summary": "Получить список клиентов в реальном времени",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/apis.RealtimeClientInfo"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/realtime/clients/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Принудительно закрывает соединение указанного клиента в реальном времени",
+                "tags": [
+                    "Realtime"
+                ],
+                "summary": "Отключить клиента в реальном времени",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор клиента",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Missing or invalid client id.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/realtime/ws": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    },
+                    {
+                        "RecordAuth": []
+                    }
+                ],
+                "description": "Альтернатива SSE для клиентов за прокси, буферизующими text/event-stream. Использует тот же протокол подписок, но в виде JSON сообщений (RealtimeWsMessage и RealtimeWsRequest):\n1. после подключения отправляется событие \"PB_CONNECT\" с идентификатором клиента (RealtimeConnectMessage);\n2. клиент заменяет свои подписки сообщением {\"type\":\"subscribe\",\"subscriptions\":[...]} (не более 100 подписок на соединение), в ответ отправляется событие \"PB_SUBSCRIBE\";\n3. события подписок доставляются как {\"id\":\"...\",\"event\":\"{подписка}\",\"data\":{...}}, ошибки - как событие \"PB_ERROR\" с данными ApiError.\nАвторизация (заголовок Authorization или подписанный URL) определяется при подключении и используется для проверки правил доступа при доставке событий.\nСервер отправляет ping фреймы каждые 30 секунд, сообщение {\"type\":\"ping\"} возвращает событие \"PB_PONG\". Неактивное соединение (без сообщений в обе стороны) закрывается через 5 минут.",
+                "tags": [
+                    "Realtime"
+                ],
+                "summary": "Установить соединение в реальном времени через WebSocket",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор последнего полученного события (пропущенные события отправляются после установки подписок)",
+                        "name": "lastEventId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols",
+                        "schema": {
+                            "$ref": "#/definitions/apis.RealtimeWsMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/scheduled-exports": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Returns a paginated scheduled exports list",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ScheduledExports"
+                ],
+                "summary": "List scheduled exports",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page",
+                        "name": "perPage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort fields",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter expression",
+                        "name": "filter",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/search.Result"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ScheduledExport"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Something went wrong while processing your request.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Creates a new recurring collection records export",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ScheduledExports"
+                ],
+                "summary": "Create scheduled export",
+                "parameters": [
+                    {
+                        "description": "Scheduled export data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/forms.ScheduledExportUpsert"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ScheduledExport"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to create the scheduled export.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/scheduled-exports/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Returns a single scheduled export by its id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ScheduledExports"
+                ],
+                "summary": "View scheduled export",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Scheduled export id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ScheduledExport"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "The requested resource wasn't found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Deletes the scheduled export with the specified id (the already exported files are not deleted)",
+                "tags": [
+                    "ScheduledExports"
+                ],
+                "summary": "Delete scheduled export",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Scheduled export id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Failed to delete the scheduled export.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "The requested resource wasn't found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Updates the scheduled export with the specified id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ScheduledExports"
+                ],
+                "summary": "Update scheduled export",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Scheduled export id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Scheduled export data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/forms.ScheduledExportUpsert"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ScheduledExport"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to update the scheduled export.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "The requested resource wasn't found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/scheduled-exports/{id}/files/{filename}": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Downloads a single exported file of the scheduled export",
+                "tags": [
+                    "ScheduledExports"
+                ],
+                "summary": "Download scheduled export file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Scheduled export id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Exported file name",
+                        "name": "filename",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Failed to load the export file.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "The requested resource wasn't found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/scheduled-exports/{id}/run": {
+            "post": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Runs the scheduled export immediately (regardless of its schedule and enabled state) and returns the updated export with the run result",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ScheduledExports"
+                ],
+                "summary": "Run scheduled export",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Scheduled export id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ScheduledExport"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to run the scheduled export.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "The requested resource wasn't found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/scim/v2/Groups": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Returns a paginated SCIM groups list (supports the displayName and id \"eq\" filters)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scim"
+                ],
+                "summary": "SCIM list groups",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter expression (eg. displayName eq \\",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "1-based index of the first result",
+                        "name": "startIndex",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Max number of results",
+                        "name": "count",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/scim.ListResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "Resources": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/scim.Group"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/scim.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires valid authorization token or API key to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Adds the new group to its members groups",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scim"
+                ],
+                "summary": "SCIM create group",
+                "parameters": [
+                    {
+                        "description": "Group data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/scim.Group"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/scim.Group"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/scim.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires valid authorization token or API key to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/scim.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/scim/v2/Groups/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Returns a single SCIM group by its id (aka. its name)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scim"
+                ],
+                "summary": "SCIM view group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/scim.Group"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires valid authorization token or API key to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/scim.Error"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Replaces the group name and members",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scim"
+                ],
+                "summary": "SCIM replace group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Group data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/scim.Group"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/scim.Group"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/scim.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires valid authorization token or API key to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/scim.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Removes the group from all of its members",
+                "tags": [
+                    "Scim"
+                ],
+                "summary": "SCIM delete group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "The request requires valid authorization token or API key to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/scim.Error"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Applies the SCIM PATCH operations on the group displayName and members",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scim"
+                ],
+                "summary": "SCIM patch group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Patch operations",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/scim.PatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/scim.Group"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/scim.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires valid authorization token or API key to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/scim.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/scim/v2/ServiceProviderConfig": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Returns the supported SCIM features",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scim"
+                ],
+                "summary": "SCIM service provider config",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires valid authorization token or API key to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/scim/v2/Users": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Returns a paginated SCIM users list (supports the userName, id, emails.value and active \"eq\" filters)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scim"
+                ],
+                "summary": "SCIM list users",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter expression (eg. userName eq \\",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "1-based index of the first result",
+                        "name": "startIndex",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Max number of results",
+                        "name": "count",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/scim.ListResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "Resources": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/scim.User"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/scim.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires valid authorization token or API key to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Provisions a new user (a random password is generated if not provided)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scim"
+                ],
+                "summary": "SCIM create user",
+                "parameters": [
+                    {
+                        "description": "User data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/scim.User"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/scim.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/scim.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires valid authorization token or API key to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/scim.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/scim/v2/Users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Returns a single SCIM user by its id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scim"
+                ],
+                "summary": "SCIM view user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/scim.User"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires valid authorization token or API key to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/scim.Error"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Replaces the user userName, email, active state and password (if provided)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scim"
+                ],
+                "summary": "SCIM replace user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/scim.User"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/scim.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/scim.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires valid authorization token or API key to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/scim.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/scim.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Permanently deletes the user (deactivate it with active=false to soft delete it instead)",
+                "tags": [
+                    "Scim"
+                ],
+                "summary": "SCIM delete user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "The request requires valid authorization token or API key to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/scim.Error"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Applies the SCIM PATCH operations on the user userName, emails, active and password attributes (the other attributes are ignored)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scim"
+                ],
+                "summary": "SCIM patch user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Patch operations",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/scim.PatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/scim.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/scim.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires valid authorization token or API key to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/scim.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/scim.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/search-sync/reindex/{collection}": {
+            "post": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Clears the external search engine index of the collection and pushes all of its records",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SearchSync"
+                ],
+                "summary": "Reindex collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection name or id",
+                        "name": "collection",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/apis.SearchSyncReindexResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to reindex the collection.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "The requested resource wasn't found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/security/report": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Aggregates the failed auth attempts, the 403 spikes and the unusual client countries from the request logs of the last report period",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Security report",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Report period in seconds (default settings securityReport.window, max 30 days)",
+                        "name": "window",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/core.SecurityReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid report window.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/settings": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Возвращает список всех настроек (поле envLocked содержит настройки, переопределенные переменными окружения PB_*)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Получение списка настроек",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Settings"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to authenticate.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Обновляет указанные настройки (изменение настроек, переопределенных переменными окружения PB_*, запрещено)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Обновление настроек",
+                "parameters": [
+                    {
+                        "description": "Данные для обновления настроек",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apis.UpdateSettingsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Обновление настроек успешно"
+                    },
+                    "400": {
+                        "description": "Failed to authenticate.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/settings/apple/generate-client-secret": {
+            "post": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Генерирует секретный ключ для использования при авторизации Apple",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Генерация секретного ключа для авторизации Apple",
+                "responses": {
+                    "200": {
+                        "description": "Генерация секретного ключа для авторизации Apple успешно"
+                    },
+                    "400": {
+                        "description": "Failed to authenticate.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/settings/history": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Возвращает список версий настроек (кто, когда и какие поля изменил), по умолчанию сначала новые",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "История изменений настроек",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество элементов на странице",
+                        "name": "perPage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Сортировка",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Фильтр",
+                        "name": "filter",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.SearchResult"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.SettingsHistory"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Something went wrong while processing your request.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/settings/rollback/{version}": {
+            "post": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Восстанавливает состояние настроек указанной версии (откат сохраняется как новая версия, настройки, переопределенные переменными окружения PB_*, ключ шифрования резервных копий и ключи подписи токенов не изменяются)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Откат настроек",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Версия настроек",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Settings"
+                        }
+                    },
+                    "400": {
+                        "description": "An error occurred while submitting the form.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "The requested resource wasn't found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/settings/secrets/status": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Возвращает состояние загрузки ссылок на секреты (Vault, AWS Secrets Manager, переменные окружения) из настроек",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Состояние внешних секретов настроек",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/apis.SecretsStatusResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/settings/test/email": {
+            "post": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Проверяет настройки для отправки электронной почты",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Тестирование настроек для электронной почты",
+                "parameters": [
+                    {
+                        "description": "Данные для тестирования настроек электронной почты",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apis.TestEmailSettingsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Тестирование настроек для электронной почты успешно"
+                    },
+                    "400": {
+                        "description": "Failed to authenticate.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/settings/test/s3": {
+            "post": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Проверяет настройки для хранилища S3",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Тестирование настроек для хранилища S3",
+                "parameters": [
+                    {
+                        "description": "Данные для тестирования настроек S3",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apis.TestS3SettingsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Тестирование настроек для хранилища S3 успешно"
+                    },
+                    "400": {
+                        "description": "Failed to authenticate.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/settings/token-signing/keys": {
+            "post": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Генерирует новый RS256/EdDSA ключ и делает его активным для подписи токенов авторизации (предыдущие ключи остаются в JWKS для проверки уже выданных токенов)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Генерация ключа подписи токенов",
+                "parameters": [
+                    {
+                        "description": "Алгоритм нового ключа",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apis.GenerateTokenSigningKeyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Settings"
+                        }
+                    },
+                    "400": {
+                        "description": "An error occurred while submitting the form.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/settings/token-signing/keys/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Удаляет неактивный ключ подписи токенов (выданные с ним токены перестают приниматься)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Удаление ключа подписи токенов",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор ключа (kid)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Settings"
+                        }
+                    },
+                    "400": {
+                        "description": "The active token signing key can't be deleted.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "The requested resource wasn't found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/signed-requests": {
+            "post": {
+                "security": [
+                    {
+                        "Auth": []
+                    }
+                ],
+                "description": "Создает ограниченный по времени (не более 1 дня) одноразовый подписанный URL, авторизующий от имени текущего администратора или пользователя только один конкретный запрос (метод, путь и тело) без передачи токена",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Создание подписанного запроса",
+                "parameters": [
+                    {
+                        "description": "Данные подписываемого запроса",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apis.SignedRequestCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/apis.SignedRequestResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to sign the request.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin or record authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/storage/usage": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Returns the app files storage usage and the uploads deduplication savings",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Storage"
+                ],
+                "summary": "Storage usage",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/apis.StorageUsage"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to load the storage usage.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/swagger.json": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Возвращает OpenAPI (Swagger 2.0) спецификацию, дополненную моделями и маршрутами записей каждой доступной коллекции",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Спецификация API",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ключ доступа к документации или API ключ с доступом docs (если не используется авторизация администратора)",
+                        "name": "X-API-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires admin authorization token or valid api docs key to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions": {
+            "post": {
+                "security": [
+                    {
+                        "Auth": []
+                    }
+                ],
+                "description": "Атомарно выполняет последовательность операций над записями разных коллекций с проверкой правил доступа (при ошибке любой операции все изменения откатываются)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Record"
+                ],
+                "summary": "Выполнение транзакции",
+                "parameters": [
+                    {
+                        "description": "Список операций",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apis.TransactionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Записи в порядке операций (null для удаления)",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Record"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to execute transaction operation.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Only admins can perform this action.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/user": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Send and record new user",
+                "tags": [
+                    "user"
+                ],
+                "summary": "New user",
+                "parameters": [
+                    {
+                        "description": "send user object",
+                        "name": "payload",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.UserPure"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "idempotency key to safely retry the request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.Data"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/apis.ID"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "Idempotent-Replayed": {
+                                "type": "string",
+                                "description": "true if the response is a replay of the stored one"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Soft delete with id or name (permanently with permanent=true)",
+                "tags": [
+                    "user"
+                ],
+                "summary": "Delete user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "get by id",
+                        "name": "id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "delete permanently instead of soft delete, default is false",
+                        "name": "permanent",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get list of the users",
+                "tags": [
+                    "user"
+                ],
+                "summary": "List users",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "set the limit, default is 20",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "set the offset, default is 0",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "search item",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "include the soft deleted users, default is false",
+                        "name": "includeDeleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "anonymization profile to apply to the users data",
+                        "name": "anonymize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "response format (dataMeta or searchResult), default is dataMeta",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "searchResult format page, default is 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "searchResult format items per page, default is 30",
+                        "name": "perPage",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "with format=searchResult",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/search.Result"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/apis.UserDataID"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/auth-with-password": {
+            "post": {
+                "description": "Authenticate a user with name (or email) and password and return a new user auth token",
+                "tags": [
+                    "user"
+                ],
+                "summary": "Authenticate user",
+                "parameters": [
+                    {
+                        "description": "user credentials",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apis.UserAuthWithPassword"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.Data"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/apis.UserAuthResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/export": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Stream the users in a csv, xlsx or parquet file (sorted by name)\nThe xlsx file has an additional groups sheet with a row for each user group membership",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "application/vnd.apache.parquet"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Export users",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "export format (csv, xlsx or parquet), default is csv",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "search item",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "include the soft deleted users, default is false",
+                        "name": "includeDeleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/groups": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get list of the users groups (the distinct values of the users groups field)",
+                "tags": [
+                    "user"
+                ],
+                "summary": "List groups",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.Data"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/apis.UserGroup"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a new users group by adding it to the member users groups",
+                "tags": [
+                    "user"
+                ],
+                "summary": "New group",
+                "parameters": [
+                    {
+                        "description": "group name and member user ids",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apis.UserGroup"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.Data"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/apis.UserGroup"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/groups/{name}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a single users group with its members",
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "group name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.Data"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/apis.UserGroup"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Error"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Rename a users group and replace its members",
+                "tags": [
+                    "user"
+                ],
+                "summary": "Replace group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "group name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "new group name and member user ids",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apis.UserGroup"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.Data"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/apis.UserGroup"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete a users group by removing it from all member users groups",
+                "tags": [
+                    "user"
+                ],
+                "summary": "Delete group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "group name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/import": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create or update (matched by name) users in a single transaction\nThe optional transforms (trim, lowercase, date, lookup, default) are applied on each user before the validation",
+                "tags": [
+                    "user"
+                ],
+                "summary": "Import users",
+                "parameters": [
+                    {
+                        "description": "users to import",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apis.UsersImport"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.Data"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/apis.UsersImportResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.Error"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/apis.UsersImportError"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update the profile of the authorized user (the current password is required)",
+                "tags": [
+                    "user"
+                ],
+                "summary": "Update my profile",
+                "parameters": [
+                    {
+                        "description": "profile fields to update",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apis.UserProfile"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.Data"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/apis.UserDataID"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/password": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Change the password of the authorized user",
+                "tags": [
+                    "user"
+                ],
+                "summary": "Change my password",
+                "parameters": [
+                    {
+                        "description": "current and new password",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apis.UserPasswordChange"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get one user by id and/or email (case-insensitive)\nThe lookup by email only is available with \"lookup\" as id (eg. /users/lookup?email=...)",
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "get by id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "get by email",
+                        "name": "email",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.Data"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/apis.UserDataID"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "multiple users with the same email",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Restore a soft deleted user",
+                "tags": [
+                    "user"
+                ],
+                "summary": "Restore user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "user id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.Data"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/apis.ID"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apis.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Returns a paginated list with the webhook event delivery logs.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "List webhook deliveries",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page",
+                        "name": "perPage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort fields",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter expression",
+                        "name": "filter",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/search.Result"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.WebhookDelivery"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Something went wrong while processing your request.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires valid admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/deliveries/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Returns a single webhook event delivery log.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "View webhook delivery",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Delivery id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookDelivery"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires valid admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "The requested resource wasn't found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/deliveries/{id}/redeliver": {
+            "post": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Sends again the logged event payload to the current url of its webhook (the failed attempt is not retried).\nThe response is the updated delivery log with the result of the new attempt.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Redeliver webhook event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Delivery id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookDelivery"
+                        }
+                    },
+                    "400": {
+                        "description": "The webhook is missing or disabled.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "The request requires valid admin authorization token to be set.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "The requested resource wasn't found.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "apis.Admin": {
+            "type": "object",
+            "properties": {
+                "avatar": {
+                    "type": "integer"
+                },
+                "created": {
+                    "type": "object"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "otpEnabled": {
+                    "type": "boolean"
+                },
+                "updated": {
+                    "type": "object"
+                }
+            }
+        },
+        "apis.AdminCreateForm": {
+            "type": "object",
+            "properties": {
+                "avatar": {
+                    "type": "integer"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "passwordConfirm": {
+                    "type": "string"
+                }
+            }
+        },
+        "apis.AdminLogin": {
+            "type": "object",
+            "properties": {
+                "identity": {
+                    "type": "string"
+                },
+                "otpCode": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "apis.AdminPasswordResetConfirm": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "passwordConfirm": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "apis.AdminPasswordResetRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "apis.AdminUpdateForm": {
+            "type": "object",
+            "properties": {
+                "avatar": {
+                    "type": "integer"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "passwordConfirm": {
+                    "type": "string"
+                }
+            }
+        },
+        "apis.AnalyticsQueryRequest": {
+            "type": "object",
+            "properties": {
+                "params": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "query": {
+                    "type": "string"
+                }
+            }
+        },
+        "apis.ApiError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "apis.ApplyResult": {
+            "type": "object",
+            "properties": {
+                "applied": {
+                    "type": "boolean"
+                },
+                "plan": {
+                    "$ref": "#/definitions/forms.DeclarativePlan"
+                }
+            }
+        },
+        "apis.AuthProviderConfig": {
+            "type": "object",
+            "properties": {
+                "authUrl": {
+                    "type": "string"
+                },
+                "clientId": {
+                    "type": "string"
+                },
+                "clientSecret": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "tokenUrl": {
+                    "type": "string"
+                },
+                "userApiUrl": {
+                    "type": "string"
+                }
+            }
+        },
+        "apis.BackupCreateRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "apis.BackupFileInfo": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "type": "string"
+                },
+                "modified": {
+                    "type": "object"
+                },
+                "size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "apis.BackupRotateKeyRequest": {
+            "type": "object",
+            "properties": {
+                "newKey": {
+                    "type": "string"
+                }
+            }
+        },
+        "apis.BackupsConfig": {
+            "type": "object",
+            "properties": {
+                "cron": {
+                    "description": "Cron is a cron expression to schedule auto backups, eg. \"* * * * *\".\n\nLeave it empty to disable the auto backups functionality.",
+                    "type": "string"
+                },
+                "cronMaxKeep": {
+                    "description": "CronMaxKeep is the the max number of cron generated backups to\nkeep before removing older entries.\n\nThis field works only when the cron config has valid cron expression.",
+                    "type": "integer"
+                },
+                "encryptionKey": {
+                    "description": "EncryptionKey is an optional 32 characters AES key used to encrypt\nthe generated backup archives before their upload.",
+                    "type": "string"
+                },
+                "s3": {
+                    "description": "S3 is an optional S3 storage config specifying where to store the app backups.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/apis.S3Config"
+                        }
+                    ]
+                }
+            }
+        },
+        "apis.BatchRequest": {
+            "type": "object",
+            "properties": {
+                "operations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/apis.TransactionOperation"
+                    }
+                }
+            }
+        },
+        "apis.BatchResponse": {
+            "type": "object",
+            "properties": {
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/apis.BatchResult"
+                    }
+                },
+                "rolledBack": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "apis.BatchResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "description": "Error is the failed operation error.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/apis.ApiError"
+                        }
+                    ]
+                },
+                "record": {
+                    "description": "Record is the created or updated record (nil for delete and failed operations).",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Record"
+                        }
+                    ]
+                },
+                "status": {
+                    "description": "Status is the http status code of the operation.",
+                    "type": "integer"
+                }
+            }
+        },
+        "apis.Collection": {
+            "type": "object",
+            "properties": {
+                "createRule": {
+                    "type": "string"
+                },
+                "created": {
+                    "type": "object"
+                },
+                "deleteRule": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "indexes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "listRule": {
+                    "description": "rules",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "options": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "schema": {
+                    "type": "object"
+                },
+                "system": {
+                    "type": "boolean"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updateRule": {
+                    "type": "string"
+                },
+                "updated": {
+                    "type": "object"
+                },
+                "viewRule": {
+                    "type": "string"
+                }
+            }
+        },
+        "apis.CollectionCreateRequest": {
+            "type": "object",
+            "properties": {
+                "createRule": {
+                    "type": "string"
+                },
+                "deleteRule": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "indexes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "listRule": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "options": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "schema": {
+                    "type": "object"
+                },
+                "system": {
+                    "type": "boolean"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updateRule": {
+                    "type": "string"
+                },
+                "viewRule": {
+                    "type": "string"
+                }
+            }
+        },
+        "apis.CollectionsExport": {
+            "type": "object",
+            "properties": {
+                "collections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Collection"
+                    }
+                }
+            }
+        },
+        "apis.CollectionsImportRequest": {
+            "type": "object",
+            "properties": {
+                "collections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/apis.Collection"
+                    }
+                },
+                "deleteMissing": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "apis.CreateRecordRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "emailVisibility": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "description": "base model fields",
+                    "type": "string"
+                },
+                "oldPassword": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "passwordConfirm": {
+                    "type": "string"
+                },
+                "username": {
+                    "description": "auth collection fields\n---",
+                    "type": "string"
+                },
+                "verified": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "apis.Data": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "apis.DataMeta": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "meta": {}
+            }
+        },
+        "apis.EmailAuthConfig": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "exceptDomains": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "minPasswordLength": {
+                    "type": "integer"
+                },
+                "onlyDomains": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "apis.EmailTemplate": {
+            "type": "object",
+            "properties": {
+                "actionUrl": {
+                    "type": "string"
+                },
+                "body": {
+                    "type": "string"
+                },
+                "subject": {
+                    "type": "string"
+                }
+            }
+        },
+        "apis.Error": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "some problem"
+                }
+            }
+        },
+        "apis.ExternalAuthResponse": {
+            "type": "object",
+            "properties": {
+                "collectionId": {
+                    "type": "string"
+                },
+                "created": {
+                    "type": "object"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "providerId": {
+                    "type": "string"
+                },
+                "recordId": {
+                    "type": "string"
+                },
+                "updated": {
+                    "type": "object"
+                }
+            }
+        },
+        "apis.FieldTranslationStatus": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "locales": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/apis.LocaleTranslationStatus"
+                    }
+                }
+            }
+        },
+        "apis.FileSignedUrlResponse": {
+            "type": "object",
+            "properties": {
+                "expires": {
+                    "$ref": "#/definitions/types.DateTime"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://bucket.s3.amazonaws.com/..."
+                }
+            }
+        },
+        "apis.GenerateTokenSigningKeyRequest": {
+            "type": "object",
+            "properties": {
+                "algorithm": {
+                    "description": "Algorithm of the new key - RS256 or EdDSA",
+                    "type": "string"
+                }
+            }
+        },
+        "apis.ID": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "cf8a07d4-077e-402e-a46b-ac0ed50989ec"
+                }
+            }
+        },
+        "apis.JwksResponse": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/security.JWK"
+                    }
+                }
+            }
+        },
+        "apis.LocaleTranslationStatus": {
+            "type": "object",
+            "properties": {
+                "locale": {
+                    "type": "string"
+                },
+                "missing": {
+                    "type": "integer"
+                },
+                "progress": {
+                    "description": "Progress is the translated records percentage (0-100).",
+                    "type": "number"
+                },
+                "translated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "apis.LogsConfig": {
+            "type": "object",
+            "properties": {
+                "maxDays": {
+                    "type": "integer"
+                }
+            }
+        },
+        "apis.Meta": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 35
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "search": {
+                    "type": "string"
+                }
+            }
+        },
+        "apis.RateLimitStatus": {
+            "type": "object",
+            "properties": {
+                "duration": {
+                    "type": "integer"
+                },
+                "identityId": {
+                    "type": "string"
+                },
+                "maxRequests": {
+                    "description": "MaxRequests and Duration are the effective identity limits.",
+                    "type": "integer"
+                },
+                "override": {
+                    "description": "Override is the identity specific limit (nil if the global settings are used).",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.RateLimit"
+                        }
+                    ]
+                },
+                "usage": {
+                    "$ref": "#/definitions/ratelimit.Usage"
+                }
+            }
+        },
+        "apis.RealtimeClientInfo": {
+            "type": "object",
+            "properties": {
+                "adminId": {
+                    "type": "string"
+                },
+                "authCollectionId": {
+                    "type": "string"
+                },
+                "authRecordId": {
+                    "type": "string"
+                },
+                "connected": {
+                    "$ref": "#/definitions/types.DateTime"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "subscriptions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "apis.RealtimeConnectMessage": {
+            "type": "object",
+            "properties": {
+                "clientId": {
+                    "type": "string"
+                }
+            }
+        },
+        "apis.RealtimeSubscribeForm": {
+            "type": "object",
+            "properties": {
+                "clientId": {
+                    "type": "string"
+                },
+                "subscriptions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "apis.RealtimeWsMessage": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "object"
+                },
+                "event": {
+                    "type": "string",
+                    "example": "PB_CONNECT"
+                },
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "apis.RecordIncrementRequest": {
+            "type": "object",
+            "properties": {
+                "fields": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/forms.RecordIncrementField"
+                    }
+                }
+            }
+        },
+        "apis.RecordLockRequest": {
+            "type": "object",
+            "properties": {
+                "duration": {
+                    "type": "integer",
+                    "example": 60
+                }
+            }
+        },
+        "apis.RecordShareRequest": {
+            "type": "object",
+            "properties": {
+                "permission": {
+                    "type": "string",
+                    "example": "view"
+                },
+                "subjectCollection": {
+                    "type": "string",
+                    "example": "users"
+                },
+                "subjectId": {
+                    "type": "string"
+                },
+                "subjectType": {
+                    "type": "string",
+                    "example": "user"
+                }
+            }
+        },
+        "apis.RecordsImportRequest": {
+            "type": "object",
+            "properties": {
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "additionalProperties": {}
+                    }
+                },
+                "transforms": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/forms.ImportTransform"
+                    }
+                }
+            }
+        },
+        "apis.RecordsSampleResult": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Record"
+                    }
+                },
+                "strategy": {
+                    "type": "string"
+                },
+                "totalItems": {
+                    "description": "TotalItems is the number of records matching the access rule and\nthe filter, aka. the size of the sampled population.",
+                    "type": "integer"
+                }
+            }
+        },
+        "apis.RuleConditionExplanation": {
+            "type": "object",
+            "properties": {
+                "condition": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "matched": {
+                    "type": "boolean"
+                },
+                "matchedItems": {
+                    "type": "integer"
+                }
+            }
+        },
+        "apis.RuleExplanation": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "allowed": {
+                    "description": "Allowed indicates whether the request will be allowed, aka. for\nthe list action whether the rule is not locked and for the\nsingle record actions whether the record matches the rule.",
+                    "type": "boolean"
+                },
+                "auth": {
+                    "description": "Auth is the auth record used when evaluating the rule (nil for guests).",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Record"
+                        }
+                    ]
+                },
+                "collection": {
+                    "type": "string"
+                },
+                "conditions": {
+                    "description": "Conditions contains the individual rule comparison expressions\nand the number of records matching each one of them.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/apis.RuleConditionExplanation"
+                    }
+                },
+                "error": {
+                    "description": "Error is the rule parse/resolve error (if any).",
+                    "type": "string"
+                },
+                "matchedItems": {
+                    "description": "MatchedItems is the number of records matching the full rule.",
+                    "type": "integer"
+                },
+                "rule": {
+                    "description": "Rule is the explained api rule (nil means that only admins can perform the action).",
+                    "type": "string"
+                },
+                "totalItems": {
+                    "description": "TotalItems is the number of records without the rule applied\n(aka. 0 or 1 for the single record actions).",
+                    "type": "integer"
+                },
+                "variables": {
+                    "description": "Variables contains the resolved values of the static\n@request.* rule variables (eg. \"@request.auth.id\").",
+                    "type": "object",
+                    "additionalProperties": {}
+                }
+            }
+        },
+        "apis.RulesTestRequest": {
+            "type": "object",
+            "properties": {
+                "auth": {
+                    "description": "Auth is the hypothetical auth record data (nil for guest).\n\nIt must contain the auth \"collectionId\" or \"collectionName\" key\nand it is not required to exist in the database.",
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "data": {
+                    "description": "Data, Query and Headers are the simulated @request.* values.\n\nData is also used as the new record data for the create operation.",
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "headers": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "operation": {
+                    "description": "Operation is the record api action to test (list, view, create, update or delete).\n\nLeave it empty to test all collection rules.",
+                    "type": "string"
+                },
+                "query": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "recordId": {
+                    "description": "RecordId is the id of the existing record to test\n(required for the view, update and delete operations).",
+                    "type": "string"
+                }
+            }
+        },
+        "apis.RulesTestResult": {
+            "type": "object",
+            "properties": {
+                "allowed": {
+                    "description": "Allowed indicates whether all tested rules allow the operation.",
+                    "type": "boolean"
+                },
+                "collection": {
+                    "type": "string"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/apis.RuleExplanation"
+                    }
+                }
+            }
+        },
+        "apis.S3Config": {
+            "type": "object",
+            "properties": {
+                "accessKey": {
+                    "type": "string"
+                },
+                "bucket": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "endpoint": {
+                    "type": "string"
+                },
+                "forcePathStyle": {
+                    "type": "boolean"
+                },
+                "region": {
+                    "type": "string"
+                },
+                "secret": {
+                    "type": "string"
+                }
+            }
+        },
+        "apis.SavedQueryRequest": {
+            "type": "object",
+            "properties": {
+                "expand": {
+                    "type": "string"
+                },
+                "fields": {
+                    "type": "string",
+                    "example": "id,title"
+                },
+                "filter": {
+                    "type": "string",
+                    "example": "active = true"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "active"
+                },
+                "shared": {
+                    "type": "boolean"
+                },
+                "sort": {
+                    "type": "string",
+                    "example": "-created"
+                }
+            }
+        },
+        "apis.SearchResult": {
+            "type": "object",
+            "properties": {
+                "items": {},
+                "page": {
+                    "type": "integer"
+                },
+                "perPage": {
+                    "type": "integer"
+                },
+                "totalItems": {
+                    "type": "integer"
+                },
+                "totalPages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "apis.SearchSyncReindexResponse": {
+            "type": "object",
+            "properties": {
+                "collection": {
+                    "type": "string"
+                },
+                "index": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "apis.SecretsStatusResponse": {
+            "type": "object",
+            "properties": {
+                "healthy": {
+                    "description": "Healthy indicates whether all secret references were resolved.",
+                    "type": "boolean"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/secrets.Status"
+                    }
+                }
+            }
+        },
+        "apis.Settings": {
+            "type": "object",
+            "properties": {
+                "adminAuthToken": {
+                    "$ref": "#/definitions/apis.TokenConfig"
+                },
+                "adminFileToken": {
+                    "$ref": "#/definitions/apis.TokenConfig"
+                },
+                "adminPasswordResetToken": {
+                    "$ref": "#/definitions/apis.TokenConfig"
+                },
+                "appleAuth": {
+                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                },
+                "backups": {
+                    "$ref": "#/definitions/apis.BackupsConfig"
+                },
+                "discordAuth": {
+                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                },
+                "emailAuth": {
+                    "description": "Deprecated: Will be removed in v0.9+",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/apis.EmailAuthConfig"
+                        }
+                    ]
+                },
+                "envLocked": {
+                    "description": "EnvLocked is the list of the settings fields overridden by the\nPB_ prefixed environment variables (eg. \"smtp.host\" by PB_SMTP_HOST).",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "facebookAuth": {
+                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                },
+                "giteaAuth": {
+                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                },
+                "giteeAuth": {
+                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                },
+                "githubAuth": {
+                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                },
+                "gitlabAuth": {
+                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                },
+                "googleAuth": {
+                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                },
+                "kakaoAuth": {
+                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                },
+                "livechatAuth": {
+                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                },
+                "logs": {
+                    "$ref": "#/definitions/apis.LogsConfig"
+                },
+                "meta": {
+                    "type": "object",
+                    "properties": {
+                        "appName": {
+                            "type": "string"
+                        },
+                        "appUrl": {
+                            "type": "string"
+                        },
+                        "confirmEmailChangeTemplate": {
+                            "$ref": "#/definitions/apis.EmailTemplate"
+                        },
+                        "hideControls": {
+                            "type": "boolean"
+                        },
+                        "resetPasswordTemplate": {
+                            "$ref": "#/definitions/apis.EmailTemplate"
+                        },
+                        "senderAddress": {
+                            "type": "string"
+                        },
+                        "senderName": {
+                            "type": "string"
+                        },
+                        "verificationTemplate": {
+                            "$ref": "#/definitions/apis.EmailTemplate"
+                        }
+                    }
+                },
+                "microsoftAuth": {
+                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                },
+                "oidc2Auth": {
+                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                },
+                "oidc3Auth": {
+                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                },
+                "oidcAuth": {
+                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                },
+                "recordAuthToken": {
+                    "$ref": "#/definitions/apis.TokenConfig"
+                },
+                "recordEmailChangeToken": {
+                    "$ref": "#/definitions/apis.TokenConfig"
+                },
+                "recordFileToken": {
+                    "$ref": "#/definitions/apis.TokenConfig"
+                },
+                "recordPasswordResetToken": {
+                    "$ref": "#/definitions/apis.TokenConfig"
+                },
+                "recordVerificationToken": {
+                    "$ref": "#/definitions/apis.TokenConfig"
+                },
+                "s3": {
+                    "$ref": "#/definitions/apis.S3Config"
+                },
+                "smtp": {
+                    "$ref": "#/definitions/apis.SmtpConfig"
+                },
+                "spotifyAuth": {
+                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                },
+                "stravaAuth": {
+                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                },
+                "twitchAuth": {
+                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                },
+                "twitterAuth": {
+                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                }
+            }
+        },
+        "apis.SignedRequestCreate": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string"
+                },
+                "expiresIn": {
+                    "description": "ExpiresIn is the signed url lifetime in seconds (default to 1 hour, max 1 day).",
+                    "type": "integer"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "path": {
+                    "description": "Path is the request path with its query parameters\n(eg. \"/api/collections/subscribers/records/RECORD_ID?expand=user\").",
+                    "type": "string"
+                }
+            }
+        },
+        "apis.SignedRequestResult": {
+            "type": "object",
+            "properties": {
+                "expires": {
+                    "$ref": "#/definitions/types.DateTime"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "apis.SlowQueryItem": {
+            "type": "object",
+            "properties": {
+                "avg": {
+                    "type": "number"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "indexSuggestions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.IndexSuggestion"
+                    }
+                },
+                "lastExec": {
+                    "type": "string"
+                },
+                "max": {
+                    "type": "number"
+                },
+                "p95": {
+                    "type": "number"
+                },
+                "query": {
+                    "type": "string"
+                }
+            }
+        },
+        "apis.SmtpConfig": {
+            "type": "object",
+            "properties": {
+                "authMethod": {
+                    "description": "SMTP AUTH - PLAIN (default) or LOGIN",
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "host": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "port": {
+                    "type": "integer"
+                },
+                "tls": {
+                    "description": "Whether to enforce TLS encryption for the mail server connection.\n\nWhen set to false StartTLS command is send, leaving the server\nto decide whether to upgrade the connection or not.",
+                    "type": "boolean"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "apis.SqlConsoleRunRequest": {
+            "type": "object",
+            "properties": {
+                "explain": {
+                    "type": "boolean"
+                },
+                "params": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "query": {
+                    "type": "string"
+                },
+                "write": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "apis.SqlQueryRunRequest": {
+            "type": "object",
+            "properties": {
+                "explain": {
+                    "type": "boolean"
+                },
+                "params": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "write": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "apis.StorageUsage": {
+            "type": "object",
+            "properties": {
+                "dedup": {
+                    "description": "Dedup is the deduplicated file objects usage (incl. the saved bytes).",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/daos.FileDedupStats"
+                        }
+                    ]
+                },
+                "dedupEnabled": {
+                    "description": "DedupEnabled reports whether the uploads deduplication is currently enabled.",
+                    "type": "boolean"
+                },
+                "files": {
+                    "description": "Files and Size are the total number and size in bytes\nof the stored files (including the thumbs and file objects).",
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "apis.TestEmailSettingsRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "template": {
+                    "type": "string"
+                }
+            }
+        },
+        "apis.TestS3SettingsRequest": {
+            "type": "object",
+            "properties": {
+                "filesystem": {
+                    "description": "The name of the filesystem - storage or backups",
+                    "type": "string"
+                }
+            }
+        },
+        "apis.TokenConfig": {
+            "type": "object",
+            "properties": {
+                "duration": {
+                    "type": "integer"
+                },
+                "secret": {
+                    "type": "string"
+                }
+            }
+        },
+        "apis.TransactionOperation": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "collection": {
+                    "type": "string"
+                },
+                "data": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "apis.TransactionRequest": {
+            "type": "object",
+            "properties": {
+                "operations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/apis.TransactionOperation"
+                    }
+                }
+            }
+        },
+        "apis.TranslationStatus": {
+            "type": "object",
+            "properties": {
+                "collection": {
+                    "type": "string"
+                },
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/apis.FieldTranslationStatus"
+                    }
+                },
+                "locales": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "totalItems": {
+                    "description": "TotalItems is the total number of the collection records\n(0 if the collection doesn't have localized fields).",
+                    "type": "integer"
+                }
+            }
+        },
+        "apis.UpdateSettingsRequest": {
+            "type": "object",
+            "properties": {
+                "adminAuthToken": {
+                    "$ref": "#/definitions/apis.TokenConfig"
+                },
+                "adminFileToken": {
+                    "$ref": "#/definitions/apis.TokenConfig"
+                },
+                "adminPasswordResetToken": {
+                    "$ref": "#/definitions/apis.TokenConfig"
+                },
+                "appleAuth": {
+                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                },
+                "backups": {
+                    "$ref": "#/definitions/apis.BackupsConfig"
+                },
+                "discordAuth": {
+                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                },
+                "emailAuth": {
+                    "description": "Deprecated: Will be removed in v0.9+",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/apis.EmailAuthConfig"
+                        }
+                    ]
+                },
+                "envLocked": {
+                    "description": "EnvLocked is the list of the settings fields overridden by the\nPB_ prefixed environment variables (eg. \"smtp.host\" by PB_SMTP_HOST).",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "facebookAuth": {
+                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                },
+                "giteaAuth": {
+                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                },
+                "giteeAuth": {
+                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                },
+                "githubAuth": {
+                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                },
+                "gitlabAuth": {
+                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                },
+                "googleAuth": {
+                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                },
+                "kakaoAuth": {
+                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                },
+                "livechatAuth": {
+                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                },
+                "logs": {
+                    "$ref": "#/definitions/apis.LogsConfig"
+                },
+                "meta": {
+                    "type": "object",
+                    "properties": {
+                        "appName": {
+                            "type": "string"
+                        },
+                        "appUrl": {
+                            "type": "string"
+                        },
+                        "confirmEmailChangeTemplate": {
+                            "$ref": "#/definitions/apis.EmailTemplate"
+                        },
+                        "hideControls": {
+                            "type": "boolean"
+                        },
+                        "resetPasswordTemplate": {
+                            "$ref": "#/definitions/apis.EmailTemplate"
+                        },
+                        "senderAddress": {
+                            "type": "string"
+                        },
+                        "senderName": {
+                            "type": "string"
+                        },
+                        "verificationTemplate": {
+                            "$ref": "#/definitions/apis.EmailTemplate"
+                        }
+                    }
+                },
+                "microsoftAuth": {
+                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                },
+                "oidc2Auth": {
+                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                },
+                "oidc3Auth": {
+                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                },
+                "oidcAuth": {
+                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                },
+                "recordAuthToken": {
+                    "$ref": "#/definitions/apis.TokenConfig"
+                },
+                "recordEmailChangeToken": {
+                    "$ref": "#/definitions/apis.TokenConfig"
+                },
+                "recordFileToken": {
+                    "$ref": "#/definitions/apis.TokenConfig"
+                },
+                "recordPasswordResetToken": {
+                    "$ref": "#/definitions/apis.TokenConfig"
+                },
+                "recordVerificationToken": {
+                    "$ref": "#/definitions/apis.TokenConfig"
+                },
+                "s3": {
+                    "$ref": "#/definitions/apis.S3Config"
+                },
+                "smtp": {
+                    "$ref": "#/definitions/apis.SmtpConfig"
+                },
+                "spotifyAuth": {
+                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                },
+                "stravaAuth": {
+                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                },
+                "twitchAuth": {
+                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                },
+                "twitterAuth": {
+                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                }
+            }
+        },
+        "apis.UserAuthResponse": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "user": {
+                    "$ref": "#/definitions/apis.UserDataID"
+                }
+            }
+        },
+        "apis.UserAuthWithPassword": {
+            "type": "object",
+            "properties": {
+                "identity": {
+                    "description": "Identity is the user name or email.",
+                    "type": "string",
+                    "example": "userX"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "pass1234"
+                }
+            }
+        },
+        "apis.UserDataID": {
+            "type": "object",
+            "properties": {
+                "deleted_at": {
+                    "description": "DeletedAt is set only for the soft deleted users\n(see the listUsers includeDeleted flag).",
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "userx@worldline.com"
+                },
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "admin",
+                        "group1"
+                    ]
+                },
+                "id": {
+                    "type": "string",
+                    "example": "cf8a07d4-077e-402e-a46b-ac0ed50989ec"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "userX"
+                }
+            }
+        },
+        "apis.UserGroup": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "admin"
+                },
+                "userIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "cf8a07d4-077e-402e-a46b-ac0ed50989ec"
+                    ]
+                }
+            }
+        },
+        "apis.UserPasswordChange": {
+            "type": "object",
+            "properties": {
+                "oldPassword": {
+                    "type": "string",
+                    "example": "pass1234"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "pass12345"
+                },
+                "passwordConfirm": {
+                    "type": "string",
+                    "example": "pass12345"
+                }
+            }
+        },
+        "apis.UserProfile": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "userx@worldline.com"
+                },
+                "password": {
+                    "description": "Password is the current user password required to confirm the profile change.",
+                    "type": "string",
+                    "example": "pass1234"
+                }
+            }
+        },
+        "apis.UsersImport": {
+            "type": "object",
+            "properties": {
+                "deleteMissing": {
+                    "description": "DeleteMissing soft deletes all existing users whose name\nis not found in the imported Users list.",
+                    "type": "boolean"
+                },
+                "transforms": {
+                    "description": "Transforms is the list of the field transformation steps\napplied on each imported user before its validation.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/forms.ImportTransform"
+                    }
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.UserPure"
+                    }
+                }
+            }
+        },
+        "apis.UsersImportError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "name is required"
+                },
+                "index": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "name": {
+                    "type": "string",
+                    "example": "userX"
+                }
+            }
+        },
+        "apis.UsersImportResult": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/apis.ID"
+                    }
+                },
+                "deleted": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "updated": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/apis.ID"
+                    }
+                }
+            }
+        },
+        "core.BackupProgress": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "percent": {
+                    "type": "integer"
+                },
+                "stage": {
+                    "type": "string"
+                },
+                "started": {
+                    "$ref": "#/definitions/types.DateTime"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated": {
+                    "$ref": "#/definitions/types.DateTime"
+                }
+            }
+        },
+        "core.SecurityAlert": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "core.SecurityReport": {
+            "type": "object",
+            "properties": {
+                "alerts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/core.SecurityAlert"
+                    }
+                },
+                "authFailures": {
+                    "description": "AuthFailures lists the IPs with the most failed auth attempts.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/daos.RequestsIpStatsItem"
+                    }
+                },
+                "countries": {
+                    "description": "Countries lists the client countries in the report period\n(available only if SecurityReportConfig.GeoHeader is set).",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/daos.RequestsCountryStatsItem"
+                    }
+                },
+                "forbidden": {
+                    "description": "Forbidden lists the IPs with the most 403 responses.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/daos.RequestsIpStatsItem"
+                    }
+                },
+                "forbiddenBaseline": {
+                    "description": "ForbiddenBaseline is the average number of 403 responses\nper report period during the baseline days.",
+                    "type": "number"
+                },
+                "forbiddenTotal": {
+                    "description": "ForbiddenTotal is the number of all 403 responses in the report period.",
+                    "type": "integer"
+                },
+                "from": {
+                    "$ref": "#/definitions/types.DateTime"
+                },
+                "newCountries": {
+                    "description": "NewCountries lists the client countries that were\nnot seen during the baseline days.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "to": {
+                    "$ref": "#/definitions/types.DateTime"
+                }
+            }
+        },
+        "daos.FileDedupStats": {
+            "type": "object",
+            "properties": {
+                "objects": {
+                    "description": "Objects is the total number of the stored file objects.",
+                    "type": "integer"
+                },
+                "refs": {
+                    "description": "Refs is the total number of the file object references.",
+                    "type": "integer"
+                },
+                "savedSize": {
+                    "description": "SavedSize is the total size in bytes that would have been\nadditionally stored without the deduplication.",
+                    "type": "integer"
+                },
+                "storedSize": {
+                    "description": "StoredSize is the total size in bytes of the stored file objects.",
+                    "type": "integer"
+                }
+            }
+        },
+        "daos.RequestsCountryStatsItem": {
+            "type": "object",
+            "properties": {
+                "country": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "daos.RequestsIpStatsItem": {
+            "type": "object",
+            "properties": {
+                "ip": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "daos.RequestsStatsItem": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "$ref": "#/definitions/types.DateTime"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "flags.Rule": {
+            "type": "object",
+            "properties": {
+                "attribute": {
+                    "description": "Attribute is the subject attribute to match (eg. \"groups\" or \"email\").\n\nLeave empty to match all subjects.",
+                    "type": "string"
+                },
+                "percentage": {
+                    "description": "Percentage is the rollout percentage (0-100)\nof the subjects matching the rule.",
+                    "type": "integer"
+                },
+                "values": {
+                    "description": "Values is the list of the attribute values to match.\n\nFor multiple values attributes (eg. groups) the rule matches\nif at least one of the subject values is in the list.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "forms.AdminImpersonate": {
+            "type": "object",
+            "properties": {
+                "duration": {
+                    "description": "Duration is the token duration in seconds (if not set, [DefaultImpersonateDuration]\nor the record auth token duration is used, whichever is shorter).",
+                    "type": "integer"
+                }
+            }
+        },
+        "forms.AdminOtpDisable": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Code is a valid TOTP code or an unused recovery code.",
+                    "type": "string"
+                }
+            }
+        },
+        "forms.AdminOtpVerify": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
+        "forms.AnalyticsQueryResult": {
+            "type": "object",
+            "properties": {
+                "columns": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "duration": {
+                    "description": "Duration is the query execution time in milliseconds.",
+                    "type": "number"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {}
+                    }
+                },
+                "rowsAffected": {
+                    "type": "integer"
+                },
+                "snapshot": {
+                    "description": "Snapshot is the date of the last analytics snapshot refresh.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.DateTime"
+                        }
+                    ]
+                },
+                "truncated": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "forms.AnonymizedBundle": {
+            "type": "object",
+            "properties": {
+                "collections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/forms.AnonymizedBundleCollection"
+                    }
+                },
+                "created": {
+                    "$ref": "#/definitions/types.DateTime"
+                },
+                "profile": {
+                    "type": "string"
+                }
+            }
+        },
+        "forms.AnonymizedBundleCollection": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "records": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "additionalProperties": {}
+                    }
+                },
+                "schema": {
+                    "$ref": "#/definitions/github_com_pocketbase_pocketbase_models_schema.Schema"
+                },
+                "truncated": {
+                    "description": "Truncated indicates that the collection has more records than the form limit.",
+                    "type": "boolean"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "forms.AnonymizedBundleExport": {
+            "type": "object",
+            "properties": {
+                "collections": {
+                    "description": "Collections is a list with the names or ids of the collections\nto export (all collections if empty).",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "limit": {
+                    "description": "Limit is the max number of exported records per collection.",
+                    "type": "integer"
+                },
+                "profile": {
+                    "type": "string"
+                }
+            }
+        },
+        "forms.ApiKeyCreate": {
+            "type": "object",
+            "properties": {
+                "collection": {
+                    "description": "Collection and RecordId are the optional auth record\n(collection name or id) that the key will act as.",
+                    "type": "string"
+                },
+                "expires": {
+                    "description": "Expires is the optional key expiration date.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.DateTime"
+                        }
+                    ]
+                },
+                "name": {
+                    "type": "string"
+                },
+                "recordId": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "forms.ApiKeyCreateResult": {
+            "type": "object",
+            "properties": {
+                "adminId": {
+                    "description": "AdminId is the id of the admin that the key acts as\n(empty if the key belongs to an auth record).",
+                    "type": "string"
+                },
+                "collectionId": {
+                    "description": "CollectionId and RecordId are the auth record that the key\nacts as (empty if the key belongs to an admin).",
+                    "type": "string"
+                },
+                "created": {
+                    "$ref": "#/definitions/types.DateTime"
+                },
+                "expires": {
+                    "description": "Expires is the optional key expiration date (zero means never).",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.DateTime"
+                        }
+                    ]
+                },
+                "id": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "lastUsed": {
+                    "$ref": "#/definitions/types.DateTime"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "description": "Prefix is the first characters of the plain key used for the\nkey lookup and to help identifying the key in the admin UI.",
+                    "type": "string"
+                },
+                "recordId": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updated": {
+                    "$ref": "#/definitions/types.DateTime"
+                }
+            }
+        },
+        "forms.CollectionDiff": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/forms.CollectionFieldDiff"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "properties": {
+                    "description": "Properties lists the changed collection properties (name, rules, options, etc.).",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "forms.CollectionFieldDiff": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "new": {
+                    "$ref": "#/definitions/schema.SchemaField"
+                },
+                "old": {
+                    "description": "Old and New are the current and the submitted field state\n(Old is nil for the created fields and New for the deleted ones).",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schema.SchemaField"
+                        }
+                    ]
+                },
+                "properties": {
+                    "description": "Properties lists the changed field properties of the updated field.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "forms.CollectionsImportChange": {
+            "type": "object",
+            "properties": {
+                "createdFields": {
+                    "description": "CreatedFields, UpdatedFields and DeletedFields list the names of the changed schema fields.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "deletedFields": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "properties": {
+                    "description": "Properties lists the changed collection properties (name, rules, options, etc.).",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updatedFields": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "forms.CollectionsImportPlan": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/forms.CollectionsImportChange"
+                    }
+                },
+                "deleted": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/forms.CollectionsImportChange"
+                    }
+                },
+                "hasChanges": {
+                    "type": "boolean"
+                },
+                "updated": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/forms.CollectionsImportChange"
+                    }
+                }
+            }
+        },
+        "forms.CommentModerate": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "forms.CommentUpsert": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "parentId": {
+                    "type": "string"
+                }
+            }
+        },
+        "forms.DeclarativeAdmin": {
+            "type": "object",
+            "properties": {
+                "avatar": {
+                    "type": "integer"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "forms.DeclarativeApply": {
+            "type": "object",
+            "properties": {
+                "admins": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/forms.DeclarativeAdmin"
+                    }
+                },
+                "collections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Collection"
+                    }
+                },
+                "deleteMissing": {
+                    "description": "DeleteMissing deletes the existing collections, collection fields\nand admins that are not found in the document.",
+                    "type": "boolean"
+                },
+                "settings": {
+                    "description": "Settings is a partial settings object that is merged with the current app settings.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "forms.DeclarativeChange": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "fields": {
+                    "description": "Fields lists the changed properties of the updated item (if any).",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "description": "Name is the collection name, the admin email or the settings key.",
+                    "type": "string"
+                }
+            }
+        },
+        "forms.DeclarativePlan": {
+            "type": "object",
+            "properties": {
+                "admins": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/forms.DeclarativeChange"
+                    }
+                },
+                "collections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/forms.DeclarativeChange"
+                    }
+                },
+                "hasChanges": {
+                    "type": "boolean"
+                },
+                "settings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/forms.DeclarativeChange"
+                    }
+                }
+            }
+        },
+        "forms.FeatureFlagUpsert": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "rollout": {
+                    "type": "integer"
+                },
+                "rules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/flags.Rule"
+                    }
+                }
+            }
+        },
+        "forms.ImportTransform": {
+            "type": "object",
+            "properties": {
+                "collection": {
+                    "description": "Collection and LookupField specify the related records that are\nlooked up by the source value and whose id replaces the field value\n(used by the \"lookup\" transformation).",
+                    "type": "string"
+                },
+                "field": {
+                    "description": "Field is the name of the transformed row field.",
+                    "type": "string"
+                },
+                "format": {
+                    "description": "Format is the Go time layout of the source value\n(eg. \"02.01.2006\") used by the \"date\" transformation.",
+                    "type": "string"
+                },
+                "lookupField": {
+                    "type": "string"
+                },
+                "type": {
+                    "description": "Type is the transformation type (trim, lowercase, date, lookup or default).",
+                    "type": "string"
+                },
+                "value": {
+                    "description": "Value is the value to set if the field is missing or empty\n(used by the \"default\" transformation)."
+                }
+            }
+        },
+        "forms.OrgInviteUpsert": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "forms.OrgMemberUpdate": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "forms.OrgUpsert": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "forms.RateLimitUpsert": {
+            "type": "object",
+            "properties": {
+                "duration": {
+                    "type": "integer"
+                },
+                "maxRequests": {
+                    "type": "integer"
+                },
+                "note": {
+                    "type": "string"
+                }
+            }
+        },
+        "forms.RealtimeBroadcast": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "topic": {
+                    "type": "string"
+                }
+            }
+        },
+        "forms.RealtimeBroadcastResult": {
+            "type": "object",
+            "properties": {
+                "clients": {
+                    "description": "Clients is the number of the subscribed clients the message was sent to.",
+                    "type": "integer"
+                },
+                "topic": {
+                    "type": "string"
+                }
+            }
+        },
+        "forms.RecordIncrementField": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Amount is the value to add to the field (use a negative one to decrement).",
+                    "type": "number"
+                },
+                "max": {
+                    "type": "number"
+                },
+                "min": {
+                    "description": "Min and Max are optional bounds to clamp the incremented value.\n\nThey are intersected with the field schema min/max options.",
+                    "type": "number"
+                }
+            }
+        },
+        "forms.S3EventIngestResult": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "field": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "recordId": {
+                    "type": "string"
+                }
+            }
+        },
+        "forms.S3EventRecord": {
+            "type": "object",
+            "properties": {
+                "eventName": {
+                    "type": "string"
+                },
+                "s3": {
+                    "type": "object",
+                    "properties": {
+                        "object": {
+                            "type": "object",
+                            "properties": {
+                                "key": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "forms.S3EventsIngest": {
+            "type": "object",
+            "properties": {
+                "Records": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/forms.S3EventRecord"
+                    }
+                },
+                "field": {
+                    "description": "Field is an optional file field name where the created objects will be registered.\n\nIf not set, the collection must have exactly one file field.",
+                    "type": "string"
+                }
+            }
+        },
+        "forms.ScheduledExportUpsert": {
+            "type": "object",
+            "properties": {
+                "collection": {
+                    "type": "string"
+                },
+                "destination": {
+                    "type": "string"
+                },
+                "emails": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "filter": {
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "schedule": {
+                    "type": "string"
+                },
+                "sort": {
+                    "type": "string"
+                }
+            }
+        },
+        "forms.SqlConsoleResult": {
+            "type": "object",
+            "properties": {
+                "columns": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "duration": {
+                    "description": "Duration is the query execution time in milliseconds.",
+                    "type": "number"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {}
+                    }
+                },
+                "rowsAffected": {
+                    "type": "integer"
+                },
+                "truncated": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "forms.SqlQueryUpsert": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "query": {
+                    "type": "string"
+                }
+            }
+        },
+        "forms.StripeWebhookResult": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "eventId": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "recordId": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_pocketbase_pocketbase_models_schema.Schema": {
+            "type": "object"
+        },
+        "graphql.Error": {
+            "type": "object",
+            "properties": {
+                "extensions": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "message": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "array",
+                    "items": {}
+                }
+            }
+        },
+        "graphql.Request": {
+            "type": "object",
+            "properties": {
+                "operationName": {
+                    "type": "string"
+                },
+                "query": {
+                    "type": "string"
+                },
+                "variables": {
+                    "type": "object",
+                    "additionalProperties": {}
+                }
+            }
+        },
+        "graphql.Response": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/graphql.Error"
+                    }
+                }
+            }
+        },
+        "models.ApiKey": {
+            "type": "object",
+            "properties": {
+                "adminId": {
+                    "description": "AdminId is the id of the admin that the key acts as\n(empty if the key belongs to an auth record).",
+                    "type": "string"
+                },
+                "collectionId": {
+                    "description": "CollectionId and RecordId are the auth record that the key\nacts as (empty if the key belongs to an admin).",
+                    "type": "string"
+                },
+                "created": {
+                    "$ref": "#/definitions/types.DateTime"
+                },
+                "expires": {
+                    "description": "Expires is the optional key expiration date (zero means never).",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.DateTime"
+                        }
+                    ]
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lastUsed": {
+                    "$ref": "#/definitions/types.DateTime"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "description": "Prefix is the first characters of the plain key used for the\nkey lookup and to help identifying the key in the admin UI.",
+                    "type": "string"
+                },
+                "recordId": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updated": {
+                    "$ref": "#/definitions/types.DateTime"
+                }
+            }
+        },
+        "models.Approval": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "body": {
+                    "type": "string"
+                },
+                "created": {
+                    "$ref": "#/definitions/types.DateTime"
+                },
+                "decidedAt": {
+                    "$ref": "#/definitions/types.DateTime"
+                },
+                "decidedBy": {
+                    "type": "string"
+                },
+                "expires": {
+                    "$ref": "#/definitions/types.DateTime"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "requestedBy": {
+                    "description": "RequestedBy and DecidedBy are the ids of the requester\nand of the approving (or rejecting) admins.",
+                    "type": "string"
+                },
+                "response": {
+                    "type": "string"
+                },
+                "responseStatus": {
+                    "description": "ResponseStatus and Response are the result of the executed request.",
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated": {
+                    "$ref": "#/definitions/types.DateTime"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AuditLog": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor": {
+                    "description": "Actor is the request auth type (see ` + "`" + `Request.Auth` + "`" + `).",
+                    "type": "string"
+                },
+                "actorId": {
+                    "type": "string"
+                },
+                "changes": {
+                    "description": "Changes holds the old and new values of the changed\nfields indexed by their json path (eg. \"options.minLength\").\n\nFor the SQL console executions it holds the executed query,\nits params and outcome (eg. \"sqlQuery\", \"sqlRows\", \"sqlError\").",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.JsonMap"
+                        }
+                    ]
+                },
+                "created": {
+                    "$ref": "#/definitions/types.DateTime"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "resource": {
+                    "type": "string"
+                },
+                "resourceId": {
+                    "type": "string"
+                },
+                "updated": {
+                    "$ref": "#/definitions/types.DateTime"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "userIp": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Collection": {
+            "type": "object",
+            "properties": {
+                "createRule": {
+                    "type": "string"
+                },
+                "created": {
+                    "$ref": "#/definitions/types.DateTime"
+                },
+                "deleteRule": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "indexes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "listRule": {
+                    "description": "rules",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "options": {
+                    "$ref": "#/definitions/types.JsonMap"
+                },
+                "schema": {
+                    "$ref": "#/definitions/github_com_pocketbase_pocketbase_models_schema.Schema"
+                },
+                "system": {
+                    "type": "boolean"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updateRule": {
+                    "type": "string"
+                },
+                "updated": {
+                    "$ref": "#/definitions/types.DateTime"
+                },
+                "viewRule": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Comment": {
+            "type": "object",
+            "properties": {
+                "authorCollectionId": {
+                    "description": "AuthorCollectionId and AuthorId are the comment author auth record.",
+                    "type": "string"
+                },
+                "authorId": {
+                    "type": "string"
+                },
+                "collectionId": {
+                    "description": "CollectionId and RecordId are the thread owner record.",
+                    "type": "string"
+                },
+                "created": {
+                    "$ref": "#/definitions/types.DateTime"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "mentions": {
+                    "description": "Mentions is the list with the ids of the auth records\nmentioned in the message (eg. \"@username\").",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "parentId": {
+                    "description": "ParentId is the id of the replied comment (empty for top level comments).",
+                    "type": "string"
+                },
+                "recordId": {
                     "type": "string"
                 },
-                "id": {
+                "status": {
                     "type": "string"
                 },
                 "updated": {
-                    "type": "object"
+                    "$ref": "#/definitions/types.DateTime"
                 }
             }
         },
-        "apis.AdminCreateForm": {
+        "models.FeatureFlag": {
             "type": "object",
             "properties": {
-                "avatar": {
-                    "type": "integer"
+                "created": {
+                    "$ref": "#/definitions/types.DateTime"
                 },
-                "email": {
+                "description": {
                     "type": "string"
                 },
+                "enabled": {
+                    "type": "boolean"
+                },
                 "id": {
                     "type": "string"
                 },
-                "password": {
+                "key": {
                     "type": "string"
                 },
-                "passwordConfirm": {
-                    "type": "string"
+                "rollout": {
+                    "description": "Rollout is the rollout percentage (0-100) of the\nsubjects that don't match any of the rules.",
+                    "type": "integer"
+                },
+                "rules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/flags.Rule"
+                    }
+                },
+                "updated": {
+                    "$ref": "#/definitions/types.DateTime"
                 }
             }
         },
-        "apis.AdminLogin": {
+        "models.IndexSuggestion": {
             "type": "object",
             "properties": {
-                "identity": {
+                "collection": {
                     "type": "string"
                 },
-                "password": {
+                "column": {
+                    "type": "string"
+                },
+                "sql": {
                     "type": "string"
                 }
             }
         },
-        "apis.AdminPasswordResetConfirm": {
+        "models.Org": {
             "type": "object",
             "properties": {
-                "password": {
+                "created": {
+                    "$ref": "#/definitions/types.DateTime"
+                },
+                "id": {
                     "type": "string"
                 },
-                "passwordConfirm": {
+                "name": {
                     "type": "string"
                 },
-                "token": {
+                "slug": {
                     "type": "string"
+                },
+                "updated": {
+                    "$ref": "#/definitions/types.DateTime"
                 }
             }
         },
-        "apis.AdminPasswordResetRequest": {
+        "models.OrgInvite": {
             "type": "object",
             "properties": {
+                "created": {
+                    "$ref": "#/definitions/types.DateTime"
+                },
                 "email": {
                     "type": "string"
+                },
+                "expires": {
+                    "$ref": "#/definitions/types.DateTime"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "orgId": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "updated": {
+                    "$ref": "#/definitions/types.DateTime"
                 }
             }
         },
-        "apis.AdminUpdateForm": {
+        "models.OrgMember": {
             "type": "object",
             "properties": {
-                "avatar": {
-                    "type": "integer"
-                },
-                "email": {
+                "collectionId": {
+                    "description": "CollectionId and RecordId are the member auth record.",
                     "type": "string"
                 },
+                "created": {
+                    "$ref": "#/definitions/types.DateTime"
+                },
                 "id": {
                     "type": "string"
                 },
-                "password": {
+                "orgId": {
                     "type": "string"
                 },
-                "passwordConfirm": {
+                "recordId": {
+                    "type": "string"
+                },
+                "role": {
                     "type": "string"
+                },
+                "updated": {
+                    "$ref": "#/definitions/types.DateTime"
                 }
             }
         },
-        "apis.AuthProviderConfig": {
+        "models.RateLimit": {
             "type": "object",
             "properties": {
-                "authUrl": {
-                    "type": "string"
+                "created": {
+                    "$ref": "#/definitions/types.DateTime"
                 },
-                "clientId": {
+                "duration": {
+                    "description": "Duration is the rate limit window in seconds.",
+                    "type": "integer"
+                },
+                "id": {
                     "type": "string"
                 },
-                "clientSecret": {
+                "identityId": {
                     "type": "string"
                 },
-                "enabled": {
-                    "type": "boolean"
+                "maxRequests": {
+                    "description": "MaxRequests is the max number of allowed requests\nper Duration window (0 means unlimited).",
+                    "type": "integer"
                 },
-                "tokenUrl": {
+                "note": {
                     "type": "string"
                 },
-                "userApiUrl": {
+                "updated": {
+                    "$ref": "#/definitions/types.DateTime"
+                }
+            }
+        },
+        "models.Record": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "$ref": "#/definitions/types.DateTime"
+                },
+                "id": {
                     "type": "string"
+                },
+                "updated": {
+                    "$ref": "#/definitions/types.DateTime"
                 }
             }
         },
-        "apis.BackupCreateRequest": {
+        "models.RecordDataProblem": {
             "type": "object",
             "properties": {
-                "name": {
+                "code": {
+                    "type": "string"
+                },
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "recordId": {
                     "type": "string"
+                },
+                "suggestedValue": {
+                    "description": "SuggestedValue is the auto-fix value suggestion\ncompatible with the current field schema."
+                },
+                "value": {
+                    "description": "Value is the raw stored field value."
                 }
             }
         },
-        "apis.BackupFileInfo": {
+        "models.RecordLock": {
             "type": "object",
             "properties": {
-                "key": {
+                "collectionId": {
+                    "description": "CollectionId and RecordId are the locked record.",
                     "type": "string"
                 },
-                "modified": {
-                    "type": "object"
+                "created": {
+                    "$ref": "#/definitions/types.DateTime"
                 },
-                "size": {
-                    "type": "integer"
+                "expires": {
+                    "$ref": "#/definitions/types.DateTime"
+                },
+                "holderCollectionId": {
+                    "description": "HolderCollectionId and HolderId are the lock holder auth record\n(HolderCollectionId is empty for admin holders).",
+                    "type": "string"
+                },
+                "holderId": {
+                    "type": "string"
+                },
+                "holderType": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "recordId": {
+                    "type": "string"
+                },
+                "updated": {
+                    "$ref": "#/definitions/types.DateTime"
                 }
             }
         },
-        "apis.BackupsConfig": {
+        "models.RecordRevision": {
             "type": "object",
             "properties": {
-                "cron": {
-                    "description": "Cron is a cron expression to schedule auto backups, eg. \"* * * * *\".\n\nLeave it empty to disable the auto backups functionality.",
+                "collectionId": {
+                    "description": "CollectionId and RecordId are the versioned record.",
                     "type": "string"
                 },
-                "cronMaxKeep": {
-                    "description": "CronMaxKeep is the the max number of cron generated backups to\nkeep before removing older entries.\n\nThis field works only when the cron config has valid cron expression.",
-                    "type": "integer"
+                "created": {
+                    "$ref": "#/definitions/types.DateTime"
                 },
-                "s3": {
-                    "description": "S3 is an optional S3 storage config specifying where to store the app backups.",
+                "data": {
+                    "description": "Data holds the record schema fields values\nas they were right before the update.",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/apis.S3Config"
+                            "$ref": "#/definitions/types.JsonMap"
                         }
                     ]
+                },
+                "id": {
+                    "type": "string"
+                },
+                "recordId": {
+                    "type": "string"
+                },
+                "revision": {
+                    "description": "Revision is the auto incremented per record revision number.",
+                    "type": "integer"
+                },
+                "updated": {
+                    "$ref": "#/definitions/types.DateTime"
                 }
             }
         },
-        "apis.Collection": {
+        "models.RecordShare": {
             "type": "object",
             "properties": {
-                "createRule": {
+                "collectionId": {
+                    "description": "CollectionId and RecordId are the shared record.",
                     "type": "string"
                 },
                 "created": {
-                    "type": "object"
+                    "$ref": "#/definitions/types.DateTime"
                 },
-                "deleteRule": {
+                "id": {
                     "type": "string"
                 },
-                "id": {
+                "permission": {
                     "type": "string"
                 },
-                "indexes": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "recordId": {
+                    "type": "string"
                 },
-                "listRule": {
-                    "description": "rules",
+                "subjectCollectionId": {
+                    "description": "SubjectCollectionId and SubjectId are the auth record\n(for user subjects) or the group record (for group subjects).",
                     "type": "string"
                 },
-                "name": {
+                "subjectId": {
                     "type": "string"
                 },
-                "options": {
-                    "type": "object",
-                    "additionalProperties": {}
+                "subjectType": {
+                    "type": "string"
                 },
-                "schema": {
-                    "type": "object"
+                "updated": {
+                    "$ref": "#/definitions/types.DateTime"
+                }
+            }
+        },
+        "models.Request": {
+            "type": "object",
+            "properties": {
+                "auth": {
+                    "type": "string"
                 },
-                "system": {
-                    "type": "boolean"
+                "created": {
+                    "$ref": "#/definitions/types.DateTime"
                 },
-                "type": {
+                "id": {
                     "type": "string"
                 },
-                "updateRule": {
+                "meta": {
+                    "$ref": "#/definitions/types.JsonMap"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "referer": {
+                    "type": "string"
+                },
+                "remoteIp": {
                     "type": "string"
                 },
+                "status": {
+                    "type": "integer"
+                },
                 "updated": {
-                    "type": "object"
+                    "$ref": "#/definitions/types.DateTime"
                 },
-                "viewRule": {
+                "url": {
+                    "type": "string"
+                },
+                "userAgent": {
+                    "type": "string"
+                },
+                "userIp": {
                     "type": "string"
                 }
             }
         },
-        "apis.CollectionCreateRequest": {
+        "models.SavedQuery": {
             "type": "object",
             "properties": {
-                "createRule": {
+                "collectionId": {
                     "type": "string"
                 },
-                "deleteRule": {
+                "created": {
+                    "$ref": "#/definitions/types.DateTime"
+                },
+                "expand": {
                     "type": "string"
                 },
-                "id": {
+                "fields": {
                     "type": "string"
                 },
-                "indexes": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "filter": {
+                    "type": "string"
                 },
-                "listRule": {
+                "id": {
                     "type": "string"
                 },
                 "name": {
+                    "description": "Name is the unique query identifier within its collection.",
                     "type": "string"
                 },
-                "options": {
-                    "type": "object",
-                    "additionalProperties": {}
-                },
-                "schema": {
-                    "type": "object"
-                },
-                "system": {
-                    "type": "boolean"
+                "ownerCollectionId": {
+                    "description": "OwnerCollectionId and OwnerId are the query owner auth record\n(OwnerCollectionId is empty for admin owners).",
+                    "type": "string"
                 },
-                "type": {
+                "ownerId": {
                     "type": "string"
                 },
-                "updateRule": {
+                "ownerType": {
                     "type": "string"
                 },
-                "viewRule": {
+                "shared": {
+                    "description": "Shared makes the query visible to everyone with\naccess to the collection records list (and not only to its owner).",
+                    "type": "boolean"
+                },
+                "sort": {
                     "type": "string"
+                },
+                "updated": {
+                    "$ref": "#/definitions/types.DateTime"
                 }
             }
         },
-        "apis.CollectionsImportRequest": {
+        "models.ScheduledExport": {
             "type": "object",
             "properties": {
-                "collections": {
+                "collectionId": {
+                    "type": "string"
+                },
+                "created": {
+                    "$ref": "#/definitions/types.DateTime"
+                },
+                "destination": {
+                    "description": "Destination is the export delivery type (s3 or email).",
+                    "type": "string"
+                },
+                "emails": {
+                    "description": "Emails is the list of the export link recipients (used only with the email destination).",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/apis.Collection"
+                        "type": "string"
                     }
                 },
-                "deleteMissing": {
+                "enabled": {
                     "type": "boolean"
-                }
-            }
-        },
-        "apis.CreateRecordRequest": {
-            "type": "object",
-            "properties": {
-                "email": {
+                },
+                "filter": {
                     "type": "string"
                 },
-                "emailVisibility": {
-                    "type": "boolean"
+                "format": {
+                    "description": "Format is the exported file format (csv, json, xlsx or parquet).",
+                    "type": "string"
                 },
                 "id": {
-                    "description": "base model fields",
                     "type": "string"
                 },
-                "oldPassword": {
+                "lastError": {
+                    "type": "string"
+                },
+                "lastFile": {
+                    "type": "string"
+                },
+                "lastRun": {
+                    "description": "LastRun, LastFile and LastError describe the result of the latest export run.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.DateTime"
+                        }
+                    ]
+                },
+                "name": {
+                    "description": "Name is the unique export identifier (it is also used as exported file name prefix).",
                     "type": "string"
                 },
-                "password": {
+                "prefix": {
+                    "description": "Prefix is the storage key prefix of the exported files.",
                     "type": "string"
                 },
-                "passwordConfirm": {
+                "schedule": {
+                    "description": "Schedule is the cron expression of the export runs.",
                     "type": "string"
                 },
-                "username": {
-                    "description": "auth collection fields\n---",
+                "sort": {
                     "type": "string"
                 },
-                "verified": {
-                    "type": "boolean"
+                "updated": {
+                    "$ref": "#/definitions/types.DateTime"
                 }
             }
         },
-        "apis.EmailAuthConfig": {
+        "models.SettingsHistory": {
             "type": "object",
             "properties": {
-                "enabled": {
-                    "type": "boolean"
+                "adminId": {
+                    "description": "AdminId is the id of the admin that changed the settings\n(empty if the change wasn't made by an admin).",
+                    "type": "string"
                 },
-                "exceptDomains": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "created": {
+                    "$ref": "#/definitions/types.DateTime"
                 },
-                "minPasswordLength": {
-                    "type": "integer"
+                "diff": {
+                    "description": "Diff holds the redacted old and new values of the changed\nsettings fields indexed by their json path (eg. \"smtp.host\").",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.JsonMap"
+                        }
+                    ]
                 },
-                "onlyDomains": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "id": {
+                    "type": "string"
+                },
+                "updated": {
+                    "$ref": "#/definitions/types.DateTime"
+                },
+                "version": {
+                    "description": "Version is the auto incremented settings change number.",
+                    "type": "integer"
                 }
             }
         },
-        "apis.EmailTemplate": {
+        "models.SqlQuery": {
             "type": "object",
             "properties": {
-                "actionUrl": {
+                "created": {
+                    "$ref": "#/definitions/types.DateTime"
+                },
+                "description": {
                     "type": "string"
                 },
-                "body": {
+                "id": {
                     "type": "string"
                 },
-                "subject": {
+                "name": {
+                    "type": "string"
+                },
+                "query": {
                     "type": "string"
+                },
+                "updated": {
+                    "$ref": "#/definitions/types.DateTime"
                 }
             }
         },
-        "apis.ExternalAuthResponse": {
+        "models.Usage": {
             "type": "object",
             "properties": {
                 "collectionId": {
                     "type": "string"
                 },
                 "created": {
-                    "type": "object"
+                    "$ref": "#/definitions/types.DateTime"
                 },
                 "id": {
                     "type": "string"
                 },
-                "provider": {
+                "metric": {
                     "type": "string"
                 },
-                "providerId": {
+                "orgId": {
+                    "type": "string"
+                },
+                "period": {
                     "type": "string"
                 },
                 "recordId": {
                     "type": "string"
                 },
                 "updated": {
-                    "type": "object"
+                    "$ref": "#/definitions/types.DateTime"
+                },
+                "value": {
+                    "type": "integer"
                 }
             }
         },
-        "apis.LogsConfig": {
+        "models.UsageSummary": {
             "type": "object",
             "properties": {
-                "maxDays": {
-                    "type": "integer"
+                "metrics": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "period": {
+                    "type": "string"
                 }
             }
         },
-        "apis.RealtimeSubscribeForm": {
+        "models.UserPure": {
             "type": "object",
             "properties": {
-                "clientId": {
-                    "type": "string"
+                "email": {
+                    "type": "string",
+                    "example": "userx@worldline.com"
                 },
-                "subscriptions": {
+                "groups": {
                     "type": "array",
                     "items": {
                         "type": "string"
-                    }
+                    },
+                    "example": [
+                        "admin",
+                        "group1"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "example": "userX"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "pass1234"
                 }
             }
         },
-        "apis.S3Config": {
+        "models.WebhookDelivery": {
             "type": "object",
             "properties": {
-                "accessKey": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created": {
+                    "$ref": "#/definitions/types.DateTime"
+                },
+                "error": {
+                    "description": "Error is the error message of the last failed attempt.",
                     "type": "string"
                 },
-                "bucket": {
+                "event": {
+                    "description": "Event is the delivered event in the format \"resource.action\",\neg. \"records.create\".",
                     "type": "string"
                 },
-                "enabled": {
-                    "type": "boolean"
+                "id": {
+                    "type": "string"
                 },
-                "endpoint": {
+                "payload": {
+                    "description": "Payload is the signed json request body.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "responseStatus": {
+                    "description": "ResponseStatus is the HTTP status code of the last\nattempt response (0 if there wasn't a response).",
+                    "type": "integer"
+                },
+                "status": {
                     "type": "string"
                 },
-                "forcePathStyle": {
-                    "type": "boolean"
+                "updated": {
+                    "$ref": "#/definitions/types.DateTime"
                 },
-                "region": {
+                "url": {
+                    "description": "Url is the webhook endpoint url of the last attempt.",
                     "type": "string"
                 },
-                "secret": {
+                "webhook": {
+                    "description": "Webhook is the name of the delivery webhook settings\n(see ` + "`" + `settings.WebhooksConfig.Hooks` + "`" + `).",
                     "type": "string"
                 }
             }
         },
-        "apis.SearchResult": {
+        "ratelimit.Usage": {
             "type": "object",
             "properties": {
-                "items": {},
-                "page": {
-                    "type": "integer"
-                },
-                "perPage": {
-                    "type": "integer"
-                },
-                "totalItems": {
+                "limit": {
+                    "description": "Limit is the max number of allowed requests per window (0 means unlimited).",
                     "type": "integer"
                 },
-                "totalPages": {
+                "remaining": {
+                    "description": "Remaining is the number of requests left in the current window\n(-1 if the limit is unlimited).",
                     "type": "integer"
-                }
-            }
-        },
-        "apis.Settings": {
-            "type": "object",
-            "properties": {
-                "adminAuthToken": {
-                    "$ref": "#/definitions/apis.TokenConfig"
-                },
-                "adminFileToken": {
-                    "$ref": "#/definitions/apis.TokenConfig"
-                },
-                "adminPasswordResetToken": {
-                    "$ref": "#/definitions/apis.TokenConfig"
                 },
-                "appleAuth": {
-                    "$ref": "#/definitions/apis.AuthProviderConfig"
-                },
-                "backups": {
-                    "$ref": "#/definitions/apis.BackupsConfig"
-                },
-                "discordAuth": {
-                    "$ref": "#/definitions/apis.AuthProviderConfig"
-                },
-                "emailAuth": {
-                    "description": "Deprecated: Will be removed in v0.9+",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/apis.EmailAuthConfig"
-                        }
-                    ]
-                },
-                "facebookAuth": {
-                    "$ref": "#/definitions/apis.AuthProviderConfig"
-                },
-                "giteaAuth": {
-                    "$ref": "#/definitions/apis.AuthProviderConfig"
-                },
-                "giteeAuth": {
-                    "$ref": "#/definitions/apis.AuthProviderConfig"
-                },
-                "githubAuth": {
-                    "$ref": "#/definitions/apis.AuthProviderConfig"
-                },
-                "gitlabAuth": {
-                    "$ref": "#/definitions/apis.AuthProviderConfig"
-                },
-                "googleAuth": {
-                    "$ref": "#/definitions/apis.AuthProviderConfig"
-                },
-                "kakaoAuth": {
-                    "$ref": "#/definitions/apis.AuthProviderConfig"
-                },
-                "livechatAuth": {
-                    "$ref": "#/definitions/apis.AuthProviderConfig"
-                },
-                "logs": {
-                    "$ref": "#/definitions/apis.LogsConfig"
-                },
-                "meta": {
-                    "type": "object",
-                    "properties": {
-                        "appName": {
-                            "type": "string"
-                        },
-                        "appUrl": {
-                            "type": "string"
-                        },
-                        "confirmEmailChangeTemplate": {
-                            "$ref": "#/definitions/apis.EmailTemplate"
-                        },
-                        "hideControls": {
-                            "type": "boolean"
-                        },
-                        "resetPasswordTemplate": {
-                            "$ref": "#/definitions/apis.EmailTemplate"
-                        },
-                        "senderAddress": {
-                            "type": "string"
-                        },
-                        "senderName": {
-                            "type": "string"
-                        },
-                        "verificationTemplate": {
-                            "$ref": "#/definitions/apis.EmailTemplate"
-                        }
-                    }
-                },
-                "microsoftAuth": {
-                    "$ref": "#/definitions/apis.AuthProviderConfig"
-                },
-                "oidc2Auth": {
-                    "$ref": "#/definitions/apis.AuthProviderConfig"
-                },
-                "oidc3Auth": {
-                    "$ref": "#/definitions/apis.AuthProviderConfig"
-                },
-                "oidcAuth": {
-                    "$ref": "#/definitions/apis.AuthProviderConfig"
-                },
-                "recordAuthToken": {
-                    "$ref": "#/definitions/apis.TokenConfig"
-                },
-                "recordEmailChangeToken": {
-                    "$ref": "#/definitions/apis.TokenConfig"
-                },
-                "recordFileToken": {
-                    "$ref": "#/definitions/apis.TokenConfig"
-                },
-                "recordPasswordResetToken": {
-                    "$ref": "#/definitions/apis.TokenConfig"
-                },
-                "recordVerificationToken": {
-                    "$ref": "#/definitions/apis.TokenConfig"
-                },
-                "s3": {
-                    "$ref": "#/definitions/apis.S3Config"
-                },
-                "smtp": {
-                    "$ref": "#/definitions/apis.SmtpConfig"
-                },
-                "spotifyAuth": {
-                    "$ref": "#/definitions/apis.AuthProviderConfig"
-                },
-                "stravaAuth": {
-                    "$ref": "#/definitions/apis.AuthProviderConfig"
-                },
-                "twitchAuth": {
-                    "$ref": "#/definitions/apis.AuthProviderConfig"
-                },
-                "twitterAuth": {
-                    "$ref": "#/definitions/apis.AuthProviderConfig"
-                }
-            }
-        },
-        "apis.SmtpConfig": {
-            "type": "object",
-            "properties": {
-                "authMethod": {
-                    "description": "SMTP AUTH - PLAIN (default) or LOGIN",
+                "resetAt": {
+                    "description": "ResetAt is the end of the current window (zero if there is no active window).",
                     "type": "string"
                 },
-                "enabled": {
-                    "type": "boolean"
-                },
-                "host": {
+                "used": {
+                    "description": "Used is the number of consumed requests in the current window.",
+                    "type": "integer"
+                }
+            }
+        },
+        "schema.SchemaField": {
+            "type": "object",
+            "properties": {
+                "id": {
                     "type": "string"
                 },
-                "password": {
+                "name": {
                     "type": "string"
                 },
-                "port": {
-                    "type": "integer"
+                "options": {},
+                "required": {
+                    "type": "boolean"
                 },
-                "tls": {
-                    "description": "Whether to enforce TLS encryption for the mail server connection.\n\nWhen set to false StartTLS command is send, leaving the server\nto decide whether to upgrade the connection or not.",
+                "system": {
                     "type": "boolean"
                 },
-                "username": {
+                "type": {
                     "type": "string"
+                },
+                "unique": {
+                    "description": "Deprecated: This field is no-op and will be removed in future versions.\nPlease use the collection.Indexes field to define a unique constraint.",
+                    "type": "boolean"
                 }
             }
         },
-        "apis.TestEmailSettingsRequest": {
+        "scim.Error": {
             "type": "object",
             "properties": {
-                "email": {
+                "detail": {
                     "type": "string"
                 },
-                "template": {
+                "schemas": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "scimType": {
+                    "type": "string"
+                },
+                "status": {
                     "type": "string"
                 }
             }
         },
-        "apis.TestS3SettingsRequest": {
+        "scim.Group": {
             "type": "object",
             "properties": {
-                "filesystem": {
-                    "description": "The name of the filesystem - storage or backups",
+                "displayName": {
+                    "type": "string"
+                },
+                "id": {
                     "type": "string"
+                },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/scim.MultiValue"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/scim.Meta"
+                },
+                "schemas": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "apis.TokenConfig": {
+        "scim.ListResponse": {
             "type": "object",
             "properties": {
-                "duration": {
+                "Resources": {},
+                "itemsPerPage": {
                     "type": "integer"
                 },
-                "secret": {
-                    "type": "string"
+                "schemas": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "startIndex": {
+                    "type": "integer"
+                },
+                "totalResults": {
+                    "type": "integer"
                 }
             }
         },
-        "apis.UpdateSettingsRequest": {
+        "scim.Meta": {
             "type": "object",
             "properties": {
-                "adminAuthToken": {
-                    "$ref": "#/definitions/apis.TokenConfig"
+                "created": {
+                    "type": "string"
                 },
-                "adminFileToken": {
-                    "$ref": "#/definitions/apis.TokenConfig"
+                "lastModified": {
+                    "type": "string"
                 },
-                "adminPasswordResetToken": {
-                    "$ref": "#/definitions/apis.TokenConfig"
+                "location": {
+                    "type": "string"
                 },
-                "appleAuth": {
-                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                "resourceType": {
+                    "type": "string"
+                }
+            }
+        },
+        "scim.MultiValue": {
+            "type": "object",
+            "properties": {
+                "$ref": {
+                    "type": "string"
                 },
-                "backups": {
-                    "$ref": "#/definitions/apis.BackupsConfig"
+                "display": {
+                    "type": "string"
                 },
-                "discordAuth": {
-                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                "primary": {
+                    "type": "boolean"
                 },
-                "emailAuth": {
-                    "description": "Deprecated: Will be removed in v0.9+",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/apis.EmailAuthConfig"
-                        }
-                    ]
+                "type": {
+                    "type": "string"
                 },
-                "facebookAuth": {
-                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "scim.PatchOperation": {
+            "type": "object",
+            "properties": {
+                "op": {
+                    "type": "string"
                 },
-                "giteaAuth": {
-                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                "path": {
+                    "type": "string"
                 },
-                "giteeAuth": {
-                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                "value": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "scim.PatchRequest": {
+            "type": "object",
+            "properties": {
+                "Operations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/scim.PatchOperation"
+                    }
                 },
-                "githubAuth": {
-                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                "schemas": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "scim.User": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
                 },
-                "gitlabAuth": {
-                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                "emails": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/scim.MultiValue"
+                    }
                 },
-                "googleAuth": {
-                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/scim.MultiValue"
+                    }
                 },
-                "kakaoAuth": {
-                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                "id": {
+                    "type": "string"
                 },
-                "livechatAuth": {
-                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                "meta": {
+                    "$ref": "#/definitions/scim.Meta"
                 },
-                "logs": {
-                    "$ref": "#/definitions/apis.LogsConfig"
+                "password": {
+                    "description": "Password is write only and it is never returned.",
+                    "type": "string"
                 },
-                "meta": {
-                    "type": "object",
-                    "properties": {
-                        "appName": {
-                            "type": "string"
-                        },
-                        "appUrl": {
-                            "type": "string"
-                        },
-                        "confirmEmailChangeTemplate": {
-                            "$ref": "#/definitions/apis.EmailTemplate"
-                        },
-                        "hideControls": {
-                            "type": "boolean"
-                        },
-                        "resetPasswordTemplate": {
-                            "$ref": "#/definitions/apis.EmailTemplate"
-                        },
-                        "senderAddress": {
-                            "type": "string"
-                        },
-                        "senderName": {
-                            "type": "string"
-                        },
-                        "verificationTemplate": {
-                            "$ref": "#/definitions/apis.EmailTemplate"
-                        }
+                "schemas": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
                     }
                 },
-                "microsoftAuth": {
-                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                "userName": {
+                    "type": "string"
+                }
+            }
+        },
+        "search.Result": {
+            "type": "object",
+            "properties": {
+                "items": {},
+                "page": {
+                    "type": "integer"
                 },
-                "oidc2Auth": {
-                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                "perPage": {
+                    "type": "integer"
                 },
-                "oidc3Auth": {
-                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                "totalItems": {
+                    "type": "integer"
                 },
-                "oidcAuth": {
-                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                "totalPages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "secrets.Status": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
                 },
-                "recordAuthToken": {
-                    "$ref": "#/definitions/apis.TokenConfig"
+                "field": {
+                    "type": "string"
                 },
-                "recordEmailChangeToken": {
-                    "$ref": "#/definitions/apis.TokenConfig"
+                "provider": {
+                    "type": "string"
                 },
-                "recordFileToken": {
-                    "$ref": "#/definitions/apis.TokenConfig"
+                "reference": {
+                    "type": "string"
                 },
-                "recordPasswordResetToken": {
-                    "$ref": "#/definitions/apis.TokenConfig"
+                "resolved": {
+                    "type": "boolean"
                 },
-                "recordVerificationToken": {
-                    "$ref": "#/definitions/apis.TokenConfig"
+                "resolvedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "security.JWK": {
+            "type": "object",
+            "properties": {
+                "alg": {
+                    "type": "string"
                 },
-                "s3": {
-                    "$ref": "#/definitions/apis.S3Config"
+                "crv": {
+                    "description": "OKP (Ed25519) specific",
+                    "type": "string"
                 },
-                "smtp": {
-                    "$ref": "#/definitions/apis.SmtpConfig"
+                "e": {
+                    "type": "string"
                 },
-                "spotifyAuth": {
-                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                "kid": {
+                    "type": "string"
                 },
-                "stravaAuth": {
-                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                "kty": {
+                    "type": "string"
                 },
-                "twitchAuth": {
-                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                "n": {
+                    "description": "RSA specific",
+                    "type": "string"
                 },
-                "twitterAuth": {
-                    "$ref": "#/definitions/apis.AuthProviderConfig"
+                "use": {
+                    "type": "string"
+                },
+                "x": {
+                    "type": "string"
                 }
             }
+        },
+        "types.DateTime": {
+            "type": "object"
+        },
+        "types.JsonMap": {
+            "type": "object",
+            "additionalProperties": {}
         }
     }
 }`
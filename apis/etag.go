@@ -0,0 +1,118 @@
+package apis
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+const (
+	HeaderETag    = "ETag"
+	HeaderIfMatch = "If-Match"
+)
+
+// errETagMismatch is returned by [submitIfMatch] when none of the
+// request If-Match entity tags matches the stored model state.
+var errETagMismatch = errors.New("etag mismatch")
+
+// modelETag returns the entity tag of the provided model.
+//
+// The tag is a hash of the model stored column values (including the
+// updated timestamp), so that two different writes within the same
+// millisecond still produce different tags.
+func modelETag(m models.Model) string {
+	var content any = m
+
+	switch v := m.(type) {
+	case models.ColumnValueMapper:
+		content = v.ColumnValueMap()
+	case *models.Admin:
+		// the token key is not exported but it changes with the admin password
+		content = []any{v, v.TokenKey}
+	}
+
+	raw, _ := json.Marshal(content)
+
+	hash := sha256.Sum256(raw)
+
+	return `"` + hex.EncodeToString(hash[:16]) + `"`
+}
+
+// setETag sets the ETag response header of the provided model.
+func setETag(c echo.Context, m models.Model) {
+	c.Response().Header().Set(HeaderETag, modelETag(m))
+}
+
+// matchIfMatch reports whether the request If-Match header is not set,
+// is "*" or at least one of its entity tags is equal to etag.
+func matchIfMatch(c echo.Context, etag string) bool {
+	header := strings.TrimSpace(c.Request().Header.Get(HeaderIfMatch))
+	if header == "" || header == "*" {
+		return true
+	}
+
+	for _, tag := range strings.Split(header, ",") {
+		if strings.TrimSpace(tag) == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// submitIfMatch calls submit in a single transaction with the check
+// of the request If-Match header against the stored model state
+// (reloaded with find).
+//
+// Because the check and the write share the same transaction,
+// two concurrent requests with the same If-Match header couldn't both
+// pass it. The form dao is replaced with the transaction one for the
+// duration of the submit call.
+//
+// Returns errETagMismatch if the stored model was modified in the meantime.
+// Requests without If-Match header (or with "*") are submitted as it is.
+func submitIfMatch(
+	c echo.Context,
+	dao *daos.Dao,
+	form interface{ SetDao(dao *daos.Dao) },
+	find func(txDao *daos.Dao) (models.Model, error),
+	submit func() error,
+) error {
+	if matchIfMatch(c, "") {
+		return submit()
+	}
+
+	defer form.SetDao(dao)
+
+	return dao.RunInTransaction(func(txDao *daos.Dao) error {
+		stored, err := find(txDao)
+		if err != nil {
+			return err
+		}
+
+		if !matchIfMatch(c, modelETag(stored)) {
+			return errETagMismatch
+		}
+
+		form.SetDao(txDao)
+
+		return submit()
+	})
+}
+
+// newETagMismatchError returns a 409 api error for the errETagMismatch
+// error or a 400 api error with the provided message for the rest.
+func newETagMismatchError(err error, message string) *ApiError {
+	if errors.Is(err, errETagMismatch) {
+		return NewApiError(http.StatusConflict, "The resource was modified by another request.", nil)
+	}
+
+	return NewBadRequestError(message, err)
+}
@@ -0,0 +1,54 @@
+package apis
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/forms"
+)
+
+// bindExportApi registers the data export api endpoints.
+func bindExportApi(app core.App, rg *echo.Group) {
+	api := exportApi{app: app}
+
+	subGroup := rg.Group("/export", ActivityLogger(app), RequireAdminAuth())
+	subGroup.POST("/anonymized-bundle", api.anonymizedBundle)
+}
+
+type exportApi struct {
+	app core.App
+}
+
+//	@Summary		Export anonymized bundle
+//	@Description	Exports the records of the selected collections (all if empty) anonymized with the specified settings anonymization profile
+//	@Tags			Export
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body	forms.AnonymizedBundleExport	true	"Export options"
+//	@Security		AdminAuth
+//	@Success		200	{object}	forms.AnonymizedBundle
+//	@Failure		400	{string}	string	"Failed to export the anonymized bundle."
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Router			/export/anonymized-bundle [post]
+func (api *exportApi) anonymizedBundle(c echo.Context) error {
+	form := forms.NewAnonymizedBundleExport(api.app)
+
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("Failed to load the submitted data due to invalid formatting.", err)
+	}
+
+	return form.Submit(func(next forms.InterceptorNextFunc[*forms.AnonymizedBundle]) forms.InterceptorNextFunc[*forms.AnonymizedBundle] {
+		return func(bundle *forms.AnonymizedBundle) error {
+			if err := next(bundle); err != nil {
+				return NewBadRequestError("Failed to export the anonymized bundle.", err)
+			}
+
+			filename := fmt.Sprintf("%s_%s.json", bundle.Profile, bundle.Created.Time().Format("20060102150405"))
+			c.Response().Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+
+			return c.JSON(http.StatusOK, bundle)
+		}
+	})
+}
@@ -0,0 +1,218 @@
+package apis
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/flags"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+// bindFeatureFlagApi registers the feature flags api endpoints.
+func bindFeatureFlagApi(app core.App, rg *echo.Group) {
+	api := featureFlagApi{app: app}
+
+	// public (guests are evaluated only against the 100% rollouts)
+	rg.GET("/flags/evaluate", api.evaluate)
+
+	subGroup := rg.Group("/flags", ActivityLogger(app), RequireAdminAuth())
+	subGroup.GET("", api.list)
+	subGroup.POST("", api.create)
+	subGroup.GET("/:key", api.view)
+	subGroup.PATCH("/:key", api.update)
+	subGroup.DELETE("/:key", api.delete)
+}
+
+type featureFlagApi struct {
+	app core.App
+}
+
+//	@Summary		List feature flags
+//	@Description	Returns a paginated feature flags list
+//	@Tags			FeatureFlags
+//	@Produce		json
+//	@Param			page	query	int		false	"Page number"
+//	@Param			perPage	query	int		false	"Items per page"
+//	@Param			sort	query	string	false	"Sort fields"
+//	@Param			filter	query	string	false	"Filter expression"
+//	@Security		AdminAuth
+//	@Success		200	{object}	search.Result{items=[]models.FeatureFlag}
+//	@Failure		400	{string}	string	"Something went wrong while processing your request."
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Router			/flags [get]
+func (api *featureFlagApi) list(c echo.Context) error {
+	fieldResolver := search.NewSimpleFieldResolver(
+		"id", "created", "updated", "key", "description", "enabled", "rollout",
+	)
+
+	featureFlags := []*models.FeatureFlag{}
+
+	result, err := search.NewProvider(fieldResolver).
+		Query(api.app.Dao().FeatureFlagQuery()).
+		ParseAndExec(c.QueryParams().Encode(), &featureFlags)
+
+	if err != nil {
+		return NewBadRequestError("", err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+//	@Summary		View feature flag
+//	@Description	Returns a single feature flag by its key
+//	@Tags			FeatureFlags
+//	@Produce		json
+//	@Param			key	path	string	true	"Feature flag key"
+//	@Security		AdminAuth
+//	@Success		200	{object}	models.FeatureFlag
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Failure		404	{string}	string	"The requested resource wasn't found."
+//	@Router			/flags/{key} [get]
+func (api *featureFlagApi) view(c echo.Context) error {
+	flag, err := api.app.Dao().FindFeatureFlagByKey(c.PathParam("key"))
+	if err != nil || flag == nil {
+		return NewNotFoundError("", err)
+	}
+
+	return c.JSON(http.StatusOK, flag)
+}
+
+//	@Summary		Create feature flag
+//	@Description	Creates a new feature flag with its targeting rules
+//	@Tags			FeatureFlags
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body	forms.FeatureFlagUpsert	true	"Feature flag data"
+//	@Security		AdminAuth
+//	@Success		200	{object}	models.FeatureFlag
+//	@Failure		400	{string}	string	"Failed to create the feature flag."
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Router			/flags [post]
+func (api *featureFlagApi) create(c echo.Context) error {
+	return api.upsert(c, &models.FeatureFlag{}, "Failed to create the feature flag.")
+}
+
+//	@Summary		Update feature flag
+//	@Description	Updates the feature flag with the specified key
+//	@Tags			FeatureFlags
+//	@Accept			json
+//	@Produce		json
+//	@Param			key		path	string					true	"Feature flag key"
+//	@Param			body	body	forms.FeatureFlagUpsert	true	"Feature flag data"
+//	@Security		AdminAuth
+//	@Success		200	{object}	models.FeatureFlag
+//	@Failure		400	{string}	string	"Failed to update the feature flag."
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Failure		404	{string}	string	"The requested resource wasn't found."
+//	@Router			/flags/{key} [patch]
+func (api *featureFlagApi) update(c echo.Context) error {
+	flag, err := api.app.Dao().FindFeatureFlagByKey(c.PathParam("key"))
+	if err != nil || flag == nil {
+		return NewNotFoundError("", err)
+	}
+
+	return api.upsert(c, flag, "Failed to update the feature flag.")
+}
+
+func (api *featureFlagApi) upsert(c echo.Context, flag *models.FeatureFlag, failMessage string) error {
+	form := forms.NewFeatureFlagUpsert(api.app, flag)
+
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("Failed to load the submitted data due to invalid formatting.", err)
+	}
+
+	return form.Submit(func(next forms.InterceptorNextFunc[*models.FeatureFlag]) forms.InterceptorNextFunc[*models.FeatureFlag] {
+		return func(m *models.FeatureFlag) error {
+			if err := next(m); err != nil {
+				return NewBadRequestError(failMessage, err)
+			}
+
+			return c.JSON(http.StatusOK, m)
+		}
+	})
+}
+
+//	@Summary		Delete feature flag
+//	@Description	Deletes the feature flag with the specified key
+//	@Tags			FeatureFlags
+//	@Param			key	path	string	true	"Feature flag key"
+//	@Security		AdminAuth
+//	@Success		204	"No Content"
+//	@Failure		400	{string}	string	"Failed to delete the feature flag."
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Failure		404	{string}	string	"The requested resource wasn't found."
+//	@Router			/flags/{key} [delete]
+func (api *featureFlagApi) delete(c echo.Context) error {
+	flag, err := api.app.Dao().FindFeatureFlagByKey(c.PathParam("key"))
+	if err != nil || flag == nil {
+		return NewNotFoundError("", err)
+	}
+
+	if err := api.app.Dao().DeleteFeatureFlag(flag); err != nil {
+		return NewBadRequestError("Failed to delete the feature flag.", err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+//	@Summary		Evaluate feature flags
+//	@Description	Evaluates the feature flags for the requesting auth record (or guest) and returns a map with the flag key and its state (unknown keys are evaluated as disabled)
+//	@Tags			FeatureFlags
+//	@Produce		json
+//	@Param			keys	query	string	false	"Comma separated list of flag keys (all flags if empty)"
+//	@Success		200	{object}	map[string]bool
+//	@Failure		400	{string}	string	"Failed to load the feature flags."
+//	@Router			/flags/evaluate [get]
+func (api *featureFlagApi) evaluate(c echo.Context) error {
+	keys := list.ToUniqueStringSlice(strings.Split(c.QueryParam("keys"), ","))
+
+	featureFlags, err := api.app.Dao().FindFeatureFlagsByKeys(keys...)
+	if err != nil {
+		return NewBadRequestError("Failed to load the feature flags.", err)
+	}
+
+	subject := featureFlagSubject(c)
+
+	result := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		result[key] = false
+	}
+	for _, flag := range featureFlags {
+		result[flag.Key] = flags.Evaluate(flag.Flag(), subject).Enabled
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// featureFlagSubject returns the feature flags evaluation subject
+// of the current request (the authorized auth record or admin).
+func featureFlagSubject(c echo.Context) flags.Subject {
+	if record, _ := c.Get(ContextAuthRecordKey).(*models.Record); record != nil {
+		attributes := record.PublicExport()
+
+		// allow targeting by the auth fields regardless of their visibility
+		attributes[schema.FieldNameEmail] = record.Email()
+		attributes[schema.FieldNameVerified] = record.Verified()
+
+		return flags.Subject{Id: record.Id, Attributes: attributes}
+	}
+
+	if admin, _ := c.Get(ContextAdminKey).(*models.Admin); admin != nil {
+		return flags.Subject{
+			Id: admin.Id,
+			Attributes: map[string]any{
+				"id":    admin.Id,
+				"email": admin.Email,
+				"admin": true,
+			},
+		}
+	}
+
+	return flags.Subject{}
+}
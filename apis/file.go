@@ -1,41 +1,62 @@
 package apis
 
 import (
+	"context"
+	"crypto/subtle"
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/labstack/echo/v5"
 	"github.com/pocketbase/dbx"
 	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/forms"
 	"github.com/pocketbase/pocketbase/models"
 	"github.com/pocketbase/pocketbase/models/schema"
 	"github.com/pocketbase/pocketbase/resolvers"
 	"github.com/pocketbase/pocketbase/tokens"
+	"github.com/pocketbase/pocketbase/tools/diskcache"
+	"github.com/pocketbase/pocketbase/tools/filesystem"
 	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/routine"
 	"github.com/pocketbase/pocketbase/tools/search"
 	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/types"
 	"github.com/spf13/cast"
 )
 
-var imageContentTypes = []string{"image/png", "image/jpg", "image/jpeg", "image/gif"}
-var defaultThumbSizes = []string{"100x100"}
-
 // bindFileApi registers the file api endpoints and the corresponding handlers.
 func bindFileApi(app core.App, rg *echo.Group) {
-	api := fileApi{app: app}
+	api := fileApi{
+		app:         app,
+		imagesCache: diskcache.New(filepath.Join(app.DataDir(), core.LocalImagesCacheDirName), 0),
+	}
 
 	subGroup := rg.Group("/files", ActivityLogger(app))
 
 	subGroup.POST("/token", api.fileToken)
+	subGroup.POST("/s3-events", api.s3Events)
+	subGroup.POST("/warm-thumbs", api.warmThumbs, RequireAdminAuth())
+	subGroup.OPTIONS("/uploads", api.uploadsOptions)
+	subGroup.POST("/uploads", api.createUpload, RequireAdminOrRecordAuth())
+	subGroup.HEAD("/uploads/:id", api.uploadOffset, RequireAdminOrRecordAuth())
+	subGroup.PATCH("/uploads/:id", api.uploadChunk, RequireAdminOrRecordAuth())
+	subGroup.DELETE("/uploads/:id", api.deleteUpload, RequireAdminOrRecordAuth())
 	subGroup.HEAD("/:collection/:recordId/:filename", api.download, LoadCollectionContext(api.app))
 	subGroup.GET("/:collection/:recordId/:filename", api.download, LoadCollectionContext(api.app))
+	subGroup.GET("/:collection/:recordId/:filename/signed-url", api.signedUrl, LoadCollectionContext(api.app))
 }
 
 type fileApi struct {
-	app core.App
+	app         core.App
+	imagesCache *diskcache.Cache
 }
 
 //	@Summary		Генерировать токен файла
@@ -50,6 +71,7 @@ type fileApi struct {
 func (api *fileApi) fileToken(c echo.Context) error {
 	event := new(core.FileTokenEvent)
 	event.HttpContext = c
+	event.RequestInfo = RequestInfo(c)
 
 	if admin, _ := c.Get(ContextAdminKey).(*models.Admin); admin != nil {
 		event.Model = admin
@@ -78,6 +100,58 @@ func (api *fileApi) fileToken(c echo.Context) error {
 	return handlerErr
 }
 
+//	@Summary		Ingest S3 event notifications
+//	@Description	Registers the objects uploaded directly to the storage bucket as record file values
+//	@Description	(and unregisters the removed ones). The object keys must follow the "{collectionId}/{recordId}/{filename}" format.
+//	@Tags			Files
+//	@Accept			json
+//	@Produce		json
+//	@Security		AdminAuth
+//	@Param			field	query	string					false	"File field name (required if the collection has more than one file field)"
+//	@Param			body	body	forms.S3EventsIngest	true	"S3 event notification"
+//	@Success		200		{array}		forms.S3EventIngestResult
+//	@Failure		400		{string}	string	"Failed to process the S3 events."
+//	@Failure		401		{string}	string	"Missing or invalid S3 events token."
+//	@Failure		404		{string}	string	"The S3 events ingestion is disabled."
+//	@Router			/files/s3-events [post]
+func (api *fileApi) s3Events(c echo.Context) error {
+	config := api.app.Settings().S3Events
+	if !config.Enabled {
+		return NewNotFoundError("The S3 events ingestion is disabled.", nil)
+	}
+
+	// the admins are allowed without the events token (eg. for manual replays)
+	admin, _ := c.Get(ContextAdminKey).(*models.Admin)
+	if admin == nil {
+		token := strings.TrimPrefix(c.Request().Header.Get("Authorization"), "Bearer ")
+
+		if token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(config.Token)) != 1 {
+			return NewUnauthorizedError("Missing or invalid S3 events token.", nil)
+		}
+	}
+
+	form := forms.NewS3EventsIngest(api.app)
+	form.SetContext(c.Request().Context())
+
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	if form.Field == "" {
+		form.Field = c.QueryParam("field")
+	}
+
+	return form.Submit(func(next forms.InterceptorNextFunc[[]*forms.S3EventIngestResult]) forms.InterceptorNextFunc[[]*forms.S3EventIngestResult] {
+		return func(results []*forms.S3EventIngestResult) error {
+			if err := next(results); err != nil {
+				return NewBadRequestError("Failed to process the S3 events.", err)
+			}
+
+			return c.JSON(http.StatusOK, results)
+		}
+	})
+}
+
 //	@Summary		Загрузить файл
 //	@Description	Загружает файл
 //	@Tags			Files
@@ -88,6 +162,9 @@ func (api *fileApi) fileToken(c echo.Context) error {
 //	@Param			filename	path	string	true	"Имя файла"
 //	@Param			token		query	string	false	"Токен доступа к файлу"
 //	@Param			thumb		query	string	false	"Размер эскиза (если применимо)"
+//	@Param			resize		query	string	false	"Произвольный размер изображения в формате WxH, WxHt, WxHb или WxHf (не более fileServe.imageTransformMaxDimension)"
+//	@Param			format		query	string	false	"Формат изображения: jpeg, png или gif (по умолчанию формат исходного файла)"
+//	@Param			quality		query	int		false	"Качество jpeg изображения (1-100)"
 //	@Success		200			"Файл загружен"
 //	@Failure		400			{string}	string	"Failed to authenticate."
 //	@Failure		403			{string}	string	"Not exists."
@@ -95,31 +172,272 @@ func (api *fileApi) fileToken(c echo.Context) error {
 //	@Router			/files/{collection}/{recordId}/{filename} [head]
 //	@Router			/files/{collection}/{recordId}/{filename} [get]
 func (api *fileApi) download(c echo.Context) error {
+	file, err := api.findRequestedFile(c)
+	if err != nil {
+		return err
+	}
+
+	fs, err := api.app.NewFilesystem()
+	if err != nil {
+		return NewBadRequestError("Filesystem initialization failure.", err)
+	}
+	defer fs.Close()
+
+	fs.SetServeChunkSize(api.app.Settings().FileServe.ChunkSize)
+
+	transform, err := api.requestedImageTransform(c)
+	if err != nil {
+		return err
+	}
+
+	thumbSize := c.QueryParam("thumb")
+	if transform != nil {
+		thumbSize = "" // the transform is always applied on the original
+	}
+
+	servedPath, servedName, err := api.resolveServedFile(fs, file, thumbSize)
+	if err != nil {
+		return err
+	}
+
+	if transform != nil {
+		attrs, err := fs.Attributes(servedPath)
+		if err != nil {
+			return NewNotFoundError("", err)
+		}
+
+		if !list.ExistInSlice(attrs.ContentType, core.ThumbImageContentTypes) {
+			return NewBadRequestError("Only png, jpg and gif images can be transformed.", nil)
+		}
+
+		if transform.Format != "" {
+			servedName = strings.TrimSuffix(servedName, filepath.Ext(servedName)) + "." + strings.ToLower(transform.Format)
+		}
+	}
+
+	event := new(core.FileDownloadEvent)
+	event.HttpContext = c
+	event.RequestInfo = RequestInfo(c)
+	event.Collection = file.collection
+	event.Record = file.record
+	event.FileField = file.field
+	event.ServedPath = servedPath
+	event.ServedName = servedName
+
+	// clickjacking shouldn't be a concern when serving uploaded files,
+	// so it safe to unset the global X-Frame-Options to allow files embedding
+	// (note: it is out of the hook to allow users to customize the behavior)
+	c.Response().Header().Del("X-Frame-Options")
+
+	return api.app.OnFileDownloadRequest().Trigger(event, func(e *core.FileDownloadEvent) error {
+		res := e.HttpContext.Response()
+		req := e.HttpContext.Request()
+
+		setFileFieldServeHeaders(res.Header(), file.options, e.ServedName)
+
+		if csp := api.app.Settings().SecurityHeaders.FilesContentSecurityPolicy; csp != "" && res.Header().Get("Content-Security-Policy") == "" {
+			res.Header().Set("Content-Security-Policy", csp)
+		}
+
+		if transform != nil {
+			return api.serveTransformedImage(fs, e, transform)
+		}
+
+		if err := fs.Serve(res, req, e.ServedPath, e.ServedName); err != nil {
+			return NewNotFoundError("", err)
+		}
+
+		return nil
+	})
+}
+
+// requestedImageTransform loads the on-the-fly image transform
+// options from the request query parameters.
+//
+// Returns nil if none of the transform parameters are set.
+func (api *fileApi) requestedImageTransform(c echo.Context) (*filesystem.ImageTransform, error) {
+	resize := c.QueryParam("resize")
+	format := c.QueryParam("format")
+	quality := c.QueryParam("quality")
+
+	if resize == "" && format == "" && quality == "" {
+		return nil, nil
+	}
+
+	transform := &filesystem.ImageTransform{
+		Resize: resize,
+		Format: format,
+	}
+
+	if quality != "" {
+		q, err := strconv.Atoi(quality)
+		if err != nil || q < 1 {
+			return nil, NewBadRequestError("Image quality must be in the range 1-100.", err)
+		}
+		transform.Quality = q
+	}
+
+	if err := transform.Validate(); err != nil {
+		return nil, NewBadRequestError(err.Error(), nil)
+	}
+
+	limit := api.app.Settings().FileServe.ImageTransformDimensionLimit()
+	if width, height := transform.Dimensions(); width > limit || height > limit {
+		return nil, NewBadRequestError(fmt.Sprintf("Image width and height must not exceed %d.", limit), nil)
+	}
+
+	return transform, nil
+}
+
+// serveTransformedImage serves the transformed version of the event
+// served image (the transformed images are cached on the local disk).
+func (api *fileApi) serveTransformedImage(fs *filesystem.System, e *core.FileDownloadEvent, transform *filesystem.ImageTransform) error {
+	api.imagesCache.SetMaxSize(api.app.Settings().FileServe.ImageTransformCacheLimit())
+
+	cacheKey := fmt.Sprintf("%s?resize=%s&format=%s&quality=%d", e.ServedPath, transform.Resize, strings.ToLower(transform.Format), transform.Quality)
+
+	path, ok := api.imagesCache.Get(cacheKey)
+	if !ok {
+		var err error
+		path, err = api.imagesCache.Set(cacheKey, func(w io.Writer) error {
+			return fs.TransformImage(e.ServedPath, *transform, w)
+		})
+		if err != nil {
+			return NewBadRequestError("Failed to transform the image.", err)
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return NewNotFoundError("", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return NewNotFoundError("", err)
+	}
+
+	header := e.HttpContext.Response().Header()
+	for k, v := range map[string]string{
+		"Content-Type":            transform.ContentType(e.ServedPath),
+		"Content-Disposition":     "inline; filename=" + e.ServedName,
+		"X-Content-Type-Options":  "nosniff",
+		"Content-Security-Policy": "default-src 'none'; media-src 'self'; style-src 'unsafe-inline'; sandbox",
+		"Cache-Control":           "max-age=2592000, stale-while-revalidate=86400",
+	} {
+		if header.Get(k) == "" {
+			header.Set(k, v)
+		}
+	}
+
+	http.ServeContent(e.HttpContext.Response(), e.HttpContext.Request(), e.ServedName, info.ModTime(), f)
+
+	return nil
+}
+
+// swagger:models FileSignedUrlResponse
+type FileSignedUrlResponse struct {
+	Url     string         `json:"url" example:"https://bucket.s3.amazonaws.com/..."`
+	Expires types.DateTime `json:"expires"`
+}
+
+//	@Summary		Pre-signed URL файла
+//	@Description	Возвращает ограниченный по времени pre-signed URL для загрузки файла (или его эскиза) напрямую из S3 хранилища в обход приложения
+//	@Description	Для защищенных файлов требуется токен доступа к файлу. Время жизни ссылки задается настройкой fileServe.signedUrlDuration (по умолчанию 5 минут)
+//	@Tags			Files
+//	@Produce		json
+//	@Param			collection	path		string	true	"Идентификатор коллекции"
+//	@Param			recordId	path		string	true	"Идентификатор записи"
+//	@Param			filename	path		string	true	"Имя файла"
+//	@Param			token		query		string	false	"Токен доступа к файлу"
+//	@Param			thumb		query		string	false	"Размер эскиза (если применимо)"
+//	@Success		200			{object}	FileSignedUrlResponse
+//	@Failure		400			{string}	string	"The files storage doesn't support pre-signed urls."
+//	@Failure		403			{string}	string	"Insufficient permissions to access the file resource."
+//	@Failure		404			{string}	string	"Not found."
+//	@Router			/files/{collection}/{recordId}/{filename}/signed-url [get]
+func (api *fileApi) signedUrl(c echo.Context) error {
+	file, err := api.findRequestedFile(c)
+	if err != nil {
+		return err
+	}
+
+	fs, err := api.app.NewFilesystem()
+	if err != nil {
+		return NewBadRequestError("Filesystem initialization failure.", err)
+	}
+	defer fs.Close()
+
+	servedPath, servedName, err := api.resolveServedFile(fs, file, c.QueryParam("thumb"))
+	if err != nil {
+		return err
+	}
+
+	var disposition string
+	if file.options.ForceDownload {
+		disposition = "attachment; filename=" + servedName
+	}
+
+	contentType := file.options.ContentTypes[strings.ToLower(filepath.Ext(servedName))]
+
+	expiry := api.app.Settings().FileServe.SignedUrlExpiry()
+
+	url, err := fs.SignedURL(servedPath, expiry, disposition, contentType)
+	if err != nil {
+		if errors.Is(err, filesystem.ErrSignedURLNotSupported) {
+			return NewBadRequestError("The files storage doesn't support pre-signed urls.", err)
+		}
+		return NewBadRequestError("Failed to generate the file pre-signed url.", err)
+	}
+
+	expires, _ := types.ParseDateTime(time.Now().Add(expiry))
+
+	return c.JSON(http.StatusOK, FileSignedUrlResponse{
+		Url:     url,
+		Expires: expires,
+	})
+}
+
+// requestedFile describes a record file from the request path.
+type requestedFile struct {
+	collection    *models.Collection
+	record        *models.Record
+	field         *schema.SchemaField
+	options       *schema.FileOptions
+	name          string
+	baseFilesPath string
+}
+
+// findRequestedFile resolves the record file from the current request
+// path and checks whether the request is authorized to access it
+// (the protected files require a valid "token" query parameter).
+func (api *fileApi) findRequestedFile(c echo.Context) (*requestedFile, error) {
 	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
 	if collection == nil {
-		return NewNotFoundError("", nil)
+		return nil, NewNotFoundError("", nil)
 	}
 
 	recordId := c.PathParam("recordId")
 	if recordId == "" {
-		return NewNotFoundError("", nil)
+		return nil, NewNotFoundError("", nil)
 	}
 
 	record, err := api.app.Dao().FindRecordById(collection.Id, recordId)
 	if err != nil {
-		return NewNotFoundError("", err)
+		return nil, NewNotFoundError("", err)
 	}
 
 	filename := c.PathParam("filename")
 
 	fileField := record.FindFileFieldByFile(filename)
 	if fileField == nil {
-		return NewNotFoundError("", nil)
+		return nil, NewNotFoundError("", nil)
 	}
 
 	options, ok := fileField.Options.(*schema.FileOptions)
 	if !ok {
-		return NewBadRequestError("", errors.New("Failed to load file options."))
+		return nil, NewBadRequestError("", errors.New("Failed to load file options."))
 	}
 
 	// check whether the request is authorized to view the protected file
@@ -129,7 +447,7 @@ func (api *fileApi) download(c echo.Context) error {
 		adminOrAuthRecord, _ := api.findAdminOrAuthRecordByFileToken(token)
 
 		if !api.canAccessRecord(adminOrAuthRecord, record, record.Collection().ViewRule) {
-			return NewForbiddenError("Insufficient permissions to access the file resource.", nil)
+			return nil, NewForbiddenError("Insufficient permissions to access the file resource.", nil)
 		}
 	}
 
@@ -139,35 +457,44 @@ func (api *fileApi) download(c echo.Context) error {
 	if collection.IsView() {
 		fileRecord, err := api.app.Dao().FindRecordByViewFile(collection.Id, fileField.Name, filename)
 		if err != nil {
-			return NewNotFoundError("", fmt.Errorf("Failed to fetch view file field record: %w", err))
+			return nil, NewNotFoundError("", fmt.Errorf("Failed to fetch view file field record: %w", err))
 		}
 		baseFilesPath = fileRecord.BaseFilesPath()
 	}
 
-	fs, err := api.app.NewFilesystem()
-	if err != nil {
-		return NewBadRequestError("Filesystem initialization failure.", err)
-	}
-	defer fs.Close()
+	return &requestedFile{
+		collection:    collection,
+		record:        record,
+		field:         fileField,
+		options:       options,
+		name:          filename,
+		baseFilesPath: baseFilesPath,
+	}, nil
+}
 
-	originalPath := baseFilesPath + "/" + filename
+// resolveServedFile returns the storage path and the name of the
+// requested file or of its thumb (it is created if missing).
+//
+// It fallbacks to the original file for invalid thumb sizes and non-image files.
+func (api *fileApi) resolveServedFile(fs *filesystem.System, file *requestedFile, thumbSize string) (string, string, error) {
+	// resolve the deduplicated file object (if any)
+	originalPath := api.app.Dao().ResolveFileKey(file.baseFilesPath + "/" + file.name)
 	servedPath := originalPath
-	servedName := filename
+	servedName := file.name
 
 	// check for valid thumb size param
-	thumbSize := c.QueryParam("thumb")
-	if thumbSize != "" && (list.ExistInSlice(thumbSize, defaultThumbSizes) || list.ExistInSlice(thumbSize, options.Thumbs)) {
+	if thumbSize != "" && (list.ExistInSlice(thumbSize, core.FileFieldThumbSizes(file.field))) {
 		// extract the original file meta attributes and check it existence
 		oAttrs, oAttrsErr := fs.Attributes(originalPath)
 		if oAttrsErr != nil {
-			return NewNotFoundError("", err)
+			return "", "", NewNotFoundError("", oAttrsErr)
 		}
 
 		// check if it is an image
-		if list.ExistInSlice(oAttrs.ContentType, imageContentTypes) {
+		if list.ExistInSlice(oAttrs.ContentType, core.ThumbImageContentTypes) {
 			// add thumb size as file suffix
-			servedName = thumbSize + "_" + filename
-			servedPath = baseFilesPath + "/thumbs_" + filename + "/" + servedName
+			servedName = thumbSize + "_" + file.name
+			servedPath = file.baseFilesPath + "/thumbs_" + file.name + "/" + servedName
 
 			// create a new thumb if it doesn exists
 			if exists, _ := fs.Exists(servedPath); !exists {
@@ -178,28 +505,24 @@ func (api *fileApi) download(c echo.Context) error {
 		}
 	}
 
-	event := new(core.FileDownloadEvent)
-	event.HttpContext = c
-	event.Collection = collection
-	event.Record = record
-	event.FileField = fileField
-	event.ServedPath = servedPath
-	event.ServedName = servedName
+	return servedPath, servedName, nil
+}
 
-	// clickjacking shouldn't be a concern when serving uploaded files,
-	// so it safe to unset the global X-Frame-Options to allow files embedding
-	// (note: it is out of the hook to allow users to customize the behavior)
-	c.Response().Header().Del("X-Frame-Options")
+// setFileFieldServeHeaders sets the file field specific serve headers
+// (the headers that were already set, eg. in a hook, are not replaced).
+func setFileFieldServeHeaders(header http.Header, options *schema.FileOptions, name string) {
+	if options.ForceDownload && header.Get("Content-Disposition") == "" {
+		header.Set("Content-Disposition", "attachment; filename="+name)
+	}
 
-	return api.app.OnFileDownloadRequest().Trigger(event, func(e *core.FileDownloadEvent) error {
-		res := e.HttpContext.Response()
-		req := e.HttpContext.Request()
-		if err := fs.Serve(res, req, e.ServedPath, e.ServedName); err != nil {
-			return NewNotFoundError("", err)
-		}
+	ext := strings.ToLower(filepath.Ext(name))
+	if contentType := options.ContentTypes[ext]; contentType != "" && header.Get("Content-Type") == "" {
+		header.Set("Content-Type", contentType)
+	}
 
-		return nil
-	})
+	if options.ContentSecurityPolicy != "" && header.Get("Content-Security-Policy") == "" {
+		header.Set("Content-Security-Policy", options.ContentSecurityPolicy)
+	}
 }
 
 func (api *fileApi) findAdminOrAuthRecordByFileToken(fileToken string) (models.Model, error) {
@@ -275,3 +598,63 @@ func (api *fileApi) canAccessRecord(adminOrAuthRecord models.Model, record *mode
 
 	return false
 }
+
+//	@Summary		Warm up thumbs
+//	@Description	Enqueues a background generation of the missing thumbs for the existing image files of the collection
+//	@Tags			Files
+//	@Security		AdminAuth
+//	@Param			collection	query	string	true	"Collection name or id"
+//	@Param			sizes		query	string	false	"Comma separated thumb sizes (default to all allowed file field thumb sizes)"
+//	@Success		204			"No Content"
+//	@Failure		400			{string}	string	"Failed to warm up the collection thumbs."
+//	@Failure		401			{string}	string	"The request requires admin authorization token to be set."
+//	@Failure		404			{string}	string	"The requested resource wasn't found."
+//	@Router			/files/warm-thumbs [post]
+func (api *fileApi) warmThumbs(c echo.Context) error {
+	collection, err := api.app.Dao().FindCollectionByNameOrId(c.QueryParam("collection"))
+	if err != nil || collection == nil {
+		return NewNotFoundError("", err)
+	}
+
+	if collection.IsView() {
+		return NewBadRequestError("View collections don't store files.", nil)
+	}
+
+	if api.app.Cache().Has(core.CacheKeyActiveThumbsWarmupPrefix + collection.Id) {
+		return NewBadRequestError("Try again later - another thumbs warmup for the collection has already been started.", nil)
+	}
+
+	allowed := []string{}
+	for _, field := range collection.Schema.Fields() {
+		if field.Type == schema.FieldTypeFile {
+			allowed = append(allowed, core.FileFieldThumbSizes(field)...)
+		}
+	}
+
+	var sizes []string
+	if raw := strings.TrimSpace(c.QueryParam("sizes")); raw != "" {
+		for _, size := range strings.Split(raw, ",") {
+			size = strings.TrimSpace(size)
+			if !list.ExistInSlice(size, allowed) {
+				return NewBadRequestError(fmt.Sprintf("The thumb size %q is not allowed for any of the collection file fields.", size), nil)
+			}
+			sizes = append(sizes, size)
+		}
+	}
+
+	routine.FireAndForget(func() {
+		result, err := api.app.WarmThumbs(context.Background(), collection, sizes)
+		if err != nil {
+			if api.app.IsDebug() {
+				log.Println("Failed to warm up the thumbs of collection", collection.Name, err)
+			}
+			return
+		}
+
+		if api.app.IsDebug() && result.Failed > 0 {
+			log.Printf("Failed to generate %d thumbs of collection %s\n", result.Failed, collection.Name)
+		}
+	})
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,438 @@
+package apis
+
+import (
+	"encoding/base64"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tools/filesystem"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+const (
+	// TusVersion is the supported TUS resumable upload protocol version.
+	TusVersion = "1.0.0"
+
+	// TusExtensions is the list of the supported TUS protocol extensions.
+	TusExtensions = "creation,termination"
+
+	// TusContentType is the required content type of the upload chunks.
+	TusContentType = "application/offset+octet-stream"
+)
+
+// fileUploadMaxAge is the max duration since the last received chunk
+// after which the incomplete uploads are deleted.
+const fileUploadMaxAge = 24 * time.Hour
+
+// fileUploadPath returns the local path of the provided upload received bytes.
+func fileUploadPath(app core.App, upload *models.FileUpload) string {
+	return filepath.Join(app.DataDir(), core.LocalUploadsDirName, upload.Id, upload.Filename)
+}
+
+// deleteFileUpload deletes the provided upload together with its received bytes.
+func deleteFileUpload(app core.App, upload *models.FileUpload) error {
+	if err := app.Dao().DeleteFileUpload(upload); err != nil {
+		return err
+	}
+
+	return os.RemoveAll(filepath.Dir(fileUploadPath(app, upload)))
+}
+
+// deleteStaleFileUploads deletes the incomplete uploads that
+// didn't receive any chunk in the last [fileUploadMaxAge].
+func deleteStaleFileUploads(app core.App) {
+	uploads, err := app.Dao().FindStaleFileUploads(time.Now().Add(-fileUploadMaxAge))
+	if err != nil {
+		if app.IsDebug() {
+			log.Println(err)
+		}
+		return
+	}
+
+	for _, upload := range uploads {
+		if err := deleteFileUpload(app, upload); err != nil && app.IsDebug() {
+			log.Println(err)
+		}
+	}
+}
+
+// parseTusMetadata parses the TUS "Upload-Metadata" header value
+// (comma separated "key base64Value" pairs).
+func parseTusMetadata(header string) (map[string]string, error) {
+	result := map[string]string{}
+
+	for _, pair := range strings.Split(header, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		key, encoded, _ := strings.Cut(pair, " ")
+
+		value, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, err
+		}
+
+		result[key] = string(value)
+	}
+
+	return result, nil
+}
+
+// checkTusRequest sets the common TUS response headers and
+// checks whether the request uses the supported protocol version.
+func checkTusRequest(c echo.Context) error {
+	c.Response().Header().Set("Tus-Resumable", TusVersion)
+
+	if c.Request().Header.Get("Tus-Resumable") != TusVersion {
+		c.Response().Header().Set("Tus-Version", TusVersion)
+		return NewApiError(http.StatusPreconditionFailed, "Unsupported TUS protocol version.", nil)
+	}
+
+	return nil
+}
+
+// findUploadRecord returns the upload target record
+// if it satisfies the collection update API rule.
+func (api *fileApi) findUploadRecord(c echo.Context, collectionNameOrId string, recordId string) (*models.Record, error) {
+	collection, err := api.app.Dao().FindCollectionByNameOrId(collectionNameOrId)
+	if err != nil || collection.IsView() {
+		return nil, NewNotFoundError("", err)
+	}
+
+	requestData := RequestData(c)
+
+	if requestData.Admin == nil && collection.UpdateRule == nil {
+		// only admins can access if the rule is nil
+		return nil, NewForbiddenError("Only admins can perform this action.", nil)
+	}
+
+	ruleFunc := func(q *dbx.SelectQuery) error {
+		if requestData.Admin == nil && collection.UpdateRule != nil && *collection.UpdateRule != "" {
+			resolver := resolvers.NewRecordFieldResolver(api.app.Dao(), collection, requestData, true)
+			expr, err := search.FilterData(*collection.UpdateRule).BuildExpr(resolver)
+			if err != nil {
+				return err
+			}
+			resolver.UpdateQuery(q)
+			q.AndWhere(expr)
+		}
+		return nil
+	}
+
+	record, err := api.app.Dao().FindRecordById(collection.Id, recordId, ruleFunc)
+	if err != nil || record == nil {
+		return nil, NewNotFoundError("", err)
+	}
+
+	return record, nil
+}
+
+// findOwnFileUpload returns the file upload with the request "id"
+// path parameter if it is owned by the current admin or auth record.
+func (api *fileApi) findOwnFileUpload(c echo.Context) (*models.FileUpload, error) {
+	upload, err := api.app.Dao().FindFileUploadById(c.PathParam("id"))
+	if err != nil {
+		return nil, NewNotFoundError("", err)
+	}
+
+	admin, _ := c.Get(ContextAdminKey).(*models.Admin)
+	authRecord, _ := c.Get(ContextAuthRecordKey).(*models.Record)
+	if !upload.IsOwnedBy(admin, authRecord) {
+		return nil, NewNotFoundError("", nil)
+	}
+
+	return upload, nil
+}
+
+//	@Summary		Resumable uploads capabilities
+//	@Description	Returns the supported TUS protocol version and extensions
+//	@Tags			Files
+//	@Success		204	"No Content"
+//	@Header			204	{string}	Tus-Version		"Supported TUS protocol versions"
+//	@Header			204	{string}	Tus-Extension	"Supported TUS protocol extensions"
+//	@Router			/files/uploads [options]
+func (api *fileApi) uploadsOptions(c echo.Context) error {
+	c.Response().Header().Set("Tus-Resumable", TusVersion)
+	c.Response().Header().Set("Tus-Version", TusVersion)
+	c.Response().Header().Set("Tus-Extension", TusExtensions)
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+//	@Summary		Create resumable upload
+//	@Description	Creates a new TUS resumable upload for a record file field. The target is specified with the "collection", "recordId", "field" and "filename" Upload-Metadata keys.
+//	@Description	Requires access to update the record (UpdateRule). Incomplete uploads are deleted 24 hours after their last received chunk.
+//	@Tags			Files
+//	@Security		AdminAuth
+//	@Security		RecordAuth
+//	@Param			Tus-Resumable	header	string	true	"TUS protocol version (1.0.0)"
+//	@Param			Upload-Length	header	int		true	"Total file size in bytes"
+//	@Param			Upload-Metadata	header	string	true	"Comma separated base64 encoded key-value pairs"
+//	@Success		201				"Created"
+//	@Header			201				{string}	Location	"The upload url"
+//	@Failure		400				{string}	string	"Failed to create the upload."
+//	@Failure		401				{string}	string	"The request requires admin or record authorization token to be set."
+//	@Failure		403				{string}	string	"Only admins can perform this action."
+//	@Failure		404				{string}	string	"Not found."
+//	@Failure		412				{string}	string	"Unsupported TUS protocol version."
+//	@Router			/files/uploads [post]
+func (api *fileApi) createUpload(c echo.Context) error {
+	if err := checkTusRequest(c); err != nil {
+		return err
+	}
+
+	metadata, err := parseTusMetadata(c.Request().Header.Get("Upload-Metadata"))
+	if err != nil {
+		return NewBadRequestError("Invalid Upload-Metadata header.", err)
+	}
+
+	size, err := strconv.ParseInt(c.Request().Header.Get("Upload-Length"), 10, 64)
+	if err != nil {
+		return NewBadRequestError("Invalid Upload-Length header.", err)
+	}
+
+	record, err := api.findUploadRecord(c, metadata["collection"], metadata["recordId"])
+	if err != nil {
+		return err
+	}
+
+	// opportunistically cleanup the abandoned uploads
+	deleteStaleFileUploads(api.app)
+
+	admin, _ := c.Get(ContextAdminKey).(*models.Admin)
+	authRecord, _ := c.Get(ContextAuthRecordKey).(*models.Record)
+
+	form := forms.NewFileUploadCreate(api.app, record, admin, authRecord)
+	form.Field = metadata["field"]
+	form.Filename = metadata["filename"]
+	form.Size = size
+
+	return form.Submit(func(next forms.InterceptorNextFunc[*models.FileUpload]) forms.InterceptorNextFunc[*models.FileUpload] {
+		return func(upload *models.FileUpload) error {
+			if err := next(upload); err != nil {
+				return NewBadRequestError("Failed to create the upload.", err)
+			}
+
+			path := fileUploadPath(api.app, upload)
+
+			createErr := os.MkdirAll(filepath.Dir(path), os.ModePerm)
+			if createErr == nil {
+				var f *os.File
+				if f, createErr = os.Create(path); createErr == nil {
+					createErr = f.Close()
+				}
+			}
+			if createErr != nil {
+				deleteFileUpload(api.app, upload)
+				return NewBadRequestError("Failed to create the upload.", createErr)
+			}
+
+			location := c.Scheme() + "://" + c.Request().Host + strings.TrimSuffix(c.Request().URL.Path, "/") + "/" + upload.Id
+			c.Response().Header().Set("Location", location)
+
+			return c.NoContent(http.StatusCreated)
+		}
+	})
+}
+
+//	@Summary		Resumable upload offset
+//	@Description	Returns the number of the already received bytes of the specified upload
+//	@Tags			Files
+//	@Security		AdminAuth
+//	@Security		RecordAuth
+//	@Param			id				path	string	true	"Upload id"
+//	@Param			Tus-Resumable	header	string	true	"TUS protocol version (1.0.0)"
+//	@Success		200				"OK"
+//	@Header			200				{int}	Upload-Offset	"Number of the received bytes"
+//	@Header			200				{int}	Upload-Length	"Total file size in bytes"
+//	@Failure		401				{string}	string	"The request requires admin or record authorization token to be set."
+//	@Failure		404				{string}	string	"Not found."
+//	@Failure		412				{string}	string	"Unsupported TUS protocol version."
+//	@Router			/files/uploads/{id} [head]
+func (api *fileApi) uploadOffset(c echo.Context) error {
+	if err := checkTusRequest(c); err != nil {
+		return err
+	}
+
+	upload, err := api.findOwnFileUpload(c)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("Upload-Offset", strconv.FormatInt(upload.Offset, 10))
+	c.Response().Header().Set("Upload-Length", strconv.FormatInt(upload.Size, 10))
+	c.Response().Header().Set("Cache-Control", "no-store")
+
+	return c.NoContent(http.StatusOK)
+}
+
+//	@Summary		Upload chunk
+//	@Description	Appends the request body to the specified upload starting from the Upload-Offset header position.
+//	@Description	Once all bytes are received the file is attached to the target record file field and the upload is deleted.
+//	@Tags			Files
+//	@Security		AdminAuth
+//	@Security		RecordAuth
+//	@Accept			application/offset+octet-stream
+//	@Param			id				path	string	true	"Upload id"
+//	@Param			Tus-Resumable	header	string	true	"TUS protocol version (1.0.0)"
+//	@Param			Upload-Offset	header	int		true	"Position of the chunk (must match the number of the already received bytes)"
+//	@Success		204				"No Content"
+//	@Header			204				{int}	Upload-Offset	"Number of the received bytes"
+//	@Failure		400				{string}	string	"Failed to attach the uploaded file."
+//	@Failure		401				{string}	string	"The request requires admin or record authorization token to be set."
+//	@Failure		404				{string}	string	"Not found."
+//	@Failure		409				{string}	string	"The upload offset doesn't match."
+//	@Failure		412				{string}	string	"Unsupported TUS protocol version."
+//	@Failure		415				{string}	string	"Unsupported upload chunk content type."
+//	@Router			/files/uploads/{id} [patch]
+func (api *fileApi) uploadChunk(c echo.Context) error {
+	if err := checkTusRequest(c); err != nil {
+		return err
+	}
+
+	upload, err := api.findOwnFileUpload(c)
+	if err != nil {
+		return err
+	}
+
+	if c.Request().Header.Get(echo.HeaderContentType) != TusContentType {
+		return NewApiError(http.StatusUnsupportedMediaType, "Unsupported upload chunk content type.", nil)
+	}
+
+	offset, err := strconv.ParseInt(c.Request().Header.Get("Upload-Offset"), 10, 64)
+	if err != nil || offset != upload.Offset {
+		return NewApiError(http.StatusConflict, "The upload offset doesn't match.", err)
+	}
+
+	if !upload.IsComplete() {
+		if err := api.appendUploadChunk(upload, c.Request().Body); err != nil {
+			return NewBadRequestError("Failed to store the upload chunk.", err)
+		}
+
+		if err := api.app.Dao().SaveFileUpload(upload); err != nil {
+			return NewBadRequestError("Failed to store the upload chunk.", err)
+		}
+	}
+
+	c.Response().Header().Set("Upload-Offset", strconv.FormatInt(upload.Offset, 10))
+
+	if upload.IsComplete() {
+		if err := api.attachFileUpload(c, upload); err != nil {
+			return err
+		}
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// appendUploadChunk appends the provided chunk data to the upload
+// received bytes and updates the upload offset accordingly.
+//
+// The received bytes are reverted to the previous offset
+// if the chunk exceeds the upload size.
+func (api *fileApi) appendUploadChunk(upload *models.FileUpload, chunk io.Reader) error {
+	f, err := os.OpenFile(fileUploadPath(api.app, upload), os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, err := f.Seek(upload.Offset, io.SeekStart); err != nil {
+		return err
+	}
+
+	remaining := upload.Size - upload.Offset
+
+	// read one additional byte to detect the chunks that exceed the upload size
+	n, err := io.Copy(f, io.LimitReader(chunk, remaining+1))
+	if err == nil && n > remaining {
+		err = errors.New("the chunk exceeds the upload length")
+	}
+	if err != nil {
+		f.Truncate(upload.Offset)
+		return err
+	}
+
+	upload.Offset += n
+
+	return nil
+}
+
+// attachFileUpload attaches the completed upload file to its
+// target record and deletes the upload.
+func (api *fileApi) attachFileUpload(c echo.Context, upload *models.FileUpload) error {
+	// recheck the record update access
+	record, err := api.findUploadRecord(c, upload.CollectionId, upload.RecordId)
+	if err != nil {
+		return err
+	}
+
+	file, err := filesystem.NewFileFromPath(fileUploadPath(api.app, upload))
+	if err != nil {
+		return NewBadRequestError("Failed to attach the uploaded file.", err)
+	}
+
+	requestData := RequestData(c)
+
+	form := forms.NewRecordUpsert(api.app, record)
+	form.SetFullManageAccess(requestData.Admin != nil || hasAuthManageAccess(api.app.Dao(), record, requestData))
+
+	if err := form.AddFiles(upload.Field, file); err != nil {
+		return NewBadRequestError("Failed to attach the uploaded file.", err)
+	}
+
+	if err := form.Submit(); err != nil {
+		return NewBadRequestError("Failed to attach the uploaded file.", err)
+	}
+
+	if err := deleteFileUpload(api.app, upload); err != nil && api.app.IsDebug() {
+		log.Println(err)
+	}
+
+	return nil
+}
+
+//	@Summary		Delete resumable upload
+//	@Description	Terminates the specified upload and deletes its received bytes
+//	@Tags			Files
+//	@Security		AdminAuth
+//	@Security		RecordAuth
+//	@Param			id				path	string	true	"Upload id"
+//	@Param			Tus-Resumable	header	string	true	"TUS protocol version (1.0.0)"
+//	@Success		204				"No Content"
+//	@Failure		400				{string}	string	"Failed to delete the upload."
+//	@Failure		401				{string}	string	"The request requires admin or record authorization token to be set."
+//	@Failure		404				{string}	string	"Not found."
+//	@Failure		412				{string}	string	"Unsupported TUS protocol version."
+//	@Router			/files/uploads/{id} [delete]
+func (api *fileApi) deleteUpload(c echo.Context) error {
+	if err := checkTusRequest(c); err != nil {
+		return err
+	}
+
+	upload, err := api.findOwnFileUpload(c)
+	if err != nil {
+		return err
+	}
+
+	if err := deleteFileUpload(api.app, upload); err != nil {
+		return NewBadRequestError("Failed to delete the upload.", err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,413 @@
+package apis_test
+
+import (
+	"encoding/base64"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+// testTusMetadata returns an "Upload-Metadata" header value
+// from the provided key-value pairs.
+func testTusMetadata(pairs ...string) string {
+	parts := make([]string, 0, len(pairs)/2)
+
+	for i := 0; i+1 < len(pairs); i += 2 {
+		parts = append(parts, pairs[i]+" "+base64.StdEncoding.EncodeToString([]byte(pairs[i+1])))
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// setupTestFileUpload creates a users/4q1xlclmfloku33 "file" field
+// upload (with 10 bytes size) owned by the same auth record.
+func setupTestFileUpload(received string) func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+	return func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+		upload := &models.FileUpload{
+			CollectionId:      "_pb_users_auth_",
+			RecordId:          "4q1xlclmfloku33",
+			Field:             "file",
+			Filename:          "test.txt",
+			Size:              10,
+			Offset:            int64(len(received)),
+			OwnerType:         models.FileUploadOwnerAuthRecord,
+			OwnerCollectionId: "_pb_users_auth_",
+			OwnerId:           "4q1xlclmfloku33",
+		}
+		upload.MarkAsNew()
+		upload.SetId("testupload00001")
+
+		if err := app.Dao().SaveFileUpload(upload); err != nil {
+			t.Fatal(err)
+		}
+
+		dir := filepath.Join(app.DataDir(), core.LocalUploadsDirName, upload.Id)
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(filepath.Join(dir, upload.Filename), []byte(received), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		app.ResetEventCalls()
+	}
+}
+
+// expectResponseHeaders registers a middleware that
+// checks the TUS response headers of the tested request.
+func expectResponseHeaders(t *testing.T, e *echo.Echo, headers map[string]string) {
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			err := next(c)
+
+			for k, v := range headers {
+				if got := c.Response().Header().Get(k); got != v {
+					t.Errorf("Expected %s header %q, got %q", k, v, got)
+				}
+			}
+
+			return err
+		}
+	})
+}
+
+func TestFileUploadsOptions(t *testing.T) {
+	scenario := tests.ApiScenario{
+		Method: http.MethodOptions,
+		Url:    "/api/files/uploads",
+		BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+			expectResponseHeaders(t, e, map[string]string{
+				"Tus-Resumable": "1.0.0",
+				"Tus-Version":   "1.0.0",
+				"Tus-Extension": "creation,termination",
+			})
+		},
+		ExpectedStatus: 204,
+	}
+
+	scenario.Test(t)
+}
+
+func TestFileUploadCreate(t *testing.T) {
+	scenarios := []tests.ApiScenario{
+		{
+			Name:   "guest",
+			Method: http.MethodPost,
+			Url:    "/api/files/uploads",
+			RequestHeaders: map[string]string{
+				"Tus-Resumable":   "1.0.0",
+				"Upload-Length":   "10",
+				"Upload-Metadata": testTusMetadata("collection", "users", "recordId", "4q1xlclmfloku33", "field", "file", "filename", "test.txt"),
+			},
+			ExpectedStatus:  401,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "unsupported protocol version",
+			Method: http.MethodPost,
+			Url:    "/api/files/uploads",
+			RequestHeaders: map[string]string{
+				"Authorization":   testOrgOwnerToken,
+				"Upload-Length":   "10",
+				"Upload-Metadata": testTusMetadata("collection", "users", "recordId", "4q1xlclmfloku33", "field", "file", "filename", "test.txt"),
+			},
+			ExpectedStatus:  412,
+			ExpectedContent: []string{`"message":"Unsupported TUS protocol version."`},
+		},
+		{
+			Name:   "auth record without update access",
+			Method: http.MethodPost,
+			Url:    "/api/files/uploads",
+			RequestHeaders: map[string]string{
+				"Authorization":   testOrgMemberToken,
+				"Tus-Resumable":   "1.0.0",
+				"Upload-Length":   "10",
+				"Upload-Metadata": testTusMetadata("collection", "users", "recordId", "4q1xlclmfloku33", "field", "file", "filename", "test.txt"),
+			},
+			ExpectedStatus:  404,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "invalid upload data",
+			Method: http.MethodPost,
+			Url:    "/api/files/uploads",
+			RequestHeaders: map[string]string{
+				"Authorization":   testOrgOwnerToken,
+				"Tus-Resumable":   "1.0.0",
+				"Upload-Length":   "5242881",
+				"Upload-Metadata": testTusMetadata("collection", "users", "recordId", "4q1xlclmfloku33", "field", "username", "filename", "../test.txt"),
+			},
+			ExpectedStatus: 400,
+			ExpectedContent: []string{
+				`"field":{"code":"validation_invalid_file_field"`,
+				`"filename":{"code":"validation_invalid_filename"`,
+			},
+		},
+		{
+			Name:   "valid upload",
+			Method: http.MethodPost,
+			Url:    "/api/files/uploads",
+			RequestHeaders: map[string]string{
+				"Authorization":   testOrgOwnerToken,
+				"Tus-Resumable":   "1.0.0",
+				"Upload-Length":   "10",
+				"Upload-Metadata": testTusMetadata("collection", "users", "recordId", "4q1xlclmfloku33", "field", "file", "filename", "test.txt"),
+			},
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				expectResponseHeaders(t, e, map[string]string{"Tus-Resumable": "1.0.0"})
+			},
+			ExpectedStatus: 201,
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeCreate": 1,
+				"OnModelAfterCreate":  1,
+			},
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				uploads := []*models.FileUpload{}
+				if err := app.Dao().FileUploadQuery().All(&uploads); err != nil {
+					t.Fatal(err)
+				}
+
+				if len(uploads) != 1 || uploads[0].Offset != 0 || uploads[0].Size != 10 || uploads[0].OwnerId != "4q1xlclmfloku33" {
+					t.Fatalf("Expected a single new upload, got %v", uploads)
+				}
+
+				path := filepath.Join(app.DataDir(), core.LocalUploadsDirName, uploads[0].Id, "test.txt")
+				if _, err := os.Stat(path); err != nil {
+					t.Fatalf("Expected the upload file to be created, got %v", err)
+				}
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
+
+func TestFileUploadOffset(t *testing.T) {
+	scenarios := []tests.ApiScenario{
+		{
+			Name:   "non owner",
+			Method: http.MethodHead,
+			Url:    "/api/files/uploads/testupload00001",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgMemberToken,
+				"Tus-Resumable": "1.0.0",
+			},
+			BeforeTestFunc: setupTestFileUpload("abc"),
+			ExpectedStatus: 404,
+		},
+		{
+			Name:   "owner",
+			Method: http.MethodHead,
+			Url:    "/api/files/uploads/testupload00001",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgOwnerToken,
+				"Tus-Resumable": "1.0.0",
+			},
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				setupTestFileUpload("abc")(t, app, e)
+				expectResponseHeaders(t, e, map[string]string{
+					"Tus-Resumable": "1.0.0",
+					"Upload-Offset": "3",
+					"Upload-Length": "10",
+					"Cache-Control": "no-store",
+				})
+			},
+			ExpectedStatus: 200,
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
+
+func TestFileUploadChunk(t *testing.T) {
+	scenarios := []tests.ApiScenario{
+		{
+			Name:   "invalid content type",
+			Method: http.MethodPatch,
+			Url:    "/api/files/uploads/testupload00001",
+			Body:   strings.NewReader("defg"),
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgOwnerToken,
+				"Tus-Resumable": "1.0.0",
+				"Upload-Offset": "3",
+			},
+			BeforeTestFunc:  setupTestFileUpload("abc"),
+			ExpectedStatus:  415,
+			ExpectedContent: []string{`"message":"Unsupported upload chunk content type."`},
+		},
+		{
+			Name:   "mismatched offset",
+			Method: http.MethodPatch,
+			Url:    "/api/files/uploads/testupload00001",
+			Body:   strings.NewReader("defg"),
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgOwnerToken,
+				"Content-Type":  "application/offset+octet-stream",
+				"Tus-Resumable": "1.0.0",
+				"Upload-Offset": "0",
+			},
+			BeforeTestFunc:  setupTestFileUpload("abc"),
+			ExpectedStatus:  409,
+			ExpectedContent: []string{`"message":"The upload offset doesn't match."`},
+		},
+		{
+			Name:   "chunk exceeding the upload length",
+			Method: http.MethodPatch,
+			Url:    "/api/files/uploads/testupload00001",
+			Body:   strings.NewReader("defghijklm"),
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgOwnerToken,
+				"Content-Type":  "application/offset+octet-stream",
+				"Tus-Resumable": "1.0.0",
+				"Upload-Offset": "3",
+			},
+			BeforeTestFunc:  setupTestFileUpload("abc"),
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"message":"Failed to store the upload chunk."`},
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				data, err := os.ReadFile(filepath.Join(app.DataDir(), core.LocalUploadsDirName, "testupload00001", "test.txt"))
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if string(data) != "abc" {
+					t.Fatalf("Expected the received bytes to be reverted, got %q", data)
+				}
+			},
+		},
+		{
+			Name:   "partial chunk",
+			Method: http.MethodPatch,
+			Url:    "/api/files/uploads/testupload00001",
+			Body:   strings.NewReader("defg"),
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgOwnerToken,
+				"Content-Type":  "application/offset+octet-stream",
+				"Tus-Resumable": "1.0.0",
+				"Upload-Offset": "3",
+			},
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				setupTestFileUpload("abc")(t, app, e)
+				expectResponseHeaders(t, e, map[string]string{"Upload-Offset": "7"})
+			},
+			ExpectedStatus: 204,
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeUpdate": 1,
+				"OnModelAfterUpdate":  1,
+			},
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				upload, err := app.Dao().FindFileUploadById("testupload00001")
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if upload.Offset != 7 {
+					t.Fatalf("Expected offset 7, got %d", upload.Offset)
+				}
+			},
+		},
+		{
+			Name:   "final chunk",
+			Method: http.MethodPatch,
+			Url:    "/api/files/uploads/testupload00001",
+			Body:   strings.NewReader("hij"),
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgOwnerToken,
+				"Content-Type":  "application/offset+octet-stream",
+				"Tus-Resumable": "1.0.0",
+				"Upload-Offset": "7",
+			},
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				setupTestFileUpload("abcdefg")(t, app, e)
+				expectResponseHeaders(t, e, map[string]string{"Upload-Offset": "10"})
+			},
+			ExpectedStatus: 204,
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeUpdate": 2, // upload + record
+				"OnModelAfterUpdate":  2,
+				"OnModelBeforeDelete": 1,
+				"OnModelAfterDelete":  1,
+			},
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				if _, err := app.Dao().FindFileUploadById("testupload00001"); err == nil {
+					t.Fatal("Expected the completed upload to be deleted")
+				}
+
+				if _, err := os.Stat(filepath.Join(app.DataDir(), core.LocalUploadsDirName, "testupload00001")); !os.IsNotExist(err) {
+					t.Fatalf("Expected the upload directory to be deleted, got %v", err)
+				}
+
+				record, err := app.Dao().FindRecordById("users", "4q1xlclmfloku33")
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				files := record.GetStringSlice("file")
+				if len(files) != 1 || !strings.HasPrefix(files[0], "test_") {
+					t.Fatalf("Expected the uploaded file to be attached, got %v", files)
+				}
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
+
+func TestFileUploadDelete(t *testing.T) {
+	scenarios := []tests.ApiScenario{
+		{
+			Name:   "non owner",
+			Method: http.MethodDelete,
+			Url:    "/api/files/uploads/testupload00001",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgMemberToken,
+				"Tus-Resumable": "1.0.0",
+			},
+			BeforeTestFunc:  setupTestFileUpload("abc"),
+			ExpectedStatus:  404,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "owner",
+			Method: http.MethodDelete,
+			Url:    "/api/files/uploads/testupload00001",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgOwnerToken,
+				"Tus-Resumable": "1.0.0",
+			},
+			BeforeTestFunc: setupTestFileUpload("abc"),
+			ExpectedStatus: 204,
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeDelete": 1,
+				"OnModelAfterDelete":  1,
+			},
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				if _, err := app.Dao().FindFileUploadById("testupload00001"); err == nil {
+					t.Fatal("Expected the upload to be deleted")
+				}
+
+				if _, err := os.Stat(filepath.Join(app.DataDir(), core.LocalUploadsDirName, "testupload00001")); !os.IsNotExist(err) {
+					t.Fatalf("Expected the upload directory to be deleted, got %v", err)
+				}
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
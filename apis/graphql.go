@@ -0,0 +1,507 @@
+package apis
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tools/graphql"
+	"github.com/pocketbase/pocketbase/tools/inflector"
+	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/spf13/cast"
+)
+
+const maxGraphqlRootFields int = 50
+
+const graphqlTypenameField = "__typename"
+
+// bindGraphqlApi registers the GraphQL api endpoints
+// (available only if enabled in the app Settings().Graphql).
+func bindGraphqlApi(app core.App, rg *echo.Group) {
+	api := graphqlApi{app: app}
+
+	subGroup := rg.Group("/graphql", ActivityLogger(app), api.requireEnabled)
+	subGroup.GET("", api.execute)
+	subGroup.POST("", api.execute)
+	subGroup.GET("/schema", api.schema, RequireAdminAuth())
+}
+
+type graphqlApi struct {
+	app core.App
+}
+
+// requireEnabled is a middleware that hides the GraphQL endpoints if disabled.
+func (api *graphqlApi) requireEnabled(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if !api.app.Settings().Graphql.Enabled {
+			return NewNotFoundError("", nil)
+		}
+
+		return next(c)
+	}
+}
+
+//	@Summary		Execute GraphQL operation
+//	@Description	Executes a GraphQL query or mutation generated from the collections schema (the collection API rules are applied the same way as in the records api)
+//	@Description	Queries: {collection}(id, expand) and {collection}List(filter, sort, page, perPage, expand).
+//	@Description	Mutations: create{Collection}(data, expand), update{Collection}(id, data, expand) and delete{Collection}(id).
+//	@Tags			GraphQL
+//	@Security		Auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			body			body		graphql.Request	false	"GraphQL request (POST)"
+//	@Param			query			query		string			false	"GraphQL query document (GET)"
+//	@Param			operationName	query		string			false	"Operation name (GET)"
+//	@Param			variables		query		string			false	"JSON encoded variables (GET)"
+//	@Success		200				{object}	graphql.Response
+//	@Failure		400				{object}	graphql.Response
+//	@Failure		404				{string}	string	"The requested resource wasn't found."
+//	@Router			/graphql [post]
+func (api *graphqlApi) execute(c echo.Context) error {
+	// note: extracted before the bind to preserve the request body
+	requestData := RequestData(c)
+
+	body := new(graphql.Request)
+
+	if c.Request().Method == http.MethodGet {
+		body.Query = c.QueryParam("query")
+		body.OperationName = c.QueryParam("operationName")
+		if raw := c.QueryParam("variables"); raw != "" {
+			if err := json.Unmarshal([]byte(raw), &body.Variables); err != nil {
+				return graphqlErrorResponse(c, http.StatusBadRequest, "Invalid variables JSON.")
+			}
+		}
+	} else if err := c.Bind(body); err != nil {
+		return graphqlErrorResponse(c, http.StatusBadRequest, "Failed to load the submitted data due to invalid formatting.")
+	}
+
+	if strings.TrimSpace(body.Query) == "" {
+		return graphqlErrorResponse(c, http.StatusBadRequest, "Missing query document.")
+	}
+
+	op, err := graphql.Parse(body.Query, body.OperationName, body.Variables)
+	if err != nil {
+		return graphqlErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	switch op.Type {
+	case graphql.OperationQuery:
+	case graphql.OperationMutation:
+		if c.Request().Method == http.MethodGet {
+			return graphqlErrorResponse(c, http.StatusMethodNotAllowed, "Mutations are allowed only with POST requests.")
+		}
+	default:
+		return graphqlErrorResponse(c, http.StatusBadRequest, "Unsupported operation type "+op.Type+".")
+	}
+
+	if len(op.Fields) > maxGraphqlRootFields {
+		return graphqlErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("The max allowed root fields (%d) are exceeded.", maxGraphqlRootFields))
+	}
+
+	collections := []*models.Collection{}
+	if err := api.app.Dao().CollectionQuery().OrderBy("created ASC").All(&collections); err != nil {
+		return NewBadRequestError("Failed to load the collections.", err)
+	}
+
+	rootType := inflector.UcFirst(op.Type)
+	data := graphql.NewObject()
+	response := &graphql.Response{Data: data}
+
+	for _, field := range op.Fields {
+		if field.Name == graphqlTypenameField {
+			data.Set(field.Key(), rootType)
+			continue
+		}
+
+		value, err := api.resolveRootField(c, requestData, collections, op.Type, field)
+		if err != nil {
+			data.Set(field.Key(), nil)
+			response.Errors = append(response.Errors, graphqlError(err, field.Key()))
+			continue
+		}
+
+		data.Set(field.Key(), graphql.Select(value, field.Selections))
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+//	@Summary		GraphQL schema
+//	@Description	Returns the GraphQL schema (SDL) generated from the current collections
+//	@Tags			GraphQL
+//	@Security		AdminAuth
+//	@Produce		plain
+//	@Success		200	{string}	string	"GraphQL schema definition"
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Failure		404	{string}	string	"The requested resource wasn't found."
+//	@Router			/graphql/schema [get]
+func (api *graphqlApi) schema(c echo.Context) error {
+	collections := []*models.Collection{}
+	if err := api.app.Dao().CollectionQuery().OrderBy("created ASC").All(&collections); err != nil {
+		return NewBadRequestError("Failed to load the collections.", err)
+	}
+
+	return c.String(http.StatusOK, graphqlSchema(collections))
+}
+
+// resolveRootField resolves a single Query or Mutation root field.
+func (api *graphqlApi) resolveRootField(
+	c echo.Context,
+	requestData *models.RequestData,
+	collections []*models.Collection,
+	opType string,
+	field *graphql.Field,
+) (any, error) {
+	for _, collection := range collections {
+		typeName := inflector.UcFirst(collection.Name)
+
+		if opType == graphql.OperationQuery {
+			switch field.Name {
+			case collection.Name:
+				return api.view(c, requestData, collection, field)
+			case collection.Name + "List":
+				return api.list(c, requestData, collection, field)
+			}
+			continue
+		}
+
+		if collection.IsView() {
+			continue
+		}
+
+		switch field.Name {
+		case "create" + typeName:
+			return api.mutate(c, requestData, collection, TransactionOperationCreate, field)
+		case "update" + typeName:
+			return api.mutate(c, requestData, collection, TransactionOperationUpdate, field)
+		case "delete" + typeName:
+			return api.mutate(c, requestData, collection, TransactionOperationDelete, field)
+		}
+	}
+
+	return nil, fmt.Errorf("Cannot query field %q on type %q.", field.Name, inflector.UcFirst(opType))
+}
+
+// list resolves the {collection}List query field.
+func (api *graphqlApi) list(
+	c echo.Context,
+	requestData *models.RequestData,
+	collection *models.Collection,
+	field *graphql.Field,
+) (any, error) {
+	filter := cast.ToString(field.Arguments["filter"])
+	sort := cast.ToString(field.Arguments["sort"])
+
+	// forbid users and guests to query special filter/sort fields
+	if requestData.Admin == nil && (strings.Contains(filter+sort, "@collection.") || strings.Contains(filter+sort, "@request.")) {
+		return nil, NewForbiddenError("Only admins can filter by @collection and @request query params", nil)
+	}
+
+	if requestData.Admin == nil && collection.ListRule == nil {
+		// only admins can access if the rule is nil
+		return nil, NewForbiddenError("Only admins can perform this action.", nil)
+	}
+
+	fieldsResolver := resolvers.NewRecordFieldResolver(
+		api.app.Dao(),
+		collection,
+		requestData,
+		// hidden fields are searchable only by admins
+		requestData.Admin != nil,
+	)
+
+	searchProvider := search.NewProvider(fieldsResolver).
+		Query(api.app.Dao().RecordQuery(collection))
+
+	if requestData.Admin == nil && collection.ListRule != nil {
+		searchProvider.AddFilter(search.FilterData(*collection.ListRule))
+	}
+
+	params := url.Values{}
+	if filter != "" {
+		params.Set(search.FilterQueryParam, filter)
+	}
+	if sort != "" {
+		params.Set(search.SortQueryParam, sort)
+	}
+	if page, ok := field.Arguments["page"]; ok && page != nil {
+		params.Set(search.PageQueryParam, cast.ToString(page))
+	}
+	if perPage, ok := field.Arguments["perPage"]; ok && perPage != nil {
+		params.Set(search.PerPageQueryParam, cast.ToString(perPage))
+	}
+
+	records := []*models.Record{}
+
+	result, err := searchProvider.ParseAndExec(params.Encode(), &records)
+	if err != nil {
+		return nil, NewBadRequestError("Invalid filter parameters.", err)
+	}
+
+	items, err := api.exportRecords(c, collection, records, field)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]any{
+		graphqlTypenameField: inflector.UcFirst(collection.Name) + "List",
+		"page":               result.Page,
+		"perPage":            result.PerPage,
+		"totalItems":         result.TotalItems,
+		"totalPages":         result.TotalPages,
+		"items":              items,
+	}, nil
+}
+
+// view resolves the {collection} query field.
+func (api *graphqlApi) view(
+	c echo.Context,
+	requestData *models.RequestData,
+	collection *models.Collection,
+	field *graphql.Field,
+) (any, error) {
+	recordId := cast.ToString(field.Arguments["id"])
+	if recordId == "" {
+		return nil, NewBadRequestError("Missing required argument \"id\".", nil)
+	}
+
+	if requestData.Admin == nil && collection.ViewRule == nil {
+		// only admins can access if the rule is nil
+		return nil, NewForbiddenError("Only admins can perform this action.", nil)
+	}
+
+	ruleFunc := func(q *dbx.SelectQuery) error {
+		if requestData.Admin == nil && collection.ViewRule != nil && *collection.ViewRule != "" {
+			resolver := resolvers.NewRecordFieldResolver(api.app.Dao(), collection, requestData, true)
+			expr, err := search.FilterData(*collection.ViewRule).BuildExpr(resolver)
+			if err != nil {
+				return err
+			}
+			resolver.UpdateQuery(q)
+			q.AndWhere(expr)
+		}
+		return nil
+	}
+
+	record, fetchErr := api.app.Dao().FindRecordById(collection.Id, recordId, ruleFunc)
+	if fetchErr != nil || record == nil {
+		return nil, NewNotFoundError("", fetchErr)
+	}
+
+	items, err := api.exportRecords(c, collection, []*models.Record{record}, field)
+	if err != nil {
+		return nil, err
+	}
+
+	return items[0], nil
+}
+
+// mutate resolves the create, update and delete mutation fields
+// by executing them as a single operation transaction.
+func (api *graphqlApi) mutate(
+	c echo.Context,
+	requestData *models.RequestData,
+	collection *models.Collection,
+	action string,
+	field *graphql.Field,
+) (any, error) {
+	op := &TransactionOperation{
+		Action:     action,
+		Collection: collection.Id,
+		Id:         cast.ToString(field.Arguments["id"]),
+	}
+
+	if raw, ok := field.Arguments["data"]; ok && raw != nil {
+		data, ok := raw.(map[string]any)
+		if !ok {
+			return nil, NewBadRequestError("The \"data\" argument must be an object.", nil)
+		}
+		op.Data = data
+	}
+
+	if err := op.Validate(); err != nil {
+		return nil, NewBadRequestError("An error occurred while validating the submitted data.", err)
+	}
+
+	var record *models.Record
+
+	txErr := api.app.Dao().RunInTransaction(func(txDao *daos.Dao) error {
+		var err error
+		record, err = (&recordTransactionApi{app: api.app}).execOperation(txDao, requestData, op)
+		return err
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	if action == TransactionOperationDelete {
+		return true, nil
+	}
+
+	items, err := api.exportRecords(c, collection, []*models.Record{record}, field)
+	if err != nil {
+		return nil, err
+	}
+
+	return items[0], nil
+}
+
+// exportRecords enriches the provided records and
+// converts them to json maps with their GraphQL type name.
+func (api *graphqlApi) exportRecords(
+	c echo.Context,
+	collection *models.Collection,
+	records []*models.Record,
+	field *graphql.Field,
+) ([]map[string]any, error) {
+	var expands []string
+	if expand := cast.ToString(field.Arguments["expand"]); expand != "" {
+		expands = strings.Split(expand, ",")
+	}
+
+	if err := EnrichRecords(c, api.app.Dao(), records, expands...); err != nil && api.app.IsDebug() {
+		log.Println(err)
+	}
+
+	raw, err := json.Marshal(records)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []map[string]any{}
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, err
+	}
+
+	typeName := inflector.UcFirst(collection.Name)
+	for _, item := range result {
+		item[graphqlTypenameField] = typeName
+	}
+
+	return result, nil
+}
+
+// graphqlError converts the provided field resolve error to a GraphQL error.
+func graphqlError(err error, key string) *graphql.Error {
+	result := &graphql.Error{
+		Message: err.Error(),
+		Path:    []any{key},
+	}
+
+	var apiErr *ApiError
+	if errors.As(err, &apiErr) {
+		result.Message = apiErr.Message
+		result.Extensions = map[string]any{
+			"code": apiErr.Code,
+			"data": apiErr.Data,
+		}
+	}
+
+	return result
+}
+
+// graphqlErrorResponse sends a GraphQL response with
+// a single request error (eg. a malformed query document).
+func graphqlErrorResponse(c echo.Context, status int, message string) error {
+	return c.JSON(status, &graphql.Response{
+		Errors: []*graphql.Error{{Message: message}},
+	})
+}
+
+// -------------------------------------------------------------------
+
+// graphqlSchema generates the GraphQL schema definition (SDL)
+// of the provided collections records queries and mutations.
+func graphqlSchema(collections []*models.Collection) string {
+	var types, queries, mutations strings.Builder
+
+	types.WriteString("scalar JSON\n")
+
+	for _, collection := range collections {
+		typeName := inflector.UcFirst(collection.Name)
+
+		types.WriteString("\ntype " + typeName + " {\n")
+		types.WriteString("  id: ID!\n")
+		types.WriteString("  created: String\n")
+		types.WriteString("  updated: String\n")
+		types.WriteString("  collectionId: String\n")
+		types.WriteString("  collectionName: String\n")
+		if collection.IsAuth() {
+			types.WriteString("  username: String\n")
+			types.WriteString("  email: String\n")
+			types.WriteString("  emailVisibility: Boolean\n")
+			types.WriteString("  verified: Boolean\n")
+		}
+		for _, f := range collection.Schema.Fields() {
+			types.WriteString("  " + f.Name + ": " + graphqlFieldType(f) + "\n")
+		}
+		types.WriteString("  expand: JSON\n")
+		types.WriteString("}\n")
+
+		types.WriteString("\ntype " + typeName + "List {\n")
+		types.WriteString("  page: Int!\n")
+		types.WriteString("  perPage: Int!\n")
+		types.WriteString("  totalItems: Int!\n")
+		types.WriteString("  totalPages: Int!\n")
+		types.WriteString("  items: [" + typeName + "!]!\n")
+		types.WriteString("}\n")
+
+		queries.WriteString("  " + collection.Name + "(id: ID!, expand: String): " + typeName + "\n")
+		queries.WriteString("  " + collection.Name + "List(filter: String, sort: String, page: Int, perPage: Int, expand: String): " + typeName + "List\n")
+
+		if collection.IsView() {
+			continue
+		}
+
+		mutations.WriteString("  create" + typeName + "(data: JSON!, expand: String): " + typeName + "\n")
+		mutations.WriteString("  update" + typeName + "(id: ID!, data: JSON!, expand: String): " + typeName + "\n")
+		mutations.WriteString("  delete" + typeName + "(id: ID!): Boolean\n")
+	}
+
+	var sdl strings.Builder
+
+	sdl.WriteString(types.String())
+
+	if queries.Len() > 0 {
+		sdl.WriteString("\ntype Query {\n" + queries.String() + "}\n")
+	}
+
+	if mutations.Len() > 0 {
+		sdl.WriteString("\ntype Mutation {\n" + mutations.String() + "}\n")
+	}
+
+	return sdl.String()
+}
+
+// graphqlFieldType returns the GraphQL type of the provided schema field.
+func graphqlFieldType(f *schema.SchemaField) string {
+	if f.IsLocalized() {
+		return "JSON"
+	}
+
+	switch f.Type {
+	case schema.FieldTypeNumber:
+		return "Float"
+	case schema.FieldTypeBool:
+		return "Boolean"
+	case schema.FieldTypeJson, schema.FieldTypeGeoPoint:
+		return "JSON"
+	}
+
+	if options, ok := f.Options.(schema.MultiValuer); ok && options.IsMultiple() {
+		return "[String]"
+	}
+
+	return "String"
+}
@@ -0,0 +1,220 @@
+package apis_test
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func enableTestGraphql(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+	app.Settings().Graphql.Enabled = true
+}
+
+func TestGraphqlExecute(t *testing.T) {
+	scenarios := []tests.ApiScenario{
+		{
+			Name:            "disabled",
+			Method:          http.MethodPost,
+			Url:             "/api/graphql",
+			Body:            strings.NewReader(`{"query":"{ demo2List { totalItems } }"}`),
+			ExpectedStatus:  404,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:            "invalid query document",
+			Method:          http.MethodPost,
+			Url:             "/api/graphql",
+			Body:            strings.NewReader(`{"query":"{ demo2List { totalItems }"}`),
+			BeforeTestFunc:  enableTestGraphql,
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"errors":[{"message":`},
+			ExpectedEvents: map[string]int{
+				"OnBeforeApiError": 0,
+				"OnAfterApiError":  0,
+			},
+		},
+		{
+			Name:            "mutation with GET request",
+			Method:          http.MethodGet,
+			Url:             "/api/graphql?query=" + url.QueryEscape(`mutation { deleteDemo2(id: "llvuca81nly1qls") }`),
+			BeforeTestFunc:  enableTestGraphql,
+			ExpectedStatus:  405,
+			ExpectedContent: []string{`"message":"Mutations are allowed only with POST requests."`},
+			ExpectedEvents: map[string]int{
+				"OnBeforeApiError": 0,
+				"OnAfterApiError":  0,
+			},
+		},
+		{
+			Name:   "guest listing with filter, sort and variables",
+			Method: http.MethodPost,
+			Url:    "/api/graphql",
+			Body: strings.NewReader(`{
+				"query":"query Items($active: String) { __typename items: demo2List(filter: $active, sort: \"-title\") { __typename totalItems items { id title } } }",
+				"variables":{"active":"active = true"}
+			}`),
+			BeforeTestFunc: enableTestGraphql,
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"data":{"__typename":"Query","items":{"__typename":"Demo2List","totalItems":2,"items":[{"id":"0yxhwia2amd8gec","title":"test3"},{"id":"achvryl401bhse3","title":"test2"}]}}`,
+			},
+			NotExpectedContent: []string{`"errors"`},
+		},
+		{
+			Name:           "guest viewing by id with GET request",
+			Method:         http.MethodGet,
+			Url:            "/api/graphql?query=" + url.QueryEscape(`{ demo2(id: "achvryl401bhse3") { title active } }`),
+			BeforeTestFunc: enableTestGraphql,
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"data":{"demo2":{"title":"test2","active":true}}`,
+			},
+			NotExpectedContent: []string{`"errors"`},
+		},
+		{
+			Name:           "guest querying admin only and unknown fields",
+			Method:         http.MethodPost,
+			Url:            "/api/graphql",
+			Body:           strings.NewReader(`{"query":"{ demo1List { totalItems } missing demo2(id: \"achvryl401bhse3\") { title } }"}`),
+			BeforeTestFunc: enableTestGraphql,
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"data":{"demo1List":null,"missing":null,"demo2":{"title":"test2"}}`,
+				`{"message":"Only admins can perform this action.","path":["demo1List"],"extensions":{"code":403,"data":{}}}`,
+				`{"message":"Cannot query field \"missing\" on type \"Query\".","path":["missing"]}`,
+			},
+		},
+		{
+			Name:           "guest filtering by @request field",
+			Method:         http.MethodPost,
+			Url:            "/api/graphql",
+			Body:           strings.NewReader(`{"query":"{ demo2List(filter: \"@request.auth.id = ''\") { totalItems } }"}`),
+			BeforeTestFunc: enableTestGraphql,
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"data":{"demo2List":null}`,
+				`"message":"Only admins can filter by @collection and @request query params."`,
+			},
+		},
+		{
+			Name:   "admin listing admin only collection",
+			Method: http.MethodPost,
+			Url:    "/api/graphql",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgAdminToken,
+			},
+			Body:           strings.NewReader(`{"query":"{ demo1List(perPage: 1) { perPage totalItems } }"}`),
+			BeforeTestFunc: enableTestGraphql,
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"data":{"demo1List":{"perPage":1,"totalItems":3}}`,
+			},
+			NotExpectedContent: []string{`"errors"`},
+		},
+		{
+			Name:   "guest creating, updating and deleting records",
+			Method: http.MethodPost,
+			Url:    "/api/graphql",
+			Body: strings.NewReader(`{"query":"mutation {` +
+				`created: createDemo2(data: {id: \"gqlrecord123456\", title: \"gql_new\"}) { __typename id title } ` +
+				`updated: updateDemo2(id: \"gqlrecord123456\", data: {active: true}) { active } ` +
+				`deleteDemo2(id: \"llvuca81nly1qls\")` +
+				`}"}`),
+			BeforeTestFunc: enableTestGraphql,
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"data":{"created":{"__typename":"Demo2","id":"gqlrecord123456","title":"gql_new"},"updated":{"active":true},"deleteDemo2":true}`,
+			},
+			NotExpectedContent: []string{`"errors"`},
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeCreate": 1,
+				"OnModelAfterCreate":  1,
+				// +1 for the deleted record relation reference cleanup
+				"OnModelBeforeUpdate": 2,
+				"OnModelAfterUpdate":  2,
+				"OnModelBeforeDelete": 1,
+				"OnModelAfterDelete":  1,
+			},
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				record, err := app.Dao().FindRecordById("demo2", "gqlrecord123456")
+				if err != nil || !record.GetBool("active") {
+					t.Fatalf("Expected the created record to be persisted and updated: %v", err)
+				}
+
+				if _, err := app.Dao().FindRecordById("demo2", "llvuca81nly1qls"); err == nil {
+					t.Fatal("Expected the deleted record to be missing")
+				}
+			},
+		},
+		{
+			Name:           "guest creating record in admin only collection",
+			Method:         http.MethodPost,
+			Url:            "/api/graphql",
+			Body:           strings.NewReader(`{"query":"mutation { createDemo1(data: {text: \"gql_new\"}) { id } }"}`),
+			BeforeTestFunc: enableTestGraphql,
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"data":{"createDemo1":null}`,
+				`"message":"Only admins can perform this action."`,
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
+
+func TestGraphqlSchema(t *testing.T) {
+	scenarios := []tests.ApiScenario{
+		{
+			Name:   "disabled",
+			Method: http.MethodGet,
+			Url:    "/api/graphql/schema",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgAdminToken,
+			},
+			ExpectedStatus:  404,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:            "guest",
+			Method:          http.MethodGet,
+			Url:             "/api/graphql/schema",
+			BeforeTestFunc:  enableTestGraphql,
+			ExpectedStatus:  401,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "admin",
+			Method: http.MethodGet,
+			Url:    "/api/graphql/schema",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgAdminToken,
+			},
+			BeforeTestFunc: enableTestGraphql,
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				"scalar JSON\n",
+				"type Demo2 {\n  id: ID!\n",
+				"  title: String\n  active: Boolean\n",
+				"type Demo2List {\n",
+				"  demo2List(filter: String, sort: String, page: Int, perPage: Int, expand: String): Demo2List\n",
+				"  createDemo2(data: JSON!, expand: String): Demo2\n",
+				"  deleteDemo2(id: ID!): Boolean\n",
+				"  view1(id: ID!, expand: String): View1\n",
+			},
+			NotExpectedContent: []string{
+				"createView1",
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
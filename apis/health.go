@@ -1,38 +1,214 @@
 package apis
 
 import (
+	"context"
+	"errors"
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/labstack/echo/v5"
 	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/registry"
 )
 
+// List of the health component statuses.
+const (
+	HealthStatusUp       = "up"
+	HealthStatusDown     = "down"
+	HealthStatusDisabled = "disabled"
+)
+
+// healthCheckTimeout is the max duration of a single component check.
+const healthCheckTimeout = 5 * time.Second
+
 // bindHealthApi registers the health api endpoint.
 func bindHealthApi(app core.App, rg *echo.Group) {
 	api := healthApi{app: app}
 
 	subGroup := rg.Group("/health")
 	subGroup.GET("", api.healthCheck)
+	subGroup.GET("/live", api.liveCheck)
 }
 
 type healthApi struct {
 	app core.App
 }
 
+// HealthComponent is the health status of a single app dependency.
+type HealthComponent struct {
+	Status string `json:"status"`
+
+	// Latency is the component check duration in milliseconds.
+	Latency float64 `json:"latency"`
+
+	// Error is the component failure reason (visible only to admins).
+	Error string `json:"error,omitempty"`
+}
+
 type healthCheckResponse struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
 	Data    struct {
-		CanBackup bool `json:"canBackup"`
+		CanBackup  bool                        `json:"canBackup"`
+		Components map[string]*HealthComponent `json:"components,omitempty"`
 	} `json:"data"`
 }
 
-// healthCheck returns a 200 OK response if the server is healthy.
+// healthCheck checks the app dependencies and returns a 200 OK response
+// if the server is ready to serve requests (otherwise 503).
+//
+// Use it for readiness probes (see liveCheck for the liveness probes).
 func (api *healthApi) healthCheck(c echo.Context) error {
+	components := api.checkComponents(c.Request().Context())
+
+	// the failure details could contain internal addresses and credentials
+	if admin, _ := c.Get(ContextAdminKey).(*models.Admin); admin == nil {
+		for _, component := range components {
+			component.Error = ""
+		}
+	}
+
 	resp := new(healthCheckResponse)
 	resp.Code = http.StatusOK
 	resp.Message = "API is healthy."
 	resp.Data.CanBackup = !api.app.Cache().Has(core.CacheKeyActiveBackup)
+	resp.Data.Components = components
+
+	for _, component := range components {
+		if component.Status == HealthStatusDown {
+			resp.Code = http.StatusServiceUnavailable
+			resp.Message = "API is unhealthy."
+			break
+		}
+	}
+
+	return c.JSON(resp.Code, resp)
+}
+
+// liveCheck returns a 200 OK response if the server is running
+// (without checking the app dependencies).
+func (api *healthApi) liveCheck(c echo.Context) error {
+	resp := new(healthCheckResponse)
+	resp.Code = http.StatusOK
+	resp.Message = "API is alive."
+	resp.Data.CanBackup = !api.app.Cache().Has(core.CacheKeyActiveBackup)
 
 	return c.JSON(http.StatusOK, resp)
 }
+
+// checkComponents concurrently runs all component checks.
+func (api *healthApi) checkComponents(ctx context.Context) map[string]*HealthComponent {
+	checks := map[string]func(ctx context.Context) (string, error){
+		"db":       api.checkDB,
+		"registry": api.checkRegistry,
+		"storage":  api.checkStorage,
+		"smtp":     api.checkSmtp,
+	}
+
+	var mux sync.Mutex
+	var wg sync.WaitGroup
+
+	result := make(map[string]*HealthComponent, len(checks))
+
+	for name, check := range checks {
+		wg.Add(1)
+
+		go func(name string, check func(ctx context.Context) (string, error)) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+			defer cancel()
+
+			start := time.Now()
+			status, err := check(checkCtx)
+
+			component := &HealthComponent{
+				Status:  status,
+				Latency: float64(time.Since(start).Microseconds()) / 1000,
+			}
+			if err != nil {
+				component.Status = HealthStatusDown
+				component.Error = err.Error()
+			}
+
+			mux.Lock()
+			result[name] = component
+			mux.Unlock()
+		}(name, check)
+	}
+
+	wg.Wait()
+
+	return result
+}
+
+// checkDB pings the app data and logs databases.
+func (api *healthApi) checkDB(ctx context.Context) (string, error) {
+	if err := api.app.DB().DB().PingContext(ctx); err != nil {
+		return "", err
+	}
+
+	if err := api.app.LogsDB().DB().PingContext(ctx); err != nil {
+		return "", err
+	}
+
+	return HealthStatusUp, nil
+}
+
+// checkRegistry pings all opened registry connections.
+func (api *healthApi) checkRegistry(ctx context.Context) (string, error) {
+	registries := registry.List()
+	if len(registries) == 0 {
+		return HealthStatusDisabled, nil
+	}
+
+	for _, reg := range registries {
+		sqlDB, err := reg.DB.DB()
+		if err != nil {
+			return "", errors.New(reg.Name + ": " + err.Error())
+		}
+
+		if err := sqlDB.PingContext(ctx); err != nil {
+			return "", errors.New(reg.Name + ": " + err.Error())
+		}
+	}
+
+	return HealthStatusUp, nil
+}
+
+// checkStorage checks whether the files storage (local or S3) is reachable.
+func (api *healthApi) checkStorage(ctx context.Context) (string, error) {
+	fs, err := api.app.NewFilesystem()
+	if err != nil {
+		return "", err
+	}
+	defer fs.Close()
+
+	fs.SetContext(ctx)
+
+	// a missing file is not an error, only the storage errors are
+	if _, err := fs.Exists("health_check"); err != nil {
+		return "", err
+	}
+
+	return HealthStatusUp, nil
+}
+
+// checkSmtp checks the SMTP configuration (no connection is established).
+//
+// If SMTP is disabled, the mails are sent with sendmail.
+func (api *healthApi) checkSmtp(ctx context.Context) (string, error) {
+	smtp := api.app.Settings().Smtp
+
+	if !smtp.Enabled {
+		return HealthStatusDisabled, nil
+	}
+
+	if smtp.Host == "" || smtp.Port <= 0 {
+		return "", errors.New("missing SMTP host or port")
+	}
+
+	return HealthStatusUp, nil
+}
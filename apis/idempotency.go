@@ -0,0 +1,207 @@
+package apis
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+)
+
+const (
+	// HeaderIdempotencyKey is the request header with the client generated idempotency key.
+	HeaderIdempotencyKey = "Idempotency-Key"
+
+	// HeaderIdempotentReplayed is the response header set when the
+	// response is a replay of a previously stored one.
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+)
+
+const maxIdempotencyKeyLength = 255
+
+type idempotentResponse struct {
+	fingerprint string
+	expires     time.Time
+
+	// completed indicates whether the first request has finished
+	// and the response fields below are populated
+	completed bool
+
+	status      int
+	contentType string
+	etag        string
+	body        []byte
+}
+
+// idempotencyStore is an in-memory store of the idempotent
+// requests responses grouped by their scoped key.
+type idempotencyStore struct {
+	mux     sync.Mutex
+	entries map[string]*idempotentResponse
+}
+
+func newIdempotencyStore() *idempotencyStore {
+	return &idempotencyStore{
+		entries: map[string]*idempotentResponse{},
+	}
+}
+
+// reserve returns the existing non-expired entry of the key
+// or registers a new pending one (in which case the second
+// returned argument is false).
+func (s *idempotencyStore) reserve(key string, fingerprint string, ttl time.Duration) (*idempotentResponse, bool) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	now := time.Now()
+
+	// cleanup the expired entries
+	for k, entry := range s.entries {
+		if !now.Before(entry.expires) {
+			delete(s.entries, k)
+		}
+	}
+
+	if entry, ok := s.entries[key]; ok {
+		copied := *entry
+		return &copied, true
+	}
+
+	s.entries[key] = &idempotentResponse{
+		fingerprint: fingerprint,
+		expires:     now.Add(ttl),
+	}
+
+	return nil, false
+}
+
+// complete stores the response of the previously reserved key.
+func (s *idempotencyStore) complete(key string, status int, header http.Header, body []byte) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok {
+		return
+	}
+
+	entry.completed = true
+	entry.status = status
+	entry.contentType = header.Get(echo.HeaderContentType)
+	entry.etag = header.Get(HeaderETag)
+	entry.body = append([]byte(nil), body...)
+}
+
+// release deletes the reserved key allowing it to be retried.
+func (s *idempotencyStore) release(key string) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	delete(s.entries, key)
+}
+
+// idempotent middleware stores the first successful response of
+// a request with an "Idempotency-Key" header and replays it for
+// the retries with the same key (for the configured settings TTL).
+//
+// The key is scoped to the request method and path and the
+// authorized admin or auth record. Failed requests are not stored
+// so that they could be retried with the same key.
+func idempotent(app core.App, store *idempotencyStore) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			config := app.Settings().Idempotency
+
+			key := c.Request().Header.Get(HeaderIdempotencyKey)
+			if !config.Enabled || key == "" {
+				return next(c)
+			}
+
+			if len(key) > maxIdempotencyKeyLength {
+				return NewBadRequestError("The Idempotency-Key header must be no more than 255 characters.", nil)
+			}
+
+			r := c.Request()
+
+			var body []byte
+			if r.Body != nil {
+				var err error
+				body, err = io.ReadAll(r.Body)
+				if err != nil {
+					return NewBadRequestError("Failed to read the request body.", err)
+				}
+				// restore the request body for the next readers
+				r.Body = io.NopCloser(bytes.NewReader(body))
+			}
+
+			hash := sha256.New()
+			hash.Write([]byte(r.URL.RequestURI()))
+			hash.Write([]byte{0})
+			hash.Write(body)
+			fingerprint := hex.EncodeToString(hash.Sum(nil))
+
+			scopedKey := idempotencyScope(c) + " " + r.Method + " " + r.URL.Path + " " + key
+
+			entry, exists := store.reserve(scopedKey, fingerprint, time.Duration(config.TTL)*time.Second)
+			if exists {
+				if entry.fingerprint != fingerprint {
+					return NewApiError(http.StatusUnprocessableEntity, "The Idempotency-Key was already used with a different request.", nil)
+				}
+
+				if !entry.completed {
+					return NewApiError(http.StatusConflict, "A request with the same Idempotency-Key is still in progress.", nil)
+				}
+
+				c.Response().Header().Set(HeaderIdempotentReplayed, "true")
+				if entry.etag != "" {
+					c.Response().Header().Set(HeaderETag, entry.etag)
+				}
+
+				return c.Blob(entry.status, entry.contentType, entry.body)
+			}
+
+			res := c.Response()
+
+			recorder := &responseBodyRecorder{ResponseWriter: res.Writer}
+			res.Writer = recorder
+
+			completed := false
+			defer func() {
+				res.Writer = recorder.ResponseWriter
+
+				// release the key also on panic
+				if !completed {
+					store.release(scopedKey)
+				}
+			}()
+
+			if err := next(c); err != nil || res.Status < 200 || res.Status > 299 {
+				return err
+			}
+
+			store.complete(scopedKey, res.Status, res.Header(), recorder.body.Bytes())
+			completed = true
+
+			return nil
+		}
+	}
+}
+
+// idempotencyScope returns the identifier of the request authorized admin or auth record.
+func idempotencyScope(c echo.Context) string {
+	requestData := RequestData(c)
+
+	switch {
+	case requestData.Admin != nil:
+		return "admin:" + requestData.Admin.Id
+	case requestData.AuthRecord != nil:
+		return requestData.AuthRecord.Collection().Id + ":" + requestData.AuthRecord.Id
+	default:
+		return "guest"
+	}
+}
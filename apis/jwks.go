@@ -0,0 +1,44 @@
+package apis
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tokens"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+// bindJwksApi registers the public auth tokens verification keys endpoint.
+func bindJwksApi(app core.App, e *echo.Echo) {
+	api := jwksApi{app: app}
+
+	e.GET("/.well-known/jwks.json", api.jwks)
+}
+
+type jwksApi struct {
+	app core.App
+}
+
+// JwksResponse is the JSON Web Key Set document (RFC 7517).
+type JwksResponse struct {
+	Keys []*security.JWK `json:"keys"`
+}
+
+//	@Summary		JSON Web Key Set
+//	@Description	Returns the public keys for local verification of the RS256/EdDSA signed admin and auth record tokens
+//	@Tags			Settings
+//	@Produce		json
+//	@Success		200	{object}	JwksResponse
+//	@Failure		400	{string}	string	"Failed to load the token signing keys."
+//	@Router			/.well-known/jwks.json [get]
+func (api *jwksApi) jwks(c echo.Context) error {
+	keys, err := tokens.JWKS(api.app)
+	if err != nil {
+		return NewBadRequestError("Failed to load the token signing keys.", err)
+	}
+
+	c.Response().Header().Set("Cache-Control", "public, max-age=300")
+
+	return c.JSON(http.StatusOK, JwksResponse{Keys: keys})
+}
@@ -0,0 +1,100 @@
+package apis_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tokens"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+func TestJwks(t *testing.T) {
+	scenarios := []tests.ApiScenario{
+		{
+			Name:            "no signing keys",
+			Method:          http.MethodGet,
+			Url:             "/.well-known/jwks.json",
+			ExpectedStatus:  200,
+			ExpectedContent: []string{`{"keys":[]}`},
+		},
+		{
+			Name:   "with signing keys",
+			Method: http.MethodGet,
+			Url:    "/.well-known/jwks.json",
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				rsaKey, _ := security.GenerateSigningKey(security.AlgorithmRS256)
+				edKey, _ := security.GenerateSigningKey(security.AlgorithmEdDSA)
+
+				app.Settings().TokenSigning.Keys = []settings.TokenSigningKey{
+					{Id: "a", Algorithm: security.AlgorithmRS256, PrivateKey: rsaKey},
+					{Id: "b", Algorithm: security.AlgorithmEdDSA, PrivateKey: edKey},
+				}
+			},
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"kty":"RSA","use":"sig","alg":"RS256","kid":"a"`,
+				`"e":"AQAB"`,
+				`"kty":"OKP","use":"sig","alg":"EdDSA","kid":"b"`,
+				`"crv":"Ed25519"`,
+			},
+			NotExpectedContent: []string{
+				"privateKey",
+				`"d":`,
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
+
+func TestAsymmetricAuthToken(t *testing.T) {
+	var token string
+
+	scenario := tests.ApiScenario{
+		Name:   "admin authorized with EdDSA signed token",
+		Method: http.MethodGet,
+		Url:    "/api/admins",
+		BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+			privateKey, _ := security.GenerateSigningKey(security.AlgorithmEdDSA)
+
+			app.Settings().TokenSigning = settings.TokenSigningConfig{
+				Algorithm: security.AlgorithmEdDSA,
+				KeyId:     "test",
+				Keys: []settings.TokenSigningKey{
+					{Id: "test", Algorithm: security.AlgorithmEdDSA, PrivateKey: privateKey},
+				},
+			}
+
+			admin, err := app.Dao().FindAdminByEmail("test@example.com")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			token, err = tokens.NewAdminAuthToken(app, admin)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			e.Pre(func(next echo.HandlerFunc) echo.HandlerFunc {
+				return func(c echo.Context) error {
+					c.Request().Header.Set("Authorization", token)
+					return next(c)
+				}
+			})
+		},
+		ExpectedStatus: 200,
+		ExpectedContent: []string{
+			`"totalItems":`,
+		},
+		ExpectedEvents: map[string]int{
+			"OnAdminsListRequest": 1,
+		},
+	}
+
+	scenario.Test(t)
+}
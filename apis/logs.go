@@ -1,12 +1,17 @@
 package apis
 
 import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/labstack/echo/v5"
 	"github.com/pocketbase/dbx"
 	"github.com/pocketbase/pocketbase/core"
 	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/parquet"
 	"github.com/pocketbase/pocketbase/tools/search"
 )
 
@@ -18,6 +23,9 @@ func bindLogsApi(app core.App, rg *echo.Group) {
 	subGroup.GET("/requests", api.requestsList)
 	subGroup.GET("/requests/stats", api.requestsStats)
 	subGroup.GET("/requests/:id", api.requestView)
+	subGroup.GET("/export", api.requestsExport)
+	subGroup.GET("/audit", api.auditList)
+	subGroup.GET("/audit/:id", api.auditView)
 }
 
 type logsApi struct {
@@ -30,6 +38,54 @@ var requestFilterFields = []string{
 	"remoteIp", "userIp", "referer", "userAgent",
 }
 
+const (
+	requestsExportFormatCsv     = "csv"
+	requestsExportFormatNdjson  = "ndjson"
+	requestsExportFormatParquet = "parquet"
+
+	// requestsExportFlushBatch is the number of the exported request
+	// logs after which the response is flushed to the client.
+	requestsExportFlushBatch = 500
+)
+
+var requestsExportCsvColumns = []string{
+	"id", "created", "method", "url", "status", "auth",
+	"userIp", "remoteIp", "referer", "userAgent", "meta",
+}
+
+var requestsExportParquetColumns = []parquet.Column{
+	{Name: "id", Type: parquet.TypeString},
+	{Name: "created", Type: parquet.TypeTimestamp},
+	{Name: "method", Type: parquet.TypeString},
+	{Name: "url", Type: parquet.TypeString},
+	{Name: "status", Type: parquet.TypeInt64},
+	{Name: "auth", Type: parquet.TypeString},
+	{Name: "userIp", Type: parquet.TypeString},
+	{Name: "remoteIp", Type: parquet.TypeString},
+	{Name: "referer", Type: parquet.TypeString},
+	{Name: "userAgent", Type: parquet.TypeString},
+	{Name: "meta", Type: parquet.TypeString},
+}
+
+var auditFilterFields = []string{
+	"rowid", "id", "created", "updated",
+	"action", "resource", "resourceId",
+	"actor", "actorId", "userIp", "url",
+}
+
+//	@Summary		List request logs
+//	@Description	Returns a paginated request logs list
+//	@Tags			Logs
+//	@Produce		json
+//	@Param			page	query	int		false	"Page number"
+//	@Param			perPage	query	int		false	"Items per page"
+//	@Param			sort	query	string	false	"Sort fields"
+//	@Param			filter	query	string	false	"Filter expression"
+//	@Security		AdminAuth
+//	@Success		200	{object}	search.Result{items=[]models.Request}
+//	@Failure		400	{string}	string	"Something went wrong while processing your request."
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Router			/logs/requests [get]
 func (api *logsApi) requestsList(c echo.Context) error {
 	fieldResolver := search.NewSimpleFieldResolver(requestFilterFields...)
 
@@ -44,6 +100,16 @@ func (api *logsApi) requestsList(c echo.Context) error {
 	return c.JSON(http.StatusOK, result)
 }
 
+//	@Summary		Request logs stats
+//	@Description	Returns the hourly grouped request logs statistics
+//	@Tags			Logs
+//	@Produce		json
+//	@Param			filter	query	string	false	"Filter expression"
+//	@Security		AdminAuth
+//	@Success		200	{array}		daos.RequestsStatsItem
+//	@Failure		400	{string}	string	"Failed to generate requests stats."
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Router			/logs/requests/stats [get]
 func (api *logsApi) requestsStats(c echo.Context) error {
 	fieldResolver := search.NewSimpleFieldResolver(requestFilterFields...)
 
@@ -66,6 +132,16 @@ func (api *logsApi) requestsStats(c echo.Context) error {
 	return c.JSON(http.StatusOK, stats)
 }
 
+//	@Summary		View request log
+//	@Description	Returns a single request log by its id
+//	@Tags			Logs
+//	@Produce		json
+//	@Param			id	path	string	true	"Request log id"
+//	@Security		AdminAuth
+//	@Success		200	{object}	models.Request
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Failure		404	{string}	string	"The requested resource wasn't found."
+//	@Router			/logs/requests/{id} [get]
 func (api *logsApi) requestView(c echo.Context) error {
 	id := c.PathParam("id")
 	if id == "" {
@@ -79,3 +155,224 @@ func (api *logsApi) requestView(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, request)
 }
+
+//	@Summary		Export request logs
+//	@Description	Streams all request logs matching the filter as csv, ndjson (newline delimited json) or parquet file
+//	@Tags			Logs
+//	@Produce		text/csv
+//	@Produce		application/x-ndjson
+//	@Produce		application/vnd.apache.parquet
+//	@Param			format	query	string	false	"Export format (csv, ndjson or parquet, default to csv)"
+//	@Param			sort	query	string	false	"Sort fields (default to -rowid)"
+//	@Param			filter	query	string	false	"Filter expression"
+//	@Security		AdminAuth
+//	@Success		200	"OK"
+//	@Failure		400	{string}	string	"Invalid export parameters."
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Router			/logs/export [get]
+func (api *logsApi) requestsExport(c echo.Context) error {
+	format := c.QueryParam("format")
+	if format == "" {
+		format = requestsExportFormatCsv
+	}
+	if format != requestsExportFormatCsv &&
+		format != requestsExportFormatNdjson &&
+		format != requestsExportFormatParquet {
+		return NewBadRequestError("Invalid export format.", nil)
+	}
+
+	fieldResolver := search.NewSimpleFieldResolver(requestFilterFields...)
+
+	query := api.app.LogsDao().RequestQuery()
+
+	if filter := c.QueryParam(search.FilterQueryParam); filter != "" {
+		expr, err := search.FilterData(filter).BuildExpr(fieldResolver)
+		if err != nil {
+			return NewBadRequestError("Invalid filter format.", err)
+		}
+		query.AndWhere(expr)
+	}
+
+	sort := c.QueryParam(search.SortQueryParam)
+	if sort == "" {
+		sort = "-rowid"
+	}
+	for _, field := range search.ParseSortFromString(sort) {
+		expr, err := field.BuildExpr(fieldResolver)
+		if err != nil {
+			return NewBadRequestError("Invalid sort format.", err)
+		}
+		query.AndOrderBy(expr)
+	}
+
+	rows, err := query.Rows()
+	if err != nil {
+		return NewBadRequestError("Failed to export the request logs.", err)
+	}
+	defer rows.Close()
+
+	var writeRow func(request *models.Request) error
+	var flush func() error
+	var finish func() error
+
+	res := c.Response()
+
+	contentType := "text/csv; charset=utf-8"
+	switch format {
+	case requestsExportFormatNdjson:
+		contentType = "application/x-ndjson"
+	case requestsExportFormatParquet:
+		contentType = "application/vnd.apache.parquet"
+	}
+	res.Header().Set(echo.HeaderContentType, contentType)
+	res.Header().Set(
+		echo.HeaderContentDisposition,
+		fmt.Sprintf("attachment; filename=%q", "requests."+format),
+	)
+	res.WriteHeader(http.StatusOK)
+
+	switch format {
+	case requestsExportFormatParquet:
+		w, err := parquet.NewWriter(res, requestsExportParquetColumns)
+		if err != nil {
+			return err
+		}
+
+		writeRow = func(request *models.Request) error {
+			meta, _ := request.Meta.MarshalJSON()
+			return w.WriteRow(
+				request.Id,
+				request.Created,
+				request.Method,
+				request.Url,
+				request.Status,
+				request.Auth,
+				request.UserIp,
+				request.RemoteIp,
+				request.Referer,
+				request.UserAgent,
+				string(meta),
+			)
+		}
+		flush = func() error {
+			return nil // the row groups are flushed by the parquet writer
+		}
+		finish = w.Close
+	case requestsExportFormatNdjson:
+		encoder := json.NewEncoder(res)
+		writeRow = func(request *models.Request) error {
+			return encoder.Encode(request)
+		}
+		flush = func() error {
+			res.Flush()
+			return nil
+		}
+		finish = flush
+	default:
+		w := csv.NewWriter(res)
+		writeRow = func(request *models.Request) error {
+			meta, _ := request.Meta.MarshalJSON()
+			return w.Write([]string{
+				request.Id,
+				request.Created.String(),
+				request.Method,
+				request.Url,
+				strconv.Itoa(request.Status),
+				request.Auth,
+				request.UserIp,
+				request.RemoteIp,
+				request.Referer,
+				request.UserAgent,
+				string(meta),
+			})
+		}
+		flush = func() error {
+			w.Flush()
+			res.Flush()
+			return w.Error()
+		}
+		finish = flush
+
+		// the csv writer is buffered so the header row is sent with the first flush
+		if err := w.Write(requestsExportCsvColumns); err != nil {
+			return err
+		}
+	}
+
+	// stream the rows in batches to keep the memory usage low for large ranges
+	total := 0
+	for rows.Next() {
+		request := &models.Request{}
+		if err := rows.ScanStruct(request); err != nil {
+			return err
+		}
+
+		if err := writeRow(request); err != nil {
+			return err
+		}
+
+		total++
+		if total%requestsExportFlushBatch == 0 {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	return finish()
+}
+
+//	@Summary		List audit logs
+//	@Description	Returns a paginated audit logs list
+//	@Tags			Logs
+//	@Produce		json
+//	@Param			page	query	int		false	"Page number"
+//	@Param			perPage	query	int		false	"Items per page"
+//	@Param			sort	query	string	false	"Sort fields"
+//	@Param			filter	query	string	false	"Filter expression"
+//	@Security		AdminAuth
+//	@Success		200	{object}	search.Result{items=[]models.AuditLog}
+//	@Failure		400	{string}	string	"Something went wrong while processing your request."
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Router			/logs/audit [get]
+func (api *logsApi) auditList(c echo.Context) error {
+	fieldResolver := search.NewSimpleFieldResolver(auditFilterFields...)
+
+	result, err := search.NewProvider(fieldResolver).
+		Query(api.app.LogsDao().AuditLogQuery()).
+		ParseAndExec(c.QueryParams().Encode(), &[]*models.AuditLog{})
+
+	if err != nil {
+		return NewBadRequestError("", err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+//	@Summary		View audit log
+//	@Description	Returns a single audit log by its id
+//	@Tags			Logs
+//	@Produce		json
+//	@Param			id	path	string	true	"Audit log id"
+//	@Security		AdminAuth
+//	@Success		200	{object}	models.AuditLog
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Failure		404	{string}	string	"The requested resource wasn't found."
+//	@Router			/logs/audit/{id} [get]
+func (api *logsApi) auditView(c echo.Context) error {
+	id := c.PathParam("id")
+	if id == "" {
+		return NewNotFoundError("", nil)
+	}
+
+	auditLog, err := api.app.LogsDao().FindAuditLogById(id)
+	if err != nil || auditLog == nil {
+		return NewNotFoundError("", err)
+	}
+
+	return c.JSON(http.StatusOK, auditLog)
+}
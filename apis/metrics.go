@@ -0,0 +1,128 @@
+package apis
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/metrics"
+)
+
+// List of the http request metric names.
+const (
+	MetricHttpRequests        string = "pocketbase_http_requests_total"
+	MetricHttpRequestDuration string = "pocketbase_http_request_duration_seconds"
+)
+
+// bindMetricsApi registers the metrics api endpoint.
+func bindMetricsApi(app core.App, rg *echo.Group) {
+	api := metricsApi{app: app}
+
+	rg.GET("/metrics", api.metrics, requireMetricsAccess(app))
+}
+
+type metricsApi struct {
+	app core.App
+}
+
+//	@Summary		Request metrics
+//	@Description	Returns the request counts, latencies and status codes per route and the db query durations in the Prometheus text format
+//	@Tags			Settings
+//	@Produce		plain
+//	@Security		AdminAuth
+//	@Success		200	{string}	string	"Prometheus metrics"
+//	@Failure		401	{string}	string	"The request requires admin authorization token or valid metrics token to be set."
+//	@Failure		404	{string}	string	"Not found."
+//	@Router			/metrics [get]
+func (api *metricsApi) metrics(c echo.Context) error {
+	var buf bytes.Buffer
+
+	if err := api.app.Metrics().Export(&buf); err != nil {
+		return NewBadRequestError("Failed to export the metrics.", err)
+	}
+
+	return c.Blob(http.StatusOK, metrics.ContentType, buf.Bytes())
+}
+
+// requireMetricsAccess middleware requires the metrics to be enabled
+// and the request to be authorized as admin or with the settings metrics token.
+func requireMetricsAccess(app core.App) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			config := app.Settings().Metrics
+
+			if !config.Enabled {
+				return NewNotFoundError("", nil)
+			}
+
+			if admin, _ := c.Get(ContextAdminKey).(*models.Admin); admin != nil {
+				return next(c)
+			}
+
+			token := strings.TrimPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer ")
+
+			if config.Token == "" || token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(config.Token)) != 1 {
+				return NewUnauthorizedError("The request requires admin authorization token or valid metrics token to be set.", nil)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// collectMetrics middleware registers the request count,
+// status code and duration per route in the app metrics (if enabled).
+func collectMetrics(app core.App) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !app.Settings().Metrics.Enabled {
+				return next(c)
+			}
+
+			start := time.Now()
+
+			err := next(c)
+
+			status := c.Response().Status
+			if err != nil {
+				switch v := err.(type) {
+				case *echo.HTTPError:
+					status = v.Code
+				case *ApiError:
+					status = v.Code
+				default:
+					status = http.StatusBadRequest
+				}
+			}
+
+			method := c.Request().Method
+
+			// use the route pattern to avoid high labels cardinality
+			route := c.Path()
+			if route == "" {
+				route = "unknown"
+			}
+
+			app.Metrics().Counter(
+				MetricHttpRequests,
+				"Total number of the processed http requests.",
+				"method", "route", "status",
+			).Inc(method, route, strconv.Itoa(status))
+
+			app.Metrics().Histogram(
+				MetricHttpRequestDuration,
+				"Duration of the processed http requests in seconds.",
+				nil,
+				"method", "route",
+			).Observe(time.Since(start).Seconds(), method, route)
+
+			return err
+		}
+	}
+}
@@ -11,8 +11,10 @@ import (
 	"github.com/labstack/echo/v5"
 	"github.com/pocketbase/pocketbase/core"
 	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/registry"
 	"github.com/pocketbase/pocketbase/tokens"
 	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/logship"
 	"github.com/pocketbase/pocketbase/tools/routine"
 	"github.com/pocketbase/pocketbase/tools/security"
 	"github.com/pocketbase/pocketbase/tools/types"
@@ -24,8 +26,31 @@ const (
 	ContextAdminKey      string = "admin"
 	ContextAuthRecordKey string = "authRecord"
 	ContextCollectionKey string = "collection"
+
+	// ContextActivityMetaKey is an optional [types.JsonMap] with
+	// extra request log meta data set by the api handlers.
+	ContextActivityMetaKey string = "activityMeta"
+
+	// ContextApiKeyKey is the [models.ApiKey] used to authorize
+	// the request (see [apis.RequireApiKeyAuth()]).
+	ContextApiKeyKey string = "apiKey"
+
+	// ContextAuditLogsKey is an optional list of [models.AuditLog]
+	// collected by the audit request hooks (see [ActivityLogger()]).
+	ContextAuditLogsKey string = "auditLogs"
+
+	// ContextUserKey is the authorized GORM registry [models.User]
+	// (see [apis.LoadUserAuthContext()]).
+	ContextUserKey string = "user"
 )
 
+// ApiKeyHeader is the name of the request header with the api key.
+const ApiKeyHeader = "X-API-Key"
+
+// apiKeyLastUsedThreshold is the min interval between
+// the api key lastUsed field updates.
+const apiKeyLastUsedThreshold = time.Minute
+
 // RequireGuestOnly middleware requires a request to NOT have a valid
 // Authorization header.
 //
@@ -205,16 +230,87 @@ func RequireAdminOrOwnerAuth(ownerIdParam string) echo.MiddlewareFunc {
 	}
 }
 
+// RequireApiKeyAuth middleware requires a request to have either
+// a valid Authorization header or a valid api key with the specified
+// scope in the [ApiKeyHeader] request header.
+//
+// The api key substitutes the regular auth, aka. its admin or auth
+// record owner is loaded into the request context, so it is usually
+// combined with the other auth middlewares, eg.:
+//
+//	rg.Group("/users", apis.RequireApiKeyAuth(app, "users"), apis.RequireAdminAuth())
+func RequireApiKeyAuth(app core.App, scope string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			// already authorized with a regular auth token
+			if c.Get(ContextAdminKey) != nil || c.Get(ContextAuthRecordKey) != nil {
+				return next(c)
+			}
+
+			unauthorizedErr := NewUnauthorizedError("The request requires valid authorization token or API key to be set.", nil)
+
+			key := c.Request().Header.Get(ApiKeyHeader)
+			if key == "" {
+				return unauthorizedErr
+			}
+
+			apiKey, err := app.Dao().FindApiKeyByKey(key)
+			if err != nil || apiKey.IsExpired() {
+				return unauthorizedErr
+			}
+
+			if !apiKey.HasScope(scope) {
+				return NewForbiddenError("The API key is not allowed to perform this action.", nil)
+			}
+
+			if apiKey.IsRecordKey() {
+				record, err := app.Dao().FindRecordById(apiKey.CollectionId, apiKey.RecordId)
+				if err != nil || !record.Collection().IsAuth() {
+					return unauthorizedErr
+				}
+				c.Set(ContextAuthRecordKey, record)
+			} else {
+				admin, err := app.Dao().FindAdminById(apiKey.AdminId)
+				if err != nil {
+					return unauthorizedErr
+				}
+				c.Set(ContextAdminKey, admin)
+			}
+
+			c.Set(ContextApiKeyKey, apiKey)
+
+			// throttle the lastUsed writes for the frequently used keys
+			if apiKey.LastUsed.Time().Before(time.Now().Add(-apiKeyLastUsedThreshold)) {
+				if err := app.Dao().UpdateApiKeyLastUsed(apiKey); err != nil && app.IsDebug() {
+					log.Println("Failed to update the api key last usage:", err)
+				}
+			}
+
+			return next(c)
+		}
+	}
+}
+
 // LoadAuthContext middleware reads the Authorization request header
 // and loads the token related record or admin instance into the
 // request's context.
 //
+// If the Authorization header is missing, it fallbacks to the signed
+// request url token (see [SignedRequestQueryParam]).
+//
 // This middleware is expected to be already registered by default for all routes.
 func LoadAuthContext(app core.App) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			token := c.Request().Header.Get("Authorization")
 			if token == "" {
+				// fallback to the signed request url token (if any)
+				if signature := c.QueryParam(SignedRequestQueryParam); signature != "" {
+					if err := loadSignedRequestAuth(app, c, signature); err != nil {
+						return err
+					}
+				}
+
 				return next(c)
 			}
 
@@ -227,18 +323,12 @@ func LoadAuthContext(app core.App) echo.MiddlewareFunc {
 
 			switch tokenType {
 			case tokens.TypeAdmin:
-				admin, err := app.Dao().FindAdminByToken(
-					token,
-					app.Settings().AdminAuthToken.Secret,
-				)
+				admin, err := tokens.FindAdminByAuthToken(app, token)
 				if err == nil && admin != nil {
 					c.Set(ContextAdminKey, admin)
 				}
 			case tokens.TypeAuthRecord:
-				record, err := app.Dao().FindAuthRecordByToken(
-					token,
-					app.Settings().RecordAuthToken.Secret,
-				)
+				record, err := tokens.FindAuthRecordByAuthToken(app, token)
 				if err == nil && record != nil {
 					c.Set(ContextAuthRecordKey, record)
 				}
@@ -249,6 +339,81 @@ func LoadAuthContext(app core.App) echo.MiddlewareFunc {
 	}
 }
 
+// LoadUserAuthContext middleware reads the Authorization request header
+// and loads the token related GORM registry user into the request's context.
+//
+// Because the users registry is resolved per request, this middleware is not
+// registered by default and it is expected to be added only to the routes
+// that need it (see [apis.RequireUserAuth()]).
+func LoadUserAuthContext(app core.App) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			token := strings.TrimPrefix(c.Request().Header.Get("Authorization"), "Bearer ")
+			if token == "" {
+				return next(c)
+			}
+
+			claims, _ := security.ParseUnverifiedJWT(token)
+			if cast.ToString(claims["type"]) != tokens.TypeUser {
+				return next(c)
+			}
+
+			registryName, _ := c.Get("registry").(string)
+
+			reg, err := registry.Get(registryName)
+			if err != nil {
+				return next(c)
+			}
+
+			user, err := tokens.FindUserByAuthToken(app, reg.DB.WithContext(c.Request().Context()), token)
+			if err == nil && user != nil {
+				c.Set(ContextUserKey, user)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// RequireUserAuth middleware requires a request to have a valid
+// GORM registry user Authorization header.
+//
+// The user is expected to be already loaded with [apis.LoadUserAuthContext()].
+func RequireUserAuth() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			user, _ := c.Get(ContextUserKey).(*models.User)
+			if user == nil {
+				return NewUnauthorizedError("The request requires valid user authorization token to be set.", nil)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// RequireGroup middleware requires a request to have a valid GORM
+// registry user Authorization header of a user that is member of
+// at least one of the provided groups (eg. RequireGroup("admin")).
+//
+// The user is expected to be already loaded with [apis.LoadUserAuthContext()].
+func RequireGroup(groups ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			user, _ := c.Get(ContextUserKey).(*models.User)
+			if user == nil {
+				return NewUnauthorizedError("The request requires valid user authorization token to be set.", nil)
+			}
+
+			if !user.HasAnyGroup(groups...) {
+				return NewForbiddenError("The authorized user is not a member of the required groups.", nil)
+			}
+
+			return next(c)
+		}
+	}
+}
+
 // LoadCollectionContext middleware finds the collection with related
 // path identifier and loads it into the request context.
 //
@@ -275,17 +440,28 @@ func LoadCollectionContext(app core.App, optCollectionTypes ...string) echo.Midd
 }
 
 // ActivityLogger middleware takes care to save the request information
+// and the collected admins, collections and settings changes audit logs
 // into the logs database.
 //
-// The middleware does nothing if the app logs retention period is zero
+// The request log is not saved if the app logs retention period is zero
 // (aka. app.Settings().Logs.MaxDays = 0).
+//
+// The request log is also queued for shipping to the external
+// log sink if app.Settings().LogShipping is enabled.
+//
+// The audit logs are not saved if the audit logs retention period is zero
+// (aka. app.Settings().Logs.AuditMaxDays = 0).
 func ActivityLogger(app core.App) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			err := next(c)
 
-			// no logs retention
-			if app.Settings().Logs.MaxDays == 0 {
+			saveAuditLogs(app, c)
+
+			shipLogs := app.Settings().LogShipping.Enabled
+
+			// no logs retention and shipping
+			if app.Settings().Logs.MaxDays == 0 && !shipLogs {
 				return err
 			}
 
@@ -294,6 +470,12 @@ func ActivityLogger(app core.App) echo.MiddlewareFunc {
 			status := httpResponse.Status
 			meta := types.JsonMap{}
 
+			if extra, ok := c.Get(ContextActivityMetaKey).(types.JsonMap); ok {
+				for k, v := range extra {
+					meta[k] = v
+				}
+			}
+
 			if err != nil {
 				switch v := err.(type) {
 				case *echo.HTTPError:
@@ -310,6 +492,19 @@ func ActivityLogger(app core.App) echo.MiddlewareFunc {
 				}
 			}
 
+			if apiKey, ok := c.Get(ContextApiKeyKey).(*models.ApiKey); ok {
+				meta["apiKeyId"] = apiKey.Id
+			}
+
+			// the geo header is set by the proxy in front of the app and
+			// it could be spoofed by the clients of direct requests
+			geoHeader := app.Settings().SecurityReport.GeoHeader
+			if geoHeader != "" && isTrustedProxyRequest(httpRequest, app.Settings().TrustedProxy) {
+				if country := strings.TrimSpace(httpRequest.Header.Get(geoHeader)); country != "" {
+					meta["country"] = strings.ToUpper(country)
+				}
+			}
+
 			requestAuth := models.RequestAuthGuest
 			if c.Get(ContextAuthRecordKey) != nil {
 				requestAuth = models.RequestAuthRecord
@@ -324,7 +519,7 @@ func ActivityLogger(app core.App) echo.MiddlewareFunc {
 				Method:    strings.ToUpper(httpRequest.Method),
 				Status:    status,
 				Auth:      requestAuth,
-				UserIp:    realUserIp(httpRequest, ip),
+				UserIp:    clientIp(c),
 				RemoteIp:  ip,
 				Referer:   httpRequest.Referer(),
 				UserAgent: httpRequest.UserAgent(),
@@ -334,6 +529,18 @@ func ActivityLogger(app core.App) echo.MiddlewareFunc {
 			model.RefreshCreated()
 			model.RefreshUpdated()
 
+			if shipLogs {
+				// generate the id upfront so that the local and the shipped log could be matched
+				model.RefreshId()
+
+				app.ShipLog(requestLogEntry(model))
+			}
+
+			// no logs retention
+			if app.Settings().Logs.MaxDays == 0 {
+				return err
+			}
+
 			routine.FireAndForget(func() {
 				if err := app.LogsDao().SaveRequest(model); err != nil && app.IsDebug() {
 					log.Println("Log save failed:", err)
@@ -360,34 +567,33 @@ func ActivityLogger(app core.App) echo.MiddlewareFunc {
 	}
 }
 
-// Returns the "real" user IP from common proxy headers (or fallbackIp if none is found).
-//
-// The returned IP value shouldn't be trusted if not behind a trusted reverse proxy!
-func realUserIp(r *http.Request, fallbackIp string) string {
-	if ip := r.Header.Get("CF-Connecting-IP"); ip != "" {
-		return ip
-	}
-
-	if ip := r.Header.Get("Fly-Client-IP"); ip != "" {
-		return ip
+// requestLogEntry converts the provided request log into a log shipping entry
+// (the 4xx and 5xx responses are shipped with warn and error level).
+func requestLogEntry(request *models.Request) *logship.Entry {
+	level := logship.LevelInfo
+	if request.Status >= 500 {
+		level = logship.LevelError
+	} else if request.Status >= 400 {
+		level = logship.LevelWarn
 	}
 
-	if ip := r.Header.Get("X-Real-IP"); ip != "" {
-		return ip
+	return &logship.Entry{
+		Time:    request.Created.Time(),
+		Level:   level,
+		Message: request.Method + " " + request.Url,
+		Data: map[string]any{
+			"id":        request.Id,
+			"url":       request.Url,
+			"method":    request.Method,
+			"status":    request.Status,
+			"auth":      request.Auth,
+			"userIp":    request.UserIp,
+			"remoteIp":  request.RemoteIp,
+			"referer":   request.Referer,
+			"userAgent": request.UserAgent,
+			"meta":      request.Meta,
+		},
 	}
-
-	if ipsList := r.Header.Get("X-Forwarded-For"); ipsList != "" {
-		// extract the first non-empty leftmost-ish ip
-		ips := strings.Split(ipsList, ",")
-		for _, ip := range ips {
-			ip = strings.TrimSpace(ip)
-			if ip != "" {
-				return ip
-			}
-		}
-	}
-
-	return fallbackIp
 }
 
 // eagerRequestDataCache ensures that the request data is cached in the request
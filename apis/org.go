@@ -0,0 +1,533 @@
+package apis
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+// bindOrgApi registers the organizations api endpoints.
+func bindOrgApi(app core.App, rg *echo.Group) {
+	api := orgApi{app: app}
+
+	subGroup := rg.Group("/orgs", ActivityLogger(app), RequireAdminOrRecordAuth())
+	subGroup.GET("", api.list)
+	subGroup.POST("", api.create)
+	subGroup.GET("/invites", api.listMyInvites, RequireRecordAuth())
+	subGroup.POST("/invites/:inviteId/accept", api.acceptInvite, RequireRecordAuth())
+	subGroup.GET("/:id", api.view)
+	subGroup.PATCH("/:id", api.update)
+	subGroup.DELETE("/:id", api.delete)
+	subGroup.GET("/:id/members", api.listMembers)
+	subGroup.PATCH("/:id/members/:memberId", api.updateMember)
+	subGroup.DELETE("/:id/members/:memberId", api.deleteMember)
+	subGroup.GET("/:id/invites", api.listInvites)
+	subGroup.POST("/:id/invites", api.invite)
+	subGroup.DELETE("/:id/invites/:inviteId", api.deleteInvite)
+	subGroup.GET("/:id/usage", api.usage)
+}
+
+type orgApi struct {
+	app core.App
+}
+
+// findOrg returns the organization from the current request path
+// that could be accessed with at least the specified member role.
+//
+// Admins have full access to all organizations (the returned member is nil).
+func (api *orgApi) findOrg(c echo.Context, role string) (*models.Org, *models.OrgMember, error) {
+	org, err := api.app.Dao().FindOrgById(c.PathParam("id"))
+	if err != nil {
+		return nil, nil, NewNotFoundError("", err)
+	}
+
+	if admin, _ := c.Get(ContextAdminKey).(*models.Admin); admin != nil {
+		return org, nil, nil
+	}
+
+	authRecord, _ := c.Get(ContextAuthRecordKey).(*models.Record)
+	if authRecord == nil {
+		return nil, nil, NewNotFoundError("", nil)
+	}
+
+	member, err := api.app.Dao().FindOrgMemberByRecord(org.Id, authRecord)
+	if err != nil {
+		return nil, nil, NewNotFoundError("", err)
+	}
+
+	if !member.HasRole(role) {
+		return nil, nil, NewForbiddenError("The organization member role is not allowed to perform this action.", nil)
+	}
+
+	return org, member, nil
+}
+
+// canManageRole checks whether the current member (nil for admins) could
+// manage the provided role (only owners could manage the owner role).
+func canManageRole(member *models.OrgMember, role string) bool {
+	return member == nil || role != models.OrgRoleOwner || member.HasRole(models.OrgRoleOwner)
+}
+
+//	@Summary		List organizations
+//	@Description	Returns a paginated organizations list (auth records see only the organizations they are member of)
+//	@Tags			Orgs
+//	@Produce		json
+//	@Param			page	query	int		false	"Page number"
+//	@Param			perPage	query	int		false	"Items per page"
+//	@Param			sort	query	string	false	"Sort fields"
+//	@Param			filter	query	string	false	"Filter expression"
+//	@Security		AdminAuth
+//	@Security		RecordAuth
+//	@Success		200	{object}	search.Result{items=[]models.Org}
+//	@Failure		400	{string}	string	"Something went wrong while processing your request."
+//	@Failure		401	{string}	string	"The request requires admin or record authorization token to be set."
+//	@Router			/orgs [get]
+func (api *orgApi) list(c echo.Context) error {
+	fieldResolver := search.NewSimpleFieldResolver(
+		"id", "created", "updated", "name", "slug",
+	)
+
+	query := api.app.Dao().OrgQuery()
+	if authRecord, _ := c.Get(ContextAuthRecordKey).(*models.Record); authRecord != nil {
+		query = api.app.Dao().OrgsByAuthRecordQuery(authRecord)
+	}
+
+	orgs := []*models.Org{}
+
+	result, err := search.NewProvider(fieldResolver).
+		Query(query).
+		ParseAndExec(c.QueryParams().Encode(), &orgs)
+
+	if err != nil {
+		return NewBadRequestError("", err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+//	@Summary		View organization
+//	@Description	Returns a single organization by its id
+//	@Tags			Orgs
+//	@Produce		json
+//	@Param			id	path	string	true	"Organization id"
+//	@Security		AdminAuth
+//	@Security		RecordAuth
+//	@Success		200	{object}	models.Org
+//	@Failure		401	{string}	string	"The request requires admin or record authorization token to be set."
+//	@Failure		404	{string}	string	"The requested resource wasn't found."
+//	@Router			/orgs/{id} [get]
+func (api *orgApi) view(c echo.Context) error {
+	org, _, err := api.findOrg(c, models.OrgRoleMember)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, org)
+}
+
+//	@Summary		Create organization
+//	@Description	Creates a new organization. The authenticated record (if any) becomes its owner
+//	@Tags			Orgs
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body	forms.OrgUpsert	true	"Organization data"
+//	@Security		AdminAuth
+//	@Security		RecordAuth
+//	@Success		200	{object}	models.Org
+//	@Failure		400	{string}	string	"Failed to create the organization."
+//	@Failure		401	{string}	string	"The request requires admin or record authorization token to be set."
+//	@Router			/orgs [post]
+func (api *orgApi) create(c echo.Context) error {
+	form := forms.NewOrgUpsert(api.app, &models.Org{})
+
+	if authRecord, _ := c.Get(ContextAuthRecordKey).(*models.Record); authRecord != nil {
+		form.SetOwner(authRecord)
+	}
+
+	return api.submitOrg(c, form, "Failed to create the organization.")
+}
+
+//	@Summary		Update organization
+//	@Description	Updates the organization with the specified id. Requires at least organization admin role
+//	@Tags			Orgs
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path	string			true	"Organization id"
+//	@Param			body	body	forms.OrgUpsert	true	"Organization data"
+//	@Security		AdminAuth
+//	@Security		RecordAuth
+//	@Success		200	{object}	models.Org
+//	@Failure		400	{string}	string	"Failed to update the organization."
+//	@Failure		401	{string}	string	"The request requires admin or record authorization token to be set."
+//	@Failure		403	{string}	string	"The organization member role is not allowed to perform this action."
+//	@Failure		404	{string}	string	"The requested resource wasn't found."
+//	@Router			/orgs/{id} [patch]
+func (api *orgApi) update(c echo.Context) error {
+	org, _, err := api.findOrg(c, models.OrgRoleAdmin)
+	if err != nil {
+		return err
+	}
+
+	return api.submitOrg(c, forms.NewOrgUpsert(api.app, org), "Failed to update the organization.")
+}
+
+func (api *orgApi) submitOrg(c echo.Context, form *forms.OrgUpsert, failureMessage string) error {
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	return form.Submit(func(next forms.InterceptorNextFunc[*models.Org]) forms.InterceptorNextFunc[*models.Org] {
+		return func(org *models.Org) error {
+			if err := next(org); err != nil {
+				return NewBadRequestError(failureMessage, err)
+			}
+
+			return c.JSON(http.StatusOK, org)
+		}
+	})
+}
+
+//	@Summary		Delete organization
+//	@Description	Deletes the organization with the specified id together with its members and invites. Requires organization owner role
+//	@Tags			Orgs
+//	@Param			id	path	string	true	"Organization id"
+//	@Security		AdminAuth
+//	@Security		RecordAuth
+//	@Success		204	"No Content"
+//	@Failure		400	{string}	string	"Failed to delete the organization."
+//	@Failure		401	{string}	string	"The request requires admin or record authorization token to be set."
+//	@Failure		403	{string}	string	"The organization member role is not allowed to perform this action."
+//	@Failure		404	{string}	string	"The requested resource wasn't found."
+//	@Router			/orgs/{id} [delete]
+func (api *orgApi) delete(c echo.Context) error {
+	org, _, err := api.findOrg(c, models.OrgRoleOwner)
+	if err != nil {
+		return err
+	}
+
+	if err := api.app.Dao().DeleteOrg(org); err != nil {
+		return NewBadRequestError("Failed to delete the organization.", err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+//	@Summary		List organization members
+//	@Description	Returns all members of the organization
+//	@Tags			Orgs
+//	@Produce		json
+//	@Param			id	path	string	true	"Organization id"
+//	@Security		AdminAuth
+//	@Security		RecordAuth
+//	@Success		200	{array}		models.OrgMember
+//	@Failure		400	{string}	string	"Failed to load the organization members."
+//	@Failure		401	{string}	string	"The request requires admin or record authorization token to be set."
+//	@Failure		404	{string}	string	"The requested resource wasn't found."
+//	@Router			/orgs/{id}/members [get]
+func (api *orgApi) listMembers(c echo.Context) error {
+	org, _, err := api.findOrg(c, models.OrgRoleMember)
+	if err != nil {
+		return err
+	}
+
+	members, err := api.app.Dao().FindOrgMembers(org.Id)
+	if err != nil {
+		return NewBadRequestError("Failed to load the organization members.", err)
+	}
+
+	return c.JSON(http.StatusOK, members)
+}
+
+//	@Summary		Update organization member
+//	@Description	Changes the role of an organization member. Requires at least organization admin role (only owners could manage the owner role)
+//	@Tags			Orgs
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path	string					true	"Organization id"
+//	@Param			memberId	path	string					true	"Member id"
+//	@Param			body		body	forms.OrgMemberUpdate	true	"Member data"
+//	@Security		AdminAuth
+//	@Security		RecordAuth
+//	@Success		200	{object}	models.OrgMember
+//	@Failure		400	{string}	string	"Failed to update the organization member."
+//	@Failure		401	{string}	string	"The request requires admin or record authorization token to be set."
+//	@Failure		403	{string}	string	"The organization member role is not allowed to perform this action."
+//	@Failure		404	{string}	string	"The requested resource wasn't found."
+//	@Router			/orgs/{id}/members/{memberId} [patch]
+func (api *orgApi) updateMember(c echo.Context) error {
+	org, current, err := api.findOrg(c, models.OrgRoleAdmin)
+	if err != nil {
+		return err
+	}
+
+	member, err := api.app.Dao().FindOrgMemberById(org.Id, c.PathParam("memberId"))
+	if err != nil {
+		return NewNotFoundError("", err)
+	}
+
+	form := forms.NewOrgMemberUpdate(api.app, member)
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	if !canManageRole(current, member.Role) || !canManageRole(current, form.Role) {
+		return NewForbiddenError("Only the organization owners can manage the owner role.", nil)
+	}
+
+	return form.Submit(func(next forms.InterceptorNextFunc[*models.OrgMember]) forms.InterceptorNextFunc[*models.OrgMember] {
+		return func(m *models.OrgMember) error {
+			if err := next(m); err != nil {
+				return NewBadRequestError("Failed to update the organization member.", err)
+			}
+
+			return c.JSON(http.StatusOK, m)
+		}
+	})
+}
+
+//	@Summary		Remove organization member
+//	@Description	Removes a member from the organization. Requires at least organization admin role, except when members leave the organization themselves
+//	@Tags			Orgs
+//	@Param			id			path	string	true	"Organization id"
+//	@Param			memberId	path	string	true	"Member id"
+//	@Security		AdminAuth
+//	@Security		RecordAuth
+//	@Success		204	"No Content"
+//	@Failure		400	{string}	string	"The organization must have at least one owner."
+//	@Failure		401	{string}	string	"The request requires admin or record authorization token to be set."
+//	@Failure		403	{string}	string	"The organization member role is not allowed to perform this action."
+//	@Failure		404	{string}	string	"The requested resource wasn't found."
+//	@Router			/orgs/{id}/members/{memberId} [delete]
+func (api *orgApi) deleteMember(c echo.Context) error {
+	org, current, err := api.findOrg(c, models.OrgRoleMember)
+	if err != nil {
+		return err
+	}
+
+	member, err := api.app.Dao().FindOrgMemberById(org.Id, c.PathParam("memberId"))
+	if err != nil {
+		return NewNotFoundError("", err)
+	}
+
+	isSelf := current != nil && current.Id == member.Id
+	if !isSelf && current != nil && !current.HasRole(models.OrgRoleAdmin) {
+		return NewForbiddenError("The organization member role is not allowed to perform this action.", nil)
+	}
+
+	if !isSelf && !canManageRole(current, member.Role) {
+		return NewForbiddenError("Only the organization owners can manage the owner role.", nil)
+	}
+
+	if member.Role == models.OrgRoleOwner {
+		if total, err := api.app.Dao().CountOrgOwners(org.Id); err != nil || total <= 1 {
+			return NewBadRequestError("The organization must have at least one owner.", err)
+		}
+	}
+
+	if err := api.app.Dao().DeleteOrgMember(member); err != nil {
+		return NewBadRequestError("Failed to remove the organization member.", err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+//	@Summary		List organization invites
+//	@Description	Returns all pending invites of the organization. Requires at least organization admin role
+//	@Tags			Orgs
+//	@Produce		json
+//	@Param			id	path	string	true	"Organization id"
+//	@Security		AdminAuth
+//	@Security		RecordAuth
+//	@Success		200	{array}		models.OrgInvite
+//	@Failure		400	{string}	string	"Failed to load the organization invites."
+//	@Failure		401	{string}	string	"The request requires admin or record authorization token to be set."
+//	@Failure		403	{string}	string	"The organization member role is not allowed to perform this action."
+//	@Failure		404	{string}	string	"The requested resource wasn't found."
+//	@Router			/orgs/{id}/invites [get]
+func (api *orgApi) listInvites(c echo.Context) error {
+	org, _, err := api.findOrg(c, models.OrgRoleAdmin)
+	if err != nil {
+		return err
+	}
+
+	invites, err := api.app.Dao().FindOrgInvites(org.Id)
+	if err != nil {
+		return NewBadRequestError("Failed to load the organization invites.", err)
+	}
+
+	return c.JSON(http.StatusOK, invites)
+}
+
+//	@Summary		Invite organization member
+//	@Description	Invites an email address to join the organization (or renews the existing invite). Requires at least organization admin role (only owners could invite owners)
+//	@Tags			Orgs
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path	string					true	"Organization id"
+//	@Param			body	body	forms.OrgInviteUpsert	true	"Invite data"
+//	@Security		AdminAuth
+//	@Security		RecordAuth
+//	@Success		200	{object}	models.OrgInvite
+//	@Failure		400	{string}	string	"Failed to invite the organization member."
+//	@Failure		401	{string}	string	"The request requires admin or record authorization token to be set."
+//	@Failure		403	{string}	string	"The organization member role is not allowed to perform this action."
+//	@Failure		404	{string}	string	"The requested resource wasn't found."
+//	@Router			/orgs/{id}/invites [post]
+func (api *orgApi) invite(c echo.Context) error {
+	org, current, err := api.findOrg(c, models.OrgRoleAdmin)
+	if err != nil {
+		return err
+	}
+
+	form := forms.NewOrgInviteUpsert(api.app, org)
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	if !canManageRole(current, form.Role) {
+		return NewForbiddenError("Only the organization owners can manage the owner role.", nil)
+	}
+
+	return form.Submit(func(next forms.InterceptorNextFunc[*models.OrgInvite]) forms.InterceptorNextFunc[*models.OrgInvite] {
+		return func(invite *models.OrgInvite) error {
+			if err := next(invite); err != nil {
+				return NewBadRequestError("Failed to invite the organization member.", err)
+			}
+
+			return c.JSON(http.StatusOK, invite)
+		}
+	})
+}
+
+//	@Summary		Delete organization invite
+//	@Description	Revokes a pending organization invite. Requires at least organization admin role
+//	@Tags			Orgs
+//	@Param			id			path	string	true	"Organization id"
+//	@Param			inviteId	path	string	true	"Invite id"
+//	@Security		AdminAuth
+//	@Security		RecordAuth
+//	@Success		204	"No Content"
+//	@Failure		400	{string}	string	"Failed to delete the organization invite."
+//	@Failure		401	{string}	string	"The request requires admin or record authorization token to be set."
+//	@Failure		403	{string}	string	"The organization member role is not allowed to perform this action."
+//	@Failure		404	{string}	string	"The requested resource wasn't found."
+//	@Router			/orgs/{id}/invites/{inviteId} [delete]
+func (api *orgApi) deleteInvite(c echo.Context) error {
+	org, _, err := api.findOrg(c, models.OrgRoleAdmin)
+	if err != nil {
+		return err
+	}
+
+	invite, err := api.app.Dao().FindOrgInviteById(c.PathParam("inviteId"))
+	if err != nil || invite.OrgId != org.Id {
+		return NewNotFoundError("", err)
+	}
+
+	if err := api.app.Dao().DeleteOrgInvite(invite); err != nil {
+		return NewBadRequestError("Failed to delete the organization invite.", err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+//	@Summary		View organization usage
+//	@Description	Returns the organization billable events totals for the specified month (default to the current one). Requires at least organization admin role
+//	@Tags			Orgs
+//	@Produce		json
+//	@Param			id		path	string	true	"Organization id"
+//	@Param			period	query	string	false	"Usage month in YYYY-MM format"
+//	@Security		AdminAuth
+//	@Security		RecordAuth
+//	@Success		200	{object}	models.UsageSummary
+//	@Failure		400	{string}	string	"Invalid usage period."
+//	@Failure		401	{string}	string	"The request requires admin or record authorization token to be set."
+//	@Failure		403	{string}	string	"The organization member role is not allowed to perform this action."
+//	@Failure		404	{string}	string	"The requested resource wasn't found."
+//	@Router			/orgs/{id}/usage [get]
+func (api *orgApi) usage(c echo.Context) error {
+	org, _, err := api.findOrg(c, models.OrgRoleAdmin)
+	if err != nil {
+		return err
+	}
+
+	period, err := usagePeriodParam(c)
+	if err != nil {
+		return err
+	}
+
+	summary, err := api.app.Dao().FindUsageSummary(period, dbx.HashExp{"orgId": org.Id})
+	if err != nil {
+		return NewBadRequestError("", err)
+	}
+
+	return c.JSON(http.StatusOK, summary)
+}
+
+//	@Summary		List my organization invites
+//	@Description	Returns the pending organization invites of the authenticated record verified email
+//	@Tags			Orgs
+//	@Produce		json
+//	@Security		RecordAuth
+//	@Success		200	{array}		models.OrgInvite
+//	@Failure		400	{string}	string	"Failed to load the organization invites."
+//	@Failure		401	{string}	string	"The request requires valid record authorization token to be set."
+//	@Router			/orgs/invites [get]
+func (api *orgApi) listMyInvites(c echo.Context) error {
+	authRecord, _ := c.Get(ContextAuthRecordKey).(*models.Record)
+
+	if authRecord == nil || !authRecord.Verified() || authRecord.Email() == "" {
+		return c.JSON(http.StatusOK, []*models.OrgInvite{})
+	}
+
+	invites, err := api.app.Dao().FindOrgInvitesByEmail(authRecord.Email())
+	if err != nil {
+		return NewBadRequestError("Failed to load the organization invites.", err)
+	}
+
+	return c.JSON(http.StatusOK, invites)
+}
+
+//	@Summary		Accept organization invite
+//	@Description	Joins the authenticated record to the invite organization. The record email must be verified and match the invite email
+//	@Tags			Orgs
+//	@Produce		json
+//	@Param			inviteId	path	string	true	"Invite id"
+//	@Security		RecordAuth
+//	@Success		200	{object}	models.OrgMember
+//	@Failure		400	{string}	string	"The organization invite has expired."
+//	@Failure		401	{string}	string	"The request requires valid record authorization token to be set."
+//	@Failure		403	{string}	string	"Only auth records with verified email could accept organization invites."
+//	@Failure		404	{string}	string	"The requested resource wasn't found."
+//	@Router			/orgs/invites/{inviteId}/accept [post]
+func (api *orgApi) acceptInvite(c echo.Context) error {
+	authRecord, _ := c.Get(ContextAuthRecordKey).(*models.Record)
+	if authRecord == nil {
+		return NewNotFoundError("", nil)
+	}
+
+	invite, err := api.app.Dao().FindOrgInviteById(c.PathParam("inviteId"))
+	if err != nil || !strings.EqualFold(invite.Email, authRecord.Email()) {
+		return NewNotFoundError("", err)
+	}
+
+	if !authRecord.Verified() {
+		return NewForbiddenError("Only auth records with verified email could accept organization invites.", nil)
+	}
+
+	if invite.IsExpired() {
+		return NewBadRequestError("The organization invite has expired.", nil)
+	}
+
+	member, err := api.app.Dao().AcceptOrgInvite(invite, authRecord)
+	if err != nil {
+		return NewBadRequestError("Failed to accept the organization invite.", err)
+	}
+
+	return c.JSON(http.StatusOK, member)
+}
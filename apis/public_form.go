@@ -0,0 +1,156 @@
+package apis
+
+import (
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/mails"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tools/captcha"
+	"github.com/pocketbase/pocketbase/tools/ratelimit"
+	"github.com/pocketbase/pocketbase/tools/rest"
+	"github.com/spf13/cast"
+)
+
+// bindPublicFormApi registers the public form submission api endpoints.
+func bindPublicFormApi(app core.App, rg *echo.Group) {
+	api := publicFormApi{app: app, limiter: ratelimit.New()}
+
+	subGroup := rg.Group("/forms", ActivityLogger(app))
+	subGroup.POST("/:name/submit", api.submit)
+}
+
+type publicFormApi struct {
+	app     core.App
+	limiter *ratelimit.Limiter
+}
+
+//	@Summary		Submit public form
+//	@Description	Creates a new record in the public form collection from the form allowlisted fields
+//	@Description	(with optional honeypot, captcha and per client IP submissions limit protections).
+//	@Description	Accepts json, multipart and url encoded bodies and redirects to the form redirect url (if set).
+//	@Tags			Forms
+//	@Accept			json
+//	@Accept			x-www-form-urlencoded
+//	@Param			name	path	string	true	"Public form name"
+//	@Success		204		"No Content"
+//	@Success		303		"Redirect to the form redirect url"
+//	@Failure		400		{string}	string	"Failed to submit the form."
+//	@Failure		404		{string}	string	"The requested resource wasn't found."
+//	@Failure		429		{string}	string	"Too many submissions."
+//	@Router			/forms/{name}/submit [post]
+func (api *publicFormApi) submit(c echo.Context) error {
+	name := c.PathParam("name")
+
+	config, ok := api.app.Settings().PublicForms.Form(name)
+	if !ok {
+		return NewNotFoundError("", nil)
+	}
+
+	if config.MaxSubmissions > 0 {
+		duration := time.Duration(config.Duration) * time.Second
+
+		if allowed, _ := api.limiter.Allow(name+":"+clientIp(c), config.MaxSubmissions, duration); !allowed {
+			return NewApiError(http.StatusTooManyRequests, "Too many submissions.", nil)
+		}
+	}
+
+	data, err := publicFormRequestData(c)
+	if err != nil {
+		return NewBadRequestError("Failed to load the submitted data due to invalid formatting.", err)
+	}
+
+	form := forms.NewPublicFormSubmit(api.app, config)
+	form.Data = data
+
+	// pretend success to not reveal the honeypot to the spam bots
+	if form.IsHoneypotFilled() {
+		return api.respond(c, config)
+	}
+
+	if config.Captcha {
+		if err := api.verifyCaptcha(c, data); err != nil {
+			return NewBadRequestError("Invalid or missing captcha.", err)
+		}
+	}
+
+	return form.Submit(func(next forms.InterceptorNextFunc[*models.Record]) forms.InterceptorNextFunc[*models.Record] {
+		return func(record *models.Record) error {
+			if err := next(record); err != nil {
+				return NewBadRequestError("Failed to submit the form.", err)
+			}
+
+			// the notification failures don't affect the already stored submission
+			if err := mails.SendPublicFormNotification(api.app, name, config.Fields, record, config.NotifyEmails); err != nil && api.app.IsDebug() {
+				log.Println(err)
+			}
+
+			return api.respond(c, config)
+		}
+	})
+}
+
+// respond writes the successful submission response
+// (redirect to the form RedirectUrl if set).
+func (api *publicFormApi) respond(c echo.Context, config settings.PublicFormConfig) error {
+	if config.RedirectUrl != "" {
+		return c.Redirect(http.StatusSeeOther, config.RedirectUrl)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// verifyCaptcha checks the submitted captcha widget response token.
+func (api *publicFormApi) verifyCaptcha(c echo.Context, data map[string]any) error {
+	var token string
+	for _, key := range captcha.ResponseKeys {
+		if token = cast.ToString(data[key]); token != "" {
+			break
+		}
+	}
+
+	client, err := api.app.NewHttpClient(settings.EgressSubsystemCaptcha)
+	if err != nil {
+		return err
+	}
+
+	config := api.app.Settings().PublicForms
+
+	return captcha.Verify(
+		c.Request().Context(),
+		client,
+		config.CaptchaVerifyUrl,
+		api.app.Secrets().Resolve(config.CaptchaSecret),
+		token,
+		clientIp(c),
+	)
+}
+
+// publicFormRequestData extracts the json or form encoded submitted
+// data (only the first value of each form key is used).
+func publicFormRequestData(c echo.Context) (map[string]any, error) {
+	data := map[string]any{}
+
+	if strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), echo.MIMEApplicationJSON) {
+		err := rest.CopyJsonBody(c.Request(), &data)
+
+		return data, err
+	}
+
+	values, err := c.FormValues()
+	if err != nil {
+		return nil, err
+	}
+
+	for key := range values {
+		data[key] = values.Get(key)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,233 @@
+package apis_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+// setupTestPublicForm registers a "contact" public form
+// storing the submitted titles in the demo2 collection.
+func setupTestPublicForm(app *tests.TestApp, config settings.PublicFormConfig) {
+	config.Collection = "demo2"
+	config.Fields = []string{"title"}
+	config.NotifyEmails = []string{"sales@example.com"}
+
+	app.Settings().PublicForms.Forms = map[string]settings.PublicFormConfig{
+		"contact": config,
+	}
+}
+
+// checkTestPublicFormRecord fails the test if the existence of the
+// demo2 record with the provided title doesn't match the expected one.
+func checkTestPublicFormRecord(t *testing.T, app *tests.TestApp, title string, expected bool) {
+	record, _ := app.Dao().FindFirstRecordByData("demo2", "title", title)
+
+	if exists := record != nil; exists != expected {
+		t.Fatalf("Expected the %q record existence to be %v, got %v", title, expected, exists)
+	}
+
+	if record != nil && record.GetBool("active") {
+		t.Fatal("Expected the not allowlisted active field to be ignored")
+	}
+}
+
+func TestPublicFormSubmit(t *testing.T) {
+	var captchaServer *httptest.Server
+
+	setupTestCaptcha := func(t *testing.T, app *tests.TestApp) {
+		captchaServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.FormValue("secret") == "test_secret" && r.FormValue("response") == "valid" {
+				w.Write([]byte(`{"success":true}`))
+			} else {
+				w.Write([]byte(`{"success":false}`))
+			}
+		}))
+
+		app.Settings().PublicForms.CaptchaVerifyUrl = captchaServer.URL
+		app.Settings().PublicForms.CaptchaSecret = "test_secret"
+		app.Settings().Egress.Subsystems = map[string]settings.EgressPolicyConfig{
+			settings.EgressSubsystemCaptcha: {AllowPrivate: true},
+		}
+	}
+
+	scenarios := []tests.ApiScenario{
+		{
+			Name:            "missing form",
+			Method:          http.MethodPost,
+			Url:             "/api/forms/missing/submit",
+			Body:            strings.NewReader(`{"title":"test_public_form"}`),
+			ExpectedStatus:  404,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "invalid data",
+			Method: http.MethodPost,
+			Url:    "/api/forms/contact/submit",
+			Body:   strings.NewReader(`{"title":"a"}`),
+			RequestHeaders: map[string]string{
+				"Content-Type": "application/json",
+			},
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				setupTestPublicForm(app, settings.PublicFormConfig{})
+			},
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"title":{"code":"validation_min_text_constraint"`},
+		},
+		{
+			Name:   "json submission",
+			Method: http.MethodPost,
+			Url:    "/api/forms/contact/submit",
+			Body:   strings.NewReader(`{"title":"test_public_form","active":true}`),
+			RequestHeaders: map[string]string{
+				"Content-Type": "application/json",
+			},
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				setupTestPublicForm(app, settings.PublicFormConfig{})
+			},
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				checkTestPublicFormRecord(t, app, "test_public_form", true)
+
+				if app.TestMailer.TotalSend != 1 || app.TestMailer.LastMessage.To[0].Address != "sales@example.com" {
+					t.Fatalf("Expected the submission notification to be sent, got %d %v", app.TestMailer.TotalSend, app.TestMailer.LastMessage.To)
+				}
+			},
+			ExpectedStatus: 204,
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeCreate": 1,
+				"OnModelAfterCreate":  1,
+			},
+		},
+		{
+			Name:   "url encoded submission with redirect",
+			Method: http.MethodPost,
+			Url:    "/api/forms/contact/submit",
+			Body:   strings.NewReader(`title=test_public_form&active=true`),
+			RequestHeaders: map[string]string{
+				"Content-Type": "application/x-www-form-urlencoded",
+			},
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				setupTestPublicForm(app, settings.PublicFormConfig{RedirectUrl: "https://example.com/thanks"})
+			},
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				checkTestPublicFormRecord(t, app, "test_public_form", true)
+			},
+			ExpectedStatus: 303,
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeCreate": 1,
+				"OnModelAfterCreate":  1,
+			},
+		},
+		{
+			Name:   "filled honeypot",
+			Method: http.MethodPost,
+			Url:    "/api/forms/contact/submit",
+			Body:   strings.NewReader(`title=test_public_form&website=spam`),
+			RequestHeaders: map[string]string{
+				"Content-Type": "application/x-www-form-urlencoded",
+			},
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				setupTestPublicForm(app, settings.PublicFormConfig{Honeypot: "website"})
+			},
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				checkTestPublicFormRecord(t, app, "test_public_form", false)
+
+				if app.TestMailer.TotalSend != 0 {
+					t.Fatalf("Expected no notifications, got %d", app.TestMailer.TotalSend)
+				}
+			},
+			ExpectedStatus: 204,
+		},
+		{
+			Name:   "missing captcha token",
+			Method: http.MethodPost,
+			Url:    "/api/forms/contact/submit",
+			Body:   strings.NewReader(`title=test_public_form`),
+			RequestHeaders: map[string]string{
+				"Content-Type": "application/x-www-form-urlencoded",
+			},
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				setupTestPublicForm(app, settings.PublicFormConfig{Captcha: true})
+				setupTestCaptcha(t, app)
+			},
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				captchaServer.Close()
+				checkTestPublicFormRecord(t, app, "test_public_form", false)
+			},
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"message":"Invalid or missing captcha."`},
+		},
+		{
+			Name:   "invalid captcha token",
+			Method: http.MethodPost,
+			Url:    "/api/forms/contact/submit",
+			Body:   strings.NewReader(`title=test_public_form&h-captcha-response=invalid`),
+			RequestHeaders: map[string]string{
+				"Content-Type": "application/x-www-form-urlencoded",
+			},
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				setupTestPublicForm(app, settings.PublicFormConfig{Captcha: true})
+				setupTestCaptcha(t, app)
+			},
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				captchaServer.Close()
+				checkTestPublicFormRecord(t, app, "test_public_form", false)
+			},
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"message":"Invalid or missing captcha."`},
+		},
+		{
+			Name:   "valid captcha token",
+			Method: http.MethodPost,
+			Url:    "/api/forms/contact/submit",
+			Body:   strings.NewReader(`title=test_public_form&cf-turnstile-response=valid`),
+			RequestHeaders: map[string]string{
+				"Content-Type": "application/x-www-form-urlencoded",
+			},
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				setupTestPublicForm(app, settings.PublicFormConfig{Captcha: true})
+				setupTestCaptcha(t, app)
+			},
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				captchaServer.Close()
+				checkTestPublicFormRecord(t, app, "test_public_form", true)
+			},
+			ExpectedStatus: 204,
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeCreate": 1,
+				"OnModelAfterCreate":  1,
+			},
+		},
+		{
+			Name:   "exceeded submissions limit",
+			Method: http.MethodPost,
+			Url:    "/api/forms/contact/submit",
+			Body:   strings.NewReader(`title=test_public_form`),
+			RequestHeaders: map[string]string{
+				"Content-Type": "application/x-www-form-urlencoded",
+			},
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				setupTestPublicForm(app, settings.PublicFormConfig{Honeypot: "website", MaxSubmissions: 1, Duration: 60})
+
+				// consume the only allowed submission
+				req := httptest.NewRequest(http.MethodPost, "/api/forms/contact/submit", strings.NewReader("website=spam"))
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+				e.ServeHTTP(httptest.NewRecorder(), req)
+			},
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				checkTestPublicFormRecord(t, app, "test_public_form", false)
+			},
+			ExpectedStatus:  429,
+			ExpectedContent: []string{`"message":"Too many submissions."`},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
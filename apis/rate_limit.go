@@ -0,0 +1,305 @@
+package apis
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/ratelimit"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+// swagger:models RateLimitStatus
+type RateLimitStatus struct {
+	IdentityId string `json:"identityId"`
+
+	// Override is the identity specific limit (nil if the global settings are used).
+	Override *models.RateLimit `json:"override"`
+
+	// MaxRequests and Duration are the effective identity limits.
+	MaxRequests int `json:"maxRequests"`
+	Duration    int `json:"duration"`
+
+	Usage ratelimit.Usage `json:"usage"`
+}
+
+// bindRateLimitApi registers the rate limit overrides api endpoints.
+func bindRateLimitApi(app core.App, rg *echo.Group, limiter *ratelimit.Limiter) {
+	api := rateLimitApi{app: app, limiter: limiter}
+
+	subGroup := rg.Group("/limits", ActivityLogger(app), RequireAdminAuth())
+	subGroup.GET("", api.list)
+	subGroup.GET("/:identityId", api.view)
+	subGroup.PUT("/:identityId", api.upsert)
+	subGroup.DELETE("/:identityId", api.delete)
+}
+
+type rateLimitApi struct {
+	app     core.App
+	limiter *ratelimit.Limiter
+}
+
+//	@Summary		Список переопределений лимитов
+//	@Description	Возвращает список индивидуальных лимитов запросов с текущим потреблением
+//	@Tags			Settings
+//	@Produce		json
+//	@Param			page	query	int		false	"Номер страницы"
+//	@Param			perPage	query	int		false	"Количество элементов на странице"
+//	@Param			sort	query	string	false	"Сортировка"
+//	@Param			filter	query	string	false	"Фильтр"
+//	@Security		AdminAuth
+//	@Success		200	{object}	SearchResult{items=[]RateLimitStatus}
+//	@Failure		400	{string}	string	"Something went wrong while processing your request."
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Router			/limits [get]
+func (api *rateLimitApi) list(c echo.Context) error {
+	fieldResolver := search.NewSimpleFieldResolver(
+		"id", "created", "updated", "identityId", "maxRequests", "duration", "note",
+	)
+
+	rateLimits := []*models.RateLimit{}
+
+	result, err := search.NewProvider(fieldResolver).
+		Query(api.app.Dao().RateLimitQuery()).
+		ParseAndExec(c.QueryParams().Encode(), &rateLimits)
+
+	if err != nil {
+		return NewBadRequestError("", err)
+	}
+
+	items := make([]*RateLimitStatus, len(rateLimits))
+	for i, rateLimit := range rateLimits {
+		items[i] = api.status(rateLimit.IdentityId, rateLimit)
+	}
+	result.Items = items
+
+	return c.JSON(http.StatusOK, result)
+}
+
+//	@Summary		Лимит идентичности
+//	@Description	Возвращает действующий лимит запросов и текущее потребление указанной идентичности (администратора, пользователя, API ключа или IP адреса)
+//	@Tags			Settings
+//	@Produce		json
+//	@Param			identityId	path	string	true	"Идентификатор администратора, записи, API ключа или IP адрес"
+//	@Security		AdminAuth
+//	@Success		200	{object}	RateLimitStatus
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Router			/limits/{identityId} [get]
+func (api *rateLimitApi) view(c echo.Context) error {
+	identityId := c.PathParam("identityId")
+
+	rateLimit, _ := api.app.Dao().FindRateLimitByIdentity(identityId)
+
+	return c.JSON(http.StatusOK, api.status(identityId, rateLimit))
+}
+
+//	@Summary		Переопределение лимита
+//	@Description	Создает или обновляет индивидуальный лимит запросов указанной идентичности поверх глобальных настроек (0 запросов означает отсутствие ограничения)
+//	@Tags			Settings
+//	@Accept			json
+//	@Produce		json
+//	@Param			identityId	path	string					true	"Идентификатор администратора, записи, API ключа или IP адрес"
+//	@Param			body		body	forms.RateLimitUpsert	true	"Данные лимита"
+//	@Security		AdminAuth
+//	@Success		200	{object}	RateLimitStatus
+//	@Failure		400	{string}	string	"Failed to save the rate limit override."
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Router			/limits/{identityId} [put]
+func (api *rateLimitApi) upsert(c echo.Context) error {
+	identityId := c.PathParam("identityId")
+
+	rateLimit, _ := api.app.Dao().FindRateLimitByIdentity(identityId)
+	if rateLimit == nil {
+		rateLimit = &models.RateLimit{IdentityId: identityId}
+	}
+
+	form := forms.NewRateLimitUpsert(api.app, rateLimit)
+
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("Failed to load the submitted data due to invalid formatting.", err)
+	}
+
+	return form.Submit(func(next forms.InterceptorNextFunc[*models.RateLimit]) forms.InterceptorNextFunc[*models.RateLimit] {
+		return func(m *models.RateLimit) error {
+			if err := next(m); err != nil {
+				return NewBadRequestError("Failed to save the rate limit override.", err)
+			}
+
+			return c.JSON(http.StatusOK, api.status(identityId, m))
+		}
+	})
+}
+
+//	@Summary		Удаление переопределения лимита
+//	@Description	Удаляет индивидуальный лимит запросов указанной идентичности (начинают действовать глобальные настройки)
+//	@Tags			Settings
+//	@Param			identityId	path	string	true	"Идентификатор администратора, записи, API ключа или IP адрес"
+//	@Security		AdminAuth
+//	@Success		204	"No Content"
+//	@Failure		400	{string}	string	"Failed to delete the rate limit override."
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Failure		404	{string}	string	"The requested resource wasn't found."
+//	@Router			/limits/{identityId} [delete]
+func (api *rateLimitApi) delete(c echo.Context) error {
+	rateLimit, err := api.app.Dao().FindRateLimitByIdentity(c.PathParam("identityId"))
+	if err != nil || rateLimit == nil {
+		return NewNotFoundError("", err)
+	}
+
+	if err := api.app.Dao().DeleteRateLimit(rateLimit); err != nil {
+		return NewBadRequestError("Failed to delete the rate limit override.", err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (api *rateLimitApi) status(identityId string, rateLimit *models.RateLimit) *RateLimitStatus {
+	maxRequests, duration := effectiveRateLimit(api.app, rateLimit)
+
+	return &RateLimitStatus{
+		IdentityId:  identityId,
+		Override:    rateLimit,
+		MaxRequests: maxRequests,
+		Duration:    int(duration / time.Second),
+		Usage:       api.limiter.Usage(identityId, maxRequests),
+	}
+}
+
+// -------------------------------------------------------------------
+
+// effectiveRateLimit returns the max requests and the window duration
+// of the provided identity override (or the global settings if nil).
+func effectiveRateLimit(app core.App, rateLimit *models.RateLimit) (int, time.Duration) {
+	if rateLimit != nil {
+		return rateLimit.MaxRequests, time.Duration(rateLimit.Duration) * time.Second
+	}
+
+	settings := app.Settings().RateLimits
+
+	return settings.MaxRequests, time.Duration(settings.Duration) * time.Second
+}
+
+// rateLimitOverrides is an in-memory cache of the identity rate limit
+// overrides so that the rate limit middleware doesn't query the
+// database on every request.
+//
+// The cache is reloaded on the next lookup after an override change.
+type rateLimitOverrides struct {
+	app core.App
+
+	mux   sync.RWMutex
+	items map[string]*models.RateLimit // nil if not loaded
+}
+
+func newRateLimitOverrides(app core.App) *rateLimitOverrides {
+	overrides := &rateLimitOverrides{app: app}
+
+	invalidate := func(e *core.ModelEvent) error {
+		overrides.invalidate()
+		return nil
+	}
+
+	tableName := (&models.RateLimit{}).TableName()
+	app.OnModelAfterCreate(tableName).Add(invalidate)
+	app.OnModelAfterUpdate(tableName).Add(invalidate)
+	app.OnModelAfterDelete(tableName).Add(invalidate)
+
+	return overrides
+}
+
+// find returns the override of the provided identity (nil if there is none).
+func (o *rateLimitOverrides) find(identityId string) *models.RateLimit {
+	o.mux.RLock()
+	items := o.items
+	o.mux.RUnlock()
+
+	if items == nil {
+		o.mux.Lock()
+		defer o.mux.Unlock()
+
+		if o.items == nil {
+			rateLimits := []*models.RateLimit{}
+			if err := o.app.Dao().RateLimitQuery().All(&rateLimits); err != nil {
+				return nil // retry on the next lookup
+			}
+
+			o.items = make(map[string]*models.RateLimit, len(rateLimits))
+			for _, rateLimit := range rateLimits {
+				o.items[rateLimit.IdentityId] = rateLimit
+			}
+		}
+
+		items = o.items
+	}
+
+	return items[identityId]
+}
+
+// invalidate clears the loaded overrides.
+func (o *rateLimitOverrides) invalidate() {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	o.items = nil
+}
+
+// rateLimitIdentity returns the rate limit identity of the current request
+// (the authorized admin or auth record id, the valid api key id,
+// otherwise the client ip).
+func rateLimitIdentity(app core.App, c echo.Context) string {
+	if admin, _ := c.Get(ContextAdminKey).(*models.Admin); admin != nil {
+		return admin.Id
+	}
+
+	if record, _ := c.Get(ContextAuthRecordKey).(*models.Record); record != nil {
+		return record.Id
+	}
+
+	// the api keys are loaded later by the RequireApiKeyAuth route middleware
+	if key := c.Request().Header.Get(ApiKeyHeader); key != "" {
+		if apiKey, err := app.Dao().FindApiKeyByKey(key); err == nil && !apiKey.IsExpired() {
+			return apiKey.Id
+		}
+	}
+
+	return clientIp(c)
+}
+
+// rateLimit middleware applies the global rate limit settings
+// (or the identity override) to the api requests.
+//
+// This middleware is expected to be registered after LoadAuthContext.
+func rateLimit(app core.App, limiter *ratelimit.Limiter, overrides *rateLimitOverrides) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !app.Settings().RateLimits.Enabled || !strings.HasPrefix(c.Request().URL.Path, "/api/") {
+				return next(c)
+			}
+
+			identityId := rateLimitIdentity(app, c)
+
+			maxRequests, duration := effectiveRateLimit(app, overrides.find(identityId))
+
+			allowed, usage := limiter.Allow(identityId, maxRequests, duration)
+
+			if maxRequests > 0 {
+				header := c.Response().Header()
+				header.Set("X-RateLimit-Limit", strconv.Itoa(usage.Limit))
+				header.Set("X-RateLimit-Remaining", strconv.Itoa(usage.Remaining))
+				header.Set("X-RateLimit-Reset", strconv.FormatInt(usage.ResetAt.Unix(), 10))
+			}
+
+			if !allowed {
+				return NewApiError(http.StatusTooManyRequests, "Too many requests.", nil)
+			}
+
+			return next(c)
+		}
+	}
+}
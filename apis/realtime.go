@@ -2,12 +2,15 @@ package apis
 
 import (
 	"context"
+	"crypto/subtle"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/labstack/echo/v5"
@@ -19,6 +22,7 @@ import (
 	"github.com/pocketbase/pocketbase/tools/routine"
 	"github.com/pocketbase/pocketbase/tools/search"
 	"github.com/pocketbase/pocketbase/tools/subscriptions"
+	"github.com/pocketbase/pocketbase/tools/types"
 )
 
 // bindRealtimeApi registers the realtime api endpoints.
@@ -29,20 +33,91 @@ func bindRealtimeApi(app core.App, rg *echo.Group) {
 
 	subGroup.GET("", api.connect)
 	subGroup.POST("", api.setSubscriptions)
+	subGroup.GET("/ws", api.connectWs)
+	subGroup.GET("/clients", api.listClients, RequireAdminAuth())
+	subGroup.DELETE("/clients/:id", api.disconnectClient, RequireAdminAuth())
+	subGroup.POST("/broadcast", api.broadcast)
 
 	api.bindEvents()
 }
 
+// RealtimeBackupsTopic is the system realtime topic that delivers
+// the backup create/restore progress changes to the subscribed admins.
+const RealtimeBackupsTopic string = "PB_BACKUPS"
+
+// RealtimeCommentsTopicPrefix is the prefix of the system realtime topics
+// that deliver the comments thread changes of a single record
+// (eg. "PB_COMMENTS/posts/RECORD_ID").
+const RealtimeCommentsTopicPrefix string = "PB_COMMENTS/"
+
+// RealtimeLocksTopicPrefix is the prefix of the system realtime topics
+// that deliver the edit lock changes of a single record
+// (eg. "PB_LOCKS/posts/RECORD_ID").
+const RealtimeLocksTopicPrefix string = "PB_LOCKS/"
+
+// List of the internal realtime client context keys.
+const (
+	realtimeClientConnectedKey   string = "@connected"
+	realtimeClientCancelKey      string = "@cancel"
+	realtimeClientLastEventIdKey string = "@lastEventId"
+)
+
 type realtimeApi struct {
 	app core.App
 }
 
+// swagger:models RealtimeConnectMessage
+//
+// RealtimeConnectMessage is the data of the "PB_CONNECT" event
+// sent right after the connection is established.
+type RealtimeConnectMessage struct {
+	ClientId string `json:"clientId"`
+}
+
+// swagger:models RealtimeRecordMessage
+//
+// RealtimeRecordMessage is the data of the record change events
+// (the event name is the matched subscription, eg. "demo/*" or "demo/RECORD_ID").
+type RealtimeRecordMessage struct {
+	Action string         `json:"action" example:"create"`
+	Record *models.Record `json:"record"`
+}
+
+// swagger:models RealtimeCommentMessage
+//
+// RealtimeCommentMessage is the data of the comment thread change events
+// (the event name is the matched "PB_COMMENTS/{collection}/{recordId}" subscription).
+type RealtimeCommentMessage struct {
+	Action  string          `json:"action" example:"create"`
+	Comment *models.Comment `json:"comment"`
+}
+
+// swagger:models RealtimeRecordLockMessage
+//
+// RealtimeRecordLockMessage is the data of the record edit lock events
+// (the event name is the matched "PB_LOCKS/{collection}/{recordId}" subscription).
+//
+// The "create" and "update" actions are sent when the lock is acquired or
+// renewed and "delete" when it is released (the expired locks are not reported).
+type RealtimeRecordLockMessage struct {
+	Action string             `json:"action" example:"create"`
+	Lock   *models.RecordLock `json:"lock"`
+}
+
 //	@Summary		Установить соединение в реальном времени
-//	@Description	Устанавливает соединение в реальном времени
+//	@Description	Открывает SSE (text/event-stream) соединение. Каждое событие отправляется в формате "id:{eventId}\nevent:{name}\ndata:{json}\n\n".
+//	@Description	Протокол:
+//	@Description	1. после подключения отправляется событие "PB_CONNECT" с идентификатором клиента (RealtimeConnectMessage);
+//	@Description	2. клиент устанавливает свои подписки через POST /api/realtime (с заголовком Authorization для доступа к закрытым записям);
+//	@Description	3. изменения записей доставляются как события с именем подписки ("{collection}/*", "{collection}/{id}") и данными RealtimeRecordMessage,
+//	@Description	изменения комментариев - как "PB_COMMENTS/{collection}/{recordId}" с данными RealtimeCommentMessage, блокировки записей - как "PB_LOCKS/{collection}/{recordId}" с данными RealtimeRecordLockMessage, прогресс резервного копирования - как "PB_BACKUPS" (только для администраторов).
+//	@Description	При переподключении можно передать идентификатор последнего полученного события (заголовок Last-Event-ID или параметр lastEventId), чтобы получить пропущенные события после установки подписок.
+//	@Description	Неактивное соединение закрывается через 5 минут.
 //	@Tags			Realtime
-//	@Security		AdminAuth
-//	@Success		200	"Соединение установлено"
-//	@Failure		400	{string}	string	"Failed to authenticate."
+//	@Produce		text/event-stream
+//	@Param			Last-Event-ID	header		string	false	"Идентификатор последнего полученного события"
+//	@Param			lastEventId		query		string	false	"Идентификатор последнего полученного события (для клиентов без поддержки заголовков)"
+//	@Success		200				{object}	RealtimeConnectMessage	"Поток событий (первое событие - PB_CONNECT)"
 //	@Router			/realtime [get]
 func (api *realtimeApi) connect(c echo.Context) error {
 	cancelCtx, cancelRequest := context.WithCancel(c.Request().Context())
@@ -51,10 +126,23 @@ func (api *realtimeApi) connect(c echo.Context) error {
 
 	// register new subscription client
 	client := subscriptions.NewDefaultClient()
+	client.Set(realtimeClientConnectedKey, types.NowDateTime())
+	client.Set(realtimeClientCancelKey, cancelRequest)
 	api.app.SubscriptionsBroker().Register(client)
+
+	// resume from the last received event (the missed events are
+	// replayed once the client submits its subscriptions)
+	lastEventId := c.Request().Header.Get("Last-Event-ID")
+	if lastEventId == "" {
+		lastEventId = c.QueryParam("lastEventId")
+	}
+	if lastEventId != "" {
+		client.Set(realtimeClientLastEventIdKey, lastEventId)
+	}
 	defer func() {
 		disconnectEvent := &core.RealtimeDisconnectEvent{
 			HttpContext: c,
+			RequestInfo: RequestInfo(c),
 			Client:      client,
 		}
 
@@ -74,6 +162,7 @@ func (api *realtimeApi) connect(c echo.Context) error {
 
 	connectEvent := &core.RealtimeConnectEvent{
 		HttpContext: c,
+		RequestInfo: RequestInfo(c),
 		Client:      client,
 	}
 
@@ -86,12 +175,18 @@ func (api *realtimeApi) connect(c echo.Context) error {
 	}
 
 	// signalize established connection (aka. fire "connect" message)
+	connectData, err := json.Marshal(&RealtimeConnectMessage{ClientId: client.Id()})
+	if err != nil {
+		return err
+	}
+
 	connectMsgEvent := &core.RealtimeMessageEvent{
 		HttpContext: c,
+		RequestInfo: RequestInfo(c),
 		Client:      client,
 		Message: &subscriptions.Message{
 			Name: "PB_CONNECT",
-			Data: `{"clientId":"` + client.Id() + `"}`,
+			Data: string(connectData),
 		},
 	}
 	connectMsgErr := api.app.OnRealtimeBeforeMessageSend().Trigger(connectMsgEvent, func(e *core.RealtimeMessageEvent) error {
@@ -132,12 +227,18 @@ func (api *realtimeApi) connect(c echo.Context) error {
 
 			msgEvent := &core.RealtimeMessageEvent{
 				HttpContext: c,
+				RequestInfo: RequestInfo(c),
 				Client:      client,
 				Message:     &msg,
 			}
 			msgErr := api.app.OnRealtimeBeforeMessageSend().Trigger(msgEvent, func(e *core.RealtimeMessageEvent) error {
+				eventId := e.Message.Id
+				if eventId == "" {
+					eventId = e.Client.Id()
+				}
+
 				w := e.HttpContext.Response()
-				fmt.Fprint(w, "id:"+e.Client.Id()+"\n")
+				fmt.Fprint(w, "id:"+eventId+"\n")
 				fmt.Fprint(w, "event:"+e.Message.Name+"\n")
 				fmt.Fprint(w, "data:"+e.Message.Data+"\n\n")
 				w.Flush()
@@ -167,21 +268,29 @@ func (api *realtimeApi) connect(c echo.Context) error {
 }
 
 // swagger:models RealtimeSubscribeForm
+//
+// Subscriptions is the list of topics, eg. "demo/*" (all demo
+// records), "demo/RECORD_ID" (single record), "PB_COMMENTS/demo/RECORD_ID"
+// (record comments thread), "PB_LOCKS/demo/RECORD_ID" (record edit lock)
+// or custom broadcast topics.
 type RealtimeSubscribeForm struct {
 	ClientId      string   `form:"clientId" json:"clientId"`
 	Subscriptions []string `form:"subscriptions" json:"subscriptions"`
 }
 
 //	@Summary		Установить подписки в реальном времени
-//	@Description	Устанавливает подписки для клиента в реальном времени
+//	@Description	Заменяет подписки клиента, подключенного через GET /api/realtime (clientId из события PB_CONNECT).
+//	@Description	Авторизация запроса (администратор или запись) сохраняется для клиента и используется для проверки правил доступа ListRule/ViewRule при доставке событий.
+//	@Description	Пустой список подписок отменяет все подписки клиента.
 //	@Tags			Realtime
 //	@Security		AdminAuth
+//	@Security		RecordAuth
 //	@Accept			json
 //	@Param			body	body	RealtimeSubscribeForm	true	"Данные подписок"
 //	@Success		204		"Подписки успешно установлены"
-//	@Failure		400		{string}	string	"Failed to authenticate."
-//	@Failure		403		{string}	string	"Not exists."
-//	@Failure		404		{string}	string	"Not found."
+//	@Failure		400		{string}	string	"Something went wrong while processing your request."
+//	@Failure		403		{string}	string	"The current and the previous request authorization don't match."
+//	@Failure		404		{string}	string	"Missing or invalid client id."
 //	@Router			/realtime [post]
 func (api *realtimeApi) setSubscriptions(c echo.Context) error {
 	form := forms.NewRealtimeSubscribe()
@@ -211,6 +320,7 @@ func (api *realtimeApi) setSubscriptions(c echo.Context) error {
 
 	event := &core.RealtimeSubscribeEvent{
 		HttpContext:   c,
+		RequestInfo:   RequestInfo(c),
 		Client:        client,
 		Subscriptions: form.Subscriptions,
 	}
@@ -226,6 +336,8 @@ func (api *realtimeApi) setSubscriptions(c echo.Context) error {
 		// subscribe to the new subscriptions
 		e.Client.Subscribe(e.Subscriptions...)
 
+		api.replayMissedEvents(e.Client)
+
 		return e.HttpContext.NoContent(http.StatusNoContent)
 	})
 
@@ -236,6 +348,130 @@ func (api *realtimeApi) setSubscriptions(c echo.Context) error {
 	return handlerErr
 }
 
+// swagger:models RealtimeClientInfo
+type RealtimeClientInfo struct {
+	Id               string         `json:"id"`
+	Subscriptions    []string       `json:"subscriptions"`
+	Connected        types.DateTime `json:"connected"`
+	AdminId          string         `json:"adminId"`
+	AuthRecordId     string         `json:"authRecordId"`
+	AuthCollectionId string         `json:"authCollectionId"`
+}
+
+//	@Summary		Получить список клиентов в реальном времени
+//	@Description	Возвращает список подключенных клиентов в реальном времени с их подписками и временем подключения
+//	@Tags			Realtime
+//	@Produce		json
+//	@Security		AdminAuth
+//	@Success		200	{array}		RealtimeClientInfo
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Router			/realtime/clients [get]
+func (api *realtimeApi) listClients(c echo.Context) error {
+	clients := api.app.SubscriptionsBroker().Clients()
+
+	result := make([]*RealtimeClientInfo, 0, len(clients))
+
+	for _, client := range clients {
+		info := &RealtimeClientInfo{
+			Id:            client.Id(),
+			Subscriptions: make([]string, 0, len(client.Subscriptions())),
+		}
+
+		for sub := range client.Subscriptions() {
+			info.Subscriptions = append(info.Subscriptions, sub)
+		}
+		sort.Strings(info.Subscriptions)
+
+		info.Connected, _ = client.Get(realtimeClientConnectedKey).(types.DateTime)
+
+		if admin, _ := client.Get(ContextAdminKey).(*models.Admin); admin != nil {
+			info.AdminId = admin.Id
+		}
+
+		if record, _ := client.Get(ContextAuthRecordKey).(*models.Record); record != nil {
+			info.AuthRecordId = record.Id
+			info.AuthCollectionId = record.Collection().Id
+		}
+
+		result = append(result, info)
+	}
+
+	// the oldest connections first
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Connected.Time().Equal(result[j].Connected.Time()) {
+			return result[i].Id < result[j].Id
+		}
+		return result[i].Connected.Time().Before(result[j].Connected.Time())
+	})
+
+	return c.JSON(http.StatusOK, result)
+}
+
+//	@Summary		Отключить клиента в реальном времени
+//	@Description	Принудительно закрывает соединение указанного клиента в реальном времени
+//	@Tags			Realtime
+//	@Param			id	path	string	true	"Идентификатор клиента"
+//	@Security		AdminAuth
+//	@Success		204	"No Content"
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Failure		404	{string}	string	"Missing or invalid client id."
+//	@Router			/realtime/clients/{id} [delete]
+func (api *realtimeApi) disconnectClient(c echo.Context) error {
+	client, err := api.app.SubscriptionsBroker().ClientById(c.PathParam("id"))
+	if err != nil {
+		return NewNotFoundError("Missing or invalid client id.", err)
+	}
+
+	// close the client connection (if still active)
+	if cancel, ok := client.Get(realtimeClientCancelKey).(context.CancelFunc); ok {
+		cancel()
+	}
+
+	api.app.SubscriptionsBroker().Unregister(client.Id())
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+//	@Summary		Опубликовать сообщение в реальном времени
+//	@Description	Отправляет пользовательское сообщение всем клиентам, подписанным на указанную тему
+//	@Tags			Realtime
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body	forms.RealtimeBroadcast	true	"Данные сообщения"
+//	@Security		AdminAuth
+//	@Success		200	{object}	forms.RealtimeBroadcastResult
+//	@Failure		400	{string}	string	"Failed to broadcast the message."
+//	@Failure		401	{string}	string	"Missing or invalid realtime broadcast token."
+//	@Router			/realtime/broadcast [post]
+func (api *realtimeApi) broadcast(c echo.Context) error {
+	config := api.app.Settings().RealtimeBroadcast
+
+	admin, _ := c.Get(ContextAdminKey).(*models.Admin)
+	if admin == nil {
+		token := strings.TrimPrefix(c.Request().Header.Get("Authorization"), "Bearer ")
+
+		if !config.Enabled || token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(config.Token)) != 1 {
+			return NewUnauthorizedError("Missing or invalid realtime broadcast token.", nil)
+		}
+	}
+
+	form := forms.NewRealtimeBroadcast(api.app)
+
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	return form.Submit(func(next forms.InterceptorNextFunc[*forms.RealtimeBroadcastResult]) forms.InterceptorNextFunc[*forms.RealtimeBroadcastResult] {
+		return func(result *forms.RealtimeBroadcastResult) error {
+			if err := next(result); err != nil {
+				return NewBadRequestError("Failed to broadcast the message.", err)
+			}
+
+			return c.JSON(http.StatusOK, result)
+		}
+	})
+}
+
 // updateClientsAuthModel updates the existing clients auth model with the new one (matched by ID).
 func (api *realtimeApi) updateClientsAuthModel(contextKey string, newModel models.Model) error {
 	for _, client := range api.app.SubscriptionsBroker().Clients() {
@@ -317,6 +553,67 @@ func (api *realtimeApi) bindEvents() {
 		}
 		return nil
 	})
+
+	api.app.OnModelAfterCreate().PreAdd(func(e *core.ModelEvent) error {
+		if comment, ok := e.Model.(*models.Comment); ok {
+			if err := api.broadcastComment("create", comment); err != nil && api.app.IsDebug() {
+				log.Println(err)
+			}
+		}
+		return nil
+	})
+
+	api.app.OnModelAfterUpdate().PreAdd(func(e *core.ModelEvent) error {
+		if comment, ok := e.Model.(*models.Comment); ok {
+			if err := api.broadcastComment("update", comment); err != nil && api.app.IsDebug() {
+				log.Println(err)
+			}
+		}
+		return nil
+	})
+
+	api.app.OnModelAfterDelete().PreAdd(func(e *core.ModelEvent) error {
+		if comment, ok := e.Model.(*models.Comment); ok {
+			if err := api.broadcastComment("delete", comment); err != nil && api.app.IsDebug() {
+				log.Println(err)
+			}
+		}
+		return nil
+	})
+
+	api.app.OnModelAfterCreate().PreAdd(func(e *core.ModelEvent) error {
+		if lock, ok := e.Model.(*models.RecordLock); ok {
+			if err := api.broadcastRecordLock("create", lock); err != nil && api.app.IsDebug() {
+				log.Println(err)
+			}
+		}
+		return nil
+	})
+
+	api.app.OnModelAfterUpdate().PreAdd(func(e *core.ModelEvent) error {
+		if lock, ok := e.Model.(*models.RecordLock); ok {
+			if err := api.broadcastRecordLock("update", lock); err != nil && api.app.IsDebug() {
+				log.Println(err)
+			}
+		}
+		return nil
+	})
+
+	api.app.OnModelAfterDelete().PreAdd(func(e *core.ModelEvent) error {
+		if lock, ok := e.Model.(*models.RecordLock); ok {
+			if err := api.broadcastRecordLock("delete", lock); err != nil && api.app.IsDebug() {
+				log.Println(err)
+			}
+		}
+		return nil
+	})
+
+	api.app.OnBackupProgress().PreAdd(func(e *core.BackupProgressEvent) error {
+		if err := api.broadcastBackupProgress(e.Progress); err != nil && api.app.IsDebug() {
+			log.Println(err)
+		}
+		return nil
+	})
 }
 
 // resolveRecord converts *if possible* the provided model interface to a Record.
@@ -388,19 +685,16 @@ func (api *realtimeApi) canAccessRecord(client subscriptions.Client, record *mod
 	return false
 }
 
-type recordData struct {
-	Action string         `json:"action"`
-	Record *models.Record `json:"record"`
-}
-
 func (api *realtimeApi) broadcastRecord(action string, record *models.Record) error {
 	collection := record.Collection()
 	if collection == nil {
 		return errors.New("Record collection not set.")
 	}
 
+	maxReplayEvents, replayTTL := api.app.Settings().RealtimeReplay.Limits()
+
 	clients := api.app.SubscriptionsBroker().Clients()
-	if len(clients) == 0 {
+	if len(clients) == 0 && maxReplayEvents == 0 {
 		return nil // no subscribers
 	}
 
@@ -418,7 +712,7 @@ func (api *realtimeApi) broadcastRecord(action string, record *models.Record) er
 		collection.Id:   collection.ListRule,
 	}
 
-	data := &recordData{
+	data := &RealtimeRecordMessage{
 		Action: action,
 		Record: cleanRecord,
 	}
@@ -433,37 +727,230 @@ func (api *realtimeApi) broadcastRecord(action string, record *models.Record) er
 
 	encodedData := string(dataBytes)
 
+	// guards the shared record email visibility toggle
+	// (the replayed messages could be resolved concurrently)
+	var emailVisibilityMux sync.Mutex
+
+	// resolves the record message for the specified client and subscription
+	//
+	// note: the access is checked against the current record state, so
+	// replayed delete events are usually delivered only to admins
+	messageFunc := func(client subscriptions.Client, subscription string) (subscriptions.Message, bool) {
+		rule, ok := subscriptionRuleMap[subscription]
+		if !ok || !api.canAccessRecord(client, data.Record, rule) {
+			return subscriptions.Message{}, false
+		}
+
+		msg := subscriptions.Message{
+			Name: subscription,
+			Data: encodedData,
+		}
+
+		// ignore the auth record email visibility checks for
+		// auth owner, admin or manager
+		if collection.IsAuth() {
+			authId := extractAuthIdFromGetter(client)
+			if authId == data.Record.Id ||
+				api.canAccessRecord(client, data.Record, collection.AuthOptions().ManageRule) {
+				emailVisibilityMux.Lock()
+				data.Record.IgnoreEmailVisibility(true) // ignore
+				if newData, err := json.Marshal(data); err == nil {
+					msg.Data = string(newData)
+				}
+				data.Record.IgnoreEmailVisibility(false) // restore
+				emailVisibilityMux.Unlock()
+			}
+		}
+
+		return msg, true
+	}
+
+	topics := make([]string, 0, len(subscriptionRuleMap))
+	for subscription := range subscriptionRuleMap {
+		topics = append(topics, subscription)
+	}
+
+	eventId := api.app.SubscriptionsBroker().Replay().Add(topics, messageFunc, maxReplayEvents, replayTTL)
+
 	for _, client := range clients {
 		client := client
 
-		for subscription, rule := range subscriptionRuleMap {
+		for subscription := range subscriptionRuleMap {
 			if !client.HasSubscription(subscription) {
 				continue
 			}
 
-			if !api.canAccessRecord(client, data.Record, rule) {
+			msg, ok := messageFunc(client, subscription)
+			if !ok {
+				continue
+			}
+
+			msg.Id = eventId
+
+			routine.FireAndForget(func() {
+				if !client.IsDiscarded() {
+					client.Channel() <- msg
+				}
+			})
+		}
+	}
+
+	return nil
+}
+
+// broadcastComment sends the provided comment change to the clients
+// subscribed to its thread topic that could view the thread record.
+//
+// The not approved comments are delivered only to the admins and the
+// comment author (the other clients receive their updates as "delete").
+func (api *realtimeApi) broadcastComment(action string, comment *models.Comment) error {
+	maxReplayEvents, replayTTL := api.app.Settings().RealtimeReplay.Limits()
+
+	clients := api.app.SubscriptionsBroker().Clients()
+	if len(clients) == 0 && maxReplayEvents == 0 {
+		return nil // no subscribers
+	}
+
+	record, err := api.app.Dao().FindRecordById(comment.CollectionId, comment.RecordId)
+	if err != nil {
+		return err
+	}
+
+	collection := record.Collection()
+
+	topics := []string{
+		RealtimeCommentsTopicPrefix + collection.Name + "/" + record.Id,
+		RealtimeCommentsTopicPrefix + collection.Id + "/" + record.Id,
+	}
+
+	dataBytes, err := json.Marshal(&RealtimeCommentMessage{Action: action, Comment: comment})
+	if err != nil {
+		return err
+	}
+
+	// the hidden comment changes are reported only with their thread identifiers
+	hiddenBytes, err := json.Marshal(&RealtimeCommentMessage{Action: "delete", Comment: &models.Comment{
+		BaseModel:    models.BaseModel{Id: comment.Id},
+		CollectionId: comment.CollectionId,
+		RecordId:     comment.RecordId,
+		ParentId:     comment.ParentId,
+	}})
+	if err != nil {
+		return err
+	}
+
+	encodedData := string(dataBytes)
+	encodedHiddenData := string(hiddenBytes)
+
+	messageFunc := func(client subscriptions.Client, topic string) (subscriptions.Message, bool) {
+		if !api.canAccessRecord(client, record, collection.ViewRule) {
+			return subscriptions.Message{}, false
+		}
+
+		msg := subscriptions.Message{
+			Name: topic,
+			Data: encodedData,
+		}
+
+		admin, _ := client.Get(ContextAdminKey).(*models.Admin)
+		authRecord, _ := client.Get(ContextAuthRecordKey).(*models.Record)
+
+		if admin == nil && !comment.IsApproved() && !comment.IsAuthor(authRecord) {
+			if action == "create" {
+				return subscriptions.Message{}, false
+			}
+			msg.Data = encodedHiddenData
+		}
+
+		return msg, true
+	}
+
+	eventId := api.app.SubscriptionsBroker().Replay().Add(topics, messageFunc, maxReplayEvents, replayTTL)
+
+	for _, client := range clients {
+		client := client
+
+		for _, topic := range topics {
+			if !client.HasSubscription(topic) {
 				continue
 			}
 
-			msg := subscriptions.Message{
-				Name: subscription,
-				Data: encodedData,
+			msg, ok := messageFunc(client, topic)
+			if !ok {
+				continue
 			}
 
-			// ignore the auth record email visibility checks for
-			// auth owner, admin or manager
-			if collection.IsAuth() {
-				authId := extractAuthIdFromGetter(client)
-				if authId == data.Record.Id ||
-					api.canAccessRecord(client, data.Record, collection.AuthOptions().ManageRule) {
-					data.Record.IgnoreEmailVisibility(true) // ignore
-					if newData, err := json.Marshal(data); err == nil {
-						msg.Data = string(newData)
-					}
-					data.Record.IgnoreEmailVisibility(false) // restore
+			msg.Id = eventId
+
+			routine.FireAndForget(func() {
+				if !client.IsDiscarded() {
+					client.Channel() <- msg
 				}
+			})
+		}
+	}
+
+	return nil
+}
+
+// broadcastRecordLock sends the provided record lock change to the
+// clients subscribed to the lock record topic and that satisfy the
+// record collection ViewRule.
+func (api *realtimeApi) broadcastRecordLock(action string, lock *models.RecordLock) error {
+	maxReplayEvents, replayTTL := api.app.Settings().RealtimeReplay.Limits()
+
+	clients := api.app.SubscriptionsBroker().Clients()
+	if len(clients) == 0 && maxReplayEvents == 0 {
+		return nil // no subscribers
+	}
+
+	record, err := api.app.Dao().FindRecordById(lock.CollectionId, lock.RecordId)
+	if err != nil {
+		return err
+	}
+
+	collection := record.Collection()
+
+	topics := []string{
+		RealtimeLocksTopicPrefix + collection.Name + "/" + record.Id,
+		RealtimeLocksTopicPrefix + collection.Id + "/" + record.Id,
+	}
+
+	dataBytes, err := json.Marshal(&RealtimeRecordLockMessage{Action: action, Lock: lock})
+	if err != nil {
+		return err
+	}
+
+	encodedData := string(dataBytes)
+
+	messageFunc := func(client subscriptions.Client, topic string) (subscriptions.Message, bool) {
+		if !api.canAccessRecord(client, record, collection.ViewRule) {
+			return subscriptions.Message{}, false
+		}
+
+		return subscriptions.Message{
+			Name: topic,
+			Data: encodedData,
+		}, true
+	}
+
+	eventId := api.app.SubscriptionsBroker().Replay().Add(topics, messageFunc, maxReplayEvents, replayTTL)
+
+	for _, client := range clients {
+		client := client
+
+		for _, topic := range topics {
+			if !client.HasSubscription(topic) {
+				continue
 			}
 
+			msg, ok := messageFunc(client, topic)
+			if !ok {
+				continue
+			}
+
+			msg.Id = eventId
+
 			routine.FireAndForget(func() {
 				if !client.IsDiscarded() {
 					client.Channel() <- msg
@@ -475,6 +962,92 @@ func (api *realtimeApi) broadcastRecord(action string, record *models.Record) er
 	return nil
 }
 
+// broadcastBackupProgress sends the provided backup progress
+// to the admin clients subscribed to the [RealtimeBackupsTopic].
+//
+// The progress messages are not stored for replay since
+// the latest state could be always fetched with GET /api/backups/status.
+func (api *realtimeApi) broadcastBackupProgress(progress *core.BackupProgress) error {
+	clients := api.app.SubscriptionsBroker().Clients()
+	if len(clients) == 0 {
+		return nil // no subscribers
+	}
+
+	dataBytes, err := json.Marshal(progress)
+	if err != nil {
+		return err
+	}
+
+	msg := subscriptions.Message{
+		Name: RealtimeBackupsTopic,
+		Data: string(dataBytes),
+	}
+
+	for _, client := range clients {
+		client := client
+
+		if !client.HasSubscription(RealtimeBackupsTopic) {
+			continue
+		}
+
+		if admin, _ := client.Get(ContextAdminKey).(*models.Admin); admin == nil {
+			continue
+		}
+
+		routine.FireAndForget(func() {
+			if !client.IsDiscarded() {
+				client.Channel() <- msg
+			}
+		})
+	}
+
+	return nil
+}
+
+// replayMissedEvents sends to the resumed client connection the buffered
+// events of its subscriptions that were broadcasted after its last received event.
+//
+// The events are replayed only once per connection.
+func (api *realtimeApi) replayMissedEvents(client subscriptions.Client) {
+	lastEventId, _ := client.Get(realtimeClientLastEventIdKey).(string)
+	if lastEventId == "" {
+		return
+	}
+
+	client.Set(realtimeClientLastEventIdKey, nil)
+
+	_, replayTTL := api.app.Settings().RealtimeReplay.Limits()
+	if replayTTL == 0 {
+		return // disabled replay
+	}
+
+	topics := make([]string, 0, len(client.Subscriptions()))
+	for subscription := range client.Subscriptions() {
+		topics = append(topics, subscription)
+	}
+
+	events := api.app.SubscriptionsBroker().Replay().Since(lastEventId, topics, replayTTL)
+	if len(events) == 0 {
+		return
+	}
+
+	// send the events sequentially to preserve their order
+	routine.FireAndForget(func() {
+		for _, e := range events {
+			msg, ok := e.Message(client)
+			if !ok {
+				continue
+			}
+
+			if client.IsDiscarded() {
+				return
+			}
+
+			client.Channel() <- msg
+		}
+	})
+}
+
 type getter interface {
 	Get(string) any
 }
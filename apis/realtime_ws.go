@@ -0,0 +1,353 @@
+package apis
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/subscriptions"
+	"github.com/pocketbase/pocketbase/tools/types"
+	"golang.org/x/net/websocket"
+)
+
+// List with the supported realtime WebSocket client message types.
+const (
+	RealtimeWsTypeSubscribe string = "subscribe"
+	RealtimeWsTypePing      string = "ping"
+)
+
+const (
+	// maxRealtimeWsSubscriptions is the max allowed number of
+	// subscriptions of a single WebSocket connection.
+	maxRealtimeWsSubscriptions = 100
+
+	// maxRealtimeWsMessageSize is the max allowed size of a single
+	// client WebSocket message (in bytes).
+	maxRealtimeWsMessageSize = 64 << 10
+
+	// realtimeWsPingInterval is the interval of the keepalive WebSocket ping frames.
+	realtimeWsPingInterval = 30 * time.Second
+)
+
+// swagger:models RealtimeWsRequest
+//
+// RealtimeWsRequest is a single client WebSocket message.
+//
+// The "subscribe" messages replace the connection subscriptions
+// (the same as POST /api/realtime) and the "ping" ones are answered
+// with a "PB_PONG" event.
+type RealtimeWsRequest struct {
+	Type          string   `json:"type" example:"subscribe"`
+	Subscriptions []string `json:"subscriptions"`
+}
+
+// swagger:models RealtimeWsMessage
+//
+// RealtimeWsMessage is a single server WebSocket message.
+//
+// The event and data are the same as the SSE ones, with the addition
+// of the "PB_SUBSCRIBE" (subscriptions change confirmation), "PB_PONG"
+// and "PB_ERROR" (ApiError data) events.
+type RealtimeWsMessage struct {
+	Id    string          `json:"id,omitempty"`
+	Event string          `json:"event" example:"PB_CONNECT"`
+	Data  json.RawMessage `json:"data" swaggertype:"object"`
+}
+
+// swagger:models RealtimeWsSubscribeMessage
+//
+// RealtimeWsSubscribeMessage is the data of the "PB_SUBSCRIBE" event.
+type RealtimeWsSubscribeMessage struct {
+	Subscriptions []string `json:"subscriptions"`
+}
+
+// realtimeWsConn is a WebSocket connection safe for concurrent writes.
+type realtimeWsConn struct {
+	mux sync.Mutex
+	ws  *websocket.Conn
+}
+
+// send writes a single JSON text message.
+func (conn *realtimeWsConn) send(msg *RealtimeWsMessage) error {
+	conn.mux.Lock()
+	defer conn.mux.Unlock()
+
+	return websocket.JSON.Send(conn.ws, msg)
+}
+
+// sendEvent writes a single JSON text message with the provided event data.
+func (conn *realtimeWsConn) sendEvent(event string, data any) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	return conn.send(&RealtimeWsMessage{Event: event, Data: raw})
+}
+
+// ping writes a single keepalive ping frame.
+func (conn *realtimeWsConn) ping() error {
+	conn.mux.Lock()
+	defer conn.mux.Unlock()
+
+	conn.ws.PayloadType = websocket.PingFrame
+	defer func() {
+		conn.ws.PayloadType = websocket.TextFrame
+	}()
+
+	_, err := conn.ws.Write(nil)
+
+	return err
+}
+
+//	@Summary		Установить соединение в реальном времени через WebSocket
+//	@Description	Альтернатива SSE для клиентов за прокси, буферизующими text/event-stream. Использует тот же протокол подписок, но в виде JSON сообщений (RealtimeWsMessage и RealtimeWsRequest):
+//	@Description	1. после подключения отправляется событие "PB_CONNECT" с идентификатором клиента (RealtimeConnectMessage);
+//	@Description	2. клиент заменяет свои подписки сообщением {"type":"subscribe","subscriptions":[...]} (не более 100 подписок на соединение), в ответ отправляется событие "PB_SUBSCRIBE";
+//	@Description	3. события подписок доставляются как {"id":"...","event":"{подписка}","data":{...}}, ошибки - как событие "PB_ERROR" с данными ApiError.
+//	@Description	Авторизация (заголовок Authorization или подписанный URL) определяется при подключении и используется для проверки правил доступа при доставке событий.
+//	@Description	Сервер отправляет ping фреймы каждые 30 секунд, сообщение {"type":"ping"} возвращает событие "PB_PONG". Неактивное соединение (без сообщений в обе стороны) закрывается через 5 минут.
+//	@Tags			Realtime
+//	@Security		AdminAuth
+//	@Security		RecordAuth
+//	@Param			lastEventId	query	string	false	"Идентификатор последнего полученного события (пропущенные события отправляются после установки подписок)"
+//	@Success		101			{object}	RealtimeWsMessage	"Switching Protocols"
+//	@Failure		400			{string}	string	"Bad Request"
+//	@Router			/realtime/ws [get]
+func (api *realtimeApi) connectWs(c echo.Context) error {
+	server := websocket.Server{
+		// the cross-origin requests are allowed the same as
+		// for the SSE connections (the auth is not cookie based)
+		Handshake: func(config *websocket.Config, r *http.Request) error {
+			return nil
+		},
+		Handler: func(ws *websocket.Conn) {
+			ws.MaxPayloadBytes = maxRealtimeWsMessageSize
+
+			if err := api.serveWs(c, &realtimeWsConn{ws: ws}); err != nil && api.app.IsDebug() {
+				log.Println("Realtime WebSocket connection error:", err)
+			}
+		},
+	}
+
+	server.ServeHTTP(c.Response(), c.Request())
+
+	return nil
+}
+
+func (api *realtimeApi) serveWs(c echo.Context, conn *realtimeWsConn) error {
+	cancelCtx, cancelRequest := context.WithCancel(c.Request().Context())
+	defer cancelRequest()
+	c.SetRequest(c.Request().Clone(cancelCtx))
+
+	// register new subscription client with the handshake request auth state
+	client := subscriptions.NewDefaultClient()
+	client.Set(realtimeClientConnectedKey, types.NowDateTime())
+	client.Set(realtimeClientCancelKey, cancelRequest)
+	client.Set(ContextAdminKey, c.Get(ContextAdminKey))
+	client.Set(ContextAuthRecordKey, c.Get(ContextAuthRecordKey))
+	if lastEventId := c.QueryParam("lastEventId"); lastEventId != "" {
+		client.Set(realtimeClientLastEventIdKey, lastEventId)
+	}
+	api.app.SubscriptionsBroker().Register(client)
+
+	defer func() {
+		disconnectEvent := &core.RealtimeDisconnectEvent{
+			HttpContext: c,
+			RequestInfo: RequestInfo(c),
+			Client:      client,
+		}
+
+		if err := api.app.OnRealtimeDisconnectRequest().Trigger(disconnectEvent); err != nil && api.app.IsDebug() {
+			log.Println(err)
+		}
+
+		api.app.SubscriptionsBroker().Unregister(client.Id())
+	}()
+
+	connectEvent := &core.RealtimeConnectEvent{
+		HttpContext: c,
+		RequestInfo: RequestInfo(c),
+		Client:      client,
+	}
+
+	if err := api.app.OnRealtimeConnectRequest().Trigger(connectEvent); err != nil {
+		return err
+	}
+
+	if api.app.IsDebug() {
+		log.Printf("Realtime WebSocket connection established: %s\n", client.Id())
+	}
+
+	// read the client messages until the connection is closed
+	requests := make(chan *RealtimeWsRequest)
+	go func() {
+		defer cancelRequest()
+
+		for {
+			var raw []byte
+			if err := websocket.Message.Receive(conn.ws, &raw); err != nil {
+				return // closed or invalid connection
+			}
+
+			req := &RealtimeWsRequest{}
+			if err := json.Unmarshal(raw, req); err != nil {
+				conn.sendEvent("PB_ERROR", NewBadRequestError("Invalid message format.", err))
+				continue
+			}
+
+			select {
+			case requests <- req:
+			case <-cancelCtx.Done():
+				return
+			}
+		}
+	}()
+
+	connectMsg := &subscriptions.Message{Name: "PB_CONNECT", Id: client.Id()}
+	if raw, err := json.Marshal(&RealtimeConnectMessage{ClientId: client.Id()}); err == nil {
+		connectMsg.Data = string(raw)
+	}
+	if err := api.sendWsMessage(c, conn, client, connectMsg); err != nil {
+		return err
+	}
+
+	// start an idle timer to keep track of inactive/forgotten connections
+	idleDuration := 5 * time.Minute
+	idleTimer := time.NewTimer(idleDuration)
+	defer idleTimer.Stop()
+
+	pingTicker := time.NewTicker(realtimeWsPingInterval)
+	defer pingTicker.Stop()
+
+	for {
+		select {
+		case <-idleTimer.C:
+			cancelRequest()
+		case <-pingTicker.C:
+			if err := conn.ping(); err != nil {
+				return err
+			}
+		case req := <-requests:
+			if err := api.handleWsRequest(c, conn, client, req); err != nil {
+				return err
+			}
+
+			idleTimer.Stop()
+			idleTimer.Reset(idleDuration)
+		case msg, ok := <-client.Channel():
+			if !ok {
+				// channel is closed
+				return nil
+			}
+
+			if err := api.sendWsMessage(c, conn, client, &msg); err != nil {
+				return err
+			}
+
+			idleTimer.Stop()
+			idleTimer.Reset(idleDuration)
+		case <-cancelCtx.Done():
+			// connection is closed
+			if api.app.IsDebug() {
+				log.Println("Realtime WebSocket connection closed:", client.Id())
+			}
+			return nil
+		}
+	}
+}
+
+// sendWsMessage delivers a single subscription message to the WebSocket client.
+func (api *realtimeApi) sendWsMessage(c echo.Context, conn *realtimeWsConn, client subscriptions.Client, msg *subscriptions.Message) error {
+	msgEvent := &core.RealtimeMessageEvent{
+		HttpContext: c,
+		RequestInfo: RequestInfo(c),
+		Client:      client,
+		Message:     msg,
+	}
+
+	msgErr := api.app.OnRealtimeBeforeMessageSend().Trigger(msgEvent, func(e *core.RealtimeMessageEvent) error {
+		eventId := e.Message.Id
+		if eventId == "" {
+			eventId = e.Client.Id()
+		}
+
+		return conn.send(&RealtimeWsMessage{
+			Id:    eventId,
+			Event: e.Message.Name,
+			Data:  json.RawMessage(e.Message.Data),
+		})
+	})
+	if msgErr != nil {
+		return msgErr
+	}
+
+	if err := api.app.OnRealtimeAfterMessageSend().Trigger(msgEvent); err != nil && api.app.IsDebug() {
+		log.Println("OnRealtimeAfterMessageSend error:", err)
+	}
+
+	return nil
+}
+
+// handleWsRequest processes a single client WebSocket message.
+//
+// The request errors are delivered to the client as "PB_ERROR" events
+// and only the connection write errors are returned.
+func (api *realtimeApi) handleWsRequest(c echo.Context, conn *realtimeWsConn, client subscriptions.Client, req *RealtimeWsRequest) error {
+	switch req.Type {
+	case RealtimeWsTypePing:
+		return conn.sendEvent("PB_PONG", map[string]any{})
+	case RealtimeWsTypeSubscribe:
+		if len(req.Subscriptions) > maxRealtimeWsSubscriptions {
+			return conn.sendEvent("PB_ERROR", NewBadRequestError("Too many subscriptions for a single connection.", nil))
+		}
+
+		event := &core.RealtimeSubscribeEvent{
+			HttpContext:   c,
+			RequestInfo:   RequestInfo(c),
+			Client:        client,
+			Subscriptions: req.Subscriptions,
+		}
+
+		handlerErr := api.app.OnRealtimeBeforeSubscribeRequest().Trigger(event, func(e *core.RealtimeSubscribeEvent) error {
+			// unsubscribe from any previous existing subscriptions
+			e.Client.Unsubscribe()
+
+			// subscribe to the new subscriptions
+			e.Client.Subscribe(e.Subscriptions...)
+
+			api.replayMissedEvents(e.Client)
+
+			result := &RealtimeWsSubscribeMessage{
+				Subscriptions: make([]string, 0, len(e.Client.Subscriptions())),
+			}
+			for sub := range e.Client.Subscriptions() {
+				result.Subscriptions = append(result.Subscriptions, sub)
+			}
+			sort.Strings(result.Subscriptions)
+
+			return conn.sendEvent("PB_SUBSCRIBE", result)
+		})
+		if handlerErr != nil {
+			apiErr, ok := handlerErr.(*ApiError)
+			if !ok {
+				apiErr = NewBadRequestError("", handlerErr)
+			}
+			return conn.sendEvent("PB_ERROR", apiErr)
+		}
+
+		if err := api.app.OnRealtimeAfterSubscribeRequest().Trigger(event); err != nil && api.app.IsDebug() {
+			log.Println(err)
+		}
+
+		return nil
+	default:
+		return conn.sendEvent("PB_ERROR", NewBadRequestError("Unsupported message type.", nil))
+	}
+}
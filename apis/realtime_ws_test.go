@@ -0,0 +1,185 @@
+package apis_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/apis"
+	"github.com/pocketbase/pocketbase/tests"
+	"golang.org/x/net/websocket"
+)
+
+func dialTestRealtimeWs(t *testing.T, app *tests.TestApp, token string) (*websocket.Conn, func()) {
+	e, err := apis.InitApi(app)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// track the hijacked connection handlers to wait for their completion
+	// (the httptest server doesn't wait for them on close)
+	var wg sync.WaitGroup
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		wg.Add(1)
+		defer wg.Done()
+
+		e.ServeHTTP(w, r)
+	}))
+
+	config, err := websocket.NewConfig("ws"+strings.TrimPrefix(server.URL, "http")+"/api/realtime/ws", server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "" {
+		config.Header.Set("Authorization", token)
+	}
+
+	ws, err := websocket.DialConfig(config)
+	if err != nil {
+		server.Close()
+		t.Fatal(err)
+	}
+
+	return ws, func() {
+		ws.Close()
+		wg.Wait()
+		server.Close()
+	}
+}
+
+func receiveTestRealtimeWsMessage(t *testing.T, ws *websocket.Conn) *apis.RealtimeWsMessage {
+	ws.SetReadDeadline(time.Now().Add(2 * time.Second))
+
+	msg := &apis.RealtimeWsMessage{}
+	if err := websocket.JSON.Receive(ws, msg); err != nil {
+		t.Fatalf("Failed to receive message: %v", err)
+	}
+
+	return msg
+}
+
+func TestRealtimeWsSubscribeAndReceive(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	ws, closeFunc := dialTestRealtimeWs(t, app, "")
+	defer closeFunc()
+
+	connectMsg := receiveTestRealtimeWsMessage(t, ws)
+	if connectMsg.Event != "PB_CONNECT" || !strings.Contains(string(connectMsg.Data), `"clientId":`) {
+		t.Fatalf("Expected PB_CONNECT message, got %s %s", connectMsg.Event, connectMsg.Data)
+	}
+
+	clients := app.SubscriptionsBroker().Clients()
+	if len(clients) != 1 {
+		t.Fatalf("Expected 1 registered client, got %d", len(clients))
+	}
+
+	// ping
+	websocket.Message.Send(ws, `{"type":"ping"}`)
+	if msg := receiveTestRealtimeWsMessage(t, ws); msg.Event != "PB_PONG" {
+		t.Fatalf("Expected PB_PONG message, got %s", msg.Event)
+	}
+
+	// invalid messages
+	websocket.Message.Send(ws, `{"type":"unknown"}`)
+	if msg := receiveTestRealtimeWsMessage(t, ws); msg.Event != "PB_ERROR" || !strings.Contains(string(msg.Data), `"message":"Unsupported message type."`) {
+		t.Fatalf("Expected unsupported type PB_ERROR message, got %s %s", msg.Event, msg.Data)
+	}
+
+	websocket.Message.Send(ws, `{invalid`)
+	if msg := receiveTestRealtimeWsMessage(t, ws); msg.Event != "PB_ERROR" || !strings.Contains(string(msg.Data), `"message":"Invalid message format."`) {
+		t.Fatalf("Expected invalid format PB_ERROR message, got %s %s", msg.Event, msg.Data)
+	}
+
+	// subscriptions limit
+	tooMany := make([]string, 101)
+	for i := range tooMany {
+		tooMany[i] = "topic" + strings.Repeat("a", i)
+	}
+	websocket.JSON.Send(ws, &apis.RealtimeWsRequest{Type: apis.RealtimeWsTypeSubscribe, Subscriptions: tooMany})
+	if msg := receiveTestRealtimeWsMessage(t, ws); msg.Event != "PB_ERROR" || !strings.Contains(string(msg.Data), `"message":"Too many subscriptions for a single connection."`) {
+		t.Fatalf("Expected subscriptions limit PB_ERROR message, got %s %s", msg.Event, msg.Data)
+	}
+
+	// subscribe
+	websocket.Message.Send(ws, `{"type":"subscribe","subscriptions":["demo2/*","demo1/*"]}`)
+	subscribeMsg := receiveTestRealtimeWsMessage(t, ws)
+	if subscribeMsg.Event != "PB_SUBSCRIBE" || string(subscribeMsg.Data) != `{"subscriptions":["demo1/*","demo2/*"]}` {
+		t.Fatalf("Expected PB_SUBSCRIBE message, got %s %s", subscribeMsg.Event, subscribeMsg.Data)
+	}
+
+	// the guest can't access the admin only demo1 records,
+	// so only the demo2 change event is expected
+	demo1Record, err := app.Dao().FindRecordById("demo1", "84nmscqy84lsi1t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := app.Dao().SaveRecord(demo1Record); err != nil {
+		t.Fatal(err)
+	}
+
+	demo2Record, err := app.Dao().FindRecordById("demo2", "achvryl401bhse3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	demo2Record.Set("title", "ws_update")
+	if err := app.Dao().SaveRecord(demo2Record); err != nil {
+		t.Fatal(err)
+	}
+
+	recordMsg := receiveTestRealtimeWsMessage(t, ws)
+	if recordMsg.Event != "demo2/*" {
+		t.Fatalf("Expected demo2/* event, got %s", recordMsg.Event)
+	}
+
+	data := struct {
+		Action string         `json:"action"`
+		Record map[string]any `json:"record"`
+	}{}
+	if err := json.Unmarshal(recordMsg.Data, &data); err != nil {
+		t.Fatal(err)
+	}
+	if data.Action != "update" || data.Record["id"] != "achvryl401bhse3" || data.Record["title"] != "ws_update" {
+		t.Fatalf("Expected the updated demo2 record message, got %s", recordMsg.Data)
+	}
+}
+
+func TestRealtimeWsAuthAndDisconnect(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	ws, closeFunc := dialTestRealtimeWs(t, app, testOrgAdminToken)
+	defer closeFunc()
+
+	if msg := receiveTestRealtimeWsMessage(t, ws); msg.Event != "PB_CONNECT" {
+		t.Fatalf("Expected PB_CONNECT message, got %s", msg.Event)
+	}
+
+	clients := app.SubscriptionsBroker().Clients()
+	if len(clients) != 1 {
+		t.Fatalf("Expected 1 registered client, got %d", len(clients))
+	}
+
+	for _, client := range clients {
+		if client.Get(apis.ContextAdminKey) == nil {
+			t.Fatal("Expected the client to be associated with the handshake admin")
+		}
+	}
+
+	ws.Close()
+
+	// wait for the connection cleanup
+	for i := 0; i < 20; i++ {
+		if len(app.SubscriptionsBroker().Clients()) == 0 {
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	t.Fatalf("Expected the client to be unregistered after the connection close, found %d", len(app.SubscriptionsBroker().Clients()))
+}
@@ -89,6 +89,7 @@ func (api *recordAuthApi) authRefresh(c echo.Context) error {
 
 	event := new(core.RecordAuthRefreshEvent)
 	event.HttpContext = c
+	event.RequestInfo = RequestInfo(c)
 	event.Collection = record.Collection()
 	event.Record = record
 
@@ -230,6 +231,7 @@ func (api *recordAuthApi) authWithOAuth2(c echo.Context) error {
 
 	event := new(core.RecordAuthWithOAuth2Event)
 	event.HttpContext = c
+	event.RequestInfo = RequestInfo(c)
 	event.Collection = collection
 	event.ProviderName = form.Provider
 	event.IsNewRecord = false
@@ -335,6 +337,7 @@ func (api *recordAuthApi) authWithPassword(c echo.Context) error {
 
 	event := new(core.RecordAuthWithPasswordEvent)
 	event.HttpContext = c
+	event.RequestInfo = RequestInfo(c)
 	event.Collection = collection
 	event.Password = form.Password
 	event.Identity = form.Identity
@@ -395,6 +398,7 @@ func (api *recordAuthApi) requestPasswordReset(c echo.Context) error {
 
 	event := new(core.RecordRequestPasswordResetEvent)
 	event.HttpContext = c
+	event.RequestInfo = RequestInfo(c)
 	event.Collection = collection
 
 	submitErr := form.Submit(func(next forms.InterceptorNextFunc[*models.Record]) forms.InterceptorNextFunc[*models.Record] {
@@ -454,6 +458,7 @@ func (api *recordAuthApi) confirmPasswordReset(c echo.Context) error {
 
 	event := new(core.RecordConfirmPasswordResetEvent)
 	event.HttpContext = c
+	event.RequestInfo = RequestInfo(c)
 	event.Collection = collection
 
 	_, submitErr := form.Submit(func(next forms.InterceptorNextFunc[*models.Record]) forms.InterceptorNextFunc[*models.Record] {
@@ -507,6 +512,7 @@ func (api *recordAuthApi) requestVerification(c echo.Context) error {
 
 	event := new(core.RecordRequestVerificationEvent)
 	event.HttpContext = c
+	event.RequestInfo = RequestInfo(c)
 	event.Collection = collection
 
 	submitErr := form.Submit(func(next forms.InterceptorNextFunc[*models.Record]) forms.InterceptorNextFunc[*models.Record] {
@@ -566,6 +572,7 @@ func (api *recordAuthApi) confirmVerification(c echo.Context) error {
 
 	event := new(core.RecordConfirmVerificationEvent)
 	event.HttpContext = c
+	event.RequestInfo = RequestInfo(c)
 	event.Collection = collection
 
 	_, submitErr := form.Submit(func(next forms.InterceptorNextFunc[*models.Record]) forms.InterceptorNextFunc[*models.Record] {
@@ -620,6 +627,7 @@ func (api *recordAuthApi) requestEmailChange(c echo.Context) error {
 
 	event := new(core.RecordRequestEmailChangeEvent)
 	event.HttpContext = c
+	event.RequestInfo = RequestInfo(c)
 	event.Collection = collection
 	event.Record = record
 
@@ -666,6 +674,7 @@ func (api *recordAuthApi) confirmEmailChange(c echo.Context) error {
 
 	event := new(core.RecordConfirmEmailChangeEvent)
 	event.HttpContext = c
+	event.RequestInfo = RequestInfo(c)
 	event.Collection = collection
 
 	_, submitErr := form.Submit(func(next forms.InterceptorNextFunc[*models.Record]) forms.InterceptorNextFunc[*models.Record] {
@@ -726,6 +735,7 @@ func (api *recordAuthApi) listExternalAuths(c echo.Context) error {
 
 	event := new(core.RecordListExternalAuthsEvent)
 	event.HttpContext = c
+	event.RequestInfo = RequestInfo(c)
 	event.Collection = collection
 	event.Record = record
 	event.ExternalAuths = externalAuths
@@ -772,6 +782,7 @@ func (api *recordAuthApi) unlinkExternalAuth(c echo.Context) error {
 
 	event := new(core.RecordUnlinkExternalAuthEvent)
 	event.HttpContext = c
+	event.RequestInfo = RequestInfo(c)
 	event.Collection = collection
 	event.Record = record
 	event.ExternalAuth = externalAuth
@@ -0,0 +1,129 @@
+package apis
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// swagger:models BatchRequest
+//
+// The operations are executed with the same rules and limitations
+// as the [TransactionRequest] ones.
+type BatchRequest struct {
+	Operations []*TransactionOperation `json:"operations"`
+}
+
+// Validate implements [validation.Validatable] interface.
+func (r BatchRequest) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Operations, validation.Required, validation.Length(1, maxTransactionOperations)),
+	)
+}
+
+// swagger:models BatchResult
+type BatchResult struct {
+	// Status is the http status code of the operation.
+	Status int `json:"status"`
+
+	// Record is the created or updated record (nil for delete and failed operations).
+	Record *models.Record `json:"record,omitempty"`
+
+	// Error is the failed operation error.
+	Error *ApiError `json:"error,omitempty"`
+}
+
+// swagger:models BatchResponse
+//
+// On failure Results contains only the executed operations
+// (the last one being the failed operation).
+type BatchResponse struct {
+	RolledBack bool           `json:"rolledBack"`
+	Results    []*BatchResult `json:"results"`
+}
+
+//	@Summary		Пакетное выполнение операций
+//	@Description	Выполняет операции создания, изменения и удаления записей разных коллекций в одной транзакции с проверкой правил доступа для каждой операции (при ошибке любой операции все изменения откатываются, а ответ содержит результаты выполненных операций и ошибку последней)
+//	@Tags			Record
+//	@Security		Auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body	BatchRequest	true	"Список операций"
+//	@Success		200		{object}	BatchResponse	"Результаты в порядке операций"
+//	@Failure		400		{object}	BatchResponse	"Результаты выполненных операций (изменения откачены)"
+//	@Failure		403		{object}	BatchResponse	"Результаты выполненных операций (изменения откачены)"
+//	@Failure		404		{object}	BatchResponse	"Результаты выполненных операций (изменения откачены)"
+//	@Router			/batch [post]
+func (api *recordTransactionApi) batch(c echo.Context) error {
+	// note: extracted before the bind to preserve the request body
+	requestData := RequestData(c)
+
+	body := new(BatchRequest)
+	if err := c.Bind(body); err != nil {
+		return NewBadRequestError("Failed to load the submitted data due to invalid formatting.", err)
+	}
+
+	if err := body.Validate(); err != nil {
+		return NewBadRequestError("An error occurred while validating the submitted data.", err)
+	}
+
+	response := &BatchResponse{Results: make([]*BatchResult, 0, len(body.Operations))}
+
+	var failed *ApiError
+
+	txErr := api.app.Dao().RunInTransaction(func(txDao *daos.Dao) error {
+		for _, op := range body.Operations {
+			record, err := api.execOperation(txDao, requestData, op)
+			if err != nil {
+				if !errors.As(err, &failed) {
+					failed = NewBadRequestError("Failed to execute batch operation.", err)
+				}
+
+				response.Results = append(response.Results, &BatchResult{Status: failed.Code, Error: failed})
+
+				return failed
+			}
+
+			result := &BatchResult{Status: http.StatusOK, Record: record}
+			if record == nil {
+				result.Status = http.StatusNoContent
+			}
+			response.Results = append(response.Results, result)
+		}
+
+		return nil
+	})
+
+	if failed != nil {
+		response.RolledBack = true
+
+		// the records of the rolled back operations are not returned
+		for _, result := range response.Results {
+			result.Record = nil
+		}
+
+		return c.JSON(failed.Code, response)
+	}
+
+	if txErr != nil {
+		return NewBadRequestError("Failed to execute batch operations.", txErr)
+	}
+
+	records := make([]*models.Record, 0, len(response.Results))
+	for _, result := range response.Results {
+		if result.Record != nil {
+			records = append(records, result.Record)
+		}
+	}
+
+	if err := EnrichRecords(c, api.app.Dao(), records); err != nil && api.app.IsDebug() {
+		log.Println(err)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
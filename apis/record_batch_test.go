@@ -0,0 +1,126 @@
+package apis_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestRecordBatch(t *testing.T) {
+	ensureNoDemo2Record := func(title string) func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+		return func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+			if record, _ := app.Dao().FindFirstRecordByData("demo2", "title", title); record != nil {
+				t.Fatalf("Expected the %q demo2 record to be rolled back", title)
+			}
+		}
+	}
+
+	scenarios := []tests.ApiScenario{
+		{
+			Name:            "empty operations",
+			Method:          http.MethodPost,
+			Url:             "/api/batch",
+			Body:            strings.NewReader(`{"operations":[]}`),
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"operations":{"code":"validation_required"`},
+		},
+		{
+			Name:            "invalid operation action",
+			Method:          http.MethodPost,
+			Url:             "/api/batch",
+			Body:            strings.NewReader(`{"operations":[{"action":"invalid","collection":"demo2"}]}`),
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"action":{"code":"validation_in_invalid"`},
+		},
+		{
+			Name:   "guest trying to create in nil rule collection",
+			Method: http.MethodPost,
+			Url:    "/api/batch",
+			Body: strings.NewReader(`{"operations":[
+				{"action":"create","collection":"demo2","data":{"title":"batch_new"}},
+				{"action":"create","collection":"demo1","data":{}},
+				{"action":"create","collection":"demo2","data":{"title":"batch_new2"}}
+			]}`),
+			ExpectedStatus: 403,
+			ExpectedContent: []string{
+				`"rolledBack":true`,
+				`"results":[{"status":200},{"status":403,"error":{"code":403,"message":"Only admins can perform this action.","data":{}}}]`,
+			},
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeCreate": 1,
+				// the failed batch response is not an api error
+				"OnBeforeApiError": 0,
+				"OnAfterApiError":  0,
+			},
+			AfterTestFunc: ensureNoDemo2Record("batch_new"),
+		},
+		{
+			Name:   "failed validation rollbacks the previous operations",
+			Method: http.MethodPost,
+			Url:    "/api/batch",
+			Body: strings.NewReader(`{"operations":[
+				{"action":"create","collection":"demo2","data":{"title":"batch_new"}},
+				{"action":"update","collection":"demo2","id":"achvryl401bhse3","data":{"title":"test1"}}
+			]}`),
+			ExpectedStatus: 400,
+			ExpectedContent: []string{
+				`"rolledBack":true`,
+				`{"status":400,"error":{"code":400,"message":"Failed to update record."`,
+				`"title":{"code":"validation_not_unique"`,
+			},
+			NotExpectedContent: []string{`"record":`},
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeCreate": 1,
+				"OnModelBeforeUpdate": 1,
+				"OnBeforeApiError":    0,
+				"OnAfterApiError":     0,
+			},
+			AfterTestFunc: ensureNoDemo2Record("batch_new"),
+		},
+		{
+			Name:   "guest creating, updating and deleting records in a single batch",
+			Method: http.MethodPost,
+			Url:    "/api/batch",
+			Body: strings.NewReader(`{"operations":[
+				{"action":"create","collection":"demo2","data":{"id":"batchrecord1234","title":"batch_new"}},
+				{"action":"update","collection":"demo2","id":"batchrecord1234","data":{"active":true}},
+				{"action":"create","collection":"demo5","data":{"total":3}},
+				{"action":"delete","collection":"demo2","id":"llvuca81nly1qls"}
+			]}`),
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"rolledBack":false`,
+				`{"status":200,"record":{`,
+				`"id":"batchrecord1234"`,
+				`"active":true`,
+				`"total":3`,
+				`,{"status":204}]`,
+			},
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeCreate": 2,
+				"OnModelAfterCreate":  2,
+				// +1 for the deleted record relation reference cleanup
+				"OnModelBeforeUpdate": 2,
+				"OnModelAfterUpdate":  2,
+				"OnModelBeforeDelete": 1,
+				"OnModelAfterDelete":  1,
+			},
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				if _, err := app.Dao().FindRecordById("demo2", "batchrecord1234"); err != nil {
+					t.Fatalf("Expected the created record to be persisted: %v", err)
+				}
+
+				if _, err := app.Dao().FindRecordById("demo2", "llvuca81nly1qls"); err == nil {
+					t.Fatal("Expected the deleted record to be missing")
+				}
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
@@ -0,0 +1,281 @@
+package apis
+
+import (
+	"bytes"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/models/settings"
+)
+
+// recordsCacheMaxEntries is the max number of cached responses per collection.
+const recordsCacheMaxEntries = 1000
+
+type cachedResponse struct {
+	body        []byte
+	contentType string
+	etag        string
+	freshUntil  time.Time
+	staleUntil  time.Time
+
+	// revalidating indicates that a request was let through
+	// to refresh the stale entry
+	revalidating bool
+}
+
+// recordsCache is an in-memory store of the guest records
+// list and view responses grouped by collection id.
+type recordsCache struct {
+	mux         sync.Mutex
+	collections map[string]map[string]*cachedResponse
+}
+
+func newRecordsCache() *recordsCache {
+	return &recordsCache{
+		collections: map[string]map[string]*cachedResponse{},
+	}
+}
+
+// lookup returns the cached response that could be served (nil on miss).
+//
+// A stale entry within its stale-while-revalidate period is served
+// to all requests except the first one that is expected to refresh it.
+func (rc *recordsCache) lookup(collectionId string, key string) *cachedResponse {
+	rc.mux.Lock()
+	defer rc.mux.Unlock()
+
+	entry := rc.collections[collectionId][key]
+	if entry == nil {
+		return nil
+	}
+
+	now := time.Now()
+
+	if now.Before(entry.freshUntil) {
+		return entry
+	}
+
+	if now.Before(entry.staleUntil) {
+		if entry.revalidating {
+			return entry
+		}
+
+		entry.revalidating = true
+
+		return nil
+	}
+
+	delete(rc.collections[collectionId], key)
+
+	return nil
+}
+
+// store saves the provided response entry (silently skipped if the collection limit is reached).
+func (rc *recordsCache) store(collectionId string, key string, entry *cachedResponse) {
+	rc.mux.Lock()
+	defer rc.mux.Unlock()
+
+	entries := rc.collections[collectionId]
+	if entries == nil {
+		entries = map[string]*cachedResponse{}
+		rc.collections[collectionId] = entries
+	}
+
+	if _, ok := entries[key]; !ok && len(entries) >= recordsCacheMaxEntries {
+		now := time.Now()
+		for k, v := range entries {
+			if !now.Before(v.staleUntil) {
+				delete(entries, k)
+			}
+		}
+
+		if len(entries) >= recordsCacheMaxEntries {
+			return
+		}
+	}
+
+	entries[key] = entry
+}
+
+// remove deletes a single cached response.
+func (rc *recordsCache) remove(collectionId string, key string) {
+	rc.mux.Lock()
+	defer rc.mux.Unlock()
+
+	delete(rc.collections[collectionId], key)
+}
+
+// invalidate deletes all cached responses of the specified collection.
+func (rc *recordsCache) invalidate(collectionId string) {
+	rc.mux.Lock()
+	defer rc.mux.Unlock()
+
+	delete(rc.collections, collectionId)
+}
+
+// bindRecordsCacheHooks registers the app model hooks that invalidate
+// the cached responses of the changed collections.
+func bindRecordsCacheHooks(app core.App, cache *recordsCache) {
+	handler := func(e *core.ModelEvent) error {
+		switch m := e.Model.(type) {
+		case *models.Record:
+			if collection := m.Collection(); collection != nil {
+				cache.invalidate(collection.Id)
+			}
+		case *models.Collection:
+			cache.invalidate(m.Id)
+		}
+
+		return nil
+	}
+
+	app.OnModelAfterCreate().Add(handler)
+	app.OnModelAfterUpdate().Add(handler)
+	app.OnModelAfterDelete().Add(handler)
+}
+
+// cacheRecords middleware sets the Cache-Control header of the successful
+// records list and view responses of the collections with caching settings
+// and serves the guest responses from the provided cache if it is
+// enabled server-side.
+//
+// This middleware is expected to be registered after LoadCollectionContext.
+func cacheRecords(app core.App, cache *recordsCache, rule func(collection *models.Collection) *string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+			if collection == nil || isExplainRulesRequest(c) {
+				return next(c)
+			}
+
+			config, ok := app.Settings().Caching.Collection(collection.Name)
+			if !ok {
+				return next(c)
+			}
+
+			requestData := RequestData(c)
+			isGuest := requestData.Admin == nil && requestData.AuthRecord == nil
+
+			res := c.Response()
+
+			cacheControl := config.CacheControl(!isGuest)
+			res.Before(func() {
+				if res.Status == http.StatusOK {
+					res.Header().Set("Cache-Control", cacheControl)
+				}
+			})
+
+			// the header rule conditions could produce different responses for the same url
+			r := rule(collection)
+			if !isGuest || !config.ServerSide || (r != nil && strings.Contains(*r, "@request.headers.")) {
+				return next(c)
+			}
+
+			// the cached responses are invalidated only on changes of the
+			// collection itself so skip the ones that could depend on
+			// the records of other collections
+			if isCrossCollectionRequest(c, collection, r) {
+				return next(c)
+			}
+
+			key := c.Request().URL.RequestURI()
+
+			if entry := cache.lookup(collection.Id, key); entry != nil {
+				res.Header().Set("X-Cache", "HIT")
+				if entry.etag != "" {
+					res.Header().Set(HeaderETag, entry.etag)
+				}
+
+				return c.Blob(http.StatusOK, entry.contentType, entry.body)
+			}
+
+			res.Header().Set("X-Cache", "MISS")
+
+			recorder := &responseBodyRecorder{ResponseWriter: res.Writer}
+			res.Writer = recorder
+
+			err := next(c)
+
+			res.Writer = recorder.ResponseWriter
+
+			if err != nil || res.Status != http.StatusOK {
+				cache.remove(collection.Id, key)
+				return err
+			}
+
+			cache.store(collection.Id, key, newCachedResponse(res.Header(), recorder.body.Bytes(), config))
+
+			return nil
+		}
+	}
+}
+
+// ruleIdentifierRegex matches the identifier-like tokens of a rule or filter expression.
+var ruleIdentifierRegex = regexp.MustCompile(`@?[\w]+(\.[\w]+)*`)
+
+// isCrossCollectionRequest reports whether the records list/view response
+// of the current request could include or be filtered by the records
+// of another collection, aka. when it has expand or when its access rule,
+// filter or sort reference @collection.* or a relation field.
+func isCrossCollectionRequest(c echo.Context, collection *models.Collection, rule *string) bool {
+	if c.QueryParam(expandQueryParam) != "" {
+		return true
+	}
+
+	expressions := []string{c.QueryParam("filter"), c.QueryParam("sort")}
+	if rule != nil {
+		expressions = append(expressions, *rule)
+	}
+
+	for _, expr := range expressions {
+		for _, ident := range ruleIdentifierRegex.FindAllString(expr, -1) {
+			if strings.HasPrefix(ident, "@collection.") {
+				return true
+			}
+
+			name, _, hasPath := strings.Cut(ident, ".")
+			if !hasPath {
+				continue
+			}
+
+			if field := collection.Schema.GetFieldByName(name); field != nil && field.Type == schema.FieldTypeRelation {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func newCachedResponse(header http.Header, body []byte, config settings.CollectionCachingConfig) *cachedResponse {
+	freshUntil := time.Now().Add(time.Duration(config.MaxAge) * time.Second)
+
+	return &cachedResponse{
+		body:        append([]byte(nil), body...),
+		contentType: header.Get(echo.HeaderContentType),
+		etag:        header.Get(HeaderETag),
+		freshUntil:  freshUntil,
+		staleUntil:  freshUntil.Add(time.Duration(config.StaleWhileRevalidate) * time.Second),
+	}
+}
+
+// responseBodyRecorder is a [http.ResponseWriter] that keeps
+// a copy of the written response body.
+type responseBodyRecorder struct {
+	http.ResponseWriter
+
+	body bytes.Buffer
+}
+
+func (r *responseBodyRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+
+	return r.ResponseWriter.Write(b)
+}
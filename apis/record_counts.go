@@ -0,0 +1,187 @@
+package apis
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+// ApproxTotalQueryParam is the records list query parameter that enables
+// the cached (and therefore approximate) total items count.
+const ApproxTotalQueryParam = "approxTotal"
+
+const (
+	// recordCountsTTL is the max age of a cached records count.
+	recordCountsTTL = 5 * time.Minute
+
+	// recordCountsMaxEntries is the max number of cached counts per collection.
+	recordCountsMaxEntries = 1000
+)
+
+type cachedCount struct {
+	total   int64
+	expires time.Time
+
+	// unfiltered indicates that the count is of all collection
+	// records and could be kept in sync on records create and delete
+	unfiltered bool
+}
+
+// recordCounts is an in-memory store of the records list count
+// query results grouped by collection id.
+//
+// The unfiltered collection counts are adjusted on records create and
+// delete while the filtered ones are served as they are until expire.
+type recordCounts struct {
+	mux         sync.Mutex
+	collections map[string]map[string]*cachedCount
+}
+
+func newRecordCounts() *recordCounts {
+	return &recordCounts{
+		collections: map[string]map[string]*cachedCount{},
+	}
+}
+
+// countFunc returns a [search.CountFunc] that serves the cached
+// count of the provided collection count query (if any) and
+// otherwise executes and caches it.
+func (rc *recordCounts) countFunc(collectionId string) search.CountFunc {
+	return func(countQuery *dbx.SelectQuery) (int64, error) {
+		built := countQuery.Build()
+		key := countQueryKey(built)
+
+		if total, ok := rc.get(collectionId, key); ok {
+			return total, nil
+		}
+
+		var total int64
+		if err := built.Row(&total); err != nil {
+			return 0, err
+		}
+
+		info := countQuery.Info()
+
+		rc.set(collectionId, key, &cachedCount{
+			total:      total,
+			expires:    time.Now().Add(recordCountsTTL),
+			unfiltered: info.Where == nil && len(info.Join) == 0,
+		})
+
+		return total, nil
+	}
+}
+
+// countQueryKey returns the query SQL with its params inlined
+// (the filter params names are random and differ on each request).
+func countQueryKey(q *dbx.Query) string {
+	key := q.SQL()
+
+	for name, value := range q.Params() {
+		key = strings.ReplaceAll(key, "{:"+name+"}", fmt.Sprintf("%#v", value))
+	}
+
+	return key
+}
+
+func (rc *recordCounts) get(collectionId string, key string) (int64, bool) {
+	rc.mux.Lock()
+	defer rc.mux.Unlock()
+
+	entry := rc.collections[collectionId][key]
+	if entry == nil {
+		return 0, false
+	}
+
+	if !time.Now().Before(entry.expires) {
+		delete(rc.collections[collectionId], key)
+		return 0, false
+	}
+
+	return entry.total, true
+}
+
+func (rc *recordCounts) set(collectionId string, key string, entry *cachedCount) {
+	rc.mux.Lock()
+	defer rc.mux.Unlock()
+
+	entries := rc.collections[collectionId]
+	if entries == nil {
+		entries = map[string]*cachedCount{}
+		rc.collections[collectionId] = entries
+	}
+
+	if _, ok := entries[key]; !ok && len(entries) >= recordCountsMaxEntries {
+		now := time.Now()
+		for k, v := range entries {
+			if !now.Before(v.expires) {
+				delete(entries, k)
+			}
+		}
+
+		if len(entries) >= recordCountsMaxEntries {
+			return
+		}
+	}
+
+	entries[key] = entry
+}
+
+// adjust changes the unfiltered counts of the specified collection with delta.
+func (rc *recordCounts) adjust(collectionId string, delta int64) {
+	rc.mux.Lock()
+	defer rc.mux.Unlock()
+
+	for _, entry := range rc.collections[collectionId] {
+		if entry.unfiltered {
+			entry.total += delta
+			if entry.total < 0 {
+				entry.total = 0
+			}
+		}
+	}
+}
+
+// invalidate deletes all cached counts of the specified collection.
+func (rc *recordCounts) invalidate(collectionId string) {
+	rc.mux.Lock()
+	defer rc.mux.Unlock()
+
+	delete(rc.collections, collectionId)
+}
+
+// bindRecordCountsHooks registers the app model hooks that
+// maintain the cached counts of the changed collections.
+func bindRecordCountsHooks(app core.App, counts *recordCounts) {
+	adjustHandler := func(delta int64) func(e *core.ModelEvent) error {
+		return func(e *core.ModelEvent) error {
+			switch m := e.Model.(type) {
+			case *models.Record:
+				if collection := m.Collection(); collection != nil {
+					counts.adjust(collection.Id, delta)
+				}
+			case *models.Collection:
+				counts.invalidate(m.Id)
+			}
+
+			return nil
+		}
+	}
+
+	app.OnModelAfterCreate().Add(adjustHandler(1))
+	app.OnModelAfterDelete().Add(adjustHandler(-1))
+	app.OnModelAfterUpdate().Add(func(e *core.ModelEvent) error {
+		// the collection schema or rules could have changed
+		if collection, ok := e.Model.(*models.Collection); ok {
+			counts.invalidate(collection.Id)
+		}
+
+		return nil
+	})
+}
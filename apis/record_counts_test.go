@@ -0,0 +1,130 @@
+package apis_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestRecordsListTotal(t *testing.T) {
+	// warmUp sends a records list request to url so that its count could be cached
+	warmUp := func(t *testing.T, e *echo.Echo, url string) {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected warm up status 200, got %d", rec.Code)
+		}
+	}
+
+	filter := url.QueryEscape("title~'test'")
+
+	scenarios := []tests.ApiScenario{
+		{
+			Name:            "invalid skipTotal",
+			Method:          http.MethodGet,
+			Url:             "/api/collections/demo2/records?skipTotal=invalid",
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:           "skipTotal",
+			Method:         http.MethodGet,
+			Url:            "/api/collections/demo2/records?skipTotal=true&perPage=1&page=2",
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"page":2`,
+				`"perPage":1`,
+				`"totalItems":-1`,
+				`"totalPages":-1`,
+				`"items":[{`,
+			},
+			ExpectedEvents: map[string]int{"OnRecordsListRequest": 1},
+		},
+		{
+			Name:   "approxTotal with unfiltered count adjusted on create",
+			Method: http.MethodGet,
+			Url:    "/api/collections/demo2/records?approxTotal=true",
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				warmUp(t, e, "/api/collections/demo2/records?approxTotal=true")
+
+				collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+				if err != nil {
+					t.Fatal(err)
+				}
+				record := models.NewRecord(collection)
+				record.Set("title", "new")
+				if err := app.Dao().SaveRecord(record); err != nil {
+					t.Fatal(err)
+				}
+
+				app.ResetEventCalls()
+			},
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"totalItems":4`,
+				`"totalPages":1`,
+			},
+			ExpectedEvents: map[string]int{"OnRecordsListRequest": 1},
+		},
+		{
+			Name:   "approxTotal with cached filtered count",
+			Method: http.MethodGet,
+			Url:    "/api/collections/demo2/records?approxTotal=true&filter=" + filter,
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				warmUp(t, e, "/api/collections/demo2/records?approxTotal=true&filter="+filter)
+
+				record, err := app.Dao().FindRecordById("demo2", "llvuca81nly1qls")
+				if err != nil {
+					t.Fatal(err)
+				}
+				record.Set("title", "changed")
+				if err := app.Dao().SaveRecord(record); err != nil {
+					t.Fatal(err)
+				}
+
+				app.ResetEventCalls()
+			},
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"totalItems":3`, // stale
+			},
+			NotExpectedContent: []string{
+				`"id":"llvuca81nly1qls"`,
+			},
+			ExpectedEvents: map[string]int{"OnRecordsListRequest": 1},
+		},
+		{
+			Name:   "exact filtered count without approxTotal",
+			Method: http.MethodGet,
+			Url:    "/api/collections/demo2/records?filter=" + filter,
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				warmUp(t, e, "/api/collections/demo2/records?approxTotal=true&filter="+filter)
+
+				record, err := app.Dao().FindRecordById("demo2", "llvuca81nly1qls")
+				if err != nil {
+					t.Fatal(err)
+				}
+				record.Set("title", "changed")
+				if err := app.Dao().SaveRecord(record); err != nil {
+					t.Fatal(err)
+				}
+
+				app.ResetEventCalls()
+			},
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"totalItems":2`,
+			},
+			ExpectedEvents: map[string]int{"OnRecordsListRequest": 1},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
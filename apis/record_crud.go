@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -20,25 +21,72 @@ import (
 
 const expandQueryParam = "expand"
 
+const (
+	defaultRecordsSampleSize int = 100
+	maxRecordsSampleSize     int = 1000
+
+	recordsSampleStrategyRandom     = "random"
+	recordsSampleStrategyStratified = "stratified"
+)
+
 // bindRecordCrudApi registers the record crud api endpoints and
 // the corresponding handlers.
 func bindRecordCrudApi(app core.App, rg *echo.Group) {
-	api := recordApi{app: app}
+	api := recordApi{app: app, counts: newRecordCounts()}
+	bindRecordCountsHooks(app, api.counts)
+
+	cache := newRecordsCache()
+	bindRecordsCacheHooks(app, cache)
+
+	listRule := func(collection *models.Collection) *string { return collection.ListRule }
+	viewRule := func(collection *models.Collection) *string { return collection.ViewRule }
 
 	subGroup := rg.Group(
 		"/collections/:collection",
 		ActivityLogger(app),
 	)
 
-	subGroup.GET("/records", api.list, LoadCollectionContext(app))
-	subGroup.GET("/records/:id", api.view, LoadCollectionContext(app))
-	subGroup.POST("/records", api.create, LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth))
+	subGroup.GET("/records", api.list, LoadCollectionContext(app), cacheRecords(app, cache, listRule))
+	subGroup.GET("/records/sample", api.sample, LoadCollectionContext(app))
+	subGroup.GET("/trash", api.trash, LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth))
+	subGroup.POST("/records/:id/restore", api.restore, LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth))
+	subGroup.GET("/records/:id", api.view, LoadCollectionContext(app), cacheRecords(app, cache, viewRule))
+	subGroup.POST("/records", api.create, LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth), idempotent(app, newIdempotencyStore()))
+	subGroup.POST("/records/import", api.importRecords, RequireAdminAuth(), LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth))
 	subGroup.PATCH("/records/:id", api.update, LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth))
 	subGroup.DELETE("/records/:id", api.delete, LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth))
+	subGroup.GET("/records/:id/revisions", api.listRevisions, LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth))
+	subGroup.POST("/records/:id/revisions/:rev/revert", api.revertRevision, LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth))
+	subGroup.POST("/records/:id/increment", api.increment, LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth))
+	subGroup.GET("/records/:id/lock", api.viewLock, LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth))
+	subGroup.POST("/records/:id/lock", api.lock, RequireAdminOrRecordAuth(), LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth))
+	subGroup.DELETE("/records/:id/lock", api.unlock, RequireAdminOrRecordAuth(), LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth))
+	subGroup.GET("/records/:id/share", api.listShares, RequireAdminOrRecordAuth(), LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth))
+	subGroup.POST("/records/:id/share", api.share, RequireAdminOrRecordAuth(), LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth))
+	subGroup.DELETE("/records/:id/share/:shareId", api.unshare, RequireAdminOrRecordAuth(), LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth))
+	subGroup.GET("/queries", api.listSavedQueries, LoadCollectionContext(app))
+	subGroup.POST("/queries", api.createSavedQuery, RequireAdminOrRecordAuth(), LoadCollectionContext(app))
+	subGroup.GET("/queries/:name", api.viewSavedQuery, LoadCollectionContext(app))
+	subGroup.PATCH("/queries/:name", api.updateSavedQuery, RequireAdminOrRecordAuth(), LoadCollectionContext(app))
+	subGroup.DELETE("/queries/:name", api.deleteSavedQuery, RequireAdminOrRecordAuth(), LoadCollectionContext(app))
+	subGroup.GET("/queries/:name/run", api.runSavedQuery, LoadCollectionContext(app))
+	subGroup.POST("/rules/test", api.testRules, RequireAdminAuth(), LoadCollectionContext(app))
+	subGroup.GET("/translations", api.translationStatus, RequireAdminAuth(), LoadCollectionContext(app))
 }
 
 type recordApi struct {
-	app core.App
+	app    core.App
+	counts *recordCounts
+}
+
+// swagger:models RecordsSampleResult
+type RecordsSampleResult struct {
+	Strategy string `json:"strategy"`
+
+	// TotalItems is the number of records matching the access rule and
+	// the filter, aka. the size of the sampled population.
+	TotalItems int              `json:"totalItems"`
+	Items      []*models.Record `json:"items"`
 }
 
 // swagger:models CreateRecordRequest
@@ -92,14 +140,30 @@ type CreateRecordRequest struct {
 }
 
 //	@Summary		Получение списка записей
-//	@Description	Возвращает список записей из указанной коллекции
+//	@Description	Возвращает постраничный список записей из указанной коллекции с учетом правила доступа к списку
 //	@Tags			Record
 //	@Security		Auth
 //	@Accept			json
 //	@Produce		json
-//	@Param			collection	path	string	true	"Идентификатор коллекции"
-//	@Success		200			"Получение списка записей успешно"
-//	@Failure		400			{string}	string	"Failed to authenticate."
+//	@Param			collection	path		string	true	"Идентификатор коллекции"
+//	@Param			page		query		int		false	"Номер страницы (по умолчанию 1)"
+//	@Param			perPage		query		int		false	"Количество записей на странице (по умолчанию 30)"
+//	@Param			sort		query		string	false	"Сортировка (например -created,id)"
+//	@Param			filter		query		string	false	"Фильтр записей"
+//	@Param			skipTotal	query		bool	false	"Не подсчитывать общее количество записей (totalItems и totalPages будут равны -1)"
+//	@Param			approxTotal	query		bool	false	"Использовать кэшированное (приблизительное) общее количество записей"
+//	@Param			search		query		string	false	"Полнотекстовый поиск по индексируемым полям коллекции (по умолчанию результаты сортируются по релевантности)"
+//	@Param			near		query		string	false	"Поиск ближайших записей в формате {geoPointField}:{lat},{lng} (по умолчанию результаты сортируются по расстоянию)"
+//	@Param			within		query		number	false	"Максимальное расстояние в метрах от точки near"
+//	@Param			expand		query		string	false	"Связи для раскрытия (через запятую)"
+//	@Param			explainRules	query		bool	false	"Вернуть объяснение правила доступа вместо выполнения действия (только для админов)"
+//	@Param			explainAuth		query		string	false	"Запись авторизации для объяснения правила в формате {collection}:{id}"
+//	@Param			locale		query		string	false	"Язык локализованных полей (например de-AT, с учетом цепочки резервных языков)"
+//	@Success		200			{object}	SearchResult{items=[]models.Record}
+//	@Header			200			{string}	Cache-Control	"Подсказки кэширования (если для коллекции заданы настройки кэширования)"
+//	@Header			200			{string}	X-Cache			"HIT или MISS (при включенном серверном кэше для гостевых запросов)"
+//	@Failure		400			{string}	string	"Invalid filter parameters."
+//	@Failure		403			{string}	string	"Only admins can perform this action."
 //	@Failure		404			{string}	string	"Not found."
 //	@Router			/collections/{collection}/records [get]
 func (api *recordApi) list(c echo.Context) error {
@@ -113,13 +177,28 @@ func (api *recordApi) list(c echo.Context) error {
 		return err
 	}
 
+	return api.listRecords(c, collection)
+}
+
+// listRecords responds with the paginated records list of the provided
+// collection based on the current request query parameters.
+func (api *recordApi) listRecords(c echo.Context, collection *models.Collection) error {
 	requestData := RequestData(c)
 
+	if isExplainRulesRequest(c) {
+		return api.explainRules(c, collection, RuleActionList, collection.ListRule, "")
+	}
+
 	if requestData.Admin == nil && collection.ListRule == nil {
 		// only admins can access if the rule is nil
 		return NewForbiddenError("Only admins can perform this action.", nil)
 	}
 
+	localeChain, err := api.localeChain(c)
+	if err != nil {
+		return err
+	}
+
 	fieldsResolver := resolvers.NewRecordFieldResolver(
 		api.app.Dao(),
 		collection,
@@ -128,13 +207,21 @@ func (api *recordApi) list(c echo.Context) error {
 		requestData.Admin != nil,
 	)
 
-	searchProvider := search.NewProvider(fieldsResolver).
-		Query(api.app.Dao().RecordQuery(collection))
+	query, err := api.recordsListQuery(c, collection)
+	if err != nil {
+		return err
+	}
+
+	searchProvider := search.NewProvider(fieldsResolver).Query(query)
 
 	if requestData.Admin == nil && collection.ListRule != nil {
 		searchProvider.AddFilter(search.FilterData(*collection.ListRule))
 	}
 
+	if approxTotal, _ := strconv.ParseBool(c.QueryParam(ApproxTotalQueryParam)); approxTotal && api.counts != nil {
+		searchProvider.CountFunc(api.counts.countFunc(collection.Id))
+	}
+
 	records := []*models.Record{}
 
 	result, err := searchProvider.ParseAndExec(c.QueryParams().Encode(), &records)
@@ -144,6 +231,7 @@ func (api *recordApi) list(c echo.Context) error {
 
 	event := new(core.RecordsListEvent)
 	event.HttpContext = c
+	event.RequestInfo = RequestInfo(c)
 	event.Collection = collection
 	event.Records = records
 	event.Result = result
@@ -153,20 +241,137 @@ func (api *recordApi) list(c echo.Context) error {
 			log.Println(err)
 		}
 
+		localizeRecords(e.Records, localeChain)
+
 		return e.HttpContext.JSON(http.StatusOK, e.Result)
 	})
 }
 
+//	@Summary		Выборка записей
+//	@Description	Возвращает случайную (или стратифицированную по значению поля) выборку записей из указанной коллекции с учетом правила доступа к списку
+//	@Tags			Record
+//	@Security		Auth
+//	@Produce		json
+//	@Param			collection	path		string	true	"Идентификатор коллекции"
+//	@Param			n			query		int		false	"Размер выборки (по умолчанию 100, максимум 1000)"
+//	@Param			strategy	query		string	false	"Стратегия выборки: random или stratified:{field}"
+//	@Param			filter		query		string	false	"Фильтр записей"
+//	@Success		200			{object}	RecordsSampleResult
+//	@Failure		400			{string}	string	"Invalid sampling strategy."
+//	@Failure		403			{string}	string	"Only admins can perform this action."
+//	@Failure		404			{string}	string	"Not found."
+//	@Router			/collections/{collection}/records/sample [get]
+func (api *recordApi) sample(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("", "Missing collection context.")
+	}
+
+	// forbid users and guests to query special filter fields
+	if err := api.checkForForbiddenQueryFields(c); err != nil {
+		return err
+	}
+
+	requestData := RequestData(c)
+
+	if requestData.Admin == nil && collection.ListRule == nil {
+		// only admins can access if the rule is nil
+		return NewForbiddenError("Only admins can perform this action.", nil)
+	}
+
+	n, _ := strconv.Atoi(c.QueryParam("n"))
+	if n <= 0 {
+		n = defaultRecordsSampleSize
+	} else if n > maxRecordsSampleSize {
+		n = maxRecordsSampleSize
+	}
+
+	var stratifyField string
+	strategy := c.QueryParam("strategy")
+	switch {
+	case strategy == "" || strategy == recordsSampleStrategyRandom:
+		strategy = recordsSampleStrategyRandom
+	case strings.HasPrefix(strategy, recordsSampleStrategyStratified+":"):
+		stratifyField = strings.TrimPrefix(strategy, recordsSampleStrategyStratified+":")
+		if collection.Schema.GetFieldByName(stratifyField) == nil {
+			return NewBadRequestError("Invalid sampling strategy.", nil)
+		}
+	default:
+		return NewBadRequestError("Invalid sampling strategy.", nil)
+	}
+
+	filter := c.QueryParam(search.FilterQueryParam)
+
+	ruleFunc := func(q *dbx.SelectQuery) error {
+		resolver := resolvers.NewRecordFieldResolver(
+			api.app.Dao(),
+			collection,
+			requestData,
+			// hidden fields are searchable only by admins
+			requestData.Admin != nil,
+		)
+
+		exprs := []dbx.Expression{}
+
+		if requestData.Admin == nil && collection.ListRule != nil && *collection.ListRule != "" {
+			expr, err := search.FilterData(*collection.ListRule).BuildExpr(resolver)
+			if err != nil {
+				return err
+			}
+			exprs = append(exprs, expr)
+		}
+
+		if filter != "" {
+			expr, err := search.FilterData(filter).BuildExpr(resolver)
+			if err != nil {
+				return err
+			}
+			exprs = append(exprs, expr)
+		}
+
+		resolver.UpdateQuery(q)
+
+		for _, expr := range exprs {
+			q.AndWhere(expr)
+		}
+
+		return nil
+	}
+
+	records, total, err := api.app.Dao().SampleRecords(collection, n, stratifyField, ruleFunc)
+	if err != nil {
+		return NewBadRequestError("Failed to sample the collection records.", err)
+	}
+
+	if err := EnrichRecords(c, api.app.Dao(), records); err != nil && api.app.IsDebug() {
+		log.Println(err)
+	}
+
+	return c.JSON(http.StatusOK, &RecordsSampleResult{
+		Strategy:   strategy,
+		TotalItems: total,
+		Items:      records,
+	})
+}
+
 //	@Summary		Просмотр записи
 //	@Description	Возвращает информацию о указанной записи из указанной коллекции
 //	@Tags			Record
 //	@Security		Auth
 //	@Accept			json
 //	@Produce		json
-//	@Param			collection	path	string	true	"Идентификатор коллекции"
-//	@Param			id			path	string	true	"Идентификатор записи"
-//	@Success		200			"Просмотр записи успешен"
-//	@Failure		400			{string}	string	"Failed to authenticate."
+//	@Param			collection	path		string	true	"Идентификатор коллекции"
+//	@Param			id			path		string	true	"Идентификатор записи"
+//	@Param			expand		query		string	false	"Связи для раскрытия (через запятую)"
+//	@Param			explainRules	query		bool	false	"Вернуть объяснение правила доступа вместо выполнения действия (только для админов)"
+//	@Param			explainAuth		query		string	false	"Запись авторизации для объяснения правила в формате {collection}:{id}"
+//	@Param			locale		query		string	false	"Язык локализованных полей (например de-AT, с учетом цепочки резервных языков)"
+//	@Success		200			{object}	models.Record
+//	@Header			200			{string}	ETag	"Версия записи (для заголовка If-Match)"
+//	@Header			200			{string}	Cache-Control	"Подсказки кэширования (если для коллекции заданы настройки кэширования)"
+//	@Header			200			{string}	X-Cache			"HIT или MISS (при включенном серверном кэше для гостевых запросов)"
+//	@Failure		400			{string}	string	"Invalid locale."
+//	@Failure		403			{string}	string	"Only admins can perform this action."
 //	@Failure		404			{string}	string	"Not found."
 //	@Router			/collections/{collection}/records/{id} [get]
 func (api *recordApi) view(c echo.Context) error {
@@ -182,11 +387,20 @@ func (api *recordApi) view(c echo.Context) error {
 
 	requestData := RequestData(c)
 
+	if isExplainRulesRequest(c) {
+		return api.explainRules(c, collection, RuleActionView, collection.ViewRule, recordId)
+	}
+
 	if requestData.Admin == nil && collection.ViewRule == nil {
 		// only admins can access if the rule is nil
 		return NewForbiddenError("Only admins can perform this action.", nil)
 	}
 
+	localeChain, err := api.localeChain(c)
+	if err != nil {
+		return err
+	}
+
 	ruleFunc := func(q *dbx.SelectQuery) error {
 		if requestData.Admin == nil && collection.ViewRule != nil && *collection.ViewRule != "" {
 			resolver := resolvers.NewRecordFieldResolver(api.app.Dao(), collection, requestData, true)
@@ -207,6 +421,7 @@ func (api *recordApi) view(c echo.Context) error {
 
 	event := new(core.RecordViewEvent)
 	event.HttpContext = c
+	event.RequestInfo = RequestInfo(c)
 	event.Collection = collection
 	event.Record = record
 
@@ -215,6 +430,10 @@ func (api *recordApi) view(c echo.Context) error {
 			log.Println(err)
 		}
 
+		localizeRecords([]*models.Record{e.Record}, localeChain)
+
+		setETag(e.HttpContext, e.Record)
+
 		return e.HttpContext.JSON(http.StatusOK, e.Record)
 	})
 }
@@ -223,12 +442,18 @@ func (api *recordApi) view(c echo.Context) error {
 //	@Description	Создает новую запись в указанной коллекции
 //	@Tags			Record
 //	@Security		Auth
-//	@Accept			json
+//	@Accept			json,mpfd
 //	@Produce		json
-//	@Param			collection	path	string				true	"Идентификатор коллекции"
-//	@Param			body		body	CreateRecordRequest	true	"Данные для создания записи"
-//	@Success		201			"Создание записи успешно"
-//	@Failure		400			{string}	string	"Failed to authenticate."
+//	@Param			collection	path		string				true	"Идентификатор коллекции"
+//	@Param			body		body		CreateRecordRequest	true	"Данные для создания записи"
+//	@Param			expand		query		string				false	"Связи для раскрытия (через запятую)"
+//	@Param			Idempotency-Key	header	string				false	"Ключ идемпотентности для безопасного повтора запроса"
+//	@Success		200			{object}	models.Record
+//	@Header			200			{string}	Idempotent-Replayed	"true, если ответ является повтором сохраненного ответа"
+//	@Failure		400			{string}	string	"Failed to create record."
+//	@Failure		409			{string}	string	"A request with the same Idempotency-Key is still in progress."
+//	@Failure		422			{string}	string	"The Idempotency-Key was already used with a different request."
+//	@Failure		403			{string}	string	"Only admins can perform this action."
 //	@Failure		404			{string}	string	"Not found."
 //	@Router			/collections/{collection}/records [post]
 func (api *recordApi) create(c echo.Context) error {
@@ -302,6 +527,7 @@ func (api *recordApi) create(c echo.Context) error {
 
 	event := new(core.RecordCreateEvent)
 	event.HttpContext = c
+	event.RequestInfo = RequestInfo(c)
 	event.Collection = collection
 	event.Record = record
 	event.UploadedFiles = form.FilesToUpload()
@@ -338,14 +564,21 @@ func (api *recordApi) create(c echo.Context) error {
 //	@Description	Обновляет информацию о указанной записи в указанной коллекции
 //	@Tags			Record
 //	@Security		Auth
-//	@Accept			json
+//	@Accept			json,mpfd
 //	@Produce		json
-//	@Param			collection	path	string				true	"Идентификатор коллекции"
-//	@Param			id			path	string				true	"Идентификатор записи"
-//	@Param			body		body	CreateRecordRequest	true	"Данные для обновления записи"
-//	@Success		200			"Обновление записи успешно"
-//	@Failure		400			{string}	string	"Failed to authenticate."
+//	@Param			collection	path		string				true	"Идентификатор коллекции"
+//	@Param			id			path		string				true	"Идентификатор записи"
+//	@Param			body		body		CreateRecordRequest	true	"Данные для обновления записи"
+//	@Param			expand		query		string				false	"Связи для раскрытия (через запятую)"
+//	@Param			explainRules	query		bool	false	"Вернуть объяснение правила доступа вместо выполнения действия (только для админов)"
+//	@Param			explainAuth		query		string	false	"Запись авторизации для объяснения правила в формате {collection}:{id}"
+//	@Param			If-Match	header		string	false	"ETag просмотренной версии (обновление отклоняется, если запись была изменена)"
+//	@Success		200			{object}	models.Record
+//	@Header			200			{string}	ETag	"Новая версия записи"
+//	@Failure		400			{string}	string	"Failed to update record."
+//	@Failure		403			{string}	string	"Only admins can perform this action."
 //	@Failure		404			{string}	string	"Not found."
+//	@Failure		409			{string}	string	"The resource was modified by another request."
 //	@Router			/collections/{collection}/records/{id} [patch]
 func (api *recordApi) update(c echo.Context) error {
 	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
@@ -375,6 +608,10 @@ func (api *recordApi) update(c echo.Context) error {
 		requestData.Data = record.ReplaceModifers(requestData.Data)
 	}
 
+	if isExplainRulesRequest(c) {
+		return api.explainRules(c, collection, RuleActionUpdate, collection.UpdateRule, recordId)
+	}
+
 	ruleFunc := func(q *dbx.SelectQuery) error {
 		if requestData.Admin == nil && collection.UpdateRule != nil && *collection.UpdateRule != "" {
 			resolver := resolvers.NewRecordFieldResolver(api.app.Dao(), collection, requestData, true)
@@ -404,6 +641,7 @@ func (api *recordApi) update(c echo.Context) error {
 
 	event := new(core.RecordUpdateEvent)
 	event.HttpContext = c
+	event.RequestInfo = RequestInfo(c)
 	event.Collection = collection
 	event.Record = record
 	event.UploadedFiles = form.FilesToUpload()
@@ -414,14 +652,21 @@ func (api *recordApi) update(c echo.Context) error {
 			event.Record = m
 
 			return api.app.OnRecordBeforeUpdateRequest().Trigger(event, func(e *core.RecordUpdateEvent) error {
-				if err := next(e.Record); err != nil {
-					return NewBadRequestError("Failed to update record.", err)
+				// the If-Match check and the record save share the same transaction
+				if err := submitIfMatch(e.HttpContext, api.app.Dao(), form, func(txDao *daos.Dao) (models.Model, error) {
+					return txDao.FindRecordById(collection.Id, record.Id)
+				}, func() error {
+					return next(e.Record)
+				}); err != nil {
+					return newETagMismatchError(err, "Failed to update record.")
 				}
 
 				if err := EnrichRecord(e.HttpContext, api.app.Dao(), e.Record); err != nil && api.app.IsDebug() {
 					log.Println(err)
 				}
 
+				setETag(e.HttpContext, e.Record)
+
 				return e.HttpContext.JSON(http.StatusOK, e.Record)
 			})
 		}
@@ -437,15 +682,18 @@ func (api *recordApi) update(c echo.Context) error {
 }
 
 //	@Summary		Удаление записи
-//	@Description	Удаляет указанную запись из указанной коллекции
+//	@Description	Удаляет указанную запись из указанной коллекции (или перемещает ее в корзину, если для коллекции включено мягкое удаление)
 //	@Tags			Record
 //	@Security		Auth
 //	@Accept			json
 //	@Produce		json
 //	@Param			collection	path	string	true	"Идентификатор коллекции"
 //	@Param			id			path	string	true	"Идентификатор записи"
-//	@Success		200			"Удаление записи успешно"
-//	@Failure		400			{string}	string	"Failed to authenticate."
+//	@Param			explainRules	query		bool	false	"Вернуть объяснение правила доступа вместо выполнения действия (только для админов)"
+//	@Param			explainAuth		query		string	false	"Запись авторизации для объяснения правила в формате {collection}:{id}"
+//	@Success		204			"No Content"
+//	@Failure		400			{string}	string	"Failed to delete record. Make sure that the record is not part of a required relation reference."
+//	@Failure		403			{string}	string	"Only admins can perform this action."
 //	@Failure		404			{string}	string	"Not found."
 //	@Router			/collections/{collection}/records/{id} [delete]
 func (api *recordApi) delete(c echo.Context) error {
@@ -461,6 +709,10 @@ func (api *recordApi) delete(c echo.Context) error {
 
 	requestData := RequestData(c)
 
+	if isExplainRulesRequest(c) {
+		return api.explainRules(c, collection, RuleActionDelete, collection.DeleteRule, recordId)
+	}
+
 	if requestData.Admin == nil && collection.DeleteRule == nil {
 		// only admins can access if the rule is nil
 		return NewForbiddenError("Only admins can perform this action.", nil)
@@ -486,12 +738,13 @@ func (api *recordApi) delete(c echo.Context) error {
 
 	event := new(core.RecordDeleteEvent)
 	event.HttpContext = c
+	event.RequestInfo = RequestInfo(c)
 	event.Collection = collection
 	event.Record = record
 
 	handlerErr := api.app.OnRecordBeforeDeleteRequest().Trigger(event, func(e *core.RecordDeleteEvent) error {
 		// delete the record
-		if err := api.app.Dao().DeleteRecord(e.Record); err != nil {
+		if err := deleteOrTrashRecord(api.app.Dao(), e.Record); err != nil {
 			return NewBadRequestError("Failed to delete record. Make sure that the record is not part of a required relation reference.", err)
 		}
 
@@ -0,0 +1,85 @@
+package apis
+
+import (
+	"sync"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+const contextExpandCacheKey = "expandCache"
+
+// expandCache is a per request store of the fetched expand relation
+// records grouped by collection id (nil entries are for the ids that
+// were not found or didn't satisfy the collection view rule).
+type expandCache struct {
+	mux         sync.Mutex
+	dao         *daos.Dao
+	collections map[string]map[string]*models.Record
+}
+
+// requestExpandCache returns the expand cache of the current request
+// (a new one is created if missing or if it was created for a different dao).
+func requestExpandCache(c echo.Context, dao *daos.Dao) *expandCache {
+	if cache, ok := c.Get(contextExpandCacheKey).(*expandCache); ok && cache.dao == dao {
+		return cache
+	}
+
+	cache := &expandCache{
+		dao:         dao,
+		collections: map[string]map[string]*models.Record{},
+	}
+
+	c.Set(contextExpandCacheKey, cache)
+
+	return cache
+}
+
+// load returns a copy of the cached records of the provided ids
+// together with the ids that are not cached yet.
+func (ec *expandCache) load(collectionId string, ids []string) ([]*models.Record, []string) {
+	ec.mux.Lock()
+	defer ec.mux.Unlock()
+
+	cached := ec.collections[collectionId]
+
+	records := make([]*models.Record, 0, len(ids))
+	missing := make([]string, 0, len(ids))
+
+	for _, id := range ids {
+		record, ok := cached[id]
+		if !ok {
+			missing = append(missing, id)
+			continue
+		}
+
+		// copy to prevent sharing the expand state between the different expand paths
+		if record != nil {
+			records = append(records, record.CleanCopy())
+		}
+	}
+
+	return records, missing
+}
+
+// store caches the provided fetched records and marks the rest of
+// the requested ids as not found.
+func (ec *expandCache) store(collectionId string, requestedIds []string, fetched []*models.Record) {
+	ec.mux.Lock()
+	defer ec.mux.Unlock()
+
+	cached := ec.collections[collectionId]
+	if cached == nil {
+		cached = map[string]*models.Record{}
+		ec.collections[collectionId] = cached
+	}
+
+	for _, id := range requestedIds {
+		cached[id] = nil
+	}
+
+	for _, record := range fetched {
+		cached[record.Id] = record.CleanCopy()
+	}
+}
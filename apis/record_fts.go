@@ -0,0 +1,47 @@
+package apis
+
+import (
+	"strings"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+// FullTextSearchQueryParam is the records list query parameter with
+// the full text search terms (see [models.Collection.SearchableFields]).
+const FullTextSearchQueryParam = "search"
+
+// recordsListQuery returns the base records list query of the provided
+// collection, limited to the full text search matches if the search
+// query parameter is set and to the located records if the near
+// query parameter is set (see [applyGeoNear]).
+//
+// The matches are ordered by relevance (or by distance for the near
+// searches) unless the sort query parameter is set.
+func (api *recordApi) recordsListQuery(c echo.Context, collection *models.Collection) (*dbx.SelectQuery, error) {
+	query := api.app.Dao().RecordQuery(collection)
+
+	if terms := strings.TrimSpace(c.QueryParam(FullTextSearchQueryParam)); terms != "" {
+		if len(collection.SearchableFields()) == 0 {
+			return nil, NewBadRequestError("The collection doesn't have searchable fields.", nil)
+		}
+
+		var err error
+		query, err = api.app.Dao().SearchRecordsQuery(collection, terms)
+		if err != nil {
+			return nil, NewBadRequestError("Invalid search parameter.", err)
+		}
+	}
+
+	if err := api.applyGeoNear(c, collection, query); err != nil {
+		return nil, err
+	}
+
+	if c.QueryParam(search.SortQueryParam) != "" {
+		query.OrderBy() // reset the relevance/distance order
+	}
+
+	return query, nil
+}
@@ -0,0 +1,98 @@
+package apis_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+// setupTestFTS enables the full text search of the demo2 collection
+// (see [tests.SkipWithoutFTS5]).
+func setupTestFTS(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+	tests.SkipWithoutFTS5(t, app)
+
+	collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	collection.Options["searchableFields"] = []string{"title"}
+
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	app.ResetEventCalls()
+}
+
+func TestRecordCrudListSearch(t *testing.T) {
+	scenarios := []tests.ApiScenario{
+		{
+			Name:            "collection without searchable fields",
+			Method:          http.MethodGet,
+			Url:             "/api/collections/demo2/records?search=test1",
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:           "blank search",
+			Method:         http.MethodGet,
+			Url:            "/api/collections/demo2/records?search=%20",
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"totalItems":3`,
+			},
+			ExpectedEvents: map[string]int{"OnRecordsListRequest": 1},
+		},
+		{
+			Name:           "single match",
+			Method:         http.MethodGet,
+			Url:            "/api/collections/demo2/records?search=test1",
+			BeforeTestFunc: setupTestFTS,
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"totalItems":1`,
+				`"id":"llvuca81nly1qls"`,
+			},
+			NotExpectedContent: []string{
+				`"id":"achvryl401bhse3"`,
+				`"id":"0yxhwia2amd8gec"`,
+			},
+			ExpectedEvents: map[string]int{"OnRecordsListRequest": 1},
+		},
+		{
+			Name:           "prefix match combined with filter and sort",
+			Method:         http.MethodGet,
+			Url:            "/api/collections/demo2/records?search=tes&filter=title!='test2'&sort=-title",
+			BeforeTestFunc: setupTestFTS,
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"totalItems":2`,
+				`"id":"0yxhwia2amd8gec","title":"test3","updated":"2022-10-14 10:52:49.596Z"},{`,
+				`"id":"llvuca81nly1qls"`,
+			},
+			NotExpectedContent: []string{
+				`"id":"achvryl401bhse3"`,
+			},
+			ExpectedEvents: map[string]int{"OnRecordsListRequest": 1},
+		},
+		{
+			Name:           "no matches",
+			Method:         http.MethodGet,
+			Url:            "/api/collections/demo2/records?search=lorem",
+			BeforeTestFunc: setupTestFTS,
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"totalItems":0`,
+				`"items":[]`,
+			},
+			ExpectedEvents: map[string]int{"OnRecordsListRequest": 1},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
@@ -0,0 +1,63 @@
+package apis
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// Records list geo query parameters.
+const (
+	// GeoNearQueryParam is the records list query parameter with the
+	// geoPoint field and the center location in "field:lat,lng" format.
+	GeoNearQueryParam = "near"
+
+	// GeoWithinQueryParam is the records list query parameter with the
+	// max distance (in meters) from the near center location.
+	GeoWithinQueryParam = "within"
+)
+
+// applyGeoNear limits the provided records list query to the records
+// located in the near query parameter geoPoint field, ordered by their
+// distance to its center location (and within the within query
+// parameter radius if set).
+func (api *recordApi) applyGeoNear(c echo.Context, collection *models.Collection, query *dbx.SelectQuery) error {
+	near := strings.TrimSpace(c.QueryParam(GeoNearQueryParam))
+	within := strings.TrimSpace(c.QueryParam(GeoWithinQueryParam))
+
+	if near == "" {
+		if within != "" {
+			return NewBadRequestError("The within parameter requires the near parameter to be set.", nil)
+		}
+		return nil
+	}
+
+	fieldName, rawCenter, ok := strings.Cut(near, ":")
+	if !ok {
+		return NewBadRequestError("Invalid near parameter (expected field:lat,lng).", nil)
+	}
+
+	center, err := types.ParseGeoPoint(rawCenter)
+	if err != nil {
+		return NewBadRequestError("Invalid near parameter (expected field:lat,lng).", err)
+	}
+
+	var radius float64
+	if within != "" {
+		radius, err = strconv.ParseFloat(within, 64)
+		if err != nil || !(radius > 0) || math.IsInf(radius, 1) {
+			return NewBadRequestError("The within parameter must be a positive distance in meters.", err)
+		}
+	}
+
+	if err := api.app.Dao().ApplyGeoNear(query, collection, strings.TrimSpace(fieldName), center, radius); err != nil {
+		return NewBadRequestError("Invalid near parameter.", err)
+	}
+
+	return nil
+}
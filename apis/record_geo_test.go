@@ -0,0 +1,140 @@
+package apis_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// setupTestGeo creates a new public "places" collection with
+// a "location" geoPoint field and a few records (one without location).
+func setupTestGeo(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+	collection := &models.Collection{
+		Name:     "places",
+		Type:     models.CollectionTypeBase,
+		ListRule: types.Pointer(""),
+		Schema: schema.NewSchema(
+			&schema.SchemaField{Name: "title", Type: schema.FieldTypeText},
+			&schema.SchemaField{Name: "location", Type: schema.FieldTypeGeoPoint},
+		),
+	}
+
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	places := map[string]any{
+		"sofia":   "42.6977,23.3219",
+		"plovdiv": "42.1354,24.7453",
+		"varna":   "43.2141,27.9147",
+		"nowhere": nil,
+	}
+
+	for title, location := range places {
+		record := models.NewRecord(collection)
+		record.Id = title + "_12345"
+		record.Set("title", title)
+		record.Set("location", location)
+
+		if err := app.Dao().SaveRecord(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	app.ResetEventCalls()
+}
+
+func TestRecordCrudListNear(t *testing.T) {
+	scenarios := []tests.ApiScenario{
+		{
+			Name:            "within without near",
+			Method:          http.MethodGet,
+			Url:             "/api/collections/places/records?within=1000",
+			BeforeTestFunc:  setupTestGeo,
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:            "invalid near format",
+			Method:          http.MethodGet,
+			Url:             "/api/collections/places/records?near=42.6977,23.3219",
+			BeforeTestFunc:  setupTestGeo,
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:            "non geoPoint near field",
+			Method:          http.MethodGet,
+			Url:             "/api/collections/places/records?near=title:42.6977,23.3219",
+			BeforeTestFunc:  setupTestGeo,
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:            "out of range near location",
+			Method:          http.MethodGet,
+			Url:             "/api/collections/places/records?near=location:91,23.3219",
+			BeforeTestFunc:  setupTestGeo,
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:            "invalid within",
+			Method:          http.MethodGet,
+			Url:             "/api/collections/places/records?near=location:42.6977,23.3219&within=-1",
+			BeforeTestFunc:  setupTestGeo,
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:           "nearest sorting",
+			Method:         http.MethodGet,
+			Url:            "/api/collections/places/records?near=location:43.2141,27.9147&fields=id",
+			BeforeTestFunc: setupTestGeo,
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"totalItems":3`,
+				`"items":[{"id":"varna_12345"},{"id":"plovdiv_12345"},{"id":"sofia_12345"}]`,
+			},
+			ExpectedEvents: map[string]int{"OnRecordsListRequest": 1},
+		},
+		{
+			Name:           "within radius",
+			Method:         http.MethodGet,
+			Url:            "/api/collections/places/records?near=location:42.6977,23.3219&within=200000",
+			BeforeTestFunc: setupTestGeo,
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"totalItems":2`,
+				`"id":"sofia_12345","location":{"lat":42.6977,"lng":23.3219}`,
+				`"id":"plovdiv_12345"`,
+			},
+			NotExpectedContent: []string{
+				`"id":"varna_12345"`,
+				`"id":"nowhere_12345"`,
+			},
+			ExpectedEvents: map[string]int{"OnRecordsListRequest": 1},
+		},
+		{
+			Name:           "within radius combined with filter and sort",
+			Method:         http.MethodGet,
+			Url:            "/api/collections/places/records?near=location:42.6977,23.3219&within=500000&filter=location.lng>24&sort=-title&fields=id",
+			BeforeTestFunc: setupTestGeo,
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"totalItems":2`,
+				`"items":[{"id":"varna_12345"},{"id":"plovdiv_12345"}]`,
+			},
+			ExpectedEvents: map[string]int{"OnRecordsListRequest": 1},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/pocketbase/pocketbase/models"
 	"github.com/pocketbase/pocketbase/resolvers"
 	"github.com/pocketbase/pocketbase/tokens"
+	"github.com/pocketbase/pocketbase/tools/list"
 	"github.com/pocketbase/pocketbase/tools/rest"
 	"github.com/pocketbase/pocketbase/tools/search"
 )
@@ -62,6 +63,7 @@ func RecordAuthResponse(app core.App, c echo.Context, authRecord *models.Record,
 
 	event := new(core.RecordAuthEvent)
 	event.HttpContext = c
+	event.RequestInfo = RequestInfo(c)
 	event.Collection = authRecord.Collection()
 	event.Record = authRecord
 	event.Token = token
@@ -81,7 +83,7 @@ func RecordAuthResponse(app core.App, c echo.Context, authRecord *models.Record,
 			failed := app.Dao().ExpandRecord(
 				e.Record,
 				expands,
-				expandFetch(app.Dao(), &requestData),
+				expandFetch(app.Dao(), &requestData, nil),
 			)
 			if len(failed) > 0 && app.IsDebug() {
 				log.Println("Failed to expand relations: ", failed)
@@ -128,7 +130,7 @@ func EnrichRecords(c echo.Context, dao *daos.Dao, records []*models.Record, defa
 		return nil // nothing to expand
 	}
 
-	errs := dao.ExpandRecords(records, expands, expandFetch(dao, requestData))
+	errs := dao.ExpandRecords(records, expands, expandFetch(dao, requestData, requestExpandCache(c, dao)))
 	if len(errs) > 0 {
 		return fmt.Errorf("Failed to expand: %v", errs)
 	}
@@ -137,38 +139,58 @@ func EnrichRecords(c echo.Context, dao *daos.Dao, records []*models.Record, defa
 }
 
 // expandFetch is the records fetch function that is used to expand related records.
+//
+// The relation records of each collection are loaded with a single query
+// and, if cache is set, only the ones that weren't fetched already
+// by the previous expand paths of the same request.
 func expandFetch(
 	dao *daos.Dao,
 	requestData *models.RequestData,
+	cache *expandCache,
 ) daos.ExpandFetchFunc {
 	return func(relCollection *models.Collection, relIds []string) ([]*models.Record, error) {
-		records, err := dao.FindRecordsByIds(relCollection.Id, relIds, func(q *dbx.SelectQuery) error {
-			if requestData.Admin != nil {
-				return nil // admins can access everything
-			}
+		if requestData.Admin == nil && relCollection.ViewRule == nil {
+			return nil, fmt.Errorf("Only admins can view collection %q records", relCollection.Name)
+		}
 
-			if relCollection.ViewRule == nil {
-				return fmt.Errorf("Only admins can view collection %q records", relCollection.Name)
-			}
+		records := []*models.Record{}
+		missingIds := relIds
+
+		if cache != nil {
+			records, missingIds = cache.load(relCollection.Id, relIds)
+		}
 
-			if *relCollection.ViewRule != "" {
+		if len(missingIds) > 0 {
+			query := dao.RecordQuery(relCollection).
+				AndWhere(dbx.In(relCollection.Name+".id", list.ToInterfaceSlice(missingIds)...))
+
+			if requestData.Admin == nil && *relCollection.ViewRule != "" {
 				resolver := resolvers.NewRecordFieldResolver(dao, relCollection, requestData, true)
 				expr, err := search.FilterData(*(relCollection.ViewRule)).BuildExpr(resolver)
 				if err != nil {
-					return err
+					return nil, err
 				}
-				resolver.UpdateQuery(q)
-				q.AndWhere(expr)
+				resolver.UpdateQuery(query)
+				query.AndWhere(expr)
+			}
+
+			fetched := make([]*models.Record, 0, len(missingIds))
+			if err := query.All(&fetched); err != nil {
+				return nil, err
 			}
 
-			return nil
-		})
+			if cache != nil {
+				cache.store(relCollection.Id, missingIds, fetched)
+			}
+
+			records = append(records, fetched...)
+		}
 
-		if err == nil && len(records) > 0 {
+		if len(records) > 0 {
 			autoIgnoreAuthRecordsEmailVisibility(dao, records, requestData)
 		}
 
-		return records, err
+		return records, nil
 	}
 }
 
@@ -218,3 +218,74 @@ func TestEnrichRecords(t *testing.T) {
 		}
 	}
 }
+
+func TestEnrichRecordsSharedExpandCache(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	dummyAdmin := &models.Admin{}
+	dummyAdmin.Id = "test_id"
+	c.Set(apis.ContextAdminKey, dummyAdmin)
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	first, err := app.Dao().FindRecordById("demo1", "84nmscqy84lsi1t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := apis.EnrichRecord(c, app.Dao(), first, "rel_many"); err != nil {
+		t.Fatal(err)
+	}
+
+	// delete the related record directly from the db to ensure
+	// that the next expand of the same request is served from the cache
+	if _, err := app.Dao().DB().NewQuery("DELETE FROM users WHERE id = 'oap640cot4yru2s'").Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := app.Dao().FindRecordById("demo1", "al1h9ijdeojtsjy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := apis.EnrichRecord(c, app.Dao(), second, "rel_many"); err != nil {
+		t.Fatal(err)
+	}
+
+	firstRels, _ := first.Expand()["rel_many"].([]*models.Record)
+	secondRels, _ := second.Expand()["rel_many"].([]*models.Record)
+
+	if len(firstRels) != 1 || firstRels[0].Id != "oap640cot4yru2s" {
+		t.Fatalf("Expected the first record to have expanded oap640cot4yru2s, got %v", firstRels)
+	}
+
+	var cached *models.Record
+	for _, rel := range secondRels {
+		if rel.Id == "oap640cot4yru2s" {
+			cached = rel
+		}
+	}
+	if cached == nil {
+		t.Fatalf("Expected oap640cot4yru2s to be served from the request expand cache, got %v", secondRels)
+	}
+	if cached == firstRels[0] {
+		t.Fatal("Expected the cached expand record to be a copy")
+	}
+
+	// a new request shouldn't reuse the cache
+	c2 := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+	c2.Set(apis.ContextAdminKey, dummyAdmin)
+
+	third, err := app.Dao().FindRecordById("demo1", "84nmscqy84lsi1t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := apis.EnrichRecord(c2, app.Dao(), third, "rel_many"); err != nil {
+		t.Fatal(err)
+	}
+	if rels, _ := third.Expand()["rel_many"].([]*models.Record); len(rels) != 0 {
+		t.Fatalf("Expected no expanded rel_many records for the new request, got %v", rels)
+	}
+}
@@ -0,0 +1,57 @@
+package apis
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// swagger:models RecordsImportRequest
+type RecordsImportRequest struct {
+	Rows       []map[string]any         `json:"rows"`
+	Transforms []*forms.ImportTransform `json:"transforms"`
+}
+
+//	@Summary		Импорт записей
+//	@Description	Создает (или обновляет при совпадении id) записи коллекции в одной транзакции. Перед валидацией к каждой строке последовательно применяются шаги преобразования полей:
+//	@Description	trim, lowercase, date (разбор даты по format в формате Go, например "02.01.2006"), lookup (замена значения на id записи коллекции collection с lookupField равным значению) и default (value для пустых полей)
+//	@Description	При ошибке любой строки все изменения откатываются, а ошибка возвращается по ключу rows.{индекс строки}
+//	@Tags			Record
+//	@Security		AdminAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			collection	path		string					true	"Идентификатор коллекции"
+//	@Param			body		body		RecordsImportRequest	true	"Импортируемые строки и шаги преобразования"
+//	@Success		200			{array}		models.Record
+//	@Failure		400			{string}	string	"Failed to import the records."
+//	@Failure		401			{string}	string	"The request requires admin authorization token to be set."
+//	@Failure		404			{string}	string	"Missing collection context."
+//	@Router			/collections/{collection}/records/import [post]
+func (api *recordApi) importRecords(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("", "Missing collection context.")
+	}
+
+	form := forms.NewRecordsImport(api.app, collection)
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("Failed to load the submitted data due to invalid formatting.", err)
+	}
+
+	return form.Submit(func(next forms.InterceptorNextFunc[[]*models.Record]) forms.InterceptorNextFunc[[]*models.Record] {
+		return func(records []*models.Record) error {
+			if err := next(records); err != nil {
+				return NewBadRequestError("Failed to import the records.", err)
+			}
+
+			if err := EnrichRecords(c, api.app.Dao(), records); err != nil && api.app.IsDebug() {
+				log.Println(err)
+			}
+
+			return c.JSON(http.StatusOK, records)
+		}
+	})
+}
@@ -0,0 +1,105 @@
+package apis_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestRecordsImport(t *testing.T) {
+	scenarios := []tests.ApiScenario{
+		{
+			Name:            "guest",
+			Method:          http.MethodPost,
+			Url:             "/api/collections/demo2/records/import",
+			Body:            strings.NewReader(`{"rows":[{"title":"new1"}]}`),
+			ExpectedStatus:  401,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "auth record",
+			Method: http.MethodPost,
+			Url:    "/api/collections/demo2/records/import",
+			Body:   strings.NewReader(`{"rows":[{"title":"new1"}]}`),
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgOwnerToken,
+			},
+			ExpectedStatus:  401,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "admin with view collection",
+			Method: http.MethodPost,
+			Url:    "/api/collections/view1/records/import",
+			Body:   strings.NewReader(`{"rows":[{"title":"new1"}]}`),
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgAdminToken,
+			},
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "admin with failed row transform",
+			Method: http.MethodPost,
+			Url:    "/api/collections/demo1/records/import",
+			Body: strings.NewReader(`{
+				"rows":[{"text":"a"},{"text":"b","datetime":"2026-10-15"}],
+				"transforms":[{"field":"datetime","type":"date","format":"02.01.2006"}]
+			}`),
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgAdminToken,
+			},
+			ExpectedStatus: 400,
+			ExpectedContent: []string{
+				`"rows":{"1":{"datetime":{"code":"validation_import_transform_failure"`,
+			},
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeCreate": 1,
+				"OnBeforeApiError":    1,
+				"OnAfterApiError":     1,
+			},
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				if _, err := app.Dao().FindFirstRecordByData("demo1", "text", "a"); err == nil {
+					t.Fatal("Expected the import to be rolled back")
+				}
+			},
+		},
+		{
+			Name:   "admin with transforms",
+			Method: http.MethodPost,
+			Url:    "/api/collections/demo1/records/import",
+			Body: strings.NewReader(`{
+				"rows":[{"text":"  Imported  ","datetime":"15.10.2026","rel_many":[" TEST@example.com "]}],
+				"transforms":[
+					{"field":"text","type":"trim"},
+					{"field":"datetime","type":"date","format":"02.01.2006"},
+					{"field":"rel_many","type":"trim"},
+					{"field":"rel_many","type":"lowercase"},
+					{"field":"rel_many","type":"lookup","collection":"users","lookupField":"email"},
+					{"field":"select_one","type":"default","value":"optionB"}
+				]
+			}`),
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgAdminToken,
+			},
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"text":"Imported"`,
+				`"datetime":"2026-10-15 00:00:00.000Z"`,
+				`"rel_many":["4q1xlclmfloku33"]`,
+				`"select_one":"optionB"`,
+			},
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeCreate": 1,
+				"OnModelAfterCreate":  1,
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
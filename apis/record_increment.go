@@ -0,0 +1,97 @@
+package apis
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+// swagger:models RecordIncrementRequest
+type RecordIncrementRequest struct {
+	Fields map[string]forms.RecordIncrementField `json:"fields"`
+}
+
+//	@Summary		Атомарное увеличение числовых полей записи
+//	@Description	Увеличивает (или уменьшает при отрицательном amount) значения числовых полей записи на стороне сервера одним UPDATE запросом, поэтому параллельные запросы не перезаписывают друг друга (лайки, остатки и т.п.)
+//	@Description	Итоговое значение ограничивается min/max запроса и min/max опциями поля схемы. Доступ проверяется правилом обновления коллекции (@request.data содержит ожидаемые итоговые значения полей)
+//	@Tags			Record
+//	@Security		Auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			collection	path		string					true	"Идентификатор коллекции"
+//	@Param			id			path		string					true	"Идентификатор записи"
+//	@Param			body		body		RecordIncrementRequest	true	"Поля для увеличения"
+//	@Param			expand		query		string					false	"Связи для раскрытия (через запятую)"
+//	@Success		200			{object}	models.Record
+//	@Failure		400			{string}	string	"Failed to increment the record fields."
+//	@Failure		403			{string}	string	"Only admins can perform this action."
+//	@Failure		404			{string}	string	"Not found."
+//	@Router			/collections/{collection}/records/{id}/increment [post]
+func (api *recordApi) increment(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("", "Missing collection context.")
+	}
+
+	recordId := c.PathParam("id")
+	if recordId == "" {
+		return NewNotFoundError("", nil)
+	}
+
+	requestData := RequestData(c)
+
+	if requestData.Admin == nil && collection.UpdateRule == nil {
+		// only admins can access if the rule is nil
+		return NewForbiddenError("Only admins can perform this action.", nil)
+	}
+
+	record, err := api.app.Dao().FindRecordById(collection.Id, recordId)
+	if err != nil || record == nil {
+		return NewNotFoundError("", err)
+	}
+
+	form := forms.NewRecordIncrement(api.app, record)
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	// expose the expected field values to the update rule
+	requestData.Data = form.ResultData()
+
+	ruleFunc := func(q *dbx.SelectQuery) error {
+		if requestData.Admin == nil && collection.UpdateRule != nil && *collection.UpdateRule != "" {
+			resolver := resolvers.NewRecordFieldResolver(api.app.Dao(), collection, requestData, true)
+			expr, err := search.FilterData(*collection.UpdateRule).BuildExpr(resolver)
+			if err != nil {
+				return err
+			}
+			resolver.UpdateQuery(q)
+			q.AndWhere(expr)
+		}
+		return nil
+	}
+
+	if _, err := api.app.Dao().FindRecordById(collection.Id, recordId, ruleFunc); err != nil {
+		return NewNotFoundError("", err)
+	}
+
+	return form.Submit(func(next forms.InterceptorNextFunc[*models.Record]) forms.InterceptorNextFunc[*models.Record] {
+		return func(m *models.Record) error {
+			if err := next(m); err != nil {
+				return NewBadRequestError("Failed to increment the record fields.", err)
+			}
+
+			if err := EnrichRecord(c, api.app.Dao(), m); err != nil && api.app.IsDebug() {
+				log.Println(err)
+			}
+
+			return c.JSON(http.StatusOK, m)
+		}
+	})
+}
@@ -0,0 +1,130 @@
+package apis_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestRecordIncrement(t *testing.T) {
+	scenarios := []tests.ApiScenario{
+		{
+			Name:            "missing collection",
+			Method:          http.MethodPost,
+			Url:             "/api/collections/missing/records/84nmscqy84lsi1t/increment",
+			Body:            strings.NewReader(`{"fields":{"number":{"amount":1}}}`),
+			ExpectedStatus:  404,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:            "view collection",
+			Method:          http.MethodPost,
+			Url:             "/api/collections/view1/records/84nmscqy84lsi1t/increment",
+			Body:            strings.NewReader(`{"fields":{"number":{"amount":1}}}`),
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:            "guest in admin only collection",
+			Method:          http.MethodPost,
+			Url:             "/api/collections/demo1/records/84nmscqy84lsi1t/increment",
+			Body:            strings.NewReader(`{"fields":{"number":{"amount":1}}}`),
+			ExpectedStatus:  403,
+			ExpectedContent: []string{`"message":"Only admins can perform this action."`},
+		},
+		{
+			Name:   "admin with missing record",
+			Method: http.MethodPost,
+			Url:    "/api/collections/demo1/records/missing/increment",
+			Body:   strings.NewReader(`{"fields":{"number":{"amount":1}}}`),
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgAdminToken,
+			},
+			ExpectedStatus:  404,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "admin with invalid fields",
+			Method: http.MethodPost,
+			Url:    "/api/collections/demo1/records/84nmscqy84lsi1t/increment",
+			Body:   strings.NewReader(`{"fields":{"text":{"amount":1},"number":{"amount":1,"min":10,"max":5}}}`),
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgAdminToken,
+			},
+			ExpectedStatus: 400,
+			ExpectedContent: []string{
+				`"fields":{`,
+				`"text":{"code":"validation_invalid_increment_field"`,
+				`"number":{"code":"validation_invalid_increment_bounds"`,
+			},
+		},
+		{
+			Name:   "admin with clamped increment",
+			Method: http.MethodPost,
+			Url:    "/api/collections/demo1/records/84nmscqy84lsi1t/increment",
+			Body:   strings.NewReader(`{"fields":{"number":{"amount":100,"max":123500}}}`),
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgAdminToken,
+			},
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"id":"84nmscqy84lsi1t"`,
+				`"number":123500`,
+			},
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeUpdate": 1,
+				"OnModelAfterUpdate":  1,
+			},
+		},
+		{
+			Name:            "guest not satisfying the update rule with the expected data",
+			Method:          http.MethodPost,
+			Url:             "/api/collections/demo5/records/qjeql998mtp1azp/increment",
+			Body:            strings.NewReader(`{"fields":{"total":{"amount":1}}}`),
+			ExpectedStatus:  404,
+			ExpectedContent: []string{`"data":{}`},
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				record, err := app.Dao().FindRecordById("demo5", "qjeql998mtp1azp")
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if v := record.GetFloat("total"); v != 0 {
+					t.Fatalf("Expected the total to be unchanged, got %v", v)
+				}
+			},
+		},
+		{
+			Name:           "guest satisfying the update rule with the expected data",
+			Method:         http.MethodPost,
+			Url:            "/api/collections/demo5/records/la4y2w4o98acwuj/increment",
+			Body:           strings.NewReader(`{"fields":{"total":{"amount":1}}}`),
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"id":"la4y2w4o98acwuj"`,
+				`"total":3`,
+			},
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeUpdate": 1,
+				"OnModelAfterUpdate":  1,
+			},
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				record, err := app.Dao().FindRecordById("demo5", "la4y2w4o98acwuj")
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if v := record.GetFloat("total"); v != 3 {
+					t.Fatalf("Expected the total to be 3, got %v", v)
+				}
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
@@ -0,0 +1,161 @@
+package apis
+
+import (
+	"math"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/locale"
+)
+
+const (
+	// localeQueryParam selects the locale of the localized record fields
+	// (the full locale -> value maps are returned if not set).
+	localeQueryParam = "locale"
+
+	// localesQueryParam is an optional comma separated list with the
+	// translation status locales (default to the app supported locales).
+	localesQueryParam = "locales"
+)
+
+// swagger:models TranslationStatus
+type TranslationStatus struct {
+	Collection string   `json:"collection"`
+	Locales    []string `json:"locales"`
+
+	// TotalItems is the total number of the collection records
+	// (0 if the collection doesn't have localized fields).
+	TotalItems int                       `json:"totalItems"`
+	Fields     []*FieldTranslationStatus `json:"fields"`
+}
+
+// FieldTranslationStatus defines the translation status of a single localized field.
+type FieldTranslationStatus struct {
+	Field   string                     `json:"field"`
+	Locales []*LocaleTranslationStatus `json:"locales"`
+}
+
+// LocaleTranslationStatus defines the translation status of a single field locale.
+type LocaleTranslationStatus struct {
+	Locale     string `json:"locale"`
+	Translated int    `json:"translated"`
+	Missing    int    `json:"missing"`
+
+	// Progress is the translated records percentage (0-100).
+	Progress float64 `json:"progress"`
+}
+
+// localeChain returns the localized values lookup chain of the
+// current request locale (returns nil if the locale is not set).
+func (api *recordApi) localeChain(c echo.Context) ([]string, error) {
+	l := c.QueryParam(localeQueryParam)
+	if l == "" {
+		return nil, nil
+	}
+
+	if !locale.IsValid(l) {
+		return nil, NewBadRequestError("Invalid locale.", nil)
+	}
+
+	return api.app.Settings().Localization.Chain(l), nil
+}
+
+// localizeRecords sets the provided locale chain to the
+// records and their expanded relations (if any).
+func localizeRecords(records []*models.Record, chain []string) {
+	if len(chain) == 0 {
+		return
+	}
+
+	for _, record := range records {
+		if record == nil {
+			continue
+		}
+
+		record.SetLocaleChain(chain)
+
+		for _, expanded := range record.Expand() {
+			switch v := expanded.(type) {
+			case *models.Record:
+				localizeRecords([]*models.Record{v}, chain)
+			case []*models.Record:
+				localizeRecords(v, chain)
+			}
+		}
+	}
+}
+
+//	@Summary		Статус переводов коллекции
+//	@Description	Возвращает количество переведенных и отсутствующих значений каждого локализованного поля коллекции по языкам
+//	@Tags			Record
+//	@Security		AdminAuth
+//	@Produce		json
+//	@Param			collection	path		string	true	"Идентификатор коллекции"
+//	@Param			locales		query		string	false	"Языки через запятую (по умолчанию поддерживаемые языки приложения)"
+//	@Success		200			{object}	TranslationStatus
+//	@Failure		400			{string}	string	"Invalid locale."
+//	@Failure		401			{string}	string	"The request requires admin authorization token to be set."
+//	@Failure		404			{string}	string	"Not found."
+//	@Router			/collections/{collection}/translations [get]
+func (api *recordApi) translationStatus(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("", "Missing collection context.")
+	}
+
+	locales := api.app.Settings().Localization.SupportedLocales()
+	if param := c.QueryParam(localesQueryParam); param != "" {
+		locales = list.ToUniqueStringSlice(strings.Split(param, ","))
+	}
+
+	for _, l := range locales {
+		if !locale.IsValid(l) {
+			return NewBadRequestError("Invalid locale.", nil)
+		}
+	}
+
+	result := &TranslationStatus{
+		Collection: collection.Name,
+		Locales:    locales,
+		Fields:     []*FieldTranslationStatus{},
+	}
+
+	for _, field := range collection.Schema.Fields() {
+		if !field.IsLocalized() {
+			continue
+		}
+
+		total, translated, err := api.app.Dao().CountRecordTranslations(collection, field.Name, locales)
+		if err != nil {
+			return NewBadRequestError("Failed to load the translation status.", err)
+		}
+
+		result.TotalItems = total
+
+		fieldStatus := &FieldTranslationStatus{
+			Field:   field.Name,
+			Locales: make([]*LocaleTranslationStatus, 0, len(locales)),
+		}
+
+		for _, l := range locales {
+			status := &LocaleTranslationStatus{
+				Locale:     l,
+				Translated: translated[l],
+				Missing:    total - translated[l],
+			}
+
+			if total > 0 {
+				status.Progress = math.Round(float64(status.Translated)/float64(total)*10000) / 100
+			}
+
+			fieldStatus.Locales = append(fieldStatus.Locales, status)
+		}
+
+		result.Fields = append(result.Fields, fieldStatus)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,183 @@
+package apis
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+// swagger:models RecordLockRequest
+type RecordLockRequest struct {
+	Duration int `json:"duration" example:"60"`
+}
+
+// lockRuleRecord returns the record from the current request context
+// if it satisfies the provided collection API rule
+// (nil rules are satisfied only by admins).
+func (api *recordApi) lockRuleRecord(c echo.Context, rule *string) (*models.Record, error) {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return nil, NewNotFoundError("", "Missing collection context.")
+	}
+
+	recordId := c.PathParam("id")
+	if recordId == "" {
+		return nil, NewNotFoundError("", nil)
+	}
+
+	requestData := RequestData(c)
+
+	if requestData.Admin == nil && rule == nil {
+		// only admins can access if the rule is nil
+		return nil, NewForbiddenError("Only admins can perform this action.", nil)
+	}
+
+	ruleFunc := func(q *dbx.SelectQuery) error {
+		if requestData.Admin == nil && rule != nil && *rule != "" {
+			resolver := resolvers.NewRecordFieldResolver(api.app.Dao(), collection, requestData, true)
+			expr, err := search.FilterData(*rule).BuildExpr(resolver)
+			if err != nil {
+				return err
+			}
+			resolver.UpdateQuery(q)
+			q.AndWhere(expr)
+		}
+		return nil
+	}
+
+	record, err := api.app.Dao().FindRecordById(collection.Id, recordId, ruleFunc)
+	if err != nil || record == nil {
+		return nil, NewNotFoundError("", err)
+	}
+
+	return record, nil
+}
+
+//	@Summary		Текущая блокировка записи
+//	@Description	Возвращает активную (не истекшую) блокировку редактирования записи. Доступно всем, кто может просматривать запись (ViewRule)
+//	@Tags			Record
+//	@Security		Auth
+//	@Produce		json
+//	@Param			collection	path		string	true	"Идентификатор коллекции"
+//	@Param			id			path		string	true	"Идентификатор записи"
+//	@Success		200			{object}	models.RecordLock
+//	@Failure		403			{string}	string	"Only admins can perform this action."
+//	@Failure		404			{string}	string	"The record is not locked."
+//	@Router			/collections/{collection}/records/{id}/lock [get]
+func (api *recordApi) viewLock(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("", "Missing collection context.")
+	}
+
+	record, err := api.lockRuleRecord(c, collection.ViewRule)
+	if err != nil {
+		return err
+	}
+
+	lock, err := api.app.Dao().FindRecordLock(record)
+	if err != nil || lock.IsExpired() {
+		return NewNotFoundError("The record is not locked.", err)
+	}
+
+	return c.JSON(http.StatusOK, lock)
+}
+
+//	@Summary		Блокировка записи для редактирования
+//	@Description	Выдает краткосрочную аренду (lease) на редактирование записи или продлевает уже выданную текущему администратору/записи авторизации. Блокировка рекомендательная (не запрещает обновление записи) и снимается автоматически по истечении срока
+//	@Description	Изменения блокировки доставляются клиентам в реальном времени по подписке "PB_LOCKS/{collection}/{recordId}". Требуется доступ к обновлению записи (UpdateRule)
+//	@Tags			Record
+//	@Security		AdminAuth
+//	@Security		RecordAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			collection	path		string				true	"Идентификатор коллекции"
+//	@Param			id			path		string				true	"Идентификатор записи"
+//	@Param			body		body		RecordLockRequest	false	"Длительность аренды в секундах (по умолчанию 60, максимум 600)"
+//	@Success		200			{object}	models.RecordLock
+//	@Failure		400			{string}	string	"Failed to lock the record."
+//	@Failure		401			{string}	string	"The request requires admin or record authorization token to be set."
+//	@Failure		403			{string}	string	"Only admins can perform this action."
+//	@Failure		404			{string}	string	"Not found."
+//	@Failure		409			{string}	string	"The record is locked by another client."
+//	@Router			/collections/{collection}/records/{id}/lock [post]
+func (api *recordApi) lock(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("", "Missing collection context.")
+	}
+
+	record, err := api.lockRuleRecord(c, collection.UpdateRule)
+	if err != nil {
+		return err
+	}
+
+	admin, _ := c.Get(ContextAdminKey).(*models.Admin)
+	authRecord, _ := c.Get(ContextAuthRecordKey).(*models.Record)
+
+	if lock, err := api.app.Dao().FindRecordLock(record); err == nil && !lock.IsExpired() && !lock.IsHeldBy(admin, authRecord) {
+		return NewApiError(http.StatusConflict, "The record is locked by another client.", nil)
+	}
+
+	form := forms.NewRecordLockAcquire(api.app, record, admin, authRecord)
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	return form.Submit(func(next forms.InterceptorNextFunc[*models.RecordLock]) forms.InterceptorNextFunc[*models.RecordLock] {
+		return func(lock *models.RecordLock) error {
+			if err := next(lock); err != nil {
+				return NewBadRequestError("Failed to lock the record.", err)
+			}
+
+			return c.JSON(http.StatusOK, lock)
+		}
+	})
+}
+
+//	@Summary		Снятие блокировки записи
+//	@Description	Освобождает блокировку редактирования записи. Доступно держателю блокировки и администраторам (принудительное снятие)
+//	@Tags			Record
+//	@Security		AdminAuth
+//	@Security		RecordAuth
+//	@Param			collection	path	string	true	"Идентификатор коллекции"
+//	@Param			id			path	string	true	"Идентификатор записи"
+//	@Success		204			"No Content"
+//	@Failure		400			{string}	string	"Failed to unlock the record."
+//	@Failure		401			{string}	string	"The request requires admin or record authorization token to be set."
+//	@Failure		403			{string}	string	"Only admins and the lock holder can perform this action."
+//	@Failure		404			{string}	string	"The record is not locked."
+//	@Router			/collections/{collection}/records/{id}/lock [delete]
+func (api *recordApi) unlock(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("", "Missing collection context.")
+	}
+
+	record, err := api.lockRuleRecord(c, collection.ViewRule)
+	if err != nil {
+		return err
+	}
+
+	lock, err := api.app.Dao().FindRecordLock(record)
+	if err != nil || lock.IsExpired() {
+		return NewNotFoundError("The record is not locked.", err)
+	}
+
+	admin, _ := c.Get(ContextAdminKey).(*models.Admin)
+	authRecord, _ := c.Get(ContextAuthRecordKey).(*models.Record)
+	if admin == nil && !lock.IsHeldBy(nil, authRecord) {
+		return NewForbiddenError("Only admins and the lock holder can perform this action.", nil)
+	}
+
+	if err := api.app.Dao().DeleteRecordLock(lock); err != nil {
+		return NewBadRequestError("Failed to unlock the record.", err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
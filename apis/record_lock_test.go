@@ -0,0 +1,303 @@
+package apis_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/apis"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/subscriptions"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// setupTestRecordLock creates a demo2 achvryl401bhse3 record lock
+// held by the test org owner (users/4q1xlclmfloku33).
+func setupTestRecordLock(expired bool) func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+	return func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+		expires := time.Now().Add(time.Minute)
+		if expired {
+			expires = time.Now().Add(-time.Second)
+		}
+
+		lock := &models.RecordLock{
+			CollectionId:       "sz5l5z67tg7gku0",
+			RecordId:           "achvryl401bhse3",
+			HolderType:         models.RecordLockHolderAuthRecord,
+			HolderCollectionId: "_pb_users_auth_",
+			HolderId:           "4q1xlclmfloku33",
+		}
+		lock.Expires, _ = types.ParseDateTime(expires)
+		lock.MarkAsNew()
+		lock.SetId("testlock0000001")
+
+		if err := app.Dao().SaveRecordLock(lock); err != nil {
+			t.Fatal(err)
+		}
+
+		app.ResetEventCalls()
+	}
+}
+
+func TestRecordLockView(t *testing.T) {
+	scenarios := []tests.ApiScenario{
+		{
+			Name:            "guest in admin only collection",
+			Method:          http.MethodGet,
+			Url:             "/api/collections/demo1/records/84nmscqy84lsi1t/lock",
+			ExpectedStatus:  403,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:            "guest with unlocked record",
+			Method:          http.MethodGet,
+			Url:             "/api/collections/demo2/records/achvryl401bhse3/lock",
+			ExpectedStatus:  404,
+			ExpectedContent: []string{`"message":"The record is not locked."`},
+		},
+		{
+			Name:            "guest with expired lock",
+			Method:          http.MethodGet,
+			Url:             "/api/collections/demo2/records/achvryl401bhse3/lock",
+			BeforeTestFunc:  setupTestRecordLock(true),
+			ExpectedStatus:  404,
+			ExpectedContent: []string{`"message":"The record is not locked."`},
+		},
+		{
+			Name:           "guest with active lock",
+			Method:         http.MethodGet,
+			Url:            "/api/collections/demo2/records/achvryl401bhse3/lock",
+			BeforeTestFunc: setupTestRecordLock(false),
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"id":"testlock0000001"`,
+				`"holderType":"authRecord"`,
+				`"holderCollectionId":"_pb_users_auth_"`,
+				`"holderId":"4q1xlclmfloku33"`,
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
+
+func TestRecordLockAcquire(t *testing.T) {
+	guestClient := subscriptions.NewDefaultClient()
+	guestClient.Subscribe(apis.RealtimeLocksTopicPrefix + "demo2/achvryl401bhse3")
+
+	scenarios := []tests.ApiScenario{
+		{
+			Name:            "guest",
+			Method:          http.MethodPost,
+			Url:             "/api/collections/demo2/records/achvryl401bhse3/lock",
+			ExpectedStatus:  401,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "auth record in admin only collection",
+			Method: http.MethodPost,
+			Url:    "/api/collections/demo1/records/84nmscqy84lsi1t/lock",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgMemberToken,
+			},
+			ExpectedStatus:  403,
+			ExpectedContent: []string{`"message":"Only admins can perform this action."`},
+		},
+		{
+			Name:   "invalid duration",
+			Method: http.MethodPost,
+			Url:    "/api/collections/demo2/records/achvryl401bhse3/lock",
+			Body:   strings.NewReader(`{"duration":601}`),
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgMemberToken,
+			},
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"duration":{"code":"validation_max_less_equal_than_required"`},
+		},
+		{
+			Name:   "locked by another auth record",
+			Method: http.MethodPost,
+			Url:    "/api/collections/demo2/records/achvryl401bhse3/lock",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgMemberToken,
+			},
+			BeforeTestFunc:  setupTestRecordLock(false),
+			ExpectedStatus:  409,
+			ExpectedContent: []string{`"message":"The record is locked by another client."`},
+		},
+		{
+			Name:   "taking over an expired lock",
+			Method: http.MethodPost,
+			Url:    "/api/collections/demo2/records/achvryl401bhse3/lock",
+			Body:   strings.NewReader(`{"duration":30}`),
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgMemberToken,
+			},
+			BeforeTestFunc: setupTestRecordLock(true),
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"id":"testlock0000001"`,
+				`"holderId":"oap640cot4yru2s"`,
+			},
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeUpdate": 1,
+				"OnModelAfterUpdate":  1,
+			},
+		},
+		{
+			Name:   "renewing own lock",
+			Method: http.MethodPost,
+			Url:    "/api/collections/demo2/records/achvryl401bhse3/lock",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgOwnerToken,
+			},
+			BeforeTestFunc: setupTestRecordLock(false),
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"id":"testlock0000001"`,
+				`"holderId":"4q1xlclmfloku33"`,
+			},
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeUpdate": 1,
+				"OnModelAfterUpdate":  1,
+			},
+		},
+		{
+			Name:   "acquiring lock with realtime notification",
+			Method: http.MethodPost,
+			Url:    "/api/collections/demo2/records/achvryl401bhse3/lock",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgMemberToken,
+			},
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				app.SubscriptionsBroker().Register(guestClient)
+			},
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"collectionId":"sz5l5z67tg7gku0"`,
+				`"recordId":"achvryl401bhse3"`,
+				`"holderType":"authRecord"`,
+				`"holderId":"oap640cot4yru2s"`,
+			},
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeCreate": 1,
+				"OnModelAfterCreate":  1,
+			},
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				select {
+				case msg := <-guestClient.Channel():
+					for _, str := range []string{`"action":"create"`, `"holderId":"oap640cot4yru2s"`} {
+						if !strings.Contains(msg.Data, str) {
+							t.Fatalf("Expected %q in message %+v", str, msg)
+						}
+					}
+				case <-time.After(time.Second):
+					t.Fatal("Expected record lock message")
+				}
+			},
+		},
+		{
+			Name:   "admin in admin only collection",
+			Method: http.MethodPost,
+			Url:    "/api/collections/demo1/records/84nmscqy84lsi1t/lock",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgAdminToken,
+			},
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"recordId":"84nmscqy84lsi1t"`,
+				`"holderType":"admin"`,
+				`"holderCollectionId":""`,
+			},
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeCreate": 1,
+				"OnModelAfterCreate":  1,
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
+
+func TestRecordLockRelease(t *testing.T) {
+	scenarios := []tests.ApiScenario{
+		{
+			Name:            "guest",
+			Method:          http.MethodDelete,
+			Url:             "/api/collections/demo2/records/achvryl401bhse3/lock",
+			BeforeTestFunc:  setupTestRecordLock(false),
+			ExpectedStatus:  401,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "unlocked record",
+			Method: http.MethodDelete,
+			Url:    "/api/collections/demo2/records/achvryl401bhse3/lock",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgOwnerToken,
+			},
+			ExpectedStatus:  404,
+			ExpectedContent: []string{`"message":"The record is not locked."`},
+		},
+		{
+			Name:   "non holder auth record",
+			Method: http.MethodDelete,
+			Url:    "/api/collections/demo2/records/achvryl401bhse3/lock",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgMemberToken,
+			},
+			BeforeTestFunc:  setupTestRecordLock(false),
+			ExpectedStatus:  403,
+			ExpectedContent: []string{`"message":"Only admins and the lock holder can perform this action."`},
+		},
+		{
+			Name:   "holder",
+			Method: http.MethodDelete,
+			Url:    "/api/collections/demo2/records/achvryl401bhse3/lock",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgOwnerToken,
+			},
+			BeforeTestFunc: setupTestRecordLock(false),
+			ExpectedStatus: 204,
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeDelete": 1,
+				"OnModelAfterDelete":  1,
+			},
+		},
+		{
+			Name:   "admin",
+			Method: http.MethodDelete,
+			Url:    "/api/collections/demo2/records/achvryl401bhse3/lock",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgAdminToken,
+			},
+			BeforeTestFunc: setupTestRecordLock(false),
+			ExpectedStatus: 204,
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeDelete": 1,
+				"OnModelAfterDelete":  1,
+			},
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				record, err := app.Dao().FindRecordById("demo2", "achvryl401bhse3")
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if _, err := app.Dao().FindRecordLock(record); err == nil {
+					t.Fatal("Expected the record lock to be deleted")
+				}
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
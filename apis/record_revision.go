@@ -0,0 +1,165 @@
+package apis
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+// findVersionedRecord returns the record from the current request path
+// if its collection is versioned and it could be updated by the current
+// admin or auth record (aka. its collection UpdateRule is satisfied).
+func (api *recordApi) findVersionedRecord(c echo.Context) (*models.Record, error) {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return nil, NewNotFoundError("", "Missing collection context.")
+	}
+
+	if _, ok := api.app.Settings().Versioning.Collection(collection.Name); !ok {
+		return nil, NewBadRequestError("The collection doesn't have versioning enabled.", nil)
+	}
+
+	recordId := c.PathParam("id")
+	if recordId == "" {
+		return nil, NewNotFoundError("", nil)
+	}
+
+	requestData := RequestData(c)
+
+	if requestData.Admin == nil && collection.UpdateRule == nil {
+		// only admins can access if the rule is nil
+		return nil, NewForbiddenError("Only admins can perform this action.", nil)
+	}
+
+	ruleFunc := func(q *dbx.SelectQuery) error {
+		if requestData.Admin == nil && collection.UpdateRule != nil && *collection.UpdateRule != "" {
+			resolver := resolvers.NewRecordFieldResolver(api.app.Dao(), collection, requestData, true)
+			expr, err := search.FilterData(*collection.UpdateRule).BuildExpr(resolver)
+			if err != nil {
+				return err
+			}
+			resolver.UpdateQuery(q)
+			q.AndWhere(expr)
+		}
+		return nil
+	}
+
+	record, fetchErr := api.app.Dao().FindRecordById(collection.Id, recordId, ruleFunc)
+	if fetchErr != nil || record == nil {
+		return nil, NewNotFoundError("", fetchErr)
+	}
+
+	return record, nil
+}
+
+//	@Summary		История версий записи
+//	@Description	Возвращает постраничный список сохраненных версий записи (доступно тем, кто может обновлять запись согласно правилу обновления)
+//	@Description	Каждая версия содержит значения полей записи до соответствующего обновления
+//	@Tags			Record
+//	@Security		Auth
+//	@Produce		json
+//	@Param			collection	path		string	true	"Идентификатор коллекции"
+//	@Param			id			path		string	true	"Идентификатор записи"
+//	@Param			page		query		int		false	"Номер страницы (по умолчанию 1)"
+//	@Param			perPage		query		int		false	"Количество версий на странице (по умолчанию 30)"
+//	@Param			sort		query		string	false	"Сортировка (по умолчанию по номеру версии, сначала последние)"
+//	@Param			filter		query		string	false	"Фильтр версий"
+//	@Success		200			{object}	SearchResult{items=[]models.RecordRevision}
+//	@Failure		400			{string}	string	"The collection doesn't have versioning enabled."
+//	@Failure		403			{string}	string	"Only admins can perform this action."
+//	@Failure		404			{string}	string	"Not found."
+//	@Router			/collections/{collection}/records/{id}/revisions [get]
+func (api *recordApi) listRevisions(c echo.Context) error {
+	record, err := api.findVersionedRecord(c)
+	if err != nil {
+		return err
+	}
+
+	fieldResolver := search.NewSimpleFieldResolver("id", "revision", "created")
+
+	searchProvider := search.NewProvider(fieldResolver).
+		Query(api.app.Dao().RecordRevisionsQuery(record))
+
+	if c.QueryParam(search.SortQueryParam) == "" {
+		searchProvider.AddSort(search.SortField{Name: "revision", Direction: search.SortDesc})
+	}
+
+	revisions := []*models.RecordRevision{}
+
+	result, err := searchProvider.ParseAndExec(c.QueryParams().Encode(), &revisions)
+	if err != nil {
+		return NewBadRequestError("", err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+//	@Summary		Откат записи к версии
+//	@Description	Восстанавливает значения полей записи из указанной версии (доступно тем, кто может обновлять запись согласно правилу обновления)
+//	@Description	Файловые поля не откатываются; текущее состояние записи сохраняется как новая версия
+//	@Tags			Record
+//	@Security		Auth
+//	@Produce		json
+//	@Param			collection	path		string	true	"Идентификатор коллекции"
+//	@Param			id			path		string	true	"Идентификатор записи"
+//	@Param			rev			path		int		true	"Номер версии"
+//	@Param			expand		query		string	false	"Связи для раскрытия (через запятую)"
+//	@Success		200			{object}	models.Record
+//	@Header			200			{string}	ETag	"Новая версия записи"
+//	@Failure		400			{string}	string	"Failed to revert record."
+//	@Failure		403			{string}	string	"Only admins can perform this action."
+//	@Failure		404			{string}	string	"Not found."
+//	@Router			/collections/{collection}/records/{id}/revisions/{rev}/revert [post]
+func (api *recordApi) revertRevision(c echo.Context) error {
+	record, err := api.findVersionedRecord(c)
+	if err != nil {
+		return err
+	}
+
+	rev, err := strconv.Atoi(c.PathParam("rev"))
+	if err != nil {
+		return NewNotFoundError("", err)
+	}
+
+	revision, err := api.app.Dao().FindRecordRevision(record, rev)
+	if err != nil {
+		return NewNotFoundError("", err)
+	}
+
+	// the old files could be already deleted
+	data := make(map[string]any, len(revision.Data))
+	for _, field := range record.Collection().Schema.Fields() {
+		if value, ok := revision.Data[field.Name]; ok && field.Type != schema.FieldTypeFile {
+			data[field.Name] = value
+		}
+	}
+
+	requestData := RequestData(c)
+
+	form := forms.NewRecordUpsert(api.app, record)
+	form.SetFullManageAccess(requestData.Admin != nil || hasAuthManageAccess(api.app.Dao(), record, requestData))
+
+	if err := form.LoadData(data); err != nil {
+		return NewBadRequestError("Failed to revert record.", err)
+	}
+
+	if err := form.Submit(); err != nil {
+		return NewBadRequestError("Failed to revert record.", err)
+	}
+
+	if err := EnrichRecord(c, api.app.Dao(), record); err != nil && api.app.IsDebug() {
+		log.Println(err)
+	}
+
+	setETag(c, record)
+
+	return c.JSON(http.StatusOK, record)
+}
@@ -0,0 +1,309 @@
+package apis
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/spf13/cast"
+)
+
+const (
+	// explainRulesQueryParam enables the admin only api rules explain
+	// mode of the record list, view, update and delete endpoints.
+	explainRulesQueryParam = "explainRules"
+
+	// explainAuthQueryParam is the optional auth record in the format
+	// "collectionNameOrId:recordId" used when evaluating the explained rule
+	// (the rule is evaluated as guest if not set).
+	explainAuthQueryParam = "explainAuth"
+)
+
+// List of the explainable record api actions.
+const (
+	RuleActionList   = "list"
+	RuleActionView   = "view"
+	RuleActionCreate = "create"
+	RuleActionUpdate = "update"
+	RuleActionDelete = "delete"
+)
+
+// RuleExplanation defines the result of a record api rule explain request.
+//
+// swagger:models RuleExplanation
+type RuleExplanation struct {
+	Collection string `json:"collection"`
+	Action     string `json:"action"`
+
+	// Rule is the explained api rule (nil means that only admins can perform the action).
+	Rule *string `json:"rule"`
+
+	// Auth is the auth record used when evaluating the rule (nil for guests).
+	Auth *models.Record `json:"auth"`
+
+	// Variables contains the resolved values of the static
+	// @request.* rule variables (eg. "@request.auth.id").
+	Variables map[string]any `json:"variables"`
+
+	// Conditions contains the individual rule comparison expressions
+	// and the number of records matching each one of them.
+	Conditions []*RuleConditionExplanation `json:"conditions"`
+
+	// TotalItems is the number of records without the rule applied
+	// (aka. 0 or 1 for the single record actions).
+	TotalItems int `json:"totalItems"`
+
+	// MatchedItems is the number of records matching the full rule.
+	MatchedItems int `json:"matchedItems"`
+
+	// Allowed indicates whether the request will be allowed, aka. for
+	// the list action whether the rule is not locked and for the
+	// single record actions whether the record matches the rule.
+	Allowed bool `json:"allowed"`
+
+	// Error is the rule parse/resolve error (if any).
+	Error string `json:"error,omitempty"`
+}
+
+// RuleConditionExplanation defines a single explained rule comparison expression.
+type RuleConditionExplanation struct {
+	Condition    string `json:"condition"`
+	Matched      bool   `json:"matched"`
+	MatchedItems int    `json:"matchedItems"`
+	Error        string `json:"error,omitempty"`
+}
+
+// isExplainRulesRequest checks whether the current request has the explain rules mode enabled.
+func isExplainRulesRequest(c echo.Context) bool {
+	return cast.ToBool(c.QueryParam(explainRulesQueryParam))
+}
+
+// explainRules evaluates the provided api rule against the current
+// request data and responds with a [RuleExplanation] without performing
+// the actual record action.
+//
+// Set recordId for the single record actions.
+func (api *recordApi) explainRules(
+	c echo.Context,
+	collection *models.Collection,
+	action string,
+	rule *string,
+	recordId string,
+) error {
+	requestData := RequestData(c)
+
+	if requestData.Admin == nil {
+		return NewForbiddenError("Only admins can explain the API rules.", nil)
+	}
+
+	// admins bypass the api rules, so evaluate them as guest or as the explainAuth record
+	simulatedData := *requestData
+	simulatedData.Admin = nil
+	simulatedData.AuthRecord = nil
+
+	if explainAuth := c.QueryParam(explainAuthQueryParam); explainAuth != "" {
+		authRecord, err := api.findExplainAuthRecord(explainAuth)
+		if err != nil {
+			return NewBadRequestError("Invalid or missing explain auth record.", err)
+		}
+		simulatedData.AuthRecord = authRecord
+	}
+
+	var filter search.FilterData
+	if action == RuleActionList {
+		filter = search.FilterData(c.QueryParam(search.FilterQueryParam))
+	}
+
+	result, err := explainRule(api.app.Dao(), collection, action, rule, &simulatedData, recordId, filter)
+	if err != nil {
+		return NewBadRequestError("Invalid filter parameters.", err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// explainRule evaluates the provided api rule against the provided request data.
+//
+// The returned error is only for the base records query (eg. invalid filter)
+// since the rule errors are reported as part of the [RuleExplanation].
+func explainRule(
+	dao *daos.Dao,
+	collection *models.Collection,
+	action string,
+	rule *string,
+	requestData *models.RequestData,
+	recordId string,
+	filter search.FilterData,
+) (*RuleExplanation, error) {
+	total, err := countExplainRecords(dao, collection, requestData, recordId, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RuleExplanation{
+		Collection: collection.Name,
+		Action:     action,
+		Rule:       rule,
+		Auth:       requestData.AuthRecord,
+		Variables:  map[string]any{},
+		Conditions: []*RuleConditionExplanation{},
+		TotalItems: total,
+	}
+
+	switch {
+	case rule == nil:
+		// locked - only admins can perform the action
+	case *rule == "":
+		result.MatchedItems = total
+		result.Allowed = action == RuleActionList || total > 0
+	default:
+		if err := explainRuleConditions(dao, result, collection, requestData, recordId, filter); err != nil {
+			result.Error = err.Error()
+		} else {
+			result.Allowed = action == RuleActionList || result.MatchedItems > 0
+		}
+	}
+
+	return result, nil
+}
+
+func explainRuleConditions(
+	dao *daos.Dao,
+	result *RuleExplanation,
+	collection *models.Collection,
+	requestData *models.RequestData,
+	recordId string,
+	filter search.FilterData,
+) error {
+	rule := search.FilterData(*result.Rule)
+
+	identifiers, err := rule.Identifiers()
+	if err != nil {
+		return err
+	}
+
+	// resolve only the static request variables since
+	// the relation ones are evaluated as part of the db query
+	resolver := resolvers.NewRecordFieldResolver(dao, collection, requestData, true)
+	for _, identifier := range identifiers {
+		if !strings.HasPrefix(identifier, "@request.") {
+			continue
+		}
+
+		if value, ok := resolveExplainVariable(resolver, identifier); ok {
+			result.Variables[identifier] = value
+		}
+	}
+
+	conditions, err := rule.Conditions()
+	if err != nil {
+		return err
+	}
+
+	for _, condition := range conditions {
+		explanation := &RuleConditionExplanation{Condition: string(condition)}
+
+		matched, err := countExplainRecords(dao, collection, requestData, recordId, filter, condition)
+		if err != nil {
+			explanation.Error = err.Error()
+		} else {
+			explanation.MatchedItems = matched
+			explanation.Matched = matched > 0
+		}
+
+		result.Conditions = append(result.Conditions, explanation)
+	}
+
+	result.MatchedItems, err = countExplainRecords(dao, collection, requestData, recordId, filter, rule)
+
+	return err
+}
+
+// countExplainRecords returns the number of collection records
+// matching all of the provided (non-empty) filters.
+func countExplainRecords(
+	dao *daos.Dao,
+	collection *models.Collection,
+	requestData *models.RequestData,
+	recordId string,
+	filters ...search.FilterData,
+) (int, error) {
+	resolver := resolvers.NewRecordFieldResolver(dao, collection, requestData, true)
+
+	query := dao.RecordQuery(collection)
+
+	if recordId != "" {
+		query.AndWhere(dbx.HashExp{collection.Name + ".id": recordId})
+	}
+
+	for _, filter := range filters {
+		if filter == "" {
+			continue
+		}
+
+		expr, err := filter.BuildExpr(resolver)
+		if err != nil {
+			return 0, err
+		}
+
+		query.AndWhere(expr)
+	}
+
+	resolver.UpdateQuery(query)
+
+	var total int
+
+	err := query.Select("COUNT(DISTINCT [[" + collection.Name + ".id]])").Row(&total)
+
+	return total, err
+}
+
+func (api *recordApi) findExplainAuthRecord(explainAuth string) (*models.Record, error) {
+	parts := strings.SplitN(explainAuth, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, errors.New("the explain auth record must be in the format collectionNameOrId:recordId")
+	}
+
+	collection, err := api.app.Dao().FindCollectionByNameOrId(parts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	if !collection.IsAuth() {
+		return nil, errors.New("the explain auth collection must be an auth collection")
+	}
+
+	return api.app.Dao().FindRecordById(collection.Id, parts[1])
+}
+
+// resolveExplainVariable returns the static value of the provided
+// @request.* identifier (returns false for non-static identifiers).
+func resolveExplainVariable(resolver *resolvers.RecordFieldResolver, identifier string) (any, bool) {
+	resolved, err := resolver.Resolve(identifier)
+	if err != nil || resolved == nil {
+		return nil, false
+	}
+
+	switch resolved.Identifier {
+	case "NULL":
+		return nil, true
+	case "TRUE":
+		return true, true
+	case "FALSE":
+		return false, true
+	}
+
+	if len(resolved.Params) == 1 && strings.HasPrefix(resolved.Identifier, "{:") {
+		for _, v := range resolved.Params {
+			return v, true
+		}
+	}
+
+	return nil, false
+}
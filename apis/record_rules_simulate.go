@@ -0,0 +1,259 @@
+package apis
+
+import (
+	"errors"
+	"net/http"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/spf13/cast"
+)
+
+// ruleActionMethods maps the record api actions to their http request method
+// (used for the simulated @request.method value).
+var ruleActionMethods = map[string]string{
+	RuleActionList:   http.MethodGet,
+	RuleActionView:   http.MethodGet,
+	RuleActionCreate: http.MethodPost,
+	RuleActionUpdate: http.MethodPatch,
+	RuleActionDelete: http.MethodDelete,
+}
+
+// swagger:models RulesTestRequest
+type RulesTestRequest struct {
+	// Operation is the record api action to test (list, view, create, update or delete).
+	//
+	// Leave it empty to test all collection rules.
+	Operation string `json:"operation"`
+
+	// RecordId is the id of the existing record to test
+	// (required for the view, update and delete operations).
+	RecordId string `json:"recordId"`
+
+	// Auth is the hypothetical auth record data (nil for guest).
+	//
+	// It must contain the auth "collectionId" or "collectionName" key
+	// and it is not required to exist in the database.
+	Auth map[string]any `json:"auth"`
+
+	// Data, Query and Headers are the simulated @request.* values.
+	//
+	// Data is also used as the new record data for the create operation.
+	Data    map[string]any `json:"data"`
+	Query   map[string]any `json:"query"`
+	Headers map[string]any `json:"headers"`
+}
+
+// swagger:models RulesTestResult
+type RulesTestResult struct {
+	Collection string `json:"collection"`
+
+	// Allowed indicates whether all tested rules allow the operation.
+	Allowed bool               `json:"allowed"`
+	Results []*RuleExplanation `json:"results"`
+}
+
+//	@Summary		Тестирование правил доступа
+//	@Description	Проверяет, разрешают ли правила доступа коллекции указанную операцию для гипотетической записи авторизации (запись не создается)
+//	@Tags			Record
+//	@Security		AdminAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			collection	path		string				true	"Идентификатор коллекции"
+//	@Param			body		body		RulesTestRequest	true	"Операция и данные симулируемого запроса"
+//	@Success		200			{object}	RulesTestResult
+//	@Failure		400			{string}	string	"Failed to test the collection rules."
+//	@Failure		401			{string}	string	"The request requires admin authorization token to be set."
+//	@Failure		404			{string}	string	"Not found."
+//	@Router			/collections/{collection}/rules/test [post]
+func (api *recordApi) testRules(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("", "Missing collection context.")
+	}
+
+	body := &RulesTestRequest{}
+	if err := c.Bind(body); err != nil {
+		return NewBadRequestError("Failed to load the submitted data due to invalid formatting.", err)
+	}
+
+	operations, err := api.rulesTestOperations(collection, body)
+	if err != nil {
+		return NewBadRequestError("Failed to test the collection rules.", err)
+	}
+
+	authRecord, err := api.rulesTestAuthRecord(body.Auth)
+	if err != nil {
+		return NewBadRequestError("Failed to test the collection rules.", validation.Errors{"auth": err})
+	}
+
+	result := &RulesTestResult{
+		Collection: collection.Name,
+		Allowed:    true,
+		Results:    make([]*RuleExplanation, 0, len(operations)),
+	}
+
+	for _, operation := range operations {
+		requestData := &models.RequestData{
+			Method:     ruleActionMethods[operation],
+			Query:      map[string]any{},
+			Data:       map[string]any{},
+			Headers:    map[string]any{},
+			AuthRecord: authRecord,
+		}
+		for k, v := range body.Query {
+			requestData.Query[k] = v
+		}
+		for k, v := range body.Headers {
+			requestData.Headers[k] = v
+		}
+		for k, v := range body.Data {
+			requestData.Data[k] = v
+		}
+
+		var explanation *RuleExplanation
+		var explainErr error
+
+		switch operation {
+		case RuleActionCreate:
+			explanation = api.explainCreateRule(collection, requestData)
+		case RuleActionList:
+			explanation, explainErr = explainRule(api.app.Dao(), collection, operation, collection.ListRule, requestData, body.RecordId, "")
+		case RuleActionView:
+			explanation, explainErr = explainRule(api.app.Dao(), collection, operation, collection.ViewRule, requestData, body.RecordId, "")
+		case RuleActionUpdate:
+			explanation, explainErr = explainRule(api.app.Dao(), collection, operation, collection.UpdateRule, requestData, body.RecordId, "")
+		case RuleActionDelete:
+			explanation, explainErr = explainRule(api.app.Dao(), collection, operation, collection.DeleteRule, requestData, body.RecordId, "")
+		}
+
+		if explainErr != nil {
+			return NewBadRequestError("Failed to test the collection rules.", explainErr)
+		}
+
+		if !explanation.Allowed {
+			result.Allowed = false
+		}
+
+		result.Results = append(result.Results, explanation)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// rulesTestOperations returns the normalized list of the operations to test.
+func (api *recordApi) rulesTestOperations(collection *models.Collection, body *RulesTestRequest) ([]string, error) {
+	supported := []string{RuleActionList, RuleActionView}
+	if !collection.IsView() {
+		supported = append(supported, RuleActionCreate, RuleActionUpdate, RuleActionDelete)
+	}
+
+	operations := supported
+	if body.Operation != "" {
+		operations = []string{body.Operation}
+	}
+
+	err := validation.Errors{
+		"operation": validation.Validate(body.Operation, validation.In(list.ToInterfaceSlice(supported)...)),
+		"recordId": validation.Validate(
+			body.RecordId,
+			validation.When(
+				body.Operation == RuleActionView || body.Operation == RuleActionUpdate || body.Operation == RuleActionDelete,
+				validation.Required,
+			),
+		),
+	}.Filter()
+	if err != nil {
+		return nil, err
+	}
+
+	// the single record operations can't be tested without a record
+	if body.RecordId == "" && body.Operation == "" {
+		operations = []string{RuleActionList}
+		if !collection.IsView() {
+			operations = append(operations, RuleActionCreate)
+		}
+	}
+
+	return operations, nil
+}
+
+// rulesTestAuthRecord creates a new (unsaved) auth record model from the provided auth data.
+func (api *recordApi) rulesTestAuthRecord(data map[string]any) (*models.Record, error) {
+	if data == nil {
+		return nil, nil // guest
+	}
+
+	collectionNameOrId := cast.ToString(data[schema.FieldNameCollectionId])
+	if collectionNameOrId == "" {
+		collectionNameOrId = cast.ToString(data[schema.FieldNameCollectionName])
+	}
+	if collectionNameOrId == "" {
+		return nil, errors.New("missing auth collectionId or collectionName")
+	}
+
+	collection, err := api.app.Dao().FindCollectionByNameOrId(collectionNameOrId)
+	if err != nil || !collection.IsAuth() {
+		return nil, errors.New("missing or invalid auth collection")
+	}
+
+	record := models.NewRecord(collection)
+
+	for k, v := range data {
+		if k == schema.FieldNameCollectionId || k == schema.FieldNameCollectionName {
+			continue
+		}
+		record.Set(k, v)
+	}
+
+	if record.Id == "" {
+		record.RefreshId()
+	}
+
+	return record, nil
+}
+
+// explainCreateRule evaluates the collection create rule against a
+// temporary record created from the request data (the record is not persisted).
+func (api *recordApi) explainCreateRule(collection *models.Collection, requestData *models.RequestData) *RuleExplanation {
+	result := &RuleExplanation{
+		Collection: collection.Name,
+		Action:     RuleActionCreate,
+		Rule:       collection.CreateRule,
+		Auth:       requestData.AuthRecord,
+		Variables:  map[string]any{},
+		Conditions: []*RuleConditionExplanation{},
+	}
+
+	testRecord := models.NewRecord(collection)
+
+	form := forms.NewRecordUpsert(api.app, testRecord)
+	form.SetFullManageAccess(true)
+
+	if err := form.LoadData(requestData.Data); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	err := form.DrySubmit(func(txDao *daos.Dao) error {
+		explanation, err := explainRule(txDao, collection, RuleActionCreate, collection.CreateRule, requestData, testRecord.Id, "")
+		if err != nil {
+			return err
+		}
+
+		result = explanation
+
+		return nil
+	})
+	if err != nil {
+		result.Error = err.Error()
+		result.Allowed = false
+	}
+
+	return result
+}
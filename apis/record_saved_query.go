@@ -0,0 +1,299 @@
+package apis
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+// swagger:models SavedQueryRequest
+type SavedQueryRequest struct {
+	Name   string `json:"name" example:"active"`
+	Filter string `json:"filter" example:"active = true"`
+	Sort   string `json:"sort" example:"-created"`
+	Fields string `json:"fields" example:"id,title"`
+	Expand string `json:"expand"`
+	Shared bool   `json:"shared"`
+}
+
+// savedQueriesCollection returns the collection from the current request
+// context if its saved queries could be accessed by the current client
+// (non-admins need access to the collection records list).
+func savedQueriesCollection(c echo.Context) (*models.Collection, error) {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return nil, NewNotFoundError("", "Missing collection context.")
+	}
+
+	admin, _ := c.Get(ContextAdminKey).(*models.Admin)
+	if admin == nil && collection.ListRule == nil {
+		// only admins can access if the rule is nil
+		return nil, NewForbiddenError("Only admins can perform this action.", nil)
+	}
+
+	return collection, nil
+}
+
+// isSavedQueryVisible checks whether the provided saved query
+// could be viewed and run by the current client.
+func isSavedQueryVisible(c echo.Context, query *models.SavedQuery) bool {
+	admin, _ := c.Get(ContextAdminKey).(*models.Admin)
+	if admin != nil || query.Shared {
+		return true
+	}
+
+	authRecord, _ := c.Get(ContextAuthRecordKey).(*models.Record)
+
+	return query.IsOwnedBy(nil, authRecord)
+}
+
+// findVisibleSavedQuery returns the saved query with the request "name"
+// path parameter if it is visible to the current client.
+func (api *recordApi) findVisibleSavedQuery(c echo.Context) (*models.Collection, *models.SavedQuery, error) {
+	collection, err := savedQueriesCollection(c)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	query, err := api.app.Dao().FindSavedQuery(collection, c.PathParam("name"))
+	if err != nil || !isSavedQueryVisible(c, query) {
+		return nil, nil, NewNotFoundError("", err)
+	}
+
+	return collection, query, nil
+}
+
+//	@Summary		Список сохраненных запросов
+//	@Description	Возвращает сохраненные запросы коллекции, доступные текущему клиенту (общие и собственные; администраторам - все). Требуется доступ к списку записей (ListRule)
+//	@Tags			Record
+//	@Security		Auth
+//	@Produce		json
+//	@Param			collection	path		string	true	"Идентификатор коллекции"
+//	@Success		200			{array}		models.SavedQuery
+//	@Failure		403			{string}	string	"Only admins can perform this action."
+//	@Failure		404			{string}	string	"Not found."
+//	@Router			/collections/{collection}/queries [get]
+func (api *recordApi) listSavedQueries(c echo.Context) error {
+	collection, err := savedQueriesCollection(c)
+	if err != nil {
+		return err
+	}
+
+	queries, err := api.app.Dao().FindSavedQueries(collection)
+	if err != nil {
+		return NewBadRequestError("Failed to load the saved queries.", err)
+	}
+
+	result := make([]*models.SavedQuery, 0, len(queries))
+	for _, query := range queries {
+		if isSavedQueryVisible(c, query) {
+			result = append(result, query)
+		}
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+//	@Summary		Сохраненный запрос
+//	@Description	Возвращает сохраненный запрос коллекции по его имени
+//	@Tags			Record
+//	@Security		Auth
+//	@Produce		json
+//	@Param			collection	path		string	true	"Идентификатор коллекции"
+//	@Param			name		path		string	true	"Имя сохраненного запроса"
+//	@Success		200			{object}	models.SavedQuery
+//	@Failure		403			{string}	string	"Only admins can perform this action."
+//	@Failure		404			{string}	string	"Not found."
+//	@Router			/collections/{collection}/queries/{name} [get]
+func (api *recordApi) viewSavedQuery(c echo.Context) error {
+	_, query, err := api.findVisibleSavedQuery(c)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, query)
+}
+
+//	@Summary		Создание сохраненного запроса
+//	@Description	Сохраняет именованный набор параметров filter/sort/fields/expand для списка записей коллекции. Владельцем запроса становится текущий администратор/запись авторизации
+//	@Description	Общие (shared) запросы доступны всем, у кого есть доступ к списку записей (ListRule)
+//	@Tags			Record
+//	@Security		AdminAuth
+//	@Security		RecordAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			collection	path		string				true	"Идентификатор коллекции"
+//	@Param			body		body		SavedQueryRequest	true	"Параметры сохраненного запроса"
+//	@Success		200			{object}	models.SavedQuery
+//	@Failure		400			{string}	string	"Failed to save the query."
+//	@Failure		401			{string}	string	"The request requires admin or record authorization token to be set."
+//	@Failure		403			{string}	string	"Only admins can perform this action."
+//	@Failure		404			{string}	string	"Not found."
+//	@Router			/collections/{collection}/queries [post]
+func (api *recordApi) createSavedQuery(c echo.Context) error {
+	collection, err := savedQueriesCollection(c)
+	if err != nil {
+		return err
+	}
+
+	admin, _ := c.Get(ContextAdminKey).(*models.Admin)
+	authRecord, _ := c.Get(ContextAuthRecordKey).(*models.Record)
+
+	form := forms.NewSavedQueryUpsert(api.app, collection, &models.SavedQuery{}, admin, authRecord)
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	return form.Submit(func(next forms.InterceptorNextFunc[*models.SavedQuery]) forms.InterceptorNextFunc[*models.SavedQuery] {
+		return func(query *models.SavedQuery) error {
+			if err := next(query); err != nil {
+				return NewBadRequestError("Failed to save the query.", err)
+			}
+
+			return c.JSON(http.StatusOK, query)
+		}
+	})
+}
+
+//	@Summary		Изменение сохраненного запроса
+//	@Description	Обновляет сохраненный запрос коллекции. Доступно владельцу запроса и администраторам
+//	@Tags			Record
+//	@Security		AdminAuth
+//	@Security		RecordAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			collection	path		string				true	"Идентификатор коллекции"
+//	@Param			name		path		string				true	"Имя сохраненного запроса"
+//	@Param			body		body		SavedQueryRequest	true	"Параметры сохраненного запроса"
+//	@Success		200			{object}	models.SavedQuery
+//	@Failure		400			{string}	string	"Failed to save the query."
+//	@Failure		401			{string}	string	"The request requires admin or record authorization token to be set."
+//	@Failure		403			{string}	string	"Only admins and the query owner can perform this action."
+//	@Failure		404			{string}	string	"Not found."
+//	@Router			/collections/{collection}/queries/{name} [patch]
+func (api *recordApi) updateSavedQuery(c echo.Context) error {
+	collection, query, err := api.findVisibleSavedQuery(c)
+	if err != nil {
+		return err
+	}
+
+	admin, _ := c.Get(ContextAdminKey).(*models.Admin)
+	authRecord, _ := c.Get(ContextAuthRecordKey).(*models.Record)
+	if admin == nil && !query.IsOwnedBy(nil, authRecord) {
+		return NewForbiddenError("Only admins and the query owner can perform this action.", nil)
+	}
+
+	form := forms.NewSavedQueryUpsert(api.app, collection, query, admin, authRecord)
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	return form.Submit(func(next forms.InterceptorNextFunc[*models.SavedQuery]) forms.InterceptorNextFunc[*models.SavedQuery] {
+		return func(query *models.SavedQuery) error {
+			if err := next(query); err != nil {
+				return NewBadRequestError("Failed to save the query.", err)
+			}
+
+			return c.JSON(http.StatusOK, query)
+		}
+	})
+}
+
+//	@Summary		Удаление сохраненного запроса
+//	@Description	Удаляет сохраненный запрос коллекции. Доступно владельцу запроса и администраторам
+//	@Tags			Record
+//	@Security		AdminAuth
+//	@Security		RecordAuth
+//	@Param			collection	path	string	true	"Идентификатор коллекции"
+//	@Param			name		path	string	true	"Имя сохраненного запроса"
+//	@Success		204			"No Content"
+//	@Failure		400			{string}	string	"Failed to delete the query."
+//	@Failure		401			{string}	string	"The request requires admin or record authorization token to be set."
+//	@Failure		403			{string}	string	"Only admins and the query owner can perform this action."
+//	@Failure		404			{string}	string	"Not found."
+//	@Router			/collections/{collection}/queries/{name} [delete]
+func (api *recordApi) deleteSavedQuery(c echo.Context) error {
+	_, query, err := api.findVisibleSavedQuery(c)
+	if err != nil {
+		return err
+	}
+
+	admin, _ := c.Get(ContextAdminKey).(*models.Admin)
+	authRecord, _ := c.Get(ContextAuthRecordKey).(*models.Record)
+	if admin == nil && !query.IsOwnedBy(nil, authRecord) {
+		return NewForbiddenError("Only admins and the query owner can perform this action.", nil)
+	}
+
+	if err := api.app.Dao().DeleteSavedQuery(query); err != nil {
+		return NewBadRequestError("Failed to delete the query.", err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+//	@Summary		Выполнение сохраненного запроса
+//	@Description	Возвращает список записей коллекции с параметрами сохраненного запроса. Фильтр из параметров запроса объединяется с сохраненным фильтром (AND), а sort/fields/expand из параметров запроса заменяют сохраненные значения
+//	@Description	Правило доступа к списку записей (ListRule) применяется как и для обычного списка
+//	@Tags			Record
+//	@Security		Auth
+//	@Produce		json
+//	@Param			collection	path		string	true	"Идентификатор коллекции"
+//	@Param			name		path		string	true	"Имя сохраненного запроса"
+//	@Param			page		query		int		false	"Номер страницы"
+//	@Param			perPage		query		int		false	"Количество записей на странице"
+//	@Param			filter		query		string	false	"Дополнительный фильтр записей"
+//	@Param			sort		query		string	false	"Сортировка записей"
+//	@Param			fields		query		string	false	"Возвращаемые поля"
+//	@Param			expand		query		string	false	"Раскрываемые связи"
+//	@Success		200			{object}	search.Result
+//	@Failure		400			{string}	string	"Invalid filter parameters."
+//	@Failure		403			{string}	string	"Only admins can perform this action."
+//	@Failure		404			{string}	string	"Not found."
+//	@Router			/collections/{collection}/queries/{name}/run [get]
+func (api *recordApi) runSavedQuery(c echo.Context) error {
+	collection, query, err := api.findVisibleSavedQuery(c)
+	if err != nil {
+		return err
+	}
+
+	// forbid users and guests to query special filter/sort fields
+	// (the saved query fields are checked on save)
+	if err := api.checkForForbiddenQueryFields(c); err != nil {
+		return err
+	}
+
+	applySavedQueryParams(c, query)
+
+	return api.listRecords(c, collection)
+}
+
+// applySavedQueryParams merges the saved query parameters with the current request ones.
+func applySavedQueryParams(c echo.Context, query *models.SavedQuery) {
+	// note: the returned query params are cached and reused by the
+	// subsequent c.QueryParam() calls (eg. in the fields serializer)
+	params := c.QueryParams()
+
+	if query.Filter != "" {
+		filter := query.Filter
+		if extra := params.Get(search.FilterQueryParam); extra != "" {
+			filter = "(" + filter + ") && (" + extra + ")"
+		}
+		params.Set(search.FilterQueryParam, filter)
+	}
+
+	defaults := map[string]string{
+		search.SortQueryParam: query.Sort,
+		"fields":              query.Fields,
+		expandQueryParam:      query.Expand,
+	}
+	for key, value := range defaults {
+		if value != "" && params.Get(key) == "" {
+			params.Set(key, value)
+		}
+	}
+
+	c.Request().URL.RawQuery = params.Encode()
+}
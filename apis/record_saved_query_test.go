@@ -0,0 +1,368 @@
+package apis_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestSavedQueriesList(t *testing.T) {
+	scenarios := []tests.ApiScenario{
+		{
+			Name:            "guest in admin only collection",
+			Method:          http.MethodGet,
+			Url:             "/api/collections/demo1/queries",
+			ExpectedStatus:  403,
+			ExpectedContent: []string{`"message":"Only admins can perform this action."`},
+		},
+		{
+			Name:           "guest",
+			Method:         http.MethodGet,
+			Url:            "/api/collections/demo2/queries",
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"id":"savedquery00001"`,
+			},
+			NotExpectedContent: []string{
+				`"id":"savedquery00002"`,
+			},
+		},
+		{
+			Name:   "query owner",
+			Method: http.MethodGet,
+			Url:    "/api/collections/demo2/queries",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgOwnerToken,
+			},
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"id":"savedquery00001"`,
+				`"id":"savedquery00002"`,
+			},
+		},
+		{
+			Name:   "admin in admin only collection",
+			Method: http.MethodGet,
+			Url:    "/api/collections/demo1/queries",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgAdminToken,
+			},
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"id":"savedquery00003"`,
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
+
+func TestSavedQueryView(t *testing.T) {
+	scenarios := []tests.ApiScenario{
+		{
+			Name:            "missing query",
+			Method:          http.MethodGet,
+			Url:             "/api/collections/demo2/queries/missing",
+			ExpectedStatus:  404,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "private query of another auth record",
+			Method: http.MethodGet,
+			Url:    "/api/collections/demo2/queries/mine",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgMemberToken,
+			},
+			ExpectedStatus:  404,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:           "shared query",
+			Method:         http.MethodGet,
+			Url:            "/api/collections/demo2/queries/active",
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"id":"savedquery00001"`,
+				`"filter":"active = true"`,
+			},
+		},
+		{
+			Name:   "private query of the owner",
+			Method: http.MethodGet,
+			Url:    "/api/collections/demo2/queries/mine",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgOwnerToken,
+			},
+			ExpectedStatus:  200,
+			ExpectedContent: []string{`"id":"savedquery00002"`},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
+
+func TestSavedQueryCreate(t *testing.T) {
+	scenarios := []tests.ApiScenario{
+		{
+			Name:            "guest",
+			Method:          http.MethodPost,
+			Url:             "/api/collections/demo2/queries",
+			Body:            strings.NewReader(`{"name":"test"}`),
+			ExpectedStatus:  401,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "auth record in admin only collection",
+			Method: http.MethodPost,
+			Url:    "/api/collections/demo1/queries",
+			Body:   strings.NewReader(`{"name":"test"}`),
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgMemberToken,
+			},
+			ExpectedStatus:  403,
+			ExpectedContent: []string{`"message":"Only admins can perform this action."`},
+		},
+		{
+			Name:   "invalid data",
+			Method: http.MethodPost,
+			Url:    "/api/collections/demo2/queries",
+			Body:   strings.NewReader(`{"name":"active","filter":"@collection.demo1.id != ''"}`),
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgMemberToken,
+			},
+			ExpectedStatus: 400,
+			ExpectedContent: []string{
+				`"name":{"code":"validation_saved_query_name_exists"`,
+				`"filter":{"code":"validation_forbidden_query_fields"`,
+			},
+		},
+		{
+			Name:   "auth record",
+			Method: http.MethodPost,
+			Url:    "/api/collections/demo2/queries",
+			Body:   strings.NewReader(`{"name":"test","filter":"title != ''","sort":"-created","shared":true}`),
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgMemberToken,
+			},
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"name":"test"`,
+				`"collectionId":"sz5l5z67tg7gku0"`,
+				`"shared":true`,
+				`"ownerType":"authRecord"`,
+				`"ownerCollectionId":"_pb_users_auth_"`,
+				`"ownerId":"oap640cot4yru2s"`,
+			},
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeCreate": 1,
+				"OnModelAfterCreate":  1,
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
+
+func TestSavedQueryUpdate(t *testing.T) {
+	scenarios := []tests.ApiScenario{
+		{
+			Name:            "guest",
+			Method:          http.MethodPatch,
+			Url:             "/api/collections/demo2/queries/active",
+			Body:            strings.NewReader(`{"sort":"title"}`),
+			ExpectedStatus:  401,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "non owner of a shared query",
+			Method: http.MethodPatch,
+			Url:    "/api/collections/demo2/queries/active",
+			Body:   strings.NewReader(`{"sort":"title"}`),
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgMemberToken,
+			},
+			ExpectedStatus:  403,
+			ExpectedContent: []string{`"message":"Only admins and the query owner can perform this action."`},
+		},
+		{
+			Name:   "owner",
+			Method: http.MethodPatch,
+			Url:    "/api/collections/demo2/queries/mine",
+			Body:   strings.NewReader(`{"sort":"title","shared":true}`),
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgOwnerToken,
+			},
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"id":"savedquery00002"`,
+				`"sort":"title"`,
+				`"shared":true`,
+			},
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeUpdate": 1,
+				"OnModelAfterUpdate":  1,
+			},
+		},
+		{
+			Name:   "admin",
+			Method: http.MethodPatch,
+			Url:    "/api/collections/demo2/queries/mine",
+			Body:   strings.NewReader(`{"filter":"@request.auth.id != ''"}`),
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgAdminToken,
+			},
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"id":"savedquery00002"`,
+				`"filter":"@request.auth.id != ''"`,
+				`"ownerId":"4q1xlclmfloku33"`,
+			},
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeUpdate": 1,
+				"OnModelAfterUpdate":  1,
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
+
+func TestSavedQueryDelete(t *testing.T) {
+	scenarios := []tests.ApiScenario{
+		{
+			Name:            "guest",
+			Method:          http.MethodDelete,
+			Url:             "/api/collections/demo2/queries/active",
+			ExpectedStatus:  401,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "private query of another auth record",
+			Method: http.MethodDelete,
+			Url:    "/api/collections/demo2/queries/mine",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgMemberToken,
+			},
+			ExpectedStatus:  404,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "owner",
+			Method: http.MethodDelete,
+			Url:    "/api/collections/demo2/queries/mine",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgOwnerToken,
+			},
+			ExpectedStatus: 204,
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeDelete": 1,
+				"OnModelAfterDelete":  1,
+			},
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if _, err := app.Dao().FindSavedQuery(collection, "mine"); err == nil {
+					t.Fatal("Expected the saved query to be deleted")
+				}
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
+
+func TestSavedQueryRun(t *testing.T) {
+	scenarios := []tests.ApiScenario{
+		{
+			Name:            "guest in admin only collection",
+			Method:          http.MethodGet,
+			Url:             "/api/collections/demo1/queries/all/run",
+			ExpectedStatus:  403,
+			ExpectedContent: []string{`"message":"Only admins can perform this action."`},
+		},
+		{
+			Name:            "guest with private query",
+			Method:          http.MethodGet,
+			Url:             "/api/collections/demo2/queries/mine/run",
+			ExpectedStatus:  404,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:            "guest with forbidden request filter",
+			Method:          http.MethodGet,
+			Url:             "/api/collections/demo2/queries/active/run?filter=@collection.demo1.id!=''",
+			ExpectedStatus:  403,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:           "guest with shared query",
+			Method:         http.MethodGet,
+			Url:            "/api/collections/demo2/queries/active/run",
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"totalItems":2`,
+				`"items":[{"id":"0yxhwia2amd8gec","title":"test3"},{"id":"achvryl401bhse3","title":"test2"}]`,
+			},
+			ExpectedEvents: map[string]int{"OnRecordsListRequest": 1},
+		},
+		{
+			Name:           "guest with shared query and additional request params",
+			Method:         http.MethodGet,
+			Url:            "/api/collections/demo2/queries/active/run?filter=title='test2'&fields=id",
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"totalItems":1`,
+				`"items":[{"id":"achvryl401bhse3"}]`,
+			},
+			ExpectedEvents: map[string]int{"OnRecordsListRequest": 1},
+		},
+		{
+			Name:   "owner with private query",
+			Method: http.MethodGet,
+			Url:    "/api/collections/demo2/queries/mine/run",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgOwnerToken,
+			},
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"totalItems":1`,
+				`"id":"llvuca81nly1qls"`,
+			},
+			ExpectedEvents: map[string]int{"OnRecordsListRequest": 1},
+		},
+		{
+			Name:   "admin in admin only collection",
+			Method: http.MethodGet,
+			Url:    "/api/collections/demo1/queries/all/run?perPage=1",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgAdminToken,
+			},
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"perPage":1`,
+				`"totalItems":3`,
+			},
+			ExpectedEvents: map[string]int{"OnRecordsListRequest": 1},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
@@ -0,0 +1,160 @@
+package apis
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+// swagger:models RecordShareRequest
+type RecordShareRequest struct {
+	SubjectType       string `json:"subjectType" example:"user"`
+	SubjectCollection string `json:"subjectCollection" example:"users"`
+	SubjectId         string `json:"subjectId"`
+	Permission        string `json:"permission" example:"view"`
+}
+
+// shareableRecord returns the record from the current request context
+// that could be shared by the current admin or auth record.
+//
+// Only admins and the record owners (aka. "@acl.owner") can manage the record shares.
+func (api *recordApi) shareableRecord(c echo.Context) (*models.Record, error) {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return nil, NewNotFoundError("", "Missing collection context.")
+	}
+
+	recordId := c.PathParam("id")
+	if recordId == "" {
+		return nil, NewNotFoundError("", nil)
+	}
+
+	record, err := api.app.Dao().FindRecordById(collection.Id, recordId)
+	if err != nil {
+		return nil, NewNotFoundError("", err)
+	}
+
+	requestData := RequestData(c)
+	if requestData.Admin != nil {
+		return record, nil
+	}
+
+	ownerFunc := func(q *dbx.SelectQuery) error {
+		resolver := resolvers.NewRecordFieldResolver(api.app.Dao(), collection, requestData, true)
+		expr, err := search.FilterData("@acl.owner = true").BuildExpr(resolver)
+		if err != nil {
+			return err
+		}
+		resolver.UpdateQuery(q)
+		q.AndWhere(expr)
+		return nil
+	}
+
+	if _, err := api.app.Dao().FindRecordById(collection.Id, recordId, ownerFunc); err != nil {
+		return nil, NewForbiddenError("Only admins and the record owners can manage the record shares.", nil)
+	}
+
+	return record, nil
+}
+
+//	@Summary		Список доступов к записи
+//	@Description	Возвращает ACL записи (владелец и пользователи/группы с доступом). Доступно администраторам и владельцам записи
+//	@Tags			Record
+//	@Security		AdminAuth
+//	@Security		RecordAuth
+//	@Produce		json
+//	@Param			collection	path		string	true	"Идентификатор коллекции"
+//	@Param			id			path		string	true	"Идентификатор записи"
+//	@Success		200			{array}		models.RecordShare
+//	@Failure		401			{string}	string	"The request requires admin or record authorization token to be set."
+//	@Failure		403			{string}	string	"Only admins and the record owners can manage the record shares."
+//	@Failure		404			{string}	string	"Not found."
+//	@Router			/collections/{collection}/records/{id}/share [get]
+func (api *recordApi) listShares(c echo.Context) error {
+	record, err := api.shareableRecord(c)
+	if err != nil {
+		return err
+	}
+
+	shares, err := api.app.Dao().FindRecordShares(record)
+	if err != nil {
+		return NewBadRequestError("Failed to load the record shares.", err)
+	}
+
+	return c.JSON(http.StatusOK, shares)
+}
+
+//	@Summary		Предоставление доступа к записи
+//	@Description	Предоставляет пользователю или группе доступ к записи (view, edit или owner) или изменяет уровень существующего доступа. Группа - это запись, на которую ссылаются поля-связи auth записей ее участников
+//	@Tags			Record
+//	@Security		AdminAuth
+//	@Security		RecordAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			collection	path		string				true	"Идентификатор коллекции"
+//	@Param			id			path		string				true	"Идентификатор записи"
+//	@Param			body		body		RecordShareRequest	true	"Данные доступа"
+//	@Success		200			{object}	models.RecordShare
+//	@Failure		400			{string}	string	"Failed to share the record."
+//	@Failure		401			{string}	string	"The request requires admin or record authorization token to be set."
+//	@Failure		403			{string}	string	"Only admins and the record owners can manage the record shares."
+//	@Failure		404			{string}	string	"Not found."
+//	@Router			/collections/{collection}/records/{id}/share [post]
+func (api *recordApi) share(c echo.Context) error {
+	record, err := api.shareableRecord(c)
+	if err != nil {
+		return err
+	}
+
+	form := forms.NewRecordShareUpsert(api.app, record)
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	return form.Submit(func(next forms.InterceptorNextFunc[*models.RecordShare]) forms.InterceptorNextFunc[*models.RecordShare] {
+		return func(share *models.RecordShare) error {
+			if err := next(share); err != nil {
+				return NewBadRequestError("Failed to share the record.", err)
+			}
+
+			return c.JSON(http.StatusOK, share)
+		}
+	})
+}
+
+//	@Summary		Отзыв доступа к записи
+//	@Description	Удаляет указанный доступ из ACL записи
+//	@Tags			Record
+//	@Security		AdminAuth
+//	@Security		RecordAuth
+//	@Param			collection	path	string	true	"Идентификатор коллекции"
+//	@Param			id			path	string	true	"Идентификатор записи"
+//	@Param			shareId		path	string	true	"Идентификатор доступа"
+//	@Success		204			"No Content"
+//	@Failure		400			{string}	string	"Failed to delete the record share."
+//	@Failure		401			{string}	string	"The request requires admin or record authorization token to be set."
+//	@Failure		403			{string}	string	"Only admins and the record owners can manage the record shares."
+//	@Failure		404			{string}	string	"Not found."
+//	@Router			/collections/{collection}/records/{id}/share/{shareId} [delete]
+func (api *recordApi) unshare(c echo.Context) error {
+	record, err := api.shareableRecord(c)
+	if err != nil {
+		return err
+	}
+
+	share, err := api.app.Dao().FindRecordShareById(c.PathParam("shareId"))
+	if err != nil || share.CollectionId != record.Collection().Id || share.RecordId != record.Id {
+		return NewNotFoundError("", err)
+	}
+
+	if err := api.app.Dao().DeleteRecordShare(share); err != nil {
+		return NewBadRequestError("Failed to delete the record share.", err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
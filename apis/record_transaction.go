@@ -0,0 +1,258 @@
+package apis
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+const maxTransactionOperations int = 100
+
+const (
+	TransactionOperationCreate = "create"
+	TransactionOperationUpdate = "update"
+	TransactionOperationDelete = "delete"
+)
+
+// swagger:models TransactionOperation
+type TransactionOperation struct {
+	Action     string         `json:"action"`
+	Collection string         `json:"collection"`
+	Id         string         `json:"id"`
+	Data       map[string]any `json:"data"`
+}
+
+// Validate implements [validation.Validatable] interface.
+func (op TransactionOperation) Validate() error {
+	return validation.ValidateStruct(&op,
+		validation.Field(
+			&op.Action,
+			validation.Required,
+			validation.In(TransactionOperationCreate, TransactionOperationUpdate, TransactionOperationDelete),
+		),
+		validation.Field(&op.Collection, validation.Required),
+		validation.Field(&op.Id, validation.When(op.Action != TransactionOperationCreate, validation.Required)),
+	)
+}
+
+// swagger:models TransactionRequest
+//
+// Only json data (without files) is supported and the record request
+// hooks (eg. OnRecordBeforeCreateRequest) are not triggered.
+//
+// Records created in the same transaction could be referenced
+// by explicitly setting their "id" in the create operation data.
+type TransactionRequest struct {
+	Operations []*TransactionOperation `json:"operations"`
+}
+
+// Validate implements [validation.Validatable] interface.
+func (r TransactionRequest) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Operations, validation.Required, validation.Length(1, maxTransactionOperations)),
+	)
+}
+
+// bindRecordTransactionApi registers the record transaction and batch api endpoints.
+func bindRecordTransactionApi(app core.App, rg *echo.Group) {
+	api := recordTransactionApi{app: app}
+
+	rg.POST("/transactions", api.submit, ActivityLogger(app))
+	rg.POST("/batch", api.batch, ActivityLogger(app))
+}
+
+type recordTransactionApi struct {
+	app core.App
+}
+
+//	@Summary		Выполнение транзакции
+//	@Description	Атомарно выполняет последовательность операций над записями разных коллекций с проверкой правил доступа (при ошибке любой операции все изменения откатываются)
+//	@Tags			Record
+//	@Security		Auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body	TransactionRequest	true	"Список операций"
+//	@Success		200		{array}		models.Record	"Записи в порядке операций (null для удаления)"
+//	@Failure		400		{string}	string			"Failed to execute transaction operation."
+//	@Failure		403		{string}	string			"Only admins can perform this action."
+//	@Router			/transactions [post]
+func (api *recordTransactionApi) submit(c echo.Context) error {
+	// note: extracted before the bind to preserve the request body
+	requestData := RequestData(c)
+
+	body := new(TransactionRequest)
+	if err := c.Bind(body); err != nil {
+		return NewBadRequestError("Failed to load the submitted data due to invalid formatting.", err)
+	}
+
+	if err := body.Validate(); err != nil {
+		return NewBadRequestError("An error occurred while validating the submitted data.", err)
+	}
+
+	results := make([]*models.Record, len(body.Operations))
+
+	txErr := api.app.Dao().RunInTransaction(func(txDao *daos.Dao) error {
+		for i, op := range body.Operations {
+			record, err := api.execOperation(txDao, requestData, op)
+			if err != nil {
+				var apiErr *ApiError
+				if errors.As(err, &apiErr) {
+					apiErr.Message = fmt.Sprintf("Operation #%d: %s", i, apiErr.Message)
+					return apiErr
+				}
+
+				return NewBadRequestError(fmt.Sprintf("Failed to execute transaction operation #%d.", i), err)
+			}
+
+			results[i] = record
+		}
+
+		return nil
+	})
+	if txErr != nil {
+		return txErr
+	}
+
+	enrich := make([]*models.Record, 0, len(results))
+	for _, r := range results {
+		if r != nil {
+			enrich = append(enrich, r)
+		}
+	}
+
+	if err := EnrichRecords(c, api.app.Dao(), enrich); err != nil && api.app.IsDebug() {
+		log.Println(err)
+	}
+
+	return c.JSON(http.StatusOK, results)
+}
+
+// execOperation executes a single transaction operation and returns
+// the created or updated record (nil for delete).
+func (api *recordTransactionApi) execOperation(
+	txDao *daos.Dao,
+	requestData *models.RequestData,
+	op *TransactionOperation,
+) (*models.Record, error) {
+	collection, err := txDao.FindCollectionByNameOrId(op.Collection)
+	if err != nil || collection == nil {
+		return nil, NewNotFoundError("Missing collection context.", err)
+	}
+
+	if collection.IsView() {
+		return nil, NewBadRequestError("View collection records cannot be modified.", nil)
+	}
+
+	if op.Data == nil {
+		op.Data = map[string]any{}
+	}
+
+	// the operation specific request data (used in the rules resolving)
+	opRequestData := *requestData
+	opRequestData.Data = op.Data
+
+	var rule *string
+	switch op.Action {
+	case TransactionOperationCreate:
+		opRequestData.Method = http.MethodPost
+		rule = collection.CreateRule
+	case TransactionOperationUpdate:
+		opRequestData.Method = http.MethodPatch
+		rule = collection.UpdateRule
+	default:
+		opRequestData.Method = http.MethodDelete
+		rule = collection.DeleteRule
+	}
+
+	if requestData.Admin == nil && rule == nil {
+		// only admins can access if the rule is nil
+		return nil, NewForbiddenError("Only admins can perform this action.", nil)
+	}
+
+	ruleFunc := func(q *dbx.SelectQuery) error {
+		if requestData.Admin == nil && rule != nil && *rule != "" {
+			resolver := resolvers.NewRecordFieldResolver(txDao, collection, &opRequestData, true)
+			expr, err := search.FilterData(*rule).BuildExpr(resolver)
+			if err != nil {
+				return err
+			}
+			resolver.UpdateQuery(q)
+			q.AndWhere(expr)
+		}
+		return nil
+	}
+
+	if op.Action == TransactionOperationCreate {
+		record := models.NewRecord(collection)
+
+		// replace modifiers fields so that the resolved value is always
+		// available when accessing requestData.Data using just the field name
+		if opRequestData.HasModifierDataKeys() {
+			opRequestData.Data = record.ReplaceModifers(op.Data)
+		}
+
+		form := forms.NewRecordUpsert(api.app, record)
+		form.SetDao(txDao)
+		form.SetFullManageAccess(requestData.Admin != nil)
+		if err := form.LoadData(op.Data); err != nil {
+			return nil, NewBadRequestError("Failed to load the submitted data due to invalid formatting.", err)
+		}
+
+		if err := form.Submit(); err != nil {
+			return nil, NewBadRequestError("Failed to create record.", err)
+		}
+
+		// check the persisted record against the create rule
+		if _, err := txDao.FindRecordById(collection.Id, record.Id, ruleFunc); err != nil {
+			return nil, NewBadRequestError("Failed to create record.", fmt.Errorf("create rule failure: %w", err))
+		}
+
+		return record, nil
+	}
+
+	// eager fetch the record so that the modifier field values are replaced
+	if op.Action == TransactionOperationUpdate && opRequestData.HasModifierDataKeys() {
+		record, err := txDao.FindRecordById(collection.Id, op.Id)
+		if err != nil || record == nil {
+			return nil, NewNotFoundError("", err)
+		}
+		opRequestData.Data = record.ReplaceModifers(op.Data)
+	}
+
+	record, err := txDao.FindRecordById(collection.Id, op.Id, ruleFunc)
+	if err != nil || record == nil {
+		return nil, NewNotFoundError("", err)
+	}
+
+	if op.Action == TransactionOperationDelete {
+		if err := deleteOrTrashRecord(txDao, record); err != nil {
+			return nil, NewBadRequestError("Failed to delete record. Make sure that the record is not part of a required relation reference.", err)
+		}
+
+		return nil, nil
+	}
+
+	form := forms.NewRecordUpsert(api.app, record)
+	form.SetDao(txDao)
+	form.SetFullManageAccess(requestData.Admin != nil || hasAuthManageAccess(txDao, record, &opRequestData))
+	if err := form.LoadData(op.Data); err != nil {
+		return nil, NewBadRequestError("Failed to load the submitted data due to invalid formatting.", err)
+	}
+
+	if err := form.Submit(); err != nil {
+		return nil, NewBadRequestError("Failed to update record.", err)
+	}
+
+	return record, nil
+}
@@ -0,0 +1,167 @@
+package apis
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+// deleteOrTrashRecord deletes the provided record or moves it
+// to the trash if its collection has soft delete enabled.
+func deleteOrTrashRecord(dao *daos.Dao, record *models.Record) error {
+	if record.Collection().IsSoftDelete() {
+		return dao.SoftDeleteRecord(record)
+	}
+
+	return dao.DeleteRecord(record)
+}
+
+// trashRuleFunc returns a query filter function that applies the
+// collection delete rule (aka. only the users that could delete
+// a record could also browse and restore it from the trash).
+func (api *recordApi) trashRuleFunc(c echo.Context, collection *models.Collection) func(q *dbx.SelectQuery) error {
+	requestData := RequestData(c)
+
+	return func(q *dbx.SelectQuery) error {
+		if requestData.Admin == nil && collection.DeleteRule != nil && *collection.DeleteRule != "" {
+			resolver := resolvers.NewRecordFieldResolver(api.app.Dao(), collection, requestData, true)
+			expr, err := search.FilterData(*collection.DeleteRule).BuildExpr(resolver)
+			if err != nil {
+				return err
+			}
+			resolver.UpdateQuery(q)
+			q.AndWhere(expr)
+		}
+		return nil
+	}
+}
+
+//	@Summary		Корзина коллекции
+//	@Description	Возвращает постраничный список мягко удаленных записей коллекции (доступно тем, кто может удалять записи согласно правилу удаления)
+//	@Tags			Record
+//	@Security		Auth
+//	@Produce		json
+//	@Param			collection	path		string	true	"Идентификатор коллекции"
+//	@Param			page		query		int		false	"Номер страницы (по умолчанию 1)"
+//	@Param			perPage		query		int		false	"Количество записей на странице (по умолчанию 30)"
+//	@Param			sort		query		string	false	"Сортировка (по умолчанию по дате удаления, сначала последние)"
+//	@Param			filter		query		string	false	"Фильтр записей"
+//	@Param			skipTotal	query		bool	false	"Не подсчитывать общее количество записей (totalItems и totalPages будут равны -1)"
+//	@Success		200			{object}	SearchResult{items=[]models.Record}
+//	@Failure		400			{string}	string	"The collection doesn't have soft delete enabled."
+//	@Failure		403			{string}	string	"Only admins can perform this action."
+//	@Failure		404			{string}	string	"Not found."
+//	@Router			/collections/{collection}/trash [get]
+func (api *recordApi) trash(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("", "Missing collection context.")
+	}
+
+	if !collection.IsSoftDelete() {
+		return NewBadRequestError("The collection doesn't have soft delete enabled.", nil)
+	}
+
+	// forbid users and guests to query special filter/sort fields
+	if err := api.checkForForbiddenQueryFields(c); err != nil {
+		return err
+	}
+
+	requestData := RequestData(c)
+
+	if requestData.Admin == nil && collection.DeleteRule == nil {
+		// only admins can access if the rule is nil
+		return NewForbiddenError("Only admins can perform this action.", nil)
+	}
+
+	query := api.app.Dao().TrashedRecordQuery(collection)
+
+	if c.QueryParam(search.SortQueryParam) == "" {
+		query.OrderBy(collection.Name + "." + schema.FieldNameDeletedAt + " DESC")
+	}
+
+	if err := api.trashRuleFunc(c, collection)(query); err != nil {
+		return NewBadRequestError("Invalid filter parameters.", err)
+	}
+
+	fieldsResolver := resolvers.NewRecordFieldResolver(
+		api.app.Dao(),
+		collection,
+		requestData,
+		// hidden fields are searchable only by admins
+		requestData.Admin != nil,
+	)
+
+	records := []*models.Record{}
+
+	result, err := search.NewProvider(fieldsResolver).
+		Query(query).
+		ParseAndExec(c.QueryParams().Encode(), &records)
+	if err != nil {
+		return NewBadRequestError("Invalid filter parameters.", err)
+	}
+
+	if err := EnrichRecords(c, api.app.Dao(), records); err != nil && api.app.IsDebug() {
+		log.Println(err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+//	@Summary		Восстановление записи
+//	@Description	Восстанавливает мягко удаленную запись из корзины коллекции (доступно тем, кто может удалять записи согласно правилу удаления)
+//	@Tags			Record
+//	@Security		Auth
+//	@Produce		json
+//	@Param			collection	path		string	true	"Идентификатор коллекции"
+//	@Param			id			path		string	true	"Идентификатор записи"
+//	@Param			expand		query		string	false	"Связи для раскрытия (через запятую)"
+//	@Success		200			{object}	models.Record
+//	@Failure		400			{string}	string	"Failed to restore record."
+//	@Failure		403			{string}	string	"Only admins can perform this action."
+//	@Failure		404			{string}	string	"Not found."
+//	@Router			/collections/{collection}/records/{id}/restore [post]
+func (api *recordApi) restore(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("", "Missing collection context.")
+	}
+
+	if !collection.IsSoftDelete() {
+		return NewBadRequestError("The collection doesn't have soft delete enabled.", nil)
+	}
+
+	recordId := c.PathParam("id")
+	if recordId == "" {
+		return NewNotFoundError("", nil)
+	}
+
+	requestData := RequestData(c)
+
+	if requestData.Admin == nil && collection.DeleteRule == nil {
+		// only admins can access if the rule is nil
+		return NewForbiddenError("Only admins can perform this action.", nil)
+	}
+
+	record, fetchErr := api.app.Dao().FindTrashedRecordById(collection, recordId, api.trashRuleFunc(c, collection))
+	if fetchErr != nil || record == nil {
+		return NewNotFoundError("", fetchErr)
+	}
+
+	if err := api.app.Dao().RestoreRecord(record); err != nil {
+		return NewBadRequestError("Failed to restore record.", err)
+	}
+
+	if err := EnrichRecord(c, api.app.Dao(), record); err != nil && api.app.IsDebug() {
+		log.Println(err)
+	}
+
+	return c.JSON(http.StatusOK, record)
+}
@@ -0,0 +1,61 @@
+package apis
+
+import (
+	"strings"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+const ContextRequestInfoKey = "requestInfo"
+
+// HeaderRequestId is the request header used to forward an existing request
+// identifier (it is also returned back with the response).
+const HeaderRequestId = "X-Request-Id"
+
+// maxRequestIdLength is the max allowed length of a forwarded request identifier.
+const maxRequestIdLength = 100
+
+// RequestInfo exports cached normalized request identity info
+// (request id, ip, user agent, logged auth state, etc.) from the provided context.
+//
+// The request id is taken from the [HeaderRequestId] header (if any)
+// or otherwise it is randomly generated.
+func RequestInfo(c echo.Context) *models.RequestInfo {
+	// return cached to keep the request id consistent between the hooks
+	if v := c.Get(ContextRequestInfoKey); v != nil {
+		if info, ok := v.(*models.RequestInfo); ok {
+			return info
+		}
+	}
+
+	r := c.Request()
+
+	result := &models.RequestInfo{
+		Id:        strings.TrimSpace(r.Header.Get(HeaderRequestId)),
+		Ip:        clientIp(c),
+		UserAgent: r.UserAgent(),
+		Auth:      models.RequestAuthGuest,
+	}
+
+	if result.Id == "" || len(result.Id) > maxRequestIdLength {
+		result.Id = security.RandomString(15)
+	}
+
+	result.Admin, _ = c.Get(ContextAdminKey).(*models.Admin)
+	result.AuthRecord, _ = c.Get(ContextAuthRecordKey).(*models.Record)
+	result.IsAdmin = result.Admin != nil
+
+	if result.AuthRecord != nil {
+		result.Auth = models.RequestAuthRecord
+	} else if result.Admin != nil {
+		result.Auth = models.RequestAuthAdmin
+	}
+
+	c.Response().Header().Set(HeaderRequestId, result.Id)
+
+	c.Set(ContextRequestInfoKey, result)
+
+	return result
+}
@@ -0,0 +1,87 @@
+package apis_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/apis"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+func TestRequestInfo(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		requestId     string
+		admin         *models.Admin
+		record        *models.Record
+		expectedId    string
+		expectedAuth  string
+		expectedAdmin bool
+	}{
+		{"guest with generated id", "", nil, nil, "", models.RequestAuthGuest, false},
+		{"guest with forwarded id", " abc ", nil, nil, "abc", models.RequestAuthGuest, false},
+		{"guest with too long forwarded id", strings.Repeat("a", 101), nil, nil, "", models.RequestAuthGuest, false},
+		{"admin", "abc", &models.Admin{}, nil, "abc", models.RequestAuthAdmin, true},
+		{"auth record", "abc", nil, &models.Record{}, "abc", models.RequestAuthRecord, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = "1.2.3.4:1234"
+			req.Header.Set("User-Agent", "test_agent")
+			if s.requestId != "" {
+				req.Header.Set(apis.HeaderRequestId, s.requestId)
+			}
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+
+			if s.admin != nil {
+				s.admin.Id = "admin_id"
+				c.Set(apis.ContextAdminKey, s.admin)
+			}
+			if s.record != nil {
+				s.record.Id = "record_id"
+				c.Set(apis.ContextAuthRecordKey, s.record)
+			}
+
+			info := apis.RequestInfo(c)
+
+			if s.expectedId != "" && info.Id != s.expectedId {
+				t.Fatalf("Expected id %q, got %q", s.expectedId, info.Id)
+			}
+
+			if s.expectedId == "" && len(info.Id) != 15 {
+				t.Fatalf("Expected a generated id, got %q", info.Id)
+			}
+
+			if v := rec.Header().Get(apis.HeaderRequestId); v != info.Id {
+				t.Fatalf("Expected response %s header %q, got %q", apis.HeaderRequestId, info.Id, v)
+			}
+
+			if info.Ip != "1.2.3.4" {
+				t.Fatalf("Expected ip 1.2.3.4, got %q", info.Ip)
+			}
+
+			if info.UserAgent != "test_agent" {
+				t.Fatalf("Expected user agent test_agent, got %q", info.UserAgent)
+			}
+
+			if info.Auth != s.expectedAuth {
+				t.Fatalf("Expected auth %q, got %q", s.expectedAuth, info.Auth)
+			}
+
+			if info.IsAdmin != s.expectedAdmin {
+				t.Fatalf("Expected IsAdmin %v, got %v", s.expectedAdmin, info.IsAdmin)
+			}
+
+			// should be cached
+			if cached := apis.RequestInfo(c); cached != info {
+				t.Fatal("Expected the request info to be cached")
+			}
+		})
+	}
+}
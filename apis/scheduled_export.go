@@ -0,0 +1,220 @@
+package apis
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+// bindScheduledExportApi registers the scheduled exports api endpoints.
+func bindScheduledExportApi(app core.App, rg *echo.Group) {
+	api := scheduledExportApi{app: app}
+
+	subGroup := rg.Group("/scheduled-exports", ActivityLogger(app), RequireAdminAuth())
+	subGroup.GET("", api.list)
+	subGroup.POST("", api.create)
+	subGroup.GET("/:id", api.view)
+	subGroup.PATCH("/:id", api.update)
+	subGroup.DELETE("/:id", api.delete)
+	subGroup.POST("/:id/run", api.run)
+	subGroup.GET("/:id/files/:filename", api.download)
+}
+
+type scheduledExportApi struct {
+	app core.App
+}
+
+//	@Summary		List scheduled exports
+//	@Description	Returns a paginated scheduled exports list
+//	@Tags			ScheduledExports
+//	@Produce		json
+//	@Param			page	query	int		false	"Page number"
+//	@Param			perPage	query	int		false	"Items per page"
+//	@Param			sort	query	string	false	"Sort fields"
+//	@Param			filter	query	string	false	"Filter expression"
+//	@Security		AdminAuth
+//	@Success		200	{object}	search.Result{items=[]models.ScheduledExport}
+//	@Failure		400	{string}	string	"Something went wrong while processing your request."
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Router			/scheduled-exports [get]
+func (api *scheduledExportApi) list(c echo.Context) error {
+	fieldResolver := search.NewSimpleFieldResolver(
+		"id", "created", "updated", "name", "collectionId", "format",
+		"destination", "enabled", "lastRun", "lastError",
+	)
+
+	exports := []*models.ScheduledExport{}
+
+	result, err := search.NewProvider(fieldResolver).
+		Query(api.app.Dao().ScheduledExportQuery()).
+		ParseAndExec(c.QueryParams().Encode(), &exports)
+
+	if err != nil {
+		return NewBadRequestError("", err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+//	@Summary		View scheduled export
+//	@Description	Returns a single scheduled export by its id
+//	@Tags			ScheduledExports
+//	@Produce		json
+//	@Param			id	path	string	true	"Scheduled export id"
+//	@Security		AdminAuth
+//	@Success		200	{object}	models.ScheduledExport
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Failure		404	{string}	string	"The requested resource wasn't found."
+//	@Router			/scheduled-exports/{id} [get]
+func (api *scheduledExportApi) view(c echo.Context) error {
+	export, err := api.app.Dao().FindScheduledExportById(c.PathParam("id"))
+	if err != nil || export == nil {
+		return NewNotFoundError("", err)
+	}
+
+	return c.JSON(http.StatusOK, export)
+}
+
+//	@Summary		Create scheduled export
+//	@Description	Creates a new recurring collection records export
+//	@Tags			ScheduledExports
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body	forms.ScheduledExportUpsert	true	"Scheduled export data"
+//	@Security		AdminAuth
+//	@Success		200	{object}	models.ScheduledExport
+//	@Failure		400	{string}	string	"Failed to create the scheduled export."
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Router			/scheduled-exports [post]
+func (api *scheduledExportApi) create(c echo.Context) error {
+	return api.upsert(c, &models.ScheduledExport{}, "Failed to create the scheduled export.")
+}
+
+//	@Summary		Update scheduled export
+//	@Description	Updates the scheduled export with the specified id
+//	@Tags			ScheduledExports
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path	string						true	"Scheduled export id"
+//	@Param			body	body	forms.ScheduledExportUpsert	true	"Scheduled export data"
+//	@Security		AdminAuth
+//	@Success		200	{object}	models.ScheduledExport
+//	@Failure		400	{string}	string	"Failed to update the scheduled export."
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Failure		404	{string}	string	"The requested resource wasn't found."
+//	@Router			/scheduled-exports/{id} [patch]
+func (api *scheduledExportApi) update(c echo.Context) error {
+	export, err := api.app.Dao().FindScheduledExportById(c.PathParam("id"))
+	if err != nil || export == nil {
+		return NewNotFoundError("", err)
+	}
+
+	return api.upsert(c, export, "Failed to update the scheduled export.")
+}
+
+func (api *scheduledExportApi) upsert(c echo.Context, export *models.ScheduledExport, failMessage string) error {
+	form := forms.NewScheduledExportUpsert(api.app, export)
+
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("Failed to load the submitted data due to invalid formatting.", err)
+	}
+
+	return form.Submit(func(next forms.InterceptorNextFunc[*models.ScheduledExport]) forms.InterceptorNextFunc[*models.ScheduledExport] {
+		return func(m *models.ScheduledExport) error {
+			if err := next(m); err != nil {
+				return NewBadRequestError(failMessage, err)
+			}
+
+			return c.JSON(http.StatusOK, m)
+		}
+	})
+}
+
+//	@Summary		Delete scheduled export
+//	@Description	Deletes the scheduled export with the specified id (the already exported files are not deleted)
+//	@Tags			ScheduledExports
+//	@Param			id	path	string	true	"Scheduled export id"
+//	@Security		AdminAuth
+//	@Success		204	"No Content"
+//	@Failure		400	{string}	string	"Failed to delete the scheduled export."
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Failure		404	{string}	string	"The requested resource wasn't found."
+//	@Router			/scheduled-exports/{id} [delete]
+func (api *scheduledExportApi) delete(c echo.Context) error {
+	export, err := api.app.Dao().FindScheduledExportById(c.PathParam("id"))
+	if err != nil || export == nil {
+		return NewNotFoundError("", err)
+	}
+
+	if err := api.app.Dao().DeleteScheduledExport(export); err != nil {
+		return NewBadRequestError("Failed to delete the scheduled export.", err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+//	@Summary		Run scheduled export
+//	@Description	Runs the scheduled export immediately (regardless of its schedule and enabled state) and returns the updated export with the run result
+//	@Tags			ScheduledExports
+//	@Produce		json
+//	@Param			id	path	string	true	"Scheduled export id"
+//	@Security		AdminAuth
+//	@Success		200	{object}	models.ScheduledExport
+//	@Failure		400	{string}	string	"Failed to run the scheduled export."
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Failure		404	{string}	string	"The requested resource wasn't found."
+//	@Router			/scheduled-exports/{id}/run [post]
+func (api *scheduledExportApi) run(c echo.Context) error {
+	export, err := api.app.Dao().FindScheduledExportById(c.PathParam("id"))
+	if err != nil || export == nil {
+		return NewNotFoundError("", err)
+	}
+
+	if err := api.app.RunScheduledExport(c.Request().Context(), export); err != nil {
+		return NewBadRequestError("Failed to run the scheduled export.", err)
+	}
+
+	return c.JSON(http.StatusOK, export)
+}
+
+//	@Summary		Download scheduled export file
+//	@Description	Downloads a single exported file of the scheduled export
+//	@Tags			ScheduledExports
+//	@Param			id			path	string	true	"Scheduled export id"
+//	@Param			filename	path	string	true	"Exported file name"
+//	@Security		AdminAuth
+//	@Success		200	"OK"
+//	@Failure		400	{string}	string	"Failed to load the export file."
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Failure		404	{string}	string	"The requested resource wasn't found."
+//	@Router			/scheduled-exports/{id}/files/{filename} [get]
+func (api *scheduledExportApi) download(c echo.Context) error {
+	export, err := api.app.Dao().FindScheduledExportById(c.PathParam("id"))
+	if err != nil || export == nil {
+		return NewNotFoundError("", err)
+	}
+
+	filename := c.PathParam("filename")
+	if !strings.HasPrefix(filename, export.Name+"_") || strings.ContainsAny(filename, `/\`) {
+		return NewNotFoundError("", nil)
+	}
+
+	fs, err := api.app.NewFilesystem()
+	if err != nil {
+		return NewBadRequestError("Failed to load the export file.", err)
+	}
+	defer fs.Close()
+
+	key := export.StoragePrefix() + "/" + filename
+
+	if exists, _ := fs.Exists(key); !exists {
+		return NewNotFoundError("", nil)
+	}
+
+	return fs.Serve(c.Response(), c.Request(), key, filename)
+}
@@ -0,0 +1,334 @@
+package apis_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestScheduledExportsList(t *testing.T) {
+	scenarios := []tests.ApiScenario{
+		{
+			Name:            "unauthorized",
+			Method:          http.MethodGet,
+			Url:             "/api/scheduled-exports",
+			ExpectedStatus:  401,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "authorized as auth record",
+			Method: http.MethodGet,
+			Url:    "/api/scheduled-exports",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgOwnerToken,
+			},
+			ExpectedStatus:  401,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "authorized as admin",
+			Method: http.MethodGet,
+			Url:    "/api/scheduled-exports?filter=enabled=true",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgAdminToken,
+			},
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"totalItems":1`,
+				`"name":"demo2_active"`,
+			},
+			NotExpectedContent: []string{
+				`"name":"demo2_weekly"`,
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
+
+func TestScheduledExportView(t *testing.T) {
+	scenarios := []tests.ApiScenario{
+		{
+			Name:            "unauthorized",
+			Method:          http.MethodGet,
+			Url:             "/api/scheduled-exports/schedexport0001",
+			ExpectedStatus:  401,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "missing export",
+			Method: http.MethodGet,
+			Url:    "/api/scheduled-exports/missing",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgAdminToken,
+			},
+			ExpectedStatus:  404,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "existing export",
+			Method: http.MethodGet,
+			Url:    "/api/scheduled-exports/schedexport0002",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgAdminToken,
+			},
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"id":"schedexport0002"`,
+				`"destination":"email"`,
+				`"emails":["test@example.com"]`,
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
+
+func TestScheduledExportCreate(t *testing.T) {
+	scenarios := []tests.ApiScenario{
+		{
+			Name:            "unauthorized",
+			Method:          http.MethodPost,
+			Url:             "/api/scheduled-exports",
+			Body:            strings.NewReader(`{"name":"new"}`),
+			ExpectedStatus:  401,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "invalid data",
+			Method: http.MethodPost,
+			Url:    "/api/scheduled-exports",
+			Body:   strings.NewReader(`{"name":"demo2_active","collection":"missing","schedule":"invalid"}`),
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgAdminToken,
+			},
+			ExpectedStatus: 400,
+			ExpectedContent: []string{
+				`"name":{"code":"validation_scheduled_export_name_exists"`,
+				`"collection":{"code":"validation_missing_collection"`,
+				`"schedule":{"code":"validation_invalid_cron"`,
+			},
+		},
+		{
+			Name:   "valid data",
+			Method: http.MethodPost,
+			Url:    "/api/scheduled-exports",
+			Body: strings.NewReader(`{
+				"name":"new",
+				"collection":"demo2",
+				"filter":"active = true",
+				"format":"json",
+				"schedule":"0 6 * * *",
+				"destination":"email",
+				"emails":["test@example.com"],
+				"enabled":true
+			}`),
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgAdminToken,
+			},
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"name":"new"`,
+				`"collectionId":"sz5l5z67tg7gku0"`,
+				`"format":"json"`,
+				`"enabled":true`,
+			},
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeCreate": 1,
+				"OnModelAfterCreate":  1,
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
+
+func TestScheduledExportUpdate(t *testing.T) {
+	scenarios := []tests.ApiScenario{
+		{
+			Name:            "unauthorized",
+			Method:          http.MethodPatch,
+			Url:             "/api/scheduled-exports/schedexport0001",
+			Body:            strings.NewReader(`{"enabled":false}`),
+			ExpectedStatus:  401,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "missing export",
+			Method: http.MethodPatch,
+			Url:    "/api/scheduled-exports/missing",
+			Body:   strings.NewReader(`{"enabled":false}`),
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgAdminToken,
+			},
+			ExpectedStatus:  404,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "valid data",
+			Method: http.MethodPatch,
+			Url:    "/api/scheduled-exports/schedexport0001",
+			Body:   strings.NewReader(`{"enabled":false,"prefix":"reports"}`),
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgAdminToken,
+			},
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"id":"schedexport0001"`,
+				`"enabled":false`,
+				`"prefix":"reports"`,
+			},
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeUpdate": 1,
+				"OnModelAfterUpdate":  1,
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
+
+func TestScheduledExportDelete(t *testing.T) {
+	scenarios := []tests.ApiScenario{
+		{
+			Name:            "unauthorized",
+			Method:          http.MethodDelete,
+			Url:             "/api/scheduled-exports/schedexport0001",
+			ExpectedStatus:  401,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "existing export",
+			Method: http.MethodDelete,
+			Url:    "/api/scheduled-exports/schedexport0001",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgAdminToken,
+			},
+			ExpectedStatus: 204,
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeDelete": 1,
+				"OnModelAfterDelete":  1,
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
+
+func TestScheduledExportRun(t *testing.T) {
+	scenarios := []tests.ApiScenario{
+		{
+			Name:            "unauthorized",
+			Method:          http.MethodPost,
+			Url:             "/api/scheduled-exports/schedexport0001/run",
+			ExpectedStatus:  401,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "missing export",
+			Method: http.MethodPost,
+			Url:    "/api/scheduled-exports/missing/run",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgAdminToken,
+			},
+			ExpectedStatus:  404,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "existing export",
+			Method: http.MethodPost,
+			Url:    "/api/scheduled-exports/schedexport0001/run",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgAdminToken,
+			},
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"id":"schedexport0001"`,
+				`"lastFile":"exports/demo2/demo2_active_`,
+				`"lastError":""`,
+			},
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeUpdate": 1,
+				"OnModelAfterUpdate":  1,
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
+
+func TestScheduledExportDownload(t *testing.T) {
+	uploadExportFile := func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+		fs, err := app.NewFilesystem()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer fs.Close()
+
+		if err := fs.Upload([]byte("id,title\n"), "exports/demo2/demo2_active_20261015000000.csv"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	scenarios := []tests.ApiScenario{
+		{
+			Name:            "unauthorized",
+			Method:          http.MethodGet,
+			Url:             "/api/scheduled-exports/schedexport0001/files/demo2_active_20261015000000.csv",
+			BeforeTestFunc:  uploadExportFile,
+			ExpectedStatus:  401,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:           "file of another export",
+			Method:         http.MethodGet,
+			Url:            "/api/scheduled-exports/schedexport0002/files/demo2_active_20261015000000.csv",
+			BeforeTestFunc: uploadExportFile,
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgAdminToken,
+			},
+			ExpectedStatus:  404,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "missing file",
+			Method: http.MethodGet,
+			Url:    "/api/scheduled-exports/schedexport0001/files/demo2_active_20261015000000.csv",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgAdminToken,
+			},
+			ExpectedStatus:  404,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:           "existing file",
+			Method:         http.MethodGet,
+			Url:            "/api/scheduled-exports/schedexport0001/files/demo2_active_20261015000000.csv",
+			BeforeTestFunc: uploadExportFile,
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgAdminToken,
+			},
+			ExpectedStatus:  200,
+			ExpectedContent: []string{"id,title"},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
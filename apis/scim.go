@@ -0,0 +1,1167 @@
+package apis
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/registry"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/scim"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/spf13/cast"
+	"gorm.io/gorm"
+)
+
+// bindScimApi registers the SCIM 2.0 users and groups provisioning endpoints.
+//
+// The SCIM Users are mapped onto the GORM registry users (userName -> name)
+// and the SCIM Groups onto the distinct values of the users groups field
+// (the group id is its display name). Because of that a group exists only
+// as long as it has at least one member.
+//
+// The endpoints require admin authorization (either with an admin auth token
+// or with an admin api key with the "users" scope). Since most identity providers
+// could send only a bearer token, the api key is also accepted as
+// "Authorization: Bearer pbk_...".
+func bindScimApi(app core.App, e *echo.Echo) {
+	api := scimApi{app: app}
+
+	subGroup := e.Group(
+		"/scim/v2",
+		ActivityLogger(app),
+		scimBearerApiKey(),
+		RequireApiKeyAuth(app, ApiKeyScopeUsers),
+		RequireAdminAuth(),
+	)
+	subGroup.GET("/ServiceProviderConfig", api.serviceProviderConfig)
+	subGroup.GET("/Users", api.listUsers)
+	subGroup.POST("/Users", api.createUser)
+	subGroup.GET("/Users/:id", api.viewUser)
+	subGroup.PUT("/Users/:id", api.replaceUser)
+	subGroup.PATCH("/Users/:id", api.patchUser)
+	subGroup.DELETE("/Users/:id", api.deleteUser)
+	subGroup.GET("/Groups", api.listGroups)
+	subGroup.POST("/Groups", api.createGroup)
+	subGroup.GET("/Groups/:id", api.viewGroup)
+	subGroup.PUT("/Groups/:id", api.replaceGroup)
+	subGroup.PATCH("/Groups/:id", api.patchGroup)
+	subGroup.DELETE("/Groups/:id", api.deleteGroup)
+}
+
+// scimBearerApiKey middleware loads the "Authorization: Bearer pbk_..."
+// api key as [ApiKeyHeader] (if not already set).
+func scimBearerApiKey() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			token := strings.TrimPrefix(c.Request().Header.Get("Authorization"), "Bearer ")
+
+			if c.Request().Header.Get(ApiKeyHeader) == "" && strings.HasPrefix(token, models.ApiKeyPrefix) {
+				c.Request().Header.Set(ApiKeyHeader, token)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+type scimApi struct {
+	app core.App
+}
+
+// scimError is a SCIM error response that is returned
+// from the internal helpers to the route handlers.
+type scimError struct {
+	status   int
+	scimType string
+	detail   string
+}
+
+func (e *scimError) Error() string {
+	return e.detail
+}
+
+func newScimError(status int, scimType string, detail string) *scimError {
+	return &scimError{status: status, scimType: scimType, detail: detail}
+}
+
+var errScimNotFound = newScimError(http.StatusNotFound, "", "Resource not found.")
+
+// respond writes the data as SCIM json response.
+func (api *scimApi) respond(c echo.Context, status int, data any) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return api.respondError(c, err)
+	}
+
+	return c.Blob(status, scim.ContentType, raw)
+}
+
+// respondError writes the error as SCIM error response
+// (non [scimError] errors are returned as 500).
+func (api *scimApi) respondError(c echo.Context, err error) error {
+	var scimErr *scimError
+	if !errors.As(err, &scimErr) {
+		if api.app.IsDebug() {
+			log.Println("SCIM request failed:", err)
+		}
+		scimErr = newScimError(http.StatusInternalServerError, "", "Something went wrong while processing your request.")
+	}
+
+	raw, _ := json.Marshal(scim.NewError(scimErr.status, scimErr.scimType, scimErr.detail))
+
+	return c.Blob(scimErr.status, scim.ContentType, raw)
+}
+
+// readBody decodes the request json body into dst
+// (the SCIM content type is not supported by the default binder).
+func (api *scimApi) readBody(c echo.Context, dst any) error {
+	if err := json.NewDecoder(c.Request().Body).Decode(dst); err != nil {
+		return newScimError(http.StatusBadRequest, scim.ErrorTypeInvalidSyntax, "Failed to load the submitted data due to invalid formatting.")
+	}
+
+	return nil
+}
+
+func (api *scimApi) registry(c echo.Context) (*registry.Registry, error) {
+	name, _ := c.Get("registry").(string)
+
+	return registry.Get(name)
+}
+
+func (api *scimApi) location(c echo.Context, resource string, id string) string {
+	return c.Scheme() + "://" + c.Request().Host + "/scim/v2/" + resource + "/" + url.PathEscape(id)
+}
+
+//	@Summary		SCIM service provider config
+//	@Description	Returns the supported SCIM features
+//	@Tags			Scim
+//	@Produce		json
+//	@Security		AdminAuth
+//	@Success		200	{object}	map[string]any
+//	@Failure		401	{string}	string	"The request requires valid authorization token or API key to be set."
+//	@Router			/scim/v2/ServiceProviderConfig [get]
+func (api *scimApi) serviceProviderConfig(c echo.Context) error {
+	return api.respond(c, http.StatusOK, map[string]any{
+		"schemas":        []string{scim.SchemaServiceProviderConfig},
+		"patch":          map[string]any{"supported": true},
+		"bulk":           map[string]any{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]any{"supported": true, "maxResults": scim.MaxCount},
+		"changePassword": map[string]any{"supported": true},
+		"sort":           map[string]any{"supported": false},
+		"etag":           map[string]any{"supported": false},
+		"authenticationSchemes": []map[string]any{{
+			"type":        "oauthbearertoken",
+			"name":        "Bearer token",
+			"description": "Admin api key with the users scope",
+		}},
+	})
+}
+
+// -------------------------------------------------------------------
+// Users
+// -------------------------------------------------------------------
+
+func (api *scimApi) toScimUser(c echo.Context, user *models.User) *scim.User {
+	id := user.ID.ID.String()
+	active := !user.DeletedAt.Valid
+
+	result := &scim.User{
+		Schemas:  []string{scim.SchemaUser},
+		Id:       id,
+		UserName: user.Name,
+		Active:   &active,
+		Meta: &scim.Meta{
+			ResourceType: "User",
+			Created:      user.CreatedAt,
+			LastModified: user.UpdatedAt,
+			Location:     api.location(c, "Users", id),
+		},
+	}
+
+	if user.Email != "" {
+		result.Emails = []scim.MultiValue{{Value: user.Email, Type: "work", Primary: true}}
+	}
+
+	for _, group := range user.GroupNames() {
+		result.Groups = append(result.Groups, scim.MultiValue{
+			Value:   group,
+			Display: group,
+			Ref:     api.location(c, "Groups", group),
+		})
+	}
+
+	return result
+}
+
+// findUser returns the user (including the inactive ones) with the provided id.
+func (api *scimApi) findUser(c echo.Context, db *gorm.DB, id string) (*models.User, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, errScimNotFound
+	}
+
+	user := &models.User{}
+
+	err := db.WithContext(c.Request().Context()).Unscoped().Where("id = ?", id).First(user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errScimNotFound
+	}
+
+	return user, err
+}
+
+// checkUniqueUserName returns an uniqueness error if another
+// user (including the inactive ones) has the provided name.
+func (api *scimApi) checkUniqueUserName(c echo.Context, db *gorm.DB, name string, excludeId string) error {
+	var total int64
+
+	query := db.WithContext(c.Request().Context()).Unscoped().Model(&models.User{}).Where("name = ?", name)
+	if excludeId != "" {
+		query = query.Where("id != ?", excludeId)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return err
+	}
+
+	if total > 0 {
+		return newScimError(http.StatusConflict, scim.ErrorTypeUniqueness, "The userName is already in use.")
+	}
+
+	return nil
+}
+
+// checkUniqueUserEmail returns an uniqueness error if the users unique email
+// constraint is enabled and another user has the provided email.
+func (api *scimApi) checkUniqueUserEmail(c echo.Context, db *gorm.DB, email string, excludeId string) error {
+	err := checkUniqueUserEmail(api.app, db.WithContext(c.Request().Context()), email, excludeId)
+	if errors.Is(err, errUserEmailExists) {
+		return newScimError(http.StatusConflict, scim.ErrorTypeUniqueness, "The email is already in use.")
+	}
+
+	return err
+}
+
+//	@Summary		SCIM list users
+//	@Description	Returns a paginated SCIM users list (supports the userName, id, emails.value and active "eq" filters)
+//	@Tags			Scim
+//	@Produce		json
+//	@Param			filter		query	string	false	"Filter expression (eg. userName eq \"john\")"
+//	@Param			startIndex	query	int		false	"1-based index of the first result"
+//	@Param			count		query	int		false	"Max number of results"
+//	@Security		AdminAuth
+//	@Success		200	{object}	scim.ListResponse{Resources=[]scim.User}
+//	@Failure		400	{object}	scim.Error
+//	@Failure		401	{string}	string	"The request requires valid authorization token or API key to be set."
+//	@Router			/scim/v2/Users [get]
+func (api *scimApi) listUsers(c echo.Context) error {
+	page := &scim.Page{}
+	if err := c.Bind(page); err != nil {
+		return api.respondError(c, newScimError(http.StatusBadRequest, scim.ErrorTypeInvalidValue, "Invalid pagination parameters."))
+	}
+	page.Normalize()
+
+	conditions, err := scim.ParseFilter(c.QueryParam("filter"))
+	if err != nil {
+		return api.respondError(c, newScimError(http.StatusBadRequest, scim.ErrorTypeInvalidFilter, err.Error()))
+	}
+
+	reg, err := api.registry(c)
+	if err != nil {
+		return api.respondError(c, err)
+	}
+
+	query := reg.DB.WithContext(c.Request().Context()).Unscoped().Model(&models.User{})
+
+	for _, condition := range conditions {
+		switch strings.ToLower(condition.Attr) {
+		case "username":
+			query = query.Where("name = ?", condition.Value)
+		case "id":
+			query = query.Where("id = ?", condition.Value)
+		case "emails.value", "emails":
+			query = query.Where("email = ?", condition.Value)
+		case "active":
+			if cast.ToBool(condition.Value) {
+				query = query.Where("deleted_at IS NULL")
+			} else {
+				query = query.Where("deleted_at IS NOT NULL")
+			}
+		default:
+			return api.respondError(c, newScimError(http.StatusBadRequest, scim.ErrorTypeInvalidFilter, fmt.Sprintf("Unsupported filter attribute %q.", condition.Attr)))
+		}
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return api.respondError(c, err)
+	}
+
+	users := []*models.User{}
+	if err := query.Order("name ASC").Limit(page.Count).Offset(page.Offset()).Find(&users).Error; err != nil {
+		return api.respondError(c, err)
+	}
+
+	resources := make([]*scim.User, 0, len(users))
+	for _, user := range users {
+		resources = append(resources, api.toScimUser(c, user))
+	}
+
+	return api.respond(c, http.StatusOK, scim.NewListResponse(resources, len(resources), page.StartIndex, int(total)))
+}
+
+//	@Summary		SCIM view user
+//	@Description	Returns a single SCIM user by its id
+//	@Tags			Scim
+//	@Produce		json
+//	@Param			id	path	string	true	"User id"
+//	@Security		AdminAuth
+//	@Success		200	{object}	scim.User
+//	@Failure		401	{string}	string	"The request requires valid authorization token or API key to be set."
+//	@Failure		404	{object}	scim.Error
+//	@Router			/scim/v2/Users/{id} [get]
+func (api *scimApi) viewUser(c echo.Context) error {
+	reg, err := api.registry(c)
+	if err != nil {
+		return api.respondError(c, err)
+	}
+
+	user, err := api.findUser(c, reg.DB, c.PathParam("id"))
+	if err != nil {
+		return api.respondError(c, err)
+	}
+
+	return api.respond(c, http.StatusOK, api.toScimUser(c, user))
+}
+
+//	@Summary		SCIM create user
+//	@Description	Provisions a new user (a random password is generated if not provided)
+//	@Tags			Scim
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body	scim.User	true	"User data"
+//	@Security		AdminAuth
+//	@Success		201	{object}	scim.User
+//	@Failure		400	{object}	scim.Error
+//	@Failure		401	{string}	string	"The request requires valid authorization token or API key to be set."
+//	@Failure		409	{object}	scim.Error
+//	@Router			/scim/v2/Users [post]
+func (api *scimApi) createUser(c echo.Context) error {
+	body := &scim.User{}
+	if err := api.readBody(c, body); err != nil {
+		return api.respondError(c, err)
+	}
+
+	if body.UserName == "" {
+		return api.respondError(c, newScimError(http.StatusBadRequest, scim.ErrorTypeInvalidValue, "The userName is required."))
+	}
+
+	reg, err := api.registry(c)
+	if err != nil {
+		return api.respondError(c, err)
+	}
+
+	if err := api.checkUniqueUserName(c, reg.DB, body.UserName, ""); err != nil {
+		return api.respondError(c, err)
+	}
+
+	if err := api.checkUniqueUserEmail(c, reg.DB, body.PrimaryEmail(), ""); err != nil {
+		return api.respondError(c, err)
+	}
+
+	password := body.Password
+	if password == "" {
+		password = security.RandomString(30)
+	}
+
+	hashedPassword, err := HashPassword([]byte(password))
+	if err != nil {
+		return api.respondError(c, err)
+	}
+
+	id, err := uuid.NewUUID()
+	if err != nil {
+		return api.respondError(c, err)
+	}
+
+	user := &models.User{
+		ModelCU: models.ModelCU{
+			ID: models.ID{ID: id},
+		},
+	}
+	user.Name = body.UserName
+	user.Email = body.PrimaryEmail()
+	user.Password = string(hashedPassword)
+	if body.Active != nil && !*body.Active {
+		user.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	}
+
+	event := new(core.UserCreateEvent)
+	event.HttpContext = c
+	event.RequestInfo = RequestInfo(c)
+	event.User = user
+
+	var created bool
+
+	handlerErr := api.app.OnUserBeforeCreateRequest().Trigger(event, func(e *core.UserCreateEvent) error {
+		if err := reg.DB.WithContext(e.HttpContext.Request().Context()).Create(e.User).Error; err != nil {
+			return api.respondError(e.HttpContext, err)
+		}
+
+		created = true
+
+		return api.respond(e.HttpContext, http.StatusCreated, api.toScimUser(e.HttpContext, e.User))
+	})
+
+	if handlerErr != nil {
+		return api.respondError(c, newScimError(http.StatusBadRequest, "", handlerErr.Error()))
+	}
+
+	if created {
+		if err := api.app.OnUserAfterCreateRequest().Trigger(event); err != nil && api.app.IsDebug() {
+			log.Println(err)
+		}
+	}
+
+	return nil
+}
+
+// updateUser updates the provided user fields wrapped in
+// the user update request hooks and responds with the updated user.
+func (api *scimApi) updateUser(c echo.Context, reg *registry.Registry, user *models.User, data map[string]any) error {
+	if name, ok := data["name"].(string); ok {
+		if name == "" {
+			return api.respondError(c, newScimError(http.StatusBadRequest, scim.ErrorTypeInvalidValue, "The userName is required."))
+		}
+
+		if err := api.checkUniqueUserName(c, reg.DB, name, user.ID.ID.String()); err != nil {
+			return api.respondError(c, err)
+		}
+	}
+
+	if email, ok := data["email"].(string); ok {
+		if err := api.checkUniqueUserEmail(c, reg.DB, email, user.ID.ID.String()); err != nil {
+			return api.respondError(c, err)
+		}
+	}
+
+	if password, ok := data["password"].(string); ok {
+		hashedPassword, err := HashPassword([]byte(password))
+		if err != nil {
+			return api.respondError(c, err)
+		}
+		data["password"] = string(hashedPassword)
+	}
+
+	event := new(core.UserUpdateEvent)
+	event.HttpContext = c
+	event.RequestInfo = RequestInfo(c)
+	event.UserId = user.ID.ID.String()
+	event.Data = data
+
+	var updated bool
+
+	handlerErr := api.app.OnUserBeforeUpdateRequest().Trigger(event, func(e *core.UserUpdateEvent) error {
+		db := reg.DB.WithContext(e.HttpContext.Request().Context())
+
+		if len(e.Data) > 0 {
+			err := db.Unscoped().Model(&models.User{}).Where("id = ?", e.UserId).Updates(e.Data).Error
+			if err != nil {
+				return api.respondError(e.HttpContext, err)
+			}
+		}
+
+		updated = true
+
+		user, err := api.findUser(e.HttpContext, db, e.UserId)
+		if err != nil {
+			return api.respondError(e.HttpContext, err)
+		}
+
+		return api.respond(e.HttpContext, http.StatusOK, api.toScimUser(e.HttpContext, user))
+	})
+
+	if handlerErr != nil {
+		return api.respondError(c, newScimError(http.StatusBadRequest, "", handlerErr.Error()))
+	}
+
+	if updated {
+		if err := api.app.OnUserAfterUpdateRequest().Trigger(event); err != nil && api.app.IsDebug() {
+			log.Println(err)
+		}
+	}
+
+	return nil
+}
+
+// scimActiveValue returns the users deleted_at column value
+// for the provided SCIM active state.
+func scimActiveValue(active bool) any {
+	if active {
+		return nil
+	}
+
+	return time.Now()
+}
+
+//	@Summary		SCIM replace user
+//	@Description	Replaces the user userName, email, active state and password (if provided)
+//	@Tags			Scim
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path	string		true	"User id"
+//	@Param			body	body	scim.User	true	"User data"
+//	@Security		AdminAuth
+//	@Success		200	{object}	scim.User
+//	@Failure		400	{object}	scim.Error
+//	@Failure		401	{string}	string	"The request requires valid authorization token or API key to be set."
+//	@Failure		404	{object}	scim.Error
+//	@Failure		409	{object}	scim.Error
+//	@Router			/scim/v2/Users/{id} [put]
+func (api *scimApi) replaceUser(c echo.Context) error {
+	reg, err := api.registry(c)
+	if err != nil {
+		return api.respondError(c, err)
+	}
+
+	user, err := api.findUser(c, reg.DB, c.PathParam("id"))
+	if err != nil {
+		return api.respondError(c, err)
+	}
+
+	body := &scim.User{}
+	if err := api.readBody(c, body); err != nil {
+		return api.respondError(c, err)
+	}
+
+	data := map[string]any{
+		"name":       body.UserName,
+		"email":      body.PrimaryEmail(),
+		"deleted_at": scimActiveValue(body.Active == nil || *body.Active),
+	}
+
+	if body.Password != "" {
+		data["password"] = body.Password
+	}
+
+	return api.updateUser(c, reg, user, data)
+}
+
+// scimUserPatchData returns the users column values
+// of a single SCIM user attribute change.
+//
+// The unsupported attributes are ignored because the identity
+// providers usually send all of their mapped attributes.
+func scimUserPatchData(op string, attr string, raw json.RawMessage, data map[string]any) error {
+	invalidValue := newScimError(http.StatusBadRequest, scim.ErrorTypeInvalidValue, fmt.Sprintf("Invalid %s value.", attr))
+
+	switch strings.ToLower(attr) {
+	case "username":
+		var name string
+		if op == scim.OpRemove || json.Unmarshal(raw, &name) != nil {
+			return invalidValue
+		}
+		data["name"] = name
+	case "active":
+		var value any
+		if op == scim.OpRemove || json.Unmarshal(raw, &value) != nil {
+			return invalidValue
+		}
+		// some providers send the boolean as string (eg. "False")
+		data["deleted_at"] = scimActiveValue(cast.ToBool(value))
+	case "password":
+		var password string
+		if op == scim.OpRemove || json.Unmarshal(raw, &password) != nil || password == "" {
+			return invalidValue
+		}
+		data["password"] = password
+	case "emails", "emails.value":
+		if op == scim.OpRemove {
+			data["email"] = ""
+			return nil
+		}
+
+		var email string
+		if json.Unmarshal(raw, &email) != nil {
+			emails := []scim.MultiValue{}
+			if json.Unmarshal(raw, &emails) != nil {
+				return invalidValue
+			}
+			email = (&scim.User{Emails: emails}).PrimaryEmail()
+		}
+		data["email"] = email
+	}
+
+	return nil
+}
+
+//	@Summary		SCIM patch user
+//	@Description	Applies the SCIM PATCH operations on the user userName, emails, active and password attributes (the other attributes are ignored)
+//	@Tags			Scim
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path	string				true	"User id"
+//	@Param			body	body	scim.PatchRequest	true	"Patch operations"
+//	@Security		AdminAuth
+//	@Success		200	{object}	scim.User
+//	@Failure		400	{object}	scim.Error
+//	@Failure		401	{string}	string	"The request requires valid authorization token or API key to be set."
+//	@Failure		404	{object}	scim.Error
+//	@Failure		409	{object}	scim.Error
+//	@Router			/scim/v2/Users/{id} [patch]
+func (api *scimApi) patchUser(c echo.Context) error {
+	reg, err := api.registry(c)
+	if err != nil {
+		return api.respondError(c, err)
+	}
+
+	user, err := api.findUser(c, reg.DB, c.PathParam("id"))
+	if err != nil {
+		return api.respondError(c, err)
+	}
+
+	body := &scim.PatchRequest{}
+	if err := api.readBody(c, body); err != nil {
+		return api.respondError(c, err)
+	}
+
+	data := map[string]any{}
+
+	for _, operation := range body.Operations {
+		op := operation.NormalizedOp()
+		if op != scim.OpAdd && op != scim.OpReplace && op != scim.OpRemove {
+			return api.respondError(c, newScimError(http.StatusBadRequest, scim.ErrorTypeInvalidSyntax, fmt.Sprintf("Unsupported operation %q.", operation.Op)))
+		}
+
+		// no path - the value is an object with the changed attributes
+		if operation.Path == "" {
+			values := map[string]json.RawMessage{}
+			if op == scim.OpRemove || json.Unmarshal(operation.Value, &values) != nil {
+				return api.respondError(c, newScimError(http.StatusBadRequest, scim.ErrorTypeInvalidValue, "Invalid operation value."))
+			}
+
+			for attr, raw := range values {
+				if err := scimUserPatchData(op, attr, raw, data); err != nil {
+					return api.respondError(c, err)
+				}
+			}
+
+			continue
+		}
+
+		path, err := scim.ParseValuePath(operation.Path)
+		if err != nil {
+			return api.respondError(c, newScimError(http.StatusBadRequest, scim.ErrorTypeInvalidPath, err.Error()))
+		}
+
+		if err := scimUserPatchData(op, path.Attr, operation.Value, data); err != nil {
+			return api.respondError(c, err)
+		}
+	}
+
+	return api.updateUser(c, reg, user, data)
+}
+
+//	@Summary		SCIM delete user
+//	@Description	Permanently deletes the user (deactivate it with active=false to soft delete it instead)
+//	@Tags			Scim
+//	@Param			id	path	string	true	"User id"
+//	@Security		AdminAuth
+//	@Success		204	"No Content"
+//	@Failure		401	{string}	string	"The request requires valid authorization token or API key to be set."
+//	@Failure		404	{object}	scim.Error
+//	@Router			/scim/v2/Users/{id} [delete]
+func (api *scimApi) deleteUser(c echo.Context) error {
+	reg, err := api.registry(c)
+	if err != nil {
+		return api.respondError(c, err)
+	}
+
+	user, err := api.findUser(c, reg.DB, c.PathParam("id"))
+	if err != nil {
+		return api.respondError(c, err)
+	}
+
+	event := new(core.UserDeleteEvent)
+	event.HttpContext = c
+	event.RequestInfo = RequestInfo(c)
+	event.UserId = user.ID.ID.String()
+	event.Permanent = true
+
+	var deleted bool
+
+	handlerErr := api.app.OnUserBeforeDeleteRequest().Trigger(event, func(e *core.UserDeleteEvent) error {
+		query := reg.DB.WithContext(e.HttpContext.Request().Context()).Where("id = ?", e.UserId)
+
+		if e.Permanent {
+			query = query.Unscoped()
+		}
+
+		if err := query.Delete(&models.User{}).Error; err != nil {
+			return api.respondError(e.HttpContext, err)
+		}
+
+		deleted = true
+
+		return e.HttpContext.NoContent(http.StatusNoContent)
+	})
+
+	if handlerErr != nil {
+		return api.respondError(c, newScimError(http.StatusBadRequest, "", handlerErr.Error()))
+	}
+
+	if deleted {
+		if err := api.app.OnUserAfterDeleteRequest().Trigger(event); err != nil && api.app.IsDebug() {
+			log.Println(err)
+		}
+	}
+
+	return nil
+}
+
+// -------------------------------------------------------------------
+// Groups
+// -------------------------------------------------------------------
+
+// groupIdParam returns the unescaped group id path parameter.
+func (api *scimApi) groupIdParam(c echo.Context) string {
+	return unescapedPathParam(c, "id")
+}
+
+// findGroup returns the group with the provided name.
+func (api *scimApi) findGroup(c echo.Context, db *gorm.DB, name string) (*userGroupState, error) {
+	group, err := findUserGroup(c.Request().Context(), db, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if group == nil {
+		return nil, errScimNotFound
+	}
+
+	return group, nil
+}
+
+func (api *scimApi) toScimGroup(c echo.Context, group *userGroupState) *scim.Group {
+	result := &scim.Group{
+		Schemas:     []string{scim.SchemaGroup},
+		Id:          group.name,
+		DisplayName: group.name,
+		Meta: &scim.Meta{
+			ResourceType: "Group",
+			Location:     api.location(c, "Groups", group.name),
+		},
+	}
+
+	// the group doesn't have its own timestamps
+	for i, member := range group.members {
+		id := member.ID.ID.String()
+
+		result.Members = append(result.Members, scim.MultiValue{
+			Value:   id,
+			Display: member.Name,
+			Ref:     api.location(c, "Users", id),
+		})
+
+		if i == 0 || member.CreatedAt.Before(result.Meta.Created) {
+			result.Meta.Created = member.CreatedAt
+		}
+
+		if member.UpdatedAt.After(result.Meta.LastModified) {
+			result.Meta.LastModified = member.UpdatedAt
+		}
+	}
+
+	return result
+}
+
+// saveGroup renames the oldName group of the users to name
+// and syncs its members with the provided user ids (see [saveUserGroup]).
+func (api *scimApi) saveGroup(c echo.Context, db *gorm.DB, oldName string, name string, memberIds []string) error {
+	err := saveUserGroup(c.Request().Context(), db, oldName, name, memberIds)
+
+	var missing missingGroupMemberError
+	if errors.As(err, &missing) {
+		return newScimError(http.StatusBadRequest, scim.ErrorTypeInvalidValue, fmt.Sprintf("Missing member user %q.", string(missing)))
+	}
+
+	return err
+}
+
+// respondGroup responds with the current state of the named group.
+func (api *scimApi) respondGroup(c echo.Context, db *gorm.DB, status int, name string) error {
+	group, err := api.findGroup(c, db, name)
+	if errors.Is(err, errScimNotFound) {
+		// a group without members
+		group, err = &userGroupState{name: name}, nil
+	}
+	if err != nil {
+		return api.respondError(c, err)
+	}
+
+	return api.respond(c, status, api.toScimGroup(c, group))
+}
+
+// scimMemberIds extracts the user ids from a members value list.
+func scimMemberIds(members []scim.MultiValue) []string {
+	result := make([]string, 0, len(members))
+
+	for _, member := range members {
+		if member.Value != "" && !list.ExistInSlice(member.Value, result) {
+			result = append(result, member.Value)
+		}
+	}
+
+	return result
+}
+
+//	@Summary		SCIM list groups
+//	@Description	Returns a paginated SCIM groups list (supports the displayName and id "eq" filters)
+//	@Tags			Scim
+//	@Produce		json
+//	@Param			filter		query	string	false	"Filter expression (eg. displayName eq \"admin\")"
+//	@Param			startIndex	query	int		false	"1-based index of the first result"
+//	@Param			count		query	int		false	"Max number of results"
+//	@Security		AdminAuth
+//	@Success		200	{object}	scim.ListResponse{Resources=[]scim.Group}
+//	@Failure		400	{object}	scim.Error
+//	@Failure		401	{string}	string	"The request requires valid authorization token or API key to be set."
+//	@Router			/scim/v2/Groups [get]
+func (api *scimApi) listGroups(c echo.Context) error {
+	page := &scim.Page{}
+	if err := c.Bind(page); err != nil {
+		return api.respondError(c, newScimError(http.StatusBadRequest, scim.ErrorTypeInvalidValue, "Invalid pagination parameters."))
+	}
+	page.Normalize()
+
+	conditions, err := scim.ParseFilter(c.QueryParam("filter"))
+	if err != nil {
+		return api.respondError(c, newScimError(http.StatusBadRequest, scim.ErrorTypeInvalidFilter, err.Error()))
+	}
+
+	for _, condition := range conditions {
+		if attr := strings.ToLower(condition.Attr); attr != "displayname" && attr != "id" {
+			return api.respondError(c, newScimError(http.StatusBadRequest, scim.ErrorTypeInvalidFilter, fmt.Sprintf("Unsupported filter attribute %q.", condition.Attr)))
+		}
+	}
+
+	reg, err := api.registry(c)
+	if err != nil {
+		return api.respondError(c, err)
+	}
+
+	groups, err := loadUserGroups(c.Request().Context(), reg.DB)
+	if err != nil {
+		return api.respondError(c, err)
+	}
+
+	filtered := make([]*userGroupState, 0, len(groups))
+	for _, group := range groups {
+		match := true
+		for _, condition := range conditions {
+			if condition.Value != group.name {
+				match = false
+				break
+			}
+		}
+
+		if match {
+			filtered = append(filtered, group)
+		}
+	}
+
+	resources := []*scim.Group{}
+	for i := page.Offset(); i < len(filtered) && len(resources) < page.Count; i++ {
+		resources = append(resources, api.toScimGroup(c, filtered[i]))
+	}
+
+	return api.respond(c, http.StatusOK, scim.NewListResponse(resources, len(resources), page.StartIndex, len(filtered)))
+}
+
+//	@Summary		SCIM view group
+//	@Description	Returns a single SCIM group by its id (aka. its name)
+//	@Tags			Scim
+//	@Produce		json
+//	@Param			id	path	string	true	"Group id"
+//	@Security		AdminAuth
+//	@Success		200	{object}	scim.Group
+//	@Failure		401	{string}	string	"The request requires valid authorization token or API key to be set."
+//	@Failure		404	{object}	scim.Error
+//	@Router			/scim/v2/Groups/{id} [get]
+func (api *scimApi) viewGroup(c echo.Context) error {
+	reg, err := api.registry(c)
+	if err != nil {
+		return api.respondError(c, err)
+	}
+
+	group, err := api.findGroup(c, reg.DB, api.groupIdParam(c))
+	if err != nil {
+		return api.respondError(c, err)
+	}
+
+	return api.respond(c, http.StatusOK, api.toScimGroup(c, group))
+}
+
+//	@Summary		SCIM create group
+//	@Description	Adds the new group to its members groups
+//	@Tags			Scim
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body	scim.Group	true	"Group data"
+//	@Security		AdminAuth
+//	@Success		201	{object}	scim.Group
+//	@Failure		400	{object}	scim.Error
+//	@Failure		401	{string}	string	"The request requires valid authorization token or API key to be set."
+//	@Failure		409	{object}	scim.Error
+//	@Router			/scim/v2/Groups [post]
+func (api *scimApi) createGroup(c echo.Context) error {
+	body := &scim.Group{}
+	if err := api.readBody(c, body); err != nil {
+		return api.respondError(c, err)
+	}
+
+	name := strings.TrimSpace(body.DisplayName)
+	if name == "" {
+		return api.respondError(c, newScimError(http.StatusBadRequest, scim.ErrorTypeInvalidValue, "The displayName is required."))
+	}
+
+	reg, err := api.registry(c)
+	if err != nil {
+		return api.respondError(c, err)
+	}
+
+	if _, err := api.findGroup(c, reg.DB, name); err == nil {
+		return api.respondError(c, newScimError(http.StatusConflict, scim.ErrorTypeUniqueness, "The group already exists."))
+	}
+
+	if err := api.saveGroup(c, reg.DB, name, name, scimMemberIds(body.Members)); err != nil {
+		return api.respondError(c, err)
+	}
+
+	return api.respondGroup(c, reg.DB, http.StatusCreated, name)
+}
+
+//	@Summary		SCIM replace group
+//	@Description	Replaces the group name and members
+//	@Tags			Scim
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path	string		true	"Group id"
+//	@Param			body	body	scim.Group	true	"Group data"
+//	@Security		AdminAuth
+//	@Success		200	{object}	scim.Group
+//	@Failure		400	{object}	scim.Error
+//	@Failure		401	{string}	string	"The request requires valid authorization token or API key to be set."
+//	@Failure		409	{object}	scim.Error
+//	@Router			/scim/v2/Groups/{id} [put]
+func (api *scimApi) replaceGroup(c echo.Context) error {
+	body := &scim.Group{}
+	if err := api.readBody(c, body); err != nil {
+		return api.respondError(c, err)
+	}
+
+	reg, err := api.registry(c)
+	if err != nil {
+		return api.respondError(c, err)
+	}
+
+	oldName := api.groupIdParam(c)
+
+	name := strings.TrimSpace(body.DisplayName)
+	if name == "" {
+		name = oldName
+	}
+
+	if err := api.checkGroupRename(c, reg.DB, oldName, name); err != nil {
+		return api.respondError(c, err)
+	}
+
+	if err := api.saveGroup(c, reg.DB, oldName, name, scimMemberIds(body.Members)); err != nil {
+		return api.respondError(c, err)
+	}
+
+	return api.respondGroup(c, reg.DB, http.StatusOK, name)
+}
+
+// checkGroupRename returns an uniqueness error if the group
+// is renamed to the name of another existing group.
+func (api *scimApi) checkGroupRename(c echo.Context, db *gorm.DB, oldName string, name string) error {
+	if oldName == name {
+		return nil
+	}
+
+	_, err := api.findGroup(c, db, name)
+	if err == nil {
+		return newScimError(http.StatusConflict, scim.ErrorTypeUniqueness, "The group already exists.")
+	}
+
+	if errors.Is(err, errScimNotFound) {
+		return nil
+	}
+
+	return err
+}
+
+//	@Summary		SCIM patch group
+//	@Description	Applies the SCIM PATCH operations on the group displayName and members
+//	@Tags			Scim
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path	string				true	"Group id"
+//	@Param			body	body	scim.PatchRequest	true	"Patch operations"
+//	@Security		AdminAuth
+//	@Success		200	{object}	scim.Group
+//	@Failure		400	{object}	scim.Error
+//	@Failure		401	{string}	string	"The request requires valid authorization token or API key to be set."
+//	@Failure		409	{object}	scim.Error
+//	@Router			/scim/v2/Groups/{id} [patch]
+func (api *scimApi) patchGroup(c echo.Context) error {
+	body := &scim.PatchRequest{}
+	if err := api.readBody(c, body); err != nil {
+		return api.respondError(c, err)
+	}
+
+	reg, err := api.registry(c)
+	if err != nil {
+		return api.respondError(c, err)
+	}
+
+	oldName := api.groupIdParam(c)
+
+	// groups without members are allowed to be patched
+	// (eg. when a group is created and its members are added later)
+	group, err := api.findGroup(c, reg.DB, oldName)
+	if errors.Is(err, errScimNotFound) {
+		group, err = &userGroupState{name: oldName}, nil
+	}
+	if err != nil {
+		return api.respondError(c, err)
+	}
+
+	name := oldName
+	memberIds := group.memberIds()
+
+	for _, operation := range body.Operations {
+		op := operation.NormalizedOp()
+		if op != scim.OpAdd && op != scim.OpReplace && op != scim.OpRemove {
+			return api.respondError(c, newScimError(http.StatusBadRequest, scim.ErrorTypeInvalidSyntax, fmt.Sprintf("Unsupported operation %q.", operation.Op)))
+		}
+
+		path := &scim.ValuePath{}
+		value := operation.Value
+
+		if operation.Path == "" {
+			// no path - the value is an object with the changed attributes
+			values := map[string]json.RawMessage{}
+			if op == scim.OpRemove || json.Unmarshal(operation.Value, &values) != nil {
+				return api.respondError(c, newScimError(http.StatusBadRequest, scim.ErrorTypeInvalidValue, "Invalid operation value."))
+			}
+
+			for attr, raw := range values {
+				switch strings.ToLower(attr) {
+				case "displayname":
+					path.Attr, value = attr, raw
+				case "members":
+					var members []scim.MultiValue
+					if json.Unmarshal(raw, &members) != nil {
+						return api.respondError(c, newScimError(http.StatusBadRequest, scim.ErrorTypeInvalidValue, "Invalid members value."))
+					}
+					memberIds = applyScimMembersOp(op, memberIds, scimMemberIds(members))
+				}
+			}
+		} else {
+			path, err = scim.ParseValuePath(operation.Path)
+			if err != nil {
+				return api.respondError(c, newScimError(http.StatusBadRequest, scim.ErrorTypeInvalidPath, err.Error()))
+			}
+		}
+
+		switch strings.ToLower(path.Attr) {
+		case "displayname":
+			if op == scim.OpRemove || json.Unmarshal(value, &name) != nil || strings.TrimSpace(name) == "" {
+				return api.respondError(c, newScimError(http.StatusBadRequest, scim.ErrorTypeInvalidValue, "Invalid displayName value."))
+			}
+			name = strings.TrimSpace(name)
+		case "members":
+			if path.Filter != nil {
+				// members[value eq "id"]
+				if op != scim.OpRemove || !strings.EqualFold(path.Filter.Attr, "value") {
+					return api.respondError(c, newScimError(http.StatusBadRequest, scim.ErrorTypeInvalidPath, "Unsupported members path filter."))
+				}
+				memberIds = applyScimMembersOp(op, memberIds, []string{path.Filter.Value})
+				break
+			}
+
+			var members []scim.MultiValue
+			if len(value) > 0 && json.Unmarshal(value, &members) != nil {
+				return api.respondError(c, newScimError(http.StatusBadRequest, scim.ErrorTypeInvalidValue, "Invalid members value."))
+			}
+
+			if op == scim.OpRemove && len(members) == 0 {
+				memberIds = []string{} // remove all members
+				break
+			}
+
+			memberIds = applyScimMembersOp(op, memberIds, scimMemberIds(members))
+		}
+	}
+
+	if err := api.checkGroupRename(c, reg.DB, oldName, name); err != nil {
+		return api.respondError(c, err)
+	}
+
+	if err := api.saveGroup(c, reg.DB, oldName, name, memberIds); err != nil {
+		return api.respondError(c, err)
+	}
+
+	return api.respondGroup(c, reg.DB, http.StatusOK, name)
+}
+
+// applyScimMembersOp applies a single members operation to the current member ids.
+func applyScimMembersOp(op string, current []string, ids []string) []string {
+	switch op {
+	case scim.OpReplace:
+		return ids
+	case scim.OpRemove:
+		return list.SubtractSlice(current, ids)
+	default:
+		return list.ToUniqueStringSlice(append(current, ids...))
+	}
+}
+
+//	@Summary		SCIM delete group
+//	@Description	Removes the group from all of its members
+//	@Tags			Scim
+//	@Param			id	path	string	true	"Group id"
+//	@Security		AdminAuth
+//	@Success		204	"No Content"
+//	@Failure		401	{string}	string	"The request requires valid authorization token or API key to be set."
+//	@Failure		404	{object}	scim.Error
+//	@Router			/scim/v2/Groups/{id} [delete]
+func (api *scimApi) deleteGroup(c echo.Context) error {
+	reg, err := api.registry(c)
+	if err != nil {
+		return api.respondError(c, err)
+	}
+
+	name := api.groupIdParam(c)
+
+	if _, err := api.findGroup(c, reg.DB, name); err != nil {
+		return api.respondError(c, err)
+	}
+
+	if err := api.saveGroup(c, reg.DB, name, name, nil); err != nil {
+		return api.respondError(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,72 @@
+package apis
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// swagger:models SearchSyncReindexResponse
+type SearchSyncReindexResponse struct {
+	Collection string `json:"collection"`
+	Index      string `json:"index"`
+	Total      int    `json:"total"`
+}
+
+// bindSearchSyncApi registers the external search sync api endpoints.
+func bindSearchSyncApi(app core.App, rg *echo.Group) {
+	api := searchSyncApi{app: app}
+
+	subGroup := rg.Group("/search-sync", ActivityLogger(app), RequireAdminAuth())
+	subGroup.POST("/reindex/:collection", api.reindex, LoadCollectionContext(app))
+}
+
+type searchSyncApi struct {
+	app core.App
+}
+
+//	@Summary		Reindex collection
+//	@Description	Clears the external search engine index of the collection and pushes all of its records
+//	@Tags			SearchSync
+//	@Produce		json
+//	@Param			collection	path	string	true	"Collection name or id"
+//	@Security		AdminAuth
+//	@Success		200	{object}	SearchSyncReindexResponse
+//	@Failure		400	{string}	string	"Failed to reindex the collection."
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Failure		404	{string}	string	"The requested resource wasn't found."
+//	@Router			/search-sync/reindex/{collection} [post]
+func (api *searchSyncApi) reindex(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("", "Missing collection context.")
+	}
+
+	config := api.app.Settings().SearchSync
+
+	if !config.Enabled {
+		return NewBadRequestError("The search sync is not enabled.", nil)
+	}
+
+	if !config.IsSynced(collection.Name) {
+		return NewBadRequestError("The collection is not configured for search sync.", nil)
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Minute)
+	defer cancel()
+
+	total, err := api.app.ReindexSearchCollection(ctx, collection)
+	if err != nil {
+		return NewBadRequestError("Failed to reindex the collection.", err)
+	}
+
+	return c.JSON(http.StatusOK, SearchSyncReindexResponse{
+		Collection: collection.Name,
+		Index:      config.IndexName(collection.Name),
+		Total:      total,
+	})
+}
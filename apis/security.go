@@ -0,0 +1,54 @@
+package apis
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+)
+
+// maxSecurityReportWindow is the max allowed report period in seconds (30 days).
+const maxSecurityReportWindow int = 2592000
+
+// bindSecurityApi registers the security insights api endpoints.
+func bindSecurityApi(app core.App, rg *echo.Group) {
+	api := securityApi{app: app}
+
+	subGroup := rg.Group("/security", RequireAdminAuth())
+	subGroup.GET("/report", api.report)
+}
+
+type securityApi struct {
+	app core.App
+}
+
+//	@Summary		Security report
+//	@Description	Aggregates the failed auth attempts, the 403 spikes and the unusual client countries from the request logs of the last report period
+//	@Tags			Admin
+//	@Produce		json
+//	@Param			window	query	int	false	"Report period in seconds (default settings securityReport.window, max 30 days)"
+//	@Security		AdminAuth
+//	@Success		200	{object}	core.SecurityReport
+//	@Failure		400	{string}	string	"Invalid report window."
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Router			/security/report [get]
+func (api *securityApi) report(c echo.Context) error {
+	var window int
+
+	if raw := c.QueryParam("window"); raw != "" {
+		var err error
+		window, err = strconv.Atoi(raw)
+		if err != nil || window <= 0 || window > maxSecurityReportWindow {
+			return NewBadRequestError("Invalid report window.", err)
+		}
+	}
+
+	report, err := api.app.SecurityReport(time.Duration(window) * time.Second)
+	if err != nil {
+		return NewBadRequestError("Failed to generate the security report.", err)
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,59 @@
+package apis
+
+import (
+	"strings"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models/settings"
+)
+
+// securityHeaders middleware sets the security response headers
+// configured in the app SecurityHeaders settings.
+func securityHeaders(app core.App) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			config := app.Settings().SecurityHeaders
+			req := c.Request()
+			header := c.Response().Header()
+
+			if config.ContentTypeNosniff {
+				header.Set(echo.HeaderXContentTypeOptions, "nosniff")
+			}
+
+			if config.FrameOptions != "" {
+				header.Set(echo.HeaderXFrameOptions, config.FrameOptions)
+			}
+
+			if config.ReferrerPolicy != "" {
+				header.Set(echo.HeaderReferrerPolicy, config.ReferrerPolicy)
+			}
+
+			hsts := config.HSTSValue()
+			if hsts != "" && (c.IsTLS() || req.Header.Get(echo.HeaderXForwardedProto) == "https") {
+				header.Set(echo.HeaderStrictTransportSecurity, hsts)
+			}
+
+			if csp := routeContentSecurityPolicy(req.URL.Path, config); csp != "" {
+				header.Set(echo.HeaderContentSecurityPolicy, csp)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// routeContentSecurityPolicy returns the Content-Security-Policy
+// header value for the specified request path.
+func routeContentSecurityPolicy(path string, config settings.SecurityHeadersConfig) string {
+	switch {
+	case path == "/api/docs":
+		return config.DocsContentSecurityPolicy
+	case strings.HasPrefix(path, "/api/files/"):
+		// the files policy is applied by the download handler
+		// so that it could be overwritten by the file field options
+		return ""
+	default:
+		return config.ContentSecurityPolicy
+	}
+}
@@ -0,0 +1,154 @@
+package apis_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/apis"
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestSecurityHeaders(t *testing.T) {
+	defaults := settings.New().SecurityHeaders
+
+	scenarios := []struct {
+		name     string
+		config   func(c *settings.SecurityHeadersConfig)
+		url      string
+		headers  map[string]string
+		expected map[string]string // empty value means that the header is expected to be missing
+	}{
+		{
+			"default settings",
+			nil,
+			"/api/health",
+			nil,
+			map[string]string{
+				"X-Content-Type-Options":    "nosniff",
+				"X-Frame-Options":           "SAMEORIGIN",
+				"Referrer-Policy":           "strict-origin-when-cross-origin",
+				"Strict-Transport-Security": "",
+				"Content-Security-Policy":   "",
+			},
+		},
+		{
+			"disabled headers",
+			func(c *settings.SecurityHeadersConfig) {
+				c.ContentTypeNosniff = false
+				c.FrameOptions = ""
+				c.ReferrerPolicy = ""
+			},
+			"/api/health",
+			nil,
+			map[string]string{
+				"X-Content-Type-Options": "",
+				"X-Frame-Options":        "",
+				"Referrer-Policy":        "",
+			},
+		},
+		{
+			"hsts over plain http",
+			func(c *settings.SecurityHeadersConfig) {
+				c.HSTSMaxAge = 100
+			},
+			"/api/health",
+			nil,
+			map[string]string{
+				"Strict-Transport-Security": "",
+			},
+		},
+		{
+			"hsts behind https proxy",
+			func(c *settings.SecurityHeadersConfig) {
+				c.HSTSMaxAge = 100
+				c.HSTSIncludeSubdomains = true
+				c.HSTSPreload = true
+			},
+			"/api/health",
+			map[string]string{"X-Forwarded-Proto": "https"},
+			map[string]string{
+				"Strict-Transport-Security": "max-age=100; includeSubdomains; preload",
+			},
+		},
+		{
+			"global csp",
+			func(c *settings.SecurityHeadersConfig) {
+				c.ContentSecurityPolicy = "default-src 'self'"
+			},
+			"/api/health",
+			nil,
+			map[string]string{
+				"Content-Security-Policy": "default-src 'self'",
+			},
+		},
+		{
+			"docs csp override",
+			func(c *settings.SecurityHeadersConfig) {
+				c.ContentSecurityPolicy = "default-src 'self'"
+			},
+			"/api/docs",
+			nil,
+			map[string]string{
+				"Content-Security-Policy": settings.DefaultDocsContentSecurityPolicy,
+			},
+		},
+		{
+			"default file csp",
+			func(c *settings.SecurityHeadersConfig) {
+				c.ContentSecurityPolicy = "default-src 'self'"
+			},
+			"/api/files/_pb_users_auth_/4q1xlclmfloku33/300_1SEi6Q6U72.png",
+			nil,
+			map[string]string{
+				"Content-Security-Policy": "default-src 'none'; media-src 'self'; style-src 'unsafe-inline'; sandbox",
+				"X-Frame-Options":         "",
+			},
+		},
+		{
+			"file csp override",
+			func(c *settings.SecurityHeadersConfig) {
+				c.ContentSecurityPolicy = "default-src 'self'"
+				c.FilesContentSecurityPolicy = "sandbox"
+			},
+			"/api/files/_pb_users_auth_/4q1xlclmfloku33/300_1SEi6Q6U72.png",
+			nil,
+			map[string]string{
+				"Content-Security-Policy": "sandbox",
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			config := defaults
+			if s.config != nil {
+				s.config(&config)
+			}
+			app.Settings().SecurityHeaders = config
+
+			e, err := apis.InitApi(app)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, s.url, nil)
+			for k, v := range s.headers {
+				req.Header.Set(k, v)
+			}
+
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			for k, v := range s.expected {
+				if got := rec.Header().Get(k); got != v {
+					t.Errorf("Expected %q header %q, got %q", k, v, got)
+				}
+			}
+		})
+	}
+}
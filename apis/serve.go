@@ -64,6 +64,8 @@ func Serve(app core.App, options *ServeOptions) error {
 		Skipper:      middleware.DefaultSkipper,
 		AllowOrigins: options.AllowedOrigins,
 		AllowMethods: []string{http.MethodGet, http.MethodHead, http.MethodPut, http.MethodPatch, http.MethodPost, http.MethodDelete},
+		// expose the resumable uploads (TUS) and the optimistic concurrency response headers
+		ExposeHeaders: []string{"Location", "Tus-Resumable", "Tus-Version", "Tus-Extension", "Upload-Offset", "Upload-Length", HeaderETag},
 	}))
 
 	// start http server
@@ -1,15 +1,23 @@
 package apis
 
 import (
+	"database/sql"
+	"errors"
 	"log"
 	"net/http"
+	"os"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/labstack/echo/v5"
 	"github.com/pocketbase/pocketbase/core"
 	"github.com/pocketbase/pocketbase/daos"
 	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
 	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/pocketbase/pocketbase/tools/secrets"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/spf13/cast"
 )
 
 type EmailTemplate struct {
@@ -68,6 +76,10 @@ type BackupsConfig struct {
 
 	// S3 is an optional S3 storage config specifying where to store the app backups.
 	S3 S3Config `form:"s3" json:"s3"`
+
+	// EncryptionKey is an optional 32 characters AES key used to encrypt
+	// the generated backup archives before their upload.
+	EncryptionKey string `form:"encryptionKey" json:"encryptionKey"`
 }
 
 type AuthProviderConfig struct {
@@ -133,6 +145,25 @@ type Settings struct {
 	OIDC2Auth     AuthProviderConfig `form:"oidc2Auth" json:"oidc2Auth"`
 	OIDC3Auth     AuthProviderConfig `form:"oidc3Auth" json:"oidc3Auth"`
 	AppleAuth     AuthProviderConfig `form:"appleAuth" json:"appleAuth"`
+
+	// EnvLocked is the list of the settings fields overridden by the
+	// PB_ prefixed environment variables (eg. "smtp.host" by PB_SMTP_HOST).
+	EnvLocked []string `json:"envLocked"`
+}
+
+// settingsResponse is the redacted settings api response
+// extended with the env-locked fields list.
+type settingsResponse struct {
+	*settings.Settings
+
+	EnvLocked []string `json:"envLocked"`
+}
+
+func newSettingsResponse(redacted *settings.Settings) *settingsResponse {
+	return &settingsResponse{
+		Settings:  redacted,
+		EnvLocked: redacted.EnvLocked(),
+	}
 }
 
 // bindSettingsApi registers the settings api endpoints.
@@ -142,10 +173,15 @@ func bindSettingsApi(app core.App, rg *echo.Group) {
 	subGroup := rg.Group("/settings", ActivityLogger(app), RequireAdminAuth())
 
 	subGroup.GET("", api.list)
-	subGroup.PATCH("", api.set)
+	subGroup.PATCH("", api.set, requireApproval(app, settings.ApprovalActionSettingsUpdate))
+	subGroup.GET("/history", api.history)
+	subGroup.POST("/rollback/:version", api.rollback, requireApproval(app, settings.ApprovalActionSettingsUpdate))
 	subGroup.POST("/test/s3", api.testS3)
 	subGroup.POST("/test/email", api.testEmail)
 	subGroup.POST("/apple/generate-client-secret", api.generateAppleClientSecret)
+	subGroup.GET("/secrets/status", api.secretsStatus)
+	subGroup.POST("/token-signing/keys", api.generateTokenSigningKey, requireApproval(app, settings.ApprovalActionSettingsUpdate))
+	subGroup.DELETE("/token-signing/keys/:id", api.deleteTokenSigningKey, requireApproval(app, settings.ApprovalActionSettingsUpdate))
 }
 
 type settingsApi struct {
@@ -153,7 +189,7 @@ type settingsApi struct {
 }
 
 // @Summary		Получение списка настроек
-// @Description	Возвращает список всех настроек
+// @Description	Возвращает список всех настроек (поле envLocked содержит настройки, переопределенные переменными окружения PB_*)
 // @Tags			Settings
 // @Security		AdminAuth
 // @Accept			json
@@ -169,10 +205,11 @@ func (api *settingsApi) list(c echo.Context) error {
 
 	event := new(core.SettingsListEvent)
 	event.HttpContext = c
+	event.RequestInfo = RequestInfo(c)
 	event.RedactedSettings = settings
 
 	return api.app.OnSettingsListRequest().Trigger(event, func(e *core.SettingsListEvent) error {
-		return e.HttpContext.JSON(http.StatusOK, e.RedactedSettings)
+		return e.HttpContext.JSON(http.StatusOK, newSettingsResponse(e.RedactedSettings))
 	})
 }
 
@@ -185,7 +222,7 @@ type UpdateSettingsRequest struct {
 }
 
 // @Summary		Обновление настроек
-// @Description	Обновляет указанные настройки
+// @Description	Обновляет указанные настройки (изменение настроек, переопределенных переменными окружения PB_*, запрещено)
 // @Tags			Settings
 // @Security		AdminAuth
 // @Accept			json
@@ -202,8 +239,104 @@ func (api *settingsApi) set(c echo.Context) error {
 		return NewBadRequestError("An error occurred while loading the submitted data.", err)
 	}
 
+	return api.submit(c, form)
+}
+
+// @Summary		История изменений настроек
+// @Description	Возвращает список версий настроек (кто, когда и какие поля изменил), по умолчанию сначала новые
+// @Tags			Settings
+// @Security		AdminAuth
+// @Produce		json
+// @Param			page	query		int		false	"Номер страницы"
+// @Param			perPage	query		int		false	"Количество элементов на странице"
+// @Param			sort	query		string	false	"Сортировка"
+// @Param			filter	query		string	false	"Фильтр"
+// @Success		200		{object}	SearchResult{items=[]models.SettingsHistory}
+// @Failure		400		{string}	string	"Something went wrong while processing your request."
+// @Failure		401		{string}	string	"The request requires admin authorization token to be set."
+// @Router			/settings/history [get]
+func (api *settingsApi) history(c echo.Context) error {
+	fieldResolver := search.NewSimpleFieldResolver(
+		"id", "created", "updated", "version", "adminId",
+	)
+
+	items := []*models.SettingsHistory{}
+
+	searchProvider := search.NewProvider(fieldResolver).
+		Query(api.app.Dao().SettingsHistoryQuery())
+
+	if c.QueryParam(search.SortQueryParam) == "" {
+		searchProvider.AddSort(search.SortField{Name: "version", Direction: search.SortDesc})
+	}
+
+	result, err := searchProvider.ParseAndExec(c.QueryParams().Encode(), &items)
+	if err != nil {
+		return NewBadRequestError("", err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// @Summary		Откат настроек
+// @Description	Восстанавливает состояние настроек указанной версии (откат сохраняется как новая версия, настройки, переопределенные переменными окружения PB_*, ключ шифрования резервных копий и ключи подписи токенов не изменяются)
+// @Tags			Settings
+// @Security		AdminAuth
+// @Produce		json
+// @Param			version	path		int	true	"Версия настроек"
+// @Success		200		{object}	Settings
+// @Failure		400		{string}	string	"An error occurred while submitting the form."
+// @Failure		401		{string}	string	"The request requires admin authorization token to be set."
+// @Failure		404		{string}	string	"The requested resource wasn't found."
+// @Router			/settings/rollback/{version} [post]
+func (api *settingsApi) rollback(c echo.Context) error {
+	version := cast.ToInt(c.PathParam("version"))
+	if version <= 0 {
+		return NewNotFoundError("", nil)
+	}
+
+	history, err := api.app.Dao().FindSettingsHistoryByVersion(version)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return NewNotFoundError("", err)
+		}
+		return NewBadRequestError("", err)
+	}
+
+	snapshot, err := api.app.Dao().SettingsHistorySnapshot(history, os.Getenv(api.app.EncryptionEnv()))
+	if err != nil {
+		return NewBadRequestError("Failed to load the settings version.", err)
+	}
+
+	// keep the env overridden fields unchanged
+	if err := snapshot.ApplyEnv(os.Environ()); err != nil {
+		return NewBadRequestError("Failed to load the settings version.", err)
+	}
+
+	// keep the current keys unchanged
+	// (eg. rolling back past a backups key rotation must not restore the old key)
+	current, err := api.app.Settings().Clone()
+	if err != nil {
+		return NewBadRequestError("Failed to load the settings version.", err)
+	}
+	snapshot.Backups.EncryptionKey = current.Backups.EncryptionKey
+	snapshot.TokenSigning = current.TokenSigning
+
+	form := forms.NewSettingsUpsert(api.app)
+	form.Settings = snapshot
+
+	return api.submit(c, form)
+}
+
+// submit submits the provided settings upsert form
+// wrapped in the settings update request hooks.
+func (api *settingsApi) submit(c echo.Context, form *forms.SettingsUpsert) error {
+	if admin, _ := c.Get(ContextAdminKey).(*models.Admin); admin != nil {
+		form.SetAdmin(admin)
+	}
+
 	event := new(core.SettingsUpdateEvent)
 	event.HttpContext = c
+	event.RequestInfo = RequestInfo(c)
 	event.OldSettings = api.app.Settings()
 
 	// update the settings
@@ -221,7 +354,7 @@ func (api *settingsApi) set(c echo.Context) error {
 					return NewBadRequestError("", err)
 				}
 
-				return e.HttpContext.JSON(http.StatusOK, redactedSettings)
+				return e.HttpContext.JSON(http.StatusOK, newSettingsResponse(redactedSettings))
 			})
 		}
 	})
@@ -235,7 +368,6 @@ func (api *settingsApi) set(c echo.Context) error {
 	return submitErr
 }
 
-
 // swagger:models TestS3SettingsRequest
 type TestS3SettingsRequest struct {
 	app core.App
@@ -349,3 +481,120 @@ func (api *settingsApi) generateAppleClientSecret(c echo.Context) error {
 		"secret": secret,
 	})
 }
+
+// SecretsStatusResponse is the settings secret references health view.
+type SecretsStatusResponse struct {
+	// Healthy indicates whether all secret references were resolved.
+	Healthy bool             `json:"healthy"`
+	Items   []secrets.Status `json:"items"`
+}
+
+// @Summary		Состояние внешних секретов настроек
+// @Description	Возвращает состояние загрузки ссылок на секреты (Vault, AWS Secrets Manager, переменные окружения) из настроек
+// @Tags			Settings
+// @Security		AdminAuth
+// @Produce		json
+// @Success		200	{object}	SecretsStatusResponse
+// @Failure		401	{string}	string	"The request requires admin authorization token to be set."
+// @Router			/settings/secrets/status [get]
+func (api *settingsApi) secretsStatus(c echo.Context) error {
+	result := &SecretsStatusResponse{
+		Healthy: true,
+		Items:   api.app.Secrets().Status(),
+	}
+
+	for _, item := range result.Items {
+		if !item.Resolved {
+			result.Healthy = false
+			break
+		}
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// swagger:models GenerateTokenSigningKeyRequest
+type GenerateTokenSigningKeyRequest struct {
+	// Algorithm of the new key - RS256 or EdDSA
+	Algorithm string `form:"algorithm" json:"algorithm"`
+}
+
+// @Summary		Генерация ключа подписи токенов
+// @Description	Генерирует новый RS256/EdDSA ключ и делает его активным для подписи токенов авторизации (предыдущие ключи остаются в JWKS для проверки уже выданных токенов)
+// @Tags			Settings
+// @Security		AdminAuth
+// @Accept			json
+// @Produce		json
+// @Param			body	body		GenerateTokenSigningKeyRequest	true	"Алгоритм нового ключа"
+// @Success		200		{object}	Settings
+// @Failure		400		{string}	string	"An error occurred while submitting the form."
+// @Failure		401		{string}	string	"The request requires admin authorization token to be set."
+// @Router			/settings/token-signing/keys [post]
+func (api *settingsApi) generateTokenSigningKey(c echo.Context) error {
+	data := &GenerateTokenSigningKeyRequest{}
+	if err := c.Bind(data); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	err := validation.ValidateStruct(data,
+		validation.Field(&data.Algorithm, validation.Required, validation.In(security.AlgorithmRS256, security.AlgorithmEdDSA)),
+	)
+	if err != nil {
+		return NewBadRequestError("An error occurred while validating the submitted data.", err)
+	}
+
+	privateKey, err := security.GenerateSigningKey(data.Algorithm)
+	if err != nil {
+		return NewBadRequestError("Failed to generate the signing key.", err)
+	}
+
+	form := forms.NewSettingsUpsert(api.app)
+
+	key := settings.TokenSigningKey{
+		Id:         security.RandomString(20),
+		Algorithm:  data.Algorithm,
+		PrivateKey: privateKey,
+	}
+
+	// the new key is listed first and it is used to sign the new tokens
+	form.TokenSigning.Keys = append([]settings.TokenSigningKey{key}, form.TokenSigning.Keys...)
+	form.TokenSigning.Algorithm = key.Algorithm
+	form.TokenSigning.KeyId = key.Id
+
+	return api.submit(c, form)
+}
+
+// @Summary		Удаление ключа подписи токенов
+// @Description	Удаляет неактивный ключ подписи токенов (выданные с ним токены перестают приниматься)
+// @Tags			Settings
+// @Security		AdminAuth
+// @Produce		json
+// @Param			id	path		string	true	"Идентификатор ключа (kid)"
+// @Success		200	{object}	Settings
+// @Failure		400	{string}	string	"The active token signing key can't be deleted."
+// @Failure		401	{string}	string	"The request requires admin authorization token to be set."
+// @Failure		404	{string}	string	"The requested resource wasn't found."
+// @Router			/settings/token-signing/keys/{id} [delete]
+func (api *settingsApi) deleteTokenSigningKey(c echo.Context) error {
+	id := c.PathParam("id")
+
+	form := forms.NewSettingsUpsert(api.app)
+
+	if form.TokenSigning.FindKey(id) == nil {
+		return NewNotFoundError("", nil)
+	}
+
+	if form.TokenSigning.IsAsymmetric() && form.TokenSigning.KeyId == id {
+		return NewBadRequestError("The active token signing key can't be deleted.", nil)
+	}
+
+	keys := make([]settings.TokenSigningKey, 0, len(form.TokenSigning.Keys)-1)
+	for _, key := range form.TokenSigning.Keys {
+		if key.Id != id {
+			keys = append(keys, key)
+		}
+	}
+	form.TokenSigning.Keys = keys
+
+	return api.submit(c, form)
+}
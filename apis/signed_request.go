@@ -0,0 +1,254 @@
+package apis
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tokens"
+	"github.com/pocketbase/pocketbase/tools/rest"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/types"
+	"github.com/spf13/cast"
+)
+
+// SignedRequestQueryParam is the name of the url query parameter with the signed request token.
+const SignedRequestQueryParam = "signature"
+
+const (
+	defaultSignedRequestDuration int64 = 3600  // 1 hour
+	maxSignedRequestDuration     int64 = 86400 // 1 day
+)
+
+// swagger:models SignedRequestCreate
+//
+// Body is the raw request body (if any) that the signed url will be
+// submitted with (eg. `{"status":"approved"}`).
+type SignedRequestCreate struct {
+	Method string `json:"method"`
+
+	// Path is the request path with its query parameters
+	// (eg. "/api/collections/subscribers/records/RECORD_ID?expand=user").
+	Path string `json:"path"`
+	Body string `json:"body"`
+
+	// ExpiresIn is the signed url lifetime in seconds (default to 1 hour, max 1 day).
+	ExpiresIn int64 `json:"expiresIn"`
+}
+
+// Validate implements [validation.Validatable] interface.
+func (r SignedRequestCreate) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(
+			&r.Method,
+			validation.Required,
+			validation.In(http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete),
+		),
+		validation.Field(&r.Path, validation.Required, validation.Length(1, 2000), validation.By(checkSignedRequestPath)),
+		validation.Field(&r.ExpiresIn, validation.Min(0), validation.Max(maxSignedRequestDuration)),
+	)
+}
+
+func checkSignedRequestPath(value any) error {
+	v, _ := value.(string)
+
+	u, err := url.Parse(v)
+	if err != nil || u.IsAbs() || !strings.HasPrefix(u.Path, "/api/") {
+		return validation.NewError("validation_invalid_signed_request_path", `Must be a relative api path starting with "/api/".`)
+	}
+
+	if u.Query().Has(SignedRequestQueryParam) {
+		return validation.NewError("validation_invalid_signed_request_path", `The path must not contain the "`+SignedRequestQueryParam+`" query parameter.`)
+	}
+
+	return nil
+}
+
+// swagger:models SignedRequestResult
+type SignedRequestResult struct {
+	Url     string         `json:"url"`
+	Method  string         `json:"method"`
+	Expires types.DateTime `json:"expires"`
+}
+
+// bindSignedRequestApi registers the signed requests api endpoints.
+func bindSignedRequestApi(app core.App, rg *echo.Group) {
+	api := signedRequestApi{app: app}
+
+	rg.POST("/signed-requests", api.create, ActivityLogger(app), RequireAdminOrRecordAuth())
+}
+
+type signedRequestApi struct {
+	app core.App
+}
+
+//	@Summary		Создание подписанного запроса
+//	@Description	Создает ограниченный по времени (не более 1 дня) одноразовый подписанный URL, авторизующий от имени текущего администратора или пользователя только один конкретный запрос (метод, путь и тело) без передачи токена
+//	@Tags			Settings
+//	@Security		Auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		SignedRequestCreate	true	"Данные подписываемого запроса"
+//	@Success		200		{object}	SignedRequestResult
+//	@Failure		400		{string}	string	"Failed to sign the request."
+//	@Failure		401		{string}	string	"The request requires admin or record authorization token to be set."
+//	@Router			/signed-requests [post]
+func (api *signedRequestApi) create(c echo.Context) error {
+	body := new(SignedRequestCreate)
+	if err := c.Bind(body); err != nil {
+		return NewBadRequestError("Failed to load the submitted data due to invalid formatting.", err)
+	}
+
+	body.Method = strings.ToUpper(body.Method)
+
+	if err := body.Validate(); err != nil {
+		return NewBadRequestError("An error occurred while validating the submitted data.", err)
+	}
+
+	duration := body.ExpiresIn
+	if duration == 0 {
+		duration = defaultSignedRequestDuration
+	}
+
+	u, _ := url.Parse(body.Path)
+	path := signedRequestPath(u)
+	bodyHash := signedRequestBodyHash([]byte(body.Body))
+
+	var token string
+	var tokenErr error
+	if admin, _ := c.Get(ContextAdminKey).(*models.Admin); admin != nil {
+		token, tokenErr = tokens.NewAdminSignedRequestToken(api.app, admin, body.Method, path, bodyHash, duration)
+	} else {
+		record, _ := c.Get(ContextAuthRecordKey).(*models.Record)
+		token, tokenErr = tokens.NewRecordSignedRequestToken(api.app, record, body.Method, path, bodyHash, duration)
+	}
+	if tokenErr != nil {
+		return NewBadRequestError("Failed to sign the request.", tokenErr)
+	}
+
+	query := u.Query()
+	query.Set(SignedRequestQueryParam, token)
+	u.RawQuery = query.Encode()
+
+	expires, _ := types.ParseDateTime(time.Now().Add(time.Duration(duration) * time.Second))
+
+	return c.JSON(http.StatusOK, &SignedRequestResult{
+		Url:     strings.TrimRight(api.app.Settings().Meta.AppUrl, "/") + u.String(),
+		Method:  body.Method,
+		Expires: expires,
+	})
+}
+
+// loadSignedRequestAuth verifies the provided signed request token and,
+// if it matches the current request and it wasn't used already,
+// loads its admin or auth record into the request context.
+//
+// It returns an error only if the request body is larger than
+// [rest.DefaultMaxMemory] and therefore couldn't be verified.
+func loadSignedRequestAuth(app core.App, c echo.Context, token string) error {
+	claims, _ := security.ParseUnverifiedJWT(token)
+	if cast.ToString(claims["type"]) != tokens.TypeSignedRequest {
+		return nil
+	}
+
+	tokenId := cast.ToString(claims["jti"])
+	if tokenId == "" {
+		return nil
+	}
+
+	r := c.Request()
+
+	if cast.ToString(claims["method"]) != r.Method || cast.ToString(claims["path"]) != signedRequestPath(r.URL) {
+		return nil
+	}
+
+	var body []byte
+	if r.Body != nil {
+		var err error
+		body, err = io.ReadAll(http.MaxBytesReader(c.Response(), r.Body, rest.DefaultMaxMemory))
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				return NewApiError(http.StatusRequestEntityTooLarge, "The signed request body is too large.", nil)
+			}
+			return nil
+		}
+		// restore the request body for the next readers
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	if cast.ToString(claims["bodyHash"]) != signedRequestBodyHash(body) {
+		return nil
+	}
+
+	if cast.ToString(claims["collectionId"]) == "" {
+		admin, err := app.Dao().FindAdminByToken(token, app.Settings().AdminAuthToken.Secret)
+		if err == nil && admin != nil && markSignedRequestUsed(app, tokenId, cast.ToInt64(claims["exp"])) {
+			c.Set(ContextAdminKey, admin)
+		}
+	} else {
+		record, err := app.Dao().FindAuthRecordByToken(token, app.Settings().RecordAuthToken.Secret)
+		if err == nil && record != nil && markSignedRequestUsed(app, tokenId, cast.ToInt64(claims["exp"])) {
+			c.Set(ContextAuthRecordKey, record)
+		}
+	}
+
+	return nil
+}
+
+// markSignedRequestUsed registers the signed request token as used
+// until its expiration and reports whether this is its first use.
+func markSignedRequestUsed(app core.App, tokenId string, exp int64) bool {
+	expires, _ := types.ParseDateTime(time.Unix(exp, 0))
+
+	if err := app.Dao().MarkSignedRequestUsed(tokenId, expires); err != nil {
+		return false
+	}
+
+	// delete the expired used signed requests
+	// ---
+	now := time.Now()
+	lastDeletedAt := cast.ToTime(app.Cache().Get("lastUsedSignedRequestsDeletedAt"))
+
+	if now.Sub(lastDeletedAt) > time.Hour {
+		err := app.Dao().DeleteExpiredUsedSignedRequests(now)
+		if err == nil {
+			app.Cache().Set("lastUsedSignedRequestsDeletedAt", now)
+		} else if app.IsDebug() {
+			log.Println("Used signed requests delete failed:", err)
+		}
+	}
+
+	return true
+}
+
+// signedRequestPath returns the normalized url path and query
+// parameters (without the signature one) used in the signing.
+func signedRequestPath(u *url.URL) string {
+	query := u.Query()
+	query.Del(SignedRequestQueryParam)
+
+	path := u.Path
+	if encoded := query.Encode(); encoded != "" {
+		path += "?" + encoded
+	}
+
+	return path
+}
+
+// signedRequestBodyHash returns the hex encoded sha256 hash of the request body.
+func signedRequestBodyHash(body []byte) string {
+	h := sha256.Sum256(body)
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,80 @@
+package apis
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+)
+
+// bindStorageApi registers the storage api endpoints.
+func bindStorageApi(app core.App, rg *echo.Group) {
+	api := storageApi{app: app}
+
+	subGroup := rg.Group("/storage", ActivityLogger(app), RequireAdminAuth())
+	subGroup.GET("/usage", api.usage)
+}
+
+type storageApi struct {
+	app core.App
+}
+
+// StorageUsage defines the app files storage usage.
+type StorageUsage struct {
+	// Files and Size are the total number and size in bytes
+	// of the stored files (including the thumbs and file objects).
+	Files int   `json:"files"`
+	Size  int64 `json:"size"`
+
+	// DedupEnabled reports whether the uploads deduplication is currently enabled.
+	DedupEnabled bool `json:"dedupEnabled"`
+
+	// Dedup is the deduplicated file objects usage (incl. the saved bytes).
+	Dedup *daos.FileDedupStats `json:"dedup"`
+}
+
+//	@Summary		Storage usage
+//	@Description	Returns the app files storage usage and the uploads deduplication savings
+//	@Tags			Storage
+//	@Produce		json
+//	@Security		AdminAuth
+//	@Success		200	{object}	StorageUsage
+//	@Failure		400	{string}	string	"Failed to load the storage usage."
+//	@Failure		401	{string}	string	"The request requires admin authorization token to be set."
+//	@Router			/storage/usage [get]
+func (api *storageApi) usage(c echo.Context) error {
+	dedup, err := api.app.Dao().FileDedupStats()
+	if err != nil {
+		return NewBadRequestError("Failed to load the storage usage.", err)
+	}
+
+	fsys, err := api.app.NewFilesystem()
+	if err != nil {
+		return NewBadRequestError("Filesystem initialization failure.", err)
+	}
+	defer fsys.Close()
+
+	fsys.SetContext(c.Request().Context())
+
+	files, err := fsys.List("")
+	if err != nil {
+		return NewBadRequestError("Failed to load the storage usage.", err)
+	}
+
+	result := &StorageUsage{
+		DedupEnabled: api.app.Settings().FileDedup.Enabled,
+		Dedup:        dedup,
+	}
+
+	for _, f := range files {
+		if f.IsDir {
+			continue
+		}
+
+		result.Files++
+		result.Size += f.Size
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,587 @@
+package apis
+
+import (
+	"bytes"
+	"crypto/subtle"
+	_ "embed"
+	"encoding/json"
+	"html/template"
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/labstack/echo/v5/middleware"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	swaggerFiles "github.com/swaggo/files/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// swaggerSpec is the static (swag generated) api spec.
+//
+//go:embed docs/swagger.json
+var swaggerSpec []byte
+
+// bindSwaggerApi registers the api docs endpoints.
+func bindSwaggerApi(app core.App, rg *echo.Group) {
+	api := swaggerApi{app: app}
+
+	rg.GET("/swagger.json", api.spec, requireSwaggerAccess(app))
+	rg.GET("/docs", api.ui, requireSwaggerAccess(app))
+	rg.GET("/docs/openapi.json", api.spec, requireSwaggerAccess(app))
+	rg.GET("/docs/openapi.yaml", api.specYaml, requireSwaggerAccess(app))
+
+	// the swagger-ui assets are embedded in the binary and are
+	// public because they are loaded by the browser without the docs key
+	rg.GET(
+		"/docs/*",
+		StaticDirectoryHandler(swaggerFiles.FS, false),
+		requireSwaggerEnabled(app),
+		uiCacheControl(),
+		middleware.Gzip(),
+	)
+}
+
+type swaggerApi struct {
+	app core.App
+}
+
+//	@Summary		Спецификация API
+//	@Description	Возвращает OpenAPI (Swagger 2.0) спецификацию, дополненную моделями и маршрутами записей каждой доступной коллекции
+//	@Tags			Settings
+//	@Produce		json
+//	@Security		AdminAuth
+//	@Param			X-API-Key	header		string	false	"Ключ доступа к документации или API ключ с доступом docs (если не используется авторизация администратора)"
+//	@Success		200	{object}	object
+//	@Failure		401	{string}	string	"The request requires admin authorization token or valid api docs key to be set."
+//	@Failure		404	{string}	string	"Not found."
+//	@Router			/swagger.json [get]
+//	@Router			/docs/openapi.json [get]
+func (api *swaggerApi) spec(c echo.Context) error {
+	spec, err := api.buildSpec(c)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, spec)
+}
+
+//	@Summary		Спецификация API в формате YAML
+//	@Description	Возвращает OpenAPI (Swagger 2.0) спецификацию в формате YAML для генераторов клиентов (openapi-generator, swagger-codegen)
+//	@Tags			Settings
+//	@Produce		application/yaml
+//	@Security		AdminAuth
+//	@Param			X-API-Key	header		string	false	"Ключ доступа к документации или API ключ с доступом docs (если не используется авторизация администратора)"
+//	@Success		200	{string}	string	"YAML"
+//	@Failure		401	{string}	string	"The request requires admin authorization token or valid api docs key to be set."
+//	@Failure		404	{string}	string	"Not found."
+//	@Router			/docs/openapi.yaml [get]
+func (api *swaggerApi) specYaml(c echo.Context) error {
+	spec, err := api.buildSpec(c)
+	if err != nil {
+		return err
+	}
+
+	raw, err := yaml.Marshal(spec)
+	if err != nil {
+		return NewBadRequestError("Failed to encode the api spec.", err)
+	}
+
+	return c.Blob(http.StatusOK, "application/yaml", raw)
+}
+
+//	@Summary		Документация API
+//	@Description	Возвращает Swagger UI страницу с документацией API
+//	@Tags			Settings
+//	@Produce		html
+//	@Security		AdminAuth
+//	@Param			X-API-Key	header		string	false	"Ключ доступа к документации или API ключ с доступом docs (если не используется авторизация администратора)"
+//	@Success		200	{string}	string	"HTML"
+//	@Failure		401	{string}	string	"The request requires admin authorization token or valid api docs key to be set."
+//	@Failure		404	{string}	string	"Not found."
+//	@Router			/docs [get]
+func (api *swaggerApi) ui(c echo.Context) error {
+	spec, err := api.buildSpec(c)
+	if err != nil {
+		return err
+	}
+
+	// note: the spec is inlined to avoid a second (unauthorized) browser request
+	var html bytes.Buffer
+	if err := swaggerUITemplate.Execute(&html, map[string]any{"Spec": spec}); err != nil {
+		return NewBadRequestError("Failed to render the api docs.", err)
+	}
+
+	return c.HTMLBlob(http.StatusOK, html.Bytes())
+}
+
+func (api *swaggerApi) buildSpec(c echo.Context) (map[string]any, error) {
+	collections := []*models.Collection{}
+	if err := api.app.Dao().CollectionQuery().OrderBy("created ASC").All(&collections); err != nil {
+		return nil, NewBadRequestError("Failed to load the collections.", err)
+	}
+
+	admin, _ := c.Get(ContextAdminKey).(*models.Admin)
+
+	// live data is exposed only to admins
+	var examples map[string]map[string]any
+	if admin != nil && api.app.Settings().Swagger.LiveExamples {
+		examples = swaggerRecordExamples(api.app.Dao(), collections)
+	}
+
+	spec, err := buildSwaggerSpec(collections, admin != nil, examples)
+	if err != nil {
+		return nil, NewBadRequestError("Failed to build the api spec.", err)
+	}
+
+	return spec, nil
+}
+
+// requireSwaggerAccess middleware requires a request from an admin
+// or with a valid api docs key in the [ApiKeyHeader] request header.
+//
+// The api docs key could be either the shared [settings.SwaggerConfig] key
+// or a regular api key with the [ApiKeyScopeDocs] scope. In both cases
+// the docs are generated as for a guest (aka. without the admin only routes).
+//
+// If the api docs are disabled it always returns 404.
+func requireSwaggerAccess(app core.App) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			config := app.Settings().Swagger
+
+			if !config.Enabled {
+				return NewNotFoundError("", nil)
+			}
+
+			if admin, _ := c.Get(ContextAdminKey).(*models.Admin); admin != nil {
+				return next(c)
+			}
+
+			key := c.Request().Header.Get(ApiKeyHeader)
+			if key == "" {
+				return NewUnauthorizedError("The request requires admin authorization token or valid api docs key to be set.", nil)
+			}
+
+			if config.ApiKey != "" && subtle.ConstantTimeCompare([]byte(key), []byte(config.ApiKey)) == 1 {
+				return next(c)
+			}
+
+			apiKey, err := app.Dao().FindApiKeyByKey(key)
+			if err != nil || apiKey.IsExpired() || !apiKey.HasScope(ApiKeyScopeDocs) {
+				return NewUnauthorizedError("The request requires admin authorization token or valid api docs key to be set.", nil)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// requireSwaggerEnabled middleware returns 404 if the api docs are disabled.
+func requireSwaggerEnabled(app core.App) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !app.Settings().Swagger.Enabled {
+				return NewNotFoundError("", nil)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// note: the swagger-ui assets are served by the "/api/docs/*" route
+var swaggerUITemplate = template.Must(template.New("swagger_ui").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+	<meta charset="utf-8">
+	<meta name="viewport" content="width=device-width, initial-scale=1">
+	<title>API docs</title>
+	<link rel="stylesheet" href="/api/docs/swagger-ui.css">
+</head>
+<body>
+	<div id="swagger-ui"></div>
+	<script src="/api/docs/swagger-ui-bundle.js"></script>
+	<script>
+		window.ui = SwaggerUIBundle({
+			spec: {{.Spec}},
+			dom_id: "#swagger-ui",
+			deepLinking: true,
+		});
+	</script>
+</body>
+</html>
+`))
+
+// swaggerRecordExamples returns the latest record of each of the provided
+// collections in its api response shape (indexed by the collection id).
+//
+// Collections without records (or that failed to load) are skipped.
+func swaggerRecordExamples(dao *daos.Dao, collections []*models.Collection) map[string]map[string]any {
+	examples := make(map[string]map[string]any, len(collections))
+
+	for _, collection := range collections {
+		query := dao.RecordQuery(collection).Limit(1)
+
+		// views are not guaranteed to have a created column
+		if !collection.IsView() {
+			query.OrderBy(schema.FieldNameCreated + " DESC")
+		}
+
+		record := &models.Record{}
+		if err := query.One(record); err != nil {
+			continue
+		}
+
+		record.IgnoreEmailVisibility(true)
+
+		examples[collection.Id] = record.PublicExport()
+	}
+
+	return examples
+}
+
+// buildSwaggerSpec extends the static api spec with the records
+// endpoints and models of the provided collections.
+//
+// If isAdmin is false, only the endpoints with non-nil API rule
+// (aka. accessible not only by admins) are included.
+//
+// The optional examples (indexed by collection id) are embedded
+// as the record models example values.
+func buildSwaggerSpec(collections []*models.Collection, isAdmin bool, examples map[string]map[string]any) (map[string]any, error) {
+	spec := map[string]any{}
+	if err := json.Unmarshal(swaggerSpec, &spec); err != nil {
+		return nil, err
+	}
+
+	if v, _ := spec["basePath"].(string); v == "" {
+		spec["basePath"] = "/api"
+	}
+
+	spec["securityDefinitions"] = map[string]any{
+		"Auth": map[string]any{
+			"type": "apiKey",
+			"name": "Authorization",
+			"in":   "header",
+		},
+		"AdminAuth": map[string]any{
+			"type": "apiKey",
+			"name": "Authorization",
+			"in":   "header",
+		},
+		"ApiKeyAuth": map[string]any{
+			"type": "apiKey",
+			"name": ApiKeyHeader,
+			"in":   "header",
+		},
+	}
+
+	paths, _ := spec["paths"].(map[string]any)
+	if paths == nil {
+		paths = map[string]any{}
+		spec["paths"] = paths
+	}
+
+	definitions, _ := spec["definitions"].(map[string]any)
+	if definitions == nil {
+		definitions = map[string]any{}
+		spec["definitions"] = definitions
+	}
+
+	for _, collection := range collections {
+		b := &swaggerCollectionBuilder{collection: collection, isAdmin: isAdmin}
+
+		listPath, itemPath := b.paths()
+		if len(listPath) == 0 && len(itemPath) == 0 {
+			continue // no accessible endpoints
+		}
+
+		basePath := "/collections/" + collection.Name + "/records"
+		if len(listPath) > 0 {
+			paths[basePath] = listPath
+		}
+		if len(itemPath) > 0 {
+			paths[basePath+"/{id}"] = itemPath
+		}
+
+		recordModel := b.recordModel()
+		if example, ok := examples[collection.Id]; ok {
+			recordModel["example"] = example
+		}
+		definitions[b.recordDefinition()] = recordModel
+		if !collection.IsView() {
+			definitions[b.upsertDefinition()] = b.upsertModel()
+		}
+	}
+
+	return spec, nil
+}
+
+type swaggerCollectionBuilder struct {
+	collection *models.Collection
+	isAdmin    bool
+}
+
+func (b *swaggerCollectionBuilder) recordDefinition() string {
+	return "records." + b.collection.Name
+}
+
+func (b *swaggerCollectionBuilder) upsertDefinition() string {
+	return "records." + b.collection.Name + ".upsert"
+}
+
+func (b *swaggerCollectionBuilder) ref(definition string) map[string]any {
+	return map[string]any{"$ref": "#/definitions/" + definition}
+}
+
+// paths returns the accessible records list and single record path operations.
+func (b *swaggerCollectionBuilder) paths() (map[string]any, map[string]any) {
+	c := b.collection
+	name := c.Name
+
+	listPath := map[string]any{}
+	itemPath := map[string]any{}
+
+	expandParam := b.queryParam("expand", "Связи для раскрытия (через запятую)", "string")
+	idParam := map[string]any{
+		"name":        "id",
+		"in":          "path",
+		"type":        "string",
+		"required":    true,
+		"description": "Идентификатор записи",
+	}
+
+	if op := b.operation(c.ListRule, "Получение списка записей "+name); op != nil {
+		op["parameters"] = []any{
+			b.queryParam("page", "Номер страницы", "integer"),
+			b.queryParam("perPage", "Количество записей на странице", "integer"),
+			b.queryParam("sort", "Сортировка (например -created,id)", "string"),
+			b.queryParam("filter", "Фильтр записей", "string"),
+			expandParam,
+		}
+		op["responses"].(map[string]any)["200"] = map[string]any{
+			"description": "OK",
+			"schema": map[string]any{
+				"allOf": []any{
+					b.ref("apis.SearchResult"),
+					map[string]any{
+						"type": "object",
+						"properties": map[string]any{
+							"items": map[string]any{
+								"type":  "array",
+								"items": b.ref(b.recordDefinition()),
+							},
+						},
+					},
+				},
+			},
+		}
+		listPath["get"] = op
+	}
+
+	if op := b.operation(c.ViewRule, "Просмотр записи "+name); op != nil {
+		op["parameters"] = []any{idParam, expandParam}
+		op["responses"].(map[string]any)["200"] = map[string]any{
+			"description": "OK",
+			"schema":      b.ref(b.recordDefinition()),
+		}
+		itemPath["get"] = op
+	}
+
+	// views are read-only
+	if c.IsView() {
+		return listPath, itemPath
+	}
+
+	bodyParam := map[string]any{
+		"name":     "body",
+		"in":       "body",
+		"required": true,
+		"schema":   b.ref(b.upsertDefinition()),
+	}
+
+	if op := b.operation(c.CreateRule, "Создание записи "+name); op != nil {
+		op["consumes"] = []any{"application/json", "multipart/form-data"}
+		op["parameters"] = []any{bodyParam, expandParam}
+		op["responses"].(map[string]any)["200"] = map[string]any{
+			"description": "OK",
+			"schema":      b.ref(b.recordDefinition()),
+		}
+		listPath["post"] = op
+	}
+
+	if op := b.operation(c.UpdateRule, "Обновление записи "+name); op != nil {
+		op["consumes"] = []any{"application/json", "multipart/form-data"}
+		op["parameters"] = []any{idParam, bodyParam, expandParam}
+		op["responses"].(map[string]any)["200"] = map[string]any{
+			"description": "OK",
+			"schema":      b.ref(b.recordDefinition()),
+		}
+		itemPath["patch"] = op
+	}
+
+	if op := b.operation(c.DeleteRule, "Удаление записи "+name); op != nil {
+		op["parameters"] = []any{idParam}
+		op["responses"].(map[string]any)["204"] = map[string]any{
+			"description": "No Content",
+		}
+		itemPath["delete"] = op
+	}
+
+	return listPath, itemPath
+}
+
+// operation returns a new base path operation or nil if the rule
+// doesn't allow access to the current requester.
+func (b *swaggerCollectionBuilder) operation(rule *string, summary string) map[string]any {
+	if rule == nil && !b.isAdmin {
+		return nil
+	}
+
+	security := "Auth"
+	if rule == nil {
+		security = "AdminAuth"
+	}
+
+	return map[string]any{
+		"summary":  summary,
+		"tags":     []any{"Record (" + b.collection.Name + ")"},
+		"produces": []any{"application/json"},
+		"security": []any{map[string]any{security: []any{}}},
+		"responses": map[string]any{
+			"400": map[string]any{"description": "Failed to process the request.", "schema": map[string]any{"type": "string"}},
+			"403": map[string]any{"description": "Only admins can perform this action.", "schema": map[string]any{"type": "string"}},
+			"404": map[string]any{"description": "Not found.", "schema": map[string]any{"type": "string"}},
+		},
+	}
+}
+
+func (b *swaggerCollectionBuilder) queryParam(name string, description string, paramType string) map[string]any {
+	return map[string]any{
+		"name":        name,
+		"in":          "query",
+		"type":        paramType,
+		"description": description,
+	}
+}
+
+// recordModel returns the collection record response model.
+func (b *swaggerCollectionBuilder) recordModel() map[string]any {
+	dateTime := map[string]any{"type": "string", "format": "date-time"}
+
+	properties := map[string]any{
+		schema.FieldNameId:             map[string]any{"type": "string"},
+		schema.FieldNameCreated:        dateTime,
+		schema.FieldNameUpdated:        dateTime,
+		schema.FieldNameCollectionId:   map[string]any{"type": "string"},
+		schema.FieldNameCollectionName: map[string]any{"type": "string"},
+		schema.FieldNameExpand:         map[string]any{"type": "object"},
+	}
+
+	if b.collection.IsAuth() {
+		properties[schema.FieldNameUsername] = map[string]any{"type": "string"}
+		properties[schema.FieldNameEmail] = map[string]any{"type": "string", "format": "email"}
+		properties[schema.FieldNameEmailVisibility] = map[string]any{"type": "boolean"}
+		properties[schema.FieldNameVerified] = map[string]any{"type": "boolean"}
+	}
+
+	for _, field := range b.collection.Schema.Fields() {
+		properties[field.Name] = swaggerFieldModel(field)
+	}
+
+	return map[string]any{
+		"type":       "object",
+		"properties": properties,
+	}
+}
+
+// upsertModel returns the collection record create/update request body model.
+func (b *swaggerCollectionBuilder) upsertModel() map[string]any {
+	properties := map[string]any{
+		schema.FieldNameId: map[string]any{"type": "string"},
+	}
+
+	required := []any{}
+
+	if b.collection.IsAuth() {
+		properties[schema.FieldNameUsername] = map[string]any{"type": "string"}
+		properties[schema.FieldNameEmail] = map[string]any{"type": "string", "format": "email"}
+		properties[schema.FieldNameEmailVisibility] = map[string]any{"type": "boolean"}
+		properties[schema.FieldNameVerified] = map[string]any{"type": "boolean"}
+		properties["password"] = map[string]any{"type": "string"}
+		properties["passwordConfirm"] = map[string]any{"type": "string"}
+		properties["oldPassword"] = map[string]any{"type": "string"}
+	}
+
+	for _, field := range b.collection.Schema.Fields() {
+		properties[field.Name] = swaggerFieldModel(field)
+
+		if field.Required {
+			required = append(required, field.Name)
+		}
+	}
+
+	result := map[string]any{
+		"type":       "object",
+		"properties": properties,
+	}
+
+	if len(required) > 0 {
+		result["required"] = required
+	}
+
+	return result
+}
+
+// swaggerFieldModel returns the swagger schema of a single collection field value.
+func swaggerFieldModel(field *schema.SchemaField) map[string]any {
+	field.InitOptions()
+
+	var result map[string]any
+
+	switch field.Type {
+	case schema.FieldTypeNumber:
+		result = map[string]any{"type": "number"}
+	case schema.FieldTypeBool:
+		result = map[string]any{"type": "boolean"}
+	case schema.FieldTypeDate:
+		result = map[string]any{"type": "string", "format": "date-time"}
+	case schema.FieldTypeEmail:
+		result = map[string]any{"type": "string", "format": "email"}
+	case schema.FieldTypeUrl:
+		result = map[string]any{"type": "string", "format": "uri"}
+	case schema.FieldTypeJson:
+		result = map[string]any{} // any json value
+	case schema.FieldTypeGeoPoint:
+		result = map[string]any{
+			"type":       "object",
+			"x-nullable": true,
+			"properties": map[string]any{
+				"lat": map[string]any{"type": "number", "minimum": -90, "maximum": 90},
+				"lng": map[string]any{"type": "number", "minimum": -180, "maximum": 180},
+			},
+		}
+	case schema.FieldTypeSelect:
+		result = map[string]any{"type": "string"}
+		if options, ok := field.Options.(*schema.SelectOptions); ok && len(options.Values) > 0 {
+			enum := make([]any, len(options.Values))
+			for i, v := range options.Values {
+				enum[i] = v
+			}
+			result["enum"] = enum
+		}
+	default:
+		result = map[string]any{"type": "string"}
+	}
+
+	if multi, ok := field.Options.(schema.MultiValuer); ok && multi.IsMultiple() {
+		return map[string]any{
+			"type":  "array",
+			"items": result,
+		}
+	}
+
+	return result
+}
@@ -3,14 +3,28 @@ package apis
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"log"
+	"math"
+	"mime"
 	"net/http"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v5"
 	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/forms"
 	"github.com/pocketbase/pocketbase/models"
 	"github.com/pocketbase/pocketbase/registry"
+	"github.com/pocketbase/pocketbase/tokens"
+	"github.com/pocketbase/pocketbase/tools/anonymize"
+	"github.com/pocketbase/pocketbase/tools/search"
 
 	"golang.org/x/crypto/bcrypt"
 
@@ -24,6 +38,71 @@ func HashPassword(password []byte) ([]byte, error) {
 	return bytes, err
 }
 
+// errUserEmailExists is returned by checkUniqueUserEmail
+// when the users email is already in use.
+var errUserEmailExists = errors.New("email already exists")
+
+// uniqueUserEmailMux serializes the users email uniqueness checks
+// together with their related writes (see lockUniqueUserEmail).
+var uniqueUserEmailMux sync.Mutex
+
+// lockUniqueUserEmail locks the users email writes if the app
+// Settings().Users.UniqueEmail constraint is enabled and returns
+// the related unlock function.
+//
+// It is expected to wrap the transaction of the checkUniqueUserEmail
+// check and the user write so that two concurrent requests can't both
+// pass the check before any of them is written.
+func lockUniqueUserEmail(app core.App) func() {
+	if !app.Settings().Users.UniqueEmail {
+		return func() {}
+	}
+
+	uniqueUserEmailMux.Lock()
+
+	return uniqueUserEmailMux.Unlock
+}
+
+// checkUniqueUserEmail returns errUserEmailExists if the app
+// Settings().Users.UniqueEmail constraint is enabled and another user
+// (the soft deleted ones included) has the same (case-insensitive) email.
+func checkUniqueUserEmail(app core.App, db *gorm.DB, email string, excludeId string) error {
+	if !app.Settings().Users.UniqueEmail || email == "" {
+		return nil
+	}
+
+	query := db.Unscoped().Model(&models.User{}).Where("LOWER(email) = LOWER(?)", email)
+	if excludeId != "" {
+		query = query.Where("id != ?", excludeId)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return err
+	}
+
+	if total > 0 {
+		return errUserEmailExists
+	}
+
+	return nil
+}
+
+// userWriteErrorResponse writes the user write (and checkUniqueUserEmail)
+// error response (409 for the already used emails and the duplicated
+// keys and 500 for everything else).
+func userWriteErrorResponse(c echo.Context, err error) error {
+	if errors.Is(err, errUserEmailExists) || errors.Is(err, gorm.ErrDuplicatedKey) {
+		return c.JSON(http.StatusConflict, Error{
+			Error: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusInternalServerError, Error{
+		Error: err.Error(),
+	})
+}
+
 type ModelCU struct {
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
@@ -48,6 +127,10 @@ type Meta struct {
 type UserDataID struct {
 	models.UserData
 	ID
+
+	// DeletedAt is set only for the soft deleted users
+	// (see the listUsers includeDeleted flag).
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
 
 type UserPureID struct {
@@ -56,10 +139,83 @@ type UserPureID struct {
 }
 
 type UserMetaAdmin struct {
-	Admin bool `json:"admin,omitempty" query:"admin"`
+	Admin          bool `json:"admin,omitempty" query:"admin"`
+	IncludeDeleted bool `json:"-" query:"includeDeleted"`
+
+	// Anonymize is the name of the settings anonymization profile
+	// to apply to the listed users.
+	Anonymize string `json:"-" query:"anonymize"`
 	Meta
 }
 
+// List of the supported list response formats.
+const (
+	// FormatDataMeta is the default DataMeta list envelope.
+	FormatDataMeta = "dataMeta"
+
+	// FormatSearchResult is the standard PocketBase search.Result list shape.
+	FormatSearchResult = "searchResult"
+)
+
+// MimeSearchResult is the Accept header media type that could be used
+// as alternative to the `format=searchResult` query parameter.
+const MimeSearchResult = "application/vnd.pocketbase.searchresult+json"
+
+// SearchResultPage holds the search.Result pagination query parameters.
+type SearchResultPage struct {
+	Page    int `query:"page"`
+	PerPage int `query:"perPage"`
+}
+
+// Normalize applies the search.Provider page defaults and limits.
+func (p *SearchResultPage) Normalize() {
+	if p.Page <= 0 {
+		p.Page = 1
+	}
+
+	if p.PerPage <= 0 {
+		p.PerPage = search.DefaultPerPage
+	} else if p.PerPage > search.MaxPerPage {
+		p.PerPage = search.MaxPerPage
+	}
+}
+
+// Offset returns the number of items to skip for the current page.
+func (p *SearchResultPage) Offset() int {
+	return (p.Page - 1) * p.PerPage
+}
+
+// Result wraps the page items and total count into a search.Result.
+func (p *SearchResultPage) Result(items any, totalItems int64) *search.Result {
+	return &search.Result{
+		Page:       p.Page,
+		PerPage:    p.PerPage,
+		TotalItems: int(totalItems),
+		TotalPages: int(math.Ceil(float64(totalItems) / float64(p.PerPage))),
+		Items:      items,
+	}
+}
+
+// IsSearchResultRequest reports whether the request asks for the standard
+// PocketBase search.Result list shape, either with the `format` query
+// parameter (which takes precedence) or with the Accept header.
+func IsSearchResultRequest(r *http.Request) bool {
+	switch r.URL.Query().Get("format") {
+	case FormatSearchResult:
+		return true
+	case FormatDataMeta:
+		return false
+	}
+
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		if mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part)); err == nil && mediaType == MimeSearchResult {
+			return true
+		}
+	}
+
+	return false
+}
+
 type Data struct {
 	Data interface{} `json:"data,omitempty" swaggertype:"object,string"`
 }
@@ -72,12 +228,31 @@ type DataMeta struct {
 func bindUsersApi(app core.App, rg *echo.Group) {
 	api := usersApi{app: app}
 
-	subGroup := rg.Group("/users", RequireAdminAuth())
-	subGroup.GET("/", api.listUsers)
+	// the group root routes are registered without a trailing slash (as in the
+	// other api groups) since it is removed from the /api/* requests before the
+	// routing and the "/users/" routes could never match
+	subGroup := rg.Group("/users", RequireApiKeyAuth(app, ApiKeyScopeUsers), RequireAdminAuth())
+	subGroup.GET("", api.listUsers)
+	subGroup.GET("/lookup", api.getUser)
 	subGroup.GET("/:id", api.getUser)
 	subGroup.DELETE("/:id", api.deleteUser)
-	subGroup.POST("/", api.postUser)
-	subGroup.PATCH("/", api.patchUser)
+	subGroup.POST("/:id/restore", api.restoreUser)
+	subGroup.POST("", api.postUser, idempotent(app, newIdempotencyStore()))
+	subGroup.POST("/import", api.importUsers)
+	subGroup.GET("/export", api.exportUsers)
+	subGroup.PATCH("", api.patchUser)
+	subGroup.GET("/groups", api.listGroups)
+	subGroup.POST("/groups", api.postGroup)
+	subGroup.GET("/groups/:name", api.getGroup)
+	subGroup.PUT("/groups/:name", api.putGroup)
+	subGroup.DELETE("/groups/:name", api.deleteGroup)
+
+	rg.POST("/users/auth-with-password", api.authWithPassword)
+
+	// self-service routes of the authorized GORM user
+	meGroup := rg.Group("/users/me", LoadUserAuthContext(app), RequireUserAuth())
+	meGroup.PATCH("", api.patchMe)
+	meGroup.PUT("/password", api.changeMePassword)
 }
 
 // @Summary List users
@@ -88,7 +263,13 @@ func bindUsersApi(app core.App, rg *echo.Group) {
 // @Param limit query int false "set the limit, default is 20"
 // @Param offset query int false "set the offset, default is 0"
 // @Param search query string string "search item"
+// @Param includeDeleted query bool false "include the soft deleted users, default is false"
+// @Param anonymize query string false "anonymization profile to apply to the users data"
+// @Param format query string false "response format (dataMeta or searchResult), default is dataMeta"
+// @Param page query int false "searchResult format page, default is 1"
+// @Param perPage query int false "searchResult format items per page, default is 30"
 // @Success 200 {object} DataMeta{data=[]UserDataID{},meta=Meta{}}
+// @Success 200 {object} search.Result{items=[]UserDataID{}} "with format=searchResult"
 // @failure 400 {object} Error{}
 // @failure 500 {object} Error{}
 func (api *usersApi) listUsers(c echo.Context) error {
@@ -105,12 +286,40 @@ func (api *usersApi) listUsers(c echo.Context) error {
 		})
 	}
 
+	// the search.Result shape is paginated with page/perPage
+	// instead of the limit/offset pair
+	var page *SearchResultPage
+	if IsSearchResultRequest(c.Request()) {
+		page = &SearchResultPage{}
+		if err := c.Bind(page); err != nil {
+			return c.JSON(http.StatusBadRequest, Error{
+				Error: err.Error(),
+			})
+		}
+		page.Normalize()
+
+		meta.Limit = page.PerPage
+		meta.Offset = page.Offset()
+	}
+
+	config := api.app.Settings().Anonymization
+	profile, ok := config.Profile(meta.Anonymize)
+	if meta.Anonymize != "" && !ok {
+		return c.JSON(http.StatusBadRequest, Error{
+			Error: "invalid anonymization profile",
+		})
+	}
+
 	reg, err := registry.Get(c.Get("registry").(string))
 	if err != nil {
 		return err
 	}
 	query := reg.DB.WithContext(c.Request().Context()).Model(&models.User{}).Limit(meta.Limit).Offset(meta.Offset)
 
+	if meta.IncludeDeleted {
+		query = query.Unscoped()
+	}
+
 	if meta.Search != "" {
 		query = query.Where("name LIKE ?", meta.Search+"%")
 	}
@@ -126,32 +335,74 @@ func (api *usersApi) listUsers(c echo.Context) error {
 
 	// get counts
 	query = reg.DB.WithContext(c.Request().Context()).Model(&models.User{})
+	if meta.IncludeDeleted {
+		query = query.Unscoped()
+	}
 	if meta.Search != "" {
 		query = query.Where("name LIKE ?", meta.Search+"%")
 	}
 
 	query.Count(&meta.Count)
 
+	var items any = users
+	if meta.Anonymize != "" {
+		items, err = AnonymizeUsers(users, profile.CollectionFields("users"), config.Salt)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, Error{
+				Error: err.Error(),
+			})
+		}
+	}
+
+	if page != nil {
+		return c.JSON(http.StatusOK, page.Result(items, meta.Count))
+	}
+
 	return c.JSON(http.StatusOK, DataMeta{
 		Meta: meta.Meta,
-		Data: Data{Data: users},
+		Data: Data{Data: items},
 	})
 }
 
+// AnonymizeUsers converts the provided users to their json
+// map representation and anonymizes the specified fields
+// (field name -> anonymization strategy).
+func AnonymizeUsers(users []UserDataID, fields map[string]string, salt string) ([]map[string]any, error) {
+	raw, err := json.Marshal(users)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []map[string]any{}
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, err
+	}
+
+	for _, item := range result {
+		anonymize.Apply(item, fields, salt)
+	}
+
+	return result, nil
+}
+
 // @Summary Get user
 // @Tags user
-// @Description Get one user with id or name
+// @Description Get one user by id and/or email (case-insensitive)
+// @Description The lookup by email only is available with "lookup" as id (eg. /users/lookup?email=...)
 // @Security ApiKeyAuth
-// @Router /user [get]
-// @Param id query string false "get by id"
+// @Router /users/{id} [get]
+// @Param id path string true "get by id"
+// @Param email query string false "get by email"
 // @Success 200 {object} Data{data=UserDataID{}}
 // @failure 400 {object} Error{}
 // @failure 404 {object} Error{}
+// @failure 409 {object} Error{} "multiple users with the same email"
 // @failure 500 {object} Error{}
 func (api *usersApi) getUser(c echo.Context) error {
-	id := c.QueryParam("id")
+	id := c.PathParam("id")
+	email := c.QueryParam("email")
 
-	if id == "" {
+	if id == "" && email == "" {
 		return c.JSON(http.StatusBadRequest, Error{
 			Error: models.ErrRequiredIDName.Error(),
 		})
@@ -169,6 +420,25 @@ func (api *usersApi) getUser(c echo.Context) error {
 		query = query.Where("id = ?", id)
 	}
 
+	if email != "" {
+		query = query.Where("LOWER(email) = LOWER(?)", email)
+
+		// the emails are not unique unless the
+		// Settings().Users.UniqueEmail constraint is enabled
+		var total int64
+		if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+			return c.JSON(http.StatusInternalServerError, Error{
+				Error: err.Error(),
+			})
+		}
+
+		if total > 1 {
+			return c.JSON(http.StatusConflict, Error{
+				Error: "multiple users found with the same email",
+			})
+		}
+	}
+
 	result := query.First(&user)
 
 	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
@@ -190,10 +460,11 @@ func (api *usersApi) getUser(c echo.Context) error {
 
 // @Summary Delete user
 // @Tags user
-// @Description Delete with id or name
+// @Description Soft delete with id or name (permanently with permanent=true)
 // @Security ApiKeyAuth
 // @Router /user [delete]
 // @Param id query string false "get by id"
+// @Param permanent query bool false "delete permanently instead of soft delete, default is false"
 // @Success 204 "No Content"
 // @failure 400 {object} Error{}
 // @failure 404 {object} Error{}
@@ -212,27 +483,99 @@ func (api *usersApi) deleteUser(c echo.Context) error {
 		return err
 	}
 
-	query := reg.DB.WithContext(c.Request().Context())
-	if id != "" {
-		query = query.Where("id = ?", id)
+	event := new(core.UserDeleteEvent)
+	event.HttpContext = c
+	event.RequestInfo = RequestInfo(c)
+	event.UserId = id
+	event.Permanent, _ = strconv.ParseBool(c.QueryParam("permanent"))
+
+	var deleted bool
+
+	handlerErr := api.app.OnUserBeforeDeleteRequest().Trigger(event, func(e *core.UserDeleteEvent) error {
+		query := reg.DB.WithContext(e.HttpContext.Request().Context()).Where("id = ?", e.UserId)
+
+		if e.Permanent {
+			query = query.Unscoped()
+		}
+
+		result := query.Delete(&models.User{})
+
+		if result.RowsAffected == 0 {
+			return e.HttpContext.JSON(http.StatusNotFound, Error{
+				Error: "not found any related data",
+			})
+		}
+
+		if result.Error != nil {
+			return e.HttpContext.JSON(http.StatusInternalServerError, Error{
+				Error: result.Error.Error(),
+			})
+		}
+
+		deleted = true
+
+		return e.HttpContext.NoContent(http.StatusNoContent)
+	})
+
+	if handlerErr != nil {
+		return c.JSON(http.StatusBadRequest, Error{
+			Error: handlerErr.Error(),
+		})
 	}
 
-	// delete directly in DB
-	result := query.Unscoped().Delete(&models.User{})
+	if deleted {
+		if err := api.app.OnUserAfterDeleteRequest().Trigger(event); err != nil && api.app.IsDebug() {
+			log.Println(err)
+		}
+	}
 
-	if result.RowsAffected == 0 {
-		return c.JSON(http.StatusNotFound, Error{
-			Error: "not found any related data",
+	return nil
+}
+
+// @Summary Restore user
+// @Tags user
+// @Description Restore a soft deleted user
+// @Security ApiKeyAuth
+// @Router /users/{id}/restore [post]
+// @Param id path string true "user id"
+// @Success 200 {object} Data{data=ID{}}
+// @failure 400 {object} Error{}
+// @failure 404 {object} Error{}
+// @failure 500 {object} Error{}
+func (api *usersApi) restoreUser(c echo.Context) error {
+	id, err := uuid.Parse(c.PathParam("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Error{
+			Error: models.ErrRequiredIDName.Error(),
 		})
 	}
 
+	reg, err := registry.Get(c.Get("registry").(string))
+	if err != nil {
+		return err
+	}
+
+	result := reg.DB.WithContext(c.Request().Context()).
+		Unscoped().
+		Model(&models.User{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+
 	if result.Error != nil {
 		return c.JSON(http.StatusInternalServerError, Error{
 			Error: result.Error.Error(),
 		})
 	}
 
-	return c.NoContent(http.StatusNoContent)
+	if result.RowsAffected == 0 {
+		return c.JSON(http.StatusNotFound, Error{
+			Error: "not found any deleted user",
+		})
+	}
+
+	return c.JSON(http.StatusOK, Data{
+		Data: ID{ID: id},
+	})
 }
 
 // @Summary New user
@@ -241,9 +584,12 @@ func (api *usersApi) deleteUser(c echo.Context) error {
 // @Security ApiKeyAuth
 // @Router /user [post]
 // @Param payload body models.UserPure{} false "send user object"
+// @Param Idempotency-Key header string false "idempotency key to safely retry the request"
 // @Success 200 {object} Data{data=ID{}}
+// @Header 200 {string} Idempotent-Replayed "true if the response is a replay of the stored one"
 // @failure 400 {object} Error{}
 // @failure 409 {object} Error{}
+// @failure 422 {object} Error{}
 // @failure 500 {object} Error{}
 func (api *usersApi) postUser(c echo.Context) error {
 	body := new(models.UserPure)
@@ -286,28 +632,286 @@ func (api *usersApi) postUser(c echo.Context) error {
 		})
 	}
 
-	result := reg.DB.WithContext(c.Request().Context()).Create(&models.User{
+	event := new(core.UserCreateEvent)
+	event.HttpContext = c
+	event.RequestInfo = RequestInfo(c)
+	event.User = &models.User{
 		UserPure: *body,
 		ModelCU: models.ModelCU{
 			ID: models.ID{ID: id},
 		},
+	}
+
+	var created bool
+
+	handlerErr := api.app.OnUserBeforeCreateRequest().Trigger(event, func(e *core.UserCreateEvent) error {
+		unlock := lockUniqueUserEmail(api.app)
+		defer unlock()
+
+		err := reg.DB.WithContext(e.HttpContext.Request().Context()).Transaction(func(tx *gorm.DB) error {
+			if err := checkUniqueUserEmail(api.app, tx, e.User.Email, ""); err != nil {
+				return err
+			}
+
+			return tx.Create(e.User).Error
+		})
+
+		// check write error
+		if err != nil {
+			return userWriteErrorResponse(e.HttpContext, err)
+		}
+
+		created = true
+
+		return e.HttpContext.JSON(http.StatusOK, Data{
+			Data: ID{ID: e.User.ID.ID},
+		})
 	})
 
-	// check write error
-	if result.Error != nil && errors.Is(result.Error, gorm.ErrDuplicatedKey) {
-		return c.JSON(http.StatusConflict, Error{
-			Error: result.Error.Error(),
+	if handlerErr != nil {
+		return c.JSON(http.StatusBadRequest, Error{
+			Error: handlerErr.Error(),
 		})
 	}
 
-	if result.Error != nil {
+	if created {
+		if err := api.app.OnUserAfterCreateRequest().Trigger(event); err != nil && api.app.IsDebug() {
+			log.Println(err)
+		}
+	}
+
+	return nil
+}
+
+// UsersImport is the users bulk import request body.
+type UsersImport struct {
+	Users []models.UserPure `json:"users"`
+
+	// DeleteMissing soft deletes all existing users whose name
+	// is not found in the imported Users list.
+	DeleteMissing bool `json:"deleteMissing"`
+
+	// Transforms is the list of the field transformation steps
+	// applied on each imported user before its validation.
+	Transforms []*forms.ImportTransform `json:"transforms"`
+}
+
+// UsersImportError describes the failure of a single imported user.
+type UsersImportError struct {
+	Index int    `json:"index" example:"0"`
+	Name  string `json:"name" example:"userX"`
+	Error string `json:"error" example:"name is required"`
+}
+
+// UsersImportResult is the users bulk import response.
+type UsersImportResult struct {
+	Created []ID  `json:"created"`
+	Updated []ID  `json:"updated"`
+	Deleted int64 `json:"deleted" example:"0"`
+}
+
+// ApplyTransforms applies the form transformation steps on each imported user.
+func (form *UsersImport) ApplyTransforms(dao *daos.Dao) []UsersImportError {
+	errs := []UsersImportError{}
+
+	for i, t := range form.Transforms {
+		if t == nil {
+			continue
+		}
+
+		if err := t.Validate(); err != nil {
+			errs = append(errs, UsersImportError{Index: -1, Error: fmt.Sprintf("invalid transform %d: %v", i, err)})
+		}
+	}
+
+	if len(errs) > 0 || len(form.Transforms) == 0 {
+		return errs
+	}
+
+	for i, user := range form.Users {
+		// transform the json representation of the user
+		// so that the steps field names match the request ones
+		row := map[string]any{}
+		raw, _ := json.Marshal(user)
+		json.Unmarshal(raw, &row)
+
+		if err := forms.ApplyImportTransforms(dao, form.Transforms, row); err != nil {
+			errs = append(errs, UsersImportError{Index: i, Name: user.Name, Error: err.Error()})
+			continue
+		}
+
+		transformed := models.UserPure{}
+		raw, _ = json.Marshal(row)
+		if err := json.Unmarshal(raw, &transformed); err != nil {
+			errs = append(errs, UsersImportError{Index: i, Name: user.Name, Error: err.Error()})
+			continue
+		}
+
+		form.Users[i] = transformed
+	}
+
+	return errs
+}
+
+// Validate checks the required fields and the uniqueness
+// of the imported user names.
+func (form *UsersImport) Validate() []UsersImportError {
+	errs := []UsersImportError{}
+
+	if len(form.Users) == 0 {
+		return append(errs, UsersImportError{Index: -1, Error: "users are required"})
+	}
+
+	names := make(map[string]int, len(form.Users))
+
+	for i, user := range form.Users {
+		switch {
+		case user.Name == "":
+			errs = append(errs, UsersImportError{Index: i, Error: "name is required"})
+		case user.Password == "":
+			errs = append(errs, UsersImportError{Index: i, Name: user.Name, Error: "password is required"})
+		default:
+			if prev, ok := names[user.Name]; ok {
+				errs = append(errs, UsersImportError{Index: i, Name: user.Name, Error: fmt.Sprintf("duplicated name (see user %d)", prev)})
+			} else {
+				names[user.Name] = i
+			}
+		}
+	}
+
+	return errs
+}
+
+// @Summary Import users
+// @Tags user
+// @Description Create or update (matched by name) users in a single transaction
+// @Description The optional transforms (trim, lowercase, date, lookup, default) are applied on each user before the validation
+// @Security ApiKeyAuth
+// @Router /users/import [post]
+// @Param payload body UsersImport{} true "users to import"
+// @Success 200 {object} Data{data=UsersImportResult{}}
+// @failure 400 {object} Error{error=[]UsersImportError{}}
+// @failure 500 {object} Error{}
+func (api *usersApi) importUsers(c echo.Context) error {
+	body := new(UsersImport)
+	if err := c.Bind(body); err != nil {
+		return c.JSON(http.StatusBadRequest, Error{
+			Error: err.Error(),
+		})
+	}
+
+	if errs := body.ApplyTransforms(api.app.Dao()); len(errs) > 0 {
+		return c.JSON(http.StatusBadRequest, Error{
+			Error: errs,
+		})
+	}
+
+	if errs := body.Validate(); len(errs) > 0 {
+		return c.JSON(http.StatusBadRequest, Error{
+			Error: errs,
+		})
+	}
+
+	reg, err := registry.Get(c.Get("registry").(string))
+	if err != nil {
+		return err
+	}
+
+	result := &UsersImportResult{
+		Created: []ID{},
+		Updated: []ID{},
+	}
+
+	// the first failed row aborts (and rollbacks) the whole import
+	var rowErr *UsersImportError
+
+	unlock := lockUniqueUserEmail(api.app)
+	defer unlock()
+
+	txErr := reg.DB.WithContext(c.Request().Context()).Transaction(func(tx *gorm.DB) error {
+		names := make([]string, 0, len(body.Users))
+
+		for i, user := range body.Users {
+			names = append(names, user.Name)
+
+			fail := func(err error) error {
+				rowErr = &UsersImportError{Index: i, Name: user.Name, Error: err.Error()}
+				return err
+			}
+
+			hashedPassword, err := HashPassword([]byte(user.Password))
+			if err != nil {
+				return fail(err)
+			}
+			user.Password = string(hashedPassword)
+
+			// soft deleted users are matched too (and restored)
+			// because their names are still unique
+			existing := new(models.User)
+			findErr := tx.Unscoped().Where("name = ?", user.Name).First(existing).Error
+
+			var excludeId string
+			if findErr == nil {
+				excludeId = existing.ID.ID.String()
+			}
+
+			if err := checkUniqueUserEmail(api.app, tx, user.Email, excludeId); err != nil {
+				return fail(err)
+			}
+
+			switch {
+			case findErr == nil:
+				existing.UserPure = user
+				existing.DeletedAt = gorm.DeletedAt{}
+				if err := tx.Unscoped().Save(existing).Error; err != nil {
+					return fail(err)
+				}
+				result.Updated = append(result.Updated, ID{ID: existing.ID.ID})
+			case errors.Is(findErr, gorm.ErrRecordNotFound):
+				id, err := uuid.NewUUID()
+				if err != nil {
+					return fail(err)
+				}
+
+				if err := tx.Create(&models.User{
+					UserPure: user,
+					ModelCU: models.ModelCU{
+						ID: models.ID{ID: id},
+					},
+				}).Error; err != nil {
+					return fail(err)
+				}
+				result.Created = append(result.Created, ID{ID: id})
+			default:
+				return fail(findErr)
+			}
+		}
+
+		if body.DeleteMissing {
+			deleteResult := tx.Where("name NOT IN ?", names).Delete(&models.User{})
+			if deleteResult.Error != nil {
+				return deleteResult.Error
+			}
+			result.Deleted = deleteResult.RowsAffected
+		}
+
+		return nil
+	})
+
+	if rowErr != nil {
+		return c.JSON(http.StatusBadRequest, Error{
+			Error: []UsersImportError{*rowErr},
+		})
+	}
+
+	if txErr != nil {
 		return c.JSON(http.StatusInternalServerError, Error{
-			Error: result.Error.Error(),
+			Error: txErr.Error(),
 		})
 	}
 
 	return c.JSON(http.StatusOK, Data{
-		Data: ID{ID: id},
+		Data: result,
 	})
 }
 
@@ -351,28 +955,359 @@ func (api *usersApi) patchUser(c echo.Context) error {
 		return err
 	}
 
-	query := reg.DB.WithContext(c.Request().Context()).Model(&models.User{}).Where("id = ?", body["id"])
+	event := new(core.UserUpdateEvent)
+	event.HttpContext = c
+	event.RequestInfo = RequestInfo(c)
+	event.UserId, _ = body["id"].(string)
+	event.Data = body
 
-	result := query.Updates(body)
+	var updated bool
 
-	// check write error
-	if result.Error != nil && errors.Is(result.Error, gorm.ErrDuplicatedKey) {
-		return c.JSON(http.StatusConflict, Error{
-			Error: result.Error.Error(),
+	handlerErr := api.app.OnUserBeforeUpdateRequest().Trigger(event, func(e *core.UserUpdateEvent) error {
+		if err := api.updateUser(e.HttpContext, reg, e.UserId, e.Data); err != nil {
+			return userWriteErrorResponse(e.HttpContext, err)
+		}
+
+		updated = true
+
+		resultData := make(map[string]interface{})
+		resultData["id"] = e.UserId
+
+		return e.HttpContext.JSON(http.StatusOK, Data{
+			Data: resultData,
+		})
+	})
+
+	if handlerErr != nil {
+		return c.JSON(http.StatusBadRequest, Error{
+			Error: handlerErr.Error(),
+		})
+	}
+
+	if updated {
+		if err := api.app.OnUserAfterUpdateRequest().Trigger(event); err != nil && api.app.IsDebug() {
+			log.Println(err)
+		}
+	}
+
+	return nil
+}
+
+// UserAuthWithPassword is the users password authentication request body.
+type UserAuthWithPassword struct {
+	// Identity is the user name or email.
+	Identity string `json:"identity" example:"userX"`
+	Password string `json:"password" example:"pass1234"`
+}
+
+// UserAuthResponse is the users authentication response.
+type UserAuthResponse struct {
+	Token string      `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	User  *UserDataID `json:"user"`
+}
+
+// @Summary Authenticate user
+// @Tags user
+// @Description Authenticate a user with name (or email) and password and return a new user auth token
+// @Router /users/auth-with-password [post]
+// @Param payload body UserAuthWithPassword{} true "user credentials"
+// @Success 200 {object} Data{data=UserAuthResponse{}}
+// @failure 400 {object} Error{}
+// @failure 500 {object} Error{}
+func (api *usersApi) authWithPassword(c echo.Context) error {
+	body := new(UserAuthWithPassword)
+	if err := c.Bind(body); err != nil {
+		return c.JSON(http.StatusBadRequest, Error{
+			Error: err.Error(),
 		})
 	}
 
+	if body.Identity == "" || body.Password == "" {
+		return c.JSON(http.StatusBadRequest, Error{
+			Error: "identity and password are required",
+		})
+	}
+
+	reg, err := registry.Get(c.Get("registry").(string))
+	if err != nil {
+		return err
+	}
+
+	db := reg.DB.WithContext(c.Request().Context())
+
+	// the name is unique, so it has precedence over the email
+	user := new(models.User)
+	result := db.Where("name = ?", body.Identity).Limit(1).Find(user)
+	if result.Error == nil && result.RowsAffected == 0 {
+		result = db.Where("LOWER(email) = LOWER(?)", body.Identity).Limit(1).Find(user)
+	}
+
 	if result.Error != nil {
 		return c.JSON(http.StatusInternalServerError, Error{
 			Error: result.Error.Error(),
 		})
 	}
 
-	resultData := make(map[string]interface{})
-	resultData["id"] = body["id"]
+	if result.RowsAffected == 0 || bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(body.Password)) != nil {
+		return c.JSON(http.StatusBadRequest, Error{
+			Error: "failed to authenticate",
+		})
+	}
+
+	token, err := tokens.NewUserAuthToken(api.app, user)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, Error{
+			Error: err.Error(),
+		})
+	}
 
 	return c.JSON(http.StatusOK, Data{
-		Data: resultData,
+		Data: UserAuthResponse{
+			Token: token,
+			User: &UserDataID{
+				UserData: user.UserData,
+				ID:       ID{ID: user.ID.ID},
+			},
+		},
+	})
+}
+
+// UserProfile is the self-service user profile update request body.
+type UserProfile struct {
+	Email string `json:"email" example:"userx@worldline.com"`
+
+	// Password is the current user password required to confirm the profile change.
+	Password string `json:"password" example:"pass1234"`
+}
+
+// Validate checks the profile fields format
+// (the password is verified against the stored hash).
+func (form *UserProfile) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(&form.Email, validation.Required, validation.Length(1, 255), is.EmailFormat),
+		validation.Field(&form.Password, validation.Required),
+	)
+}
+
+// UserPasswordChange is the self-service user password change request body.
+type UserPasswordChange struct {
+	OldPassword     string `json:"oldPassword" example:"pass1234"`
+	Password        string `json:"password" example:"pass12345"`
+	PasswordConfirm string `json:"passwordConfirm" example:"pass12345"`
+}
+
+// UserPasswordMinLength is the min length of the self-service changed user password.
+const UserPasswordMinLength = 8
+
+// Validate checks the new password requirements
+// (the old password is verified against the stored hash).
+func (form *UserPasswordChange) Validate() error {
+	switch {
+	case form.OldPassword == "":
+		return errors.New("oldPassword is required")
+	case len(form.Password) < UserPasswordMinLength:
+		return fmt.Errorf("password must be at least %d characters", UserPasswordMinLength)
+	case form.Password != form.PasswordConfirm:
+		return errors.New("passwordConfirm doesn't match")
+	}
+
+	return nil
+}
+
+// findMeUser returns the request authorized GORM user
+// (loaded with [LoadUserAuthContext()]).
+//
+// Note that the auth records are never paired with a GORM user
+// because any auth collection could have a record with the same username.
+func (api *usersApi) findMeUser(c echo.Context) (*models.User, error) {
+	user, _ := c.Get(ContextUserKey).(*models.User)
+	if user == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return user, nil
+}
+
+// updateUser updates the provided user fields in a single transaction
+// with the checkUniqueUserEmail check of the changed email (if any).
+func (api *usersApi) updateUser(c echo.Context, reg *registry.Registry, id string, data map[string]any) error {
+	unlock := lockUniqueUserEmail(api.app)
+	defer unlock()
+
+	return reg.DB.WithContext(c.Request().Context()).Transaction(func(tx *gorm.DB) error {
+		if email, ok := data["email"].(string); ok {
+			if err := checkUniqueUserEmail(api.app, tx, email, id); err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&models.User{}).Where("id = ?", id).Updates(data).Error
+	})
+}
+
+// updateMeUser updates the provided user fields
+// wrapped in the user update request hooks.
+func (api *usersApi) updateMeUser(c echo.Context, reg *registry.Registry, user *models.User, data map[string]any, response func(c echo.Context) error) error {
+	event := new(core.UserUpdateEvent)
+	event.HttpContext = c
+	event.RequestInfo = RequestInfo(c)
+	event.UserId = user.ID.ID.String()
+	event.Data = data
+
+	var updated bool
+
+	handlerErr := api.app.OnUserBeforeUpdateRequest().Trigger(event, func(e *core.UserUpdateEvent) error {
+		if err := api.updateUser(e.HttpContext, reg, e.UserId, e.Data); err != nil {
+			return userWriteErrorResponse(e.HttpContext, err)
+		}
+
+		updated = true
+
+		return response(e.HttpContext)
+	})
+
+	if handlerErr != nil {
+		return c.JSON(http.StatusBadRequest, Error{
+			Error: handlerErr.Error(),
+		})
+	}
+
+	if updated {
+		if err := api.app.OnUserAfterUpdateRequest().Trigger(event); err != nil && api.app.IsDebug() {
+			log.Println(err)
+		}
+	}
+
+	return nil
+}
+
+// @Summary Update my profile
+// @Tags user
+// @Description Update the profile of the authorized user (the current password is required)
+// @Security ApiKeyAuth
+// @Router /users/me [patch]
+// @Param payload body UserProfile{} true "profile fields to update"
+// @Success 200 {object} Data{data=UserDataID{}}
+// @failure 400 {object} Error{}
+// @failure 401 {object} Error{}
+// @failure 404 {object} Error{}
+// @failure 409 {object} Error{}
+// @failure 500 {object} Error{}
+func (api *usersApi) patchMe(c echo.Context) error {
+	body := new(UserProfile)
+	if err := c.Bind(body); err != nil {
+		return c.JSON(http.StatusBadRequest, Error{
+			Error: err.Error(),
+		})
+	}
+
+	if err := body.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, Error{
+			Error: err.Error(),
+		})
+	}
+
+	reg, err := registry.Get(c.Get("registry").(string))
+	if err != nil {
+		return err
+	}
+
+	user, err := api.findMeUser(c)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return c.JSON(http.StatusNotFound, Error{
+			Error: "not found any related user",
+		})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, Error{
+			Error: err.Error(),
+		})
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(body.Password)) != nil {
+		return c.JSON(http.StatusBadRequest, Error{
+			Error: "invalid password",
+		})
+	}
+
+	data := map[string]any{
+		"email": body.Email,
+	}
+
+	return api.updateMeUser(c, reg, user, data, func(c echo.Context) error {
+		result := &UserDataID{
+			UserData: user.UserData,
+			ID:       ID{ID: user.ID.ID},
+		}
+		result.Email = body.Email
+
+		return c.JSON(http.StatusOK, Data{
+			Data: result,
+		})
+	})
+}
+
+// @Summary Change my password
+// @Tags user
+// @Description Change the password of the authorized user
+// @Security ApiKeyAuth
+// @Router /users/me/password [put]
+// @Param payload body UserPasswordChange{} true "current and new password"
+// @Success 204 "No Content"
+// @failure 400 {object} Error{}
+// @failure 401 {object} Error{}
+// @failure 404 {object} Error{}
+// @failure 500 {object} Error{}
+func (api *usersApi) changeMePassword(c echo.Context) error {
+	body := new(UserPasswordChange)
+	if err := c.Bind(body); err != nil {
+		return c.JSON(http.StatusBadRequest, Error{
+			Error: err.Error(),
+		})
+	}
+
+	if err := body.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, Error{
+			Error: err.Error(),
+		})
+	}
+
+	reg, err := registry.Get(c.Get("registry").(string))
+	if err != nil {
+		return err
+	}
+
+	user, err := api.findMeUser(c)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return c.JSON(http.StatusNotFound, Error{
+			Error: "not found any related user",
+		})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, Error{
+			Error: err.Error(),
+		})
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(body.OldPassword)) != nil {
+		return c.JSON(http.StatusBadRequest, Error{
+			Error: "invalid oldPassword",
+		})
+	}
+
+	hashedPassword, err := HashPassword([]byte(body.Password))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, Error{
+			Error: err.Error(),
+		})
+	}
+
+	data := map[string]any{
+		"password": string(hashedPassword),
+	}
+
+	return api.updateMeUser(c, reg, user, data, func(c echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
 	})
 }
 
@@ -0,0 +1,290 @@
+package apis
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/registry"
+	"github.com/pocketbase/pocketbase/tools/parquet"
+	"github.com/pocketbase/pocketbase/tools/xlsx"
+)
+
+const (
+	UsersExportFormatCsv     = "csv"
+	UsersExportFormatXlsx    = "xlsx"
+	UsersExportFormatParquet = "parquet"
+
+	// usersExportFlushBatch is the number of the exported users
+	// after which the csv response is flushed to the client.
+	usersExportFlushBatch = 500
+)
+
+// UsersExportQuery defines the users export query parameters.
+type UsersExportQuery struct {
+	Format         string `query:"format"`
+	Search         string `query:"search"`
+	IncludeDeleted bool   `query:"includeDeleted"`
+}
+
+// usersExportColumns returns the users sheet columns
+// (deleted_at is exported only with the includeDeleted flag).
+func usersExportColumns(includeDeleted bool) []xlsx.Column {
+	columns := []xlsx.Column{
+		{Name: "id"},
+		{Name: "name"},
+		{Name: "email"},
+		{Name: "groups"},
+		{Name: "created_at", Type: xlsx.TypeDate},
+		{Name: "updated_at", Type: xlsx.TypeDate},
+	}
+
+	if includeDeleted {
+		columns = append(columns, xlsx.Column{Name: "deleted_at", Type: xlsx.TypeDate})
+	}
+
+	return columns
+}
+
+// usersExportParquetColumns returns the usersExportColumns as Parquet file columns.
+func usersExportParquetColumns(includeDeleted bool) []parquet.Column {
+	columns := usersExportColumns(includeDeleted)
+
+	result := make([]parquet.Column, len(columns))
+	for i, column := range columns {
+		result[i] = parquet.Column{Name: column.Name, Type: parquet.TypeString}
+		if column.Type == xlsx.TypeDate {
+			result[i].Type = parquet.TypeTimestamp
+		}
+	}
+
+	return result
+}
+
+// usersExportRow returns the usersExportColumns values of the provided user.
+func usersExportRow(user *models.User, includeDeleted bool) []any {
+	row := []any{
+		user.ID.ID.String(),
+		user.Name,
+		user.Email,
+		strings.Join(user.GroupNames(), ","),
+		user.CreatedAt,
+		user.UpdatedAt,
+	}
+
+	if includeDeleted {
+		var deletedAt any
+		if user.DeletedAt.Valid {
+			deletedAt = user.DeletedAt.Time
+		}
+		row = append(row, deletedAt)
+	}
+
+	return row
+}
+
+// @Summary Export users
+// @Tags user
+// @Description Stream the users in a csv, xlsx or parquet file (sorted by name)
+// @Description The xlsx file has an additional groups sheet with a row for each user group membership
+// @Security ApiKeyAuth
+// @Router /users/export [get]
+// @Param format query string false "export format (csv, xlsx or parquet), default is csv"
+// @Param search query string false "search item"
+// @Param includeDeleted query bool false "include the soft deleted users, default is false"
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce application/vnd.apache.parquet
+// @Success 200 {file} file
+// @failure 400 {object} Error{}
+// @failure 500 {object} Error{}
+func (api *usersApi) exportUsers(c echo.Context) error {
+	params := &UsersExportQuery{}
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, Error{
+			Error: err.Error(),
+		})
+	}
+
+	if params.Format == "" {
+		params.Format = UsersExportFormatCsv
+	}
+
+	if params.Format != UsersExportFormatCsv &&
+		params.Format != UsersExportFormatXlsx &&
+		params.Format != UsersExportFormatParquet {
+		return c.JSON(http.StatusBadRequest, Error{
+			Error: "invalid export format",
+		})
+	}
+
+	reg, err := registry.Get(c.Get("registry").(string))
+	if err != nil {
+		return err
+	}
+
+	query := reg.DB.WithContext(c.Request().Context()).Model(&models.User{}).Order("name ASC")
+
+	if params.IncludeDeleted {
+		query = query.Unscoped()
+	}
+
+	if params.Search != "" {
+		query = query.Where("name LIKE ?", params.Search+"%")
+	}
+
+	rows, err := query.Rows()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, Error{
+			Error: err.Error(),
+		})
+	}
+	defer rows.Close()
+
+	columns := usersExportColumns(params.IncludeDeleted)
+
+	// the group memberships are collected while streaming the users sheet
+	// (the xlsx sheets can be written only one after another)
+	type membership struct {
+		group  string
+		userId string
+		name   string
+	}
+	memberships := []membership{}
+
+	var writeRow func(user *models.User) error
+	var flush func() error
+	var finish func() error
+
+	res := c.Response()
+
+	contentType := "text/csv; charset=utf-8"
+	switch params.Format {
+	case UsersExportFormatXlsx:
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	case UsersExportFormatParquet:
+		contentType = "application/vnd.apache.parquet"
+	}
+	res.Header().Set(echo.HeaderContentType, contentType)
+	res.Header().Set(
+		echo.HeaderContentDisposition,
+		fmt.Sprintf("attachment; filename=%q", "users."+params.Format),
+	)
+	res.WriteHeader(http.StatusOK)
+
+	switch params.Format {
+	case UsersExportFormatParquet:
+		w, err := parquet.NewWriter(res, usersExportParquetColumns(params.IncludeDeleted))
+		if err != nil {
+			return err
+		}
+
+		writeRow = func(user *models.User) error {
+			return w.WriteRow(usersExportRow(user, params.IncludeDeleted)...)
+		}
+		flush = func() error {
+			return nil // the row groups are flushed by the parquet writer
+		}
+		finish = w.Close
+	case UsersExportFormatXlsx:
+		w := xlsx.NewWriter(res)
+
+		sheet, err := w.AddSheet("users", columns)
+		if err != nil {
+			return err
+		}
+
+		writeRow = func(user *models.User) error {
+			for _, group := range user.GroupNames() {
+				memberships = append(memberships, membership{group, user.ID.ID.String(), user.Name})
+			}
+
+			return sheet.WriteRow(usersExportRow(user, params.IncludeDeleted)...)
+		}
+		flush = func() error {
+			return nil // the zip entries are flushed by the xlsx writer
+		}
+		finish = func() error {
+			groups, err := w.AddSheet("groups", []xlsx.Column{
+				{Name: "group"},
+				{Name: "user_id"},
+				{Name: "user_name"},
+			})
+			if err != nil {
+				return err
+			}
+
+			for _, m := range memberships {
+				if err := groups.WriteRow(m.group, m.userId, m.name); err != nil {
+					return err
+				}
+			}
+
+			return w.Close()
+		}
+	default:
+		w := csv.NewWriter(res)
+
+		header := make([]string, len(columns))
+		for i, column := range columns {
+			header[i] = column.Name
+		}
+		if err := w.Write(header); err != nil {
+			return err
+		}
+
+		writeRow = func(user *models.User) error {
+			values := usersExportRow(user, params.IncludeDeleted)
+
+			record := make([]string, len(values))
+			for i, v := range values {
+				switch v := v.(type) {
+				case nil:
+				case time.Time:
+					if !v.IsZero() {
+						record[i] = v.UTC().Format(time.RFC3339)
+					}
+				default:
+					record[i] = fmt.Sprint(v)
+				}
+			}
+
+			return w.Write(record)
+		}
+		flush = func() error {
+			w.Flush()
+			res.Flush()
+			return w.Error()
+		}
+		finish = flush
+	}
+
+	total := 0
+	for rows.Next() {
+		user := &models.User{}
+		if err := query.ScanRows(rows, user); err != nil {
+			return err
+		}
+
+		if err := writeRow(user); err != nil {
+			return err
+		}
+
+		total++
+		if total%usersExportFlushBatch == 0 {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	return finish()
+}
@@ -0,0 +1,398 @@
+package apis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/registry"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// UserGroup is a single users group with its member user ids.
+type UserGroup struct {
+	Name    string   `json:"name" example:"admin"`
+	UserIds []string `json:"userIds" example:"cf8a07d4-077e-402e-a46b-ac0ed50989ec" swaggertype:"array,string"`
+}
+
+// UserGroupNameMaxLength is the max length of a users group name.
+const UserGroupNameMaxLength = 100
+
+// Validate checks the group name and members requirements
+// (a group exists only as long as it has at least one member).
+func (form *UserGroup) Validate() error {
+	switch {
+	case form.Name == "":
+		return errors.New("name is required")
+	case len(form.Name) > UserGroupNameMaxLength:
+		return fmt.Errorf("name must be at most %d characters", UserGroupNameMaxLength)
+	case strings.TrimSpace(form.Name) != form.Name || strings.Contains(form.Name, ","):
+		return errors.New("name must not contain commas or leading and trailing spaces")
+	case len(form.UserIds) == 0:
+		return errors.New("userIds are required")
+	}
+
+	return nil
+}
+
+// userGroupState is the resolved state of a single users group
+// (aka. a distinct value of the users groups field).
+type userGroupState struct {
+	name    string
+	members []*models.User
+}
+
+// memberIds returns the member user ids of the provided group.
+func (group *userGroupState) memberIds() []string {
+	result := make([]string, 0, len(group.members))
+
+	for _, member := range group.members {
+		result = append(result, member.ID.ID.String())
+	}
+
+	return result
+}
+
+// missingGroupMemberError is returned when a group member user doesn't exist.
+type missingGroupMemberError string
+
+func (e missingGroupMemberError) Error() string {
+	return fmt.Sprintf("missing group member user %q", string(e))
+}
+
+// loadUserGroups returns all groups (sorted by their name) from the users
+// groups field (the soft deleted users are also included as members).
+func loadUserGroups(ctx context.Context, db *gorm.DB) ([]*userGroupState, error) {
+	users := []*models.User{}
+
+	if err := db.WithContext(ctx).Unscoped().Order("name ASC").Find(&users).Error; err != nil {
+		return nil, err
+	}
+
+	groups := map[string]*userGroupState{}
+
+	for _, user := range users {
+		for _, name := range user.GroupNames() {
+			group, ok := groups[name]
+			if !ok {
+				group = &userGroupState{name: name}
+				groups[name] = group
+			}
+			group.members = append(group.members, user)
+		}
+	}
+
+	result := make([]*userGroupState, 0, len(groups))
+	for _, group := range groups {
+		result = append(result, group)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].name < result[j].name
+	})
+
+	return result, nil
+}
+
+// findUserGroup returns the group with the provided name
+// (or nil if none of the users is member of the group).
+func findUserGroup(ctx context.Context, db *gorm.DB, name string) (*userGroupState, error) {
+	groups, err := loadUserGroups(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, group := range groups {
+		if group.name == name {
+			return group, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// saveUserGroup renames the oldName group of the users to name
+// and syncs its members with the provided user ids.
+//
+// Returns [missingGroupMemberError] if any of the member users doesn't exist.
+//
+// Note that the group membership changes don't trigger the user request hooks.
+func saveUserGroup(ctx context.Context, db *gorm.DB, oldName string, name string, memberIds []string) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		users := []*models.User{}
+		if err := tx.Unscoped().Find(&users).Error; err != nil {
+			return err
+		}
+
+		found := map[string]bool{}
+
+		for _, user := range users {
+			id := user.ID.ID.String()
+			isMember := list.ExistInSlice(id, memberIds)
+			if isMember {
+				found[id] = true
+			}
+
+			groups := user.GroupNames()
+
+			newGroups := make([]string, 0, len(groups)+1)
+			for _, group := range groups {
+				if group != oldName && group != name {
+					newGroups = append(newGroups, group)
+				}
+			}
+			if isMember {
+				newGroups = append(newGroups, name)
+			}
+
+			if strings.Join(groups, "\x00") == strings.Join(newGroups, "\x00") {
+				continue // no changes
+			}
+
+			raw, err := json.Marshal(newGroups)
+			if err != nil {
+				return err
+			}
+
+			err = tx.Unscoped().Model(&models.User{}).Where("id = ?", id).Update("groups", datatypes.JSON(raw)).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, id := range memberIds {
+			if !found[id] {
+				return missingGroupMemberError(id)
+			}
+		}
+
+		return nil
+	})
+}
+
+// unescapedPathParam returns the unescaped value of the named path parameter
+// (the router matches with the raw path if it differs from the decoded one).
+func unescapedPathParam(c echo.Context, name string) string {
+	value := c.PathParam(name)
+
+	if c.Request().URL.RawPath != "" {
+		if unescaped, err := url.PathUnescape(value); err == nil {
+			value = unescaped
+		}
+	}
+
+	return value
+}
+
+func toUserGroup(group *userGroupState) UserGroup {
+	return UserGroup{
+		Name:    group.name,
+		UserIds: group.memberIds(),
+	}
+}
+
+// @Summary List groups
+// @Tags user
+// @Description Get list of the users groups (the distinct values of the users groups field)
+// @Security ApiKeyAuth
+// @Router /users/groups [get]
+// @Success 200 {object} Data{data=[]UserGroup{}}
+// @failure 500 {object} Error{}
+func (api *usersApi) listGroups(c echo.Context) error {
+	reg, err := registry.Get(c.Get("registry").(string))
+	if err != nil {
+		return err
+	}
+
+	groups, err := loadUserGroups(c.Request().Context(), reg.DB)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, Error{
+			Error: err.Error(),
+		})
+	}
+
+	result := make([]UserGroup, 0, len(groups))
+	for _, group := range groups {
+		result = append(result, toUserGroup(group))
+	}
+
+	return c.JSON(http.StatusOK, Data{
+		Data: result,
+	})
+}
+
+// @Summary Get group
+// @Tags user
+// @Description Get a single users group with its members
+// @Security ApiKeyAuth
+// @Router /users/groups/{name} [get]
+// @Param name path string true "group name"
+// @Success 200 {object} Data{data=UserGroup{}}
+// @failure 404 {object} Error{}
+// @failure 500 {object} Error{}
+func (api *usersApi) getGroup(c echo.Context) error {
+	reg, err := registry.Get(c.Get("registry").(string))
+	if err != nil {
+		return err
+	}
+
+	group, err := findUserGroup(c.Request().Context(), reg.DB, unescapedPathParam(c, "name"))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, Error{
+			Error: err.Error(),
+		})
+	}
+
+	if group == nil {
+		return c.JSON(http.StatusNotFound, Error{
+			Error: "not found any related group",
+		})
+	}
+
+	return c.JSON(http.StatusOK, Data{
+		Data: toUserGroup(group),
+	})
+}
+
+// @Summary New group
+// @Tags user
+// @Description Create a new users group by adding it to the member users groups
+// @Security ApiKeyAuth
+// @Router /users/groups [post]
+// @Param payload body UserGroup true "group name and member user ids"
+// @Success 200 {object} Data{data=UserGroup{}}
+// @failure 400 {object} Error{}
+// @failure 409 {object} Error{}
+// @failure 500 {object} Error{}
+func (api *usersApi) postGroup(c echo.Context) error {
+	return api.saveGroup(c, "")
+}
+
+// @Summary Replace group
+// @Tags user
+// @Description Rename a users group and replace its members
+// @Security ApiKeyAuth
+// @Router /users/groups/{name} [put]
+// @Param name path string true "group name"
+// @Param payload body UserGroup true "new group name and member user ids"
+// @Success 200 {object} Data{data=UserGroup{}}
+// @failure 400 {object} Error{}
+// @failure 404 {object} Error{}
+// @failure 409 {object} Error{}
+// @failure 500 {object} Error{}
+func (api *usersApi) putGroup(c echo.Context) error {
+	return api.saveGroup(c, unescapedPathParam(c, "name"))
+}
+
+// saveGroup creates (if oldName is empty) or replaces the oldName group
+// with the group from the request body.
+func (api *usersApi) saveGroup(c echo.Context, oldName string) error {
+	body := &UserGroup{}
+
+	if err := c.Bind(body); err != nil {
+		return c.JSON(http.StatusBadRequest, Error{
+			Error: err.Error(),
+		})
+	}
+
+	if err := body.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, Error{
+			Error: err.Error(),
+		})
+	}
+
+	reg, err := registry.Get(c.Get("registry").(string))
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+
+	if oldName != "" {
+		if group, err := findUserGroup(ctx, reg.DB, oldName); err != nil || group == nil {
+			return c.JSON(http.StatusNotFound, Error{
+				Error: "not found any related group",
+			})
+		}
+	}
+
+	if body.Name != oldName {
+		if group, err := findUserGroup(ctx, reg.DB, body.Name); err != nil || group != nil {
+			return c.JSON(http.StatusConflict, Error{
+				Error: "group already exists",
+			})
+		}
+	}
+
+	if oldName == "" {
+		oldName = body.Name
+	}
+
+	err = saveUserGroup(ctx, reg.DB, oldName, body.Name, list.ToUniqueStringSlice(body.UserIds))
+
+	var missing missingGroupMemberError
+	if errors.As(err, &missing) {
+		return c.JSON(http.StatusBadRequest, Error{
+			Error: err.Error(),
+		})
+	}
+
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, Error{
+			Error: err.Error(),
+		})
+	}
+
+	group, err := findUserGroup(ctx, reg.DB, body.Name)
+	if err != nil || group == nil {
+		return c.JSON(http.StatusInternalServerError, Error{
+			Error: "failed to load the saved group",
+		})
+	}
+
+	return c.JSON(http.StatusOK, Data{
+		Data: toUserGroup(group),
+	})
+}
+
+// @Summary Delete group
+// @Tags user
+// @Description Delete a users group by removing it from all member users groups
+// @Security ApiKeyAuth
+// @Router /users/groups/{name} [delete]
+// @Param name path string true "group name"
+// @Success 204
+// @failure 404 {object} Error{}
+// @failure 500 {object} Error{}
+func (api *usersApi) deleteGroup(c echo.Context) error {
+	reg, err := registry.Get(c.Get("registry").(string))
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	name := unescapedPathParam(c, "name")
+
+	if group, err := findUserGroup(ctx, reg.DB, name); err != nil || group == nil {
+		return c.JSON(http.StatusNotFound, Error{
+			Error: "not found any related group",
+		})
+	}
+
+	if err := saveUserGroup(ctx, reg.DB, name, name, nil); err != nil {
+		return c.JSON(http.StatusInternalServerError, Error{
+			Error: err.Error(),
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
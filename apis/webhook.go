@@ -0,0 +1,116 @@
+package apis
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+// bindWebhookApi registers the webhook delivery logs api endpoints.
+func bindWebhookApi(app core.App, rg *echo.Group) {
+	api := webhookApi{app: app}
+
+	subGroup := rg.Group("/webhooks", RequireAdminAuth())
+	subGroup.GET("/deliveries", api.deliveriesList)
+	subGroup.GET("/deliveries/:id", api.deliveryView)
+	subGroup.POST("/deliveries/:id/redeliver", api.redeliver)
+}
+
+type webhookApi struct {
+	app core.App
+}
+
+var webhookDeliveryFilterFields = []string{
+	"rowid", "id", "created", "updated",
+	"webhook", "event", "url", "status",
+	"attempts", "responseStatus", "error",
+}
+
+//	@Summary		List webhook deliveries
+//	@Description	Returns a paginated list with the webhook event delivery logs.
+//	@Tags			Webhooks
+//	@Produce		json
+//	@Param			page	query	int		false	"Page number"
+//	@Param			perPage	query	int		false	"Items per page"
+//	@Param			sort	query	string	false	"Sort fields"
+//	@Param			filter	query	string	false	"Filter expression"
+//	@Security		AdminAuth
+//	@Success		200	{object}	search.Result{items=[]models.WebhookDelivery}
+//	@Failure		400	{string}	string	"Something went wrong while processing your request."
+//	@Failure		401	{string}	string	"The request requires valid admin authorization token to be set."
+//	@Router			/webhooks/deliveries [get]
+func (api *webhookApi) deliveriesList(c echo.Context) error {
+	fieldResolver := search.NewSimpleFieldResolver(webhookDeliveryFilterFields...)
+
+	result, err := search.NewProvider(fieldResolver).
+		Query(api.app.LogsDao().WebhookDeliveryQuery()).
+		ParseAndExec(c.QueryParams().Encode(), &[]*models.WebhookDelivery{})
+
+	if err != nil {
+		return NewBadRequestError("", err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+//	@Summary		View webhook delivery
+//	@Description	Returns a single webhook event delivery log.
+//	@Tags			Webhooks
+//	@Produce		json
+//	@Param			id	path	string	true	"Delivery id"
+//	@Security		AdminAuth
+//	@Success		200	{object}	models.WebhookDelivery
+//	@Failure		401	{string}	string	"The request requires valid admin authorization token to be set."
+//	@Failure		404	{string}	string	"The requested resource wasn't found."
+//	@Router			/webhooks/deliveries/{id} [get]
+func (api *webhookApi) deliveryView(c echo.Context) error {
+	id := c.PathParam("id")
+	if id == "" {
+		return NewNotFoundError("", nil)
+	}
+
+	delivery, err := api.app.LogsDao().FindWebhookDeliveryById(id)
+	if err != nil || delivery == nil {
+		return NewNotFoundError("", err)
+	}
+
+	return c.JSON(http.StatusOK, delivery)
+}
+
+//	@Summary		Redeliver webhook event
+//	@Description	Sends again the logged event payload to the current url of its webhook (the failed attempt is not retried).
+//	@Description	The response is the updated delivery log with the result of the new attempt.
+//	@Tags			Webhooks
+//	@Produce		json
+//	@Param			id	path	string	true	"Delivery id"
+//	@Security		AdminAuth
+//	@Success		200	{object}	models.WebhookDelivery
+//	@Failure		400	{string}	string	"The webhook is missing or disabled."
+//	@Failure		401	{string}	string	"The request requires valid admin authorization token to be set."
+//	@Failure		404	{string}	string	"The requested resource wasn't found."
+//	@Router			/webhooks/deliveries/{id}/redeliver [post]
+func (api *webhookApi) redeliver(c echo.Context) error {
+	id := c.PathParam("id")
+	if id == "" {
+		return NewNotFoundError("", nil)
+	}
+
+	delivery, err := api.app.LogsDao().FindWebhookDeliveryById(id)
+	if err != nil || delivery == nil {
+		return NewNotFoundError("", err)
+	}
+
+	if hook, ok := api.app.Settings().Webhooks.Hook(delivery.Webhook); !ok || !hook.Enabled {
+		return NewBadRequestError("The webhook is missing or disabled.", nil)
+	}
+
+	// the failed endpoint responses are reported with the delivery status
+	if err := api.app.DeliverWebhook(c.Request().Context(), delivery); err != nil && delivery.Error == "" {
+		return NewBadRequestError("Failed to redeliver the webhook event.", err)
+	}
+
+	return c.JSON(http.StatusOK, delivery)
+}
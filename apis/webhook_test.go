@@ -0,0 +1,223 @@
+package apis_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestWebhookDeliveriesList(t *testing.T) {
+	scenarios := []tests.ApiScenario{
+		{
+			Name:            "unauthorized",
+			Method:          http.MethodGet,
+			Url:             "/api/webhooks/deliveries",
+			ExpectedStatus:  401,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "authorized as auth record",
+			Method: http.MethodGet,
+			Url:    "/api/webhooks/deliveries",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgMemberToken,
+			},
+			ExpectedStatus:  401,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "authorized as admin",
+			Method: http.MethodGet,
+			Url:    "/api/webhooks/deliveries",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgAdminToken,
+			},
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				if err := tests.MockWebhookDeliveriesData(app); err != nil {
+					t.Fatal(err)
+				}
+			},
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"totalItems":2`,
+				`"id":"d1b2c3d4-0000-4000-8000-000000000001"`,
+				`"id":"d1b2c3d4-0000-4000-8000-000000000002"`,
+			},
+		},
+		{
+			Name:   "authorized as admin + filter",
+			Method: http.MethodGet,
+			Url:    "/api/webhooks/deliveries?filter=status='failed'",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgAdminToken,
+			},
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				if err := tests.MockWebhookDeliveriesData(app); err != nil {
+					t.Fatal(err)
+				}
+			},
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"totalItems":1`,
+				`"id":"d1b2c3d4-0000-4000-8000-000000000002"`,
+				`"error":"unexpected response status 500"`,
+			},
+		},
+		{
+			Name:   "authorized as admin + invalid filter",
+			Method: http.MethodGet,
+			Url:    "/api/webhooks/deliveries?filter=payload='test'",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgAdminToken,
+			},
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"data":{}`},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
+
+func TestWebhookDeliveryView(t *testing.T) {
+	scenarios := []tests.ApiScenario{
+		{
+			Name:            "unauthorized",
+			Method:          http.MethodGet,
+			Url:             "/api/webhooks/deliveries/d1b2c3d4-0000-4000-8000-000000000001",
+			ExpectedStatus:  401,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "authorized as admin + missing delivery",
+			Method: http.MethodGet,
+			Url:    "/api/webhooks/deliveries/missing",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgAdminToken,
+			},
+			ExpectedStatus:  404,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "authorized as admin + existing delivery",
+			Method: http.MethodGet,
+			Url:    "/api/webhooks/deliveries/d1b2c3d4-0000-4000-8000-000000000001",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgAdminToken,
+			},
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				if err := tests.MockWebhookDeliveriesData(app); err != nil {
+					t.Fatal(err)
+				}
+			},
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"id":"d1b2c3d4-0000-4000-8000-000000000001"`,
+				`"webhook":"crm"`,
+				`"event":"records.create"`,
+				`"payload":{"id":"d1b2c3d4-0000-4000-8000-000000000001"`,
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
+
+func TestWebhookDeliveryRedeliver(t *testing.T) {
+	var received string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get("X-Webhook-Id")
+	}))
+	defer server.Close()
+
+	setupWebhook := func(enabled bool) func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+		return func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+			if err := tests.MockWebhookDeliveriesData(app); err != nil {
+				t.Fatal(err)
+			}
+
+			app.Settings().Egress.Subsystems = map[string]settings.EgressPolicyConfig{
+				settings.EgressSubsystemWebhooks: {AllowPrivate: true},
+			}
+			app.Settings().Webhooks.Hooks = map[string]settings.WebhookConfig{
+				"crm": {Enabled: enabled, Url: server.URL, Events: []string{"*.*"}},
+			}
+		}
+	}
+
+	scenarios := []tests.ApiScenario{
+		{
+			Name:            "unauthorized",
+			Method:          http.MethodPost,
+			Url:             "/api/webhooks/deliveries/d1b2c3d4-0000-4000-8000-000000000002/redeliver",
+			ExpectedStatus:  401,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "authorized as admin + missing delivery",
+			Method: http.MethodPost,
+			Url:    "/api/webhooks/deliveries/missing/redeliver",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgAdminToken,
+			},
+			BeforeTestFunc:  setupWebhook(true),
+			ExpectedStatus:  404,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "authorized as admin + disabled webhook",
+			Method: http.MethodPost,
+			Url:    "/api/webhooks/deliveries/d1b2c3d4-0000-4000-8000-000000000002/redeliver",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgAdminToken,
+			},
+			BeforeTestFunc:  setupWebhook(false),
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"message":"The webhook is missing or disabled."`},
+		},
+		{
+			Name:   "authorized as admin + enabled webhook",
+			Method: http.MethodPost,
+			Url:    "/api/webhooks/deliveries/d1b2c3d4-0000-4000-8000-000000000002/redeliver",
+			RequestHeaders: map[string]string{
+				"Authorization": testOrgAdminToken,
+			},
+			BeforeTestFunc: setupWebhook(true),
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"id":"d1b2c3d4-0000-4000-8000-000000000002"`,
+				`"status":"success"`,
+				`"attempts":4`,
+				`"responseStatus":200`,
+				`"error":""`,
+			},
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				if received != "d1b2c3d4-0000-4000-8000-000000000002" {
+					t.Fatalf("Expected the delivery to be resent, got %q", received)
+				}
+
+				delivery, err := app.LogsDao().FindWebhookDeliveryById("d1b2c3d4-0000-4000-8000-000000000002")
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if delivery.Status != models.WebhookDeliveryStatusSuccess || delivery.Attempts != 4 {
+					t.Fatalf("Expected the delivery log to be updated, got %v", delivery)
+				}
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
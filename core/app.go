@@ -5,15 +5,24 @@ package core
 
 import (
 	"context"
+	"net/http"
+	"time"
 
 	"github.com/pocketbase/dbx"
 	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
 	"github.com/pocketbase/pocketbase/models/settings"
 	"github.com/pocketbase/pocketbase/tools/filesystem"
 	"github.com/pocketbase/pocketbase/tools/hook"
+	"github.com/pocketbase/pocketbase/tools/logship"
 	"github.com/pocketbase/pocketbase/tools/mailer"
+	"github.com/pocketbase/pocketbase/tools/metrics"
+	"github.com/pocketbase/pocketbase/tools/querystats"
+	"github.com/pocketbase/pocketbase/tools/searchsync"
+	"github.com/pocketbase/pocketbase/tools/secrets"
 	"github.com/pocketbase/pocketbase/tools/store"
 	"github.com/pocketbase/pocketbase/tools/subscriptions"
+	"github.com/pocketbase/pocketbase/tools/types"
 )
 
 // App defines the main PocketBase app interface.
@@ -68,6 +77,14 @@ type App interface {
 	// SubscriptionsBroker returns the app realtime subscriptions broker instance.
 	SubscriptionsBroker() *subscriptions.Broker
 
+	// QueryStats returns the app tracker with the most recently
+	// executed data db queries and their durations.
+	QueryStats() *querystats.Tracker
+
+	// Metrics returns the app metrics registry
+	// (the metrics are collected only if enabled in the settings).
+	Metrics() *metrics.Registry
+
 	// NewMailClient creates and returns a configured app mail client.
 	NewMailClient() mailer.Mailer
 
@@ -85,9 +102,25 @@ type App interface {
 	// after you are done working with it.
 	NewBackupsFilesystem() (*filesystem.System, error)
 
+	// NewSearchIndexer creates and returns a configured searchsync.Indexer
+	// for the external search engine from the app SearchSync settings.
+	NewSearchIndexer() (searchsync.Indexer, error)
+
+	// NewHttpClient creates and returns an http client for the outgoing
+	// requests of the provided subsystem that honors the app Egress
+	// settings (proxy, destinations allowlist, private ranges guard).
+	NewHttpClient(subsystem string) (*http.Client, error)
+
 	// RefreshSettings reinitializes and reloads the stored application settings.
 	RefreshSettings() error
 
+	// Secrets returns the app settings secret references manager.
+	Secrets() *secrets.Manager
+
+	// RefreshSecrets resolves (and caches) the secret references
+	// of the current application settings.
+	RefreshSecrets()
+
 	// IsBootstrapped checks if the application was initialized
 	// (aka. whether Bootstrap() was called).
 	IsBootstrapped() bool
@@ -122,6 +155,50 @@ type App interface {
 	// NB! This feature is experimental and currently is expected to work only on UNIX based systems.
 	RestoreBackup(ctx context.Context, name string) error
 
+	// RotateBackupsEncryptionKey re-encrypts all encrypted backups
+	// from the backups filesystem with newKey.
+	//
+	// Unencrypted backups are left unchanged and the app settings are not updated.
+	RotateBackupsEncryptionKey(ctx context.Context, oldKey string, newKey string) error
+
+	// ReindexSearchCollection clears the external search engine index of
+	// the provided collection and pushes all of its records.
+	//
+	// Returns the total number of indexed records.
+	ReindexSearchCollection(ctx context.Context, collection *models.Collection) (int, error)
+
+	// DeliverWebhook sends a single delivery attempt of the provided
+	// webhook delivery payload and saves the attempt result in its log.
+	DeliverWebhook(ctx context.Context, delivery *models.WebhookDelivery) error
+
+	// WarmThumbs generates the missing thumbs of the existing image files
+	// of the provided collection (all allowed sizes if sizes is empty).
+	WarmThumbs(ctx context.Context, collection *models.Collection, sizes []string) (*ThumbsWarmupResult, error)
+
+	// SecurityReport aggregates the failed auth attempts, the 403 responses
+	// and the client countries from the request logs of the last window period.
+	SecurityReport(window time.Duration) (*SecurityReport, error)
+
+	// RefreshAnalyticsSnapshot replaces the analytics snapshot database
+	// with a fresh copy of the current app database.
+	RefreshAnalyticsSnapshot() error
+
+	// RunAnalyticsQuery executes fn in a read-only transaction of the
+	// analytics snapshot database and returns the snapshot refresh date.
+	//
+	// The snapshot is created on first use if it doesn't exist yet.
+	RunAnalyticsQuery(ctx context.Context, fn func(tx dbx.Builder) error) (types.DateTime, error)
+
+	// RunScheduledExport exports the records of the provided scheduled export,
+	// delivers the exported file and saves the run result in the export model.
+	RunScheduledExport(ctx context.Context, export *models.ScheduledExport) error
+
+	// ShipLog queues the provided log entry for asynchronous shipping
+	// to the external sink configured in app.Settings().LogShipping.
+	//
+	// It returns false if the log shipping is disabled or the entry was dropped.
+	ShipLog(entry *logship.Entry) bool
+
 	// Restart restarts the current running application process.
 	//
 	// Currently it is relying on execve so it is supported only on UNIX based systems.
@@ -157,6 +234,22 @@ type App interface {
 	// of being terminated (eg. on SIGTERM signal).
 	OnTerminate() *hook.Hook[*TerminateEvent]
 
+	// OnBackupProgress hook is triggered on each backup create or
+	// restore progress change (see also [CacheKeyBackupProgress]).
+	OnBackupProgress() *hook.Hook[*BackupProgressEvent]
+
+	// OnSecurityAlert hook is triggered when the periodic security report
+	// check (see SecurityReportConfig.NotifyCron) finds any alerts.
+	//
+	// If not stopped, the alerts are sent to the configured notify emails.
+	OnSecurityAlert() *hook.Hook[*SecurityAlertEvent]
+
+	// OnScheduledExportDelivery hook is triggered after each successfully
+	// uploaded scheduled export file with email destination.
+	//
+	// If not stopped, the file download link is sent to the export emails.
+	OnScheduledExportDelivery() *hook.Hook[*ScheduledExportDeliveryEvent]
+
 	// ---------------------------------------------------------------
 	// Dao event hooks
 	// ---------------------------------------------------------------
@@ -419,6 +512,17 @@ type App interface {
 	// authenticated admin data and token.
 	OnAdminAuthRequest() *hook.Hook[*AdminAuthEvent]
 
+	// OnAdminBeforeImpersonateRequest hook is triggered before each Admin
+	// impersonate API request (after the record load and before generating the token).
+	//
+	// Could be used to additionally validate the request data or implement
+	// completely different impersonate behavior (returning [hook.StopPropagation]).
+	OnAdminBeforeImpersonateRequest() *hook.Hook[*AdminImpersonateEvent]
+
+	// OnAdminAfterImpersonateRequest hook is triggered after each
+	// successful Admin impersonate API request (eg. for auditing).
+	OnAdminAfterImpersonateRequest() *hook.Hook[*AdminImpersonateEvent]
+
 	// OnAdminBeforeAuthWithPasswordRequest hook is triggered before each Admin
 	// auth with password API request (after request data load and before password validation).
 	//
@@ -816,4 +920,41 @@ type App interface {
 	// OnCollectionsAfterImportRequest hook is triggered after each
 	// successful API collections import request.
 	OnCollectionsAfterImportRequest() *hook.Hook[*CollectionsImportEvent]
+
+	// ---------------------------------------------------------------
+	// Users API event hooks
+	// ---------------------------------------------------------------
+
+	// OnUserBeforeCreateRequest hook is triggered before each API
+	// registry User create request (after request data load and before model persistence).
+	//
+	// Could be used to additionally validate the request data or implement
+	// completely different persistence behavior (returning [hook.StopPropagation]).
+	OnUserBeforeCreateRequest() *hook.Hook[*UserCreateEvent]
+
+	// OnUserAfterCreateRequest hook is triggered after each
+	// successful API registry User create request.
+	OnUserAfterCreateRequest() *hook.Hook[*UserCreateEvent]
+
+	// OnUserBeforeUpdateRequest hook is triggered before each API
+	// registry User update request (after request data load and before model persistence).
+	//
+	// The user groups are changed with the same request, so this is also
+	// the hook to use for validating the group membership changes (see Data["groups"]).
+	OnUserBeforeUpdateRequest() *hook.Hook[*UserUpdateEvent]
+
+	// OnUserAfterUpdateRequest hook is triggered after each
+	// successful API registry User update request.
+	OnUserAfterUpdateRequest() *hook.Hook[*UserUpdateEvent]
+
+	// OnUserBeforeDeleteRequest hook is triggered before each API
+	// registry User delete request (before the actual soft or permanent deletion).
+	//
+	// Could be used to additionally validate the request data or implement
+	// completely different delete behavior (returning [hook.StopPropagation]).
+	OnUserBeforeDeleteRequest() *hook.Hook[*UserDeleteEvent]
+
+	// OnUserAfterDeleteRequest hook is triggered after each
+	// successful API registry User delete request.
+	OnUserAfterDeleteRequest() *hook.Hook[*UserDeleteEvent]
 }
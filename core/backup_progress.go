@@ -0,0 +1,188 @@
+package core
+
+import (
+	"io"
+	"log"
+	"sync"
+
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// CacheKeyBackupProgress is the app cache key that holds
+// the last reported [BackupProgress] value.
+const CacheKeyBackupProgress string = "@backupProgress"
+
+// List of the reported backup actions.
+const (
+	BackupActionCreate  = "create"
+	BackupActionRestore = "restore"
+)
+
+// List of the backup progress statuses.
+const (
+	BackupStatusIdle      = "idle"
+	BackupStatusRunning   = "running"
+	BackupStatusCompleted = "completed"
+	BackupStatusFailed    = "failed"
+)
+
+// List of the backup progress stages.
+const (
+	BackupStageArchive  = "archive"
+	BackupStageEncrypt  = "encrypt"
+	BackupStageUpload   = "upload"
+	BackupStageDownload = "download"
+	BackupStageDecrypt  = "decrypt"
+	BackupStageExtract  = "extract"
+	BackupStageReplace  = "replace"
+	BackupStageRestart  = "restart"
+)
+
+// BackupProgress describes the state of a single backup create or restore operation.
+type BackupProgress struct {
+	Action  string         `json:"action"`
+	Name    string         `json:"name"`
+	Status  string         `json:"status"`
+	Stage   string         `json:"stage"`
+	Percent int            `json:"percent"`
+	Error   string         `json:"error"`
+	Started types.DateTime `json:"started"`
+	Updated types.DateTime `json:"updated"`
+}
+
+// backupProgressReporter reports the progress changes of a single backup operation.
+type backupProgressReporter struct {
+	app      *BaseApp
+	mux      sync.Mutex
+	progress BackupProgress
+}
+
+func newBackupProgressReporter(app *BaseApp, action string, name string) *backupProgressReporter {
+	r := &backupProgressReporter{
+		app: app,
+		progress: BackupProgress{
+			Action:  action,
+			Name:    name,
+			Status:  BackupStatusRunning,
+			Started: types.NowDateTime(),
+		},
+	}
+
+	return r
+}
+
+// report updates the running operation stage and percent.
+func (r *backupProgressReporter) report(stage string, percent int) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	if r.progress.Status != BackupStatusRunning {
+		return
+	}
+
+	// the progress percent never goes back
+	if percent < r.progress.Percent {
+		percent = r.progress.Percent
+	}
+
+	if stage == r.progress.Stage && percent == r.progress.Percent {
+		return // no change
+	}
+
+	r.progress.Stage = stage
+	r.progress.Percent = percent
+
+	r.emit()
+}
+
+// finish marks the operation as completed or failed (if err is not nil).
+//
+// It does nothing if the operation was already finished.
+func (r *backupProgressReporter) finish(err error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	if r.progress.Status != BackupStatusRunning {
+		return
+	}
+
+	if err != nil {
+		r.progress.Status = BackupStatusFailed
+		r.progress.Error = err.Error()
+	} else {
+		r.progress.Status = BackupStatusCompleted
+		r.progress.Percent = 100
+	}
+
+	r.emit()
+}
+
+// emit stores the current progress state in the app cache
+// and triggers the OnBackupProgress hook.
+//
+// Note: must be called with the reporter lock acquired.
+func (r *backupProgressReporter) emit() {
+	r.progress.Updated = types.NowDateTime()
+
+	r.app.Cache().Set(CacheKeyBackupProgress, r.progress)
+
+	progress := r.progress
+
+	event := &BackupProgressEvent{
+		App:      r.app,
+		Progress: &progress,
+	}
+
+	if err := r.app.OnBackupProgress().Trigger(event); err != nil && r.app.IsDebug() {
+		log.Println(err)
+	}
+}
+
+// backupProgressWriter is an [io.Writer] that reports the number
+// of the written bytes as progress percent within [from, to].
+//
+// It is safe for concurrent use (eg. with the parallel part downloads).
+type backupProgressWriter struct {
+	mux      sync.Mutex
+	reporter *backupProgressReporter
+	stage    string
+	total    int64
+	written  int64
+	from     int
+	to       int
+}
+
+// Write implements the [io.Writer] interface.
+func (w *backupProgressWriter) Write(p []byte) (int, error) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	w.written += int64(len(p))
+
+	if w.total > 0 {
+		percent := w.from + int(int64(w.to-w.from)*w.written/w.total)
+		if percent > w.to {
+			percent = w.to
+		}
+
+		w.reporter.report(w.stage, percent)
+	}
+
+	return len(p), nil
+}
+
+// progressWriterAt is an [io.WriterAt] that also writes
+// the written bytes into the progress writer.
+type progressWriterAt struct {
+	io.WriterAt
+	progress io.Writer
+}
+
+// WriteAt implements the [io.WriterAt] interface.
+func (w *progressWriterAt) WriteAt(p []byte, off int64) (int, error) {
+	n, err := w.WriterAt.WriteAt(p, off)
+
+	w.progress.Write(p[:n])
+
+	return n, err
+}
@@ -0,0 +1,82 @@
+package core_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestCreateBackupProgress(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	app.Settings().Backups.EncryptionKey = "abcdabcdabcdabcdabcdabcdabcdabcd"
+
+	events := []core.BackupProgress{}
+	app.OnBackupProgress().Add(func(e *core.BackupProgressEvent) error {
+		events = append(events, *e.Progress)
+		return nil
+	})
+
+	if err := app.CreateBackup(context.Background(), "test.zip"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"running:archive:0",
+		"running:encrypt:50",
+		"running:upload:70",
+		"completed:upload:100",
+	}
+
+	if len(events) != len(expected) {
+		t.Fatalf("Expected %d progress events, got %d: %v", len(expected), len(events), events)
+	}
+
+	for i, e := range events {
+		if e.Action != core.BackupActionCreate || e.Name != "test.zip" {
+			t.Fatalf("(%d) Expected create test.zip progress, got %v", i, e)
+		}
+
+		if str := fmt.Sprintf("%s:%s:%d", e.Status, e.Stage, e.Percent); str != expected[i] {
+			t.Fatalf("(%d) Expected %q, got %q", i, expected[i], str)
+		}
+	}
+
+	cached, _ := app.Cache().Get(core.CacheKeyBackupProgress).(core.BackupProgress)
+	if cached.Status != core.BackupStatusCompleted || cached.Name != "test.zip" {
+		t.Fatalf("Expected the completed progress to be cached, got %v", cached)
+	}
+}
+
+func TestRestoreBackupProgressFailure(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	events := []core.BackupProgress{}
+	app.OnBackupProgress().Add(func(e *core.BackupProgressEvent) error {
+		events = append(events, *e.Progress)
+		return nil
+	})
+
+	if err := app.RestoreBackup(context.Background(), "missing.zip"); err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 progress events, got %d: %v", len(events), events)
+	}
+
+	last := events[len(events)-1]
+	if last.Action != core.BackupActionRestore || last.Status != core.BackupStatusFailed || last.Error == "" {
+		t.Fatalf("Expected failed restore progress with error, got %v", last)
+	}
+
+	cached, _ := app.Cache().Get(core.CacheKeyBackupProgress).(core.BackupProgress)
+	if cached.Status != core.BackupStatusFailed {
+		t.Fatalf("Expected the failed progress to be cached, got %v", cached)
+	}
+}
@@ -5,9 +5,11 @@ import (
 	"database/sql"
 	"errors"
 	"log"
+	"net/http"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"syscall"
 	"time"
 
@@ -16,10 +18,15 @@ import (
 	"github.com/pocketbase/pocketbase/daos"
 	"github.com/pocketbase/pocketbase/models"
 	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tools/egress"
 	"github.com/pocketbase/pocketbase/tools/filesystem"
 	"github.com/pocketbase/pocketbase/tools/hook"
 	"github.com/pocketbase/pocketbase/tools/mailer"
+	"github.com/pocketbase/pocketbase/tools/metrics"
+	"github.com/pocketbase/pocketbase/tools/querystats"
 	"github.com/pocketbase/pocketbase/tools/routine"
+	"github.com/pocketbase/pocketbase/tools/searchsync"
+	"github.com/pocketbase/pocketbase/tools/secrets"
 	"github.com/pocketbase/pocketbase/tools/store"
 	"github.com/pocketbase/pocketbase/tools/subscriptions"
 )
@@ -30,9 +37,12 @@ const (
 	DefaultLogsMaxOpenConns int = 10
 	DefaultLogsMaxIdleConns int = 2
 
-	LocalStorageDirName string = "storage"
-	LocalBackupsDirName string = "backups"
-	LocalTempDirName    string = ".pb_temp_to_delete" // temp pb_data sub directory that will be deleted on each app.Bootstrap()
+	LocalStorageDirName     string = "storage"
+	LocalBackupsDirName     string = "backups"
+	LocalTempDirName        string = ".pb_temp_to_delete" // temp pb_data sub directory that will be deleted on each app.Bootstrap()
+	LocalUploadsDirName     string = ".pb_uploads"        // pb_data sub directory with the incomplete resumable file uploads
+	LocalImagesCacheDirName string = ".pb_images_cache"   // pb_data sub directory with the cached on-the-fly image transformations
+	LocalAnalyticsDirName   string = ".pb_analytics"      // pb_data sub directory with the analytics snapshot database
 )
 
 var _ App = (*BaseApp)(nil)
@@ -54,6 +64,11 @@ type BaseApp struct {
 	dao                 *daos.Dao
 	logsDao             *daos.Dao
 	subscriptionsBroker *subscriptions.Broker
+	queryStats          *querystats.Tracker
+	metrics             *metrics.Registry
+	secrets             *secrets.Manager
+	logShipping         logShippingState
+	analytics           analyticsState
 
 	// app event hooks
 	onBeforeBootstrap *hook.Hook[*BootstrapEvent]
@@ -62,6 +77,10 @@ type BaseApp struct {
 	onBeforeApiError  *hook.Hook[*ApiErrorEvent]
 	onAfterApiError   *hook.Hook[*ApiErrorEvent]
 	onTerminate       *hook.Hook[*TerminateEvent]
+	onBackupProgress  *hook.Hook[*BackupProgressEvent]
+	onSecurityAlert   *hook.Hook[*SecurityAlertEvent]
+
+	onScheduledExportDelivery *hook.Hook[*ScheduledExportDeliveryEvent]
 
 	// dao event hooks
 	onModelBeforeCreate *hook.Hook[*ModelEvent]
@@ -109,6 +128,8 @@ type BaseApp struct {
 	onAdminBeforeDeleteRequest               *hook.Hook[*AdminDeleteEvent]
 	onAdminAfterDeleteRequest                *hook.Hook[*AdminDeleteEvent]
 	onAdminAuthRequest                       *hook.Hook[*AdminAuthEvent]
+	onAdminBeforeImpersonateRequest          *hook.Hook[*AdminImpersonateEvent]
+	onAdminAfterImpersonateRequest           *hook.Hook[*AdminImpersonateEvent]
 	onAdminBeforeAuthWithPasswordRequest     *hook.Hook[*AdminAuthWithPasswordEvent]
 	onAdminAfterAuthWithPasswordRequest      *hook.Hook[*AdminAuthWithPasswordEvent]
 	onAdminBeforeAuthRefreshRequest          *hook.Hook[*AdminAuthRefreshEvent]
@@ -163,6 +184,14 @@ type BaseApp struct {
 	onCollectionAfterDeleteRequest   *hook.Hook[*CollectionDeleteEvent]
 	onCollectionsBeforeImportRequest *hook.Hook[*CollectionsImportEvent]
 	onCollectionsAfterImportRequest  *hook.Hook[*CollectionsImportEvent]
+
+	// users API event hooks
+	onUserBeforeCreateRequest *hook.Hook[*UserCreateEvent]
+	onUserAfterCreateRequest  *hook.Hook[*UserCreateEvent]
+	onUserBeforeUpdateRequest *hook.Hook[*UserUpdateEvent]
+	onUserAfterUpdateRequest  *hook.Hook[*UserUpdateEvent]
+	onUserBeforeDeleteRequest *hook.Hook[*UserDeleteEvent]
+	onUserAfterDeleteRequest  *hook.Hook[*UserDeleteEvent]
 }
 
 // BaseAppConfig defines a BaseApp configuration option
@@ -192,6 +221,9 @@ func NewBaseApp(config *BaseAppConfig) *BaseApp {
 		cache:               store.New[any](nil),
 		settings:            settings.New(),
 		subscriptionsBroker: subscriptions.NewBroker(),
+		queryStats:          querystats.NewTracker(querystats.DefaultCapacity),
+		metrics:             metrics.NewRegistry(),
+		secrets:             newSecretsManager(),
 
 		// app event hooks
 		onBeforeBootstrap: &hook.Hook[*BootstrapEvent]{},
@@ -200,6 +232,10 @@ func NewBaseApp(config *BaseAppConfig) *BaseApp {
 		onBeforeApiError:  &hook.Hook[*ApiErrorEvent]{},
 		onAfterApiError:   &hook.Hook[*ApiErrorEvent]{},
 		onTerminate:       &hook.Hook[*TerminateEvent]{},
+		onBackupProgress:  &hook.Hook[*BackupProgressEvent]{},
+		onSecurityAlert:   &hook.Hook[*SecurityAlertEvent]{},
+
+		onScheduledExportDelivery: &hook.Hook[*ScheduledExportDeliveryEvent]{},
 
 		// dao event hooks
 		onModelBeforeCreate: &hook.Hook[*ModelEvent]{},
@@ -247,6 +283,8 @@ func NewBaseApp(config *BaseAppConfig) *BaseApp {
 		onAdminBeforeDeleteRequest:               &hook.Hook[*AdminDeleteEvent]{},
 		onAdminAfterDeleteRequest:                &hook.Hook[*AdminDeleteEvent]{},
 		onAdminAuthRequest:                       &hook.Hook[*AdminAuthEvent]{},
+		onAdminBeforeImpersonateRequest:          &hook.Hook[*AdminImpersonateEvent]{},
+		onAdminAfterImpersonateRequest:           &hook.Hook[*AdminImpersonateEvent]{},
 		onAdminBeforeAuthWithPasswordRequest:     &hook.Hook[*AdminAuthWithPasswordEvent]{},
 		onAdminAfterAuthWithPasswordRequest:      &hook.Hook[*AdminAuthWithPasswordEvent]{},
 		onAdminBeforeAuthRefreshRequest:          &hook.Hook[*AdminAuthRefreshEvent]{},
@@ -301,6 +339,14 @@ func NewBaseApp(config *BaseAppConfig) *BaseApp {
 		onCollectionAfterDeleteRequest:   &hook.Hook[*CollectionDeleteEvent]{},
 		onCollectionsBeforeImportRequest: &hook.Hook[*CollectionsImportEvent]{},
 		onCollectionsAfterImportRequest:  &hook.Hook[*CollectionsImportEvent]{},
+
+		// users API event hooks
+		onUserBeforeCreateRequest: &hook.Hook[*UserCreateEvent]{},
+		onUserAfterCreateRequest:  &hook.Hook[*UserCreateEvent]{},
+		onUserBeforeUpdateRequest: &hook.Hook[*UserUpdateEvent]{},
+		onUserAfterUpdateRequest:  &hook.Hook[*UserUpdateEvent]{},
+		onUserBeforeDeleteRequest: &hook.Hook[*UserDeleteEvent]{},
+		onUserAfterDeleteRequest:  &hook.Hook[*UserDeleteEvent]{},
 	}
 
 	app.registerDefaultHooks()
@@ -377,6 +423,10 @@ func (app *BaseApp) ResetBootstrapState() error {
 		}
 	}
 
+	if err := app.closeAnalyticsSnapshot(); err != nil {
+		return err
+	}
+
 	app.dao = nil
 	app.logsDao = nil
 	app.settings = nil
@@ -464,6 +514,12 @@ func (app *BaseApp) SubscriptionsBroker() *subscriptions.Broker {
 	return app.subscriptionsBroker
 }
 
+// QueryStats returns the app tracker with the most recently
+// executed data db queries and their durations.
+func (app *BaseApp) QueryStats() *querystats.Tracker {
+	return app.queryStats
+}
+
 // NewMailClient creates and returns a new SMTP or Sendmail client
 // based on the current app settings.
 func (app *BaseApp) NewMailClient() mailer.Mailer {
@@ -472,7 +528,7 @@ func (app *BaseApp) NewMailClient() mailer.Mailer {
 			Host:       app.Settings().Smtp.Host,
 			Port:       app.Settings().Smtp.Port,
 			Username:   app.Settings().Smtp.Username,
-			Password:   app.Settings().Smtp.Password,
+			Password:   app.secrets.Resolve(app.Settings().Smtp.Password),
 			Tls:        app.Settings().Smtp.Tls,
 			AuthMethod: app.Settings().Smtp.AuthMethod,
 		}
@@ -481,49 +537,104 @@ func (app *BaseApp) NewMailClient() mailer.Mailer {
 	return &mailer.Sendmail{}
 }
 
-// NewFilesystem creates a new local or S3 filesystem instance
-// for managing regular app files (eg. collection uploads)
+// NewFilesystem creates a new local, S3 or registered driver filesystem
+// instance for managing regular app files (eg. collection uploads)
 // based on the current app settings.
 //
 // NB! Make sure to call `Close()` on the returned result
 // after you are done working with it.
 func (app *BaseApp) NewFilesystem() (*filesystem.System, error) {
+	if app.settings != nil && app.settings.StorageDriver.Enabled() {
+		return app.newDriverFilesystem(app.settings.StorageDriver)
+	}
+
 	if app.settings != nil && app.settings.S3.Enabled {
-		return filesystem.NewS3(
-			app.settings.S3.Bucket,
-			app.settings.S3.Region,
-			app.settings.S3.Endpoint,
-			app.settings.S3.AccessKey,
-			app.settings.S3.Secret,
-			app.settings.S3.ForcePathStyle,
-		)
+		return app.newS3Filesystem(app.settings.S3)
 	}
 
 	// fallback to local filesystem
 	return filesystem.NewLocal(filepath.Join(app.DataDir(), LocalStorageDirName))
 }
 
-// NewFilesystem creates a new local or S3 filesystem instance
-// for managing app backups based on the current app settings.
+// NewBackupsFilesystem creates a new local, S3 or registered driver
+// filesystem instance for managing app backups based on the current app settings.
 //
 // NB! Make sure to call `Close()` on the returned result
 // after you are done working with it.
 func (app *BaseApp) NewBackupsFilesystem() (*filesystem.System, error) {
+	if app.settings != nil && app.settings.Backups.Driver.Enabled() {
+		return app.newDriverFilesystem(app.settings.Backups.Driver)
+	}
+
 	if app.settings != nil && app.settings.Backups.S3.Enabled {
-		return filesystem.NewS3(
-			app.settings.Backups.S3.Bucket,
-			app.settings.Backups.S3.Region,
-			app.settings.Backups.S3.Endpoint,
-			app.settings.Backups.S3.AccessKey,
-			app.settings.Backups.S3.Secret,
-			app.settings.Backups.S3.ForcePathStyle,
-		)
+		return app.newS3Filesystem(app.settings.Backups.S3)
 	}
 
 	// fallback to local filesystem
 	return filesystem.NewLocal(filepath.Join(app.DataDir(), LocalBackupsDirName))
 }
 
+// newS3Filesystem creates a new S3 filesystem instance from the provided config.
+func (app *BaseApp) newS3Filesystem(config settings.S3Config) (*filesystem.System, error) {
+	client, err := app.NewHttpClient(settings.EgressSubsystemS3)
+	if err != nil {
+		return nil, err
+	}
+
+	fsys, err := filesystem.NewS3WithHttpClient(
+		config.Bucket,
+		config.Region,
+		config.Endpoint,
+		config.AccessKey,
+		app.secrets.Resolve(config.Secret),
+		config.ForcePathStyle,
+		client,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	fsys.SetTransferOptions(config.TransferPartSize(), config.TransferConcurrency())
+
+	return fsys, nil
+}
+
+// newDriverFilesystem creates a new filesystem instance from the
+// registered driver of the provided config (resolving its secret options).
+func (app *BaseApp) newDriverFilesystem(config settings.FilesystemDriverConfig) (*filesystem.System, error) {
+	options := make(map[string]string, len(config.Options))
+	for k, v := range config.Options {
+		options[k] = app.secrets.Resolve(v)
+	}
+
+	return filesystem.NewWithDriver(config.Name, options)
+}
+
+// NewSearchIndexer creates and returns a configured searchsync.Indexer
+// for the external search engine from the app SearchSync settings.
+func (app *BaseApp) NewSearchIndexer() (searchsync.Indexer, error) {
+	config := app.Settings().SearchSync
+
+	client, err := app.NewHttpClient(settings.EgressSubsystemSearchSync)
+	if err != nil {
+		return nil, err
+	}
+
+	return searchsync.New(config.Provider, config.Host, app.secrets.Resolve(config.ApiKey), client)
+}
+
+// NewHttpClient creates and returns an http client for the outgoing
+// requests of the provided subsystem that honors the app Egress
+// settings (proxy, destinations allowlist, private ranges guard).
+func (app *BaseApp) NewHttpClient(subsystem string) (*http.Client, error) {
+	var policy egress.Policy
+	if app.settings != nil {
+		policy = app.Settings().Egress.Policy(subsystem)
+	}
+
+	return egress.NewClient(policy, 30*time.Second)
+}
+
 // Restart restarts (aka. replaces) the current running application process.
 //
 // NB! It relies on execve which is supported only on UNIX based systems.
@@ -563,19 +674,65 @@ func (app *BaseApp) RefreshSettings() error {
 		return err
 	}
 
-	// no settings were previously stored
 	if storedSettings == nil {
-		return app.Dao().SaveSettings(app.settings, encryptionKey)
+		// no settings were previously stored
+		if err := app.Dao().SaveSettings(app.settings, encryptionKey); err != nil {
+			return err
+		}
+	} else if err := app.settings.Merge(storedSettings); err != nil {
+		// failed to load the settings from the stored param into the app ones
+		return err
 	}
 
-	// load the settings from the stored param into the app ones
-	if err := app.settings.Merge(storedSettings); err != nil {
+	// apply the PB_ prefixed env overrides on top of the stored settings
+	if err := app.settings.ApplyEnv(os.Environ()); err != nil {
 		return err
 	}
 
+	app.RefreshSecrets()
+
 	return nil
 }
 
+// Secrets returns the app settings secret references manager.
+func (app *BaseApp) Secrets() *secrets.Manager {
+	return app.secrets
+}
+
+// RefreshSecrets resolves (and caches) the secret references
+// of the current application settings.
+//
+// The resolve errors are not returned and could be inspected
+// with app.Secrets().Status().
+func (app *BaseApp) RefreshSecrets() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	app.secrets.Load(ctx, app.Settings().SecretFields())
+
+	if app.IsDebug() {
+		for _, status := range app.secrets.Status() {
+			if !status.Resolved {
+				log.Printf("Failed to resolve the %s secret reference: %s\n", status.Field, status.Error)
+			}
+		}
+	}
+}
+
+// newSecretsManager creates a new secrets manager with the builtin
+// env and aws providers (and vault if VAULT_ADDR is set).
+func newSecretsManager() *secrets.Manager {
+	manager := secrets.NewManager()
+
+	manager.Register(secrets.ProviderAws, &secrets.AwsProvider{})
+
+	if vault := secrets.NewVaultProviderFromEnv(); vault != nil {
+		manager.Register(secrets.ProviderVault, vault)
+	}
+
+	return manager
+}
+
 // -------------------------------------------------------------------
 // App event hooks
 // -------------------------------------------------------------------
@@ -604,6 +761,18 @@ func (app *BaseApp) OnTerminate() *hook.Hook[*TerminateEvent] {
 	return app.onTerminate
 }
 
+func (app *BaseApp) OnBackupProgress() *hook.Hook[*BackupProgressEvent] {
+	return app.onBackupProgress
+}
+
+func (app *BaseApp) OnSecurityAlert() *hook.Hook[*SecurityAlertEvent] {
+	return app.onSecurityAlert
+}
+
+func (app *BaseApp) OnScheduledExportDelivery() *hook.Hook[*ScheduledExportDeliveryEvent] {
+	return app.onScheduledExportDelivery
+}
+
 // -------------------------------------------------------------------
 // Dao event hooks
 // -------------------------------------------------------------------
@@ -768,6 +937,14 @@ func (app *BaseApp) OnAdminAuthRequest() *hook.Hook[*AdminAuthEvent] {
 	return app.onAdminAuthRequest
 }
 
+func (app *BaseApp) OnAdminBeforeImpersonateRequest() *hook.Hook[*AdminImpersonateEvent] {
+	return app.onAdminBeforeImpersonateRequest
+}
+
+func (app *BaseApp) OnAdminAfterImpersonateRequest() *hook.Hook[*AdminImpersonateEvent] {
+	return app.onAdminAfterImpersonateRequest
+}
+
 func (app *BaseApp) OnAdminBeforeAuthWithPasswordRequest() *hook.Hook[*AdminAuthWithPasswordEvent] {
 	return app.onAdminBeforeAuthWithPasswordRequest
 }
@@ -972,6 +1149,34 @@ func (app *BaseApp) OnCollectionsAfterImportRequest() *hook.Hook[*CollectionsImp
 	return app.onCollectionsAfterImportRequest
 }
 
+// -------------------------------------------------------------------
+// Users API event hooks
+// -------------------------------------------------------------------
+
+func (app *BaseApp) OnUserBeforeCreateRequest() *hook.Hook[*UserCreateEvent] {
+	return app.onUserBeforeCreateRequest
+}
+
+func (app *BaseApp) OnUserAfterCreateRequest() *hook.Hook[*UserCreateEvent] {
+	return app.onUserAfterCreateRequest
+}
+
+func (app *BaseApp) OnUserBeforeUpdateRequest() *hook.Hook[*UserUpdateEvent] {
+	return app.onUserBeforeUpdateRequest
+}
+
+func (app *BaseApp) OnUserAfterUpdateRequest() *hook.Hook[*UserUpdateEvent] {
+	return app.onUserAfterUpdateRequest
+}
+
+func (app *BaseApp) OnUserBeforeDeleteRequest() *hook.Hook[*UserDeleteEvent] {
+	return app.onUserBeforeDeleteRequest
+}
+
+func (app *BaseApp) OnUserAfterDeleteRequest() *hook.Hook[*UserDeleteEvent] {
+	return app.onUserAfterDeleteRequest
+}
+
 // -------------------------------------------------------------------
 // Helpers
 // -------------------------------------------------------------------
@@ -1033,23 +1238,42 @@ func (app *BaseApp) initDataDB() error {
 	nonconcurrentDB.DB().SetMaxIdleConns(1)
 	nonconcurrentDB.DB().SetConnMaxIdleTime(5 * time.Minute)
 
-	if app.IsDebug() {
-		nonconcurrentDB.QueryLogFunc = func(ctx context.Context, t time.Duration, sql string, rows *sql.Rows, err error) {
-			color.HiBlack("[%.2fms] %v\n", float64(t.Milliseconds()), sql)
-		}
-		concurrentDB.QueryLogFunc = nonconcurrentDB.QueryLogFunc
+	nonconcurrentDB.QueryLogFunc = func(ctx context.Context, t time.Duration, sql string, rows *sql.Rows, err error) {
+		app.logQuery(t, sql)
+	}
+	concurrentDB.QueryLogFunc = nonconcurrentDB.QueryLogFunc
 
-		nonconcurrentDB.ExecLogFunc = func(ctx context.Context, t time.Duration, sql string, result sql.Result, err error) {
-			color.HiBlack("[%.2fms] %v\n", float64(t.Milliseconds()), sql)
-		}
-		concurrentDB.ExecLogFunc = nonconcurrentDB.ExecLogFunc
+	nonconcurrentDB.ExecLogFunc = func(ctx context.Context, t time.Duration, sql string, result sql.Result, err error) {
+		app.logQuery(t, sql)
 	}
+	concurrentDB.ExecLogFunc = nonconcurrentDB.ExecLogFunc
 
 	app.dao = app.createDaoWithHooks(concurrentDB, nonconcurrentDB)
 
 	return nil
 }
 
+// logQuery prints the executed data db query to the console when the
+// app is in debug mode and registers it in the app query stats if it
+// exceeds the enabled slow queries threshold.
+func (app *BaseApp) logQuery(t time.Duration, sql string) {
+	if app.IsDebug() {
+		color.HiBlack("[%.2fms] %v\n", float64(t.Milliseconds()), sql)
+	}
+
+	// skip the query plan lookups to avoid polluting the stats
+	if strings.HasPrefix(sql, "EXPLAIN ") {
+		return
+	}
+
+	app.observeQueryMetric(t, sql)
+
+	config := app.Settings().SlowQueries
+	if config.Enabled && t >= time.Duration(config.Threshold)*time.Millisecond {
+		app.queryStats.Track(sql, t)
+	}
+}
+
 func (app *BaseApp) createDaoWithHooks(concurrentDB, nonconcurrentDB dbx.Builder) *daos.Dao {
 	dao := daos.NewMultiDB(concurrentDB, nonconcurrentDB)
 
@@ -1119,6 +1343,10 @@ func (app *BaseApp) registerDefaultHooks() {
 		defer fs.Close()
 
 		failed := fs.DeletePrefix(prefix)
+
+		// release the deduplicated file objects references (if any)
+		failed = append(failed, app.Dao().DeleteFileRefsByPrefix(fs, prefix+"/")...)
+
 		if len(failed) > 0 {
 			return errors.New("failed to delete the files at " + prefix)
 		}
@@ -1156,4 +1384,28 @@ func (app *BaseApp) registerDefaultHooks() {
 	if err := app.initAutobackupHooks(); err != nil && app.IsDebug() {
 		log.Println(err)
 	}
+
+	app.initSearchSyncHooks()
+
+	app.initWebhookHooks()
+
+	app.initRecordRevisionsHooks()
+
+	app.initRecordFTSHooks()
+
+	app.initRecordGeoHooks()
+
+	app.initMaterializedViewsHooks()
+
+	if err := app.initSecurityReportHooks(); err != nil && app.IsDebug() {
+		log.Println(err)
+	}
+
+	app.initScheduledExportsHooks()
+
+	app.initLogShippingHooks()
+
+	if err := app.initAnalyticsHooks(); err != nil && app.IsDebug() {
+		log.Println(err)
+	}
 }
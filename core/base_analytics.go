@@ -0,0 +1,210 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/cron"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// AnalyticsSnapshotName is the analytics snapshot database file name
+// (stored in the LocalAnalyticsDirName pb_data sub directory).
+const AnalyticsSnapshotName = "data.db"
+
+// errAnalyticsRollback is used to rollback the analytics query transactions.
+var errAnalyticsRollback = errors.New("analytics rollback")
+
+type analyticsState struct {
+	// refreshMux serializes the snapshot refreshes
+	refreshMux sync.Mutex
+
+	// mux guards the snapshot db (the queries hold a read lock
+	// so that the db is not closed while they are running)
+	mux       sync.RWMutex
+	db        *dbx.DB
+	refreshed types.DateTime
+}
+
+// RefreshAnalyticsSnapshot replaces the analytics snapshot database
+// with a fresh copy of the current app database.
+func (app *BaseApp) RefreshAnalyticsSnapshot() error {
+	app.analytics.refreshMux.Lock()
+	defer app.analytics.refreshMux.Unlock()
+
+	dir := filepath.Join(app.DataDir(), LocalAnalyticsDirName)
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create the analytics dir: %w", err)
+	}
+
+	// note: it needs to be inside the current pb_data to avoid "cross-device link" errors
+	tempPath := filepath.Join(dir, "snapshot_"+security.PseudorandomString(6)+".db")
+	defer os.Remove(tempPath)
+
+	// VACUUM INTO creates a consistent (and compacted) copy without blocking the writes
+	_, err := app.Dao().ConcurrentDB().NewQuery("VACUUM INTO {:path}").
+		Bind(dbx.Params{"path": tempPath}).
+		Execute()
+	if err != nil {
+		return fmt.Errorf("failed to create the analytics snapshot: %w", err)
+	}
+
+	app.analytics.mux.Lock()
+	defer app.analytics.mux.Unlock()
+
+	if app.analytics.db != nil {
+		if err := app.analytics.db.Close(); err != nil {
+			return err
+		}
+		app.analytics.db = nil
+	}
+
+	snapshotPath := filepath.Join(dir, AnalyticsSnapshotName)
+
+	// remove the old snapshot leftovers
+	for _, suffix := range []string{"-wal", "-shm"} {
+		if err := os.Remove(snapshotPath + suffix); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	if err := os.Rename(tempPath, snapshotPath); err != nil {
+		return fmt.Errorf("failed to replace the analytics snapshot: %w", err)
+	}
+
+	db, err := connectDB(snapshotPath)
+	if err != nil {
+		return err
+	}
+
+	app.analytics.db = db
+	app.analytics.refreshed = types.NowDateTime()
+
+	return nil
+}
+
+// RunAnalyticsQuery executes fn in a read-only transaction of the
+// analytics snapshot database and returns the snapshot refresh date.
+//
+// The snapshot is created on first use if it doesn't exist yet.
+// All changes made by fn are rolled back.
+func (app *BaseApp) RunAnalyticsQuery(ctx context.Context, fn func(tx dbx.Builder) error) (types.DateTime, error) {
+	app.analytics.mux.RLock()
+	exists := app.analytics.db != nil
+	app.analytics.mux.RUnlock()
+
+	if !exists {
+		if err := app.RefreshAnalyticsSnapshot(); err != nil {
+			return types.DateTime{}, err
+		}
+	}
+
+	app.analytics.mux.RLock()
+	defer app.analytics.mux.RUnlock()
+
+	if app.analytics.db == nil {
+		return types.DateTime{}, errors.New("the analytics snapshot is not available")
+	}
+
+	refreshed := app.analytics.refreshed
+
+	err := app.analytics.db.TransactionalContext(ctx, nil, func(tx *dbx.Tx) error {
+		// reject any write attempt on the connection level
+		if _, err := tx.NewQuery("PRAGMA query_only = TRUE").Execute(); err != nil {
+			return err
+		}
+
+		if err := fn(tx); err != nil {
+			return err
+		}
+
+		return errAnalyticsRollback
+	})
+
+	if err != nil && !errors.Is(err, errAnalyticsRollback) {
+		return refreshed, err
+	}
+
+	return refreshed, nil
+}
+
+// closeAnalyticsSnapshot closes the analytics snapshot database connection (if any).
+func (app *BaseApp) closeAnalyticsSnapshot() error {
+	app.analytics.mux.Lock()
+	defer app.analytics.mux.Unlock()
+
+	if app.analytics.db == nil {
+		return nil
+	}
+
+	err := app.analytics.db.Close()
+	app.analytics.db = nil
+
+	return err
+}
+
+// initAnalyticsHooks registers the app serve hooks that
+// periodically refresh the analytics snapshot database.
+func (app *BaseApp) initAnalyticsHooks() error {
+	c := cron.New()
+	isServe := false
+
+	loadJob := func() {
+		c.Stop()
+		c.RemoveAll()
+
+		config := app.Settings().Analytics
+		if !config.Enabled || config.RefreshCron == "" || !isServe || !app.IsBootstrapped() {
+			return
+		}
+
+		err := c.Add("@analyticsSnapshot", config.RefreshCron, func() {
+			if err := app.RefreshAnalyticsSnapshot(); err != nil && app.IsDebug() {
+				// @todo replace after logs generalization
+				log.Println(err)
+			}
+		})
+		if err != nil && app.IsDebug() {
+			log.Println(err)
+			return
+		}
+
+		// restart the ticker
+		c.Start()
+	}
+
+	// load on app serve
+	app.OnBeforeServe().Add(func(e *ServeEvent) error {
+		isServe = true
+		loadJob()
+		return nil
+	})
+
+	// stop the ticker on app termination
+	app.OnTerminate().Add(func(e *TerminateEvent) error {
+		c.Stop()
+		return nil
+	})
+
+	// reload on app settings change
+	app.OnModelAfterUpdate((&models.Param{}).TableName()).Add(func(e *ModelEvent) error {
+		p := e.Model.(*models.Param)
+		if p == nil || p.Key != models.ParamAppSettings {
+			return nil
+		}
+
+		loadJob()
+
+		return nil
+	})
+
+	return nil
+}
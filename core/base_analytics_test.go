@@ -0,0 +1,68 @@
+package core_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestRunAnalyticsQuery(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	countDemo2 := func() int {
+		var total int
+
+		_, err := app.RunAnalyticsQuery(context.Background(), func(tx dbx.Builder) error {
+			return tx.NewQuery("SELECT COUNT(*) FROM demo2").Row(&total)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return total
+	}
+
+	// lazily created snapshot
+	initialTotal := countDemo2()
+	if initialTotal == 0 {
+		t.Fatal("Expected the snapshot to contain the demo2 records")
+	}
+
+	snapshotPath := filepath.Join(app.DataDir(), core.LocalAnalyticsDirName, core.AnalyticsSnapshotName)
+	if _, err := os.Stat(snapshotPath); err != nil {
+		t.Fatalf("Expected the snapshot file to exist: %v", err)
+	}
+
+	// live changes are not visible until the snapshot is refreshed
+	if _, err := app.Dao().DB().NewQuery("DELETE FROM demo2").Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if total := countDemo2(); total != initialTotal {
+		t.Fatalf("Expected %d snapshot records, got %d", initialTotal, total)
+	}
+
+	// writes are rejected
+	_, err := app.RunAnalyticsQuery(context.Background(), func(tx dbx.Builder) error {
+		_, err := tx.NewQuery("DELETE FROM demo2").Execute()
+		return err
+	})
+	if err == nil {
+		t.Fatal("Expected the snapshot write to fail")
+	}
+	if total := countDemo2(); total != initialTotal {
+		t.Fatalf("Expected %d snapshot records after the failed write, got %d", initialTotal, total)
+	}
+
+	if err := app.RefreshAnalyticsSnapshot(); err != nil {
+		t.Fatal(err)
+	}
+	if total := countDemo2(); total != 0 {
+		t.Fatalf("Expected 0 records after the snapshot refresh, got %d", total)
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"io"
 	"log"
 	"os"
 	"path/filepath"
@@ -38,7 +37,10 @@ const CacheKeyActiveBackup string = "@activeBackup"
 // take care manually to backup those since they are not part of the pb_data.
 //
 // Backups can be stored on S3 if it is configured in app.Settings().Backups.
-func (app *BaseApp) CreateBackup(ctx context.Context, name string) error {
+//
+// If app.Settings().Backups.EncryptionKey is set, the generated
+// archive is encrypted with it before the upload.
+func (app *BaseApp) CreateBackup(ctx context.Context, name string) (err error) {
 	if app.Cache().Has(CacheKeyActiveBackup) {
 		return errors.New("try again later - another backup/restore operation has already been started")
 	}
@@ -54,13 +56,21 @@ func (app *BaseApp) CreateBackup(ctx context.Context, name string) error {
 	app.Cache().Set(CacheKeyActiveBackup, name)
 	defer app.Cache().Remove(CacheKeyActiveBackup)
 
-	// Archive pb_data in a temp directory, exluding the "backups" dir itself (if exist).
+	progress := newBackupProgressReporter(app, BackupActionCreate, name)
+	defer func() {
+		progress.finish(err)
+	}()
+
+	progress.report(BackupStageArchive, 0)
+
+	// Archive pb_data in a temp directory, exluding the "backups" dir itself (if exist)
+	// and the analytics snapshot (it is recreated on demand).
 	//
 	// Run in transaction to temporary block other writes (transactions uses the NonconcurrentDB connection).
 	// ---
 	tempPath := filepath.Join(os.TempDir(), "pb_backup_"+security.PseudorandomString(4))
 	createErr := app.Dao().RunInTransaction(func(txDao *daos.Dao) error {
-		if err := archive.Create(app.DataDir(), tempPath, LocalBackupsDirName); err != nil {
+		if err := archive.Create(app.DataDir(), tempPath, LocalBackupsDirName, LocalAnalyticsDirName); err != nil {
 			return err
 		}
 		return nil
@@ -70,6 +80,20 @@ func (app *BaseApp) CreateBackup(ctx context.Context, name string) error {
 	}
 	defer os.Remove(tempPath)
 
+	// Encrypt the archive (if a backups encryption key is configured).
+	// ---
+	if key := app.Settings().Backups.EncryptionKey; key != "" {
+		progress.report(BackupStageEncrypt, 50)
+
+		encryptedPath := tempPath + "_encrypted"
+		if err := archive.Encrypt(tempPath, encryptedPath, key); err != nil {
+			return fmt.Errorf("failed to encrypt the backup: %w", err)
+		}
+		defer os.Remove(encryptedPath)
+
+		tempPath = encryptedPath
+	}
+
 	// Persist the backup in the backups filesystem.
 	// ---
 	fsys, err := app.NewBackupsFilesystem()
@@ -87,6 +111,8 @@ func (app *BaseApp) CreateBackup(ctx context.Context, name string) error {
 	file.OriginalName = name
 	file.Name = file.OriginalName
 
+	progress.report(BackupStageUpload, 70)
+
 	if err := fsys.UploadFile(file, file.Name); err != nil {
 		return err
 	}
@@ -106,6 +132,7 @@ func (app *BaseApp) CreateBackup(ctx context.Context, name string) error {
 //
 //  1. Download the backup with the specified name in a temp location
 //     (this is in case of S3; otherwise it creates a temp copy of the zip)
+//     and decrypt it with app.Settings().Backups.EncryptionKey if it is encrypted.
 //
 //  2. Extract the backup in a temp directory inside the app "pb_data"
 //     (eg. "pb_data/.pb_temp_to_delete/pb_restore").
@@ -122,7 +149,7 @@ func (app *BaseApp) CreateBackup(ctx context.Context, name string) error {
 //
 // If a failure occure during the restore process the dir changes are reverted.
 // If for whatever reason the revert is not possible, it panics.
-func (app *BaseApp) RestoreBackup(ctx context.Context, name string) error {
+func (app *BaseApp) RestoreBackup(ctx context.Context, name string) (err error) {
 	if runtime.GOOS == "windows" {
 		return errors.New("restore is not supported on windows")
 	}
@@ -134,6 +161,18 @@ func (app *BaseApp) RestoreBackup(ctx context.Context, name string) error {
 	app.Cache().Set(CacheKeyActiveBackup, name)
 	defer app.Cache().Remove(CacheKeyActiveBackup)
 
+	progress := newBackupProgressReporter(app, BackupActionRestore, name)
+	defer func() {
+		if r := recover(); r != nil {
+			progress.finish(fmt.Errorf("%v", r))
+			panic(r)
+		}
+
+		progress.finish(err)
+	}()
+
+	progress.report(BackupStageDownload, 0)
+
 	fsys, err := app.NewBackupsFilesystem()
 	if err != nil {
 		return err
@@ -143,11 +182,10 @@ func (app *BaseApp) RestoreBackup(ctx context.Context, name string) error {
 	fsys.SetContext(ctx)
 
 	// fetch the backup file in a temp location
-	br, err := fsys.GetFile(name)
+	attrs, err := fsys.Attributes(name)
 	if err != nil {
 		return err
 	}
-	defer br.Close()
 
 	tempZip, err := os.CreateTemp(os.TempDir(), "pb_restore")
 	if err != nil {
@@ -155,9 +193,41 @@ func (app *BaseApp) RestoreBackup(ctx context.Context, name string) error {
 	}
 	defer os.Remove(tempZip.Name())
 
-	if _, err := io.Copy(tempZip, br); err != nil {
+	downloadProgress := &backupProgressWriter{
+		reporter: progress,
+		stage:    BackupStageDownload,
+		total:    attrs.Size,
+		to:       40,
+	}
+
+	if _, err := fsys.DownloadTo(name, &progressWriterAt{WriterAt: tempZip, progress: downloadProgress}); err != nil {
+		return err
+	}
+
+	archivePath := tempZip.Name()
+
+	// decrypt the backup (if encrypted)
+	encrypted, err := archive.IsEncrypted(archivePath)
+	if err != nil {
 		return err
 	}
+	if encrypted {
+		progress.report(BackupStageDecrypt, 40)
+
+		key := app.Settings().Backups.EncryptionKey
+		if key == "" {
+			return errors.New("the backup is encrypted but there is no backups encryption key configured")
+		}
+
+		decryptedPath := archivePath + "_decrypted"
+		defer os.Remove(decryptedPath)
+
+		if err := archive.Decrypt(archivePath, decryptedPath, key); err != nil {
+			return fmt.Errorf("failed to decrypt the backup: %w", err)
+		}
+
+		archivePath = decryptedPath
+	}
 
 	// make sure that the special temp directory
 	if err := os.MkdirAll(filepath.Join(app.DataDir(), LocalTempDirName), os.ModePerm); err != nil {
@@ -167,7 +237,10 @@ func (app *BaseApp) RestoreBackup(ctx context.Context, name string) error {
 	// note: it needs to be inside the current pb_data to avoid "cross-device link" errors
 	extractedDataDir := filepath.Join(app.DataDir(), LocalTempDirName, "pb_restore_"+security.PseudorandomString(4))
 	defer os.RemoveAll(extractedDataDir)
-	if err := archive.Extract(tempZip.Name(), extractedDataDir); err != nil {
+
+	progress.report(BackupStageExtract, 50)
+
+	if err := archive.Extract(archivePath, extractedDataDir); err != nil {
 		return err
 	}
 
@@ -177,15 +250,22 @@ func (app *BaseApp) RestoreBackup(ctx context.Context, name string) error {
 		return fmt.Errorf("data.db file is missing or invalid: %w", err)
 	}
 
-	// remove the extracted zip file since we no longer need it
+	// remove the extracted zip file(s) since we no longer need them
 	// (this is in case the app restarts and the defer calls are not called)
 	if err := os.Remove(tempZip.Name()); err != nil && app.IsDebug() {
 		log.Println(err)
 	}
+	if archivePath != tempZip.Name() {
+		if err := os.Remove(archivePath); err != nil && app.IsDebug() {
+			log.Println(err)
+		}
+	}
 
 	// root dir entries to exclude from the backup restore
 	exclude := []string{LocalBackupsDirName, LocalTempDirName}
 
+	progress.report(BackupStageReplace, 70)
+
 	// move the current pb_data content to a special temp location
 	// that will hold the old data between dirs replace
 	// (the temp dir will be automatically removed on the next app start)
@@ -211,6 +291,10 @@ func (app *BaseApp) RestoreBackup(ctx context.Context, name string) error {
 		return nil
 	}
 
+	// note: on successful restart the app process is replaced,
+	// so the restart stage is the last reported one
+	progress.report(BackupStageRestart, 100)
+
 	// restart the app
 	if err := app.Restart(); err != nil {
 		if err := revertDataDirChanges(); err != nil {
@@ -223,6 +307,115 @@ func (app *BaseApp) RestoreBackup(ctx context.Context, name string) error {
 	return nil
 }
 
+// RotateBackupsEncryptionKey re-encrypts all encrypted backups
+// from the backups filesystem with newKey.
+//
+// The backups are first downloaded and re-encrypted in a temp location
+// and only then uploaded back, so that an invalid oldKey or a corrupted
+// backup doesn't leave the backups encrypted with different keys.
+//
+// Unencrypted backups are left unchanged.
+//
+// Note that this method doesn't update app.Settings().Backups.EncryptionKey.
+func (app *BaseApp) RotateBackupsEncryptionKey(ctx context.Context, oldKey string, newKey string) error {
+	if newKey == "" {
+		return errors.New("the new encryption key cannot be empty")
+	}
+
+	if app.Cache().Has(CacheKeyActiveBackup) {
+		return errors.New("try again later - another backup/restore operation has already been started")
+	}
+
+	app.Cache().Set(CacheKeyActiveBackup, "@rotate")
+	defer app.Cache().Remove(CacheKeyActiveBackup)
+
+	fsys, err := app.NewBackupsFilesystem()
+	if err != nil {
+		return err
+	}
+	defer fsys.Close()
+
+	fsys.SetContext(ctx)
+
+	files, err := fsys.List("")
+	if err != nil {
+		return err
+	}
+
+	tempDir, err := os.MkdirTemp("", "pb_backups_rotate")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tempDir)
+
+	// backup key -> re-encrypted file path
+	rotated := make(map[string]string, len(files))
+
+	for i, f := range files {
+		downloadedPath := filepath.Join(tempDir, fmt.Sprintf("%d_downloaded", i))
+		if err := downloadBackup(fsys, f.Key, downloadedPath); err != nil {
+			return err
+		}
+
+		encrypted, err := archive.IsEncrypted(downloadedPath)
+		if err != nil {
+			return err
+		}
+		if !encrypted {
+			os.Remove(downloadedPath)
+			continue
+		}
+
+		if oldKey == "" {
+			return fmt.Errorf("the backup %q is encrypted but there is no current encryption key", f.Key)
+		}
+
+		decryptedPath := filepath.Join(tempDir, fmt.Sprintf("%d_decrypted", i))
+		if err := archive.Decrypt(downloadedPath, decryptedPath, oldKey); err != nil {
+			return fmt.Errorf("failed to decrypt the backup %q: %w", f.Key, err)
+		}
+		os.Remove(downloadedPath)
+
+		encryptedPath := filepath.Join(tempDir, fmt.Sprintf("%d_encrypted", i))
+		if err := archive.Encrypt(decryptedPath, encryptedPath, newKey); err != nil {
+			return fmt.Errorf("failed to encrypt the backup %q: %w", f.Key, err)
+		}
+		os.Remove(decryptedPath)
+
+		rotated[f.Key] = encryptedPath
+	}
+
+	for key, path := range rotated {
+		file, err := filesystem.NewFileFromPath(path)
+		if err != nil {
+			return err
+		}
+		file.OriginalName = key
+		file.Name = key
+
+		if err := fsys.UploadFile(file, key); err != nil {
+			return fmt.Errorf("failed to upload the rotated backup %q: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+// downloadBackup downloads the backup with the specified key to dest.
+func downloadBackup(fsys *filesystem.System, key string, dest string) error {
+	f, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, err := fsys.DownloadTo(key, f); err != nil {
+		return err
+	}
+
+	return f.Close()
+}
+
 // initAutobackupHooks registers the autobackup app serve hooks.
 // @todo add tests
 func (app *BaseApp) initAutobackupHooks() error {
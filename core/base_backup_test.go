@@ -99,8 +99,148 @@ func TestRestoreBackup(t *testing.T) {
 	}
 }
 
+func TestCreateBackupEncrypted(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	key := "abcdabcdabcdabcdabcdabcdabcdabcd"
+
+	app.Settings().Backups.EncryptionKey = key
+
+	if err := app.CreateBackup(context.Background(), "test.zip"); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(app.DataDir(), core.LocalBackupsDirName, "test.zip")
+
+	if encrypted, err := archive.IsEncrypted(path); err != nil || !encrypted {
+		t.Fatalf("Expected the backup to be encrypted, got %v (%v)", encrypted, err)
+	}
+
+	decryptedPath := path + "_decrypted"
+	defer os.Remove(decryptedPath)
+
+	if err := archive.Decrypt(path, decryptedPath, key); err != nil {
+		t.Fatalf("Failed to decrypt the backup: %v", err)
+	}
+
+	if err := verifyBackupContent(app, decryptedPath); err != nil {
+		t.Fatalf("Failed to verify backup content: %v", err)
+	}
+}
+
+func TestRestoreBackupEncrypted(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	app.Settings().Backups.EncryptionKey = "abcdabcdabcdabcdabcdabcdabcdabcd"
+
+	if err := app.CreateBackup(context.Background(), "test"); err != nil {
+		t.Fatal("Failed to create test backup")
+	}
+
+	// missing key
+	app.Settings().Backups.EncryptionKey = ""
+	err := app.RestoreBackup(context.Background(), "test")
+	if err == nil || !strings.Contains(err.Error(), "encryption key") {
+		t.Fatalf("Expected missing encryption key error, got %v", err)
+	}
+
+	// wrong key
+	app.Settings().Backups.EncryptionKey = "1234abcdabcdabcdabcdabcdabcdabcd"
+	err = app.RestoreBackup(context.Background(), "test")
+	if err == nil || !strings.Contains(err.Error(), "failed to decrypt") {
+		t.Fatalf("Expected decrypt error, got %v", err)
+	}
+}
+
+func TestRotateBackupsEncryptionKey(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	oldKey := "abcdabcdabcdabcdabcdabcdabcdabcd"
+	newKey := "1234abcdabcdabcdabcdabcdabcdabcd"
+
+	backupsDir := filepath.Join(app.DataDir(), core.LocalBackupsDirName)
+
+	// create 1 unencrypted and 2 encrypted backups
+	if err := app.CreateBackup(context.Background(), "plain.zip"); err != nil {
+		t.Fatal(err)
+	}
+	app.Settings().Backups.EncryptionKey = oldKey
+	if err := app.CreateBackup(context.Background(), "encrypted1.zip"); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.CreateBackup(context.Background(), "encrypted2.zip"); err != nil {
+		t.Fatal(err)
+	}
+
+	// empty new key
+	if err := app.RotateBackupsEncryptionKey(context.Background(), oldKey, ""); err == nil {
+		t.Fatal("Expected empty new key error, got nil")
+	}
+
+	// pending error
+	app.Cache().Set(core.CacheKeyActiveBackup, "")
+	if err := app.RotateBackupsEncryptionKey(context.Background(), oldKey, newKey); err == nil {
+		t.Fatal("Expected pending error, got nil")
+	}
+	app.Cache().Remove(core.CacheKeyActiveBackup)
+
+	// missing old key
+	if err := app.RotateBackupsEncryptionKey(context.Background(), "", newKey); err == nil {
+		t.Fatal("Expected missing old key error, got nil")
+	}
+
+	// wrong old key
+	if err := app.RotateBackupsEncryptionKey(context.Background(), newKey, newKey); err == nil {
+		t.Fatal("Expected wrong old key error, got nil")
+	}
+
+	// the backups should be still decryptable with the old key
+	for _, name := range []string{"encrypted1.zip", "encrypted2.zip"} {
+		if err := verifyEncryptedBackup(app, filepath.Join(backupsDir, name), oldKey); err != nil {
+			t.Fatalf("[%s] Expected the backup to be unchanged after the failed rotation: %v", name, err)
+		}
+	}
+
+	if err := app.RotateBackupsEncryptionKey(context.Background(), oldKey, newKey); err != nil {
+		t.Fatalf("Failed to rotate the encryption key: %v", err)
+	}
+
+	for _, name := range []string{"encrypted1.zip", "encrypted2.zip"} {
+		if err := verifyEncryptedBackup(app, filepath.Join(backupsDir, name), newKey); err != nil {
+			t.Fatalf("[%s] Failed to verify the rotated backup: %v", name, err)
+		}
+	}
+
+	if encrypted, err := archive.IsEncrypted(filepath.Join(backupsDir, "plain.zip")); err != nil || encrypted {
+		t.Fatalf("Expected the unencrypted backup to remain unencrypted, got %v (%v)", encrypted, err)
+	}
+
+	if app.Cache().Has(core.CacheKeyActiveBackup) {
+		t.Fatal("Expected the active backup cache key to be removed")
+	}
+}
+
 // -------------------------------------------------------------------
 
+func verifyEncryptedBackup(app core.App, path string, key string) error {
+	dir, err := os.MkdirTemp("", "backup_test")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	decryptedPath := filepath.Join(dir, "decrypted.zip")
+
+	if err := archive.Decrypt(path, decryptedPath, key); err != nil {
+		return err
+	}
+
+	return verifyBackupContent(app, decryptedPath)
+}
+
 func verifyBackupContent(app core.App, path string) error {
 	dir, err := os.MkdirTemp("", "backup_test")
 	if err != nil {
@@ -0,0 +1,136 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tools/logship"
+)
+
+// logShippingCloseTimeout is the max duration to wait for the
+// queued log entries to be sent when the log shipper is replaced or stopped.
+const logShippingCloseTimeout = 10 * time.Second
+
+// logShippingState holds the active log shipper and the
+// settings signature that was used to create it.
+type logShippingState struct {
+	mux     sync.Mutex
+	key     string
+	shipper *logship.Shipper
+}
+
+// ShipLog queues the provided log entry for asynchronous shipping
+// to the external sink configured in app.Settings().LogShipping.
+//
+// The log shipper is (re)created lazily on settings change.
+//
+// It returns false if the log shipping is disabled or the entry was dropped.
+func (app *BaseApp) ShipLog(entry *logship.Entry) bool {
+	shipper := app.activeLogShipper()
+	if shipper == nil {
+		return false
+	}
+
+	return shipper.Push(entry)
+}
+
+// activeLogShipper returns the log shipper of the current
+// LogShipping settings (or nil if the shipping is disabled).
+func (app *BaseApp) activeLogShipper() *logship.Shipper {
+	config := app.Settings().LogShipping
+
+	key := ""
+	if config.Enabled {
+		raw, _ := json.Marshal(config)
+		key = string(raw)
+	}
+
+	app.logShipping.mux.Lock()
+	defer app.logShipping.mux.Unlock()
+
+	if app.logShipping.key == key {
+		return app.logShipping.shipper
+	}
+
+	// the settings were changed -> flush and replace the old shipper
+	if old := app.logShipping.shipper; old != nil {
+		go app.closeLogShipper(old)
+	}
+
+	app.logShipping.key = key
+	app.logShipping.shipper = nil
+
+	if !config.Enabled {
+		return nil
+	}
+
+	shipper, err := app.newLogShipper(config)
+	if err != nil {
+		if app.IsDebug() {
+			log.Println("Failed to initialize the log shipper:", err)
+		}
+		return nil
+	}
+
+	app.logShipping.shipper = shipper
+
+	return shipper
+}
+
+func (app *BaseApp) newLogShipper(config settings.LogShippingConfig) (*logship.Shipper, error) {
+	client, err := app.NewHttpClient(settings.EgressSubsystemLogShipping)
+	if err != nil {
+		return nil, err
+	}
+
+	sink, err := logship.NewSink(config.Sink, logship.Config{
+		Url:        config.Url,
+		AuthHeader: app.secrets.Resolve(config.AuthHeader),
+		Labels:     config.Labels,
+		Client:     client,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	options := config.ShipperOptions()
+	options.OnError = func(err error) {
+		if app.IsDebug() {
+			// non critical error - only log for debug
+			log.Println("Log shipping failed:", err)
+		}
+	}
+
+	return logship.NewShipper(sink, options), nil
+}
+
+func (app *BaseApp) closeLogShipper(shipper *logship.Shipper) {
+	ctx, cancel := context.WithTimeout(context.Background(), logShippingCloseTimeout)
+	defer cancel()
+
+	if err := shipper.Close(ctx); err != nil && app.IsDebug() {
+		log.Println("Failed to flush the log shipper queue:", err)
+	}
+}
+
+// initLogShippingHooks registers the app hooks that flush
+// the queued log entries before the app termination.
+func (app *BaseApp) initLogShippingHooks() {
+	app.OnTerminate().Add(func(e *TerminateEvent) error {
+		app.logShipping.mux.Lock()
+		shipper := app.logShipping.shipper
+		app.logShipping.key = ""
+		app.logShipping.shipper = nil
+		app.logShipping.mux.Unlock()
+
+		if shipper != nil {
+			app.closeLogShipper(shipper)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,94 @@
+package core
+
+import (
+	"log"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/cron"
+)
+
+// initMaterializedViewsHooks registers the app serve and collection
+// model hooks that periodically refresh the materialized view collections
+// with RefreshCron option.
+func (app *BaseApp) initMaterializedViewsHooks() {
+	c := cron.New()
+	isServe := false
+
+	jobId := func(collection *models.Collection) string {
+		return "@materialized_" + collection.Id
+	}
+
+	loadJob := func(collection *models.Collection) {
+		c.Remove(jobId(collection))
+
+		if !collection.IsView() {
+			return
+		}
+
+		options := collection.ViewOptions()
+		if !options.Materialized || options.RefreshCron == "" {
+			return
+		}
+
+		collectionId := collection.Id
+
+		err := c.Add(jobId(collection), options.RefreshCron, func() {
+			// reload the collection to ensure that its latest state is used
+			collection, err := app.Dao().FindCollectionByNameOrId(collectionId)
+			if err == nil {
+				err = app.Dao().RefreshMaterializedView(collection)
+			}
+
+			if err != nil && app.IsDebug() {
+				// @todo replace after logs generalization
+				log.Println(err)
+			}
+		})
+		if err != nil && app.IsDebug() {
+			log.Println(err)
+		}
+	}
+
+	// load on app serve
+	app.OnBeforeServe().Add(func(e *ServeEvent) error {
+		isServe = true
+
+		c.RemoveAll()
+
+		views, err := app.Dao().FindCollectionsByType(models.CollectionTypeView)
+		if err != nil {
+			return err
+		}
+
+		for _, view := range views {
+			loadJob(view)
+		}
+
+		c.Start()
+
+		return nil
+	})
+
+	// stop the ticker on app termination
+	app.OnTerminate().Add(func(e *TerminateEvent) error {
+		c.Stop()
+		return nil
+	})
+
+	// reload the changed collection job
+	reloadHandler := func(e *ModelEvent) error {
+		if collection, ok := e.Model.(*models.Collection); ok && isServe {
+			loadJob(collection)
+		}
+		return nil
+	}
+	app.OnModelAfterCreate((&models.Collection{}).TableName()).Add(reloadHandler)
+	app.OnModelAfterUpdate((&models.Collection{}).TableName()).Add(reloadHandler)
+
+	app.OnModelAfterDelete((&models.Collection{}).TableName()).Add(func(e *ModelEvent) error {
+		if collection, ok := e.Model.(*models.Collection); ok {
+			c.Remove(jobId(collection))
+		}
+		return nil
+	})
+}
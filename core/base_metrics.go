@@ -0,0 +1,50 @@
+package core
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/metrics"
+)
+
+// MetricDbQueryDuration is the name of the data db queries duration histogram.
+const MetricDbQueryDuration string = "pocketbase_db_query_duration_seconds"
+
+// Metrics returns the app metrics registry
+// (the metrics are collected only if enabled in the settings).
+func (app *BaseApp) Metrics() *metrics.Registry {
+	return app.metrics
+}
+
+// observeQueryMetric registers the executed data db query
+// duration in the app metrics (if enabled).
+func (app *BaseApp) observeQueryMetric(t time.Duration, sql string) {
+	if !app.Settings().Metrics.Enabled {
+		return
+	}
+
+	app.metrics.Histogram(
+		MetricDbQueryDuration,
+		"Duration of the executed data db queries in seconds.",
+		nil,
+		"operation",
+	).Observe(t.Seconds(), queryOperation(sql))
+}
+
+// queryOperation returns the lowercased first keyword of the provided
+// SQL statement (eg. "select", "insert") or "other" for the less common ones.
+func queryOperation(sql string) string {
+	fields := strings.Fields(sql)
+	if len(fields) == 0 {
+		return "other"
+	}
+
+	op := strings.ToLower(fields[0])
+
+	switch op {
+	case "select", "insert", "update", "delete", "with":
+		return op
+	default:
+		return "other"
+	}
+}
@@ -0,0 +1,36 @@
+package core_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestMetricsDbQueries(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	histogram := app.Metrics().Histogram(core.MetricDbQueryDuration, "", nil, "operation")
+
+	// disabled
+	app.Settings().Metrics.Enabled = false
+	if _, err := app.Dao().FindCollectionByNameOrId("demo1"); err != nil {
+		t.Fatal(err)
+	}
+	if total := histogram.Count("select"); total != 0 {
+		t.Fatalf("Expected no tracked queries, got %d", total)
+	}
+
+	// enabled
+	app.Settings().Metrics.Enabled = true
+	if _, err := app.Dao().FindCollectionByNameOrId("demo1"); err != nil {
+		t.Fatal(err)
+	}
+	if total := histogram.Count("select"); total == 0 {
+		t.Fatal("Expected the select query to be tracked")
+	}
+	if total := histogram.Count("insert"); total != 0 {
+		t.Fatalf("Expected no tracked insert queries, got %d", total)
+	}
+}
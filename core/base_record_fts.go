@@ -0,0 +1,45 @@
+package core
+
+import (
+	"log"
+
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// initRecordFTSHooks registers the hooks that keep the full text search
+// tables of the collections with searchable fields in sync with their records.
+func (app *BaseApp) initRecordFTSHooks() {
+	syncRecord := func(e *ModelEvent, isDelete bool) {
+		record, ok := e.Model.(*models.Record)
+		if !ok {
+			return
+		}
+
+		var err error
+		if isDelete {
+			err = e.Dao.DeleteRecordFTS(record)
+		} else {
+			err = e.Dao.SyncRecordFTS(record)
+		}
+
+		if err != nil && app.IsDebug() {
+			// non critical error - only log for debug
+			log.Println(err)
+		}
+	}
+
+	app.OnModelAfterCreate().Add(func(e *ModelEvent) error {
+		syncRecord(e, false)
+		return nil
+	})
+
+	app.OnModelAfterUpdate().Add(func(e *ModelEvent) error {
+		syncRecord(e, false)
+		return nil
+	})
+
+	app.OnModelAfterDelete().Add(func(e *ModelEvent) error {
+		syncRecord(e, true)
+		return nil
+	})
+}
@@ -0,0 +1,33 @@
+package core
+
+import (
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// initRecordRevisionsHooks registers the hooks that store the previous
+// state of the versioned collections records on every update.
+func (app *BaseApp) initRecordRevisionsHooks() {
+	app.OnModelBeforeUpdate().Add(func(e *ModelEvent) error {
+		record, ok := e.Model.(*models.Record)
+		if !ok {
+			return nil
+		}
+
+		config, ok := app.Settings().Versioning.Collection(record.Collection().Name)
+		if !ok {
+			return nil
+		}
+
+		// moving a record to the trash doesn't change its data
+		if record.Collection().IsSoftDelete() && !record.DeletedAt().IsZero() {
+			return nil
+		}
+
+		revision, err := e.Dao.CreateRecordRevision(record)
+		if err != nil || revision == nil {
+			return err
+		}
+
+		return e.Dao.PruneRecordRevisions(record, config.MaxRevisions, config.MaxDays)
+	})
+}
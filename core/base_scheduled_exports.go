@@ -0,0 +1,588 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"log"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tools/cron"
+	"github.com/pocketbase/pocketbase/tools/filesystem"
+	"github.com/pocketbase/pocketbase/tools/mailer"
+	"github.com/pocketbase/pocketbase/tools/parquet"
+	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/pocketbase/pocketbase/tools/types"
+	"github.com/pocketbase/pocketbase/tools/xlsx"
+	"github.com/spf13/cast"
+)
+
+// ScheduledExportLinkExpiry is the expiration duration of the
+// pre-signed export file links sent to the email recipients.
+const ScheduledExportLinkExpiry = 7 * 24 * time.Hour
+
+// initScheduledExportsHooks registers the app serve and scheduled export
+// model hooks that periodically run the enabled scheduled exports.
+func (app *BaseApp) initScheduledExportsHooks() {
+	c := cron.New()
+	isServe := false
+
+	jobId := func(export *models.ScheduledExport) string {
+		return "@scheduledExport_" + export.Id
+	}
+
+	loadJob := func(export *models.ScheduledExport) {
+		c.Remove(jobId(export))
+
+		if !export.Enabled {
+			return
+		}
+
+		exportId := export.Id
+
+		err := c.Add(jobId(export), export.Schedule, func() {
+			// reload the export to ensure that its latest state is used
+			export, err := app.Dao().FindScheduledExportById(exportId)
+			if err == nil {
+				err = app.RunScheduledExport(context.Background(), export)
+			}
+
+			if err != nil && app.IsDebug() {
+				// @todo replace after logs generalization
+				log.Println(err)
+			}
+		})
+		if err != nil && app.IsDebug() {
+			log.Println(err)
+		}
+	}
+
+	loadAll := func() error {
+		c.RemoveAll()
+
+		exports, err := app.Dao().FindEnabledScheduledExports()
+		if err != nil {
+			return err
+		}
+
+		for _, export := range exports {
+			loadJob(export)
+		}
+
+		return nil
+	}
+
+	// load on app serve
+	app.OnBeforeServe().Add(func(e *ServeEvent) error {
+		isServe = true
+
+		if err := loadAll(); err != nil {
+			return err
+		}
+
+		c.Start()
+
+		return nil
+	})
+
+	// stop the ticker on app termination
+	app.OnTerminate().Add(func(e *TerminateEvent) error {
+		c.Stop()
+		return nil
+	})
+
+	// reload the changed export job
+	reloadHandler := func(e *ModelEvent) error {
+		if export, ok := e.Model.(*models.ScheduledExport); ok && isServe {
+			loadJob(export)
+		}
+		return nil
+	}
+	app.OnModelAfterCreate((&models.ScheduledExport{}).TableName()).Add(reloadHandler)
+	app.OnModelAfterUpdate((&models.ScheduledExport{}).TableName()).Add(reloadHandler)
+
+	app.OnModelAfterDelete((&models.ScheduledExport{}).TableName()).Add(func(e *ModelEvent) error {
+		if export, ok := e.Model.(*models.ScheduledExport); ok {
+			c.Remove(jobId(export))
+		}
+		return nil
+	})
+
+	// the collection exports are deleted together with the collection
+	app.OnModelAfterDelete((&models.Collection{}).TableName()).Add(func(e *ModelEvent) error {
+		if isServe {
+			return loadAll()
+		}
+		return nil
+	})
+}
+
+// RunScheduledExport exports the records of the provided scheduled export
+// (matching its filter and sort) in a csv, json, xlsx or parquet file, uploads it under the
+// export storage prefix and, for the email destination, sends a link to the
+// uploaded file to the export recipients.
+//
+// The run time, the uploaded file key and the error message (if any)
+// are stored in the export model.
+func (app *BaseApp) RunScheduledExport(ctx context.Context, export *models.ScheduledExport) error {
+	runErr := app.runScheduledExport(ctx, export)
+
+	export.LastRun = types.NowDateTime()
+	export.LastError = ""
+	if runErr != nil {
+		export.LastError = runErr.Error()
+	}
+
+	if err := app.Dao().SaveScheduledExport(export); err != nil {
+		return err
+	}
+
+	return runErr
+}
+
+func (app *BaseApp) runScheduledExport(ctx context.Context, export *models.ScheduledExport) error {
+	collection, err := app.Dao().FindCollectionByNameOrId(export.CollectionId)
+	if err != nil {
+		return fmt.Errorf("failed to find the export collection: %w", err)
+	}
+
+	records, err := app.findScheduledExportRecords(collection, export)
+	if err != nil {
+		return err
+	}
+
+	var content []byte
+	switch export.Format {
+	case models.ScheduledExportFormatJson:
+		content, err = exportRecordsJson(records)
+	case models.ScheduledExportFormatXlsx:
+		buf := new(bytes.Buffer)
+		err = app.exportRecordsXlsx(buf, collection, records)
+		content = buf.Bytes()
+	case models.ScheduledExportFormatParquet:
+		buf := new(bytes.Buffer)
+		err = exportRecordsParquet(buf, collection, records)
+		content = buf.Bytes()
+	default:
+		content, err = exportRecordsCsv(collection, records)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	fs, err := app.NewFilesystem()
+	if err != nil {
+		return err
+	}
+	defer fs.Close()
+
+	name := export.Name + "_" + time.Now().UTC().Format("20060102150405") + export.FileExtension()
+	key := export.StoragePrefix() + "/" + name
+
+	if err := fs.Upload(content, key); err != nil {
+		return fmt.Errorf("failed to upload the export file: %w", err)
+	}
+
+	export.LastFile = key
+
+	if export.Destination != models.ScheduledExportDestinationEmail {
+		return nil
+	}
+
+	link, err := fs.SignedURL(key, ScheduledExportLinkExpiry, "attachment; filename="+name, "")
+	if errors.Is(err, filesystem.ErrSignedURLNotSupported) {
+		// fallback to the admin only export file download endpoint
+		link = strings.TrimRight(app.Settings().Meta.AppUrl, "/") +
+			"/api/scheduled-exports/" + export.Id + "/files/" + name
+	} else if err != nil {
+		return err
+	}
+
+	event := &ScheduledExportDeliveryEvent{
+		App:     app,
+		Export:  export,
+		FileKey: key,
+		Link:    link,
+	}
+
+	return app.OnScheduledExportDelivery().Trigger(event, func(e *ScheduledExportDeliveryEvent) error {
+		return app.sendScheduledExportEmail(e.Export, e.Link)
+	})
+}
+
+func (app *BaseApp) findScheduledExportRecords(
+	collection *models.Collection,
+	export *models.ScheduledExport,
+) ([]*models.Record, error) {
+	query := app.Dao().RecordQuery(collection)
+
+	resolver := resolvers.NewRecordFieldResolver(app.Dao(), collection, nil, true)
+
+	if export.Filter != "" {
+		expr, err := search.FilterData(export.Filter).BuildExpr(resolver)
+		if err != nil {
+			return nil, fmt.Errorf("invalid export filter: %w", err)
+		}
+		query.AndWhere(expr)
+	}
+
+	if export.Sort != "" {
+		for _, field := range search.ParseSortFromString(export.Sort) {
+			expr, err := field.BuildExpr(resolver)
+			if err != nil {
+				return nil, fmt.Errorf("invalid export sort: %w", err)
+			}
+			query.AndOrderBy(expr)
+		}
+	}
+
+	if err := resolver.UpdateQuery(query); err != nil {
+		return nil, err
+	}
+
+	records := []*models.Record{}
+	if err := query.All(&records); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+func exportRecordsJson(records []*models.Record) ([]byte, error) {
+	items := make([]map[string]any, len(records))
+	for i, record := range records {
+		record.IgnoreEmailVisibility(true)
+		items[i] = record.PublicExport()
+	}
+
+	return json.Marshal(items)
+}
+
+// recordExportColumns returns the exported record columns of the provided
+// collection (the system fields followed by the collection schema fields).
+func recordExportColumns(collection *models.Collection) []string {
+	columns := []string{schema.FieldNameId}
+	if !collection.IsView() {
+		columns = append(columns, schema.FieldNameCreated, schema.FieldNameUpdated)
+	}
+	if collection.IsAuth() {
+		columns = append(
+			columns,
+			schema.FieldNameUsername,
+			schema.FieldNameEmail,
+			schema.FieldNameEmailVisibility,
+			schema.FieldNameVerified,
+		)
+	}
+	for _, field := range collection.Schema.Fields() {
+		columns = append(columns, field.Name)
+	}
+
+	return columns
+}
+
+// recordExportColumnType returns the xlsx cell type of the provided record column.
+func recordExportColumnType(collection *models.Collection, column string) string {
+	switch column {
+	case schema.FieldNameCreated, schema.FieldNameUpdated:
+		return xlsx.TypeDate
+	case schema.FieldNameEmailVisibility, schema.FieldNameVerified:
+		if collection.IsAuth() {
+			return xlsx.TypeBool
+		}
+	}
+
+	field := collection.Schema.GetFieldByName(column)
+	if field == nil {
+		return xlsx.TypeString
+	}
+
+	switch field.Type {
+	case schema.FieldTypeNumber:
+		return xlsx.TypeNumber
+	case schema.FieldTypeBool:
+		return xlsx.TypeBool
+	case schema.FieldTypeDate:
+		return xlsx.TypeDate
+	}
+
+	return xlsx.TypeString
+}
+
+func exportRecordsCsv(collection *models.Collection, records []*models.Record) ([]byte, error) {
+	columns := recordExportColumns(collection)
+
+	buf := new(bytes.Buffer)
+	w := csv.NewWriter(buf)
+
+	if err := w.Write(columns); err != nil {
+		return nil, err
+	}
+
+	row := make([]string, len(columns))
+	for _, record := range records {
+		record.IgnoreEmailVisibility(true)
+		data := record.PublicExport()
+
+		for i, column := range columns {
+			value, err := csvValue(data[column])
+			if err != nil {
+				return nil, err
+			}
+			row[i] = value
+		}
+
+		if err := w.Write(row); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+
+	return buf.Bytes(), w.Error()
+}
+
+// exportRecordsXlsx writes the provided records in a xlsx spreadsheet
+// with type-aware columns.
+//
+// The records are written in a sheet named after their collection,
+// followed by a sheet per relation field with the expanded related
+// records (the first column is the id of the parent record).
+func (app *BaseApp) exportRecordsXlsx(
+	w io.Writer,
+	collection *models.Collection,
+	records []*models.Record,
+) error {
+	xw := xlsx.NewWriter(w)
+
+	if err := writeRecordsSheet(xw, collection.Name, collection, records, ""); err != nil {
+		return err
+	}
+
+	relFields := []*schema.SchemaField{}
+	for _, field := range collection.Schema.Fields() {
+		if field.Type == schema.FieldTypeRelation {
+			relFields = append(relFields, field)
+		}
+	}
+
+	if len(relFields) > 0 && len(records) > 0 {
+		expands := make([]string, len(relFields))
+		for i, field := range relFields {
+			expands[i] = field.Name
+		}
+
+		failed := app.Dao().ExpandRecords(records, expands, func(relCollection *models.Collection, relIds []string) ([]*models.Record, error) {
+			return app.Dao().FindRecordsByIds(relCollection.Id, relIds)
+		})
+		for expand, err := range failed {
+			return fmt.Errorf("failed to expand %q: %w", expand, err)
+		}
+	}
+
+	for _, field := range relFields {
+		field.InitOptions()
+		options, _ := field.Options.(*schema.RelationOptions)
+		if options == nil {
+			continue
+		}
+
+		relCollection, err := app.Dao().FindCollectionByNameOrId(options.CollectionId)
+		if err != nil {
+			return fmt.Errorf("failed to find the %q related collection: %w", field.Name, err)
+		}
+
+		parentColumn := collection.Name + "." + schema.FieldNameId
+
+		if err := writeRecordsSheet(xw, field.Name, relCollection, records, parentColumn); err != nil {
+			return err
+		}
+	}
+
+	return xw.Close()
+}
+
+// writeRecordsSheet adds a new records sheet to the provided xlsx writer.
+//
+// If parentColumn is set, the sheet rows are the expanded field records
+// (the sheet name) of the provided records prefixed with their parent id.
+func writeRecordsSheet(
+	xw *xlsx.Writer,
+	name string,
+	collection *models.Collection,
+	records []*models.Record,
+	parentColumn string,
+) error {
+	columns := recordExportColumns(collection)
+
+	sheetColumns := make([]xlsx.Column, 0, len(columns)+1)
+	if parentColumn != "" {
+		sheetColumns = append(sheetColumns, xlsx.Column{Name: parentColumn})
+	}
+	for _, column := range columns {
+		sheetColumns = append(sheetColumns, xlsx.Column{
+			Name: column,
+			Type: recordExportColumnType(collection, column),
+		})
+	}
+
+	sheet, err := xw.AddSheet(name, sheetColumns)
+	if err != nil {
+		return err
+	}
+
+	row := make([]any, len(sheetColumns))
+
+	writeRecord := func(parentId string, record *models.Record) error {
+		record.IgnoreEmailVisibility(true)
+		data := record.PublicExport()
+
+		offset := 0
+		if parentColumn != "" {
+			row[0] = parentId
+			offset = 1
+		}
+		for i, column := range columns {
+			row[offset+i] = data[column]
+		}
+
+		return sheet.WriteRow(row...)
+	}
+
+	for _, record := range records {
+		if parentColumn == "" {
+			if err := writeRecord("", record); err != nil {
+				return err
+			}
+			continue
+		}
+
+		var expanded []*models.Record
+		switch v := record.Expand()[name].(type) {
+		case *models.Record:
+			expanded = []*models.Record{v}
+		case []*models.Record:
+			expanded = v
+		}
+
+		for _, rel := range expanded {
+			if err := writeRecord(record.Id, rel); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// recordExportParquetTypes maps the record export xlsx column
+// types to their Parquet column type.
+var recordExportParquetTypes = map[string]string{
+	xlsx.TypeString: parquet.TypeString,
+	xlsx.TypeNumber: parquet.TypeDouble,
+	xlsx.TypeBool:   parquet.TypeBool,
+	xlsx.TypeDate:   parquet.TypeTimestamp,
+}
+
+// exportRecordsParquet writes the provided records in a Parquet file
+// with a schema derived from the collection fields.
+//
+// The non-scalar field values (multiple select, relation and file values
+// and the json fields) are stored as json encoded strings.
+func exportRecordsParquet(w io.Writer, collection *models.Collection, records []*models.Record) error {
+	columns := recordExportColumns(collection)
+
+	fileColumns := make([]parquet.Column, len(columns))
+	for i, column := range columns {
+		fileColumns[i] = parquet.Column{
+			Name: column,
+			Type: recordExportParquetTypes[recordExportColumnType(collection, column)],
+		}
+	}
+
+	pw, err := parquet.NewWriter(w, fileColumns)
+	if err != nil {
+		return err
+	}
+
+	row := make([]any, len(columns))
+	for _, record := range records {
+		record.IgnoreEmailVisibility(true)
+		data := record.PublicExport()
+
+		for i, column := range columns {
+			row[i] = data[column]
+		}
+
+		if err := pw.WriteRow(row...); err != nil {
+			return err
+		}
+	}
+
+	return pw.Close()
+}
+
+// csvValue normalizes the provided record value into a single csv cell
+// (non-scalar values are json encoded).
+func csvValue(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string, bool, int, int64, float64:
+		return cast.ToString(v), nil
+	case fmt.Stringer:
+		return v.String(), nil
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	return string(raw), nil
+}
+
+// sendScheduledExportEmail sends the export file link to the export emails.
+func (app *BaseApp) sendScheduledExportEmail(export *models.ScheduledExport, link string) error {
+	if len(export.Emails) == 0 {
+		return nil // no recipients
+	}
+
+	to := make([]mail.Address, len(export.Emails))
+	for i, email := range export.Emails {
+		to[i] = mail.Address{Address: email}
+	}
+
+	var body strings.Builder
+	body.WriteString("<p>The ")
+	body.WriteString(html.EscapeString(export.Name))
+	body.WriteString(" export is ready and could be downloaded from the link below:</p><p><a href=\"")
+	body.WriteString(html.EscapeString(link))
+	body.WriteString("\" target=\"_blank\" rel=\"noopener\">")
+	body.WriteString(html.EscapeString(link))
+	body.WriteString("</a></p>")
+
+	return app.NewMailClient().Send(&mailer.Message{
+		From: mail.Address{
+			Name:    app.Settings().Meta.SenderName,
+			Address: app.Settings().Meta.SenderAddress,
+		},
+		To:      to,
+		Subject: fmt.Sprintf("%s %s export", app.Settings().Meta.AppName, export.Name),
+		HTML:    body.String(),
+	})
+}
@@ -0,0 +1,273 @@
+package core_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/hook"
+)
+
+func TestRunScheduledExportCsv(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	export, err := app.Dao().FindScheduledExportByName("demo2_active")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.RunScheduledExport(context.Background(), export); err != nil {
+		t.Fatal(err)
+	}
+
+	if export.LastRun.IsZero() || export.LastError != "" {
+		t.Fatalf("Expected successful run, got %v (%q)", export.LastRun, export.LastError)
+	}
+
+	if !strings.HasPrefix(export.LastFile, "exports/demo2/demo2_active_") || !strings.HasSuffix(export.LastFile, ".csv") {
+		t.Fatalf("Unexpected export file %q", export.LastFile)
+	}
+
+	content := readStorageFile(t, app, export.LastFile)
+
+	expectedLines := []string{
+		"id,created,updated,title,active",
+		"achvryl401bhse3,",
+		"0yxhwia2amd8gec,",
+	}
+	lines := strings.Split(strings.TrimSpace(content), "\n")
+	if len(lines) != len(expectedLines) {
+		t.Fatalf("Expected %d lines, got \n%s", len(expectedLines), content)
+	}
+	for i, line := range expectedLines {
+		if !strings.HasPrefix(lines[i], line) {
+			t.Fatalf("Expected line %d to start with %q, got %q", i, line, lines[i])
+		}
+	}
+
+	if app.TestMailer.TotalSend != 0 {
+		t.Fatalf("Expected no emails to be sent, got %d", app.TestMailer.TotalSend)
+	}
+
+	// check whether the run result was persisted
+	saved, err := app.Dao().FindScheduledExportById(export.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.LastFile != export.LastFile {
+		t.Fatalf("Expected the last file %q to be saved, got %q", export.LastFile, saved.LastFile)
+	}
+}
+
+func TestRunScheduledExportJsonWithEmail(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	export, err := app.Dao().FindScheduledExportByName("demo2_weekly")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var delivery *core.ScheduledExportDeliveryEvent
+	app.OnScheduledExportDelivery().Add(func(e *core.ScheduledExportDeliveryEvent) error {
+		delivery = e
+		return hook.StopPropagation // prevent the default email send
+	})
+
+	if err := app.RunScheduledExport(context.Background(), export); err != nil {
+		t.Fatal(err)
+	}
+
+	content := readStorageFile(t, app, export.LastFile)
+
+	items := []map[string]any{}
+	if err := json.Unmarshal([]byte(content), &items); err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("Expected 3 exported records, got %d", len(items))
+	}
+
+	if delivery == nil {
+		t.Fatal("Expected OnScheduledExportDelivery to be triggered")
+	}
+
+	if delivery.Export.Id != export.Id || delivery.FileKey != export.LastFile {
+		t.Fatalf("Unexpected delivery export %q and file %q", delivery.Export.Id, delivery.FileKey)
+	}
+
+	// local storage doesn't support pre-signed urls
+	name := export.LastFile[strings.LastIndex(export.LastFile, "/")+1:]
+	expectedLink := "/api/scheduled-exports/" + export.Id + "/files/" + name
+	if !strings.HasSuffix(delivery.Link, expectedLink) {
+		t.Fatalf("Expected link ending with %q, got %q", expectedLink, delivery.Link)
+	}
+}
+
+func TestRunScheduledExportXlsx(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	export, err := app.Dao().FindScheduledExportByName("demo2_active")
+	if err != nil {
+		t.Fatal(err)
+	}
+	export.CollectionId = "demo5"
+	export.Filter = ""
+	export.Sort = "created"
+	export.Format = "xlsx"
+
+	if err := app.RunScheduledExport(context.Background(), export); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasSuffix(export.LastFile, ".xlsx") {
+		t.Fatalf("Unexpected export file %q", export.LastFile)
+	}
+
+	content := []byte(readStorageFile(t, app, export.LastFile))
+
+	zr, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		raw, _ := io.ReadAll(rc)
+		rc.Close()
+		files[f.Name] = string(raw)
+	}
+
+	expectedSheets := []string{
+		`<sheet name="demo5" sheetId="1" r:id="rId1"/>`,
+		`<sheet name="rel_one" sheetId="2" r:id="rId2"/>`,
+		`<sheet name="rel_many" sheetId="3" r:id="rId3"/>`,
+	}
+	for _, sheet := range expectedSheets {
+		if !strings.Contains(files["xl/workbook.xml"], sheet) {
+			t.Fatalf("Expected %s in \n%s", sheet, files["xl/workbook.xml"])
+		}
+	}
+
+	// type-aware columns (date created and number total)
+	expectedCells := []string{
+		`<c r="B2" s="1"><v>44933.`,
+		`<c r="H2"><v>0</v></c>`,
+		`<c r="H3"><v>2</v></c>`,
+	}
+	for _, cell := range expectedCells {
+		if !strings.Contains(files["xl/worksheets/sheet1.xml"], cell) {
+			t.Fatalf("Expected %s in \n%s", cell, files["xl/worksheets/sheet1.xml"])
+		}
+	}
+
+	// expanded relations prefixed with the parent id
+	expectedRelations := map[string][]string{
+		"xl/worksheets/sheet2.xml": {
+			`<t xml:space="preserve">demo5.id</t>`,
+			`<c r="A2" t="inlineStr"><is><t xml:space="preserve">qjeql998mtp1azp</t></is></c><c r="B2" t="inlineStr"><is><t xml:space="preserve">i9naidtvr6qsgb4</t>`,
+		},
+		"xl/worksheets/sheet3.xml": {
+			`<c r="A2" t="inlineStr"><is><t xml:space="preserve">qjeql998mtp1azp</t></is></c><c r="B2" t="inlineStr"><is><t xml:space="preserve">qzaqccwrmva4o1n</t>`,
+			`<c r="A3" t="inlineStr"><is><t xml:space="preserve">qjeql998mtp1azp</t></is></c><c r="B3" t="inlineStr"><is><t xml:space="preserve">i9naidtvr6qsgb4</t>`,
+		},
+	}
+	for file, items := range expectedRelations {
+		for _, item := range items {
+			if !strings.Contains(files[file], item) {
+				t.Fatalf("Expected %s in %s \n%s", item, file, files[file])
+			}
+		}
+	}
+}
+
+func TestRunScheduledExportParquet(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	export, err := app.Dao().FindScheduledExportByName("demo2_active")
+	if err != nil {
+		t.Fatal(err)
+	}
+	export.CollectionId = "demo5"
+	export.Filter = ""
+	export.Sort = ""
+	export.Format = "parquet"
+
+	if err := app.RunScheduledExport(context.Background(), export); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasSuffix(export.LastFile, ".parquet") {
+		t.Fatalf("Unexpected export file %q", export.LastFile)
+	}
+
+	content := readStorageFile(t, app, export.LastFile)
+
+	if !strings.HasPrefix(content, "PAR1") || !strings.HasSuffix(content, "PAR1") {
+		t.Fatal("Expected a parquet file")
+	}
+
+	// the schema column names are stored uncompressed in the file footer
+	for _, column := range []string{"id", "created", "updated", "select_one", "select_many", "rel_one", "rel_many", "total", "file"} {
+		if !strings.Contains(content, column) {
+			t.Fatalf("Missing column %q", column)
+		}
+	}
+}
+
+func TestRunScheduledExportWithInvalidFilter(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	export, err := app.Dao().FindScheduledExportByName("demo2_active")
+	if err != nil {
+		t.Fatal(err)
+	}
+	export.Filter = "missing = 1"
+
+	if err := app.RunScheduledExport(context.Background(), export); err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+
+	saved, err := app.Dao().FindScheduledExportById(export.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.LastError == "" || saved.LastRun.IsZero() {
+		t.Fatalf("Expected the failed run to be saved, got %v (%q)", saved.LastRun, saved.LastError)
+	}
+}
+
+func readStorageFile(t *testing.T, app *tests.TestApp, key string) string {
+	fs, err := app.NewFilesystem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fs.Close()
+
+	r, err := fs.GetFile(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(raw)
+}
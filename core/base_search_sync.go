@@ -0,0 +1,144 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/routine"
+)
+
+// searchSyncBatchSize is the max number of records pushed to the
+// external search engine with a single request during reindex.
+const searchSyncBatchSize = 500
+
+// searchSyncTimeout is the max duration of a single background record sync.
+const searchSyncTimeout = 30 * time.Second
+
+// ReindexSearchCollection clears the external search engine index of
+// the provided collection and pushes all of its records in batches.
+//
+// Returns the total number of indexed records.
+//
+// It returns an error if the search sync is not enabled in app.Settings().SearchSync.
+func (app *BaseApp) ReindexSearchCollection(ctx context.Context, collection *models.Collection) (int, error) {
+	config := app.Settings().SearchSync
+	if !config.Enabled {
+		return 0, errors.New("the search sync is not enabled")
+	}
+
+	indexer, err := app.NewSearchIndexer()
+	if err != nil {
+		return 0, err
+	}
+
+	index := config.IndexName(collection.Name)
+
+	if err := indexer.Clear(ctx, index); err != nil {
+		return 0, err
+	}
+
+	var total int
+
+	for offset := 0; ; offset += searchSyncBatchSize {
+		records := []*models.Record{}
+
+		err := app.Dao().RecordQuery(collection).
+			OrderBy("rowid ASC").
+			Offset(int64(offset)).
+			Limit(searchSyncBatchSize).
+			All(&records)
+		if err != nil {
+			return total, err
+		}
+
+		if len(records) == 0 {
+			break
+		}
+
+		docs := make([]map[string]any, len(records))
+		for i, r := range records {
+			docs[i] = r.PublicExport()
+		}
+
+		if err := indexer.Upsert(ctx, index, docs); err != nil {
+			return total, err
+		}
+
+		total += len(records)
+
+		if len(records) < searchSyncBatchSize {
+			break
+		}
+	}
+
+	return total, nil
+}
+
+// initSearchSyncHooks registers the record model hooks that push
+// the changes of the SearchSync collections to the external search engine.
+//
+// The sync is performed in the background, so failures are only logged in debug.
+func (app *BaseApp) initSearchSyncHooks() {
+	syncRecord := func(record *models.Record, isDelete bool) {
+		config := app.Settings().SearchSync
+		if !config.IsSynced(record.Collection().Name) {
+			return
+		}
+
+		index := config.IndexName(record.Collection().Name)
+		id := record.Id
+
+		var doc map[string]any
+		if !isDelete {
+			doc = record.PublicExport()
+		}
+
+		routine.FireAndForget(func() {
+			indexer, err := app.NewSearchIndexer()
+			if err != nil {
+				if app.IsDebug() {
+					log.Println(err)
+				}
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), searchSyncTimeout)
+			defer cancel()
+
+			if isDelete {
+				err = indexer.Delete(ctx, index, []string{id})
+			} else {
+				err = indexer.Upsert(ctx, index, []map[string]any{doc})
+			}
+
+			if err != nil && app.IsDebug() {
+				// non critical error - only log for debug
+				log.Println(err)
+			}
+		})
+	}
+
+	app.OnModelAfterCreate().Add(func(e *ModelEvent) error {
+		if record, ok := e.Model.(*models.Record); ok {
+			syncRecord(record, false)
+		}
+		return nil
+	})
+
+	app.OnModelAfterUpdate().Add(func(e *ModelEvent) error {
+		if record, ok := e.Model.(*models.Record); ok {
+			syncRecord(record, false)
+		}
+		return nil
+	})
+
+	app.OnModelAfterDelete().Add(func(e *ModelEvent) error {
+		if record, ok := e.Model.(*models.Record); ok {
+			syncRecord(record, true)
+		}
+		return nil
+	})
+}
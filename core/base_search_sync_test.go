@@ -0,0 +1,144 @@
+package core_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/egress"
+)
+
+func TestReindexSearchCollection(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	requests := []string{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	collection, err := app.Dao().FindCollectionByNameOrId("demo1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// disabled search sync
+	if _, err := app.ReindexSearchCollection(context.Background(), collection); err == nil {
+		t.Fatal("Expected disabled search sync error, got nil")
+	}
+
+	app.Settings().SearchSync.Enabled = true
+	app.Settings().SearchSync.Provider = "meilisearch"
+	app.Settings().SearchSync.Host = server.URL
+	app.Settings().SearchSync.IndexPrefix = "test_"
+
+	// the local test server is denied by the default egress policy
+	if _, err := app.ReindexSearchCollection(context.Background(), collection); !errors.Is(err, egress.ErrDestinationNotAllowed) {
+		t.Fatalf("Expected egress.ErrDestinationNotAllowed, got %v", err)
+	}
+
+	if len(requests) != 0 {
+		t.Fatalf("Expected no requests, got %v", requests)
+	}
+
+	app.Settings().Egress.Subsystems = map[string]settings.EgressPolicyConfig{
+		settings.EgressSubsystemSearchSync: {AllowPrivate: true},
+	}
+
+	total, err := app.ReindexSearchCollection(context.Background(), collection)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if total != 3 {
+		t.Fatalf("Expected 3 indexed records, got %d", total)
+	}
+
+	expected := []string{
+		"DELETE /indexes/test_demo1/documents",
+		"POST /indexes/test_demo1/documents",
+	}
+
+	if strings.Join(requests, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("Expected requests %v, got %v", expected, requests)
+	}
+}
+
+func TestSearchSyncHooks(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	requests := make(chan string, 10)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- r.Method + " " + r.URL.Path + " " + string(body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	app.Settings().SearchSync.Enabled = true
+	app.Settings().SearchSync.Provider = "meilisearch"
+	app.Settings().SearchSync.Host = server.URL
+	app.Settings().SearchSync.Collections = []string{"demo2"}
+	app.Settings().Egress.Subsystems = map[string]settings.EgressPolicyConfig{
+		settings.EgressSubsystemSearchSync: {AllowPrivate: true},
+	}
+
+	waitRequest := func() string {
+		select {
+		case r := <-requests:
+			return r
+		case <-time.After(2 * time.Second):
+			return ""
+		}
+	}
+
+	// non synced collection
+	demo1Record, err := app.Dao().FindRecordById("demo1", "al1h9ijdeojtsjy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := app.Dao().SaveRecord(demo1Record); err != nil {
+		t.Fatal(err)
+	}
+
+	// synced collection
+	record, err := app.Dao().FindRecordById("demo2", "0yxhwia2amd8gec")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record.Set("title", "search_sync_test")
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	upsert := waitRequest()
+	if !strings.HasPrefix(upsert, "POST /indexes/demo2/documents ") || !strings.Contains(upsert, `"title":"search_sync_test"`) {
+		t.Fatalf("Expected demo2 upsert request, got %q", upsert)
+	}
+
+	if err := app.Dao().DeleteRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	del := waitRequest()
+	if del != `POST /indexes/demo2/documents/delete-batch ["0yxhwia2amd8gec"]` {
+		t.Fatalf("Expected demo2 delete request, got %q", del)
+	}
+
+	if r := waitRequest(); r != "" {
+		t.Fatalf("Expected no more requests, got %q", r)
+	}
+}
@@ -0,0 +1,296 @@
+package core
+
+import (
+	"fmt"
+	"html"
+	"log"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/cron"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/mailer"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// List of the security report alert types.
+const (
+	SecurityAlertAuthFailures   = "auth_failures"
+	SecurityAlertForbiddenSpike = "forbidden_spike"
+	SecurityAlertNewCountry     = "new_country"
+)
+
+// securityReportIpsLimit is the max number of IPs listed in a single report section.
+const securityReportIpsLimit = 20
+
+// SecurityAlert defines a single security report alert.
+type SecurityAlert struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// SecurityReport defines the brute-force and anomaly detection report
+// aggregated from the request logs.
+type SecurityReport struct {
+	From types.DateTime `json:"from"`
+	To   types.DateTime `json:"to"`
+
+	// AuthFailures lists the IPs with the most failed auth attempts.
+	AuthFailures []*daos.RequestsIpStatsItem `json:"authFailures"`
+
+	// Forbidden lists the IPs with the most 403 responses.
+	Forbidden []*daos.RequestsIpStatsItem `json:"forbidden"`
+
+	// ForbiddenTotal is the number of all 403 responses in the report period.
+	ForbiddenTotal int `json:"forbiddenTotal"`
+
+	// ForbiddenBaseline is the average number of 403 responses
+	// per report period during the baseline days.
+	ForbiddenBaseline float64 `json:"forbiddenBaseline"`
+
+	// Countries lists the client countries in the report period
+	// (available only if SecurityReportConfig.GeoHeader is set).
+	Countries []*daos.RequestsCountryStatsItem `json:"countries"`
+
+	// NewCountries lists the client countries that were
+	// not seen during the baseline days.
+	NewCountries []string `json:"newCountries"`
+
+	Alerts []*SecurityAlert `json:"alerts"`
+}
+
+// SecurityReport aggregates the failed auth attempts, the 403 responses
+// and the client countries from the request logs of the last window period
+// and compares them with the configured thresholds and baseline days.
+func (app *BaseApp) SecurityReport(window time.Duration) (*SecurityReport, error) {
+	config := app.Settings().SecurityReport
+
+	if window <= 0 {
+		window = time.Duration(config.Window) * time.Second
+	}
+
+	now := time.Now().UTC()
+	from := now.Add(-window)
+	baselineFrom := from.AddDate(0, 0, -config.BaselineDays)
+
+	report := &SecurityReport{
+		NewCountries: []string{},
+		Alerts:       []*SecurityAlert{},
+	}
+	report.From, _ = types.ParseDateTime(from)
+	report.To, _ = types.ParseDateTime(now)
+
+	periodExpr := dbx.NewExp("[[created]] >= {:from}", dbx.Params{
+		"from": from.Format(types.DefaultDateLayout),
+	})
+	baselineExpr := dbx.NewExp("[[created]] >= {:from} AND [[created]] < {:to}", dbx.Params{
+		"from": baselineFrom.Format(types.DefaultDateLayout),
+		"to":   from.Format(types.DefaultDateLayout),
+	})
+	authFailureExpr := dbx.NewExp("[[status]] >= 400 AND [[url]] LIKE '%/auth-with-%'")
+	forbiddenExpr := dbx.HashExp{"status": 403}
+
+	var err error
+
+	// failed auth attempts
+	// ---
+	report.AuthFailures, err = app.LogsDao().RequestsIpStats(dbx.And(periodExpr, authFailureExpr), securityReportIpsLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range report.AuthFailures {
+		if item.Total >= config.AuthFailuresThreshold {
+			report.Alerts = append(report.Alerts, &SecurityAlert{
+				Type:    SecurityAlertAuthFailures,
+				Message: fmt.Sprintf("%d failed auth attempts from %s.", item.Total, item.Ip),
+			})
+		}
+	}
+
+	// 403 spikes
+	// ---
+	report.Forbidden, err = app.LogsDao().RequestsIpStats(dbx.And(periodExpr, forbiddenExpr), securityReportIpsLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	report.ForbiddenTotal, err = app.LogsDao().RequestsCount(dbx.And(periodExpr, forbiddenExpr))
+	if err != nil {
+		return nil, err
+	}
+
+	if config.BaselineDays > 0 {
+		baselineTotal, err := app.LogsDao().RequestsCount(dbx.And(baselineExpr, forbiddenExpr))
+		if err != nil {
+			return nil, err
+		}
+
+		periods := from.Sub(baselineFrom).Seconds() / window.Seconds()
+		report.ForbiddenBaseline = float64(baselineTotal) / periods
+	}
+
+	if report.ForbiddenTotal >= config.ForbiddenThreshold &&
+		float64(report.ForbiddenTotal) >= 2*report.ForbiddenBaseline {
+		report.Alerts = append(report.Alerts, &SecurityAlert{
+			Type: SecurityAlertForbiddenSpike,
+			Message: fmt.Sprintf(
+				"%d forbidden responses (baseline %.2f).",
+				report.ForbiddenTotal,
+				report.ForbiddenBaseline,
+			),
+		})
+	}
+
+	// unusual countries
+	// ---
+	report.Countries, err = app.LogsDao().RequestsCountryStats(periodExpr)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.BaselineDays > 0 && len(report.Countries) > 0 {
+		baselineCountries, err := app.LogsDao().RequestsCountryStats(baselineExpr)
+		if err != nil {
+			return nil, err
+		}
+
+		// there is nothing to compare with (eg. the geo header was just configured)
+		if len(baselineCountries) > 0 {
+			known := make([]string, len(baselineCountries))
+			for i, item := range baselineCountries {
+				known[i] = item.Country
+			}
+
+			for _, item := range report.Countries {
+				if list.ExistInSlice(item.Country, known) {
+					continue
+				}
+
+				report.NewCountries = append(report.NewCountries, item.Country)
+				report.Alerts = append(report.Alerts, &SecurityAlert{
+					Type:    SecurityAlertNewCountry,
+					Message: fmt.Sprintf("%d requests from a new country %s.", item.Total, item.Country),
+				})
+			}
+		}
+	}
+
+	return report, nil
+}
+
+// initSecurityReportHooks registers the app serve hooks
+// that periodically check the security report for alerts.
+func (app *BaseApp) initSecurityReportHooks() error {
+	c := cron.New()
+	isServe := false
+
+	loadJob := func() {
+		c.Stop()
+		c.RemoveAll()
+
+		rawSchedule := app.Settings().SecurityReport.NotifyCron
+		if rawSchedule == "" || !isServe || !app.IsBootstrapped() {
+			return
+		}
+
+		err := c.Add("@securityReport", rawSchedule, func() {
+			report, err := app.SecurityReport(0)
+			if err != nil {
+				if app.IsDebug() {
+					// @todo replace after logs generalization
+					log.Println(err)
+				}
+				return
+			}
+
+			if len(report.Alerts) == 0 {
+				return // nothing to notify
+			}
+
+			event := &SecurityAlertEvent{App: app, Report: report}
+
+			err = app.OnSecurityAlert().Trigger(event, func(e *SecurityAlertEvent) error {
+				return app.sendSecurityAlertEmail(e.Report)
+			})
+			if err != nil && app.IsDebug() {
+				// @todo replace after logs generalization
+				log.Println(err)
+			}
+		})
+		if err != nil && app.IsDebug() {
+			log.Println(err)
+			return
+		}
+
+		// restart the ticker
+		c.Start()
+	}
+
+	// load on app serve
+	app.OnBeforeServe().Add(func(e *ServeEvent) error {
+		isServe = true
+		loadJob()
+		return nil
+	})
+
+	// stop the ticker on app termination
+	app.OnTerminate().Add(func(e *TerminateEvent) error {
+		c.Stop()
+		return nil
+	})
+
+	// reload on app settings change
+	app.OnModelAfterUpdate((&models.Param{}).TableName()).Add(func(e *ModelEvent) error {
+		p := e.Model.(*models.Param)
+		if p == nil || p.Key != models.ParamAppSettings {
+			return nil
+		}
+
+		loadJob()
+
+		return nil
+	})
+
+	return nil
+}
+
+// sendSecurityAlertEmail sends the report alerts to the configured notify emails.
+func (app *BaseApp) sendSecurityAlertEmail(report *SecurityReport) error {
+	emails := app.Settings().SecurityReport.NotifyEmails
+	if len(emails) == 0 {
+		return nil // no recipients
+	}
+
+	to := make([]mail.Address, len(emails))
+	for i, email := range emails {
+		to[i] = mail.Address{Address: email}
+	}
+
+	var body strings.Builder
+	body.WriteString("<p>The following security alerts were detected between ")
+	body.WriteString(html.EscapeString(report.From.String()))
+	body.WriteString(" and ")
+	body.WriteString(html.EscapeString(report.To.String()))
+	body.WriteString(":</p><ul>")
+	for _, alert := range report.Alerts {
+		body.WriteString("<li>")
+		body.WriteString(html.EscapeString(alert.Message))
+		body.WriteString("</li>")
+	}
+	body.WriteString("</ul>")
+
+	return app.NewMailClient().Send(&mailer.Message{
+		From: mail.Address{
+			Name:    app.Settings().Meta.SenderName,
+			Address: app.Settings().Meta.SenderAddress,
+		},
+		To:      to,
+		Subject: fmt.Sprintf("%s security alerts", app.Settings().Meta.AppName),
+		HTML:    body.String(),
+	})
+}
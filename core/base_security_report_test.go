@@ -0,0 +1,122 @@
+package core_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestSecurityReport(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	app.Settings().SecurityReport.BaselineDays = 1
+	app.Settings().SecurityReport.AuthFailuresThreshold = 3
+	app.Settings().SecurityReport.ForbiddenThreshold = 2
+
+	now := time.Now()
+
+	logs := []struct {
+		url     string
+		status  int
+		ip      string
+		country string
+		created time.Time
+	}{
+		// baseline
+		{"/api/test", 403, "1.1.1.1", "BG", now.Add(-5 * time.Hour)},
+		{"/api/test", 200, "1.1.1.1", "US", now.Add(-5 * time.Hour)},
+		// report period
+		{"/api/admins/auth-with-password", 400, "2.2.2.2", "BG", now.Add(-10 * time.Minute)},
+		{"/api/admins/auth-with-password", 400, "2.2.2.2", "BG", now.Add(-10 * time.Minute)},
+		{"/api/collections/users/auth-with-password", 400, "2.2.2.2", "BG", now.Add(-10 * time.Minute)},
+		{"/api/collections/users/auth-with-password", 400, "3.3.3.3", "DE", now.Add(-10 * time.Minute)},
+		{"/api/collections/users/auth-with-password", 200, "3.3.3.3", "DE", now.Add(-10 * time.Minute)},
+		{"/api/test", 403, "3.3.3.3", "DE", now.Add(-10 * time.Minute)},
+		{"/api/test", 403, "4.4.4.4", "", now.Add(-10 * time.Minute)},
+	}
+
+	for _, l := range logs {
+		request := &models.Request{
+			Url:    l.url,
+			Status: l.status,
+			UserIp: l.ip,
+			Meta:   types.JsonMap{"country": l.country},
+		}
+		request.Created, _ = types.ParseDateTime(l.created)
+
+		if err := app.LogsDao().SaveRequest(request); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	report, err := app.SecurityReport(time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(report.AuthFailures) != 2 ||
+		report.AuthFailures[0].Ip != "2.2.2.2" || report.AuthFailures[0].Total != 3 ||
+		report.AuthFailures[1].Ip != "3.3.3.3" || report.AuthFailures[1].Total != 1 {
+		t.Fatalf("Unexpected auth failures %v", report.AuthFailures)
+	}
+
+	if len(report.Forbidden) != 2 || report.ForbiddenTotal != 2 {
+		t.Fatalf("Expected 2 forbidden IPs and total, got %d and %d", len(report.Forbidden), report.ForbiddenTotal)
+	}
+
+	// 1 baseline 403 for 24 periods of 1 hour
+	if v := report.ForbiddenBaseline; v != 1.0/24 {
+		t.Fatalf("Expected forbidden baseline %v, got %v", 1.0/24, v)
+	}
+
+	if len(report.Countries) != 2 {
+		t.Fatalf("Expected 2 countries, got %v", report.Countries)
+	}
+
+	if len(report.NewCountries) != 1 || report.NewCountries[0] != "DE" {
+		t.Fatalf("Expected new countries [DE], got %v", report.NewCountries)
+	}
+
+	expectedAlerts := []string{
+		core.SecurityAlertAuthFailures,
+		core.SecurityAlertForbiddenSpike,
+		core.SecurityAlertNewCountry,
+	}
+	if len(report.Alerts) != len(expectedAlerts) {
+		t.Fatalf("Expected %d alerts, got %d", len(expectedAlerts), len(report.Alerts))
+	}
+	for i, alert := range report.Alerts {
+		if alert.Type != expectedAlerts[i] {
+			t.Errorf("(%d) Expected alert type %q, got %q", i, expectedAlerts[i], alert.Type)
+		}
+	}
+}
+
+func TestSecurityReportWithoutAlerts(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	tests.MockRequestLogsData(app)
+
+	report, err := app.SecurityReport(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if d := report.To.Time().Sub(report.From.Time()); d != time.Duration(app.Settings().SecurityReport.Window)*time.Second {
+		t.Fatalf("Expected the default settings window, got %v", d)
+	}
+
+	if len(report.AuthFailures) != 0 || len(report.Forbidden) != 0 || len(report.Countries) != 0 {
+		t.Fatalf("Expected empty report sections, got %v", report)
+	}
+
+	if len(report.Alerts) != 0 {
+		t.Fatalf("Expected no alerts, got %v", report.Alerts)
+	}
+}
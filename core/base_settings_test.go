@@ -1,6 +1,7 @@
 package core_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/pocketbase/pocketbase/models"
@@ -50,6 +51,37 @@ func TestBaseAppRefreshSettings(t *testing.T) {
 	testEventCalls(t, app, nil)
 }
 
+func TestBaseAppRefreshSettingsEnvOverrides(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	t.Setenv("PB_META_APP_NAME", "env_app")
+	t.Setenv("PB_SMTP_PORT", "2525")
+
+	app.ResetEventCalls()
+	if err := app.RefreshSettings(); err != nil {
+		t.Fatalf("Failed to refresh the app settings: %v", err)
+	}
+	testEventCalls(t, app, nil)
+
+	if app.Settings().Meta.AppName != "env_app" || app.Settings().Smtp.Port != 2525 {
+		t.Fatalf("Expected the env overrides to be applied, got %q and %d", app.Settings().Meta.AppName, app.Settings().Smtp.Port)
+	}
+
+	if locked := strings.Join(app.Settings().EnvLocked(), ","); locked != "meta.appName,smtp.port" {
+		t.Fatalf("Expected env locked fields %q, got %q", "meta.appName,smtp.port", locked)
+	}
+
+	// the env values must not be persisted
+	stored, err := app.Dao().FindSettings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Meta.AppName == "env_app" {
+		t.Fatal("Expected the env app name to not be persisted")
+	}
+}
+
 func testEventCalls(t *testing.T, app *tests.TestApp, events map[string]int) {
 	if len(events) != len(app.EventCalls) {
 		t.Fatalf("Expected events doesn't match: \n%v, \ngot \n%v", events, app.EventCalls)
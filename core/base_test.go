@@ -1,10 +1,18 @@
 package core
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tools/filesystem"
 	"github.com/pocketbase/pocketbase/tools/mailer"
+	"gocloud.dev/blob"
+	"gocloud.dev/blob/memblob"
 )
 
 func TestNewBaseApp(t *testing.T) {
@@ -184,6 +192,43 @@ func TestBaseAppGetters(t *testing.T) {
 	}
 }
 
+func TestBaseAppLogQuery(t *testing.T) {
+	app := NewBaseApp(&BaseAppConfig{
+		DataDir:       "./pb_base_app_test_data_dir/",
+		EncryptionEnv: "pb_test_env",
+	})
+
+	query := "SELECT * FROM `demo1` WHERE `id` = 'test'"
+
+	// disabled
+	app.Settings().SlowQueries.Enabled = false
+	app.Settings().SlowQueries.Threshold = 0
+	app.logQuery(time.Second, query)
+	if total := len(app.QueryStats().Slowest(10)); total != 0 {
+		t.Fatalf("Expected no tracked queries, got %d", total)
+	}
+
+	// enabled but below the threshold
+	app.Settings().SlowQueries.Enabled = true
+	app.Settings().SlowQueries.Threshold = 100
+	app.logQuery(99*time.Millisecond, query)
+	if total := len(app.QueryStats().Slowest(10)); total != 0 {
+		t.Fatalf("Expected no tracked queries below the threshold, got %d", total)
+	}
+
+	// query plan lookup
+	app.logQuery(time.Second, "EXPLAIN QUERY PLAN "+query)
+	if total := len(app.QueryStats().Slowest(10)); total != 0 {
+		t.Fatalf("Expected the query plan lookups to not be tracked, got %d", total)
+	}
+
+	// enabled and above the threshold
+	app.logQuery(100*time.Millisecond, query)
+	if total := len(app.QueryStats().Slowest(10)); total != 1 {
+		t.Fatalf("Expected 1 tracked query, got %d", total)
+	}
+}
+
 func TestBaseAppNewMailClient(t *testing.T) {
 	const testDataDir = "./pb_base_app_test_data_dir/"
 	defer os.RemoveAll(testDataDir)
@@ -235,6 +280,29 @@ func TestBaseAppNewFilesystem(t *testing.T) {
 	if s3 != nil {
 		t.Fatalf("Expected nil s3 filesystem, got %v", s3)
 	}
+
+	// registered driver (takes precedence over s3)
+	var driverOptions map[string]string
+	filesystem.RegisterDriver("test_NewFilesystem", func(ctx context.Context, options map[string]string) (*blob.Bucket, error) {
+		driverOptions = options
+		return memblob.OpenBucket(nil), nil
+	})
+	app.Settings().StorageDriver.Name = "test_NewFilesystem"
+	app.Settings().StorageDriver.Options = map[string]string{"bucket": "abc"}
+	driverFs, driverErr := app.NewFilesystem()
+	if driverErr != nil {
+		t.Fatal(driverErr)
+	}
+	defer driverFs.Close()
+	if driverOptions["bucket"] != "abc" {
+		t.Fatalf("Expected the driver to be called with the configured options, got %v", driverOptions)
+	}
+
+	// unregistered driver
+	app.Settings().StorageDriver.Name = "test_missing"
+	if _, err := app.NewFilesystem(); err == nil {
+		t.Fatal("Expected missing driver error, got nil")
+	}
 }
 
 func TestBaseAppNewBackupsFilesystem(t *testing.T) {
@@ -265,4 +333,79 @@ func TestBaseAppNewBackupsFilesystem(t *testing.T) {
 	if s3 != nil {
 		t.Fatalf("Expected nil s3 backups filesystem, got %v", s3)
 	}
+
+	// registered driver (takes precedence over s3)
+	var driverOptions map[string]string
+	filesystem.RegisterDriver("test_NewBackupsFilesystem", func(ctx context.Context, options map[string]string) (*blob.Bucket, error) {
+		driverOptions = options
+		return memblob.OpenBucket(nil), nil
+	})
+	app.Settings().Backups.Driver.Name = "test_NewBackupsFilesystem"
+	app.Settings().Backups.Driver.Options = map[string]string{"bucket": "abc"}
+	driverFs, driverErr := app.NewBackupsFilesystem()
+	if driverErr != nil {
+		t.Fatal(driverErr)
+	}
+	defer driverFs.Close()
+	if driverOptions["bucket"] != "abc" {
+		t.Fatalf("Expected the driver to be called with the configured options, got %v", driverOptions)
+	}
+
+	// unregistered driver
+	app.Settings().Backups.Driver.Name = "test_missing"
+	if _, err := app.NewBackupsFilesystem(); err == nil {
+		t.Fatal("Expected missing driver error, got nil")
+	}
+}
+
+func TestBaseAppNewHttpClient(t *testing.T) {
+	const testDataDir = "./pb_base_app_test_data_dir/"
+	defer os.RemoveAll(testDataDir)
+
+	app := NewBaseApp(&BaseAppConfig{
+		DataDir:       testDataDir,
+		EncryptionEnv: "pb_test_env",
+		IsDebug:       false,
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	app.Settings().Egress.Subsystems = map[string]settings.EgressPolicyConfig{
+		settings.EgressSubsystemS3: {AllowPrivate: true},
+	}
+
+	scenarios := []struct {
+		subsystem   string
+		expectError bool
+	}{
+		{settings.EgressSubsystemOAuth2, true},
+		{settings.EgressSubsystemSearchSync, true},
+		{settings.EgressSubsystemS3, false},
+	}
+
+	for _, s := range scenarios {
+		client, err := app.NewHttpClient(s.subsystem)
+		if err != nil {
+			t.Fatalf("(%s) Failed to create client: %v", s.subsystem, err)
+		}
+
+		res, err := client.Get(server.URL)
+		if res != nil {
+			res.Body.Close()
+		}
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("(%s) Expected hasErr %v, got %v (%v)", s.subsystem, s.expectError, hasErr, err)
+		}
+	}
+
+	// invalid proxy
+	app.Settings().Egress.Default.Proxy = "://invalid"
+	if _, err := app.NewHttpClient(settings.EgressSubsystemOAuth2); err == nil {
+		t.Fatal("Expected invalid proxy error, got nil")
+	}
 }
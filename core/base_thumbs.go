@@ -0,0 +1,167 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/list"
+)
+
+// CacheKeyActiveThumbsWarmupPrefix is the cache key prefix
+// of the running collection thumbs warmups.
+const CacheKeyActiveThumbsWarmupPrefix string = "@activeThumbsWarmup_"
+
+// thumbsWarmupBatchSize is the max number of records loaded at once during the thumbs warmup.
+const thumbsWarmupBatchSize = 200
+
+// DefaultThumbSizes is the list of the thumb sizes allowed for every file field.
+var DefaultThumbSizes = []string{"100x100"}
+
+// ThumbImageContentTypes is the list of the image content types that support thumbs.
+var ThumbImageContentTypes = []string{"image/png", "image/jpg", "image/jpeg", "image/gif"}
+
+// ThumbsWarmupResult defines the result of a single collection thumbs warmup.
+type ThumbsWarmupResult struct {
+	Collection string `json:"collection"`
+
+	// Created, Skipped and Failed are the number of the generated,
+	// the already existing and the failed thumbs.
+	Created int `json:"created"`
+	Skipped int `json:"skipped"`
+	Failed  int `json:"failed"`
+}
+
+// FileFieldThumbSizes returns the thumb sizes allowed for the provided file field
+// (the default ones and the field configured thumbs).
+func FileFieldThumbSizes(field *schema.SchemaField) []string {
+	sizes := append([]string{}, DefaultThumbSizes...)
+
+	if options, ok := field.Options.(*schema.FileOptions); ok {
+		sizes = list.ToUniqueStringSlice(append(sizes, options.Thumbs...))
+	}
+
+	return sizes
+}
+
+// WarmThumbs generates the missing thumbs of the existing image files
+// of all file fields of the provided collection.
+//
+// If sizes is empty, all thumb sizes allowed for the file field are generated
+// (see [FileFieldThumbSizes]), otherwise only the allowed ones from the list.
+//
+// Only a single warmup per collection could run at a time.
+func (app *BaseApp) WarmThumbs(ctx context.Context, collection *models.Collection, sizes []string) (*ThumbsWarmupResult, error) {
+	if collection.IsView() {
+		return nil, errors.New("view collections don't store files")
+	}
+
+	cacheKey := CacheKeyActiveThumbsWarmupPrefix + collection.Id
+	if app.Cache().Has(cacheKey) {
+		return nil, errors.New("try again later - another thumbs warmup for the collection has already been started")
+	}
+
+	app.Cache().Set(cacheKey, true)
+	defer app.Cache().Remove(cacheKey)
+
+	result := &ThumbsWarmupResult{Collection: collection.Name}
+
+	fileFields := map[string][]string{} // field name -> thumb sizes
+	for _, field := range collection.Schema.Fields() {
+		if field.Type != schema.FieldTypeFile {
+			continue
+		}
+
+		fieldSizes := FileFieldThumbSizes(field)
+		if len(sizes) > 0 {
+			fieldSizes = list.ToUniqueStringSlice(filterThumbSizes(sizes, fieldSizes))
+		}
+
+		if len(fieldSizes) > 0 {
+			fileFields[field.Name] = fieldSizes
+		}
+	}
+
+	if len(fileFields) == 0 {
+		return result, nil
+	}
+
+	fs, err := app.NewFilesystem()
+	if err != nil {
+		return nil, err
+	}
+	defer fs.Close()
+
+	fs.SetContext(ctx)
+
+	for offset := 0; ; offset += thumbsWarmupBatchSize {
+		records := []*models.Record{}
+
+		err := app.Dao().RecordQuery(collection).
+			OrderBy("rowid ASC").
+			Offset(int64(offset)).
+			Limit(thumbsWarmupBatchSize).
+			All(&records)
+		if err != nil {
+			return result, err
+		}
+
+		for _, record := range records {
+			if err := ctx.Err(); err != nil {
+				return result, err
+			}
+
+			for fieldName, fieldSizes := range fileFields {
+				for _, filename := range record.GetStringSlice(fieldName) {
+					originalKey := app.Dao().ResolveFileKey(record.BaseFilesPath() + "/" + filename)
+
+					attrs, err := fs.Attributes(originalKey)
+					if err != nil {
+						result.Failed += len(fieldSizes)
+						continue
+					}
+
+					if !list.ExistInSlice(attrs.ContentType, ThumbImageContentTypes) {
+						continue
+					}
+
+					for _, size := range fieldSizes {
+						thumbKey := fmt.Sprintf("%s/thumbs_%s/%s_%s", record.BaseFilesPath(), filename, size, filename)
+
+						if exists, _ := fs.Exists(thumbKey); exists {
+							result.Skipped++
+							continue
+						}
+
+						if err := fs.CreateThumb(originalKey, thumbKey, size); err != nil {
+							result.Failed++
+						} else {
+							result.Created++
+						}
+					}
+				}
+			}
+		}
+
+		if len(records) < thumbsWarmupBatchSize {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// filterThumbSizes returns the sizes that are also in the allowed list.
+func filterThumbSizes(sizes []string, allowed []string) []string {
+	result := make([]string, 0, len(sizes))
+
+	for _, size := range sizes {
+		if list.ExistInSlice(size, allowed) {
+			result = append(result, size)
+		}
+	}
+
+	return result
+}
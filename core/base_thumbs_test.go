@@ -0,0 +1,90 @@
+package core_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestWarmThumbs(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	fs, err := app.NewFilesystem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fs.Close()
+
+	thumbKey := "wsmn24bux7wo113/84nmscqy84lsi1t/thumbs_300_WlbFWSGmW9.png/100x100_300_WlbFWSGmW9.png"
+
+	// remove one of the existing thumbs
+	if err := fs.Delete(thumbKey); err != nil {
+		t.Fatal(err)
+	}
+
+	demo1, err := app.Dao().FindCollectionByNameOrId("demo1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := app.WarmThumbs(context.Background(), demo1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Collection != "demo1" || result.Created != 1 || result.Skipped != 1 || result.Failed != 0 {
+		t.Fatalf("Unexpected result %+v", result)
+	}
+
+	if exists, _ := fs.Exists(thumbKey); !exists {
+		t.Fatal("Expected the missing thumb to be generated")
+	}
+
+	// not allowed sizes should be ignored
+	result, err = app.WarmThumbs(context.Background(), demo1, []string{"70x50"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Created != 0 || result.Skipped != 0 || result.Failed != 0 {
+		t.Fatalf("Expected the not allowed sizes to be ignored, got %+v", result)
+	}
+
+	users, err := app.Dao().FindCollectionByNameOrId("users")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err = app.WarmThumbs(context.Background(), users, []string{"70x50", "70x50t"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Created != 0 || result.Skipped != 2 || result.Failed != 0 {
+		t.Fatalf("Expected the existing user thumbs to be skipped, got %+v", result)
+	}
+}
+
+func TestWarmThumbsFailure(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	view1, err := app.Dao().FindCollectionByNameOrId("view1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := app.WarmThumbs(context.Background(), view1, nil); err == nil {
+		t.Fatal("Expected view collection error, got nil")
+	}
+
+	demo1, err := app.Dao().FindCollectionByNameOrId("demo1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app.Cache().Set(core.CacheKeyActiveThumbsWarmupPrefix+demo1.Id, true)
+	if _, err := app.WarmThumbs(context.Background(), demo1, nil); err == nil {
+		t.Fatal("Expected already running warmup error, got nil")
+	}
+}
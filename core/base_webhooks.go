@@ -0,0 +1,248 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tools/routine"
+	"github.com/pocketbase/pocketbase/tools/types"
+	"github.com/pocketbase/pocketbase/tools/webhook"
+	"github.com/spf13/cast"
+)
+
+// webhookTimeout is the max duration of a single webhook delivery attempt.
+const webhookTimeout = 30 * time.Second
+
+// webhookPayload is the json body of the webhook delivery requests.
+type webhookPayload struct {
+	Id         string         `json:"id"`
+	Event      string         `json:"event"`
+	Collection string         `json:"collection,omitempty"`
+	Created    types.DateTime `json:"created"`
+	Data       any            `json:"data"`
+}
+
+// DeliverWebhook sends a single delivery attempt of the provided
+// webhook delivery payload and saves the attempt result in its log.
+//
+// The request is sent to the current url of the delivery webhook
+// and it is signed with its secret (if any).
+//
+// It returns an error if the webhook is missing or disabled
+// or if the endpoint doesn't respond with 2xx status code.
+func (app *BaseApp) DeliverWebhook(ctx context.Context, delivery *models.WebhookDelivery) error {
+	hook, ok := app.Settings().Webhooks.Hook(delivery.Webhook)
+	if !ok || !hook.Enabled {
+		return fmt.Errorf("missing or disabled webhook %q", delivery.Webhook)
+	}
+
+	delivery.Url = hook.Url
+	delivery.Attempts++
+	delivery.ResponseStatus = 0
+	delivery.Error = ""
+
+	sendErr := app.sendWebhook(ctx, hook, delivery)
+	if sendErr != nil {
+		delivery.Status = models.WebhookDeliveryStatusFailed
+		delivery.Error = sendErr.Error()
+	} else {
+		delivery.Status = models.WebhookDeliveryStatusSuccess
+	}
+
+	if err := app.LogsDao().SaveWebhookDelivery(delivery); err != nil {
+		return err
+	}
+
+	return sendErr
+}
+
+func (app *BaseApp) sendWebhook(ctx context.Context, hook settings.WebhookConfig, delivery *models.WebhookDelivery) error {
+	client, err := app.NewHttpClient(settings.EgressSubsystemWebhooks)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.Url, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhook.IdHeader, delivery.Id)
+	req.Header.Set(webhook.EventHeader, delivery.Event)
+
+	if secret := app.secrets.Resolve(hook.Secret); secret != "" {
+		req.Header.Set(webhook.SignatureHeader, webhook.SignatureHeaderValue(delivery.Payload, time.Now(), secret))
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	delivery.ResponseStatus = res.StatusCode
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("unexpected response status %d", res.StatusCode)
+	}
+
+	return nil
+}
+
+// deliverWebhookWithRetries logs and sends the provided pending
+// delivery, retrying the failed attempts with exponential backoff
+// according to the webhook retry policy.
+func (app *BaseApp) deliverWebhookWithRetries(hook settings.WebhookConfig, delivery *models.WebhookDelivery) {
+	if err := app.LogsDao().SaveWebhookDelivery(delivery); err != nil {
+		if app.IsDebug() {
+			log.Println("Webhook delivery log save failed:", err)
+		}
+		return
+	}
+
+	app.deleteOldWebhookDeliveries()
+
+	delay := time.Duration(hook.RetryDelay) * time.Second
+
+	for attempt := 0; attempt <= hook.MaxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(delay)
+			delay *= 2
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), webhookTimeout)
+		err := app.DeliverWebhook(ctx, delivery)
+		cancel()
+
+		if err == nil {
+			return
+		}
+
+		if app.IsDebug() {
+			// non critical error - only log for debug
+			log.Println("Webhook delivery failed:", err)
+		}
+	}
+}
+
+// deleteOldWebhookDeliveries deletes the expired webhook
+// delivery logs (the check is performed at most once per day).
+func (app *BaseApp) deleteOldWebhookDeliveries() {
+	maxDays := app.Settings().Webhooks.LogsMaxDays
+	if maxDays <= 0 {
+		return
+	}
+
+	now := time.Now()
+	lastDeletedAt := cast.ToTime(app.Cache().Get("lastWebhookDeliveriesDeletedAt"))
+
+	if now.Sub(lastDeletedAt) > 24*time.Hour {
+		err := app.LogsDao().DeleteOldWebhookDeliveries(now.AddDate(0, 0, -1*maxDays))
+		if err == nil {
+			app.Cache().Set("lastWebhookDeliveriesDeletedAt", now)
+		} else if app.IsDebug() {
+			log.Println("Webhook deliveries delete failed:", err)
+		}
+	}
+}
+
+// webhookResource returns the webhook events resource name
+// of the provided model ("records", "admins" or "collections").
+func webhookResource(model models.Model) string {
+	switch model.(type) {
+	case *models.Record:
+		return "records"
+	case *models.Admin:
+		return "admins"
+	case *models.Collection:
+		return "collections"
+	default:
+		return ""
+	}
+}
+
+// initWebhookHooks registers the model hooks that dispatch
+// the records, admins and collections changes to the
+// subscribed webhooks from app.Settings().Webhooks.
+//
+// The deliveries are performed in the background and their
+// results are stored in the logs db (see [models.WebhookDelivery]).
+func (app *BaseApp) initWebhookHooks() {
+	dispatch := func(action string, model models.Model) {
+		config := app.Settings().Webhooks
+		if len(config.Hooks) == 0 {
+			return
+		}
+
+		resource := webhookResource(model)
+		if resource == "" {
+			return
+		}
+
+		var collection string
+		var data any = model
+		if record, ok := model.(*models.Record); ok {
+			collection = record.Collection().Name
+			data = record.PublicExport()
+		}
+
+		event := resource + "." + action
+
+		for name, hook := range config.Hooks {
+			if !hook.IsSubscribed(resource, action, collection) {
+				continue
+			}
+
+			delivery := &models.WebhookDelivery{
+				Webhook: name,
+				Event:   event,
+				Url:     hook.Url,
+				Status:  models.WebhookDeliveryStatusPending,
+			}
+			delivery.RefreshId()
+
+			payload, err := json.Marshal(webhookPayload{
+				Id:         delivery.Id,
+				Event:      event,
+				Collection: collection,
+				Created:    types.NowDateTime(),
+				Data:       data,
+			})
+			if err != nil {
+				if app.IsDebug() {
+					log.Println(err)
+				}
+				continue
+			}
+			delivery.Payload = payload
+
+			hook := hook
+			routine.FireAndForget(func() {
+				app.deliverWebhookWithRetries(hook, delivery)
+			})
+		}
+	}
+
+	app.OnModelAfterCreate().Add(func(e *ModelEvent) error {
+		dispatch("create", e.Model)
+		return nil
+	})
+
+	app.OnModelAfterUpdate().Add(func(e *ModelEvent) error {
+		dispatch("update", e.Model)
+		return nil
+	})
+
+	app.OnModelAfterDelete().Add(func(e *ModelEvent) error {
+		dispatch("delete", e.Model)
+		return nil
+	})
+}
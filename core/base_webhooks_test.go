@@ -0,0 +1,192 @@
+package core_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/egress"
+	"github.com/pocketbase/pocketbase/tools/types"
+	"github.com/pocketbase/pocketbase/tools/webhook"
+)
+
+func TestDeliverWebhook(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	responseStatus := http.StatusOK
+	var lastRequest *http.Request
+	var lastBody []byte
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastRequest = r
+		lastBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(responseStatus)
+	}))
+	defer server.Close()
+
+	delivery := &models.WebhookDelivery{
+		Webhook: "crm",
+		Event:   "records.create",
+		Payload: types.JsonRaw(`{"event":"records.create"}`),
+		Status:  models.WebhookDeliveryStatusPending,
+	}
+
+	// missing webhook
+	if err := app.DeliverWebhook(context.Background(), delivery); err == nil {
+		t.Fatal("Expected missing webhook error, got nil")
+	}
+
+	app.Settings().Webhooks.Hooks = map[string]settings.WebhookConfig{
+		"crm": {Enabled: true, Url: server.URL, Secret: "test_secret", Events: []string{"*.*"}},
+	}
+
+	// the local test server is denied by the default egress policy
+	if err := app.DeliverWebhook(context.Background(), delivery); !errors.Is(err, egress.ErrDestinationNotAllowed) {
+		t.Fatalf("Expected egress.ErrDestinationNotAllowed, got %v", err)
+	}
+	if delivery.Status != models.WebhookDeliveryStatusFailed || delivery.Attempts != 1 || delivery.Error == "" {
+		t.Fatalf("Expected failed delivery attempt, got %v", delivery)
+	}
+
+	app.Settings().Egress.Subsystems = map[string]settings.EgressPolicyConfig{
+		settings.EgressSubsystemWebhooks: {AllowPrivate: true},
+	}
+
+	// endpoint error
+	responseStatus = http.StatusInternalServerError
+	if err := app.DeliverWebhook(context.Background(), delivery); err == nil {
+		t.Fatal("Expected response status error, got nil")
+	}
+	if delivery.Status != models.WebhookDeliveryStatusFailed || delivery.Attempts != 2 || delivery.ResponseStatus != 500 {
+		t.Fatalf("Expected failed delivery attempt with 500 response status, got %v", delivery)
+	}
+
+	// success
+	responseStatus = http.StatusNoContent
+	if err := app.DeliverWebhook(context.Background(), delivery); err != nil {
+		t.Fatal(err)
+	}
+	if delivery.Status != models.WebhookDeliveryStatusSuccess || delivery.Attempts != 3 || delivery.ResponseStatus != 204 || delivery.Error != "" {
+		t.Fatalf("Expected successful delivery attempt, got %v", delivery)
+	}
+
+	if string(lastBody) != `{"event":"records.create"}` {
+		t.Fatalf("Unexpected request body %s", lastBody)
+	}
+	if v := lastRequest.Header.Get(webhook.IdHeader); v != delivery.Id {
+		t.Fatalf("Expected id header %q, got %q", delivery.Id, v)
+	}
+	if v := lastRequest.Header.Get(webhook.EventHeader); v != "records.create" {
+		t.Fatalf("Expected event header records.create, got %q", v)
+	}
+	signature := lastRequest.Header.Get(webhook.SignatureHeader)
+	if err := webhook.VerifySignature(lastBody, signature, "test_secret", webhook.DefaultTolerance, time.Now()); err != nil {
+		t.Fatalf("Invalid signature %q: %v", signature, err)
+	}
+
+	saved, err := app.LogsDao().FindWebhookDeliveryById(delivery.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Status != models.WebhookDeliveryStatusSuccess || saved.Attempts != 3 || saved.Url != server.URL {
+		t.Fatalf("Unexpected saved delivery %v", saved)
+	}
+}
+
+func TestWebhookHooksDispatch(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	requests := make(chan []byte, 10)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- body
+	}))
+	defer server.Close()
+
+	app.Settings().Egress.Subsystems = map[string]settings.EgressPolicyConfig{
+		settings.EgressSubsystemWebhooks: {AllowPrivate: true},
+	}
+	app.Settings().Webhooks.Hooks = map[string]settings.WebhookConfig{
+		"crm": {
+			Enabled:     true,
+			Url:         server.URL,
+			Events:      []string{"records.update"},
+			Collections: []string{"demo2"},
+		},
+	}
+
+	// not subscribed collection
+	demo1Record, err := app.Dao().FindRecordById("demo1", "84nmscqy84lsi1t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := app.Dao().SaveRecord(demo1Record); err != nil {
+		t.Fatal(err)
+	}
+
+	demo2Record, err := app.Dao().FindRecordById("demo2", "achvryl401bhse3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	demo2Record.Set("title", "webhook_update")
+	if err := app.Dao().SaveRecord(demo2Record); err != nil {
+		t.Fatal(err)
+	}
+
+	var body []byte
+	select {
+	case body = <-requests:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected webhook request")
+	}
+
+	payload := struct {
+		Id         string         `json:"id"`
+		Event      string         `json:"event"`
+		Collection string         `json:"collection"`
+		Data       map[string]any `json:"data"`
+	}{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatal(err)
+	}
+
+	if payload.Event != "records.update" || payload.Collection != "demo2" || payload.Data["title"] != "webhook_update" {
+		t.Fatalf("Unexpected payload %s", body)
+	}
+
+	// wait for the delivery log to be updated
+	for i := 0; i < 20; i++ {
+		delivery, err := app.LogsDao().FindWebhookDeliveryById(payload.Id)
+		if err == nil && delivery.Status == models.WebhookDeliveryStatusSuccess {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	var total int
+	app.LogsDao().WebhookDeliveryQuery().
+		Select("count(*)").
+		AndWhere(dbx.HashExp{"status": models.WebhookDeliveryStatusSuccess}).
+		Row(&total)
+	if total != 1 {
+		t.Fatalf("Expected 1 successful webhook delivery, got %d", total)
+	}
+
+	select {
+	case body := <-requests:
+		t.Fatalf("Expected a single webhook request, got another one %s", body)
+	default:
+	}
+}
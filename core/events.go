@@ -69,6 +69,23 @@ type TerminateEvent struct {
 	App App
 }
 
+type BackupProgressEvent struct {
+	App      App
+	Progress *BackupProgress
+}
+
+type SecurityAlertEvent struct {
+	App    App
+	Report *SecurityReport
+}
+
+type ScheduledExportDeliveryEvent struct {
+	App     App
+	Export  *models.ScheduledExport
+	FileKey string
+	Link    string
+}
+
 type ServeEvent struct {
 	App    App
 	Router *echo.Echo
@@ -76,6 +93,7 @@ type ServeEvent struct {
 
 type ApiErrorEvent struct {
 	HttpContext echo.Context
+	RequestInfo *models.RequestInfo
 	Error       error
 }
 
@@ -115,22 +133,26 @@ type MailerAdminEvent struct {
 
 type RealtimeConnectEvent struct {
 	HttpContext echo.Context
+	RequestInfo *models.RequestInfo
 	Client      subscriptions.Client
 }
 
 type RealtimeDisconnectEvent struct {
 	HttpContext echo.Context
+	RequestInfo *models.RequestInfo
 	Client      subscriptions.Client
 }
 
 type RealtimeMessageEvent struct {
 	HttpContext echo.Context
+	RequestInfo *models.RequestInfo
 	Client      subscriptions.Client
 	Message     *subscriptions.Message
 }
 
 type RealtimeSubscribeEvent struct {
 	HttpContext   echo.Context
+	RequestInfo   *models.RequestInfo
 	Client        subscriptions.Client
 	Subscriptions []string
 }
@@ -141,11 +163,13 @@ type RealtimeSubscribeEvent struct {
 
 type SettingsListEvent struct {
 	HttpContext      echo.Context
+	RequestInfo      *models.RequestInfo
 	RedactedSettings *settings.Settings
 }
 
 type SettingsUpdateEvent struct {
 	HttpContext echo.Context
+	RequestInfo *models.RequestInfo
 	OldSettings *settings.Settings
 	NewSettings *settings.Settings
 }
@@ -158,6 +182,7 @@ type RecordsListEvent struct {
 	BaseCollectionEvent
 
 	HttpContext echo.Context
+	RequestInfo *models.RequestInfo
 	Records     []*models.Record
 	Result      *search.Result
 }
@@ -166,6 +191,7 @@ type RecordViewEvent struct {
 	BaseCollectionEvent
 
 	HttpContext echo.Context
+	RequestInfo *models.RequestInfo
 	Record      *models.Record
 }
 
@@ -173,6 +199,7 @@ type RecordCreateEvent struct {
 	BaseCollectionEvent
 
 	HttpContext   echo.Context
+	RequestInfo   *models.RequestInfo
 	Record        *models.Record
 	UploadedFiles map[string][]*filesystem.File
 }
@@ -181,6 +208,7 @@ type RecordUpdateEvent struct {
 	BaseCollectionEvent
 
 	HttpContext   echo.Context
+	RequestInfo   *models.RequestInfo
 	Record        *models.Record
 	UploadedFiles map[string][]*filesystem.File
 }
@@ -189,6 +217,7 @@ type RecordDeleteEvent struct {
 	BaseCollectionEvent
 
 	HttpContext echo.Context
+	RequestInfo *models.RequestInfo
 	Record      *models.Record
 }
 
@@ -200,6 +229,7 @@ type RecordAuthEvent struct {
 	BaseCollectionEvent
 
 	HttpContext echo.Context
+	RequestInfo *models.RequestInfo
 	Record      *models.Record
 	Token       string
 	Meta        any
@@ -209,6 +239,7 @@ type RecordAuthWithPasswordEvent struct {
 	BaseCollectionEvent
 
 	HttpContext echo.Context
+	RequestInfo *models.RequestInfo
 	Record      *models.Record
 	Identity    string
 	Password    string
@@ -218,6 +249,7 @@ type RecordAuthWithOAuth2Event struct {
 	BaseCollectionEvent
 
 	HttpContext    echo.Context
+	RequestInfo    *models.RequestInfo
 	ProviderName   string
 	ProviderClient auth.Provider
 	Record         *models.Record
@@ -229,6 +261,7 @@ type RecordAuthRefreshEvent struct {
 	BaseCollectionEvent
 
 	HttpContext echo.Context
+	RequestInfo *models.RequestInfo
 	Record      *models.Record
 }
 
@@ -236,6 +269,7 @@ type RecordRequestPasswordResetEvent struct {
 	BaseCollectionEvent
 
 	HttpContext echo.Context
+	RequestInfo *models.RequestInfo
 	Record      *models.Record
 }
 
@@ -243,6 +277,7 @@ type RecordConfirmPasswordResetEvent struct {
 	BaseCollectionEvent
 
 	HttpContext echo.Context
+	RequestInfo *models.RequestInfo
 	Record      *models.Record
 }
 
@@ -250,6 +285,7 @@ type RecordRequestVerificationEvent struct {
 	BaseCollectionEvent
 
 	HttpContext echo.Context
+	RequestInfo *models.RequestInfo
 	Record      *models.Record
 }
 
@@ -257,6 +293,7 @@ type RecordConfirmVerificationEvent struct {
 	BaseCollectionEvent
 
 	HttpContext echo.Context
+	RequestInfo *models.RequestInfo
 	Record      *models.Record
 }
 
@@ -264,6 +301,7 @@ type RecordRequestEmailChangeEvent struct {
 	BaseCollectionEvent
 
 	HttpContext echo.Context
+	RequestInfo *models.RequestInfo
 	Record      *models.Record
 }
 
@@ -271,6 +309,7 @@ type RecordConfirmEmailChangeEvent struct {
 	BaseCollectionEvent
 
 	HttpContext echo.Context
+	RequestInfo *models.RequestInfo
 	Record      *models.Record
 }
 
@@ -278,6 +317,7 @@ type RecordListExternalAuthsEvent struct {
 	BaseCollectionEvent
 
 	HttpContext   echo.Context
+	RequestInfo   *models.RequestInfo
 	Record        *models.Record
 	ExternalAuths []*models.ExternalAuth
 }
@@ -286,6 +326,7 @@ type RecordUnlinkExternalAuthEvent struct {
 	BaseCollectionEvent
 
 	HttpContext  echo.Context
+	RequestInfo  *models.RequestInfo
 	Record       *models.Record
 	ExternalAuth *models.ExternalAuth
 }
@@ -296,38 +337,53 @@ type RecordUnlinkExternalAuthEvent struct {
 
 type AdminsListEvent struct {
 	HttpContext echo.Context
+	RequestInfo *models.RequestInfo
 	Admins      []*models.Admin
 	Result      *search.Result
 }
 
 type AdminViewEvent struct {
 	HttpContext echo.Context
+	RequestInfo *models.RequestInfo
 	Admin       *models.Admin
 }
 
 type AdminCreateEvent struct {
 	HttpContext echo.Context
+	RequestInfo *models.RequestInfo
 	Admin       *models.Admin
 }
 
 type AdminUpdateEvent struct {
 	HttpContext echo.Context
+	RequestInfo *models.RequestInfo
 	Admin       *models.Admin
 }
 
 type AdminDeleteEvent struct {
 	HttpContext echo.Context
+	RequestInfo *models.RequestInfo
 	Admin       *models.Admin
 }
 
 type AdminAuthEvent struct {
 	HttpContext echo.Context
+	RequestInfo *models.RequestInfo
 	Admin       *models.Admin
 	Token       string
 }
 
+type AdminImpersonateEvent struct {
+	HttpContext echo.Context
+	RequestInfo *models.RequestInfo
+	Admin       *models.Admin
+	Record      *models.Record
+	Token       string
+}
+
 type AdminAuthWithPasswordEvent struct {
 	HttpContext echo.Context
+	RequestInfo *models.RequestInfo
 	Admin       *models.Admin
 	Identity    string
 	Password    string
@@ -335,16 +391,19 @@ type AdminAuthWithPasswordEvent struct {
 
 type AdminAuthRefreshEvent struct {
 	HttpContext echo.Context
+	RequestInfo *models.RequestInfo
 	Admin       *models.Admin
 }
 
 type AdminRequestPasswordResetEvent struct {
 	HttpContext echo.Context
+	RequestInfo *models.RequestInfo
 	Admin       *models.Admin
 }
 
 type AdminConfirmPasswordResetEvent struct {
 	HttpContext echo.Context
+	RequestInfo *models.RequestInfo
 	Admin       *models.Admin
 }
 
@@ -354,6 +413,7 @@ type AdminConfirmPasswordResetEvent struct {
 
 type CollectionsListEvent struct {
 	HttpContext echo.Context
+	RequestInfo *models.RequestInfo
 	Collections []*models.Collection
 	Result      *search.Result
 }
@@ -362,28 +422,33 @@ type CollectionViewEvent struct {
 	BaseCollectionEvent
 
 	HttpContext echo.Context
+	RequestInfo *models.RequestInfo
 }
 
 type CollectionCreateEvent struct {
 	BaseCollectionEvent
 
 	HttpContext echo.Context
+	RequestInfo *models.RequestInfo
 }
 
 type CollectionUpdateEvent struct {
 	BaseCollectionEvent
 
 	HttpContext echo.Context
+	RequestInfo *models.RequestInfo
 }
 
 type CollectionDeleteEvent struct {
 	BaseCollectionEvent
 
 	HttpContext echo.Context
+	RequestInfo *models.RequestInfo
 }
 
 type CollectionsImportEvent struct {
 	HttpContext echo.Context
+	RequestInfo *models.RequestInfo
 	Collections []*models.Collection
 }
 
@@ -395,6 +460,7 @@ type FileTokenEvent struct {
 	BaseModelEvent
 
 	HttpContext echo.Context
+	RequestInfo *models.RequestInfo
 	Token       string
 }
 
@@ -402,8 +468,33 @@ type FileDownloadEvent struct {
 	BaseCollectionEvent
 
 	HttpContext echo.Context
+	RequestInfo *models.RequestInfo
 	Record      *models.Record
 	FileField   *schema.SchemaField
 	ServedPath  string
 	ServedName  string
 }
+
+// -------------------------------------------------------------------
+// Users API events data
+// -------------------------------------------------------------------
+
+type UserCreateEvent struct {
+	HttpContext echo.Context
+	RequestInfo *models.RequestInfo
+	User        *models.User
+}
+
+type UserUpdateEvent struct {
+	HttpContext echo.Context
+	RequestInfo *models.RequestInfo
+	UserId      string
+	Data        map[string]any
+}
+
+type UserDeleteEvent struct {
+	HttpContext echo.Context
+	RequestInfo *models.RequestInfo
+	UserId      string
+	Permanent   bool
+}
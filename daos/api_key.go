@@ -0,0 +1,87 @@
+package daos
+
+import (
+	"errors"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// ApiKeyQuery returns a new ApiKey select query.
+func (dao *Dao) ApiKeyQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.ApiKey{})
+}
+
+// FindApiKeyById finds the api key with the provided id.
+func (dao *Dao) FindApiKeyById(id string) (*models.ApiKey, error) {
+	model := &models.ApiKey{}
+
+	err := dao.ApiKeyQuery().
+		AndWhere(dbx.HashExp{"id": id}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindApiKeyByKey finds the api key matching the provided plain key.
+//
+// Note that the key expiration is not checked.
+func (dao *Dao) FindApiKeyByKey(key string) (*models.ApiKey, error) {
+	prefix := models.ApiKeyLookupPrefix(key)
+	if prefix == "" {
+		return nil, errors.New("invalid api key format")
+	}
+
+	model := &models.ApiKey{}
+
+	err := dao.ApiKeyQuery().
+		AndWhere(dbx.HashExp{"prefix": prefix}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !model.ValidateKey(key) {
+		return nil, errors.New("invalid api key")
+	}
+
+	return model, nil
+}
+
+// UpdateApiKeyLastUsed updates the LastUsed field of the provided
+// api key with the current date (without triggering the model hooks).
+func (dao *Dao) UpdateApiKeyLastUsed(apiKey *models.ApiKey) error {
+	lastUsed := types.NowDateTime()
+
+	_, err := dao.DB().Update(
+		apiKey.TableName(),
+		dbx.Params{"lastUsed": lastUsed.String()},
+		dbx.HashExp{"id": apiKey.Id},
+	).Execute()
+
+	if err != nil {
+		return err
+	}
+
+	apiKey.LastUsed = lastUsed
+
+	return nil
+}
+
+// DeleteApiKey deletes (aka. revokes) the provided ApiKey model.
+func (dao *Dao) DeleteApiKey(apiKey *models.ApiKey) error {
+	return dao.Delete(apiKey)
+}
+
+// SaveApiKey upserts the provided ApiKey model.
+func (dao *Dao) SaveApiKey(apiKey *models.ApiKey) error {
+	return dao.Save(apiKey)
+}
@@ -0,0 +1,143 @@
+package daos_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestApiKeyQuery(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	expected := "SELECT {{_apiKeys}}.* FROM `_apiKeys`"
+
+	sql := app.Dao().ApiKeyQuery().Build().SQL()
+	if sql != expected {
+		t.Errorf("Expected sql %s, got %s", expected, sql)
+	}
+}
+
+func TestFindApiKeyById(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		id          string
+		expectError bool
+	}{
+		{"", true},
+		{"missing", true},
+		{"ak1adminusers01", false},
+	}
+
+	for i, scenario := range scenarios {
+		apiKey, err := app.Dao().FindApiKeyById(scenario.id)
+
+		hasErr := err != nil
+		if hasErr != scenario.expectError {
+			t.Errorf("(%d) Expected hasErr to be %v, got %v (%v)", i, scenario.expectError, hasErr, err)
+		}
+
+		if apiKey != nil && apiKey.Id != scenario.id {
+			t.Errorf("(%d) Expected api key with id %s, got %s", i, scenario.id, apiKey.Id)
+		}
+	}
+}
+
+func TestFindApiKeyByKey(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		key         string
+		expectError bool
+		expectedId  string
+	}{
+		{"", true, ""},
+		{"invalid", true, ""},
+		{"pbk_missing0000000000000000000000000000000000", true, ""},
+		// matching prefix but different key
+		{"pbk_adminUsers000000000000000000000000000001", true, ""},
+		{"pbk_adminUsers000000000000000000000000000000", false, "ak1adminusers01"},
+		// expired keys are still returned
+		{"pbk_expiredAll000000000000000000000000000000", false, "ak3expiredall03"},
+	}
+
+	for i, scenario := range scenarios {
+		apiKey, err := app.Dao().FindApiKeyByKey(scenario.key)
+
+		hasErr := err != nil
+		if hasErr != scenario.expectError {
+			t.Errorf("(%d) Expected hasErr to be %v, got %v (%v)", i, scenario.expectError, hasErr, err)
+		}
+
+		if apiKey != nil && apiKey.Id != scenario.expectedId {
+			t.Errorf("(%d) Expected api key with id %s, got %s", i, scenario.expectedId, apiKey.Id)
+		}
+	}
+}
+
+func TestSaveAndDeleteApiKey(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	apiKey := &models.ApiKey{
+		Name:    "test",
+		Scopes:  []string{"users"},
+		AdminId: "sywbhecnh46rhm0",
+	}
+	key := apiKey.RefreshKey()
+
+	if err := app.Dao().SaveApiKey(apiKey); err != nil {
+		t.Fatal(err)
+	}
+
+	saved, err := app.Dao().FindApiKeyByKey(key)
+	if err != nil {
+		t.Fatalf("Expected the api key to be saved, got %v", err)
+	}
+	if len(saved.Scopes) != 1 || saved.Scopes[0] != "users" {
+		t.Fatalf("Expected the scopes to be persisted, got %v", saved.Scopes)
+	}
+
+	if err := app.Dao().DeleteApiKey(apiKey); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindApiKeyByKey(key); err == nil {
+		t.Fatal("Expected the api key to be deleted")
+	}
+}
+
+func TestUpdateApiKeyLastUsed(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	apiKey, err := app.Dao().FindApiKeyById("ak1adminusers01")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().UpdateApiKeyLastUsed(apiKey); err != nil {
+		t.Fatal(err)
+	}
+
+	if apiKey.LastUsed.IsZero() {
+		t.Fatal("Expected the model lastUsed to be updated")
+	}
+
+	saved, err := app.Dao().FindApiKeyById("ak1adminusers01")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if saved.LastUsed.String() != apiKey.LastUsed.String() {
+		t.Fatalf("Expected lastUsed %q, got %q", apiKey.LastUsed.String(), saved.LastUsed.String())
+	}
+
+	if saved.Updated.String() != apiKey.Updated.String() {
+		t.Fatalf("Expected the updated date to be unchanged, got %q", saved.Updated.String())
+	}
+}
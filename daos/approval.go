@@ -0,0 +1,74 @@
+package daos
+
+import (
+	"errors"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// ErrApprovalNotPending is returned by [Dao.DecideApproval] when the
+// approval request was already decided (eg. by a concurrent request).
+var ErrApprovalNotPending = errors.New("the approval request is no longer pending")
+
+// ApprovalQuery returns a new Approval select query.
+func (dao *Dao) ApprovalQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.Approval{})
+}
+
+// FindApprovalById finds a single Approval model by its id.
+func (dao *Dao) FindApprovalById(id string) (*models.Approval, error) {
+	model := &models.Approval{}
+
+	err := dao.ApprovalQuery().
+		AndWhere(dbx.HashExp{"id": id}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// SaveApproval upserts the provided Approval model.
+func (dao *Dao) SaveApproval(approval *models.Approval) error {
+	return dao.Save(approval)
+}
+
+// DecideApproval atomically changes the status of the provided
+// pending approval and marks it as decided by the specified admin
+// (without triggering the model hooks).
+//
+// Returns [ErrApprovalNotPending] if the approval is no longer pending
+// in the database, allowing only one of several concurrent decisions to succeed.
+func (dao *Dao) DecideApproval(approval *models.Approval, status string, decidedBy string) error {
+	now := types.NowDateTime()
+
+	result, err := dao.DB().Update(
+		approval.TableName(),
+		dbx.Params{
+			"status":    status,
+			"decidedBy": decidedBy,
+			"decidedAt": now.String(),
+			"updated":   now.String(),
+		},
+		dbx.HashExp{"id": approval.Id, "status": models.ApprovalStatusPending},
+	).Execute()
+	if err != nil {
+		return err
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return ErrApprovalNotPending
+	}
+
+	approval.Status = status
+	approval.DecidedBy = decidedBy
+	approval.DecidedAt = now
+	approval.Updated = now
+
+	return nil
+}
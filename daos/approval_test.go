@@ -0,0 +1,124 @@
+package daos_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestApprovalQuery(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	expected := "SELECT {{_approvals}}.* FROM `_approvals`"
+
+	sql := app.Dao().ApprovalQuery().Build().SQL()
+	if sql != expected {
+		t.Errorf("Expected sql %s, got %s", expected, sql)
+	}
+}
+
+func TestFindApprovalById(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		id          string
+		expectError bool
+	}{
+		{"", true},
+		{"missing", true},
+		{"a1settingsupd01", false},
+		{"a3rejectedbkp01", false},
+	}
+
+	for i, scenario := range scenarios {
+		approval, err := app.Dao().FindApprovalById(scenario.id)
+
+		hasErr := err != nil
+		if hasErr != scenario.expectError {
+			t.Errorf("(%d) Expected hasErr to be %v, got %v (%v)", i, scenario.expectError, hasErr, err)
+		}
+
+		if approval != nil && approval.Id != scenario.id {
+			t.Errorf("(%d) Expected approval with id %s, got %s", i, scenario.id, approval.Id)
+		}
+	}
+}
+
+func TestSaveApproval(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	approval := &models.Approval{
+		Action:      "settings_update",
+		Method:      "PATCH",
+		Url:         "/api/settings",
+		Status:      models.ApprovalStatusPending,
+		RequestedBy: "sywbhecnh46rhm0",
+	}
+
+	if err := app.Dao().SaveApproval(approval); err != nil {
+		t.Fatal(err)
+	}
+
+	approval.Status = models.ApprovalStatusRejected
+	if err := app.Dao().SaveApproval(approval); err != nil {
+		t.Fatal(err)
+	}
+
+	saved, err := app.Dao().FindApprovalById(approval.Id)
+	if err != nil {
+		t.Fatalf("Expected the approval to be saved, got %v", err)
+	}
+
+	if saved.Status != models.ApprovalStatusRejected {
+		t.Fatalf("Expected status %q, got %q", models.ApprovalStatusRejected, saved.Status)
+	}
+}
+
+func TestDecideApproval(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	approval := &models.Approval{
+		Action:      "settings_update",
+		Method:      "PATCH",
+		Url:         "/api/settings",
+		Status:      models.ApprovalStatusPending,
+		RequestedBy: "sywbhecnh46rhm0",
+	}
+	if err := app.Dao().SaveApproval(approval); err != nil {
+		t.Fatal(err)
+	}
+
+	// simulate a concurrent decision with a stale copy of the same approval
+	stale, err := app.Dao().FindApprovalById(approval.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().DecideApproval(approval, models.ApprovalStatusApproved, "9q2trqumvlyr3bd"); err != nil {
+		t.Fatalf("Expected the first decision to succeed, got %v", err)
+	}
+
+	if approval.Status != models.ApprovalStatusApproved || approval.DecidedBy != "9q2trqumvlyr3bd" || approval.DecidedAt.IsZero() {
+		t.Fatalf("Expected the approval model to be updated, got %v", approval)
+	}
+
+	if err := app.Dao().DecideApproval(stale, models.ApprovalStatusRejected, "9q2trqumvlyr3bd"); !errors.Is(err, daos.ErrApprovalNotPending) {
+		t.Fatalf("Expected ErrApprovalNotPending for the second decision, got %v", err)
+	}
+
+	saved, err := app.Dao().FindApprovalById(approval.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if saved.Status != models.ApprovalStatusApproved {
+		t.Fatalf("Expected status %q, got %q", models.ApprovalStatusApproved, saved.Status)
+	}
+}
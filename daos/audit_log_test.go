@@ -0,0 +1,112 @@
+package daos_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestAuditLogQuery(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	expected := "SELECT {{_auditLogs}}.* FROM `_auditLogs`"
+
+	sql := app.LogsDao().AuditLogQuery().Build().SQL()
+	if sql != expected {
+		t.Errorf("Expected sql %s, got %s", expected, sql)
+	}
+}
+
+func TestFindAuditLogById(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	tests.MockAuditLogsData(app)
+
+	scenarios := []struct {
+		id          string
+		expectError bool
+	}{
+		{"", true},
+		{"invalid", true},
+		{"a1b2c3d4-0000-4000-8000-000000000001", false},
+	}
+
+	for i, scenario := range scenarios {
+		auditLog, err := app.LogsDao().FindAuditLogById(scenario.id)
+
+		hasErr := err != nil
+		if hasErr != scenario.expectError {
+			t.Errorf("(%d) Expected hasErr to be %v, got %v (%v)", i, scenario.expectError, hasErr, err)
+		}
+
+		if auditLog != nil && auditLog.Id != scenario.id {
+			t.Errorf("(%d) Expected audit log with id %s, got %s", i, scenario.id, auditLog.Id)
+		}
+	}
+}
+
+func TestDeleteOldAuditLogs(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	tests.MockAuditLogsData(app)
+
+	scenarios := []struct {
+		date          string
+		expectedTotal int
+	}{
+		{"2022-01-01 10:00:00.000Z", 2},
+		{"2022-05-01 11:00:00.000Z", 1},
+		{"2022-05-03 11:00:00.000Z", 0},
+	}
+
+	for i, scenario := range scenarios {
+		date, dateErr := time.Parse(types.DefaultDateLayout, scenario.date)
+		if dateErr != nil {
+			t.Errorf("(%d) Date error %v", i, dateErr)
+		}
+
+		if err := app.LogsDao().DeleteOldAuditLogs(date); err != nil {
+			t.Errorf("(%d) Delete error %v", i, err)
+		}
+
+		var total int
+		if err := app.LogsDao().AuditLogQuery().Select("count(*)").Row(&total); err != nil {
+			t.Errorf("(%d) Count error %v", i, err)
+		}
+
+		if total != scenario.expectedTotal {
+			t.Errorf("(%d) Expected %d remaining audit logs, got %d", i, scenario.expectedTotal, total)
+		}
+	}
+}
+
+func TestSaveAuditLog(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	auditLog := &models.AuditLog{
+		Action:   models.AuditActionUpdate,
+		Resource: models.AuditResourceSettings,
+		Actor:    models.RequestAuthAdmin,
+		Changes:  types.JsonMap{"meta.appName": map[string]any{"old": "a", "new": "b"}},
+	}
+
+	if err := app.LogsDao().SaveAuditLog(auditLog); err != nil {
+		t.Fatal(err)
+	}
+
+	saved, err := app.LogsDao().FindAuditLogById(auditLog.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if saved.Resource != models.AuditResourceSettings || saved.Changes["meta.appName"] == nil {
+		t.Fatalf("Unexpected saved audit log %v", saved)
+	}
+}
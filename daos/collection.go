@@ -141,13 +141,46 @@ func (dao *Dao) DeleteCollection(collection *models.Collection) error {
 	return dao.RunInTransaction(func(txDao *Dao) error {
 		// delete the related view or records table
 		if collection.IsView() {
-			if err := txDao.DeleteView(collection.Name); err != nil {
+			if err := txDao.deleteViewStorage(collection.Name); err != nil {
 				return err
 			}
 		} else {
 			if err := txDao.DeleteTable(collection.Name); err != nil {
 				return err
 			}
+
+			if err := txDao.syncRecordFTSTable(nil, collection); err != nil {
+				return err
+			}
+
+			if err := txDao.syncRecordGeoTable(nil, collection); err != nil {
+				return err
+			}
+		}
+
+		// delete the collection records ACL entries
+		_, err := txDao.DB().Delete((&models.RecordShare{}).TableName(), dbx.Or(
+			dbx.HashExp{"collectionId": collection.Id},
+			dbx.HashExp{"subjectCollectionId": collection.Id},
+		)).Execute()
+		if err != nil {
+			return err
+		}
+
+		// delete the collection saved queries
+		_, err = txDao.DB().Delete((&models.SavedQuery{}).TableName(), dbx.HashExp{
+			"collectionId": collection.Id,
+		}).Execute()
+		if err != nil {
+			return err
+		}
+
+		// delete the collection scheduled exports
+		_, err = txDao.DB().Delete((&models.ScheduledExport{}).TableName(), dbx.HashExp{
+			"collectionId": collection.Id,
+		}).Execute()
+		if err != nil {
+			return err
 		}
 
 		// trigger views resave to check for dependencies
@@ -290,7 +323,7 @@ func (dao *Dao) ImportCollections(
 
 				// delete the related records table or view
 				if existing.IsView() {
-					if err := txDao.DeleteView(existing.Name); err != nil {
+					if err := txDao.deleteViewStorage(existing.Name); err != nil {
 						return err
 					}
 				} else {
@@ -350,8 +383,8 @@ func (dao *Dao) ImportCollections(
 }
 
 // saveViewCollection persists the provided View collection changes:
-//   - deletes the old related SQL view (if any)
-//   - creates a new SQL view with the latest newCollection.Options.Query
+//   - deletes the old related SQL view or materialized table (if any)
+//   - creates a new SQL view (or materialized table) with the latest newCollection.Options.Query
 //   - generates a new schema based on newCollection.Options.Query
 //   - updates newCollection.Schema based on the generated view table info and query
 //   - saves the newCollection
@@ -373,13 +406,21 @@ func (dao *Dao) saveViewCollection(newCollection, oldCollection *models.Collecti
 
 		// delete old renamed view
 		if oldCollection != nil {
-			if err := txDao.DeleteView(oldCollection.Name); err != nil {
+			if err := txDao.deleteViewStorage(oldCollection.Name); err != nil {
 				return err
 			}
 		}
 
-		// (re)create the view
-		if err := txDao.SaveView(newCollection.Name, query); err != nil {
+		// (re)create the view or its materialized table
+		if newCollection.ViewOptions().Materialized {
+			err = txDao.SaveMaterializedView(newCollection.Name, query)
+		} else {
+			// delete the previous materialized table (if any)
+			if err = txDao.deleteViewStorage(newCollection.Name); err == nil {
+				err = txDao.SaveView(newCollection.Name, query)
+			}
+		}
+		if err != nil {
 			return err
 		}
 
@@ -0,0 +1,91 @@
+package daos
+
+import (
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// CommentQuery returns a new Comment select query.
+func (dao *Dao) CommentQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.Comment{})
+}
+
+// RecordCommentsQuery returns a new Comment select query
+// filtered to the thread of the provided record.
+func (dao *Dao) RecordCommentsQuery(record *models.Record) *dbx.SelectQuery {
+	return dao.CommentQuery().AndWhere(dbx.HashExp{
+		"collectionId": record.Collection().Id,
+		"recordId":     record.Id,
+	})
+}
+
+// FindCommentById finds the comment with the provided id.
+func (dao *Dao) FindCommentById(id string) (*models.Comment, error) {
+	model := &models.Comment{}
+
+	err := dao.CommentQuery().
+		AndWhere(dbx.HashExp{"id": id}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindRecordCommentById finds the comment with the provided id
+// from the thread of the provided record.
+func (dao *Dao) FindRecordCommentById(record *models.Record, id string) (*models.Comment, error) {
+	model := &models.Comment{}
+
+	err := dao.RecordCommentsQuery(record).
+		AndWhere(dbx.HashExp{"id": id}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// SaveComment upserts the provided Comment model.
+func (dao *Dao) SaveComment(comment *models.Comment) error {
+	return dao.Save(comment)
+}
+
+// DeleteComment deletes the provided Comment model and all its replies.
+func (dao *Dao) DeleteComment(comment *models.Comment) error {
+	return dao.RunInTransaction(func(txDao *Dao) error {
+		replies := []*models.Comment{}
+
+		err := txDao.CommentQuery().
+			AndWhere(dbx.HashExp{"parentId": comment.Id}).
+			All(&replies)
+		if err != nil {
+			return err
+		}
+
+		for _, reply := range replies {
+			if err := txDao.DeleteComment(reply); err != nil {
+				return err
+			}
+		}
+
+		return txDao.Delete(comment)
+	})
+}
+
+// deleteRecordComments deletes (without triggering the
+// model hooks) the comments thread of the provided record.
+func (dao *Dao) deleteRecordComments(record *models.Record) error {
+	_, err := dao.DB().Delete((&models.Comment{}).TableName(), dbx.HashExp{
+		"collectionId": record.Collection().Id,
+		"recordId":     record.Id,
+	}).Execute()
+
+	return err
+}
@@ -0,0 +1,113 @@
+package daos_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func createTestComments(t *testing.T, app *tests.TestApp) []*models.Comment {
+	comments := []*models.Comment{
+		{CollectionId: "sz5l5z67tg7gku0", RecordId: "achvryl401bhse3", AuthorCollectionId: "_pb_users_auth_", AuthorId: "4q1xlclmfloku33", Message: "first", Status: models.CommentStatusApproved},
+		{CollectionId: "sz5l5z67tg7gku0", RecordId: "achvryl401bhse3", AuthorCollectionId: "_pb_users_auth_", AuthorId: "oap640cot4yru2s", Message: "second", Status: models.CommentStatusPending},
+		{CollectionId: "sz5l5z67tg7gku0", RecordId: "0yxhwia2amd8gec", AuthorCollectionId: "_pb_users_auth_", AuthorId: "4q1xlclmfloku33", Message: "other thread", Status: models.CommentStatusApproved},
+	}
+
+	for _, comment := range comments {
+		if err := app.Dao().SaveComment(comment); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// reply to the first comment
+	reply := &models.Comment{CollectionId: "sz5l5z67tg7gku0", RecordId: "achvryl401bhse3", ParentId: comments[0].Id, AuthorCollectionId: "_pb_users_auth_", AuthorId: "oap640cot4yru2s", Message: "reply", Status: models.CommentStatusApproved}
+	if err := app.Dao().SaveComment(reply); err != nil {
+		t.Fatal(err)
+	}
+
+	return append(comments, reply)
+}
+
+func TestFindRecordComments(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	comments := createTestComments(t, app)
+
+	record, err := app.Dao().FindRecordById("demo2", "achvryl401bhse3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := []*models.Comment{}
+	if err := app.Dao().RecordCommentsQuery(record).All(&result); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result) != 3 {
+		t.Fatalf("Expected 3 thread comments, got %d", len(result))
+	}
+
+	byId, err := app.Dao().FindCommentById(comments[2].Id)
+	if err != nil || byId.Message != "other thread" {
+		t.Fatalf("Expected comment %q, got %v (%v)", comments[2].Id, byId, err)
+	}
+
+	inThread, err := app.Dao().FindRecordCommentById(record, comments[1].Id)
+	if err != nil || inThread.Id != comments[1].Id {
+		t.Fatalf("Expected comment %q, got %v (%v)", comments[1].Id, inThread, err)
+	}
+
+	if _, err := app.Dao().FindRecordCommentById(record, comments[2].Id); err == nil {
+		t.Fatal("Expected error for comment from another thread, got nil")
+	}
+}
+
+func TestDeleteComment(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	comments := createTestComments(t, app)
+
+	if err := app.Dao().DeleteComment(comments[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	// the comment and its reply should be deleted
+	for _, id := range []string{comments[0].Id, comments[3].Id} {
+		if _, err := app.Dao().FindCommentById(id); err == nil {
+			t.Errorf("Expected comment %q to be deleted", id)
+		}
+	}
+
+	for _, id := range []string{comments[1].Id, comments[2].Id} {
+		if _, err := app.Dao().FindCommentById(id); err != nil {
+			t.Errorf("Expected comment %q to be kept, got %v", id, err)
+		}
+	}
+}
+
+func TestDeleteRecordDeletesComments(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	comments := createTestComments(t, app)
+
+	record, err := app.Dao().FindRecordById("demo2", "achvryl401bhse3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().DeleteRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, comment := range comments {
+		_, err := app.Dao().FindCommentById(comment.Id)
+
+		if deleted := err != nil; deleted != (comment.RecordId == record.Id) {
+			t.Errorf("Unexpected comment %q deleted state %v", comment.Id, deleted)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package daos
+
+import (
+	"strings"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/list"
+)
+
+// FeatureFlagQuery returns a new FeatureFlag select query.
+func (dao *Dao) FeatureFlagQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.FeatureFlag{})
+}
+
+// FindFeatureFlagByKey finds the feature flag with the provided key.
+func (dao *Dao) FindFeatureFlagByKey(key string) (*models.FeatureFlag, error) {
+	model := &models.FeatureFlag{}
+
+	err := dao.FeatureFlagQuery().
+		AndWhere(dbx.HashExp{"key": key}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindFeatureFlagsByKeys finds all feature flags with the provided keys
+// (returns all feature flags if no keys are specified).
+func (dao *Dao) FindFeatureFlagsByKeys(keys ...string) ([]*models.FeatureFlag, error) {
+	query := dao.FeatureFlagQuery().OrderBy("key ASC")
+
+	if len(keys) > 0 {
+		query.AndWhere(dbx.In("key", list.ToInterfaceSlice(keys)...))
+	}
+
+	result := []*models.FeatureFlag{}
+
+	if err := query.All(&result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// IsFeatureFlagKeyUnique checks that there is no other feature flag
+// with the provided key (case insensitive).
+func (dao *Dao) IsFeatureFlagKeyUnique(key string, excludeIds ...string) bool {
+	if key == "" {
+		return false
+	}
+
+	query := dao.FeatureFlagQuery().Select("count(*)").
+		AndWhere(dbx.NewExp("LOWER([[key]])={:key}", dbx.Params{"key": strings.ToLower(key)})).
+		Limit(1)
+
+	if uniqueExcludeIds := list.NonzeroUniques(excludeIds); len(uniqueExcludeIds) > 0 {
+		query.AndWhere(dbx.NotIn("id", list.ToInterfaceSlice(uniqueExcludeIds)...))
+	}
+
+	var exists bool
+
+	return query.Row(&exists) == nil && !exists
+}
+
+// DeleteFeatureFlag deletes the provided FeatureFlag model.
+func (dao *Dao) DeleteFeatureFlag(flag *models.FeatureFlag) error {
+	return dao.Delete(flag)
+}
+
+// SaveFeatureFlag upserts the provided FeatureFlag model.
+func (dao *Dao) SaveFeatureFlag(flag *models.FeatureFlag) error {
+	return dao.Save(flag)
+}
@@ -0,0 +1,144 @@
+package daos_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/flags"
+)
+
+func TestFeatureFlagQuery(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	expected := "SELECT {{_featureFlags}}.* FROM `_featureFlags`"
+
+	sql := app.Dao().FeatureFlagQuery().Build().SQL()
+	if sql != expected {
+		t.Errorf("Expected sql %s, got %s", expected, sql)
+	}
+}
+
+func TestFindFeatureFlagByKey(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		key         string
+		expectError bool
+		expectedId  string
+	}{
+		{"", true, ""},
+		{"missing", true, ""},
+		{"new-dashboard", false, "ff1nwdash00001a"},
+	}
+
+	for i, scenario := range scenarios {
+		flag, err := app.Dao().FindFeatureFlagByKey(scenario.key)
+
+		hasErr := err != nil
+		if hasErr != scenario.expectError {
+			t.Errorf("(%d) Expected hasErr to be %v, got %v (%v)", i, scenario.expectError, hasErr, err)
+		}
+
+		if flag != nil && flag.Id != scenario.expectedId {
+			t.Errorf("(%d) Expected feature flag with id %s, got %s", i, scenario.expectedId, flag.Id)
+		}
+
+		if flag != nil && len(flag.Rules) != 1 {
+			t.Errorf("(%d) Expected the feature flag rules to be loaded, got %v", i, flag.Rules)
+		}
+	}
+}
+
+func TestFindFeatureFlagsByKeys(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		keys         []string
+		expectedKeys []string
+	}{
+		{nil, []string{"disabled-feature", "new-dashboard", "public-banner", "verified-only"}},
+		{[]string{"missing"}, []string{}},
+		{[]string{"public-banner", "missing", "new-dashboard"}, []string{"new-dashboard", "public-banner"}},
+	}
+
+	for i, scenario := range scenarios {
+		result, err := app.Dao().FindFeatureFlagsByKeys(scenario.keys...)
+		if err != nil {
+			t.Errorf("(%d) Unexpected error %v", i, err)
+			continue
+		}
+
+		if len(result) != len(scenario.expectedKeys) {
+			t.Errorf("(%d) Expected %d feature flags, got %d", i, len(scenario.expectedKeys), len(result))
+			continue
+		}
+
+		for j, flag := range result {
+			if flag.Key != scenario.expectedKeys[j] {
+				t.Errorf("(%d) Expected feature flag %s, got %s", i, scenario.expectedKeys[j], flag.Key)
+			}
+		}
+	}
+}
+
+func TestIsFeatureFlagKeyUnique(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		key       string
+		excludeId string
+		expected  bool
+	}{
+		{"", "", false},
+		{"new-dashboard", "", false},
+		{"NEW-dashboard", "", false},
+		{"new-dashboard", "ff1nwdash00001a", true},
+		{"new", "", true},
+	}
+
+	for i, scenario := range scenarios {
+		result := app.Dao().IsFeatureFlagKeyUnique(scenario.key, scenario.excludeId)
+		if result != scenario.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, scenario.expected, result)
+		}
+	}
+}
+
+func TestSaveAndDeleteFeatureFlag(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	flag := &models.FeatureFlag{
+		Key:     "test",
+		Enabled: true,
+		Rollout: 50,
+		Rules: []flags.Rule{
+			{Attribute: "groups", Values: []string{"beta"}, Percentage: 100},
+		},
+	}
+
+	if err := app.Dao().SaveFeatureFlag(flag); err != nil {
+		t.Fatal(err)
+	}
+
+	saved, err := app.Dao().FindFeatureFlagByKey("test")
+	if err != nil {
+		t.Fatalf("Expected the feature flag to be saved, got %v", err)
+	}
+	if len(saved.Rules) != 1 || saved.Rules[0].Values[0] != "beta" {
+		t.Fatalf("Expected the rules to be persisted, got %v", saved.Rules)
+	}
+
+	if err := app.Dao().DeleteFeatureFlag(flag); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindFeatureFlagByKey("test"); err == nil {
+		t.Fatal("Expected the feature flag to be deleted")
+	}
+}
@@ -0,0 +1,263 @@
+package daos
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/filesystem"
+)
+
+// FileObjectQuery returns a new FileObject select query.
+func (dao *Dao) FileObjectQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.FileObject{})
+}
+
+// FileRefQuery returns a new FileRef select query.
+func (dao *Dao) FileRefQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.FileRef{})
+}
+
+// FindFileObjectByHash finds the file object with the provided content hash.
+func (dao *Dao) FindFileObjectByHash(hash string) (*models.FileObject, error) {
+	model := &models.FileObject{}
+
+	err := dao.FileObjectQuery().
+		AndWhere(dbx.HashExp{"hash": hash}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindFileRefByKey finds the file object reference of the provided file key.
+func (dao *Dao) FindFileRefByKey(key string) (*models.FileRef, error) {
+	model := &models.FileRef{}
+
+	err := dao.FileRefQuery().
+		AndWhere(dbx.HashExp{"key": key}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindFileRefsByPrefix returns all file object references
+// which keys start with the provided prefix.
+func (dao *Dao) FindFileRefsByPrefix(prefix string) ([]*models.FileRef, error) {
+	result := []*models.FileRef{}
+
+	err := dao.FileRefQuery().
+		AndWhere(dbx.Like("key", prefix).Match(false, true)).
+		All(&result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ResolveFileKey returns the storage key of the file object referenced
+// by the provided file key (or the file key itself if it is not a reference).
+func (dao *Dao) ResolveFileKey(key string) string {
+	var objectKey string
+
+	err := dao.FileObjectQuery().
+		Select("{{_fileObjects}}.[[key]]").
+		InnerJoin("{{_fileRefs}}", dbx.NewExp("[[_fileRefs.objectId]] = [[_fileObjects.id]]")).
+		AndWhere(dbx.HashExp{"_fileRefs.key": key}).
+		Limit(1).
+		Row(&objectKey)
+
+	if err != nil || objectKey == "" {
+		return key
+	}
+
+	return objectKey
+}
+
+// UploadDedupFile uploads the provided file as a deduplicated file object
+// and stores a reference to it under the specified file key.
+//
+// If a file object with the same content hash already exists,
+// only the reference is created and nothing is uploaded.
+func (dao *Dao) UploadDedupFile(fsys *filesystem.System, file *filesystem.File, key string) error {
+	hash, size, err := hashFile(file)
+	if err != nil {
+		return err
+	}
+
+	object, err := dao.FindFileObjectByHash(hash)
+	if err != nil {
+		object = &models.FileObject{
+			Hash: hash,
+			Key:  models.FileObjectsPrefix + hash,
+			Size: size,
+		}
+
+		// the object key is derived from the content hash so
+		// concurrent uploads are expected to store the same content
+		if err := fsys.UploadFile(file, object.Key); err != nil {
+			return err
+		}
+
+		if err := dao.Save(object); err != nil {
+			// check whether the object was created concurrently
+			existing, findErr := dao.FindFileObjectByHash(hash)
+			if findErr != nil {
+				return err
+			}
+			object = existing
+		}
+	}
+
+	ref, _ := dao.FindFileRefByKey(key)
+	if ref == nil {
+		ref = &models.FileRef{Key: key}
+	}
+	ref.ObjectId = object.Id
+
+	return dao.Save(ref)
+}
+
+// DeleteFile deletes the provided storage file key.
+//
+// If the file key is a file object reference, only the reference is
+// deleted and the referenced file object is removed from the storage
+// together with its last reference.
+func (dao *Dao) DeleteFile(fsys *filesystem.System, key string) error {
+	ref, _ := dao.FindFileRefByKey(key)
+	if ref == nil {
+		return fsys.Delete(key)
+	}
+
+	return dao.deleteFileRef(fsys, ref)
+}
+
+// DeleteFileRefsByPrefix deletes all file object references which keys
+// start with the provided prefix (and their no longer referenced file objects).
+//
+// Note that the non-reference files under the prefix are not deleted.
+func (dao *Dao) DeleteFileRefsByPrefix(fsys *filesystem.System, prefix string) []error {
+	refs, err := dao.FindFileRefsByPrefix(prefix)
+	if err != nil {
+		return []error{err}
+	}
+
+	failed := []error{}
+
+	for _, ref := range refs {
+		if err := dao.deleteFileRef(fsys, ref); err != nil {
+			failed = append(failed, fmt.Errorf("%s: %w", ref.Key, err))
+		}
+	}
+
+	return failed
+}
+
+func (dao *Dao) deleteFileRef(fsys *filesystem.System, ref *models.FileRef) error {
+	if err := dao.Delete(ref); err != nil {
+		return err
+	}
+
+	var total int
+
+	err := dao.FileRefQuery().
+		Select("count(*)").
+		AndWhere(dbx.HashExp{"objectId": ref.ObjectId}).
+		Row(&total)
+	if err != nil || total > 0 {
+		return err
+	}
+
+	object := &models.FileObject{}
+	if err := dao.FileObjectQuery().AndWhere(dbx.HashExp{"id": ref.ObjectId}).Limit(1).One(object); err != nil {
+		return nil // already deleted
+	}
+
+	if err := dao.Delete(object); err != nil {
+		return err
+	}
+
+	return fsys.Delete(object.Key)
+}
+
+// FileDedupStats defines the deduplicated file objects storage usage.
+type FileDedupStats struct {
+	// Objects is the total number of the stored file objects.
+	Objects int `json:"objects"`
+
+	// Refs is the total number of the file object references.
+	Refs int `json:"refs"`
+
+	// StoredSize is the total size in bytes of the stored file objects.
+	StoredSize int64 `json:"storedSize"`
+
+	// SavedSize is the total size in bytes that would have been
+	// additionally stored without the deduplication.
+	SavedSize int64 `json:"savedSize"`
+}
+
+// FileDedupStats returns the deduplicated file objects storage usage.
+func (dao *Dao) FileDedupStats() (*FileDedupStats, error) {
+	stats := &FileDedupStats{}
+
+	err := dao.FileObjectQuery().
+		Select("count(*)", "coalesce(sum([[size]]), 0)").
+		Row(&stats.Objects, &stats.StoredSize)
+	if err != nil {
+		return nil, err
+	}
+
+	var referencedSize int64
+
+	err = dao.FileRefQuery().
+		Select("count(*)", "coalesce(sum([[_fileObjects.size]]), 0)").
+		InnerJoin("{{_fileObjects}}", dbx.NewExp("[[_fileObjects.id]] = [[_fileRefs.objectId]]")).
+		Row(&stats.Refs, &referencedSize)
+	if err != nil {
+		return nil, err
+	}
+
+	stats.SavedSize = referencedSize - stats.StoredSize
+	if stats.SavedSize < 0 {
+		stats.SavedSize = 0
+	}
+
+	return stats, nil
+}
+
+// hashFile returns the hex encoded sha256 hash and the size of the file content.
+func hashFile(file *filesystem.File) (string, int64, error) {
+	f, err := file.Reader.Open()
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return "", 0, err
+	}
+
+	if size == 0 {
+		return "", 0, errors.New("cannot deduplicate an empty file")
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), size, nil
+}
@@ -0,0 +1,111 @@
+package daos_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/filesystem"
+)
+
+func TestUploadDedupFile(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	fsys, err := app.NewFilesystem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fsys.Close()
+
+	file1, _ := filesystem.NewFileFromBytes([]byte("test"), "a.txt")
+	file2, _ := filesystem.NewFileFromBytes([]byte("test"), "b.txt")
+	file3, _ := filesystem.NewFileFromBytes([]byte("other"), "c.txt")
+
+	uploads := []struct {
+		file *filesystem.File
+		key  string
+	}{
+		{file1, "col/rec1/a.txt"},
+		{file2, "col/rec2/b.txt"},
+		{file3, "col/rec2/c.txt"},
+	}
+	for _, u := range uploads {
+		if err := app.Dao().UploadDedupFile(fsys, u.file, u.key); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	objects := []*models.FileObject{}
+	if err := app.Dao().FileObjectQuery().All(&objects); err != nil {
+		t.Fatal(err)
+	}
+	if len(objects) != 2 {
+		t.Fatalf("Expected 2 file objects, got %d", len(objects))
+	}
+
+	key1 := app.Dao().ResolveFileKey("col/rec1/a.txt")
+	key2 := app.Dao().ResolveFileKey("col/rec2/b.txt")
+	if key1 != key2 || key1 == "col/rec1/a.txt" {
+		t.Fatalf("Expected both keys to be resolved to the same file object, got %q and %q", key1, key2)
+	}
+
+	if v := app.Dao().ResolveFileKey("col/rec1/missing.txt"); v != "col/rec1/missing.txt" {
+		t.Fatalf("Expected the non-reference key to be returned as it is, got %q", v)
+	}
+
+	stats, err := app.Dao().FileDedupStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Objects != 2 || stats.Refs != 3 || stats.StoredSize != 9 || stats.SavedSize != 4 {
+		t.Fatalf("Unexpected stats %+v", stats)
+	}
+
+	// delete one of the shared references
+	if err := app.Dao().DeleteFile(fsys, "col/rec1/a.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if exists, _ := fsys.Exists(key2); !exists {
+		t.Fatal("Expected the still referenced file object to be kept")
+	}
+
+	// delete the remaining references
+	if errs := app.Dao().DeleteFileRefsByPrefix(fsys, "col/rec2/"); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	if exists, _ := fsys.Exists(key2); exists {
+		t.Fatal("Expected the no longer referenced file object to be deleted")
+	}
+
+	stats, err = app.Dao().FileDedupStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Objects != 0 || stats.Refs != 0 || stats.StoredSize != 0 || stats.SavedSize != 0 {
+		t.Fatalf("Expected zero stats, got %+v", stats)
+	}
+}
+
+func TestDeleteFileWithoutRef(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	fsys, err := app.NewFilesystem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fsys.Close()
+
+	if err := fsys.Upload([]byte("test"), "col/rec/plain.txt"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().DeleteFile(fsys, "col/rec/plain.txt"); err != nil {
+		t.Fatal(err)
+	}
+
+	if exists, _ := fsys.Exists("col/rec/plain.txt"); exists {
+		t.Fatal("Expected the regular file to be deleted")
+	}
+}
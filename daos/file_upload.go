@@ -0,0 +1,58 @@
+package daos
+
+import (
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// FileUploadQuery returns a new FileUpload select query.
+func (dao *Dao) FileUploadQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.FileUpload{})
+}
+
+// FindFileUploadById finds the file upload with the provided id.
+func (dao *Dao) FindFileUploadById(id string) (*models.FileUpload, error) {
+	model := &models.FileUpload{}
+
+	err := dao.FileUploadQuery().
+		AndWhere(dbx.HashExp{"id": id}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindStaleFileUploads returns all file uploads that
+// were not updated since the specified date.
+func (dao *Dao) FindStaleFileUploads(updatedBefore time.Time) ([]*models.FileUpload, error) {
+	result := []*models.FileUpload{}
+
+	formattedDate := updatedBefore.UTC().Format(types.DefaultDateLayout)
+
+	err := dao.FileUploadQuery().
+		AndWhere(dbx.NewExp("[[updated]] < {:date}", dbx.Params{"date": formattedDate})).
+		All(&result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// SaveFileUpload upserts the provided FileUpload model.
+func (dao *Dao) SaveFileUpload(upload *models.FileUpload) error {
+	return dao.Save(upload)
+}
+
+// DeleteFileUpload deletes the provided FileUpload model.
+func (dao *Dao) DeleteFileUpload(upload *models.FileUpload) error {
+	return dao.Delete(upload)
+}
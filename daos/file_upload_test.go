@@ -0,0 +1,82 @@
+package daos_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestFileUploadCRUD(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	if _, err := app.Dao().FindFileUploadById("missing"); err == nil {
+		t.Fatal("Expected error for missing file upload, got nil")
+	}
+
+	upload := &models.FileUpload{
+		CollectionId: "_pb_users_auth_",
+		RecordId:     "4q1xlclmfloku33",
+		Field:        "file",
+		Filename:     "test.txt",
+		Size:         10,
+		OwnerType:    models.FileUploadOwnerAuthRecord,
+		OwnerId:      "4q1xlclmfloku33",
+	}
+	if err := app.Dao().SaveFileUpload(upload); err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := app.Dao().FindFileUploadById(upload.Id)
+	if err != nil || found.Filename != upload.Filename || found.Size != upload.Size {
+		t.Fatalf("Expected file upload %q, got %v (%v)", upload.Id, found, err)
+	}
+
+	if err := app.Dao().DeleteFileUpload(found); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindFileUploadById(upload.Id); err == nil {
+		t.Fatal("Expected the file upload to be deleted")
+	}
+}
+
+func TestFindStaleFileUploads(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	upload := &models.FileUpload{
+		CollectionId: "_pb_users_auth_",
+		RecordId:     "4q1xlclmfloku33",
+		Field:        "file",
+		Filename:     "test.txt",
+		Size:         10,
+		OwnerType:    models.FileUploadOwnerAuthRecord,
+		OwnerId:      "4q1xlclmfloku33",
+	}
+	if err := app.Dao().SaveFileUpload(upload); err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		updatedBefore time.Time
+		expected      int
+	}{
+		{time.Now().Add(-time.Hour), 0},
+		{time.Now().Add(time.Hour), 1},
+	}
+
+	for i, s := range scenarios {
+		uploads, err := app.Dao().FindStaleFileUploads(s.updatedBefore)
+		if err != nil {
+			t.Errorf("(%d) %v", i, err)
+			continue
+		}
+
+		if len(uploads) != s.expected {
+			t.Errorf("(%d) Expected %d stale uploads, got %d", i, s.expected, len(uploads))
+		}
+	}
+}
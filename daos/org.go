@@ -0,0 +1,363 @@
+package daos
+
+import (
+	"strings"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// OrgQuery returns a new Org select query.
+func (dao *Dao) OrgQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.Org{})
+}
+
+// OrgMemberQuery returns a new OrgMember select query.
+func (dao *Dao) OrgMemberQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.OrgMember{})
+}
+
+// OrgInviteQuery returns a new OrgInvite select query.
+func (dao *Dao) OrgInviteQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.OrgInvite{})
+}
+
+// FindOrgById finds the organization with the provided id.
+func (dao *Dao) FindOrgById(id string) (*models.Org, error) {
+	model := &models.Org{}
+
+	err := dao.OrgQuery().
+		AndWhere(dbx.HashExp{"id": id}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindOrgBySlug finds the organization with the provided slug (case insensitive).
+func (dao *Dao) FindOrgBySlug(slug string) (*models.Org, error) {
+	model := &models.Org{}
+
+	err := dao.OrgQuery().
+		AndWhere(dbx.NewExp("LOWER([[slug]])={:slug}", dbx.Params{"slug": strings.ToLower(slug)})).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// IsOrgSlugUnique checks that there is no other organization
+// with the provided slug (case insensitive).
+func (dao *Dao) IsOrgSlugUnique(slug string, excludeIds ...string) bool {
+	if slug == "" {
+		return false
+	}
+
+	query := dao.OrgQuery().Select("count(*)").
+		AndWhere(dbx.NewExp("LOWER([[slug]])={:slug}", dbx.Params{"slug": strings.ToLower(slug)})).
+		Limit(1)
+
+	if uniqueExcludeIds := list.NonzeroUniques(excludeIds); len(uniqueExcludeIds) > 0 {
+		query.AndWhere(dbx.NotIn("id", list.ToInterfaceSlice(uniqueExcludeIds)...))
+	}
+
+	var exists bool
+
+	return query.Row(&exists) == nil && !exists
+}
+
+// OrgsByAuthRecordQuery returns a new Org select query
+// limited only to the organizations of the provided auth record.
+func (dao *Dao) OrgsByAuthRecordQuery(authRecord *models.Record) *dbx.SelectQuery {
+	membersTable := (&models.OrgMember{}).TableName()
+
+	return dao.OrgQuery().AndWhere(dbx.Exists(
+		dbx.NewExp(
+			"SELECT 1 FROM {{"+membersTable+"}} {{__m}} WHERE [[__m.orgId]] = [[_orgs.id]] AND [[__m.collectionId]] = {:collectionId} AND [[__m.recordId]] = {:recordId}",
+			dbx.Params{
+				"collectionId": authRecord.Collection().Id,
+				"recordId":     authRecord.Id,
+			},
+		),
+	))
+}
+
+// CreateOrg persists the provided new organization and registers
+// the (optional) owner auth record as its first member.
+func (dao *Dao) CreateOrg(org *models.Org, owner *models.Record) error {
+	return dao.RunInTransaction(func(txDao *Dao) error {
+		if err := txDao.Save(org); err != nil {
+			return err
+		}
+
+		if owner == nil {
+			return nil
+		}
+
+		return txDao.Save(&models.OrgMember{
+			OrgId:        org.Id,
+			CollectionId: owner.Collection().Id,
+			RecordId:     owner.Id,
+			Role:         models.OrgRoleOwner,
+		})
+	})
+}
+
+// SaveOrg upserts the provided Org model.
+func (dao *Dao) SaveOrg(org *models.Org) error {
+	return dao.Save(org)
+}
+
+// DeleteOrg deletes the provided Org model together with its members and invites.
+func (dao *Dao) DeleteOrg(org *models.Org) error {
+	return dao.RunInTransaction(func(txDao *Dao) error {
+		if err := txDao.Delete(org); err != nil {
+			return err
+		}
+
+		tables := []string{
+			(&models.OrgMember{}).TableName(),
+			(&models.OrgInvite{}).TableName(),
+		}
+
+		for _, table := range tables {
+			if _, err := txDao.DB().Delete(table, dbx.HashExp{"orgId": org.Id}).Execute(); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// FindOrgMembers returns all members of the provided organization
+// (ordered by their created date).
+func (dao *Dao) FindOrgMembers(orgId string) ([]*models.OrgMember, error) {
+	result := []*models.OrgMember{}
+
+	err := dao.OrgMemberQuery().
+		AndWhere(dbx.HashExp{"orgId": orgId}).
+		OrderBy("created ASC").
+		All(&result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// FindOrgMemberById finds the organization member with the provided id.
+func (dao *Dao) FindOrgMemberById(orgId string, id string) (*models.OrgMember, error) {
+	model := &models.OrgMember{}
+
+	err := dao.OrgMemberQuery().
+		AndWhere(dbx.HashExp{"orgId": orgId, "id": id}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindOrgMemberByRecord finds the membership of the provided
+// auth record in the specified organization.
+func (dao *Dao) FindOrgMemberByRecord(orgId string, authRecord *models.Record) (*models.OrgMember, error) {
+	model := &models.OrgMember{}
+
+	err := dao.OrgMemberQuery().
+		AndWhere(dbx.HashExp{
+			"orgId":        orgId,
+			"collectionId": authRecord.Collection().Id,
+			"recordId":     authRecord.Id,
+		}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindFirstOrgMemberByRecord returns the oldest organization
+// membership of the provided auth record.
+func (dao *Dao) FindFirstOrgMemberByRecord(authRecord *models.Record) (*models.OrgMember, error) {
+	model := &models.OrgMember{}
+
+	err := dao.OrgMemberQuery().
+		AndWhere(dbx.HashExp{
+			"collectionId": authRecord.Collection().Id,
+			"recordId":     authRecord.Id,
+		}).
+		OrderBy("created ASC", "id ASC").
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// CountOrgOwners returns the total number of owners of the provided organization.
+func (dao *Dao) CountOrgOwners(orgId string) (int, error) {
+	var total int
+
+	err := dao.OrgMemberQuery().
+		Select("count(*)").
+		AndWhere(dbx.HashExp{"orgId": orgId, "role": models.OrgRoleOwner}).
+		Row(&total)
+
+	return total, err
+}
+
+// SaveOrgMember upserts the provided OrgMember model.
+func (dao *Dao) SaveOrgMember(member *models.OrgMember) error {
+	return dao.Save(member)
+}
+
+// DeleteOrgMember deletes the provided OrgMember model.
+func (dao *Dao) DeleteOrgMember(member *models.OrgMember) error {
+	return dao.Delete(member)
+}
+
+// deleteOrgMembers deletes (without triggering the model hooks)
+// all organization memberships of the provided auth record.
+func (dao *Dao) deleteOrgMembers(authRecord *models.Record) error {
+	_, err := dao.DB().Delete((&models.OrgMember{}).TableName(), dbx.HashExp{
+		"collectionId": authRecord.Collection().Id,
+		"recordId":     authRecord.Id,
+	}).Execute()
+
+	return err
+}
+
+// FindOrgInvites returns all invites of the provided organization
+// (ordered by their created date).
+func (dao *Dao) FindOrgInvites(orgId string) ([]*models.OrgInvite, error) {
+	result := []*models.OrgInvite{}
+
+	err := dao.OrgInviteQuery().
+		AndWhere(dbx.HashExp{"orgId": orgId}).
+		OrderBy("created ASC").
+		All(&result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// FindOrgInviteById finds the organization invite with the provided id.
+func (dao *Dao) FindOrgInviteById(id string) (*models.OrgInvite, error) {
+	model := &models.OrgInvite{}
+
+	err := dao.OrgInviteQuery().
+		AndWhere(dbx.HashExp{"id": id}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindOrgInviteByEmail finds the invite of the provided email
+// in the specified organization (case insensitive).
+func (dao *Dao) FindOrgInviteByEmail(orgId string, email string) (*models.OrgInvite, error) {
+	model := &models.OrgInvite{}
+
+	err := dao.OrgInviteQuery().
+		AndWhere(dbx.HashExp{"orgId": orgId, "email": strings.ToLower(email)}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindOrgInvitesByEmail returns all non-expired invites
+// of the provided email (case insensitive).
+func (dao *Dao) FindOrgInvitesByEmail(email string) ([]*models.OrgInvite, error) {
+	result := []*models.OrgInvite{}
+
+	err := dao.OrgInviteQuery().
+		AndWhere(dbx.HashExp{"email": strings.ToLower(email)}).
+		AndWhere(dbx.NewExp("[[expires]] > {:now}", dbx.Params{"now": types.NowDateTime().String()})).
+		OrderBy("created ASC").
+		All(&result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// SaveOrgInvite upserts the provided OrgInvite model.
+func (dao *Dao) SaveOrgInvite(invite *models.OrgInvite) error {
+	invite.Email = strings.ToLower(invite.Email)
+
+	return dao.Save(invite)
+}
+
+// DeleteOrgInvite deletes the provided OrgInvite model.
+func (dao *Dao) DeleteOrgInvite(invite *models.OrgInvite) error {
+	return dao.Delete(invite)
+}
+
+// AcceptOrgInvite registers the provided auth record as member of the
+// invite organization (updating its role if it is already a member)
+// and deletes the invite.
+func (dao *Dao) AcceptOrgInvite(invite *models.OrgInvite, authRecord *models.Record) (*models.OrgMember, error) {
+	var member *models.OrgMember
+
+	err := dao.RunInTransaction(func(txDao *Dao) error {
+		var findErr error
+		member, findErr = txDao.FindOrgMemberByRecord(invite.OrgId, authRecord)
+		if findErr != nil {
+			member = &models.OrgMember{
+				OrgId:        invite.OrgId,
+				CollectionId: authRecord.Collection().Id,
+				RecordId:     authRecord.Id,
+				Role:         invite.Role,
+			}
+		} else if !member.HasRole(invite.Role) {
+			member.Role = invite.Role
+		}
+
+		if err := txDao.Save(member); err != nil {
+			return err
+		}
+
+		return txDao.Delete(invite)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
@@ -0,0 +1,246 @@
+package daos_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// createTestOrg creates a new "acme" organization owned by the 4q1xlclmfloku33
+// user with oap640cot4yru2s as member and a pending test3@example.com invite.
+func createTestOrg(t *testing.T, app *tests.TestApp) *models.Org {
+	owner, err := app.Dao().FindRecordById("users", "4q1xlclmfloku33")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	org := &models.Org{Name: "Acme", Slug: "acme"}
+	if err := app.Dao().CreateOrg(org, owner); err != nil {
+		t.Fatal(err)
+	}
+
+	member := &models.OrgMember{
+		OrgId:        org.Id,
+		CollectionId: "_pb_users_auth_",
+		RecordId:     "oap640cot4yru2s",
+		Role:         models.OrgRoleMember,
+	}
+	if err := app.Dao().SaveOrgMember(member); err != nil {
+		t.Fatal(err)
+	}
+
+	expires, _ := types.ParseDateTime(time.Now().Add(time.Hour))
+	invite := &models.OrgInvite{OrgId: org.Id, Email: "TEST3@example.com", Role: models.OrgRoleAdmin, Expires: expires}
+	if err := app.Dao().SaveOrgInvite(invite); err != nil {
+		t.Fatal(err)
+	}
+
+	return org
+}
+
+func TestCreateOrg(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	org := createTestOrg(t, app)
+
+	members, err := app.Dao().FindOrgMembers(org.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(members) != 2 {
+		t.Fatalf("Expected 2 members, got %d", len(members))
+	}
+
+	if members[0].RecordId != "4q1xlclmfloku33" || members[0].Role != models.OrgRoleOwner {
+		t.Fatalf("Expected the first member to be the owner, got %v", members[0])
+	}
+
+	if total, err := app.Dao().CountOrgOwners(org.Id); err != nil || total != 1 {
+		t.Fatalf("Expected 1 owner, got %d (%v)", total, err)
+	}
+
+	// without owner
+	org2 := &models.Org{Name: "Other", Slug: "other"}
+	if err := app.Dao().CreateOrg(org2, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	members2, _ := app.Dao().FindOrgMembers(org2.Id)
+	if len(members2) != 0 {
+		t.Fatalf("Expected no members, got %d", len(members2))
+	}
+}
+
+func TestFindOrgs(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	org := createTestOrg(t, app)
+
+	if found, err := app.Dao().FindOrgById(org.Id); err != nil || found.Slug != "acme" {
+		t.Fatalf("Expected to find the org by id, got %v (%v)", found, err)
+	}
+
+	if found, err := app.Dao().FindOrgBySlug("ACME"); err != nil || found.Id != org.Id {
+		t.Fatalf("Expected to find the org by slug, got %v (%v)", found, err)
+	}
+
+	if app.Dao().IsOrgSlugUnique("Acme") {
+		t.Fatal("Expected the slug to not be unique")
+	}
+
+	if !app.Dao().IsOrgSlugUnique("acme", org.Id) {
+		t.Fatal("Expected the slug to be unique when excluding the org")
+	}
+
+	scenarios := []struct {
+		recordId string
+		expected int
+	}{
+		{"4q1xlclmfloku33", 1},
+		{"oap640cot4yru2s", 1},
+		{"bgs820n361vj1qd", 0},
+	}
+
+	for _, s := range scenarios {
+		record, err := app.Dao().FindRecordById("users", s.recordId)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		orgs := []*models.Org{}
+		if err := app.Dao().OrgsByAuthRecordQuery(record).All(&orgs); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(orgs) != s.expected {
+			t.Errorf("(%s) Expected %d orgs, got %d", s.recordId, s.expected, len(orgs))
+		}
+
+		member, err := app.Dao().FindFirstOrgMemberByRecord(record)
+		if s.expected == 0 {
+			if err == nil {
+				t.Errorf("(%s) Expected no membership, got %v", s.recordId, member)
+			}
+			continue
+		}
+		if err != nil || member.OrgId != org.Id {
+			t.Errorf("(%s) Expected membership in %q, got %v (%v)", s.recordId, org.Id, member, err)
+		}
+	}
+}
+
+func TestDeleteOrg(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	org := createTestOrg(t, app)
+
+	if err := app.Dao().DeleteOrg(org); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindOrgById(org.Id); err == nil {
+		t.Fatal("Expected the org to be deleted")
+	}
+
+	members, _ := app.Dao().FindOrgMembers(org.Id)
+	invites, _ := app.Dao().FindOrgInvites(org.Id)
+	if len(members) != 0 || len(invites) != 0 {
+		t.Fatalf("Expected the members and invites to be deleted, got %d and %d", len(members), len(invites))
+	}
+}
+
+func TestDeleteRecordOrgMembers(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	org := createTestOrg(t, app)
+
+	record, err := app.Dao().FindRecordById("users", "oap640cot4yru2s")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().DeleteRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	members, _ := app.Dao().FindOrgMembers(org.Id)
+	if len(members) != 1 || members[0].RecordId != "4q1xlclmfloku33" {
+		t.Fatalf("Expected only the owner membership to remain, got %v", members)
+	}
+}
+
+func TestOrgInvites(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	org := createTestOrg(t, app)
+
+	invite, err := app.Dao().FindOrgInviteByEmail(org.Id, "test3@EXAMPLE.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if invite.Email != "test3@example.com" {
+		t.Fatalf("Expected the invite email to be normalized, got %q", invite.Email)
+	}
+
+	// expired invite
+	expired := &models.OrgInvite{OrgId: "other", Email: "test3@example.com", Role: models.OrgRoleMember}
+	if err := app.Dao().SaveOrgInvite(expired); err != nil {
+		t.Fatal(err)
+	}
+
+	invites, err := app.Dao().FindOrgInvitesByEmail("Test3@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(invites) != 1 || invites[0].Id != invite.Id {
+		t.Fatalf("Expected only the non-expired invite, got %v", invites)
+	}
+
+	record, err := app.Dao().FindRecordById("users", "bgs820n361vj1qd")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	member, err := app.Dao().AcceptOrgInvite(invite, record)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if member.OrgId != org.Id || member.RecordId != record.Id || member.Role != models.OrgRoleAdmin {
+		t.Fatalf("Unexpected member %v", member)
+	}
+
+	if _, err := app.Dao().FindOrgInviteById(invite.Id); err == nil {
+		t.Fatal("Expected the accepted invite to be deleted")
+	}
+
+	// accepting a lower role invite doesn't downgrade the existing member
+	lower := &models.OrgInvite{OrgId: org.Id, Email: "test3@example.com", Role: models.OrgRoleMember}
+	if err := app.Dao().SaveOrgInvite(lower); err != nil {
+		t.Fatal(err)
+	}
+
+	member, err = app.Dao().AcceptOrgInvite(lower, record)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if member.Role != models.OrgRoleAdmin {
+		t.Fatalf("Expected the member role to remain admin, got %q", member.Role)
+	}
+
+	members, _ := app.Dao().FindOrgMembers(org.Id)
+	if len(members) != 3 {
+		t.Fatalf("Expected 3 members, got %d", len(members))
+	}
+}
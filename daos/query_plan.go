@@ -0,0 +1,183 @@
+package daos
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/dbutils"
+	"github.com/pocketbase/pocketbase/tools/list"
+)
+
+var (
+	// matches string literals and unnamed "?" placeholders
+	unnamedParamRegex = regexp.MustCompile(`'(?:[^']|'')*'|\?`)
+
+	// matches full table scans, eg. "SCAN demo" or "SCAN TABLE demo AS d" (older sqlite versions)
+	planScanRegex = regexp.MustCompile(`^SCAN (?:TABLE )?(\w+)(?: AS (\w+))?`)
+
+	// matches "FROM/JOIN table [AS] alias" expressions
+	tableAliasRegex = regexp.MustCompile("(?i)\\b(?:FROM|JOIN)\\s+[`\"\\[]?(\\w+)[`\"\\]]?(?:\\s+(?:AS\\s+)?[`\"\\[]?(\\w+)[`\"\\]]?)?")
+
+	// matches "[table.]column OPERATOR" comparison expressions
+	filterColumnRegex = regexp.MustCompile("(?i)(?:[`\"\\[]?(\\w+)[`\"\\]]?\\.)?[`\"\\[]?(\\w+)[`\"\\]]?\\s*(?:=|!=|<>|>=|<=|>|<|\\bLIKE\\b|\\bIN\\b|\\bIS\\b|\\bGLOB\\b)")
+)
+
+// sqlKeywords is a list with keywords that could be matched
+// as table aliases by the tableAliasRegex.
+var sqlKeywords = []string{
+	"where", "on", "using", "left", "right", "inner", "outer", "cross", "full",
+	"natural", "join", "group", "order", "limit", "offset", "having", "union", "window",
+}
+
+// QueryPlanDetails returns the "detail" column values of
+// the "EXPLAIN QUERY PLAN" result for the provided query.
+//
+// Unbound "?" query placeholders are treated as NULL.
+//
+// Be aware that this method is vulnerable to SQL injection and the
+// "query" argument must come only from trusted input!
+func (dao *Dao) QueryPlanDetails(query string) ([]string, error) {
+	rows := []struct {
+		Detail string `db:"detail"`
+	}{}
+
+	// bind the unnamed placeholders as NULL params
+	params := dbx.Params{}
+	query = unnamedParamRegex.ReplaceAllStringFunc(query, func(match string) string {
+		if match != "?" {
+			return match // string literal
+		}
+
+		name := "p" + strconv.Itoa(len(params))
+		params[name] = nil
+
+		return "{:" + name + "}"
+	})
+
+	err := dao.DB().NewQuery("EXPLAIN QUERY PLAN " + query).Bind(params).All(&rows)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]string, len(rows))
+	for i, row := range rows {
+		result[i] = row.Detail
+	}
+
+	return result, nil
+}
+
+// SuggestIndexes inspects the query plan of the provided query and returns
+// single column index suggestions for the fully scanned collection tables
+// whose filtered columns are not covered by an existing index.
+//
+// Be aware that this method is vulnerable to SQL injection and the
+// "query" argument must come only from trusted input!
+func (dao *Dao) SuggestIndexes(query string) ([]*models.IndexSuggestion, error) {
+	details, err := dao.QueryPlanDetails(query)
+	if err != nil {
+		return nil, err
+	}
+
+	aliases := map[string]string{}
+	for _, match := range tableAliasRegex.FindAllStringSubmatch(query, -1) {
+		aliases[strings.ToLower(match[1])] = match[1]
+		if match[2] != "" && !list.ExistInSlice(strings.ToLower(match[2]), sqlKeywords) {
+			aliases[strings.ToLower(match[2])] = match[1]
+		}
+	}
+
+	// resolve the fully scanned tables
+	scanned := []string{}
+	for _, detail := range details {
+		match := planScanRegex.FindStringSubmatch(detail)
+		if len(match) == 0 {
+			continue
+		}
+
+		table := match[1]
+		if t, ok := aliases[strings.ToLower(table)]; ok {
+			table = t
+		}
+
+		if !list.ExistInSlice(table, scanned) {
+			scanned = append(scanned, table)
+		}
+	}
+
+	result := []*models.IndexSuggestion{}
+
+	if len(scanned) == 0 {
+		return result, nil
+	}
+
+	filters := filterColumnRegex.FindAllStringSubmatch(query, -1)
+
+	for _, table := range scanned {
+		collection, err := dao.FindCollectionByNameOrId(table)
+		if err != nil || collection.IsView() || !strings.EqualFold(collection.Name, table) {
+			continue // not a collection table
+		}
+
+		info, err := dao.TableInfo(collection.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		indexed, err := dao.indexedColumns(collection.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, match := range filters {
+			if match[1] != "" && !strings.EqualFold(aliases[strings.ToLower(match[1])], collection.Name) {
+				continue // column of another table
+			}
+
+			for _, col := range info {
+				if col.PK > 0 || !strings.EqualFold(col.Name, match[2]) || list.ExistInSlice(strings.ToLower(col.Name), indexed) {
+					continue
+				}
+
+				indexed = append(indexed, strings.ToLower(col.Name))
+
+				idx := dbutils.Index{
+					IndexName: "idx_" + collection.Name + "_" + col.Name,
+					TableName: collection.Name,
+					Columns:   []dbutils.IndexColumn{{Name: col.Name}},
+				}
+
+				result = append(result, &models.IndexSuggestion{
+					Collection: collection.Name,
+					Column:     col.Name,
+					Sql:        idx.Build(),
+				})
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// indexedColumns returns the lowercased names of the table columns
+// that are used as first (aka. leftmost) column of an index.
+func (dao *Dao) indexedColumns(tableName string) ([]string, error) {
+	indexes, err := dao.TableIndexes(tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]string, 0, len(indexes))
+
+	for _, sql := range indexes {
+		parsed := dbutils.ParseIndex(sql)
+		if len(parsed.Columns) > 0 {
+			result = append(result, strings.ToLower(parsed.Columns[0].Name))
+		}
+	}
+
+	return result, nil
+}
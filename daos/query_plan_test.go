@@ -0,0 +1,75 @@
+package daos_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestQueryPlanDetails(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	if _, err := app.Dao().QueryPlanDetails("SELECT * FROM missing"); err == nil {
+		t.Fatal("Expected error for missing table, got nil")
+	}
+
+	details, err := app.Dao().QueryPlanDetails("SELECT * FROM demo1 WHERE text = ?")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(details) != 1 || !strings.HasPrefix(details[0], "SCAN demo1") {
+		t.Fatalf("Expected demo1 scan, got %v", details)
+	}
+}
+
+func TestSuggestIndexes(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		query       string
+		expectError bool
+		expected    string
+	}{
+		{"SELECT * FROM missing", true, ""},
+		{"SELECT * FROM demo1", false, `[]`},
+		{"SELECT * FROM demo1 WHERE id = ?", false, `[]`},
+		// already indexed column
+		{"SELECT * FROM `demo2` WHERE `demo2`.`title` = ?", false, `[]`},
+		// non collection table
+		{"SELECT * FROM _admins WHERE avatar = ?", false, `[]`},
+		{
+			"SELECT `demo1`.* FROM `demo1` WHERE (`demo1`.`text` = ? AND `demo1`.`number` > ?) ORDER BY `demo1`.`created`",
+			false,
+			"[{\"collection\":\"demo1\",\"column\":\"text\",\"sql\":\"CREATE INDEX `idx_demo1_text` ON `demo1` (`text`)\"},{\"collection\":\"demo1\",\"column\":\"number\",\"sql\":\"CREATE INDEX `idx_demo1_number` ON `demo1` (`number`)\"}]",
+		},
+		{
+			"SELECT d.* FROM demo1 AS d LEFT JOIN demo2 x ON x.id = d.rel_one WHERE d.text LIKE ? OR d.text = ? OR x.title = ?",
+			false,
+			"[{\"collection\":\"demo1\",\"column\":\"text\",\"sql\":\"CREATE INDEX `idx_demo1_text` ON `demo1` (`text`)\"}]",
+		},
+	}
+
+	for i, s := range scenarios {
+		result, err := app.Dao().SuggestIndexes(s.query)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("(%d) Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+			continue
+		}
+
+		if hasErr {
+			continue
+		}
+
+		encoded, _ := json.Marshal(result)
+		if string(encoded) != s.expected {
+			t.Errorf("(%d) Expected \n%s, \ngot \n%s", i, s.expected, encoded)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package daos
+
+import (
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// RateLimitQuery returns a new RateLimit select query.
+func (dao *Dao) RateLimitQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.RateLimit{})
+}
+
+// FindRateLimitByIdentity finds the rate limit override of the provided identity.
+func (dao *Dao) FindRateLimitByIdentity(identityId string) (*models.RateLimit, error) {
+	model := &models.RateLimit{}
+
+	err := dao.RateLimitQuery().
+		AndWhere(dbx.HashExp{"identityId": identityId}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// DeleteRateLimit deletes the provided RateLimit model.
+func (dao *Dao) DeleteRateLimit(rateLimit *models.RateLimit) error {
+	return dao.Delete(rateLimit)
+}
+
+// SaveRateLimit upserts the provided RateLimit model.
+func (dao *Dao) SaveRateLimit(rateLimit *models.RateLimit) error {
+	return dao.Save(rateLimit)
+}
@@ -0,0 +1,75 @@
+package daos_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestRateLimitQuery(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	expected := "SELECT {{_rateLimits}}.* FROM `_rateLimits`"
+
+	sql := app.Dao().RateLimitQuery().Build().SQL()
+	if sql != expected {
+		t.Errorf("Expected sql %s, got %s", expected, sql)
+	}
+}
+
+func TestFindRateLimitByIdentity(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		identityId  string
+		expectError bool
+		expectedId  string
+	}{
+		{"", true, ""},
+		{"missing", true, ""},
+		{"4q1xlclmfloku33", false, "rl1x0d3kq8v2m7n"},
+	}
+
+	for i, scenario := range scenarios {
+		rateLimit, err := app.Dao().FindRateLimitByIdentity(scenario.identityId)
+
+		hasErr := err != nil
+		if hasErr != scenario.expectError {
+			t.Errorf("(%d) Expected hasErr to be %v, got %v (%v)", i, scenario.expectError, hasErr, err)
+		}
+
+		if rateLimit != nil && rateLimit.Id != scenario.expectedId {
+			t.Errorf("(%d) Expected rate limit with id %s, got %s", i, scenario.expectedId, rateLimit.Id)
+		}
+	}
+}
+
+func TestSaveAndDeleteRateLimit(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	rateLimit := &models.RateLimit{
+		IdentityId:  "127.0.0.1",
+		MaxRequests: 10,
+		Duration:    60,
+	}
+
+	if err := app.Dao().SaveRateLimit(rateLimit); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindRateLimitByIdentity("127.0.0.1"); err != nil {
+		t.Fatalf("Expected the rate limit to be saved, got %v", err)
+	}
+
+	if err := app.Dao().DeleteRateLimit(rateLimit); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindRateLimitByIdentity("127.0.0.1"); err == nil {
+		t.Fatal("Expected the rate limit to be deleted")
+	}
+}
@@ -16,7 +16,21 @@ import (
 )
 
 // RecordQuery returns a new Record select query.
+//
+// The trashed records of the soft delete collections are excluded
+// (see [Dao.TrashedRecordQuery]).
 func (dao *Dao) RecordQuery(collection *models.Collection) *dbx.SelectQuery {
+	query := dao.recordQuery(collection)
+
+	if collection.IsSoftDelete() {
+		query.AndWhere(dbx.HashExp{collection.Name + "." + schema.FieldNameDeletedAt: ""})
+	}
+
+	return query
+}
+
+// recordQuery returns a new Record select query for all collection table rows.
+func (dao *Dao) recordQuery(collection *models.Collection) *dbx.SelectQuery {
 	tableName := collection.Name
 	selectCols := fmt.Sprintf("%s.*", dao.DB().QuoteSimpleColumnName(tableName))
 
@@ -456,6 +470,28 @@ func (dao *Dao) DeleteRecord(record *models.Record) error {
 			return err
 		}
 
+		if err := txDao.deleteRecordShares(record); err != nil {
+			return err
+		}
+
+		if err := txDao.deleteRecordComments(record); err != nil {
+			return err
+		}
+
+		if err := txDao.deleteRecordRevisions(record); err != nil {
+			return err
+		}
+
+		if err := txDao.deleteRecordLock(record); err != nil {
+			return err
+		}
+
+		if record.Collection().IsAuth() {
+			if err := txDao.deleteOrgMembers(record); err != nil {
+				return err
+			}
+		}
+
 		return txDao.cascadeRecordDelete(record, refs)
 	})
 }
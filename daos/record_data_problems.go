@@ -0,0 +1,271 @@
+package daos
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/types"
+	"github.com/spf13/cast"
+)
+
+// recordDataProblemsBatchSize is the number of the records
+// loaded at once while checking the collection records data.
+const recordDataProblemsBatchSize = 1000
+
+// FindRecordDataProblems checks the stored records data of the provided
+// collection against its current schema and returns the found problems
+// (type mismatches, invalid select values and orphaned relation ids)
+// ordered by the record creation date.
+//
+// View collections are not supported since their data is not stored.
+func (dao *Dao) FindRecordDataProblems(collection *models.Collection) ([]*models.RecordDataProblem, error) {
+	if collection.IsView() {
+		return nil, errors.New("view collections data can't be validated")
+	}
+
+	result := []*models.RecordDataProblem{}
+
+	// cache with the existing relation ids per related collection
+	existingIds := map[string]map[string]struct{}{}
+
+	for offset := 0; ; offset += recordDataProblemsBatchSize {
+		rows := []dbx.NullStringMap{}
+
+		err := dao.DB().Select("*").
+			From(collection.Name).
+			OrderBy("created ASC", "id ASC").
+			Limit(recordDataProblemsBatchSize).
+			Offset(int64(offset)).
+			All(&rows)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, field := range collection.Schema.Fields() {
+			if field.Type != schema.FieldTypeRelation {
+				continue
+			}
+
+			if err := dao.loadExistingRelationIds(field, rows, existingIds); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, row := range rows {
+			for _, field := range collection.Schema.Fields() {
+				raw, ok := row[field.Name]
+				if !ok || !raw.Valid {
+					continue // missing column or NULL
+				}
+
+				if problem := checkRecordFieldData(field, raw.String, existingIds); problem != nil {
+					problem.RecordId = row["id"].String
+					problem.Field = field.Name
+					result = append(result, problem)
+				}
+			}
+		}
+
+		if len(rows) < recordDataProblemsBatchSize {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// loadExistingRelationIds loads into the provided cache the existing
+// related record ids referenced by the specified relation field rows.
+func (dao *Dao) loadExistingRelationIds(
+	field *schema.SchemaField,
+	rows []dbx.NullStringMap,
+	cache map[string]map[string]struct{},
+) error {
+	options, _ := field.Options.(*schema.RelationOptions)
+	if options == nil {
+		return nil
+	}
+
+	if cache[options.CollectionId] == nil {
+		cache[options.CollectionId] = map[string]struct{}{}
+	}
+
+	ids := []any{}
+	for _, row := range rows {
+		for _, id := range list.ToUniqueStringSlice(row[field.Name].String) {
+			ids = append(ids, id)
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	relCollection, err := dao.FindCollectionByNameOrId(options.CollectionId)
+	if err != nil {
+		return nil // the related collection is missing, so all ids are orphaned
+	}
+
+	existing := []string{}
+
+	err = dao.DB().Select("id").
+		From(relCollection.Name).
+		Where(dbx.In("id", ids...)).
+		Column(&existing)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range existing {
+		cache[options.CollectionId][id] = struct{}{}
+	}
+
+	return nil
+}
+
+// checkRecordFieldData checks a single raw record field value
+// against its schema and returns the found problem (if any).
+func checkRecordFieldData(
+	field *schema.SchemaField,
+	raw string,
+	existingIds map[string]map[string]struct{},
+) *models.RecordDataProblem {
+	switch field.Type {
+	case schema.FieldTypeNumber:
+		if _, err := strconv.ParseFloat(raw, 64); err != nil {
+			return typeMismatchProblem(raw, "number", cast.ToFloat64(raw))
+		}
+	case schema.FieldTypeBool:
+		// note: the driver could return the BOOLEAN columns as "true"/"false"
+		if !list.ExistInSlice(raw, []string{"0", "1", "true", "false"}) {
+			return typeMismatchProblem(raw, "bool", cast.ToBool(raw))
+		}
+	case schema.FieldTypeDate:
+		if raw != "" {
+			// unparsable dates are silently normalized to zero
+			if date, err := types.ParseDateTime(raw); err != nil || date.IsZero() {
+				return typeMismatchProblem(raw, "date", "")
+			}
+		}
+	case schema.FieldTypeEmail:
+		if err := validation.Validate(raw, is.EmailFormat); err != nil {
+			return typeMismatchProblem(raw, "email", "")
+		}
+	case schema.FieldTypeUrl:
+		if err := validation.Validate(raw, is.URL); err != nil {
+			return typeMismatchProblem(raw, "url", "")
+		}
+	case schema.FieldTypeJson:
+		if raw != "" && !json.Valid([]byte(raw)) {
+			return typeMismatchProblem(raw, "json", nil)
+		}
+	case schema.FieldTypeGeoPoint:
+		if raw != "" {
+			if point, err := types.ParseGeoPoint(raw); err != nil || !point.IsValid() {
+				return typeMismatchProblem(raw, "geoPoint", nil)
+			}
+		}
+	case schema.FieldTypeSelect:
+		options, _ := field.Options.(*schema.SelectOptions)
+		if options == nil {
+			return nil
+		}
+
+		return checkMultiValueFieldData(raw, options.MaxSelect, func(value string) bool {
+			return list.ExistInSlice(value, options.Values)
+		}, models.RecordDataProblemInvalidSelectValue, "invalid select value(s)")
+	case schema.FieldTypeRelation:
+		options, _ := field.Options.(*schema.RelationOptions)
+		if options == nil {
+			return nil
+		}
+
+		maxSelect := 0
+		if options.MaxSelect != nil {
+			maxSelect = *options.MaxSelect
+		}
+
+		return checkMultiValueFieldData(raw, maxSelect, func(value string) bool {
+			_, ok := existingIds[options.CollectionId][value]
+			return ok
+		}, models.RecordDataProblemOrphanedRelation, "orphaned relation id(s)")
+	case schema.FieldTypeFile:
+		options, _ := field.Options.(*schema.FileOptions)
+		if options == nil {
+			return nil
+		}
+
+		return checkMultiValueFieldData(raw, options.MaxSelect, nil, "", "")
+	}
+
+	return nil
+}
+
+// checkMultiValueFieldData checks the raw single/multiple value format
+// and optionally each individual value with the provided isValid func.
+func checkMultiValueFieldData(
+	raw string,
+	maxSelect int,
+	isValid func(value string) bool,
+	invalidCode string,
+	invalidMessage string,
+) *models.RecordDataProblem {
+	values := list.ToUniqueStringSlice(raw)
+
+	valid := make([]string, 0, len(values))
+	for _, v := range values {
+		if isValid == nil || isValid(v) {
+			valid = append(valid, v)
+		}
+	}
+
+	isMultiple := maxSelect != 1
+	isArray := strings.HasPrefix(strings.TrimSpace(raw), "[")
+
+	var suggested any
+	if isMultiple {
+		suggested = valid
+	} else if len(valid) > 0 {
+		suggested = valid[0]
+	} else {
+		suggested = ""
+	}
+
+	if len(valid) != len(values) {
+		return &models.RecordDataProblem{
+			Code:           invalidCode,
+			Message:        fmt.Sprintf("The value contains %s.", invalidMessage),
+			Value:          raw,
+			SuggestedValue: suggested,
+		}
+	}
+
+	if raw != "" && isMultiple != isArray {
+		expected := "single"
+		if isMultiple {
+			expected = "multiple"
+		}
+
+		return typeMismatchProblem(raw, expected, suggested)
+	}
+
+	return nil
+}
+
+func typeMismatchProblem(raw string, expectedType string, suggested any) *models.RecordDataProblem {
+	return &models.RecordDataProblem{
+		Code:           models.RecordDataProblemTypeMismatch,
+		Message:        fmt.Sprintf("The value is not a valid %s value.", expectedType),
+		Value:          raw,
+		SuggestedValue: suggested,
+	}
+}
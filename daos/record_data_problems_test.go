@@ -0,0 +1,127 @@
+package daos_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestFindRecordDataProblemsWithValidData(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collections, err := app.Dao().FindCollectionsByType(models.CollectionTypeBase)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authCollections, err := app.Dao().FindCollectionsByType(models.CollectionTypeAuth)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, collection := range append(collections, authCollections...) {
+		problems, err := app.Dao().FindRecordDataProblems(collection)
+		if err != nil {
+			t.Fatalf("[%s] %v", collection.Name, err)
+		}
+
+		if len(problems) != 0 {
+			raw, _ := json.Marshal(problems)
+			t.Errorf("[%s] Expected no problems, got %s", collection.Name, raw)
+		}
+	}
+}
+
+func TestFindRecordDataProblemsWithView(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, err := app.Dao().FindCollectionByNameOrId("view1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindRecordDataProblems(collection); err == nil {
+		t.Fatal("Expected view collection error, got nil")
+	}
+}
+
+func TestFindRecordDataProblems(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	_, err := app.Dao().DB().NewQuery(`
+		UPDATE demo1 SET
+			[[number]]      = 'abc',
+			[[bool]]        = 'TRUE',
+			[[email]]       = 'invalid',
+			[[url]]         = 'invalid',
+			[[datetime]]    = 'invalid',
+			[[json]]        = '{invalid',
+			[[select_one]]  = '["optionA","optionB"]',
+			[[select_many]] = '["optionA","missing"]',
+			[[rel_one]]     = 'missing',
+			[[rel_many]]    = '["oap640cot4yru2s","missing"]',
+			[[file_many]]   = 'test.txt'
+		WHERE [[id]] = '84nmscqy84lsi1t'
+	`).Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	collection, err := app.Dao().FindCollectionByNameOrId("demo1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	problems, err := app.Dao().FindRecordDataProblems(collection)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]struct {
+		code      string
+		suggested string
+	}{
+		"number":      {models.RecordDataProblemTypeMismatch, `0`},
+		"bool":        {models.RecordDataProblemTypeMismatch, `true`},
+		"email":       {models.RecordDataProblemTypeMismatch, `""`},
+		"url":         {models.RecordDataProblemTypeMismatch, `""`},
+		"datetime":    {models.RecordDataProblemTypeMismatch, `""`},
+		"json":        {models.RecordDataProblemTypeMismatch, `null`},
+		"select_one":  {models.RecordDataProblemTypeMismatch, `"optionA"`},
+		"select_many": {models.RecordDataProblemInvalidSelectValue, `["optionA"]`},
+		"rel_one":     {models.RecordDataProblemOrphanedRelation, `""`},
+		"rel_many":    {models.RecordDataProblemOrphanedRelation, `["oap640cot4yru2s"]`},
+		"file_many":   {models.RecordDataProblemTypeMismatch, `["test.txt"]`},
+	}
+
+	if len(problems) != len(expected) {
+		raw, _ := json.Marshal(problems)
+		t.Fatalf("Expected %d problems, got %d: %s", len(expected), len(problems), raw)
+	}
+
+	for _, problem := range problems {
+		if problem.RecordId != "84nmscqy84lsi1t" {
+			t.Errorf("[%s] Expected record 84nmscqy84lsi1t, got %q", problem.Field, problem.RecordId)
+		}
+
+		e, ok := expected[problem.Field]
+		if !ok {
+			t.Errorf("Unexpected problem for field %q", problem.Field)
+			continue
+		}
+
+		if problem.Code != e.code {
+			t.Errorf("[%s] Expected code %q, got %q", problem.Field, e.code, problem.Code)
+		}
+
+		suggested, _ := json.Marshal(problem.SuggestedValue)
+		if string(suggested) != e.suggested {
+			t.Errorf("[%s] Expected suggested value %s, got %s", problem.Field, e.suggested, suggested)
+		}
+	}
+}
@@ -20,6 +20,9 @@ import (
 const MaxExpandDepth = 6
 
 // ExpandFetchFunc defines the function that is used to fetch the expanded relation records.
+//
+// The function is called once per expand path level with the unique
+// ids of all relations that need to be loaded from relCollection.
 type ExpandFetchFunc func(relCollection *models.Collection, relIds []string) ([]*models.Record, error)
 
 // ExpandRecord expands the relations of a single Record model.
@@ -37,8 +40,12 @@ func (dao *Dao) ExpandRecords(records []*models.Record, expands []string, fetchF
 
 	failed := map[string]error{}
 
+	// shared between the expand paths to avoid loading
+	// the same related collection multiple times
+	collections := map[string]*models.Collection{}
+
 	for _, expand := range normalized {
-		if err := dao.expandRecords(records, expand, fetchFunc, 1); err != nil {
+		if err := dao.expandRecords(records, expand, fetchFunc, collections, 1); err != nil {
 			failed[expand] = err
 		}
 	}
@@ -53,7 +60,14 @@ var indirectExpandRegex = regexp.MustCompile(`^(\w+)\((\w+)\)$`)
 // - all records are expected to be from the same collection
 // - if MaxExpandDepth is reached, the function returns nil ignoring the remaining expand path
 // - indirect expands are supported only with single relation fields
-func (dao *Dao) expandRecords(records []*models.Record, expandPath string, fetchFunc ExpandFetchFunc, recursionLevel int) error {
+// - collections is used as cache for the already loaded related collections
+func (dao *Dao) expandRecords(
+	records []*models.Record,
+	expandPath string,
+	fetchFunc ExpandFetchFunc,
+	collections map[string]*models.Collection,
+	recursionLevel int,
+) error {
 	if fetchFunc == nil {
 		return errors.New("Relation records fetchFunc is not set.")
 	}
@@ -72,7 +86,7 @@ func (dao *Dao) expandRecords(records []*models.Record, expandPath string, fetch
 	matches := indirectExpandRegex.FindStringSubmatch(parts[0])
 
 	if len(matches) == 3 {
-		indirectRel, _ := dao.FindCollectionByNameOrId(matches[1])
+		indirectRel := dao.findExpandCollection(collections, matches[1])
 		if indirectRel == nil {
 			return fmt.Errorf("Couldn't find indirect related collection %q.", matches[1])
 		}
@@ -150,7 +164,7 @@ func (dao *Dao) expandRecords(records []*models.Record, expandPath string, fetch
 			return fmt.Errorf("Couldn't initialize the options of relation field %q.", parts[0])
 		}
 
-		relCollection, _ = dao.FindCollectionByNameOrId(relFieldOptions.CollectionId)
+		relCollection = dao.findExpandCollection(collections, relFieldOptions.CollectionId)
 		if relCollection == nil {
 			return fmt.Errorf("Couldn't find related collection %q.", relFieldOptions.CollectionId)
 		}
@@ -163,6 +177,7 @@ func (dao *Dao) expandRecords(records []*models.Record, expandPath string, fetch
 	for _, record := range records {
 		relIds = append(relIds, record.GetStringSlice(relField.Name)...)
 	}
+	relIds = list.ToUniqueStringSlice(relIds)
 
 	// fetch rels
 	rels, relsErr := fetchFunc(relCollection, relIds)
@@ -172,7 +187,7 @@ func (dao *Dao) expandRecords(records []*models.Record, expandPath string, fetch
 
 	// expand nested fields
 	if len(parts) > 1 {
-		err := dao.expandRecords(rels, parts[1], fetchFunc, recursionLevel+1)
+		err := dao.expandRecords(rels, parts[1], fetchFunc, collections, recursionLevel+1)
 		if err != nil {
 			return err
 		}
@@ -236,6 +251,23 @@ func (dao *Dao) expandRecords(records []*models.Record, expandPath string, fetch
 	return nil
 }
 
+// findExpandCollection returns the collection with the provided name or id
+// from the collections cache, loading it from the db if missing.
+//
+// Returns nil if the collection doesn't exist.
+func (dao *Dao) findExpandCollection(collections map[string]*models.Collection, nameOrId string) *models.Collection {
+	if collection, ok := collections[nameOrId]; ok {
+		return collection
+	}
+
+	collection, _ := dao.FindCollectionByNameOrId(nameOrId)
+	if collection != nil {
+		collections[nameOrId] = collection
+	}
+
+	return collection
+}
+
 // normalizeExpands normalizes expand strings and merges self containing paths
 // (eg. ["a.b.c", "a.b", "   test  ", "  ", "test"] -> ["a.b.c", "test"]).
 func normalizeExpands(paths []string) []string {
@@ -416,3 +416,44 @@ func TestIndirectExpandSingeVsArrayResult(t *testing.T) {
 		}
 	}
 }
+
+func TestExpandRecordsUniqueFetchIds(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	records, err := app.Dao().FindRecordsByIds("demo1", []string{"al1h9ijdeojtsjy", "84nmscqy84lsi1t"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	calls := [][]string{}
+
+	failed := app.Dao().ExpandRecords(records, []string{"rel_many"}, func(c *models.Collection, ids []string) ([]*models.Record, error) {
+		calls = append(calls, ids)
+		return app.Dao().FindRecordsByIds(c.Id, ids, nil)
+	})
+	if len(failed) > 0 {
+		t.Fatal(failed)
+	}
+
+	if len(calls) != 1 {
+		t.Fatalf("Expected fetchFunc to be called once, got %d times", len(calls))
+	}
+
+	expectedIds := []string{"oap640cot4yru2s", "bgs820n361vj1qd", "4q1xlclmfloku33"}
+	if len(calls[0]) != len(expectedIds) {
+		t.Fatalf("Expected ids %v, got %v", expectedIds, calls[0])
+	}
+	for _, id := range expectedIds {
+		if !list.ExistInSlice(id, calls[0]) {
+			t.Fatalf("Missing id %q in %v", id, calls[0])
+		}
+	}
+
+	for _, record := range records {
+		expanded, _ := record.Expand()["rel_many"].([]*models.Record)
+		if len(expanded) != len(record.GetStringSlice("rel_many")) {
+			t.Fatalf("Expected record %q to have %d expanded rel_many records, got %d", record.Id, len(record.GetStringSlice("rel_many")), len(expanded))
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package daos
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/spf13/cast"
+)
+
+// ftsRecordIdColumn is the full text search table column
+// that references the indexed record.
+const ftsRecordIdColumn = "recordId"
+
+// HasFTS5 checks whether the current SQLite build supports
+// the FTS5 full text search extension.
+//
+// Note that the cgo driver requires the "sqlite_fts5" build tag.
+func (dao *Dao) HasFTS5() bool {
+	var total int
+
+	err := dao.DB().NewQuery("SELECT COUNT(*) FROM pragma_compile_options WHERE compile_options = 'ENABLE_FTS5'").
+		Row(&total)
+
+	return err == nil && total > 0
+}
+
+// SyncRecordFTS replaces the full text search entry of the provided
+// record with its current searchable fields values.
+//
+// It does nothing if the record collection doesn't have searchable fields.
+func (dao *Dao) SyncRecordFTS(record *models.Record) error {
+	fields := record.Collection().SearchableFields()
+	if len(fields) == 0 {
+		return nil
+	}
+
+	if err := dao.DeleteRecordFTS(record); err != nil {
+		return err
+	}
+
+	values := record.ColumnValueMap()
+
+	cols := dbx.Params{ftsRecordIdColumn: record.Id}
+	for _, name := range fields {
+		cols[name] = ftsValue(values[name])
+	}
+
+	_, err := dao.DB().Insert(record.Collection().FTSTableName(), cols).Execute()
+
+	return err
+}
+
+// DeleteRecordFTS deletes the full text search entry of the provided record.
+//
+// It does nothing if the record collection doesn't have searchable fields.
+func (dao *Dao) DeleteRecordFTS(record *models.Record) error {
+	if len(record.Collection().SearchableFields()) == 0 {
+		return nil
+	}
+
+	_, err := dao.DB().Delete(record.Collection().FTSTableName(), dbx.HashExp{
+		ftsRecordIdColumn: record.Id,
+	}).Execute()
+
+	return err
+}
+
+// ftsValue returns the indexed text of a record column value
+// (the non-scalar values are indexed as they are stored in the db).
+func ftsValue(value any) string {
+	if str, err := cast.ToStringE(value); err == nil {
+		return str
+	}
+
+	encoded, _ := json.Marshal(value)
+
+	return string(encoded)
+}
+
+// SearchRecordsQuery returns a new Record select query filtered to the
+// records matching the provided full text search terms and ordered
+// by relevance (the best matches first).
+//
+// Each whitespace separated term is matched as a word prefix
+// and all of them must be present in the searchable fields.
+//
+// Returns an error if the collection doesn't have searchable fields.
+func (dao *Dao) SearchRecordsQuery(collection *models.Collection, search string) (*dbx.SelectQuery, error) {
+	if len(collection.SearchableFields()) == 0 {
+		return nil, fmt.Errorf("The collection %q doesn't have searchable fields.", collection.Name)
+	}
+
+	ftsTable := collection.FTSTableName()
+
+	query := dao.RecordQuery(collection).
+		InnerJoin(
+			"{{"+ftsTable+"}}",
+			dbx.NewExp(fmt.Sprintf("[[%s.%s]] = [[%s.id]]", ftsTable, ftsRecordIdColumn, collection.Name)),
+		).
+		AndWhere(dbx.NewExp(
+			fmt.Sprintf("[[%s]] MATCH {:ftsSearch}", ftsTable),
+			dbx.Params{"ftsSearch": ftsMatchExpr(search)},
+		)).
+		OrderBy(fmt.Sprintf("[[%s.rank]]", ftsTable))
+
+	return query, nil
+}
+
+// ftsMatchExpr converts the provided user search terms into a safe FTS5
+// MATCH expression (each term is quoted and matched as prefix).
+func ftsMatchExpr(search string) string {
+	terms := strings.Fields(search)
+
+	quoted := make([]string, 0, len(terms))
+	for _, term := range terms {
+		quoted = append(quoted, `"`+strings.ReplaceAll(term, `"`, `""`)+`"*`)
+	}
+
+	return strings.Join(quoted, " ")
+}
+
+// syncRecordFTSTable (re)creates the full text search table of the
+// collection and reindexes all of its records on searchable fields change.
+//
+// The full text search table is dropped if newCollection is nil
+// or it doesn't have searchable fields.
+func (dao *Dao) syncRecordFTSTable(newCollection, oldCollection *models.Collection) error {
+	var newFields, oldFields []string
+	var tableName string
+
+	if newCollection != nil {
+		newFields = newCollection.SearchableFields()
+		tableName = newCollection.FTSTableName()
+	}
+
+	if oldCollection != nil {
+		oldFields = oldCollection.SearchableFields()
+		tableName = oldCollection.FTSTableName()
+	}
+
+	if oldCollection != nil && newCollection != nil && strings.Join(newFields, ",") == strings.Join(oldFields, ",") {
+		return nil // no change
+	}
+
+	if len(oldFields) > 0 {
+		if _, err := dao.DB().NewQuery("DROP TABLE IF EXISTS {{" + tableName + "}}").Execute(); err != nil {
+			return fmt.Errorf("failed to drop the full text search table - %w", err)
+		}
+	}
+
+	if len(newFields) == 0 {
+		return nil
+	}
+
+	ftsCols := make([]string, 0, len(newFields)+1)
+	ftsCols = append(ftsCols, "[["+ftsRecordIdColumn+"]] UNINDEXED")
+
+	insertCols := make([]string, 0, len(newFields)+1)
+	insertCols = append(insertCols, "[["+ftsRecordIdColumn+"]]")
+
+	selectCols := make([]string, 0, len(newFields)+1)
+	selectCols = append(selectCols, "[["+schema.FieldNameId+"]]")
+
+	for _, name := range newFields {
+		ftsCols = append(ftsCols, "[["+name+"]]")
+		insertCols = append(insertCols, "[["+name+"]]")
+		selectCols = append(selectCols, "COALESCE([["+name+"]], '')")
+	}
+
+	_, err := dao.DB().NewQuery(fmt.Sprintf(
+		"CREATE VIRTUAL TABLE {{%s}} USING fts5(%s)",
+		tableName,
+		strings.Join(ftsCols, ", "),
+	)).Execute()
+	if err != nil {
+		return fmt.Errorf("failed to create the full text search table - %w", err)
+	}
+
+	_, err = dao.DB().NewQuery(fmt.Sprintf(
+		"INSERT INTO {{%s}} (%s) SELECT %s FROM {{%s}}",
+		tableName,
+		strings.Join(insertCols, ", "),
+		strings.Join(selectCols, ", "),
+		newCollection.Name,
+	)).Execute()
+	if err != nil {
+		return fmt.Errorf("failed to index the collection records - %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,148 @@
+package daos_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+// enableTestFTS sets the searchable fields of the specified collection
+// (see [tests.SkipWithoutFTS5]).
+func enableTestFTS(t *testing.T, app *tests.TestApp, collectionName string, fields ...string) *models.Collection {
+	tests.SkipWithoutFTS5(t, app)
+
+	collection, err := app.Dao().FindCollectionByNameOrId(collectionName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	collection.Options["searchableFields"] = fields
+
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	return collection
+}
+
+func searchTestRecordIds(t *testing.T, app *tests.TestApp, collection *models.Collection, search string) []string {
+	query, err := app.Dao().SearchRecordsQuery(collection, search)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	records := []*models.Record{}
+	if err := query.All(&records); err != nil {
+		t.Fatal(err)
+	}
+
+	ids := make([]string, len(records))
+	for i, r := range records {
+		ids[i] = r.Id
+	}
+
+	return ids
+}
+
+func TestSearchRecordsQueryWithoutSearchableFields(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().SearchRecordsQuery(collection, "test"); err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+}
+
+func TestRecordFTSSync(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := enableTestFTS(t, app, "demo2", "title")
+
+	if !hasTableColumn(t, app, collection.FTSTableName(), "title") {
+		t.Fatal("Expected the full text search table to be created")
+	}
+
+	// existing records are indexed
+	if ids := searchTestRecordIds(t, app, collection, "test1"); len(ids) != 1 || ids[0] != "llvuca81nly1qls" {
+		t.Fatalf("Expected only llvuca81nly1qls, got %v", ids)
+	}
+
+	// prefix match
+	if ids := searchTestRecordIds(t, app, collection, "tes"); len(ids) != 3 {
+		t.Fatalf("Expected 3 prefix matches, got %v", ids)
+	}
+
+	// special characters are treated as plain text
+	if ids := searchTestRecordIds(t, app, collection, `te"st OR AND (`); len(ids) != 0 {
+		t.Fatalf("Expected no matches, got %v", ids)
+	}
+
+	// update
+	record, err := app.Dao().FindRecordById(collection.Id, "achvryl401bhse3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	record.Set("title", "lorem ipsum")
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+	if ids := searchTestRecordIds(t, app, collection, "test2"); len(ids) != 0 {
+		t.Fatalf("Expected the old title to be removed from the index, got %v", ids)
+	}
+	if ids := searchTestRecordIds(t, app, collection, "ipsum lorem"); len(ids) != 1 || ids[0] != record.Id {
+		t.Fatalf("Expected only %s, got %v", record.Id, ids)
+	}
+
+	// create
+	created := models.NewRecord(collection)
+	created.Set("title", "dolor lorem")
+	if err := app.Dao().SaveRecord(created); err != nil {
+		t.Fatal(err)
+	}
+	if ids := searchTestRecordIds(t, app, collection, "lorem"); len(ids) != 2 {
+		t.Fatalf("Expected 2 matches, got %v", ids)
+	}
+
+	// delete
+	if err := app.Dao().DeleteRecord(created); err != nil {
+		t.Fatal(err)
+	}
+	if ids := searchTestRecordIds(t, app, collection, "dolor"); len(ids) != 0 {
+		t.Fatalf("Expected the deleted record to be removed from the index, got %v", ids)
+	}
+
+	// disable
+	delete(collection.Options, "searchableFields")
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+	if app.Dao().HasTable(collection.FTSTableName()) {
+		t.Fatal("Expected the full text search table to be dropped")
+	}
+}
+
+func TestDeleteCollectionWithFTS(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := enableTestFTS(t, app, "clients", "name")
+
+	if !app.Dao().HasTable(collection.FTSTableName()) {
+		t.Fatal("Expected the full text search table to be created")
+	}
+
+	if err := app.Dao().DeleteCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	if app.Dao().HasTable(collection.FTSTableName()) {
+		t.Fatal("Expected the full text search table to be dropped")
+	}
+}
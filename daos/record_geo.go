@@ -0,0 +1,232 @@
+package daos
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// geo R-tree table columns that reference the indexed record field.
+const (
+	geoRecordIdColumn = "recordId"
+	geoFieldColumn    = "field"
+)
+
+// GeoMetersPerDegree is the approximate length of a single latitude
+// degree (based on the mean Earth radius of 6371008.8m).
+const GeoMetersPerDegree = 6371008.8 * math.Pi / 180
+
+// SyncRecordGeo replaces the geo R-tree entries of the provided
+// record with its current geoPoint fields values.
+//
+// It does nothing if the record collection doesn't have geoPoint fields.
+func (dao *Dao) SyncRecordGeo(record *models.Record) error {
+	fields := record.Collection().GeoPointFields()
+	if len(fields) == 0 {
+		return nil
+	}
+
+	if err := dao.DeleteRecordGeo(record); err != nil {
+		return err
+	}
+
+	for _, name := range fields {
+		point, err := types.ParseGeoPoint(record.Get(name))
+		if err != nil {
+			continue // no location
+		}
+
+		_, err = dao.DB().Insert(record.Collection().GeoTableName(), dbx.Params{
+			"minLat":          point.Lat,
+			"maxLat":          point.Lat,
+			"minLng":          point.Lng,
+			"maxLng":          point.Lng,
+			geoRecordIdColumn: record.Id,
+			geoFieldColumn:    name,
+		}).Execute()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteRecordGeo deletes the geo R-tree entries of the provided record.
+//
+// It does nothing if the record collection doesn't have geoPoint fields.
+func (dao *Dao) DeleteRecordGeo(record *models.Record) error {
+	if len(record.Collection().GeoPointFields()) == 0 {
+		return nil
+	}
+
+	_, err := dao.DB().Delete(record.Collection().GeoTableName(), dbx.HashExp{
+		geoRecordIdColumn: record.Id,
+	}).Execute()
+
+	return err
+}
+
+// ApplyGeoNear limits the provided collection records query to the records
+// with location in the specified geoPoint field and orders them by their
+// distance to center (the nearest first).
+//
+// If radius (in meters) is positive, only the records within radius from
+// center are returned (the candidates are prefiltered with the collection
+// geo R-tree index).
+//
+// The distances are calculated with the equirectangular approximation,
+// which is accurate enough for the local (eg. store locator) searches but
+// doesn't wrap around the antimeridian.
+func (dao *Dao) ApplyGeoNear(
+	query *dbx.SelectQuery,
+	collection *models.Collection,
+	fieldName string,
+	center types.GeoPoint,
+	radius float64,
+) error {
+	if !list.ExistInSlice(fieldName, collection.GeoPointFields()) {
+		return fmt.Errorf("%q is not a geoPoint field of collection %q.", fieldName, collection.Name)
+	}
+
+	if !center.IsValid() {
+		return fmt.Errorf("Invalid center location %q.", center.String())
+	}
+
+	// longitude degrees scale at the center latitude
+	lngScale := math.Cos(center.Lat * math.Pi / 180)
+
+	latExpr := fmt.Sprintf("JSON_EXTRACT([[%s.%s]], '$.lat')", collection.Name, fieldName)
+	lngExpr := fmt.Sprintf("JSON_EXTRACT([[%s.%s]], '$.lng')", collection.Name, fieldName)
+
+	// squared distance in latitude degrees
+	distanceExpr := fmt.Sprintf(
+		"((%[1]s - (%[2]s)) * (%[1]s - (%[2]s)) + (%[3]s - (%[4]s)) * (%[3]s - (%[4]s)) * %[5]s)",
+		latExpr,
+		formatGeoFloat(center.Lat),
+		lngExpr,
+		formatGeoFloat(center.Lng),
+		formatGeoFloat(lngScale*lngScale),
+	)
+
+	query.AndWhere(dbx.NewExp(latExpr + " IS NOT NULL"))
+
+	if radius > 0 {
+		radiusDeg := radius / GeoMetersPerDegree
+
+		minLng, maxLng := -180.0, 180.0
+		if lngScale > 1e-9 && radiusDeg/lngScale < 180 {
+			minLng = center.Lng - radiusDeg/lngScale
+			maxLng = center.Lng + radiusDeg/lngScale
+		}
+
+		geoTable := collection.GeoTableName()
+
+		query.InnerJoin(
+			"{{"+geoTable+"}}",
+			dbx.NewExp(
+				fmt.Sprintf(
+					"[[%[1]s.%[2]s]] = [[%[3]s.id]] AND [[%[1]s.%[4]s]] = {:geoField} AND "+
+						"[[%[1]s.maxLat]] >= {:geoMinLat} AND [[%[1]s.minLat]] <= {:geoMaxLat} AND "+
+						"[[%[1]s.maxLng]] >= {:geoMinLng} AND [[%[1]s.minLng]] <= {:geoMaxLng}",
+					geoTable,
+					geoRecordIdColumn,
+					collection.Name,
+					geoFieldColumn,
+				),
+				dbx.Params{
+					"geoField":  fieldName,
+					"geoMinLat": center.Lat - radiusDeg,
+					"geoMaxLat": center.Lat + radiusDeg,
+					"geoMinLng": minLng,
+					"geoMaxLng": maxLng,
+				},
+			),
+		)
+
+		query.AndWhere(dbx.NewExp(
+			distanceExpr+" <= {:geoRadius}",
+			dbx.Params{"geoRadius": radiusDeg * radiusDeg},
+		))
+	}
+
+	query.OrderBy(distanceExpr + " ASC")
+
+	return nil
+}
+
+// formatGeoFloat formats the provided number as SQL float literal.
+func formatGeoFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// syncRecordGeoTable (re)creates the geo R-tree table of the collection
+// and reindexes all of its records on geoPoint fields change.
+//
+// The geo R-tree table is dropped if newCollection is nil
+// or it doesn't have geoPoint fields.
+func (dao *Dao) syncRecordGeoTable(newCollection, oldCollection *models.Collection) error {
+	var newFields, oldFields []string
+	var tableName string
+
+	if newCollection != nil {
+		newFields = newCollection.GeoPointFields()
+		tableName = newCollection.GeoTableName()
+	}
+
+	if oldCollection != nil {
+		oldFields = oldCollection.GeoPointFields()
+		tableName = oldCollection.GeoTableName()
+	}
+
+	if oldCollection != nil && newCollection != nil && strings.Join(newFields, ",") == strings.Join(oldFields, ",") {
+		return nil // no change
+	}
+
+	if len(oldFields) > 0 {
+		if _, err := dao.DB().NewQuery("DROP TABLE IF EXISTS {{" + tableName + "}}").Execute(); err != nil {
+			return fmt.Errorf("failed to drop the geo table - %w", err)
+		}
+	}
+
+	if len(newFields) == 0 {
+		return nil
+	}
+
+	_, err := dao.DB().NewQuery(fmt.Sprintf(
+		"CREATE VIRTUAL TABLE {{%s}} USING rtree([[id]], [[minLat]], [[maxLat]], [[minLng]], [[maxLng]], +[[%s]], +[[%s]])",
+		tableName,
+		geoRecordIdColumn,
+		geoFieldColumn,
+	)).Execute()
+	if err != nil {
+		return fmt.Errorf("failed to create the geo table - %w", err)
+	}
+
+	for _, name := range newFields {
+		_, err := dao.DB().NewQuery(fmt.Sprintf(
+			"INSERT INTO {{%[1]s}} ([[minLat]], [[maxLat]], [[minLng]], [[maxLng]], [[%[2]s]], [[%[3]s]]) "+
+				"SELECT [[lat]], [[lat]], [[lng]], [[lng]], [[%[4]s]], {:field} FROM ("+
+				"SELECT [[%[4]s]], JSON_EXTRACT([[%[5]s]], '$.lat') as [[lat]], JSON_EXTRACT([[%[5]s]], '$.lng') as [[lng]] FROM {{%[6]s}}"+
+				") WHERE [[lat]] IS NOT NULL AND [[lng]] IS NOT NULL",
+			tableName,
+			geoRecordIdColumn,
+			geoFieldColumn,
+			schema.FieldNameId,
+			name,
+			newCollection.Name,
+		)).Bind(dbx.Params{"field": name}).Execute()
+		if err != nil {
+			return fmt.Errorf("failed to index the collection records - %w", err)
+		}
+	}
+
+	return nil
+}
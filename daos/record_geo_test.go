@@ -0,0 +1,240 @@
+package daos_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// createTestGeoCollection creates a new "places" collection with
+// a "location" geoPoint field and a few records (one without location).
+func createTestGeoCollection(t *testing.T, app *tests.TestApp) *models.Collection {
+	collection := &models.Collection{
+		Name: "places",
+		Type: models.CollectionTypeBase,
+		Schema: schema.NewSchema(
+			&schema.SchemaField{Name: "title", Type: schema.FieldTypeText},
+			&schema.SchemaField{Name: "location", Type: schema.FieldTypeGeoPoint},
+		),
+	}
+
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	places := []struct {
+		title    string
+		location any
+	}{
+		{"sofia", types.GeoPoint{Lat: 42.6977, Lng: 23.3219}},
+		{"plovdiv", types.GeoPoint{Lat: 42.1354, Lng: 24.7453}},
+		{"varna", types.GeoPoint{Lat: 43.2141, Lng: 27.9147}},
+		{"nowhere", nil},
+	}
+
+	for _, p := range places {
+		record := models.NewRecord(collection)
+		record.Id = p.title + "_12345"
+		record.Set("title", p.title)
+		record.Set("location", p.location)
+
+		if err := app.Dao().SaveRecord(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return collection
+}
+
+func countTestGeoEntries(t *testing.T, app *tests.TestApp, collection *models.Collection) int {
+	var total int
+
+	err := app.Dao().DB().Select("count(*)").From(collection.GeoTableName()).Row(&total)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return total
+}
+
+func geoNearTestTitles(t *testing.T, app *tests.TestApp, collection *models.Collection, center types.GeoPoint, radius float64) []string {
+	query := app.Dao().RecordQuery(collection)
+
+	if err := app.Dao().ApplyGeoNear(query, collection, "location", center, radius); err != nil {
+		t.Fatal(err)
+	}
+
+	records := []*models.Record{}
+	if err := query.All(&records); err != nil {
+		t.Fatal(err)
+	}
+
+	titles := make([]string, len(records))
+	for i, r := range records {
+		titles[i] = r.GetString("title")
+	}
+
+	return titles
+}
+
+func TestApplyGeoNearInvalidArgs(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := createTestGeoCollection(t, app)
+
+	scenarios := []struct {
+		fieldName string
+		center    types.GeoPoint
+	}{
+		{"missing", types.GeoPoint{}},
+		{"title", types.GeoPoint{}},
+		{"location", types.GeoPoint{Lat: 91}},
+	}
+
+	for i, s := range scenarios {
+		err := app.Dao().ApplyGeoNear(app.Dao().RecordQuery(collection), collection, s.fieldName, s.center, 0)
+		if err == nil {
+			t.Errorf("(%d) Expected error, got nil", i)
+		}
+	}
+}
+
+func TestApplyGeoNear(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := createTestGeoCollection(t, app)
+
+	sofia := types.GeoPoint{Lat: 42.6977, Lng: 23.3219}
+	varna := types.GeoPoint{Lat: 43.2141, Lng: 27.9147}
+
+	scenarios := []struct {
+		center   types.GeoPoint
+		radius   float64
+		expected []string
+	}{
+		{sofia, 0, []string{"sofia", "plovdiv", "varna"}},
+		{varna, 0, []string{"varna", "plovdiv", "sofia"}},
+		{sofia, 1000, []string{"sofia"}},
+		{sofia, 200000, []string{"sofia", "plovdiv"}},
+		{varna, 200000, []string{"varna"}},
+		{varna, 500000, []string{"varna", "plovdiv", "sofia"}},
+		{types.GeoPoint{Lat: -33.8688, Lng: 151.2093}, 500000, []string{}},
+	}
+
+	for i, s := range scenarios {
+		titles := geoNearTestTitles(t, app, collection, s.center, s.radius)
+
+		if len(titles) != len(s.expected) {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, titles)
+			continue
+		}
+
+		for j, title := range s.expected {
+			if titles[j] != title {
+				t.Errorf("(%d) Expected %v, got %v", i, s.expected, titles)
+				break
+			}
+		}
+	}
+}
+
+func TestRecordGeoSync(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := createTestGeoCollection(t, app)
+
+	if total := countTestGeoEntries(t, app, collection); total != 3 {
+		t.Fatalf("Expected 3 geo entries, got %d", total)
+	}
+
+	// update (move sofia to varna)
+	record, err := app.Dao().FindRecordById(collection.Id, "sofia_12345")
+	if err != nil {
+		t.Fatal(err)
+	}
+	record.Set("location", "43.2141,27.9147")
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+	titles := geoNearTestTitles(t, app, collection, types.GeoPoint{Lat: 43.2141, Lng: 27.9147}, 1000)
+	if len(titles) != 2 {
+		t.Fatalf("Expected 2 records near varna, got %v", titles)
+	}
+
+	// clear location
+	record.Set("location", nil)
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+	if total := countTestGeoEntries(t, app, collection); total != 2 {
+		t.Fatalf("Expected 2 geo entries, got %d", total)
+	}
+
+	// delete
+	varna, err := app.Dao().FindRecordById(collection.Id, "varna_12345")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := app.Dao().DeleteRecord(varna); err != nil {
+		t.Fatal(err)
+	}
+	if total := countTestGeoEntries(t, app, collection); total != 1 {
+		t.Fatalf("Expected 1 geo entry, got %d", total)
+	}
+
+	// rename the field
+	collection.Schema.GetFieldByName("location").Name = "position"
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+	var field string
+	err = app.Dao().DB().Select("field").From(collection.GeoTableName()).Row(&field)
+	if err != nil || field != "position" {
+		t.Fatalf("Expected the geo entries to be reindexed, got %q (%v)", field, err)
+	}
+
+	// remove the field
+	collection.Schema.RemoveField(collection.Schema.GetFieldByName("position").Id)
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+	if app.Dao().HasTable(collection.GeoTableName()) {
+		t.Fatal("Expected the geo table to be dropped")
+	}
+}
+
+func TestDeleteCollectionWithGeo(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := createTestGeoCollection(t, app)
+
+	if !app.Dao().HasTable(collection.GeoTableName()) {
+		t.Fatal("Expected the geo table to be created")
+	}
+
+	if err := app.Dao().DeleteCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	if app.Dao().HasTable(collection.GeoTableName()) {
+		t.Fatal("Expected the geo table to be dropped")
+	}
+
+	// ensure that the R-tree shadow tables are dropped too
+	var total int
+	app.Dao().DB().Select("count(*)").
+		From("sqlite_master").
+		Where(dbx.Like("name", "_geo_")).
+		Row(&total)
+	if total != 0 {
+		t.Fatalf("Expected no geo tables, got %d", total)
+	}
+}
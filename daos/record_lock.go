@@ -0,0 +1,53 @@
+package daos
+
+import (
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// RecordLockQuery returns a new RecordLock select query.
+func (dao *Dao) RecordLockQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.RecordLock{})
+}
+
+// FindRecordLock returns the lock of the provided record.
+//
+// Note that the returned lock could be already expired (see [models.RecordLock.IsExpired()]).
+func (dao *Dao) FindRecordLock(record *models.Record) (*models.RecordLock, error) {
+	model := &models.RecordLock{}
+
+	err := dao.RecordLockQuery().
+		AndWhere(dbx.HashExp{
+			"collectionId": record.Collection().Id,
+			"recordId":     record.Id,
+		}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// SaveRecordLock upserts the provided RecordLock model.
+func (dao *Dao) SaveRecordLock(lock *models.RecordLock) error {
+	return dao.Save(lock)
+}
+
+// DeleteRecordLock deletes the provided RecordLock model.
+func (dao *Dao) DeleteRecordLock(lock *models.RecordLock) error {
+	return dao.Delete(lock)
+}
+
+// deleteRecordLock deletes (without triggering the
+// model hooks) the lock of the provided record.
+func (dao *Dao) deleteRecordLock(record *models.Record) error {
+	_, err := dao.DB().Delete((&models.RecordLock{}).TableName(), dbx.HashExp{
+		"collectionId": record.Collection().Id,
+		"recordId":     record.Id,
+	}).Execute()
+
+	return err
+}
@@ -0,0 +1,78 @@
+package daos_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestRecordLockCRUD(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	record, err := app.Dao().FindRecordById("demo2", "achvryl401bhse3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindRecordLock(record); err == nil {
+		t.Fatal("Expected error for missing record lock, got nil")
+	}
+
+	expires, _ := types.ParseDateTime(time.Now().Add(time.Minute))
+
+	lock := &models.RecordLock{
+		CollectionId: record.Collection().Id,
+		RecordId:     record.Id,
+		HolderType:   models.RecordLockHolderAuthRecord,
+		HolderId:     "4q1xlclmfloku33",
+		Expires:      expires,
+	}
+	if err := app.Dao().SaveRecordLock(lock); err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := app.Dao().FindRecordLock(record)
+	if err != nil || found.Id != lock.Id || found.IsExpired() {
+		t.Fatalf("Expected active lock %q, got %v (%v)", lock.Id, found, err)
+	}
+
+	if err := app.Dao().DeleteRecordLock(found); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindRecordLock(record); err == nil {
+		t.Fatal("Expected the record lock to be deleted")
+	}
+}
+
+func TestDeleteRecordWithLock(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	record, err := app.Dao().FindRecordById("demo2", "achvryl401bhse3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lock := &models.RecordLock{
+		CollectionId: record.Collection().Id,
+		RecordId:     record.Id,
+		HolderType:   models.RecordLockHolderAuthRecord,
+		HolderId:     "4q1xlclmfloku33",
+	}
+	if err := app.Dao().SaveRecordLock(lock); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().DeleteRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindRecordLock(record); err == nil {
+		t.Fatal("Expected the record lock to be deleted together with the record")
+	}
+}
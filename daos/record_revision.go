@@ -0,0 +1,137 @@
+package daos
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// RecordRevisionQuery returns a new RecordRevision select query.
+func (dao *Dao) RecordRevisionQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.RecordRevision{})
+}
+
+// RecordRevisionsQuery returns a new RecordRevision select query
+// filtered to the revisions of the provided record.
+func (dao *Dao) RecordRevisionsQuery(record *models.Record) *dbx.SelectQuery {
+	return dao.RecordRevisionQuery().AndWhere(dbx.HashExp{
+		"collectionId": record.Collection().Id,
+		"recordId":     record.Id,
+	})
+}
+
+// FindRecordRevision finds the revision with the provided number
+// from the history of the provided record.
+func (dao *Dao) FindRecordRevision(record *models.Record, revision int) (*models.RecordRevision, error) {
+	model := &models.RecordRevision{}
+
+	err := dao.RecordRevisionsQuery(record).
+		AndWhere(dbx.HashExp{"revision": revision}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// CreateRecordRevision stores the current db state of the provided
+// record schema fields as its next revision.
+//
+// Returns nil revision if the record doesn't exist in the db (or is trashed).
+func (dao *Dao) CreateRecordRevision(record *models.Record) (*models.RecordRevision, error) {
+	current, err := dao.FindRecordById(record.Collection().Id, record.Id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var lastRevision int
+
+	err = dao.RecordRevisionsQuery(record).
+		Select("COALESCE(MAX([[revision]]), 0)").
+		Row(&lastRevision)
+	if err != nil {
+		return nil, err
+	}
+
+	revision := &models.RecordRevision{
+		CollectionId: record.Collection().Id,
+		RecordId:     record.Id,
+		Revision:     lastRevision + 1,
+		Data:         types.JsonMap(current.SchemaData()),
+	}
+
+	if err := dao.Save(revision); err != nil {
+		return nil, err
+	}
+
+	return revision, nil
+}
+
+// PruneRecordRevisions deletes the revisions of the provided record
+// that exceed the maxRevisions limit (the oldest are deleted first)
+// or that are older than maxDays (0 disables the related limit).
+func (dao *Dao) PruneRecordRevisions(record *models.Record, maxRevisions int, maxDays int) error {
+	table := (&models.RecordRevision{}).TableName()
+
+	owner := dbx.HashExp{
+		"collectionId": record.Collection().Id,
+		"recordId":     record.Id,
+	}
+
+	if maxDays > 0 {
+		minDate, err := types.ParseDateTime(time.Now().AddDate(0, 0, -maxDays))
+		if err != nil {
+			return err
+		}
+
+		_, err = dao.DB().Delete(table, dbx.And(owner, dbx.NewExp("[[created]] < {:date}", dbx.Params{"date": minDate.String()}))).Execute()
+		if err != nil {
+			return err
+		}
+	}
+
+	if maxRevisions > 0 {
+		var minRevision int
+
+		err := dao.RecordRevisionsQuery(record).
+			Select("revision").
+			OrderBy("revision DESC").
+			Offset(int64(maxRevisions - 1)).
+			Limit(1).
+			Row(&minRevision)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil // no more than maxRevisions
+		}
+		if err != nil {
+			return err
+		}
+
+		_, err = dao.DB().Delete(table, dbx.And(owner, dbx.NewExp("[[revision]] < {:revision}", dbx.Params{"revision": minRevision}))).Execute()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteRecordRevisions deletes (without triggering the
+// model hooks) the revisions history of the provided record.
+func (dao *Dao) deleteRecordRevisions(record *models.Record) error {
+	_, err := dao.DB().Delete((&models.RecordRevision{}).TableName(), dbx.HashExp{
+		"collectionId": record.Collection().Id,
+		"recordId":     record.Id,
+	}).Execute()
+
+	return err
+}
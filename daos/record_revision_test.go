@@ -0,0 +1,133 @@
+package daos_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestCreateRecordRevision(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	record, err := app.Dao().FindRecordById("demo2", "llvuca81nly1qls")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// change the model without persisting it to ensure
+	// that the revision is created from the db state
+	record.Set("title", "changed")
+
+	for i := 1; i <= 2; i++ {
+		revision, err := app.Dao().CreateRecordRevision(record)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if revision.Revision != i {
+			t.Fatalf("Expected revision %d, got %d", i, revision.Revision)
+		}
+
+		if revision.CollectionId != record.Collection().Id || revision.RecordId != record.Id {
+			t.Fatalf("Expected revision of record %q, got %v", record.Id, revision)
+		}
+
+		if title := revision.Data["title"]; title != "test1" {
+			t.Fatalf("Expected revision title %q, got %v", "test1", title)
+		}
+	}
+
+	missing := models.NewRecord(record.Collection())
+	missing.Id = "missing"
+
+	revision, err := app.Dao().CreateRecordRevision(missing)
+	if err != nil || revision != nil {
+		t.Fatalf("Expected nil revision and error for missing record, got %v (%v)", revision, err)
+	}
+}
+
+func TestPruneRecordRevisions(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	record, err := app.Dao().FindRecordById("demo2", "llvuca81nly1qls")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 5; i++ {
+		if _, err := app.Dao().CreateRecordRevision(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// mark the first revision as old
+	_, err = app.Dao().DB().Update(
+		(&models.RecordRevision{}).TableName(),
+		dbx.Params{"created": "2020-01-01 00:00:00.000Z"},
+		dbx.HashExp{"revision": 1},
+	).Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		maxRevisions int
+		maxDays      int
+		expected     []int
+	}{
+		{0, 0, []int{1, 2, 3, 4, 5}},
+		{10, 0, []int{1, 2, 3, 4, 5}},
+		{0, 30, []int{2, 3, 4, 5}},
+		{2, 0, []int{4, 5}},
+	}
+
+	for i, s := range scenarios {
+		if err := app.Dao().PruneRecordRevisions(record, s.maxRevisions, s.maxDays); err != nil {
+			t.Fatalf("[%d] %v", i, err)
+		}
+
+		revisions := []*models.RecordRevision{}
+		if err := app.Dao().RecordRevisionsQuery(record).OrderBy("revision ASC").All(&revisions); err != nil {
+			t.Fatalf("[%d] %v", i, err)
+		}
+
+		if len(revisions) != len(s.expected) {
+			t.Fatalf("[%d] Expected %d revisions, got %d", i, len(s.expected), len(revisions))
+		}
+		for j, rev := range revisions {
+			if rev.Revision != s.expected[j] {
+				t.Fatalf("[%d] Expected revisions %v, got %d at position %d", i, s.expected, rev.Revision, j)
+			}
+		}
+	}
+}
+
+func TestDeleteRecordWithRevisions(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	record, err := app.Dao().FindRecordById("demo2", "llvuca81nly1qls")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().CreateRecordRevision(record); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().DeleteRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	var total int
+	if err := app.Dao().RecordRevisionsQuery(record).Select("count(*)").Row(&total); err != nil {
+		t.Fatal(err)
+	}
+	if total != 0 {
+		t.Fatalf("Expected the record revisions to be deleted, got %d", total)
+	}
+}
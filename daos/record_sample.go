@@ -0,0 +1,214 @@
+package daos
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+)
+
+// SampleRecords returns up to n randomly selected records of the
+// provided collection and the total number of the sampled population records.
+//
+// If stratifyField is set, the sample is stratified by the field values,
+// aka. each distinct value group is represented proportionally to its size.
+// Only single value schema fields could be used for stratification.
+//
+// The optional filters are applied to both the population and the sample queries.
+func (dao *Dao) SampleRecords(
+	collection *models.Collection,
+	n int,
+	stratifyField string,
+	optFilters ...func(q *dbx.SelectQuery) error,
+) ([]*models.Record, int, error) {
+	if stratifyField != "" {
+		return dao.sampleStratifiedRecords(collection, n, stratifyField, optFilters...)
+	}
+
+	var total int
+
+	countQuery := dao.DB().
+		Select("count(distinct [[" + collection.Name + ".id]])").
+		From(collection.Name)
+
+	if err := applySelectFilters(countQuery, optFilters...); err != nil {
+		return nil, 0, err
+	}
+
+	if err := countQuery.Row(&total); err != nil {
+		return nil, 0, err
+	}
+
+	records, err := dao.randomRecords(collection, n, nil, optFilters...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return records, total, nil
+}
+
+func (dao *Dao) sampleStratifiedRecords(
+	collection *models.Collection,
+	n int,
+	field string,
+	optFilters ...func(q *dbx.SelectQuery) error,
+) ([]*models.Record, int, error) {
+	schemaField := collection.Schema.GetFieldByName(field)
+	if schemaField == nil {
+		return nil, 0, fmt.Errorf("missing or invalid stratify field %q", field)
+	}
+
+	if err := schemaField.InitOptions(); err != nil {
+		return nil, 0, err
+	}
+
+	if multi, ok := schemaField.Options.(schema.MultiValuer); ok && multi.IsMultiple() {
+		return nil, 0, errors.New("multiple values fields cannot be used for stratification")
+	}
+
+	column := collection.Name + "." + schemaField.Name
+
+	groups := []struct {
+		Value any `db:"value"`
+		Total int `db:"total"`
+	}{}
+
+	groupsQuery := dao.DB().
+		Select("[["+column+"]] as value", "count(distinct [["+collection.Name+".id]]) as total").
+		From(collection.Name).
+		GroupBy(column).
+		OrderBy("value ASC")
+
+	if err := applySelectFilters(groupsQuery, optFilters...); err != nil {
+		return nil, 0, err
+	}
+
+	if err := groupsQuery.All(&groups); err != nil {
+		return nil, 0, err
+	}
+
+	totals := make([]int, len(groups))
+	total := 0
+	for i, g := range groups {
+		totals[i] = g.Total
+		total += g.Total
+	}
+
+	records := []*models.Record{}
+
+	for i, quota := range allocateSample(n, totals) {
+		if quota == 0 {
+			continue
+		}
+
+		value := groups[i].Value
+		if b, ok := value.([]byte); ok {
+			value = string(b)
+		}
+
+		groupRecords, err := dao.randomRecords(collection, quota, dbx.HashExp{column: value}, optFilters...)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		records = append(records, groupRecords...)
+	}
+
+	return records, total, nil
+}
+
+func (dao *Dao) randomRecords(
+	collection *models.Collection,
+	limit int,
+	expr dbx.Expression,
+	optFilters ...func(q *dbx.SelectQuery) error,
+) ([]*models.Record, error) {
+	records := []*models.Record{}
+
+	if limit <= 0 {
+		return records, nil
+	}
+
+	query := dao.RecordQuery(collection)
+
+	if expr != nil {
+		query.AndWhere(expr)
+	}
+
+	if err := applySelectFilters(query, optFilters...); err != nil {
+		return nil, err
+	}
+
+	if err := query.OrderBy("RANDOM()").Limit(int64(limit)).All(&records); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+func applySelectFilters(query *dbx.SelectQuery, filters ...func(q *dbx.SelectQuery) error) error {
+	for _, filter := range filters {
+		if filter == nil {
+			continue
+		}
+		if err := filter(query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// allocateSample distributes n sample items between the groups
+// proportionally to their totals using the largest remainder method.
+func allocateSample(n int, totals []int) []int {
+	result := make([]int, len(totals))
+
+	sum := 0
+	for _, t := range totals {
+		sum += t
+	}
+
+	if n <= 0 || sum == 0 {
+		return result
+	}
+
+	// the entire population fits in the sample
+	if sum <= n {
+		copy(result, totals)
+		return result
+	}
+
+	remainders := make([]int, len(totals))
+	allocated := 0
+
+	for i, t := range totals {
+		result[i] = n * t / sum
+		remainders[i] = n * t % sum
+		allocated += result[i]
+	}
+
+	// distribute the rest in order of the largest remainders
+	order := make([]int, len(totals))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return remainders[order[i]] > remainders[order[j]]
+	})
+
+	for _, i := range order {
+		if allocated >= n {
+			break
+		}
+		if result[i] < totals[i] {
+			result[i]++
+			allocated++
+		}
+	}
+
+	return result
+}
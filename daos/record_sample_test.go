@@ -0,0 +1,111 @@
+package daos_test
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestSampleRecords(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	demo1, err := app.Dao().FindCollectionByNameOrId("demo1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	demo2, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	activeFilter := func(q *dbx.SelectQuery) error {
+		q.AndWhere(dbx.HashExp{"demo2.active": true})
+		return nil
+	}
+
+	scenarios := []struct {
+		name          string
+		n             int
+		stratifyField string
+		filter        func(q *dbx.SelectQuery) error
+		expectError   bool
+		expectedTotal int
+		expectedCount int
+		// expected number of sampled records per active value (for stratified samples)
+		expectedActive int
+	}{
+		{"random with smaller sample", 2, "", nil, false, 3, 2, -1},
+		{"random with larger sample", 10, "", nil, false, 3, 3, -1},
+		{"random with filter", 10, "", activeFilter, false, 2, 2, 2},
+		{"random with zero sample", 0, "", nil, false, 3, 0, -1},
+		{"stratified by missing field", 2, "missing", nil, true, 0, 0, -1},
+		{"stratified with larger sample", 10, "active", nil, false, 3, 3, 2},
+		{"stratified with smaller sample", 2, "active", nil, false, 3, 2, 1},
+		{"stratified with filter", 1, "active", activeFilter, false, 2, 1, 1},
+	}
+
+	for _, s := range scenarios {
+		records, total, err := app.Dao().SampleRecords(demo2, s.n, s.stratifyField, s.filter)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("[%s] Expected hasErr %v, got %v (%v)", s.name, s.expectError, hasErr, err)
+			continue
+		}
+
+		if hasErr {
+			continue
+		}
+
+		if total != s.expectedTotal {
+			t.Errorf("[%s] Expected total %d, got %d", s.name, s.expectedTotal, total)
+		}
+
+		if len(records) != s.expectedCount {
+			t.Errorf("[%s] Expected %d records, got %d", s.name, s.expectedCount, len(records))
+		}
+
+		ids := make([]string, len(records))
+		active := 0
+		for i, r := range records {
+			if r.Collection().Id != demo2.Id {
+				t.Errorf("[%s] Expected record from collection %q, got %q", s.name, demo2.Id, r.Collection().Id)
+			}
+			if r.GetBool("active") {
+				active++
+			}
+			ids[i] = r.Id
+		}
+
+		if s.expectedActive >= 0 && active != s.expectedActive {
+			t.Errorf("[%s] Expected %d active records, got %d", s.name, s.expectedActive, active)
+		}
+
+		sort.Strings(ids)
+		for i := 1; i < len(ids); i++ {
+			if ids[i] == ids[i-1] {
+				t.Errorf("[%s] Duplicated sample record %q", s.name, ids[i])
+			}
+		}
+	}
+
+	// multiple values fields can't be used for stratification
+	_, _, err = app.Dao().SampleRecords(demo1, 10, "select_many")
+	if err == nil || !strings.Contains(err.Error(), "multiple") {
+		t.Fatalf("Expected multiple values field error, got %v", err)
+	}
+
+	// single value select field
+	records, total, err := app.Dao().SampleRecords(demo1, 10, "select_one")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 3 || len(records) != 3 {
+		t.Fatalf("Expected 3 sampled demo1 records, got %d (total %d)", len(records), total)
+	}
+}
@@ -0,0 +1,149 @@
+package daos
+
+import (
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// RecordShareQuery returns a new RecordShare select query.
+func (dao *Dao) RecordShareQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.RecordShare{})
+}
+
+// FindRecordShareById finds the record share with the provided id.
+func (dao *Dao) FindRecordShareById(id string) (*models.RecordShare, error) {
+	model := &models.RecordShare{}
+
+	err := dao.RecordShareQuery().
+		AndWhere(dbx.HashExp{"id": id}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindRecordShares returns all ACL entries of the provided record
+// (ordered by their created date).
+func (dao *Dao) FindRecordShares(record *models.Record) ([]*models.RecordShare, error) {
+	result := []*models.RecordShare{}
+
+	err := dao.RecordShareQuery().
+		AndWhere(dbx.HashExp{
+			"collectionId": record.Collection().Id,
+			"recordId":     record.Id,
+		}).
+		OrderBy("created ASC").
+		All(&result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// FindRecordShareBySubject finds the ACL entry of the provided record
+// for the specified subject.
+func (dao *Dao) FindRecordShareBySubject(
+	record *models.Record,
+	subjectType string,
+	subjectCollectionId string,
+	subjectId string,
+) (*models.RecordShare, error) {
+	model := &models.RecordShare{}
+
+	err := dao.RecordShareQuery().
+		AndWhere(dbx.HashExp{
+			"collectionId":        record.Collection().Id,
+			"recordId":            record.Id,
+			"subjectType":         subjectType,
+			"subjectCollectionId": subjectCollectionId,
+			"subjectId":           subjectId,
+		}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindRecordOwnerShare returns the owner ACL entry of the provided record.
+func (dao *Dao) FindRecordOwnerShare(record *models.Record) (*models.RecordShare, error) {
+	model := &models.RecordShare{}
+
+	err := dao.RecordShareQuery().
+		AndWhere(dbx.HashExp{
+			"collectionId": record.Collection().Id,
+			"recordId":     record.Id,
+			"permission":   models.RecordSharePermissionOwner,
+		}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// SaveRecordShare upserts the provided RecordShare model.
+//
+// Saving an owner share downgrades the previous record owner (if any) to editor.
+func (dao *Dao) SaveRecordShare(share *models.RecordShare) error {
+	return dao.RunInTransaction(func(txDao *Dao) error {
+		if share.Permission == models.RecordSharePermissionOwner {
+			prevOwners := []*models.RecordShare{}
+
+			err := txDao.RecordShareQuery().
+				AndWhere(dbx.HashExp{
+					"collectionId": share.CollectionId,
+					"recordId":     share.RecordId,
+					"permission":   models.RecordSharePermissionOwner,
+				}).
+				AndWhere(dbx.Not(dbx.HashExp{"id": share.Id})).
+				All(&prevOwners)
+			if err != nil {
+				return err
+			}
+
+			for _, prev := range prevOwners {
+				prev.Permission = models.RecordSharePermissionEdit
+				if err := txDao.Save(prev); err != nil {
+					return err
+				}
+			}
+		}
+
+		return txDao.Save(share)
+	})
+}
+
+// DeleteRecordShare deletes the provided RecordShare model.
+func (dao *Dao) DeleteRecordShare(share *models.RecordShare) error {
+	return dao.Delete(share)
+}
+
+// deleteRecordShares deletes (without triggering the model hooks) all ACL
+// entries of the provided record and the ones where the record is the subject.
+func (dao *Dao) deleteRecordShares(record *models.Record) error {
+	_, err := dao.DB().Delete((&models.RecordShare{}).TableName(), dbx.Or(
+		dbx.HashExp{
+			"collectionId": record.Collection().Id,
+			"recordId":     record.Id,
+		},
+		dbx.HashExp{
+			"subjectCollectionId": record.Collection().Id,
+			"subjectId":           record.Id,
+		},
+	)).Execute()
+
+	return err
+}
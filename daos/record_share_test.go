@@ -0,0 +1,164 @@
+package daos_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func createTestRecordShares(t *testing.T, app *tests.TestApp) []*models.RecordShare {
+	shares := []*models.RecordShare{
+		{CollectionId: "wsmn24bux7wo113", RecordId: "84nmscqy84lsi1t", SubjectType: models.RecordShareSubjectUser, SubjectCollectionId: "_pb_users_auth_", SubjectId: "4q1xlclmfloku33", Permission: models.RecordSharePermissionOwner},
+		{CollectionId: "wsmn24bux7wo113", RecordId: "84nmscqy84lsi1t", SubjectType: models.RecordShareSubjectGroup, SubjectCollectionId: "sz5l5z67tg7gku0", SubjectId: "llvuca81nly1qls", Permission: models.RecordSharePermissionView},
+		{CollectionId: "wsmn24bux7wo113", RecordId: "al1h9ijdeojtsjy", SubjectType: models.RecordShareSubjectUser, SubjectCollectionId: "_pb_users_auth_", SubjectId: "oap640cot4yru2s", Permission: models.RecordSharePermissionEdit},
+	}
+
+	for _, share := range shares {
+		if err := app.Dao().SaveRecordShare(share); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return shares
+}
+
+func TestFindRecordShares(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	shares := createTestRecordShares(t, app)
+
+	record, err := app.Dao().FindRecordById("demo1", "84nmscqy84lsi1t")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := app.Dao().FindRecordShares(record)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 shares, got %d", len(result))
+	}
+
+	for _, share := range result {
+		if share.Id != shares[0].Id && share.Id != shares[1].Id {
+			t.Errorf("Unexpected share %q", share.Id)
+		}
+	}
+
+	byId, err := app.Dao().FindRecordShareById(shares[2].Id)
+	if err != nil || byId.Id != shares[2].Id {
+		t.Fatalf("Expected share %q, got %v (%v)", shares[2].Id, byId, err)
+	}
+
+	bySubject, err := app.Dao().FindRecordShareBySubject(record, models.RecordShareSubjectGroup, "sz5l5z67tg7gku0", "llvuca81nly1qls")
+	if err != nil || bySubject.Id != shares[1].Id {
+		t.Fatalf("Expected share %q, got %v (%v)", shares[1].Id, bySubject, err)
+	}
+
+	if _, err := app.Dao().FindRecordShareBySubject(record, models.RecordShareSubjectUser, "sz5l5z67tg7gku0", "llvuca81nly1qls"); err == nil {
+		t.Fatal("Expected error for mismatched subject type, got nil")
+	}
+
+	owner, err := app.Dao().FindRecordOwnerShare(record)
+	if err != nil || owner.Id != shares[0].Id {
+		t.Fatalf("Expected owner share %q, got %v (%v)", shares[0].Id, owner, err)
+	}
+}
+
+func TestSaveRecordShareOwnerTransfer(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	shares := createTestRecordShares(t, app)
+
+	record, err := app.Dao().FindRecordById("demo1", "84nmscqy84lsi1t")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// promote the group share to owner
+	shares[1].Permission = models.RecordSharePermissionOwner
+	if err := app.Dao().SaveRecordShare(shares[1]); err != nil {
+		t.Fatal(err)
+	}
+
+	owner, err := app.Dao().FindRecordOwnerShare(record)
+	if err != nil || owner.Id != shares[1].Id {
+		t.Fatalf("Expected owner share %q, got %v (%v)", shares[1].Id, owner, err)
+	}
+
+	prevOwner, err := app.Dao().FindRecordShareById(shares[0].Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prevOwner.Permission != models.RecordSharePermissionEdit {
+		t.Fatalf("Expected the previous owner to be downgraded to edit, got %q", prevOwner.Permission)
+	}
+
+	// the other record shares should be unchanged
+	other, err := app.Dao().FindRecordShareById(shares[2].Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other.Permission != models.RecordSharePermissionEdit {
+		t.Fatalf("Expected the other record share to be unchanged, got %q", other.Permission)
+	}
+}
+
+func TestDeleteRecordShare(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	shares := createTestRecordShares(t, app)
+
+	if err := app.Dao().DeleteRecordShare(shares[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindRecordShareById(shares[0].Id); err == nil {
+		t.Fatal("Expected the share to be deleted")
+	}
+}
+
+func TestDeleteRecordWithShares(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	shares := createTestRecordShares(t, app)
+
+	// delete the shared record
+	record, err := app.Dao().FindRecordById("demo1", "84nmscqy84lsi1t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := app.Dao().DeleteRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, share := range shares[:2] {
+		if _, err := app.Dao().FindRecordShareById(share.Id); err == nil {
+			t.Errorf("(%d) Expected the shared record ACL entry to be deleted", i)
+		}
+	}
+
+	if _, err := app.Dao().FindRecordShareById(shares[2].Id); err != nil {
+		t.Fatalf("Expected the other record share to remain, got %v", err)
+	}
+
+	// delete the subject record
+	user, err := app.Dao().FindRecordById("users", "oap640cot4yru2s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := app.Dao().DeleteRecord(user); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindRecordShareById(shares[2].Id); err == nil {
+		t.Fatal("Expected the subject record ACL entry to be deleted")
+	}
+}
@@ -40,6 +40,10 @@ func (dao *Dao) SyncRecordTableSchema(newCollection *models.Collection, oldColle
 				cols[schema.FieldNameLastVerificationSentAt] = "TEXT DEFAULT '' NOT NULL"
 			}
 
+			if newCollection.IsSoftDelete() {
+				cols[schema.FieldNameDeletedAt] = softDeleteColDefinition
+			}
+
 			// ensure that the new collection has an id
 			if !newCollection.HasId() {
 				newCollection.RefreshId()
@@ -75,6 +79,14 @@ func (dao *Dao) SyncRecordTableSchema(newCollection *models.Collection, oldColle
 				}
 			}
 
+			if err := txDao.syncRecordFTSTable(newCollection, nil); err != nil {
+				return err
+			}
+
+			if err := txDao.syncRecordGeoTable(newCollection, nil); err != nil {
+				return err
+			}
+
 			return txDao.createCollectionIndexes(newCollection)
 		}
 
@@ -155,6 +167,18 @@ func (dao *Dao) SyncRecordTableSchema(newCollection *models.Collection, oldColle
 			}
 		}
 
+		if err := txDao.syncSoftDeleteColumn(newCollection, oldCollection); err != nil {
+			return err
+		}
+
+		if err := txDao.syncRecordFTSTable(newCollection, oldCollection); err != nil {
+			return err
+		}
+
+		if err := txDao.syncRecordGeoTable(newCollection, oldCollection); err != nil {
+			return err
+		}
+
 		if err := txDao.normalizeSingleVsMultipleFieldChanges(newCollection, oldCollection); err != nil {
 			return err
 		}
@@ -167,6 +191,32 @@ func (dao *Dao) SyncRecordTableSchema(newCollection *models.Collection, oldColle
 	})
 }
 
+const softDeleteColDefinition = "TEXT DEFAULT '' NOT NULL"
+
+// syncSoftDeleteColumn adds or drops the "deletedAt" record table
+// column on collection soft delete option change.
+//
+// Note that disabling the soft delete restores all trashed records.
+func (dao *Dao) syncSoftDeleteColumn(newCollection, oldCollection *models.Collection) error {
+	if newCollection.IsSoftDelete() == oldCollection.IsSoftDelete() {
+		return nil // no change
+	}
+
+	var err error
+
+	if newCollection.IsSoftDelete() {
+		_, err = dao.DB().AddColumn(newCollection.Name, schema.FieldNameDeletedAt, softDeleteColDefinition).Execute()
+	} else {
+		_, err = dao.DB().DropColumn(newCollection.Name, schema.FieldNameDeletedAt).Execute()
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to sync the soft delete column - %w", err)
+	}
+
+	return nil
+}
+
 func (dao *Dao) normalizeSingleVsMultipleFieldChanges(newCollection, oldCollection *models.Collection) error {
 	if newCollection.IsView() || oldCollection == nil {
 		return nil // view or not an update
@@ -0,0 +1,64 @@
+package daos
+
+import (
+	"fmt"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// CountRecordTranslations returns the total number of the collection
+// records and the number of records with non-empty value for each
+// of the provided locales of the specified localized field.
+func (dao *Dao) CountRecordTranslations(
+	collection *models.Collection,
+	fieldName string,
+	locales []string,
+) (int, map[string]int, error) {
+	field := collection.Schema.GetFieldByName(fieldName)
+	if field == nil || !field.IsLocalized() {
+		return 0, nil, fmt.Errorf("missing or non-localized field %q", fieldName)
+	}
+
+	column := "[[" + collection.Name + "." + field.Name + "]]"
+
+	selects := make([]string, 0, len(locales)+1)
+	selects = append(selects, "count(*)")
+
+	params := dbx.Params{}
+
+	for i, l := range locales {
+		param := fmt.Sprintf("locale%d", i)
+		params[param] = fmt.Sprintf("$.%q", l)
+
+		// the nested CASE ensures that the json functions
+		// are called only with valid json (eg. a plain text value)
+		selects = append(selects, fmt.Sprintf(
+			"count(CASE WHEN json_valid(%[1]s) THEN CASE WHEN json_type(%[1]s) = 'object' AND COALESCE(json_extract(%[1]s, {:%[2]s}), '') != '' THEN 1 END END)",
+			column,
+			param,
+		))
+	}
+
+	counts := make([]int, len(selects))
+	dest := make([]any, len(selects))
+	for i := range counts {
+		dest[i] = &counts[i]
+	}
+
+	err := dao.DB().
+		Select(selects...).
+		From(collection.Name).
+		Bind(params).
+		Row(dest...)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	translated := make(map[string]int, len(locales))
+	for i, l := range locales {
+		translated[l] = counts[i+1]
+	}
+
+	return counts[0], translated, nil
+}
@@ -0,0 +1,99 @@
+package daos_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestCountRecordTranslations(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := &models.Collection{
+		Name: "translations_test",
+		Type: models.CollectionTypeBase,
+		Schema: schema.NewSchema(
+			&schema.SchemaField{
+				Name:    "title",
+				Type:    schema.FieldTypeText,
+				Options: &schema.TextOptions{Localized: true},
+			},
+			&schema.SchemaField{
+				Name: "name",
+				Type: schema.FieldTypeText,
+			},
+		),
+	}
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	values := []any{
+		map[string]any{"en": "Hello", "de": "Hallo"},
+		map[string]any{"en": "Bye", "de": ""},
+		"plain",
+		nil,
+	}
+	for _, v := range values {
+		record := models.NewRecord(collection)
+		record.Set("title", v)
+		if err := app.Dao().SaveRecord(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// legacy non-json value
+	_, err := app.Dao().DB().Insert(collection.Name, dbx.Params{
+		"id":    "legacy000000001",
+		"title": "not json",
+	}).Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		field              string
+		locales            []string
+		expectError        bool
+		expectedTotal      int
+		expectedTranslated map[string]int
+	}{
+		{"missing", []string{"en"}, true, 0, nil},
+		{"name", []string{"en"}, true, 0, nil},
+		{"title", nil, false, 5, map[string]int{}},
+		{"title", []string{"en", "de", "fr", "*"}, false, 5, map[string]int{"en": 2, "de": 1, "fr": 0, "*": 1}},
+	}
+
+	for i, s := range scenarios {
+		total, translated, err := app.Dao().CountRecordTranslations(collection, s.field, s.locales)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("(%d) Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+			continue
+		}
+
+		if hasErr {
+			continue
+		}
+
+		if total != s.expectedTotal {
+			t.Errorf("(%d) Expected total %d, got %d", i, s.expectedTotal, total)
+		}
+
+		if len(translated) != len(s.expectedTranslated) {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expectedTranslated, translated)
+			continue
+		}
+
+		for l, count := range s.expectedTranslated {
+			if translated[l] != count {
+				t.Errorf("(%d) Expected %d %q translations, got %d", i, count, l, translated[l])
+			}
+		}
+	}
+}
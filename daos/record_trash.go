@@ -0,0 +1,77 @@
+package daos
+
+import (
+	"errors"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// TrashedRecordQuery returns a new select query for the
+// soft deleted records of the provided collection.
+func (dao *Dao) TrashedRecordQuery(collection *models.Collection) *dbx.SelectQuery {
+	return dao.recordQuery(collection).
+		AndWhere(dbx.Not(dbx.HashExp{collection.Name + "." + schema.FieldNameDeletedAt: ""}))
+}
+
+// FindTrashedRecordById finds the soft deleted Record model by its id.
+func (dao *Dao) FindTrashedRecordById(
+	collection *models.Collection,
+	recordId string,
+	optFilters ...func(q *dbx.SelectQuery) error,
+) (*models.Record, error) {
+	if !collection.IsSoftDelete() {
+		return nil, errors.New("The collection doesn't have soft delete enabled.")
+	}
+
+	query := dao.TrashedRecordQuery(collection).
+		AndWhere(dbx.HashExp{collection.Name + ".id": recordId})
+
+	for _, filter := range optFilters {
+		if filter == nil {
+			continue
+		}
+		if err := filter(query); err != nil {
+			return nil, err
+		}
+	}
+
+	record := &models.Record{}
+
+	if err := query.Limit(1).One(record); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// SoftDeleteRecord moves the provided record to its collection trash
+// by marking it with the current "deletedAt" date.
+//
+// The record files and relation references are left untouched.
+func (dao *Dao) SoftDeleteRecord(record *models.Record) error {
+	if !record.DeletedAt().IsZero() {
+		return errors.New("The record is already deleted.")
+	}
+
+	if err := record.SetDeletedAt(types.NowDateTime()); err != nil {
+		return err
+	}
+
+	return dao.SaveRecord(record)
+}
+
+// RestoreRecord restores the provided soft deleted record from its collection trash.
+func (dao *Dao) RestoreRecord(record *models.Record) error {
+	if record.DeletedAt().IsZero() {
+		return errors.New("The record is not deleted.")
+	}
+
+	if err := record.SetDeletedAt(types.DateTime{}); err != nil {
+		return err
+	}
+
+	return dao.SaveRecord(record)
+}
@@ -0,0 +1,107 @@
+package daos_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/list"
+)
+
+func hasTableColumn(t *testing.T, app *tests.TestApp, tableName string, column string) bool {
+	columns, err := app.Dao().TableColumns(tableName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return list.ExistInSlice(column, columns)
+}
+
+func TestRecordSoftDelete(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record, err := app.Dao().FindRecordById(collection.Id, "achvryl401bhse3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().SoftDeleteRecord(record); err == nil {
+		t.Fatal("Expected error for collection without soft delete, got nil")
+	}
+
+	if _, err := app.Dao().FindTrashedRecordById(collection, record.Id); err == nil {
+		t.Fatal("Expected error for collection without soft delete, got nil")
+	}
+
+	// enable soft delete
+	collection.Options["softDelete"] = true
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	if !hasTableColumn(t, app, collection.Name, "deletedAt") {
+		t.Fatal("Expected the deletedAt column to be created")
+	}
+
+	record, err = app.Dao().FindRecordById(collection.Id, record.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().RestoreRecord(record); err == nil {
+		t.Fatal("Expected error for not deleted record, got nil")
+	}
+
+	if err := app.Dao().SoftDeleteRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().SoftDeleteRecord(record); err == nil {
+		t.Fatal("Expected error for already deleted record, got nil")
+	}
+
+	if _, err := app.Dao().FindRecordById(collection.Id, record.Id); err == nil {
+		t.Fatal("Expected the trashed record to be excluded from the records query")
+	}
+
+	var trashed []*models.Record
+	if err := app.Dao().TrashedRecordQuery(collection).All(&trashed); err != nil {
+		t.Fatal(err)
+	}
+	if len(trashed) != 1 || trashed[0].Id != record.Id || trashed[0].DeletedAt().IsZero() {
+		t.Fatalf("Expected only the %q record in the trash, got %v", record.Id, trashed)
+	}
+
+	// restore
+	if err := app.Dao().RestoreRecord(trashed[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindRecordById(collection.Id, record.Id); err != nil {
+		t.Fatalf("Expected the restored record to be found, got %v", err)
+	}
+
+	// disabling the soft delete restores all trashed records
+	if err := app.Dao().SoftDeleteRecord(trashed[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	collection.Options["softDelete"] = false
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	if hasTableColumn(t, app, collection.Name, "deletedAt") {
+		t.Fatal("Expected the deletedAt column to be dropped")
+	}
+
+	if _, err := app.Dao().FindRecordById(collection.Id, record.Id); err != nil {
+		t.Fatalf("Expected the record to be restored, got %v", err)
+	}
+}
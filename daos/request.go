@@ -51,6 +51,77 @@ func (dao *Dao) RequestsStats(expr dbx.Expression) ([]*RequestsStatsItem, error)
 	return result, err
 }
 
+// RequestsCount returns the total number of the request logs matching expr.
+func (dao *Dao) RequestsCount(expr dbx.Expression) (int, error) {
+	var total int
+
+	query := dao.RequestQuery().Select("count(*)")
+
+	if expr != nil {
+		query.AndWhere(expr)
+	}
+
+	err := query.Row(&total)
+
+	return total, err
+}
+
+type RequestsIpStatsItem struct {
+	Ip    string `db:"ip" json:"ip"`
+	Total int    `db:"total" json:"total"`
+}
+
+// RequestsIpStats returns the request logs statistics grouped by
+// the client IP and sorted by the highest total.
+//
+// If limit is <= 0, all IPs are returned.
+func (dao *Dao) RequestsIpStats(expr dbx.Expression, limit int) ([]*RequestsIpStatsItem, error) {
+	result := []*RequestsIpStatsItem{}
+
+	query := dao.RequestQuery().
+		Select("count(id) as total", "[[userIp]] as ip").
+		GroupBy("ip").
+		OrderBy("total DESC", "ip ASC")
+
+	if expr != nil {
+		query.AndWhere(expr)
+	}
+
+	if limit > 0 {
+		query.Limit(int64(limit))
+	}
+
+	err := query.All(&result)
+
+	return result, err
+}
+
+type RequestsCountryStatsItem struct {
+	Country string `db:"country" json:"country"`
+	Total   int    `db:"total" json:"total"`
+}
+
+// RequestsCountryStats returns the request logs statistics grouped by
+// the client country stored in the "country" meta key
+// (the logs without country are ignored).
+func (dao *Dao) RequestsCountryStats(expr dbx.Expression) ([]*RequestsCountryStatsItem, error) {
+	result := []*RequestsCountryStatsItem{}
+
+	query := dao.RequestQuery().
+		Select("count(id) as total", "json_extract([[meta]], '$.country') as country").
+		AndWhere(dbx.NewExp("coalesce(json_extract([[meta]], '$.country'), '') != ''")).
+		GroupBy("country").
+		OrderBy("total DESC", "country ASC")
+
+	if expr != nil {
+		query.AndWhere(expr)
+	}
+
+	err := query.All(&result)
+
+	return result, err
+}
+
 // DeleteOldRequests delete all requests that are created before createdBefore.
 func (dao *Dao) DeleteOldRequests(createdBefore time.Time) error {
 	m := models.Request{}
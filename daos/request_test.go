@@ -74,6 +74,109 @@ func TestRequestsStats(t *testing.T) {
 	}
 }
 
+func TestRequestsCount(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	tests.MockRequestLogsData(app)
+
+	scenarios := []struct {
+		expr     dbx.Expression
+		expected int
+	}{
+		{nil, 2},
+		{dbx.HashExp{"status": 400}, 1},
+		{dbx.HashExp{"status": 403}, 0},
+	}
+
+	for i, s := range scenarios {
+		total, err := app.LogsDao().RequestsCount(s.expr)
+		if err != nil {
+			t.Errorf("(%d) Unexpected error %v", i, err)
+			continue
+		}
+
+		if total != s.expected {
+			t.Errorf("(%d) Expected total %d, got %d", i, s.expected, total)
+		}
+	}
+}
+
+func TestRequestsIpStats(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	tests.MockRequestLogsData(app)
+
+	for _, ip := range []string{"1.2.3.4", "1.2.3.4", "5.6.7.8"} {
+		request := &models.Request{UserIp: ip, Status: 403, Meta: types.JsonMap{}}
+		if err := app.LogsDao().SaveRequest(request); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	scenarios := []struct {
+		expr     dbx.Expression
+		limit    int
+		expected string
+	}{
+		{
+			nil,
+			0,
+			`[{"ip":"1.2.3.4","total":2},{"ip":"127.0.0.1","total":2},{"ip":"5.6.7.8","total":1}]`,
+		},
+		{
+			nil,
+			1,
+			`[{"ip":"1.2.3.4","total":2}]`,
+		},
+		{
+			dbx.HashExp{"status": 403},
+			0,
+			`[{"ip":"1.2.3.4","total":2},{"ip":"5.6.7.8","total":1}]`,
+		},
+	}
+
+	for i, s := range scenarios {
+		result, err := app.LogsDao().RequestsIpStats(s.expr, s.limit)
+		if err != nil {
+			t.Errorf("(%d) Unexpected error %v", i, err)
+			continue
+		}
+
+		encoded, _ := json.Marshal(result)
+		if string(encoded) != s.expected {
+			t.Errorf("(%d) Expected %s, got %s", i, s.expected, encoded)
+		}
+	}
+}
+
+func TestRequestsCountryStats(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	tests.MockRequestLogsData(app)
+
+	for _, country := range []string{"BG", "US", "BG", ""} {
+		request := &models.Request{Status: 200, Meta: types.JsonMap{"country": country}}
+		if err := app.LogsDao().SaveRequest(request); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected := `[{"country":"BG","total":2},{"country":"US","total":1}]`
+
+	result, err := app.LogsDao().RequestsCountryStats(dbx.HashExp{"status": 200})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encoded, _ := json.Marshal(result)
+	if string(encoded) != expected {
+		t.Fatalf("Expected %s, got %s", expected, encoded)
+	}
+}
+
 func TestDeleteOldRequests(t *testing.T) {
 	app, _ := tests.NewTestApp()
 	defer app.Cleanup()
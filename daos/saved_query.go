@@ -0,0 +1,58 @@
+package daos
+
+import (
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// SavedQueryQuery returns a new SavedQuery select query.
+func (dao *Dao) SavedQueryQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.SavedQuery{})
+}
+
+// FindSavedQueries returns all saved queries of the provided collection
+// (ordered by their name).
+func (dao *Dao) FindSavedQueries(collection *models.Collection) ([]*models.SavedQuery, error) {
+	result := []*models.SavedQuery{}
+
+	err := dao.SavedQueryQuery().
+		AndWhere(dbx.HashExp{"collectionId": collection.Id}).
+		OrderBy("name ASC").
+		All(&result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// FindSavedQuery finds the saved query with the provided name
+// within the specified collection.
+func (dao *Dao) FindSavedQuery(collection *models.Collection, name string) (*models.SavedQuery, error) {
+	model := &models.SavedQuery{}
+
+	err := dao.SavedQueryQuery().
+		AndWhere(dbx.HashExp{
+			"collectionId": collection.Id,
+			"name":         name,
+		}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// SaveSavedQuery upserts the provided SavedQuery model.
+func (dao *Dao) SaveSavedQuery(query *models.SavedQuery) error {
+	return dao.Save(query)
+}
+
+// DeleteSavedQuery deletes the provided SavedQuery model.
+func (dao *Dao) DeleteSavedQuery(query *models.SavedQuery) error {
+	return dao.Delete(query)
+}
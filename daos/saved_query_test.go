@@ -0,0 +1,138 @@
+package daos_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestFindSavedQueries(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	queries, err := app.Dao().FindSavedQueries(collection)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"active", "mine"}
+	if len(queries) != len(expected) {
+		t.Fatalf("Expected %d queries, got %d", len(expected), len(queries))
+	}
+	for i, q := range queries {
+		if q.Name != expected[i] {
+			t.Errorf("(%d) Expected query %q, got %q", i, expected[i], q.Name)
+		}
+	}
+}
+
+func TestFindSavedQuery(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	demo1, err := app.Dao().FindCollectionByNameOrId("demo1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	demo2, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		collection *models.Collection
+		name       string
+		expectId   string
+	}{
+		{demo2, "", ""},
+		{demo2, "missing", ""},
+		{demo2, "all", ""},
+		{demo2, "active", "savedquery00001"},
+		{demo2, "mine", "savedquery00002"},
+		{demo1, "all", "savedquery00003"},
+	}
+
+	for i, s := range scenarios {
+		query, err := app.Dao().FindSavedQuery(s.collection, s.name)
+
+		hasErr := err != nil
+		expectErr := s.expectId == ""
+		if hasErr != expectErr {
+			t.Errorf("(%d) Expected hasErr %v, got %v (%v)", i, expectErr, hasErr, err)
+			continue
+		}
+
+		if query != nil && query.Id != s.expectId {
+			t.Errorf("(%d) Expected query %q, got %q", i, s.expectId, query.Id)
+		}
+	}
+}
+
+func TestSavedQueryCRUD(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	query := &models.SavedQuery{
+		CollectionId: collection.Id,
+		Name:         "test",
+		Filter:       "title != ''",
+		OwnerType:    models.SavedQueryOwnerAdmin,
+		OwnerId:      "sywbhecnh46rhm0",
+	}
+	if err := app.Dao().SaveSavedQuery(query); err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := app.Dao().FindSavedQuery(collection, "test")
+	if err != nil || found.Id != query.Id || found.Filter != query.Filter {
+		t.Fatalf("Expected saved query %q, got %v (%v)", query.Id, found, err)
+	}
+
+	if err := app.Dao().DeleteSavedQuery(found); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindSavedQuery(collection, "test"); err == nil {
+		t.Fatal("Expected the saved query to be deleted")
+	}
+}
+
+func TestDeleteCollectionWithSavedQueries(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, err := app.Dao().FindCollectionByNameOrId("clients")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	query := &models.SavedQuery{
+		CollectionId: collection.Id,
+		Name:         "test",
+		OwnerType:    models.SavedQueryOwnerAdmin,
+		OwnerId:      "sywbhecnh46rhm0",
+	}
+	if err := app.Dao().SaveSavedQuery(query); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().DeleteCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindSavedQuery(collection, "test"); err == nil {
+		t.Fatal("Expected the collection saved queries to be deleted")
+	}
+}
@@ -0,0 +1,69 @@
+package daos
+
+import (
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// ScheduledExportQuery returns a new ScheduledExport select query.
+func (dao *Dao) ScheduledExportQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.ScheduledExport{})
+}
+
+// FindScheduledExportById finds the scheduled export with the provided id.
+func (dao *Dao) FindScheduledExportById(id string) (*models.ScheduledExport, error) {
+	model := &models.ScheduledExport{}
+
+	err := dao.ScheduledExportQuery().
+		AndWhere(dbx.HashExp{"id": id}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindScheduledExportByName finds the scheduled export with the provided name.
+func (dao *Dao) FindScheduledExportByName(name string) (*models.ScheduledExport, error) {
+	model := &models.ScheduledExport{}
+
+	err := dao.ScheduledExportQuery().
+		AndWhere(dbx.HashExp{"name": name}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindEnabledScheduledExports returns all enabled scheduled exports.
+func (dao *Dao) FindEnabledScheduledExports() ([]*models.ScheduledExport, error) {
+	result := []*models.ScheduledExport{}
+
+	err := dao.ScheduledExportQuery().
+		AndWhere(dbx.HashExp{"enabled": true}).
+		OrderBy("created ASC").
+		All(&result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// SaveScheduledExport upserts the provided ScheduledExport model.
+func (dao *Dao) SaveScheduledExport(export *models.ScheduledExport) error {
+	return dao.Save(export)
+}
+
+// DeleteScheduledExport deletes the provided ScheduledExport model.
+func (dao *Dao) DeleteScheduledExport(export *models.ScheduledExport) error {
+	return dao.Delete(export)
+}
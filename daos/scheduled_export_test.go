@@ -0,0 +1,141 @@
+package daos_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestFindScheduledExportById(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		id          string
+		expectError bool
+	}{
+		{"", true},
+		{"missing", true},
+		{"schedexport0001", false},
+		{"schedexport0002", false},
+	}
+
+	for i, s := range scenarios {
+		export, err := app.Dao().FindScheduledExportById(s.id)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("(%d) Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+			continue
+		}
+
+		if export != nil && export.Id != s.id {
+			t.Errorf("(%d) Expected export %q, got %q", i, s.id, export.Id)
+		}
+	}
+}
+
+func TestFindScheduledExportByName(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		name     string
+		expectId string
+	}{
+		{"", ""},
+		{"missing", ""},
+		{"demo2_active", "schedexport0001"},
+		{"demo2_weekly", "schedexport0002"},
+	}
+
+	for i, s := range scenarios {
+		export, err := app.Dao().FindScheduledExportByName(s.name)
+
+		hasErr := err != nil
+		expectErr := s.expectId == ""
+		if hasErr != expectErr {
+			t.Errorf("(%d) Expected hasErr %v, got %v (%v)", i, expectErr, hasErr, err)
+			continue
+		}
+
+		if export != nil && export.Id != s.expectId {
+			t.Errorf("(%d) Expected export %q, got %q", i, s.expectId, export.Id)
+		}
+	}
+}
+
+func TestFindEnabledScheduledExports(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	exports, err := app.Dao().FindEnabledScheduledExports()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(exports) != 1 || exports[0].Id != "schedexport0001" {
+		t.Fatalf("Expected only schedexport0001 to be enabled, got %v", exports)
+	}
+}
+
+func TestScheduledExportCRUD(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	export := &models.ScheduledExport{
+		Name:         "test",
+		CollectionId: "sz5l5z67tg7gku0",
+		Format:       models.ScheduledExportFormatJson,
+		Schedule:     "@daily",
+		Destination:  models.ScheduledExportDestinationEmail,
+	}
+	export.Emails = append(export.Emails, "test@example.com")
+
+	if err := app.Dao().SaveScheduledExport(export); err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := app.Dao().FindScheduledExportById(export.Id)
+	if err != nil || found.Name != "test" || len(found.Emails) != 1 {
+		t.Fatalf("Expected scheduled export %q, got %v (%v)", export.Id, found, err)
+	}
+
+	if err := app.Dao().DeleteScheduledExport(found); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindScheduledExportById(export.Id); err == nil {
+		t.Fatal("Expected the scheduled export to be deleted")
+	}
+}
+
+func TestDeleteCollectionWithScheduledExports(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, err := app.Dao().FindCollectionByNameOrId("clients")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	export := &models.ScheduledExport{
+		Name:         "test",
+		CollectionId: collection.Id,
+		Format:       models.ScheduledExportFormatCsv,
+		Schedule:     "@daily",
+		Destination:  models.ScheduledExportDestinationS3,
+	}
+	if err := app.Dao().SaveScheduledExport(export); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().DeleteCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindScheduledExportById(export.Id); err == nil {
+		t.Fatal("Expected the collection scheduled exports to be deleted")
+	}
+}
@@ -22,10 +22,16 @@ func (dao *Dao) FindSettings(optEncryptionKey ...string) (*settings.Settings, er
 		return nil, err
 	}
 
+	return decodeSettings(param.Value, optEncryptionKey...)
+}
+
+// decodeSettings decodes the provided serialized settings value
+// (decrypting it first with optEncryptionKey, if necessary).
+func decodeSettings(raw []byte, optEncryptionKey ...string) (*settings.Settings, error) {
 	result := settings.New()
 
 	// try first without decryption
-	plainDecodeErr := json.Unmarshal(param.Value, result)
+	plainDecodeErr := json.Unmarshal(raw, result)
 
 	// failed, try to decrypt
 	if plainDecodeErr != nil {
@@ -40,7 +46,7 @@ func (dao *Dao) FindSettings(optEncryptionKey ...string) (*settings.Settings, er
 		}
 
 		// decrypt
-		decrypted, decryptErr := security.Decrypt(string(param.Value), encryptionKey)
+		decrypted, decryptErr := security.Decrypt(string(raw), encryptionKey)
 		if decryptErr != nil {
 			return nil, decryptErr
 		}
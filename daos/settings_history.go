@@ -0,0 +1,81 @@
+package daos
+
+import (
+	"encoding/json"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+// SettingsHistoryQuery returns a new SettingsHistory select query.
+func (dao *Dao) SettingsHistoryQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.SettingsHistory{})
+}
+
+// FindSettingsHistoryByVersion finds the settings history entry with the provided version.
+func (dao *Dao) FindSettingsHistoryByVersion(version int) (*models.SettingsHistory, error) {
+	model := &models.SettingsHistory{}
+
+	err := dao.SettingsHistoryQuery().
+		AndWhere(dbx.HashExp{"version": version}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// SettingsHistorySnapshot returns and decode the full settings state
+// stored in the provided settings history entry.
+//
+// If the snapshot is encrypted, optEncryptionKey will be used to decrypt it.
+func (dao *Dao) SettingsHistorySnapshot(history *models.SettingsHistory, optEncryptionKey ...string) (*settings.Settings, error) {
+	return decodeSettings([]byte(history.Snapshot), optEncryptionKey...)
+}
+
+// SaveSettingsHistory persists the provided settings history entry
+// together with the serialized snapshot settings state.
+//
+// New entries are assigned with the next available version number.
+//
+// If optEncryptionKey is set, then the stored snapshot will be encrypted with it.
+func (dao *Dao) SaveSettingsHistory(
+	history *models.SettingsHistory,
+	snapshot *settings.Settings,
+	optEncryptionKey ...string,
+) error {
+	encoded, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	history.Snapshot = string(encoded)
+
+	if len(optEncryptionKey) > 0 && optEncryptionKey[0] != "" {
+		encrypted, err := security.Encrypt(encoded, optEncryptionKey[0])
+		if err != nil {
+			return err
+		}
+		history.Snapshot = encrypted
+	}
+
+	if history.IsNew() && history.Version == 0 {
+		var lastVersion int
+
+		err := dao.SettingsHistoryQuery().
+			Select("COALESCE(MAX([[version]]), 0)").
+			Row(&lastVersion)
+		if err != nil {
+			return err
+		}
+
+		history.Version = lastVersion + 1
+	}
+
+	return dao.Save(history)
+}
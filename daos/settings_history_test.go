@@ -0,0 +1,122 @@
+package daos_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestSettingsHistoryQuery(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	expected := "SELECT {{_settingsHistory}}.* FROM `_settingsHistory`"
+
+	sql := app.Dao().SettingsHistoryQuery().Build().SQL()
+	if sql != expected {
+		t.Errorf("Expected sql %s, got %s", expected, sql)
+	}
+}
+
+func TestFindSettingsHistoryByVersion(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		version     int
+		expectError bool
+		expectedId  string
+	}{
+		{0, true, ""},
+		{10, true, ""},
+		{1, false, "h1settingsv001a"},
+		{2, false, "h2settingsv002b"},
+	}
+
+	for i, s := range scenarios {
+		history, err := app.Dao().FindSettingsHistoryByVersion(s.version)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("(%d) Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+			continue
+		}
+
+		if history != nil && history.Id != s.expectedId {
+			t.Errorf("(%d) Expected history with id %q, got %q", i, s.expectedId, history.Id)
+		}
+	}
+}
+
+func TestSaveSettingsHistory(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	encryptionKey := security.PseudorandomString(32)
+
+	snapshot, err := app.Settings().Clone()
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot.Meta.AppName = "history_test"
+
+	// unencrypted
+	h1 := &models.SettingsHistory{
+		AdminId: "sywbhecnh46rhm0",
+		Diff:    types.JsonMap{"meta.appName": map[string]any{"old": "acme_test", "new": "history_test"}},
+	}
+	if err := app.Dao().SaveSettingsHistory(h1, snapshot); err != nil {
+		t.Fatal(err)
+	}
+
+	if h1.Version != 3 {
+		t.Fatalf("Expected version 3, got %d", h1.Version)
+	}
+
+	s1, err := app.Dao().SettingsHistorySnapshot(h1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s1.Meta.AppName != "history_test" {
+		t.Fatalf("Expected snapshot app name %q, got %q", "history_test", s1.Meta.AppName)
+	}
+
+	// encrypted
+	h2 := &models.SettingsHistory{}
+	if err := app.Dao().SaveSettingsHistory(h2, snapshot, encryptionKey); err != nil {
+		t.Fatal(err)
+	}
+
+	if h2.Version != 4 {
+		t.Fatalf("Expected version 4, got %d", h2.Version)
+	}
+
+	// try to decode the snapshot without encryption key (should fail)
+	if s, err := app.Dao().SettingsHistorySnapshot(h2); err == nil {
+		t.Fatalf("Expected SettingsHistorySnapshot to fail without an encryption key, got \n%v", s)
+	}
+
+	stored, err := app.Dao().FindSettingsHistoryByVersion(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s2, err := app.Dao().SettingsHistorySnapshot(stored, encryptionKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s2.Meta.AppName != "history_test" {
+		t.Fatalf("Expected snapshot app name %q, got %q", "history_test", s2.Meta.AppName)
+	}
+
+	// updating an existing entry must preserve its version
+	if err := app.Dao().SaveSettingsHistory(stored, snapshot); err != nil {
+		t.Fatal(err)
+	}
+	if stored.Version != 4 {
+		t.Fatalf("Expected version 4 to be preserved, got %d", stored.Version)
+	}
+}
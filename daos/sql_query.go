@@ -0,0 +1,77 @@
+package daos
+
+import (
+	"strings"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/list"
+)
+
+// SqlQueryQuery returns a new SqlQuery select query.
+func (dao *Dao) SqlQueryQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.SqlQuery{})
+}
+
+// FindSqlQueryById finds the saved sql query with the provided id.
+func (dao *Dao) FindSqlQueryById(id string) (*models.SqlQuery, error) {
+	model := &models.SqlQuery{}
+
+	err := dao.SqlQueryQuery().
+		AndWhere(dbx.HashExp{"id": id}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindSqlQueryByName finds the saved sql query with the provided name (case insensitive).
+func (dao *Dao) FindSqlQueryByName(name string) (*models.SqlQuery, error) {
+	model := &models.SqlQuery{}
+
+	err := dao.SqlQueryQuery().
+		AndWhere(dbx.NewExp("LOWER([[name]])={:name}", dbx.Params{"name": strings.ToLower(name)})).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// IsSqlQueryNameUnique checks if the provided name is unique.
+//
+// Optionally you could pass a list of query ids to exclude from the check.
+func (dao *Dao) IsSqlQueryNameUnique(name string, excludeIds ...string) bool {
+	if name == "" {
+		return false
+	}
+
+	query := dao.SqlQueryQuery().Select("count(*)").
+		AndWhere(dbx.NewExp("LOWER([[name]])={:name}", dbx.Params{"name": strings.ToLower(name)})).
+		Limit(1)
+
+	if uniqueExcludeIds := list.NonzeroUniques(excludeIds); len(uniqueExcludeIds) > 0 {
+		query.AndWhere(dbx.NotIn("id", list.ToInterfaceSlice(uniqueExcludeIds)...))
+	}
+
+	var exists bool
+
+	return query.Row(&exists) == nil && !exists
+}
+
+// DeleteSqlQuery deletes the provided SqlQuery model.
+func (dao *Dao) DeleteSqlQuery(query *models.SqlQuery) error {
+	return dao.Delete(query)
+}
+
+// SaveSqlQuery upserts the provided SqlQuery model.
+func (dao *Dao) SaveSqlQuery(query *models.SqlQuery) error {
+	return dao.Save(query)
+}
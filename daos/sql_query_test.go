@@ -0,0 +1,126 @@
+package daos_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestSqlQueryQuery(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	expected := "SELECT {{_sqlQueries}}.* FROM `_sqlQueries`"
+
+	sql := app.Dao().SqlQueryQuery().Build().SQL()
+	if sql != expected {
+		t.Errorf("Expected sql %s, got %s", expected, sql)
+	}
+}
+
+func TestFindSqlQueryById(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		id          string
+		expectError bool
+	}{
+		{"", true},
+		{"missing", true},
+		{"e7kuy4x0b6q8kkw", false},
+	}
+
+	for i, scenario := range scenarios {
+		sqlQuery, err := app.Dao().FindSqlQueryById(scenario.id)
+
+		hasErr := err != nil
+		if hasErr != scenario.expectError {
+			t.Errorf("(%d) Expected hasErr to be %v, got %v (%v)", i, scenario.expectError, hasErr, err)
+		}
+
+		if sqlQuery != nil && sqlQuery.Id != scenario.id {
+			t.Errorf("(%d) Expected query with id %s, got %s", i, scenario.id, sqlQuery.Id)
+		}
+	}
+}
+
+func TestFindSqlQueryByName(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		name        string
+		expectError bool
+		expectedId  string
+	}{
+		{"", true, ""},
+		{"missing", true, ""},
+		{"total_admins", false, "q2p6tvbr9ciahbl"},
+		{"DEMO2_BY_TITLE", false, "e7kuy4x0b6q8kkw"},
+	}
+
+	for i, scenario := range scenarios {
+		sqlQuery, err := app.Dao().FindSqlQueryByName(scenario.name)
+
+		hasErr := err != nil
+		if hasErr != scenario.expectError {
+			t.Errorf("(%d) Expected hasErr to be %v, got %v (%v)", i, scenario.expectError, hasErr, err)
+		}
+
+		if sqlQuery != nil && sqlQuery.Id != scenario.expectedId {
+			t.Errorf("(%d) Expected query with id %s, got %s", i, scenario.expectedId, sqlQuery.Id)
+		}
+	}
+}
+
+func TestIsSqlQueryNameUnique(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		name      string
+		excludeId string
+		expected  bool
+	}{
+		{"", "", false},
+		{"total_admins", "", false},
+		{"Total_Admins", "", false},
+		{"new", "", true},
+		{"total_admins", "q2p6tvbr9ciahbl", true},
+	}
+
+	for i, scenario := range scenarios {
+		result := app.Dao().IsSqlQueryNameUnique(scenario.name, scenario.excludeId)
+		if result != scenario.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, scenario.expected, result)
+		}
+	}
+}
+
+func TestSaveAndDeleteSqlQuery(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	sqlQuery := &models.SqlQuery{
+		Name:  "new",
+		Query: "SELECT 1",
+	}
+
+	if err := app.Dao().SaveSqlQuery(sqlQuery); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindSqlQueryByName("new"); err != nil {
+		t.Fatalf("Expected the query to be saved, got %v", err)
+	}
+
+	if err := app.Dao().DeleteSqlQuery(sqlQuery); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindSqlQueryById(sqlQuery.Id); err == nil {
+		t.Fatal("Expected the query to be deleted")
+	}
+}
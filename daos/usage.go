@@ -0,0 +1,100 @@
+package daos
+
+import (
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// UsageQuery returns a new Usage select query.
+func (dao *Dao) UsageQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.Usage{})
+}
+
+// IncrementUsage adds usage.Value to the counter identified by the
+// usage period, metric, orgId, collectionId and recordId
+// (the counter is created if it doesn't exist yet).
+//
+// The counter is updated without triggering the model hooks.
+func (dao *Dao) IncrementUsage(usage *models.Usage) error {
+	return dao.upsertUsage(usage, "[[value]] = [[value]] + excluded.[[value]], [[updated]] = excluded.[[updated]]")
+}
+
+// EnsureUsage creates the counter identified by the usage period,
+// metric, orgId, collectionId and recordId with usage.Value
+// if it doesn't exist yet (existing counters are left unchanged).
+//
+// It is useful for the distinct events like the monthly active users.
+func (dao *Dao) EnsureUsage(usage *models.Usage) error {
+	return dao.upsertUsage(usage, "")
+}
+
+func (dao *Dao) upsertUsage(usage *models.Usage, conflictUpdate string) error {
+	if !usage.HasId() {
+		usage.RefreshId()
+	}
+
+	now := types.NowDateTime().String()
+
+	onConflict := "DO NOTHING"
+	if conflictUpdate != "" {
+		onConflict = "DO UPDATE SET " + conflictUpdate
+	}
+
+	_, err := dao.DB().NewQuery(`
+		INSERT INTO {{_usage}} ([[id]], [[period]], [[metric]], [[orgId]], [[collectionId]], [[recordId]], [[value]], [[created]], [[updated]])
+		VALUES ({:id}, {:period}, {:metric}, {:orgId}, {:collectionId}, {:recordId}, {:value}, {:now}, {:now})
+		ON CONFLICT ([[period]], [[metric]], [[orgId]], [[collectionId]], [[recordId]]) ` + onConflict,
+	).Bind(dbx.Params{
+		"id":           usage.Id,
+		"period":       usage.Period,
+		"metric":       usage.Metric,
+		"orgId":        usage.OrgId,
+		"collectionId": usage.CollectionId,
+		"recordId":     usage.RecordId,
+		"value":        usage.Value,
+		"now":          now,
+	}).Execute()
+
+	return err
+}
+
+// FindUsageSummary returns the totals of all metered billable events
+// of the provided period that match the optional filter expression
+// (eg. dbx.HashExp{"orgId": "abc"}).
+//
+// Metrics without any counters are returned with zero value.
+func (dao *Dao) FindUsageSummary(period string, filter dbx.Expression) (*models.UsageSummary, error) {
+	rows := []struct {
+		Metric string `db:"metric"`
+		Total  int64  `db:"total"`
+	}{}
+
+	query := dao.UsageQuery().
+		Select("metric", "SUM([[value]]) as total").
+		AndWhere(dbx.HashExp{"period": period}).
+		GroupBy("metric")
+
+	if filter != nil {
+		query.AndWhere(filter)
+	}
+
+	if err := query.All(&rows); err != nil {
+		return nil, err
+	}
+
+	summary := &models.UsageSummary{
+		Period:  period,
+		Metrics: map[string]int64{},
+	}
+
+	for _, metric := range models.UsageMetrics() {
+		summary.Metrics[metric] = 0
+	}
+
+	for _, row := range rows {
+		summary.Metrics[row.Metric] = row.Total
+	}
+
+	return summary, nil
+}
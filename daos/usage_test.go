@@ -0,0 +1,134 @@
+package daos_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestIncrementUsage(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	increments := []*models.Usage{
+		{Period: "2023-04", Metric: models.UsageMetricRecordsCreated, OrgId: "org1", CollectionId: "c1", RecordId: "r1", Value: 1},
+		{Period: "2023-04", Metric: models.UsageMetricRecordsCreated, OrgId: "org1", CollectionId: "c1", RecordId: "r1", Value: 2},
+		{Period: "2023-04", Metric: models.UsageMetricRecordsCreated, OrgId: "org1", CollectionId: "c1", RecordId: "r2", Value: 5},
+		{Period: "2023-05", Metric: models.UsageMetricRecordsCreated, OrgId: "org1", CollectionId: "c1", RecordId: "r1", Value: 10},
+	}
+
+	for _, usage := range increments {
+		if err := app.Dao().IncrementUsage(usage); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	counters := []*models.Usage{}
+	if err := app.Dao().UsageQuery().OrderBy("period ASC", "recordId ASC").All(&counters); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []int64{3, 5, 10}
+
+	if len(counters) != len(expected) {
+		t.Fatalf("Expected %d counters, got %d", len(expected), len(counters))
+	}
+
+	for i, v := range expected {
+		if counters[i].Value != v {
+			t.Errorf("(%d) Expected value %d, got %d", i, v, counters[i].Value)
+		}
+	}
+}
+
+func TestEnsureUsage(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	for i := 0; i < 3; i++ {
+		usage := &models.Usage{Period: "2023-04", Metric: models.UsageMetricActiveUsers, CollectionId: "c1", RecordId: "r1", Value: 1}
+		if err := app.Dao().EnsureUsage(usage); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	counters := []*models.Usage{}
+	if err := app.Dao().UsageQuery().All(&counters); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(counters) != 1 || counters[0].Value != 1 {
+		t.Fatalf("Expected a single counter with value 1, got %v", counters)
+	}
+}
+
+func TestFindUsageSummary(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	increments := []*models.Usage{
+		{Period: "2023-04", Metric: models.UsageMetricRecordsCreated, OrgId: "org1", RecordId: "r1", Value: 2},
+		{Period: "2023-04", Metric: models.UsageMetricRecordsCreated, OrgId: "org1", RecordId: "r2", Value: 3},
+		{Period: "2023-04", Metric: models.UsageMetricRecordsCreated, OrgId: "org2", RecordId: "r3", Value: 4},
+		{Period: "2023-04", Metric: models.UsageMetricStorageBytes, OrgId: "org1", RecordId: "r1", Value: 100},
+		{Period: "2023-05", Metric: models.UsageMetricStorageBytes, OrgId: "org1", RecordId: "r1", Value: 200},
+	}
+
+	for _, usage := range increments {
+		if err := app.Dao().IncrementUsage(usage); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	scenarios := []struct {
+		period   string
+		filter   dbx.Expression
+		expected map[string]int64
+	}{
+		{
+			"2023-01",
+			nil,
+			map[string]int64{models.UsageMetricRecordsCreated: 0, models.UsageMetricStorageBytes: 0, models.UsageMetricActiveUsers: 0},
+		},
+		{
+			"2023-04",
+			nil,
+			map[string]int64{models.UsageMetricRecordsCreated: 9, models.UsageMetricStorageBytes: 100, models.UsageMetricActiveUsers: 0},
+		},
+		{
+			"2023-04",
+			dbx.HashExp{"orgId": "org1"},
+			map[string]int64{models.UsageMetricRecordsCreated: 5, models.UsageMetricStorageBytes: 100, models.UsageMetricActiveUsers: 0},
+		},
+		{
+			"2023-05",
+			dbx.HashExp{"recordId": "r1"},
+			map[string]int64{models.UsageMetricRecordsCreated: 0, models.UsageMetricStorageBytes: 200, models.UsageMetricActiveUsers: 0},
+		},
+	}
+
+	for i, s := range scenarios {
+		summary, err := app.Dao().FindUsageSummary(s.period, s.filter)
+		if err != nil {
+			t.Errorf("(%d) %v", i, err)
+			continue
+		}
+
+		if summary.Period != s.period {
+			t.Errorf("(%d) Expected period %q, got %q", i, s.period, summary.Period)
+		}
+
+		if len(summary.Metrics) != len(s.expected) {
+			t.Errorf("(%d) Expected metrics %v, got %v", i, s.expected, summary.Metrics)
+			continue
+		}
+
+		for metric, v := range s.expected {
+			if summary.Metrics[metric] != v {
+				t.Errorf("(%d) Expected %s %d, got %d", i, metric, v, summary.Metrics[metric])
+			}
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package daos
+
+import (
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// MarkSignedRequestUsed registers the signed request token with the
+// provided id as used until its expiration.
+//
+// Returns an error if the token is already marked as used.
+func (dao *Dao) MarkSignedRequestUsed(tokenId string, expires types.DateTime) error {
+	now := types.NowDateTime().String()
+
+	_, err := dao.NonconcurrentDB().Insert((&models.UsedSignedRequest{}).TableName(), dbx.Params{
+		"id":      tokenId,
+		"expires": expires.String(),
+		"created": now,
+		"updated": now,
+	}).Execute()
+
+	return err
+}
+
+// DeleteExpiredUsedSignedRequests deletes all used signed requests that expire before expiredBefore.
+func (dao *Dao) DeleteExpiredUsedSignedRequests(expiredBefore time.Time) error {
+	m := models.UsedSignedRequest{}
+	tableName := m.TableName()
+
+	formattedDate := expiredBefore.UTC().Format(types.DefaultDateLayout)
+	expr := dbx.NewExp("[[expires]] <= {:date}", dbx.Params{"date": formattedDate})
+
+	_, err := dao.NonconcurrentDB().Delete(tableName, expr).Execute()
+
+	return err
+}
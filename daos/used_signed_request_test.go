@@ -0,0 +1,76 @@
+package daos_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestMarkSignedRequestUsed(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	expires, _ := types.ParseDateTime(time.Now().Add(time.Hour))
+
+	if err := app.Dao().MarkSignedRequestUsed("test", expires); err != nil {
+		t.Fatalf("Expected the first use to succeed, got %v", err)
+	}
+
+	if err := app.Dao().MarkSignedRequestUsed("test", expires); err == nil {
+		t.Fatal("Expected the second use to fail")
+	}
+
+	if err := app.Dao().MarkSignedRequestUsed("test2", expires); err != nil {
+		t.Fatalf("Expected the use of a different token to succeed, got %v", err)
+	}
+}
+
+func TestDeleteExpiredUsedSignedRequests(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	for id, date := range map[string]string{
+		"test1": "2022-01-01 10:00:00.000Z",
+		"test2": "2022-05-01 10:00:00.000Z",
+	} {
+		expires, _ := types.ParseDateTime(date)
+		if err := app.Dao().MarkSignedRequestUsed(id, expires); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	scenarios := []struct {
+		date          string
+		expectedTotal int
+	}{
+		{"2021-01-01 10:00:00.000Z", 2},
+		{"2022-01-01 11:00:00.000Z", 1},
+		{"2022-05-01 11:00:00.000Z", 0},
+	}
+
+	for i, scenario := range scenarios {
+		date, dateErr := time.Parse(types.DefaultDateLayout, scenario.date)
+		if dateErr != nil {
+			t.Errorf("(%d) Date error %v", i, dateErr)
+		}
+
+		if err := app.Dao().DeleteExpiredUsedSignedRequests(date); err != nil {
+			t.Errorf("(%d) Delete error %v", i, err)
+		}
+
+		var total int
+		err := app.Dao().DB().Select("count(*)").
+			From((&models.UsedSignedRequest{}).TableName()).
+			Row(&total)
+		if err != nil {
+			t.Errorf("(%d) Count error %v", i, err)
+		}
+
+		if total != scenario.expectedTotal {
+			t.Errorf("(%d) Expected %d remaining used signed requests, got %d", i, scenario.expectedTotal, total)
+		}
+	}
+}
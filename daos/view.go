@@ -1,6 +1,7 @@
 package daos
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
 	"io"
@@ -43,13 +44,9 @@ func (dao *Dao) SaveView(name string, selectQuery string) error {
 			return err
 		}
 
-		trimmed := strings.Trim(selectQuery, ";")
-
-		// try to eagerly detect multiple inline statements
-		tk := tokenizer.NewFromString(trimmed)
-		tk.Separators(';')
-		if queryParts, _ := tk.ScanAll(); len(queryParts) > 1 {
-			return errors.New("multiple statements are not supported")
+		trimmed, err := normalizeViewQuery(selectQuery)
+		if err != nil {
+			return err
 		}
 
 		// (re)create the view
@@ -75,6 +72,102 @@ func (dao *Dao) SaveView(name string, selectQuery string) error {
 	})
 }
 
+// SaveMaterializedView creates (or replaces already existing) table
+// populated with the results of the provided select query.
+//
+// Be aware that this method is vulnerable to SQL injection and the
+// "selectQuery" argument must come only from trusted input!
+func (dao *Dao) SaveMaterializedView(name string, selectQuery string) error {
+	return dao.RunInTransaction(func(txDao *Dao) error {
+		// delete old view or table (if exists)
+		if err := txDao.deleteViewStorage(name); err != nil {
+			return err
+		}
+
+		trimmed, err := normalizeViewQuery(selectQuery)
+		if err != nil {
+			return err
+		}
+
+		tableQuery := fmt.Sprintf("CREATE TABLE {{%s}} AS SELECT * FROM (%s)", name, trimmed)
+		if _, err := txDao.DB().NewQuery(tableQuery).Execute(); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+// RefreshMaterializedView replaces the stored rows of the provided
+// materialized view collection with the latest query results.
+//
+// This method returns an error if the collection is not a materialized view.
+func (dao *Dao) RefreshMaterializedView(collection *models.Collection) error {
+	if !collection.IsView() {
+		return errors.New("not a view collection")
+	}
+
+	options := collection.ViewOptions()
+	if !options.Materialized {
+		return errors.New("not a materialized view collection")
+	}
+
+	trimmed, err := normalizeViewQuery(options.Query)
+	if err != nil {
+		return err
+	}
+
+	return dao.RunInTransaction(func(txDao *Dao) error {
+		if _, err := txDao.DB().Delete(collection.Name, nil).Execute(); err != nil {
+			return err
+		}
+
+		insertQuery := fmt.Sprintf("INSERT INTO {{%s}} SELECT * FROM (%s)", collection.Name, trimmed)
+		if _, err := txDao.DB().NewQuery(insertQuery).Execute(); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+// deleteViewStorage drops the SQL view or the materialized view table
+// with the provided name (if any).
+func (dao *Dao) deleteViewStorage(name string) error {
+	var objectType string
+
+	err := dao.DB().Select("type").
+		From("sqlite_schema").
+		AndWhere(dbx.HashExp{"type": []any{"table", "view"}}).
+		AndWhere(dbx.HashExp{"name": name}).
+		Limit(1).
+		Row(&objectType)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	if objectType == "table" {
+		return dao.DeleteTable(name)
+	}
+
+	return dao.DeleteView(name)
+}
+
+// normalizeViewQuery trims the provided view select query and
+// ensures that it doesn't contain multiple inline statements.
+func normalizeViewQuery(selectQuery string) (string, error) {
+	trimmed := strings.Trim(selectQuery, ";")
+
+	// try to eagerly detect multiple inline statements
+	tk := tokenizer.NewFromString(trimmed)
+	tk.Separators(';')
+	if queryParts, _ := tk.ScanAll(); len(queryParts) > 1 {
+		return "", errors.New("multiple statements are not supported")
+	}
+
+	return trimmed, nil
+}
+
 // CreateViewSchema creates a new view schema from the provided select query.
 //
 // There are some caveats:
@@ -134,7 +134,9 @@ func TestSaveView(t *testing.T) {
 			[]string{
 				"id", "created", "updated",
 				"passwordHash", "tokenKey", "email",
-				"lastResetSentAt", "avatar", "c",
+				"lastResetSentAt", "avatar", "otpSecret",
+				"otpEnabled", "otpRecoveryCodes", "otpLastStep",
+				"c",
 			},
 		},
 		{
@@ -180,6 +182,201 @@ func TestSaveView(t *testing.T) {
 	ensureNoTempViews(app, t)
 }
 
+func TestSaveMaterializedView(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		scenarioName  string
+		viewName      string
+		query         string
+		expectError   bool
+		expectColumns []string
+	}{
+		{
+			"empty query",
+			"123Test",
+			"",
+			true,
+			nil,
+		},
+		{
+			"missing table",
+			"123Test",
+			"select id from missing",
+			true,
+			nil,
+		},
+		{
+			"multiple select queries",
+			"123Test",
+			"select id from _admins; select * from demo1;",
+			true,
+			nil,
+		},
+		{
+			"simple select query (+ trimmed semicolon)",
+			"123Test",
+			";select id, email from _admins;",
+			false,
+			[]string{"id", "email"},
+		},
+		{
+			"update old table with new query",
+			"123Test",
+			"select 1 as test from _admins",
+			false,
+			[]string{"test"},
+		},
+		{
+			"replace existing view",
+			"view1",
+			"select id from demo1",
+			false,
+			[]string{"id"},
+		},
+	}
+
+	for _, s := range scenarios {
+		err := app.Dao().SaveMaterializedView(s.viewName, s.query)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("[%s] Expected hasErr %v, got %v (%v)", s.scenarioName, s.expectError, hasErr, err)
+			continue
+		}
+
+		if hasErr {
+			continue
+		}
+
+		var objectType string
+		err = app.Dao().DB().Select("type").
+			From("sqlite_schema").
+			AndWhere(dbx.HashExp{"name": s.viewName}).
+			Row(&objectType)
+		if err != nil || objectType != "table" {
+			t.Errorf("[%s] Expected %q to be a table, got %q (%v)", s.scenarioName, s.viewName, objectType, err)
+			continue
+		}
+
+		infoRows, err := app.Dao().TableInfo(s.viewName)
+		if err != nil {
+			t.Errorf("[%s] Failed to fetch table info for %s: %v", s.scenarioName, s.viewName, err)
+			continue
+		}
+
+		if len(s.expectColumns) != len(infoRows) {
+			t.Errorf("[%s] Expected %d columns, got %d", s.scenarioName, len(s.expectColumns), len(infoRows))
+			continue
+		}
+
+		for _, row := range infoRows {
+			if !list.ExistInSlice(row.Name, s.expectColumns) {
+				t.Errorf("[%s] Missing %q column in %v", s.scenarioName, row.Name, s.expectColumns)
+			}
+		}
+	}
+}
+
+func TestRefreshMaterializedView(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	countRows := func(table string) int {
+		var total int
+		if err := app.Dao().DB().Select("count(*)").From(table).Row(&total); err != nil {
+			t.Fatal(err)
+		}
+		return total
+	}
+
+	// non view collection
+	demo2, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := app.Dao().RefreshMaterializedView(demo2); err == nil {
+		t.Fatal("Expected error for non view collection, got nil")
+	}
+
+	// non materialized view collection
+	view1, err := app.Dao().FindCollectionByNameOrId("view1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := app.Dao().RefreshMaterializedView(view1); err == nil {
+		t.Fatal("Expected error for non materialized view collection, got nil")
+	}
+
+	collection := &models.Collection{
+		Name: "mat_demo2",
+		Type: models.CollectionTypeView,
+	}
+	collection.SetOptions(models.CollectionViewOptions{
+		Query:        "select id, title from demo2",
+		Materialized: true,
+	})
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	if total := countRows(collection.Name); total != 3 {
+		t.Fatalf("Expected 3 initial rows, got %d", total)
+	}
+
+	record := models.NewRecord(demo2)
+	record.Set("title", "test_refresh")
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	// the stored results shouldn't be updated until refresh
+	if total := countRows(collection.Name); total != 3 {
+		t.Fatalf("Expected 3 rows before refresh, got %d", total)
+	}
+
+	if err := app.Dao().RefreshMaterializedView(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	if total := countRows(collection.Name); total != 4 {
+		t.Fatalf("Expected 4 rows after refresh, got %d", total)
+	}
+
+	// switch back to a regular view
+	collection.SetOptions(models.CollectionViewOptions{
+		Query: "select id, title from demo2",
+	})
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	var objectType string
+	err = app.Dao().DB().Select("type").
+		From("sqlite_schema").
+		AndWhere(dbx.HashExp{"name": collection.Name}).
+		Row(&objectType)
+	if err != nil || objectType != "view" {
+		t.Fatalf("Expected %q to be a view, got %q (%v)", collection.Name, objectType, err)
+	}
+
+	// switch again to materialized and delete
+	collection.SetOptions(models.CollectionViewOptions{
+		Query:        "select id, title from demo2",
+		Materialized: true,
+	})
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.Dao().DeleteCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+	if app.Dao().HasTable(collection.Name) {
+		t.Fatalf("Expected the materialized table %q to be deleted", collection.Name)
+	}
+}
+
 func TestCreateViewSchemaWithDiscardedNestedTransaction(t *testing.T) {
 	app, _ := tests.NewTestApp()
 	defer app.Cleanup()
@@ -0,0 +1,48 @@
+package daos
+
+import (
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// WebhookDeliveryQuery returns a new WebhookDelivery select query.
+func (dao *Dao) WebhookDeliveryQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.WebhookDelivery{})
+}
+
+// FindWebhookDeliveryById finds a single WebhookDelivery by its id.
+func (dao *Dao) FindWebhookDeliveryById(id string) (*models.WebhookDelivery, error) {
+	model := &models.WebhookDelivery{}
+
+	err := dao.WebhookDeliveryQuery().
+		AndWhere(dbx.HashExp{"id": id}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// DeleteOldWebhookDeliveries delete all webhook deliveries that are created before createdBefore.
+func (dao *Dao) DeleteOldWebhookDeliveries(createdBefore time.Time) error {
+	m := models.WebhookDelivery{}
+	tableName := m.TableName()
+
+	formattedDate := createdBefore.UTC().Format(types.DefaultDateLayout)
+	expr := dbx.NewExp("[[created]] <= {:date}", dbx.Params{"date": formattedDate})
+
+	_, err := dao.NonconcurrentDB().Delete(tableName, expr).Execute()
+
+	return err
+}
+
+// SaveWebhookDelivery upserts the provided WebhookDelivery model.
+func (dao *Dao) SaveWebhookDelivery(delivery *models.WebhookDelivery) error {
+	return dao.Save(delivery)
+}
@@ -0,0 +1,112 @@
+package daos_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestWebhookDeliveryQuery(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	expected := "SELECT {{_webhookDeliveries}}.* FROM `_webhookDeliveries`"
+
+	sql := app.LogsDao().WebhookDeliveryQuery().Build().SQL()
+	if sql != expected {
+		t.Errorf("Expected sql %s, got %s", expected, sql)
+	}
+}
+
+func TestFindWebhookDeliveryById(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	tests.MockWebhookDeliveriesData(app)
+
+	scenarios := []struct {
+		id          string
+		expectError bool
+	}{
+		{"", true},
+		{"invalid", true},
+		{"d1b2c3d4-0000-4000-8000-000000000001", false},
+	}
+
+	for i, scenario := range scenarios {
+		delivery, err := app.LogsDao().FindWebhookDeliveryById(scenario.id)
+
+		hasErr := err != nil
+		if hasErr != scenario.expectError {
+			t.Errorf("(%d) Expected hasErr to be %v, got %v (%v)", i, scenario.expectError, hasErr, err)
+		}
+
+		if delivery != nil && delivery.Id != scenario.id {
+			t.Errorf("(%d) Expected webhook delivery with id %s, got %s", i, scenario.id, delivery.Id)
+		}
+	}
+}
+
+func TestDeleteOldWebhookDeliveries(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	tests.MockWebhookDeliveriesData(app)
+
+	scenarios := []struct {
+		date          string
+		expectedTotal int
+	}{
+		{"2022-01-01 10:00:00.000Z", 2},
+		{"2022-05-01 11:00:00.000Z", 1},
+		{"2022-05-03 11:00:00.000Z", 0},
+	}
+
+	for i, scenario := range scenarios {
+		date, dateErr := time.Parse(types.DefaultDateLayout, scenario.date)
+		if dateErr != nil {
+			t.Errorf("(%d) Date error %v", i, dateErr)
+		}
+
+		if err := app.LogsDao().DeleteOldWebhookDeliveries(date); err != nil {
+			t.Errorf("(%d) Delete error %v", i, err)
+		}
+
+		var total int
+		if err := app.LogsDao().WebhookDeliveryQuery().Select("count(*)").Row(&total); err != nil {
+			t.Errorf("(%d) Count error %v", i, err)
+		}
+
+		if total != scenario.expectedTotal {
+			t.Errorf("(%d) Expected %d remaining webhook deliveries, got %d", i, scenario.expectedTotal, total)
+		}
+	}
+}
+
+func TestSaveWebhookDelivery(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	delivery := &models.WebhookDelivery{
+		Webhook: "crm",
+		Event:   "records.update",
+		Payload: types.JsonRaw(`{"event":"records.update"}`),
+		Status:  models.WebhookDeliveryStatusPending,
+	}
+
+	if err := app.LogsDao().SaveWebhookDelivery(delivery); err != nil {
+		t.Fatal(err)
+	}
+
+	saved, err := app.LogsDao().FindWebhookDeliveryById(delivery.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if saved.Webhook != "crm" || saved.Status != models.WebhookDeliveryStatusPending || saved.Payload.String() != `{"event":"records.update"}` {
+		t.Fatalf("Unexpected saved webhook delivery %v", saved)
+	}
+}
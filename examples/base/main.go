@@ -13,6 +13,8 @@ import (
 	"github.com/pocketbase/pocketbase/plugins/ghupdate"
 	"github.com/pocketbase/pocketbase/plugins/jsvm"
 	"github.com/pocketbase/pocketbase/plugins/migratecmd"
+	"github.com/pocketbase/pocketbase/plugins/mqttbridge"
+	"github.com/pocketbase/pocketbase/plugins/usersync"
 )
 
 func main() {
@@ -62,6 +64,22 @@ func main() {
 		"the default SELECT queries timeout in seconds",
 	)
 
+	var mqttAddress string
+	app.RootCmd.PersistentFlags().StringVar(
+		&mqttAddress,
+		"mqtt",
+		"",
+		"TCP address of the optional MQTT record events bridge (eg. :1883)",
+	)
+
+	var usersSyncRegistry string
+	app.RootCmd.PersistentFlags().StringVar(
+		&usersSyncRegistry,
+		"usersSync",
+		"",
+		"connection string of the GORM users registry to sync with the users auth collection",
+	)
+
 	app.RootCmd.ParseFlags(os.Args[1:])
 
 	// ---------------------------------------------------------------
@@ -83,6 +101,20 @@ func main() {
 	// GitHub selfupdate
 	ghupdate.MustRegister(app, app.RootCmd, nil)
 
+	// MQTT record events bridge
+	if mqttAddress != "" {
+		mqttbridge.MustRegister(app, &mqttbridge.Options{
+			Address: mqttAddress,
+		})
+	}
+
+	// GORM users <-> auth records sync bridge
+	if usersSyncRegistry != "" {
+		usersync.MustRegister(app, &usersync.Options{
+			Registry: usersSyncRegistry,
+		})
+	}
+
 	app.OnAfterBootstrap().Add(func(e *core.BootstrapEvent) error {
 		app.Dao().ModelQueryTimeout = time.Duration(queryTimeout) * time.Second
 		return nil
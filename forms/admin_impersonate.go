@@ -0,0 +1,79 @@
+package forms
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tokens"
+)
+
+// DefaultImpersonateDuration is the default impersonate token duration in seconds.
+const DefaultImpersonateDuration int64 = 900 // 15 minutes
+
+// AdminImpersonate is a form that generates a short-lived
+// auth token of an auth record on behalf of an admin.
+type AdminImpersonate struct {
+	app    core.App
+	record *models.Record
+
+	// Duration is the token duration in seconds (if not set, [DefaultImpersonateDuration]
+	// or the record auth token duration is used, whichever is shorter).
+	Duration int64 `form:"duration" json:"duration"`
+}
+
+// NewAdminImpersonate creates a new [AdminImpersonate] form
+// initialized with from the provided [core.App] and [models.Record] instances.
+func NewAdminImpersonate(app core.App, record *models.Record) *AdminImpersonate {
+	return &AdminImpersonate{
+		app:    app,
+		record: record,
+	}
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *AdminImpersonate) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(
+			&form.Duration,
+			validation.Min(0),
+			validation.Max(form.app.Settings().RecordAuthToken.Duration),
+		),
+	)
+}
+
+// Submit validates and submits the form.
+// On success returns the generated impersonate auth token.
+//
+// You can optionally provide a list of InterceptorFunc to
+// further modify the form behavior before generating the token.
+func (form *AdminImpersonate) Submit(interceptors ...InterceptorFunc[*models.Record]) (string, error) {
+	if err := form.Validate(); err != nil {
+		return "", err
+	}
+
+	var token string
+
+	interceptorsErr := runInterceptors(form.record, func(m *models.Record) error {
+		form.record = m
+
+		duration := form.Duration
+		if duration == 0 {
+			duration = DefaultImpersonateDuration
+
+			if maxDuration := form.app.Settings().RecordAuthToken.Duration; duration > maxDuration {
+				duration = maxDuration
+			}
+		}
+
+		var err error
+		token, err = tokens.NewRecordImpersonateToken(form.app, form.record, duration)
+
+		return err
+	}, interceptors...)
+
+	if interceptorsErr != nil {
+		return "", interceptorsErr
+	}
+
+	return token, nil
+}
@@ -0,0 +1,65 @@
+package forms_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/spf13/cast"
+)
+
+func TestAdminImpersonateSubmit(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	user, err := app.Dao().FindAuthRecordByEmail("users", "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app.Settings().RecordAuthToken.Duration = 300
+
+	scenarios := []struct {
+		name        string
+		duration    int64
+		expectError bool
+		expectedExp int64 // max expected token duration in seconds
+	}{
+		{"negative duration", -1, true, 0},
+		{"duration > auth token duration", 301, true, 0},
+		{"default duration (capped to the auth token duration)", 0, false, 300},
+		{"custom duration", 10, false, 10},
+	}
+
+	for _, s := range scenarios {
+		form := forms.NewAdminImpersonate(app, user)
+		form.Duration = s.duration
+
+		token, err := form.Submit()
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("[%s] Expected hasErr %v, got %v (%v)", s.name, s.expectError, hasErr, err)
+			continue
+		}
+
+		if hasErr {
+			continue
+		}
+
+		record, _ := app.Dao().FindAuthRecordByToken(token, app.Settings().RecordAuthToken.Secret)
+		if record == nil || record.Id != user.Id {
+			t.Errorf("[%s] Expected the token to be valid auth token of %q", s.name, user.Id)
+			continue
+		}
+
+		claims, _ := security.ParseUnverifiedJWT(token)
+		exp := cast.ToInt64(claims["exp"])
+		now := time.Now().Unix()
+		if exp > now+s.expectedExp || exp < now+s.expectedExp-5 {
+			t.Errorf("[%s] Expected the token to expire in ~%d seconds, got %d", s.name, s.expectedExp, exp-now)
+		}
+	}
+}
@@ -19,6 +19,10 @@ type AdminLogin struct {
 
 	Identity string `form:"identity" json:"identity"`
 	Password string `form:"password" json:"password"`
+
+	// OtpCode is a TOTP code or an unused recovery code
+	// (required only if the admin has the two-factor authentication enabled).
+	OtpCode string `form:"otpCode" json:"otpCode"`
 }
 
 // NewAdminLogin creates a new [AdminLogin] form initialized with
@@ -70,6 +74,10 @@ func (form *AdminLogin) Submit(interceptors ...InterceptorFunc[*models.Admin]) (
 			return errors.New("Invalid login credentials.")
 		}
 
+		if admin.OtpEnabled {
+			return form.checkOtpCode(admin)
+		}
+
 		return nil
 	}, interceptors...)
 
@@ -79,3 +87,21 @@ func (form *AdminLogin) Submit(interceptors ...InterceptorFunc[*models.Admin]) (
 
 	return admin, nil
 }
+
+// checkOtpCode validates the form OTP code against the provided admin
+// and persists the admin used TOTP time step or recovery code.
+func (form *AdminLogin) checkOtpCode(admin *models.Admin) error {
+	if form.OtpCode == "" {
+		return validation.Errors{
+			"otpCode": validation.NewError("validation_otp_required", "Missing one-time code."),
+		}
+	}
+
+	if !admin.ValidateOtpCode(form.OtpCode) {
+		return validation.Errors{
+			"otpCode": validation.NewError("validation_invalid_otp_code", "Invalid one-time or recovery code."),
+		}
+	}
+
+	return form.dao.SaveAdmin(admin)
+}
@@ -3,10 +3,12 @@ package forms_test
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/pocketbase/pocketbase/forms"
 	"github.com/pocketbase/pocketbase/models"
 	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/security"
 )
 
 func TestAdminLoginValidateAndSubmit(t *testing.T) {
@@ -94,3 +96,63 @@ func TestAdminLoginInterceptors(t *testing.T) {
 		t.Fatalf("Expected Admin model with email %s, got %v", form.Identity, interceptorAdmin)
 	}
 }
+
+func TestAdminLoginWithOtp(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	admin, _ := app.Dao().FindAdminByEmail("test@example.com")
+	admin.OtpSecret = security.NewTOTPSecret()
+	admin.OtpEnabled = true
+	admin.OtpRecoveryCodes = []string{models.HashOtpRecoveryCode("recovery")}
+	if err := app.Dao().SaveAdmin(admin); err != nil {
+		t.Fatal(err)
+	}
+
+	code, _ := security.TOTPCode(admin.OtpSecret, time.Now())
+
+	scenarios := []struct {
+		name          string
+		password      string
+		otpCode       string
+		expectedError string
+	}{
+		{"invalid password", "123456", code, ""},
+		{"missing otp code", "1234567890", "", "validation_otp_required"},
+		{"invalid otp code", "1234567890", "123456789", "validation_invalid_otp_code"},
+		{"valid otp code", "1234567890", code, ""},
+		{"already used otp code", "1234567890", code, "validation_invalid_otp_code"},
+		{"valid recovery code", "1234567890", "recovery", ""},
+		{"already used recovery code", "1234567890", "recovery", "validation_invalid_otp_code"},
+	}
+
+	for _, s := range scenarios {
+		form := forms.NewAdminLogin(app)
+		form.Identity = admin.Email
+		form.Password = s.password
+		form.OtpCode = s.otpCode
+
+		result, err := form.Submit()
+
+		if s.password != "1234567890" {
+			if err == nil {
+				t.Errorf("[%s] Expected error, got nil", s.name)
+			}
+			continue
+		}
+
+		if errCode := otpErrorCode(err, "otpCode"); errCode != s.expectedError {
+			t.Errorf("[%s] Expected otpCode error %q, got %q (%v)", s.name, s.expectedError, errCode, err)
+			continue
+		}
+
+		if s.expectedError == "" && (err != nil || result == nil || result.Id != admin.Id) {
+			t.Errorf("[%s] Expected admin %q to be returned, got %v (%v)", s.name, admin.Id, result, err)
+		}
+	}
+
+	refreshed, _ := app.Dao().FindAdminById(admin.Id)
+	if len(refreshed.OtpRecoveryCodes) != 0 {
+		t.Fatalf("Expected the used recovery code to be removed, got %v", refreshed.OtpRecoveryCodes)
+	}
+}
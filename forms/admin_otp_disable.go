@@ -0,0 +1,75 @@
+package forms
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// AdminOtpDisable is an admin two-factor authentication disable form.
+type AdminOtpDisable struct {
+	app   core.App
+	dao   *daos.Dao
+	admin *models.Admin
+
+	// Code is a valid TOTP code or an unused recovery code.
+	Code string `form:"code" json:"code"`
+}
+
+// NewAdminOtpDisable creates a new [AdminOtpDisable] form
+// initialized with from the provided [core.App] and [models.Admin] instances.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewAdminOtpDisable(app core.App, admin *models.Admin) *AdminOtpDisable {
+	return &AdminOtpDisable{
+		app:   app,
+		dao:   app.Dao(),
+		admin: admin,
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *AdminOtpDisable) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *AdminOtpDisable) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(&form.Code, validation.Required, validation.By(form.checkCode)),
+	)
+}
+
+func (form *AdminOtpDisable) checkCode(value any) error {
+	v, _ := value.(string)
+
+	if !form.admin.OtpEnabled {
+		return validation.NewError("validation_otp_not_enabled", "The two-factor authentication is not enabled.")
+	}
+
+	if !form.admin.ValidateOtpCode(v) {
+		return validation.NewError("validation_invalid_otp_code", "Invalid one-time or recovery code.")
+	}
+
+	return nil
+}
+
+// Submit validates and submits the form.
+// On success disables the admin two-factor authentication.
+//
+// You can optionally provide a list of InterceptorFunc to
+// further modify the form behavior before persisting it.
+func (form *AdminOtpDisable) Submit(interceptors ...InterceptorFunc[*models.Admin]) error {
+	if err := form.Validate(); err != nil {
+		return err
+	}
+
+	form.admin.ResetOtp()
+
+	return runInterceptors(form.admin, func(m *models.Admin) error {
+		form.admin = m
+		return form.dao.SaveAdmin(m)
+	}, interceptors...)
+}
@@ -0,0 +1,67 @@
+package forms
+
+import (
+	"errors"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+// AdminOtpEnroll is an admin TOTP enrollment form.
+//
+// The enrollment generates a new TOTP secret that needs to be
+// confirmed with [AdminOtpVerify] before it is required on sign-in.
+type AdminOtpEnroll struct {
+	app   core.App
+	dao   *daos.Dao
+	admin *models.Admin
+}
+
+// NewAdminOtpEnroll creates a new [AdminOtpEnroll] form
+// initialized with from the provided [core.App] and [models.Admin] instances.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewAdminOtpEnroll(app core.App, admin *models.Admin) *AdminOtpEnroll {
+	return &AdminOtpEnroll{
+		app:   app,
+		dao:   app.Dao(),
+		admin: admin,
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *AdminOtpEnroll) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Submit generates and persists a new admin TOTP secret.
+// On success returns the "otpauth://" key URI of the generated secret.
+//
+// You can optionally provide a list of InterceptorFunc to
+// further modify the form behavior before persisting it.
+func (form *AdminOtpEnroll) Submit(interceptors ...InterceptorFunc[*models.Admin]) (string, error) {
+	if form.admin.OtpEnabled {
+		return "", errors.New("The two-factor authentication is already enabled.")
+	}
+
+	form.admin.ResetOtp()
+	form.admin.OtpSecret = security.NewTOTPSecret()
+
+	interceptorsErr := runInterceptors(form.admin, func(m *models.Admin) error {
+		form.admin = m
+		return form.dao.SaveAdmin(m)
+	}, interceptors...)
+
+	if interceptorsErr != nil {
+		return "", interceptorsErr
+	}
+
+	return security.TOTPAuthURI(
+		form.app.Settings().Meta.AppName,
+		form.admin.Email,
+		form.admin.OtpSecret,
+	), nil
+}
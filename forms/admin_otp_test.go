@@ -0,0 +1,154 @@
+package forms_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+func TestAdminOtpEnrollSubmit(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	admin, err := app.Dao().FindAdminByEmail("test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	uri, err := forms.NewAdminOtpEnroll(app, admin).Submit()
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	if admin.OtpSecret == "" || admin.OtpEnabled {
+		t.Fatalf("Expected enrolled but not enabled OTP, got %q (enabled %v)", admin.OtpSecret, admin.OtpEnabled)
+	}
+
+	if !strings.HasPrefix(uri, "otpauth://totp/") || !strings.Contains(uri, "secret="+admin.OtpSecret) {
+		t.Fatalf("Unexpected key uri %q", uri)
+	}
+
+	refreshed, _ := app.Dao().FindAdminById(admin.Id)
+	if refreshed.OtpSecret != admin.OtpSecret {
+		t.Fatalf("Expected the secret to be persisted, got %q", refreshed.OtpSecret)
+	}
+
+	// already enabled
+	admin.OtpEnabled = true
+	if _, err := forms.NewAdminOtpEnroll(app, admin).Submit(); err == nil {
+		t.Fatal("Expected error for already enabled OTP, got nil")
+	}
+}
+
+func TestAdminOtpVerifySubmit(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	secret := security.NewTOTPSecret()
+	code, _ := security.TOTPCode(secret, time.Now())
+
+	scenarios := []struct {
+		name          string
+		admin         *models.Admin
+		code          string
+		expectedError string
+	}{
+		{"empty code", &models.Admin{OtpSecret: secret}, "", "validation_required"},
+		{"not enrolled", &models.Admin{}, code, "validation_otp_not_enrolled"},
+		{"already enabled", &models.Admin{OtpSecret: secret, OtpEnabled: true}, code, "validation_otp_already_enabled"},
+		{"invalid code", &models.Admin{OtpSecret: secret}, "123", "validation_invalid_otp_code"},
+	}
+
+	for _, s := range scenarios {
+		form := forms.NewAdminOtpVerify(app, s.admin)
+		form.Code = s.code
+
+		_, err := form.Submit()
+		if err == nil || otpErrorCode(err, "code") != s.expectedError {
+			t.Errorf("[%s] Expected %q error, got %v", s.name, s.expectedError, err)
+		}
+	}
+
+	// valid code
+	admin, _ := app.Dao().FindAdminByEmail("test@example.com")
+	admin.OtpSecret = secret
+
+	form := forms.NewAdminOtpVerify(app, admin)
+	form.Code = code
+
+	recoveryCodes, err := form.Submit()
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	if len(recoveryCodes) != 10 {
+		t.Fatalf("Expected 10 recovery codes, got %v", recoveryCodes)
+	}
+
+	refreshed, _ := app.Dao().FindAdminById(admin.Id)
+	if !refreshed.OtpEnabled || len(refreshed.OtpRecoveryCodes) != 10 {
+		t.Fatalf("Expected enabled OTP with 10 recovery codes, got %v", refreshed)
+	}
+
+	for i, rc := range recoveryCodes {
+		if refreshed.OtpRecoveryCodes[i] != models.HashOtpRecoveryCode(rc) {
+			t.Fatalf("Expected recovery code %q to be stored hashed, got %q", rc, refreshed.OtpRecoveryCodes[i])
+		}
+	}
+}
+
+func TestAdminOtpDisableSubmit(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	admin, _ := app.Dao().FindAdminByEmail("test@example.com")
+
+	// not enabled
+	form := forms.NewAdminOtpDisable(app, admin)
+	form.Code = "123456"
+	if err := form.Submit(); err == nil || otpErrorCode(err, "code") != "validation_otp_not_enabled" {
+		t.Fatalf("Expected validation_otp_not_enabled error, got %v", err)
+	}
+
+	admin.OtpSecret = security.NewTOTPSecret()
+	admin.OtpEnabled = true
+	admin.OtpRecoveryCodes = []string{models.HashOtpRecoveryCode("recovery")}
+	if err := app.Dao().SaveAdmin(admin); err != nil {
+		t.Fatal(err)
+	}
+
+	// invalid code
+	form.Code = "123"
+	if err := form.Submit(); err == nil || otpErrorCode(err, "code") != "validation_invalid_otp_code" {
+		t.Fatalf("Expected validation_invalid_otp_code error, got %v", err)
+	}
+
+	// recovery code
+	form.Code = "recovery"
+	if err := form.Submit(); err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	refreshed, _ := app.Dao().FindAdminById(admin.Id)
+	if refreshed.OtpEnabled || refreshed.OtpSecret != "" || len(refreshed.OtpRecoveryCodes) != 0 {
+		t.Fatalf("Expected the OTP to be disabled, got %v", refreshed)
+	}
+}
+
+// otpErrorCode returns the validation error code of the specified form field (if any).
+func otpErrorCode(err error, field string) string {
+	errs, _ := err.(validation.Errors)
+
+	fieldErr, _ := errs[field].(validation.Error)
+	if fieldErr == nil {
+		return ""
+	}
+
+	return fieldErr.Code()
+}
@@ -0,0 +1,103 @@
+package forms
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+// otpRecoveryCodesCount is the number of the generated admin OTP recovery codes.
+const otpRecoveryCodesCount = 10
+
+// AdminOtpVerify is an admin TOTP enrollment verification form.
+type AdminOtpVerify struct {
+	app   core.App
+	dao   *daos.Dao
+	admin *models.Admin
+
+	Code string `form:"code" json:"code"`
+}
+
+// NewAdminOtpVerify creates a new [AdminOtpVerify] form
+// initialized with from the provided [core.App] and [models.Admin] instances.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewAdminOtpVerify(app core.App, admin *models.Admin) *AdminOtpVerify {
+	return &AdminOtpVerify{
+		app:   app,
+		dao:   app.Dao(),
+		admin: admin,
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *AdminOtpVerify) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *AdminOtpVerify) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(&form.Code, validation.Required, validation.By(form.checkCode)),
+	)
+}
+
+func (form *AdminOtpVerify) checkCode(value any) error {
+	v, _ := value.(string)
+
+	if form.admin.OtpEnabled {
+		return validation.NewError("validation_otp_already_enabled", "The two-factor authentication is already enabled.")
+	}
+
+	if form.admin.OtpSecret == "" {
+		return validation.NewError("validation_otp_not_enrolled", "Missing two-factor authentication enrollment.")
+	}
+
+	step, ok := security.MatchTOTP(form.admin.OtpSecret, v, time.Now())
+	if !ok {
+		return validation.NewError("validation_invalid_otp_code", "Invalid one-time code.")
+	}
+
+	// prevent the verification code reuse
+	form.admin.OtpLastStep = step
+
+	return nil
+}
+
+// Submit validates and submits the form.
+// On success enables the admin two-factor authentication and
+// returns the generated plain recovery codes (they are stored hashed).
+//
+// You can optionally provide a list of InterceptorFunc to
+// further modify the form behavior before persisting it.
+func (form *AdminOtpVerify) Submit(interceptors ...InterceptorFunc[*models.Admin]) ([]string, error) {
+	if err := form.Validate(); err != nil {
+		return nil, err
+	}
+
+	recoveryCodes := make([]string, otpRecoveryCodesCount)
+	hashes := make([]string, otpRecoveryCodesCount)
+	for i := range recoveryCodes {
+		recoveryCodes[i] = security.RandomStringWithAlphabet(10, "abcdefghijklmnopqrstuvwxyz0123456789")
+		hashes[i] = models.HashOtpRecoveryCode(recoveryCodes[i])
+	}
+
+	form.admin.OtpEnabled = true
+	form.admin.OtpRecoveryCodes = hashes
+
+	interceptorsErr := runInterceptors(form.admin, func(m *models.Admin) error {
+		form.admin = m
+		return form.dao.SaveAdmin(m)
+	}, interceptors...)
+
+	if interceptorsErr != nil {
+		return nil, interceptorsErr
+	}
+
+	return recoveryCodes, nil
+}
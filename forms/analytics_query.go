@@ -0,0 +1,96 @@
+package forms
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// AnalyticsQueryResult defines the result of a single analytics query execution.
+type AnalyticsQueryResult struct {
+	SqlConsoleResult
+
+	// Snapshot is the date of the last analytics snapshot refresh.
+	Snapshot types.DateTime `json:"snapshot"`
+}
+
+// AnalyticsQuery is a form that executes a single read-only SQL
+// query against the analytics snapshot database.
+type AnalyticsQuery struct {
+	app core.App
+	ctx context.Context
+
+	Query  string         `form:"query" json:"query"`
+	Params map[string]any `form:"params" json:"params"`
+}
+
+// NewAnalyticsQuery creates a new [AnalyticsQuery] form.
+func NewAnalyticsQuery(app core.App) *AnalyticsQuery {
+	return &AnalyticsQuery{
+		app: app,
+		ctx: context.Background(),
+	}
+}
+
+// SetContext replaces the default form context with the provided one.
+func (form *AnalyticsQuery) SetContext(ctx context.Context) {
+	form.ctx = ctx
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *AnalyticsQuery) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(
+			&form.Query,
+			validation.Required,
+			validation.Length(1, 10000),
+			validation.By(checkSqlSingleStatement),
+			validation.By(checkSqlReadOnly),
+		),
+	)
+}
+
+// Submit validates and executes the form query.
+//
+// You can optionally provide a list of InterceptorFunc to further
+// modify the form behavior before returning the query result.
+func (form *AnalyticsQuery) Submit(interceptors ...InterceptorFunc[*AnalyticsQueryResult]) error {
+	if err := form.Validate(); err != nil {
+		return err
+	}
+
+	config := form.app.Settings().Analytics
+	if !config.Enabled {
+		return errors.New("the analytics queries are not enabled")
+	}
+
+	ctx, cancel := context.WithTimeout(form.ctx, time.Duration(config.Timeout)*time.Second)
+	defer cancel()
+
+	result := &AnalyticsQueryResult{}
+	result.Columns = []string{}
+	result.Rows = [][]any{}
+
+	start := time.Now()
+
+	snapshot, err := form.app.RunAnalyticsQuery(ctx, func(tx dbx.Builder) error {
+		q := tx.NewQuery(form.Query).Bind(dbx.Params(form.Params)).WithContext(ctx)
+
+		return fetchSqlRows(q, config.MaxRows, &result.SqlConsoleResult)
+	})
+	if err != nil {
+		return err
+	}
+
+	result.Snapshot = snapshot
+	result.Duration = float64(time.Since(start).Microseconds()) / 1000
+
+	return runInterceptors(result, func(r *AnalyticsQueryResult) error {
+		return nil
+	}, interceptors...)
+}
@@ -0,0 +1,128 @@
+package forms_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestAnalyticsQuerySubmit(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		disabled       bool
+		maxRows        int
+		jsonData       string
+		expectedErrors []string
+		expectError    bool
+		expectedResult []string
+	}{
+		{
+			name:           "empty data",
+			jsonData:       `{}`,
+			expectedErrors: []string{"query"},
+		},
+		{
+			name:        "disabled analytics",
+			disabled:    true,
+			jsonData:    `{"query":"SELECT 1"}`,
+			expectError: true,
+		},
+		{
+			name:           "multiple statements",
+			jsonData:       `{"query":"SELECT 1; SELECT 2;"}`,
+			expectedErrors: []string{"query"},
+		},
+		{
+			name:           "write statement",
+			jsonData:       `{"query":"DELETE FROM demo2"}`,
+			expectedErrors: []string{"query"},
+		},
+		{
+			name:        "write statement in a cte",
+			jsonData:    `{"query":"WITH t AS (SELECT 1) DELETE FROM demo2"}`,
+			expectError: true,
+		},
+		{
+			name:        "invalid query",
+			jsonData:    `{"query":"SELECT * FROM missing"}`,
+			expectError: true,
+		},
+		{
+			name:           "read query with params",
+			jsonData:       `{"query":"SELECT id FROM demo2 WHERE title = {:title}","params":{"title":"test2"}}`,
+			expectedResult: []string{`"columns":["id"]`, `"rows":[["achvryl401bhse3"]]`, `"snapshot":"20`},
+		},
+		{
+			name:           "read query with max rows limit",
+			maxRows:        2,
+			jsonData:       `{"query":"SELECT id FROM demo2 ORDER BY title"}`,
+			expectedResult: []string{`"rows":[["llvuca81nly1qls"],["achvryl401bhse3"]]`, `"truncated":true`},
+		},
+	}
+
+	for _, s := range scenarios {
+		func() {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			app.Settings().Analytics.Enabled = !s.disabled
+			if s.maxRows > 0 {
+				app.Settings().Analytics.MaxRows = s.maxRows
+			}
+
+			form := forms.NewAnalyticsQuery(app)
+
+			if err := json.Unmarshal([]byte(s.jsonData), form); err != nil {
+				t.Fatalf("[%s] Failed to load form data: %v", s.name, err)
+			}
+
+			var result *forms.AnalyticsQueryResult
+
+			err := form.Submit(func(next forms.InterceptorNextFunc[*forms.AnalyticsQueryResult]) forms.InterceptorNextFunc[*forms.AnalyticsQueryResult] {
+				return func(r *forms.AnalyticsQueryResult) error {
+					result = r
+					return next(r)
+				}
+			})
+
+			if s.expectError {
+				if err == nil {
+					t.Errorf("[%s] Expected error, got nil", s.name)
+				}
+				return
+			}
+
+			// parse errors
+			errs, ok := err.(validation.Errors)
+			if !ok && err != nil {
+				t.Errorf("[%s] Failed to parse errors %v", s.name, err)
+				return
+			}
+
+			// check errors
+			if len(errs) > len(s.expectedErrors) {
+				t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+			}
+			for _, k := range s.expectedErrors {
+				if _, ok := errs[k]; !ok {
+					t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+				}
+			}
+
+			if len(s.expectedErrors) > 0 {
+				return
+			}
+
+			raw, _ := json.Marshal(result)
+			for _, expected := range s.expectedResult {
+				if !strings.Contains(string(raw), expected) {
+					t.Errorf("[%s] Expected %s in result %s", s.name, expected, raw)
+				}
+			}
+		}()
+	}
+}
@@ -0,0 +1,209 @@
+package forms
+
+import (
+	"errors"
+	"strconv"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/anonymize"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+const (
+	defaultAnonymizedBundleLimit = 500
+	maxAnonymizedBundleLimit     = 10000
+)
+
+// AnonymizedBundle defines an anonymized records export bundle.
+type AnonymizedBundle struct {
+	Profile     string                        `json:"profile"`
+	Created     types.DateTime                `json:"created"`
+	Collections []*AnonymizedBundleCollection `json:"collections"`
+}
+
+// AnonymizedBundleCollection defines the anonymized records
+// of a single bundle collection.
+type AnonymizedBundleCollection struct {
+	Id      string           `json:"id"`
+	Name    string           `json:"name"`
+	Type    string           `json:"type"`
+	Schema  schema.Schema    `json:"schema"`
+	Records []map[string]any `json:"records"`
+
+	// Truncated indicates that the collection has more records than the form limit.
+	Truncated bool `json:"truncated"`
+}
+
+// AnonymizedBundleExport is a form that exports the records of the
+// selected collections anonymized with one of the
+// app.Settings().Anonymization profiles.
+type AnonymizedBundleExport struct {
+	app core.App
+	dao *daos.Dao
+
+	Profile string `form:"profile" json:"profile"`
+
+	// Collections is a list with the names or ids of the collections
+	// to export (all collections if empty).
+	Collections []string `form:"collections" json:"collections"`
+
+	// Limit is the max number of exported records per collection.
+	Limit int `form:"limit" json:"limit"`
+}
+
+// NewAnonymizedBundleExport creates a new [AnonymizedBundleExport] form
+// initialized with the default limit.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewAnonymizedBundleExport(app core.App) *AnonymizedBundleExport {
+	return &AnonymizedBundleExport{
+		app:   app,
+		dao:   app.Dao(),
+		Limit: defaultAnonymizedBundleLimit,
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *AnonymizedBundleExport) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *AnonymizedBundleExport) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(&form.Profile, validation.Required, validation.By(form.checkProfile)),
+		validation.Field(&form.Collections, validation.By(form.checkCollections)),
+		validation.Field(&form.Limit, validation.Min(0), validation.Max(maxAnonymizedBundleLimit)),
+	)
+}
+
+func (form *AnonymizedBundleExport) checkProfile(value any) error {
+	v, _ := value.(string)
+
+	if _, ok := form.app.Settings().Anonymization.Profile(v); !ok {
+		return validation.NewError("validation_invalid_anonymization_profile", "Missing or invalid anonymization profile.")
+	}
+
+	return nil
+}
+
+func (form *AnonymizedBundleExport) checkCollections(value any) error {
+	v, _ := value.([]string)
+
+	errs := validation.Errors{}
+
+	for i, nameOrId := range v {
+		if _, err := form.dao.FindCollectionByNameOrId(nameOrId); err != nil {
+			errs[strconv.Itoa(i)] = validation.NewError("validation_invalid_collection", "Missing or invalid collection.")
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// Submit validates the form and builds the anonymized bundle.
+//
+// You can optionally provide a list of InterceptorFunc to further
+// modify the form behavior before returning the bundle.
+func (form *AnonymizedBundleExport) Submit(interceptors ...InterceptorFunc[*AnonymizedBundle]) error {
+	if err := form.Validate(); err != nil {
+		return err
+	}
+
+	config := form.app.Settings().Anonymization
+
+	profile, ok := config.Profile(form.Profile)
+	if !ok {
+		return errors.New("missing anonymization profile")
+	}
+
+	collections, err := form.loadCollections()
+	if err != nil {
+		return err
+	}
+
+	limit := form.Limit
+	if limit <= 0 {
+		limit = defaultAnonymizedBundleLimit
+	}
+
+	bundle := &AnonymizedBundle{
+		Profile:     form.Profile,
+		Created:     types.NowDateTime(),
+		Collections: make([]*AnonymizedBundleCollection, 0, len(collections)),
+	}
+
+	for _, collection := range collections {
+		query := form.dao.RecordQuery(collection).Limit(int64(limit) + 1)
+
+		// views are not guaranteed to have a created column
+		if !collection.IsView() {
+			query.OrderBy(schema.FieldNameCreated + " ASC")
+		}
+
+		records := []*models.Record{}
+		if err := query.All(&records); err != nil {
+			return err
+		}
+
+		item := &AnonymizedBundleCollection{
+			Id:      collection.Id,
+			Name:    collection.Name,
+			Type:    collection.Type,
+			Schema:  collection.Schema,
+			Records: make([]map[string]any, 0, len(records)),
+		}
+
+		if len(records) > limit {
+			records = records[:limit]
+			item.Truncated = true
+		}
+
+		fields := profile.CollectionFields(collection.Name)
+
+		for _, record := range records {
+			record.IgnoreEmailVisibility(true)
+
+			data := record.PublicExport()
+			anonymize.Apply(data, fields, config.Salt)
+
+			item.Records = append(item.Records, data)
+		}
+
+		bundle.Collections = append(bundle.Collections, item)
+	}
+
+	return runInterceptors(bundle, func(b *AnonymizedBundle) error {
+		return nil
+	}, interceptors...)
+}
+
+func (form *AnonymizedBundleExport) loadCollections() ([]*models.Collection, error) {
+	collections := []*models.Collection{}
+
+	if len(form.Collections) == 0 {
+		err := form.dao.CollectionQuery().OrderBy("created ASC").All(&collections)
+
+		return collections, err
+	}
+
+	for _, nameOrId := range form.Collections {
+		collection, err := form.dao.FindCollectionByNameOrId(nameOrId)
+		if err != nil {
+			return nil, err
+		}
+
+		collections = append(collections, collection)
+	}
+
+	return collections, nil
+}
@@ -0,0 +1,150 @@
+package forms_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/anonymize"
+)
+
+func TestAnonymizedBundleExportSubmit(t *testing.T) {
+	salt := "test_salt_123"
+
+	scenarios := []struct {
+		name                string
+		jsonData            string
+		expectedErrors      []string
+		expectedCollections int
+		expectedResult      []string
+		notExpectedResult   []string
+	}{
+		{
+			name:           "empty data",
+			jsonData:       `{}`,
+			expectedErrors: []string{"profile"},
+		},
+		{
+			name:           "invalid data",
+			jsonData:       `{"profile":"missing","collections":["demo2","missing"],"limit":10001}`,
+			expectedErrors: []string{"profile", "collections", "limit"},
+		},
+		{
+			name:                "selected collections",
+			jsonData:            `{"profile":"dev","collections":["users","demo2"]}`,
+			expectedCollections: 2,
+			expectedResult: []string{
+				`"profile":"dev"`,
+				`"name":"users","type":"auth"`,
+				`"name":"demo2","type":"base"`,
+				`"email":"t**t@example.com"`,
+				`"email":"t***2@example.com"`,
+				`"name":"name_`,
+				`"name":""`,
+				`"title":"` + anonymize.Hash("test1", salt) + `"`,
+				`"title":"` + anonymize.Hash("test3", salt) + `"`,
+				`"truncated":false`,
+			},
+			notExpectedResult: []string{
+				`test@example.com`,
+				`"name":"test1"`,
+				`"title":"test1"`,
+			},
+		},
+		{
+			name:                "limit",
+			jsonData:            `{"profile":"dev","collections":["demo2"],"limit":2}`,
+			expectedCollections: 1,
+			expectedResult: []string{
+				`"title":"` + anonymize.Hash("test1", salt) + `"`,
+				`"title":"` + anonymize.Hash("test2", salt) + `"`,
+				`"truncated":true`,
+			},
+			notExpectedResult: []string{
+				`"title":"` + anonymize.Hash("test3", salt) + `"`,
+			},
+		},
+		{
+			name:                "all collections",
+			jsonData:            `{"profile":"dev"}`,
+			expectedCollections: 10,
+			expectedResult: []string{
+				`"name":"view1","type":"view"`,
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		func() {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			app.Settings().Anonymization = settings.AnonymizationConfig{
+				Salt: salt,
+				Profiles: map[string]settings.AnonymizationProfile{
+					"dev": {Fields: map[string]string{
+						"email":       anonymize.StrategyMask,
+						"users.name":  anonymize.StrategyFake,
+						"demo2.title": anonymize.StrategyHash,
+					}},
+				},
+			}
+
+			form := forms.NewAnonymizedBundleExport(app)
+
+			if err := json.Unmarshal([]byte(s.jsonData), form); err != nil {
+				t.Fatalf("[%s] Failed to load form data: %v", s.name, err)
+			}
+
+			var result *forms.AnonymizedBundle
+
+			err := form.Submit(func(next forms.InterceptorNextFunc[*forms.AnonymizedBundle]) forms.InterceptorNextFunc[*forms.AnonymizedBundle] {
+				return func(b *forms.AnonymizedBundle) error {
+					result = b
+					return next(b)
+				}
+			})
+
+			// parse errors
+			errs, ok := err.(validation.Errors)
+			if !ok && err != nil {
+				t.Errorf("[%s] Failed to parse errors %v", s.name, err)
+				return
+			}
+
+			// check errors
+			if len(errs) > len(s.expectedErrors) {
+				t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+			}
+			for _, k := range s.expectedErrors {
+				if _, ok := errs[k]; !ok {
+					t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+				}
+			}
+
+			if len(s.expectedErrors) > 0 {
+				return
+			}
+
+			if len(result.Collections) != s.expectedCollections {
+				t.Errorf("[%s] Expected %d collections, got %d", s.name, s.expectedCollections, len(result.Collections))
+			}
+
+			raw, _ := json.Marshal(result)
+			for _, expected := range s.expectedResult {
+				if !strings.Contains(string(raw), expected) {
+					t.Errorf("[%s] Expected %s in result %s", s.name, expected, raw)
+				}
+			}
+			for _, notExpected := range s.notExpectedResult {
+				if strings.Contains(string(raw), notExpected) {
+					t.Errorf("[%s] Didn't expect %s in result %s", s.name, notExpected, raw)
+				}
+			}
+		}()
+	}
+}
@@ -0,0 +1,147 @@
+package forms
+
+import (
+	"regexp"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+var apiKeyScopeRegex = regexp.MustCompile(`^(\*|[a-zA-Z0-9][\w\.\-:]*)$`)
+
+// ApiKeyCreateResult defines the result of a successful [ApiKeyCreate] submit.
+//
+// Key is the plain api key and it is not possible to retrieve it later.
+type ApiKeyCreateResult struct {
+	*models.ApiKey
+
+	Key string `json:"key"`
+}
+
+// ApiKeyCreate is a [models.ApiKey] create form.
+//
+// The created key acts as the form admin, unless RecordId is set.
+type ApiKeyCreate struct {
+	app   core.App
+	dao   *daos.Dao
+	admin *models.Admin
+
+	Name   string   `form:"name" json:"name"`
+	Scopes []string `form:"scopes" json:"scopes"`
+
+	// Collection and RecordId are the optional auth record
+	// (collection name or id) that the key will act as.
+	Collection string `form:"collection" json:"collection"`
+	RecordId   string `form:"recordId" json:"recordId"`
+
+	// Expires is the optional key expiration date.
+	Expires types.DateTime `form:"expires" json:"expires"`
+}
+
+// NewApiKeyCreate creates a new [ApiKeyCreate] form initialized
+// with the provided [core.App] and [models.Admin] (the key creator) instances.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewApiKeyCreate(app core.App, admin *models.Admin) *ApiKeyCreate {
+	return &ApiKeyCreate{
+		app:   app,
+		dao:   app.Dao(),
+		admin: admin,
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *ApiKeyCreate) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *ApiKeyCreate) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(&form.Name, validation.Required, validation.Length(1, 100)),
+		validation.Field(
+			&form.Scopes,
+			validation.Required,
+			validation.Length(1, 50),
+			validation.Each(validation.Required, validation.Length(1, 100), validation.Match(apiKeyScopeRegex)),
+		),
+		validation.Field(&form.Collection, validation.When(form.RecordId != "", validation.Required)),
+		validation.Field(
+			&form.RecordId,
+			// keys created without an admin must belong to an auth record
+			validation.When(form.Collection != "" || form.admin == nil, validation.Required),
+			validation.By(form.checkRecord),
+		),
+		validation.Field(&form.Expires, validation.By(form.checkExpires)),
+	)
+}
+
+func (form *ApiKeyCreate) checkRecord(value any) error {
+	v, _ := value.(string)
+	if v == "" || form.Collection == "" {
+		return nil // nothing to check
+	}
+
+	collection, err := form.dao.FindCollectionByNameOrId(form.Collection)
+	if err != nil || !collection.IsAuth() {
+		return validation.NewError("validation_invalid_auth_record", "Missing or invalid auth record.")
+	}
+
+	if _, err := form.dao.FindRecordById(collection.Id, v); err != nil {
+		return validation.NewError("validation_invalid_auth_record", "Missing or invalid auth record.")
+	}
+
+	return nil
+}
+
+func (form *ApiKeyCreate) checkExpires(value any) error {
+	v, _ := value.(types.DateTime)
+
+	if !v.IsZero() && v.Time().Before(time.Now()) {
+		return validation.NewError("validation_invalid_expires", "The expiration date must be in the future.")
+	}
+
+	return nil
+}
+
+// Submit validates the form and creates a new api key.
+//
+// You can optionally provide a list of InterceptorFunc to further
+// modify the form behavior before persisting it.
+func (form *ApiKeyCreate) Submit(interceptors ...InterceptorFunc[*ApiKeyCreateResult]) error {
+	if err := form.Validate(); err != nil {
+		return err
+	}
+
+	apiKey := &models.ApiKey{
+		Name:    form.Name,
+		Scopes:  list.ToUniqueStringSlice(form.Scopes),
+		Expires: form.Expires,
+	}
+
+	if form.RecordId != "" {
+		collection, err := form.dao.FindCollectionByNameOrId(form.Collection)
+		if err != nil {
+			return err
+		}
+		apiKey.CollectionId = collection.Id
+		apiKey.RecordId = form.RecordId
+	} else if form.admin != nil {
+		apiKey.AdminId = form.admin.Id
+	}
+
+	result := &ApiKeyCreateResult{
+		ApiKey: apiKey,
+		Key:    apiKey.RefreshKey(),
+	}
+
+	return runInterceptors(result, func(r *ApiKeyCreateResult) error {
+		return form.dao.SaveApiKey(r.ApiKey)
+	}, interceptors...)
+}
@@ -0,0 +1,166 @@
+package forms_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestApiKeyCreateValidateAndSubmit(t *testing.T) {
+	scenarios := []struct {
+		name             string
+		withAdmin        bool
+		jsonData         string
+		expectedErrors   []string
+		expectedAdminId  string
+		expectedRecordId string
+	}{
+		{
+			"empty data",
+			true,
+			`{}`,
+			[]string{"name", "scopes"},
+			"",
+			"",
+		},
+		{
+			"invalid data",
+			true,
+			`{"name":"` + strings.Repeat("a", 101) + `","scopes":["users",""," invalid"],"expires":"2022-01-01 00:00:00.000Z"}`,
+			[]string{"name", "scopes", "expires"},
+			"",
+			"",
+		},
+		{
+			"record without collection",
+			true,
+			`{"name":"test","scopes":["users"],"recordId":"4q1xlclmfloku33"}`,
+			[]string{"collection"},
+			"",
+			"",
+		},
+		{
+			"non auth collection record",
+			true,
+			`{"name":"test","scopes":["users"],"collection":"demo2","recordId":"llvuca81nly1qls"}`,
+			[]string{"recordId"},
+			"",
+			"",
+		},
+		{
+			"missing auth record",
+			true,
+			`{"name":"test","scopes":["users"],"collection":"users","recordId":"missing"}`,
+			[]string{"recordId"},
+			"",
+			"",
+		},
+		{
+			"admin key without admin",
+			false,
+			`{"name":"test","scopes":["users"]}`,
+			[]string{"recordId"},
+			"",
+			"",
+		},
+		{
+			"valid admin key",
+			true,
+			`{"name":"test","scopes":["users","*","users"],"expires":"2100-01-01 00:00:00.000Z"}`,
+			[]string{},
+			"sywbhecnh46rhm0",
+			"",
+		},
+		{
+			"valid record key",
+			true,
+			`{"name":"test","scopes":["users"],"collection":"users","recordId":"4q1xlclmfloku33"}`,
+			[]string{},
+			"",
+			"4q1xlclmfloku33",
+		},
+	}
+
+	for _, s := range scenarios {
+		func() {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			var admin *models.Admin
+			if s.withAdmin {
+				admin, _ = app.Dao().FindAdminById("sywbhecnh46rhm0")
+			}
+
+			form := forms.NewApiKeyCreate(app, admin)
+
+			if err := json.Unmarshal([]byte(s.jsonData), form); err != nil {
+				t.Fatalf("[%s] Failed to load form data: %v", s.name, err)
+			}
+
+			var result *forms.ApiKeyCreateResult
+			interceptorCalls := 0
+
+			err := form.Submit(func(next forms.InterceptorNextFunc[*forms.ApiKeyCreateResult]) forms.InterceptorNextFunc[*forms.ApiKeyCreateResult] {
+				return func(r *forms.ApiKeyCreateResult) error {
+					interceptorCalls++
+					result = r
+					return next(r)
+				}
+			})
+
+			// parse errors
+			errs, ok := err.(validation.Errors)
+			if !ok && err != nil {
+				t.Errorf("[%s] Failed to parse errors %v", s.name, err)
+				return
+			}
+
+			// check errors
+			if len(errs) > len(s.expectedErrors) {
+				t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+			}
+			for _, k := range s.expectedErrors {
+				if _, ok := errs[k]; !ok {
+					t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+				}
+			}
+
+			if len(s.expectedErrors) > 0 {
+				if interceptorCalls != 0 {
+					t.Errorf("[%s] Expected the interceptor to not be called", s.name)
+				}
+				return
+			}
+
+			if interceptorCalls != 1 {
+				t.Fatalf("[%s] Expected the interceptor to be called once, got %d", s.name, interceptorCalls)
+			}
+
+			if result.AdminId != s.expectedAdminId {
+				t.Errorf("[%s] Expected adminId %q, got %q", s.name, s.expectedAdminId, result.AdminId)
+			}
+
+			if result.RecordId != s.expectedRecordId {
+				t.Errorf("[%s] Expected recordId %q, got %q", s.name, s.expectedRecordId, result.RecordId)
+			}
+
+			saved, err := app.Dao().FindApiKeyByKey(result.Key)
+			if err != nil {
+				t.Fatalf("[%s] Expected the api key to be persisted, got %v", s.name, err)
+			}
+
+			raw, _ := json.Marshal(result)
+			if !strings.Contains(string(raw), `"key":"`+result.Key+`"`) || !strings.Contains(string(raw), `"id":"`+saved.Id+`"`) {
+				t.Errorf("[%s] Expected the plain key and the model fields in the result, got %s", s.name, raw)
+			}
+			if strings.Contains(string(raw), saved.KeyHash) {
+				t.Errorf("[%s] Expected the key hash to be hidden, got %s", s.name, raw)
+			}
+		}()
+	}
+}
@@ -0,0 +1,97 @@
+package forms
+
+import (
+	"context"
+	"fmt"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// BackupRotateKey is a request form for rotating the backups encryption key.
+type BackupRotateKey struct {
+	app   core.App
+	ctx   context.Context
+	admin *models.Admin
+
+	NewKey string `form:"newKey" json:"newKey"`
+}
+
+// NewBackupRotateKey creates new BackupRotateKey request form.
+func NewBackupRotateKey(app core.App) *BackupRotateKey {
+	return &BackupRotateKey{
+		app: app,
+		ctx: context.Background(),
+	}
+}
+
+// SetContext replaces the default form context with the provided one.
+func (form *BackupRotateKey) SetContext(ctx context.Context) {
+	form.ctx = ctx
+}
+
+// SetAdmin sets the admin that submits the form
+// (used as author of the settings history entry).
+func (form *BackupRotateKey) SetAdmin(admin *models.Admin) {
+	form.admin = admin
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *BackupRotateKey) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(
+			&form.NewKey,
+			validation.Required,
+			validation.Length(32, 32),
+			validation.NotIn(form.app.Settings().Backups.EncryptionKey).
+				ErrorObject(validation.NewError("validation_same_encryption_key", "The new key must be different from the current one.")),
+		),
+	)
+}
+
+// Submit validates the form, re-encrypts the existing encrypted
+// backups with the new key and updates the app settings.
+//
+// If the settings update fails, the backups are re-encrypted back with the current key.
+//
+// You can optionally provide a list of InterceptorFunc to further
+// modify the form behavior before rotating the key.
+func (form *BackupRotateKey) Submit(interceptors ...InterceptorFunc[string]) error {
+	if err := form.Validate(); err != nil {
+		return err
+	}
+
+	settingsForm := NewSettingsUpsert(form.app)
+	settingsForm.SetAdmin(form.admin)
+	settingsForm.Backups.EncryptionKey = form.NewKey
+
+	// eagerly validate the settings change (eg. in case the key is env locked)
+	if err := settingsForm.Validate(); err != nil {
+		return err
+	}
+
+	return runInterceptors(form.NewKey, func(newKey string) error {
+		oldKey := form.app.Settings().Backups.EncryptionKey
+
+		if err := form.app.RotateBackupsEncryptionKey(form.ctx, oldKey, newKey); err != nil {
+			return err
+		}
+
+		settingsForm.Backups.EncryptionKey = newKey
+
+		if err := settingsForm.Submit(); err != nil {
+			// the new key wasn't stored -> revert the rotated backups
+			// so that they remain decryptable with the current key
+			if oldKey != "" {
+				if revertErr := form.app.RotateBackupsEncryptionKey(form.ctx, newKey, oldKey); revertErr != nil {
+					return fmt.Errorf("%w (failed to revert the rotated backups: %v)", err, revertErr)
+				}
+			}
+
+			return err
+		}
+
+		return nil
+	}, interceptors...)
+}
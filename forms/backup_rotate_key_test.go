@@ -0,0 +1,202 @@
+package forms_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/archive"
+)
+
+func TestBackupRotateKeyValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		currentKey     string
+		newKey         string
+		expectedErrors []string
+	}{
+		{"empty", "", "", []string{"newKey"}},
+		{"invalid length", "", "abc", []string{"newKey"}},
+		{"same as the current key", strings.Repeat("a", 32), strings.Repeat("a", 32), []string{"newKey"}},
+		{"valid", strings.Repeat("a", 32), strings.Repeat("b", 32), []string{}},
+	}
+
+	for _, s := range scenarios {
+		func() {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			app.Settings().Backups.EncryptionKey = s.currentKey
+
+			form := forms.NewBackupRotateKey(app)
+			form.NewKey = s.newKey
+
+			result := form.Validate()
+
+			// parse errors
+			errs, ok := result.(validation.Errors)
+			if !ok && result != nil {
+				t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+				return
+			}
+
+			// check errors
+			if len(errs) > len(s.expectedErrors) {
+				t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+			}
+			for _, k := range s.expectedErrors {
+				if _, ok := errs[k]; !ok {
+					t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+				}
+			}
+		}()
+	}
+}
+
+func TestBackupRotateKeySubmit(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	os.Unsetenv(app.EncryptionEnv())
+
+	oldKey := strings.Repeat("a", 32)
+	newKey := strings.Repeat("b", 32)
+
+	app.Settings().Backups.EncryptionKey = oldKey
+
+	if err := app.CreateBackup(context.Background(), "test.zip"); err != nil {
+		t.Fatal(err)
+	}
+
+	admin, err := app.Dao().FindAdminByEmail("test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	form := forms.NewBackupRotateKey(app)
+	form.SetAdmin(admin)
+	form.NewKey = newKey
+
+	interceptorCalls := 0
+	interceptor := func(next forms.InterceptorNextFunc[string]) forms.InterceptorNextFunc[string] {
+		return func(k string) error {
+			interceptorCalls++
+			return next(k)
+		}
+	}
+
+	if err := form.Submit(interceptor); err != nil {
+		t.Fatalf("Failed to submit the form: %v", err)
+	}
+
+	if interceptorCalls != 1 {
+		t.Fatalf("Expected interceptor to be called once, got %d", interceptorCalls)
+	}
+
+	if key := app.Settings().Backups.EncryptionKey; key != newKey {
+		t.Fatalf("Expected the app settings key to be updated, got %q", key)
+	}
+
+	// check the persisted settings
+	storedSettings, err := app.Dao().FindSettings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if storedSettings.Backups.EncryptionKey != newKey {
+		t.Fatalf("Expected the stored settings key to be updated, got %q", storedSettings.Backups.EncryptionKey)
+	}
+
+	// check the rotated backup
+	path := filepath.Join(app.DataDir(), core.LocalBackupsDirName, "test.zip")
+	decryptedPath := path + "_decrypted"
+	defer os.Remove(decryptedPath)
+	if err := archive.Decrypt(path, decryptedPath, newKey); err != nil {
+		t.Fatalf("Expected the backup to be encrypted with the new key: %v", err)
+	}
+
+	// check the settings history entry
+	history, err := app.Dao().FindSettingsHistoryByVersion(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if history.AdminId != admin.Id {
+		t.Fatalf("Expected history admin %q, got %q", admin.Id, history.AdminId)
+	}
+	if _, ok := history.Diff["backups.encryptionKey"]; !ok {
+		t.Fatalf("Expected backups.encryptionKey history change, got %v", history.Diff)
+	}
+}
+
+func TestBackupRotateKeySubmitFailure(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	os.Unsetenv(app.EncryptionEnv())
+
+	oldKey := strings.Repeat("a", 32)
+
+	app.Settings().Backups.EncryptionKey = oldKey
+
+	if err := app.CreateBackup(context.Background(), "test.zip"); err != nil {
+		t.Fatal(err)
+	}
+
+	// simulate a misconfigured current key
+	app.Settings().Backups.EncryptionKey = strings.Repeat("c", 32)
+
+	form := forms.NewBackupRotateKey(app)
+	form.NewKey = strings.Repeat("b", 32)
+
+	if err := form.Submit(); err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+
+	if key := app.Settings().Backups.EncryptionKey; key != strings.Repeat("c", 32) {
+		t.Fatalf("Expected the app settings key to remain unchanged, got %q", key)
+	}
+}
+
+func TestBackupRotateKeySubmitSettingsSaveFailure(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	os.Unsetenv(app.EncryptionEnv())
+
+	oldKey := strings.Repeat("a", 32)
+
+	app.Settings().Backups.EncryptionKey = oldKey
+
+	if err := app.CreateBackup(context.Background(), "test.zip"); err != nil {
+		t.Fatal(err)
+	}
+
+	app.OnModelBeforeUpdate().Add(func(e *core.ModelEvent) error {
+		return errors.New("test_save_failure")
+	})
+
+	form := forms.NewBackupRotateKey(app)
+	form.NewKey = strings.Repeat("b", 32)
+
+	if err := form.Submit(); err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+
+	if key := app.Settings().Backups.EncryptionKey; key != oldKey {
+		t.Fatalf("Expected the app settings key to remain unchanged, got %q", key)
+	}
+
+	// the backup should be still decryptable with the stored key
+	path := filepath.Join(app.DataDir(), core.LocalBackupsDirName, "test.zip")
+	decryptedPath := path + "_decrypted"
+	defer os.Remove(decryptedPath)
+	if err := archive.Decrypt(path, decryptedPath, oldKey); err != nil {
+		t.Fatalf("Expected the backup to be encrypted with the old key: %v", err)
+	}
+}
@@ -0,0 +1,114 @@
+package forms_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/filesystem"
+)
+
+func createTestZip(t *testing.T) []byte {
+	buf := new(bytes.Buffer)
+
+	zw := zip.NewWriter(buf)
+	w, err := zw.Create("data.db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte("test")); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestBackupUploadValidateAndSubmit(t *testing.T) {
+	zipContent := createTestZip(t)
+
+	scenarios := []struct {
+		name           string
+		content        []byte
+		fileName       string
+		expectedErrors []string
+	}{
+		{"missing file", nil, "", []string{"file"}},
+		{"non zip file", []byte("test"), "test.zip", []string{"file"}},
+		{"invalid name", zipContent, "Test Backup.zip", []string{"file"}},
+		{"existing name", zipContent, "existing.zip", []string{"file"}},
+		{"valid zip", zipContent, "test.zip", []string{}},
+		{"valid encrypted archive", []byte("PBENC\x00\x01\ntest"), "test.zip", []string{}},
+	}
+
+	for _, s := range scenarios {
+		func() {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			fsys, err := app.NewBackupsFilesystem()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer fsys.Close()
+
+			if err := fsys.Upload(zipContent, "existing.zip"); err != nil {
+				t.Fatal(err)
+			}
+
+			form := forms.NewBackupUpload(app)
+
+			if len(s.content) > 0 {
+				form.File, err = filesystem.NewFileFromBytes(s.content, s.fileName)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			interceptorCalls := 0
+			interceptor := func(next forms.InterceptorNextFunc[*filesystem.File]) forms.InterceptorNextFunc[*filesystem.File] {
+				return func(f *filesystem.File) error {
+					interceptorCalls++
+					return next(f)
+				}
+			}
+
+			result := form.Submit(interceptor)
+
+			// parse errors
+			errs, ok := result.(validation.Errors)
+			if !ok && result != nil {
+				t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+				return
+			}
+
+			// check errors
+			if len(errs) > len(s.expectedErrors) {
+				t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+			}
+			for _, k := range s.expectedErrors {
+				if _, ok := errs[k]; !ok {
+					t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+				}
+			}
+
+			expectedInterceptorCalls := 1
+			if len(s.expectedErrors) > 0 {
+				expectedInterceptorCalls = 0
+			}
+			if interceptorCalls != expectedInterceptorCalls {
+				t.Errorf("[%s] Expected %d interceptor calls, got %d", s.name, expectedInterceptorCalls, interceptorCalls)
+			}
+
+			exists, _ := fsys.Exists("test.zip")
+			if exists != (len(s.expectedErrors) == 0) {
+				t.Errorf("[%s] Expected the uploaded backup existence to be %v", s.name, !exists)
+			}
+		}()
+	}
+}
@@ -140,6 +140,7 @@ func (form *CollectionUpsert) Validate() error {
 			validation.By(form.ensureNoFieldsTypeChange),
 			validation.By(form.checkRelationFields),
 			validation.When(isAuth, validation.By(form.ensureNoAuthFieldName)),
+			validation.By(form.ensureNoSoftDeleteFieldName),
 		),
 		validation.Field(&form.ListRule, validation.By(form.checkRule)),
 		validation.Field(&form.ViewRule, validation.By(form.checkRule)),
@@ -330,6 +331,32 @@ func (form *CollectionUpsert) ensureNoAuthFieldName(value any) error {
 	return nil
 }
 
+func (form *CollectionUpsert) ensureNoSoftDeleteFieldName(value any) error {
+	v, _ := value.(schema.Schema)
+
+	if softDelete, _ := form.Options["softDelete"].(bool); !softDelete {
+		return nil // soft delete is disabled
+	}
+
+	errs := validation.Errors{}
+	for i, field := range v.Fields() {
+		if field.Name == schema.FieldNameDeletedAt {
+			errs[fmt.Sprint(i)] = validation.Errors{
+				"name": validation.NewError(
+					"validation_reserved_soft_delete_field_name",
+					"The field name is reserved for the soft delete and cannot be used.",
+				),
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
 func (form *CollectionUpsert) checkMinSchemaFields(value any) error {
 	v, _ := value.(schema.Schema)
 
@@ -464,6 +491,47 @@ func (form *CollectionUpsert) checkOptions(value any) error {
 		}
 	}
 
+	if form.Type != models.CollectionTypeView {
+		if err := form.checkSearchableFields(v["searchableFields"]); err != nil {
+			return validation.Errors{"searchableFields": err}
+		}
+	}
+
+	return nil
+}
+
+// searchableFieldTypes are the field types that could be full text indexed.
+var searchableFieldTypes = []string{
+	schema.FieldTypeText,
+	schema.FieldTypeEditor,
+	schema.FieldTypeEmail,
+	schema.FieldTypeUrl,
+	schema.FieldTypeSelect,
+}
+
+func (form *CollectionUpsert) checkSearchableFields(value any) error {
+	names := list.ToUniqueStringSlice(value)
+	if len(names) == 0 {
+		return nil // full text search is disabled
+	}
+
+	if !form.dao.HasFTS5() {
+		return validation.NewError(
+			"validation_fts5_unavailable",
+			"The full text search is not supported by the current SQLite build (FTS5 is required).",
+		)
+	}
+
+	for _, name := range names {
+		field := form.Schema.GetFieldByName(name)
+		if field == nil || !list.ExistInSlice(field.Type, searchableFieldTypes) {
+			return validation.NewError(
+				"validation_invalid_searchable_field",
+				fmt.Sprintf("%q is not a text field of the collection.", name),
+			)
+		}
+	}
+
 	return nil
 }
 
@@ -213,6 +213,20 @@ func TestCollectionUpsertValidateAndSubmit(t *testing.T) {
 			}`,
 			[]string{"options"},
 		},
+		{
+			"create failure - invalid searchable fields",
+			"",
+			`{
+				"name": "test_new",
+				"type": "base",
+				"schema": [
+					{"name":"test1","type":"text"},
+					{"name":"test2","type":"number"}
+				],
+				"options": { "searchableFields": ["test2", "missing"] }
+			}`,
+			[]string{"options"},
+		},
 		{
 			"create success",
 			"",
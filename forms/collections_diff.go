@@ -0,0 +1,228 @@
+package forms
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+)
+
+// CollectionFieldDiff defines the difference of a single collection schema field.
+type CollectionFieldDiff struct {
+	Action string `json:"action"`
+	Id     string `json:"id"`
+	Name   string `json:"name"`
+
+	// Properties lists the changed field properties of the updated field.
+	Properties []string `json:"properties"`
+
+	// Old and New are the current and the submitted field state
+	// (Old is nil for the created fields and New for the deleted ones).
+	Old *schema.SchemaField `json:"old"`
+	New *schema.SchemaField `json:"new"`
+}
+
+// CollectionDiff defines the difference of a single collection.
+type CollectionDiff struct {
+	Action string `json:"action"`
+	Id     string `json:"id"`
+	Name   string `json:"name"`
+
+	// Properties lists the changed collection properties (name, rules, options, etc.).
+	Properties []string `json:"properties"`
+
+	Fields []*CollectionFieldDiff `json:"fields"`
+}
+
+// CollectionsDiff is a form model to resolve the field level difference
+// between the current collections schema and a user provided list.
+type CollectionsDiff struct {
+	app core.App
+	dao *daos.Dao
+
+	Collections   []*models.Collection `form:"collections" json:"collections"`
+	DeleteMissing bool                 `form:"deleteMissing" json:"deleteMissing"`
+}
+
+// NewCollectionsDiff creates a new [CollectionsDiff] form
+// initialized with from the provided [core.App] instance.
+//
+// If you want to resolve the diff as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewCollectionsDiff(app core.App) *CollectionsDiff {
+	return &CollectionsDiff{
+		app: app,
+		dao: app.Dao(),
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *CollectionsDiff) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *CollectionsDiff) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(&form.Collections, validation.Required),
+	)
+}
+
+// Diff validates the form and returns the changed collections.
+//
+// Similar to [CollectionsImport], the collections and their schema fields
+// are matched by their id and the missing existing collections and fields
+// are reported as deleted only if [form.DeleteMissing] is set.
+//
+// Note that the submitted collections are only compared and not validated
+// (use the import dry run if you want to check the import integrity).
+func (form *CollectionsDiff) Diff() ([]*CollectionDiff, error) {
+	if err := form.Validate(); err != nil {
+		return nil, err
+	}
+
+	return diffCollections(form.dao, form.Collections, form.DeleteMissing)
+}
+
+// diffCollections resolves the field level difference between the
+// existing collections and the provided ones.
+//
+// The collections and their schema fields are matched by their id and
+// the missing existing collections and fields are reported as deleted
+// only if deleteMissing is set.
+//
+// It is used both by [CollectionsDiff.Diff] and [CollectionsImport.Plan].
+func diffCollections(dao *daos.Dao, collections []*models.Collection, deleteMissing bool) ([]*CollectionDiff, error) {
+	existingCollections := []*models.Collection{}
+	if err := dao.CollectionQuery().OrderBy("created ASC").All(&existingCollections); err != nil {
+		return nil, err
+	}
+
+	mappedExisting := make(map[string]*models.Collection, len(existingCollections))
+	for _, existing := range existingCollections {
+		mappedExisting[existing.Id] = existing
+	}
+
+	result := []*CollectionDiff{}
+
+	submitted := make(map[string]struct{}, len(collections))
+
+	for _, collection := range collections {
+		if collection == nil {
+			continue
+		}
+
+		existing := mappedExisting[collection.Id]
+		if collection.Id == "" || existing == nil {
+			diff := newCollectionDiff(DeclarativeActionCreate, collection)
+			for _, field := range collection.Schema.Fields() {
+				diff.Fields = append(diff.Fields, newCollectionFieldDiff(DeclarativeActionCreate, nil, field))
+			}
+			result = append(result, diff)
+			continue
+		}
+
+		submitted[existing.Id] = struct{}{}
+
+		diff := newCollectionDiff(DeclarativeActionUpdate, collection)
+		diff.Properties = diffCollectionProps(existing, collection)
+
+		for _, field := range collection.Schema.Fields() {
+			old := existing.Schema.GetFieldById(field.Id)
+			if old == nil || field.Id == "" {
+				diff.Fields = append(diff.Fields, newCollectionFieldDiff(DeclarativeActionCreate, nil, field))
+				continue
+			}
+
+			if props := diffSchemaFieldProps(old, field); len(props) > 0 {
+				fieldDiff := newCollectionFieldDiff(DeclarativeActionUpdate, old, field)
+				fieldDiff.Properties = props
+				diff.Fields = append(diff.Fields, fieldDiff)
+			}
+		}
+
+		if deleteMissing {
+			for _, old := range existing.Schema.Fields() {
+				if collection.Schema.GetFieldById(old.Id) == nil {
+					diff.Fields = append(diff.Fields, newCollectionFieldDiff(DeclarativeActionDelete, old, nil))
+				}
+			}
+		}
+
+		if len(diff.Properties) > 0 || len(diff.Fields) > 0 {
+			result = append(result, diff)
+		}
+	}
+
+	if deleteMissing {
+		for _, existing := range existingCollections {
+			if _, ok := submitted[existing.Id]; ok {
+				continue
+			}
+
+			diff := newCollectionDiff(DeclarativeActionDelete, existing)
+			for _, field := range existing.Schema.Fields() {
+				diff.Fields = append(diff.Fields, newCollectionFieldDiff(DeclarativeActionDelete, field, nil))
+			}
+			result = append(result, diff)
+		}
+	}
+
+	return result, nil
+}
+
+func newCollectionDiff(action string, collection *models.Collection) *CollectionDiff {
+	return &CollectionDiff{
+		Action:     action,
+		Id:         collection.Id,
+		Name:       collection.Name,
+		Properties: []string{},
+		Fields:     []*CollectionFieldDiff{},
+	}
+}
+
+func newCollectionFieldDiff(action string, existing, desired *schema.SchemaField) *CollectionFieldDiff {
+	diff := &CollectionFieldDiff{
+		Action:     action,
+		Properties: []string{},
+		Old:        existing,
+		New:        desired,
+	}
+
+	if desired != nil {
+		diff.Id = desired.Id
+		diff.Name = desired.Name
+	} else if existing != nil {
+		diff.Id = existing.Id
+		diff.Name = existing.Name
+	}
+
+	return diff
+}
+
+// diffSchemaFieldProps returns the names of the changed schema field properties.
+func diffSchemaFieldProps(existing, desired *schema.SchemaField) []string {
+	fields := []string{}
+
+	props := []struct {
+		name     string
+		existing any
+		desired  any
+	}{
+		{"system", existing.System, desired.System},
+		{"name", existing.Name, desired.Name},
+		{"type", existing.Type, desired.Type},
+		{"required", existing.Required, desired.Required},
+		{"unique", existing.Unique, desired.Unique},
+		{"options", existing.Options, desired.Options},
+	}
+
+	for _, p := range props {
+		if !jsonEqual(p.existing, p.desired) {
+			fields = append(fields, p.name)
+		}
+	}
+
+	return fields
+}
@@ -0,0 +1,107 @@
+package forms_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestCollectionsDiffValidate(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	form := forms.NewCollectionsDiff(app)
+
+	if _, err := form.Diff(); err == nil {
+		t.Fatal("Expected validation error for the missing collections, got nil")
+	}
+}
+
+func TestCollectionsDiff(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	demo1, err := app.Dao().FindCollectionByNameOrId("demo1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// drop the first field, change the second one and add a new one
+	fields := demo1.Schema.Fields()
+	removed := fields[0]
+	changed := fields[1]
+	changedCopy := *changed
+	changedCopy.Required = !changedCopy.Required
+	submitted := &models.Collection{}
+	*submitted = *demo1
+	submitted.ListRule = types.Pointer("id != ''")
+	submitted.Schema = schema.NewSchema(append(
+		[]*schema.SchemaField{&changedCopy},
+		append(fields[2:], &schema.SchemaField{Name: "new_field", Type: schema.FieldTypeText})...,
+	)...)
+
+	scenarios := []struct {
+		name          string
+		deleteMissing bool
+		expected      []string
+		notExpected   []string
+	}{
+		{
+			"without deleteMissing",
+			false,
+			[]string{
+				`{"action":"create","id":"","name":"new_collection","properties":[],"fields":[{"action":"create","id":"`,
+				`{"action":"update","id":"wsmn24bux7wo113","name":"demo1","properties":["listRule"],"fields":[`,
+				`{"action":"update","id":"` + changed.Id + `","name":"` + changed.Name + `","properties":["required"],"old":{`,
+				`"name":"new_field","properties":[],"old":null,"new":{`,
+			},
+			[]string{
+				`"action":"delete"`,
+			},
+		},
+		{
+			"with deleteMissing",
+			true,
+			[]string{
+				`{"action":"delete","id":"` + removed.Id + `","name":"` + removed.Name + `","properties":[],"old":{`,
+				`{"action":"delete","id":"_pb_users_auth_","name":"users","properties":[],"fields":[`,
+			},
+			nil,
+		},
+	}
+
+	for _, s := range scenarios {
+		form := forms.NewCollectionsDiff(app)
+		form.DeleteMissing = s.deleteMissing
+		form.Collections = []*models.Collection{
+			submitted,
+			{
+				Name:   "new_collection",
+				Schema: schema.NewSchema(&schema.SchemaField{Name: "title", Type: schema.FieldTypeText}),
+			},
+		}
+
+		result, err := form.Diff()
+		if err != nil {
+			t.Fatalf("[%s] Failed to resolve the diff: %v", s.name, err)
+		}
+
+		raw, _ := json.Marshal(result)
+		for _, str := range s.expected {
+			if !strings.Contains(string(raw), str) {
+				t.Fatalf("[%s] Cannot find %s in\n%s", s.name, str, raw)
+			}
+		}
+		for _, str := range s.notExpected {
+			if strings.Contains(string(raw), str) {
+				t.Fatalf("[%s] Didn't expect %s in\n%s", s.name, str, raw)
+			}
+		}
+	}
+}
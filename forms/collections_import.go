@@ -2,10 +2,12 @@ package forms
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/dbx"
 	"github.com/pocketbase/pocketbase/core"
 	"github.com/pocketbase/pocketbase/daos"
 	"github.com/pocketbase/pocketbase/models"
@@ -63,32 +65,163 @@ func (form *CollectionsImport) Submit(interceptors ...InterceptorFunc[[]*models.
 
 	return runInterceptors(form.Collections, func(collections []*models.Collection) error {
 		return form.dao.RunInTransaction(func(txDao *daos.Dao) error {
-			importErr := txDao.ImportCollections(
-				collections,
-				form.DeleteMissing,
-				form.afterSync,
-			)
-			if importErr == nil {
-				return nil
-			}
-
-			// validation failure
-			if err, ok := importErr.(validation.Errors); ok {
-				return err
-			}
-
-			// generic/db failure
-			if form.app.IsDebug() {
-				log.Println("Internal import failure:", importErr)
-			}
-			return validation.Errors{"collections": validation.NewError(
-				"collections_import_failure",
-				"Failed to import the collections configuration.",
-			)}
+			return form.importCollections(txDao, collections)
 		})
 	}, interceptors...)
 }
 
+// errDryRunRollback is used to rollback the dry run import transaction.
+var errDryRunRollback = errors.New("collections import dry run rollback")
+
+// DryRun validates the form and runs the full collections import
+// (including the records table sync and the integrity validations)
+// in a transaction that is always rollbacked.
+//
+// On success it returns the import plan with the changes that
+// would have been applied by [CollectionsImport.Submit].
+//
+// Note that the dry run cannot be nested in another transaction
+// since it is not possible to rollback only the import changes.
+func (form *CollectionsImport) DryRun() (*CollectionsImportPlan, error) {
+	if _, ok := form.dao.NonconcurrentDB().(*dbx.Tx); ok {
+		return nil, errors.New("collections import dry run cannot be executed in a transaction")
+	}
+
+	if err := form.Validate(); err != nil {
+		return nil, err
+	}
+
+	// resolve the plan before the import because
+	// the imported collections are modified during the sync
+	plan, err := form.Plan()
+	if err != nil {
+		return nil, err
+	}
+
+	txErr := form.dao.RunInTransaction(func(txDao *daos.Dao) error {
+		if err := form.importCollections(txDao, form.Collections); err != nil {
+			return err
+		}
+
+		return errDryRunRollback
+	})
+
+	if txErr != nil && !errors.Is(txErr, errDryRunRollback) {
+		return nil, txErr
+	}
+
+	return plan, nil
+}
+
+func (form *CollectionsImport) importCollections(txDao *daos.Dao, collections []*models.Collection) error {
+	importErr := txDao.ImportCollections(
+		collections,
+		form.DeleteMissing,
+		form.afterSync,
+	)
+	if importErr == nil {
+		return nil
+	}
+
+	// validation failure
+	if err, ok := importErr.(validation.Errors); ok {
+		return err
+	}
+
+	// generic/db failure
+	if form.app.IsDebug() {
+		log.Println("Internal import failure:", importErr)
+	}
+	return validation.Errors{"collections": validation.NewError(
+		"collections_import_failure",
+		"Failed to import the collections configuration.",
+	)}
+}
+
+// CollectionsImportChange defines the changes of a single imported collection.
+type CollectionsImportChange struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+
+	// Properties lists the changed collection properties (name, rules, options, etc.).
+	Properties []string `json:"properties"`
+
+	// CreatedFields, UpdatedFields and DeletedFields list the names of the changed schema fields.
+	CreatedFields []string `json:"createdFields"`
+	UpdatedFields []string `json:"updatedFields"`
+	DeletedFields []string `json:"deletedFields"`
+}
+
+// CollectionsImportPlan defines the changes that a collections import would apply.
+type CollectionsImportPlan struct {
+	Created    []*CollectionsImportChange `json:"created"`
+	Updated    []*CollectionsImportChange `json:"updated"`
+	Deleted    []*CollectionsImportChange `json:"deleted"`
+	HasChanges bool                       `json:"hasChanges"`
+}
+
+// Plan resolves and returns the difference between the current
+// collections and the form ones (without validating or importing them).
+//
+// Similar to the import, the collections are matched by their id
+// and the existing schema fields that are missing in the imported
+// collection are deleted only if [form.DeleteMissing] is set.
+func (form *CollectionsImport) Plan() (*CollectionsImportPlan, error) {
+	diffs, err := diffCollections(form.dao, form.Collections, form.DeleteMissing)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &CollectionsImportPlan{
+		Created: []*CollectionsImportChange{},
+		Updated: []*CollectionsImportChange{},
+		Deleted: []*CollectionsImportChange{},
+	}
+
+	for _, diff := range diffs {
+		change := newCollectionsImportChange(diff)
+
+		switch diff.Action {
+		case DeclarativeActionCreate:
+			plan.Created = append(plan.Created, change)
+		case DeclarativeActionUpdate:
+			plan.Updated = append(plan.Updated, change)
+		case DeclarativeActionDelete:
+			plan.Deleted = append(plan.Deleted, change)
+		}
+	}
+
+	plan.HasChanges = len(plan.Created) > 0 || len(plan.Updated) > 0 || len(plan.Deleted) > 0
+
+	return plan, nil
+}
+
+// newCollectionsImportChange flattens the provided collection diff
+// into a changed properties and field names list.
+func newCollectionsImportChange(diff *CollectionDiff) *CollectionsImportChange {
+	change := &CollectionsImportChange{
+		Id:            diff.Id,
+		Name:          diff.Name,
+		Properties:    diff.Properties,
+		CreatedFields: []string{},
+		UpdatedFields: []string{},
+		DeletedFields: []string{},
+	}
+
+	for _, field := range diff.Fields {
+		switch field.Action {
+		case DeclarativeActionCreate:
+			change.CreatedFields = append(change.CreatedFields, field.Name)
+		case DeclarativeActionUpdate:
+			change.UpdatedFields = append(change.UpdatedFields, field.Name)
+		case DeclarativeActionDelete:
+			change.DeletedFields = append(change.DeletedFields, field.Name)
+		}
+	}
+
+	return change
+}
+
 func (form *CollectionsImport) afterSync(txDao *daos.Dao, mappedNew, mappedOld map[string]*models.Collection) error {
 	// refresh the actual persisted collections list
 	refreshedCollections := []*models.Collection{}
@@ -3,10 +3,13 @@ package forms_test
 import (
 	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
 
+	"github.com/pocketbase/pocketbase/daos"
 	"github.com/pocketbase/pocketbase/forms"
 	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
 	"github.com/pocketbase/pocketbase/tests"
 )
 
@@ -502,3 +505,143 @@ func TestCollectionsImportSubmitInterceptors(t *testing.T) {
 		t.Fatalf("Expected interceptor2 to be called")
 	}
 }
+
+func TestCollectionsImportPlan(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	demo1, err := app.Dao().FindCollectionByNameOrId("demo1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// drop the first field, change the second one and add a new one
+	fields := demo1.Schema.Fields()
+	removed := fields[0].Name
+	changed := fields[1].Name
+	fields[1].Required = !fields[1].Required
+	imported := &models.Collection{}
+	*imported = *demo1
+	imported.Schema = schema.NewSchema(append(fields[1:], &schema.SchemaField{Name: "new_field", Type: schema.FieldTypeText})...)
+
+	scenarios := []struct {
+		name          string
+		deleteMissing bool
+		expected      []string
+	}{
+		{
+			"without deleteMissing",
+			false,
+			[]string{
+				`"created":[{"id":"","name":"new_collection","properties":[],"createdFields":[],"updatedFields":[],"deletedFields":[]}]`,
+				`"updated":[{"id":"wsmn24bux7wo113","name":"demo1","properties":[],"createdFields":["new_field"],"updatedFields":["` + changed + `"],"deletedFields":[]}]`,
+				`"deleted":[]`,
+				`"hasChanges":true`,
+			},
+		},
+		{
+			"with deleteMissing",
+			true,
+			[]string{
+				`"updated":[{"id":"wsmn24bux7wo113","name":"demo1","properties":[],"createdFields":["new_field"],"updatedFields":["` + changed + `"],"deletedFields":["` + removed + `"]}]`,
+				`{"id":"_pb_users_auth_","name":"users","properties":[]`,
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		form := forms.NewCollectionsImport(app)
+		form.DeleteMissing = s.deleteMissing
+		form.Collections = []*models.Collection{imported, {Name: "new_collection"}}
+
+		plan, err := form.Plan()
+		if err != nil {
+			t.Fatalf("[%s] Failed to plan: %v", s.name, err)
+		}
+
+		raw, _ := json.Marshal(plan)
+		for _, str := range s.expected {
+			if !strings.Contains(string(raw), str) {
+				t.Fatalf("[%s] Cannot find %s in\n%s", s.name, str, raw)
+			}
+		}
+
+		// the plan should report the same changes as the collections diff
+		diffForm := forms.NewCollectionsDiff(app)
+		diffForm.DeleteMissing = s.deleteMissing
+		diffForm.Collections = form.Collections
+
+		diffs, err := diffForm.Diff()
+		if err != nil {
+			t.Fatalf("[%s] Failed to diff: %v", s.name, err)
+		}
+
+		changes := append(append(append([]*forms.CollectionsImportChange{}, plan.Created...), plan.Updated...), plan.Deleted...)
+		if len(diffs) != len(changes) {
+			t.Fatalf("[%s] Expected %d changes, got %d", s.name, len(diffs), len(changes))
+		}
+
+		for _, diff := range diffs {
+			var change *forms.CollectionsImportChange
+			for _, c := range changes {
+				if c.Id == diff.Id && c.Name == diff.Name {
+					change = c
+					break
+				}
+			}
+
+			if change == nil {
+				t.Fatalf("[%s] Missing plan change for collection %q", s.name, diff.Name)
+			}
+
+			total := len(change.CreatedFields) + len(change.UpdatedFields) + len(change.DeletedFields)
+			if total != len(diff.Fields) {
+				t.Fatalf("[%s] Expected %d %q field changes, got %d", s.name, len(diff.Fields), diff.Name, total)
+			}
+		}
+	}
+}
+
+func TestCollectionsImportDryRun(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	form := forms.NewCollectionsImport(app)
+	form.Collections = []*models.Collection{{
+		Name:   "new_collection",
+		Schema: schema.NewSchema(&schema.SchemaField{Name: "title", Type: schema.FieldTypeText}),
+	}}
+
+	plan, err := form.DryRun()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !plan.HasChanges || len(plan.Created) != 1 || plan.Created[0].Name != "new_collection" {
+		t.Fatalf("Unexpected plan %v", plan)
+	}
+
+	if _, err := app.Dao().FindCollectionByNameOrId("new_collection"); err == nil {
+		t.Fatal("Expected the dry run collection to not be persisted")
+	}
+
+	if app.Dao().HasTable("new_collection") {
+		t.Fatal("Expected the dry run table to not be persisted")
+	}
+
+	// invalid import
+	form.Collections = []*models.Collection{{Name: "demo1"}}
+	if _, err := form.DryRun(); err == nil {
+		t.Fatal("Expected validation error, got nil")
+	}
+
+	// nested transaction
+	app.Dao().RunInTransaction(func(txDao *daos.Dao) error {
+		form.SetDao(txDao)
+		form.Collections = []*models.Collection{{Name: "new_collection"}}
+		if _, err := form.DryRun(); err == nil {
+			t.Fatal("Expected transaction error, got nil")
+		}
+		return nil
+	})
+}
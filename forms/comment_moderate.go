@@ -0,0 +1,64 @@
+package forms
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/list"
+)
+
+// CommentModerate is a record thread comment moderation status update form.
+type CommentModerate struct {
+	app     core.App
+	dao     *daos.Dao
+	comment *models.Comment
+
+	Status string `form:"status" json:"status"`
+}
+
+// NewCommentModerate creates a new [CommentModerate] form
+// for the provided comment.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewCommentModerate(app core.App, comment *models.Comment) *CommentModerate {
+	return &CommentModerate{
+		app:     app,
+		dao:     app.Dao(),
+		comment: comment,
+		Status:  comment.Status,
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *CommentModerate) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *CommentModerate) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(
+			&form.Status,
+			validation.Required,
+			validation.In(list.ToInterfaceSlice(models.CommentStatuses())...),
+		),
+	)
+}
+
+// Submit validates the form and updates the comment moderation status.
+//
+// You can optionally provide a list of InterceptorFunc to further
+// modify the form behavior before persisting it.
+func (form *CommentModerate) Submit(interceptors ...InterceptorFunc[*models.Comment]) error {
+	if err := form.Validate(); err != nil {
+		return err
+	}
+
+	form.comment.Status = form.Status
+
+	return runInterceptors(form.comment, func(m *models.Comment) error {
+		return form.dao.SaveComment(m)
+	}, interceptors...)
+}
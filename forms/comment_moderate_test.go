@@ -0,0 +1,66 @@
+package forms_test
+
+import (
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestCommentModerateValidateAndSubmit(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		status         string
+		expectedErrors []string
+	}{
+		{"empty", "", []string{"status"}},
+		{"invalid status", "unknown", []string{"status"}},
+		{"approve", models.CommentStatusApproved, []string{}},
+		{"reject", models.CommentStatusRejected, []string{}},
+	}
+
+	for _, s := range scenarios {
+		func() {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			comment := &models.Comment{CollectionId: "sz5l5z67tg7gku0", RecordId: "achvryl401bhse3", AuthorCollectionId: "_pb_users_auth_", AuthorId: "4q1xlclmfloku33", Message: "test", Status: models.CommentStatusPending}
+			if err := app.Dao().SaveComment(comment); err != nil {
+				t.Fatal(err)
+			}
+
+			form := forms.NewCommentModerate(app, comment)
+			form.Status = s.status
+
+			result := form.Submit()
+
+			// parse errors
+			errs, ok := result.(validation.Errors)
+			if !ok && result != nil {
+				t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+				return
+			}
+
+			// check errors
+			if len(errs) > len(s.expectedErrors) {
+				t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+			}
+			for _, k := range s.expectedErrors {
+				if _, ok := errs[k]; !ok {
+					t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+				}
+			}
+
+			if len(s.expectedErrors) > 0 {
+				return
+			}
+
+			saved, err := app.Dao().FindCommentById(comment.Id)
+			if err != nil || saved.Status != s.status {
+				t.Errorf("[%s] Expected status %q, got %v (%v)", s.name, s.status, saved, err)
+			}
+		}()
+	}
+}
@@ -0,0 +1,160 @@
+package forms
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tools/list"
+)
+
+// maxCommentMentions is the max number of resolved mentions per comment.
+const maxCommentMentions = 20
+
+// commentMentionRegex matches the "@username" message mentions
+// (the "@" must not be preceded by a word character, eg. emails are ignored).
+var commentMentionRegex = regexp.MustCompile(`(?:^|[^\w@])@(\w[\w.\-]*)`)
+
+// CommentUpsert is a record thread comment create/update form.
+type CommentUpsert struct {
+	app     core.App
+	dao     *daos.Dao
+	config  settings.CommentsCollectionConfig
+	comment *models.Comment
+
+	Message  string `form:"message" json:"message"`
+	ParentId string `form:"parentId" json:"parentId"`
+}
+
+// NewCommentUpsert creates a new [CommentUpsert] form with initializer
+// config created from the provided [core.App], thread settings and
+// [models.Comment] instances (for create you could pass a pointer to
+// a Comment model with filled thread and author fields).
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewCommentUpsert(app core.App, config settings.CommentsCollectionConfig, comment *models.Comment) *CommentUpsert {
+	return &CommentUpsert{
+		app:      app,
+		dao:      app.Dao(),
+		config:   config,
+		comment:  comment,
+		Message:  comment.Message,
+		ParentId: comment.ParentId,
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *CommentUpsert) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *CommentUpsert) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(
+			&form.Message,
+			validation.Required,
+			validation.Length(1, form.config.MessageMaxLength()),
+		),
+		validation.Field(&form.ParentId, validation.By(form.checkParent)),
+	)
+}
+
+func (form *CommentUpsert) checkParent(value any) error {
+	v, _ := value.(string)
+
+	if !form.comment.IsNew() {
+		if v != form.comment.ParentId {
+			return validation.NewError("validation_comment_parent_change", "The comment parent cannot be changed.")
+		}
+		return nil
+	}
+
+	if v == "" {
+		return nil // top level comment
+	}
+
+	parent, err := form.dao.FindCommentById(v)
+	if err != nil || parent.CollectionId != form.comment.CollectionId || parent.RecordId != form.comment.RecordId {
+		return validation.NewError("validation_invalid_comment_parent", "The parent comment must be from the same thread.")
+	}
+
+	return nil
+}
+
+// Submit validates the form and upserts the form comment model.
+//
+// New and edited comments of moderated threads are stored with
+// "pending" status, otherwise new comments are approved immediately.
+//
+// You can optionally provide a list of InterceptorFunc to further
+// modify the form behavior before persisting it.
+func (form *CommentUpsert) Submit(interceptors ...InterceptorFunc[*models.Comment]) error {
+	if err := form.Validate(); err != nil {
+		return err
+	}
+
+	if form.comment.AuthorCollectionId == "" || form.comment.AuthorId == "" {
+		return errors.New("missing comment author")
+	}
+
+	form.comment.Message = form.Message
+	form.comment.ParentId = form.ParentId
+	form.comment.Mentions = form.resolveMentions()
+
+	if form.config.Moderated {
+		form.comment.Status = models.CommentStatusPending
+	} else if form.comment.IsNew() {
+		form.comment.Status = models.CommentStatusApproved
+	}
+
+	return runInterceptors(form.comment, func(m *models.Comment) error {
+		return form.dao.SaveComment(m)
+	}, interceptors...)
+}
+
+// resolveMentions returns the ids of the existing auth records from
+// the author collection mentioned in the form message (the author is excluded).
+func (form *CommentUpsert) resolveMentions() []string {
+	result := []string{}
+
+	for _, username := range ParseCommentMentions(form.Message) {
+		if len(result) >= maxCommentMentions {
+			break
+		}
+
+		record, err := form.dao.FindAuthRecordByUsername(form.comment.AuthorCollectionId, username)
+		if err != nil || record.Id == form.comment.AuthorId {
+			continue
+		}
+
+		if !list.ExistInSlice(record.Id, result) {
+			result = append(result, record.Id)
+		}
+	}
+
+	return result
+}
+
+// ParseCommentMentions returns the unique usernames mentioned
+// in the provided comment message (eg. "Hi @john.doe!" -> ["john.doe"]).
+func ParseCommentMentions(message string) []string {
+	result := []string{}
+
+	for _, match := range commentMentionRegex.FindAllStringSubmatch(message, -1) {
+		// trailing punctuation is not part of the username
+		username := strings.TrimRight(match[1], ".-")
+
+		if username != "" && !list.ExistInSlice(username, result) {
+			result = append(result, username)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,160 @@
+package forms_test
+
+import (
+	"strings"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestParseCommentMentions(t *testing.T) {
+	scenarios := []struct {
+		message  string
+		expected string
+	}{
+		{"", ""},
+		{"no mentions", ""},
+		{"test@example.com", ""},
+		{"@@double", ""},
+		{"@john", "john"},
+		{"Hi @john.doe! cc @jane-doe, @john.doe.", "john.doe,jane-doe"},
+		{"(@a_b)\n@c", "a_b,c"},
+	}
+
+	for _, s := range scenarios {
+		result := strings.Join(forms.ParseCommentMentions(s.message), ",")
+		if result != s.expected {
+			t.Errorf("(%q) Expected %q, got %q", s.message, s.expected, result)
+		}
+	}
+}
+
+func TestCommentUpsertValidateAndSubmit(t *testing.T) {
+	scenarios := []struct {
+		name             string
+		config           settings.CommentsCollectionConfig
+		message          string
+		parent           string // "valid", "other" or ""
+		expectedErrors   []string
+		expectedStatus   string
+		expectedMentions string
+	}{
+		{"empty", settings.CommentsCollectionConfig{}, "", "", []string{"message"}, "", ""},
+		{"too long", settings.CommentsCollectionConfig{MaxLength: 5}, "123456", "", []string{"message"}, "", ""},
+		{"parent from another thread", settings.CommentsCollectionConfig{}, "test", "other", []string{"parentId"}, "", ""},
+		{
+			"unmoderated",
+			settings.CommentsCollectionConfig{},
+			"Hi @test2_username and @users75657 (self) and @missing",
+			"",
+			[]string{},
+			models.CommentStatusApproved,
+			"oap640cot4yru2s",
+		},
+		{"moderated reply", settings.CommentsCollectionConfig{Moderated: true}, "reply", "valid", []string{}, models.CommentStatusPending, ""},
+	}
+
+	for _, s := range scenarios {
+		func() {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			parent := &models.Comment{CollectionId: "sz5l5z67tg7gku0", RecordId: "achvryl401bhse3", AuthorCollectionId: "_pb_users_auth_", AuthorId: "oap640cot4yru2s", Message: "parent", Status: models.CommentStatusApproved}
+			if s.parent == "other" {
+				parent.RecordId = "0yxhwia2amd8gec"
+			}
+			if err := app.Dao().SaveComment(parent); err != nil {
+				t.Fatal(err)
+			}
+
+			comment := &models.Comment{CollectionId: "sz5l5z67tg7gku0", RecordId: "achvryl401bhse3", AuthorCollectionId: "_pb_users_auth_", AuthorId: "4q1xlclmfloku33"}
+
+			form := forms.NewCommentUpsert(app, s.config, comment)
+			form.Message = s.message
+			if s.parent != "" {
+				form.ParentId = parent.Id
+			}
+
+			result := form.Submit()
+
+			// parse errors
+			errs, ok := result.(validation.Errors)
+			if !ok && result != nil {
+				t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+				return
+			}
+
+			// check errors
+			if len(errs) > len(s.expectedErrors) {
+				t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+			}
+			for _, k := range s.expectedErrors {
+				if _, ok := errs[k]; !ok {
+					t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+				}
+			}
+
+			if len(s.expectedErrors) > 0 {
+				return
+			}
+
+			saved, err := app.Dao().FindCommentById(comment.Id)
+			if err != nil {
+				t.Fatalf("[%s] Expected the comment to be saved, got %v", s.name, err)
+			}
+
+			if saved.Status != s.expectedStatus {
+				t.Errorf("[%s] Expected status %q, got %q", s.name, s.expectedStatus, saved.Status)
+			}
+
+			if mentions := strings.Join(saved.Mentions, ","); mentions != s.expectedMentions {
+				t.Errorf("[%s] Expected mentions %q, got %q", s.name, s.expectedMentions, mentions)
+			}
+
+			if s.parent != "" && saved.ParentId != parent.Id {
+				t.Errorf("[%s] Expected parent %q, got %q", s.name, parent.Id, saved.ParentId)
+			}
+		}()
+	}
+}
+
+func TestCommentUpsertUpdate(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	comment := &models.Comment{CollectionId: "sz5l5z67tg7gku0", RecordId: "achvryl401bhse3", AuthorCollectionId: "_pb_users_auth_", AuthorId: "4q1xlclmfloku33", Message: "old", Status: models.CommentStatusApproved}
+	if err := app.Dao().SaveComment(comment); err != nil {
+		t.Fatal(err)
+	}
+
+	// parent change
+	form := forms.NewCommentUpsert(app, settings.CommentsCollectionConfig{}, comment)
+	form.ParentId = "missing"
+	if err := form.Submit(); err == nil {
+		t.Fatal("Expected parent change error, got nil")
+	}
+
+	// unmoderated edit keeps the status
+	form = forms.NewCommentUpsert(app, settings.CommentsCollectionConfig{}, comment)
+	form.Message = "new"
+	if err := form.Submit(); err != nil {
+		t.Fatal(err)
+	}
+	if comment.Message != "new" || comment.Status != models.CommentStatusApproved {
+		t.Fatalf("Expected approved comment with the new message, got %v", comment)
+	}
+
+	// moderated edit sends the comment back to moderation
+	form = forms.NewCommentUpsert(app, settings.CommentsCollectionConfig{Moderated: true}, comment)
+	form.Message = "newer"
+	if err := form.Submit(); err != nil {
+		t.Fatal(err)
+	}
+	if comment.Message != "newer" || comment.Status != models.CommentStatusPending {
+		t.Fatalf("Expected pending comment with the newer message, got %v", comment)
+	}
+}
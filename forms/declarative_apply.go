@@ -0,0 +1,596 @@
+package forms
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+// List of the declarative plan change actions.
+const (
+	DeclarativeActionCreate = "create"
+	DeclarativeActionUpdate = "update"
+	DeclarativeActionDelete = "delete"
+)
+
+// DeclarativeAdmin defines the desired state of a single admin.
+//
+// The admins are matched by their email. Password is required only
+// for the new admins and it is never stored as part of the applied state.
+type DeclarativeAdmin struct {
+	Email    string `form:"email" json:"email"`
+	Avatar   int    `form:"avatar" json:"avatar"`
+	Password string `form:"password" json:"password,omitempty"`
+}
+
+// Validate makes DeclarativeAdmin validatable by implementing [validation.Validatable] interface.
+func (a DeclarativeAdmin) Validate() error {
+	return validation.ValidateStruct(&a,
+		validation.Field(&a.Email, validation.Required, validation.Length(1, 255), is.EmailFormat),
+		validation.Field(&a.Avatar, validation.Min(0), validation.Max(9)),
+	)
+}
+
+// DeclarativeChange defines a single planned change.
+type DeclarativeChange struct {
+	Action string `json:"action"`
+
+	// Name is the collection name, the admin email or the settings key.
+	Name string `json:"name"`
+
+	// Fields lists the changed properties of the updated item (if any).
+	Fields []string `json:"fields,omitempty"`
+}
+
+// DeclarativePlan defines the difference between the current
+// and the desired (declarative) app state.
+type DeclarativePlan struct {
+	Collections []*DeclarativeChange `json:"collections"`
+	Settings    []*DeclarativeChange `json:"settings"`
+	Admins      []*DeclarativeChange `json:"admins"`
+	HasChanges  bool                 `json:"hasChanges"`
+}
+
+// DeclarativeApply is a form model to plan and apply a declarative
+// desired state document of the app collections, settings and admins.
+//
+// Only the document sections that are set (aka. non-nil) are managed.
+type DeclarativeApply struct {
+	app core.App
+	dao *daos.Dao
+
+	Collections []*models.Collection `form:"collections" json:"collections"`
+	Admins      []*DeclarativeAdmin  `form:"admins" json:"admins"`
+
+	// Settings is a partial settings object that is merged with the current app settings.
+	Settings json.RawMessage `form:"settings" json:"settings"`
+
+	// DeleteMissing deletes the existing collections, collection fields
+	// and admins that are not found in the document.
+	DeleteMissing bool `form:"deleteMissing" json:"deleteMissing"`
+}
+
+// NewDeclarativeApply creates a new [DeclarativeApply] form
+// initialized with from the provided [core.App] instance.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewDeclarativeApply(app core.App) *DeclarativeApply {
+	return &DeclarativeApply{
+		app: app,
+		dao: app.Dao(),
+	}
+}
+
+// NewDeclarativeApplyFromState creates a new [DeclarativeApply] form
+// loaded with the last successfully applied state document.
+//
+// Returns an error if no state has been applied yet.
+func NewDeclarativeApplyFromState(app core.App) (*DeclarativeApply, error) {
+	param, err := app.Dao().FindParamByKey(models.ParamDeclarativeState)
+	if err != nil {
+		return nil, err
+	}
+
+	form := NewDeclarativeApply(app)
+
+	// try first without decryption
+	if plainDecodeErr := json.Unmarshal(param.Value, form); plainDecodeErr == nil {
+		return form, nil
+	}
+
+	encryptionKey := os.Getenv(app.EncryptionEnv())
+	if encryptionKey == "" {
+		return nil, errors.New("failed to load the applied declarative state - missing or invalid encryption key")
+	}
+
+	decrypted, err := security.Decrypt(string(param.Value), encryptionKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(decrypted, form); err != nil {
+		return nil, err
+	}
+
+	return form, nil
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *DeclarativeApply) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *DeclarativeApply) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(&form.Collections, validation.When(form.Collections != nil, validation.Required)),
+		validation.Field(
+			&form.Admins,
+			validation.When(form.Admins != nil, validation.Required),
+			validation.By(form.checkUniqueAdmins),
+		),
+		validation.Field(&form.Settings, validation.By(form.checkSettings)),
+	)
+}
+
+func (form *DeclarativeApply) checkUniqueAdmins(value any) error {
+	admins, _ := value.([]*DeclarativeAdmin)
+
+	emails := make(map[string]struct{}, len(admins))
+	for _, admin := range admins {
+		if admin == nil {
+			continue
+		}
+		if _, ok := emails[admin.Email]; ok {
+			return validation.NewError("validation_duplicated_admin", fmt.Sprintf("Duplicated admin email %q.", admin.Email))
+		}
+		emails[admin.Email] = struct{}{}
+	}
+
+	return nil
+}
+
+func (form *DeclarativeApply) checkSettings(value any) error {
+	if !form.hasSettings() {
+		return nil
+	}
+
+	if _, err := form.desiredSettings(); err != nil {
+		return validation.NewError("validation_invalid_settings", "Invalid settings object.")
+	}
+
+	return nil
+}
+
+// Plan resolves and returns the difference between the current app state
+// and the form desired state.
+//
+// Note that the form collections without id are resolved by their
+// name (and their schema fields - by field name).
+func (form *DeclarativeApply) Plan() (*DeclarativePlan, error) {
+	plan := &DeclarativePlan{
+		Collections: []*DeclarativeChange{},
+		Settings:    []*DeclarativeChange{},
+		Admins:      []*DeclarativeChange{},
+	}
+
+	if form.Collections != nil {
+		changes, err := form.planCollections()
+		if err != nil {
+			return nil, err
+		}
+		plan.Collections = changes
+	}
+
+	if form.Admins != nil {
+		changes, err := form.planAdmins()
+		if err != nil {
+			return nil, err
+		}
+		plan.Admins = changes
+	}
+
+	if form.hasSettings() {
+		changes, err := form.planSettings()
+		if err != nil {
+			return nil, err
+		}
+		plan.Settings = changes
+	}
+
+	plan.HasChanges = len(plan.Collections) > 0 || len(plan.Admins) > 0 || len(plan.Settings) > 0
+
+	return plan, nil
+}
+
+// Submit validates the form, applies the planned changes and
+// stores the form document as the last applied state.
+//
+// All db operations are wrapped in a single transaction that are
+// rollbacked on the first encountered error.
+//
+// You can optionally provide a list of InterceptorFunc to further
+// modify the form behavior before persisting it.
+func (form *DeclarativeApply) Submit(interceptors ...InterceptorFunc[*DeclarativePlan]) error {
+	if err := form.Validate(); err != nil {
+		return err
+	}
+
+	// snapshot the document before the collections resolving
+	state, err := form.state()
+	if err != nil {
+		return err
+	}
+
+	plan, err := form.Plan()
+	if err != nil {
+		return err
+	}
+
+	return runInterceptors(plan, func(plan *DeclarativePlan) error {
+		return form.dao.RunInTransaction(func(txDao *daos.Dao) error {
+			if len(plan.Collections) > 0 {
+				importForm := NewCollectionsImport(form.app)
+				importForm.SetDao(txDao)
+				importForm.Collections = form.Collections
+				importForm.DeleteMissing = form.DeleteMissing
+				if err := importForm.Submit(); err != nil {
+					return err
+				}
+			}
+
+			if err := form.applyAdmins(txDao, plan.Admins); err != nil {
+				return err
+			}
+
+			// settings are applied last since they are also eagerly
+			// merged with the app settings on successful save
+			if len(plan.Settings) > 0 {
+				settingsForm := NewSettingsUpsert(form.app)
+				settingsForm.SetDao(txDao)
+				if err := json.Unmarshal(form.Settings, settingsForm.Settings); err != nil {
+					return err
+				}
+				if err := settingsForm.Submit(); err != nil {
+					return validation.Errors{"settings": err}
+				}
+			}
+
+			encryptionKey := os.Getenv(form.app.EncryptionEnv())
+
+			return txDao.SaveParam(models.ParamDeclarativeState, state, encryptionKey)
+		})
+	}, interceptors...)
+}
+
+func (form *DeclarativeApply) applyAdmins(txDao *daos.Dao, changes []*DeclarativeChange) error {
+	desired := make(map[string]*DeclarativeAdmin, len(form.Admins))
+	for _, admin := range form.Admins {
+		desired[admin.Email] = admin
+	}
+
+	for _, change := range changes {
+		switch change.Action {
+		case DeclarativeActionDelete:
+			admin, err := txDao.FindAdminByEmail(change.Name)
+			if err != nil {
+				return err
+			}
+			if err := txDao.DeleteAdmin(admin); err != nil {
+				return validation.Errors{"admins": validation.NewError(
+					"admins_delete_failure",
+					fmt.Sprintf("Failed to delete admin %q: %v", change.Name, err),
+				)}
+			}
+		default:
+			admin, _ := txDao.FindAdminByEmail(change.Name)
+			if admin == nil {
+				admin = &models.Admin{}
+			}
+
+			upsertForm := NewAdminUpsert(form.app, admin)
+			upsertForm.SetDao(txDao)
+			upsertForm.Email = desired[change.Name].Email
+			upsertForm.Avatar = desired[change.Name].Avatar
+			upsertForm.Password = desired[change.Name].Password
+			upsertForm.PasswordConfirm = desired[change.Name].Password
+			if err := upsertForm.Submit(); err != nil {
+				return validation.Errors{"admins": validation.NewError(
+					"admins_upsert_failure",
+					fmt.Sprintf("Failed to %s admin %q: %v", change.Action, change.Name, err),
+				)}
+			}
+		}
+	}
+
+	return nil
+}
+
+// state returns the serialized form document without the admin passwords.
+func (form *DeclarativeApply) state() (json.RawMessage, error) {
+	clone := *form
+
+	if form.Admins != nil {
+		clone.Admins = make([]*DeclarativeAdmin, len(form.Admins))
+		for i, admin := range form.Admins {
+			clone.Admins[i] = &DeclarativeAdmin{Email: admin.Email, Avatar: admin.Avatar}
+		}
+	}
+
+	return json.Marshal(clone)
+}
+
+func (form *DeclarativeApply) planCollections() ([]*DeclarativeChange, error) {
+	existingCollections := []*models.Collection{}
+	if err := form.dao.CollectionQuery().OrderBy("created ASC").All(&existingCollections); err != nil {
+		return nil, err
+	}
+
+	changes := []*DeclarativeChange{}
+	resolved := map[string]struct{}{}
+
+	for _, desired := range form.Collections {
+		existing := form.resolveCollection(desired, existingCollections)
+		if existing == nil {
+			changes = append(changes, &DeclarativeChange{Action: DeclarativeActionCreate, Name: desired.Name})
+			continue
+		}
+
+		resolved[existing.Id] = struct{}{}
+
+		if fields := diffCollection(existing, desired, form.DeleteMissing); len(fields) > 0 {
+			changes = append(changes, &DeclarativeChange{Action: DeclarativeActionUpdate, Name: desired.Name, Fields: fields})
+		}
+	}
+
+	if form.DeleteMissing {
+		for _, existing := range existingCollections {
+			if _, ok := resolved[existing.Id]; !ok {
+				changes = append(changes, &DeclarativeChange{Action: DeclarativeActionDelete, Name: existing.Name})
+			}
+		}
+	}
+
+	return changes, nil
+}
+
+// resolveCollection returns the existing collection matching the
+// desired one by id or name and loads the missing desired ids.
+func (form *DeclarativeApply) resolveCollection(desired *models.Collection, existingCollections []*models.Collection) *models.Collection {
+	var existing *models.Collection
+	for _, c := range existingCollections {
+		if (desired.Id != "" && c.Id == desired.Id) || (desired.Id == "" && c.Name == desired.Name) {
+			existing = c
+			break
+		}
+	}
+
+	if existing == nil {
+		return nil
+	}
+
+	desired.Id = existing.Id
+
+	for _, field := range desired.Schema.Fields() {
+		if field.Id != "" {
+			continue
+		}
+		if f := existing.Schema.GetFieldByName(field.Name); f != nil {
+			field.Id = f.Id
+		}
+	}
+
+	return existing
+}
+
+// diffCollection returns the names of the changed collection properties.
+func diffCollection(existing, desired *models.Collection, deleteMissing bool) []string {
+	fields := diffCollectionProps(existing, desired)
+
+	for _, field := range desired.Schema.Fields() {
+		old := existing.Schema.GetFieldById(field.Id)
+		if old == nil || !jsonEqual(old, field) {
+			fields = append(fields, "schema."+field.Name)
+		}
+	}
+
+	if deleteMissing {
+		for _, old := range existing.Schema.Fields() {
+			if desired.Schema.GetFieldById(old.Id) == nil {
+				fields = append(fields, "schema."+old.Name)
+			}
+		}
+	}
+
+	return fields
+}
+
+// diffCollectionProps returns the names of the changed
+// collection properties (excluding the schema fields).
+func diffCollectionProps(existing, desired *models.Collection) []string {
+	fields := []string{}
+
+	desiredType := desired.Type
+	if desiredType == "" {
+		desiredType = models.CollectionTypeBase
+	}
+
+	props := []struct {
+		name     string
+		existing any
+		desired  any
+	}{
+		{"name", existing.Name, desired.Name},
+		{"type", existing.Type, desiredType},
+		{"system", existing.System, desired.System},
+		{"indexes", normalizeSlice(existing.Indexes), normalizeSlice(desired.Indexes)},
+		{"listRule", existing.ListRule, desired.ListRule},
+		{"viewRule", existing.ViewRule, desired.ViewRule},
+		{"createRule", existing.CreateRule, desired.CreateRule},
+		{"updateRule", existing.UpdateRule, desired.UpdateRule},
+		{"deleteRule", existing.DeleteRule, desired.DeleteRule},
+		{"options", normalizeMap(existing.Options), normalizeMap(desired.Options)},
+	}
+
+	for _, p := range props {
+		if !jsonEqual(p.existing, p.desired) {
+			fields = append(fields, p.name)
+		}
+	}
+
+	return fields
+}
+
+func (form *DeclarativeApply) planAdmins() ([]*DeclarativeChange, error) {
+	changes := []*DeclarativeChange{}
+	desiredEmails := map[string]struct{}{}
+
+	for _, desired := range form.Admins {
+		desiredEmails[desired.Email] = struct{}{}
+
+		existing, _ := form.dao.FindAdminByEmail(desired.Email)
+		if existing == nil {
+			changes = append(changes, &DeclarativeChange{Action: DeclarativeActionCreate, Name: desired.Email})
+			continue
+		}
+
+		fields := []string{}
+		if existing.Avatar != desired.Avatar {
+			fields = append(fields, "avatar")
+		}
+		if desired.Password != "" && !existing.ValidatePassword(desired.Password) {
+			fields = append(fields, "password")
+		}
+		if len(fields) > 0 {
+			changes = append(changes, &DeclarativeChange{Action: DeclarativeActionUpdate, Name: desired.Email, Fields: fields})
+		}
+	}
+
+	if form.DeleteMissing {
+		existingAdmins := []*models.Admin{}
+		if err := form.dao.AdminQuery().OrderBy("created ASC").All(&existingAdmins); err != nil {
+			return nil, err
+		}
+
+		for _, existing := range existingAdmins {
+			if _, ok := desiredEmails[existing.Email]; !ok {
+				changes = append(changes, &DeclarativeChange{Action: DeclarativeActionDelete, Name: existing.Email})
+			}
+		}
+	}
+
+	return changes, nil
+}
+
+func (form *DeclarativeApply) hasSettings() bool {
+	return len(form.Settings) > 0 && !bytes.Equal(bytes.TrimSpace(form.Settings), []byte("null"))
+}
+
+// desiredSettings returns the current app settings merged with the form ones.
+func (form *DeclarativeApply) desiredSettings() (map[string]any, error) {
+	clone, err := form.app.Settings().Clone()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(form.Settings, clone); err != nil {
+		return nil, err
+	}
+
+	return toFlatMap(clone)
+}
+
+func (form *DeclarativeApply) planSettings() ([]*DeclarativeChange, error) {
+	current, err := toFlatMap(form.app.Settings())
+	if err != nil {
+		return nil, err
+	}
+
+	desired, err := form.desiredSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(desired))
+	for k := range desired {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	changes := []*DeclarativeChange{}
+	for _, k := range keys {
+		if !jsonEqual(current[k], desired[k]) {
+			changes = append(changes, &DeclarativeChange{Action: DeclarativeActionUpdate, Name: k})
+		}
+	}
+
+	return changes, nil
+}
+
+// -------------------------------------------------------------------
+
+// toFlatMap serializes v and returns its leaf values
+// mapped by their dot separated json path (eg. "meta.appName").
+func toFlatMap(v any) (map[string]any, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	data := map[string]any{}
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, err
+	}
+
+	result := map[string]any{}
+	flattenInto(result, "", data)
+
+	return result, nil
+}
+
+func flattenInto(result map[string]any, prefix string, data map[string]any) {
+	for k, v := range data {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+
+		if nested, ok := v.(map[string]any); ok && len(nested) > 0 {
+			flattenInto(result, key, nested)
+		} else {
+			result[key] = v
+		}
+	}
+}
+
+func jsonEqual(a, b any) bool {
+	rawA, errA := json.Marshal(a)
+	rawB, errB := json.Marshal(b)
+
+	return errA == nil && errB == nil && bytes.Equal(rawA, rawB)
+}
+
+func normalizeSlice[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
+
+func normalizeMap[T any](m map[string]T) map[string]T {
+	if m == nil {
+		return map[string]T{}
+	}
+	return m
+}
@@ -0,0 +1,262 @@
+package forms_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestDeclarativeApplyValidate(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		name           string
+		jsonData       string
+		expectedErrors []string
+	}{
+		{
+			"empty document",
+			`{}`,
+			[]string{},
+		},
+		{
+			"empty sections",
+			`{"collections":[],"admins":[]}`,
+			[]string{"collections", "admins"},
+		},
+		{
+			"invalid data",
+			`{
+				"admins":[{"email":"invalid"},{"email":"a@example.com","avatar":10}],
+				"settings":{"meta":{"appName":123}}
+			}`,
+			[]string{"admins", "settings"},
+		},
+		{
+			"duplicated admins",
+			`{"admins":[{"email":"a@example.com"},{"email":"a@example.com"}]}`,
+			[]string{"admins"},
+		},
+		{
+			"valid data",
+			`{
+				"collections":[{"name":"new"}],
+				"admins":[{"email":"a@example.com","avatar":1}],
+				"settings":{"meta":{"appName":"test"}}
+			}`,
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		form := forms.NewDeclarativeApply(app)
+
+		if err := json.Unmarshal([]byte(s.jsonData), form); err != nil {
+			t.Errorf("[%s] Failed to load form data: %v", s.name, err)
+			continue
+		}
+
+		result := form.Validate()
+
+		// parse errors
+		errs, ok := result.(validation.Errors)
+		if !ok && result != nil {
+			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+			continue
+		}
+
+		// check errors
+		if len(errs) > len(s.expectedErrors) {
+			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+		}
+		for _, k := range s.expectedErrors {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+			}
+		}
+	}
+}
+
+func TestDeclarativeApplyPlan(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	// identical collection resolved by name
+	demo2, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	demo2.Id = ""
+
+	// changed collection with schema field resolved by name
+	demo3, err := app.Dao().FindCollectionByNameOrId("demo3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	demo3.Schema.Fields()[0].Id = ""
+	rule := "id != ''"
+	demo3.ListRule = &rule
+	demo3.Schema.AddField(&schema.SchemaField{Name: "new_field", Type: schema.FieldTypeBool})
+
+	form := forms.NewDeclarativeApply(app)
+	err = json.Unmarshal([]byte(`{
+		"collections": [{"name":"new_collection","schema":[{"name":"title","type":"text"}]}],
+		"admins": [
+			{"email":"test@example.com"},
+			{"email":"test2@example.com","avatar":9,"password":"new_password"},
+			{"email":"new@example.com","password":"1234567890"}
+		],
+		"settings": {"meta":{"appName":"declarative_test","appUrl":"http://localhost:8090"}}
+	}`), form)
+	if err != nil {
+		t.Fatal(err)
+	}
+	form.Collections = append([]*models.Collection{demo2, demo3}, form.Collections...)
+
+	plan, err := form.Plan()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	raw, _ := json.Marshal(plan)
+	rawStr := string(raw)
+
+	expectations := []string{
+		`"collections":[{"action":"update","name":"demo3","fields":["listRule","schema.new_field"]},{"action":"create","name":"new_collection"}]`,
+		`"settings":[{"action":"update","name":"meta.appName"}]`,
+		`"admins":[{"action":"update","name":"test2@example.com","fields":["avatar","password"]},{"action":"create","name":"new@example.com"}]`,
+		`"hasChanges":true`,
+	}
+	for _, e := range expectations {
+		if !strings.Contains(rawStr, e) {
+			t.Errorf("Expected %s in\n%s", e, rawStr)
+		}
+	}
+
+	// with delete missing
+	form.DeleteMissing = true
+
+	plan, err = form.Plan()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	raw, _ = json.Marshal(plan)
+	rawStr = string(raw)
+
+	expectations = []string{
+		`{"action":"delete","name":"demo1"}`,
+		`{"action":"delete","name":"users"}`,
+		`{"action":"delete","name":"test3@example.com"}`,
+	}
+	for _, e := range expectations {
+		if !strings.Contains(rawStr, e) {
+			t.Errorf("Expected %s in\n%s", e, rawStr)
+		}
+	}
+
+	if strings.Contains(rawStr, `{"action":"delete","name":"demo2"}`) {
+		t.Errorf("Didn't expect demo2 to be deleted in\n%s", rawStr)
+	}
+}
+
+func TestDeclarativeApplySubmit(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	if _, err := forms.NewDeclarativeApplyFromState(app); err == nil {
+		t.Fatal("Expected missing state error, got nil")
+	}
+
+	form := forms.NewDeclarativeApply(app)
+	err := json.Unmarshal([]byte(`{
+		"collections": [
+			{"name":"new_collection","schema":[{"name":"title","type":"text"}]}
+		],
+		"admins": [
+			{"email":"test@example.com"},
+			{"email":"new@example.com","avatar":3,"password":"1234567890"}
+		],
+		"settings": {"meta":{"appName":"declarative_test"}}
+	}`), form)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	interceptorCalls := 0
+	interceptor := func(next forms.InterceptorNextFunc[*forms.DeclarativePlan]) forms.InterceptorNextFunc[*forms.DeclarativePlan] {
+		return func(plan *forms.DeclarativePlan) error {
+			interceptorCalls++
+			if !plan.HasChanges {
+				t.Fatal("Expected the plan to have changes")
+			}
+			return next(plan)
+		}
+	}
+
+	if err := form.Submit(interceptor); err != nil {
+		t.Fatal(err)
+	}
+
+	if interceptorCalls != 1 {
+		t.Fatalf("Expected interceptor to be called 1 time, got %d", interceptorCalls)
+	}
+
+	if _, err := app.Dao().FindCollectionByNameOrId("new_collection"); err != nil {
+		t.Fatalf("Expected the collection to be created, got %v", err)
+	}
+
+	admin, err := app.Dao().FindAdminByEmail("new@example.com")
+	if err != nil {
+		t.Fatalf("Expected the admin to be created, got %v", err)
+	}
+	if admin.Avatar != 3 || !admin.ValidatePassword("1234567890") {
+		t.Fatalf("The created admin doesn't match the desired state: %v", admin)
+	}
+
+	if app.Settings().Meta.AppName != "declarative_test" {
+		t.Fatalf("Expected the settings to be updated, got app name %q", app.Settings().Meta.AppName)
+	}
+
+	param, err := app.Dao().FindParamByKey(models.ParamDeclarativeState)
+	if err != nil {
+		t.Fatalf("Expected the applied state to be stored, got %v", err)
+	}
+	if strings.Contains(string(param.Value), "1234567890") {
+		t.Fatalf("Expected the stored state to not contain the admin passwords, got %s", param.Value)
+	}
+
+	// no drift right after the apply
+	stateForm, err := forms.NewDeclarativeApplyFromState(app)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err := stateForm.Plan()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.HasChanges {
+		raw, _ := json.Marshal(plan)
+		t.Fatalf("Expected no changes, got %s", raw)
+	}
+
+	// drift
+	app.Settings().Meta.AppName = "changed"
+
+	plan, err = stateForm.Plan()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !plan.HasChanges || len(plan.Settings) != 1 || plan.Settings[0].Name != "meta.appName" {
+		raw, _ := json.Marshal(plan)
+		t.Fatalf("Expected meta.appName drift, got %s", raw)
+	}
+}
@@ -0,0 +1,136 @@
+package forms
+
+import (
+	"regexp"
+	"strconv"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/flags"
+	"github.com/pocketbase/pocketbase/tools/list"
+)
+
+var featureFlagKeyRegex = regexp.MustCompile(`^[a-zA-Z0-9][\w\.\-]*$`)
+
+// FeatureFlagReservedKeys is a list of the feature flag keys
+// that conflict with the flags api routes.
+var FeatureFlagReservedKeys = []string{"evaluate"}
+
+// FeatureFlagUpsert is a [models.FeatureFlag] upsert (create/update) form.
+type FeatureFlagUpsert struct {
+	app  core.App
+	dao  *daos.Dao
+	flag *models.FeatureFlag
+
+	Key         string       `form:"key" json:"key"`
+	Description string       `form:"description" json:"description"`
+	Enabled     bool         `form:"enabled" json:"enabled"`
+	Rollout     int          `form:"rollout" json:"rollout"`
+	Rules       []flags.Rule `form:"rules" json:"rules"`
+}
+
+// NewFeatureFlagUpsert creates a new [FeatureFlagUpsert] form with initializer
+// config created from the provided [core.App] and [models.FeatureFlag] instances
+// (for create you could pass a pointer to an empty FeatureFlag - `&models.FeatureFlag{}`).
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewFeatureFlagUpsert(app core.App, flag *models.FeatureFlag) *FeatureFlagUpsert {
+	form := &FeatureFlagUpsert{
+		app:  app,
+		dao:  app.Dao(),
+		flag: flag,
+	}
+
+	// load defaults
+	form.Key = flag.Key
+	form.Description = flag.Description
+	form.Enabled = flag.Enabled
+	form.Rollout = flag.Rollout
+	form.Rules = flag.Rules
+
+	return form
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *FeatureFlagUpsert) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *FeatureFlagUpsert) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(
+			&form.Key,
+			validation.Required,
+			validation.Length(1, 100),
+			validation.Match(featureFlagKeyRegex),
+			validation.NotIn(list.ToInterfaceSlice(FeatureFlagReservedKeys)...).Error("The key is reserved."),
+			validation.By(form.checkUniqueKey),
+		),
+		validation.Field(&form.Description, validation.Length(0, 500)),
+		validation.Field(&form.Rollout, validation.Min(0), validation.Max(100)),
+		validation.Field(&form.Rules, validation.Length(0, 50), validation.By(form.checkRules)),
+	)
+}
+
+func (form *FeatureFlagUpsert) checkUniqueKey(value any) error {
+	v, _ := value.(string)
+
+	if form.dao.IsFeatureFlagKeyUnique(v, form.flag.Id) {
+		return nil
+	}
+
+	return validation.NewError("validation_feature_flag_key_exists", "Feature flag key already exists.")
+}
+
+func (form *FeatureFlagUpsert) checkRules(value any) error {
+	rules, _ := value.([]flags.Rule)
+
+	errs := validation.Errors{}
+
+	for i, rule := range rules {
+		r := rule
+
+		err := validation.ValidateStruct(&r,
+			validation.Field(&r.Attribute, validation.Length(0, 100)),
+			validation.Field(
+				&r.Values,
+				validation.When(r.Attribute != "", validation.Required),
+				validation.When(r.Attribute == "", validation.Empty),
+			),
+			validation.Field(&r.Percentage, validation.Min(0), validation.Max(100)),
+		)
+		if err != nil {
+			errs[strconv.Itoa(i)] = err
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// Submit validates the form and upserts the form FeatureFlag model.
+//
+// You can optionally provide a list of InterceptorFunc to further
+// modify the form behavior before persisting it.
+func (form *FeatureFlagUpsert) Submit(interceptors ...InterceptorFunc[*models.FeatureFlag]) error {
+	if err := form.Validate(); err != nil {
+		return err
+	}
+
+	form.flag.Key = form.Key
+	form.flag.Description = form.Description
+	form.flag.Enabled = form.Enabled
+	form.flag.Rollout = form.Rollout
+	form.flag.Rules = form.Rules
+
+	return runInterceptors(form.flag, func(flag *models.FeatureFlag) error {
+		return form.dao.SaveFeatureFlag(flag)
+	}, interceptors...)
+}
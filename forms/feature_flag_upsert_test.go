@@ -0,0 +1,143 @@
+package forms_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestFeatureFlagUpsertValidateAndSubmit(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		existingKey    string
+		jsonData       string
+		expectedErrors []string
+	}{
+		{
+			"create with empty data",
+			"",
+			`{}`,
+			[]string{"key"},
+		},
+		{
+			"create with invalid data",
+			"",
+			`{"key":"-invalid key","description":"` + strings.Repeat("a", 501) + `","rollout":101}`,
+			[]string{"key", "description", "rollout"},
+		},
+		{
+			"create with reserved key",
+			"",
+			`{"key":"evaluate"}`,
+			[]string{"key"},
+		},
+		{
+			"create with existing key",
+			"",
+			`{"key":"NEW-DASHBOARD"}`,
+			[]string{"key"},
+		},
+		{
+			"create with invalid rules",
+			"",
+			`{"key":"new","rules":[{"attribute":"groups","values":[],"percentage":100},{"values":["a"]},{"percentage":-1}]}`,
+			[]string{"rules"},
+		},
+		{
+			"create with valid data",
+			"",
+			`{"key":"new.feature_1","description":"test","enabled":true,"rollout":10,"rules":[{"attribute":"groups","values":["beta"],"percentage":100},{"percentage":50}]}`,
+			[]string{},
+		},
+		{
+			"update with invalid data",
+			"new-dashboard",
+			`{"key":"public-banner","rollout":-1}`,
+			[]string{"key", "rollout"},
+		},
+		{
+			"update with valid data",
+			"new-dashboard",
+			`{"key":"renamed-dashboard","enabled":false}`,
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		func() {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			flag := &models.FeatureFlag{}
+			if s.existingKey != "" {
+				var err error
+				flag, err = app.Dao().FindFeatureFlagByKey(s.existingKey)
+				if err != nil {
+					t.Fatalf("[%s] Failed to load the feature flag: %v", s.name, err)
+				}
+			}
+
+			form := forms.NewFeatureFlagUpsert(app, flag)
+
+			if err := json.Unmarshal([]byte(s.jsonData), form); err != nil {
+				t.Fatalf("[%s] Failed to load form data: %v", s.name, err)
+			}
+
+			interceptorCalls := 0
+
+			result := form.Submit(func(next forms.InterceptorNextFunc[*models.FeatureFlag]) forms.InterceptorNextFunc[*models.FeatureFlag] {
+				return func(m *models.FeatureFlag) error {
+					interceptorCalls++
+					return next(m)
+				}
+			})
+
+			// parse errors
+			errs, ok := result.(validation.Errors)
+			if !ok && result != nil {
+				t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+				return
+			}
+
+			// check errors
+			if len(errs) > len(s.expectedErrors) {
+				t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+			}
+			for _, k := range s.expectedErrors {
+				if _, ok := errs[k]; !ok {
+					t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+				}
+			}
+
+			expectInterceptorCalls := 1
+			if len(s.expectedErrors) > 0 {
+				expectInterceptorCalls = 0
+			}
+			if interceptorCalls != expectInterceptorCalls {
+				t.Errorf("[%s] Expected interceptor to be called %d, got %d", s.name, expectInterceptorCalls, interceptorCalls)
+			}
+
+			if len(s.expectedErrors) > 0 {
+				return
+			}
+
+			saved, err := app.Dao().FindFeatureFlagByKey(form.Key)
+			if err != nil {
+				t.Errorf("[%s] Expected the feature flag to be saved, got %v", s.name, err)
+				return
+			}
+
+			if saved.Description != form.Description ||
+				saved.Enabled != form.Enabled ||
+				saved.Rollout != form.Rollout ||
+				len(saved.Rules) != len(form.Rules) {
+				t.Errorf("[%s] Expected the form data to be persisted, got %v", s.name, saved)
+			}
+		}()
+	}
+}
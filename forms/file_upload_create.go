@@ -0,0 +1,135 @@
+package forms
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+)
+
+// FileUploadCreate is a resumable record file upload create form.
+type FileUploadCreate struct {
+	app        core.App
+	dao        *daos.Dao
+	record     *models.Record
+	admin      *models.Admin
+	authRecord *models.Record
+
+	Field    string `form:"field" json:"field"`
+	Filename string `form:"filename" json:"filename"`
+	Size     int64  `form:"size" json:"size"`
+}
+
+// NewFileUploadCreate creates a new [FileUploadCreate] form for
+// uploading a file to the provided record by the specified admin or auth record.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewFileUploadCreate(app core.App, record *models.Record, admin *models.Admin, authRecord *models.Record) *FileUploadCreate {
+	return &FileUploadCreate{
+		app:        app,
+		dao:        app.Dao(),
+		record:     record,
+		admin:      admin,
+		authRecord: authRecord,
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *FileUploadCreate) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *FileUploadCreate) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(&form.Field, validation.Required, validation.By(form.checkField)),
+		validation.Field(
+			&form.Filename,
+			validation.Required,
+			validation.Length(1, 255),
+			validation.By(checkUploadFilename),
+		),
+		validation.Field(&form.Size, validation.Required, validation.Min(int64(1)), validation.By(form.checkSize)),
+	)
+}
+
+func (form *FileUploadCreate) fileOptions() *schema.FileOptions {
+	field := form.record.Collection().Schema.GetFieldByName(form.Field)
+	if field == nil || field.Type != schema.FieldTypeFile {
+		return nil
+	}
+
+	options, _ := field.Options.(*schema.FileOptions)
+
+	return options
+}
+
+func (form *FileUploadCreate) checkField(value any) error {
+	if form.fileOptions() == nil {
+		return validation.NewError("validation_invalid_file_field", "Must be a valid record file field.")
+	}
+
+	return nil
+}
+
+func (form *FileUploadCreate) checkSize(value any) error {
+	v, _ := value.(int64)
+
+	options := form.fileOptions()
+	if options == nil {
+		return nil // the field is validated separately
+	}
+
+	if v > int64(options.MaxSize) {
+		return validation.NewError(
+			"validation_file_size_limit",
+			fmt.Sprintf("Failed to upload %q - the maximum allowed file size is %v bytes.", form.Filename, options.MaxSize),
+		)
+	}
+
+	return nil
+}
+
+func checkUploadFilename(value any) error {
+	v, _ := value.(string)
+
+	if v == "." || v == ".." || filepath.Base(v) != v {
+		return validation.NewError("validation_invalid_filename", "Invalid file name.")
+	}
+
+	return nil
+}
+
+// Submit validates the form and creates a new file upload
+// for the form record.
+//
+// You can optionally provide a list of InterceptorFunc to further
+// modify the form behavior before persisting it.
+func (form *FileUploadCreate) Submit(interceptors ...InterceptorFunc[*models.FileUpload]) error {
+	if err := form.Validate(); err != nil {
+		return err
+	}
+
+	if form.admin == nil && form.authRecord == nil {
+		return errors.New("missing file upload owner")
+	}
+
+	upload := &models.FileUpload{
+		CollectionId: form.record.Collection().Id,
+		RecordId:     form.record.Id,
+		Field:        form.Field,
+		Filename:     form.Filename,
+		Size:         form.Size,
+	}
+	upload.SetOwner(form.admin, form.authRecord)
+
+	return runInterceptors(upload, func(u *models.FileUpload) error {
+		return form.dao.SaveFileUpload(u)
+	}, interceptors...)
+}
@@ -0,0 +1,104 @@
+package forms_test
+
+import (
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestFileUploadCreateValidateAndSubmit(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	record, err := app.Dao().FindRecordById("users", "4q1xlclmfloku33")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		name           string
+		field          string
+		filename       string
+		size           int64
+		expectedErrors []string
+	}{
+		{"empty data", "", "", 0, []string{"field", "filename", "size"}},
+		{"non file field", "username", "test.txt", 10, []string{"field"}},
+		{"invalid filename", "file", "../test.txt", 10, []string{"filename"}},
+		{"dot filename", "file", "..", 10, []string{"filename"}},
+		{"too large file", "file", "test.txt", 5242881, []string{"size"}},
+		{"valid data", "file", "test.txt", 5242880, []string{}},
+	}
+
+	for _, s := range scenarios {
+		form := forms.NewFileUploadCreate(app, record, nil, record)
+		form.Field = s.field
+		form.Filename = s.filename
+		form.Size = s.size
+
+		interceptorCalls := 0
+
+		result := form.Submit(func(next forms.InterceptorNextFunc[*models.FileUpload]) forms.InterceptorNextFunc[*models.FileUpload] {
+			return func(upload *models.FileUpload) error {
+				interceptorCalls++
+
+				if err := next(upload); err != nil {
+					return err
+				}
+
+				if upload.RecordId != record.Id || upload.Offset != 0 || !upload.IsOwnedBy(nil, record) {
+					t.Errorf("[%s] Unexpected file upload %v", s.name, upload)
+				}
+
+				return nil
+			}
+		})
+
+		// parse errors
+		errs, ok := result.(validation.Errors)
+		if !ok && result != nil {
+			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+			continue
+		}
+
+		// check errors
+		if len(errs) > len(s.expectedErrors) {
+			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+		}
+		for _, k := range s.expectedErrors {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+			}
+		}
+
+		expectInterceptorCalls := 1
+		if len(s.expectedErrors) > 0 {
+			expectInterceptorCalls = 0
+		}
+		if interceptorCalls != expectInterceptorCalls {
+			t.Errorf("[%s] Expected interceptor to be called %d, got %d", s.name, expectInterceptorCalls, interceptorCalls)
+		}
+	}
+}
+
+func TestFileUploadCreateSubmitWithoutOwner(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	record, err := app.Dao().FindRecordById("users", "4q1xlclmfloku33")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	form := forms.NewFileUploadCreate(app, record, nil, nil)
+	form.Field = "file"
+	form.Filename = "test.txt"
+	form.Size = 10
+
+	if err := form.Submit(); err == nil {
+		t.Fatal("Expected error for missing owner, got nil")
+	}
+}
@@ -0,0 +1,179 @@
+package forms
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/tools/types"
+	"github.com/spf13/cast"
+)
+
+const (
+	ImportTransformTrim      = "trim"
+	ImportTransformLowercase = "lowercase"
+	ImportTransformDate      = "date"
+	ImportTransformLookup    = "lookup"
+	ImportTransformDefault   = "default"
+)
+
+var importTransformFieldRegex = regexp.MustCompile(`^\w+$`)
+
+// ImportTransform defines a single declarative field transformation
+// step that is applied on each imported row before its validation.
+//
+// The steps are applied in the order they are defined, so multiple
+// steps could be chained for the same field (eg. trim -> lowercase -> lookup).
+type ImportTransform struct {
+	// Field is the name of the transformed row field.
+	Field string `form:"field" json:"field"`
+
+	// Type is the transformation type (trim, lowercase, date, lookup or default).
+	Type string `form:"type" json:"type"`
+
+	// Format is the Go time layout of the source value
+	// (eg. "02.01.2006") used by the "date" transformation.
+	Format string `form:"format" json:"format"`
+
+	// Collection and LookupField specify the related records that are
+	// looked up by the source value and whose id replaces the field value
+	// (used by the "lookup" transformation).
+	Collection  string `form:"collection" json:"collection"`
+	LookupField string `form:"lookupField" json:"lookupField"`
+
+	// Value is the value to set if the field is missing or empty
+	// (used by the "default" transformation).
+	Value any `form:"value" json:"value"`
+}
+
+// Validate makes ImportTransform validatable by implementing [validation.Validatable] interface.
+func (t ImportTransform) Validate() error {
+	return validation.ValidateStruct(&t,
+		validation.Field(&t.Field, validation.Required, validation.Match(importTransformFieldRegex)),
+		validation.Field(
+			&t.Type,
+			validation.Required,
+			validation.In(
+				ImportTransformTrim,
+				ImportTransformLowercase,
+				ImportTransformDate,
+				ImportTransformLookup,
+				ImportTransformDefault,
+			),
+		),
+		validation.Field(&t.Format, validation.When(t.Type == ImportTransformDate, validation.Required)),
+		validation.Field(&t.Collection, validation.When(t.Type == ImportTransformLookup, validation.Required)),
+		validation.Field(
+			&t.LookupField,
+			validation.When(t.Type == ImportTransformLookup, validation.Required),
+			validation.Match(importTransformFieldRegex),
+		),
+		validation.Field(&t.Value, validation.When(t.Type == ImportTransformDefault, validation.NotNil)),
+	)
+}
+
+// ApplyImportTransforms applies the provided transformation steps
+// (in order) to the row data.
+//
+// The dao is used to look up the related records of the "lookup" steps.
+//
+// Returns [validation.Errors] with the failed row fields.
+func ApplyImportTransforms(dao *daos.Dao, transforms []*ImportTransform, row map[string]any) error {
+	for _, t := range transforms {
+		if t == nil {
+			continue
+		}
+
+		value, exists := row[t.Field]
+
+		// don't add the missing fields to prevent resetting
+		// the existing values of the updated records
+		if !exists && t.Type != ImportTransformDefault {
+			continue
+		}
+
+		value, err := t.apply(dao, value)
+		if err != nil {
+			return validation.Errors{t.Field: validation.NewError(
+				"validation_import_transform_failure",
+				fmt.Sprintf("Failed to apply the %s transformation: %v.", t.Type, err),
+			)}
+		}
+
+		row[t.Field] = value
+	}
+
+	return nil
+}
+
+func (t *ImportTransform) apply(dao *daos.Dao, value any) (any, error) {
+	if t.Type == ImportTransformDefault {
+		if value == nil || value == "" {
+			return t.Value, nil
+		}
+		return value, nil
+	}
+
+	// multiple values fields (eg. relation with maxSelect > 1)
+	if values, ok := value.([]any); ok {
+		result := make([]any, len(values))
+		for i, v := range values {
+			transformed, err := t.apply(dao, v)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = transformed
+		}
+		return result, nil
+	}
+
+	str, ok := value.(string)
+	if !ok {
+		if value == nil {
+			return nil, nil
+		}
+
+		// the lookup could be also by a non-string (eg. number) value
+		if t.Type != ImportTransformLookup {
+			return value, nil
+		}
+
+		str = cast.ToString(value)
+	}
+
+	switch t.Type {
+	case ImportTransformTrim:
+		return strings.TrimSpace(str), nil
+	case ImportTransformLowercase:
+		return strings.ToLower(str), nil
+	case ImportTransformDate:
+		if str == "" {
+			return str, nil
+		}
+
+		parsed, err := time.Parse(t.Format, strings.TrimSpace(str))
+		if err != nil {
+			return nil, fmt.Errorf("%q doesn't match the %q format", str, t.Format)
+		}
+
+		date, _ := types.ParseDateTime(parsed)
+
+		return date.String(), nil
+	case ImportTransformLookup:
+		if str == "" {
+			return str, nil
+		}
+
+		record, err := dao.FindFirstRecordByData(t.Collection, t.LookupField, value)
+		if err != nil {
+			return nil, fmt.Errorf("missing %s record with %s %q", t.Collection, t.LookupField, str)
+		}
+
+		return record.Id, nil
+	}
+
+	return value, nil
+}
@@ -0,0 +1,154 @@
+package forms_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestImportTransformValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		transform      forms.ImportTransform
+		expectedErrors []string
+	}{
+		{"empty", forms.ImportTransform{}, []string{"field", "type"}},
+		{"invalid field and type", forms.ImportTransform{Field: "a b", Type: "unknown"}, []string{"field", "type"}},
+		{"date without format", forms.ImportTransform{Field: "a", Type: forms.ImportTransformDate}, []string{"format"}},
+		{
+			"lookup without collection and field",
+			forms.ImportTransform{Field: "a", Type: forms.ImportTransformLookup},
+			[]string{"collection", "lookupField"},
+		},
+		{
+			"lookup with invalid field",
+			forms.ImportTransform{Field: "a", Type: forms.ImportTransformLookup, Collection: "users", LookupField: "email;"},
+			[]string{"lookupField"},
+		},
+		{"default without value", forms.ImportTransform{Field: "a", Type: forms.ImportTransformDefault}, []string{"value"}},
+		{"valid trim", forms.ImportTransform{Field: "a", Type: forms.ImportTransformTrim}, []string{}},
+		{"valid date", forms.ImportTransform{Field: "a", Type: forms.ImportTransformDate, Format: "02.01.2006"}, []string{}},
+		{
+			"valid lookup",
+			forms.ImportTransform{Field: "a", Type: forms.ImportTransformLookup, Collection: "users", LookupField: "email"},
+			[]string{},
+		},
+		{"valid default", forms.ImportTransform{Field: "a", Type: forms.ImportTransformDefault, Value: false}, []string{}},
+	}
+
+	for _, s := range scenarios {
+		result := s.transform.Validate()
+
+		errs, ok := result.(validation.Errors)
+		if !ok && result != nil {
+			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+			continue
+		}
+
+		if len(errs) != len(s.expectedErrors) {
+			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+		}
+		for _, k := range s.expectedErrors {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+			}
+		}
+	}
+}
+
+func TestApplyImportTransforms(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		name          string
+		transforms    []*forms.ImportTransform
+		row           map[string]any
+		expectedError string
+		expectedRow   string
+	}{
+		{
+			"trim and lowercase (incl. multiple values and non-string values)",
+			[]*forms.ImportTransform{
+				{Field: "a", Type: forms.ImportTransformTrim},
+				{Field: "a", Type: forms.ImportTransformLowercase},
+				{Field: "b", Type: forms.ImportTransformTrim},
+				{Field: "c", Type: forms.ImportTransformLowercase},
+				{Field: "missing", Type: forms.ImportTransformTrim},
+			},
+			map[string]any{"a": " Test ", "b": []any{" x", "y "}, "c": 123.0},
+			"",
+			`{"a":"test","b":["x","y"],"c":123}`,
+		},
+		{
+			"date",
+			[]*forms.ImportTransform{
+				{Field: "a", Type: forms.ImportTransformDate, Format: "02.01.2006"},
+				{Field: "b", Type: forms.ImportTransformDate, Format: "02.01.2006 15:04"},
+			},
+			map[string]any{"a": "15.10.2026", "b": ""},
+			"",
+			`{"a":"2026-10-15 00:00:00.000Z","b":""}`,
+		},
+		{
+			"invalid date",
+			[]*forms.ImportTransform{{Field: "a", Type: forms.ImportTransformDate, Format: "02.01.2006"}},
+			map[string]any{"a": "2026-10-15"},
+			"a",
+			"",
+		},
+		{
+			"lookup",
+			[]*forms.ImportTransform{
+				{Field: "a", Type: forms.ImportTransformLookup, Collection: "users", LookupField: "email"},
+				{Field: "b", Type: forms.ImportTransformLookup, Collection: "users", LookupField: "username"},
+			},
+			map[string]any{"a": "test@example.com", "b": []any{"users75657", "test2_username"}},
+			"",
+			`{"a":"4q1xlclmfloku33","b":["4q1xlclmfloku33","oap640cot4yru2s"]}`,
+		},
+		{
+			"missing lookup record",
+			[]*forms.ImportTransform{{Field: "a", Type: forms.ImportTransformLookup, Collection: "users", LookupField: "email"}},
+			map[string]any{"a": "missing@example.com"},
+			"a",
+			"",
+		},
+		{
+			"default",
+			[]*forms.ImportTransform{
+				{Field: "a", Type: forms.ImportTransformDefault, Value: "x"},
+				{Field: "b", Type: forms.ImportTransformDefault, Value: "x"},
+				{Field: "c", Type: forms.ImportTransformDefault, Value: true},
+			},
+			map[string]any{"a": "", "b": "y"},
+			"",
+			`{"a":"x","b":"y","c":true}`,
+		},
+	}
+
+	for _, s := range scenarios {
+		err := forms.ApplyImportTransforms(app.Dao(), s.transforms, s.row)
+
+		if s.expectedError != "" {
+			errs, ok := err.(validation.Errors)
+			if !ok || errs[s.expectedError] == nil {
+				t.Errorf("[%s] Expected %q error, got %v", s.name, s.expectedError, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("[%s] Expected nil error, got %v", s.name, err)
+			continue
+		}
+
+		raw, _ := json.Marshal(s.row)
+		if string(raw) != s.expectedRow {
+			t.Errorf("[%s] Expected row %s, got %s", s.name, s.expectedRow, raw)
+		}
+	}
+}
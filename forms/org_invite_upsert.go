@@ -0,0 +1,89 @@
+package forms
+
+import (
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// OrgInviteDuration is the lifetime of a newly created (or renewed) organization invite.
+const OrgInviteDuration = 7 * 24 * time.Hour
+
+// OrgInviteUpsert is an organization invite upsert (create/update) form.
+//
+// If the email is already invited, the existing invite
+// role is updated and its expiration is renewed.
+type OrgInviteUpsert struct {
+	app core.App
+	dao *daos.Dao
+	org *models.Org
+
+	Email string `form:"email" json:"email"`
+	Role  string `form:"role" json:"role"`
+}
+
+// NewOrgInviteUpsert creates a new [OrgInviteUpsert] form
+// for inviting members to the provided organization.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewOrgInviteUpsert(app core.App, org *models.Org) *OrgInviteUpsert {
+	return &OrgInviteUpsert{
+		app:  app,
+		dao:  app.Dao(),
+		org:  org,
+		Role: models.OrgRoleMember,
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *OrgInviteUpsert) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *OrgInviteUpsert) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(&form.Email, validation.Required, validation.Length(1, 255), is.EmailFormat),
+		validation.Field(
+			&form.Role,
+			validation.Required,
+			validation.In(list.ToInterfaceSlice(models.OrgRoles())...),
+		),
+	)
+}
+
+// Submit validates the form and upserts the organization invite.
+//
+// You can optionally provide a list of InterceptorFunc to further
+// modify the form behavior before persisting it.
+func (form *OrgInviteUpsert) Submit(interceptors ...InterceptorFunc[*models.OrgInvite]) error {
+	if err := form.Validate(); err != nil {
+		return err
+	}
+
+	invite, err := form.dao.FindOrgInviteByEmail(form.org.Id, form.Email)
+	if err != nil {
+		invite = &models.OrgInvite{
+			OrgId: form.org.Id,
+			Email: strings.ToLower(form.Email),
+		}
+	}
+
+	invite.Role = form.Role
+	invite.Expires, err = types.ParseDateTime(time.Now().Add(OrgInviteDuration))
+	if err != nil {
+		return err
+	}
+
+	return runInterceptors(invite, func(i *models.OrgInvite) error {
+		return form.dao.SaveOrgInvite(i)
+	}, interceptors...)
+}
@@ -0,0 +1,111 @@
+package forms_test
+
+import (
+	"testing"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestOrgInviteUpsertValidateAndSubmit(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		email          string
+		role           string
+		expectedErrors []string
+	}{
+		{"empty", "", "", []string{"email", "role"}},
+		{"invalid data", "invalid", "unknown", []string{"email", "role"}},
+		{"valid", "New@example.com", models.OrgRoleAdmin, []string{}},
+		{"existing invite", "existing@example.com", models.OrgRoleOwner, []string{}},
+	}
+
+	for _, s := range scenarios {
+		func() {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			org := &models.Org{Name: "Acme", Slug: "acme"}
+			if err := app.Dao().CreateOrg(org, nil); err != nil {
+				t.Fatal(err)
+			}
+
+			existing := &models.OrgInvite{OrgId: org.Id, Email: "existing@example.com", Role: models.OrgRoleMember}
+			if err := app.Dao().SaveOrgInvite(existing); err != nil {
+				t.Fatal(err)
+			}
+
+			form := forms.NewOrgInviteUpsert(app, org)
+			form.Email = s.email
+			form.Role = s.role
+
+			interceptorCalls := 0
+			interceptor := func(next forms.InterceptorNextFunc[*models.OrgInvite]) forms.InterceptorNextFunc[*models.OrgInvite] {
+				return func(i *models.OrgInvite) error {
+					interceptorCalls++
+					return next(i)
+				}
+			}
+
+			result := form.Submit(interceptor)
+
+			// parse errors
+			errs, ok := result.(validation.Errors)
+			if !ok && result != nil {
+				t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+				return
+			}
+
+			// check errors
+			if len(errs) > len(s.expectedErrors) {
+				t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+			}
+			for _, k := range s.expectedErrors {
+				if _, ok := errs[k]; !ok {
+					t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+				}
+			}
+
+			if len(s.expectedErrors) > 0 {
+				if interceptorCalls != 0 {
+					t.Errorf("[%s] Expected interceptor to not be called", s.name)
+				}
+				return
+			}
+
+			if interceptorCalls != 1 {
+				t.Errorf("[%s] Expected interceptor to be called once, got %d", s.name, interceptorCalls)
+			}
+
+			invites, err := app.Dao().FindOrgInvites(org.Id)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			invite, err := app.Dao().FindOrgInviteByEmail(org.Id, s.email)
+			if err != nil {
+				t.Fatalf("[%s] Failed to find the invite: %v", s.name, err)
+			}
+
+			if invite.Role != s.role {
+				t.Errorf("[%s] Expected role %q, got %q", s.name, s.role, invite.Role)
+			}
+
+			if invite.IsExpired() || invite.Expires.Time().After(time.Now().Add(forms.OrgInviteDuration)) {
+				t.Errorf("[%s] Unexpected invite expiration %v", s.name, invite.Expires)
+			}
+
+			// the existing invite should be updated instead of creating a new one
+			expectedTotal := 2
+			if s.email == existing.Email {
+				expectedTotal = 1
+			}
+			if len(invites) != expectedTotal {
+				t.Errorf("[%s] Expected %d invites, got %d", s.name, expectedTotal, len(invites))
+			}
+		}()
+	}
+}
@@ -0,0 +1,79 @@
+package forms
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/list"
+)
+
+// OrgMemberUpdate is an organization member role update form.
+type OrgMemberUpdate struct {
+	app    core.App
+	dao    *daos.Dao
+	member *models.OrgMember
+
+	Role string `form:"role" json:"role"`
+}
+
+// NewOrgMemberUpdate creates a new [OrgMemberUpdate] form
+// for the provided organization member.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewOrgMemberUpdate(app core.App, member *models.OrgMember) *OrgMemberUpdate {
+	return &OrgMemberUpdate{
+		app:    app,
+		dao:    app.Dao(),
+		member: member,
+		Role:   member.Role,
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *OrgMemberUpdate) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *OrgMemberUpdate) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(
+			&form.Role,
+			validation.Required,
+			validation.In(list.ToInterfaceSlice(models.OrgRoles())...),
+			validation.By(form.checkLastOwner),
+		),
+	)
+}
+
+func (form *OrgMemberUpdate) checkLastOwner(value any) error {
+	v, _ := value.(string)
+
+	if v == models.OrgRoleOwner || form.member.Role != models.OrgRoleOwner {
+		return nil
+	}
+
+	if total, err := form.dao.CountOrgOwners(form.member.OrgId); err != nil || total <= 1 {
+		return validation.NewError("validation_org_last_owner", "The organization must have at least one owner.")
+	}
+
+	return nil
+}
+
+// Submit validates the form and updates the organization member role.
+//
+// You can optionally provide a list of InterceptorFunc to further
+// modify the form behavior before persisting it.
+func (form *OrgMemberUpdate) Submit(interceptors ...InterceptorFunc[*models.OrgMember]) error {
+	if err := form.Validate(); err != nil {
+		return err
+	}
+
+	form.member.Role = form.Role
+
+	return runInterceptors(form.member, func(m *models.OrgMember) error {
+		return form.dao.SaveOrgMember(m)
+	}, interceptors...)
+}
@@ -0,0 +1,92 @@
+package forms_test
+
+import (
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestOrgMemberUpdateValidateAndSubmit(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		recordId       string
+		role           string
+		expectedErrors []string
+	}{
+		{"empty", "oap640cot4yru2s", "", []string{"role"}},
+		{"invalid role", "oap640cot4yru2s", "unknown", []string{"role"}},
+		{"demote the last owner", "4q1xlclmfloku33", models.OrgRoleAdmin, []string{"role"}},
+		{"promote member", "oap640cot4yru2s", models.OrgRoleOwner, []string{}},
+		{"demote member", "oap640cot4yru2s", models.OrgRoleMember, []string{}},
+	}
+
+	for _, s := range scenarios {
+		func() {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			owner, err := app.Dao().FindRecordById("users", "4q1xlclmfloku33")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			org := &models.Org{Name: "Acme", Slug: "acme"}
+			if err := app.Dao().CreateOrg(org, owner); err != nil {
+				t.Fatal(err)
+			}
+
+			other := &models.OrgMember{OrgId: org.Id, CollectionId: "_pb_users_auth_", RecordId: "oap640cot4yru2s", Role: models.OrgRoleAdmin}
+			if err := app.Dao().SaveOrgMember(other); err != nil {
+				t.Fatal(err)
+			}
+
+			record, err := app.Dao().FindRecordById("users", s.recordId)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			member, err := app.Dao().FindOrgMemberByRecord(org.Id, record)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			form := forms.NewOrgMemberUpdate(app, member)
+			form.Role = s.role
+
+			result := form.Submit()
+
+			// parse errors
+			errs, ok := result.(validation.Errors)
+			if !ok && result != nil {
+				t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+				return
+			}
+
+			// check errors
+			if len(errs) > len(s.expectedErrors) {
+				t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+			}
+			for _, k := range s.expectedErrors {
+				if _, ok := errs[k]; !ok {
+					t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+				}
+			}
+
+			if len(s.expectedErrors) > 0 {
+				return
+			}
+
+			saved, err := app.Dao().FindOrgMemberById(org.Id, member.Id)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if saved.Role != s.role {
+				t.Errorf("[%s] Expected role %q, got %q", s.name, s.role, saved.Role)
+			}
+		}()
+	}
+}
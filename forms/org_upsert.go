@@ -0,0 +1,99 @@
+package forms
+
+import (
+	"regexp"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+var orgSlugRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9\-]*$`)
+
+// OrgUpsert is a [models.Org] upsert (create/update) form.
+type OrgUpsert struct {
+	app   core.App
+	dao   *daos.Dao
+	org   *models.Org
+	owner *models.Record
+
+	Name string `form:"name" json:"name"`
+	Slug string `form:"slug" json:"slug"`
+}
+
+// NewOrgUpsert creates a new [OrgUpsert] form with initializer
+// config created from the provided [core.App] and [models.Org] instances
+// (for create you could pass a pointer to an empty Org - `&models.Org{}`).
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewOrgUpsert(app core.App, org *models.Org) *OrgUpsert {
+	form := &OrgUpsert{
+		app: app,
+		dao: app.Dao(),
+		org: org,
+	}
+
+	// load defaults
+	form.Name = org.Name
+	form.Slug = org.Slug
+
+	return form
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *OrgUpsert) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// SetOwner sets the auth record that will be registered as
+// owner of the organization on create.
+func (form *OrgUpsert) SetOwner(owner *models.Record) {
+	form.owner = owner
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *OrgUpsert) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(&form.Name, validation.Required, validation.Length(1, 100)),
+		validation.Field(
+			&form.Slug,
+			validation.Required,
+			validation.Length(1, 100),
+			validation.Match(orgSlugRegex),
+			validation.By(form.checkUniqueSlug),
+		),
+	)
+}
+
+func (form *OrgUpsert) checkUniqueSlug(value any) error {
+	v, _ := value.(string)
+
+	if form.dao.IsOrgSlugUnique(v, form.org.Id) {
+		return nil
+	}
+
+	return validation.NewError("validation_org_slug_exists", "Organization slug already exists.")
+}
+
+// Submit validates the form and upserts the form Org model.
+//
+// You can optionally provide a list of InterceptorFunc to further
+// modify the form behavior before persisting it.
+func (form *OrgUpsert) Submit(interceptors ...InterceptorFunc[*models.Org]) error {
+	if err := form.Validate(); err != nil {
+		return err
+	}
+
+	form.org.Name = form.Name
+	form.org.Slug = form.Slug
+
+	return runInterceptors(form.org, func(org *models.Org) error {
+		if org.IsNew() {
+			return form.dao.CreateOrg(org, form.owner)
+		}
+
+		return form.dao.SaveOrg(org)
+	}, interceptors...)
+}
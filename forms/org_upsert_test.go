@@ -0,0 +1,113 @@
+package forms_test
+
+import (
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestOrgUpsertValidateAndSubmit(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		existing       bool
+		withOwner      bool
+		orgName        string
+		slug           string
+		expectedErrors []string
+	}{
+		{"empty", false, false, "", "", []string{"name", "slug"}},
+		{"invalid slug", false, false, "Test", "Invalid slug", []string{"slug"}},
+		{"duplicated slug", false, false, "Test", "acme", []string{"slug"}},
+		{"create without owner", false, false, "Test", "test-org", []string{}},
+		{"create with owner", false, true, "Test", "test-org", []string{}},
+		{"update with the same slug", true, false, "Acme Inc.", "acme", []string{}},
+	}
+
+	for _, s := range scenarios {
+		func() {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			existing := &models.Org{Name: "Acme", Slug: "acme"}
+			if err := app.Dao().CreateOrg(existing, nil); err != nil {
+				t.Fatal(err)
+			}
+
+			org := &models.Org{}
+			if s.existing {
+				org = existing
+			}
+
+			form := forms.NewOrgUpsert(app, org)
+			form.Name = s.orgName
+			form.Slug = s.slug
+
+			if s.withOwner {
+				owner, err := app.Dao().FindRecordById("users", "4q1xlclmfloku33")
+				if err != nil {
+					t.Fatal(err)
+				}
+				form.SetOwner(owner)
+			}
+
+			interceptorCalls := 0
+			interceptor := func(next forms.InterceptorNextFunc[*models.Org]) forms.InterceptorNextFunc[*models.Org] {
+				return func(o *models.Org) error {
+					interceptorCalls++
+					return next(o)
+				}
+			}
+
+			result := form.Submit(interceptor)
+
+			// parse errors
+			errs, ok := result.(validation.Errors)
+			if !ok && result != nil {
+				t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+				return
+			}
+
+			// check errors
+			if len(errs) > len(s.expectedErrors) {
+				t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+			}
+			for _, k := range s.expectedErrors {
+				if _, ok := errs[k]; !ok {
+					t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+				}
+			}
+
+			if len(s.expectedErrors) > 0 {
+				if interceptorCalls != 0 {
+					t.Errorf("[%s] Expected interceptor to not be called", s.name)
+				}
+				return
+			}
+
+			if interceptorCalls != 1 {
+				t.Errorf("[%s] Expected interceptor to be called once, got %d", s.name, interceptorCalls)
+			}
+
+			saved, err := app.Dao().FindOrgById(org.Id)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if saved.Name != s.orgName || saved.Slug != s.slug {
+				t.Errorf("[%s] The org doesn't match with the submitted data: %v", s.name, saved)
+			}
+
+			members, _ := app.Dao().FindOrgMembers(org.Id)
+			expectedMembers := 0
+			if s.withOwner {
+				expectedMembers = 1
+			}
+			if len(members) != expectedMembers {
+				t.Errorf("[%s] Expected %d members, got %d", s.name, expectedMembers, len(members))
+			}
+		}()
+	}
+}
@@ -0,0 +1,77 @@
+package forms
+
+import (
+	"errors"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/spf13/cast"
+)
+
+// PublicFormSubmit is a form that creates a new record in the target
+// collection of a public form (see [settings.PublicFormsConfig]).
+//
+// Only the form allowlisted fields are loaded from the submitted Data
+// and the target collection api rules are not checked.
+type PublicFormSubmit struct {
+	app    core.App
+	dao    *daos.Dao
+	config settings.PublicFormConfig
+
+	Data map[string]any
+}
+
+// NewPublicFormSubmit creates a new [PublicFormSubmit] form
+// for the provided public form config.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewPublicFormSubmit(app core.App, config settings.PublicFormConfig) *PublicFormSubmit {
+	return &PublicFormSubmit{
+		app:    app,
+		dao:    app.Dao(),
+		config: config,
+		Data:   map[string]any{},
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *PublicFormSubmit) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// IsHoneypotFilled reports whether the form honeypot field was
+// filled (usually by a spam bot).
+func (form *PublicFormSubmit) IsHoneypotFilled() bool {
+	return form.config.Honeypot != "" && cast.ToString(form.Data[form.config.Honeypot]) != ""
+}
+
+// Submit validates the allowlisted submitted fields and
+// creates a new record in the public form collection.
+//
+// You can optionally provide a list of InterceptorFunc to further
+// modify the form behavior before persisting the record.
+func (form *PublicFormSubmit) Submit(interceptors ...InterceptorFunc[*models.Record]) error {
+	collection, err := form.dao.FindCollectionByNameOrId(form.config.Collection)
+	if err != nil || !collection.IsBase() {
+		return errors.New("the public form collection is missing or it is not a base collection")
+	}
+
+	data := make(map[string]any, len(form.config.Fields))
+	for _, field := range form.config.Fields {
+		if v, ok := form.Data[field]; ok {
+			data[field] = v
+		}
+	}
+
+	upsert := NewRecordUpsert(form.app, models.NewRecord(collection))
+	upsert.SetDao(form.dao)
+
+	if err := upsert.LoadData(data); err != nil {
+		return err
+	}
+
+	return upsert.Submit(interceptors...)
+}
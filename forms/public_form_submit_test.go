@@ -0,0 +1,133 @@
+package forms_test
+
+import (
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestPublicFormSubmitIsHoneypotFilled(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		honeypot string
+		data     map[string]any
+		expected bool
+	}{
+		{"", map[string]any{"website": "test"}, false},
+		{"website", map[string]any{}, false},
+		{"website", map[string]any{"website": ""}, false},
+		{"website", map[string]any{"website": "test"}, true},
+	}
+
+	for i, s := range scenarios {
+		form := forms.NewPublicFormSubmit(app, settings.PublicFormConfig{Honeypot: s.honeypot})
+		form.Data = s.data
+
+		if result := form.IsHoneypotFilled(); result != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, result)
+		}
+	}
+}
+
+func TestPublicFormSubmitSubmit(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		collection     string
+		data           map[string]any
+		expectError    bool
+		expectedErrors []string
+	}{
+		{
+			"missing collection",
+			"missing",
+			map[string]any{"title": "test"},
+			true,
+			nil,
+		},
+		{
+			"non base collection",
+			"users",
+			map[string]any{"title": "test"},
+			true,
+			nil,
+		},
+		{
+			"invalid data",
+			"demo2",
+			map[string]any{"title": "a"},
+			true,
+			[]string{"title"},
+		},
+		{
+			"valid data",
+			"demo2",
+			map[string]any{"id": "public_form_id1", "title": "public_form_test", "active": true},
+			false,
+			nil,
+		},
+	}
+
+	for _, s := range scenarios {
+		app, _ := tests.NewTestApp()
+
+		form := forms.NewPublicFormSubmit(app, settings.PublicFormConfig{
+			Collection: s.collection,
+			Fields:     []string{"title"},
+		})
+		form.Data = s.data
+
+		var submitted bool
+
+		err := form.Submit(func(next forms.InterceptorNextFunc[*models.Record]) forms.InterceptorNextFunc[*models.Record] {
+			return func(r *models.Record) error {
+				submitted = true
+				return next(r)
+			}
+		})
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("[%s] Expected hasErr %v, got %v (%v)", s.name, s.expectError, hasErr, err)
+			app.Cleanup()
+			continue
+		}
+
+		if len(s.expectedErrors) > 0 {
+			errs, ok := err.(validation.Errors)
+			if !ok {
+				t.Errorf("[%s] Failed to parse errors %v", s.name, err)
+			}
+			for _, k := range s.expectedErrors {
+				if _, ok := errs[k]; !ok {
+					t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+				}
+			}
+		}
+
+		if !hasErr {
+			if !submitted {
+				t.Errorf("[%s] Expected the interceptor to be called", s.name)
+			}
+
+			record, err := app.Dao().FindFirstRecordByData("demo2", "title", "public_form_test")
+			if err != nil {
+				t.Errorf("[%s] Expected the record to be created: %v", s.name, err)
+			} else {
+				if record.Id == "public_form_id1" {
+					t.Errorf("[%s] Expected the not allowlisted id to be ignored", s.name)
+				}
+				if record.GetBool("active") {
+					t.Errorf("[%s] Expected the not allowlisted active field to be ignored", s.name)
+				}
+			}
+		}
+
+		app.Cleanup()
+	}
+}
@@ -0,0 +1,72 @@
+package forms
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// RateLimitUpsert is a [models.RateLimit] upsert (create/update) form.
+type RateLimitUpsert struct {
+	app       core.App
+	dao       *daos.Dao
+	rateLimit *models.RateLimit
+
+	MaxRequests int    `form:"maxRequests" json:"maxRequests"`
+	Duration    int    `form:"duration" json:"duration"`
+	Note        string `form:"note" json:"note"`
+}
+
+// NewRateLimitUpsert creates a new [RateLimitUpsert] form with initializer
+// config created from the provided [core.App] and [models.RateLimit] instances
+// (for create you could pass a pointer to a RateLimit with only its IdentityId set).
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewRateLimitUpsert(app core.App, rateLimit *models.RateLimit) *RateLimitUpsert {
+	form := &RateLimitUpsert{
+		app:       app,
+		dao:       app.Dao(),
+		rateLimit: rateLimit,
+	}
+
+	// load defaults
+	form.MaxRequests = rateLimit.MaxRequests
+	form.Duration = rateLimit.Duration
+	form.Note = rateLimit.Note
+
+	return form
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *RateLimitUpsert) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *RateLimitUpsert) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(&form.MaxRequests, validation.Min(0)),
+		validation.Field(&form.Duration, validation.Required, validation.Min(1), validation.Max(86400)),
+		validation.Field(&form.Note, validation.Length(0, 500)),
+	)
+}
+
+// Submit validates the form and upserts the form RateLimit model.
+//
+// You can optionally provide a list of InterceptorFunc to further
+// modify the form behavior before persisting it.
+func (form *RateLimitUpsert) Submit(interceptors ...InterceptorFunc[*models.RateLimit]) error {
+	if err := form.Validate(); err != nil {
+		return err
+	}
+
+	form.rateLimit.MaxRequests = form.MaxRequests
+	form.rateLimit.Duration = form.Duration
+	form.rateLimit.Note = form.Note
+
+	return runInterceptors(form.rateLimit, func(rateLimit *models.RateLimit) error {
+		return form.dao.SaveRateLimit(rateLimit)
+	}, interceptors...)
+}
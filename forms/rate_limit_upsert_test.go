@@ -0,0 +1,118 @@
+package forms_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestRateLimitUpsertValidateAndSubmit(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		identityId     string
+		jsonData       string
+		expectedErrors []string
+	}{
+		{
+			"create with empty data",
+			"new",
+			`{}`,
+			[]string{"duration"},
+		},
+		{
+			"create with invalid data",
+			"new",
+			`{"maxRequests":-1,"duration":86401,"note":"` + strings.Repeat("a", 501) + `"}`,
+			[]string{"maxRequests", "duration", "note"},
+		},
+		{
+			"create with valid data",
+			"new",
+			`{"maxRequests":0,"duration":60,"note":"unlimited"}`,
+			[]string{},
+		},
+		{
+			"update with invalid data",
+			"4q1xlclmfloku33",
+			`{"duration":0}`,
+			[]string{"duration"},
+		},
+		{
+			"update with valid data",
+			"4q1xlclmfloku33",
+			`{"maxRequests":1000}`,
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		func() {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			rateLimit, _ := app.Dao().FindRateLimitByIdentity(s.identityId)
+			if rateLimit == nil {
+				rateLimit = &models.RateLimit{IdentityId: s.identityId}
+			}
+
+			form := forms.NewRateLimitUpsert(app, rateLimit)
+
+			if err := json.Unmarshal([]byte(s.jsonData), form); err != nil {
+				t.Fatalf("[%s] Failed to load form data: %v", s.name, err)
+			}
+
+			interceptorCalls := 0
+
+			result := form.Submit(func(next forms.InterceptorNextFunc[*models.RateLimit]) forms.InterceptorNextFunc[*models.RateLimit] {
+				return func(m *models.RateLimit) error {
+					interceptorCalls++
+					return next(m)
+				}
+			})
+
+			// parse errors
+			errs, ok := result.(validation.Errors)
+			if !ok && result != nil {
+				t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+				return
+			}
+
+			// check errors
+			if len(errs) > len(s.expectedErrors) {
+				t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+			}
+			for _, k := range s.expectedErrors {
+				if _, ok := errs[k]; !ok {
+					t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+				}
+			}
+
+			expectInterceptorCalls := 1
+			if len(s.expectedErrors) > 0 {
+				expectInterceptorCalls = 0
+			}
+			if interceptorCalls != expectInterceptorCalls {
+				t.Errorf("[%s] Expected interceptor to be called %d, got %d", s.name, expectInterceptorCalls, interceptorCalls)
+			}
+
+			if len(s.expectedErrors) > 0 {
+				return
+			}
+
+			saved, err := app.Dao().FindRateLimitByIdentity(s.identityId)
+			if err != nil {
+				t.Errorf("[%s] Expected the rate limit to be saved, got %v", s.name, err)
+				return
+			}
+
+			if saved.MaxRequests != form.MaxRequests || saved.Duration != form.Duration || saved.Note != form.Note {
+				t.Errorf("[%s] Expected the form data to be persisted, got %v", s.name, saved)
+			}
+		}()
+	}
+}
@@ -0,0 +1,135 @@
+package forms
+
+import (
+	"encoding/json"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/routine"
+	"github.com/pocketbase/pocketbase/tools/subscriptions"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// RealtimeBroadcastResult defines the result of a single realtime broadcast.
+type RealtimeBroadcastResult struct {
+	Topic string `json:"topic"`
+
+	// Clients is the number of the subscribed clients the message was sent to.
+	Clients int `json:"clients"`
+}
+
+// RealtimeBroadcast is a form that publishes a custom message
+// to all realtime clients subscribed to the specified topic.
+//
+// Custom topics don't have access rules, aka. every client
+// subscribed to the topic will receive the message.
+type RealtimeBroadcast struct {
+	app core.App
+
+	Topic string        `form:"topic" json:"topic"`
+	Data  types.JsonRaw `form:"data" json:"data"`
+}
+
+// NewRealtimeBroadcast creates a new [RealtimeBroadcast] form.
+func NewRealtimeBroadcast(app core.App) *RealtimeBroadcast {
+	return &RealtimeBroadcast{
+		app: app,
+	}
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *RealtimeBroadcast) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(
+			&form.Topic,
+			validation.Required,
+			validation.Length(1, 255),
+			validation.By(form.checkReservedTopic),
+		),
+		validation.Field(&form.Data, validation.Length(0, 1<<20), validation.By(form.checkJson)),
+	)
+}
+
+func (form *RealtimeBroadcast) checkJson(value any) error {
+	v, _ := value.(types.JsonRaw)
+
+	if len(v) > 0 && !json.Valid(v) {
+		return validation.NewError("validation_invalid_json", "Must be a valid json value.")
+	}
+
+	return nil
+}
+
+// checkReservedTopic disallows broadcasting to the system and
+// the collection record topics (eg. "PB_CONNECT", "demo/*", "demo/RECORD_ID").
+func (form *RealtimeBroadcast) checkReservedTopic(value any) error {
+	v, _ := value.(string)
+
+	if strings.HasPrefix(v, "PB_") {
+		return validation.NewError("validation_reserved_topic", "The topic is reserved for system messages.")
+	}
+
+	collectionNameOrId := strings.SplitN(v, "/", 2)[0]
+
+	if collection, _ := form.app.Dao().FindCollectionByNameOrId(collectionNameOrId); collection != nil {
+		return validation.NewError("validation_reserved_topic", "The topic is reserved for the collection record changes.")
+	}
+
+	return nil
+}
+
+// Submit validates the form and sends the message to the subscribed clients.
+//
+// You can optionally provide a list of InterceptorFunc to further
+// modify the form behavior before returning the broadcast result.
+func (form *RealtimeBroadcast) Submit(interceptors ...InterceptorFunc[*RealtimeBroadcastResult]) error {
+	if err := form.Validate(); err != nil {
+		return err
+	}
+
+	encodedData, err := json.Marshal(form.Data)
+	if err != nil {
+		return err
+	}
+
+	result := &RealtimeBroadcastResult{Topic: form.Topic}
+
+	return runInterceptors(result, func(result *RealtimeBroadcastResult) error {
+		messageFunc := func(client subscriptions.Client, topic string) (subscriptions.Message, bool) {
+			return subscriptions.Message{
+				Name: topic,
+				Data: string(encodedData),
+			}, true
+		}
+
+		maxReplayEvents, replayTTL := form.app.Settings().RealtimeReplay.Limits()
+
+		eventId := form.app.SubscriptionsBroker().Replay().Add(
+			[]string{form.Topic},
+			messageFunc,
+			maxReplayEvents,
+			replayTTL,
+		)
+
+		for _, client := range form.app.SubscriptionsBroker().Clients() {
+			if client.IsDiscarded() || !client.HasSubscription(form.Topic) {
+				continue
+			}
+
+			result.Clients++
+
+			msg, _ := messageFunc(client, form.Topic)
+			msg.Id = eventId
+
+			client := client
+			routine.FireAndForget(func() {
+				if !client.IsDiscarded() {
+					client.Channel() <- msg
+				}
+			})
+		}
+
+		return nil
+	}, interceptors...)
+}
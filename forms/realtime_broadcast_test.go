@@ -0,0 +1,116 @@
+package forms_test
+
+import (
+	"testing"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/subscriptions"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestRealtimeBroadcastValidate(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		name           string
+		topic          string
+		data           string
+		expectedErrors []string
+	}{
+		{"empty", "", "", []string{"topic"}},
+		{"system topic", "PB_CONNECT", "", []string{"topic"}},
+		{"collection name topic", "demo1", "", []string{"topic"}},
+		{"collection wildcard topic", "demo1/*", "", []string{"topic"}},
+		{"collection id record topic", "wsmn24bux7wo113/84nmscqy84lsi1t", "", []string{"topic"}},
+		{"invalid json data", "jobs/progress", `{"a":`, []string{"data"}},
+		{"valid without data", "jobs/progress", "", []string{}},
+		{"valid with data", "jobs/progress", `{"progress":50}`, []string{}},
+	}
+
+	for _, s := range scenarios {
+		form := forms.NewRealtimeBroadcast(app)
+		form.Topic = s.topic
+		form.Data = types.JsonRaw(s.data)
+
+		result := form.Validate()
+
+		errs, ok := result.(validation.Errors)
+		if !ok && result != nil {
+			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+			continue
+		}
+
+		if len(errs) != len(s.expectedErrors) {
+			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+		}
+		for _, k := range s.expectedErrors {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+			}
+		}
+	}
+}
+
+func TestRealtimeBroadcastSubmit(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	subscribed := subscriptions.NewDefaultClient()
+	subscribed.Subscribe("jobs/progress")
+	app.SubscriptionsBroker().Register(subscribed)
+
+	discarded := subscriptions.NewDefaultClient()
+	discarded.Subscribe("jobs/progress")
+	discarded.Discard()
+	app.SubscriptionsBroker().Register(discarded)
+
+	other := subscriptions.NewDefaultClient()
+	other.Subscribe("jobs/other")
+	app.SubscriptionsBroker().Register(other)
+
+	form := forms.NewRealtimeBroadcast(app)
+	form.Topic = "jobs/progress"
+	form.Data = types.JsonRaw(`{ "progress": 50 }`)
+
+	var result *forms.RealtimeBroadcastResult
+
+	interceptorCalls := 0
+	err := form.Submit(func(next forms.InterceptorNextFunc[*forms.RealtimeBroadcastResult]) forms.InterceptorNextFunc[*forms.RealtimeBroadcastResult] {
+		return func(r *forms.RealtimeBroadcastResult) error {
+			interceptorCalls++
+			err := next(r)
+			result = r
+			return err
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if interceptorCalls != 1 {
+		t.Fatalf("Expected interceptor to be called once, got %d", interceptorCalls)
+	}
+
+	if result.Topic != "jobs/progress" || result.Clients != 1 {
+		t.Fatalf("Unexpected result %+v", result)
+	}
+
+	select {
+	case msg := <-subscribed.Channel():
+		if msg.Name != "jobs/progress" || msg.Data != `{"progress":50}` {
+			t.Fatalf("Unexpected message %+v", msg)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected the subscribed client to receive the message")
+	}
+
+	select {
+	case msg := <-other.Channel():
+		t.Fatalf("Expected no message for the non subscribed client, got %+v", msg)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
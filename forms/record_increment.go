@@ -0,0 +1,225 @@
+package forms
+
+import (
+	"fmt"
+	"math"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+)
+
+// RecordIncrementField defines a single record number field increment.
+type RecordIncrementField struct {
+	// Amount is the value to add to the field (use a negative one to decrement).
+	Amount float64 `form:"amount" json:"amount"`
+
+	// Min and Max are optional bounds to clamp the incremented value.
+	//
+	// They are intersected with the field schema min/max options.
+	Min *float64 `form:"min" json:"min"`
+	Max *float64 `form:"max" json:"max"`
+}
+
+// RecordIncrement is a record number fields atomic increment form.
+//
+// The new field values are calculated by the database in a single
+// UPDATE statement, so concurrent increments don't override each other.
+type RecordIncrement struct {
+	app    core.App
+	dao    *daos.Dao
+	record *models.Record
+
+	Fields map[string]RecordIncrementField `form:"fields" json:"fields"`
+}
+
+// NewRecordIncrement creates a new [RecordIncrement] form
+// for incrementing the provided record number fields.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewRecordIncrement(app core.App, record *models.Record) *RecordIncrement {
+	return &RecordIncrement{
+		app:    app,
+		dao:    app.Dao(),
+		record: record,
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *RecordIncrement) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *RecordIncrement) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(
+			&form.Fields,
+			validation.Required,
+			validation.By(form.checkFields),
+		),
+	)
+}
+
+func (form *RecordIncrement) checkFields(value any) error {
+	v, _ := value.(map[string]RecordIncrementField)
+
+	errs := validation.Errors{}
+
+	for name, increment := range v {
+		field := form.numberField(name)
+		if field == nil {
+			errs[name] = validation.NewError("validation_invalid_increment_field", "Missing or non-number record field.")
+			continue
+		}
+
+		if increment.Amount == 0 || math.IsNaN(increment.Amount) || math.IsInf(increment.Amount, 0) {
+			errs[name] = validation.NewError("validation_invalid_increment_amount", "The increment amount must be a non-zero number.")
+			continue
+		}
+
+		min, max := form.bounds(field, increment)
+		if min != nil && max != nil && *min > *max {
+			errs[name] = validation.NewError("validation_invalid_increment_bounds", "The min bound must be less than or equal to the max one.")
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// numberField returns the record collection number field with the provided name.
+func (form *RecordIncrement) numberField(name string) *schema.SchemaField {
+	field := form.record.Collection().Schema.GetFieldByName(name)
+	if field == nil || field.Type != schema.FieldTypeNumber {
+		return nil
+	}
+
+	return field
+}
+
+// bounds returns the effective min/max bounds of a single field increment
+// (aka. the intersection of the field schema and the increment bounds).
+func (form *RecordIncrement) bounds(field *schema.SchemaField, increment RecordIncrementField) (min *float64, max *float64) {
+	min, max = increment.Min, increment.Max
+
+	if err := field.InitOptions(); err != nil {
+		return min, max
+	}
+
+	options, _ := field.Options.(*schema.NumberOptions)
+	if options == nil {
+		return min, max
+	}
+
+	if options.Min != nil && (min == nil || *options.Min > *min) {
+		min = options.Min
+	}
+
+	if options.Max != nil && (max == nil || *options.Max < *max) {
+		max = options.Max
+	}
+
+	return min, max
+}
+
+// ResultData returns the expected values of the valid increment fields
+// based on the current record data.
+//
+// It could be used for checking the collection API rules that reference
+// the submitted data before the actual increment.
+func (form *RecordIncrement) ResultData() map[string]any {
+	result := make(map[string]any, len(form.Fields))
+
+	for name, increment := range form.Fields {
+		field := form.numberField(name)
+		if field == nil {
+			continue
+		}
+
+		value := form.record.GetFloat(name) + increment.Amount
+
+		min, max := form.bounds(field, increment)
+		if min != nil && value < *min {
+			value = *min
+		}
+		if max != nil && value > *max {
+			value = *max
+		}
+
+		result[name] = value
+	}
+
+	return result
+}
+
+// Submit validates the form and atomically increments the record fields.
+//
+// On success the form record is reloaded with its latest persisted state.
+//
+// You can optionally provide a list of InterceptorFunc to further
+// modify the form behavior before persisting it.
+func (form *RecordIncrement) Submit(interceptors ...InterceptorFunc[*models.Record]) error {
+	if err := form.Validate(); err != nil {
+		return err
+	}
+
+	columns := make(dbx.Params, len(form.Fields))
+
+	var i int
+	for name, increment := range form.Fields {
+		params := dbx.Params{fmt.Sprintf("amount%d", i): increment.Amount}
+
+		expr := fmt.Sprintf("COALESCE([[%s]], 0) + {:amount%d}", name, i)
+
+		min, max := form.bounds(form.numberField(name), increment)
+		if min != nil {
+			params[fmt.Sprintf("min%d", i)] = *min
+			expr = fmt.Sprintf("MAX(%s, {:min%d})", expr, i)
+		}
+		if max != nil {
+			params[fmt.Sprintf("max%d", i)] = *max
+			expr = fmt.Sprintf("MIN(%s, {:max%d})", expr, i)
+		}
+
+		columns[name] = dbx.NewExp(expr, params)
+
+		i++
+	}
+
+	return runInterceptors(form.record, func(record *models.Record) error {
+		return form.dao.RunInTransaction(func(txDao *daos.Dao) error {
+			_, err := txDao.DB().Update(
+				record.TableName(),
+				columns,
+				dbx.HashExp{"id": record.Id},
+			).Execute()
+			if err != nil {
+				return err
+			}
+
+			// reload the record within the transaction to prevent
+			// overwriting concurrent changes of the other fields
+			fresh, err := txDao.FindRecordById(record.Collection().Id, record.Id)
+			if err != nil {
+				return err
+			}
+
+			// resave to refresh the updated date and trigger the model hooks
+			if err := txDao.SaveRecord(fresh); err != nil {
+				return err
+			}
+
+			record.Load(fresh.ColumnValueMap())
+
+			return nil
+		})
+	}, interceptors...)
+}
@@ -0,0 +1,124 @@
+package forms_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestRecordIncrementResultData(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	record, err := app.Dao().FindRecordById("demo1", "84nmscqy84lsi1t")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	form := forms.NewRecordIncrement(app, record)
+	if err := json.Unmarshal([]byte(`{"fields":{"number":{"amount":10,"max":123460},"text":{"amount":1}}}`), form); err != nil {
+		t.Fatal(err)
+	}
+
+	raw, _ := json.Marshal(form.ResultData())
+	if expected := `{"number":123460}`; string(raw) != expected {
+		t.Fatalf("Expected %s, got %s", expected, raw)
+	}
+}
+
+func TestRecordIncrementValidateAndSubmit(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		data           string
+		expectedErrors []string
+		expectedNumber float64
+	}{
+		{"empty", `{}`, []string{"fields"}, 123456},
+		{
+			"invalid fields",
+			`{"fields":{"missing":{"amount":1},"text":{"amount":1},"number":{"amount":0}}}`,
+			[]string{"fields"},
+			123456,
+		},
+		{"min greater than max", `{"fields":{"number":{"amount":1,"min":10,"max":5}}}`, []string{"fields"}, 123456},
+		{"increment", `{"fields":{"number":{"amount":4}}}`, []string{}, 123460},
+		{"decrement", `{"fields":{"number":{"amount":-0.5}}}`, []string{}, 123455.5},
+		{"clamped to max", `{"fields":{"number":{"amount":100,"max":123500}}}`, []string{}, 123500},
+		{"clamped to min", `{"fields":{"number":{"amount":-200000,"min":0}}}`, []string{}, 0},
+	}
+
+	for _, s := range scenarios {
+		func() {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			record, err := app.Dao().FindRecordById("demo1", "84nmscqy84lsi1t")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			originalUpdated := record.Updated
+
+			form := forms.NewRecordIncrement(app, record)
+			if err := json.Unmarshal([]byte(s.data), form); err != nil {
+				t.Fatalf("[%s] Failed to load form data: %v", s.name, err)
+			}
+
+			interceptorCalls := 0
+			interceptor := func(next forms.InterceptorNextFunc[*models.Record]) forms.InterceptorNextFunc[*models.Record] {
+				return func(r *models.Record) error {
+					interceptorCalls++
+					return next(r)
+				}
+			}
+
+			result := form.Submit(interceptor)
+
+			// parse errors
+			errs, ok := result.(validation.Errors)
+			if !ok && result != nil {
+				t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+				return
+			}
+
+			// check errors
+			if len(errs) > len(s.expectedErrors) {
+				t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+			}
+			for _, k := range s.expectedErrors {
+				if _, ok := errs[k]; !ok {
+					t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+				}
+			}
+
+			expectedInterceptorCalls := 1
+			if len(s.expectedErrors) > 0 {
+				expectedInterceptorCalls = 0
+			}
+			if interceptorCalls != expectedInterceptorCalls {
+				t.Errorf("[%s] Expected interceptor to be called %d times, got %d", s.name, expectedInterceptorCalls, interceptorCalls)
+			}
+
+			if v := record.GetFloat("number"); v != s.expectedNumber {
+				t.Errorf("[%s] Expected the form record number %v, got %v", s.name, s.expectedNumber, v)
+			}
+
+			persisted, err := app.Dao().FindRecordById("demo1", "84nmscqy84lsi1t")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if v := persisted.GetFloat("number"); v != s.expectedNumber {
+				t.Errorf("[%s] Expected the persisted number %v, got %v", s.name, s.expectedNumber, v)
+			}
+
+			if len(s.expectedErrors) == 0 && !persisted.Updated.Time().After(originalUpdated.Time()) {
+				t.Errorf("[%s] Expected the updated date to be refreshed", s.name)
+			}
+		}()
+	}
+}
@@ -0,0 +1,100 @@
+package forms
+
+import (
+	"errors"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// RecordLockAcquire is a record edit lease acquire (or renew) form.
+type RecordLockAcquire struct {
+	app        core.App
+	dao        *daos.Dao
+	record     *models.Record
+	admin      *models.Admin
+	authRecord *models.Record
+
+	// Duration is the lease duration in seconds
+	// (if not set, [models.RecordLockDefaultDuration] is used).
+	Duration int `form:"duration" json:"duration"`
+}
+
+// NewRecordLockAcquire creates a new [RecordLockAcquire] form for
+// locking the provided record by the specified admin or auth record.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewRecordLockAcquire(app core.App, record *models.Record, admin *models.Admin, authRecord *models.Record) *RecordLockAcquire {
+	return &RecordLockAcquire{
+		app:        app,
+		dao:        app.Dao(),
+		record:     record,
+		admin:      admin,
+		authRecord: authRecord,
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *RecordLockAcquire) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *RecordLockAcquire) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(
+			&form.Duration,
+			validation.Min(0),
+			validation.Max(models.RecordLockMaxDuration),
+		),
+	)
+}
+
+// Submit validates the form and acquires the record lock
+// (or renews it if it is already held by the same holder).
+//
+// Returns an error if the record is locked by another holder
+// and its lease hasn't expired yet.
+//
+// You can optionally provide a list of InterceptorFunc to further
+// modify the form behavior before persisting it.
+func (form *RecordLockAcquire) Submit(interceptors ...InterceptorFunc[*models.RecordLock]) error {
+	if err := form.Validate(); err != nil {
+		return err
+	}
+
+	if form.admin == nil && form.authRecord == nil {
+		return errors.New("missing record lock holder")
+	}
+
+	lock, err := form.dao.FindRecordLock(form.record)
+	if err != nil {
+		lock = &models.RecordLock{
+			CollectionId: form.record.Collection().Id,
+			RecordId:     form.record.Id,
+		}
+	} else if !lock.IsExpired() && !lock.IsHeldBy(form.admin, form.authRecord) {
+		return errors.New("The record is locked by another client.")
+	}
+
+	duration := form.Duration
+	if duration == 0 {
+		duration = models.RecordLockDefaultDuration
+	}
+
+	lock.SetHolder(form.admin, form.authRecord)
+
+	lock.Expires, err = types.ParseDateTime(time.Now().Add(time.Duration(duration) * time.Second))
+	if err != nil {
+		return err
+	}
+
+	return runInterceptors(lock, func(l *models.RecordLock) error {
+		return form.dao.SaveRecordLock(l)
+	}, interceptors...)
+}
@@ -0,0 +1,131 @@
+package forms_test
+
+import (
+	"testing"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestRecordLockAcquireValidate(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		duration    int
+		expectError bool
+	}{
+		{-1, true},
+		{0, false},
+		{models.RecordLockMaxDuration, false},
+		{models.RecordLockMaxDuration + 1, true},
+	}
+
+	for _, s := range scenarios {
+		form := forms.NewRecordLockAcquire(app, nil, nil, nil)
+		form.Duration = s.duration
+
+		err := form.Validate()
+
+		errs, _ := err.(validation.Errors)
+		if _, ok := errs["duration"]; ok != s.expectError {
+			t.Errorf("(%d) Expected duration error %v, got %v", s.duration, s.expectError, err)
+		}
+	}
+}
+
+func TestRecordLockAcquireSubmit(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	record, err := app.Dao().FindRecordById("demo2", "achvryl401bhse3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	holder, err := app.Dao().FindRecordById("users", "4q1xlclmfloku33")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	other, err := app.Dao().FindRecordById("users", "oap640cot4yru2s")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	admin, err := app.Dao().FindAdminByEmail("test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// missing holder
+	if err := forms.NewRecordLockAcquire(app, record, nil, nil).Submit(); err == nil {
+		t.Fatal("Expected missing holder error, got nil")
+	}
+
+	// acquire
+	form := forms.NewRecordLockAcquire(app, record, nil, holder)
+	form.Duration = 10
+
+	interceptorCalls := 0
+	interceptor := func(next forms.InterceptorNextFunc[*models.RecordLock]) forms.InterceptorNextFunc[*models.RecordLock] {
+		return func(lock *models.RecordLock) error {
+			interceptorCalls++
+			return next(lock)
+		}
+	}
+
+	if err := form.Submit(interceptor); err != nil {
+		t.Fatalf("Failed to acquire the lock: %v", err)
+	}
+
+	if interceptorCalls != 1 {
+		t.Fatalf("Expected interceptor to be called once, got %d", interceptorCalls)
+	}
+
+	lock, err := app.Dao().FindRecordLock(record)
+	if err != nil || !lock.IsHeldBy(nil, holder) || lock.IsExpired() {
+		t.Fatalf("Expected active lock held by %q, got %v (%v)", holder.Id, lock, err)
+	}
+
+	if d := time.Until(lock.Expires.Time()); d <= 0 || d > 10*time.Second {
+		t.Fatalf("Expected the lock to expire in 10s, got %v", d)
+	}
+
+	// conflict
+	if err := forms.NewRecordLockAcquire(app, record, nil, other).Submit(); err == nil {
+		t.Fatal("Expected locked record error, got nil")
+	}
+	if err := forms.NewRecordLockAcquire(app, record, admin, nil).Submit(); err == nil {
+		t.Fatal("Expected locked record error for admin, got nil")
+	}
+
+	// renew with the default duration
+	if err := forms.NewRecordLockAcquire(app, record, nil, holder).Submit(); err != nil {
+		t.Fatalf("Failed to renew the lock: %v", err)
+	}
+
+	renewed, err := app.Dao().FindRecordLock(record)
+	if err != nil || renewed.Id != lock.Id || !renewed.Expires.Time().After(lock.Expires.Time()) {
+		t.Fatalf("Expected the lock %q to be renewed, got %v (%v)", lock.Id, renewed, err)
+	}
+
+	// take over an expired lock
+	renewed.Expires, _ = types.ParseDateTime(time.Now().Add(-time.Second))
+	if err := app.Dao().SaveRecordLock(renewed); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := forms.NewRecordLockAcquire(app, record, nil, other).Submit(); err != nil {
+		t.Fatalf("Failed to take over the expired lock: %v", err)
+	}
+
+	takenOver, err := app.Dao().FindRecordLock(record)
+	if err != nil || takenOver.Id != lock.Id || !takenOver.IsHeldBy(nil, other) || takenOver.IsExpired() {
+		t.Fatalf("Expected the lock to be held by %q, got %v (%v)", other.Id, takenOver, err)
+	}
+}
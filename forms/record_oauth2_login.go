@@ -11,6 +11,7 @@ import (
 	"github.com/pocketbase/pocketbase/core"
 	"github.com/pocketbase/pocketbase/daos"
 	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/settings"
 	"github.com/pocketbase/pocketbase/tools/auth"
 	"github.com/pocketbase/pocketbase/tools/security"
 	"golang.org/x/oauth2"
@@ -130,10 +131,16 @@ func (form *RecordOAuth2Login) Submit(
 		return nil, nil, err
 	}
 
+	httpClient, err := form.app.NewHttpClient(settings.EgressSubsystemOAuth2)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	provider.SetContext(ctx)
+	// the oauth2 package sends its requests with the context http client
+	provider.SetContext(context.WithValue(ctx, oauth2.HTTPClient, httpClient))
 
 	// load provider configuration
 	providerConfig := form.app.Settings().NamedAuthProviderConfigs()[form.Provider]
@@ -141,6 +148,9 @@ func (form *RecordOAuth2Login) Submit(
 		return nil, nil, err
 	}
 
+	// the client secret could be an external secret reference
+	provider.SetClientSecret(form.app.Secrets().Resolve(providerConfig.ClientSecret))
+
 	provider.SetRedirectUrl(form.RedirectUrl)
 
 	// fetch token
@@ -0,0 +1,132 @@
+package forms
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/list"
+)
+
+// RecordShareUpsert is a record ACL entry upsert (create/update) form.
+//
+// If the record is already shared with the submitted subject,
+// the existing share permission is updated.
+type RecordShareUpsert struct {
+	app    core.App
+	dao    *daos.Dao
+	record *models.Record
+
+	SubjectType string `form:"subjectType" json:"subjectType"`
+
+	// SubjectCollection is the name or id of the subject record collection.
+	SubjectCollection string `form:"subjectCollection" json:"subjectCollection"`
+	SubjectId         string `form:"subjectId" json:"subjectId"`
+	Permission        string `form:"permission" json:"permission"`
+}
+
+// NewRecordShareUpsert creates a new [RecordShareUpsert] form
+// for sharing the provided record.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewRecordShareUpsert(app core.App, record *models.Record) *RecordShareUpsert {
+	return &RecordShareUpsert{
+		app:    app,
+		dao:    app.Dao(),
+		record: record,
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *RecordShareUpsert) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *RecordShareUpsert) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(
+			&form.SubjectType,
+			validation.Required,
+			validation.In(models.RecordShareSubjectUser, models.RecordShareSubjectGroup),
+		),
+		validation.Field(
+			&form.SubjectCollection,
+			validation.Required,
+			validation.By(form.checkSubjectCollection),
+		),
+		validation.Field(
+			&form.SubjectId,
+			validation.Required,
+			validation.By(form.checkSubjectId),
+		),
+		validation.Field(
+			&form.Permission,
+			validation.Required,
+			validation.In(list.ToInterfaceSlice(models.RecordSharePermissions())...),
+		),
+	)
+}
+
+func (form *RecordShareUpsert) checkSubjectCollection(value any) error {
+	v, _ := value.(string)
+
+	collection, err := form.dao.FindCollectionByNameOrId(v)
+	if err != nil || collection.IsView() {
+		return validation.NewError("validation_invalid_subject_collection", "Missing or invalid subject collection.")
+	}
+
+	if form.SubjectType == models.RecordShareSubjectUser && !collection.IsAuth() {
+		return validation.NewError("validation_invalid_subject_collection", "The user subject collection must be an auth collection.")
+	}
+
+	return nil
+}
+
+func (form *RecordShareUpsert) checkSubjectId(value any) error {
+	v, _ := value.(string)
+
+	if _, err := form.dao.FindRecordById(form.SubjectCollection, v); err != nil {
+		return validation.NewError("validation_invalid_subject_id", "Missing or invalid subject record.")
+	}
+
+	return nil
+}
+
+// Submit validates the form and upserts the record share.
+//
+// You can optionally provide a list of InterceptorFunc to further
+// modify the form behavior before persisting it.
+func (form *RecordShareUpsert) Submit(interceptors ...InterceptorFunc[*models.RecordShare]) error {
+	if err := form.Validate(); err != nil {
+		return err
+	}
+
+	subjectCollection, err := form.dao.FindCollectionByNameOrId(form.SubjectCollection)
+	if err != nil {
+		return err
+	}
+
+	share, err := form.dao.FindRecordShareBySubject(
+		form.record,
+		form.SubjectType,
+		subjectCollection.Id,
+		form.SubjectId,
+	)
+	if err != nil {
+		share = &models.RecordShare{
+			CollectionId:        form.record.Collection().Id,
+			RecordId:            form.record.Id,
+			SubjectType:         form.SubjectType,
+			SubjectCollectionId: subjectCollection.Id,
+			SubjectId:           form.SubjectId,
+		}
+	}
+
+	share.Permission = form.Permission
+
+	return runInterceptors(share, func(s *models.RecordShare) error {
+		return form.dao.SaveRecordShare(s)
+	}, interceptors...)
+}
@@ -0,0 +1,171 @@
+package forms_test
+
+import (
+	"errors"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestRecordShareUpsertValidateAndSubmit(t *testing.T) {
+	scenarios := []struct {
+		name              string
+		subjectType       string
+		subjectCollection string
+		subjectId         string
+		permission        string
+		expectedErrors    []string
+	}{
+		{"empty", "", "", "", "", []string{"subjectType", "subjectCollection", "subjectId", "permission"}},
+		{"invalid data", "unknown", "missing", "missing", "unknown", []string{"subjectType", "subjectCollection", "subjectId", "permission"}},
+		{"user with non auth collection", "user", "demo2", "llvuca81nly1qls", "view", []string{"subjectCollection"}},
+		{"group with view collection", "group", "view2", "84nmscqy84lsi1t", "view", []string{"subjectCollection"}},
+		{"missing subject record", "user", "users", "oap640cot4yru2s_", "view", []string{"subjectId"}},
+		{"valid user share", "user", "users", "oap640cot4yru2s", "view", []string{}},
+		{"valid user share (collection id)", "user", "_pb_users_auth_", "oap640cot4yru2s", "edit", []string{}},
+		{"valid group share", "group", "demo2", "llvuca81nly1qls", "owner", []string{}},
+	}
+
+	for _, s := range scenarios {
+		func() {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			record, err := app.Dao().FindRecordById("demo1", "84nmscqy84lsi1t")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			form := forms.NewRecordShareUpsert(app, record)
+			form.SubjectType = s.subjectType
+			form.SubjectCollection = s.subjectCollection
+			form.SubjectId = s.subjectId
+			form.Permission = s.permission
+
+			interceptorCalls := 0
+			interceptor := func(next forms.InterceptorNextFunc[*models.RecordShare]) forms.InterceptorNextFunc[*models.RecordShare] {
+				return func(share *models.RecordShare) error {
+					interceptorCalls++
+					return next(share)
+				}
+			}
+
+			result := form.Submit(interceptor)
+
+			// parse errors
+			errs, ok := result.(validation.Errors)
+			if !ok && result != nil {
+				t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+				return
+			}
+
+			// check errors
+			if len(errs) > len(s.expectedErrors) {
+				t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+			}
+			for _, k := range s.expectedErrors {
+				if _, ok := errs[k]; !ok {
+					t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+				}
+			}
+
+			if len(s.expectedErrors) > 0 {
+				if interceptorCalls != 0 {
+					t.Errorf("[%s] Expected interceptor to not be called", s.name)
+				}
+				return
+			}
+
+			if interceptorCalls != 1 {
+				t.Errorf("[%s] Expected interceptor to be called once, got %d", s.name, interceptorCalls)
+			}
+
+			shares, err := app.Dao().FindRecordShares(record)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(shares) != 1 {
+				t.Fatalf("[%s] Expected 1 record share, got %d", s.name, len(shares))
+			}
+
+			if shares[0].SubjectId != s.subjectId || shares[0].Permission != s.permission || shares[0].SubjectType != s.subjectType {
+				t.Errorf("[%s] The record share doesn't match with the submitted data: %v", s.name, shares[0])
+			}
+		}()
+	}
+}
+
+func TestRecordShareUpsertSubmitExisting(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	record, err := app.Dao().FindRecordById("demo1", "84nmscqy84lsi1t")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, permission := range []string{"view", "edit"} {
+		form := forms.NewRecordShareUpsert(app, record)
+		form.SubjectType = "user"
+		form.SubjectCollection = "users"
+		form.SubjectId = "oap640cot4yru2s"
+		form.Permission = permission
+		if err := form.Submit(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	shares, err := app.Dao().FindRecordShares(record)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(shares) != 1 {
+		t.Fatalf("Expected the existing share to be updated, got %d shares", len(shares))
+	}
+
+	if shares[0].Permission != "edit" {
+		t.Fatalf("Expected the share permission to be updated to edit, got %q", shares[0].Permission)
+	}
+}
+
+func TestRecordShareUpsertSubmitInterceptorError(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	record, err := app.Dao().FindRecordById("demo1", "84nmscqy84lsi1t")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	form := forms.NewRecordShareUpsert(app, record)
+	form.SubjectType = "user"
+	form.SubjectCollection = "users"
+	form.SubjectId = "oap640cot4yru2s"
+	form.Permission = "view"
+
+	testErr := errors.New("test_error")
+
+	interceptor := func(next forms.InterceptorNextFunc[*models.RecordShare]) forms.InterceptorNextFunc[*models.RecordShare] {
+		return func(share *models.RecordShare) error {
+			return testErr
+		}
+	}
+
+	if err := form.Submit(interceptor); err != testErr {
+		t.Fatalf("Expected %v, got %v", testErr, err)
+	}
+
+	shares, err := app.Dao().FindRecordShares(record)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(shares) != 0 {
+		t.Fatalf("Expected no record shares, got %d", len(shares))
+	}
+}
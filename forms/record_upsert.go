@@ -756,7 +756,7 @@ func (form *RecordUpsert) Submit(interceptors ...InterceptorFunc[*models.Record]
 			}
 
 			if m.TableName() == form.record.TableName() && m.GetId() == form.record.GetId() {
-				return form.processFilesToUpload()
+				return form.processFilesToUpload(eventDao)
 			}
 
 			return nil
@@ -770,7 +770,7 @@ func (form *RecordUpsert) Submit(interceptors ...InterceptorFunc[*models.Record]
 			}
 
 			if m.TableName() == form.record.TableName() && m.GetId() == form.record.GetId() {
-				return form.processFilesToUpload()
+				return form.processFilesToUpload(eventDao)
 			}
 
 			return nil
@@ -794,7 +794,7 @@ func (form *RecordUpsert) Submit(interceptors ...InterceptorFunc[*models.Record]
 	}, interceptors...)
 }
 
-func (form *RecordUpsert) processFilesToUpload() error {
+func (form *RecordUpsert) processFilesToUpload(dao *daos.Dao) error {
 	if len(form.filesToUpload) == 0 {
 		return nil // no parsed file fields
 	}
@@ -809,13 +809,23 @@ func (form *RecordUpsert) processFilesToUpload() error {
 	}
 	defer fs.Close()
 
+	dedup := form.app.Settings().FileDedup.Enabled
+
 	var uploadErrors []error // list of upload errors
 	var uploaded []string    // list of uploaded file paths
 
 	for fieldKey := range form.filesToUpload {
 		for i, file := range form.filesToUpload[fieldKey] {
 			path := form.record.BaseFilesPath() + "/" + file.Name
-			if err := fs.UploadFile(file, path); err == nil {
+
+			var err error
+			if dedup {
+				err = dao.UploadDedupFile(fs, file, path)
+			} else {
+				err = fs.UploadFile(file, path)
+			}
+
+			if err == nil {
 				// keep track of the already uploaded file
 				uploaded = append(uploaded, path)
 			} else {
@@ -863,7 +873,7 @@ func (form *RecordUpsert) deleteFilesByNamesList(filenames []string) ([]string,
 		filename := filenames[i]
 		path := form.record.BaseFilesPath() + "/" + filename
 
-		if err := fs.Delete(path); err == nil {
+		if err := form.dao.DeleteFile(fs, path); err == nil {
 			// remove the deleted file from the list
 			filenames = append(filenames[:i], filenames[i+1:]...)
 
@@ -1039,6 +1039,65 @@ func TestRecordUpsertAddAndRemoveFiles(t *testing.T) {
 	}
 }
 
+func TestRecordUpsertFileDedup(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	app.Settings().FileDedup.Enabled = true
+
+	records := make([]*models.Record, 2)
+	for i, id := range []string{"84nmscqy84lsi1t", "al1h9ijdeojtsjy"} {
+		record, err := app.Dao().FindRecordById("demo1", id)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		file, _ := filesystem.NewFileFromBytes([]byte("dedup_test"), "test.txt")
+
+		form := forms.NewRecordUpsert(app, record)
+		form.AddFiles("file_one", file)
+		if err := form.Submit(); err != nil {
+			t.Fatalf("[%s] Failed to submit the form: %v", id, err)
+		}
+
+		records[i] = record
+	}
+
+	key1 := records[0].BaseFilesPath() + "/" + records[0].GetString("file_one")
+	key2 := records[1].BaseFilesPath() + "/" + records[1].GetString("file_one")
+
+	objectKey := app.Dao().ResolveFileKey(key1)
+	if objectKey == key1 || objectKey != app.Dao().ResolveFileKey(key2) {
+		t.Fatalf("Expected both record files to reference the same file object, got %q and %q", objectKey, app.Dao().ResolveFileKey(key2))
+	}
+
+	if hasRecordFile(app, records[0], records[0].GetString("file_one")) {
+		t.Fatal("Expected the deduplicated file to not be stored under the record path")
+	}
+
+	// replace the first record file
+	replacement, _ := filesystem.NewFileFromBytes([]byte("replacement"), "test.txt")
+	form := forms.NewRecordUpsert(app, records[0])
+	form.AddFiles("file_one", replacement)
+	if err := form.Submit(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindFileRefByKey(key1); err == nil {
+		t.Fatal("Expected the replaced file reference to be deleted")
+	}
+
+	fs, err := app.NewFilesystem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fs.Close()
+
+	if exists, _ := fs.Exists(objectKey); !exists {
+		t.Fatal("Expected the still referenced file object to be kept")
+	}
+}
+
 func TestRecordUpsertUploadFailure(t *testing.T) {
 	app, _ := tests.NewTestApp()
 	defer app.Cleanup()
@@ -0,0 +1,126 @@
+package forms
+
+import (
+	"strconv"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+)
+
+// RecordsImportMaxRows is the max number of rows of a single records import.
+const RecordsImportMaxRows = 1000
+
+// RecordsImport is a form model to bulk import (create or update)
+// collection records with optional field transformation rules.
+type RecordsImport struct {
+	app        core.App
+	dao        *daos.Dao
+	collection *models.Collection
+
+	// Rows is the list of the imported records data.
+	//
+	// Rows with "id" of an existing record update it, the others create a new record.
+	Rows []map[string]any `form:"rows" json:"rows"`
+
+	// Transforms is the list of the transformation steps applied on each row.
+	Transforms []*ImportTransform `form:"transforms" json:"transforms"`
+}
+
+// NewRecordsImport creates a new [RecordsImport] form for
+// importing records in the provided collection.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewRecordsImport(app core.App, collection *models.Collection) *RecordsImport {
+	return &RecordsImport{
+		app:        app,
+		dao:        app.Dao(),
+		collection: collection,
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *RecordsImport) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *RecordsImport) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(&form.Rows, validation.Required, validation.Length(1, RecordsImportMaxRows)),
+		validation.Field(&form.Transforms),
+	)
+}
+
+// Submit validates the form, applies the transformations on each row
+// and creates (or updates) the collection records.
+//
+// All rows are imported in a single transaction that is rollbacked
+// on the first failed row (the error is returned under the "rows.{index}" key).
+//
+// You can optionally provide a list of InterceptorFunc to further
+// modify the form behavior before persisting it.
+func (form *RecordsImport) Submit(interceptors ...InterceptorFunc[[]*models.Record]) error {
+	if err := form.Validate(); err != nil {
+		return err
+	}
+
+	if form.collection.IsView() {
+		return validation.Errors{"rows": validation.NewError(
+			"validation_view_collection",
+			"View collection records cannot be imported.",
+		)}
+	}
+
+	// note: preallocated so that the interceptors could access the imported records
+	records := make([]*models.Record, len(form.Rows))
+
+	return runInterceptors(records, func(records []*models.Record) error {
+		return form.dao.RunInTransaction(func(txDao *daos.Dao) error {
+			for i, row := range form.Rows {
+				record, err := form.importRow(txDao, row)
+				if err != nil {
+					return validation.Errors{"rows": validation.Errors{strconv.Itoa(i): err}}
+				}
+
+				records[i] = record
+			}
+
+			return nil
+		})
+	}, interceptors...)
+}
+
+func (form *RecordsImport) importRow(txDao *daos.Dao, row map[string]any) (*models.Record, error) {
+	if row == nil {
+		row = map[string]any{}
+	}
+
+	if err := ApplyImportTransforms(txDao, form.Transforms, row); err != nil {
+		return nil, err
+	}
+
+	record := models.NewRecord(form.collection)
+	if id, _ := row[schema.FieldNameId].(string); id != "" {
+		if existing, err := txDao.FindRecordById(form.collection.Id, id); err == nil {
+			record = existing
+		}
+	}
+
+	upsert := NewRecordUpsert(form.app, record)
+	upsert.SetDao(txDao)
+	upsert.SetFullManageAccess(true)
+
+	if err := upsert.LoadData(row); err != nil {
+		return nil, err
+	}
+
+	if err := upsert.Submit(); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
@@ -0,0 +1,144 @@
+package forms_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestRecordsImportValidateAndSubmit(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		collection     string
+		data           string
+		expectedErrors []string
+		expectedTotal  int
+	}{
+		{"empty", "demo2", `{}`, []string{"rows"}, 3},
+		{
+			"invalid transforms",
+			"demo2",
+			`{"rows":[{"title":"abc"}],"transforms":[{"field":"title","type":"unknown"}]}`,
+			[]string{"transforms"},
+			3,
+		},
+		{"view collection", "view1", `{"rows":[{"title":"abc"}]}`, []string{"rows"}, 3},
+		{
+			"failed row (should rollback the previous rows)",
+			"demo2",
+			`{"rows":[{"title":"new1"},{"title":"test1"}]}`,
+			[]string{"rows"},
+			3,
+		},
+		{
+			"failed transform",
+			"demo2",
+			`{"rows":[{"title":"new1","active":"x"}],"transforms":[{"field":"active","type":"lookup","collection":"users","lookupField":"email"}]}`,
+			[]string{"rows"},
+			3,
+		},
+		{
+			"create and update with transforms",
+			"demo2",
+			`{
+				"rows":[
+					{"title":"  New1  "},
+					{"id":"achvryl401bhse3","title":" Updated "},
+					{"title":"new2","active":false}
+				],
+				"transforms":[
+					{"field":"title","type":"trim"},
+					{"field":"title","type":"lowercase"},
+					{"field":"active","type":"default","value":true}
+				]
+			}`,
+			[]string{},
+			5,
+		},
+	}
+
+	for _, s := range scenarios {
+		func() {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			collection, err := app.Dao().FindCollectionByNameOrId(s.collection)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			form := forms.NewRecordsImport(app, collection)
+			if err := json.Unmarshal([]byte(s.data), form); err != nil {
+				t.Fatalf("[%s] Failed to load form data: %v", s.name, err)
+			}
+
+			var imported []*models.Record
+			interceptorCalls := 0
+			interceptor := func(next forms.InterceptorNextFunc[[]*models.Record]) forms.InterceptorNextFunc[[]*models.Record] {
+				return func(records []*models.Record) error {
+					interceptorCalls++
+					err := next(records)
+					imported = records
+					return err
+				}
+			}
+
+			result := form.Submit(interceptor)
+
+			errs, ok := result.(validation.Errors)
+			if !ok && result != nil {
+				t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+				return
+			}
+
+			if len(errs) > len(s.expectedErrors) {
+				t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+			}
+			for _, k := range s.expectedErrors {
+				if _, ok := errs[k]; !ok {
+					t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+				}
+			}
+
+			if collection.IsView() {
+				return
+			}
+
+			records, err := app.Dao().FindRecordsByExpr(collection.Id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(records) != s.expectedTotal {
+				t.Errorf("[%s] Expected %d records, got %d", s.name, s.expectedTotal, len(records))
+			}
+
+			if len(s.expectedErrors) > 0 {
+				return
+			}
+
+			if interceptorCalls != 1 {
+				t.Errorf("[%s] Expected interceptor to be called once, got %d", s.name, interceptorCalls)
+			}
+
+			if len(imported) != 3 {
+				t.Fatalf("[%s] Expected 3 imported records, got %d", s.name, len(imported))
+			}
+
+			if v := imported[0].GetString("title"); v != "new1" || !imported[0].GetBool("active") {
+				t.Errorf("[%s] Expected the first record to be transformed, got %q %v", s.name, v, imported[0].GetBool("active"))
+			}
+
+			if imported[1].Id != "achvryl401bhse3" || imported[1].GetString("title") != "updated" {
+				t.Errorf("[%s] Expected the existing record to be updated, got %s %q", s.name, imported[1].Id, imported[1].GetString("title"))
+			}
+
+			if imported[2].GetBool("active") {
+				t.Errorf("[%s] Expected the explicit active value to be preserved", s.name)
+			}
+		}()
+	}
+}
@@ -0,0 +1,286 @@
+package forms
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/filesystem"
+	"github.com/pocketbase/pocketbase/tools/list"
+)
+
+// List with the supported S3 event ingest actions.
+const (
+	S3EventActionCreated string = "created"
+	S3EventActionRemoved string = "removed"
+	S3EventActionSkipped string = "skipped"
+)
+
+// S3EventRecord defines a single S3 event notification record
+// (only the fields that are relevant for the ingestion).
+//
+// https://docs.aws.amazon.com/AmazonS3/latest/userguide/notification-content-structure.html
+type S3EventRecord struct {
+	EventName string `json:"eventName"`
+	S3        struct {
+		Object struct {
+			Key string `json:"key"`
+		} `json:"object"`
+	} `json:"s3"`
+}
+
+// S3EventIngestResult defines the ingestion result of a single S3 event record.
+type S3EventIngestResult struct {
+	Key      string `json:"key"`
+	Action   string `json:"action"`
+	RecordId string `json:"recordId,omitempty"`
+	Field    string `json:"field,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// S3EventsIngest is a form that registers objects uploaded directly
+// to the app storage bucket as record file values (and unregisters the removed ones).
+//
+// The object keys are expected to follow the app storage
+// structure, aka. "{collectionId}/{recordId}/{filename}".
+type S3EventsIngest struct {
+	app core.App
+	ctx context.Context
+
+	Records []S3EventRecord `form:"Records" json:"Records"`
+
+	// Field is an optional file field name where the created objects will be registered.
+	//
+	// If not set, the collection must have exactly one file field.
+	Field string `form:"field" json:"field"`
+}
+
+// NewS3EventsIngest creates a new S3EventsIngest form.
+func NewS3EventsIngest(app core.App) *S3EventsIngest {
+	return &S3EventsIngest{
+		app: app,
+		ctx: context.Background(),
+	}
+}
+
+// SetContext replaces the default form context with the provided one.
+func (form *S3EventsIngest) SetContext(ctx context.Context) {
+	form.ctx = ctx
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *S3EventsIngest) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(&form.Records, validation.Length(0, 1000)),
+		validation.Field(&form.Field, validation.Length(0, 255)),
+	)
+}
+
+// Submit validates the form and processes the S3 event records.
+//
+// Events that couldn't be matched to an existing record are
+// reported as skipped and don't interrupt the submit.
+//
+// You can optionally provide a list of InterceptorFunc to further
+// modify the form behavior before returning the ingestion results.
+func (form *S3EventsIngest) Submit(interceptors ...InterceptorFunc[[]*S3EventIngestResult]) error {
+	if err := form.Validate(); err != nil {
+		return err
+	}
+
+	fsys, err := form.app.NewFilesystem()
+	if err != nil {
+		return err
+	}
+	defer fsys.Close()
+
+	fsys.SetContext(form.ctx)
+
+	results := make([]*S3EventIngestResult, 0, len(form.Records))
+
+	for _, r := range form.Records {
+		results = append(results, form.ingest(fsys, r))
+	}
+
+	return runInterceptors(results, func(results []*S3EventIngestResult) error {
+		return nil
+	}, interceptors...)
+}
+
+func (form *S3EventsIngest) ingest(fsys *filesystem.System, r S3EventRecord) *S3EventIngestResult {
+	// the event object keys are url encoded
+	key, err := url.QueryUnescape(r.S3.Object.Key)
+	if err != nil {
+		key = r.S3.Object.Key
+	}
+
+	result := &S3EventIngestResult{Key: key, Action: S3EventActionSkipped}
+
+	isCreate := strings.Contains(r.EventName, "ObjectCreated:")
+	isRemove := strings.Contains(r.EventName, "ObjectRemoved:")
+	if !isCreate && !isRemove {
+		result.Reason = "Unsupported event type."
+		return result
+	}
+
+	// thumbs and other nested files have more than 3 segments
+	parts := strings.Split(key, "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		result.Reason = "Unsupported object key format."
+		return result
+	}
+
+	collection, err := form.app.Dao().FindCollectionByNameOrId(parts[0])
+	if err != nil || collection.Id != parts[0] || collection.IsView() {
+		result.Reason = "Missing or invalid collection."
+		return result
+	}
+
+	record, err := form.app.Dao().FindRecordById(collection.Id, parts[1])
+	if err != nil {
+		result.Reason = "Missing record."
+		return result
+	}
+
+	result.RecordId = record.Id
+
+	// check the actual object state in case of a delayed or spoofed event
+	exists, err := fsys.Exists(key)
+	if err != nil {
+		result.Reason = "Failed to check the object state."
+		return result
+	}
+
+	filename := parts[2]
+
+	if isCreate {
+		if !exists {
+			result.Reason = "The object doesn't exist."
+			return result
+		}
+
+		return form.register(fsys, record, filename, result)
+	}
+
+	if exists {
+		result.Reason = "The object still exists."
+		return result
+	}
+
+	return form.unregister(record, filename, result)
+}
+
+func (form *S3EventsIngest) register(
+	fsys *filesystem.System,
+	record *models.Record,
+	filename string,
+	result *S3EventIngestResult,
+) *S3EventIngestResult {
+	fileFields := form.fileFields(record.Collection())
+
+	for _, f := range fileFields {
+		if list.ExistInSlice(filename, record.GetStringSlice(f.Name)) {
+			result.Field = f.Name
+			result.Reason = "The file is already registered."
+			return result
+		}
+	}
+
+	var field *schema.SchemaField
+	if form.Field != "" {
+		for _, f := range fileFields {
+			if f.Name == form.Field {
+				field = f
+				break
+			}
+		}
+	} else if len(fileFields) == 1 {
+		field = fileFields[0]
+	}
+
+	if field == nil {
+		result.Reason = "Missing or ambiguous file field."
+		return result
+	}
+
+	result.Field = field.Name
+
+	options, _ := field.Options.(*schema.FileOptions)
+
+	var oldFilename string
+
+	if options != nil && options.MaxSelect > 1 {
+		current := record.GetStringSlice(field.Name)
+		if len(current) >= options.MaxSelect {
+			result.Reason = "The file field max select limit is reached."
+			return result
+		}
+		record.Set(field.Name, append(current, filename))
+	} else {
+		oldFilename = record.GetString(field.Name)
+		record.Set(field.Name, filename)
+	}
+
+	if err := form.app.Dao().SaveRecord(record); err != nil {
+		result.Reason = "Failed to save the record."
+		return result
+	}
+
+	// the replaced single file is no longer referenced by the record
+	if oldFilename != "" {
+		if err := form.app.Dao().DeleteFile(fsys, record.BaseFilesPath()+"/"+oldFilename); err == nil {
+			fsys.DeletePrefix(record.BaseFilesPath() + "/thumbs_" + oldFilename + "/")
+		}
+	}
+
+	result.Action = S3EventActionCreated
+	result.Reason = ""
+
+	return result
+}
+
+func (form *S3EventsIngest) unregister(
+	record *models.Record,
+	filename string,
+	result *S3EventIngestResult,
+) *S3EventIngestResult {
+	for _, f := range form.fileFields(record.Collection()) {
+		current := record.GetStringSlice(f.Name)
+		if !list.ExistInSlice(filename, current) {
+			continue
+		}
+
+		result.Field = f.Name
+
+		record.Set(f.Name, list.SubtractSlice(current, []string{filename}))
+
+		if err := form.app.Dao().SaveRecord(record); err != nil {
+			result.Reason = "Failed to save the record."
+			return result
+		}
+
+		result.Action = S3EventActionRemoved
+
+		return result
+	}
+
+	result.Reason = "The file is not registered."
+
+	return result
+}
+
+func (form *S3EventsIngest) fileFields(collection *models.Collection) []*schema.SchemaField {
+	result := []*schema.SchemaField{}
+
+	for _, f := range collection.Schema.Fields() {
+		if f.Type == schema.FieldTypeFile {
+			result = append(result, f)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,252 @@
+package forms_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestS3EventsIngestValidate(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	form := forms.NewS3EventsIngest(app)
+	form.Records = make([]forms.S3EventRecord, 1001)
+
+	errs, ok := form.Validate().(validation.Errors)
+	if !ok {
+		t.Fatalf("Failed to parse errors %v", errs)
+	}
+
+	if _, ok := errs["Records"]; !ok || len(errs) != 1 {
+		t.Fatalf("Expected only Records error, got %v", errs)
+	}
+}
+
+func TestS3EventsIngestSubmit(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		field          string
+		eventName      string
+		key            string
+		uploadKey      string
+		deleteKey      string
+		expectedResult string
+		expectedValue  string
+	}{
+		{
+			name:           "unsupported event",
+			eventName:      "s3:TestEvent",
+			key:            "wzlqyes4orhoygb/1tmknxy2868d869/new.txt",
+			uploadKey:      "wzlqyes4orhoygb/1tmknxy2868d869/new.txt",
+			expectedResult: `{"key":"wzlqyes4orhoygb/1tmknxy2868d869/new.txt","action":"skipped","reason":"Unsupported event type."}`,
+		},
+		{
+			name:           "invalid key format (thumb)",
+			eventName:      "ObjectCreated:Put",
+			key:            "wzlqyes4orhoygb/mk5fmymtx4wsprk/thumbs_300_JdfBOieXAW.png/100x100_300_JdfBOieXAW.png",
+			expectedResult: `"action":"skipped","reason":"Unsupported object key format."`,
+		},
+		{
+			name:           "missing collection",
+			eventName:      "ObjectCreated:Put",
+			key:            "missing/1tmknxy2868d869/new.txt",
+			expectedResult: `"action":"skipped","reason":"Missing or invalid collection."`,
+		},
+		{
+			name:           "collection name instead of id",
+			eventName:      "ObjectCreated:Put",
+			key:            "demo3/1tmknxy2868d869/new.txt",
+			expectedResult: `"action":"skipped","reason":"Missing or invalid collection."`,
+		},
+		{
+			name:           "missing record",
+			eventName:      "ObjectCreated:Put",
+			key:            "wzlqyes4orhoygb/missing/new.txt",
+			expectedResult: `"action":"skipped","reason":"Missing record."`,
+		},
+		{
+			name:           "created event for missing object",
+			eventName:      "ObjectCreated:Put",
+			key:            "wzlqyes4orhoygb/1tmknxy2868d869/new.txt",
+			expectedResult: `"action":"skipped","recordId":"1tmknxy2868d869","reason":"The object doesn't exist."`,
+			expectedValue:  `[]`,
+		},
+		{
+			name:           "created event for already registered object",
+			eventName:      "ObjectCreated:Put",
+			key:            "wzlqyes4orhoygb/7nwo8tuiatetxdm/test_JnXeKEwgwr.txt",
+			expectedResult: `"action":"skipped","recordId":"7nwo8tuiatetxdm","field":"files","reason":"The file is already registered."`,
+			expectedValue:  `["test_JnXeKEwgwr.txt"]`,
+		},
+		{
+			name:           "created event for multiple file field (url encoded key)",
+			eventName:      "ObjectCreated:Put",
+			key:            "wzlqyes4orhoygb/7nwo8tuiatetxdm/new+file.txt",
+			uploadKey:      "wzlqyes4orhoygb/7nwo8tuiatetxdm/new file.txt",
+			expectedResult: `{"key":"wzlqyes4orhoygb/7nwo8tuiatetxdm/new file.txt","action":"created","recordId":"7nwo8tuiatetxdm","field":"files"}`,
+			expectedValue:  `["test_JnXeKEwgwr.txt","new file.txt"]`,
+		},
+		{
+			name:           "created event with ambiguous file field",
+			eventName:      "ObjectCreated:Put",
+			key:            "wsmn24bux7wo113/84nmscqy84lsi1t/new.txt",
+			uploadKey:      "wsmn24bux7wo113/84nmscqy84lsi1t/new.txt",
+			expectedResult: `"action":"skipped","recordId":"84nmscqy84lsi1t","reason":"Missing or ambiguous file field."`,
+		},
+		{
+			name:           "created event with explicit file field",
+			field:          "file_many",
+			eventName:      "ObjectCreated:Put",
+			key:            "wsmn24bux7wo113/84nmscqy84lsi1t/new.txt",
+			uploadKey:      "wsmn24bux7wo113/84nmscqy84lsi1t/new.txt",
+			expectedResult: `"action":"created","recordId":"84nmscqy84lsi1t","field":"file_many"`,
+		},
+		{
+			name:           "created event for single file field (replace)",
+			eventName:      "ObjectCreated:Copy",
+			key:            "9n89pl5vkct6330/qjeql998mtp1azp/new.txt",
+			uploadKey:      "9n89pl5vkct6330/qjeql998mtp1azp/new.txt",
+			expectedResult: `"action":"created","recordId":"qjeql998mtp1azp","field":"file"`,
+			expectedValue:  `"new.txt"`,
+		},
+		{
+			name:           "removed event for existing object",
+			eventName:      "ObjectRemoved:Delete",
+			key:            "wzlqyes4orhoygb/7nwo8tuiatetxdm/test_JnXeKEwgwr.txt",
+			expectedResult: `"action":"skipped","recordId":"7nwo8tuiatetxdm","reason":"The object still exists."`,
+			expectedValue:  `["test_JnXeKEwgwr.txt"]`,
+		},
+		{
+			name:           "removed event for unregistered object",
+			eventName:      "ObjectRemoved:Delete",
+			key:            "wzlqyes4orhoygb/7nwo8tuiatetxdm/missing.txt",
+			expectedResult: `"action":"skipped","recordId":"7nwo8tuiatetxdm","reason":"The file is not registered."`,
+			expectedValue:  `["test_JnXeKEwgwr.txt"]`,
+		},
+		{
+			name:           "removed event for deleted object",
+			eventName:      "ObjectRemoved:Delete",
+			key:            "wzlqyes4orhoygb/lcl9d87w22ml6jy/test_FLurQTgrY8.txt",
+			deleteKey:      "wzlqyes4orhoygb/lcl9d87w22ml6jy/test_FLurQTgrY8.txt",
+			expectedResult: `"action":"removed","recordId":"lcl9d87w22ml6jy","field":"files"`,
+			expectedValue:  `["300_UhLKX91HVb.png"]`,
+		},
+	}
+
+	for _, s := range scenarios {
+		func() {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			fsys, err := app.NewFilesystem()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer fsys.Close()
+
+			if s.uploadKey != "" {
+				if err := fsys.Upload([]byte("test"), s.uploadKey); err != nil {
+					t.Fatalf("[%s] Failed to upload test file: %v", s.name, err)
+				}
+			}
+
+			if s.deleteKey != "" {
+				if err := fsys.Delete(s.deleteKey); err != nil {
+					t.Fatalf("[%s] Failed to delete test file: %v", s.name, err)
+				}
+			}
+
+			form := forms.NewS3EventsIngest(app)
+			form.Field = s.field
+			form.Records = []forms.S3EventRecord{{EventName: s.eventName}}
+			form.Records[0].S3.Object.Key = s.key
+
+			var results []*forms.S3EventIngestResult
+
+			interceptorCalls := 0
+			err = form.Submit(func(next forms.InterceptorNextFunc[[]*forms.S3EventIngestResult]) forms.InterceptorNextFunc[[]*forms.S3EventIngestResult] {
+				return func(r []*forms.S3EventIngestResult) error {
+					interceptorCalls++
+					results = r
+					return next(r)
+				}
+			})
+			if err != nil {
+				t.Fatalf("[%s] Unexpected error: %v", s.name, err)
+			}
+
+			if interceptorCalls != 1 {
+				t.Fatalf("[%s] Expected interceptor to be called once, got %d", s.name, interceptorCalls)
+			}
+
+			if len(results) != 1 {
+				t.Fatalf("[%s] Expected 1 result, got %d", s.name, len(results))
+			}
+
+			raw, _ := json.Marshal(results[0])
+			if !strings.Contains(string(raw), s.expectedResult) {
+				t.Fatalf("[%s] Expected result %s, got %s", s.name, s.expectedResult, raw)
+			}
+
+			if s.expectedValue == "" || results[0].RecordId == "" {
+				return
+			}
+
+			record, err := app.Dao().FindRecordById(s.key[:15], results[0].RecordId)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			field := results[0].Field
+			if field == "" {
+				field = "files"
+			}
+
+			rawValue, _ := json.Marshal(record.Get(field))
+			if string(rawValue) != s.expectedValue {
+				t.Fatalf("[%s] Expected %s value %s, got %s", s.name, field, s.expectedValue, rawValue)
+			}
+		}()
+	}
+}
+
+func TestS3EventsIngestReplacedFileDelete(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	fsys, err := app.NewFilesystem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fsys.Close()
+
+	if err := fsys.Upload([]byte("test"), "9n89pl5vkct6330/la4y2w4o98acwuj/new.txt"); err != nil {
+		t.Fatal(err)
+	}
+
+	form := forms.NewS3EventsIngest(app)
+	form.Records = []forms.S3EventRecord{{EventName: "ObjectCreated:Put"}}
+	form.Records[0].S3.Object.Key = "9n89pl5vkct6330/la4y2w4o98acwuj/new.txt"
+
+	if err := form.Submit(); err != nil {
+		t.Fatal(err)
+	}
+
+	checks := map[string]bool{
+		"9n89pl5vkct6330/la4y2w4o98acwuj/new.txt":                                                                        true,
+		"9n89pl5vkct6330/la4y2w4o98acwuj/300_uh_lkx91_hvb_Da8K5pl069.png":                                                false,
+		"9n89pl5vkct6330/la4y2w4o98acwuj/thumbs_300_uh_lkx91_hvb_Da8K5pl069.png/100x100_300_uh_lkx91_hvb_Da8K5pl069.png": false,
+	}
+
+	for key, expected := range checks {
+		exists, _ := fsys.Exists(key)
+		if exists != expected {
+			t.Errorf("Expected %q exists to be %v, got %v", key, expected, exists)
+		}
+	}
+}
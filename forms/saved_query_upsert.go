@@ -0,0 +1,186 @@
+package forms
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+var savedQueryNameRegex = regexp.MustCompile(`^\w+$`)
+
+// SavedQueryUpsert is a [models.SavedQuery] upsert (create/update) form.
+type SavedQueryUpsert struct {
+	app        core.App
+	dao        *daos.Dao
+	collection *models.Collection
+	savedQuery *models.SavedQuery
+	admin      *models.Admin
+	authRecord *models.Record
+
+	Name   string `form:"name" json:"name"`
+	Filter string `form:"filter" json:"filter"`
+	Sort   string `form:"sort" json:"sort"`
+	Fields string `form:"fields" json:"fields"`
+	Expand string `form:"expand" json:"expand"`
+	Shared bool   `form:"shared" json:"shared"`
+}
+
+// NewSavedQueryUpsert creates a new [SavedQueryUpsert] form with initializer
+// config created from the provided [core.App], [models.Collection] and
+// [models.SavedQuery] instances (for create you could pass a pointer to
+// an empty SavedQuery - `&models.SavedQuery{}`).
+//
+// The provided admin or auth record is set as owner of the newly created queries.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewSavedQueryUpsert(
+	app core.App,
+	collection *models.Collection,
+	savedQuery *models.SavedQuery,
+	admin *models.Admin,
+	authRecord *models.Record,
+) *SavedQueryUpsert {
+	form := &SavedQueryUpsert{
+		app:        app,
+		dao:        app.Dao(),
+		collection: collection,
+		savedQuery: savedQuery,
+		admin:      admin,
+		authRecord: authRecord,
+	}
+
+	// load defaults
+	form.Name = savedQuery.Name
+	form.Filter = savedQuery.Filter
+	form.Sort = savedQuery.Sort
+	form.Fields = savedQuery.Fields
+	form.Expand = savedQuery.Expand
+	form.Shared = savedQuery.Shared
+
+	return form
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *SavedQueryUpsert) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *SavedQueryUpsert) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(
+			&form.Name,
+			validation.Required,
+			validation.Length(1, 100),
+			validation.Match(savedQueryNameRegex),
+			validation.By(form.checkUniqueName),
+		),
+		validation.Field(&form.Filter, validation.Length(0, 3500), validation.By(form.checkFilter)),
+		validation.Field(&form.Sort, validation.Length(0, 500), validation.By(form.checkSort)),
+		validation.Field(&form.Fields, validation.Length(0, 500)),
+		validation.Field(&form.Expand, validation.Length(0, 500)),
+	)
+}
+
+func (form *SavedQueryUpsert) checkUniqueName(value any) error {
+	v, _ := value.(string)
+
+	existing, err := form.dao.FindSavedQuery(form.collection, v)
+	if err != nil || existing.Id == form.savedQuery.Id {
+		return nil
+	}
+
+	return validation.NewError("validation_saved_query_name_exists", "Query name already exists.")
+}
+
+func (form *SavedQueryUpsert) checkFilter(value any) error {
+	v, _ := value.(string)
+	if v == "" {
+		return nil // nothing to check
+	}
+
+	if err := form.checkForbiddenFields(v); err != nil {
+		return err
+	}
+
+	r := resolvers.NewRecordFieldResolver(form.dao, form.collection, nil, true)
+
+	if _, err := search.FilterData(v).BuildExpr(r); err != nil {
+		return validation.NewError("validation_invalid_filter", "Invalid filter expression.")
+	}
+
+	return nil
+}
+
+func (form *SavedQueryUpsert) checkSort(value any) error {
+	v, _ := value.(string)
+	if v == "" {
+		return nil // nothing to check
+	}
+
+	if err := form.checkForbiddenFields(v); err != nil {
+		return err
+	}
+
+	r := resolvers.NewRecordFieldResolver(form.dao, form.collection, nil, true)
+
+	for _, field := range search.ParseSortFromString(v) {
+		if _, err := field.BuildExpr(r); err != nil {
+			return validation.NewError("validation_invalid_sort", "Invalid sort expression.")
+		}
+	}
+
+	return nil
+}
+
+// checkForbiddenFields forbids non-admins to store @collection and
+// @request filter/sort fields (similar to the records list query params).
+func (form *SavedQueryUpsert) checkForbiddenFields(value string) error {
+	if form.admin != nil {
+		return nil // admins are allowed to query everything
+	}
+
+	if strings.Contains(value, "@collection.") || strings.Contains(value, "@request.") {
+		return validation.NewError("validation_forbidden_query_fields", "Only admins can use @collection and @request fields.")
+	}
+
+	return nil
+}
+
+// Submit validates the form and upserts the form SavedQuery model.
+//
+// You can optionally provide a list of InterceptorFunc to further
+// modify the form behavior before persisting it.
+func (form *SavedQueryUpsert) Submit(interceptors ...InterceptorFunc[*models.SavedQuery]) error {
+	if err := form.Validate(); err != nil {
+		return err
+	}
+
+	if form.savedQuery.IsNew() {
+		if form.admin == nil && form.authRecord == nil {
+			return errors.New("missing saved query owner")
+		}
+
+		form.savedQuery.CollectionId = form.collection.Id
+		form.savedQuery.SetOwner(form.admin, form.authRecord)
+	}
+
+	form.savedQuery.Name = form.Name
+	form.savedQuery.Filter = form.Filter
+	form.savedQuery.Sort = form.Sort
+	form.savedQuery.Fields = form.Fields
+	form.savedQuery.Expand = form.Expand
+	form.savedQuery.Shared = form.Shared
+
+	return runInterceptors(form.savedQuery, func(savedQuery *models.SavedQuery) error {
+		return form.dao.SaveSavedQuery(savedQuery)
+	}, interceptors...)
+}
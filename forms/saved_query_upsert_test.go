@@ -0,0 +1,153 @@
+package forms_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestSavedQueryUpsertValidateAndSubmit(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		queryName      string
+		jsonData       string
+		expectedErrors []string
+	}{
+		{
+			"create with empty data",
+			"",
+			`{}`,
+			[]string{"name"},
+		},
+		{
+			"create with invalid data",
+			"",
+			`{"name":"invalid name","filter":"missing = 1","sort":"-missing","fields":"` + strings.Repeat("a", 501) + `"}`,
+			[]string{"name", "filter", "sort", "fields"},
+		},
+		{
+			"create with forbidden fields",
+			"",
+			`{"name":"new_query","filter":"@request.auth.id != ''","sort":"@collection.demo1.text"}`,
+			[]string{"filter", "sort"},
+		},
+		{
+			"create with existing name",
+			"",
+			`{"name":"active"}`,
+			[]string{"name"},
+		},
+		{
+			"create with valid data",
+			"",
+			`{"name":"new_query","filter":"active = true && title != ''","sort":"-created,title","fields":"id,title","shared":true}`,
+			[]string{},
+		},
+		{
+			"update with existing name",
+			"mine",
+			`{"name":"active"}`,
+			[]string{"name"},
+		},
+		{
+			"update with the same name",
+			"mine",
+			`{"name":"mine","filter":"active = false"}`,
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		func() {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			authRecord, err := app.Dao().FindRecordById("users", "oap640cot4yru2s")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			savedQuery := &models.SavedQuery{}
+			if s.queryName != "" {
+				savedQuery, err = app.Dao().FindSavedQuery(collection, s.queryName)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			originalOwnerId := savedQuery.OwnerId
+
+			form := forms.NewSavedQueryUpsert(app, collection, savedQuery, nil, authRecord)
+
+			if err := json.Unmarshal([]byte(s.jsonData), form); err != nil {
+				t.Fatalf("[%s] Failed to load form data: %v", s.name, err)
+			}
+
+			interceptorCalls := 0
+
+			result := form.Submit(func(next forms.InterceptorNextFunc[*models.SavedQuery]) forms.InterceptorNextFunc[*models.SavedQuery] {
+				return func(m *models.SavedQuery) error {
+					interceptorCalls++
+					return next(m)
+				}
+			})
+
+			// parse errors
+			errs, ok := result.(validation.Errors)
+			if !ok && result != nil {
+				t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+				return
+			}
+
+			// check errors
+			if len(errs) > len(s.expectedErrors) {
+				t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+			}
+			for _, k := range s.expectedErrors {
+				if _, ok := errs[k]; !ok {
+					t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+				}
+			}
+
+			expectInterceptorCalls := 1
+			if len(s.expectedErrors) > 0 {
+				expectInterceptorCalls = 0
+			}
+			if interceptorCalls != expectInterceptorCalls {
+				t.Errorf("[%s] Expected interceptor to be called %d, got %d", s.name, expectInterceptorCalls, interceptorCalls)
+			}
+
+			if len(s.expectedErrors) > 0 {
+				return
+			}
+
+			saved, err := app.Dao().FindSavedQuery(collection, form.Name)
+			if err != nil {
+				t.Errorf("[%s] Expected the query to be saved, got %v", s.name, err)
+				return
+			}
+
+			if saved.Filter != form.Filter || saved.Sort != form.Sort || saved.Fields != form.Fields || saved.Shared != form.Shared {
+				t.Errorf("[%s] Expected the form data to be persisted, got %v", s.name, saved)
+			}
+
+			// the owner should be set only on create
+			expectedOwnerId := originalOwnerId
+			if s.queryName == "" {
+				expectedOwnerId = authRecord.Id
+			}
+			if saved.OwnerId != expectedOwnerId {
+				t.Errorf("[%s] Expected owner %q, got %q", s.name, expectedOwnerId, saved.OwnerId)
+			}
+		}()
+	}
+}
@@ -0,0 +1,224 @@
+package forms
+
+import (
+	"regexp"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tools/cron"
+	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+var (
+	scheduledExportNameRegex   = regexp.MustCompile(`^\w+$`)
+	scheduledExportPrefixRegex = regexp.MustCompile(`^[\w\-]+(/[\w\-]+)*$`)
+)
+
+// ScheduledExportUpsert is a [models.ScheduledExport] upsert (create/update) form.
+type ScheduledExportUpsert struct {
+	app    core.App
+	dao    *daos.Dao
+	export *models.ScheduledExport
+
+	Name        string                  `form:"name" json:"name"`
+	Collection  string                  `form:"collection" json:"collection"`
+	Filter      string                  `form:"filter" json:"filter"`
+	Sort        string                  `form:"sort" json:"sort"`
+	Format      string                  `form:"format" json:"format"`
+	Schedule    string                  `form:"schedule" json:"schedule"`
+	Destination string                  `form:"destination" json:"destination"`
+	Prefix      string                  `form:"prefix" json:"prefix"`
+	Emails      types.JsonArray[string] `form:"emails" json:"emails"`
+	Enabled     bool                    `form:"enabled" json:"enabled"`
+}
+
+// NewScheduledExportUpsert creates a new [ScheduledExportUpsert] form with initializer
+// config created from the provided [core.App] and [models.ScheduledExport] instances
+// (for create you could pass a pointer to an empty ScheduledExport - `&models.ScheduledExport{}`).
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewScheduledExportUpsert(app core.App, export *models.ScheduledExport) *ScheduledExportUpsert {
+	form := &ScheduledExportUpsert{
+		app:    app,
+		dao:    app.Dao(),
+		export: export,
+	}
+
+	// load defaults
+	form.Name = export.Name
+	form.Collection = export.CollectionId
+	form.Filter = export.Filter
+	form.Sort = export.Sort
+	form.Format = export.Format
+	form.Schedule = export.Schedule
+	form.Destination = export.Destination
+	form.Prefix = export.Prefix
+	form.Emails = export.Emails
+	form.Enabled = export.Enabled
+
+	if form.Format == "" {
+		form.Format = models.ScheduledExportFormatCsv
+	}
+
+	if form.Destination == "" {
+		form.Destination = models.ScheduledExportDestinationS3
+	}
+
+	return form
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *ScheduledExportUpsert) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *ScheduledExportUpsert) Validate() error {
+	collection, _ := form.dao.FindCollectionByNameOrId(form.Collection)
+
+	return validation.ValidateStruct(form,
+		validation.Field(
+			&form.Name,
+			validation.Required,
+			validation.Length(1, 100),
+			validation.Match(scheduledExportNameRegex),
+			validation.By(form.checkUniqueName),
+		),
+		validation.Field(&form.Collection, validation.Required, validation.By(form.checkCollection(collection))),
+		validation.Field(&form.Filter, validation.Length(0, 3500), validation.By(form.checkFilter(collection))),
+		validation.Field(&form.Sort, validation.Length(0, 500), validation.By(form.checkSort(collection))),
+		validation.Field(
+			&form.Format,
+			validation.Required,
+			validation.In(
+				models.ScheduledExportFormatCsv,
+				models.ScheduledExportFormatJson,
+				models.ScheduledExportFormatXlsx,
+				models.ScheduledExportFormatParquet,
+			),
+		),
+		validation.Field(&form.Schedule, validation.Required, validation.By(form.checkSchedule)),
+		validation.Field(
+			&form.Destination,
+			validation.Required,
+			validation.In(models.ScheduledExportDestinationS3, models.ScheduledExportDestinationEmail),
+		),
+		validation.Field(
+			&form.Prefix,
+			validation.Length(0, 255),
+			validation.Match(scheduledExportPrefixRegex),
+		),
+		validation.Field(
+			&form.Emails,
+			validation.When(form.Destination == models.ScheduledExportDestinationEmail, validation.Required),
+			validation.Length(0, 50),
+			validation.Each(is.EmailFormat),
+		),
+	)
+}
+
+func (form *ScheduledExportUpsert) checkUniqueName(value any) error {
+	v, _ := value.(string)
+
+	existing, err := form.dao.FindScheduledExportByName(v)
+	if err != nil || existing.Id == form.export.Id {
+		return nil
+	}
+
+	return validation.NewError("validation_scheduled_export_name_exists", "Export name already exists.")
+}
+
+func (form *ScheduledExportUpsert) checkCollection(collection *models.Collection) validation.RuleFunc {
+	return func(value any) error {
+		if collection == nil {
+			return validation.NewError("validation_missing_collection", "Missing or invalid collection.")
+		}
+
+		return nil
+	}
+}
+
+func (form *ScheduledExportUpsert) checkFilter(collection *models.Collection) validation.RuleFunc {
+	return func(value any) error {
+		v, _ := value.(string)
+		if v == "" || collection == nil {
+			return nil // nothing to check
+		}
+
+		r := resolvers.NewRecordFieldResolver(form.dao, collection, nil, true)
+
+		if _, err := search.FilterData(v).BuildExpr(r); err != nil {
+			return validation.NewError("validation_invalid_filter", "Invalid filter expression.")
+		}
+
+		return nil
+	}
+}
+
+func (form *ScheduledExportUpsert) checkSort(collection *models.Collection) validation.RuleFunc {
+	return func(value any) error {
+		v, _ := value.(string)
+		if v == "" || collection == nil {
+			return nil // nothing to check
+		}
+
+		r := resolvers.NewRecordFieldResolver(form.dao, collection, nil, true)
+
+		for _, field := range search.ParseSortFromString(v) {
+			if _, err := field.BuildExpr(r); err != nil {
+				return validation.NewError("validation_invalid_sort", "Invalid sort expression.")
+			}
+		}
+
+		return nil
+	}
+}
+
+func (form *ScheduledExportUpsert) checkSchedule(value any) error {
+	v, _ := value.(string)
+	if v == "" {
+		return nil // nothing to check
+	}
+
+	if _, err := cron.NewSchedule(v); err != nil {
+		return validation.NewError("validation_invalid_cron", err.Error())
+	}
+
+	return nil
+}
+
+// Submit validates the form and upserts the form ScheduledExport model.
+//
+// You can optionally provide a list of InterceptorFunc to further
+// modify the form behavior before persisting it.
+func (form *ScheduledExportUpsert) Submit(interceptors ...InterceptorFunc[*models.ScheduledExport]) error {
+	if err := form.Validate(); err != nil {
+		return err
+	}
+
+	collection, err := form.dao.FindCollectionByNameOrId(form.Collection)
+	if err != nil {
+		return err
+	}
+
+	form.export.Name = form.Name
+	form.export.CollectionId = collection.Id
+	form.export.Filter = form.Filter
+	form.export.Sort = form.Sort
+	form.export.Format = form.Format
+	form.export.Schedule = form.Schedule
+	form.export.Destination = form.Destination
+	form.export.Prefix = form.Prefix
+	form.export.Emails = form.Emails
+	form.export.Enabled = form.Enabled
+
+	return runInterceptors(form.export, func(export *models.ScheduledExport) error {
+		return form.dao.SaveScheduledExport(export)
+	}, interceptors...)
+}
@@ -0,0 +1,169 @@
+package forms_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestScheduledExportUpsertValidateAndSubmit(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		existingName   string
+		jsonData       string
+		expectedErrors []string
+	}{
+		{
+			"create with empty data",
+			"",
+			`{}`,
+			[]string{"name", "collection", "schedule"},
+		},
+		{
+			"create with invalid data",
+			"",
+			`{
+				"name":"invalid name",
+				"collection":"missing",
+				"format":"xml",
+				"schedule":"invalid",
+				"destination":"ftp",
+				"prefix":"../exports",
+				"emails":["invalid"]
+			}`,
+			[]string{"name", "collection", "format", "schedule", "destination", "prefix", "emails"},
+		},
+		{
+			"create with existing name and invalid filter and sort",
+			"",
+			`{"name":"demo2_active","collection":"demo2","filter":"missing = 1","sort":"-missing","schedule":"0 0 * * *"}`,
+			[]string{"name", "filter", "sort"},
+		},
+		{
+			"create with email destination and no emails",
+			"",
+			`{"name":"new","collection":"demo2","schedule":"0 0 * * *","destination":"email"}`,
+			[]string{"emails"},
+		},
+		{
+			"create with valid data",
+			"",
+			`{
+				"name":"new",
+				"collection":"demo2",
+				"filter":"active = false",
+				"sort":"-title",
+				"format":"json",
+				"schedule":"*/5 * * * *",
+				"destination":"email",
+				"prefix":"reports/demo2",
+				"emails":["test@example.com"],
+				"enabled":true
+			}`,
+			[]string{},
+		},
+		{
+			"update with invalid data",
+			"demo2_active",
+			`{"name":"demo2_weekly","schedule":"* *"}`,
+			[]string{"name", "schedule"},
+		},
+		{
+			"update with valid data",
+			"demo2_active",
+			`{"name":"demo2_renamed","enabled":false}`,
+			[]string{},
+		},
+		{
+			"update with xlsx format",
+			"demo2_active",
+			`{"format":"xlsx"}`,
+			[]string{},
+		},
+		{
+			"update with parquet format",
+			"demo2_active",
+			`{"format":"parquet"}`,
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		func() {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			export := &models.ScheduledExport{}
+			if s.existingName != "" {
+				var err error
+				export, err = app.Dao().FindScheduledExportByName(s.existingName)
+				if err != nil {
+					t.Fatalf("[%s] Failed to load the scheduled export: %v", s.name, err)
+				}
+			}
+
+			form := forms.NewScheduledExportUpsert(app, export)
+
+			if err := json.Unmarshal([]byte(s.jsonData), form); err != nil {
+				t.Fatalf("[%s] Failed to load form data: %v", s.name, err)
+			}
+
+			interceptorCalls := 0
+
+			result := form.Submit(func(next forms.InterceptorNextFunc[*models.ScheduledExport]) forms.InterceptorNextFunc[*models.ScheduledExport] {
+				return func(m *models.ScheduledExport) error {
+					interceptorCalls++
+					return next(m)
+				}
+			})
+
+			// parse errors
+			errs, ok := result.(validation.Errors)
+			if !ok && result != nil {
+				t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+				return
+			}
+
+			// check errors
+			if len(errs) > len(s.expectedErrors) {
+				t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+			}
+			for _, k := range s.expectedErrors {
+				if _, ok := errs[k]; !ok {
+					t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+				}
+			}
+
+			expectInterceptorCalls := 1
+			if len(s.expectedErrors) > 0 {
+				expectInterceptorCalls = 0
+			}
+			if interceptorCalls != expectInterceptorCalls {
+				t.Errorf("[%s] Expected interceptor to be called %d, got %d", s.name, expectInterceptorCalls, interceptorCalls)
+			}
+
+			if len(s.expectedErrors) > 0 {
+				return
+			}
+
+			saved, err := app.Dao().FindScheduledExportByName(form.Name)
+			if err != nil {
+				t.Errorf("[%s] Expected the scheduled export to be saved, got %v", s.name, err)
+				return
+			}
+
+			if saved.CollectionId != "sz5l5z67tg7gku0" ||
+				saved.Filter != form.Filter ||
+				saved.Format != form.Format ||
+				saved.Schedule != form.Schedule ||
+				saved.Enabled != form.Enabled ||
+				len(saved.Emails) != len(form.Emails) {
+				t.Errorf("[%s] Expected the form data to be persisted, got %v", s.name, saved)
+			}
+		}()
+	}
+}
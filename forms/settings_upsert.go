@@ -1,20 +1,24 @@
 package forms
 
 import (
+	"database/sql"
 	"os"
 	"time"
 
 	"github.com/pocketbase/pocketbase/core"
 	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
 	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tools/types"
 )
 
 // SettingsUpsert is a [settings.Settings] upsert (create/update) form.
 type SettingsUpsert struct {
 	*settings.Settings
 
-	app core.App
-	dao *daos.Dao
+	app   core.App
+	dao   *daos.Dao
+	admin *models.Admin
 }
 
 // NewSettingsUpsert creates a new [SettingsUpsert] form with initializer
@@ -39,8 +43,19 @@ func (form *SettingsUpsert) SetDao(dao *daos.Dao) {
 	form.dao = dao
 }
 
+// SetAdmin sets the admin that submits the form
+// (used as author of the settings history entry).
+func (form *SettingsUpsert) SetAdmin(admin *models.Admin) {
+	form.admin = admin
+}
+
 // Validate makes the form validatable by implementing [validation.Validatable] interface.
 func (form *SettingsUpsert) Validate() error {
+	// the env overridden fields can't be changed
+	if err := form.app.Settings().CheckEnvLocked(form.Settings); err != nil {
+		return err
+	}
+
 	return form.Settings.Validate()
 }
 
@@ -70,13 +85,31 @@ func (form *SettingsUpsert) Submit(interceptors ...InterceptorFunc[*settings.Set
 
 		// persists settings change
 		encryptionKey := os.Getenv(form.app.EncryptionEnv())
-		if err := form.dao.SaveSettings(form.Settings, encryptionKey); err != nil {
+		toSave, err := form.settingsToSave(encryptionKey)
+		if err != nil {
 			// try to revert app settings
 			form.app.Settings().Merge(oldSettings)
 
 			return err
 		}
 
+		saveErr := form.dao.RunInTransaction(func(txDao *daos.Dao) error {
+			if err := txDao.SaveSettings(toSave, encryptionKey); err != nil {
+				return err
+			}
+
+			return form.saveHistory(txDao, oldSettings, toSave, encryptionKey)
+		})
+		if saveErr != nil {
+			// try to revert app settings
+			form.app.Settings().Merge(oldSettings)
+
+			return saveErr
+		}
+
+		// resolve the new settings secret references (if any)
+		form.app.RefreshSecrets()
+
 		// explicitly trigger old logs deletion
 		form.app.LogsDao().DeleteOldRequests(
 			time.Now().AddDate(0, 0, -1*form.Settings.Logs.MaxDays),
@@ -90,3 +123,61 @@ func (form *SettingsUpsert) Submit(interceptors ...InterceptorFunc[*settings.Set
 		return nil
 	}, interceptors...)
 }
+
+// settingsToSave returns the form settings with the env overridden
+// fields replaced with their stored values (aka. the env values are not persisted).
+func (form *SettingsUpsert) settingsToSave(encryptionKey string) (*settings.Settings, error) {
+	if len(form.Settings.EnvLocked()) == 0 {
+		return form.Settings, nil
+	}
+
+	stored, err := form.dao.FindSettings(encryptionKey)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			return nil, err
+		}
+		stored = settings.New()
+	}
+
+	result, err := form.Settings.Clone()
+	if err != nil {
+		return nil, err
+	}
+
+	result.RestoreEnvLocked(stored)
+
+	return result, nil
+}
+
+// saveHistory persists a new settings history entry with the changes
+// between the old and the form settings (if there are any).
+func (form *SettingsUpsert) saveHistory(
+	txDao *daos.Dao,
+	oldSettings *settings.Settings,
+	snapshot *settings.Settings,
+	encryptionKey string,
+) error {
+	// compare with the form settings since both have the same env overrides
+	changes, err := oldSettings.Diff(form.Settings)
+	if err != nil {
+		return err
+	}
+
+	if len(changes) == 0 {
+		return nil // nothing has changed
+	}
+
+	history := &models.SettingsHistory{
+		Diff: make(types.JsonMap, len(changes)),
+	}
+
+	for path, change := range changes {
+		history.Diff[path] = change
+	}
+
+	if form.admin != nil {
+		history.AdminId = form.admin.Id
+	}
+
+	return txDao.SaveSettingsHistory(history, snapshot, encryptionKey)
+}
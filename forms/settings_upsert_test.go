@@ -164,3 +164,57 @@ func TestSettingsUpsertSubmitInterceptors(t *testing.T) {
 		t.Fatalf("Expected interceptor2 to be called")
 	}
 }
+
+func TestSettingsUpsertSubmitHistory(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	os.Unsetenv(app.EncryptionEnv())
+
+	admin, err := app.Dao().FindAdminByEmail("test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// unchanged settings
+	form1 := forms.NewSettingsUpsert(app)
+	form1.SetAdmin(admin)
+	if err := form1.Submit(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindSettingsHistoryByVersion(3); err == nil {
+		t.Fatal("Expected no history entry for unchanged settings")
+	}
+
+	// changed settings
+	form2 := forms.NewSettingsUpsert(app)
+	form2.SetAdmin(admin)
+	form2.Meta.AppName = "history_test"
+	if err := form2.Submit(); err != nil {
+		t.Fatal(err)
+	}
+
+	history, err := app.Dao().FindSettingsHistoryByVersion(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if history.AdminId != admin.Id {
+		t.Fatalf("Expected history admin %q, got %q", admin.Id, history.AdminId)
+	}
+
+	diff, _ := json.Marshal(history.Diff)
+	expectedDiff := `{"meta.appName":{"new":"history_test","old":"acme_test"}}`
+	if string(diff) != expectedDiff {
+		t.Fatalf("Expected diff \n%s, got \n%s", expectedDiff, diff)
+	}
+
+	snapshot, err := app.Dao().SettingsHistorySnapshot(history)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snapshot.Meta.AppName != "history_test" {
+		t.Fatalf("Expected snapshot app name %q, got %q", "history_test", snapshot.Meta.AppName)
+	}
+}
@@ -0,0 +1,299 @@
+package forms
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+)
+
+// sqlCommentsRegex matches the sql line and block comments.
+var sqlCommentsRegex = regexp.MustCompile(`(?s)--[^\n]*|/\*.*?\*/`)
+
+// readOnlySqlKeywords is a list with the statement keywords
+// that are allowed to be executed in read-only mode.
+var readOnlySqlKeywords = []string{"SELECT", "WITH", "VALUES", "EXPLAIN", "PRAGMA"}
+
+// sqlPragmaRegex matches a "PRAGMA [schema.]name[(argument)]" statement.
+var sqlPragmaRegex = regexp.MustCompile(`(?is)^PRAGMA\s+(?:\w+\s*\.\s*)?(\w+)\s*(\(.*\))?\s*;?$`)
+
+// readOnlySqlPragmas is a list with the pragmas that are allowed to be
+// executed in read-only mode.
+//
+// The map value indicates whether the pragma accepts an argument
+// (for the rest the argument form is a write, eg. "PRAGMA journal_mode(OFF)").
+var readOnlySqlPragmas = map[string]bool{
+	// introspection pragmas (the argument is an object name or a limit)
+	"collation_list":    false,
+	"compile_options":   false,
+	"database_list":     false,
+	"foreign_key_check": true,
+	"foreign_key_list":  true,
+	"function_list":     false,
+	"index_info":        true,
+	"index_list":        true,
+	"index_xinfo":       true,
+	"integrity_check":   true,
+	"module_list":       false,
+	"pragma_list":       false,
+	"quick_check":       true,
+	"table_info":        true,
+	"table_list":        true,
+	"table_xinfo":       true,
+
+	// status pragmas (read only without an argument)
+	"application_id":     false,
+	"auto_vacuum":        false,
+	"busy_timeout":       false,
+	"cache_size":         false,
+	"data_version":       false,
+	"encoding":           false,
+	"foreign_keys":       false,
+	"freelist_count":     false,
+	"journal_mode":       false,
+	"max_page_count":     false,
+	"page_count":         false,
+	"page_size":          false,
+	"schema_version":     false,
+	"synchronous":        false,
+	"user_version":       false,
+	"wal_autocheckpoint": false,
+}
+
+// errSqlConsoleRollback is used to rollback the read-only transactions.
+var errSqlConsoleRollback = errors.New("sql console rollback")
+
+// SqlConsoleResult defines the result of a single SQL console query execution.
+type SqlConsoleResult struct {
+	Columns      []string `json:"columns"`
+	Rows         [][]any  `json:"rows"`
+	Truncated    bool     `json:"truncated"`
+	RowsAffected int64    `json:"rowsAffected"`
+
+	// Duration is the query execution time in milliseconds.
+	Duration float64 `json:"duration"`
+}
+
+// SqlConsoleRun is a form that executes a single admin SQL console query.
+//
+// The query is executed in a transaction that is always rolled back,
+// unless Write is set and the writes are allowed in app.Settings().SqlConsole.
+type SqlConsoleRun struct {
+	app core.App
+	ctx context.Context
+
+	Query   string         `form:"query" json:"query"`
+	Params  map[string]any `form:"params" json:"params"`
+	Explain bool           `form:"explain" json:"explain"`
+	Write   bool           `form:"write" json:"write"`
+}
+
+// NewSqlConsoleRun creates a new [SqlConsoleRun] form.
+func NewSqlConsoleRun(app core.App) *SqlConsoleRun {
+	return &SqlConsoleRun{
+		app: app,
+		ctx: context.Background(),
+	}
+}
+
+// SetContext replaces the default form context with the provided one.
+func (form *SqlConsoleRun) SetContext(ctx context.Context) {
+	form.ctx = ctx
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *SqlConsoleRun) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(
+			&form.Query,
+			validation.Required,
+			validation.Length(1, 10000),
+			validation.By(checkSqlSingleStatement),
+			validation.When(!form.Write && !form.Explain, validation.By(checkSqlReadOnly)),
+		),
+		validation.Field(&form.Write, validation.By(form.checkWriteAllowed)),
+	)
+}
+
+func (form *SqlConsoleRun) isWrite() bool {
+	return form.Write && !form.Explain
+}
+
+func (form *SqlConsoleRun) checkWriteAllowed(value any) error {
+	v, _ := value.(bool)
+
+	if v && !form.app.Settings().SqlConsole.AllowWrites {
+		return validation.NewError("validation_sql_console_writes_disabled", "The SQL console writes are disabled.")
+	}
+
+	return nil
+}
+
+// checkSqlSingleStatement checks whether the value is a single SQL statement.
+func checkSqlSingleStatement(value any) error {
+	v, _ := value.(string)
+
+	normalized := strings.TrimSpace(sqlCommentsRegex.ReplaceAllString(v, ""))
+	normalized = strings.TrimSpace(strings.TrimSuffix(normalized, ";"))
+
+	// note: this is a rough check and it could reject valid
+	// queries with ";" in string literals
+	if strings.Contains(normalized, ";") {
+		return validation.NewError("validation_sql_console_multiple_statements", "Only a single SQL statement is allowed.")
+	}
+
+	return nil
+}
+
+// checkSqlReadOnly checks whether the value is a read SQL statement.
+func checkSqlReadOnly(value any) error {
+	v, _ := value.(string)
+
+	normalized := strings.TrimSpace(sqlCommentsRegex.ReplaceAllString(v, ""))
+
+	fields := strings.Fields(normalized)
+	if len(fields) == 0 {
+		return nil // nothing to check
+	}
+
+	keyword := strings.ToUpper(fields[0])
+
+	for _, k := range readOnlySqlKeywords {
+		if keyword != k {
+			continue
+		}
+
+		if k == "PRAGMA" && !isReadOnlySqlPragma(normalized) {
+			break
+		}
+
+		return nil
+	}
+
+	return validation.NewError("validation_sql_console_read_only", "Only read statements are allowed in read-only mode.")
+}
+
+// isReadOnlySqlPragma checks whether the normalized PRAGMA statement
+// is an allowed read-only pragma (without assignments or a value argument).
+func isReadOnlySqlPragma(normalized string) bool {
+	match := sqlPragmaRegex.FindStringSubmatch(normalized)
+	if len(match) != 3 {
+		return false
+	}
+
+	allowArgument, ok := readOnlySqlPragmas[strings.ToLower(match[1])]
+	if !ok {
+		return false
+	}
+
+	return match[2] == "" || allowArgument
+}
+
+// Submit validates and executes the form query.
+//
+// You can optionally provide a list of InterceptorFunc to further
+// modify the form behavior before returning the query result.
+func (form *SqlConsoleRun) Submit(interceptors ...InterceptorFunc[*SqlConsoleResult]) error {
+	if err := form.Validate(); err != nil {
+		return err
+	}
+
+	config := form.app.Settings().SqlConsole
+	if !config.Enabled {
+		return errors.New("the SQL console is not enabled")
+	}
+
+	ctx, cancel := context.WithTimeout(form.ctx, time.Duration(config.Timeout)*time.Second)
+	defer cancel()
+
+	query := form.Query
+	if form.Explain {
+		query = "EXPLAIN QUERY PLAN " + query
+	}
+
+	result := &SqlConsoleResult{
+		Columns: []string{},
+		Rows:    [][]any{},
+	}
+
+	start := time.Now()
+
+	txErr := form.app.Dao().RunInTransaction(func(txDao *daos.Dao) error {
+		q := txDao.DB().NewQuery(query).Bind(dbx.Params(form.Params)).WithContext(ctx)
+
+		if form.isWrite() {
+			res, err := q.Execute()
+			if err != nil {
+				return err
+			}
+
+			result.RowsAffected, _ = res.RowsAffected()
+
+			return nil
+		}
+
+		if err := fetchSqlRows(q, config.MaxRows, result); err != nil {
+			return err
+		}
+
+		return errSqlConsoleRollback
+	})
+
+	if txErr != nil && !errors.Is(txErr, errSqlConsoleRollback) {
+		return txErr
+	}
+
+	result.Duration = float64(time.Since(start).Microseconds()) / 1000
+
+	return runInterceptors(result, func(r *SqlConsoleResult) error {
+		return nil
+	}, interceptors...)
+}
+
+// fetchSqlRows loads up to maxRows rows of the provided query in result.
+func fetchSqlRows(q *dbx.Query, maxRows int, result *SqlConsoleResult) error {
+	rows, err := q.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	result.Columns, err = rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	for rows.Next() {
+		if len(result.Rows) >= maxRows {
+			result.Truncated = true
+			break
+		}
+
+		values := make([]any, len(result.Columns))
+		pointers := make([]any, len(values))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+
+		if err := rows.Scan(pointers...); err != nil {
+			return err
+		}
+
+		// normalize the raw bytes for json serialization
+		for i, v := range values {
+			if b, ok := v.([]byte); ok {
+				values[i] = string(b)
+			}
+		}
+
+		result.Rows = append(result.Rows, values)
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,222 @@
+package forms_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestSqlConsoleRunSubmit(t *testing.T) {
+	scenarios := []struct {
+		name             string
+		disabled         bool
+		allowWrites      bool
+		maxRows          int
+		jsonData         string
+		expectedErrors   []string
+		expectError      bool
+		expectedResult   []string
+		expectedDemo2Ids int
+	}{
+		{
+			name:           "empty data",
+			jsonData:       `{}`,
+			expectedErrors: []string{"query"},
+		},
+		{
+			name:        "disabled console",
+			disabled:    true,
+			jsonData:    `{"query":"SELECT 1"}`,
+			expectError: true,
+		},
+		{
+			name:           "multiple statements",
+			jsonData:       `{"query":"SELECT 1; SELECT 2;"}`,
+			expectedErrors: []string{"query"},
+		},
+		{
+			name:           "write statement in read-only mode",
+			jsonData:       `{"query":"DELETE FROM demo2"}`,
+			expectedErrors: []string{"query"},
+		},
+		{
+			name:           "pragma assignment in read-only mode",
+			jsonData:       `{"query":"PRAGMA foreign_keys = OFF"}`,
+			expectedErrors: []string{"query"},
+		},
+		{
+			name:           "pragma function form assignment in read-only mode",
+			jsonData:       `{"query":"PRAGMA journal_mode(OFF)"}`,
+			expectedErrors: []string{"query"},
+		},
+		{
+			name:           "schema pragma function form assignment in read-only mode",
+			jsonData:       `{"query":"PRAGMA main.writable_schema(1)"}`,
+			expectedErrors: []string{"query"},
+		},
+		{
+			name:           "not allowed pragma in read-only mode",
+			jsonData:       `{"query":"PRAGMA shrink_memory"}`,
+			expectedErrors: []string{"query"},
+		},
+		{
+			name:           "read-only pragma",
+			jsonData:       `{"query":"PRAGMA user_version;"}`,
+			expectedResult: []string{`"columns":["user_version"]`},
+		},
+		{
+			name:           "read-only pragma with argument",
+			jsonData:       `{"query":"PRAGMA main.table_info(demo2)"}`,
+			expectedResult: []string{`"columns":["cid","name","type","notnull","dflt_value","pk"]`, `"title"`},
+		},
+		{
+			name:           "write with disabled writes",
+			jsonData:       `{"query":"DELETE FROM demo2","write":true}`,
+			expectedErrors: []string{"write"},
+		},
+		{
+			name:        "invalid query",
+			jsonData:    `{"query":"SELECT * FROM missing"}`,
+			expectError: true,
+		},
+		{
+			name:           "read query with comments and trailing semicolon",
+			jsonData:       `{"query":"-- test\n/* test */ SELECT id, title FROM demo2 ORDER BY title;"}`,
+			expectedResult: []string{`"columns":["id","title"]`, `"rows":[["llvuca81nly1qls","test1"],["achvryl401bhse3","test2"],["0yxhwia2amd8gec","test3"]]`, `"truncated":false`},
+		},
+		{
+			name:           "read query with params",
+			jsonData:       `{"query":"SELECT id FROM demo2 WHERE title = {:title}","params":{"title":"test2"}}`,
+			expectedResult: []string{`"rows":[["achvryl401bhse3"]]`},
+		},
+		{
+			name:           "read query with max rows limit",
+			maxRows:        2,
+			jsonData:       `{"query":"SELECT id FROM demo2 ORDER BY title"}`,
+			expectedResult: []string{`"rows":[["llvuca81nly1qls"],["achvryl401bhse3"]]`, `"truncated":true`},
+		},
+		{
+			name:           "explain query",
+			jsonData:       `{"query":"SELECT id FROM demo2","explain":true}`,
+			expectedResult: []string{`"columns":["id","parent","notused","detail"]`, `SCAN`},
+		},
+		{
+			name:             "write query",
+			allowWrites:      true,
+			jsonData:         `{"query":"DELETE FROM demo2 WHERE title = {:title}","params":{"title":"test1"},"write":true}`,
+			expectedResult:   []string{`"rowsAffected":1`},
+			expectedDemo2Ids: 2,
+		},
+		{
+			name:             "write query with explain",
+			allowWrites:      true,
+			jsonData:         `{"query":"DELETE FROM demo2","write":true,"explain":true}`,
+			expectedResult:   []string{`"rowsAffected":0`, `"detail"`},
+			expectedDemo2Ids: 3,
+		},
+	}
+
+	for _, s := range scenarios {
+		func() {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			app.Settings().SqlConsole.Enabled = !s.disabled
+			app.Settings().SqlConsole.AllowWrites = s.allowWrites
+			if s.maxRows > 0 {
+				app.Settings().SqlConsole.MaxRows = s.maxRows
+			}
+
+			form := forms.NewSqlConsoleRun(app)
+
+			if err := json.Unmarshal([]byte(s.jsonData), form); err != nil {
+				t.Fatalf("[%s] Failed to load form data: %v", s.name, err)
+			}
+
+			var result *forms.SqlConsoleResult
+
+			err := form.Submit(func(next forms.InterceptorNextFunc[*forms.SqlConsoleResult]) forms.InterceptorNextFunc[*forms.SqlConsoleResult] {
+				return func(r *forms.SqlConsoleResult) error {
+					result = r
+					return next(r)
+				}
+			})
+
+			if s.expectError {
+				if err == nil {
+					t.Errorf("[%s] Expected error, got nil", s.name)
+				}
+				return
+			}
+
+			// parse errors
+			errs, ok := err.(validation.Errors)
+			if !ok && err != nil {
+				t.Errorf("[%s] Failed to parse errors %v", s.name, err)
+				return
+			}
+
+			// check errors
+			if len(errs) > len(s.expectedErrors) {
+				t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+			}
+			for _, k := range s.expectedErrors {
+				if _, ok := errs[k]; !ok {
+					t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+				}
+			}
+
+			if len(s.expectedErrors) > 0 {
+				return
+			}
+
+			raw, _ := json.Marshal(result)
+			for _, expected := range s.expectedResult {
+				if !strings.Contains(string(raw), expected) {
+					t.Errorf("[%s] Expected %s in result %s", s.name, expected, raw)
+				}
+			}
+
+			// ensure that the read queries are not persisted
+			ids := []string{}
+			if err := app.Dao().DB().Select("id").From("demo2").Column(&ids); err != nil {
+				t.Fatal(err)
+			}
+			expectedIds := s.expectedDemo2Ids
+			if expectedIds == 0 {
+				expectedIds = 3
+			}
+			if len(ids) != expectedIds {
+				t.Errorf("[%s] Expected %d demo2 records, got %d", s.name, expectedIds, len(ids))
+			}
+		}()
+	}
+}
+
+func TestSqlConsoleRunReadOnlyRollback(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	app.Settings().SqlConsole.Enabled = true
+
+	// data modifying CTE that passes the keyword check
+	form := forms.NewSqlConsoleRun(app)
+	form.Query = "WITH t AS (SELECT 1) DELETE FROM demo2"
+
+	if err := form.Submit(); err != nil {
+		t.Fatal(err)
+	}
+
+	var total int
+	if err := app.Dao().DB().Select("count(*)").From("demo2").Row(&total); err != nil {
+		t.Fatal(err)
+	}
+
+	if total != 3 {
+		t.Fatalf("Expected the read-only transaction to be rolled back, got %d demo2 records", total)
+	}
+}
@@ -0,0 +1,92 @@
+package forms
+
+import (
+	"regexp"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+var sqlQueryNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_]+$`)
+
+// SqlQueryUpsert is a [models.SqlQuery] upsert (create/update) form.
+type SqlQueryUpsert struct {
+	app      core.App
+	dao      *daos.Dao
+	sqlQuery *models.SqlQuery
+
+	Name        string `form:"name" json:"name"`
+	Description string `form:"description" json:"description"`
+	Query       string `form:"query" json:"query"`
+}
+
+// NewSqlQueryUpsert creates a new [SqlQueryUpsert] form with initializer
+// config created from the provided [core.App] and [models.SqlQuery] instances
+// (for create you could pass a pointer to an empty SqlQuery - `&models.SqlQuery{}`).
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewSqlQueryUpsert(app core.App, sqlQuery *models.SqlQuery) *SqlQueryUpsert {
+	form := &SqlQueryUpsert{
+		app:      app,
+		dao:      app.Dao(),
+		sqlQuery: sqlQuery,
+	}
+
+	// load defaults
+	form.Name = sqlQuery.Name
+	form.Description = sqlQuery.Description
+	form.Query = sqlQuery.Query
+
+	return form
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *SqlQueryUpsert) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *SqlQueryUpsert) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(
+			&form.Name,
+			validation.Required,
+			validation.Length(1, 100),
+			validation.Match(sqlQueryNameRegex),
+			validation.By(form.checkUniqueName),
+		),
+		validation.Field(&form.Description, validation.Length(0, 500)),
+		validation.Field(&form.Query, validation.Required, validation.Length(1, 10000)),
+	)
+}
+
+func (form *SqlQueryUpsert) checkUniqueName(value any) error {
+	v, _ := value.(string)
+
+	if form.dao.IsSqlQueryNameUnique(v, form.sqlQuery.Id) {
+		return nil
+	}
+
+	return validation.NewError("validation_sql_query_name_exists", "Query name already exists.")
+}
+
+// Submit validates the form and upserts the form SqlQuery model.
+//
+// You can optionally provide a list of InterceptorFunc to further
+// modify the form behavior before persisting it.
+func (form *SqlQueryUpsert) Submit(interceptors ...InterceptorFunc[*models.SqlQuery]) error {
+	if err := form.Validate(); err != nil {
+		return err
+	}
+
+	form.sqlQuery.Name = form.Name
+	form.sqlQuery.Description = form.Description
+	form.sqlQuery.Query = form.Query
+
+	return runInterceptors(form.sqlQuery, func(sqlQuery *models.SqlQuery) error {
+		return form.dao.SaveSqlQuery(sqlQuery)
+	}, interceptors...)
+}
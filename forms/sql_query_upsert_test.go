@@ -0,0 +1,128 @@
+package forms_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestSqlQueryUpsertValidateAndSubmit(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		id             string
+		jsonData       string
+		expectedErrors []string
+	}{
+		{
+			"create with empty data",
+			"",
+			`{}`,
+			[]string{"name", "query"},
+		},
+		{
+			"create with invalid data",
+			"",
+			`{"name":"invalid name","description":"` + strings.Repeat("a", 501) + `","query":"SELECT 1"}`,
+			[]string{"name", "description"},
+		},
+		{
+			"create with existing name",
+			"",
+			`{"name":"TOTAL_admins","query":"SELECT 1"}`,
+			[]string{"name"},
+		},
+		{
+			"create with valid data",
+			"",
+			`{"name":"new_query","description":"test","query":"SELECT 1"}`,
+			[]string{},
+		},
+		{
+			"update with existing name",
+			"q2p6tvbr9ciahbl",
+			`{"name":"demo2_by_title"}`,
+			[]string{"name"},
+		},
+		{
+			"update with the same name",
+			"q2p6tvbr9ciahbl",
+			`{"name":"total_admins","query":"SELECT 2"}`,
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		func() {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			sqlQuery := &models.SqlQuery{}
+			if s.id != "" {
+				var err error
+				sqlQuery, err = app.Dao().FindSqlQueryById(s.id)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			form := forms.NewSqlQueryUpsert(app, sqlQuery)
+
+			if err := json.Unmarshal([]byte(s.jsonData), form); err != nil {
+				t.Fatalf("[%s] Failed to load form data: %v", s.name, err)
+			}
+
+			interceptorCalls := 0
+
+			result := form.Submit(func(next forms.InterceptorNextFunc[*models.SqlQuery]) forms.InterceptorNextFunc[*models.SqlQuery] {
+				return func(m *models.SqlQuery) error {
+					interceptorCalls++
+					return next(m)
+				}
+			})
+
+			// parse errors
+			errs, ok := result.(validation.Errors)
+			if !ok && result != nil {
+				t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+				return
+			}
+
+			// check errors
+			if len(errs) > len(s.expectedErrors) {
+				t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+			}
+			for _, k := range s.expectedErrors {
+				if _, ok := errs[k]; !ok {
+					t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+				}
+			}
+
+			expectInterceptorCalls := 1
+			if len(s.expectedErrors) > 0 {
+				expectInterceptorCalls = 0
+			}
+			if interceptorCalls != expectInterceptorCalls {
+				t.Errorf("[%s] Expected interceptor to be called %d, got %d", s.name, expectInterceptorCalls, interceptorCalls)
+			}
+
+			if len(s.expectedErrors) > 0 {
+				return
+			}
+
+			saved, err := app.Dao().FindSqlQueryById(sqlQuery.Id)
+			if err != nil {
+				t.Errorf("[%s] Expected the query to be saved, got %v", s.name, err)
+				return
+			}
+
+			if saved.Name != form.Name || saved.Query != form.Query || saved.Description != form.Description {
+				t.Errorf("[%s] Expected the form data to be persisted, got %v", s.name, saved)
+			}
+		}()
+	}
+}
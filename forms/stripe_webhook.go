@@ -0,0 +1,180 @@
+package forms
+
+import (
+	"errors"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/stripe"
+)
+
+// List with the Stripe webhook event processing actions.
+const (
+	StripeWebhookActionUpdated string = "updated"
+	StripeWebhookActionSkipped string = "skipped"
+)
+
+// StripeWebhookResult defines the processing result of a single Stripe webhook event.
+type StripeWebhookResult struct {
+	EventId  string `json:"eventId"`
+	Action   string `json:"action"`
+	RecordId string `json:"recordId,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// StripeWebhook is a form that applies a Stripe webhook event to the
+// subscription state of the app Settings().Billing.StripeCollection auth records.
+//
+// The form doesn't verify the event signature, so make sure to
+// call [stripe.VerifySignature] before submitting it.
+//
+// The auth record is resolved by:
+//   - the checkout session "client_reference_id" (the customer id is stored in the record)
+//   - the subscription customer id (or its "recordId" metadata as fallback)
+type StripeWebhook struct {
+	app core.App
+	dao *daos.Dao
+
+	Event stripe.Event
+}
+
+// NewStripeWebhook creates a new [StripeWebhook] form.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewStripeWebhook(app core.App) *StripeWebhook {
+	return &StripeWebhook{
+		app: app,
+		dao: app.Dao(),
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *StripeWebhook) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *StripeWebhook) Validate() error {
+	return validation.ValidateStruct(&form.Event,
+		validation.Field(&form.Event.Id, validation.Required),
+		validation.Field(&form.Event.Type, validation.Required),
+	)
+}
+
+// Submit validates the form and updates the subscription state
+// of the auth record related to the Stripe event.
+//
+// Unsupported events and events that couldn't be matched to an
+// existing auth record are reported as skipped (Stripe retries
+// the deliveries of the failed events, so they are not errors).
+//
+// You can optionally provide a list of InterceptorFunc to further
+// modify the form behavior before persisting the auth record.
+func (form *StripeWebhook) Submit(interceptors ...InterceptorFunc[*StripeWebhookResult]) error {
+	if err := form.Validate(); err != nil {
+		return err
+	}
+
+	config := form.app.Settings().Billing
+
+	collection, err := form.dao.FindCollectionByNameOrId(config.StripeCollection)
+	if err != nil || !collection.IsAuth() {
+		return errors.New("the Stripe collection is missing or it is not an auth collection")
+	}
+
+	if config.StripeCustomerField == "" || collection.Schema.GetFieldByName(config.StripeCustomerField) == nil {
+		return errors.New("the Stripe customer field is missing in the Stripe collection schema")
+	}
+
+	result := &StripeWebhookResult{
+		EventId: form.Event.Id,
+		Action:  StripeWebhookActionSkipped,
+	}
+
+	var record *models.Record
+
+	switch form.Event.Type {
+	case stripe.EventCheckoutSessionCompleted:
+		session := &stripe.CheckoutSession{}
+		if err := form.Event.UnmarshalObject(session); err != nil {
+			return err
+		}
+
+		if session.ClientReferenceId == "" || session.Customer == "" {
+			result.Reason = "missing client reference or customer"
+			break
+		}
+
+		record, _ = form.dao.FindRecordById(collection.Id, session.ClientReferenceId)
+		if record == nil {
+			result.Reason = "unknown client reference"
+			break
+		}
+
+		record.Set(config.StripeCustomerField, session.Customer)
+	case stripe.EventCustomerSubscriptionCreated,
+		stripe.EventCustomerSubscriptionUpdated,
+		stripe.EventCustomerSubscriptionDeleted:
+		sub := &stripe.Subscription{}
+		if err := form.Event.UnmarshalObject(sub); err != nil {
+			return err
+		}
+
+		if sub.Customer != "" {
+			record, _ = form.dao.FindFirstRecordByData(collection.Id, config.StripeCustomerField, sub.Customer)
+		}
+
+		if record == nil && sub.Metadata["recordId"] != "" {
+			record, _ = form.dao.FindRecordById(collection.Id, sub.Metadata["recordId"])
+			if record != nil && sub.Customer != "" {
+				record.Set(config.StripeCustomerField, sub.Customer)
+			}
+		}
+
+		if record == nil {
+			result.Reason = "unknown customer"
+			break
+		}
+
+		status := sub.Status
+		if status == "" && form.Event.Type == stripe.EventCustomerSubscriptionDeleted {
+			status = "canceled"
+		}
+
+		setSchemaValue(record, config.StripeStatusField, status)
+		setSchemaValue(record, config.StripePlanField, sub.PriceId())
+
+		if sub.CurrentPeriodEnd > 0 {
+			setSchemaValue(record, config.StripePeriodEndField, time.Unix(sub.CurrentPeriodEnd, 0).UTC())
+		}
+	default:
+		result.Reason = "unsupported event type"
+	}
+
+	if record != nil {
+		result.Action = StripeWebhookActionUpdated
+		result.RecordId = record.Id
+	}
+
+	return runInterceptors(result, func(result *StripeWebhookResult) error {
+		if record == nil {
+			return nil
+		}
+
+		return form.dao.SaveRecord(record)
+	}, interceptors...)
+}
+
+// setSchemaValue sets the record field value only if
+// the field is defined in the record collection schema.
+func setSchemaValue(record *models.Record, field string, value any) {
+	if field == "" || record.Collection().Schema.GetFieldByName(field) == nil {
+		return
+	}
+
+	record.Set(field, value)
+}
@@ -0,0 +1,218 @@
+package forms_test
+
+import (
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+// setupTestStripeCollection adds the Stripe subscription fields
+// (except the plan one) to the users collection and configures
+// it as the app billing Stripe collection.
+func setupTestStripeCollection(t *testing.T, app *tests.TestApp) {
+	collection, err := app.Dao().FindCollectionByNameOrId("users")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	collection.Schema.AddField(&schema.SchemaField{Name: "stripeCustomerId", Type: schema.FieldTypeText})
+	collection.Schema.AddField(&schema.SchemaField{Name: "subscriptionStatus", Type: schema.FieldTypeText})
+	collection.Schema.AddField(&schema.SchemaField{Name: "subscriptionPeriodEnd", Type: schema.FieldTypeDate})
+
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	record, err := app.Dao().FindRecordById("users", "oap640cot4yru2s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	record.Set("stripeCustomerId", "cus_test2")
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	app.Settings().Billing.StripeWebhookSecret = "whsec_test"
+	app.Settings().Billing.StripeCollection = "users"
+}
+
+func TestStripeWebhookValidateAndSubmit(t *testing.T) {
+	scenarios := []struct {
+		name             string
+		eventType        string
+		object           string
+		expectedErrors   []string
+		expectedAction   string
+		expectedRecordId string
+		expectedFields   map[string]string
+	}{
+		{
+			"empty",
+			"",
+			"",
+			[]string{"id", "type"},
+			"",
+			"",
+			nil,
+		},
+		{
+			"unsupported event type",
+			"invoice.paid",
+			`{"id":"in_test"}`,
+			[]string{},
+			forms.StripeWebhookActionSkipped,
+			"",
+			nil,
+		},
+		{
+			"checkout session with unknown client reference",
+			"checkout.session.completed",
+			`{"id":"cs_test","customer":"cus_test1","client_reference_id":"missing"}`,
+			[]string{},
+			forms.StripeWebhookActionSkipped,
+			"",
+			nil,
+		},
+		{
+			"checkout session",
+			"checkout.session.completed",
+			`{"id":"cs_test","customer":"cus_test1","client_reference_id":"4q1xlclmfloku33"}`,
+			[]string{},
+			forms.StripeWebhookActionUpdated,
+			"4q1xlclmfloku33",
+			map[string]string{"stripeCustomerId": "cus_test1"},
+		},
+		{
+			"subscription with unknown customer",
+			"customer.subscription.updated",
+			`{"id":"sub_test","customer":"cus_missing","status":"active"}`,
+			[]string{},
+			forms.StripeWebhookActionSkipped,
+			"",
+			nil,
+		},
+		{
+			"subscription with known customer",
+			"customer.subscription.updated",
+			`{"id":"sub_test","customer":"cus_test2","status":"past_due","current_period_end":1680307200,"items":{"data":[{"price":{"id":"price_test"}}]}}`,
+			[]string{},
+			forms.StripeWebhookActionUpdated,
+			"oap640cot4yru2s",
+			map[string]string{"stripeCustomerId": "cus_test2", "subscriptionStatus": "past_due", "subscriptionPeriodEnd": "2023-04-01 00:00:00.000Z"},
+		},
+		{
+			"subscription with recordId metadata",
+			"customer.subscription.created",
+			`{"id":"sub_test","customer":"cus_test3","status":"active","metadata":{"recordId":"bgs820n361vj1qd"}}`,
+			[]string{},
+			forms.StripeWebhookActionUpdated,
+			"bgs820n361vj1qd",
+			map[string]string{"stripeCustomerId": "cus_test3", "subscriptionStatus": "active"},
+		},
+		{
+			"deleted subscription",
+			"customer.subscription.deleted",
+			`{"id":"sub_test","customer":"cus_test2"}`,
+			[]string{},
+			forms.StripeWebhookActionUpdated,
+			"oap640cot4yru2s",
+			map[string]string{"subscriptionStatus": "canceled"},
+		},
+	}
+
+	for _, s := range scenarios {
+		func() {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			setupTestStripeCollection(t, app)
+
+			form := forms.NewStripeWebhook(app)
+			form.Event.Type = s.eventType
+			form.Event.Data.Object = []byte(s.object)
+			if s.eventType != "" {
+				form.Event.Id = "evt_test"
+			}
+
+			var result *forms.StripeWebhookResult
+
+			submitErr := form.Submit(func(next forms.InterceptorNextFunc[*forms.StripeWebhookResult]) forms.InterceptorNextFunc[*forms.StripeWebhookResult] {
+				return func(r *forms.StripeWebhookResult) error {
+					result = r
+					return next(r)
+				}
+			})
+
+			// parse errors
+			errs, ok := submitErr.(validation.Errors)
+			if !ok && submitErr != nil {
+				t.Errorf("[%s] Failed to parse errors %v", s.name, submitErr)
+				return
+			}
+
+			// check errors
+			if len(errs) > len(s.expectedErrors) {
+				t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+			}
+			for _, k := range s.expectedErrors {
+				if _, ok := errs[k]; !ok {
+					t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+				}
+			}
+
+			if len(s.expectedErrors) > 0 {
+				return
+			}
+
+			if result.Action != s.expectedAction || result.RecordId != s.expectedRecordId {
+				t.Errorf("[%s] Expected %s %q, got %s %q", s.name, s.expectedAction, s.expectedRecordId, result.Action, result.RecordId)
+				return
+			}
+
+			if s.expectedRecordId == "" {
+				return
+			}
+
+			record, err := app.Dao().FindRecordById("users", s.expectedRecordId)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for field, value := range s.expectedFields {
+				if v := record.GetString(field); v != value {
+					t.Errorf("[%s] Expected %s %q, got %q", s.name, field, value, v)
+				}
+			}
+		}()
+	}
+}
+
+func TestStripeWebhookSubmitInvalidCollection(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	form := forms.NewStripeWebhook(app)
+	form.Event.Id = "evt_test"
+	form.Event.Type = "customer.subscription.updated"
+
+	// missing collection
+	app.Settings().Billing.StripeCollection = "missing"
+	if err := form.Submit(); err == nil {
+		t.Fatal("Expected error for missing collection, got nil")
+	}
+
+	// non-auth collection
+	app.Settings().Billing.StripeCollection = "demo1"
+	if err := form.Submit(); err == nil {
+		t.Fatal("Expected error for non-auth collection, got nil")
+	}
+
+	// missing customer field
+	app.Settings().Billing.StripeCollection = "users"
+	if err := form.Submit(); err == nil {
+		t.Fatal("Expected error for missing customer field, got nil")
+	}
+}
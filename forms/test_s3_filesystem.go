@@ -58,13 +58,19 @@ func (form *TestS3Filesystem) Submit() error {
 		return errors.New("S3 storage filesystem is not enabled")
 	}
 
-	fsys, err := filesystem.NewS3(
+	httpClient, err := form.app.NewHttpClient(settings.EgressSubsystemS3)
+	if err != nil {
+		return fmt.Errorf("failed to initialize the S3 http client: %w", err)
+	}
+
+	fsys, err := filesystem.NewS3WithHttpClient(
 		s3Config.Bucket,
 		s3Config.Region,
 		s3Config.Endpoint,
 		s3Config.AccessKey,
-		s3Config.Secret,
+		form.app.Secrets().Resolve(s3Config.Secret),
 		s3Config.ForcePathStyle,
+		httpClient,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to initialize the S3 filesystem: %w", err)
@@ -14,6 +14,7 @@ import (
 	"github.com/pocketbase/pocketbase/models/schema"
 	"github.com/pocketbase/pocketbase/tools/filesystem"
 	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/locale"
 	"github.com/pocketbase/pocketbase/tools/types"
 )
 
@@ -76,7 +77,7 @@ func (validator *RecordDataValidator) Validate(data map[string]any) error {
 		value := field.PrepareValue(data[key])
 
 		// check required constraint
-		if field.Required && validation.Required.Validate(value) != nil {
+		if field.Required && isEmptyValue(value) {
 			errs[key] = requiredErr
 			continue
 		}
@@ -95,6 +96,17 @@ func (validator *RecordDataValidator) Validate(data map[string]any) error {
 	return errs
 }
 
+// isEmptyValue checks whether the provided normalized field value is empty.
+func isEmptyValue(value any) bool {
+	// the localized values are serialized as "{}" when empty
+	// so the default required rule can't be used with them
+	if localized, ok := value.(types.JsonMap); ok {
+		return len(localized) == 0
+	}
+
+	return validation.Required.Validate(value) != nil
+}
+
 func (validator *RecordDataValidator) checkFieldValue(field *schema.SchemaField, value any) error {
 	switch field.Type {
 	case schema.FieldTypeText:
@@ -119,12 +131,18 @@ func (validator *RecordDataValidator) checkFieldValue(field *schema.SchemaField,
 		return validator.checkFileValue(field, value)
 	case schema.FieldTypeRelation:
 		return validator.checkRelationValue(field, value)
+	case schema.FieldTypeGeoPoint:
+		return validator.checkGeoPointValue(field, value)
 	}
 
 	return nil
 }
 
 func (validator *RecordDataValidator) checkTextValue(field *schema.SchemaField, value any) error {
+	if localized, ok := value.(types.JsonMap); ok {
+		return validator.checkLocalizedValue(field, localized, validator.checkTextValue)
+	}
+
 	val, _ := value.(string)
 	if val == "" {
 		return nil // nothing to check (skip zero-defaults)
@@ -229,6 +247,37 @@ func (validator *RecordDataValidator) checkUrlValue(field *schema.SchemaField, v
 }
 
 func (validator *RecordDataValidator) checkEditorValue(field *schema.SchemaField, value any) error {
+	if localized, ok := value.(types.JsonMap); ok {
+		return validator.checkLocalizedValue(field, localized, validator.checkEditorValue)
+	}
+
+	return nil
+}
+
+// checkLocalizedValue validates the locale keys of the provided
+// localized field value and each locale value with checkFunc.
+func (validator *RecordDataValidator) checkLocalizedValue(
+	field *schema.SchemaField,
+	value types.JsonMap,
+	checkFunc func(field *schema.SchemaField, value any) error,
+) error {
+	errs := validation.Errors{}
+
+	for l, v := range value {
+		if l != locale.Any && !locale.IsValid(l) {
+			errs[l] = validation.NewError("validation_invalid_locale", "Invalid locale.")
+			continue
+		}
+
+		if err := checkFunc(field, v); err != nil {
+			errs[l] = err
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
 	return nil
 }
 
@@ -303,6 +352,22 @@ func (validator *RecordDataValidator) checkJsonValue(field *schema.SchemaField,
 	return nil
 }
 
+func (validator *RecordDataValidator) checkGeoPointValue(field *schema.SchemaField, value any) error {
+	point, ok := value.(types.GeoPoint)
+	if !ok {
+		return nil // nothing to check (no location)
+	}
+
+	if !point.IsValid() {
+		return validation.NewError(
+			"validation_invalid_geo_point",
+			"Must be a valid location (lat between -90 and 90 and lng between -180 and 180)",
+		)
+	}
+
+	return nil
+}
+
 func (validator *RecordDataValidator) checkFileValue(field *schema.SchemaField, value any) error {
 	names := list.ToUniqueStringSlice(value)
 	if len(names) == 0 && field.Required {
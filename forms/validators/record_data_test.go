@@ -152,6 +152,83 @@ func TestRecordDataValidatorValidateText(t *testing.T) {
 	checkValidatorErrors(t, app.Dao(), models.NewRecord(collection), scenarios)
 }
 
+func TestRecordDataValidatorValidateLocalized(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	// create new test collection
+	collection := &models.Collection{}
+	collection.Name = "validate_test"
+	max := 5
+	collection.Schema = schema.NewSchema(
+		&schema.SchemaField{
+			Name:    "field1",
+			Type:    schema.FieldTypeText,
+			Options: &schema.TextOptions{Localized: true},
+		},
+		&schema.SchemaField{
+			Name:     "field2",
+			Required: true,
+			Type:     schema.FieldTypeText,
+			Options: &schema.TextOptions{
+				Max:       &max,
+				Localized: true,
+			},
+		},
+		&schema.SchemaField{
+			Name:    "field3",
+			Type:    schema.FieldTypeEditor,
+			Options: &schema.EditorOptions{Localized: true},
+		},
+	)
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []testDataFieldScenario{
+		{
+			"(localized) check required constraint",
+			map[string]any{
+				"field1": nil,
+				"field2": map[string]any{"en": ""},
+				"field3": nil,
+			},
+			nil,
+			[]string{"field2"},
+		},
+		{
+			"(localized) check locale values constraints",
+			map[string]any{
+				"field2": map[string]any{"en": "test", "de": "too long"},
+			},
+			nil,
+			[]string{"field2"},
+		},
+		{
+			"(localized) check invalid locales",
+			map[string]any{
+				"field1": map[string]any{"en_US": "test"},
+				"field2": map[string]any{"en": "test"},
+				"field3": `{"invalid locale":"<p>test</p>"}`,
+			},
+			nil,
+			[]string{"field1", "field3"},
+		},
+		{
+			"(localized) valid data",
+			map[string]any{
+				"field1": "test", // stored under the "*" locale
+				"field2": `{"en":"test","de-AT":"test2"}`,
+				"field3": map[string]any{"en": "<p>test</p>"},
+			},
+			nil,
+			[]string{},
+		},
+	}
+
+	checkValidatorErrors(t, app.Dao(), models.NewRecord(collection), scenarios)
+}
+
 func TestRecordDataValidatorValidateNumber(t *testing.T) {
 	app, _ := tests.NewTestApp()
 	defer app.Cleanup()
@@ -1230,6 +1307,78 @@ func TestRecordDataValidatorValidateRelation(t *testing.T) {
 	checkValidatorErrors(t, app.Dao(), models.NewRecord(collection), scenarios)
 }
 
+func TestRecordDataValidatorValidateGeoPoint(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	// create new test collection
+	collection := &models.Collection{}
+	collection.Name = "validate_test"
+	collection.Schema = schema.NewSchema(
+		&schema.SchemaField{
+			Name: "field1",
+			Type: schema.FieldTypeGeoPoint,
+		},
+		&schema.SchemaField{
+			Name:     "field2",
+			Required: true,
+			Type:     schema.FieldTypeGeoPoint,
+		},
+	)
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []testDataFieldScenario{
+		{
+			"(geoPoint) check required constraint",
+			map[string]any{
+				"field1": nil,
+				"field2": nil,
+			},
+			nil,
+			[]string{"field2"},
+		},
+		{
+			"(geoPoint) check required constraint + casting",
+			map[string]any{
+				"field1": "invalid",
+				"field2": map[string]any{"lat": 1},
+			},
+			nil,
+			[]string{"field2"},
+		},
+		{
+			"(geoPoint) check coordinates range",
+			map[string]any{
+				"field1": "90.1,0",
+				"field2": map[string]any{"lat": 0, "lng": -180.1},
+			},
+			nil,
+			[]string{"field1", "field2"},
+		},
+		{
+			"(geoPoint) valid data (only required)",
+			map[string]any{
+				"field2": "0,0",
+			},
+			nil,
+			[]string{},
+		},
+		{
+			"(geoPoint) valid data (all)",
+			map[string]any{
+				"field1": types.GeoPoint{Lat: -90, Lng: 180},
+				"field2": `{"lat":42.6977,"lng":23.3219}`,
+			},
+			nil,
+			[]string{},
+		},
+	}
+
+	checkValidatorErrors(t, app.Dao(), models.NewRecord(collection), scenarios)
+}
+
 func checkValidatorErrors(t *testing.T, dao *daos.Dao, record *models.Record, scenarios []testDataFieldScenario) {
 	for i, s := range scenarios {
 		validator := validators.NewRecordDataValidator(dao, record, s.files)
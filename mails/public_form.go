@@ -0,0 +1,73 @@
+package mails
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"net/mail"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/mails/templates"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/mailer"
+)
+
+var publicFormTemplate = template.Must(template.New("public_form").Parse(
+	`<p>New <strong>{{.Form}}</strong> form submission ({{.Id}}):</p>` +
+		`<table>{{range .Fields}}<tr><th align="left">{{.Name}}</th><td>{{.Value}}</td></tr>{{end}}</table>`,
+))
+
+// SendPublicFormNotification sends a new public form submission
+// email with the submitted record fields to the specified recipients.
+func SendPublicFormNotification(app core.App, formName string, fields []string, record *models.Record, to []string) error {
+	if len(to) == 0 {
+		return nil
+	}
+
+	type field struct {
+		Name  string
+		Value string
+	}
+
+	params := struct {
+		Form   string
+		Id     string
+		Fields []field
+	}{
+		Form: formName,
+		Id:   record.Id,
+	}
+
+	for _, name := range fields {
+		params.Fields = append(params.Fields, field{Name: name, Value: fmt.Sprint(record.Get(name))})
+	}
+
+	var content bytes.Buffer
+	if err := publicFormTemplate.Execute(&content, params); err != nil {
+		return err
+	}
+
+	body, err := resolveTemplateContent(
+		struct{ HtmlContent template.HTML }{HtmlContent: template.HTML(content.String())},
+		templates.Layout,
+		templates.HtmlBody,
+	)
+	if err != nil {
+		return err
+	}
+
+	recipients := make([]mail.Address, len(to))
+	for i, address := range to {
+		recipients[i] = mail.Address{Address: address}
+	}
+
+	return app.NewMailClient().Send(&mailer.Message{
+		From: mail.Address{
+			Name:    app.Settings().Meta.SenderName,
+			Address: app.Settings().Meta.SenderAddress,
+		},
+		To:      recipients,
+		Subject: fmt.Sprintf("New %s form submission", formName),
+		HTML:    body,
+	})
+}
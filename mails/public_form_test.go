@@ -0,0 +1,62 @@
+package mails_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/mails"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestSendPublicFormNotification(t *testing.T) {
+	testApp, _ := tests.NewTestApp()
+	defer testApp.Cleanup()
+
+	collection, err := testApp.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record := models.NewRecord(collection)
+	record.Id = "test_record_id0"
+	record.Set("title", "<b>hello</b>")
+
+	// no recipients
+	if err := mails.SendPublicFormNotification(testApp, "contact", []string{"title"}, record, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if testApp.TestMailer.TotalSend != 0 {
+		t.Fatalf("Expected no emails to be sent, got %d", testApp.TestMailer.TotalSend)
+	}
+
+	err = mails.SendPublicFormNotification(testApp, "contact", []string{"title"}, record, []string{"a@example.com", "b@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if testApp.TestMailer.TotalSend != 1 {
+		t.Fatalf("Expected one email to be sent, got %d", testApp.TestMailer.TotalSend)
+	}
+
+	message := testApp.TestMailer.LastMessage
+
+	if len(message.To) != 2 || message.To[1].Address != "b@example.com" {
+		t.Fatalf("Unexpected recipients %v", message.To)
+	}
+
+	if message.Subject != "New contact form submission" {
+		t.Fatalf("Unexpected subject %q", message.Subject)
+	}
+
+	expectedParts := []string{
+		"test_record_id0",
+		"<th align=\"left\">title</th><td>&lt;b&gt;hello&lt;/b&gt;</td>",
+	}
+	for _, part := range expectedParts {
+		if !strings.Contains(message.HTML, part) {
+			t.Fatalf("Couldn't find %s \nin\n %s", part, message.HTML)
+		}
+	}
+}
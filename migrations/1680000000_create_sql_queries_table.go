@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Creates the _sqlQueries table used to store the admin SQL console saved queries.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_sqlQueries}} (
+				[[id]]          TEXT PRIMARY KEY NOT NULL,
+				[[name]]        TEXT NOT NULL,
+				[[description]] TEXT DEFAULT "" NOT NULL,
+				[[query]]       TEXT DEFAULT "" NOT NULL,
+				[[created]]     TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]     TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+			);
+
+			CREATE UNIQUE INDEX _sqlQueries_name_idx on {{_sqlQueries}} ([[name]] COLLATE NOCASE);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_sqlQueries").Execute()
+		return err
+	})
+}
@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Creates the _rateLimits table used to store the per identity rate limit overrides.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_rateLimits}} (
+				[[id]]          TEXT PRIMARY KEY NOT NULL,
+				[[identityId]]  TEXT NOT NULL,
+				[[maxRequests]] INTEGER DEFAULT 0 NOT NULL,
+				[[duration]]    INTEGER DEFAULT 0 NOT NULL,
+				[[note]]        TEXT DEFAULT "" NOT NULL,
+				[[created]]     TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]     TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+			);
+
+			CREATE UNIQUE INDEX _rateLimits_identityId_idx on {{_rateLimits}} ([[identityId]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_rateLimits").Execute()
+		return err
+	})
+}
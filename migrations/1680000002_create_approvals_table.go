@@ -0,0 +1,36 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Creates the _approvals table used to store the admin approval requests.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_approvals}} (
+				[[id]]             TEXT PRIMARY KEY NOT NULL,
+				[[action]]         TEXT NOT NULL,
+				[[method]]         TEXT NOT NULL,
+				[[url]]            TEXT NOT NULL,
+				[[body]]           TEXT DEFAULT "" NOT NULL,
+				[[status]]         TEXT NOT NULL,
+				[[requestedBy]]    TEXT NOT NULL,
+				[[decidedBy]]      TEXT DEFAULT "" NOT NULL,
+				[[expires]]        TEXT DEFAULT "" NOT NULL,
+				[[decidedAt]]      TEXT DEFAULT "" NOT NULL,
+				[[responseStatus]] INTEGER DEFAULT 0 NOT NULL,
+				[[response]]       TEXT DEFAULT "" NOT NULL,
+				[[created]]        TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]        TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+			);
+
+			CREATE INDEX _approvals_status_idx on {{_approvals}} ([[status]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_approvals").Execute()
+		return err
+	})
+}
@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Creates the _featureFlags table used to store the feature flags and their targeting rules.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_featureFlags}} (
+				[[id]]          TEXT PRIMARY KEY NOT NULL,
+				[[key]]         TEXT NOT NULL,
+				[[description]] TEXT DEFAULT "" NOT NULL,
+				[[enabled]]     BOOLEAN DEFAULT FALSE NOT NULL,
+				[[rollout]]     INTEGER DEFAULT 0 NOT NULL,
+				[[rules]]       JSON DEFAULT "[]" NOT NULL,
+				[[created]]     TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]     TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+			);
+
+			CREATE UNIQUE INDEX _featureFlags_key_idx on {{_featureFlags}} ([[key]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_featureFlags").Execute()
+		return err
+	})
+}
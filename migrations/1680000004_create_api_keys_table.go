@@ -0,0 +1,36 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Creates the _apiKeys table used to store the scoped admin and auth record api keys.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_apiKeys}} (
+				[[id]]           TEXT PRIMARY KEY NOT NULL,
+				[[name]]         TEXT NOT NULL,
+				[[prefix]]       TEXT NOT NULL,
+				[[keyHash]]      TEXT NOT NULL,
+				[[scopes]]       JSON DEFAULT "[]" NOT NULL,
+				[[adminId]]      TEXT DEFAULT "" NOT NULL,
+				[[collectionId]] TEXT DEFAULT "" NOT NULL,
+				[[recordId]]     TEXT DEFAULT "" NOT NULL,
+				[[expires]]      TEXT DEFAULT "" NOT NULL,
+				[[lastUsed]]     TEXT DEFAULT "" NOT NULL,
+				[[created]]      TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]      TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+			);
+
+			CREATE UNIQUE INDEX _apiKeys_prefix_idx on {{_apiKeys}} ([[prefix]]);
+			CREATE INDEX _apiKeys_adminId_idx on {{_apiKeys}} ([[adminId]]);
+			CREATE INDEX _apiKeys_recordId_idx on {{_apiKeys}} ([[recordId]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_apiKeys").Execute()
+		return err
+	})
+}
@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Creates the _settingsHistory table used to store the app settings changes.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_settingsHistory}} (
+				[[id]]       TEXT PRIMARY KEY NOT NULL,
+				[[version]]  INTEGER NOT NULL,
+				[[adminId]]  TEXT DEFAULT "" NOT NULL,
+				[[diff]]     JSON DEFAULT "{}" NOT NULL,
+				[[snapshot]] TEXT DEFAULT "" NOT NULL,
+				[[created]]  TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]  TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+			);
+
+			CREATE UNIQUE INDEX _settingsHistory_version_idx on {{_settingsHistory}} ([[version]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_settingsHistory").Execute()
+		return err
+	})
+}
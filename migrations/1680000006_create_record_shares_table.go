@@ -0,0 +1,38 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Creates the _recordShares table used to store the per-record ACL entries.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_recordShares}} (
+				[[id]]                  TEXT PRIMARY KEY NOT NULL,
+				[[collectionId]]        TEXT NOT NULL,
+				[[recordId]]            TEXT NOT NULL,
+				[[subjectType]]         TEXT NOT NULL,
+				[[subjectCollectionId]] TEXT NOT NULL,
+				[[subjectId]]           TEXT NOT NULL,
+				[[permission]]          TEXT NOT NULL,
+				[[created]]             TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]             TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+			);
+
+			CREATE UNIQUE INDEX _recordShares_record_subject_idx on {{_recordShares}} (
+				[[collectionId]],
+				[[recordId]],
+				[[subjectType]],
+				[[subjectCollectionId]],
+				[[subjectId]]
+			);
+			CREATE INDEX _recordShares_subject_idx on {{_recordShares}} ([[subjectCollectionId]], [[subjectId]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_recordShares").Execute()
+		return err
+	})
+}
@@ -0,0 +1,45 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Creates the _fileObjects and _fileRefs tables used to store
+// the deduplicated uploaded files and their references.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_fileObjects}} (
+				[[id]]      TEXT PRIMARY KEY NOT NULL,
+				[[hash]]    TEXT NOT NULL,
+				[[key]]     TEXT NOT NULL,
+				[[size]]    INTEGER DEFAULT 0 NOT NULL,
+				[[created]] TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]] TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+			);
+
+			CREATE UNIQUE INDEX _fileObjects_hash_idx on {{_fileObjects}} ([[hash]]);
+
+			CREATE TABLE {{_fileRefs}} (
+				[[id]]       TEXT PRIMARY KEY NOT NULL,
+				[[key]]      TEXT NOT NULL,
+				[[objectId]] TEXT NOT NULL,
+				[[created]]  TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]  TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				FOREIGN KEY ([[objectId]]) REFERENCES {{_fileObjects}} ([[id]]) ON UPDATE CASCADE ON DELETE CASCADE
+			);
+
+			CREATE UNIQUE INDEX _fileRefs_key_idx on {{_fileRefs}} ([[key]]);
+			CREATE INDEX _fileRefs_objectId_idx on {{_fileRefs}} ([[objectId]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		if _, err := db.DropTable("_fileRefs").Execute(); err != nil {
+			return err
+		}
+
+		_, err := db.DropTable("_fileObjects").Execute()
+		return err
+	})
+}
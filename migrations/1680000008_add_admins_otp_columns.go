@@ -0,0 +1,32 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Adds the _admins TOTP two-factor authentication columns.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		if _, err := db.AddColumn("_admins", "otpSecret", `TEXT DEFAULT "" NOT NULL`).Execute(); err != nil {
+			return err
+		}
+
+		if _, err := db.AddColumn("_admins", "otpEnabled", `BOOLEAN DEFAULT 0 NOT NULL`).Execute(); err != nil {
+			return err
+		}
+
+		_, err := db.AddColumn("_admins", "otpRecoveryCodes", `JSON DEFAULT "[]" NOT NULL`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		columns := []string{"otpRecoveryCodes", "otpEnabled", "otpSecret"}
+
+		for _, column := range columns {
+			if _, err := db.DropColumn("_admins", column).Execute(); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,59 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Creates the _orgs, _orgMembers and _orgInvites tables
+// used to store the organizations and their memberships.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_orgs}} (
+				[[id]]      TEXT PRIMARY KEY NOT NULL,
+				[[name]]    TEXT NOT NULL,
+				[[slug]]    TEXT UNIQUE NOT NULL,
+				[[created]] TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]] TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+			);
+
+			CREATE TABLE {{_orgMembers}} (
+				[[id]]           TEXT PRIMARY KEY NOT NULL,
+				[[orgId]]        TEXT NOT NULL,
+				[[collectionId]] TEXT NOT NULL,
+				[[recordId]]     TEXT NOT NULL,
+				[[role]]         TEXT NOT NULL,
+				[[created]]      TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]      TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+			);
+
+			CREATE UNIQUE INDEX _orgMembers_org_record_idx on {{_orgMembers}} ([[orgId]], [[collectionId]], [[recordId]]);
+			CREATE INDEX _orgMembers_record_idx on {{_orgMembers}} ([[collectionId]], [[recordId]]);
+
+			CREATE TABLE {{_orgInvites}} (
+				[[id]]      TEXT PRIMARY KEY NOT NULL,
+				[[orgId]]   TEXT NOT NULL,
+				[[email]]   TEXT NOT NULL,
+				[[role]]    TEXT NOT NULL,
+				[[expires]] TEXT DEFAULT "" NOT NULL,
+				[[created]] TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]] TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+			);
+
+			CREATE UNIQUE INDEX _orgInvites_org_email_idx on {{_orgInvites}} ([[orgId]], [[email]]);
+			CREATE INDEX _orgInvites_email_idx on {{_orgInvites}} ([[email]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		tables := []string{"_orgInvites", "_orgMembers", "_orgs"}
+
+		for _, name := range tables {
+			if _, err := db.DropTable(name).Execute(); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Creates the _usage table used to store the
+// monthly billable events counters.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_usage}} (
+				[[id]]           TEXT PRIMARY KEY NOT NULL,
+				[[period]]       TEXT NOT NULL,
+				[[metric]]       TEXT NOT NULL,
+				[[orgId]]        TEXT DEFAULT "" NOT NULL,
+				[[collectionId]] TEXT DEFAULT "" NOT NULL,
+				[[recordId]]     TEXT DEFAULT "" NOT NULL,
+				[[value]]        INTEGER DEFAULT 0 NOT NULL,
+				[[created]]      TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]      TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+			);
+
+			CREATE UNIQUE INDEX _usage_counter_idx on {{_usage}} ([[period]], [[metric]], [[orgId]], [[collectionId]], [[recordId]]);
+			CREATE INDEX _usage_org_idx on {{_usage}} ([[orgId]], [[period]]);
+			CREATE INDEX _usage_record_idx on {{_usage}} ([[collectionId]], [[recordId]], [[period]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_usage").Execute()
+		return err
+	})
+}
@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Creates the _comments table used to store the record comment threads.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_comments}} (
+				[[id]]                 TEXT PRIMARY KEY NOT NULL,
+				[[collectionId]]       TEXT NOT NULL,
+				[[recordId]]           TEXT NOT NULL,
+				[[parentId]]           TEXT DEFAULT "" NOT NULL,
+				[[authorCollectionId]] TEXT NOT NULL,
+				[[authorId]]           TEXT NOT NULL,
+				[[message]]            TEXT NOT NULL,
+				[[status]]             TEXT NOT NULL,
+				[[mentions]]           JSON DEFAULT "[]" NOT NULL,
+				[[created]]            TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]            TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+			);
+
+			CREATE INDEX _comments_record_idx on {{_comments}} ([[collectionId]], [[recordId]], [[created]]);
+			CREATE INDEX _comments_parent_idx on {{_comments}} ([[parentId]]);
+			CREATE INDEX _comments_author_idx on {{_comments}} ([[authorCollectionId]], [[authorId]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_comments").Execute()
+		return err
+	})
+}
@@ -0,0 +1,31 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Creates the _recordLocks table used to store the record edit leases.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_recordLocks}} (
+				[[id]]                 TEXT PRIMARY KEY NOT NULL,
+				[[collectionId]]       TEXT NOT NULL,
+				[[recordId]]           TEXT NOT NULL,
+				[[holderType]]         TEXT NOT NULL,
+				[[holderCollectionId]] TEXT DEFAULT "" NOT NULL,
+				[[holderId]]           TEXT NOT NULL,
+				[[expires]]            TEXT DEFAULT "" NOT NULL,
+				[[created]]            TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]            TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+			);
+
+			CREATE UNIQUE INDEX _recordLocks_record_idx on {{_recordLocks}} ([[collectionId]], [[recordId]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_recordLocks").Execute()
+		return err
+	})
+}
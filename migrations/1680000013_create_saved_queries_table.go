@@ -0,0 +1,36 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Creates the _savedQueries table used to store the named collection records list presets.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_savedQueries}} (
+				[[id]]                TEXT PRIMARY KEY NOT NULL,
+				[[collectionId]]      TEXT NOT NULL,
+				[[name]]              TEXT NOT NULL,
+				[[filter]]            TEXT DEFAULT "" NOT NULL,
+				[[sort]]              TEXT DEFAULT "" NOT NULL,
+				[[fields]]            TEXT DEFAULT "" NOT NULL,
+				[[expand]]            TEXT DEFAULT "" NOT NULL,
+				[[shared]]            BOOLEAN DEFAULT FALSE NOT NULL,
+				[[ownerType]]         TEXT NOT NULL,
+				[[ownerCollectionId]] TEXT DEFAULT "" NOT NULL,
+				[[ownerId]]           TEXT NOT NULL,
+				[[created]]           TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]           TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+			);
+
+			CREATE UNIQUE INDEX _savedQueries_name_idx on {{_savedQueries}} ([[collectionId]], [[name]]);
+			CREATE INDEX _savedQueries_owner_idx on {{_savedQueries}} ([[ownerCollectionId]], [[ownerId]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_savedQueries").Execute()
+		return err
+	})
+}
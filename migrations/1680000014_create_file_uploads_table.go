@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Creates the _fileUploads table used to track the resumable record file uploads.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_fileUploads}} (
+				[[id]]                TEXT PRIMARY KEY NOT NULL,
+				[[collectionId]]      TEXT NOT NULL,
+				[[recordId]]          TEXT NOT NULL,
+				[[field]]             TEXT NOT NULL,
+				[[filename]]          TEXT NOT NULL,
+				[[size]]              INTEGER DEFAULT 0 NOT NULL,
+				[[offset]]            INTEGER DEFAULT 0 NOT NULL,
+				[[ownerType]]         TEXT NOT NULL,
+				[[ownerCollectionId]] TEXT DEFAULT "" NOT NULL,
+				[[ownerId]]           TEXT NOT NULL,
+				[[created]]           TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]           TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+			);
+
+			CREATE INDEX _fileUploads_updated_idx on {{_fileUploads}} ([[updated]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_fileUploads").Execute()
+		return err
+	})
+}
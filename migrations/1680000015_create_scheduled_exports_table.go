@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Creates the _scheduledExports table used to store the recurring collection records exports.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_scheduledExports}} (
+				[[id]]           TEXT PRIMARY KEY NOT NULL,
+				[[name]]         TEXT NOT NULL,
+				[[collectionId]] TEXT NOT NULL,
+				[[filter]]       TEXT DEFAULT "" NOT NULL,
+				[[sort]]         TEXT DEFAULT "" NOT NULL,
+				[[format]]       TEXT NOT NULL,
+				[[schedule]]     TEXT NOT NULL,
+				[[destination]]  TEXT NOT NULL,
+				[[prefix]]       TEXT DEFAULT "" NOT NULL,
+				[[emails]]       JSON DEFAULT "[]" NOT NULL,
+				[[enabled]]      BOOLEAN DEFAULT FALSE NOT NULL,
+				[[lastRun]]      TEXT DEFAULT "" NOT NULL,
+				[[lastFile]]     TEXT DEFAULT "" NOT NULL,
+				[[lastError]]    TEXT DEFAULT "" NOT NULL,
+				[[created]]      TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]      TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+			);
+
+			CREATE UNIQUE INDEX _scheduledExports_name_idx on {{_scheduledExports}} ([[name]]);
+			CREATE INDEX _scheduledExports_collectionId_idx on {{_scheduledExports}} ([[collectionId]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_scheduledExports").Execute()
+		return err
+	})
+}
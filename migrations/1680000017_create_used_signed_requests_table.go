@@ -0,0 +1,26 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Creates the _usedSignedRequests table used to prevent the signed requests replay.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_usedSignedRequests}} (
+				[[id]]      TEXT PRIMARY KEY NOT NULL,
+				[[expires]] TEXT DEFAULT "" NOT NULL,
+				[[created]] TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]] TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+			);
+
+			CREATE INDEX _usedSignedRequests_expires_idx on {{_usedSignedRequests}} ([[expires]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_usedSignedRequests").Execute()
+		return err
+	})
+}
@@ -0,0 +1,18 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Adds the _admins last accepted TOTP time step column.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.AddColumn("_admins", "otpLastStep", `INTEGER DEFAULT 0 NOT NULL`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropColumn("_admins", "otpLastStep").Execute()
+
+		return err
+	})
+}
@@ -0,0 +1,35 @@
+package logs
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Creates the _auditLogs table used to store the admins, collections and settings changes.
+func init() {
+	LogsMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_auditLogs}} (
+				[[id]]         TEXT PRIMARY KEY NOT NULL,
+				[[action]]     TEXT DEFAULT "" NOT NULL,
+				[[resource]]   TEXT DEFAULT "" NOT NULL,
+				[[resourceId]] TEXT DEFAULT "" NOT NULL,
+				[[actor]]      TEXT DEFAULT "guest" NOT NULL,
+				[[actorId]]    TEXT DEFAULT "" NOT NULL,
+				[[userIp]]     TEXT DEFAULT "" NOT NULL,
+				[[url]]        TEXT DEFAULT "" NOT NULL,
+				[[changes]]    JSON DEFAULT "{}" NOT NULL,
+				[[created]]    TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]    TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+			);
+
+			CREATE INDEX _auditLogs_resource_idx on {{_auditLogs}} ([[resource]], [[resourceId]]);
+			CREATE INDEX _auditLogs_actor_idx on {{_auditLogs}} ([[actor]], [[actorId]]);
+			CREATE INDEX _auditLogs_created_idx on {{_auditLogs}} ([[created]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_auditLogs").Execute()
+		return err
+	})
+}
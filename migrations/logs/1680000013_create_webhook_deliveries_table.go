@@ -0,0 +1,35 @@
+package logs
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Creates the _webhookDeliveries table used to store the webhook event delivery logs.
+func init() {
+	LogsMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_webhookDeliveries}} (
+				[[id]]             TEXT PRIMARY KEY NOT NULL,
+				[[webhook]]        TEXT DEFAULT "" NOT NULL,
+				[[event]]          TEXT DEFAULT "" NOT NULL,
+				[[url]]            TEXT DEFAULT "" NOT NULL,
+				[[payload]]        JSON DEFAULT "{}" NOT NULL,
+				[[status]]         TEXT DEFAULT "pending" NOT NULL,
+				[[attempts]]       INTEGER DEFAULT 0 NOT NULL,
+				[[responseStatus]] INTEGER DEFAULT 0 NOT NULL,
+				[[error]]          TEXT DEFAULT "" NOT NULL,
+				[[created]]        TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]        TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+			);
+
+			CREATE INDEX _webhookDeliveries_webhook_idx on {{_webhookDeliveries}} ([[webhook]], [[event]]);
+			CREATE INDEX _webhookDeliveries_status_idx on {{_webhookDeliveries}} ([[status]]);
+			CREATE INDEX _webhookDeliveries_created_idx on {{_webhookDeliveries}} ([[created]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_webhookDeliveries").Execute()
+		return err
+	})
+}
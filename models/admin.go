@@ -1,7 +1,12 @@
 package models
 
 import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
 	"errors"
+	"strings"
+	"time"
 
 	"github.com/pocketbase/pocketbase/tools/security"
 	"github.com/pocketbase/pocketbase/tools/types"
@@ -20,6 +25,20 @@ type Admin struct {
 	TokenKey        string         `db:"tokenKey" json:"-"`
 	PasswordHash    string         `db:"passwordHash" json:"-"`
 	LastResetSentAt types.DateTime `db:"lastResetSentAt" json:"-"`
+
+	// OtpSecret is the base32 encoded TOTP secret (set on enrollment).
+	OtpSecret string `db:"otpSecret" json:"-"`
+
+	// OtpEnabled indicates whether a TOTP code is required
+	// on sign-in (aka. whether the enrollment was verified).
+	OtpEnabled bool `db:"otpEnabled" json:"otpEnabled"`
+
+	// OtpRecoveryCodes is the list with the hashes of the unused recovery codes.
+	OtpRecoveryCodes types.JsonArray[string] `db:"otpRecoveryCodes" json:"-"`
+
+	// OtpLastStep is the time step of the last accepted TOTP code
+	// (the codes of the same or earlier time steps are rejected).
+	OtpLastStep int64 `db:"otpLastStep" json:"-"`
 }
 
 // TableName returns the Admin model SQL table name.
@@ -60,6 +79,53 @@ func (m *Admin) SetPassword(password string) error {
 	return m.RefreshTokenKey()
 }
 
+// ValidateOtpCode validates a TOTP code or an unused recovery code
+// against the model's OTP secret and recovery codes.
+//
+// A TOTP code is accepted only once - its time step is stored in the
+// model's OtpLastStep and the matched recovery code is removed from
+// the model's recovery codes (the model still needs to be persisted).
+func (m *Admin) ValidateOtpCode(code string) bool {
+	if m.OtpSecret == "" {
+		return false
+	}
+
+	if step, ok := security.MatchTOTP(m.OtpSecret, code, time.Now()); ok {
+		if step <= m.OtpLastStep {
+			return false // already used
+		}
+
+		m.OtpLastStep = step
+
+		return true
+	}
+
+	hash := HashOtpRecoveryCode(code)
+	for i, h := range m.OtpRecoveryCodes {
+		if subtle.ConstantTimeCompare([]byte(h), []byte(hash)) == 1 {
+			m.OtpRecoveryCodes = append(m.OtpRecoveryCodes[:i:i], m.OtpRecoveryCodes[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// ResetOtp clears all of the model's OTP fields.
+func (m *Admin) ResetOtp() {
+	m.OtpSecret = ""
+	m.OtpEnabled = false
+	m.OtpRecoveryCodes = nil
+	m.OtpLastStep = 0
+}
+
+// HashOtpRecoveryCode returns the hex encoded sha256 hash of the provided recovery code.
+func HashOtpRecoveryCode(code string) string {
+	h := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(code))))
+
+	return hex.EncodeToString(h[:])
+}
+
 // RefreshTokenKey generates and sets new random token key.
 func (m *Admin) RefreshTokenKey() error {
 	m.TokenKey = security.RandomString(50)
@@ -2,8 +2,10 @@ package models_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/security"
 	"github.com/pocketbase/pocketbase/tools/types"
 )
 
@@ -102,3 +104,66 @@ func TestAdminRefreshTokenKey(t *testing.T) {
 		t.Fatalf("Expected TokenKey to change, got %q", m.TokenKey)
 	}
 }
+
+func TestAdminValidateOtpCode(t *testing.T) {
+	secret := security.NewTOTPSecret()
+	code, err := security.TOTPCode(secret, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := models.Admin{
+		OtpSecret:        secret,
+		OtpRecoveryCodes: []string{models.HashOtpRecoveryCode("abc"), models.HashOtpRecoveryCode("def")},
+	}
+
+	scenarios := []struct {
+		code          string
+		expected      bool
+		expectedTotal int
+	}{
+		{"", false, 2},
+		{"000000" + code, false, 2},
+		{code, true, 2},
+		// already used
+		{code, false, 2},
+		{"missing", false, 2},
+		{" ABC ", true, 1},
+		// already used
+		{"abc", false, 1},
+		{"def", true, 0},
+	}
+
+	for i, s := range scenarios {
+		result := m.ValidateOtpCode(s.code)
+
+		if result != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, result)
+		}
+
+		if total := len(m.OtpRecoveryCodes); total != s.expectedTotal {
+			t.Errorf("(%d) Expected %d recovery codes, got %d", i, s.expectedTotal, total)
+		}
+	}
+
+	// missing secret
+	empty := models.Admin{OtpRecoveryCodes: []string{models.HashOtpRecoveryCode("abc")}}
+	if empty.ValidateOtpCode("abc") {
+		t.Fatal("Expected the code to be invalid for admin without OTP secret")
+	}
+}
+
+func TestAdminResetOtp(t *testing.T) {
+	m := models.Admin{
+		OtpSecret:        "test",
+		OtpEnabled:       true,
+		OtpRecoveryCodes: []string{"test"},
+		OtpLastStep:      1,
+	}
+
+	m.ResetOtp()
+
+	if m.OtpSecret != "" || m.OtpEnabled || len(m.OtpRecoveryCodes) != 0 || m.OtpLastStep != 0 {
+		t.Fatalf("Expected the OTP fields to be cleared, got %v", m)
+	}
+}
@@ -0,0 +1,106 @@
+package models
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+var _ Model = (*ApiKey)(nil)
+
+const (
+	// ApiKeyPrefix is the common prefix of all generated api keys.
+	ApiKeyPrefix = "pbk_"
+
+	// ApiKeyScopeAll is the wildcard scope that grants access to all api key scopes.
+	ApiKeyScopeAll = "*"
+
+	apiKeyLookupLength = 12
+	apiKeySecretLength = 40
+)
+
+// ApiKey defines a scoped api key that acts on behalf of an admin
+// or an auth record (see the "X-API-Key" request header).
+//
+// Only the key prefix and hash are stored, aka. the plain key
+// is available only once after [ApiKey.RefreshKey()].
+type ApiKey struct {
+	BaseModel
+
+	Name string `db:"name" json:"name"`
+
+	// Prefix is the first characters of the plain key used for the
+	// key lookup and to help identifying the key in the admin UI.
+	Prefix  string `db:"prefix" json:"prefix"`
+	KeyHash string `db:"keyHash" json:"-"`
+
+	Scopes types.JsonArray[string] `db:"scopes" json:"scopes"`
+
+	// AdminId is the id of the admin that the key acts as
+	// (empty if the key belongs to an auth record).
+	AdminId string `db:"adminId" json:"adminId"`
+
+	// CollectionId and RecordId are the auth record that the key
+	// acts as (empty if the key belongs to an admin).
+	CollectionId string `db:"collectionId" json:"collectionId"`
+	RecordId     string `db:"recordId" json:"recordId"`
+
+	// Expires is the optional key expiration date (zero means never).
+	Expires  types.DateTime `db:"expires" json:"expires"`
+	LastUsed types.DateTime `db:"lastUsed" json:"lastUsed"`
+}
+
+func (m *ApiKey) TableName() string {
+	return "_apiKeys"
+}
+
+// RefreshKey generates a new random api key, updates the
+// model Prefix and KeyHash fields and returns the plain key.
+func (m *ApiKey) RefreshKey() string {
+	key := ApiKeyPrefix + security.RandomString(apiKeySecretLength)
+
+	m.Prefix = ApiKeyLookupPrefix(key)
+	m.KeyHash = hashApiKey(key)
+
+	return key
+}
+
+// ValidateKey checks whether the provided plain key matches the model key hash.
+func (m *ApiKey) ValidateKey(key string) bool {
+	return m.KeyHash != "" && subtle.ConstantTimeCompare([]byte(m.KeyHash), []byte(hashApiKey(key))) == 1
+}
+
+// IsExpired checks whether the api key has a past expiration date.
+func (m *ApiKey) IsExpired() bool {
+	return !m.Expires.IsZero() && m.Expires.Time().Before(time.Now())
+}
+
+// IsRecordKey checks whether the api key acts as an auth record (otherwise as an admin).
+func (m *ApiKey) IsRecordKey() bool {
+	return m.RecordId != ""
+}
+
+// HasScope checks whether the api key grants access to the provided scope.
+func (m *ApiKey) HasScope(scope string) bool {
+	return list.ExistInSlice(ApiKeyScopeAll, m.Scopes) || list.ExistInSlice(scope, m.Scopes)
+}
+
+// ApiKeyLookupPrefix returns the stored lookup prefix of the provided plain key.
+func ApiKeyLookupPrefix(key string) string {
+	if len(key) <= apiKeyLookupLength || !strings.HasPrefix(key, ApiKeyPrefix) {
+		return ""
+	}
+
+	return key[:apiKeyLookupLength]
+}
+
+func hashApiKey(key string) string {
+	h := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,117 @@
+package models_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestApiKeyTableName(t *testing.T) {
+	m := models.ApiKey{}
+	if m.TableName() != "_apiKeys" {
+		t.Fatalf("Unexpected table name, got %q", m.TableName())
+	}
+}
+
+func TestApiKeyRefreshKey(t *testing.T) {
+	m := models.ApiKey{}
+
+	key := m.RefreshKey()
+
+	if !strings.HasPrefix(key, models.ApiKeyPrefix) || len(key) != 44 {
+		t.Fatalf("Expected %q prefixed key with 44 characters, got %q", models.ApiKeyPrefix, key)
+	}
+
+	if m.Prefix != key[:12] {
+		t.Fatalf("Expected prefix %q, got %q", key[:12], m.Prefix)
+	}
+
+	if m.KeyHash == "" || strings.Contains(m.KeyHash, key) {
+		t.Fatalf("Expected the key hash to be set, got %q", m.KeyHash)
+	}
+
+	if !m.ValidateKey(key) {
+		t.Fatal("Expected the generated key to be valid")
+	}
+
+	if m.ValidateKey(key + "a") {
+		t.Fatal("Expected the modified key to be invalid")
+	}
+
+	if newKey := m.RefreshKey(); newKey == key || m.ValidateKey(key) {
+		t.Fatal("Expected the old key to be invalidated")
+	}
+}
+
+func TestApiKeyValidateKeyWithoutHash(t *testing.T) {
+	m := models.ApiKey{}
+
+	if m.ValidateKey("") {
+		t.Fatal("Expected the key to be invalid")
+	}
+}
+
+func TestApiKeyIsExpired(t *testing.T) {
+	past, _ := types.ParseDateTime(time.Now().Add(-time.Minute))
+	future, _ := types.ParseDateTime(time.Now().Add(time.Minute))
+
+	scenarios := []struct {
+		expires  types.DateTime
+		expected bool
+	}{
+		{types.DateTime{}, false},
+		{past, true},
+		{future, false},
+	}
+
+	for i, s := range scenarios {
+		m := models.ApiKey{Expires: s.expires}
+
+		if result := m.IsExpired(); result != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, result)
+		}
+	}
+}
+
+func TestApiKeyHasScope(t *testing.T) {
+	scenarios := []struct {
+		scopes   []string
+		scope    string
+		expected bool
+	}{
+		{nil, "users", false},
+		{[]string{"flags"}, "users", false},
+		{[]string{"flags", "users"}, "users", true},
+		{[]string{models.ApiKeyScopeAll}, "users", true},
+	}
+
+	for i, s := range scenarios {
+		m := models.ApiKey{Scopes: s.scopes}
+
+		if result := m.HasScope(s.scope); result != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, result)
+		}
+	}
+}
+
+func TestApiKeyLookupPrefix(t *testing.T) {
+	scenarios := []struct {
+		key      string
+		expected string
+	}{
+		{"", ""},
+		{"pbk_", ""},
+		{"pbk_12345678", ""},
+		{"abc_123456789", ""},
+		{"pbk_123456789", "pbk_12345678"},
+	}
+
+	for i, s := range scenarios {
+		if result := models.ApiKeyLookupPrefix(s.key); result != s.expected {
+			t.Errorf("(%d) Expected %q, got %q", i, s.expected, result)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package models
+
+import "github.com/pocketbase/pocketbase/tools/types"
+
+var _ Model = (*Approval)(nil)
+
+// List of the approval request statuses.
+const (
+	ApprovalStatusPending  = "pending"
+	ApprovalStatusApproved = "approved"
+	ApprovalStatusRejected = "rejected"
+	ApprovalStatusExpired  = "expired"
+	ApprovalStatusFailed   = "failed"
+)
+
+// Approval defines a sensitive admin request (eg. settings update)
+// that is held until confirmed by a second admin.
+//
+// The Approval model also serves as an audit entry for the request,
+// storing who requested and decided it, and the execution response.
+type Approval struct {
+	BaseModel
+
+	Action string `db:"action" json:"action"`
+	Method string `db:"method" json:"method"`
+	Url    string `db:"url" json:"url"`
+	Body   string `db:"body" json:"body"`
+	Status string `db:"status" json:"status"`
+
+	// RequestedBy and DecidedBy are the ids of the requester
+	// and of the approving (or rejecting) admins.
+	RequestedBy string `db:"requestedBy" json:"requestedBy"`
+	DecidedBy   string `db:"decidedBy" json:"decidedBy"`
+
+	Expires   types.DateTime `db:"expires" json:"expires"`
+	DecidedAt types.DateTime `db:"decidedAt" json:"decidedAt"`
+
+	// ResponseStatus and Response are the result of the executed request.
+	ResponseStatus int    `db:"responseStatus" json:"responseStatus"`
+	Response       string `db:"response" json:"response"`
+}
+
+func (m *Approval) TableName() string {
+	return "_approvals"
+}
+
+// IsExpired reports whether the approval request has expired.
+func (m *Approval) IsExpired() bool {
+	return !m.Expires.IsZero() && m.Expires.Time().Before(types.NowDateTime().Time())
+}
@@ -0,0 +1,37 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestApprovalTableName(t *testing.T) {
+	m := models.Approval{}
+	if m.TableName() != "_approvals" {
+		t.Fatalf("Unexpected table name, got %q", m.TableName())
+	}
+}
+
+func TestApprovalIsExpired(t *testing.T) {
+	past, _ := types.ParseDateTime(time.Now().Add(-1 * time.Minute))
+	future, _ := types.ParseDateTime(time.Now().Add(1 * time.Minute))
+
+	scenarios := []struct {
+		expires  types.DateTime
+		expected bool
+	}{
+		{types.DateTime{}, false},
+		{past, true},
+		{future, false},
+	}
+
+	for i, s := range scenarios {
+		m := models.Approval{Expires: s.expires}
+		if v := m.IsExpired(); v != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, v)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package models
+
+import "github.com/pocketbase/pocketbase/tools/types"
+
+var _ Model = (*AuditLog)(nil)
+
+// list with the supported values for `AuditLog.Action`
+const (
+	AuditActionCreate  = "create"
+	AuditActionUpdate  = "update"
+	AuditActionDelete  = "delete"
+	AuditActionExecute = "execute"
+)
+
+// list with the supported values for `AuditLog.Resource`
+const (
+	AuditResourceAdmins      = "admins"
+	AuditResourceCollections = "collections"
+	AuditResourceSettings    = "settings"
+	AuditResourceSqlConsole  = "sqlConsole"
+)
+
+// AuditLog defines a single admin mutation (or SQL console execution) audit entry.
+type AuditLog struct {
+	BaseModel
+
+	Action     string `db:"action" json:"action"`
+	Resource   string `db:"resource" json:"resource"`
+	ResourceId string `db:"resourceId" json:"resourceId"`
+
+	// Actor is the request auth type (see `Request.Auth`).
+	Actor   string `db:"actor" json:"actor"`
+	ActorId string `db:"actorId" json:"actorId"`
+
+	UserIp string `db:"userIp" json:"userIp"`
+	Url    string `db:"url" json:"url"`
+
+	// Changes holds the old and new values of the changed
+	// fields indexed by their json path (eg. "options.minLength").
+	//
+	// For the SQL console executions it holds the executed query,
+	// its params and outcome (eg. "sqlQuery", "sqlRows", "sqlError").
+	Changes types.JsonMap `db:"changes" json:"changes"`
+}
+
+func (m *AuditLog) TableName() string {
+	return "_auditLogs"
+}
@@ -6,6 +6,8 @@ import (
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
 	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/cron"
+	"github.com/pocketbase/pocketbase/tools/list"
 	"github.com/pocketbase/pocketbase/tools/types"
 )
 
@@ -73,6 +75,58 @@ func (m Collection) MarshalJSON() ([]byte, error) {
 	return json.Marshal(alias(m))
 }
 
+// IsSoftDelete checks if the current collection has the soft delete
+// option enabled (aka. the deleted records are only marked with "deletedAt").
+func (m *Collection) IsSoftDelete() bool {
+	if m.IsView() {
+		return false
+	}
+
+	v, _ := m.Options["softDelete"].(bool)
+
+	return v
+}
+
+// SearchableFields returns the names of the collection fields that
+// are indexed in the collection full text search table (if any).
+func (m *Collection) SearchableFields() []string {
+	if m.IsView() {
+		return nil
+	}
+
+	return list.ToUniqueStringSlice(m.Options["searchableFields"])
+}
+
+// FTSTableName returns the name of the collection full text search
+// table (it is based on the collection id to be unaffected by renames).
+func (m *Collection) FTSTableName() string {
+	return "_fts_" + m.Id
+}
+
+// GeoPointFields returns the names of the collection geoPoint fields
+// that are indexed in the collection geo R-tree table (if any).
+func (m *Collection) GeoPointFields() []string {
+	if m.IsView() {
+		return nil
+	}
+
+	var result []string
+
+	for _, field := range m.Schema.Fields() {
+		if field.Type == schema.FieldTypeGeoPoint {
+			result = append(result, field.Name)
+		}
+	}
+
+	return result
+}
+
+// GeoTableName returns the name of the collection geo R-tree
+// table (it is based on the collection id to be unaffected by renames).
+func (m *Collection) GeoTableName() string {
+	return "_geo_" + m.Id
+}
+
 // BaseOptions decodes the current collection options and returns them
 // as new [CollectionBaseOptions] instance.
 func (m *Collection) BaseOptions() CollectionBaseOptions {
@@ -162,6 +216,13 @@ func (m *Collection) SetOptions(typedOptions any) error {
 
 // CollectionBaseOptions defines the "base" Collection.Options fields.
 type CollectionBaseOptions struct {
+	// SoftDelete moves the deleted records to the collection trash
+	// (see [Collection.IsSoftDelete]).
+	SoftDelete bool `form:"softDelete" json:"softDelete,omitempty"`
+
+	// SearchableFields are the names of the fields indexed for
+	// full text search (see [Collection.SearchableFields]).
+	SearchableFields []string `form:"searchableFields" json:"searchableFields,omitempty"`
 }
 
 // Validate implements [validation.Validatable] interface.
@@ -181,6 +242,14 @@ type CollectionAuthOptions struct {
 	ExceptEmailDomains []string `form:"exceptEmailDomains" json:"exceptEmailDomains"`
 	OnlyEmailDomains   []string `form:"onlyEmailDomains" json:"onlyEmailDomains"`
 	MinPasswordLength  int      `form:"minPasswordLength" json:"minPasswordLength"`
+
+	// SoftDelete moves the deleted records to the collection trash
+	// (see [Collection.IsSoftDelete]).
+	SoftDelete bool `form:"softDelete" json:"softDelete,omitempty"`
+
+	// SearchableFields are the names of the fields indexed for
+	// full text search (see [Collection.SearchableFields]).
+	SearchableFields []string `form:"searchableFields" json:"searchableFields,omitempty"`
 }
 
 // Validate implements [validation.Validatable] interface.
@@ -209,11 +278,37 @@ func (o CollectionAuthOptions) Validate() error {
 // CollectionViewOptions defines the "view" Collection.Options fields.
 type CollectionViewOptions struct {
 	Query string `form:"query" json:"query"`
+
+	// Materialized indicates whether the query results should be
+	// stored in a regular table instead of a SQL view.
+	//
+	// The stored results are updated only on explicit refresh
+	// (or periodically if RefreshCron is set).
+	Materialized bool   `form:"materialized" json:"materialized,omitempty"`
+	RefreshCron  string `form:"refreshCron" json:"refreshCron,omitempty"`
 }
 
 // Validate implements [validation.Validatable] interface.
 func (o CollectionViewOptions) Validate() error {
 	return validation.ValidateStruct(&o,
 		validation.Field(&o.Query, validation.Required),
+		validation.Field(
+			&o.RefreshCron,
+			validation.When(!o.Materialized, validation.Empty),
+			validation.By(checkCronExpression),
+		),
 	)
 }
+
+func checkCronExpression(value any) error {
+	v, _ := value.(string)
+	if v == "" {
+		return nil // nothing to check
+	}
+
+	if _, err := cron.NewSchedule(v); err != nil {
+		return validation.NewError("validation_invalid_cron", err.Error())
+	}
+
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
 	"github.com/pocketbase/pocketbase/tools/list"
 	"github.com/pocketbase/pocketbase/tools/types"
 )
@@ -66,6 +67,98 @@ func TestCollectionIsAuth(t *testing.T) {
 	}
 }
 
+func TestCollectionSearchableFields(t *testing.T) {
+	scenarios := []struct {
+		collection models.Collection
+		expected   []string
+	}{
+		{models.Collection{}, nil},
+		{
+			models.Collection{
+				Type:    models.CollectionTypeBase,
+				Options: types.JsonMap{"searchableFields": []any{"title", "body", "title"}},
+			},
+			[]string{"title", "body"},
+		},
+		{
+			models.Collection{
+				Type:    models.CollectionTypeAuth,
+				Options: types.JsonMap{"searchableFields": []string{"name"}},
+			},
+			[]string{"name"},
+		},
+		{
+			models.Collection{
+				Type:    models.CollectionTypeView,
+				Options: types.JsonMap{"searchableFields": []string{"name"}},
+			},
+			nil,
+		},
+	}
+
+	for i, s := range scenarios {
+		result := s.collection.SearchableFields()
+
+		if len(result) != len(s.expected) {
+			t.Fatalf("(%d) Expected %v, got %v", i, s.expected, result)
+		}
+		for j, name := range s.expected {
+			if result[j] != name {
+				t.Fatalf("(%d) Expected %v, got %v", i, s.expected, result)
+			}
+		}
+	}
+}
+
+func TestCollectionFTSTableName(t *testing.T) {
+	m := models.Collection{}
+	m.Id = "test"
+
+	if name := m.FTSTableName(); name != "_fts_test" {
+		t.Fatalf("Unexpected full text search table name, got %q", name)
+	}
+}
+
+func TestCollectionGeoPointFields(t *testing.T) {
+	geoSchema := schema.NewSchema(
+		&schema.SchemaField{Name: "title", Type: schema.FieldTypeText},
+		&schema.SchemaField{Name: "location", Type: schema.FieldTypeGeoPoint},
+		&schema.SchemaField{Name: "pickup", Type: schema.FieldTypeGeoPoint},
+	)
+
+	scenarios := []struct {
+		collection models.Collection
+		expected   []string
+	}{
+		{models.Collection{Type: models.CollectionTypeBase}, nil},
+		{models.Collection{Type: models.CollectionTypeBase, Schema: geoSchema}, []string{"location", "pickup"}},
+		{models.Collection{Type: models.CollectionTypeAuth, Schema: geoSchema}, []string{"location", "pickup"}},
+		{models.Collection{Type: models.CollectionTypeView, Schema: geoSchema}, nil},
+	}
+
+	for i, s := range scenarios {
+		result := s.collection.GeoPointFields()
+
+		if len(result) != len(s.expected) {
+			t.Fatalf("(%d) Expected %v, got %v", i, s.expected, result)
+		}
+		for j, name := range s.expected {
+			if result[j] != name {
+				t.Fatalf("(%d) Expected %v, got %v", i, s.expected, result)
+			}
+		}
+	}
+}
+
+func TestCollectionGeoTableName(t *testing.T) {
+	m := models.Collection{}
+	m.Id = "test"
+
+	if name := m.GeoTableName(); name != "_geo_test" {
+		t.Fatalf("Unexpected geo table name, got %q", name)
+	}
+}
+
 func TestCollectionMarshalJSON(t *testing.T) {
 	scenarios := []struct {
 		name       string
@@ -458,6 +551,32 @@ func TestCollectionViewOptionsValidate(t *testing.T) {
 			},
 			[]string{},
 		},
+		{
+			"refresh cron without materialized",
+			models.CollectionViewOptions{
+				Query:       "test123",
+				RefreshCron: "* * * * *",
+			},
+			[]string{"refreshCron"},
+		},
+		{
+			"materialized with invalid refresh cron",
+			models.CollectionViewOptions{
+				Query:        "test123",
+				Materialized: true,
+				RefreshCron:  "invalid",
+			},
+			[]string{"refreshCron"},
+		},
+		{
+			"materialized with valid refresh cron",
+			models.CollectionViewOptions{
+				Query:        "test123",
+				Materialized: true,
+				RefreshCron:  "*/5 * * * *",
+			},
+			[]string{},
+		},
 	}
 
 	for _, s := range scenarios {
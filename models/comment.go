@@ -0,0 +1,64 @@
+package models
+
+import (
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+var _ Model = (*Comment)(nil)
+
+const (
+	CommentStatusPending  = "pending"
+	CommentStatusApproved = "approved"
+	CommentStatusRejected = "rejected"
+)
+
+// Comment defines a single comment of a record thread
+// (any record of a collection with enabled comments could own a thread).
+type Comment struct {
+	BaseModel
+
+	// CollectionId and RecordId are the thread owner record.
+	CollectionId string `db:"collectionId" json:"collectionId"`
+	RecordId     string `db:"recordId" json:"recordId"`
+
+	// ParentId is the id of the replied comment (empty for top level comments).
+	ParentId string `db:"parentId" json:"parentId"`
+
+	// AuthorCollectionId and AuthorId are the comment author auth record.
+	AuthorCollectionId string `db:"authorCollectionId" json:"authorCollectionId"`
+	AuthorId           string `db:"authorId" json:"authorId"`
+
+	Message string `db:"message" json:"message"`
+	Status  string `db:"status" json:"status"`
+
+	// Mentions is the list with the ids of the auth records
+	// mentioned in the message (eg. "@username").
+	Mentions types.JsonArray[string] `db:"mentions" json:"mentions"`
+}
+
+func (m *Comment) TableName() string {
+	return "_comments"
+}
+
+// IsApproved checks whether the comment is visible to everyone with
+// access to the thread (the other comments are visible only to their
+// author and the admins).
+func (m *Comment) IsApproved() bool {
+	return m.Status == CommentStatusApproved
+}
+
+// IsAuthor checks whether the provided auth record is the comment author.
+func (m *Comment) IsAuthor(authRecord *Record) bool {
+	return authRecord != nil &&
+		m.AuthorId == authRecord.Id &&
+		m.AuthorCollectionId == authRecord.Collection().Id
+}
+
+// CommentStatuses returns the list of all comment moderation statuses.
+func CommentStatuses() []string {
+	return []string{
+		CommentStatusPending,
+		CommentStatusApproved,
+		CommentStatusRejected,
+	}
+}
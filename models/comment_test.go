@@ -0,0 +1,68 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+)
+
+func TestCommentTableName(t *testing.T) {
+	m := models.Comment{}
+	if m.TableName() != "_comments" {
+		t.Fatalf("Unexpected table name, got %q", m.TableName())
+	}
+}
+
+func TestCommentIsApproved(t *testing.T) {
+	scenarios := []struct {
+		status   string
+		expected bool
+	}{
+		{"", false},
+		{models.CommentStatusPending, false},
+		{models.CommentStatusRejected, false},
+		{models.CommentStatusApproved, true},
+	}
+
+	for _, s := range scenarios {
+		m := models.Comment{Status: s.status}
+		if v := m.IsApproved(); v != s.expected {
+			t.Errorf("(%q) Expected %v, got %v", s.status, s.expected, v)
+		}
+	}
+}
+
+func TestCommentIsAuthor(t *testing.T) {
+	collection := &models.Collection{}
+	collection.Id = "test_collection"
+
+	author := models.NewRecord(collection)
+	author.Id = "test_author"
+
+	other := models.NewRecord(collection)
+	other.Id = "test_other"
+
+	otherCollection := &models.Collection{}
+	otherCollection.Id = "test_other_collection"
+
+	sameIdOtherCollection := models.NewRecord(otherCollection)
+	sameIdOtherCollection.Id = "test_author"
+
+	comment := models.Comment{AuthorCollectionId: "test_collection", AuthorId: "test_author"}
+
+	scenarios := []struct {
+		record   *models.Record
+		expected bool
+	}{
+		{nil, false},
+		{other, false},
+		{sameIdOtherCollection, false},
+		{author, true},
+	}
+
+	for i, s := range scenarios {
+		if v := comment.IsAuthor(s.record); v != s.expected {
+			t.Errorf("[%d] Expected %v, got %v", i, s.expected, v)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"github.com/pocketbase/pocketbase/tools/flags"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+var _ Model = (*FeatureFlag)(nil)
+
+// FeatureFlag defines a feature flag with its targeting rules
+// (see [flags.Evaluate] for the evaluation order).
+type FeatureFlag struct {
+	BaseModel
+
+	Key         string `db:"key" json:"key"`
+	Description string `db:"description" json:"description"`
+	Enabled     bool   `db:"enabled" json:"enabled"`
+
+	// Rollout is the rollout percentage (0-100) of the
+	// subjects that don't match any of the rules.
+	Rollout int `db:"rollout" json:"rollout"`
+
+	Rules types.JsonArray[flags.Rule] `db:"rules" json:"rules"`
+}
+
+func (m *FeatureFlag) TableName() string {
+	return "_featureFlags"
+}
+
+// Flag returns the evaluation definition of the feature flag.
+func (m *FeatureFlag) Flag() flags.Flag {
+	return flags.Flag{
+		Key:     m.Key,
+		Enabled: m.Enabled,
+		Rollout: m.Rollout,
+		Rules:   m.Rules,
+	}
+}
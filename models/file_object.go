@@ -0,0 +1,43 @@
+package models
+
+var (
+	_ Model = (*FileObject)(nil)
+	_ Model = (*FileRef)(nil)
+)
+
+// FileObjectsPrefix is the storage prefix of the deduplicated file objects.
+const FileObjectsPrefix = "_objects/"
+
+// FileObject defines a single deduplicated stored file object
+// identified by the hash of its content.
+type FileObject struct {
+	BaseModel
+
+	// Hash is the hex encoded sha256 hash of the file content.
+	Hash string `db:"hash" json:"hash"`
+
+	// Key is the storage key of the file object.
+	Key string `db:"key" json:"key"`
+
+	Size int64 `db:"size" json:"size"`
+}
+
+func (m *FileObject) TableName() string {
+	return "_fileObjects"
+}
+
+// FileRef defines a reference of a single record file
+// (or any other storage file key) to a deduplicated file object.
+type FileRef struct {
+	BaseModel
+
+	// Key is the referencing storage file key
+	// (eg. "collectionId/recordId/filename").
+	Key string `db:"key" json:"key"`
+
+	ObjectId string `db:"objectId" json:"objectId"`
+}
+
+func (m *FileRef) TableName() string {
+	return "_fileRefs"
+}
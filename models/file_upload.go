@@ -0,0 +1,71 @@
+package models
+
+var _ Model = (*FileUpload)(nil)
+
+const (
+	FileUploadOwnerAdmin      = "admin"
+	FileUploadOwnerAuthRecord = "authRecord"
+)
+
+// FileUpload defines a single resumable (chunked) record file upload.
+//
+// The uploaded chunks are stored locally and the file is attached
+// to the target record file field once all of its bytes are received.
+type FileUpload struct {
+	BaseModel
+
+	// CollectionId, RecordId and Field are the target record file field.
+	CollectionId string `db:"collectionId" json:"collectionId"`
+	RecordId     string `db:"recordId" json:"recordId"`
+	Field        string `db:"field" json:"field"`
+
+	// Filename is the original name of the uploaded file.
+	Filename string `db:"filename" json:"filename"`
+
+	// Size is the total file size (in bytes).
+	Size int64 `db:"size" json:"size"`
+
+	// Offset is the number of the already received bytes.
+	Offset int64 `db:"offset" json:"offset"`
+
+	OwnerType string `db:"ownerType" json:"ownerType"`
+
+	// OwnerCollectionId and OwnerId are the upload owner auth record
+	// (OwnerCollectionId is empty for admin owners).
+	OwnerCollectionId string `db:"ownerCollectionId" json:"ownerCollectionId"`
+	OwnerId           string `db:"ownerId" json:"ownerId"`
+}
+
+func (m *FileUpload) TableName() string {
+	return "_fileUploads"
+}
+
+// IsComplete checks whether all of the upload bytes were received.
+func (m *FileUpload) IsComplete() bool {
+	return m.Offset >= m.Size
+}
+
+// IsOwnedBy checks whether the provided admin or auth record is the upload owner.
+func (m *FileUpload) IsOwnedBy(admin *Admin, authRecord *Record) bool {
+	if admin != nil {
+		return m.OwnerType == FileUploadOwnerAdmin && m.OwnerId == admin.Id
+	}
+
+	return authRecord != nil &&
+		m.OwnerType == FileUploadOwnerAuthRecord &&
+		m.OwnerId == authRecord.Id &&
+		m.OwnerCollectionId == authRecord.Collection().Id
+}
+
+// SetOwner replaces the upload owner with the provided admin or auth record.
+func (m *FileUpload) SetOwner(admin *Admin, authRecord *Record) {
+	if admin != nil {
+		m.OwnerType = FileUploadOwnerAdmin
+		m.OwnerCollectionId = ""
+		m.OwnerId = admin.Id
+	} else if authRecord != nil {
+		m.OwnerType = FileUploadOwnerAuthRecord
+		m.OwnerCollectionId = authRecord.Collection().Id
+		m.OwnerId = authRecord.Id
+	}
+}
@@ -0,0 +1,68 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+)
+
+func TestFileUploadTableName(t *testing.T) {
+	m := models.FileUpload{}
+	if m.TableName() != "_fileUploads" {
+		t.Fatalf("Unexpected table name, got %q", m.TableName())
+	}
+}
+
+func TestFileUploadIsComplete(t *testing.T) {
+	scenarios := []struct {
+		size     int64
+		offset   int64
+		expected bool
+	}{
+		{10, 0, false},
+		{10, 9, false},
+		{10, 10, true},
+	}
+
+	for i, s := range scenarios {
+		m := models.FileUpload{Size: s.size, Offset: s.offset}
+
+		if v := m.IsComplete(); v != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, v)
+		}
+	}
+}
+
+func TestFileUploadOwner(t *testing.T) {
+	collection := &models.Collection{}
+	collection.Id = "test_collection"
+
+	authRecord := models.NewRecord(collection)
+	authRecord.Id = "test_owner"
+
+	admin := &models.Admin{}
+	admin.Id = "test_owner"
+
+	upload := models.FileUpload{}
+	upload.SetOwner(admin, nil)
+
+	if upload.OwnerType != models.FileUploadOwnerAdmin || upload.OwnerCollectionId != "" || upload.OwnerId != "test_owner" {
+		t.Fatalf("Unexpected admin owner %q %q %q", upload.OwnerType, upload.OwnerCollectionId, upload.OwnerId)
+	}
+
+	scenarios := []struct {
+		admin      *models.Admin
+		authRecord *models.Record
+		expected   bool
+	}{
+		{nil, nil, false},
+		{nil, authRecord, false},
+		{admin, nil, true},
+	}
+
+	for i, s := range scenarios {
+		if v := upload.IsOwnedBy(s.admin, s.authRecord); v != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, v)
+		}
+	}
+}
@@ -0,0 +1,9 @@
+package models
+
+// IndexSuggestion defines a single missing collection index suggestion
+// (usually derived from a slow query plan).
+type IndexSuggestion struct {
+	Collection string `json:"collection"`
+	Column     string `json:"column"`
+	Sql        string `json:"sql"`
+}
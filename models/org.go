@@ -0,0 +1,109 @@
+package models
+
+import (
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+var (
+	_ Model = (*Org)(nil)
+	_ Model = (*OrgMember)(nil)
+	_ Model = (*OrgInvite)(nil)
+)
+
+const (
+	OrgRoleMember = "member"
+	OrgRoleAdmin  = "admin"
+	OrgRoleOwner  = "owner"
+)
+
+// OrgRequestHeader is the request header used to select the active
+// organization of the request auth record (aka. "@request.auth.org").
+const OrgRequestHeader = "X-Org-Id"
+
+// Org defines a single organization (aka. team) grouping auth records.
+type Org struct {
+	BaseModel
+
+	Name string `db:"name" json:"name"`
+	Slug string `db:"slug" json:"slug"`
+}
+
+func (m *Org) TableName() string {
+	return "_orgs"
+}
+
+// OrgMember defines a single auth record membership in an organization.
+type OrgMember struct {
+	BaseModel
+
+	OrgId string `db:"orgId" json:"orgId"`
+
+	// CollectionId and RecordId are the member auth record.
+	CollectionId string `db:"collectionId" json:"collectionId"`
+	RecordId     string `db:"recordId" json:"recordId"`
+
+	Role string `db:"role" json:"role"`
+}
+
+func (m *OrgMember) TableName() string {
+	return "_orgMembers"
+}
+
+// HasRole checks whether the member role is equal or higher than the provided one.
+func (m *OrgMember) HasRole(role string) bool {
+	for _, r := range OrgRolesAtLeast(role) {
+		if m.Role == r {
+			return true
+		}
+	}
+
+	return false
+}
+
+// OrgInvite defines a pending organization invitation.
+//
+// The invitation could be accepted by any auth record
+// with the same verified email address.
+type OrgInvite struct {
+	BaseModel
+
+	OrgId   string         `db:"orgId" json:"orgId"`
+	Email   string         `db:"email" json:"email"`
+	Role    string         `db:"role" json:"role"`
+	Expires types.DateTime `db:"expires" json:"expires"`
+}
+
+func (m *OrgInvite) TableName() string {
+	return "_orgInvites"
+}
+
+// IsExpired checks whether the invite has expired.
+func (m *OrgInvite) IsExpired() bool {
+	return m.Expires.IsZero() || m.Expires.Time().Before(types.NowDateTime().Time())
+}
+
+// OrgRoles returns the list of all organization member
+// roles (from the lowest to the highest one).
+func OrgRoles() []string {
+	return []string{
+		OrgRoleMember,
+		OrgRoleAdmin,
+		OrgRoleOwner,
+	}
+}
+
+// OrgRolesAtLeast returns the roles that are equal or higher
+// than the provided one (eg. "admin" -> ["admin", "owner"]).
+//
+// Returns nil for unknown role.
+func OrgRolesAtLeast(role string) []string {
+	all := OrgRoles()
+
+	for i, r := range all {
+		if r == role {
+			return all[i:]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package models_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestOrgTableNames(t *testing.T) {
+	scenarios := []struct {
+		model    models.Model
+		expected string
+	}{
+		{&models.Org{}, "_orgs"},
+		{&models.OrgMember{}, "_orgMembers"},
+		{&models.OrgInvite{}, "_orgInvites"},
+	}
+
+	for _, s := range scenarios {
+		if s.model.TableName() != s.expected {
+			t.Errorf("Expected table name %q, got %q", s.expected, s.model.TableName())
+		}
+	}
+}
+
+func TestOrgRolesAtLeast(t *testing.T) {
+	scenarios := []struct {
+		role     string
+		expected string
+	}{
+		{"", ""},
+		{"unknown", ""},
+		{models.OrgRoleMember, "member,admin,owner"},
+		{models.OrgRoleAdmin, "admin,owner"},
+		{models.OrgRoleOwner, "owner"},
+	}
+
+	for _, s := range scenarios {
+		result := strings.Join(models.OrgRolesAtLeast(s.role), ",")
+		if result != s.expected {
+			t.Errorf("(%q) Expected %q, got %q", s.role, s.expected, result)
+		}
+	}
+}
+
+func TestOrgMemberHasRole(t *testing.T) {
+	scenarios := []struct {
+		memberRole string
+		role       string
+		expected   bool
+	}{
+		{models.OrgRoleMember, models.OrgRoleMember, true},
+		{models.OrgRoleMember, models.OrgRoleAdmin, false},
+		{models.OrgRoleAdmin, models.OrgRoleMember, true},
+		{models.OrgRoleAdmin, models.OrgRoleOwner, false},
+		{models.OrgRoleOwner, models.OrgRoleAdmin, true},
+		{models.OrgRoleOwner, "unknown", false},
+		{"unknown", models.OrgRoleMember, false},
+	}
+
+	for _, s := range scenarios {
+		member := models.OrgMember{Role: s.memberRole}
+
+		if result := member.HasRole(s.role); result != s.expected {
+			t.Errorf("(%q -> %q) Expected %v, got %v", s.memberRole, s.role, s.expected, result)
+		}
+	}
+}
+
+func TestOrgInviteIsExpired(t *testing.T) {
+	past, _ := types.ParseDateTime(time.Now().Add(-time.Minute))
+	future, _ := types.ParseDateTime(time.Now().Add(time.Minute))
+
+	scenarios := []struct {
+		expires  types.DateTime
+		expected bool
+	}{
+		{types.DateTime{}, true},
+		{past, true},
+		{future, false},
+	}
+
+	for i, s := range scenarios {
+		invite := models.OrgInvite{Expires: s.expires}
+
+		if result := invite.IsExpired(); result != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, result)
+		}
+	}
+}
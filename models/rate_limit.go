@@ -0,0 +1,26 @@
+package models
+
+var _ Model = (*RateLimit)(nil)
+
+// RateLimit defines a per identity override of the global
+// api rate limit settings.
+//
+// The identity could be an admin id, an auth record id, an api key id
+// or a client ip address (for the guest requests).
+type RateLimit struct {
+	BaseModel
+
+	IdentityId string `db:"identityId" json:"identityId"`
+
+	// MaxRequests is the max number of allowed requests
+	// per Duration window (0 means unlimited).
+	MaxRequests int `db:"maxRequests" json:"maxRequests"`
+
+	// Duration is the rate limit window in seconds.
+	Duration int    `db:"duration" json:"duration"`
+	Note     string `db:"note" json:"note"`
+}
+
+func (m *RateLimit) TableName() string {
+	return "_rateLimits"
+}
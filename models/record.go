@@ -11,6 +11,7 @@ import (
 	"github.com/pocketbase/dbx"
 	"github.com/pocketbase/pocketbase/models/schema"
 	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/locale"
 	"github.com/pocketbase/pocketbase/tools/security"
 	"github.com/pocketbase/pocketbase/tools/store"
 	"github.com/pocketbase/pocketbase/tools/types"
@@ -32,6 +33,7 @@ type Record struct {
 	exportUnknown         bool // whether to export unknown fields
 	ignoreEmailVisibility bool // whether to ignore the emailVisibility flag for auth collections
 	loaded                bool
+	localeChain           []string          // the localized fields export lookup chain
 	originalData          map[string]any    // the original (aka. first loaded) model data
 	expand                *store.Store[any] // expanded relations
 	data                  *store.Store[any] // any custom data in addition to the base model fields
@@ -82,6 +84,11 @@ func NewRecordFromNullStringMap(collection *Collection, data dbx.NullStringMap)
 		}
 	}
 
+	// load the soft delete mark
+	if collection.IsSoftDelete() {
+		resultMap[schema.FieldNameDeletedAt] = nullStringMapValue(data, schema.FieldNameDeletedAt)
+	}
+
 	record := NewRecord(collection)
 
 	record.Load(resultMap)
@@ -285,6 +292,22 @@ func (m *Record) WithUnknownData(state bool) {
 	m.exportUnknown = state
 }
 
+// SetLocaleChain sets the locales lookup chain used to resolve
+// the localized field values on export/serialization.
+//
+// The full locale -> value maps are exported if the chain is empty (default).
+func (m *Record) SetLocaleChain(chain []string) {
+	m.localeChain = chain
+}
+
+// GetLocalized returns the localized field value for "key" resolved
+// by the first non-empty locale from the provided lookup chain.
+func (m *Record) GetLocalized(key string, chain []string) string {
+	value, _ := locale.Resolve(locale.NormalizeValues(m.Get(key)), chain)
+
+	return value
+}
+
 // Set sets the provided key-value data pair for the current Record model.
 //
 // If the record collection has field with name matching the provided "key",
@@ -304,6 +327,8 @@ func (m *Record) Set(key string, value any) {
 
 		if field := m.Collection().Schema.GetFieldByName(key); field != nil {
 			v = field.PrepareValue(value)
+		} else if key == schema.FieldNameDeletedAt && m.collection.IsSoftDelete() {
+			v, _ = types.ParseDateTime(value)
 		} else if m.collection.IsAuth() {
 			// normalize auth fields
 			switch key {
@@ -373,6 +398,13 @@ func (m *Record) GetDateTime(key string) types.DateTime {
 	return d
 }
 
+// GetGeoPoint returns the data value for "key" as a GeoPoint instance
+// (the zero GeoPoint is returned if the value is missing or invalid).
+func (m *Record) GetGeoPoint(key string) types.GeoPoint {
+	p, _ := types.ParseGeoPoint(m.Get(key))
+	return p
+}
+
 // GetStringSlice returns the data value for "key" as a slice of unique strings.
 func (m *Record) GetStringSlice(key string) []string {
 	return list.ToUniqueStringSlice(m.Get(key))
@@ -442,6 +474,11 @@ func (m *Record) ColumnValueMap() map[string]any {
 	result[schema.FieldNameCreated] = m.getNormalizeDataValueForDB(schema.FieldNameCreated)
 	result[schema.FieldNameUpdated] = m.getNormalizeDataValueForDB(schema.FieldNameUpdated)
 
+	// export the soft delete mark
+	if m.collection.IsSoftDelete() {
+		result[schema.FieldNameDeletedAt] = m.DeletedAt()
+	}
+
 	return result
 }
 
@@ -460,7 +497,11 @@ func (m *Record) PublicExport() map[string]any {
 
 	// export schema field values
 	for _, field := range m.collection.Schema.Fields() {
-		result[field.Name] = m.Get(field.Name)
+		if len(m.localeChain) > 0 && field.IsLocalized() {
+			result[field.Name] = m.GetLocalized(field.Name, m.localeChain)
+		} else {
+			result[field.Name] = m.Get(field.Name)
+		}
 	}
 
 	// export some of the safe auth collection fields
@@ -482,6 +523,13 @@ func (m *Record) PublicExport() map[string]any {
 		result[schema.FieldNameUpdated] = updated
 	}
 
+	// export the soft delete mark only for the trashed records
+	if m.collection.IsSoftDelete() {
+		if deletedAt := m.DeletedAt(); !deletedAt.IsZero() {
+			result[schema.FieldNameDeletedAt] = deletedAt
+		}
+	}
+
 	// add helper collection reference fields
 	result[schema.FieldNameCollectionId] = m.collection.Id
 	result[schema.FieldNameCollectionName] = m.collection.Name
@@ -496,9 +544,10 @@ func (m *Record) PublicExport() map[string]any {
 
 // MarshalJSON implements the [json.Marshaler] interface.
 //
-// Only the data exported by `PublicExport()` will be serialized.
+// Only the data exported by `PublicExport()` will be serialized
+// (see also [Record.AppendJSONObject]).
 func (m Record) MarshalJSON() ([]byte, error) {
-	return json.Marshal(m.PublicExport())
+	return m.AppendJSONObject(nil, nil)
 }
 
 // UnmarshalJSON implements the [json.Unmarshaler] interface.
@@ -677,6 +726,10 @@ func (m *Record) extractUnknownData(data map[string]any) map[string]any {
 		}
 	}
 
+	if m.collection.IsSoftDelete() {
+		knownFields[schema.FieldNameDeletedAt] = struct{}{}
+	}
+
 	result := map[string]any{}
 
 	for k, v := range data {
@@ -688,6 +741,25 @@ func (m *Record) extractUnknownData(data map[string]any) map[string]any {
 	return result
 }
 
+// DeletedAt returns the "deletedAt" soft delete mark of the record
+// (zero for the not trashed records).
+func (m *Record) DeletedAt() types.DateTime {
+	return m.GetDateTime(schema.FieldNameDeletedAt)
+}
+
+// SetDeletedAt sets the "deletedAt" soft delete mark of the record.
+//
+// Returns an error if the record collection doesn't have soft delete enabled.
+func (m *Record) SetDeletedAt(dateTime types.DateTime) error {
+	if !m.collection.IsSoftDelete() {
+		return errors.New("The record collection doesn't have soft delete enabled.")
+	}
+
+	m.Set(schema.FieldNameDeletedAt, dateTime)
+
+	return nil
+}
+
 // -------------------------------------------------------------------
 // Auth helpers
 // -------------------------------------------------------------------
@@ -0,0 +1,24 @@
+package models
+
+const (
+	RecordDataProblemTypeMismatch       = "type_mismatch"
+	RecordDataProblemInvalidSelectValue = "invalid_select_value"
+	RecordDataProblemOrphanedRelation   = "orphaned_relation"
+)
+
+// RecordDataProblem defines a single stored record field value
+// that doesn't match the current collection schema
+// (eg. leftovers from old imports or schema changes).
+type RecordDataProblem struct {
+	RecordId string `json:"recordId"`
+	Field    string `json:"field"`
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+
+	// Value is the raw stored field value.
+	Value any `json:"value"`
+
+	// SuggestedValue is the auto-fix value suggestion
+	// compatible with the current field schema.
+	SuggestedValue any `json:"suggestedValue"`
+}
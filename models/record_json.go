@@ -0,0 +1,245 @@
+package models
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/jsonenc"
+	"github.com/pocketbase/pocketbase/tools/list"
+)
+
+var _ jsonenc.ObjectAppender = (*Record)(nil)
+
+// recordJSONSource describes the origin of a single Record public export value.
+type recordJSONSource uint8
+
+// note: the order matches the PublicExport assignments order
+// (aka. the later sources take precedence on duplicated keys)
+const (
+	recordJSONUnknown recordJSONSource = iota
+	recordJSONField
+	recordJSONVerified
+	recordJSONUsername
+	recordJSONEmailVisibility
+	recordJSONEmail
+	recordJSONId
+	recordJSONCreated
+	recordJSONUpdated
+	recordJSONDeletedAt
+	recordJSONCollectionId
+	recordJSONCollectionName
+	recordJSONExpand
+)
+
+type recordJSONEntry struct {
+	key    string
+	source recordJSONSource
+	field  *schema.SchemaField
+	value  any // used only for the unknown data
+}
+
+// recordJSONEntries is a reusable list of Record json object entries
+// sortable by their key (used to preserve the [json.Marshal] map keys order).
+type recordJSONEntries []recordJSONEntry
+
+func (e recordJSONEntries) Len() int           { return len(e) }
+func (e recordJSONEntries) Less(i, j int) bool { return e[i].key < e[j].key }
+func (e recordJSONEntries) Swap(i, j int)      { e[i], e[j] = e[j], e[i] }
+
+var recordJSONEntriesPool = sync.Pool{
+	New: func() any {
+		entries := make(recordJSONEntries, 0, 32)
+		return &entries
+	},
+}
+
+// AppendJSONObject implements the [jsonenc.ObjectAppender] interface.
+//
+// It appends to dst the same json object as the one produced by
+// serializing [Record.PublicExport] but without building the
+// intermediate export map.
+//
+// If fields is not empty, only the listed top level fields are encoded.
+func (m *Record) AppendJSONObject(dst []byte, fields []string) ([]byte, error) {
+	if m == nil {
+		return append(dst, "null"...), nil
+	}
+
+	entriesPtr := recordJSONEntriesPool.Get().(*recordJSONEntries)
+	defer func() {
+		// clear the entries to release the values references
+		for i := range *entriesPtr {
+			(*entriesPtr)[i] = recordJSONEntry{}
+		}
+		*entriesPtr = (*entriesPtr)[:0]
+		recordJSONEntriesPool.Put(entriesPtr)
+	}()
+
+	entries := m.appendJSONEntries((*entriesPtr)[:0], fields)
+	*entriesPtr = entries
+
+	sort.Stable(entriesPtr)
+
+	var err error
+	var written int
+
+	dst = append(dst, '{')
+
+	for i := range entries {
+		// skip the overwritten duplicated keys
+		if i+1 < len(entries) && entries[i+1].key == entries[i].key {
+			continue
+		}
+
+		if written > 0 {
+			dst = append(dst, ',')
+		}
+		written++
+
+		dst = jsonenc.AppendString(dst, entries[i].key)
+		dst = append(dst, ':')
+
+		dst, err = m.appendJSONEntryValue(dst, &entries[i])
+		if err != nil {
+			return dst, err
+		}
+	}
+
+	return append(dst, '}'), nil
+}
+
+// appendJSONEntries appends to entries the keys exported by
+// [Record.PublicExport] (optionally limited to the specified fields).
+func (m *Record) appendJSONEntries(entries recordJSONEntries, fields []string) recordJSONEntries {
+	add := func(key string, source recordJSONSource, field *schema.SchemaField, value any) {
+		if len(fields) > 0 && !list.ExistInSlice(key, fields) {
+			return
+		}
+
+		entries = append(entries, recordJSONEntry{key: key, source: source, field: field, value: value})
+	}
+
+	if m.exportUnknown {
+		for k, v := range m.UnknownData() {
+			add(k, recordJSONUnknown, nil, v)
+		}
+	}
+
+	for _, field := range m.collection.Schema.Fields() {
+		add(field.Name, recordJSONField, field, nil)
+	}
+
+	if m.collection.IsAuth() {
+		add(schema.FieldNameVerified, recordJSONVerified, nil, nil)
+		add(schema.FieldNameUsername, recordJSONUsername, nil, nil)
+		add(schema.FieldNameEmailVisibility, recordJSONEmailVisibility, nil, nil)
+		if m.ignoreEmailVisibility || m.EmailVisibility() {
+			add(schema.FieldNameEmail, recordJSONEmail, nil, nil)
+		}
+	}
+
+	add(schema.FieldNameId, recordJSONId, nil, nil)
+	if !m.Collection().IsView() || !m.GetCreated().IsZero() {
+		add(schema.FieldNameCreated, recordJSONCreated, nil, nil)
+	}
+	if !m.Collection().IsView() || !m.GetUpdated().IsZero() {
+		add(schema.FieldNameUpdated, recordJSONUpdated, nil, nil)
+	}
+
+	if m.collection.IsSoftDelete() && !m.DeletedAt().IsZero() {
+		add(schema.FieldNameDeletedAt, recordJSONDeletedAt, nil, nil)
+	}
+
+	add(schema.FieldNameCollectionId, recordJSONCollectionId, nil, nil)
+	add(schema.FieldNameCollectionName, recordJSONCollectionName, nil, nil)
+
+	if m.expand != nil && m.expand.Length() > 0 {
+		add(schema.FieldNameExpand, recordJSONExpand, nil, nil)
+	}
+
+	return entries
+}
+
+func (m *Record) appendJSONEntryValue(dst []byte, entry *recordJSONEntry) ([]byte, error) {
+	switch entry.source {
+	case recordJSONField:
+		if len(m.localeChain) > 0 && entry.field.IsLocalized() {
+			return jsonenc.AppendString(dst, m.GetLocalized(entry.key, m.localeChain)), nil
+		}
+		return jsonenc.AppendValue(dst, m.Get(entry.key))
+	case recordJSONVerified:
+		return strconv.AppendBool(dst, m.Verified()), nil
+	case recordJSONUsername:
+		return jsonenc.AppendString(dst, m.Username()), nil
+	case recordJSONEmailVisibility:
+		return strconv.AppendBool(dst, m.EmailVisibility()), nil
+	case recordJSONEmail:
+		return jsonenc.AppendString(dst, m.Email()), nil
+	case recordJSONId:
+		return jsonenc.AppendString(dst, m.GetId()), nil
+	case recordJSONCreated:
+		return jsonenc.AppendDateTime(dst, m.GetCreated()), nil
+	case recordJSONUpdated:
+		return jsonenc.AppendDateTime(dst, m.GetUpdated()), nil
+	case recordJSONDeletedAt:
+		return jsonenc.AppendDateTime(dst, m.DeletedAt()), nil
+	case recordJSONCollectionId:
+		return jsonenc.AppendString(dst, m.collection.Id), nil
+	case recordJSONCollectionName:
+		return jsonenc.AppendString(dst, m.collection.Name), nil
+	case recordJSONExpand:
+		return appendJSONExpand(dst, m.expand.GetAll())
+	default:
+		return jsonenc.AppendValue(dst, entry.value)
+	}
+}
+
+// appendJSONExpand appends to dst the json encoding of the provided
+// record expand data (the expanded records are encoded in place).
+func appendJSONExpand(dst []byte, expand map[string]any) ([]byte, error) {
+	keys := make([]string, 0, len(expand))
+	for k := range expand {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var err error
+
+	dst = append(dst, '{')
+
+	for i, k := range keys {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+
+		dst = jsonenc.AppendString(dst, k)
+		dst = append(dst, ':')
+
+		switch v := expand[k].(type) {
+		case []*Record:
+			if v == nil {
+				dst = append(dst, "null"...)
+				continue
+			}
+
+			dst = append(dst, '[')
+			for j, r := range v {
+				if j > 0 {
+					dst = append(dst, ',')
+				}
+				if dst, err = r.AppendJSONObject(dst, nil); err != nil {
+					return dst, err
+				}
+			}
+			dst = append(dst, ']')
+		default:
+			if dst, err = jsonenc.AppendValue(dst, v); err != nil {
+				return dst, err
+			}
+		}
+	}
+
+	return append(dst, '}'), nil
+}
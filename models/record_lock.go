@@ -0,0 +1,77 @@
+package models
+
+import (
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+var _ Model = (*RecordLock)(nil)
+
+const (
+	RecordLockHolderAdmin      = "admin"
+	RecordLockHolderAuthRecord = "authRecord"
+)
+
+const (
+	// RecordLockDefaultDuration is the default record lock lease duration (in seconds).
+	RecordLockDefaultDuration = 60
+
+	// RecordLockMaxDuration is the max allowed record lock lease duration (in seconds).
+	RecordLockMaxDuration = 600
+)
+
+// RecordLock defines a short record edit lease ("someone else is editing").
+//
+// The lock is advisory (it doesn't prevent the record updates) and
+// it is released automatically once its lease expires.
+type RecordLock struct {
+	BaseModel
+
+	// CollectionId and RecordId are the locked record.
+	CollectionId string `db:"collectionId" json:"collectionId"`
+	RecordId     string `db:"recordId" json:"recordId"`
+
+	HolderType string `db:"holderType" json:"holderType"`
+
+	// HolderCollectionId and HolderId are the lock holder auth record
+	// (HolderCollectionId is empty for admin holders).
+	HolderCollectionId string `db:"holderCollectionId" json:"holderCollectionId"`
+	HolderId           string `db:"holderId" json:"holderId"`
+
+	Expires types.DateTime `db:"expires" json:"expires"`
+}
+
+func (m *RecordLock) TableName() string {
+	return "_recordLocks"
+}
+
+// IsExpired checks whether the lock lease has expired.
+func (m *RecordLock) IsExpired() bool {
+	return m.Expires.IsZero() || !m.Expires.Time().After(time.Now())
+}
+
+// IsHeldBy checks whether the provided admin or auth record is the lock holder.
+func (m *RecordLock) IsHeldBy(admin *Admin, authRecord *Record) bool {
+	if admin != nil {
+		return m.HolderType == RecordLockHolderAdmin && m.HolderId == admin.Id
+	}
+
+	return authRecord != nil &&
+		m.HolderType == RecordLockHolderAuthRecord &&
+		m.HolderId == authRecord.Id &&
+		m.HolderCollectionId == authRecord.Collection().Id
+}
+
+// SetHolder replaces the lock holder with the provided admin or auth record.
+func (m *RecordLock) SetHolder(admin *Admin, authRecord *Record) {
+	if admin != nil {
+		m.HolderType = RecordLockHolderAdmin
+		m.HolderCollectionId = ""
+		m.HolderId = admin.Id
+	} else if authRecord != nil {
+		m.HolderType = RecordLockHolderAuthRecord
+		m.HolderCollectionId = authRecord.Collection().Id
+		m.HolderId = authRecord.Id
+	}
+}
@@ -0,0 +1,88 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestRecordLockTableName(t *testing.T) {
+	m := models.RecordLock{}
+	if m.TableName() != "_recordLocks" {
+		t.Fatalf("Unexpected table name, got %q", m.TableName())
+	}
+}
+
+func TestRecordLockIsExpired(t *testing.T) {
+	past, _ := types.ParseDateTime(time.Now().Add(-1 * time.Second))
+	future, _ := types.ParseDateTime(time.Now().Add(1 * time.Minute))
+
+	scenarios := []struct {
+		expires  types.DateTime
+		expected bool
+	}{
+		{types.DateTime{}, true},
+		{past, true},
+		{future, false},
+	}
+
+	for i, s := range scenarios {
+		m := models.RecordLock{Expires: s.expires}
+		if v := m.IsExpired(); v != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, v)
+		}
+	}
+}
+
+func TestRecordLockHolder(t *testing.T) {
+	collection := &models.Collection{}
+	collection.Id = "test_collection"
+
+	authRecord := models.NewRecord(collection)
+	authRecord.Id = "test_holder"
+
+	otherCollection := &models.Collection{}
+	otherCollection.Id = "test_other_collection"
+
+	sameIdOtherCollection := models.NewRecord(otherCollection)
+	sameIdOtherCollection.Id = "test_holder"
+
+	admin := &models.Admin{}
+	admin.Id = "test_holder"
+
+	lock := models.RecordLock{}
+	lock.SetHolder(nil, authRecord)
+
+	if lock.HolderType != models.RecordLockHolderAuthRecord || lock.HolderCollectionId != "test_collection" || lock.HolderId != "test_holder" {
+		t.Fatalf("Unexpected auth record holder %q %q %q", lock.HolderType, lock.HolderCollectionId, lock.HolderId)
+	}
+
+	scenarios := []struct {
+		admin      *models.Admin
+		authRecord *models.Record
+		expected   bool
+	}{
+		{nil, nil, false},
+		{admin, nil, false},
+		{nil, sameIdOtherCollection, false},
+		{nil, authRecord, true},
+	}
+
+	for i, s := range scenarios {
+		if v := lock.IsHeldBy(s.admin, s.authRecord); v != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, v)
+		}
+	}
+
+	lock.SetHolder(admin, nil)
+
+	if lock.HolderType != models.RecordLockHolderAdmin || lock.HolderCollectionId != "" || lock.HolderId != "test_holder" {
+		t.Fatalf("Unexpected admin holder %q %q %q", lock.HolderType, lock.HolderCollectionId, lock.HolderId)
+	}
+
+	if !lock.IsHeldBy(admin, nil) || lock.IsHeldBy(nil, authRecord) {
+		t.Fatal("Expected the lock to be held only by the admin")
+	}
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+var _ Model = (*RecordRevision)(nil)
+
+// RecordRevision defines a single stored version of a record payload
+// (a new revision is created on every update of a versioned collection record).
+type RecordRevision struct {
+	BaseModel
+
+	// CollectionId and RecordId are the versioned record.
+	CollectionId string `db:"collectionId" json:"collectionId"`
+	RecordId     string `db:"recordId" json:"recordId"`
+
+	// Revision is the auto incremented per record revision number.
+	Revision int `db:"revision" json:"revision"`
+
+	// Data holds the record schema fields values
+	// as they were right before the update.
+	Data types.JsonMap `db:"data" json:"data"`
+}
+
+func (m *RecordRevision) TableName() string {
+	return "_revisions"
+}
@@ -0,0 +1,70 @@
+package models
+
+var _ Model = (*RecordShare)(nil)
+
+const (
+	// RecordShareSubjectUser is the subject type of a record share
+	// with a single auth record.
+	RecordShareSubjectUser = "user"
+
+	// RecordShareSubjectGroup is the subject type of a record share with
+	// a group, aka. all auth records that have a relation to the subject record.
+	RecordShareSubjectGroup = "group"
+)
+
+const (
+	RecordSharePermissionView  = "view"
+	RecordSharePermissionEdit  = "edit"
+	RecordSharePermissionOwner = "owner"
+)
+
+// RecordShare defines a single record ACL entry granting a permission
+// level to a subject (user or group) for a specific record.
+//
+// The record ACL is consulted by the "@acl.*" rule identifiers.
+type RecordShare struct {
+	BaseModel
+
+	// CollectionId and RecordId are the shared record.
+	CollectionId string `db:"collectionId" json:"collectionId"`
+	RecordId     string `db:"recordId" json:"recordId"`
+
+	SubjectType string `db:"subjectType" json:"subjectType"`
+
+	// SubjectCollectionId and SubjectId are the auth record
+	// (for user subjects) or the group record (for group subjects).
+	SubjectCollectionId string `db:"subjectCollectionId" json:"subjectCollectionId"`
+	SubjectId           string `db:"subjectId" json:"subjectId"`
+
+	Permission string `db:"permission" json:"permission"`
+}
+
+func (m *RecordShare) TableName() string {
+	return "_recordShares"
+}
+
+// RecordSharePermissions returns the list of all record share
+// permission levels (from the lowest to the highest one).
+func RecordSharePermissions() []string {
+	return []string{
+		RecordSharePermissionView,
+		RecordSharePermissionEdit,
+		RecordSharePermissionOwner,
+	}
+}
+
+// RecordSharePermissionsAtLeast returns the permission levels that
+// are equal or higher than the provided one (eg. "edit" -> ["edit", "owner"]).
+//
+// Returns nil for unknown permission.
+func RecordSharePermissionsAtLeast(permission string) []string {
+	all := RecordSharePermissions()
+
+	for i, p := range all {
+		if p == permission {
+			return all[i:]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package models_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+)
+
+func TestRecordShareTableName(t *testing.T) {
+	m := models.RecordShare{}
+	if m.TableName() != "_recordShares" {
+		t.Fatalf("Unexpected table name, got %q", m.TableName())
+	}
+}
+
+func TestRecordSharePermissionsAtLeast(t *testing.T) {
+	scenarios := []struct {
+		permission string
+		expected   string
+	}{
+		{"", ""},
+		{"unknown", ""},
+		{models.RecordSharePermissionView, "view,edit,owner"},
+		{models.RecordSharePermissionEdit, "edit,owner"},
+		{models.RecordSharePermissionOwner, "owner"},
+	}
+
+	for _, s := range scenarios {
+		result := strings.Join(models.RecordSharePermissionsAtLeast(s.permission), ",")
+		if result != s.expected {
+			t.Errorf("(%q) Expected %q, got %q", s.permission, s.expected, result)
+		}
+	}
+}
@@ -897,6 +897,32 @@ func TestRecordGetDateTime(t *testing.T) {
 	}
 }
 
+func TestRecordGetGeoPoint(t *testing.T) {
+	scenarios := []struct {
+		value    any
+		expected types.GeoPoint
+	}{
+		{nil, types.GeoPoint{}},
+		{"", types.GeoPoint{}},
+		{"test", types.GeoPoint{}},
+		{123, types.GeoPoint{}},
+		{"1.5,2", types.GeoPoint{Lat: 1.5, Lng: 2}},
+		{`{"lat":1.5,"lng":2}`, types.GeoPoint{Lat: 1.5, Lng: 2}},
+		{types.GeoPoint{Lat: 1.5, Lng: 2}, types.GeoPoint{Lat: 1.5, Lng: 2}},
+	}
+
+	collection := &models.Collection{}
+
+	for i, s := range scenarios {
+		m := models.NewRecord(collection)
+		m.Set("test", s.value)
+
+		if result := m.GetGeoPoint("test"); result != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, result)
+		}
+	}
+}
+
 func TestRecordGetStringSlice(t *testing.T) {
 	nowTime := time.Now()
 
@@ -1226,6 +1252,70 @@ func TestRecordColumnValueMap(t *testing.T) {
 	}
 }
 
+func TestRecordLocaleChain(t *testing.T) {
+	collection := &models.Collection{
+		Name: "c_name",
+		Schema: schema.NewSchema(
+			&schema.SchemaField{
+				Name: "field1",
+				Type: schema.FieldTypeText,
+			},
+			&schema.SchemaField{
+				Name:    "field2",
+				Type:    schema.FieldTypeText,
+				Options: &schema.TextOptions{Localized: true},
+			},
+			&schema.SchemaField{
+				Name:    "field3",
+				Type:    schema.FieldTypeEditor,
+				Options: &schema.EditorOptions{Localized: true},
+			},
+		),
+	}
+	collection.Id = "c_id"
+
+	m := models.NewRecord(collection)
+	m.Id = "test_id"
+	m.Set("field1", `{"en":"plain"}`)
+	m.Set("field2", map[string]any{"en": "Hello", "de": "Hallo"})
+	m.Set("field3", "locale independent")
+
+	if v := m.GetLocalized("field2", []string{"fr", "de"}); v != "Hallo" {
+		t.Fatalf("Expected field2 localized value %q, got %q", "Hallo", v)
+	}
+
+	scenarios := []struct {
+		chain    []string
+		expected string
+	}{
+		{
+			nil,
+			`{"collectionId":"c_id","collectionName":"c_name","created":"","field1":"{\"en\":\"plain\"}","field2":{"de":"Hallo","en":"Hello"},"field3":{"*":"locale independent"},"id":"test_id","updated":""}`,
+		},
+		{
+			[]string{"de", "en", "*"},
+			`{"collectionId":"c_id","collectionName":"c_name","created":"","field1":"{\"en\":\"plain\"}","field2":"Hallo","field3":"locale independent","id":"test_id","updated":""}`,
+		},
+		{
+			[]string{"fr"},
+			`{"collectionId":"c_id","collectionName":"c_name","created":"","field1":"{\"en\":\"plain\"}","field2":"","field3":"","id":"test_id","updated":""}`,
+		},
+	}
+
+	for i, s := range scenarios {
+		m.SetLocaleChain(s.chain)
+
+		raw, err := json.Marshal(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(raw) != s.expected {
+			t.Errorf("(%d) Expected \n%s, got \n%s", i, s.expected, raw)
+		}
+	}
+}
+
 func TestRecordPublicExportAndMarshalJSON(t *testing.T) {
 	collection := &models.Collection{
 		Name: "c_name",
@@ -1370,6 +1460,116 @@ func TestRecordPublicExportAndMarshalJSON(t *testing.T) {
 	}
 }
 
+func TestRecordAppendJSONObject(t *testing.T) {
+	collection := &models.Collection{
+		Name: "c_name",
+		Type: models.CollectionTypeBase,
+		Schema: schema.NewSchema(
+			&schema.SchemaField{Name: "title", Type: schema.FieldTypeText},
+			&schema.SchemaField{Name: "total", Type: schema.FieldTypeNumber},
+			&schema.SchemaField{Name: "data", Type: schema.FieldTypeJson},
+		),
+	}
+	collection.Id = "c_id"
+
+	created, _ := types.ParseDateTime("2022-01-01 10:00:30.123Z")
+
+	rel1 := models.NewRecord(collection)
+	rel1.Id = "rel1"
+	rel2 := models.NewRecord(collection)
+	rel2.Id = "rel2"
+
+	record := models.NewRecord(collection)
+	record.Id = "test_id"
+	record.Created = created
+	record.Set("title", "<b>test</b>")
+	record.Set("total", 1.5)
+	record.Set("data", `{"b":1,"a":[1, 2]}`)
+	record.SetExpand(map[string]any{"many": []*models.Record{rel1, rel2}, "one": rel1, "nil": (*models.Record)(nil)})
+
+	scenarios := []struct {
+		record   *models.Record
+		fields   []string
+		expected string
+	}{
+		{
+			nil,
+			nil,
+			`null`,
+		},
+		{
+			record,
+			[]string{"id", "title", "missing"},
+			`{"id":"test_id","title":"\u003cb\u003etest\u003c/b\u003e"}`,
+		},
+		{
+			record,
+			[]string{"missing"},
+			`{}`,
+		},
+		{
+			record,
+			nil,
+			`{"collectionId":"c_id","collectionName":"c_name","created":"2022-01-01 10:00:30.123Z","data":{"b":1,"a":[1,2]},"expand":{"many":[{"collectionId":"c_id","collectionName":"c_name","created":"","data":null,"id":"rel1","title":null,"total":null,"updated":""},{"collectionId":"c_id","collectionName":"c_name","created":"","data":null,"id":"rel2","title":null,"total":null,"updated":""}],"nil":null,"one":{"collectionId":"c_id","collectionName":"c_name","created":"","data":null,"id":"rel1","title":null,"total":null,"updated":""}},"id":"test_id","title":"\u003cb\u003etest\u003c/b\u003e","total":1.5,"updated":""}`,
+		},
+	}
+
+	for i, s := range scenarios {
+		result, err := s.record.AppendJSONObject([]byte("prefix:"), s.fields)
+		if err != nil {
+			t.Errorf("(%d) Unexpected error %v", i, err)
+			continue
+		}
+
+		if string(result) != "prefix:"+s.expected {
+			t.Errorf("(%d) Expected \n%s, \ngot \n%s", i, s.expected, result)
+		}
+	}
+
+	// should match the PublicExport serialization
+	exported, err := json.Marshal(record.PublicExport())
+	if err != nil {
+		t.Fatal(err)
+	}
+	appended, _ := record.AppendJSONObject(nil, nil)
+	if string(exported) != string(appended) {
+		t.Fatalf("Expected the PublicExport to be the same as AppendJSONObject, but got \n%s \nvs \n%s", exported, appended)
+	}
+}
+
+func TestRecordAppendJSONObjectAllocs(t *testing.T) {
+	collection := &models.Collection{
+		Type: models.CollectionTypeBase,
+		Schema: schema.NewSchema(
+			&schema.SchemaField{Name: "title", Type: schema.FieldTypeText},
+			&schema.SchemaField{Name: "total", Type: schema.FieldTypeNumber},
+			&schema.SchemaField{Name: "active", Type: schema.FieldTypeBool},
+			&schema.SchemaField{Name: "date", Type: schema.FieldTypeDate},
+		),
+	}
+
+	record := models.NewRecord(collection)
+	record.Id = "test_id"
+	record.Set("title", "test")
+	record.Set("total", 123)
+	record.Set("active", true)
+	record.Set("date", "2022-01-01 10:00:30.123Z")
+	record.RefreshCreated()
+
+	buf := make([]byte, 0, 1024)
+
+	// warm up the entries pool
+	record.AppendJSONObject(buf, nil)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		record.AppendJSONObject(buf[:0], nil)
+	})
+
+	if allocs != 0 {
+		t.Fatalf("Expected 0 allocations, got %v", allocs)
+	}
+}
+
 func TestRecordUnmarshalJSON(t *testing.T) {
 	collection := &models.Collection{
 		Schema: schema.NewSchema(
@@ -0,0 +1,30 @@
+package models
+
+// RequestInfo defines a normalized HTTP request identity info,
+// usually attached to the request hook events.
+type RequestInfo struct {
+	// Id is the request identifier (the "X-Request-Id" header value or a random string).
+	Id        string `json:"id"`
+	Ip        string `json:"ip"`
+	UserAgent string `json:"userAgent"`
+
+	// Auth is the request auth type (see the RequestAuth* constants).
+	Auth       string  `json:"auth"`
+	IsAdmin    bool    `json:"isAdmin"`
+	Admin      *Admin  `json:"admin"`
+	AuthRecord *Record `json:"authRecord"`
+}
+
+// AuthId returns the id of the request admin or auth record
+// (or empty string for guests).
+func (r *RequestInfo) AuthId() string {
+	if r.Admin != nil {
+		return r.Admin.Id
+	}
+
+	if r.AuthRecord != nil {
+		return r.AuthRecord.Id
+	}
+
+	return ""
+}
@@ -0,0 +1,31 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+)
+
+func TestRequestInfoAuthId(t *testing.T) {
+	admin := &models.Admin{}
+	admin.Id = "admin_id"
+
+	record := &models.Record{}
+	record.Id = "record_id"
+
+	scenarios := []struct {
+		name     string
+		info     *models.RequestInfo
+		expected string
+	}{
+		{"guest", &models.RequestInfo{}, ""},
+		{"admin", &models.RequestInfo{Admin: admin}, "admin_id"},
+		{"auth record", &models.RequestInfo{AuthRecord: record}, "record_id"},
+	}
+
+	for _, s := range scenarios {
+		if v := s.info.AuthId(); v != s.expected {
+			t.Errorf("[%s] Expected %q, got %q", s.name, s.expected, v)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package models
+
+var _ Model = (*SavedQuery)(nil)
+
+const (
+	SavedQueryOwnerAdmin      = "admin"
+	SavedQueryOwnerAuthRecord = "authRecord"
+)
+
+// SavedQuery defines a named collection records list preset
+// (filter, sort, fields and expand query parameters).
+type SavedQuery struct {
+	BaseModel
+
+	CollectionId string `db:"collectionId" json:"collectionId"`
+
+	// Name is the unique query identifier within its collection.
+	Name string `db:"name" json:"name"`
+
+	Filter string `db:"filter" json:"filter"`
+	Sort   string `db:"sort" json:"sort"`
+	Fields string `db:"fields" json:"fields"`
+	Expand string `db:"expand" json:"expand"`
+
+	// Shared makes the query visible to everyone with
+	// access to the collection records list (and not only to its owner).
+	Shared bool `db:"shared" json:"shared"`
+
+	OwnerType string `db:"ownerType" json:"ownerType"`
+
+	// OwnerCollectionId and OwnerId are the query owner auth record
+	// (OwnerCollectionId is empty for admin owners).
+	OwnerCollectionId string `db:"ownerCollectionId" json:"ownerCollectionId"`
+	OwnerId           string `db:"ownerId" json:"ownerId"`
+}
+
+func (m *SavedQuery) TableName() string {
+	return "_savedQueries"
+}
+
+// IsOwnedBy checks whether the provided admin or auth record is the query owner.
+func (m *SavedQuery) IsOwnedBy(admin *Admin, authRecord *Record) bool {
+	if admin != nil {
+		return m.OwnerType == SavedQueryOwnerAdmin && m.OwnerId == admin.Id
+	}
+
+	return authRecord != nil &&
+		m.OwnerType == SavedQueryOwnerAuthRecord &&
+		m.OwnerId == authRecord.Id &&
+		m.OwnerCollectionId == authRecord.Collection().Id
+}
+
+// SetOwner replaces the query owner with the provided admin or auth record.
+func (m *SavedQuery) SetOwner(admin *Admin, authRecord *Record) {
+	if admin != nil {
+		m.OwnerType = SavedQueryOwnerAdmin
+		m.OwnerCollectionId = ""
+		m.OwnerId = admin.Id
+	} else if authRecord != nil {
+		m.OwnerType = SavedQueryOwnerAuthRecord
+		m.OwnerCollectionId = authRecord.Collection().Id
+		m.OwnerId = authRecord.Id
+	}
+}
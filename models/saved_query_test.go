@@ -0,0 +1,65 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+)
+
+func TestSavedQueryTableName(t *testing.T) {
+	m := models.SavedQuery{}
+	if m.TableName() != "_savedQueries" {
+		t.Fatalf("Unexpected table name, got %q", m.TableName())
+	}
+}
+
+func TestSavedQueryOwner(t *testing.T) {
+	collection := &models.Collection{}
+	collection.Id = "test_collection"
+
+	authRecord := models.NewRecord(collection)
+	authRecord.Id = "test_owner"
+
+	otherCollection := &models.Collection{}
+	otherCollection.Id = "test_other_collection"
+
+	sameIdOtherCollection := models.NewRecord(otherCollection)
+	sameIdOtherCollection.Id = "test_owner"
+
+	admin := &models.Admin{}
+	admin.Id = "test_owner"
+
+	query := models.SavedQuery{}
+	query.SetOwner(nil, authRecord)
+
+	if query.OwnerType != models.SavedQueryOwnerAuthRecord || query.OwnerCollectionId != "test_collection" || query.OwnerId != "test_owner" {
+		t.Fatalf("Unexpected auth record owner %q %q %q", query.OwnerType, query.OwnerCollectionId, query.OwnerId)
+	}
+
+	scenarios := []struct {
+		admin      *models.Admin
+		authRecord *models.Record
+		expected   bool
+	}{
+		{nil, nil, false},
+		{admin, nil, false},
+		{nil, sameIdOtherCollection, false},
+		{nil, authRecord, true},
+	}
+
+	for i, s := range scenarios {
+		if v := query.IsOwnedBy(s.admin, s.authRecord); v != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, v)
+		}
+	}
+
+	query.SetOwner(admin, nil)
+
+	if query.OwnerType != models.SavedQueryOwnerAdmin || query.OwnerCollectionId != "" || query.OwnerId != "test_owner" {
+		t.Fatalf("Unexpected admin owner %q %q %q", query.OwnerType, query.OwnerCollectionId, query.OwnerId)
+	}
+
+	if !query.IsOwnedBy(admin, nil) || query.IsOwnedBy(nil, authRecord) {
+		t.Fatal("Expected the query to be owned only by the admin")
+	}
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+var _ Model = (*ScheduledExport)(nil)
+
+const (
+	ScheduledExportFormatCsv     = "csv"
+	ScheduledExportFormatJson    = "json"
+	ScheduledExportFormatXlsx    = "xlsx"
+	ScheduledExportFormatParquet = "parquet"
+)
+
+const (
+	ScheduledExportDestinationS3    = "s3"
+	ScheduledExportDestinationEmail = "email"
+)
+
+// ScheduledExport defines a recurring collection records export
+// that is uploaded to the app files storage under Prefix
+// (and optionally emailed as a download link).
+type ScheduledExport struct {
+	BaseModel
+
+	// Name is the unique export identifier (it is also used as exported file name prefix).
+	Name string `db:"name" json:"name"`
+
+	CollectionId string `db:"collectionId" json:"collectionId"`
+	Filter       string `db:"filter" json:"filter"`
+	Sort         string `db:"sort" json:"sort"`
+
+	// Format is the exported file format (csv, json, xlsx or parquet).
+	Format string `db:"format" json:"format"`
+
+	// Schedule is the cron expression of the export runs.
+	Schedule string `db:"schedule" json:"schedule"`
+
+	// Destination is the export delivery type (s3 or email).
+	Destination string `db:"destination" json:"destination"`
+
+	// Prefix is the storage key prefix of the exported files.
+	Prefix string `db:"prefix" json:"prefix"`
+
+	// Emails is the list of the export link recipients (used only with the email destination).
+	Emails types.JsonArray[string] `db:"emails" json:"emails"`
+
+	Enabled bool `db:"enabled" json:"enabled"`
+
+	// LastRun, LastFile and LastError describe the result of the latest export run.
+	LastRun   types.DateTime `db:"lastRun" json:"lastRun"`
+	LastFile  string         `db:"lastFile" json:"lastFile"`
+	LastError string         `db:"lastError" json:"lastError"`
+}
+
+func (m *ScheduledExport) TableName() string {
+	return "_scheduledExports"
+}
+
+// StoragePrefix returns the storage key prefix of the export files
+// (default to "exports/{name}" if Prefix is not set).
+func (m *ScheduledExport) StoragePrefix() string {
+	if m.Prefix == "" {
+		return "exports/" + m.Name
+	}
+
+	return strings.Trim(m.Prefix, "/")
+}
+
+// FileExtension returns the extension (with leading dot) of the export files.
+func (m *ScheduledExport) FileExtension() string {
+	switch m.Format {
+	case ScheduledExportFormatJson:
+		return ".json"
+	case ScheduledExportFormatXlsx:
+		return ".xlsx"
+	case ScheduledExportFormatParquet:
+		return ".parquet"
+	}
+
+	return ".csv"
+}
@@ -3,13 +3,16 @@ package schema
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"regexp"
 	"strconv"
+	"strings"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
 	"github.com/pocketbase/pocketbase/tools/filesystem"
 	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/locale"
 	"github.com/pocketbase/pocketbase/tools/types"
 	"github.com/spf13/cast"
 )
@@ -46,6 +49,7 @@ const (
 	FieldNamePasswordHash           string = "passwordHash"
 	FieldNameLastResetSentAt        string = "lastResetSentAt"
 	FieldNameLastVerificationSentAt string = "lastVerificationSentAt"
+	FieldNameDeletedAt              string = "deletedAt"
 )
 
 // BaseModelFieldNames returns the field names that all models have (id, created, updated).
@@ -93,6 +97,7 @@ const (
 	FieldTypeJson     string = "json"
 	FieldTypeFile     string = "file"
 	FieldTypeRelation string = "relation"
+	FieldTypeGeoPoint string = "geoPoint"
 
 	// Deprecated: Will be removed in v0.9+
 	FieldTypeUser string = "user"
@@ -112,6 +117,7 @@ func FieldTypes() []string {
 		FieldTypeJson,
 		FieldTypeFile,
 		FieldTypeRelation,
+		FieldTypeGeoPoint,
 	}
 }
 
@@ -146,7 +152,7 @@ func (f *SchemaField) ColDefinition() string {
 		return "NUMERIC DEFAULT 0"
 	case FieldTypeBool:
 		return "BOOLEAN DEFAULT FALSE"
-	case FieldTypeJson:
+	case FieldTypeJson, FieldTypeGeoPoint:
 		return "JSON DEFAULT NULL"
 	default:
 		return "TEXT DEFAULT ''"
@@ -257,6 +263,8 @@ func (f *SchemaField) InitOptions() error {
 		options = &FileOptions{}
 	case FieldTypeRelation:
 		options = &RelationOptions{}
+	case FieldTypeGeoPoint:
+		options = &GeoPointOptions{}
 
 	// Deprecated: Will be removed in v0.9+
 	case FieldTypeUser:
@@ -275,11 +283,31 @@ func (f *SchemaField) InitOptions() error {
 	return nil
 }
 
+// IsLocalized checks whether the field stores per-locale values
+// (aka. a text or editor field with the "localized" option).
+func (f *SchemaField) IsLocalized() bool {
+	// init field options (if not already)
+	f.InitOptions()
+
+	switch options := f.Options.(type) {
+	case *TextOptions:
+		return options.Localized
+	case *EditorOptions:
+		return options.Localized
+	}
+
+	return false
+}
+
 // PrepareValue returns normalized and properly formatted field value.
 func (f *SchemaField) PrepareValue(value any) any {
 	// init field options (if not already)
 	f.InitOptions()
 
+	if f.IsLocalized() {
+		return locale.NormalizeValues(value)
+	}
+
 	switch f.Type {
 	case FieldTypeText, FieldTypeEmail, FieldTypeUrl, FieldTypeEditor:
 		return cast.ToString(value)
@@ -357,6 +385,14 @@ func (f *SchemaField) PrepareValue(value any) any {
 		}
 
 		return ids
+	case FieldTypeGeoPoint:
+		// unparsable values are normalized to nil (aka. no location)
+		point, err := types.ParseGeoPoint(value)
+		if err != nil {
+			return nil
+		}
+
+		return point
 	default:
 		return value // unmodified
 	}
@@ -417,6 +453,10 @@ type TextOptions struct {
 	Min     *int   `form:"min" json:"min"`
 	Max     *int   `form:"max" json:"max"`
 	Pattern string `form:"pattern" json:"pattern"`
+
+	// Localized stores the field value as locale -> text map
+	// (eg. {"en": "Hello", "de": "Hallo"}).
+	Localized bool `form:"localized" json:"localized,omitempty"`
 }
 
 func (o TextOptions) Validate() error {
@@ -515,6 +555,9 @@ func (o UrlOptions) Validate() error {
 // -------------------------------------------------------------------
 
 type EditorOptions struct {
+	// Localized stores the field value as locale -> html map
+	// (eg. {"en": "<p>Hello</p>", "de": "<p>Hallo</p>"}).
+	Localized bool `form:"localized" json:"localized,omitempty"`
 }
 
 func (o EditorOptions) Validate() error {
@@ -590,6 +633,15 @@ func (o JsonOptions) Validate() error {
 
 // -------------------------------------------------------------------
 
+type GeoPointOptions struct {
+}
+
+func (o GeoPointOptions) Validate() error {
+	return nil
+}
+
+// -------------------------------------------------------------------
+
 var _ MultiValuer = (*FileOptions)(nil)
 
 type FileOptions struct {
@@ -598,6 +650,19 @@ type FileOptions struct {
 	MimeTypes []string `form:"mimeTypes" json:"mimeTypes"`
 	Thumbs    []string `form:"thumbs" json:"thumbs"`
 	Protected bool     `form:"protected" json:"protected"`
+
+	// ForceDownload indicates whether to always serve the field files
+	// as attachment (even the ones that could be displayed inline, eg. images).
+	ForceDownload bool `form:"forceDownload" json:"forceDownload"`
+
+	// ContentTypes is an optional map with lowercased file extensions
+	// and the content type to serve them with instead of the sniffed one
+	// (eg. {".md": "text/plain"}).
+	ContentTypes map[string]string `form:"contentTypes" json:"contentTypes"`
+
+	// ContentSecurityPolicy is an optional custom Content-Security-Policy
+	// header to serve the field files with (if empty the default sandbox policy is used).
+	ContentSecurityPolicy string `form:"contentSecurityPolicy" json:"contentSecurityPolicy"`
 }
 
 func (o FileOptions) Validate() error {
@@ -608,9 +673,61 @@ func (o FileOptions) Validate() error {
 			validation.NotIn("0x0", "0x0t", "0x0b", "0x0f"),
 			validation.Match(filesystem.ThumbSizeRegex),
 		)),
+		validation.Field(&o.ContentTypes, validation.By(o.checkContentTypes)),
+		validation.Field(
+			&o.ContentSecurityPolicy,
+			validation.Length(1, 1000),
+			validation.Match(fileContentSecurityPolicyRegex),
+		),
 	)
 }
 
+var fileExtensionRegex = regexp.MustCompile(`^\.[a-z0-9_\-]+$`)
+var fileContentTypeRegex = regexp.MustCompile(`^[\w.+\-]+/[\w.+\-]+$`)
+var fileContentSecurityPolicyRegex = regexp.MustCompile(`^[^\r\n]+$`)
+
+// unsafeFileContentTypes is the list of content types that are
+// not allowed to be used as file content type override because
+// they could execute scripts when opened directly in the browser.
+var unsafeFileContentTypes = []string{
+	"text/html",
+	"application/xhtml+xml",
+	"text/javascript",
+	"application/javascript",
+	"application/ecmascript",
+	"text/xml",
+	"application/xml",
+}
+
+func (o *FileOptions) checkContentTypes(value any) error {
+	v, _ := value.(map[string]string)
+
+	for ext, contentType := range v {
+		if !fileExtensionRegex.MatchString(ext) {
+			return validation.NewError(
+				"validation_invalid_file_extension",
+				fmt.Sprintf("Invalid file extension %q (must be lowercased and start with dot, eg. \".txt\").", ext),
+			)
+		}
+
+		if !fileContentTypeRegex.MatchString(contentType) {
+			return validation.NewError(
+				"validation_invalid_content_type",
+				fmt.Sprintf("Invalid content type %q for %q.", contentType, ext),
+			)
+		}
+
+		if list.ExistInSlice(strings.ToLower(contentType), unsafeFileContentTypes) {
+			return validation.NewError(
+				"validation_unsafe_content_type",
+				fmt.Sprintf("The content type %q is not allowed to be served.", contentType),
+			)
+		}
+	}
+
+	return nil
+}
+
 // IsMultiple implements MultiValuer interface and checks whether the
 // current field options support multiple values.
 func (o FileOptions) IsMultiple() bool {
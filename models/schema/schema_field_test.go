@@ -40,7 +40,7 @@ func TestAuthFieldNames(t *testing.T) {
 
 func TestFieldTypes(t *testing.T) {
 	result := schema.FieldTypes()
-	expected := 11
+	expected := 12
 
 	if len(result) != expected {
 		t.Fatalf("Expected %d types, got %d (%v)", expected, len(result), result)
@@ -105,6 +105,10 @@ func TestSchemaFieldColDefinition(t *testing.T) {
 			schema.SchemaField{Type: schema.FieldTypeRelation, Name: "test"},
 			"TEXT DEFAULT ''",
 		},
+		{
+			schema.SchemaField{Type: schema.FieldTypeGeoPoint, Name: "test"},
+			"JSON DEFAULT NULL",
+		},
 	}
 
 	for i, s := range scenarios {
@@ -504,13 +508,18 @@ func TestSchemaFieldInitOptions(t *testing.T) {
 		{
 			schema.SchemaField{Type: schema.FieldTypeFile},
 			false,
-			`{"system":false,"id":"","name":"","type":"file","required":false,"unique":false,"options":{"maxSelect":0,"maxSize":0,"mimeTypes":null,"thumbs":null,"protected":false}}`,
+			`{"system":false,"id":"","name":"","type":"file","required":false,"unique":false,"options":{"maxSelect":0,"maxSize":0,"mimeTypes":null,"thumbs":null,"protected":false,"forceDownload":false,"contentTypes":null,"contentSecurityPolicy":""}}`,
 		},
 		{
 			schema.SchemaField{Type: schema.FieldTypeRelation},
 			false,
 			`{"system":false,"id":"","name":"","type":"relation","required":false,"unique":false,"options":{"collectionId":"","cascadeDelete":false,"minSelect":null,"maxSelect":null,"displayFields":null}}`,
 		},
+		{
+			schema.SchemaField{Type: schema.FieldTypeGeoPoint},
+			false,
+			`{"system":false,"id":"","name":"","type":"geoPoint","required":false,"unique":false,"options":{}}`,
+		},
 		{
 			schema.SchemaField{Type: schema.FieldTypeUser},
 			false,
@@ -556,6 +565,10 @@ func TestSchemaFieldPrepareValue(t *testing.T) {
 		{schema.SchemaField{Type: schema.FieldTypeText}, []int{1, 2}, `""`},
 		{schema.SchemaField{Type: schema.FieldTypeText}, "test", `"test"`},
 		{schema.SchemaField{Type: schema.FieldTypeText}, 123, `"123"`},
+		{schema.SchemaField{Type: schema.FieldTypeText, Options: &schema.TextOptions{Localized: true}}, nil, `{}`},
+		{schema.SchemaField{Type: schema.FieldTypeText, Options: &schema.TextOptions{Localized: true}}, "test", `{"*":"test"}`},
+		{schema.SchemaField{Type: schema.FieldTypeText, Options: &schema.TextOptions{Localized: true}}, `{"en":"test","de":""}`, `{"en":"test"}`},
+		{schema.SchemaField{Type: schema.FieldTypeText, Options: &schema.TextOptions{Localized: true}}, map[string]any{"en": "test", "de": 123}, `{"de":"123","en":"test"}`},
 
 		// email
 		{schema.SchemaField{Type: schema.FieldTypeEmail}, nil, `""`},
@@ -577,6 +590,8 @@ func TestSchemaFieldPrepareValue(t *testing.T) {
 		{schema.SchemaField{Type: schema.FieldTypeEditor}, []int{1, 2}, `""`},
 		{schema.SchemaField{Type: schema.FieldTypeEditor}, "test", `"test"`},
 		{schema.SchemaField{Type: schema.FieldTypeEditor}, 123, `"123"`},
+		{schema.SchemaField{Type: schema.FieldTypeEditor, Options: &schema.EditorOptions{Localized: true}}, "", `{}`},
+		{schema.SchemaField{Type: schema.FieldTypeEditor, Options: &schema.EditorOptions{Localized: true}}, map[string]string{"en": "<p>test</p>"}, `{"en":"\u003cp\u003etest\u003c/p\u003e"}`},
 
 		// json
 		{schema.SchemaField{Type: schema.FieldTypeJson}, nil, "null"},
@@ -881,6 +896,16 @@ func TestSchemaFieldPrepareValue(t *testing.T) {
 			[]string{"1ba88b4f-e9da-42f0-9764-9a55c953e724", "2ba88b4f-e9da-42f0-9764-9a55c953e724", "1ba88b4f-e9da-42f0-9764-9a55c953e724"},
 			`["1ba88b4f-e9da-42f0-9764-9a55c953e724","2ba88b4f-e9da-42f0-9764-9a55c953e724"]`,
 		},
+
+		// geoPoint
+		{schema.SchemaField{Type: schema.FieldTypeGeoPoint}, nil, "null"},
+		{schema.SchemaField{Type: schema.FieldTypeGeoPoint}, "", "null"},
+		{schema.SchemaField{Type: schema.FieldTypeGeoPoint}, "invalid", "null"},
+		{schema.SchemaField{Type: schema.FieldTypeGeoPoint}, map[string]any{"lat": 1}, "null"},
+		{schema.SchemaField{Type: schema.FieldTypeGeoPoint}, "42.5,-23", `{"lat":42.5,"lng":-23}`},
+		{schema.SchemaField{Type: schema.FieldTypeGeoPoint}, `{"lat":42.5,"lng":-23}`, `{"lat":42.5,"lng":-23}`},
+		{schema.SchemaField{Type: schema.FieldTypeGeoPoint}, map[string]any{"lat": 100, "lng": "200"}, `{"lat":100,"lng":200}`},
+		{schema.SchemaField{Type: schema.FieldTypeGeoPoint}, types.GeoPoint{Lat: 1, Lng: 2}, `{"lat":1,"lng":2}`},
 	}
 
 	for i, s := range scenarios {
@@ -898,6 +923,26 @@ func TestSchemaFieldPrepareValue(t *testing.T) {
 	}
 }
 
+func TestSchemaFieldIsLocalized(t *testing.T) {
+	scenarios := []struct {
+		field    schema.SchemaField
+		expected bool
+	}{
+		{schema.SchemaField{Type: schema.FieldTypeText}, false},
+		{schema.SchemaField{Type: schema.FieldTypeText, Options: &schema.TextOptions{Localized: true}}, true},
+		{schema.SchemaField{Type: schema.FieldTypeText, Options: map[string]any{"localized": true}}, true},
+		{schema.SchemaField{Type: schema.FieldTypeEditor}, false},
+		{schema.SchemaField{Type: schema.FieldTypeEditor, Options: &schema.EditorOptions{Localized: true}}, true},
+		{schema.SchemaField{Type: schema.FieldTypeJson, Options: map[string]any{"localized": true}}, false},
+	}
+
+	for i, s := range scenarios {
+		if v := s.field.IsLocalized(); v != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, v)
+		}
+	}
+}
+
 func TestSchemaFieldPrepareValueWithModifier(t *testing.T) {
 	scenarios := []struct {
 		name          string
@@ -2017,6 +2062,18 @@ func TestJsonOptionsValidate(t *testing.T) {
 	checkFieldOptionsScenarios(t, scenarios)
 }
 
+func TestGeoPointOptionsValidate(t *testing.T) {
+	scenarios := []fieldOptionsScenario{
+		{
+			"empty",
+			schema.GeoPointOptions{},
+			[]string{},
+		},
+	}
+
+	checkFieldOptionsScenarios(t, scenarios)
+}
+
 func TestFileOptionsValidate(t *testing.T) {
 	scenarios := []fieldOptionsScenario{
 		{
@@ -2070,6 +2127,53 @@ func TestFileOptionsValidate(t *testing.T) {
 			},
 			[]string{},
 		},
+		{
+			"invalid content types extension",
+			schema.FileOptions{
+				MaxSize:      1,
+				MaxSelect:    2,
+				ContentTypes: map[string]string{"TXT": "text/plain"},
+			},
+			[]string{"contentTypes"},
+		},
+		{
+			"invalid content types value",
+			schema.FileOptions{
+				MaxSize:      1,
+				MaxSelect:    2,
+				ContentTypes: map[string]string{".txt": "invalid"},
+			},
+			[]string{"contentTypes"},
+		},
+		{
+			"unsafe content types value",
+			schema.FileOptions{
+				MaxSize:      1,
+				MaxSelect:    2,
+				ContentTypes: map[string]string{".txt": "text/HTML"},
+			},
+			[]string{"contentTypes"},
+		},
+		{
+			"invalid content security policy",
+			schema.FileOptions{
+				MaxSize:               1,
+				MaxSelect:             2,
+				ContentSecurityPolicy: "sandbox\r\nX-Test: 1",
+			},
+			[]string{"contentSecurityPolicy"},
+		},
+		{
+			"valid serve options",
+			schema.FileOptions{
+				MaxSize:               1,
+				MaxSelect:             2,
+				ForceDownload:         true,
+				ContentTypes:          map[string]string{".md": "text/plain", ".svg": "application/octet-stream"},
+				ContentSecurityPolicy: "default-src 'none'; sandbox",
+			},
+			[]string{},
+		},
 	}
 
 	checkFieldOptionsScenarios(t, scenarios)
@@ -0,0 +1,69 @@
+package settings
+
+import (
+	"reflect"
+
+	"github.com/pocketbase/pocketbase/tools/jsondiff"
+)
+
+// Change defines a single settings field change.
+type Change struct {
+	Old any `json:"old"`
+	New any `json:"new"`
+}
+
+// Diff returns the changed fields between the current and the provided
+// new settings indexed by their json path (eg. "smtp.host").
+//
+// The changed secret values are masked (see [Settings.RedactClone()]).
+func (s *Settings) Diff(newSettings *Settings) (map[string]Change, error) {
+	oldFields, err := flattenSettings(s)
+	if err != nil {
+		return nil, err
+	}
+
+	newFields, err := flattenSettings(newSettings)
+	if err != nil {
+		return nil, err
+	}
+
+	oldRedacted, err := s.RedactClone()
+	if err != nil {
+		return nil, err
+	}
+	oldRedactedFields, err := flattenSettings(oldRedacted)
+	if err != nil {
+		return nil, err
+	}
+
+	newRedacted, err := newSettings.RedactClone()
+	if err != nil {
+		return nil, err
+	}
+	newRedactedFields, err := flattenSettings(newRedacted)
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]Change{}
+
+	for path, newValue := range newFields {
+		if oldValue, ok := oldFields[path]; !ok || !reflect.DeepEqual(oldValue, newValue) {
+			result[path] = Change{Old: oldRedactedFields[path], New: newRedactedFields[path]}
+		}
+	}
+
+	for path := range oldFields {
+		if _, ok := newFields[path]; !ok {
+			result[path] = Change{Old: oldRedactedFields[path], New: nil}
+		}
+	}
+
+	return result, nil
+}
+
+// flattenSettings serializes the provided settings into a flat
+// json path -> value map (arrays are not flattened).
+func flattenSettings(s *Settings) (map[string]any, error) {
+	return jsondiff.Flatten(s)
+}
@@ -0,0 +1,46 @@
+package settings_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models/settings"
+)
+
+func TestSettingsDiff(t *testing.T) {
+	s1 := settings.New()
+	s1.Meta.AppName = "old"
+	s1.Smtp.Password = "old_password"
+
+	// no changes
+	s2, _ := s1.Clone()
+	changes, err := s1.Diff(s2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 0 {
+		t.Fatalf("Expected no changes, got %v", changes)
+	}
+
+	// with changes
+	s2.Meta.AppName = "new"
+	s2.Smtp.Port = 1234
+	s2.Smtp.Password = "new_password"
+	s2.SqlConsole.Superadmins = []string{"a"}
+
+	changes, err = s1.Diff(s2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encoded, _ := json.Marshal(changes)
+
+	expected := `{"meta.appName":{"old":"old","new":"new"},` +
+		`"smtp.password":{"old":"******","new":"******"},` +
+		`"smtp.port":{"old":587,"new":1234},` +
+		`"sqlConsole.superadmins":{"old":null,"new":["a"]}}`
+
+	if string(encoded) != expected {
+		t.Fatalf("Expected \n%s, got \n%s", expected, encoded)
+	}
+}
@@ -0,0 +1,240 @@
+package settings
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// EnvPrefix is the common prefix of the settings environment variable overrides.
+const EnvPrefix string = "PB_"
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// EnvName returns the environment variable name that overrides the
+// settings field with the provided json path (eg. "smtp.host" -> "PB_SMTP_HOST").
+func EnvName(path string) string {
+	var sb strings.Builder
+
+	sb.WriteString(EnvPrefix)
+
+	var prev rune
+	for _, r := range path {
+		switch {
+		case r == '.':
+			sb.WriteRune('_')
+		case unicode.IsUpper(r) && (unicode.IsLower(prev) || unicode.IsDigit(prev)):
+			sb.WriteRune('_')
+			sb.WriteRune(r)
+		default:
+			sb.WriteRune(unicode.ToUpper(r))
+		}
+		prev = r
+	}
+
+	return sb.String()
+}
+
+// ApplyEnv overrides the settings fields with the values of their
+// matching [EnvPrefix] environment variables from the provided
+// "KEY=value" list (usually os.Environ()).
+//
+// String fields use the raw env value and all other fields are
+// decoded as json (eg. PB_SMTP_PORT=587, PB_SMTP_ENABLED=true).
+//
+// The overridden fields are marked as env-locked (see [Settings.EnvLocked()]).
+func (s *Settings) ApplyEnv(environ []string) error {
+	env := make(map[string]string, len(environ))
+	for _, item := range environ {
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) == 2 && strings.HasPrefix(parts[0], EnvPrefix) {
+			env[parts[0]] = parts[1]
+		}
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	type override struct {
+		field reflect.Value
+		value reflect.Value
+	}
+
+	// decode all values first so that the settings are not partially overridden on error
+	overrides := []override{}
+	locked := map[string]string{}
+
+	var decodeErr error
+
+	walkEnvFields(reflect.ValueOf(s).Elem(), "", func(path string, field reflect.Value) {
+		name := EnvName(path)
+
+		raw, ok := env[name]
+		if !ok || decodeErr != nil {
+			return
+		}
+
+		value := reflect.New(field.Type())
+		if field.Kind() == reflect.String {
+			value.Elem().SetString(raw)
+		} else if err := json.Unmarshal([]byte(raw), value.Interface()); err != nil {
+			decodeErr = fmt.Errorf("invalid %s env value: %w", name, err)
+			return
+		}
+
+		overrides = append(overrides, override{field, value.Elem()})
+		locked[path] = name
+	})
+
+	if decodeErr != nil {
+		return decodeErr
+	}
+
+	for _, o := range overrides {
+		o.field.Set(o.value)
+	}
+
+	s.envLocked = locked
+
+	return nil
+}
+
+// EnvLocked returns the sorted json paths of the settings fields
+// overridden by environment variables (eg. "smtp.host").
+func (s *Settings) EnvLocked() []string {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	result := make([]string, 0, len(s.envLocked))
+	for path := range s.envLocked {
+		result = append(result, path)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+// CheckEnvLocked checks whether the env-locked fields of the current
+// settings have the same values in the provided other settings.
+//
+// Returns [validation.Errors] nested by the json path of each changed field.
+func (s *Settings) CheckEnvLocked(other *Settings) error {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	if len(s.envLocked) == 0 {
+		return nil
+	}
+
+	other.mux.RLock()
+	defer other.mux.RUnlock()
+
+	result := validation.Errors{}
+
+	for path, name := range s.envLocked {
+		if reflect.DeepEqual(envFieldByPath(s, path).Interface(), envFieldByPath(other, path).Interface()) {
+			continue
+		}
+
+		parts := strings.Split(path, ".")
+		errs := result
+		for _, part := range parts[:len(parts)-1] {
+			nested, ok := errs[part].(validation.Errors)
+			if !ok {
+				nested = validation.Errors{}
+				errs[part] = nested
+			}
+			errs = nested
+		}
+		errs[parts[len(parts)-1]] = validation.NewError(
+			"validation_env_locked",
+			fmt.Sprintf("The field is locked by the %s environment variable.", name),
+		)
+	}
+
+	if len(result) == 0 {
+		return nil
+	}
+
+	return result
+}
+
+// RestoreEnvLocked replaces the env-locked fields of the current
+// settings with the ones from the provided original settings
+// (eg. to prevent persisting the env values).
+func (s *Settings) RestoreEnvLocked(original *Settings) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	original.mux.RLock()
+	defer original.mux.RUnlock()
+
+	for path := range s.envLocked {
+		envFieldByPath(s, path).Set(envFieldByPath(original, path))
+	}
+}
+
+// walkEnvFields calls fn for each exported leaf field of the provided struct value.
+//
+// Nested structs are traversed, unless they implement [json.Unmarshaler].
+func walkEnvFields(v reflect.Value, prefix string, fn func(path string, field reflect.Value)) {
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		name := envFieldName(sf)
+		if name == "" {
+			continue
+		}
+
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+
+		field := v.Field(i)
+
+		if field.Kind() == reflect.Struct && !reflect.PtrTo(field.Type()).Implements(jsonUnmarshalerType) {
+			walkEnvFields(field, path, fn)
+			continue
+		}
+
+		fn(path, field)
+	}
+}
+
+// envFieldByPath returns the settings field with the provided json path.
+func envFieldByPath(s *Settings, path string) reflect.Value {
+	v := reflect.ValueOf(s).Elem()
+
+	for _, part := range strings.Split(path, ".") {
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).IsExported() && envFieldName(t.Field(i)) == part {
+				v = v.Field(i)
+				break
+			}
+		}
+	}
+
+	return v
+}
+
+func envFieldName(sf reflect.StructField) string {
+	name := strings.Split(sf.Tag.Get("json"), ",")[0]
+	if name == "-" {
+		return ""
+	}
+
+	return name
+}
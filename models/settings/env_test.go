@@ -0,0 +1,175 @@
+package settings_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/models/settings"
+)
+
+func TestEnvName(t *testing.T) {
+	scenarios := []struct {
+		path     string
+		expected string
+	}{
+		{"smtp.host", "PB_SMTP_HOST"},
+		{"meta.appName", "PB_META_APP_NAME"},
+		{"s3Events.token", "PB_S3_EVENTS_TOKEN"},
+		{"oidc2Auth.clientSecret", "PB_OIDC2_AUTH_CLIENT_SECRET"},
+		{"backups.s3.forcePathStyle", "PB_BACKUPS_S3_FORCE_PATH_STYLE"},
+	}
+
+	for _, s := range scenarios {
+		if v := settings.EnvName(s.path); v != s.expected {
+			t.Errorf("(%s) Expected %q, got %q", s.path, s.expected, v)
+		}
+	}
+}
+
+func TestSettingsApplyEnv(t *testing.T) {
+	s := settings.New()
+	s.Smtp.Host = "stored.example.com"
+	s.Smtp.Port = 25
+
+	err := s.ApplyEnv([]string{
+		"PB_SMTP_HOST=env.example.com",
+		"PB_SMTP_PORT=587",
+		"PB_SMTP_ENABLED=true",
+		"PB_META_APP_NAME=a=b",
+		"PB_LOGS_MAX_DAYS=10",
+		"PB_SQL_CONSOLE_SUPERADMINS=[\"a\",\"b\"]",
+		"PB_UNKNOWN=test",
+		"OTHER_SMTP_USERNAME=test",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s.Smtp.Host != "env.example.com" || s.Smtp.Port != 587 || !s.Smtp.Enabled {
+		t.Fatalf("Expected the smtp env overrides to be applied, got %+v", s.Smtp)
+	}
+
+	if s.Smtp.Username != "" {
+		t.Fatalf("Expected the non-prefixed env variable to be ignored, got %q", s.Smtp.Username)
+	}
+
+	if s.Meta.AppName != "a=b" {
+		t.Fatalf("Expected app name %q, got %q", "a=b", s.Meta.AppName)
+	}
+
+	if s.Logs.MaxDays != 10 {
+		t.Fatalf("Expected logs max days 10, got %d", s.Logs.MaxDays)
+	}
+
+	if v := strings.Join(s.SqlConsole.Superadmins, ","); v != "a,b" {
+		t.Fatalf("Expected superadmins %q, got %q", "a,b", v)
+	}
+
+	expectedLocked := "logs.maxDays,meta.appName,smtp.enabled,smtp.host,smtp.port,sqlConsole.superadmins"
+	if locked := strings.Join(s.EnvLocked(), ","); locked != expectedLocked {
+		t.Fatalf("Expected env locked fields %q, got %q", expectedLocked, locked)
+	}
+
+	// the locked fields must be preserved on clone
+	clone, err := s.Clone()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a, b := strings.Join(s.EnvLocked(), ","), strings.Join(clone.EnvLocked(), ","); a != b {
+		t.Fatalf("Expected the clone env locked fields %q, got %q", a, b)
+	}
+
+	// reapply without env variables
+	if err := s.ApplyEnv(nil); err != nil {
+		t.Fatal(err)
+	}
+	if locked := s.EnvLocked(); len(locked) != 0 {
+		t.Fatalf("Expected no env locked fields, got %v", locked)
+	}
+}
+
+func TestSettingsApplyEnvInvalidValue(t *testing.T) {
+	s := settings.New()
+	s.Smtp.Host = "stored.example.com"
+
+	err := s.ApplyEnv([]string{
+		"PB_SMTP_HOST=env.example.com",
+		"PB_SMTP_PORT=invalid",
+	})
+	if err == nil || !strings.Contains(err.Error(), "PB_SMTP_PORT") {
+		t.Fatalf("Expected PB_SMTP_PORT error, got %v", err)
+	}
+
+	// no partial overrides
+	if s.Smtp.Host != "stored.example.com" {
+		t.Fatalf("Expected the smtp host to remain unchanged, got %q", s.Smtp.Host)
+	}
+
+	if locked := s.EnvLocked(); len(locked) != 0 {
+		t.Fatalf("Expected no env locked fields, got %v", locked)
+	}
+}
+
+func TestSettingsCheckEnvLocked(t *testing.T) {
+	s := settings.New()
+
+	// no locked fields
+	other, _ := s.Clone()
+	other.Smtp.Host = "changed.example.com"
+	if err := s.CheckEnvLocked(other); err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	if err := s.ApplyEnv([]string{"PB_SMTP_HOST=env.example.com", "PB_META_APP_NAME=env"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// unchanged locked fields
+	unchanged, _ := s.Clone()
+	unchanged.Smtp.Port = 1234
+	if err := s.CheckEnvLocked(unchanged); err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	// changed locked fields
+	changed, _ := s.Clone()
+	changed.Smtp.Host = "changed.example.com"
+	changed.Smtp.Port = 1234
+
+	err := s.CheckEnvLocked(changed)
+
+	errs, ok := err.(validation.Errors)
+	if !ok {
+		t.Fatalf("Expected validation.Errors, got %v", err)
+	}
+
+	encoded, _ := json.Marshal(errs)
+	expected := `{"smtp":{"host":"The field is locked by the PB_SMTP_HOST environment variable."}}`
+	if string(encoded) != expected {
+		t.Fatalf("Expected \n%s, got \n%s", expected, encoded)
+	}
+}
+
+func TestSettingsRestoreEnvLocked(t *testing.T) {
+	original := settings.New()
+	original.Smtp.Host = "stored.example.com"
+	original.Meta.AppName = "stored"
+
+	s, _ := original.Clone()
+	if err := s.ApplyEnv([]string{"PB_SMTP_HOST=env.example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	s.Meta.AppName = "changed"
+
+	s.RestoreEnvLocked(original)
+
+	if s.Smtp.Host != "stored.example.com" {
+		t.Fatalf("Expected the locked smtp host to be restored, got %q", s.Smtp.Host)
+	}
+
+	if s.Meta.AppName != "changed" {
+		t.Fatalf("Expected the non-locked app name to remain unchanged, got %q", s.Meta.AppName)
+	}
+}
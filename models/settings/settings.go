@@ -4,15 +4,27 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/pocketbase/pocketbase/tools/anonymize"
 	"github.com/pocketbase/pocketbase/tools/auth"
 	"github.com/pocketbase/pocketbase/tools/cron"
+	"github.com/pocketbase/pocketbase/tools/egress"
+	"github.com/pocketbase/pocketbase/tools/filesystem"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/locale"
+	"github.com/pocketbase/pocketbase/tools/logship"
 	"github.com/pocketbase/pocketbase/tools/mailer"
 	"github.com/pocketbase/pocketbase/tools/rest"
+	"github.com/pocketbase/pocketbase/tools/searchsync"
 	"github.com/pocketbase/pocketbase/tools/security"
 )
 
@@ -24,12 +36,65 @@ const SecretMask string = "******"
 type Settings struct {
 	mux sync.RWMutex
 
+	// envLocked holds the env overridden fields
+	// as json path -> env name pairs (see ApplyEnv()).
+	envLocked map[string]string
+
 	Meta    MetaConfig    `form:"meta" json:"meta"`
 	Logs    LogsConfig    `form:"logs" json:"logs"`
 	Smtp    SmtpConfig    `form:"smtp" json:"smtp"`
 	S3      S3Config      `form:"s3" json:"s3"`
 	Backups BackupsConfig `form:"backups" json:"backups"`
 
+	// StorageDriver is an optional registered filesystem driver config
+	// for the app files storage (it takes precedence over the S3 config).
+	StorageDriver FilesystemDriverConfig `form:"storageDriver" json:"storageDriver"`
+
+	// FileDedup enables the content hash based deduplication of the uploaded record files.
+	FileDedup FileDedupConfig `form:"fileDedup" json:"fileDedup"`
+
+	// FileServe configures how the stored files are served (eg. the range requests chunks).
+	FileServe FileServeConfig `form:"fileServe" json:"fileServe"`
+
+	// TrustedProxy configures the client IP resolution behind reverse proxies.
+	TrustedProxy TrustedProxyConfig `form:"trustedProxy" json:"trustedProxy"`
+
+	// SecurityHeaders configures the security related response headers.
+	SecurityHeaders SecurityHeadersConfig `form:"securityHeaders" json:"securityHeaders"`
+
+	// SecurityReport configures the brute-force and anomaly detection report.
+	SecurityReport SecurityReportConfig `form:"securityReport" json:"securityReport"`
+
+	// Metrics configures the Prometheus metrics collection and endpoint.
+	Metrics MetricsConfig `form:"metrics" json:"metrics"`
+
+	// SlowQueries configures the tracking of the slow data db queries.
+	SlowQueries SlowQueriesConfig `form:"slowQueries" json:"slowQueries"`
+
+	// LogShipping configures the streaming of the request logs to an external sink.
+	LogShipping LogShippingConfig `form:"logShipping" json:"logShipping"`
+
+	S3Events          S3EventsConfig          `form:"s3Events" json:"s3Events"`
+	SearchSync        SearchSyncConfig        `form:"searchSync" json:"searchSync"`
+	SqlConsole        SqlConsoleConfig        `form:"sqlConsole" json:"sqlConsole"`
+	Analytics         AnalyticsConfig         `form:"analytics" json:"analytics"`
+	RealtimeBroadcast RealtimeBroadcastConfig `form:"realtimeBroadcast" json:"realtimeBroadcast"`
+	RealtimeReplay    RealtimeReplayConfig    `form:"realtimeReplay" json:"realtimeReplay"`
+	RateLimits        RateLimitsConfig        `form:"rateLimits" json:"rateLimits"`
+	Swagger           SwaggerConfig           `form:"swagger" json:"swagger"`
+	Graphql           GraphqlConfig           `form:"graphql" json:"graphql"`
+	Approvals         ApprovalsConfig         `form:"approvals" json:"approvals"`
+	Egress            EgressConfig            `form:"egress" json:"egress"`
+	Anonymization     AnonymizationConfig     `form:"anonymization" json:"anonymization"`
+	Localization      LocalizationConfig      `form:"localization" json:"localization"`
+	Billing           BillingConfig           `form:"billing" json:"billing"`
+	PublicForms       PublicFormsConfig       `form:"publicForms" json:"publicForms"`
+	Comments          CommentsConfig          `form:"comments" json:"comments"`
+	Caching           CachingConfig           `form:"caching" json:"caching"`
+	Versioning        VersioningConfig        `form:"versioning" json:"versioning"`
+	Idempotency       IdempotencyConfig       `form:"idempotency" json:"idempotency"`
+	Webhooks          WebhooksConfig          `form:"webhooks" json:"webhooks"`
+
 	AdminAuthToken           TokenConfig `form:"adminAuthToken" json:"adminAuthToken"`
 	AdminPasswordResetToken  TokenConfig `form:"adminPasswordResetToken" json:"adminPasswordResetToken"`
 	AdminFileToken           TokenConfig `form:"adminFileToken" json:"adminFileToken"`
@@ -39,6 +104,15 @@ type Settings struct {
 	RecordVerificationToken  TokenConfig `form:"recordVerificationToken" json:"recordVerificationToken"`
 	RecordFileToken          TokenConfig `form:"recordFileToken" json:"recordFileToken"`
 
+	// Users configures the GORM registry users constraints.
+	Users UsersConfig `form:"users" json:"users"`
+
+	// UserAuthToken configures the GORM registry users authentication tokens.
+	UserAuthToken TokenConfig `form:"userAuthToken" json:"userAuthToken"`
+
+	// TokenSigning configures the admin and auth record authentication tokens signing.
+	TokenSigning TokenSigningConfig `form:"tokenSigning" json:"tokenSigning"`
+
 	// Deprecated: Will be removed in v0.9+
 	EmailAuth EmailAuthConfig `form:"emailAuth" json:"emailAuth"`
 
@@ -76,7 +150,8 @@ func New() *Settings {
 			ConfirmEmailChangeTemplate: defaultConfirmEmailChangeTemplate,
 		},
 		Logs: LogsConfig{
-			MaxDays: 5,
+			MaxDays:      5,
+			AuditMaxDays: 90,
 		},
 		Smtp: SmtpConfig{
 			Enabled:  false,
@@ -89,6 +164,75 @@ func New() *Settings {
 		Backups: BackupsConfig{
 			CronMaxKeep: 3,
 		},
+		SearchSync: SearchSyncConfig{
+			Enabled:  false,
+			Provider: searchsync.ProviderMeilisearch,
+		},
+		SqlConsole: SqlConsoleConfig{
+			Enabled: false,
+			MaxRows: 500,
+			Timeout: 10,
+		},
+		Analytics: AnalyticsConfig{
+			Enabled:      false,
+			RefreshCron:  "0 * * * *",
+			MaxRows:      1000,
+			Timeout:      30,
+			QuotaQueries: 100,
+			QuotaWindow:  3600,
+		},
+		Idempotency: IdempotencyConfig{
+			Enabled: false,
+			TTL:     86400,
+		},
+		SlowQueries: SlowQueriesConfig{
+			Enabled:   false,
+			Threshold: 100,
+		},
+		RealtimeReplay: RealtimeReplayConfig{
+			Enabled:   false,
+			MaxEvents: 100,
+			TTL:       300,
+		},
+		RateLimits: RateLimitsConfig{
+			Enabled:     false,
+			MaxRequests: 300,
+			Duration:    60,
+		},
+		Swagger: SwaggerConfig{
+			Enabled: true,
+		},
+		SecurityHeaders: SecurityHeadersConfig{
+			ContentTypeNosniff:        true,
+			FrameOptions:              "SAMEORIGIN",
+			ReferrerPolicy:            "strict-origin-when-cross-origin",
+			DocsContentSecurityPolicy: DefaultDocsContentSecurityPolicy,
+		},
+		SecurityReport: SecurityReportConfig{
+			Window:                3600, // 1 hour
+			BaselineDays:          7,
+			AuthFailuresThreshold: 10,
+			ForbiddenThreshold:    50,
+		},
+		Approvals: ApprovalsConfig{
+			Enabled:  false,
+			Duration: 86400, // 1 day
+		},
+		Anonymization: AnonymizationConfig{
+			Salt: security.RandomString(50),
+		},
+		Localization: LocalizationConfig{
+			DefaultLocale: "en",
+		},
+		Webhooks: WebhooksConfig{
+			LogsMaxDays: 7,
+		},
+		Billing: BillingConfig{
+			StripeCustomerField:  "stripeCustomerId",
+			StripeStatusField:    "subscriptionStatus",
+			StripePlanField:      "subscriptionPlan",
+			StripePeriodEndField: "subscriptionPeriodEnd",
+		},
 		AdminAuthToken: TokenConfig{
 			Secret:   security.RandomString(50),
 			Duration: 1209600, // 14 days
@@ -117,10 +261,18 @@ func New() *Settings {
 			Secret:   security.RandomString(50),
 			Duration: 120, // 2 minutes
 		},
+		UserAuthToken: TokenConfig{
+			Secret:   security.RandomString(50),
+			Duration: 1209600, // 14 days
+		},
 		RecordEmailChangeToken: TokenConfig{
 			Secret:   security.RandomString(50),
 			Duration: 1800, // 30 minutes
 		},
+		TokenSigning: TokenSigningConfig{
+			Algorithm: security.AlgorithmHS256,
+			Keys:      []TokenSigningKey{},
+		},
 		GoogleAuth: AuthProviderConfig{
 			Enabled: false,
 		},
@@ -186,6 +338,7 @@ func (s *Settings) Validate() error {
 	return validation.ValidateStruct(s,
 		validation.Field(&s.Meta),
 		validation.Field(&s.Logs),
+		validation.Field(&s.LogShipping),
 		validation.Field(&s.AdminAuthToken),
 		validation.Field(&s.AdminPasswordResetToken),
 		validation.Field(&s.AdminFileToken),
@@ -194,9 +347,37 @@ func (s *Settings) Validate() error {
 		validation.Field(&s.RecordEmailChangeToken),
 		validation.Field(&s.RecordVerificationToken),
 		validation.Field(&s.RecordFileToken),
+		validation.Field(&s.UserAuthToken),
+		validation.Field(&s.TokenSigning),
 		validation.Field(&s.Smtp),
 		validation.Field(&s.S3),
+		validation.Field(&s.StorageDriver),
+		validation.Field(&s.FileServe),
+		validation.Field(&s.TrustedProxy),
+		validation.Field(&s.SecurityHeaders),
+		validation.Field(&s.SecurityReport),
+		validation.Field(&s.Metrics),
+		validation.Field(&s.SlowQueries),
+		validation.Field(&s.S3Events),
 		validation.Field(&s.Backups),
+		validation.Field(&s.SearchSync),
+		validation.Field(&s.SqlConsole),
+		validation.Field(&s.Analytics),
+		validation.Field(&s.RealtimeBroadcast),
+		validation.Field(&s.RealtimeReplay),
+		validation.Field(&s.RateLimits),
+		validation.Field(&s.Swagger),
+		validation.Field(&s.Approvals),
+		validation.Field(&s.Egress),
+		validation.Field(&s.Anonymization),
+		validation.Field(&s.Localization),
+		validation.Field(&s.Billing),
+		validation.Field(&s.PublicForms),
+		validation.Field(&s.Comments),
+		validation.Field(&s.Caching),
+		validation.Field(&s.Versioning),
+		validation.Field(&s.Idempotency),
+		validation.Field(&s.Webhooks),
 		validation.Field(&s.GoogleAuth),
 		validation.Field(&s.FacebookAuth),
 		validation.Field(&s.GithubAuth),
@@ -237,6 +418,14 @@ func (s *Settings) Clone() (*Settings, error) {
 	if err := clone.Merge(s); err != nil {
 		return nil, err
 	}
+
+	s.mux.RLock()
+	clone.envLocked = make(map[string]string, len(s.envLocked))
+	for path, name := range s.envLocked {
+		clone.envLocked[path] = name
+	}
+	s.mux.RUnlock()
+
 	return clone, nil
 }
 
@@ -251,7 +440,17 @@ func (s *Settings) RedactClone() (*Settings, error) {
 	sensitiveFields := []*string{
 		&clone.Smtp.Password,
 		&clone.S3.Secret,
+		&clone.S3Events.Token,
 		&clone.Backups.S3.Secret,
+		&clone.Backups.EncryptionKey,
+		&clone.SearchSync.ApiKey,
+		&clone.LogShipping.AuthHeader,
+		&clone.RealtimeBroadcast.Token,
+		&clone.Swagger.ApiKey,
+		&clone.Metrics.Token,
+		&clone.Anonymization.Salt,
+		&clone.Billing.StripeWebhookSecret,
+		&clone.PublicForms.CaptchaSecret,
 		&clone.AdminAuthToken.Secret,
 		&clone.AdminPasswordResetToken.Secret,
 		&clone.AdminFileToken.Secret,
@@ -260,6 +459,7 @@ func (s *Settings) RedactClone() (*Settings, error) {
 		&clone.RecordEmailChangeToken.Secret,
 		&clone.RecordVerificationToken.Secret,
 		&clone.RecordFileToken.Secret,
+		&clone.UserAuthToken.Secret,
 		&clone.GoogleAuth.ClientSecret,
 		&clone.FacebookAuth.ClientSecret,
 		&clone.GithubAuth.ClientSecret,
@@ -280,6 +480,17 @@ func (s *Settings) RedactClone() (*Settings, error) {
 		&clone.AppleAuth.ClientSecret,
 	}
 
+	for i := range clone.TokenSigning.Keys {
+		sensitiveFields = append(sensitiveFields, &clone.TokenSigning.Keys[i].PrivateKey)
+	}
+
+	for name, hook := range clone.Webhooks.Hooks {
+		if hook.Secret != "" {
+			hook.Secret = SecretMask
+			clone.Webhooks.Hooks[name] = hook
+		}
+	}
+
 	// mask all sensitive fields
 	for _, v := range sensitiveFields {
 		if v != nil && *v != "" {
@@ -287,9 +498,53 @@ func (s *Settings) RedactClone() (*Settings, error) {
 		}
 	}
 
+	// the driver options are arbitrary and could hold credentials
+	for _, options := range []map[string]string{clone.StorageDriver.Options, clone.Backups.Driver.Options} {
+		for k, v := range options {
+			if v != "" {
+				options[k] = SecretMask
+			}
+		}
+	}
+
 	return clone, nil
 }
 
+// SecretFields returns a map with the settings fields that could hold
+// a secret reference (see [secrets.Manager]) indexed by their json path
+// (eg. "smtp.password").
+func (s *Settings) SecretFields() map[string]string {
+	result := map[string]string{}
+
+	for name, config := range s.NamedAuthProviderConfigs() {
+		result[name+"Auth.clientSecret"] = config.ClientSecret
+	}
+
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	result["smtp.password"] = s.Smtp.Password
+	result["s3.secret"] = s.S3.Secret
+	result["backups.s3.secret"] = s.Backups.S3.Secret
+	result["searchSync.apiKey"] = s.SearchSync.ApiKey
+	result["logShipping.authHeader"] = s.LogShipping.AuthHeader
+	result["billing.stripeWebhookSecret"] = s.Billing.StripeWebhookSecret
+	result["publicForms.captchaSecret"] = s.PublicForms.CaptchaSecret
+
+	for name, hook := range s.Webhooks.Hooks {
+		result["webhooks.hooks."+name+".secret"] = hook.Secret
+	}
+
+	for k, v := range s.StorageDriver.Options {
+		result["storageDriver.options."+k] = v
+	}
+	for k, v := range s.Backups.Driver.Options {
+		result["backups.driver.options."+k] = v
+	}
+
+	return result
+}
+
 // NamedAuthProviderConfigs returns a map with all registered OAuth2
 // provider configurations (indexed by their name identifier).
 func (s *Settings) NamedAuthProviderConfigs() map[string]AuthProviderConfig {
@@ -335,6 +590,123 @@ func (c TokenConfig) Validate() error {
 
 // -------------------------------------------------------------------
 
+var tokenSigningKeyIdRegex = regexp.MustCompile(`^[\w\-]+$`)
+
+// TokenSigningConfig defines the signing options of the admin and auth record
+// authentication tokens.
+//
+// With the asymmetric algorithms (RS256 and EdDSA) the tokens could be verified
+// by other services with the public keys listed in "/.well-known/jwks.json".
+// The previously issued HS256 tokens remain valid until their expiration.
+type TokenSigningConfig struct {
+	// Algorithm is the auth tokens signing algorithm - HS256 (default), RS256 or EdDSA.
+	Algorithm string `form:"algorithm" json:"algorithm"`
+
+	// KeyId is the id of the key used to sign the new tokens
+	// (required for the asymmetric algorithms).
+	KeyId string `form:"keyId" json:"keyId"`
+
+	// Keys lists the asymmetric signing keys.
+	//
+	// The non active keys are still used to verify the already
+	// issued tokens and are listed in the JWKS, allowing key rotation.
+	Keys []TokenSigningKey `form:"keys" json:"keys"`
+}
+
+// Validate makes TokenSigningConfig validatable by implementing [validation.Validatable] interface.
+func (c TokenSigningConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(
+			&c.Algorithm,
+			validation.In(security.AlgorithmHS256, security.AlgorithmRS256, security.AlgorithmEdDSA),
+		),
+		validation.Field(
+			&c.KeyId,
+			validation.When(c.IsAsymmetric(), validation.Required, validation.By(c.checkActiveKey)),
+		),
+		validation.Field(&c.Keys, validation.By(checkTokenSigningKeysUniqueness)),
+	)
+}
+
+// IsAsymmetric reports whether the auth tokens are signed with an asymmetric key.
+func (c TokenSigningConfig) IsAsymmetric() bool {
+	return c.Algorithm == security.AlgorithmRS256 || c.Algorithm == security.AlgorithmEdDSA
+}
+
+// FindKey returns the signing key with the specified id (or nil if missing).
+func (c TokenSigningConfig) FindKey(id string) *TokenSigningKey {
+	for i := range c.Keys {
+		if c.Keys[i].Id == id {
+			return &c.Keys[i]
+		}
+	}
+
+	return nil
+}
+
+func (c TokenSigningConfig) checkActiveKey(value any) error {
+	v, _ := value.(string)
+	if v == "" {
+		return nil // nothing to check
+	}
+
+	if key := c.FindKey(v); key == nil || key.Algorithm != c.Algorithm {
+		return validation.NewError("validation_invalid_token_signing_key", "Missing "+c.Algorithm+" signing key with the specified id.")
+	}
+
+	return nil
+}
+
+func checkTokenSigningKeysUniqueness(value any) error {
+	v, _ := value.([]TokenSigningKey)
+
+	ids := make(map[string]struct{}, len(v))
+	for _, key := range v {
+		if _, ok := ids[key.Id]; ok {
+			return validation.NewError("validation_duplicated_token_signing_key", "Duplicated signing key id "+key.Id+".")
+		}
+		ids[key.Id] = struct{}{}
+	}
+
+	return nil
+}
+
+// TokenSigningKey defines a single asymmetric auth tokens signing key.
+type TokenSigningKey struct {
+	// Id is the key identifier (aka. the token "kid" header).
+	Id string `form:"id" json:"id"`
+
+	// Algorithm is the key algorithm - RS256 or EdDSA.
+	Algorithm string `form:"algorithm" json:"algorithm"`
+
+	// PrivateKey is the PEM encoded private key.
+	PrivateKey string `form:"privateKey" json:"privateKey"`
+}
+
+// Validate makes TokenSigningKey validatable by implementing [validation.Validatable] interface.
+func (k TokenSigningKey) Validate() error {
+	return validation.ValidateStruct(&k,
+		validation.Field(&k.Id, validation.Required, validation.Length(1, 100), validation.Match(tokenSigningKeyIdRegex)),
+		validation.Field(&k.Algorithm, validation.Required, validation.In(security.AlgorithmRS256, security.AlgorithmEdDSA)),
+		validation.Field(&k.PrivateKey, validation.Required, validation.By(k.checkPrivateKey)),
+	)
+}
+
+func (k TokenSigningKey) checkPrivateKey(value any) error {
+	v, _ := value.(string)
+	if v == "" {
+		return nil // nothing to check
+	}
+
+	if _, err := security.ParseSigningKey(k.Algorithm, v); err != nil {
+		return validation.NewError("validation_invalid_private_key", "Invalid "+k.Algorithm+" private key.")
+	}
+
+	return nil
+}
+
+// -------------------------------------------------------------------
+
 type SmtpConfig struct {
 	Enabled  bool   `form:"enabled" json:"enabled"`
 	Host     string `form:"host" json:"host"`
@@ -385,6 +757,14 @@ type S3Config struct {
 	AccessKey      string `form:"accessKey" json:"accessKey"`
 	Secret         string `form:"secret" json:"secret"`
 	ForcePathStyle bool   `form:"forcePathStyle" json:"forcePathStyle"`
+
+	// PartSize is the size in megabytes of the multipart upload parts
+	// and of the parallel download ranges (default to 5MB if not set).
+	PartSize int `form:"partSize" json:"partSize"`
+
+	// Concurrency is the max number of parts that are uploaded
+	// or downloaded in parallel (default to 5 if not set).
+	Concurrency int `form:"concurrency" json:"concurrency"`
 }
 
 // Validate makes S3Config validatable by implementing [validation.Validatable] interface.
@@ -395,177 +775,1802 @@ func (c S3Config) Validate() error {
 		validation.Field(&c.Region, validation.When(c.Enabled, validation.Required)),
 		validation.Field(&c.AccessKey, validation.When(c.Enabled, validation.Required)),
 		validation.Field(&c.Secret, validation.When(c.Enabled, validation.Required)),
+		// S3 requires at least 5MB parts (except the last one)
+		validation.Field(&c.PartSize, validation.When(c.PartSize != 0, validation.Min(5)), validation.Max(5120)),
+		validation.Field(&c.Concurrency, validation.Min(0), validation.Max(64)),
 	)
 }
 
+// TransferPartSize returns the multipart transfers part size in bytes.
+func (c S3Config) TransferPartSize() int64 {
+	if c.PartSize <= 0 {
+		return filesystem.DefaultTransferPartSize
+	}
+
+	return int64(c.PartSize) << 20
+}
+
+// TransferConcurrency returns the max number of parallel transferred parts.
+func (c S3Config) TransferConcurrency() int {
+	if c.Concurrency <= 0 {
+		return filesystem.DefaultTransferConcurrency
+	}
+
+	return c.Concurrency
+}
+
 // -------------------------------------------------------------------
 
-type BackupsConfig struct {
-	// Cron is a cron expression to schedule auto backups, eg. "* * * * *".
+// FilesystemDriverConfig defines a registered filesystem driver
+// (see [filesystem.RegisterDriver]) used for a specific storage purpose.
+type FilesystemDriverConfig struct {
+	// Name is the registered driver name.
 	//
-	// Leave it empty to disable the auto backups functionality.
-	Cron string `form:"cron" json:"cron"`
+	// Leave it empty to fallback to the S3 or local filesystem.
+	Name string `form:"name" json:"name"`
 
-	// CronMaxKeep is the the max number of cron generated backups to
-	// keep before removing older entries.
+	// Options are the driver specific options.
 	//
-	// This field works only when the cron config has valid cron expression.
-	CronMaxKeep int `form:"cronMaxKeep" json:"cronMaxKeep"`
+	// The option values could be also secret references (see [secrets.Manager]).
+	Options map[string]string `form:"options" json:"options"`
+}
 
-	// S3 is an optional S3 storage config specifying where to store the app backups.
-	S3 S3Config `form:"s3" json:"s3"`
+// Enabled reports whether a driver is configured.
+func (c FilesystemDriverConfig) Enabled() bool {
+	return c.Name != ""
 }
 
-// Validate makes BackupsConfig validatable by implementing [validation.Validatable] interface.
-func (c BackupsConfig) Validate() error {
+// Validate makes FilesystemDriverConfig validatable by implementing [validation.Validatable] interface.
+func (c FilesystemDriverConfig) Validate() error {
 	return validation.ValidateStruct(&c,
-		validation.Field(&c.S3),
-		validation.Field(&c.Cron, validation.By(checkCronExpression)),
-		validation.Field(
-			&c.CronMaxKeep,
-			validation.When(c.Cron != "", validation.Required),
-			validation.Min(1),
-		),
+		validation.Field(&c.Name, validation.By(checkFilesystemDriver)),
 	)
 }
 
-func checkCronExpression(value any) error {
+func checkFilesystemDriver(value any) error {
 	v, _ := value.(string)
-	if v == "" {
-		return nil // nothing to check
-	}
-
-	_, err := cron.NewSchedule(v)
-	if err != nil {
-		return validation.NewError("validation_invalid_cron", err.Error())
+	if v == "" || filesystem.HasDriver(v) {
+		return nil
 	}
 
-	return nil
+	return validation.NewError("validation_unknown_filesystem_driver", "Unknown filesystem driver.")
 }
 
 // -------------------------------------------------------------------
 
-type MetaConfig struct {
-	AppName                    string        `form:"appName" json:"appName"`
-	AppUrl                     string        `form:"appUrl" json:"appUrl"`
-	HideControls               bool          `form:"hideControls" json:"hideControls"`
-	SenderName                 string        `form:"senderName" json:"senderName"`
-	SenderAddress              string        `form:"senderAddress" json:"senderAddress"`
-	VerificationTemplate       EmailTemplate `form:"verificationTemplate" json:"verificationTemplate"`
-	ResetPasswordTemplate      EmailTemplate `form:"resetPasswordTemplate" json:"resetPasswordTemplate"`
-	ConfirmEmailChangeTemplate EmailTemplate `form:"confirmEmailChangeTemplate" json:"confirmEmailChangeTemplate"`
+// FileDedupConfig defines the uploaded files deduplication settings.
+//
+// When enabled, the uploaded record files are stored as file objects
+// identified by their content hash and the identical uploads only
+// reference the already stored object.
+type FileDedupConfig struct {
+	Enabled bool `form:"enabled" json:"enabled"`
 }
 
-// Validate makes MetaConfig validatable by implementing [validation.Validatable] interface.
-func (c MetaConfig) Validate() error {
-	return validation.ValidateStruct(&c,
-		validation.Field(&c.AppName, validation.Required, validation.Length(1, 255)),
-		validation.Field(&c.AppUrl, validation.Required, is.URL),
-		validation.Field(&c.SenderName, validation.Required, validation.Length(1, 255)),
-		validation.Field(&c.SenderAddress, is.EmailFormat, validation.Required),
-		validation.Field(&c.VerificationTemplate, validation.Required),
-		validation.Field(&c.ResetPasswordTemplate, validation.Required),
-		validation.Field(&c.ConfirmEmailChangeTemplate, validation.Required),
-	)
-}
+// -------------------------------------------------------------------
 
-type EmailTemplate struct {
-	Body      string `form:"body" json:"body"`
-	Subject   string `form:"subject" json:"subject"`
-	ActionUrl string `form:"actionUrl" json:"actionUrl"`
+// FileServeConfig defines the files serving settings.
+type FileServeConfig struct {
+	// ChunkSize is the max number of bytes (0 for no limit) that are:
+	//  - fetched from the storage with a single range read
+	//  - served in response to an open-ended range request (eg. "bytes=100-")
+	//
+	// Browsers use open-ended range requests for the audio/video
+	// playback so a limit allows faster scrubbing of large media files.
+	ChunkSize int64 `form:"chunkSize" json:"chunkSize"`
+
+	// SignedUrlDuration is the lifetime in seconds of the pre-signed
+	// storage download urls (default to 5 minutes if not set).
+	SignedUrlDuration int `form:"signedUrlDuration" json:"signedUrlDuration"`
+
+	// ImageTransformMaxDimension is the max allowed width and height
+	// of the on-the-fly transformed images (default to 2048 if not set).
+	ImageTransformMaxDimension int `form:"imageTransformMaxDimension" json:"imageTransformMaxDimension"`
+
+	// ImageTransformCacheMaxSize is the max total size in bytes of the local
+	// transformed images cache (default to 100MB if not set).
+	//
+	// The least recently served images are evicted once the limit is reached.
+	ImageTransformCacheMaxSize int64 `form:"imageTransformCacheMaxSize" json:"imageTransformCacheMaxSize"`
 }
 
-// Validate makes EmailTemplate validatable by implementing [validation.Validatable] interface.
-func (t EmailTemplate) Validate() error {
-	return validation.ValidateStruct(&t,
-		validation.Field(&t.Subject, validation.Required),
-		validation.Field(
-			&t.Body,
-			validation.Required,
-			validation.By(checkPlaceholderParams(EmailPlaceholderActionUrl)),
-		),
-		validation.Field(
-			&t.ActionUrl,
-			validation.Required,
-			validation.By(checkPlaceholderParams(EmailPlaceholderToken)),
-		),
+// Validate makes FileServeConfig validatable by implementing [validation.Validatable] interface.
+func (c FileServeConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.ChunkSize, validation.Min(0)),
+		// the S3 pre-signed urls could be valid for max 7 days
+		validation.Field(&c.SignedUrlDuration, validation.Min(0), validation.Max(604800)),
+		validation.Field(&c.ImageTransformMaxDimension, validation.Min(0), validation.Max(10000)),
+		validation.Field(&c.ImageTransformCacheMaxSize, validation.Min(int64(0))),
 	)
 }
 
-func checkPlaceholderParams(params ...string) validation.RuleFunc {
-	return func(value any) error {
-		v, _ := value.(string)
-
-		for _, param := range params {
-			if !strings.Contains(v, param) {
-				return validation.NewError(
-					"validation_missing_required_param",
-					fmt.Sprintf("Missing required parameter %q", param),
-				)
-			}
-		}
-
-		return nil
+// SignedUrlExpiry returns the lifetime of the pre-signed storage download urls.
+func (c FileServeConfig) SignedUrlExpiry() time.Duration {
+	if c.SignedUrlDuration <= 0 {
+		return 5 * time.Minute
 	}
+
+	return time.Duration(c.SignedUrlDuration) * time.Second
 }
 
-// Resolve replaces the placeholder parameters in the current email
-// template and returns its components as ready-to-use strings.
-func (t EmailTemplate) Resolve(
-	appName string,
-	appUrl,
-	token string,
-) (subject, body, actionUrl string) {
-	// replace action url placeholder params (if any)
-	actionUrlParams := map[string]string{
-		EmailPlaceholderAppName: appName,
-		EmailPlaceholderAppUrl:  appUrl,
-		EmailPlaceholderToken:   token,
-	}
-	actionUrl = t.ActionUrl
-	for k, v := range actionUrlParams {
-		actionUrl = strings.ReplaceAll(actionUrl, k, v)
+// ImageTransformDimensionLimit returns the max allowed width
+// and height of the on-the-fly transformed images.
+func (c FileServeConfig) ImageTransformDimensionLimit() int {
+	if c.ImageTransformMaxDimension <= 0 {
+		return 2048
 	}
-	actionUrl, _ = rest.NormalizeUrl(actionUrl)
 
-	// replace body placeholder params (if any)
-	bodyParams := map[string]string{
-		EmailPlaceholderAppName:   appName,
-		EmailPlaceholderAppUrl:    appUrl,
-		EmailPlaceholderToken:     token,
-		EmailPlaceholderActionUrl: actionUrl,
-	}
-	body = t.Body
-	for k, v := range bodyParams {
-		body = strings.ReplaceAll(body, k, v)
-	}
+	return c.ImageTransformMaxDimension
+}
 
-	// replace subject placeholder params (if any)
-	subjectParams := map[string]string{
-		EmailPlaceholderAppName: appName,
-		EmailPlaceholderAppUrl:  appUrl,
-	}
-	subject = t.Subject
-	for k, v := range subjectParams {
-		subject = strings.ReplaceAll(subject, k, v)
+// ImageTransformCacheLimit returns the max total size in bytes
+// of the local transformed images cache.
+func (c FileServeConfig) ImageTransformCacheLimit() int64 {
+	if c.ImageTransformCacheMaxSize <= 0 {
+		return 100 << 20
 	}
 
-	return subject, body, actionUrl
+	return c.ImageTransformCacheMaxSize
 }
 
 // -------------------------------------------------------------------
 
-type LogsConfig struct {
-	MaxDays int `form:"maxDays" json:"maxDays"`
-}
+// TrustedProxyConfig defines the client IP resolution settings
+// for apps deployed behind reverse proxies and load balancers.
+type TrustedProxyConfig struct {
+	// Headers is the list of the headers to read the client IP from
+	// (checked in order), eg. "X-Forwarded-For", "CF-Connecting-IP".
+	//
+	// If empty, the proxy headers are ignored and the client IP
+	// is the request remote address.
+	Headers []string `form:"headers" json:"headers"`
+
+	// Ranges is the list of the trusted proxy IPs and CIDR ranges
+	// (eg. "10.0.0.0/8", "172.17.0.1").
+	//
+	// The headers are honored only for requests from a trusted proxy.
+	// If empty, the loopback and private network addresses are trusted.
+	Ranges []string `form:"ranges" json:"ranges"`
+}
+
+// Enabled reports whether the client IP is resolved from the configured headers.
+func (c TrustedProxyConfig) Enabled() bool {
+	return len(c.Headers) > 0
+}
+
+// Validate makes TrustedProxyConfig validatable by implementing [validation.Validatable] interface.
+func (c TrustedProxyConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Headers, validation.Each(validation.Required, validation.Match(headerNameRegex))),
+		validation.Field(&c.Ranges, validation.Each(validation.Required, validation.By(checkIpRange))),
+	)
+}
+
+// ParseRanges parses and returns the trusted proxy ranges
+// (the single IPs are converted to /32 or /128 ranges).
+func (c TrustedProxyConfig) ParseRanges() ([]*net.IPNet, error) {
+	result := make([]*net.IPNet, 0, len(c.Ranges))
+
+	for _, r := range c.Ranges {
+		ipNet, err := parseIpRange(r)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, ipNet)
+	}
+
+	return result, nil
+}
+
+var headerNameRegex = regexp.MustCompile(`^[\w\-]+$`)
+
+func checkIpRange(value any) error {
+	v, _ := value.(string)
+
+	if _, err := parseIpRange(v); err != nil {
+		return validation.NewError("validation_invalid_ip_range", "Must be a valid IP or CIDR range.")
+	}
+
+	return nil
+}
+
+func parseIpRange(value string) (*net.IPNet, error) {
+	value = strings.TrimSpace(value)
+
+	if !strings.Contains(value, "/") {
+		ip := net.ParseIP(value)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IP %q", value)
+		}
+
+		bits := 128
+		if ip.To4() != nil {
+			ip = ip.To4()
+			bits = 32
+		}
+
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+
+	_, ipNet, err := net.ParseCIDR(value)
+
+	return ipNet, err
+}
+
+// -------------------------------------------------------------------
+
+// DefaultDocsContentSecurityPolicy is the default api docs UI
+// Content-Security-Policy (the UI assets are embedded and the spec is inlined).
+const DefaultDocsContentSecurityPolicy = "default-src 'self'; " +
+	"script-src 'self' 'unsafe-inline'; " +
+	"style-src 'self' 'unsafe-inline'; " +
+	"img-src 'self' data: https:"
+
+// SecurityHeadersConfig defines the security related response headers.
+//
+// An empty string value disables the related header.
+type SecurityHeadersConfig struct {
+	// HSTSMaxAge is the Strict-Transport-Security max-age in seconds
+	// (the header is sent only for HTTPS requests; 0 disables it).
+	HSTSMaxAge            int  `form:"hstsMaxAge" json:"hstsMaxAge"`
+	HSTSIncludeSubdomains bool `form:"hstsIncludeSubdomains" json:"hstsIncludeSubdomains"`
+	HSTSPreload           bool `form:"hstsPreload" json:"hstsPreload"`
+
+	// ContentTypeNosniff enables the "X-Content-Type-Options: nosniff" header.
+	ContentTypeNosniff bool `form:"contentTypeNosniff" json:"contentTypeNosniff"`
+
+	// FrameOptions is the X-Frame-Options header value ("DENY" or "SAMEORIGIN").
+	FrameOptions string `form:"frameOptions" json:"frameOptions"`
+
+	// ReferrerPolicy is the Referrer-Policy header value.
+	ReferrerPolicy string `form:"referrerPolicy" json:"referrerPolicy"`
+
+	// ContentSecurityPolicy is the default Content-Security-Policy header value.
+	ContentSecurityPolicy string `form:"contentSecurityPolicy" json:"contentSecurityPolicy"`
+
+	// DocsContentSecurityPolicy overrides ContentSecurityPolicy for the api docs UI.
+	DocsContentSecurityPolicy string `form:"docsContentSecurityPolicy" json:"docsContentSecurityPolicy"`
+
+	// FilesContentSecurityPolicy overrides the default Content-Security-Policy
+	// of the served files (the file field option still takes precedence).
+	FilesContentSecurityPolicy string `form:"filesContentSecurityPolicy" json:"filesContentSecurityPolicy"`
+}
+
+// HSTSValue returns the Strict-Transport-Security header value
+// (or empty string if HSTS is disabled).
+func (c SecurityHeadersConfig) HSTSValue() string {
+	if c.HSTSMaxAge <= 0 {
+		return ""
+	}
+
+	value := fmt.Sprintf("max-age=%d", c.HSTSMaxAge)
+
+	if c.HSTSIncludeSubdomains {
+		value += "; includeSubdomains"
+	}
+
+	if c.HSTSPreload {
+		value += "; preload"
+	}
+
+	return value
+}
+
+// Validate makes SecurityHeadersConfig validatable by implementing [validation.Validatable] interface.
+func (c SecurityHeadersConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.HSTSMaxAge, validation.Min(0)),
+		validation.Field(&c.FrameOptions, validation.In("DENY", "SAMEORIGIN")),
+		validation.Field(
+			&c.ReferrerPolicy,
+			validation.In(
+				"no-referrer",
+				"no-referrer-when-downgrade",
+				"origin",
+				"origin-when-cross-origin",
+				"same-origin",
+				"strict-origin",
+				"strict-origin-when-cross-origin",
+				"unsafe-url",
+			),
+		),
+		validation.Field(&c.ContentSecurityPolicy, validation.Length(0, 1000), validation.Match(headerValueRegex)),
+		validation.Field(&c.DocsContentSecurityPolicy, validation.Length(0, 1000), validation.Match(headerValueRegex)),
+		validation.Field(&c.FilesContentSecurityPolicy, validation.Length(0, 1000), validation.Match(headerValueRegex)),
+	)
+}
+
+var headerValueRegex = regexp.MustCompile(`^[^\r\n]*$`)
+
+// -------------------------------------------------------------------
+
+// SecurityReportConfig defines the settings of the brute-force and
+// anomaly detection report (see the "/api/security/report" endpoint).
+type SecurityReportConfig struct {
+	// GeoHeader is an optional request header with the client country code
+	// (eg. "CF-IPCountry") that is stored in the request logs to detect
+	// requests from unusual locations.
+	//
+	// The header is read only from the requests of the trusted proxies (see [TrustedProxyConfig]).
+	GeoHeader string `form:"geoHeader" json:"geoHeader"`
+
+	// Window is the default report period in seconds.
+	Window int `form:"window" json:"window"`
+
+	// BaselineDays is the number of days before the report period
+	// used to compare the 403 responses and the client countries.
+	BaselineDays int `form:"baselineDays" json:"baselineDays"`
+
+	// AuthFailuresThreshold is the min number of failed auth attempts
+	// from a single IP within the report period to raise an alert.
+	AuthFailuresThreshold int `form:"authFailuresThreshold" json:"authFailuresThreshold"`
+
+	// ForbiddenThreshold is the min number of 403 responses within
+	// the report period to raise an alert (if it is also at least
+	// twice the average of the baseline period).
+	ForbiddenThreshold int `form:"forbiddenThreshold" json:"forbiddenThreshold"`
+
+	// NotifyCron is an optional cron expression of the periodic report
+	// check that triggers the OnSecurityAlert app hook on alerts.
+	NotifyCron string `form:"notifyCron" json:"notifyCron"`
+
+	// NotifyEmails is an optional list of addresses that
+	// receive an email with the periodic check alerts.
+	NotifyEmails []string `form:"notifyEmails" json:"notifyEmails"`
+}
+
+// Validate makes SecurityReportConfig validatable by implementing [validation.Validatable] interface.
+func (c SecurityReportConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.GeoHeader, validation.Length(0, 100), validation.Match(headerNameRegex)),
+		validation.Field(&c.Window, validation.Required, validation.Min(60), validation.Max(2592000)),
+		validation.Field(&c.BaselineDays, validation.Min(0), validation.Max(365)),
+		validation.Field(&c.AuthFailuresThreshold, validation.Required, validation.Min(1)),
+		validation.Field(&c.ForbiddenThreshold, validation.Required, validation.Min(1)),
+		validation.Field(&c.NotifyCron, validation.By(checkCronExpression)),
+		validation.Field(&c.NotifyEmails, validation.Each(is.EmailFormat)),
+	)
+}
+
+// -------------------------------------------------------------------
+
+// MetricsConfig defines the settings of the request and db metrics
+// exposed in the Prometheus format (see the "/api/metrics" endpoint).
+type MetricsConfig struct {
+	Enabled bool `form:"enabled" json:"enabled"`
+
+	// Token is an optional shared secret that allows non-admin access
+	// to the metrics endpoint (sent as "Authorization: Bearer {token}" header).
+	//
+	// If not set, the metrics are accessible only by admins.
+	Token string `form:"token" json:"token"`
+}
+
+// Validate makes MetricsConfig validatable by implementing [validation.Validatable] interface.
+func (c MetricsConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Token, validation.Length(30, 300)),
+	)
+}
+
+// -------------------------------------------------------------------
+
+// SlowQueriesConfig defines the settings of the slow data db queries
+// tracking (see the "/api/admin/db/slow-queries" endpoint).
+type SlowQueriesConfig struct {
+	Enabled bool `form:"enabled" json:"enabled"`
+
+	// Threshold is the min duration in milliseconds
+	// of the data db queries to be tracked.
+	Threshold int `form:"threshold" json:"threshold"`
+}
+
+// Validate makes SlowQueriesConfig validatable by implementing [validation.Validatable] interface.
+func (c SlowQueriesConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Threshold, validation.Min(0), validation.Max(60000)),
+	)
+}
+
+// -------------------------------------------------------------------
+
+// S3EventsConfig defines the settings of the S3 event notifications ingestion
+// for objects uploaded directly to the storage bucket.
+type S3EventsConfig struct {
+	Enabled bool `form:"enabled" json:"enabled"`
+
+	// Token is the shared secret that the S3 event notification
+	// requests must send as "Authorization: Bearer {token}" header.
+	Token string `form:"token" json:"token"`
+}
+
+// Validate makes S3EventsConfig validatable by implementing [validation.Validatable] interface.
+func (c S3EventsConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Token, validation.When(c.Enabled, validation.Required), validation.Length(30, 300)),
+	)
+}
+
+// -------------------------------------------------------------------
+
+// RealtimeBroadcastConfig defines the settings of the custom
+// realtime topics broadcast api.
+type RealtimeBroadcastConfig struct {
+	Enabled bool `form:"enabled" json:"enabled"`
+
+	// Token is the shared secret that the non-admin broadcast
+	// requests must send as "Authorization: Bearer {token}" header.
+	Token string `form:"token" json:"token"`
+}
+
+// Validate makes RealtimeBroadcastConfig validatable by implementing [validation.Validatable] interface.
+func (c RealtimeBroadcastConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Token, validation.When(c.Enabled, validation.Required), validation.Length(30, 300)),
+	)
+}
+
+// -------------------------------------------------------------------
+
+// RealtimeReplayConfig defines the settings of the realtime events
+// replay buffer used to resume the reconnecting clients (see "Last-Event-ID").
+type RealtimeReplayConfig struct {
+	Enabled bool `form:"enabled" json:"enabled"`
+
+	// MaxEvents is the max number of buffered events per topic.
+	MaxEvents int `form:"maxEvents" json:"maxEvents"`
+
+	// TTL is the max age of the buffered events in seconds.
+	TTL int `form:"ttl" json:"ttl"`
+}
+
+// Validate makes RealtimeReplayConfig validatable by implementing [validation.Validatable] interface.
+func (c RealtimeReplayConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.MaxEvents, validation.When(c.Enabled, validation.Required), validation.Min(0), validation.Max(10000)),
+		validation.Field(&c.TTL, validation.When(c.Enabled, validation.Required), validation.Min(0), validation.Max(86400)),
+	)
+}
+
+// Limits returns the replay buffer max events per topic and ttl
+// (both are zero if the replay is disabled).
+func (c RealtimeReplayConfig) Limits() (int, time.Duration) {
+	if !c.Enabled {
+		return 0, 0
+	}
+
+	return c.MaxEvents, time.Duration(c.TTL) * time.Second
+}
+
+// -------------------------------------------------------------------
+
+// RateLimitsConfig defines the global api requests rate limit
+// applied per identity (admin, auth record or client ip).
+//
+// The limit of individual identities could be overwritten
+// with the "/api/limits" endpoints.
+type RateLimitsConfig struct {
+	Enabled bool `form:"enabled" json:"enabled"`
+
+	// MaxRequests is the max number of allowed requests per Duration window.
+	MaxRequests int `form:"maxRequests" json:"maxRequests"`
+
+	// Duration is the rate limit window in seconds.
+	Duration int `form:"duration" json:"duration"`
+}
+
+// Validate makes RateLimitsConfig validatable by implementing [validation.Validatable] interface.
+func (c RateLimitsConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.MaxRequests, validation.When(c.Enabled, validation.Required), validation.Min(0)),
+		validation.Field(&c.Duration, validation.When(c.Enabled, validation.Required), validation.Min(0), validation.Max(86400)),
+	)
+}
+
+// -------------------------------------------------------------------
+
+// SwaggerConfig defines the settings of the api docs endpoints
+// ("/api/docs" and "/api/swagger.json").
+type SwaggerConfig struct {
+	Enabled bool `form:"enabled" json:"enabled"`
+
+	// ApiKey is an optional shared secret that allows non-admin access
+	// to the api docs (sent as "X-API-Key" header).
+	//
+	// If not set, the api docs are accessible only by admins.
+	ApiKey string `form:"apiKey" json:"apiKey"`
+
+	// LiveExamples embeds the latest record of each collection as its
+	// record model example in the admin api docs (the api key docs are
+	// never populated with live data).
+	LiveExamples bool `form:"liveExamples" json:"liveExamples"`
+}
+
+// Validate makes SwaggerConfig validatable by implementing [validation.Validatable] interface.
+func (c SwaggerConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.ApiKey, validation.Length(30, 300)),
+	)
+}
+
+// -------------------------------------------------------------------
+
+// List of the actions that could require an approval.
+const (
+	ApprovalActionSettingsUpdate   = "settings_update"
+	ApprovalActionCollectionDelete = "collection_delete"
+	ApprovalActionBackupRestore    = "backup_restore"
+)
+
+// ApprovalsConfig defines the two-person rule settings.
+//
+// When enabled, the configured Actions are not executed immediately
+// and instead create an approval request that needs to be confirmed
+// by a second admin (see the "/api/approvals" endpoints).
+type ApprovalsConfig struct {
+	Enabled bool     `form:"enabled" json:"enabled"`
+	Actions []string `form:"actions" json:"actions"`
+
+	// Duration is the time in seconds after which the
+	// pending approval requests expire.
+	Duration int `form:"duration" json:"duration"`
+}
+
+// Validate makes ApprovalsConfig validatable by implementing [validation.Validatable] interface.
+func (c ApprovalsConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(
+			&c.Actions,
+			validation.When(c.Enabled, validation.Required),
+			validation.Each(validation.In(
+				ApprovalActionSettingsUpdate,
+				ApprovalActionCollectionDelete,
+				ApprovalActionBackupRestore,
+			)),
+		),
+		validation.Field(&c.Duration, validation.When(c.Enabled, validation.Required), validation.Min(60), validation.Max(2592000)),
+	)
+}
+
+// IsRequired reports whether the provided action requires an approval.
+func (c ApprovalsConfig) IsRequired(action string) bool {
+	return c.Enabled && list.ExistInSlice(action, c.Actions)
+}
+
+// -------------------------------------------------------------------
+
+// List of the subsystems with outgoing requests that
+// could have their own egress policy.
+const (
+	EgressSubsystemOAuth2     = "oauth2"
+	EgressSubsystemS3         = "s3"
+	EgressSubsystemSearchSync = "searchSync"
+	EgressSubsystemCaptcha    = "captcha"
+	EgressSubsystemWebhooks   = "webhooks"
+
+	EgressSubsystemLogShipping = "logShipping"
+)
+
+// EgressPolicyConfig defines the outgoing requests restrictions
+// (see [egress.Policy]).
+type EgressPolicyConfig struct {
+	// Proxy is an optional proxy url, eg. "http://proxy.internal:3128".
+	Proxy string `form:"proxy" json:"proxy"`
+
+	// Allowlist is an optional list with the allowed destination
+	// hostnames, "*.example.com" wildcards, IP addresses or CIDR ranges.
+	Allowlist []string `form:"allowlist" json:"allowlist"`
+
+	// AllowPrivate allows requests to private network addresses
+	// (eg. a local S3 or search engine server).
+	AllowPrivate bool `form:"allowPrivate" json:"allowPrivate"`
+}
+
+// Validate makes EgressPolicyConfig validatable by implementing [validation.Validatable] interface.
+func (c EgressPolicyConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Proxy, is.URL),
+		validation.Field(&c.Allowlist, validation.Each(validation.Required, validation.Length(1, 255))),
+	)
+}
+
+// Policy converts the config into an [egress.Policy].
+func (c EgressPolicyConfig) Policy() egress.Policy {
+	return egress.Policy{
+		Proxy:        c.Proxy,
+		Allowlist:    c.Allowlist,
+		AllowPrivate: c.AllowPrivate,
+	}
+}
+
+// EgressConfig defines the outgoing requests (OAuth2, S3, search sync, webhooks)
+// proxy and destination restrictions.
+//
+// By default requests to private network addresses are denied.
+type EgressConfig struct {
+	Default EgressPolicyConfig `form:"default" json:"default"`
+
+	// Subsystems is an optional map with subsystem specific
+	// policies that replace the default one.
+	Subsystems map[string]EgressPolicyConfig `form:"subsystems" json:"subsystems"`
+}
+
+// Validate makes EgressConfig validatable by implementing [validation.Validatable] interface.
+func (c EgressConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Default),
+		validation.Field(&c.Subsystems, validation.By(checkEgressSubsystems)),
+	)
+}
+
+// Policy returns the egress policy of the provided subsystem
+// (fallbacks to the default one).
+func (c EgressConfig) Policy(subsystem string) egress.Policy {
+	if config, ok := c.Subsystems[subsystem]; ok {
+		return config.Policy()
+	}
+
+	return c.Default.Policy()
+}
+
+func checkEgressSubsystems(value any) error {
+	v, _ := value.(map[string]EgressPolicyConfig)
+
+	errs := validation.Errors{}
+
+	for subsystem, config := range v {
+		switch subsystem {
+		case EgressSubsystemOAuth2, EgressSubsystemS3, EgressSubsystemSearchSync, EgressSubsystemCaptcha, EgressSubsystemWebhooks, EgressSubsystemLogShipping:
+			if err := config.Validate(); err != nil {
+				errs[subsystem] = err
+			}
+		default:
+			errs[subsystem] = validation.NewError("validation_invalid_egress_subsystem", "Invalid egress subsystem.")
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// -------------------------------------------------------------------
+
+var anonymizationProfileNameRegex = regexp.MustCompile(`^\w+$`)
+
+// AnonymizationConfig defines the named anonymization profiles
+// applied to the records and users exports (eg. "?anonymize=dev").
+type AnonymizationConfig struct {
+	// Salt is the secret key of the "hash" and "fake" strategies.
+	//
+	// Changing it invalidates the consistency with the previous exports.
+	Salt string `form:"salt" json:"salt"`
+
+	Profiles map[string]AnonymizationProfile `form:"profiles" json:"profiles"`
+}
+
+// Validate makes AnonymizationConfig validatable by implementing [validation.Validatable] interface.
+func (c AnonymizationConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Salt, validation.When(len(c.Profiles) > 0, validation.Required), validation.Length(10, 300)),
+		validation.Field(&c.Profiles, validation.By(checkAnonymizationProfiles)),
+	)
+}
+
+// Profile returns the named anonymization profile.
+func (c AnonymizationConfig) Profile(name string) (AnonymizationProfile, bool) {
+	profile, ok := c.Profiles[name]
+
+	return profile, ok
+}
+
+// AnonymizationProfile defines the anonymization strategy
+// (mask, hash or fake) of each listed field.
+type AnonymizationProfile struct {
+	// Fields is a map with the field strategies indexed by the field
+	// name (applied to all collections) or by "collection.field".
+	//
+	// The registry users export uses the "users" collection name.
+	Fields map[string]string `form:"fields" json:"fields"`
+}
+
+// Validate makes AnonymizationProfile validatable by implementing [validation.Validatable] interface.
+func (p AnonymizationProfile) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Fields, validation.Required, validation.By(checkAnonymizationFields)),
+	)
+}
+
+// CollectionFields returns the field strategies of the specified
+// collection (the "collection.field" entries take precedence).
+func (p AnonymizationProfile) CollectionFields(collection string) map[string]string {
+	result := map[string]string{}
+
+	for field, strategy := range p.Fields {
+		if !strings.Contains(field, ".") {
+			result[field] = strategy
+		}
+	}
+
+	prefix := collection + "."
+	for field, strategy := range p.Fields {
+		if strings.HasPrefix(field, prefix) {
+			result[strings.TrimPrefix(field, prefix)] = strategy
+		}
+	}
+
+	return result
+}
+
+func checkAnonymizationProfiles(value any) error {
+	v, _ := value.(map[string]AnonymizationProfile)
+
+	errs := validation.Errors{}
+
+	for name, profile := range v {
+		if !anonymizationProfileNameRegex.MatchString(name) {
+			errs[name] = validation.NewError("validation_invalid_anonymization_profile", "Invalid profile name.")
+			continue
+		}
+
+		if err := profile.Validate(); err != nil {
+			errs[name] = err
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func checkAnonymizationFields(value any) error {
+	v, _ := value.(map[string]string)
+
+	errs := validation.Errors{}
+
+	for field, strategy := range v {
+		if field == "" || strings.HasPrefix(field, ".") || strings.HasSuffix(field, ".") {
+			errs[field] = validation.NewError("validation_invalid_anonymization_field", "Invalid field name.")
+			continue
+		}
+
+		if !list.ExistInSlice(strategy, anonymize.Strategies()) {
+			errs[field] = validation.NewError("validation_invalid_anonymization_strategy", "Invalid anonymization strategy.")
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// -------------------------------------------------------------------
+
+// LocalizationConfig defines the locales of the localized
+// record fields (see the text and editor field "localized" option).
+type LocalizationConfig struct {
+	// DefaultLocale is the last locale fallback when
+	// resolving a localized value (eg. "en").
+	DefaultLocale string `form:"defaultLocale" json:"defaultLocale"`
+
+	// Locales is an optional list of the app supported locales
+	// used for the collections translation status reports.
+	Locales []string `form:"locales" json:"locales"`
+
+	// Fallbacks is an optional map with the explicit fallback
+	// locales of each locale (eg. {"de-AT": ["de-CH"]}).
+	//
+	// The parent locales (eg. "de" for "de-AT") and the default
+	// locale are always implicitly appended to the lookup chain.
+	Fallbacks map[string][]string `form:"fallbacks" json:"fallbacks"`
+}
+
+// Validate makes LocalizationConfig validatable by implementing [validation.Validatable] interface.
+func (c LocalizationConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.DefaultLocale, validation.Required, validation.By(checkLocale)),
+		validation.Field(&c.Locales, validation.Each(validation.By(checkLocale))),
+		validation.Field(&c.Fallbacks, validation.By(checkLocaleFallbacks)),
+	)
+}
+
+// Chain returns the localized values lookup chain of the provided locale.
+func (c LocalizationConfig) Chain(l string) []string {
+	return locale.Chain(l, c.Fallbacks, c.DefaultLocale)
+}
+
+// SupportedLocales returns the configured app locales
+// (fallbacks to the default locale if not set).
+func (c LocalizationConfig) SupportedLocales() []string {
+	if len(c.Locales) == 0 {
+		return []string{c.DefaultLocale}
+	}
+
+	return list.ToUniqueStringSlice(c.Locales)
+}
+
+func checkLocale(value any) error {
+	v, _ := value.(string)
+	if v == "" {
+		return nil // nothing to check
+	}
+
+	if !locale.IsValid(v) {
+		return validation.NewError("validation_invalid_locale", "Invalid locale.")
+	}
+
+	return nil
+}
+
+func checkLocaleFallbacks(value any) error {
+	v, _ := value.(map[string][]string)
+
+	errs := validation.Errors{}
+
+	for l, fallbacks := range v {
+		if !locale.IsValid(l) {
+			errs[l] = validation.NewError("validation_invalid_locale", "Invalid locale.")
+			continue
+		}
+
+		if err := validation.Validate(fallbacks, validation.Each(validation.Required, validation.By(checkLocale))); err != nil {
+			errs[l] = err
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// -------------------------------------------------------------------
+
+// BillingConfig defines the settings of the billable events metering
+// and the Stripe subscriptions webhook receiver.
+type BillingConfig struct {
+	// Metering enables the counting of the billable events per auth
+	// record and organization (created records, uploaded files size
+	// and monthly active users).
+	Metering bool `form:"metering" json:"metering"`
+
+	// StripeWebhookSecret is the Stripe webhook endpoint signing
+	// secret (eg. "whsec_...") used to verify the received events.
+	//
+	// The Stripe webhook receiver is disabled if not set.
+	StripeWebhookSecret string `form:"stripeWebhookSecret" json:"stripeWebhookSecret"`
+
+	// StripeCollection is the name or id of the auth collection
+	// whose records hold the Stripe customers subscription state.
+	StripeCollection string `form:"stripeCollection" json:"stripeCollection"`
+
+	// StripeCustomerField is the StripeCollection field with the Stripe customer id.
+	StripeCustomerField string `form:"stripeCustomerField" json:"stripeCustomerField"`
+
+	// StripeStatusField, StripePlanField and StripePeriodEndField are the
+	// optional StripeCollection fields where the subscription status,
+	// price id and current period end date are stored
+	// (fields that are missing in the collection schema are ignored).
+	StripeStatusField    string `form:"stripeStatusField" json:"stripeStatusField"`
+	StripePlanField      string `form:"stripePlanField" json:"stripePlanField"`
+	StripePeriodEndField string `form:"stripePeriodEndField" json:"stripePeriodEndField"`
+}
+
+// Validate makes BillingConfig validatable by implementing [validation.Validatable] interface.
+func (c BillingConfig) Validate() error {
+	isStripeEnabled := c.StripeWebhookSecret != ""
+
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.StripeWebhookSecret, validation.Length(0, 300)),
+		validation.Field(&c.StripeCollection, validation.When(isStripeEnabled, validation.Required)),
+		validation.Field(&c.StripeCustomerField, validation.When(isStripeEnabled, validation.Required), validation.Match(billingFieldNameRegex)),
+		validation.Field(&c.StripeStatusField, validation.Match(billingFieldNameRegex)),
+		validation.Field(&c.StripePlanField, validation.Match(billingFieldNameRegex)),
+		validation.Field(&c.StripePeriodEndField, validation.Match(billingFieldNameRegex)),
+	)
+}
+
+var billingFieldNameRegex = regexp.MustCompile(`^\w+$`)
+
+// -------------------------------------------------------------------
+
+var (
+	publicFormNameRegex  = regexp.MustCompile(`^[\w\-]+$`)
+	publicFormFieldRegex = regexp.MustCompile(`^\w+$`)
+)
+
+// PublicFormsConfig defines the public form submission endpoints
+// ("/api/forms/{name}/submit") that allow static sites to create
+// records without exposing the target collections create api rule.
+type PublicFormsConfig struct {
+	// CaptchaVerifyUrl is the captcha provider siteverify endpoint
+	// (hCaptcha, Cloudflare Turnstile and reCAPTCHA are compatible),
+	// eg. "https://hcaptcha.com/siteverify".
+	CaptchaVerifyUrl string `form:"captchaVerifyUrl" json:"captchaVerifyUrl"`
+
+	// CaptchaSecret is the captcha provider secret key.
+	CaptchaSecret string `form:"captchaSecret" json:"captchaSecret"`
+
+	// Forms is a map with the public forms indexed by their name.
+	Forms map[string]PublicFormConfig `form:"forms" json:"forms"`
+}
+
+// Validate makes PublicFormsConfig validatable by implementing [validation.Validatable] interface.
+func (c PublicFormsConfig) Validate() error {
+	var hasCaptcha bool
+	for _, form := range c.Forms {
+		if form.Captcha {
+			hasCaptcha = true
+			break
+		}
+	}
+
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.CaptchaVerifyUrl, validation.When(hasCaptcha, validation.Required), is.URL),
+		validation.Field(&c.CaptchaSecret, validation.When(hasCaptcha, validation.Required), validation.Length(0, 300)),
+		validation.Field(&c.Forms, validation.By(checkPublicForms)),
+	)
+}
+
+// Form returns the named public form.
+func (c PublicFormsConfig) Form(name string) (PublicFormConfig, bool) {
+	form, ok := c.Forms[name]
+
+	return form, ok
+}
+
+// PublicFormConfig defines a single public form and its spam protections.
+type PublicFormConfig struct {
+	// Collection is the name or id of the base collection
+	// where the form submissions are stored.
+	Collection string `form:"collection" json:"collection"`
+
+	// Fields is the list of the collection fields that could be
+	// submitted (all other submitted values are ignored).
+	Fields []string `form:"fields" json:"fields"`
+
+	// Honeypot is an optional hidden input name that must be
+	// left empty (the filled submissions are silently discarded).
+	Honeypot string `form:"honeypot" json:"honeypot"`
+
+	// Captcha requires a valid captcha response token
+	// (see PublicFormsConfig.CaptchaVerifyUrl).
+	Captcha bool `form:"captcha" json:"captcha"`
+
+	// MaxSubmissions is the max allowed submissions per client IP
+	// in Duration seconds (0 means unlimited).
+	MaxSubmissions int `form:"maxSubmissions" json:"maxSubmissions"`
+	Duration       int `form:"duration" json:"duration"`
+
+	// NotifyEmails is an optional list with the email addresses
+	// notified for each new submission.
+	NotifyEmails []string `form:"notifyEmails" json:"notifyEmails"`
+
+	// RedirectUrl is an optional url where the submitters are redirected
+	// after a successful submission (useful for plain html forms).
+	RedirectUrl string `form:"redirectUrl" json:"redirectUrl"`
+}
+
+// Validate makes PublicFormConfig validatable by implementing [validation.Validatable] interface.
+func (c PublicFormConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Collection, validation.Required),
+		validation.Field(&c.Fields, validation.Required, validation.Each(validation.Required, validation.Match(publicFormFieldRegex))),
+		validation.Field(&c.Honeypot, validation.Match(publicFormNameRegex), validation.NotIn(list.ToInterfaceSlice(c.Fields)...)),
+		validation.Field(&c.MaxSubmissions, validation.Min(0)),
+		validation.Field(&c.Duration, validation.When(c.MaxSubmissions > 0, validation.Required), validation.Min(0), validation.Max(86400)),
+		validation.Field(&c.NotifyEmails, validation.Each(is.EmailFormat)),
+		validation.Field(&c.RedirectUrl, is.URL),
+	)
+}
+
+func checkPublicForms(value any) error {
+	v, _ := value.(map[string]PublicFormConfig)
+
+	errs := validation.Errors{}
+
+	for name, form := range v {
+		if !publicFormNameRegex.MatchString(name) {
+			errs[name] = validation.NewError("validation_invalid_public_form_name", "Invalid form name.")
+			continue
+		}
+
+		if err := form.Validate(); err != nil {
+			errs[name] = err
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// -------------------------------------------------------------------
+
+// collectionNameRegex is used to validate the collection name keys of the per-collection settings.
+var collectionNameRegex = regexp.MustCompile(`^\w+$`)
+
+// CommentsConfig defines the record comment threads ("/api/comments").
+type CommentsConfig struct {
+	// Collections is a map with the comment threads settings
+	// indexed by the name of the collection owning the threads
+	// (the comments are disabled for the missing collections).
+	Collections map[string]CommentsCollectionConfig `form:"collections" json:"collections"`
+}
+
+// Validate makes CommentsConfig validatable by implementing [validation.Validatable] interface.
+func (c CommentsConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Collections, validation.By(checkCommentsCollections)),
+	)
+}
+
+// Collection returns the comment threads settings of the named collection.
+func (c CommentsConfig) Collection(name string) (CommentsCollectionConfig, bool) {
+	config, ok := c.Collections[name]
+
+	return config, ok
+}
+
+// CommentsCollectionConfig defines the comment threads settings of a single collection.
+type CommentsCollectionConfig struct {
+	// Moderated enables the premoderation of the new and edited
+	// comments (they are visible only to their author and the
+	// admins until approved).
+	Moderated bool `form:"moderated" json:"moderated"`
+
+	// MaxLength is the max allowed comment message length
+	// (default to 5000 characters if not set).
+	MaxLength int `form:"maxLength" json:"maxLength"`
+}
+
+// Validate makes CommentsCollectionConfig validatable by implementing [validation.Validatable] interface.
+func (c CommentsCollectionConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.MaxLength, validation.Min(0), validation.Max(100000)),
+	)
+}
+
+// MessageMaxLength returns the max allowed comment message length.
+func (c CommentsCollectionConfig) MessageMaxLength() int {
+	if c.MaxLength <= 0 {
+		return 5000
+	}
+
+	return c.MaxLength
+}
+
+func checkCommentsCollections(value any) error {
+	v, _ := value.(map[string]CommentsCollectionConfig)
+
+	errs := validation.Errors{}
+
+	for name, config := range v {
+		if !collectionNameRegex.MatchString(name) {
+			errs[name] = validation.NewError("validation_invalid_collection_name", "Invalid collection name.")
+			continue
+		}
+
+		if err := config.Validate(); err != nil {
+			errs[name] = err
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// -------------------------------------------------------------------
+
+// CachingConfig defines the records list and view responses caching
+// of the read-heavy collections.
+type CachingConfig struct {
+	// Collections is a map with the caching settings indexed
+	// by collection name (the missing collections are not cached).
+	Collections map[string]CollectionCachingConfig `form:"collections" json:"collections"`
+}
+
+// Validate makes CachingConfig validatable by implementing [validation.Validatable] interface.
+func (c CachingConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Collections, validation.By(checkCachingCollections)),
+	)
+}
+
+// Collection returns the caching settings of the named collection.
+func (c CachingConfig) Collection(name string) (CollectionCachingConfig, bool) {
+	config, ok := c.Collections[name]
+
+	return config, ok
+}
+
+// CollectionCachingConfig defines the caching settings of a single collection.
+type CollectionCachingConfig struct {
+	// MaxAge is the period in seconds during which
+	// the list and view responses are considered fresh.
+	MaxAge int `form:"maxAge" json:"maxAge"`
+
+	// StaleWhileRevalidate is the additional period in seconds during
+	// which a stale response could be served while it is revalidated.
+	StaleWhileRevalidate int `form:"staleWhileRevalidate" json:"staleWhileRevalidate"`
+
+	// ServerSide enables the in-memory caching of the guest responses.
+	//
+	// The cached responses are invalidated on any collection record change
+	// (the expanded relations of other collections could remain stale
+	// until the response expires).
+	ServerSide bool `form:"serverSide" json:"serverSide"`
+}
+
+// Validate makes CollectionCachingConfig validatable by implementing [validation.Validatable] interface.
+func (c CollectionCachingConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.MaxAge, validation.Required, validation.Min(1), validation.Max(31536000)),
+		validation.Field(&c.StaleWhileRevalidate, validation.Min(0), validation.Max(31536000)),
+	)
+}
+
+// CacheControl returns the Cache-Control header value of the collection
+// responses (private responses are not stored by the shared caches).
+func (c CollectionCachingConfig) CacheControl(private bool) string {
+	result := "public"
+	if private {
+		result = "private"
+	}
+
+	result += ", max-age=" + strconv.Itoa(c.MaxAge)
+
+	if c.StaleWhileRevalidate > 0 {
+		result += ", stale-while-revalidate=" + strconv.Itoa(c.StaleWhileRevalidate)
+	}
+
+	return result
+}
+
+func checkCachingCollections(value any) error {
+	v, _ := value.(map[string]CollectionCachingConfig)
+
+	errs := validation.Errors{}
+
+	for name, config := range v {
+		if !collectionNameRegex.MatchString(name) {
+			errs[name] = validation.NewError("validation_invalid_collection_name", "Invalid collection name.")
+			continue
+		}
+
+		if err := config.Validate(); err != nil {
+			errs[name] = err
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// -------------------------------------------------------------------
+
+// VersioningConfig defines the record revisions history of the
+// versioned collections (see the "/records/:id/revisions" endpoints).
+type VersioningConfig struct {
+	// Collections is a map with the versioning settings indexed by
+	// collection name (the missing collections are not versioned).
+	Collections map[string]VersioningCollectionConfig `form:"collections" json:"collections"`
+}
+
+// Validate makes VersioningConfig validatable by implementing [validation.Validatable] interface.
+func (c VersioningConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Collections, validation.By(checkVersioningCollections)),
+	)
+}
+
+// Collection returns the versioning settings of the named collection.
+func (c VersioningConfig) Collection(name string) (VersioningCollectionConfig, bool) {
+	config, ok := c.Collections[name]
+
+	return config, ok
+}
+
+// VersioningCollectionConfig defines the revisions retention of a single collection.
+//
+// The retention limits are applied every time a new record revision is stored.
+type VersioningCollectionConfig struct {
+	// MaxRevisions is the max number of stored revisions per record
+	// (the oldest ones are deleted first; 0 means no limit).
+	MaxRevisions int `form:"maxRevisions" json:"maxRevisions"`
+
+	// MaxDays is the number of days after which the
+	// revisions are deleted (0 means no limit).
+	MaxDays int `form:"maxDays" json:"maxDays"`
+}
+
+// Validate makes VersioningCollectionConfig validatable by implementing [validation.Validatable] interface.
+func (c VersioningCollectionConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.MaxRevisions, validation.Min(0), validation.Max(10000)),
+		validation.Field(&c.MaxDays, validation.Min(0), validation.Max(3650)),
+	)
+}
+
+func checkVersioningCollections(value any) error {
+	v, _ := value.(map[string]VersioningCollectionConfig)
+
+	errs := validation.Errors{}
+
+	for name, config := range v {
+		if !collectionNameRegex.MatchString(name) {
+			errs[name] = validation.NewError("validation_invalid_collection_name", "Invalid collection name.")
+			continue
+		}
+
+		if err := config.Validate(); err != nil {
+			errs[name] = err
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// -------------------------------------------------------------------
+
+var (
+	webhookNameRegex  = regexp.MustCompile(`^\w+$`)
+	webhookEventRegex = regexp.MustCompile(`^(records|admins|collections|\*)\.(create|update|delete|\*)$`)
+)
+
+// WebhooksConfig defines the webhooks notified on the records,
+// admins and collections changes.
+type WebhooksConfig struct {
+	// Hooks is a map with the webhooks indexed by their name.
+	Hooks map[string]WebhookConfig `form:"hooks" json:"hooks"`
+
+	// LogsMaxDays is the max days to keep the webhook delivery
+	// logs (0 means that the logs are never deleted).
+	LogsMaxDays int `form:"logsMaxDays" json:"logsMaxDays"`
+}
+
+// Validate makes WebhooksConfig validatable by implementing [validation.Validatable] interface.
+func (c WebhooksConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Hooks, validation.By(checkWebhooks)),
+		validation.Field(&c.LogsMaxDays, validation.Min(0), validation.Max(365)),
+	)
+}
+
+// Hook returns the named webhook.
+func (c WebhooksConfig) Hook(name string) (WebhookConfig, bool) {
+	hook, ok := c.Hooks[name]
+
+	return hook, ok
+}
+
+// WebhookConfig defines a single webhook endpoint and its delivery policy.
+type WebhookConfig struct {
+	Enabled bool `form:"enabled" json:"enabled"`
+
+	// Url is the endpoint where the event payloads are POST-ed.
+	Url string `form:"url" json:"url"`
+
+	// Secret is an optional key used to sign the payloads
+	// (see the "X-Webhook-Signature" request header).
+	Secret string `form:"secret" json:"secret"`
+
+	// Events is the list of the subscribed events in the format
+	// "resource.action", eg. "records.create", "admins.*", "*.delete".
+	Events []string `form:"events" json:"events"`
+
+	// Collections is an optional list with the collection names
+	// to filter the records events (empty means all collections).
+	Collections []string `form:"collections" json:"collections"`
+
+	// MaxRetries is the max number of delivery retries after a failed attempt.
+	MaxRetries int `form:"maxRetries" json:"maxRetries"`
+
+	// RetryDelay is the delay in seconds before the first retry
+	// (it is doubled after each failed retry).
+	RetryDelay int `form:"retryDelay" json:"retryDelay"`
+}
+
+// Validate makes WebhookConfig validatable by implementing [validation.Validatable] interface.
+func (c WebhookConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Url, validation.When(c.Enabled, validation.Required), is.URL),
+		validation.Field(&c.Secret, validation.Length(0, 300)),
+		validation.Field(&c.Events, validation.When(c.Enabled, validation.Required), validation.Each(validation.Match(webhookEventRegex))),
+		validation.Field(&c.Collections, validation.Each(validation.Required)),
+		validation.Field(&c.MaxRetries, validation.Min(0), validation.Max(10)),
+		validation.Field(&c.RetryDelay, validation.When(c.MaxRetries > 0, validation.Required), validation.Min(0), validation.Max(3600)),
+	)
+}
+
+// IsSubscribed checks whether the webhook is enabled and
+// subscribed to the provided resource event.
+//
+// The collection name is checked only for the records events.
+func (c WebhookConfig) IsSubscribed(resource string, action string, collection string) bool {
+	if !c.Enabled {
+		return false
+	}
+
+	if resource == "records" && len(c.Collections) > 0 && !list.ExistInSlice(collection, c.Collections) {
+		return false
+	}
+
+	for _, event := range c.Events {
+		eventResource, eventAction, _ := strings.Cut(event, ".")
+
+		if (eventResource == "*" || eventResource == resource) && (eventAction == "*" || eventAction == action) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func checkWebhooks(value any) error {
+	v, _ := value.(map[string]WebhookConfig)
+
+	errs := validation.Errors{}
+
+	for name, hook := range v {
+		if !webhookNameRegex.MatchString(name) {
+			errs[name] = validation.NewError("validation_invalid_webhook_name", "Invalid webhook name.")
+			continue
+		}
+
+		if err := hook.Validate(); err != nil {
+			errs[name] = err
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// -------------------------------------------------------------------
+
+// GraphqlConfig defines the settings of the GraphQL endpoint
+// ("/api/graphql") generated from the collections schema.
+type GraphqlConfig struct {
+	Enabled bool `form:"enabled" json:"enabled"`
+}
+
+// -------------------------------------------------------------------
+
+// UsersConfig defines the settings of the GORM registry users.
+type UsersConfig struct {
+	// UniqueEmail enables the case-insensitive uniqueness check of the
+	// users email on create and update (the soft deleted users included).
+	//
+	// Empty emails are not checked.
+	UniqueEmail bool `form:"uniqueEmail" json:"uniqueEmail"`
+}
+
+// -------------------------------------------------------------------
+
+type BackupsConfig struct {
+	// Cron is a cron expression to schedule auto backups, eg. "* * * * *".
+	//
+	// Leave it empty to disable the auto backups functionality.
+	Cron string `form:"cron" json:"cron"`
+
+	// CronMaxKeep is the the max number of cron generated backups to
+	// keep before removing older entries.
+	//
+	// This field works only when the cron config has valid cron expression.
+	CronMaxKeep int `form:"cronMaxKeep" json:"cronMaxKeep"`
+
+	// S3 is an optional S3 storage config specifying where to store the app backups.
+	S3 S3Config `form:"s3" json:"s3"`
+
+	// Driver is an optional registered filesystem driver config specifying
+	// where to store the app backups (it takes precedence over the S3 config).
+	Driver FilesystemDriverConfig `form:"driver" json:"driver"`
+
+	// EncryptionKey is an optional 32 characters AES key used to encrypt
+	// the generated backup archives before their upload.
+	//
+	// Leave it empty to store the backups unencrypted.
+	EncryptionKey string `form:"encryptionKey" json:"encryptionKey"`
+}
+
+// Validate makes BackupsConfig validatable by implementing [validation.Validatable] interface.
+func (c BackupsConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.S3),
+		validation.Field(&c.Driver),
+		validation.Field(&c.Cron, validation.By(checkCronExpression)),
+		validation.Field(
+			&c.CronMaxKeep,
+			validation.When(c.Cron != "", validation.Required),
+			validation.Min(1),
+		),
+		validation.Field(&c.EncryptionKey, validation.Length(32, 32)),
+	)
+}
+
+// -------------------------------------------------------------------
+
+type SearchSyncConfig struct {
+	Enabled bool `form:"enabled" json:"enabled"`
+
+	// Provider is the external search engine type
+	// (meilisearch, typesense or elasticsearch).
+	Provider string `form:"provider" json:"provider"`
+
+	// Host is the base url of the search engine server, eg. "http://localhost:7700".
+	Host   string `form:"host" json:"host"`
+	ApiKey string `form:"apiKey" json:"apiKey"`
+
+	// IndexPrefix is an optional prefix added to the collection name
+	// when resolving the search engine index name.
+	IndexPrefix string `form:"indexPrefix" json:"indexPrefix"`
+
+	// Collections is a list with the names of the collections
+	// whose record changes should be pushed to the search engine.
+	Collections []string `form:"collections" json:"collections"`
+}
+
+// Validate makes SearchSyncConfig validatable by implementing [validation.Validatable] interface.
+func (c SearchSyncConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(
+			&c.Provider,
+			validation.When(c.Enabled, validation.Required),
+			validation.In(list.ToInterfaceSlice(searchsync.Providers())...),
+		),
+		validation.Field(&c.Host, validation.When(c.Enabled, validation.Required), is.URL),
+		validation.Field(&c.IndexPrefix, validation.Length(0, 100)),
+	)
+}
+
+// IsSynced checks whether the provided collection name is marked for sync.
+func (c SearchSyncConfig) IsSynced(collectionName string) bool {
+	return c.Enabled && list.ExistInSlice(collectionName, c.Collections)
+}
+
+// IndexName returns the search engine index name for the provided collection.
+func (c SearchSyncConfig) IndexName(collectionName string) string {
+	return c.IndexPrefix + collectionName
+}
+
+// -------------------------------------------------------------------
+
+type SqlConsoleConfig struct {
+	Enabled bool `form:"enabled" json:"enabled"`
+
+	// Superadmins is the list with the ids of the admins
+	// that are allowed to use the SQL console.
+	Superadmins []string `form:"superadmins" json:"superadmins"`
+
+	// AllowWrites allows executing data modifying statements
+	// (the console is read-only by default).
+	AllowWrites bool `form:"allowWrites" json:"allowWrites"`
+
+	// MaxRows is the max number of returned rows per query.
+	MaxRows int `form:"maxRows" json:"maxRows"`
+
+	// Timeout is the max query execution time in seconds.
+	Timeout int `form:"timeout" json:"timeout"`
+}
+
+// Validate makes SqlConsoleConfig validatable by implementing [validation.Validatable] interface.
+func (c SqlConsoleConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Superadmins, validation.When(c.Enabled, validation.Required)),
+		validation.Field(&c.MaxRows, validation.When(c.Enabled, validation.Required), validation.Min(1), validation.Max(10000)),
+		validation.Field(&c.Timeout, validation.When(c.Enabled, validation.Required), validation.Min(1), validation.Max(300)),
+	)
+}
+
+// IsSuperadmin checks whether the provided admin id is allowed to use the SQL console.
+func (c SqlConsoleConfig) IsSuperadmin(adminId string) bool {
+	return c.Enabled && adminId != "" && list.ExistInSlice(adminId, c.Superadmins)
+}
+
+// -------------------------------------------------------------------
+
+// IdempotencyConfig defines the settings of the "Idempotency-Key"
+// request header support of the records, admins and users create endpoints.
+type IdempotencyConfig struct {
+	Enabled bool `form:"enabled" json:"enabled"`
+
+	// TTL is the number of seconds for which the first successful
+	// response of an idempotency key is stored and replayed.
+	TTL int `form:"ttl" json:"ttl"`
+}
+
+// Validate makes IdempotencyConfig validatable by implementing [validation.Validatable] interface.
+func (c IdempotencyConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.TTL, validation.When(c.Enabled, validation.Required), validation.Min(0), validation.Max(604800)),
+	)
+}
+
+// -------------------------------------------------------------------
+
+// AnalyticsConfig defines the settings of the read-only SQL analytics
+// endpoint (see the "/api/analytics/query" endpoint).
+//
+// The analytics queries are executed against a periodically
+// refreshed snapshot of the app database and not the live file.
+type AnalyticsConfig struct {
+	Enabled bool `form:"enabled" json:"enabled"`
+
+	// RefreshCron is the cron expression of the snapshot refresh.
+	RefreshCron string `form:"refreshCron" json:"refreshCron"`
+
+	// MaxRows is the max number of returned rows per query.
+	MaxRows int `form:"maxRows" json:"maxRows"`
+
+	// Timeout is the max query execution time in seconds.
+	Timeout int `form:"timeout" json:"timeout"`
+
+	// QuotaQueries is the max number of queries that a single admin
+	// could execute per QuotaWindow (0 means unlimited).
+	QuotaQueries int `form:"quotaQueries" json:"quotaQueries"`
+
+	// QuotaWindow is the admin quota period in seconds.
+	QuotaWindow int `form:"quotaWindow" json:"quotaWindow"`
+}
+
+// Validate makes AnalyticsConfig validatable by implementing [validation.Validatable] interface.
+func (c AnalyticsConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.RefreshCron, validation.When(c.Enabled, validation.Required), validation.By(checkCronExpression)),
+		validation.Field(&c.MaxRows, validation.When(c.Enabled, validation.Required), validation.Min(1), validation.Max(10000)),
+		validation.Field(&c.Timeout, validation.When(c.Enabled, validation.Required), validation.Min(1), validation.Max(300)),
+		validation.Field(&c.QuotaQueries, validation.Min(0)),
+		validation.Field(&c.QuotaWindow, validation.When(c.Enabled && c.QuotaQueries > 0, validation.Required), validation.Min(1), validation.Max(2592000)),
+	)
+}
+
+func checkCronExpression(value any) error {
+	v, _ := value.(string)
+	if v == "" {
+		return nil // nothing to check
+	}
+
+	_, err := cron.NewSchedule(v)
+	if err != nil {
+		return validation.NewError("validation_invalid_cron", err.Error())
+	}
+
+	return nil
+}
+
+// -------------------------------------------------------------------
+
+type MetaConfig struct {
+	AppName                    string        `form:"appName" json:"appName"`
+	AppUrl                     string        `form:"appUrl" json:"appUrl"`
+	HideControls               bool          `form:"hideControls" json:"hideControls"`
+	SenderName                 string        `form:"senderName" json:"senderName"`
+	SenderAddress              string        `form:"senderAddress" json:"senderAddress"`
+	VerificationTemplate       EmailTemplate `form:"verificationTemplate" json:"verificationTemplate"`
+	ResetPasswordTemplate      EmailTemplate `form:"resetPasswordTemplate" json:"resetPasswordTemplate"`
+	ConfirmEmailChangeTemplate EmailTemplate `form:"confirmEmailChangeTemplate" json:"confirmEmailChangeTemplate"`
+}
+
+// Validate makes MetaConfig validatable by implementing [validation.Validatable] interface.
+func (c MetaConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.AppName, validation.Required, validation.Length(1, 255)),
+		validation.Field(&c.AppUrl, validation.Required, is.URL),
+		validation.Field(&c.SenderName, validation.Required, validation.Length(1, 255)),
+		validation.Field(&c.SenderAddress, is.EmailFormat, validation.Required),
+		validation.Field(&c.VerificationTemplate, validation.Required),
+		validation.Field(&c.ResetPasswordTemplate, validation.Required),
+		validation.Field(&c.ConfirmEmailChangeTemplate, validation.Required),
+	)
+}
+
+type EmailTemplate struct {
+	Body      string `form:"body" json:"body"`
+	Subject   string `form:"subject" json:"subject"`
+	ActionUrl string `form:"actionUrl" json:"actionUrl"`
+}
+
+// Validate makes EmailTemplate validatable by implementing [validation.Validatable] interface.
+func (t EmailTemplate) Validate() error {
+	return validation.ValidateStruct(&t,
+		validation.Field(&t.Subject, validation.Required),
+		validation.Field(
+			&t.Body,
+			validation.Required,
+			validation.By(checkPlaceholderParams(EmailPlaceholderActionUrl)),
+		),
+		validation.Field(
+			&t.ActionUrl,
+			validation.Required,
+			validation.By(checkPlaceholderParams(EmailPlaceholderToken)),
+		),
+	)
+}
+
+func checkPlaceholderParams(params ...string) validation.RuleFunc {
+	return func(value any) error {
+		v, _ := value.(string)
+
+		for _, param := range params {
+			if !strings.Contains(v, param) {
+				return validation.NewError(
+					"validation_missing_required_param",
+					fmt.Sprintf("Missing required parameter %q", param),
+				)
+			}
+		}
+
+		return nil
+	}
+}
+
+// Resolve replaces the placeholder parameters in the current email
+// template and returns its components as ready-to-use strings.
+func (t EmailTemplate) Resolve(
+	appName string,
+	appUrl,
+	token string,
+) (subject, body, actionUrl string) {
+	// replace action url placeholder params (if any)
+	actionUrlParams := map[string]string{
+		EmailPlaceholderAppName: appName,
+		EmailPlaceholderAppUrl:  appUrl,
+		EmailPlaceholderToken:   token,
+	}
+	actionUrl = t.ActionUrl
+	for k, v := range actionUrlParams {
+		actionUrl = strings.ReplaceAll(actionUrl, k, v)
+	}
+	actionUrl, _ = rest.NormalizeUrl(actionUrl)
+
+	// replace body placeholder params (if any)
+	bodyParams := map[string]string{
+		EmailPlaceholderAppName:   appName,
+		EmailPlaceholderAppUrl:    appUrl,
+		EmailPlaceholderToken:     token,
+		EmailPlaceholderActionUrl: actionUrl,
+	}
+	body = t.Body
+	for k, v := range bodyParams {
+		body = strings.ReplaceAll(body, k, v)
+	}
+
+	// replace subject placeholder params (if any)
+	subjectParams := map[string]string{
+		EmailPlaceholderAppName: appName,
+		EmailPlaceholderAppUrl:  appUrl,
+	}
+	subject = t.Subject
+	for k, v := range subjectParams {
+		subject = strings.ReplaceAll(subject, k, v)
+	}
+
+	return subject, body, actionUrl
+}
+
+// -------------------------------------------------------------------
+
+type LogsConfig struct {
+	MaxDays int `form:"maxDays" json:"maxDays"`
+
+	// AuditMaxDays is the retention period (in days) of the admins,
+	// collections and settings changes audit logs.
+	//
+	// Set it to 0 to disable the audit logs.
+	AuditMaxDays int `form:"auditMaxDays" json:"auditMaxDays"`
+}
 
 // Validate makes LogsConfig validatable by implementing [validation.Validatable] interface.
 func (c LogsConfig) Validate() error {
 	return validation.ValidateStruct(&c,
 		validation.Field(&c.MaxDays, validation.Min(0)),
+		validation.Field(&c.AuditMaxDays, validation.Min(0)),
+	)
+}
+
+// -------------------------------------------------------------------
+
+type LogShippingConfig struct {
+	Enabled bool `form:"enabled" json:"enabled"`
+
+	// Sink is the external logs sink type (loki, syslog or http).
+	Sink string `form:"sink" json:"sink"`
+
+	// Url is the sink endpoint address, eg. "http://localhost:3100/loki/api/v1/push"
+	// for loki or "udp://localhost:514" for syslog.
+	Url string `form:"url" json:"url"`
+
+	// AuthHeader is an optional Authorization header value
+	// of the loki and http sink requests.
+	AuthHeader string `form:"authHeader" json:"authHeader"`
+
+	// Labels are optional static labels attached to each shipped log entry.
+	Labels map[string]string `form:"labels" json:"labels"`
+
+	// BatchSize is the max number of log entries sent with a single request (default to 100).
+	BatchSize int `form:"batchSize" json:"batchSize"`
+
+	// FlushInterval is the max number of seconds that a log entry
+	// waits before being sent (default to 5).
+	FlushInterval int `form:"flushInterval" json:"flushInterval"`
+
+	// QueueSize is the max number of the pending log entries (default to 10000).
+	//
+	// New entries are dropped when the queue is full
+	// (eg. because the sink is slow or unavailable).
+	QueueSize int `form:"queueSize" json:"queueSize"`
+}
+
+// Validate makes LogShippingConfig validatable by implementing [validation.Validatable] interface.
+func (c LogShippingConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(
+			&c.Sink,
+			validation.When(c.Enabled, validation.Required),
+			validation.In(list.ToInterfaceSlice(logship.Sinks())...),
+		),
+		validation.Field(&c.Url, validation.When(c.Enabled, validation.Required), validation.By(c.checkUrl)),
+		validation.Field(&c.AuthHeader, validation.Length(0, 1000)),
+		validation.Field(&c.BatchSize, validation.Min(0), validation.Max(1000)),
+		validation.Field(&c.FlushInterval, validation.Min(0), validation.Max(300)),
+		validation.Field(&c.QueueSize, validation.Min(0), validation.Max(100000)),
 	)
 }
 
+func (c LogShippingConfig) checkUrl(value any) error {
+	v, _ := value.(string)
+	if v == "" {
+		return nil // nothing to check
+	}
+
+	if c.Sink != logship.SinkSyslog {
+		return is.URL.Validate(v)
+	}
+
+	u, err := url.Parse(v)
+	if err != nil || (u.Scheme != "udp" && u.Scheme != "tcp") || u.Host == "" {
+		return validation.NewError(
+			"validation_invalid_syslog_url",
+			"Must be a syslog server address in the format udp://host:port or tcp://host:port.",
+		)
+	}
+
+	return nil
+}
+
+// ShipperOptions returns the log shipper batching and queue options.
+func (c LogShippingConfig) ShipperOptions() logship.ShipperOptions {
+	return logship.ShipperOptions{
+		BatchSize:     c.BatchSize,
+		FlushInterval: time.Duration(c.FlushInterval) * time.Second,
+		QueueSize:     c.QueueSize,
+	}
+}
+
 // -------------------------------------------------------------------
 
 type AuthProviderConfig struct {
@@ -2,15 +2,20 @@ package settings_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/pocketbase/pocketbase/models/settings"
 	"github.com/pocketbase/pocketbase/tools/auth"
+	"github.com/pocketbase/pocketbase/tools/filesystem"
 	"github.com/pocketbase/pocketbase/tools/mailer"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"gocloud.dev/blob"
 )
 
 func TestSettingsValidate(t *testing.T) {
@@ -23,6 +28,32 @@ func TestSettingsValidate(t *testing.T) {
 	s.Smtp.Host = ""
 	s.S3.Enabled = true
 	s.S3.Endpoint = "invalid"
+	s.FileServe.ChunkSize = -1
+	s.TrustedProxy.Ranges = []string{"invalid"}
+	s.SecurityHeaders.HSTSMaxAge = -1
+	s.SecurityReport.Window = -1
+	s.Metrics.Token = "short"
+	s.SlowQueries.Threshold = -1
+	s.S3Events.Enabled = true
+	s.SearchSync.Enabled = true
+	s.SqlConsole.Enabled = true
+	s.Analytics.Timeout = -1
+	s.RealtimeBroadcast.Enabled = true
+	s.RealtimeReplay.MaxEvents = -10
+	s.RateLimits.Duration = -10
+	s.Swagger.ApiKey = "short"
+	s.Approvals.Duration = 10
+	s.Egress.Default.Proxy = "invalid"
+	s.Anonymization.Salt = "short"
+	s.Localization.DefaultLocale = "invalid_locale"
+	s.Billing.StripeWebhookSecret = "whsec_test"
+	s.PublicForms.Forms = map[string]settings.PublicFormConfig{"contact": {}}
+	s.Comments.Collections = map[string]settings.CommentsCollectionConfig{"posts": {MaxLength: -1}}
+	s.Caching.Collections = map[string]settings.CollectionCachingConfig{"posts": {}}
+	s.Versioning.Collections = map[string]settings.VersioningCollectionConfig{"posts": {MaxRevisions: -1}}
+	s.Idempotency.TTL = -1
+	s.Webhooks.LogsMaxDays = -1
+	s.SearchSync.Host = ""
 	s.AdminAuthToken.Duration = -10
 	s.AdminPasswordResetToken.Duration = -10
 	s.AdminFileToken.Duration = -10
@@ -31,6 +62,8 @@ func TestSettingsValidate(t *testing.T) {
 	s.RecordEmailChangeToken.Duration = -10
 	s.RecordVerificationToken.Duration = -10
 	s.RecordFileToken.Duration = -10
+	s.UserAuthToken.Duration = -10
+	s.TokenSigning.Algorithm = "invalid"
 	s.GoogleAuth.Enabled = true
 	s.GoogleAuth.ClientId = ""
 	s.FacebookAuth.Enabled = true
@@ -79,6 +112,31 @@ func TestSettingsValidate(t *testing.T) {
 		`"logs":{`,
 		`"smtp":{`,
 		`"s3":{`,
+		`"fileServe":{`,
+		`"trustedProxy":{`,
+		`"securityHeaders":{`,
+		`"securityReport":{`,
+		`"metrics":{`,
+		`"slowQueries":{`,
+		`"s3Events":{`,
+		`"searchSync":{`,
+		`"sqlConsole":{`,
+		`"analytics":{`,
+		`"realtimeBroadcast":{`,
+		`"realtimeReplay":{`,
+		`"rateLimits":{`,
+		`"swagger":{`,
+		`"approvals":{`,
+		`"egress":{`,
+		`"anonymization":{`,
+		`"localization":{`,
+		`"billing":{`,
+		`"publicForms":{`,
+		`"comments":{`,
+		`"caching":{`,
+		`"versioning":{`,
+		`"idempotency":{`,
+		`"webhooks":{`,
 		`"adminAuthToken":{`,
 		`"adminPasswordResetToken":{`,
 		`"adminFileToken":{`,
@@ -87,6 +145,8 @@ func TestSettingsValidate(t *testing.T) {
 		`"recordEmailChangeToken":{`,
 		`"recordVerificationToken":{`,
 		`"recordFileToken":{`,
+		`"userAuthToken":{`,
+		`"tokenSigning":{`,
 		`"googleAuth":{`,
 		`"facebookAuth":{`,
 		`"githubAuth":{`,
@@ -136,6 +196,7 @@ func TestSettingsMerge(t *testing.T) {
 	s2.RecordEmailChangeToken.Duration = 5
 	s2.RecordVerificationToken.Duration = 6
 	s2.RecordFileToken.Duration = 7
+	s2.UserAuthToken.Duration = 8
 	s2.GoogleAuth.Enabled = true
 	s2.GoogleAuth.ClientId = "google_test"
 	s2.FacebookAuth.Enabled = true
@@ -232,7 +293,18 @@ func TestSettingsRedactClone(t *testing.T) {
 	// secrets
 	s1.Smtp.Password = testSecret
 	s1.S3.Secret = testSecret
+	s1.S3Events.Token = testSecret
 	s1.Backups.S3.Secret = testSecret
+	s1.Backups.EncryptionKey = testSecret
+	s1.SearchSync.ApiKey = testSecret
+	s1.LogShipping.AuthHeader = testSecret
+	s1.RealtimeBroadcast.Token = testSecret
+	s1.Swagger.ApiKey = testSecret
+	s1.Metrics.Token = testSecret
+	s1.Anonymization.Salt = testSecret
+	s1.Billing.StripeWebhookSecret = testSecret
+	s1.PublicForms.CaptchaSecret = testSecret
+	s1.Webhooks.Hooks = map[string]settings.WebhookConfig{"test": {Secret: testSecret}}
 	s1.AdminAuthToken.Secret = testSecret
 	s1.AdminPasswordResetToken.Secret = testSecret
 	s1.AdminFileToken.Secret = testSecret
@@ -241,6 +313,8 @@ func TestSettingsRedactClone(t *testing.T) {
 	s1.RecordEmailChangeToken.Secret = testSecret
 	s1.RecordVerificationToken.Secret = testSecret
 	s1.RecordFileToken.Secret = testSecret
+	s1.UserAuthToken.Secret = testSecret
+	s1.TokenSigning.Keys = []settings.TokenSigningKey{{Id: "test", PrivateKey: testSecret}}
 	s1.GoogleAuth.ClientSecret = testSecret
 	s1.FacebookAuth.ClientSecret = testSecret
 	s1.GithubAuth.ClientSecret = testSecret
@@ -259,6 +333,8 @@ func TestSettingsRedactClone(t *testing.T) {
 	s1.OIDC2Auth.ClientSecret = testSecret
 	s1.OIDC3Auth.ClientSecret = testSecret
 	s1.AppleAuth.ClientSecret = testSecret
+	s1.StorageDriver.Options = map[string]string{"key": testSecret}
+	s1.Backups.Driver.Options = map[string]string{"key": testSecret}
 
 	s1Bytes, err := json.Marshal(s1)
 	if err != nil {
@@ -350,6 +426,50 @@ func TestNamedAuthProviderConfigs(t *testing.T) {
 	}
 }
 
+func TestSettingsSecretFields(t *testing.T) {
+	s := settings.New()
+
+	s.Smtp.Password = "secret://env/SMTP_PASSWORD"
+	s.S3.Secret = "s3_test"
+	s.Backups.S3.Secret = "secret://aws/backups#secret"
+	s.SearchSync.ApiKey = "search_test"
+	s.LogShipping.AuthHeader = "secret://env/LOKI_AUTH"
+	s.Billing.StripeWebhookSecret = "secret://env/STRIPE_SECRET"
+	s.PublicForms.CaptchaSecret = "secret://env/CAPTCHA_SECRET"
+	s.Webhooks.Hooks = map[string]settings.WebhookConfig{"crm": {Secret: "secret://env/CRM_WEBHOOK_SECRET"}}
+	s.GithubAuth.ClientSecret = "secret://vault/secret/data/pb#github"
+	s.StorageDriver.Options = map[string]string{"key": "secret://env/STORAGE_KEY"}
+	s.Backups.Driver.Options = map[string]string{"bucket": "backups_test"}
+
+	result := s.SecretFields()
+
+	expected := map[string]string{
+		"smtp.password":                 "secret://env/SMTP_PASSWORD",
+		"s3.secret":                     "s3_test",
+		"backups.s3.secret":             "secret://aws/backups#secret",
+		"searchSync.apiKey":             "search_test",
+		"logShipping.authHeader":        "secret://env/LOKI_AUTH",
+		"billing.stripeWebhookSecret":   "secret://env/STRIPE_SECRET",
+		"publicForms.captchaSecret":     "secret://env/CAPTCHA_SECRET",
+		"webhooks.hooks.crm.secret":     "secret://env/CRM_WEBHOOK_SECRET",
+		"githubAuth.clientSecret":       "secret://vault/secret/data/pb#github",
+		"googleAuth.clientSecret":       "",
+		"storageDriver.options.key":     "secret://env/STORAGE_KEY",
+		"backups.driver.options.bucket": "backups_test",
+	}
+
+	for field, value := range expected {
+		v, ok := result[field]
+		if !ok {
+			t.Errorf("Missing field %q", field)
+			continue
+		}
+		if v != value {
+			t.Errorf("(%s) Expected %q, got %q", field, value, v)
+		}
+	}
+}
+
 func TestTokenConfigValidate(t *testing.T) {
 	scenarios := []struct {
 		config      settings.TokenConfig
@@ -399,6 +519,183 @@ func TestTokenConfigValidate(t *testing.T) {
 	}
 }
 
+func TestTokenSigningConfigValidate(t *testing.T) {
+	rsaKey, err := security.GenerateSigningKey(security.AlgorithmRS256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	edKey, err := security.GenerateSigningKey(security.AlgorithmEdDSA)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		name           string
+		config         settings.TokenSigningConfig
+		expectedErrors []string
+	}{
+		{
+			"zero value",
+			settings.TokenSigningConfig{},
+			[]string{},
+		},
+		{
+			"invalid algorithm",
+			settings.TokenSigningConfig{Algorithm: "HS512"},
+			[]string{"algorithm"},
+		},
+		{
+			"asymmetric algorithm without key id",
+			settings.TokenSigningConfig{Algorithm: security.AlgorithmRS256},
+			[]string{"keyId"},
+		},
+		{
+			"missing active key",
+			settings.TokenSigningConfig{
+				Algorithm: security.AlgorithmRS256,
+				KeyId:     "missing",
+				Keys: []settings.TokenSigningKey{
+					{Id: "a", Algorithm: security.AlgorithmRS256, PrivateKey: rsaKey},
+				},
+			},
+			[]string{"keyId"},
+		},
+		{
+			"active key with different algorithm",
+			settings.TokenSigningConfig{
+				Algorithm: security.AlgorithmRS256,
+				KeyId:     "a",
+				Keys: []settings.TokenSigningKey{
+					{Id: "a", Algorithm: security.AlgorithmEdDSA, PrivateKey: edKey},
+				},
+			},
+			[]string{"keyId"},
+		},
+		{
+			"invalid and duplicated keys",
+			settings.TokenSigningConfig{
+				Algorithm: security.AlgorithmHS256,
+				Keys: []settings.TokenSigningKey{
+					{Id: "a", Algorithm: security.AlgorithmRS256, PrivateKey: rsaKey},
+					{Id: "a", Algorithm: security.AlgorithmRS256, PrivateKey: rsaKey},
+				},
+			},
+			[]string{"keys"},
+		},
+		{
+			"invalid key data",
+			settings.TokenSigningConfig{
+				Algorithm: security.AlgorithmHS256,
+				Keys: []settings.TokenSigningKey{
+					{Id: "invalid id", Algorithm: security.AlgorithmRS256, PrivateKey: edKey},
+				},
+			},
+			[]string{"keys"},
+		},
+		{
+			"valid data",
+			settings.TokenSigningConfig{
+				Algorithm: security.AlgorithmEdDSA,
+				KeyId:     "b",
+				Keys: []settings.TokenSigningKey{
+					{Id: "a", Algorithm: security.AlgorithmRS256, PrivateKey: rsaKey},
+					{Id: "b", Algorithm: security.AlgorithmEdDSA, PrivateKey: edKey},
+				},
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		result := s.config.Validate()
+
+		// parse errors
+		errs, ok := result.(validation.Errors)
+		if !ok && result != nil {
+			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+			continue
+		}
+
+		// check errors
+		if len(errs) > len(s.expectedErrors) {
+			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+		}
+		for _, k := range s.expectedErrors {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+			}
+		}
+	}
+}
+
+func TestTokenSigningKeyValidate(t *testing.T) {
+	edKey, err := security.GenerateSigningKey(security.AlgorithmEdDSA)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		name           string
+		key            settings.TokenSigningKey
+		expectedErrors []string
+	}{
+		{
+			"zero value",
+			settings.TokenSigningKey{},
+			[]string{"id", "algorithm", "privateKey"},
+		},
+		{
+			"invalid data",
+			settings.TokenSigningKey{
+				Id:         "invalid id",
+				Algorithm:  security.AlgorithmHS256,
+				PrivateKey: "invalid",
+			},
+			[]string{"id", "algorithm", "privateKey"},
+		},
+		{
+			"private key with different algorithm",
+			settings.TokenSigningKey{
+				Id:         "test",
+				Algorithm:  security.AlgorithmRS256,
+				PrivateKey: edKey,
+			},
+			[]string{"privateKey"},
+		},
+		{
+			"valid data",
+			settings.TokenSigningKey{
+				Id:         "test-1_a",
+				Algorithm:  security.AlgorithmEdDSA,
+				PrivateKey: edKey,
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		result := s.key.Validate()
+
+		// parse errors
+		errs, ok := result.(validation.Errors)
+		if !ok && result != nil {
+			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+			continue
+		}
+
+		// check errors
+		if len(errs) > len(s.expectedErrors) {
+			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+		}
+		for _, k := range s.expectedErrors {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+			}
+		}
+	}
+}
+
 func TestSmtpConfigValidate(t *testing.T) {
 	scenarios := []struct {
 		config      settings.SmtpConfig
@@ -515,6 +812,21 @@ func TestS3ConfigValidate(t *testing.T) {
 			},
 			false,
 		},
+		// too small part size
+		{
+			settings.S3Config{PartSize: 4},
+			true,
+		},
+		// too large concurrency
+		{
+			settings.S3Config{Concurrency: 65},
+			true,
+		},
+		// valid transfer options
+		{
+			settings.S3Config{PartSize: 64, Concurrency: 16},
+			false,
+		},
 	}
 
 	for i, scenario := range scenarios {
@@ -530,130 +842,57 @@ func TestS3ConfigValidate(t *testing.T) {
 	}
 }
 
-func TestMetaConfigValidate(t *testing.T) {
-	invalidTemplate := settings.EmailTemplate{
-		Subject:   "test",
-		ActionUrl: "test",
-		Body:      "test",
-	}
-
-	noPlaceholdersTemplate := settings.EmailTemplate{
-		Subject:   "test",
-		ActionUrl: "http://example.com",
-		Body:      "test",
-	}
-
-	withPlaceholdersTemplate := settings.EmailTemplate{
-		Subject:   "test",
-		ActionUrl: "http://example.com" + settings.EmailPlaceholderToken,
-		Body:      "test" + settings.EmailPlaceholderActionUrl,
-	}
-
+func TestS3ConfigTransferOptions(t *testing.T) {
 	scenarios := []struct {
-		config      settings.MetaConfig
-		expectError bool
+		config              settings.S3Config
+		expectedPartSize    int64
+		expectedConcurrency int
 	}{
-		// zero values
-		{
-			settings.MetaConfig{},
-			true,
-		},
-		// invalid data
-		{
-			settings.MetaConfig{
-				AppName:                    strings.Repeat("a", 300),
-				AppUrl:                     "test",
-				SenderName:                 strings.Repeat("a", 300),
-				SenderAddress:              "invalid_email",
-				VerificationTemplate:       invalidTemplate,
-				ResetPasswordTemplate:      invalidTemplate,
-				ConfirmEmailChangeTemplate: invalidTemplate,
-			},
-			true,
-		},
-		// invalid data (missing required placeholders)
-		{
-			settings.MetaConfig{
-				AppName:                    "test",
-				AppUrl:                     "https://example.com",
-				SenderName:                 "test",
-				SenderAddress:              "test@example.com",
-				VerificationTemplate:       noPlaceholdersTemplate,
-				ResetPasswordTemplate:      noPlaceholdersTemplate,
-				ConfirmEmailChangeTemplate: noPlaceholdersTemplate,
-			},
-			true,
-		},
-		// valid data
-		{
-			settings.MetaConfig{
-				AppName:                    "test",
-				AppUrl:                     "https://example.com",
-				SenderName:                 "test",
-				SenderAddress:              "test@example.com",
-				VerificationTemplate:       withPlaceholdersTemplate,
-				ResetPasswordTemplate:      withPlaceholdersTemplate,
-				ConfirmEmailChangeTemplate: withPlaceholdersTemplate,
-			},
-			false,
-		},
+		{settings.S3Config{}, 5 << 20, 5},
+		{settings.S3Config{PartSize: -1, Concurrency: -1}, 5 << 20, 5},
+		{settings.S3Config{PartSize: 64, Concurrency: 16}, 64 << 20, 16},
 	}
 
-	for i, scenario := range scenarios {
-		result := scenario.config.Validate()
-
-		if result != nil && !scenario.expectError {
-			t.Errorf("(%d) Didn't expect error, got %v", i, result)
+	for i, s := range scenarios {
+		if v := s.config.TransferPartSize(); v != s.expectedPartSize {
+			t.Errorf("(%d) Expected part size %d, got %d", i, s.expectedPartSize, v)
 		}
 
-		if result == nil && scenario.expectError {
-			t.Errorf("(%d) Expected error, got nil", i)
+		if v := s.config.TransferConcurrency(); v != s.expectedConcurrency {
+			t.Errorf("(%d) Expected concurrency %d, got %d", i, s.expectedConcurrency, v)
 		}
 	}
 }
 
-func TestBackupsConfigValidate(t *testing.T) {
+func TestFileServeConfigValidate(t *testing.T) {
 	scenarios := []struct {
 		name           string
-		config         settings.BackupsConfig
+		config         settings.FileServeConfig
 		expectedErrors []string
 	}{
 		{
 			"zero value",
-			settings.BackupsConfig{},
+			settings.FileServeConfig{},
 			[]string{},
 		},
 		{
-			"invalid cron",
-			settings.BackupsConfig{
-				Cron:        "invalid",
-				CronMaxKeep: 0,
-			},
-			[]string{"cron", "cronMaxKeep"},
+			"negative chunk size",
+			settings.FileServeConfig{ChunkSize: -1},
+			[]string{"chunkSize"},
 		},
 		{
-			"invalid enabled S3",
-			settings.BackupsConfig{
-				S3: settings.S3Config{
-					Enabled: true,
-				},
-			},
-			[]string{"s3"},
+			"invalid signed url duration",
+			settings.FileServeConfig{SignedUrlDuration: 604801},
+			[]string{"signedUrlDuration"},
+		},
+		{
+			"invalid image transform limits",
+			settings.FileServeConfig{ImageTransformMaxDimension: 10001, ImageTransformCacheMaxSize: -1},
+			[]string{"imageTransformMaxDimension", "imageTransformCacheMaxSize"},
 		},
 		{
 			"valid data",
-			settings.BackupsConfig{
-				S3: settings.S3Config{
-					Enabled:   true,
-					Endpoint:  "example.com",
-					Bucket:    "test",
-					Region:    "test",
-					AccessKey: "test",
-					Secret:    "test",
-				},
-				Cron:        "*/10 * * * *",
-				CronMaxKeep: 1,
-			},
+			settings.FileServeConfig{ChunkSize: 1024, SignedUrlDuration: 3600, ImageTransformMaxDimension: 1000, ImageTransformCacheMaxSize: 1024},
 			[]string{},
 		},
 	}
@@ -680,266 +919,2312 @@ func TestBackupsConfigValidate(t *testing.T) {
 	}
 }
 
-func TestEmailTemplateValidate(t *testing.T) {
+func TestFileServeConfigSignedUrlExpiry(t *testing.T) {
 	scenarios := []struct {
-		emailTemplate  settings.EmailTemplate
+		config   settings.FileServeConfig
+		expected time.Duration
+	}{
+		{settings.FileServeConfig{}, 5 * time.Minute},
+		{settings.FileServeConfig{SignedUrlDuration: -1}, 5 * time.Minute},
+		{settings.FileServeConfig{SignedUrlDuration: 60}, time.Minute},
+	}
+
+	for i, s := range scenarios {
+		if result := s.config.SignedUrlExpiry(); result != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, result)
+		}
+	}
+}
+
+func TestFileServeConfigImageTransformLimits(t *testing.T) {
+	scenarios := []struct {
+		config            settings.FileServeConfig
+		expectedDimension int
+		expectedCacheSize int64
+	}{
+		{settings.FileServeConfig{}, 2048, 100 << 20},
+		{settings.FileServeConfig{ImageTransformMaxDimension: -1, ImageTransformCacheMaxSize: -1}, 2048, 100 << 20},
+		{settings.FileServeConfig{ImageTransformMaxDimension: 100, ImageTransformCacheMaxSize: 1024}, 100, 1024},
+	}
+
+	for i, s := range scenarios {
+		if result := s.config.ImageTransformDimensionLimit(); result != s.expectedDimension {
+			t.Errorf("(%d) Expected dimension limit %v, got %v", i, s.expectedDimension, result)
+		}
+
+		if result := s.config.ImageTransformCacheLimit(); result != s.expectedCacheSize {
+			t.Errorf("(%d) Expected cache limit %v, got %v", i, s.expectedCacheSize, result)
+		}
+	}
+}
+
+func TestTrustedProxyConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         settings.TrustedProxyConfig
 		expectedErrors []string
 	}{
-		// require values
 		{
-			settings.EmailTemplate{},
-			[]string{"subject", "actionUrl", "body"},
+			"zero value",
+			settings.TrustedProxyConfig{},
+			[]string{},
 		},
-		// missing placeholders
 		{
-			settings.EmailTemplate{
-				Subject:   "test",
-				ActionUrl: "test",
-				Body:      "test",
+			"invalid data",
+			settings.TrustedProxyConfig{
+				Headers: []string{"", "X Invalid"},
+				Ranges:  []string{"", "1.2.3", "10.0.0.0/33"},
 			},
-			[]string{"actionUrl", "body"},
+			[]string{"headers", "ranges"},
 		},
-		// valid data
 		{
-			settings.EmailTemplate{
-				Subject:   "test",
-				ActionUrl: "test" + settings.EmailPlaceholderToken,
-				Body:      "test" + settings.EmailPlaceholderActionUrl,
+			"valid data",
+			settings.TrustedProxyConfig{
+				Headers: []string{"X-Forwarded-For", "CF-Connecting-IP"},
+				Ranges:  []string{"10.0.0.0/8", "172.17.0.1", "::1", "fd00::/8"},
 			},
 			[]string{},
 		},
 	}
 
-	for i, s := range scenarios {
-		result := s.emailTemplate.Validate()
+	for _, s := range scenarios {
+		result := s.config.Validate()
 
 		// parse errors
 		errs, ok := result.(validation.Errors)
 		if !ok && result != nil {
-			t.Errorf("(%d) Failed to parse errors %v", i, result)
+			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
 			continue
 		}
 
 		// check errors
 		if len(errs) > len(s.expectedErrors) {
-			t.Errorf("(%d) Expected error keys %v, got %v", i, s.expectedErrors, errs)
+			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
 		}
 		for _, k := range s.expectedErrors {
 			if _, ok := errs[k]; !ok {
-				t.Errorf("(%d) Missing expected error key %q in %v", i, k, errs)
+				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
 			}
 		}
 	}
 }
 
-func TestEmailTemplateResolve(t *testing.T) {
-	allPlaceholders := settings.EmailPlaceholderActionUrl + settings.EmailPlaceholderToken + settings.EmailPlaceholderAppName + settings.EmailPlaceholderAppUrl
+func TestTrustedProxyConfigParseRanges(t *testing.T) {
+	config := settings.TrustedProxyConfig{
+		Ranges: []string{"10.0.0.0/8", " 172.17.0.1 ", "::1"},
+	}
+
+	ranges, err := config.ParseRanges()
+	if err != nil {
+		t.Fatal(err)
+	}
 
+	expected := []string{"10.0.0.0/8", "172.17.0.1/32", "::1/128"}
+	if len(ranges) != len(expected) {
+		t.Fatalf("Expected %d ranges, got %d", len(expected), len(ranges))
+	}
+	for i, r := range ranges {
+		if r.String() != expected[i] {
+			t.Errorf("Expected range %q, got %q", expected[i], r.String())
+		}
+	}
+
+	config.Ranges = append(config.Ranges, "invalid")
+	if _, err := config.ParseRanges(); err == nil {
+		t.Fatal("Expected error for the invalid range, got nil")
+	}
+}
+
+func TestSecurityHeadersConfigValidate(t *testing.T) {
 	scenarios := []struct {
-		emailTemplate     settings.EmailTemplate
-		expectedSubject   string
-		expectedBody      string
-		expectedActionUrl string
+		name           string
+		config         settings.SecurityHeadersConfig
+		expectedErrors []string
 	}{
-		// no placeholders
 		{
-			emailTemplate: settings.EmailTemplate{
-				Subject:   "subject:",
-				Body:      "body:",
-				ActionUrl: "/actionUrl////",
+			"zero value",
+			settings.SecurityHeadersConfig{},
+			[]string{},
+		},
+		{
+			"invalid data",
+			settings.SecurityHeadersConfig{
+				HSTSMaxAge:                 -1,
+				FrameOptions:               "ALLOW-FROM https://example.com",
+				ReferrerPolicy:             "invalid",
+				ContentSecurityPolicy:      "default-src 'self'\r\nX-Test: 1",
+				DocsContentSecurityPolicy:  strings.Repeat("a", 1001),
+				FilesContentSecurityPolicy: "sandbox\n",
+			},
+			[]string{
+				"hstsMaxAge",
+				"frameOptions",
+				"referrerPolicy",
+				"contentSecurityPolicy",
+				"docsContentSecurityPolicy",
+				"filesContentSecurityPolicy",
 			},
-			expectedSubject:   "subject:",
-			expectedActionUrl: "/actionUrl/",
-			expectedBody:      "body:",
 		},
-		// with placeholders
 		{
-			emailTemplate: settings.EmailTemplate{
-				ActionUrl: "/actionUrl////" + allPlaceholders,
-				Subject:   "subject:" + allPlaceholders,
-				Body:      "body:" + allPlaceholders,
+			"valid data",
+			settings.SecurityHeadersConfig{
+				HSTSMaxAge:                 31536000,
+				FrameOptions:               "DENY",
+				ReferrerPolicy:             "no-referrer",
+				ContentSecurityPolicy:      "default-src 'self'",
+				DocsContentSecurityPolicy:  settings.DefaultDocsContentSecurityPolicy,
+				FilesContentSecurityPolicy: "sandbox",
 			},
-			expectedActionUrl: fmt.Sprintf(
-				"/actionUrl/%%7BACTION_URL%%7D%s%s%s",
-				"token_test",
-				"name_test",
-				"url_test",
-			),
-			expectedSubject: fmt.Sprintf(
-				"subject:%s%s%s%s",
-				settings.EmailPlaceholderActionUrl,
-				settings.EmailPlaceholderToken,
-				"name_test",
-				"url_test",
-			),
-			expectedBody: fmt.Sprintf(
-				"body:%s%s%s%s",
-				fmt.Sprintf(
-					"/actionUrl/%%7BACTION_URL%%7D%s%s%s",
-					"token_test",
-					"name_test",
-					"url_test",
-				),
-				"token_test",
-				"name_test",
-				"url_test",
-			),
+			[]string{},
 		},
 	}
 
-	for i, s := range scenarios {
-		subject, body, actionUrl := s.emailTemplate.Resolve("name_test", "url_test", "token_test")
+	for _, s := range scenarios {
+		result := s.config.Validate()
 
-		if s.expectedSubject != subject {
-			t.Errorf("(%d) Expected subject %q got %q", i, s.expectedSubject, subject)
+		// parse errors
+		errs, ok := result.(validation.Errors)
+		if !ok && result != nil {
+			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+			continue
 		}
 
-		if s.expectedBody != body {
-			t.Errorf("(%d) Expected body \n%v got \n%v", i, s.expectedBody, body)
+		// check errors
+		if len(errs) > len(s.expectedErrors) {
+			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+		}
+		for _, k := range s.expectedErrors {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+			}
 		}
+	}
+}
 
-		if s.expectedActionUrl != actionUrl {
-			t.Errorf("(%d) Expected actionUrl \n%v got \n%v", i, s.expectedActionUrl, actionUrl)
+func TestSecurityHeadersConfigHSTSValue(t *testing.T) {
+	scenarios := []struct {
+		config   settings.SecurityHeadersConfig
+		expected string
+	}{
+		{settings.SecurityHeadersConfig{}, ""},
+		{settings.SecurityHeadersConfig{HSTSMaxAge: -1, HSTSPreload: true}, ""},
+		{settings.SecurityHeadersConfig{HSTSMaxAge: 100}, "max-age=100"},
+		{settings.SecurityHeadersConfig{HSTSMaxAge: 100, HSTSIncludeSubdomains: true}, "max-age=100; includeSubdomains"},
+		{settings.SecurityHeadersConfig{HSTSMaxAge: 100, HSTSIncludeSubdomains: true, HSTSPreload: true}, "max-age=100; includeSubdomains; preload"},
+	}
+
+	for i, s := range scenarios {
+		if v := s.config.HSTSValue(); v != s.expected {
+			t.Errorf("(%d) Expected %q, got %q", i, s.expected, v)
 		}
 	}
 }
 
-func TestLogsConfigValidate(t *testing.T) {
+func TestSecurityReportConfigValidate(t *testing.T) {
 	scenarios := []struct {
-		config      settings.LogsConfig
-		expectError bool
+		name           string
+		config         settings.SecurityReportConfig
+		expectedErrors []string
 	}{
-		// zero values
 		{
-			settings.LogsConfig{},
-			false,
+			"zero value",
+			settings.SecurityReportConfig{},
+			[]string{"window", "authFailuresThreshold", "forbiddenThreshold"},
 		},
-		// invalid data
 		{
-			settings.LogsConfig{MaxDays: -10},
-			true,
+			"invalid data",
+			settings.SecurityReportConfig{
+				GeoHeader:             "CF IPCountry",
+				Window:                10,
+				BaselineDays:          -1,
+				AuthFailuresThreshold: -1,
+				ForbiddenThreshold:    -1,
+				NotifyCron:            "invalid",
+				NotifyEmails:          []string{"test@example.com", "invalid"},
+			},
+			[]string{
+				"geoHeader",
+				"window",
+				"baselineDays",
+				"authFailuresThreshold",
+				"forbiddenThreshold",
+				"notifyCron",
+				"notifyEmails",
+			},
 		},
-		// valid data
 		{
-			settings.LogsConfig{MaxDays: 1},
-			false,
+			"valid data",
+			settings.SecurityReportConfig{
+				GeoHeader:             "CF-IPCountry",
+				Window:                3600,
+				BaselineDays:          7,
+				AuthFailuresThreshold: 10,
+				ForbiddenThreshold:    50,
+				NotifyCron:            "*/30 * * * *",
+				NotifyEmails:          []string{"test@example.com"},
+			},
+			[]string{},
 		},
 	}
 
-	for i, scenario := range scenarios {
-		result := scenario.config.Validate()
+	for _, s := range scenarios {
+		result := s.config.Validate()
 
-		if result != nil && !scenario.expectError {
-			t.Errorf("(%d) Didn't expect error, got %v", i, result)
+		// parse errors
+		errs, ok := result.(validation.Errors)
+		if !ok && result != nil {
+			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+			continue
 		}
 
-		if result == nil && scenario.expectError {
-			t.Errorf("(%d) Expected error, got nil", i)
+		// check errors
+		if len(errs) > len(s.expectedErrors) {
+			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+		}
+		for _, k := range s.expectedErrors {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+			}
 		}
 	}
 }
 
-func TestAuthProviderConfigValidate(t *testing.T) {
+func TestMetricsConfigValidate(t *testing.T) {
 	scenarios := []struct {
-		config      settings.AuthProviderConfig
-		expectError bool
+		name           string
+		config         settings.MetricsConfig
+		expectedErrors []string
 	}{
-		// zero values (disabled)
-		{
-			settings.AuthProviderConfig{},
-			false,
-		},
-		// zero values (enabled)
-		{
-			settings.AuthProviderConfig{Enabled: true},
-			true,
-		},
-		// invalid data
 		{
-			settings.AuthProviderConfig{
-				Enabled:      true,
-				ClientId:     "",
-				ClientSecret: "",
-				AuthUrl:      "test",
-				TokenUrl:     "test",
-				UserApiUrl:   "test",
-			},
-			true,
+			"zero value",
+			settings.MetricsConfig{},
+			[]string{},
 		},
-		// valid data (only the required)
 		{
-			settings.AuthProviderConfig{
-				Enabled:      true,
-				ClientId:     "test",
-				ClientSecret: "test",
+			"invalid token",
+			settings.MetricsConfig{
+				Enabled: true,
+				Token:   "short",
 			},
-			false,
+			[]string{"token"},
 		},
-		// valid data (fill all fields)
 		{
-			settings.AuthProviderConfig{
-				Enabled:      true,
-				ClientId:     "test",
-				ClientSecret: "test",
-				AuthUrl:      "https://example.com",
-				TokenUrl:     "https://example.com",
-				UserApiUrl:   "https://example.com",
+			"valid data",
+			settings.MetricsConfig{
+				Enabled: true,
+				Token:   strings.Repeat("a", 30),
 			},
-			false,
+			[]string{},
 		},
 	}
 
-	for i, scenario := range scenarios {
-		result := scenario.config.Validate()
+	for _, s := range scenarios {
+		result := s.config.Validate()
 
-		if result != nil && !scenario.expectError {
-			t.Errorf("(%d) Didn't expect error, got %v", i, result)
+		// parse errors
+		errs, ok := result.(validation.Errors)
+		if !ok && result != nil {
+			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+			continue
 		}
 
-		if result == nil && scenario.expectError {
-			t.Errorf("(%d) Expected error, got nil", i)
+		// check errors
+		if len(errs) > len(s.expectedErrors) {
+			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+		}
+		for _, k := range s.expectedErrors {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+			}
 		}
 	}
 }
 
-func TestAuthProviderConfigSetupProvider(t *testing.T) {
-	provider := auth.NewGithubProvider()
-
-	// disabled config
-	c1 := settings.AuthProviderConfig{Enabled: false}
-	if err := c1.SetupProvider(provider); err == nil {
-		t.Errorf("Expected error, got nil")
+func TestS3EventsConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         settings.S3EventsConfig
+		expectedErrors []string
+	}{
+		{
+			"zero value (disabled)",
+			settings.S3EventsConfig{},
+			[]string{},
+		},
+		{
+			"zero value (enabled)",
+			settings.S3EventsConfig{Enabled: true},
+			[]string{"token"},
+		},
+		{
+			"invalid data",
+			settings.S3EventsConfig{Token: "short"},
+			[]string{"token"},
+		},
+		{
+			"valid data",
+			settings.S3EventsConfig{
+				Enabled: true,
+				Token:   strings.Repeat("a", 30),
+			},
+			[]string{},
+		},
 	}
 
-	c2 := settings.AuthProviderConfig{
-		Enabled:      true,
-		ClientId:     "test_ClientId",
-		ClientSecret: "test_ClientSecret",
-		AuthUrl:      "test_AuthUrl",
+	for _, s := range scenarios {
+		result := s.config.Validate()
+
+		// parse errors
+		errs, ok := result.(validation.Errors)
+		if !ok && result != nil {
+			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+			continue
+		}
+
+		// check errors
+		if len(errs) > len(s.expectedErrors) {
+			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+		}
+		for _, k := range s.expectedErrors {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+			}
+		}
+	}
+}
+
+func TestRealtimeBroadcastConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         settings.RealtimeBroadcastConfig
+		expectedErrors []string
+	}{
+		{
+			"zero value (disabled)",
+			settings.RealtimeBroadcastConfig{},
+			[]string{},
+		},
+		{
+			"zero value (enabled)",
+			settings.RealtimeBroadcastConfig{Enabled: true},
+			[]string{"token"},
+		},
+		{
+			"invalid data",
+			settings.RealtimeBroadcastConfig{Token: "short"},
+			[]string{"token"},
+		},
+		{
+			"valid data",
+			settings.RealtimeBroadcastConfig{
+				Enabled: true,
+				Token:   strings.Repeat("a", 30),
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		result := s.config.Validate()
+
+		// parse errors
+		errs, ok := result.(validation.Errors)
+		if !ok && result != nil {
+			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+			continue
+		}
+
+		// check errors
+		if len(errs) > len(s.expectedErrors) {
+			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+		}
+		for _, k := range s.expectedErrors {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+			}
+		}
+	}
+}
+
+func TestRateLimitsConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         settings.RateLimitsConfig
+		expectedErrors []string
+	}{
+		{
+			"zero value (disabled)",
+			settings.RateLimitsConfig{},
+			[]string{},
+		},
+		{
+			"zero value (enabled)",
+			settings.RateLimitsConfig{Enabled: true},
+			[]string{"maxRequests", "duration"},
+		},
+		{
+			"invalid data",
+			settings.RateLimitsConfig{MaxRequests: -1, Duration: 86401},
+			[]string{"maxRequests", "duration"},
+		},
+		{
+			"valid data",
+			settings.RateLimitsConfig{
+				Enabled:     true,
+				MaxRequests: 100,
+				Duration:    60,
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		result := s.config.Validate()
+
+		// parse errors
+		errs, ok := result.(validation.Errors)
+		if !ok && result != nil {
+			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+			continue
+		}
+
+		// check errors
+		if len(errs) > len(s.expectedErrors) {
+			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+		}
+		for _, k := range s.expectedErrors {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+			}
+		}
+	}
+}
+
+func TestSwaggerConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         settings.SwaggerConfig
+		expectedErrors []string
+	}{
+		{
+			"zero value",
+			settings.SwaggerConfig{},
+			[]string{},
+		},
+		{
+			"too short api key",
+			settings.SwaggerConfig{Enabled: true, ApiKey: "short"},
+			[]string{"apiKey"},
+		},
+		{
+			"valid data",
+			settings.SwaggerConfig{
+				Enabled: true,
+				ApiKey:  strings.Repeat("a", 30),
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		result := s.config.Validate()
+
+		// parse errors
+		errs, ok := result.(validation.Errors)
+		if !ok && result != nil {
+			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+			continue
+		}
+
+		// check errors
+		if len(errs) > len(s.expectedErrors) {
+			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+		}
+		for _, k := range s.expectedErrors {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+			}
+		}
+	}
+}
+
+func TestApprovalsConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         settings.ApprovalsConfig
+		expectedErrors []string
+	}{
+		{
+			"zero value (disabled)",
+			settings.ApprovalsConfig{},
+			[]string{},
+		},
+		{
+			"zero value (enabled)",
+			settings.ApprovalsConfig{Enabled: true},
+			[]string{"actions", "duration"},
+		},
+		{
+			"invalid data",
+			settings.ApprovalsConfig{
+				Actions:  []string{settings.ApprovalActionSettingsUpdate, "missing"},
+				Duration: 59,
+			},
+			[]string{"actions", "duration"},
+		},
+		{
+			"valid data",
+			settings.ApprovalsConfig{
+				Enabled:  true,
+				Actions:  []string{settings.ApprovalActionCollectionDelete, settings.ApprovalActionBackupRestore},
+				Duration: 3600,
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		result := s.config.Validate()
+
+		// parse errors
+		errs, ok := result.(validation.Errors)
+		if !ok && result != nil {
+			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+			continue
+		}
+
+		// check errors
+		if len(errs) > len(s.expectedErrors) {
+			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+		}
+		for _, k := range s.expectedErrors {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+			}
+		}
+	}
+}
+
+func TestApprovalsConfigIsRequired(t *testing.T) {
+	scenarios := []struct {
+		config   settings.ApprovalsConfig
+		action   string
+		expected bool
+	}{
+		{settings.ApprovalsConfig{Actions: []string{settings.ApprovalActionSettingsUpdate}}, settings.ApprovalActionSettingsUpdate, false},
+		{settings.ApprovalsConfig{Enabled: true}, settings.ApprovalActionSettingsUpdate, false},
+		{settings.ApprovalsConfig{Enabled: true, Actions: []string{settings.ApprovalActionSettingsUpdate}}, settings.ApprovalActionBackupRestore, false},
+		{settings.ApprovalsConfig{Enabled: true, Actions: []string{settings.ApprovalActionSettingsUpdate, settings.ApprovalActionBackupRestore}}, settings.ApprovalActionBackupRestore, true},
+	}
+
+	for i, s := range scenarios {
+		result := s.config.IsRequired(s.action)
+		if result != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, result)
+		}
+	}
+}
+
+func TestEgressConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         settings.EgressConfig
+		expectedErrors []string
+	}{
+		{
+			"zero value",
+			settings.EgressConfig{},
+			[]string{},
+		},
+		{
+			"invalid data",
+			settings.EgressConfig{
+				Default: settings.EgressPolicyConfig{
+					Proxy:     "invalid",
+					Allowlist: []string{"example.com", ""},
+				},
+				Subsystems: map[string]settings.EgressPolicyConfig{
+					"missing": {},
+				},
+			},
+			[]string{"default", "subsystems"},
+		},
+		{
+			"invalid subsystem policy",
+			settings.EgressConfig{
+				Subsystems: map[string]settings.EgressPolicyConfig{
+					settings.EgressSubsystemS3: {Proxy: "invalid"},
+				},
+			},
+			[]string{"subsystems"},
+		},
+		{
+			"valid data",
+			settings.EgressConfig{
+				Default: settings.EgressPolicyConfig{
+					Proxy:     "http://proxy.example.com:3128",
+					Allowlist: []string{"*.example.com", "10.0.0.0/8"},
+				},
+				Subsystems: map[string]settings.EgressPolicyConfig{
+					settings.EgressSubsystemS3:         {AllowPrivate: true},
+					settings.EgressSubsystemOAuth2:     {},
+					settings.EgressSubsystemSearchSync: {Allowlist: []string{"search.internal"}},
+				},
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		result := s.config.Validate()
+
+		// parse errors
+		errs, ok := result.(validation.Errors)
+		if !ok && result != nil {
+			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+			continue
+		}
+
+		// check errors
+		if len(errs) > len(s.expectedErrors) {
+			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+		}
+		for _, k := range s.expectedErrors {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+			}
+		}
+	}
+}
+
+func TestAnonymizationConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         settings.AnonymizationConfig
+		expectedErrors []string
+	}{
+		{
+			"zero value",
+			settings.AnonymizationConfig{},
+			[]string{},
+		},
+		{
+			"profiles without salt",
+			settings.AnonymizationConfig{
+				Profiles: map[string]settings.AnonymizationProfile{
+					"dev": {Fields: map[string]string{"email": "mask"}},
+				},
+			},
+			[]string{"salt"},
+		},
+		{
+			"invalid profiles",
+			settings.AnonymizationConfig{
+				Salt: "short",
+				Profiles: map[string]settings.AnonymizationProfile{
+					"invalid name": {Fields: map[string]string{"email": "mask"}},
+					"empty":        {},
+					"invalid":      {Fields: map[string]string{"email": "unknown", ".name": "mask"}},
+				},
+			},
+			[]string{"salt", "profiles"},
+		},
+		{
+			"valid data",
+			settings.AnonymizationConfig{
+				Salt: "0123456789",
+				Profiles: map[string]settings.AnonymizationProfile{
+					"dev": {Fields: map[string]string{
+						"email":      "mask",
+						"users.name": "fake",
+						"posts.body": "hash",
+					}},
+				},
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		result := s.config.Validate()
+
+		// parse errors
+		errs, ok := result.(validation.Errors)
+		if !ok && result != nil {
+			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+			continue
+		}
+
+		// check errors
+		if len(errs) > len(s.expectedErrors) {
+			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+		}
+		for _, k := range s.expectedErrors {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+			}
+		}
+	}
+
+	// check the profile errors
+	err := settings.AnonymizationConfig{
+		Salt: "0123456789",
+		Profiles: map[string]settings.AnonymizationProfile{
+			"invalid name": {Fields: map[string]string{"email": "mask"}},
+			"empty":        {},
+			"invalid":      {Fields: map[string]string{"email": "unknown", ".name": "mask"}},
+			"valid":        {Fields: map[string]string{"email": "mask"}},
+		},
+	}.Validate()
+
+	raw, _ := json.Marshal(err)
+	expected := `{"profiles":{"empty":{"fields":"cannot be blank"},"invalid":{"fields":{".name":"Invalid field name.","email":"Invalid anonymization strategy."}},"invalid name":"Invalid profile name."}}`
+	if string(raw) != expected {
+		t.Fatalf("Expected \n%s, got \n%s", expected, raw)
+	}
+}
+
+func TestLocalizationConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         settings.LocalizationConfig
+		expectedErrors []string
+	}{
+		{
+			"zero value",
+			settings.LocalizationConfig{},
+			[]string{"defaultLocale"},
+		},
+		{
+			"invalid data",
+			settings.LocalizationConfig{
+				DefaultLocale: "en_US",
+				Locales:       []string{"en", "*"},
+				Fallbacks: map[string][]string{
+					"de-AT":   {"de-CH"},
+					"invalid": {"de"},
+				},
+			},
+			[]string{"defaultLocale", "locales", "fallbacks"},
+		},
+		{
+			"valid data",
+			settings.LocalizationConfig{
+				DefaultLocale: "en",
+				Locales:       []string{"en", "de", "de-AT"},
+				Fallbacks: map[string][]string{
+					"de-AT": {"de-CH"},
+				},
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		result := s.config.Validate()
+
+		// parse errors
+		errs, ok := result.(validation.Errors)
+		if !ok && result != nil {
+			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+			continue
+		}
+
+		// check errors
+		if len(errs) > len(s.expectedErrors) {
+			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+		}
+		for _, k := range s.expectedErrors {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+			}
+		}
+	}
+}
+
+func TestLocalizationConfigChain(t *testing.T) {
+	config := settings.LocalizationConfig{
+		DefaultLocale: "en",
+		Fallbacks: map[string][]string{
+			"de-AT": {"de-CH"},
+		},
+	}
+
+	result := strings.Join(config.Chain("de-AT"), ",")
+
+	expected := "de-AT,de-CH,de,en,*"
+	if result != expected {
+		t.Fatalf("Expected %q, got %q", expected, result)
+	}
+}
+
+func TestLocalizationConfigSupportedLocales(t *testing.T) {
+	scenarios := []struct {
+		config   settings.LocalizationConfig
+		expected string
+	}{
+		{settings.LocalizationConfig{DefaultLocale: "en"}, "en"},
+		{settings.LocalizationConfig{DefaultLocale: "en", Locales: []string{"de", "fr", "de"}}, "de,fr"},
+	}
+
+	for i, s := range scenarios {
+		result := strings.Join(s.config.SupportedLocales(), ",")
+		if result != s.expected {
+			t.Errorf("(%d) Expected %q, got %q", i, s.expected, result)
+		}
+	}
+}
+
+func TestAnonymizationProfileCollectionFields(t *testing.T) {
+	profile := settings.AnonymizationProfile{
+		Fields: map[string]string{
+			"email":       "mask",
+			"name":        "hash",
+			"users.name":  "fake",
+			"posts.title": "hash",
+		},
+	}
+
+	scenarios := []struct {
+		collection string
+		expected   string
+	}{
+		{"", `{"email":"mask","name":"hash"}`},
+		{"demo", `{"email":"mask","name":"hash"}`},
+		{"users", `{"email":"mask","name":"fake"}`},
+		{"posts", `{"email":"mask","name":"hash","title":"hash"}`},
+	}
+
+	for _, s := range scenarios {
+		raw, _ := json.Marshal(profile.CollectionFields(s.collection))
+		if string(raw) != s.expected {
+			t.Errorf("[%s] Expected %s, got %s", s.collection, s.expected, raw)
+		}
+	}
+}
+
+func TestEgressConfigPolicy(t *testing.T) {
+	config := settings.EgressConfig{
+		Default: settings.EgressPolicyConfig{
+			Proxy:     "http://proxy.example.com:3128",
+			Allowlist: []string{"example.com"},
+		},
+		Subsystems: map[string]settings.EgressPolicyConfig{
+			settings.EgressSubsystemS3: {AllowPrivate: true},
+		},
+	}
+
+	s3 := config.Policy(settings.EgressSubsystemS3)
+	if s3.Proxy != "" || len(s3.Allowlist) != 0 || !s3.AllowPrivate {
+		t.Fatalf("Expected the s3 subsystem policy, got %v", s3)
+	}
+
+	oauth2 := config.Policy(settings.EgressSubsystemOAuth2)
+	if oauth2.Proxy != config.Default.Proxy || len(oauth2.Allowlist) != 1 || oauth2.AllowPrivate {
+		t.Fatalf("Expected the default policy, got %v", oauth2)
+	}
+}
+
+func TestRealtimeReplayConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         settings.RealtimeReplayConfig
+		expectedErrors []string
+	}{
+		{
+			"zero value (disabled)",
+			settings.RealtimeReplayConfig{},
+			[]string{},
+		},
+		{
+			"zero value (enabled)",
+			settings.RealtimeReplayConfig{Enabled: true},
+			[]string{"maxEvents", "ttl"},
+		},
+		{
+			"invalid data",
+			settings.RealtimeReplayConfig{MaxEvents: 10001, TTL: -1},
+			[]string{"maxEvents", "ttl"},
+		},
+		{
+			"valid data",
+			settings.RealtimeReplayConfig{
+				Enabled:   true,
+				MaxEvents: 100,
+				TTL:       60,
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		result := s.config.Validate()
+
+		// parse errors
+		errs, ok := result.(validation.Errors)
+		if !ok && result != nil {
+			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+			continue
+		}
+
+		// check errors
+		if len(errs) > len(s.expectedErrors) {
+			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+		}
+		for _, k := range s.expectedErrors {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+			}
+		}
+	}
+}
+
+func TestSlowQueriesConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         settings.SlowQueriesConfig
+		expectedErrors []string
+	}{
+		{
+			"zero value",
+			settings.SlowQueriesConfig{},
+			[]string{},
+		},
+		{
+			"invalid data",
+			settings.SlowQueriesConfig{Enabled: true, Threshold: -1},
+			[]string{"threshold"},
+		},
+		{
+			"valid data",
+			settings.SlowQueriesConfig{
+				Enabled:   true,
+				Threshold: 100,
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		result := s.config.Validate()
+
+		// parse errors
+		errs, ok := result.(validation.Errors)
+		if !ok && result != nil {
+			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+			continue
+		}
+
+		// check errors
+		if len(errs) > len(s.expectedErrors) {
+			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+		}
+		for _, k := range s.expectedErrors {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+			}
+		}
+	}
+}
+
+func TestIdempotencyConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         settings.IdempotencyConfig
+		expectedErrors []string
+	}{
+		{
+			"zero value (disabled)",
+			settings.IdempotencyConfig{},
+			[]string{},
+		},
+		{
+			"zero value (enabled)",
+			settings.IdempotencyConfig{Enabled: true},
+			[]string{"ttl"},
+		},
+		{
+			"invalid data",
+			settings.IdempotencyConfig{TTL: 604801},
+			[]string{"ttl"},
+		},
+		{
+			"valid data",
+			settings.IdempotencyConfig{
+				Enabled: true,
+				TTL:     60,
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		result := s.config.Validate()
+
+		// parse errors
+		errs, ok := result.(validation.Errors)
+		if !ok && result != nil {
+			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+			continue
+		}
+
+		// check errors
+		if len(errs) > len(s.expectedErrors) {
+			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+		}
+		for _, k := range s.expectedErrors {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+			}
+		}
+	}
+}
+
+func TestMetaConfigValidate(t *testing.T) {
+	invalidTemplate := settings.EmailTemplate{
+		Subject:   "test",
+		ActionUrl: "test",
+		Body:      "test",
+	}
+
+	noPlaceholdersTemplate := settings.EmailTemplate{
+		Subject:   "test",
+		ActionUrl: "http://example.com",
+		Body:      "test",
+	}
+
+	withPlaceholdersTemplate := settings.EmailTemplate{
+		Subject:   "test",
+		ActionUrl: "http://example.com" + settings.EmailPlaceholderToken,
+		Body:      "test" + settings.EmailPlaceholderActionUrl,
+	}
+
+	scenarios := []struct {
+		config      settings.MetaConfig
+		expectError bool
+	}{
+		// zero values
+		{
+			settings.MetaConfig{},
+			true,
+		},
+		// invalid data
+		{
+			settings.MetaConfig{
+				AppName:                    strings.Repeat("a", 300),
+				AppUrl:                     "test",
+				SenderName:                 strings.Repeat("a", 300),
+				SenderAddress:              "invalid_email",
+				VerificationTemplate:       invalidTemplate,
+				ResetPasswordTemplate:      invalidTemplate,
+				ConfirmEmailChangeTemplate: invalidTemplate,
+			},
+			true,
+		},
+		// invalid data (missing required placeholders)
+		{
+			settings.MetaConfig{
+				AppName:                    "test",
+				AppUrl:                     "https://example.com",
+				SenderName:                 "test",
+				SenderAddress:              "test@example.com",
+				VerificationTemplate:       noPlaceholdersTemplate,
+				ResetPasswordTemplate:      noPlaceholdersTemplate,
+				ConfirmEmailChangeTemplate: noPlaceholdersTemplate,
+			},
+			true,
+		},
+		// valid data
+		{
+			settings.MetaConfig{
+				AppName:                    "test",
+				AppUrl:                     "https://example.com",
+				SenderName:                 "test",
+				SenderAddress:              "test@example.com",
+				VerificationTemplate:       withPlaceholdersTemplate,
+				ResetPasswordTemplate:      withPlaceholdersTemplate,
+				ConfirmEmailChangeTemplate: withPlaceholdersTemplate,
+			},
+			false,
+		},
+	}
+
+	for i, scenario := range scenarios {
+		result := scenario.config.Validate()
+
+		if result != nil && !scenario.expectError {
+			t.Errorf("(%d) Didn't expect error, got %v", i, result)
+		}
+
+		if result == nil && scenario.expectError {
+			t.Errorf("(%d) Expected error, got nil", i)
+		}
+	}
+}
+
+func TestBackupsConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         settings.BackupsConfig
+		expectedErrors []string
+	}{
+		{
+			"zero value",
+			settings.BackupsConfig{},
+			[]string{},
+		},
+		{
+			"invalid cron",
+			settings.BackupsConfig{
+				Cron:        "invalid",
+				CronMaxKeep: 0,
+			},
+			[]string{"cron", "cronMaxKeep"},
+		},
+		{
+			"invalid enabled S3",
+			settings.BackupsConfig{
+				S3: settings.S3Config{
+					Enabled: true,
+				},
+			},
+			[]string{"s3"},
+		},
+		{
+			"invalid encryption key",
+			settings.BackupsConfig{
+				EncryptionKey: "abc",
+			},
+			[]string{"encryptionKey"},
+		},
+		{
+			"unknown driver",
+			settings.BackupsConfig{
+				Driver: settings.FilesystemDriverConfig{Name: "test_missing"},
+			},
+			[]string{"driver"},
+		},
+		{
+			"valid data",
+			settings.BackupsConfig{
+				S3: settings.S3Config{
+					Enabled:   true,
+					Endpoint:  "example.com",
+					Bucket:    "test",
+					Region:    "test",
+					AccessKey: "test",
+					Secret:    "test",
+				},
+				Cron:          "*/10 * * * *",
+				CronMaxKeep:   1,
+				EncryptionKey: "abcdabcdabcdabcdabcdabcdabcdabcd",
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		result := s.config.Validate()
+
+		// parse errors
+		errs, ok := result.(validation.Errors)
+		if !ok && result != nil {
+			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+			continue
+		}
+
+		// check errors
+		if len(errs) > len(s.expectedErrors) {
+			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+		}
+		for _, k := range s.expectedErrors {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+			}
+		}
+	}
+}
+
+func TestFilesystemDriverConfigValidate(t *testing.T) {
+	filesystem.RegisterDriver("test_settings", func(ctx context.Context, options map[string]string) (*blob.Bucket, error) {
+		return nil, nil
+	})
+
+	scenarios := []struct {
+		name        string
+		config      settings.FilesystemDriverConfig
+		expectError bool
+	}{
+		{"zero value", settings.FilesystemDriverConfig{}, false},
+		{"unknown driver", settings.FilesystemDriverConfig{Name: "test_missing"}, true},
+		{"registered driver", settings.FilesystemDriverConfig{Name: "test_settings"}, false},
+	}
+
+	for _, s := range scenarios {
+		err := s.config.Validate()
+
+		if hasErr := err != nil; hasErr != s.expectError {
+			t.Errorf("[%s] Expected hasErr %v, got %v (%v)", s.name, s.expectError, hasErr, err)
+		}
+
+		if enabled := s.config.Name != ""; enabled != s.config.Enabled() {
+			t.Errorf("[%s] Expected Enabled() %v", s.name, enabled)
+		}
+	}
+}
+
+func TestSearchSyncConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         settings.SearchSyncConfig
+		expectedErrors []string
+	}{
+		{
+			"zero value (disabled)",
+			settings.SearchSyncConfig{},
+			[]string{},
+		},
+		{
+			"zero value (enabled)",
+			settings.SearchSyncConfig{Enabled: true},
+			[]string{"provider", "host"},
+		},
+		{
+			"invalid data",
+			settings.SearchSyncConfig{
+				Provider:    "invalid",
+				Host:        "invalid",
+				IndexPrefix: strings.Repeat("a", 101),
+			},
+			[]string{"provider", "host", "indexPrefix"},
+		},
+		{
+			"valid data",
+			settings.SearchSyncConfig{
+				Enabled:     true,
+				Provider:    "typesense",
+				Host:        "http://localhost:8108",
+				IndexPrefix: "pb_",
+				Collections: []string{"demo1"},
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		result := s.config.Validate()
+
+		// parse errors
+		errs, ok := result.(validation.Errors)
+		if !ok && result != nil {
+			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+			continue
+		}
+
+		// check errors
+		if len(errs) > len(s.expectedErrors) {
+			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+		}
+		for _, k := range s.expectedErrors {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+			}
+		}
+	}
+}
+
+func TestLogShippingConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         settings.LogShippingConfig
+		expectedErrors []string
+	}{
+		{
+			"zero value (disabled)",
+			settings.LogShippingConfig{},
+			[]string{},
+		},
+		{
+			"zero value (enabled)",
+			settings.LogShippingConfig{Enabled: true},
+			[]string{"sink", "url"},
+		},
+		{
+			"invalid data",
+			settings.LogShippingConfig{
+				Sink:          "invalid",
+				Url:           "invalid",
+				BatchSize:     1001,
+				FlushInterval: -1,
+				QueueSize:     100001,
+			},
+			[]string{"sink", "url", "batchSize", "flushInterval", "queueSize"},
+		},
+		{
+			"syslog with http url",
+			settings.LogShippingConfig{
+				Enabled: true,
+				Sink:    "syslog",
+				Url:     "http://localhost:514",
+			},
+			[]string{"url"},
+		},
+		{
+			"valid syslog data",
+			settings.LogShippingConfig{
+				Enabled: true,
+				Sink:    "syslog",
+				Url:     "tcp://localhost:514",
+			},
+			[]string{},
+		},
+		{
+			"valid loki data",
+			settings.LogShippingConfig{
+				Enabled:       true,
+				Sink:          "loki",
+				Url:           "http://localhost:3100/loki/api/v1/push",
+				AuthHeader:    "Bearer test",
+				Labels:        map[string]string{"app": "test"},
+				BatchSize:     1000,
+				FlushInterval: 300,
+				QueueSize:     100000,
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		result := s.config.Validate()
+
+		// parse errors
+		errs, ok := result.(validation.Errors)
+		if !ok && result != nil {
+			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+			continue
+		}
+
+		// check errors
+		if len(errs) > len(s.expectedErrors) {
+			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+		}
+		for _, k := range s.expectedErrors {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+			}
+		}
+	}
+}
+
+func TestSearchSyncConfigIsSynced(t *testing.T) {
+	scenarios := []struct {
+		config     settings.SearchSyncConfig
+		collection string
+		expected   bool
+	}{
+		{settings.SearchSyncConfig{Collections: []string{"demo1"}}, "demo1", false},
+		{settings.SearchSyncConfig{Enabled: true}, "demo1", false},
+		{settings.SearchSyncConfig{Enabled: true, Collections: []string{"demo1"}}, "demo2", false},
+		{settings.SearchSyncConfig{Enabled: true, Collections: []string{"demo1", "demo2"}}, "demo2", true},
+	}
+
+	for i, s := range scenarios {
+		result := s.config.IsSynced(s.collection)
+		if result != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, result)
+		}
+	}
+}
+
+func TestSqlConsoleConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         settings.SqlConsoleConfig
+		expectedErrors []string
+	}{
+		{
+			"zero value (disabled)",
+			settings.SqlConsoleConfig{},
+			[]string{},
+		},
+		{
+			"zero value (enabled)",
+			settings.SqlConsoleConfig{Enabled: true},
+			[]string{"superadmins", "maxRows", "timeout"},
+		},
+		{
+			"invalid data",
+			settings.SqlConsoleConfig{
+				MaxRows: 10001,
+				Timeout: 301,
+			},
+			[]string{"maxRows", "timeout"},
+		},
+		{
+			"valid data",
+			settings.SqlConsoleConfig{
+				Enabled:     true,
+				Superadmins: []string{"test"},
+				MaxRows:     10000,
+				Timeout:     300,
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		result := s.config.Validate()
+
+		// parse errors
+		errs, ok := result.(validation.Errors)
+		if !ok && result != nil {
+			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+			continue
+		}
+
+		// check errors
+		if len(errs) > len(s.expectedErrors) {
+			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+		}
+		for _, k := range s.expectedErrors {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+			}
+		}
+	}
+}
+
+func TestAnalyticsConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         settings.AnalyticsConfig
+		expectedErrors []string
+	}{
+		{
+			"zero value (disabled)",
+			settings.AnalyticsConfig{},
+			[]string{},
+		},
+		{
+			"zero value (enabled)",
+			settings.AnalyticsConfig{Enabled: true},
+			[]string{"refreshCron", "maxRows", "timeout"},
+		},
+		{
+			"enabled with quota and no window",
+			settings.AnalyticsConfig{
+				Enabled:      true,
+				RefreshCron:  "0 * * * *",
+				MaxRows:      10,
+				Timeout:      10,
+				QuotaQueries: 10,
+			},
+			[]string{"quotaWindow"},
+		},
+		{
+			"invalid data",
+			settings.AnalyticsConfig{
+				RefreshCron:  "invalid",
+				MaxRows:      10001,
+				Timeout:      301,
+				QuotaQueries: -1,
+				QuotaWindow:  2592001,
+			},
+			[]string{"refreshCron", "maxRows", "timeout", "quotaQueries", "quotaWindow"},
+		},
+		{
+			"valid data",
+			settings.AnalyticsConfig{
+				Enabled:      true,
+				RefreshCron:  "*/30 * * * *",
+				MaxRows:      10000,
+				Timeout:      300,
+				QuotaQueries: 100,
+				QuotaWindow:  3600,
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		result := s.config.Validate()
+
+		// parse errors
+		errs, ok := result.(validation.Errors)
+		if !ok && result != nil {
+			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+			continue
+		}
+
+		// check errors
+		if len(errs) > len(s.expectedErrors) {
+			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+		}
+		for _, k := range s.expectedErrors {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+			}
+		}
+	}
+}
+
+func TestSqlConsoleConfigIsSuperadmin(t *testing.T) {
+	scenarios := []struct {
+		config   settings.SqlConsoleConfig
+		adminId  string
+		expected bool
+	}{
+		{settings.SqlConsoleConfig{Superadmins: []string{"a"}}, "a", false},
+		{settings.SqlConsoleConfig{Enabled: true, Superadmins: []string{"a"}}, "", false},
+		{settings.SqlConsoleConfig{Enabled: true, Superadmins: []string{"a"}}, "b", false},
+		{settings.SqlConsoleConfig{Enabled: true, Superadmins: []string{"a", "b"}}, "b", true},
+	}
+
+	for i, s := range scenarios {
+		result := s.config.IsSuperadmin(s.adminId)
+		if result != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, result)
+		}
+	}
+}
+
+func TestEmailTemplateValidate(t *testing.T) {
+	scenarios := []struct {
+		emailTemplate  settings.EmailTemplate
+		expectedErrors []string
+	}{
+		// require values
+		{
+			settings.EmailTemplate{},
+			[]string{"subject", "actionUrl", "body"},
+		},
+		// missing placeholders
+		{
+			settings.EmailTemplate{
+				Subject:   "test",
+				ActionUrl: "test",
+				Body:      "test",
+			},
+			[]string{"actionUrl", "body"},
+		},
+		// valid data
+		{
+			settings.EmailTemplate{
+				Subject:   "test",
+				ActionUrl: "test" + settings.EmailPlaceholderToken,
+				Body:      "test" + settings.EmailPlaceholderActionUrl,
+			},
+			[]string{},
+		},
+	}
+
+	for i, s := range scenarios {
+		result := s.emailTemplate.Validate()
+
+		// parse errors
+		errs, ok := result.(validation.Errors)
+		if !ok && result != nil {
+			t.Errorf("(%d) Failed to parse errors %v", i, result)
+			continue
+		}
+
+		// check errors
+		if len(errs) > len(s.expectedErrors) {
+			t.Errorf("(%d) Expected error keys %v, got %v", i, s.expectedErrors, errs)
+		}
+		for _, k := range s.expectedErrors {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("(%d) Missing expected error key %q in %v", i, k, errs)
+			}
+		}
+	}
+}
+
+func TestEmailTemplateResolve(t *testing.T) {
+	allPlaceholders := settings.EmailPlaceholderActionUrl + settings.EmailPlaceholderToken + settings.EmailPlaceholderAppName + settings.EmailPlaceholderAppUrl
+
+	scenarios := []struct {
+		emailTemplate     settings.EmailTemplate
+		expectedSubject   string
+		expectedBody      string
+		expectedActionUrl string
+	}{
+		// no placeholders
+		{
+			emailTemplate: settings.EmailTemplate{
+				Subject:   "subject:",
+				Body:      "body:",
+				ActionUrl: "/actionUrl////",
+			},
+			expectedSubject:   "subject:",
+			expectedActionUrl: "/actionUrl/",
+			expectedBody:      "body:",
+		},
+		// with placeholders
+		{
+			emailTemplate: settings.EmailTemplate{
+				ActionUrl: "/actionUrl////" + allPlaceholders,
+				Subject:   "subject:" + allPlaceholders,
+				Body:      "body:" + allPlaceholders,
+			},
+			expectedActionUrl: fmt.Sprintf(
+				"/actionUrl/%%7BACTION_URL%%7D%s%s%s",
+				"token_test",
+				"name_test",
+				"url_test",
+			),
+			expectedSubject: fmt.Sprintf(
+				"subject:%s%s%s%s",
+				settings.EmailPlaceholderActionUrl,
+				settings.EmailPlaceholderToken,
+				"name_test",
+				"url_test",
+			),
+			expectedBody: fmt.Sprintf(
+				"body:%s%s%s%s",
+				fmt.Sprintf(
+					"/actionUrl/%%7BACTION_URL%%7D%s%s%s",
+					"token_test",
+					"name_test",
+					"url_test",
+				),
+				"token_test",
+				"name_test",
+				"url_test",
+			),
+		},
+	}
+
+	for i, s := range scenarios {
+		subject, body, actionUrl := s.emailTemplate.Resolve("name_test", "url_test", "token_test")
+
+		if s.expectedSubject != subject {
+			t.Errorf("(%d) Expected subject %q got %q", i, s.expectedSubject, subject)
+		}
+
+		if s.expectedBody != body {
+			t.Errorf("(%d) Expected body \n%v got \n%v", i, s.expectedBody, body)
+		}
+
+		if s.expectedActionUrl != actionUrl {
+			t.Errorf("(%d) Expected actionUrl \n%v got \n%v", i, s.expectedActionUrl, actionUrl)
+		}
+	}
+}
+
+func TestLogsConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		config      settings.LogsConfig
+		expectError bool
+	}{
+		// zero values
+		{
+			settings.LogsConfig{},
+			false,
+		},
+		// invalid data
+		{
+			settings.LogsConfig{MaxDays: -10},
+			true,
+		},
+		{
+			settings.LogsConfig{AuditMaxDays: -10},
+			true,
+		},
+		// valid data
+		{
+			settings.LogsConfig{MaxDays: 1, AuditMaxDays: 1},
+			false,
+		},
+	}
+
+	for i, scenario := range scenarios {
+		result := scenario.config.Validate()
+
+		if result != nil && !scenario.expectError {
+			t.Errorf("(%d) Didn't expect error, got %v", i, result)
+		}
+
+		if result == nil && scenario.expectError {
+			t.Errorf("(%d) Expected error, got nil", i)
+		}
+	}
+}
+
+func TestAuthProviderConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		config      settings.AuthProviderConfig
+		expectError bool
+	}{
+		// zero values (disabled)
+		{
+			settings.AuthProviderConfig{},
+			false,
+		},
+		// zero values (enabled)
+		{
+			settings.AuthProviderConfig{Enabled: true},
+			true,
+		},
+		// invalid data
+		{
+			settings.AuthProviderConfig{
+				Enabled:      true,
+				ClientId:     "",
+				ClientSecret: "",
+				AuthUrl:      "test",
+				TokenUrl:     "test",
+				UserApiUrl:   "test",
+			},
+			true,
+		},
+		// valid data (only the required)
+		{
+			settings.AuthProviderConfig{
+				Enabled:      true,
+				ClientId:     "test",
+				ClientSecret: "test",
+			},
+			false,
+		},
+		// valid data (fill all fields)
+		{
+			settings.AuthProviderConfig{
+				Enabled:      true,
+				ClientId:     "test",
+				ClientSecret: "test",
+				AuthUrl:      "https://example.com",
+				TokenUrl:     "https://example.com",
+				UserApiUrl:   "https://example.com",
+			},
+			false,
+		},
+	}
+
+	for i, scenario := range scenarios {
+		result := scenario.config.Validate()
+
+		if result != nil && !scenario.expectError {
+			t.Errorf("(%d) Didn't expect error, got %v", i, result)
+		}
+
+		if result == nil && scenario.expectError {
+			t.Errorf("(%d) Expected error, got nil", i)
+		}
+	}
+}
+
+func TestAuthProviderConfigSetupProvider(t *testing.T) {
+	provider := auth.NewGithubProvider()
+
+	// disabled config
+	c1 := settings.AuthProviderConfig{Enabled: false}
+	if err := c1.SetupProvider(provider); err == nil {
+		t.Errorf("Expected error, got nil")
+	}
+
+	c2 := settings.AuthProviderConfig{
+		Enabled:      true,
+		ClientId:     "test_ClientId",
+		ClientSecret: "test_ClientSecret",
+		AuthUrl:      "test_AuthUrl",
 		UserApiUrl:   "test_UserApiUrl",
 		TokenUrl:     "test_TokenUrl",
 	}
-	if err := c2.SetupProvider(provider); err != nil {
-		t.Error(err)
+	if err := c2.SetupProvider(provider); err != nil {
+		t.Error(err)
+	}
+
+	if provider.ClientId() != c2.ClientId {
+		t.Fatalf("Expected ClientId %s, got %s", c2.ClientId, provider.ClientId())
+	}
+
+	if provider.ClientSecret() != c2.ClientSecret {
+		t.Fatalf("Expected ClientSecret %s, got %s", c2.ClientSecret, provider.ClientSecret())
+	}
+
+	if provider.AuthUrl() != c2.AuthUrl {
+		t.Fatalf("Expected AuthUrl %s, got %s", c2.AuthUrl, provider.AuthUrl())
+	}
+
+	if provider.UserApiUrl() != c2.UserApiUrl {
+		t.Fatalf("Expected UserApiUrl %s, got %s", c2.UserApiUrl, provider.UserApiUrl())
+	}
+
+	if provider.TokenUrl() != c2.TokenUrl {
+		t.Fatalf("Expected TokenUrl %s, got %s", c2.TokenUrl, provider.TokenUrl())
+	}
+}
+
+func TestBillingConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         settings.BillingConfig
+		expectedErrors []string
+	}{
+		{
+			"zero value",
+			settings.BillingConfig{},
+			[]string{},
+		},
+		{
+			"stripe webhook secret without collection and customer field",
+			settings.BillingConfig{
+				StripeWebhookSecret: "whsec_test",
+			},
+			[]string{"stripeCollection", "stripeCustomerField"},
+		},
+		{
+			"invalid field names",
+			settings.BillingConfig{
+				StripeCustomerField:  "a b",
+				StripeStatusField:    "a.b",
+				StripePlanField:      "a-b",
+				StripePeriodEndField: "@a",
+			},
+			[]string{"stripeCustomerField", "stripeStatusField", "stripePlanField", "stripePeriodEndField"},
+		},
+		{
+			"valid data",
+			settings.BillingConfig{
+				Metering:             true,
+				StripeWebhookSecret:  "whsec_test",
+				StripeCollection:     "users",
+				StripeCustomerField:  "stripeCustomerId",
+				StripeStatusField:    "subscriptionStatus",
+				StripePlanField:      "",
+				StripePeriodEndField: "subscriptionPeriodEnd",
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		result := s.config.Validate()
+
+		// parse errors
+		errs, ok := result.(validation.Errors)
+		if !ok && result != nil {
+			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+			continue
+		}
+
+		// check errors
+		if len(errs) > len(s.expectedErrors) {
+			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+		}
+		for _, k := range s.expectedErrors {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+			}
+		}
+	}
+}
+
+func TestPublicFormsConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         settings.PublicFormsConfig
+		expectedErrors []string
+	}{
+		{
+			"zero value",
+			settings.PublicFormsConfig{},
+			[]string{},
+		},
+		{
+			"captcha form without captcha settings",
+			settings.PublicFormsConfig{
+				CaptchaVerifyUrl: "invalid",
+				Forms: map[string]settings.PublicFormConfig{
+					"contact": {Collection: "messages", Fields: []string{"email"}, Captcha: true},
+				},
+			},
+			[]string{"captchaVerifyUrl", "captchaSecret"},
+		},
+		{
+			"invalid forms",
+			settings.PublicFormsConfig{
+				Forms: map[string]settings.PublicFormConfig{
+					"a b":     {Collection: "messages", Fields: []string{"email"}},
+					"contact": {Fields: []string{"a.b"}, Honeypot: "a b", MaxSubmissions: 1, NotifyEmails: []string{"invalid"}, RedirectUrl: "invalid"},
+				},
+			},
+			[]string{"forms"},
+		},
+		{
+			"valid data",
+			settings.PublicFormsConfig{
+				CaptchaVerifyUrl: "https://hcaptcha.com/siteverify",
+				CaptchaSecret:    "0x0000000000000000000000000000000000000000",
+				Forms: map[string]settings.PublicFormConfig{
+					"contact-us": {
+						Collection:     "messages",
+						Fields:         []string{"email", "message"},
+						Honeypot:       "website",
+						Captcha:        true,
+						MaxSubmissions: 5,
+						Duration:       3600,
+						NotifyEmails:   []string{"sales@example.com"},
+						RedirectUrl:    "https://example.com/thanks",
+					},
+				},
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		result := s.config.Validate()
+
+		// parse errors
+		errs, ok := result.(validation.Errors)
+		if !ok && result != nil {
+			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+			continue
+		}
+
+		// check errors
+		if len(errs) > len(s.expectedErrors) {
+			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+		}
+		for _, k := range s.expectedErrors {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+			}
+		}
 	}
+}
 
-	if provider.ClientId() != c2.ClientId {
-		t.Fatalf("Expected ClientId %s, got %s", c2.ClientId, provider.ClientId())
+func TestPublicFormConfigValidate(t *testing.T) {
+	config := settings.PublicFormConfig{
+		Fields:         []string{"a.b", "website"},
+		Honeypot:       "website",
+		MaxSubmissions: 1,
+		NotifyEmails:   []string{"invalid"},
+		RedirectUrl:    "invalid",
 	}
 
-	if provider.ClientSecret() != c2.ClientSecret {
-		t.Fatalf("Expected ClientSecret %s, got %s", c2.ClientSecret, provider.ClientSecret())
+	errs, ok := config.Validate().(validation.Errors)
+	if !ok {
+		t.Fatalf("Expected validation errors, got %v", errs)
 	}
 
-	if provider.AuthUrl() != c2.AuthUrl {
-		t.Fatalf("Expected AuthUrl %s, got %s", c2.AuthUrl, provider.AuthUrl())
+	expectedErrors := []string{"collection", "fields", "honeypot", "duration", "notifyEmails", "redirectUrl"}
+
+	if len(errs) != len(expectedErrors) {
+		t.Fatalf("Expected error keys %v, got %v", expectedErrors, errs)
+	}
+	for _, k := range expectedErrors {
+		if _, ok := errs[k]; !ok {
+			t.Errorf("Missing expected error key %q in %v", k, errs)
+		}
 	}
+}
 
-	if provider.UserApiUrl() != c2.UserApiUrl {
-		t.Fatalf("Expected UserApiUrl %s, got %s", c2.UserApiUrl, provider.UserApiUrl())
+func TestCommentsConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         settings.CommentsConfig
+		expectedErrors []string
+	}{
+		{
+			"zero value",
+			settings.CommentsConfig{},
+			[]string{},
+		},
+		{
+			"invalid collections",
+			settings.CommentsConfig{
+				Collections: map[string]settings.CommentsCollectionConfig{
+					"a b":   {},
+					"posts": {MaxLength: 100001},
+				},
+			},
+			[]string{"collections"},
+		},
+		{
+			"valid data",
+			settings.CommentsConfig{
+				Collections: map[string]settings.CommentsCollectionConfig{
+					"posts": {Moderated: true, MaxLength: 1000},
+					"tasks": {},
+				},
+			},
+			[]string{},
+		},
 	}
 
-	if provider.TokenUrl() != c2.TokenUrl {
-		t.Fatalf("Expected TokenUrl %s, got %s", c2.TokenUrl, provider.TokenUrl())
+	for _, s := range scenarios {
+		result := s.config.Validate()
+
+		// parse errors
+		errs, ok := result.(validation.Errors)
+		if !ok && result != nil {
+			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+			continue
+		}
+
+		// check errors
+		if len(errs) > len(s.expectedErrors) {
+			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+		}
+		for _, k := range s.expectedErrors {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+			}
+		}
+	}
+}
+
+func TestCachingConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         settings.CachingConfig
+		expectedErrors []string
+	}{
+		{
+			"zero value",
+			settings.CachingConfig{},
+			[]string{},
+		},
+		{
+			"invalid collections",
+			settings.CachingConfig{
+				Collections: map[string]settings.CollectionCachingConfig{
+					"a b":   {MaxAge: 10},
+					"posts": {MaxAge: 0, StaleWhileRevalidate: -1},
+				},
+			},
+			[]string{"collections"},
+		},
+		{
+			"valid data",
+			settings.CachingConfig{
+				Collections: map[string]settings.CollectionCachingConfig{
+					"posts": {MaxAge: 60, StaleWhileRevalidate: 30, ServerSide: true},
+					"tags":  {MaxAge: 3600},
+				},
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		result := s.config.Validate()
+
+		// parse errors
+		errs, ok := result.(validation.Errors)
+		if !ok && result != nil {
+			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+			continue
+		}
+
+		// check errors
+		if len(errs) > len(s.expectedErrors) {
+			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+		}
+		for _, k := range s.expectedErrors {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+			}
+		}
+	}
+}
+
+func TestVersioningConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         settings.VersioningConfig
+		expectedErrors []string
+	}{
+		{
+			"zero value",
+			settings.VersioningConfig{},
+			[]string{},
+		},
+		{
+			"invalid collections",
+			settings.VersioningConfig{
+				Collections: map[string]settings.VersioningCollectionConfig{
+					"a b":   {},
+					"posts": {MaxRevisions: -1, MaxDays: 4000},
+				},
+			},
+			[]string{"collections"},
+		},
+		{
+			"valid data",
+			settings.VersioningConfig{
+				Collections: map[string]settings.VersioningCollectionConfig{
+					"posts": {MaxRevisions: 50, MaxDays: 30},
+					"tags":  {},
+				},
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		result := s.config.Validate()
+
+		// parse errors
+		errs, ok := result.(validation.Errors)
+		if !ok && result != nil {
+			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+			continue
+		}
+
+		// check errors
+		if len(errs) > len(s.expectedErrors) {
+			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+		}
+		for _, k := range s.expectedErrors {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+			}
+		}
+	}
+}
+
+func TestCollectionCachingConfigCacheControl(t *testing.T) {
+	scenarios := []struct {
+		config   settings.CollectionCachingConfig
+		private  bool
+		expected string
+	}{
+		{settings.CollectionCachingConfig{MaxAge: 60}, false, "public, max-age=60"},
+		{settings.CollectionCachingConfig{MaxAge: 60}, true, "private, max-age=60"},
+		{settings.CollectionCachingConfig{MaxAge: 60, StaleWhileRevalidate: 30}, false, "public, max-age=60, stale-while-revalidate=30"},
+	}
+
+	for i, s := range scenarios {
+		result := s.config.CacheControl(s.private)
+		if result != s.expected {
+			t.Errorf("(%d) Expected %q, got %q", i, s.expected, result)
+		}
+	}
+}
+
+func TestCommentsCollectionConfigMessageMaxLength(t *testing.T) {
+	scenarios := []struct {
+		config   settings.CommentsCollectionConfig
+		expected int
+	}{
+		{settings.CommentsCollectionConfig{}, 5000},
+		{settings.CommentsCollectionConfig{MaxLength: -1}, 5000},
+		{settings.CommentsCollectionConfig{MaxLength: 10}, 10},
+	}
+
+	for i, s := range scenarios {
+		if v := s.config.MessageMaxLength(); v != s.expected {
+			t.Errorf("[%d] Expected %d, got %d", i, s.expected, v)
+		}
+	}
+}
+
+func TestWebhooksConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         settings.WebhooksConfig
+		expectedErrors []string
+	}{
+		{
+			"zero value",
+			settings.WebhooksConfig{},
+			[]string{},
+		},
+		{
+			"invalid hooks",
+			settings.WebhooksConfig{
+				LogsMaxDays: 366,
+				Hooks: map[string]settings.WebhookConfig{
+					"a b": {},
+					"crm": {Enabled: true, Url: "invalid", Events: []string{"records.invalid"}, MaxRetries: 11},
+				},
+			},
+			[]string{"hooks", "logsMaxDays"},
+		},
+		{
+			"enabled hook without url and events",
+			settings.WebhooksConfig{
+				Hooks: map[string]settings.WebhookConfig{
+					"crm": {Enabled: true, MaxRetries: 1},
+				},
+			},
+			[]string{"hooks"},
+		},
+		{
+			"valid data",
+			settings.WebhooksConfig{
+				LogsMaxDays: 30,
+				Hooks: map[string]settings.WebhookConfig{
+					"crm": {
+						Enabled:     true,
+						Url:         "https://example.com/hooks",
+						Secret:      "test",
+						Events:      []string{"records.create", "admins.*", "*.delete"},
+						Collections: []string{"demo1"},
+						MaxRetries:  3,
+						RetryDelay:  10,
+					},
+					"disabled": {},
+				},
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		result := s.config.Validate()
+
+		// parse errors
+		errs, ok := result.(validation.Errors)
+		if !ok && result != nil {
+			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+			continue
+		}
+
+		// check errors
+		if len(errs) > len(s.expectedErrors) {
+			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+		}
+		for _, k := range s.expectedErrors {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+			}
+		}
+	}
+}
+
+func TestWebhookConfigIsSubscribed(t *testing.T) {
+	hook := settings.WebhookConfig{
+		Enabled:     true,
+		Events:      []string{"records.create", "admins.*", "*.delete"},
+		Collections: []string{"demo1"},
+	}
+
+	scenarios := []struct {
+		hook       settings.WebhookConfig
+		resource   string
+		action     string
+		collection string
+		expected   bool
+	}{
+		{settings.WebhookConfig{Events: []string{"*.*"}}, "records", "create", "demo1", false},
+		{hook, "records", "create", "demo1", true},
+		{hook, "records", "create", "demo2", false},
+		{hook, "records", "update", "demo1", false},
+		{hook, "records", "delete", "demo1", true},
+		{hook, "admins", "update", "", true},
+		{hook, "collections", "create", "", false},
+		{hook, "collections", "delete", "", true},
+	}
+
+	for i, s := range scenarios {
+		result := s.hook.IsSubscribed(s.resource, s.action, s.collection)
+		if result != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, result)
+		}
 	}
 }
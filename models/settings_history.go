@@ -0,0 +1,31 @@
+package models
+
+import (
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+var _ Model = (*SettingsHistory)(nil)
+
+// SettingsHistory defines a single persisted app settings change.
+type SettingsHistory struct {
+	BaseModel
+
+	// Version is the auto incremented settings change number.
+	Version int `db:"version" json:"version"`
+
+	// AdminId is the id of the admin that changed the settings
+	// (empty if the change wasn't made by an admin).
+	AdminId string `db:"adminId" json:"adminId"`
+
+	// Diff holds the redacted old and new values of the changed
+	// settings fields indexed by their json path (eg. "smtp.host").
+	Diff types.JsonMap `db:"diff" json:"diff"`
+
+	// Snapshot is the serialized (and optionally encrypted)
+	// full settings state after the change.
+	Snapshot string `db:"snapshot" json:"-"`
+}
+
+func (m *SettingsHistory) TableName() string {
+	return "_settingsHistory"
+}
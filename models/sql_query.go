@@ -0,0 +1,18 @@
+package models
+
+var _ Model = (*SqlQuery)(nil)
+
+// SqlQuery defines a saved (optionally parameterized) admin SQL console query.
+//
+// The query params are defined with the dbx placeholder syntax, eg. "{:name}".
+type SqlQuery struct {
+	BaseModel
+
+	Name        string `db:"name" json:"name"`
+	Description string `db:"description" json:"description"`
+	Query       string `db:"query" json:"query"`
+}
+
+func (m *SqlQuery) TableName() string {
+	return "_sqlQueries"
+}
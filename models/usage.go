@@ -0,0 +1,62 @@
+package models
+
+import "time"
+
+var _ Model = (*Usage)(nil)
+
+// List of the metered billable events.
+const (
+	// UsageMetricRecordsCreated is the number of the records created via the api.
+	UsageMetricRecordsCreated = "recordsCreated"
+
+	// UsageMetricStorageBytes is the total size of the files uploaded via the api.
+	UsageMetricStorageBytes = "storageBytes"
+
+	// UsageMetricActiveUsers is the number of the distinct
+	// auth records that have authenticated in the period (aka. MAU).
+	UsageMetricActiveUsers = "activeUsers"
+)
+
+// UsagePeriodFormat is the time layout of the monthly usage periods (eg. "2023-04").
+const UsagePeriodFormat = "2006-01"
+
+// Usage defines a single monthly billable events counter
+// of an auth record and/or organization.
+//
+// OrgId, CollectionId and RecordId are empty for the events that
+// couldn't be attributed (eg. guest or admin requests).
+type Usage struct {
+	BaseModel
+
+	Period       string `db:"period" json:"period"`
+	Metric       string `db:"metric" json:"metric"`
+	OrgId        string `db:"orgId" json:"orgId"`
+	CollectionId string `db:"collectionId" json:"collectionId"`
+	RecordId     string `db:"recordId" json:"recordId"`
+	Value        int64  `db:"value" json:"value"`
+}
+
+func (m *Usage) TableName() string {
+	return "_usage"
+}
+
+// UsagePeriod returns the monthly usage period of the provided time.
+func UsagePeriod(t time.Time) string {
+	return t.UTC().Format(UsagePeriodFormat)
+}
+
+// UsageMetrics returns the list of all metered billable events.
+func UsageMetrics() []string {
+	return []string{
+		UsageMetricRecordsCreated,
+		UsageMetricStorageBytes,
+		UsageMetricActiveUsers,
+	}
+}
+
+// UsageSummary defines the totals of the metered billable
+// events of a single period (indexed by their metric name).
+type UsageSummary struct {
+	Period  string           `json:"period"`
+	Metrics map[string]int64 `json:"metrics"`
+}
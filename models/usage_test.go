@@ -0,0 +1,32 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/models"
+)
+
+func TestUsageTableName(t *testing.T) {
+	m := models.Usage{}
+	if m.TableName() != "_usage" {
+		t.Fatalf("Unexpected table name, got %q", m.TableName())
+	}
+}
+
+func TestUsagePeriod(t *testing.T) {
+	scenarios := []struct {
+		time     time.Time
+		expected string
+	}{
+		{time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC), "2023-04"},
+		{time.Date(2023, 12, 31, 23, 59, 59, 0, time.UTC), "2023-12"},
+		{time.Date(2024, 1, 1, 1, 0, 0, 0, time.FixedZone("test", 2*3600)), "2023-12"},
+	}
+
+	for i, s := range scenarios {
+		if result := models.UsagePeriod(s.time); result != s.expected {
+			t.Errorf("(%d) Expected %q, got %q", i, s.expected, result)
+		}
+	}
+}
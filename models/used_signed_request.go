@@ -0,0 +1,19 @@
+package models
+
+import "github.com/pocketbase/pocketbase/tools/types"
+
+var _ Model = (*UsedSignedRequest)(nil)
+
+// UsedSignedRequest defines an already used signed request token
+// (the model id is the token "jti" claim).
+//
+// The entry is kept until the token expires to prevent its replay.
+type UsedSignedRequest struct {
+	BaseModel
+
+	Expires types.DateTime `db:"expires" json:"expires"`
+}
+
+func (m *UsedSignedRequest) TableName() string {
+	return "_usedSignedRequests"
+}
@@ -1,10 +1,14 @@
 package models
 
 import (
+	"encoding/json"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/pocketbase/pocketbase/tools/list"
 	"gorm.io/datatypes"
+	"gorm.io/gorm"
 )
 
 type ModelCU struct {
@@ -31,6 +35,42 @@ type Groups struct {
 	Groups datatypes.JSON `json:"groups" example:"admin,group1" swaggertype:"array,string"`
 }
 
+// GroupNames returns the unique group names
+// (both json array and comma separated list values are supported).
+func (g *Groups) GroupNames() []string {
+	groups := []string{}
+
+	if len(g.Groups) == 0 {
+		return groups
+	}
+
+	if json.Unmarshal(g.Groups, &groups) != nil {
+		var raw string
+		json.Unmarshal(g.Groups, &raw)
+		groups = strings.Split(raw, ",")
+	}
+
+	result := make([]string, 0, len(groups))
+	for _, group := range groups {
+		if group = strings.TrimSpace(group); group != "" && !list.ExistInSlice(group, result) {
+			result = append(result, group)
+		}
+	}
+
+	return result
+}
+
+// HasAnyGroup checks whether at least one of the provided group names is in the groups list.
+func (g *Groups) HasAnyGroup(names ...string) bool {
+	for _, group := range g.GroupNames() {
+		if list.ExistInSlice(group, names) {
+			return true
+		}
+	}
+
+	return false
+}
+
 type UserData struct {
 	Name  string `json:"name" gorm:"unique;uniqueIndex;not null" example:"userX"`
 	Email string `json:"email" example:"userx@worldline.com"`
@@ -49,4 +89,8 @@ type UserPure struct {
 type User struct {
 	UserPure
 	ModelCU
+
+	// DeletedAt marks the user as soft deleted
+	// (soft deleted users are excluded from the default queries).
+	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"index" swaggertype:"string" format:"date-time"`
 }
@@ -0,0 +1,54 @@
+package models_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+	"gorm.io/datatypes"
+)
+
+func TestGroupsGroupNames(t *testing.T) {
+	scenarios := []struct {
+		raw      string
+		expected string
+	}{
+		{``, ""},
+		{`null`, ""},
+		{`[]`, ""},
+		{`["admin","dev"]`, "admin,dev"},
+		{`[" admin ","","admin","dev"]`, "admin,dev"},
+		{`"admin, dev,,admin"`, "admin,dev"},
+	}
+
+	for _, s := range scenarios {
+		groups := models.Groups{Groups: datatypes.JSON(s.raw)}
+
+		result := strings.Join(groups.GroupNames(), ",")
+		if result != s.expected {
+			t.Errorf("(%s) Expected %q, got %q", s.raw, s.expected, result)
+		}
+	}
+}
+
+func TestGroupsHasAnyGroup(t *testing.T) {
+	scenarios := []struct {
+		raw      string
+		names    []string
+		expected bool
+	}{
+		{``, []string{"admin"}, false},
+		{`["admin","dev"]`, nil, false},
+		{`["admin","dev"]`, []string{"ops"}, false},
+		{`["admin","dev"]`, []string{"ops", "dev"}, true},
+		{`"admin,dev"`, []string{"admin"}, true},
+	}
+
+	for _, s := range scenarios {
+		groups := models.Groups{Groups: datatypes.JSON(s.raw)}
+
+		if result := groups.HasAnyGroup(s.names...); result != s.expected {
+			t.Errorf("(%s %v) Expected %v, got %v", s.raw, s.names, s.expected, result)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package models
+
+import "github.com/pocketbase/pocketbase/tools/types"
+
+var _ Model = (*WebhookDelivery)(nil)
+
+// list with the supported values for `WebhookDelivery.Status`
+const (
+	WebhookDeliveryStatusPending = "pending"
+	WebhookDeliveryStatusSuccess = "success"
+	WebhookDeliveryStatusFailed  = "failed"
+)
+
+// WebhookDelivery defines a single webhook event delivery log.
+type WebhookDelivery struct {
+	BaseModel
+
+	// Webhook is the name of the delivery webhook settings
+	// (see `settings.WebhooksConfig.Hooks`).
+	Webhook string `db:"webhook" json:"webhook"`
+
+	// Event is the delivered event in the format "resource.action",
+	// eg. "records.create".
+	Event string `db:"event" json:"event"`
+
+	// Url is the webhook endpoint url of the last attempt.
+	Url string `db:"url" json:"url"`
+
+	// Payload is the signed json request body.
+	Payload types.JsonRaw `db:"payload" json:"payload"`
+
+	Status   string `db:"status" json:"status"`
+	Attempts int    `db:"attempts" json:"attempts"`
+
+	// ResponseStatus is the HTTP status code of the last
+	// attempt response (0 if there wasn't a response).
+	ResponseStatus int `db:"responseStatus" json:"responseStatus"`
+
+	// Error is the error message of the last failed attempt.
+	Error string `db:"error" json:"error"`
+}
+
+func (m *WebhookDelivery) TableName() string {
+	return "_webhookDeliveries"
+}
@@ -0,0 +1,480 @@
+package jsvm
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dop251/goja"
+	"github.com/dop251/goja_nodejs/console"
+	"github.com/dop251/goja_nodejs/require"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/apis"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/cron"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+const (
+	// FunctionsCollectionId is the id of the system collection with the registered functions.
+	FunctionsCollectionId = "_pb_functions_"
+
+	// FunctionRunsCollectionId is the id of the system collection with the functions run history.
+	FunctionRunsCollectionId = "_pb_function_runs_"
+
+	// the supported function auth requirements
+	FunctionAuthGuest  = "guest"
+	FunctionAuthRecord = "record"
+	FunctionAuthAdmin  = "admin"
+
+	// the supported function run triggers
+	FunctionRunTriggerCron   = "cron"
+	FunctionRunTriggerManual = "manual"
+
+	// the supported function run statuses
+	FunctionRunStatusSuccess = "success"
+	FunctionRunStatusError   = "error"
+)
+
+// maxFunctionRetries is the max allowed number of retries of a failed function run.
+const maxFunctionRetries = 10
+
+// FunctionsOptions defines optional struct to customize the default functions plugin behavior.
+type FunctionsOptions struct {
+	// CollectionName specifies the name of the system functions collection.
+	//
+	// If not set it fallbacks to "functions".
+	CollectionName string
+
+	// RunsCollectionName specifies the name of the system functions run history collection.
+	//
+	// If not set it fallbacks to "function_runs".
+	RunsCollectionName string
+
+	// Timeout specifies the max execution time of a single function call.
+	//
+	// If not set it fallbacks to 10 seconds.
+	Timeout time.Duration
+}
+
+// functions is the serverless functions plugin definition.
+// Usually it is instantiated via RegisterFunctions or MustRegisterFunctions.
+type functions struct {
+	app     core.App
+	options *FunctionsOptions
+
+	registry *require.Registry
+	cron     *cron.Cron
+
+	mux      sync.Mutex
+	programs map[string]*cachedProgram
+}
+
+type cachedProgram struct {
+	updated string
+	program *goja.Program
+}
+
+// MustRegisterFunctions registers the functions plugin to
+// the provided app instance and panics if it fails.
+//
+// Internally it calls RegisterFunctions(app, options).
+func MustRegisterFunctions(app core.App, options *FunctionsOptions) {
+	if err := RegisterFunctions(app, options); err != nil {
+		panic(err)
+	}
+}
+
+// RegisterFunctions registers the plugin to the provided app instance.
+//
+// The plugin ensures that the functions system collection exists and
+// exposes each of its records at "/api/fn/{name}".
+//
+// The function code must declare a top-level `handle(c)` function, eg.:
+//
+//	function handle(c) {
+//		return c.json(200, { "message": "Hello " + c.queryParam("name") })
+//	}
+//
+// The app instance is available in the code as `$app`.
+//
+// Functions with a "cron" expression must also declare a top-level
+// `run()` function, which is invoked on schedule (or manually via
+// "POST /api/fn/{name}/runs") and whose executions are stored in the
+// runs history collection (see "GET /api/fn/{name}/runs"), eg.:
+//
+//	function run() {
+//		console.log("cleanup started")
+//		return { "deleted": 10 }
+//	}
+func RegisterFunctions(app core.App, options *FunctionsOptions) error {
+	p := &functions{
+		app:      app,
+		registry: new(require.Registry),
+		cron:     cron.New(),
+		programs: map[string]*cachedProgram{},
+	}
+
+	if options != nil {
+		p.options = options
+	} else {
+		p.options = &FunctionsOptions{}
+	}
+
+	if p.options.CollectionName == "" {
+		p.options.CollectionName = "functions"
+	}
+
+	if p.options.RunsCollectionName == "" {
+		p.options.RunsCollectionName = "function_runs"
+	}
+
+	if p.options.Timeout <= 0 {
+		p.options.Timeout = 10 * time.Second
+	}
+
+	app.OnBeforeServe().Add(func(e *core.ServeEvent) error {
+		if err := p.ensureCollections(); err != nil {
+			return err
+		}
+
+		e.Router.Any("/api/fn/:name", p.handler, apis.ActivityLogger(app))
+
+		runs := e.Router.Group("/api/fn/:name/runs", apis.ActivityLogger(app), apis.RequireAdminAuth())
+		runs.GET("", p.listRuns)
+		runs.POST("", p.manualRun)
+
+		return p.startCron()
+	})
+
+	app.OnTerminate().Add(func(e *core.TerminateEvent) error {
+		p.cron.Stop()
+		return nil
+	})
+
+	// validate the function code syntax before persisting
+	validateCode := func(e *core.ModelEvent) error {
+		record, ok := e.Model.(*models.Record)
+		if !ok || record.Collection().Id != FunctionsCollectionId {
+			return nil
+		}
+
+		if _, err := goja.Compile(record.GetString("name"), record.GetString("code"), false); err != nil {
+			return validation.Errors{
+				"code": validation.NewError("validation_invalid_function_code", err.Error()),
+			}
+		}
+
+		if expr := record.GetString("cron"); expr != "" {
+			if _, err := cron.NewSchedule(expr); err != nil {
+				return validation.Errors{
+					"cron": validation.NewError("validation_invalid_cron", err.Error()),
+				}
+			}
+		}
+
+		return nil
+	}
+	app.OnModelBeforeCreate().Add(validateCode)
+	app.OnModelBeforeUpdate().Add(validateCode)
+
+	// reload the changed function schedule
+	reloadJob := func(e *core.ModelEvent) error {
+		if record, ok := e.Model.(*models.Record); ok && record.Collection().Id == FunctionsCollectionId && p.cron.HasStarted() {
+			p.loadJob(record)
+		}
+		return nil
+	}
+	app.OnModelAfterCreate().Add(reloadJob)
+	app.OnModelAfterUpdate().Add(reloadJob)
+	app.OnModelAfterDelete().Add(func(e *core.ModelEvent) error {
+		if record, ok := e.Model.(*models.Record); ok && record.Collection().Id == FunctionsCollectionId {
+			p.cron.Remove(jobId(record))
+		}
+		return nil
+	})
+
+	return nil
+}
+
+// ensureCollections creates the functions and runs history system
+// collections (if missing).
+func (p *functions) ensureCollections() error {
+	if err := p.ensureFunctionsCollection(); err != nil {
+		return err
+	}
+
+	return p.ensureRunsCollection()
+}
+
+// ensureFunctionsCollection creates the functions system collection
+// (or adds the missing fields to an already existing one).
+func (p *functions) ensureFunctionsCollection() error {
+	fields := []*schema.SchemaField{
+		{
+			Id:       "functions_name",
+			Type:     schema.FieldTypeText,
+			Name:     "name",
+			Required: true,
+			Options:  &schema.TextOptions{Pattern: `^[\w\-]+$`},
+		},
+		{
+			Id:       "functions_code",
+			Type:     schema.FieldTypeText,
+			Name:     "code",
+			Required: true,
+			Options:  &schema.TextOptions{},
+		},
+		{
+			Id:       "functions_auth",
+			Type:     schema.FieldTypeSelect,
+			Name:     "auth",
+			Required: true,
+			Options: &schema.SelectOptions{
+				MaxSelect: 1,
+				Values:    []string{FunctionAuthGuest, FunctionAuthRecord, FunctionAuthAdmin},
+			},
+		},
+		{
+			Id:      "functions_cron",
+			Type:    schema.FieldTypeText,
+			Name:    "cron",
+			Options: &schema.TextOptions{},
+		},
+		{
+			Id:   "functions_retries",
+			Type: schema.FieldTypeNumber,
+			Name: "retries",
+			Options: &schema.NumberOptions{
+				Min: types.Pointer(0.0),
+				Max: types.Pointer(float64(maxFunctionRetries)),
+			},
+		},
+	}
+
+	if existing, _ := p.app.Dao().FindCollectionByNameOrId(FunctionsCollectionId); existing != nil {
+		return p.addMissingFields(existing, fields)
+	}
+
+	collection, err := p.newSystemCollection(FunctionsCollectionId, p.options.CollectionName, fields)
+	if err != nil {
+		return err
+	}
+	collection.Indexes = types.JsonArray[string]{
+		fmt.Sprintf("CREATE UNIQUE INDEX `idx_unique_%s_name` ON `%s` (`name`)", p.options.CollectionName, p.options.CollectionName),
+	}
+
+	return p.app.Dao().SaveCollection(collection)
+}
+
+// ensureRunsCollection creates the functions runs history system collection (if missing).
+func (p *functions) ensureRunsCollection() error {
+	fields := []*schema.SchemaField{
+		{
+			Id:       "function_runs_function",
+			Type:     schema.FieldTypeRelation,
+			Name:     "function",
+			Required: true,
+			Options: &schema.RelationOptions{
+				CollectionId:  FunctionsCollectionId,
+				CascadeDelete: true,
+				MaxSelect:     types.Pointer(1),
+			},
+		},
+		{
+			Id:   "function_runs_trigger",
+			Type: schema.FieldTypeSelect,
+			Name: "trigger",
+			Options: &schema.SelectOptions{
+				MaxSelect: 1,
+				Values:    []string{FunctionRunTriggerCron, FunctionRunTriggerManual},
+			},
+		},
+		{
+			Id:   "function_runs_status",
+			Type: schema.FieldTypeSelect,
+			Name: "status",
+			Options: &schema.SelectOptions{
+				MaxSelect: 1,
+				Values:    []string{FunctionRunStatusSuccess, FunctionRunStatusError},
+			},
+		},
+		{
+			Id:      "function_runs_attempts",
+			Type:    schema.FieldTypeNumber,
+			Name:    "attempts",
+			Options: &schema.NumberOptions{},
+		},
+		{
+			Id:      "function_runs_duration",
+			Type:    schema.FieldTypeNumber,
+			Name:    "duration",
+			Options: &schema.NumberOptions{},
+		},
+		{
+			Id:      "function_runs_logs",
+			Type:    schema.FieldTypeText,
+			Name:    "logs",
+			Options: &schema.TextOptions{},
+		},
+		{
+			Id:      "function_runs_result",
+			Type:    schema.FieldTypeJson,
+			Name:    "result",
+			Options: &schema.JsonOptions{},
+		},
+		{
+			Id:      "function_runs_error",
+			Type:    schema.FieldTypeText,
+			Name:    "error",
+			Options: &schema.TextOptions{},
+		},
+	}
+
+	if existing, _ := p.app.Dao().FindCollectionByNameOrId(FunctionRunsCollectionId); existing != nil {
+		return nil // already exists
+	}
+
+	collection, err := p.newSystemCollection(FunctionRunsCollectionId, p.options.RunsCollectionName, fields)
+	if err != nil {
+		return err
+	}
+
+	return p.app.Dao().SaveCollection(collection)
+}
+
+// newSystemCollection initializes a new system base collection model
+// and ensures that there is no other collection with the same name.
+func (p *functions) newSystemCollection(id string, name string, fields []*schema.SchemaField) (*models.Collection, error) {
+	if existing, _ := p.app.Dao().FindCollectionByNameOrId(name); existing != nil {
+		return nil, fmt.Errorf("collection with name %q already exists", name)
+	}
+
+	collection := &models.Collection{}
+	collection.MarkAsNew()
+	collection.Id = id
+	collection.Name = name
+	collection.Type = models.CollectionTypeBase
+	collection.System = true
+	collection.Schema = schema.NewSchema(fields...)
+
+	return collection, nil
+}
+
+// addMissingFields adds to the collection the fields that are not
+// defined yet (eg. when upgrading from an older plugin version).
+func (p *functions) addMissingFields(collection *models.Collection, fields []*schema.SchemaField) error {
+	var changed bool
+
+	for _, field := range fields {
+		if collection.Schema.GetFieldById(field.Id) == nil {
+			collection.Schema.AddField(field)
+			changed = true
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+
+	return p.app.Dao().SaveCollection(collection)
+}
+
+func (p *functions) handler(c echo.Context) error {
+	fn, err := p.findFunction(c)
+	if err != nil {
+		return err
+	}
+
+	admin, _ := c.Get(apis.ContextAdminKey).(*models.Admin)
+	authRecord, _ := c.Get(apis.ContextAuthRecordKey).(*models.Record)
+
+	// functions without explicit auth requirement (eg. created before
+	// the field was required) are accessible only by admins
+	switch fn.GetString("auth") {
+	case FunctionAuthGuest:
+	case FunctionAuthRecord:
+		if admin == nil && authRecord == nil {
+			return apis.NewUnauthorizedError("The request requires admin or record authorization token to be set.", nil)
+		}
+	default:
+		if admin == nil {
+			return apis.NewUnauthorizedError("The request requires admin authorization token to be set.", nil)
+		}
+	}
+
+	program, err := p.compile(fn)
+	if err != nil {
+		return apis.NewBadRequestError("Failed to compile the function code.", err)
+	}
+
+	vm := NewBaseVM()
+	p.registry.Enable(vm)
+	console.Enable(vm)
+	apisBind(vm)
+	vm.Set("$app", p.app)
+
+	timer := time.AfterFunc(p.options.Timeout, func() {
+		vm.Interrupt("function execution timeout")
+	})
+	defer timer.Stop()
+
+	if _, err := vm.RunProgram(program); err != nil {
+		return apis.NewBadRequestError("Failed to execute the function.", err)
+	}
+
+	handle, ok := goja.AssertFunction(vm.Get("handle"))
+	if !ok {
+		return apis.NewBadRequestError("Missing function handle(c) declaration.", nil)
+	}
+
+	result, err := handle(goja.Undefined(), vm.ToValue(c))
+	if err != nil {
+		var exception *goja.Exception
+		if errors.As(err, &exception) {
+			// eg. throw $apis.notFoundError(...)
+			if apiErr, ok := exception.Value().Export().(*apis.ApiError); ok {
+				return apiErr
+			}
+		}
+
+		return apis.NewBadRequestError("Failed to execute the function.", err)
+	}
+
+	if result != nil {
+		if resultErr, ok := result.Export().(error); ok {
+			return resultErr
+		}
+	}
+
+	return nil
+}
+
+// compile returns the compiled code of the provided function record
+// (reusing the previously compiled program if the record wasn't changed).
+func (p *functions) compile(fn *models.Record) (*goja.Program, error) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	updated := fn.GetUpdated().String()
+
+	if cached, ok := p.programs[fn.Id]; ok && cached.updated == updated {
+		return cached.program, nil
+	}
+
+	program, err := goja.Compile(fn.GetString("name"), fn.GetString("code"), false)
+	if err != nil {
+		return nil, err
+	}
+
+	p.programs[fn.Id] = &cachedProgram{
+		updated: updated,
+		program: program,
+	}
+
+	return program, nil
+}
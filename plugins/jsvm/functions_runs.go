@@ -0,0 +1,237 @@
+package jsvm
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dop251/goja"
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/apis"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+// jobId returns the cron job id of the provided function record.
+func jobId(fn *models.Record) string {
+	return "@function_" + fn.Id
+}
+
+// startCron (re)loads the scheduled functions and starts the cron ticker.
+func (p *functions) startCron() error {
+	p.cron.RemoveAll()
+
+	fns, err := p.app.Dao().FindRecordsByExpr(FunctionsCollectionId, dbx.Not(dbx.HashExp{"cron": ""}))
+	if err != nil {
+		return err
+	}
+
+	for _, fn := range fns {
+		p.loadJob(fn)
+	}
+
+	p.cron.Start()
+
+	return nil
+}
+
+// loadJob registers (or removes) the cron job of the provided function record.
+func (p *functions) loadJob(fn *models.Record) {
+	p.cron.Remove(jobId(fn))
+
+	expr := fn.GetString("cron")
+	if expr == "" {
+		return
+	}
+
+	fnId := fn.Id
+
+	err := p.cron.Add(jobId(fn), expr, func() {
+		// reload the function to ensure that its latest state is used
+		fn, err := p.app.Dao().FindRecordById(FunctionsCollectionId, fnId)
+		if err == nil {
+			_, err = p.execute(fn, FunctionRunTriggerCron)
+		}
+
+		if err != nil && p.app.IsDebug() {
+			log.Println(err)
+		}
+	})
+	if err != nil && p.app.IsDebug() {
+		log.Println(err)
+	}
+}
+
+// execute runs the `run()` function of the provided function record
+// (retrying up to its "retries" times on failure) and stores the
+// execution details in the runs history collection.
+func (p *functions) execute(fn *models.Record, trigger string) (*models.Record, error) {
+	runs, err := p.app.Dao().FindCollectionByNameOrId(FunctionRunsCollectionId)
+	if err != nil {
+		return nil, err
+	}
+
+	retries := fn.GetInt("retries")
+	if retries < 0 {
+		retries = 0
+	} else if retries > maxFunctionRetries {
+		retries = maxFunctionRetries
+	}
+
+	logs := []string{}
+	attempts := 0
+	start := time.Now()
+
+	var result any
+	var runErr error
+	for attempts <= retries {
+		attempts++
+
+		result, runErr = p.runOnce(fn, &logs)
+		if runErr == nil {
+			break
+		}
+
+		logs = append(logs, fmt.Sprintf("[error] attempt #%d failed: %v", attempts, runErr))
+	}
+
+	run := models.NewRecord(runs)
+	run.Set("function", fn.Id)
+	run.Set("trigger", trigger)
+	run.Set("attempts", attempts)
+	run.Set("duration", time.Since(start).Milliseconds())
+	run.Set("logs", strings.Join(logs, "\n"))
+	if runErr != nil {
+		run.Set("status", FunctionRunStatusError)
+		run.Set("error", runErr.Error())
+	} else {
+		run.Set("status", FunctionRunStatusSuccess)
+		run.Set("result", result)
+	}
+
+	if err := p.app.Dao().SaveRecord(run); err != nil {
+		return nil, err
+	}
+
+	return run, nil
+}
+
+// runOnce executes a single `run()` call of the provided function record
+// and returns its exported result.
+//
+// The function console output is appended to logs.
+func (p *functions) runOnce(fn *models.Record, logs *[]string) (any, error) {
+	program, err := p.compile(fn)
+	if err != nil {
+		return nil, err
+	}
+
+	vm := NewBaseVM()
+	p.registry.Enable(vm)
+	apisBind(vm)
+	vm.Set("$app", p.app)
+
+	// capture the console output
+	logger := vm.NewObject()
+	for _, level := range []string{"log", "debug", "info", "warn", "error"} {
+		level := level
+		logger.Set(level, func(call goja.FunctionCall) goja.Value {
+			args := make([]string, len(call.Arguments))
+			for i, arg := range call.Arguments {
+				args[i] = arg.String()
+			}
+			*logs = append(*logs, "["+level+"] "+strings.Join(args, " "))
+			return goja.Undefined()
+		})
+	}
+	vm.Set("console", logger)
+
+	timer := time.AfterFunc(p.options.Timeout, func() {
+		vm.Interrupt("function execution timeout")
+	})
+	defer timer.Stop()
+
+	if _, err := vm.RunProgram(program); err != nil {
+		return nil, err
+	}
+
+	run, ok := goja.AssertFunction(vm.Get("run"))
+	if !ok {
+		return nil, errors.New("missing function run() declaration")
+	}
+
+	result, err := run(goja.Undefined())
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil || goja.IsUndefined(result) || goja.IsNull(result) {
+		return nil, nil
+	}
+
+	return result.Export(), nil
+}
+
+func (p *functions) findFunction(c echo.Context) (*models.Record, error) {
+	fn, err := p.app.Dao().FindFirstRecordByData(FunctionsCollectionId, "name", c.PathParam("name"))
+	if err != nil || fn == nil {
+		return nil, apis.NewNotFoundError("", err)
+	}
+
+	return fn, nil
+}
+
+// listRuns returns a paginated list with the run history of a single function
+// (by default sorted by the most recent runs first).
+func (p *functions) listRuns(c echo.Context) error {
+	fn, err := p.findFunction(c)
+	if err != nil {
+		return err
+	}
+
+	runs, err := p.app.Dao().FindCollectionByNameOrId(FunctionRunsCollectionId)
+	if err != nil {
+		return apis.NewNotFoundError("Missing runs collection context.", err)
+	}
+
+	fieldsResolver := resolvers.NewRecordFieldResolver(p.app.Dao(), runs, apis.RequestData(c), true)
+
+	searchProvider := search.NewProvider(fieldsResolver).
+		Query(p.app.Dao().RecordQuery(runs).AndWhere(dbx.HashExp{runs.Name + ".function": fn.Id}))
+
+	if c.QueryParam(search.SortQueryParam) == "" {
+		searchProvider.AddSort(search.SortField{Name: "created", Direction: search.SortDesc})
+	}
+
+	records := []*models.Record{}
+
+	result, err := searchProvider.ParseAndExec(c.QueryParams().Encode(), &records)
+	if err != nil {
+		return apis.NewBadRequestError("Invalid filter parameters.", err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// manualRun executes the function and returns the created run record.
+//
+// Failed executions are not returned as error and instead are
+// reported in the run record "status" and "error" fields.
+func (p *functions) manualRun(c echo.Context) error {
+	fn, err := p.findFunction(c)
+	if err != nil {
+		return err
+	}
+
+	run, err := p.execute(fn, FunctionRunTriggerManual)
+	if err != nil {
+		return apis.NewBadRequestError("Failed to store the function run.", err)
+	}
+
+	return c.JSON(http.StatusOK, run)
+}
@@ -8,6 +8,12 @@
 //	jsvm.MustRegisterMigrations(app, &jsvm.MigrationsOptions{
 //		Dir: "custom_js_migrations_dir_path", // default to "pb_data/../pb_migrations"
 //	})
+//
+// 2. Serverless functions (admin managed JS handlers exposed at "/api/fn/{name}"):
+//
+//	jsvm.MustRegisterFunctions(app, &jsvm.FunctionsOptions{
+//		CollectionName: "custom_functions_collection", // default to "functions"
+//	})
 package jsvm
 
 import (
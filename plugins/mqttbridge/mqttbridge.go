@@ -0,0 +1,270 @@
+// Package mqttbridge implements an optional embedded MQTT broker that
+// publishes the record change events for IoT and other constrained devices.
+//
+// The events are published to the `{prefix}/{collection}/{id}/{action}` topics
+// (eg. "pb/posts/RECORD_ID/create") and could be consumed with any MQTT 3.1.1
+// client by subscribing to a topic filter like "pb/posts/+/+" or "pb/#".
+//
+// Clients can authenticate by sending an admin or auth record token
+// as CONNECT password. Similar to the realtime api, each event is
+// delivered only if the client satisfies the collection view API rule.
+//
+// Example:
+//
+//	mqttbridge.MustRegister(app, &mqttbridge.Options{
+//		Address: ":1883",
+//	})
+package mqttbridge
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net"
+	"strings"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tokens"
+	"github.com/pocketbase/pocketbase/tools/mqtt"
+	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/spf13/cast"
+)
+
+// List of the published record actions.
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+const (
+	clientAdminKey      = "admin"
+	clientAuthRecordKey = "authRecord"
+)
+
+// Options defines optional struct to customize the default plugin behavior.
+type Options struct {
+	// Address is the TCP address of the broker listener (default to ":1883").
+	Address string
+
+	// TopicPrefix is the first level of the published topics (default to "pb").
+	TopicPrefix string
+}
+
+// Message is the payload of a single published record event.
+type Message struct {
+	Action string         `json:"action"`
+	Record *models.Record `json:"record"`
+}
+
+type plugin struct {
+	app     core.App
+	options *Options
+	broker  *mqtt.Broker
+}
+
+// MustRegister registers the mqttbridge plugin to the provided app instance
+// and panic if it fails.
+func MustRegister(app core.App, options *Options) {
+	if err := Register(app, options); err != nil {
+		panic(err)
+	}
+}
+
+// Register registers the mqttbridge plugin to the provided app instance.
+//
+// The broker listener is started together with the app http server.
+func Register(app core.App, options *Options) error {
+	p := &plugin{
+		app:    app,
+		broker: mqtt.NewBroker(),
+	}
+
+	if options != nil {
+		p.options = options
+	} else {
+		p.options = &Options{}
+	}
+
+	if p.options.Address == "" {
+		p.options.Address = ":1883"
+	}
+
+	if p.options.TopicPrefix == "" {
+		p.options.TopicPrefix = "pb"
+	}
+
+	p.broker.Authenticate = p.authenticate
+
+	app.OnBeforeServe().Add(func(e *core.ServeEvent) error {
+		ln, err := net.Listen("tcp", p.options.Address)
+		if err != nil {
+			return err
+		}
+
+		go func() {
+			if err := p.broker.Serve(ln); err != nil && !errors.Is(err, net.ErrClosed) {
+				log.Println("mqttbridge:", err)
+			}
+		}()
+
+		return nil
+	})
+
+	app.OnTerminate().Add(func(e *core.TerminateEvent) error {
+		return p.broker.Close()
+	})
+
+	p.bindEvents()
+
+	return nil
+}
+
+func (p *plugin) bindEvents() {
+	app := p.app
+
+	app.OnModelAfterCreate().Add(func(e *core.ModelEvent) error {
+		p.publishModel(ActionCreate, e.Model)
+		return nil
+	})
+
+	app.OnModelAfterUpdate().Add(func(e *core.ModelEvent) error {
+		p.publishModel(ActionUpdate, e.Model)
+		return nil
+	})
+
+	// published before the actual delete so that the view rule
+	// could still be checked against the current record state
+	app.OnModelBeforeDelete().Add(func(e *core.ModelEvent) error {
+		p.publishModel(ActionDelete, e.Model)
+		return nil
+	})
+}
+
+// authenticate loads the admin or auth record associated with
+// the token sent as CONNECT password.
+//
+// Clients without password are connected as guests.
+func (p *plugin) authenticate(client *mqtt.Client, username string, password string) error {
+	if password == "" {
+		return nil
+	}
+
+	claims, _ := security.ParseUnverifiedJWT(password)
+
+	switch cast.ToString(claims["type"]) {
+	case tokens.TypeAdmin:
+		admin, err := tokens.FindAdminByAuthToken(p.app, password)
+		if err == nil && admin != nil {
+			client.Set(clientAdminKey, admin)
+			return nil
+		}
+	case tokens.TypeAuthRecord:
+		record, err := tokens.FindAuthRecordByAuthToken(p.app, password)
+		if err == nil && record != nil {
+			client.Set(clientAuthRecordKey, record)
+			return nil
+		}
+	}
+
+	return mqtt.ErrBadCredentials
+}
+
+// publishModel publishes the record change event of the provided model
+// (non record models are ignored).
+func (p *plugin) publishModel(action string, model models.Model) {
+	if p.broker.TotalClients() == 0 {
+		return // no subscribers
+	}
+
+	record := p.resolveRecord(model)
+	if record == nil {
+		return
+	}
+
+	if err := p.publish(action, record); err != nil && p.app.IsDebug() {
+		log.Println(err)
+	}
+}
+
+func (p *plugin) publish(action string, record *models.Record) error {
+	collection := record.Collection()
+	if collection == nil {
+		return errors.New("Record collection not set.")
+	}
+
+	// create a clean record copy without expand and unknown fields
+	// because we don't know if the clients have permissions to view them
+	payload, err := json.Marshal(&Message{
+		Action: action,
+		Record: record.CleanCopy(),
+	})
+	if err != nil {
+		return err
+	}
+
+	topic := strings.Join([]string{p.options.TopicPrefix, collection.Name, record.Id, action}, "/")
+
+	p.broker.Publish(topic, payload, func(c *mqtt.Client) bool {
+		return p.canAccessRecord(c, record, collection.ViewRule)
+	})
+
+	return nil
+}
+
+// resolveRecord converts *if possible* the provided model interface to a Record.
+// This is usually helpful if the provided model is a custom Record model struct.
+func (p *plugin) resolveRecord(model models.Model) (record *models.Record) {
+	record, _ = model.(*models.Record)
+
+	// check if it is custom Record model struct (ignore "private" tables)
+	if record == nil && !strings.HasPrefix(model.TableName(), "_") {
+		record, _ = p.app.Dao().FindRecordById(model.TableName(), model.GetId())
+	}
+
+	return record
+}
+
+// canAccessRecord checks if the mqtt client has access to the specified record model.
+func (p *plugin) canAccessRecord(client *mqtt.Client, record *models.Record, accessRule *string) bool {
+	admin, _ := client.Get(clientAdminKey).(*models.Admin)
+	if admin != nil {
+		// admins can access everything
+		return true
+	}
+
+	if accessRule == nil {
+		// only admins can access this record
+		return false
+	}
+
+	ruleFunc := func(q *dbx.SelectQuery) error {
+		if *accessRule == "" {
+			return nil // empty public rule
+		}
+
+		// mock request data
+		requestData := &models.RequestData{
+			Method: "GET",
+		}
+		requestData.AuthRecord, _ = client.Get(clientAuthRecordKey).(*models.Record)
+
+		resolver := resolvers.NewRecordFieldResolver(p.app.Dao(), record.Collection(), requestData, true)
+		expr, err := search.FilterData(*accessRule).BuildExpr(resolver)
+		if err != nil {
+			return err
+		}
+		resolver.UpdateQuery(q)
+		q.AndWhere(expr)
+
+		return nil
+	}
+
+	foundRecord, err := p.app.Dao().FindRecordById(record.Collection().Id, record.Id, ruleFunc)
+
+	return err == nil && foundRecord != nil
+}
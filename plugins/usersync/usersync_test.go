@@ -0,0 +1,336 @@
+package usersync
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/registry"
+	"github.com/pocketbase/pocketbase/tests"
+	"gorm.io/datatypes"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	_ "modernc.org/sqlite"
+)
+
+// setupTestRegistry registers an in-memory sqlite registry
+// (with the mysql dialector) and creates the GORM users table.
+func setupTestRegistry(t *testing.T) *gorm.DB {
+	t.Cleanup(func() {
+		registry.Reset()
+	})
+
+	registry.RegisterDriver(registry.DriverSqlite, func(dsn string) gorm.Dialector {
+		db, err := sql.Open("sqlite", ":memory:")
+		if err != nil {
+			t.Fatal(err)
+		}
+		db.SetMaxOpenConns(1)
+
+		// MariaDB version to store the json values as they are (instead of CAST(? AS JSON))
+		return mysql.New(mysql.Config{Conn: db, SkipInitializeWithVersion: true, ServerVersion: "MariaDB"})
+	})
+
+	reg, err := registry.Get("sqlite://usersync")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = reg.DB.Exec("CREATE TABLE `users` (" +
+		"`id` varchar(191) PRIMARY KEY, `name` varchar(191) NOT NULL UNIQUE, `email` text, `password` text NOT NULL," +
+		"`groups` JSON, `created_at` datetime NULL, `updated_at` datetime NULL, `deleted_at` datetime NULL)").Error
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return reg.DB
+}
+
+// createTestUser creates a GORM user paired with the provided auth record.
+func createTestUser(t *testing.T, db *gorm.DB, record *models.Record) *models.User {
+	user := &models.User{}
+	user.ID.ID = uuid.New()
+	user.Name = record.Username()
+	user.Email = record.Email()
+	user.Password = record.PasswordHash()
+	user.Groups.Groups = datatypes.JSON(`["a"]`)
+
+	if err := db.Create(user).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	return user
+}
+
+func registerTestPlugin(t *testing.T, app *tests.TestApp, mapping *Mapping) *[]*Conflict {
+	conflicts := []*Conflict{}
+
+	err := Register(app, &Options{
+		Registry: "sqlite://usersync",
+		Mapping:  mapping,
+		OnConflict: func(c *Conflict) {
+			conflicts = append(conflicts, c)
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &conflicts
+}
+
+func findTestUser(t *testing.T, db *gorm.DB, name string) *models.User {
+	user := &models.User{}
+	if err := db.Where("name = ?", name).First(user).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	return user
+}
+
+func TestRegisterMissingRegistry(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	if err := Register(app, nil); err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+}
+
+func TestRecordChangesPropagation(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	db := setupTestRegistry(t)
+
+	record, err := app.Dao().FindRecordById("users", "4q1xlclmfloku33")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	createTestUser(t, db, record)
+
+	conflicts := registerTestPlugin(t, app, nil)
+
+	record.SetEmail("new@example.com")
+	record.SetPassword("1234567890")
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	user := findTestUser(t, db, record.Username())
+
+	if user.Email != "new@example.com" {
+		t.Fatalf("Expected the user email to be updated, got %q", user.Email)
+	}
+
+	if user.Password != record.PasswordHash() {
+		t.Fatalf("Expected the user password hash %q, got %q", record.PasswordHash(), user.Password)
+	}
+
+	if string(user.Groups.Groups) != `["a"]` {
+		t.Fatalf("Expected the user groups to be unchanged (disabled groups sync), got %s", user.Groups.Groups)
+	}
+
+	if len(*conflicts) != 0 {
+		t.Fatalf("Expected no conflicts, got %v", *conflicts)
+	}
+}
+
+func TestRegistryChangesPropagation(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	db := setupTestRegistry(t)
+
+	// add a groups field to the users collection
+	collection, err := app.Dao().FindCollectionByNameOrId("users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	collection.Schema.AddField(&schema.SchemaField{Name: "groups", Type: schema.FieldTypeJson})
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	record, err := app.Dao().FindRecordById("users", "4q1xlclmfloku33")
+	if err != nil {
+		t.Fatal(err)
+	}
+	record.Set("groups", []string{"a"})
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	user := createTestUser(t, db, record)
+
+	mapping := DefaultMapping()
+	mapping.Groups = "groups"
+	conflicts := registerTestPlugin(t, app, mapping)
+
+	err = db.Model(&models.User{}).Where("id = ?", user.ID.ID).Updates(map[string]any{
+		"email":    "new@example.com",
+		"password": "$2a$10$test",
+		"groups":   datatypes.JSON(`["a","b"]`),
+	}).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	updated, err := app.Dao().FindRecordById("users", "4q1xlclmfloku33")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if updated.Email() != "new@example.com" {
+		t.Fatalf("Expected the record email to be updated, got %q", updated.Email())
+	}
+
+	if updated.PasswordHash() != "$2a$10$test" {
+		t.Fatalf("Expected the record password hash to be updated, got %q", updated.PasswordHash())
+	}
+
+	if updated.TokenKey() == record.TokenKey() {
+		t.Fatal("Expected the record token key to be refreshed")
+	}
+
+	if groups := updated.GetStringSlice("groups"); len(groups) != 2 || groups[1] != "b" {
+		t.Fatalf("Expected the record groups to be updated, got %v", groups)
+	}
+
+	if len(*conflicts) != 0 {
+		t.Fatalf("Expected no conflicts, got %v", *conflicts)
+	}
+}
+
+func TestRegistryCreatePropagation(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	db := setupTestRegistry(t)
+
+	conflicts := registerTestPlugin(t, app, nil)
+
+	record, err := app.Dao().FindRecordById("users", "oap640cot4yru2s")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// new user paired with an existing record
+	user := &models.User{}
+	user.ID.ID = uuid.New()
+	user.Name = record.Username()
+	user.Email = "new@example.com"
+	user.Password = record.PasswordHash()
+	if err := db.Create(user).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	// new user without auth record
+	missing := &models.User{}
+	missing.ID.ID = uuid.New()
+	missing.Name = "missing"
+	missing.Password = "test"
+	if err := db.Create(missing).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	updated, err := app.Dao().FindRecordById("users", "oap640cot4yru2s")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if updated.Email() != "new@example.com" {
+		t.Fatalf("Expected the record email to be updated, got %q", updated.Email())
+	}
+
+	if len(*conflicts) != 1 || (*conflicts)[0].Reason != ReasonMissing || (*conflicts)[0].Name != "missing" {
+		t.Fatalf("Expected a single missing conflict, got %v", *conflicts)
+	}
+}
+
+func TestConflicts(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	db := setupTestRegistry(t)
+
+	record, err := app.Dao().FindRecordById("users", "4q1xlclmfloku33")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user := createTestUser(t, db, record)
+
+	conflicts := registerTestPlugin(t, app, nil)
+
+	// diverged registry email
+	if err := db.Exec("UPDATE `users` SET `email` = ? WHERE `id` = ?", "diverged@example.com", user.ID.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	record.SetEmail("new@example.com")
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	if email := findTestUser(t, db, record.Username()).Email; email != "diverged@example.com" {
+		t.Fatalf("Expected the diverged user email to be unchanged, got %q", email)
+	}
+
+	if len(*conflicts) != 1 || (*conflicts)[0].Reason != ReasonDiverged || (*conflicts)[0].Field != FieldEmail || (*conflicts)[0].Source != SourceRecord {
+		t.Fatalf("Expected a single diverged email conflict, got %v", *conflicts)
+	}
+
+	// duplicated record email
+	*conflicts = nil
+
+	err = db.Model(&models.User{}).Where("id = ?", user.ID.ID).Update("email", "test2@example.com").Error
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(*conflicts) != 1 || (*conflicts)[0].Reason != ReasonDiverged {
+		t.Fatalf("Expected a single diverged conflict (the record email was changed), got %v", *conflicts)
+	}
+
+	*conflicts = nil
+
+	err = db.Model(&models.User{}).Where("id = ?", user.ID.ID).Update("email", "new@example.com").Error
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = db.Model(&models.User{}).Where("id = ?", user.ID.ID).Update("email", "test2@example.com").Error
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(*conflicts) != 1 || (*conflicts)[0].Reason != ReasonFailed || (*conflicts)[0].Source != SourceRegistry {
+		t.Fatalf("Expected a single failed conflict, got %v", *conflicts)
+	}
+}
+
+func TestNormalizeGroups(t *testing.T) {
+	scenarios := []struct {
+		groups   string
+		expected string
+	}{
+		{``, `[]`},
+		{`null`, `[]`},
+		{`[]`, `[]`},
+		{`["a", " b ", ""]`, `["a","b"]`},
+		{`"a, b"`, `["a","b"]`},
+	}
+
+	for i, s := range scenarios {
+		user := &models.User{}
+		user.Groups.Groups = datatypes.JSON(s.groups)
+
+		if result := userValues(user)[FieldGroups]; result != s.expected {
+			t.Errorf("(%d) Expected %s, got %s", i, s.expected, result)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+//go:build cgo
+
+package registry
+
+import (
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// sqliteDriverName is the database/sql driver used by the sqlite registries.
+const sqliteDriverName = "sqlite3"
@@ -63,6 +63,12 @@ func (r *runner) run() (*search.ResolverResult, error) {
 		return r.processCollectionField()
 	}
 
+	// check for record ACL field
+	// must be in the format "@acl.PERMISSION"
+	if r.activeProps[0] == "@acl" {
+		return r.processAclField()
+	}
+
 	if r.activeProps[0] == "@request" {
 		if r.resolver.requestData == nil {
 			return &search.ResolverResult{Identifier: "NULL"}, nil
@@ -168,7 +174,102 @@ func (r *runner) processCollectionField() (*search.ResolverResult, error) {
 	return r.processActiveProps()
 }
 
+// processAclField resolves the "@acl.PERMISSION" field to a boolean
+// expression checking whether the request auth record has at least the
+// specified permission level in the ACL of the base collection record.
+//
+// The auth record is matched directly (user share) or through the
+// records that it has relation to (group share).
+func (r *runner) processAclField() (*search.ResolverResult, error) {
+	permissions := models.RecordSharePermissionsAtLeast(r.activeProps[1])
+	if len(permissions) == 0 {
+		return nil, fmt.Errorf("invalid record ACL permission %q", r.activeProps[1])
+	}
+
+	if r.resolver.requestData == nil || r.resolver.requestData.AuthRecord == nil || r.resolver.requestData.AuthRecord.Collection() == nil {
+		return &search.ResolverResult{Identifier: "FALSE"}, nil
+	}
+
+	authRecord := r.resolver.requestData.AuthRecord
+
+	params := dbx.Params{}
+	addParam := func(value any) string {
+		placeholder := "acl" + security.PseudorandomString(6)
+		params[placeholder] = value
+		return "{:" + placeholder + "}"
+	}
+
+	permissionPlaceholders := make([]string, len(permissions))
+	for i, p := range permissions {
+		permissionPlaceholders[i] = addParam(p)
+	}
+
+	subjects := []string{fmt.Sprintf(
+		"([[__acl.subjectType]] = %s AND [[__acl.subjectCollectionId]] = %s AND [[__acl.subjectId]] = %s)",
+		addParam(models.RecordShareSubjectUser),
+		addParam(authRecord.Collection().Id),
+		addParam(authRecord.Id),
+	)}
+
+	// groups (aka. the auth record relations)
+	for _, field := range authRecord.Collection().Schema.Fields() {
+		if field.Type != schema.FieldTypeRelation {
+			continue
+		}
+
+		field.InitOptions()
+		options, _ := field.Options.(*schema.RelationOptions)
+		if options == nil {
+			continue
+		}
+
+		ids := authRecord.GetStringSlice(field.Name)
+		if len(ids) == 0 {
+			continue
+		}
+
+		idPlaceholders := make([]string, len(ids))
+		for i, id := range ids {
+			idPlaceholders[i] = addParam(id)
+		}
+
+		subjects = append(subjects, fmt.Sprintf(
+			"([[__acl.subjectType]] = %s AND [[__acl.subjectCollectionId]] = %s AND [[__acl.subjectId]] IN (%s))",
+			addParam(models.RecordShareSubjectGroup),
+			addParam(options.CollectionId),
+			strings.Join(idPlaceholders, ", "),
+		))
+	}
+
+	identifier := fmt.Sprintf(
+		"(EXISTS (SELECT 1 FROM {{%s}} {{__acl}} WHERE [[__acl.collectionId]] = %s AND [[__acl.recordId]] = [[%s.id]] AND [[__acl.permission]] IN (%s) AND (%s)))",
+		(&models.RecordShare{}).TableName(),
+		addParam(r.resolver.baseCollection.Id),
+		r.activeTableAlias,
+		strings.Join(permissionPlaceholders, ", "),
+		strings.Join(subjects, " OR "),
+	)
+
+	return &search.ResolverResult{
+		Identifier: identifier,
+		Params:     params,
+	}, nil
+}
+
 func (r *runner) processRequestAuthField() (*search.ResolverResult, error) {
+	// organization field
+	// ---
+	if r.fieldName == requestAuthOrgField || r.fieldName == requestAuthOrgRoleField {
+		authRecord := r.resolver.requestData.AuthRecord
+		if authRecord == nil || authRecord.Collection() == nil {
+			return &search.ResolverResult{Identifier: "NULL"}, nil
+		}
+
+		if authRecord.Collection().Schema.GetFieldByName(r.activeProps[2]) == nil {
+			return r.processRequestAuthOrgField()
+		}
+	}
+
 	// plain auth field
 	// ---
 	if list.ExistInSlice(r.fieldName, plainRequestAuthFields) {
@@ -217,6 +318,28 @@ func (r *runner) processRequestAuthField() (*search.ResolverResult, error) {
 	return r.processActiveProps()
 }
 
+// processRequestAuthOrgField resolves the "@request.auth.org" and
+// "@request.auth.orgRole" fields from the active organization
+// membership of the request auth record.
+func (r *runner) processRequestAuthOrgField() (*search.ResolverResult, error) {
+	member := r.resolver.loadAuthOrgMember()
+	if member == nil {
+		return &search.ResolverResult{Identifier: "NULL"}, nil
+	}
+
+	value := member.OrgId
+	if r.fieldName == requestAuthOrgRoleField {
+		value = member.Role
+	}
+
+	placeholder := "org" + security.PseudorandomString(5)
+
+	return &search.ResolverResult{
+		Identifier: "{:" + placeholder + "}",
+		Params:     dbx.Params{placeholder: value},
+	}, nil
+}
+
 func (r *runner) processRequestDataLengthModifier(dataField *schema.SchemaField) (*search.ResolverResult, error) {
 	dataItems := list.ToUniqueStringSlice(r.resolver.requestData.Data[dataField.Name])
 
@@ -456,8 +579,8 @@ func (r *runner) processActiveProps() (*search.ResolverResult, error) {
 			return nil, fmt.Errorf("unknown field %q", prop)
 		}
 
-		// check if it is a json field
-		if field.Type == schema.FieldTypeJson {
+		// check if it is a json field (geoPoint values are stored as json objects)
+		if field.Type == schema.FieldTypeJson || field.Type == schema.FieldTypeGeoPoint {
 			var jsonPath strings.Builder
 			jsonPath.WriteString("$")
 			for _, p := range r.activeProps[i+1:] {
@@ -36,6 +36,15 @@ var plainRequestAuthFields = []string{
 	"@request.auth." + schema.FieldNameUpdated,
 }
 
+// organization scoped auth filter fields, resolved from the active
+// organization membership of the request auth record (see [models.OrgRequestHeader]).
+//
+// Auth collection schema fields with the same names take precedence.
+const (
+	requestAuthOrgField     = "@request.auth.org"
+	requestAuthOrgRoleField = "@request.auth.orgRole"
+)
+
 // ensure that `search.FieldResolver` interface is implemented
 var _ search.FieldResolver = (*RecordFieldResolver)(nil)
 
@@ -62,6 +71,8 @@ type RecordFieldResolver struct {
 	joins             []*join // we cannot use a map because the insertion order is not preserved
 	requestData       *models.RequestData
 	staticRequestData map[string]any
+	authOrgMember     *models.OrgMember
+	authOrgLoaded     bool
 }
 
 // NewRecordFieldResolver creates and initializes a new `RecordFieldResolver`.
@@ -86,6 +97,7 @@ func NewRecordFieldResolver(
 			`^\@request\.query\.[\w\.\:]*\w+$`,
 			`^\@request\.headers\.\w+$`,
 			`^\@collection\.\w+\.[\w\.\:]*\w+$`,
+			`^\@acl\.(view|edit|owner)$`,
 		},
 	}
 
@@ -136,11 +148,14 @@ func (r *RecordFieldResolver) UpdateQuery(query *dbx.SelectQuery) error {
 //	@request.query.filter
 //	@request.headers.x_token
 //	@request.auth.someRelation.name
+//	@request.auth.org
+//	@request.auth.orgRole
 //	@request.data.someRelation.name
 //	@request.data.someField
 //	@request.data.someSelect:each
 //	@request.data.someField:isset
 //	@collection.product.name
+//	@acl.view
 func (r *RecordFieldResolver) Resolve(fieldName string) (*search.ResolverResult, error) {
 	return parseAndRun(fieldName, r)
 }
@@ -210,6 +225,32 @@ func (r *RecordFieldResolver) resolveStaticRequestField(path ...string) (*search
 	}, nil
 }
 
+// loadAuthOrgMember returns the active organization membership of the
+// request auth record (or nil if the auth record is not a member of any organization).
+//
+// The active organization is the one specified with the [models.OrgRequestHeader]
+// request header or, if the header is not set, the oldest auth record organization.
+func (r *RecordFieldResolver) loadAuthOrgMember() *models.OrgMember {
+	if r.authOrgLoaded {
+		return r.authOrgMember
+	}
+	r.authOrgLoaded = true
+
+	if r.requestData == nil || r.requestData.AuthRecord == nil || r.requestData.AuthRecord.Collection() == nil {
+		return nil
+	}
+
+	headerKey := strings.ToLower(strings.ReplaceAll(models.OrgRequestHeader, "-", "_"))
+
+	if orgId := cast.ToString(r.requestData.Headers[headerKey]); orgId != "" {
+		r.authOrgMember, _ = r.dao.FindOrgMemberByRecord(orgId, r.requestData.AuthRecord)
+	} else {
+		r.authOrgMember, _ = r.dao.FindFirstOrgMemberByRecord(r.requestData.AuthRecord)
+	}
+
+	return r.authOrgMember
+}
+
 func (r *RecordFieldResolver) loadCollection(collectionNameOrId string) (*models.Collection, error) {
 	// return already loaded
 	for _, collection := range r.loadedCollections {
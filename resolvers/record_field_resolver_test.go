@@ -3,14 +3,18 @@ package resolvers_test
 import (
 	"encoding/json"
 	"regexp"
+	"sort"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/pocketbase/dbx"
 	"github.com/pocketbase/pocketbase/models"
 	"github.com/pocketbase/pocketbase/resolvers"
 	"github.com/pocketbase/pocketbase/tests"
 	"github.com/pocketbase/pocketbase/tools/list"
 	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/pocketbase/pocketbase/tools/types"
 )
 
 func TestRecordFieldResolverUpdateQuery(t *testing.T) {
@@ -173,6 +177,13 @@ func TestRecordFieldResolverUpdateQuery(t *testing.T) {
 			false,
 			"SELECT DISTINCT `demo4`.* FROM `demo4` LEFT JOIN `users` `__auth_users` ON `__auth_users`.`id`={:TEST} LEFT JOIN `demo2` `__auth_users_rel` ON [[__auth_users_rel.id]] = [[__auth_users.rel]] WHERE ({:TEST} > 1 OR {:TEST} > 1 OR [[__auth_users_rel.title]] > 1 OR NULL > 1)",
 		},
+		{
+			"@acl fields",
+			"demo4",
+			"@acl.view > true || @acl.owner > true",
+			false,
+			"SELECT `demo4`.* FROM `demo4` WHERE ((EXISTS (SELECT 1 FROM {{_recordShares}} {{__acl}} WHERE [[__acl.collectionId]] = {:TEST} AND [[__acl.recordId]] = [[demo4.id]] AND [[__acl.permission]] IN ({:TEST}, {:TEST}, {:TEST}) AND (([[__acl.subjectType]] = {:TEST} AND [[__acl.subjectCollectionId]] = {:TEST} AND [[__acl.subjectId]] = {:TEST}) OR ([[__acl.subjectType]] = {:TEST} AND [[__acl.subjectCollectionId]] = {:TEST} AND [[__acl.subjectId]] IN ({:TEST}))))) > 1 OR (EXISTS (SELECT 1 FROM {{_recordShares}} {{__acl}} WHERE [[__acl.collectionId]] = {:TEST} AND [[__acl.recordId]] = [[demo4.id]] AND [[__acl.permission]] IN ({:TEST}) AND (([[__acl.subjectType]] = {:TEST} AND [[__acl.subjectCollectionId]] = {:TEST} AND [[__acl.subjectId]] = {:TEST}) OR ([[__acl.subjectType]] = {:TEST} AND [[__acl.subjectCollectionId]] = {:TEST} AND [[__acl.subjectId]] IN ({:TEST}))))) > 1)",
+		},
 		{
 			"hidden field with system filters (multi-match and ignore emailVisibility)",
 			"demo4",
@@ -535,3 +546,202 @@ func TestRecordFieldResolverResolveStaticRequestDataFields(t *testing.T) {
 		}
 	}
 }
+
+func TestRecordFieldResolverResolveAclFields(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, err := app.Dao().FindCollectionByNameOrId("demo1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// user 4q1xlclmfloku33 is related to demo2 llvuca81nly1qls
+	// and user bgs820n361vj1qd is related to demo2 0yxhwia2amd8gec
+	shares := []*models.RecordShare{
+		{CollectionId: collection.Id, RecordId: "84nmscqy84lsi1t", SubjectType: models.RecordShareSubjectUser, SubjectCollectionId: "_pb_users_auth_", SubjectId: "4q1xlclmfloku33", Permission: models.RecordSharePermissionOwner},
+		{CollectionId: collection.Id, RecordId: "al1h9ijdeojtsjy", SubjectType: models.RecordShareSubjectUser, SubjectCollectionId: "_pb_users_auth_", SubjectId: "4q1xlclmfloku33", Permission: models.RecordSharePermissionView},
+		{CollectionId: collection.Id, RecordId: "imy661ixudk5izi", SubjectType: models.RecordShareSubjectGroup, SubjectCollectionId: "sz5l5z67tg7gku0", SubjectId: "llvuca81nly1qls", Permission: models.RecordSharePermissionEdit},
+		// different collection
+		{CollectionId: "wzlqyes4orhoygb", RecordId: "imy661ixudk5izi", SubjectType: models.RecordShareSubjectUser, SubjectCollectionId: "_pb_users_auth_", SubjectId: "bgs820n361vj1qd", Permission: models.RecordSharePermissionOwner},
+	}
+	for _, share := range shares {
+		if err := app.Dao().SaveRecordShare(share); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	scenarios := []struct {
+		name        string
+		authId      string
+		rule        string
+		expectError bool
+		expectedIds []string
+	}{
+		{"invalid permission", "4q1xlclmfloku33", "@acl.unknown = true", true, nil},
+		{"missing permission", "4q1xlclmfloku33", "@acl = true", true, nil},
+		{"nested permission", "4q1xlclmfloku33", "@acl.view.test = true", true, nil},
+		{"guest", "", "@acl.view = true", false, []string{}},
+		{"view (user and group shares)", "4q1xlclmfloku33", "@acl.view = true", false, []string{"84nmscqy84lsi1t", "al1h9ijdeojtsjy", "imy661ixudk5izi"}},
+		{"edit", "4q1xlclmfloku33", "@acl.edit = true", false, []string{"84nmscqy84lsi1t", "imy661ixudk5izi"}},
+		{"owner", "4q1xlclmfloku33", "@acl.owner = true", false, []string{"84nmscqy84lsi1t"}},
+		{"negated", "4q1xlclmfloku33", "@acl.edit = false", false, []string{"al1h9ijdeojtsjy"}},
+		{"combined with other rules", "4q1xlclmfloku33", "@acl.view = true && text = 'test'", false, []string{"84nmscqy84lsi1t"}},
+		{"user without shares", "oap640cot4yru2s", "@acl.view = true", false, []string{}},
+		{"user with another collection shares", "bgs820n361vj1qd", "@acl.view = true", false, []string{}},
+	}
+
+	for _, s := range scenarios {
+		requestData := &models.RequestData{}
+
+		if s.authId != "" {
+			authRecord, err := app.Dao().FindRecordById("users", s.authId)
+			if err != nil {
+				t.Fatal(err)
+			}
+			requestData.AuthRecord = authRecord
+		}
+
+		r := resolvers.NewRecordFieldResolver(app.Dao(), collection, requestData, false)
+
+		expr, err := search.FilterData(s.rule).BuildExpr(r)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("[%s] Expected hasErr %v, got %v (%v)", s.name, s.expectError, hasErr, err)
+			continue
+		}
+
+		if hasErr {
+			continue
+		}
+
+		query := app.Dao().RecordQuery(collection)
+
+		if err := r.UpdateQuery(query); err != nil {
+			t.Fatalf("[%s] UpdateQuery failed with error %v", s.name, err)
+		}
+
+		rows := []dbx.NullStringMap{}
+		if err := query.AndWhere(expr).All(&rows); err != nil {
+			t.Fatalf("[%s] Failed to fetch records: %v", s.name, err)
+		}
+
+		ids := make([]string, len(rows))
+		for i, row := range rows {
+			ids[i] = row["id"].String
+		}
+		sort.Strings(ids)
+
+		expected := append([]string{}, s.expectedIds...)
+		sort.Strings(expected)
+
+		if strings.Join(ids, ",") != strings.Join(expected, ",") {
+			t.Errorf("[%s] Expected records %v, got %v", s.name, expected, ids)
+		}
+	}
+}
+
+func TestRecordFieldResolverResolveAuthOrgFields(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, err := app.Dao().FindCollectionByNameOrId("demo1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	users, err := app.Dao().FindRecordsByIds("users", []string{"4q1xlclmfloku33", "oap640cot4yru2s"})
+	if err != nil || len(users) != 2 {
+		t.Fatalf("Failed to load the test users: %v", err)
+	}
+
+	for _, id := range []string{"org_acme000001", "org_other00001"} {
+		org := &models.Org{Name: id, Slug: strings.ReplaceAll(id, "_", "-")}
+		org.MarkAsNew()
+		org.SetId(id)
+		if err := app.Dao().SaveOrg(org); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the oldest membership is the default active organization
+	members := []*models.OrgMember{
+		{OrgId: "org_acme000001", CollectionId: "_pb_users_auth_", RecordId: "4q1xlclmfloku33", Role: models.OrgRoleOwner},
+		{OrgId: "org_other00001", CollectionId: "_pb_users_auth_", RecordId: "4q1xlclmfloku33", Role: models.OrgRoleMember},
+	}
+	for i, member := range members {
+		member.Created, _ = types.ParseDateTime(time.Now().Add(time.Duration(i) * time.Minute))
+		if err := app.Dao().SaveOrgMember(member); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	scenarios := []struct {
+		name        string
+		authId      string
+		orgHeader   string
+		rule        string
+		expectError bool
+		expectedIds []string
+	}{
+		{"nested org field", "4q1xlclmfloku33", "", "@request.auth.org.name = 'test'", false, []string{}},
+		{"guest", "", "", "@request.auth.org != ''", false, []string{}},
+		{"user without organizations", "oap640cot4yru2s", "", "@request.auth.org != ''", false, []string{}},
+		{"default organization", "4q1xlclmfloku33", "", "id = '84nmscqy84lsi1t' && @request.auth.org = 'org_acme000001' && @request.auth.orgRole = 'owner'", false, []string{"84nmscqy84lsi1t"}},
+		{"header organization", "4q1xlclmfloku33", "org_other00001", "id = '84nmscqy84lsi1t' && @request.auth.org = 'org_other00001' && @request.auth.orgRole = 'member'", false, []string{"84nmscqy84lsi1t"}},
+		{"header organization without membership", "4q1xlclmfloku33", "org_missing001", "@request.auth.org != ''", false, []string{}},
+	}
+
+	for _, s := range scenarios {
+		requestData := &models.RequestData{
+			Headers: map[string]any{},
+		}
+
+		if s.orgHeader != "" {
+			requestData.Headers["x_org_id"] = s.orgHeader
+		}
+
+		if s.authId != "" {
+			authRecord, err := app.Dao().FindRecordById("users", s.authId)
+			if err != nil {
+				t.Fatal(err)
+			}
+			requestData.AuthRecord = authRecord
+		}
+
+		r := resolvers.NewRecordFieldResolver(app.Dao(), collection, requestData, false)
+
+		expr, err := search.FilterData(s.rule).BuildExpr(r)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("[%s] Expected hasErr %v, got %v (%v)", s.name, s.expectError, hasErr, err)
+			continue
+		}
+
+		if hasErr {
+			continue
+		}
+
+		query := app.Dao().RecordQuery(collection)
+
+		if err := r.UpdateQuery(query); err != nil {
+			t.Fatalf("[%s] UpdateQuery failed with error %v", s.name, err)
+		}
+
+		rows := []dbx.NullStringMap{}
+		if err := query.AndWhere(expr).All(&rows); err != nil {
+			t.Fatalf("[%s] Failed to fetch records: %v", s.name, err)
+		}
+
+		ids := make([]string, len(rows))
+		for i, row := range rows {
+			ids[i] = row["id"].String
+		}
+
+		if strings.Join(ids, ",") != strings.Join(s.expectedIds, ",") {
+			t.Errorf("[%s] Expected records %v, got %v", s.name, s.expectedIds, ids)
+		}
+	}
+}
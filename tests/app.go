@@ -443,6 +443,14 @@ func NewTestApp(optTestDataDir ...string) (*TestApp, error) {
 		return t.registerEventCall("OnAdminAfterConfirmPasswordResetRequest")
 	})
 
+	t.OnAdminBeforeImpersonateRequest().Add(func(e *core.AdminImpersonateEvent) error {
+		return t.registerEventCall("OnAdminBeforeImpersonateRequest")
+	})
+
+	t.OnAdminAfterImpersonateRequest().Add(func(e *core.AdminImpersonateEvent) error {
+		return t.registerEventCall("OnAdminAfterImpersonateRequest")
+	})
+
 	t.OnFileDownloadRequest().Add(func(e *core.FileDownloadEvent) error {
 		return t.registerEventCall("OnFileDownloadRequest")
 	})
@@ -455,6 +463,30 @@ func NewTestApp(optTestDataDir ...string) (*TestApp, error) {
 		return t.registerEventCall("OnFileAfterTokenRequest")
 	})
 
+	t.OnUserBeforeCreateRequest().Add(func(e *core.UserCreateEvent) error {
+		return t.registerEventCall("OnUserBeforeCreateRequest")
+	})
+
+	t.OnUserAfterCreateRequest().Add(func(e *core.UserCreateEvent) error {
+		return t.registerEventCall("OnUserAfterCreateRequest")
+	})
+
+	t.OnUserBeforeUpdateRequest().Add(func(e *core.UserUpdateEvent) error {
+		return t.registerEventCall("OnUserBeforeUpdateRequest")
+	})
+
+	t.OnUserAfterUpdateRequest().Add(func(e *core.UserUpdateEvent) error {
+		return t.registerEventCall("OnUserAfterUpdateRequest")
+	})
+
+	t.OnUserBeforeDeleteRequest().Add(func(e *core.UserDeleteEvent) error {
+		return t.registerEventCall("OnUserBeforeDeleteRequest")
+	})
+
+	t.OnUserAfterDeleteRequest().Add(func(e *core.UserDeleteEvent) error {
+		return t.registerEventCall("OnUserAfterDeleteRequest")
+	})
+
 	return t, nil
 }
 
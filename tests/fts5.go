@@ -0,0 +1,23 @@
+package tests
+
+import "testing"
+
+// SkipWithoutFTS5 skips the current test if the test app SQLite build
+// doesn't support the FTS5 full text search extension.
+//
+// If the build is expected to support FTS5 (the pure Go driver or the
+// cgo driver with the "sqlite_fts5" build tag) the test fails instead,
+// so that the full text search tests are never silently skipped, eg.:
+//
+//	go test -tags sqlite_fts5 ./...
+func SkipWithoutFTS5(t testing.TB, app *TestApp) {
+	if app.Dao().HasFTS5() {
+		return
+	}
+
+	if fts5Expected {
+		t.Fatal("FTS5 is expected to be supported by the current SQLite build")
+	}
+
+	t.Skip(`FTS5 is not supported by the current SQLite build (run the tests with "-tags sqlite_fts5")`)
+}
@@ -0,0 +1,6 @@
+//go:build cgo && !sqlite_fts5 && !fts5
+
+package tests
+
+// the cgo sqlite driver supports FTS5 only with the "sqlite_fts5" build tag
+const fts5Expected = false
@@ -0,0 +1,5 @@
+//go:build !cgo || sqlite_fts5 || fts5
+
+package tests
+
+const fts5Expected = true
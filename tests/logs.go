@@ -51,3 +51,101 @@ func MockRequestLogsData(app *TestApp) error {
 
 	return err
 }
+
+func MockAuditLogsData(app *TestApp) error {
+	_, err := app.LogsDB().NewQuery(`
+		delete from {{_auditLogs}};
+
+		insert into {{_auditLogs}} (
+			[[id]],
+			[[action]],
+			[[resource]],
+			[[resourceId]],
+			[[actor]],
+			[[actorId]],
+			[[userIp]],
+			[[url]],
+			[[changes]],
+			[[created]],
+			[[updated]]
+		)
+		values
+		(
+			"a1b2c3d4-0000-4000-8000-000000000001",
+			"update",
+			"settings",
+			"",
+			"admin",
+			"sywbhecnh46rhm0",
+			"127.0.0.1",
+			"/api/settings",
+			'{"meta.appName":{"old":"acme","new":"test"}}',
+			"2022-05-01 10:00:00.123Z",
+			"2022-05-01 10:00:00.123Z"
+		),
+		(
+			"a1b2c3d4-0000-4000-8000-000000000002",
+			"delete",
+			"collections",
+			"wsmn24bux7wo113",
+			"admin",
+			"9q2trqumvlyr3bd",
+			"127.0.0.1",
+			"/api/collections/wsmn24bux7wo113",
+			'{"name":{"old":"demo1","new":null}}',
+			"2022-05-02 10:00:00.123Z",
+			"2022-05-02 10:00:00.123Z"
+		);
+	`).Execute()
+
+	return err
+}
+
+func MockWebhookDeliveriesData(app *TestApp) error {
+	_, err := app.LogsDB().NewQuery(`
+		delete from {{_webhookDeliveries}};
+
+		insert into {{_webhookDeliveries}} (
+			[[id]],
+			[[webhook]],
+			[[event]],
+			[[url]],
+			[[payload]],
+			[[status]],
+			[[attempts]],
+			[[responseStatus]],
+			[[error]],
+			[[created]],
+			[[updated]]
+		)
+		values
+		(
+			"d1b2c3d4-0000-4000-8000-000000000001",
+			"crm",
+			"records.create",
+			"https://example.com/hooks",
+			'{"id":"d1b2c3d4-0000-4000-8000-000000000001","event":"records.create","collection":"demo2","data":{"id":"achvryl401bhse3"}}',
+			"success",
+			1,
+			200,
+			"",
+			"2022-05-01 10:00:00.123Z",
+			"2022-05-01 10:00:00.123Z"
+		),
+		(
+			"d1b2c3d4-0000-4000-8000-000000000002",
+			"crm",
+			"admins.delete",
+			"https://example.com/hooks",
+			'{"id":"d1b2c3d4-0000-4000-8000-000000000002","event":"admins.delete","data":{"id":"9q2trqumvlyr3bd"}}',
+			"failed",
+			3,
+			500,
+			"unexpected response status 500",
+			"2022-05-02 10:00:00.123Z",
+			"2022-05-02 10:00:00.123Z"
+		);
+	`).Execute()
+
+	return err
+}
@@ -8,10 +8,15 @@ import (
 )
 
 // NewAdminAuthToken generates and returns a new admin authentication token.
+//
+// The token is signed with the active asymmetric key if
+// [settings.TokenSigningConfig] is configured with RS256 or EdDSA.
 func NewAdminAuthToken(app core.App, admin *models.Admin) (string, error) {
-	return security.NewToken(
+	return newAuthToken(
+		app,
 		jwt.MapClaims{"id": admin.Id, "type": TypeAdmin},
-		(admin.TokenKey + app.Settings().AdminAuthToken.Secret),
+		admin.TokenKey,
+		app.Settings().AdminAuthToken.Secret,
 		app.Settings().AdminAuthToken.Duration,
 	)
 }
@@ -33,3 +38,30 @@ func NewAdminFileToken(app core.App, admin *models.Admin) (string, error) {
 		app.Settings().AdminFileToken.Duration,
 	)
 }
+
+// NewAdminSignedRequestToken generates and returns a new admin token
+// that authorizes only a single request with the provided method, path and body hash.
+//
+// The token is signed with the admin auth token secret but it has a
+// different type and can't be used as regular "Authorization" token.
+func NewAdminSignedRequestToken(
+	app core.App,
+	admin *models.Admin,
+	method string,
+	path string,
+	bodyHash string,
+	duration int64,
+) (string, error) {
+	return security.NewToken(
+		jwt.MapClaims{
+			"id":       admin.Id,
+			"jti":      security.RandomString(20),
+			"type":     TypeSignedRequest,
+			"method":   method,
+			"path":     path,
+			"bodyHash": bodyHash,
+		},
+		(admin.TokenKey + app.Settings().AdminAuthToken.Secret),
+		duration,
+	)
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/pocketbase/pocketbase/tests"
 	"github.com/pocketbase/pocketbase/tokens"
+	"github.com/pocketbase/pocketbase/tools/security"
 )
 
 func TestNewAdminAuthToken(t *testing.T) {
@@ -75,3 +76,31 @@ func TestNewAdminFileToken(t *testing.T) {
 		t.Fatalf("Expected admin %v, got %v", admin, tokenAdmin)
 	}
 }
+
+func TestNewAdminSignedRequestToken(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	admin, err := app.Dao().FindAdminByEmail("test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := tokens.NewAdminSignedRequestToken(app, admin, "GET", "/api/admins", "hash", 60)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tokenAdmin, _ := app.Dao().FindAdminByToken(
+		token,
+		app.Settings().AdminAuthToken.Secret,
+	)
+	if tokenAdmin == nil || tokenAdmin.Id != admin.Id {
+		t.Fatalf("Expected admin %v, got %v", admin, tokenAdmin)
+	}
+
+	claims, _ := security.ParseUnverifiedJWT(token)
+	if claims["type"] != tokens.TypeSignedRequest || claims["method"] != "GET" || claims["path"] != "/api/admins" || claims["bodyHash"] != "hash" {
+		t.Fatalf("Unexpected token claims %v", claims)
+	}
+}
@@ -10,22 +10,50 @@ import (
 )
 
 // NewRecordAuthToken generates and returns a new auth record authentication token.
+//
+// The token is signed with the active asymmetric key if
+// [settings.TokenSigningConfig] is configured with RS256 or EdDSA.
 func NewRecordAuthToken(app core.App, record *models.Record) (string, error) {
 	if !record.Collection().IsAuth() {
 		return "", errors.New("The record is not from an auth collection.")
 	}
 
-	return security.NewToken(
+	return newAuthToken(
+		app,
 		jwt.MapClaims{
 			"id":           record.Id,
 			"type":         TypeAuthRecord,
 			"collectionId": record.Collection().Id,
 		},
-		(record.TokenKey() + app.Settings().RecordAuthToken.Secret),
+		record.TokenKey(),
+		app.Settings().RecordAuthToken.Secret,
 		app.Settings().RecordAuthToken.Duration,
 	)
 }
 
+// NewRecordImpersonateToken generates and returns a new auth record
+// authentication token with custom duration (in seconds).
+//
+// The token is signed with the record auth token secret, aka. it is
+// accepted as regular "Authorization" token until its expiration.
+func NewRecordImpersonateToken(app core.App, record *models.Record, duration int64) (string, error) {
+	if !record.Collection().IsAuth() {
+		return "", errors.New("The record is not from an auth collection.")
+	}
+
+	return newAuthToken(
+		app,
+		jwt.MapClaims{
+			"id":           record.Id,
+			"type":         TypeAuthRecord,
+			"collectionId": record.Collection().Id,
+		},
+		record.TokenKey(),
+		app.Settings().RecordAuthToken.Secret,
+		duration,
+	)
+}
+
 // NewRecordVerifyToken generates and returns a new record verification token.
 func NewRecordVerifyToken(app core.App, record *models.Record) (string, error) {
 	if !record.Collection().IsAuth() {
@@ -93,3 +121,35 @@ func NewRecordFileToken(app core.App, record *models.Record) (string, error) {
 		app.Settings().RecordFileToken.Duration,
 	)
 }
+
+// NewRecordSignedRequestToken generates and returns a new auth record token
+// that authorizes only a single request with the provided method, path and body hash.
+//
+// The token is signed with the record auth token secret but it has a
+// different type and can't be used as regular "Authorization" token.
+func NewRecordSignedRequestToken(
+	app core.App,
+	record *models.Record,
+	method string,
+	path string,
+	bodyHash string,
+	duration int64,
+) (string, error) {
+	if !record.Collection().IsAuth() {
+		return "", errors.New("The record is not from an auth collection.")
+	}
+
+	return security.NewToken(
+		jwt.MapClaims{
+			"id":           record.Id,
+			"jti":          security.RandomString(20),
+			"type":         TypeSignedRequest,
+			"collectionId": record.Collection().Id,
+			"method":       method,
+			"path":         path,
+			"bodyHash":     bodyHash,
+		},
+		(record.TokenKey() + app.Settings().RecordAuthToken.Secret),
+		duration,
+	)
+}
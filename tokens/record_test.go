@@ -2,9 +2,12 @@ package tokens_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/pocketbase/pocketbase/tests"
 	"github.com/pocketbase/pocketbase/tokens"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/spf13/cast"
 )
 
 func TestNewRecordAuthToken(t *testing.T) {
@@ -30,6 +33,34 @@ func TestNewRecordAuthToken(t *testing.T) {
 	}
 }
 
+func TestNewRecordImpersonateToken(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	user, err := app.Dao().FindAuthRecordByEmail("users", "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := tokens.NewRecordImpersonateToken(app, user, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tokenRecord, _ := app.Dao().FindAuthRecordByToken(
+		token,
+		app.Settings().RecordAuthToken.Secret,
+	)
+	if tokenRecord == nil || tokenRecord.Id != user.Id {
+		t.Fatalf("Expected auth record %v, got %v", user, tokenRecord)
+	}
+
+	claims, _ := security.ParseUnverifiedJWT(token)
+	if exp := cast.ToInt64(claims["exp"]); exp > time.Now().Add(100*time.Second).Unix() {
+		t.Fatalf("Expected the token to expire in max 100 seconds, got exp %d", exp)
+	}
+}
+
 func TestNewRecordVerifyToken(t *testing.T) {
 	app, _ := tests.NewTestApp()
 	defer app.Cleanup()
@@ -121,3 +152,40 @@ func TestNewRecordFileToken(t *testing.T) {
 		t.Fatalf("Expected auth record %v, got %v", user, tokenRecord)
 	}
 }
+
+func TestNewRecordSignedRequestToken(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	user, err := app.Dao().FindAuthRecordByEmail("users", "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := tokens.NewRecordSignedRequestToken(app, user, "POST", "/api/test?a=1", "hash", 60)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tokenRecord, _ := app.Dao().FindAuthRecordByToken(
+		token,
+		app.Settings().RecordAuthToken.Secret,
+	)
+	if tokenRecord == nil || tokenRecord.Id != user.Id {
+		t.Fatalf("Expected auth record %v, got %v", user, tokenRecord)
+	}
+
+	claims, _ := security.ParseUnverifiedJWT(token)
+	if claims["type"] != tokens.TypeSignedRequest || claims["method"] != "POST" || claims["path"] != "/api/test?a=1" || claims["bodyHash"] != "hash" {
+		t.Fatalf("Unexpected token claims %v", claims)
+	}
+
+	// non-auth record
+	demo, err := app.Dao().FindRecordById("demo1", "84nmscqy84lsi1t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tokens.NewRecordSignedRequestToken(app, demo, "GET", "/api/test", "hash", 60); err == nil {
+		t.Fatal("Expected non-auth record error, got nil")
+	}
+}
@@ -0,0 +1,184 @@
+package tokens
+
+import (
+	"crypto/subtle"
+	"errors"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+// tokenKeyHashClaim is the asymmetric auth tokens claim that binds the token
+// to the admin/record TokenKey, allowing its invalidation on password change.
+const tokenKeyHashClaim = "tokenKeyHash"
+
+// newAuthToken generates a new admin or auth record authentication token.
+//
+// The token is signed with the active asymmetric token signing key (if configured),
+// otherwise with the HMAC tokenKey + secret.
+func newAuthToken(app core.App, payload jwt.MapClaims, tokenKey string, secret string, duration int64) (string, error) {
+	config := app.Settings().TokenSigning
+	if !config.IsAsymmetric() {
+		return security.NewToken(payload, tokenKey+secret, duration)
+	}
+
+	key := config.FindKey(config.KeyId)
+	if key == nil {
+		return "", errors.New("Missing active token signing key.")
+	}
+
+	signer, err := security.ParseSigningKey(key.Algorithm, key.PrivateKey)
+	if err != nil {
+		return "", err
+	}
+
+	payload[tokenKeyHashClaim] = security.HS256(tokenKey, secret)
+
+	return security.NewSignedToken(payload, key.Algorithm, key.Id, signer, duration)
+}
+
+// FindAdminByAuthToken verifies the provided admin authentication token
+// (HS256 or signed with one of the token signing keys) and returns its admin.
+func FindAdminByAuthToken(app core.App, token string) (*models.Admin, error) {
+	secret := app.Settings().AdminAuthToken.Secret
+
+	key, err := findTokenSigningKey(app, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if key == nil {
+		return app.Dao().FindAdminByToken(token, secret)
+	}
+
+	claims, err := parseSignedToken(token, key)
+	if err != nil {
+		return nil, err
+	}
+
+	id, _ := claims["id"].(string)
+	if id == "" || claims["type"] != TypeAdmin {
+		return nil, errors.New("Missing or invalid token claims.")
+	}
+
+	admin, err := app.Dao().FindAdminById(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkTokenKeyHash(claims, admin.TokenKey, secret); err != nil {
+		return nil, err
+	}
+
+	return admin, nil
+}
+
+// FindAuthRecordByAuthToken verifies the provided auth record authentication token
+// (HS256 or signed with one of the token signing keys) and returns its record.
+func FindAuthRecordByAuthToken(app core.App, token string) (*models.Record, error) {
+	secret := app.Settings().RecordAuthToken.Secret
+
+	key, err := findTokenSigningKey(app, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if key == nil {
+		return app.Dao().FindAuthRecordByToken(token, secret)
+	}
+
+	claims, err := parseSignedToken(token, key)
+	if err != nil {
+		return nil, err
+	}
+
+	id, _ := claims["id"].(string)
+	collectionId, _ := claims["collectionId"].(string)
+	if id == "" || collectionId == "" || claims["type"] != TypeAuthRecord {
+		return nil, errors.New("Missing or invalid token claims.")
+	}
+
+	record, err := app.Dao().FindRecordById(collectionId, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !record.Collection().IsAuth() {
+		return nil, errors.New("The token is not associated to an auth collection record.")
+	}
+
+	if err := checkTokenKeyHash(claims, record.TokenKey(), secret); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// JWKS returns the public keys of all token signing keys
+// (aka. the "keys" of the JSON Web Key Set document).
+func JWKS(app core.App) ([]*security.JWK, error) {
+	keys := app.Settings().TokenSigning.Keys
+
+	result := make([]*security.JWK, 0, len(keys))
+
+	for _, key := range keys {
+		signer, err := security.ParseSigningKey(key.Algorithm, key.PrivateKey)
+		if err != nil {
+			return nil, err
+		}
+
+		jwk, err := security.NewJWK(key.Id, key.Algorithm, signer.Public())
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, jwk)
+	}
+
+	return result, nil
+}
+
+// findTokenSigningKey returns the token signing key matching the token header
+// or nil if the token is a regular HS256 token.
+func findTokenSigningKey(app core.App, token string) (*settings.TokenSigningKey, error) {
+	header, err := security.ParseUnverifiedJWTHeader(token)
+	if err != nil {
+		return nil, err
+	}
+
+	alg, _ := header["alg"].(string)
+	if alg == security.AlgorithmHS256 {
+		return nil, nil
+	}
+
+	kid, _ := header["kid"].(string)
+
+	key := app.Settings().TokenSigning.FindKey(kid)
+	if key == nil || key.Algorithm != alg {
+		return nil, errors.New("Unknown token signing key.")
+	}
+
+	return key, nil
+}
+
+func parseSignedToken(token string, key *settings.TokenSigningKey) (jwt.MapClaims, error) {
+	signer, err := security.ParseSigningKey(key.Algorithm, key.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return security.ParseSignedJWT(token, key.Algorithm, signer.Public())
+}
+
+func checkTokenKeyHash(claims jwt.MapClaims, tokenKey string, secret string) error {
+	hash, _ := claims[tokenKeyHashClaim].(string)
+
+	if subtle.ConstantTimeCompare([]byte(hash), []byte(security.HS256(tokenKey, secret))) != 1 {
+		return errors.New("The token is no longer valid.")
+	}
+
+	return nil
+}
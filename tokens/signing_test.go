@@ -0,0 +1,175 @@
+package tokens_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tokens"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+func setTestTokenSigningKey(t *testing.T, app *tests.TestApp, algorithm string) {
+	privateKey, err := security.GenerateSigningKey(algorithm)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app.Settings().TokenSigning = settings.TokenSigningConfig{
+		Algorithm: algorithm,
+		KeyId:     "test_" + algorithm,
+		Keys: []settings.TokenSigningKey{
+			{Id: "test_" + algorithm, Algorithm: algorithm, PrivateKey: privateKey},
+		},
+	}
+}
+
+func TestFindAdminByAuthToken(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	admin, err := app.Dao().FindAdminByEmail("test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hsToken, err := tokens.NewAdminAuthToken(app, admin)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, algorithm := range []string{security.AlgorithmRS256, security.AlgorithmEdDSA} {
+		setTestTokenSigningKey(t, app, algorithm)
+
+		token, err := tokens.NewAdminAuthToken(app, admin)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		header, _ := security.ParseUnverifiedJWTHeader(token)
+		if header["alg"] != algorithm || header["kid"] != "test_"+algorithm {
+			t.Fatalf("[%s] Unexpected token header %v", algorithm, header)
+		}
+
+		// the HMAC verification should fail
+		if found, _ := app.Dao().FindAdminByToken(token, app.Settings().AdminAuthToken.Secret); found != nil {
+			t.Fatalf("[%s] Expected the asymmetric token to not be accepted as HS256 token", algorithm)
+		}
+
+		for _, tk := range []string{token, hsToken} {
+			found, err := tokens.FindAdminByAuthToken(app, tk)
+			if err != nil || found == nil || found.Id != admin.Id {
+				t.Fatalf("[%s] Expected admin %q, got %v (%v)", algorithm, admin.Id, found, err)
+			}
+		}
+
+		// auth record token with the same key
+		user, _ := app.Dao().FindAuthRecordByEmail("users", "test@example.com")
+		userToken, _ := tokens.NewRecordAuthToken(app, user)
+		if found, _ := tokens.FindAdminByAuthToken(app, userToken); found != nil {
+			t.Fatalf("[%s] Expected the auth record token to not be accepted as admin token", algorithm)
+		}
+
+		// changed admin token key (eg. after password change)
+		oldTokenKey := admin.TokenKey
+		admin.RefreshTokenKey()
+		if err := app.Dao().SaveAdmin(admin); err != nil {
+			t.Fatal(err)
+		}
+		if found, _ := tokens.FindAdminByAuthToken(app, token); found != nil {
+			t.Fatalf("[%s] Expected the token to be invalidated after token key change", algorithm)
+		}
+		admin.TokenKey = oldTokenKey
+		if err := app.Dao().SaveAdmin(admin); err != nil {
+			t.Fatal(err)
+		}
+
+		// removed signing key
+		app.Settings().TokenSigning = settings.TokenSigningConfig{Algorithm: security.AlgorithmHS256}
+		if found, _ := tokens.FindAdminByAuthToken(app, token); found != nil {
+			t.Fatalf("[%s] Expected the token to not be accepted after its key removal", algorithm)
+		}
+	}
+}
+
+func TestFindAuthRecordByAuthToken(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	user, err := app.Dao().FindAuthRecordByEmail("users", "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hsToken, err := tokens.NewRecordAuthToken(app, user)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, algorithm := range []string{security.AlgorithmRS256, security.AlgorithmEdDSA} {
+		setTestTokenSigningKey(t, app, algorithm)
+
+		token, err := tokens.NewRecordAuthToken(app, user)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		impersonateToken, err := tokens.NewRecordImpersonateToken(app, user, 100)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, tk := range []string{token, impersonateToken, hsToken} {
+			found, err := tokens.FindAuthRecordByAuthToken(app, tk)
+			if err != nil || found == nil || found.Id != user.Id {
+				t.Fatalf("[%s] Expected auth record %q, got %v (%v)", algorithm, user.Id, found, err)
+			}
+		}
+
+		// admin token with the same key
+		admin, _ := app.Dao().FindAdminByEmail("test@example.com")
+		adminToken, _ := tokens.NewAdminAuthToken(app, admin)
+		if found, _ := tokens.FindAuthRecordByAuthToken(app, adminToken); found != nil {
+			t.Fatalf("[%s] Expected the admin token to not be accepted as auth record token", algorithm)
+		}
+
+		// changed secret
+		oldSecret := app.Settings().RecordAuthToken.Secret
+		app.Settings().RecordAuthToken.Secret = security.RandomString(50)
+		if found, _ := tokens.FindAuthRecordByAuthToken(app, token); found != nil {
+			t.Fatalf("[%s] Expected the token to be invalidated after secret change", algorithm)
+		}
+		app.Settings().RecordAuthToken.Secret = oldSecret
+	}
+}
+
+func TestJWKS(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	keys, err := tokens.JWKS(app)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 0 {
+		t.Fatalf("Expected no keys, got %v", keys)
+	}
+
+	rsaKey, _ := security.GenerateSigningKey(security.AlgorithmRS256)
+	edKey, _ := security.GenerateSigningKey(security.AlgorithmEdDSA)
+
+	app.Settings().TokenSigning.Keys = []settings.TokenSigningKey{
+		{Id: "a", Algorithm: security.AlgorithmRS256, PrivateKey: rsaKey},
+		{Id: "b", Algorithm: security.AlgorithmEdDSA, PrivateKey: edKey},
+	}
+
+	keys, err = tokens.JWKS(app)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 2 ||
+		keys[0].Kid != "a" || keys[0].Kty != "RSA" ||
+		keys[1].Kid != "b" || keys[1].Kty != "OKP" {
+		t.Fatalf("Unexpected keys %v", keys)
+	}
+}
@@ -4,4 +4,12 @@ package tokens
 const (
 	TypeAdmin      = "admin"
 	TypeAuthRecord = "authRecord"
+
+	// TypeUser is the type of the GORM registry users auth tokens.
+	TypeUser = "user"
+
+	// TypeSignedRequest is the type of the tokens that authorize only
+	// a single specific request (see NewAdminSignedRequestToken and
+	// NewRecordSignedRequestToken).
+	TypeSignedRequest = "signedRequest"
 )
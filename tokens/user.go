@@ -0,0 +1,52 @@
+package tokens
+
+import (
+	"errors"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"gorm.io/gorm"
+)
+
+// NewUserAuthToken generates and returns a new GORM registry user authentication token.
+//
+// Similar to the admin tokens, the token is signed with the user password
+// hash + secret, so that it is invalidated on password change.
+func NewUserAuthToken(app core.App, user *models.User) (string, error) {
+	return security.NewToken(
+		jwt.MapClaims{"id": user.ID.ID.String(), "type": TypeUser},
+		(user.Password + app.Settings().UserAuthToken.Secret),
+		app.Settings().UserAuthToken.Duration,
+	)
+}
+
+// FindUserByAuthToken verifies the provided GORM user authentication token
+// and returns its (not soft deleted) user from the provided registry db.
+func FindUserByAuthToken(app core.App, db *gorm.DB, token string) (*models.User, error) {
+	unverifiedClaims, err := security.ParseUnverifiedJWT(token)
+	if err != nil {
+		return nil, err
+	}
+
+	// check required claims
+	id, _ := unverifiedClaims["id"].(string)
+	if id == "" || unverifiedClaims["type"] != TypeUser {
+		return nil, errors.New("Missing or invalid token claims.")
+	}
+
+	user := &models.User{}
+	if err := db.Where("id = ?", id).First(user).Error; err != nil {
+		return nil, err
+	}
+
+	verificationKey := user.Password + app.Settings().UserAuthToken.Secret
+
+	// verify token signature
+	if _, err := security.ParseJWT(token, verificationKey); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
@@ -0,0 +1,112 @@
+package tokens_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tokens"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	_ "modernc.org/sqlite"
+)
+
+// newTestUsersDB creates an in-memory sqlite users db (with the mysql
+// dialector) with a single "test" user and returns the db and the user.
+func newTestUsersDB(t *testing.T) (*gorm.DB, *models.User) {
+	conn, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.SetMaxOpenConns(1)
+	t.Cleanup(func() {
+		conn.Close()
+	})
+
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: conn, SkipInitializeWithVersion: true, ServerVersion: "MariaDB"}), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = db.Exec("CREATE TABLE `users` (" +
+		"`id` varchar(191) PRIMARY KEY, `name` varchar(191) NOT NULL UNIQUE, `email` text, `password` text NOT NULL," +
+		"`groups` JSON, `created_at` datetime NULL, `updated_at` datetime NULL, `deleted_at` datetime NULL)").Error
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = db.Exec("INSERT INTO `users` (`id`, `name`, `password`) VALUES (?, ?, ?)",
+		"cf8a07d4-077e-402e-a46b-ac0ed50989ec", "test", "password_hash").Error
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user := &models.User{}
+	if err := db.Where("name = ?", "test").First(user).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	return db, user
+}
+
+func TestFindUserByAuthToken(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	db, user := newTestUsersDB(t)
+
+	token, err := tokens.NewUserAuthToken(app, user)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims, _ := security.ParseUnverifiedJWT(token)
+	if claims["id"] != user.ID.ID.String() || claims["type"] != tokens.TypeUser {
+		t.Fatalf("Unexpected token claims %v", claims)
+	}
+
+	found, err := tokens.FindUserByAuthToken(app, db, token)
+	if err != nil || found == nil || found.ID.ID != user.ID.ID {
+		t.Fatalf("Expected user %v, got %v (%v)", user.ID.ID, found, err)
+	}
+
+	// admin token
+	admin, _ := app.Dao().FindAdminByEmail("test@example.com")
+	adminToken, _ := tokens.NewAdminAuthToken(app, admin)
+	if found, _ := tokens.FindUserByAuthToken(app, db, adminToken); found != nil {
+		t.Fatal("Expected the admin token to not be accepted as user token")
+	}
+
+	// changed secret
+	oldSecret := app.Settings().UserAuthToken.Secret
+	app.Settings().UserAuthToken.Secret = security.RandomString(50)
+	if found, _ := tokens.FindUserByAuthToken(app, db, token); found != nil {
+		t.Fatal("Expected the token to be invalidated after secret change")
+	}
+	app.Settings().UserAuthToken.Secret = oldSecret
+
+	// changed password
+	if err := db.Exec("UPDATE `users` SET `password` = ?", "new_password_hash").Error; err != nil {
+		t.Fatal(err)
+	}
+	if found, _ := tokens.FindUserByAuthToken(app, db, token); found != nil {
+		t.Fatal("Expected the token to be invalidated after password change")
+	}
+
+	// soft deleted user
+	token, _ = tokens.NewUserAuthToken(app, &models.User{
+		UserPure: models.UserPure{UserPrivate: models.UserPrivate{Password: "new_password_hash"}},
+		ModelCU:  user.ModelCU,
+	})
+	if found, _ := tokens.FindUserByAuthToken(app, db, token); found == nil {
+		t.Fatal("Expected the token with the new password to be valid")
+	}
+	if err := db.Exec("UPDATE `users` SET `deleted_at` = CURRENT_TIMESTAMP").Error; err != nil {
+		t.Fatal(err)
+	}
+	if found, _ := tokens.FindUserByAuthToken(app, db, token); found != nil {
+		t.Fatal("Expected the soft deleted user token to not be accepted")
+	}
+}
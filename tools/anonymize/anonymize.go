@@ -0,0 +1,152 @@
+// Package anonymize implements simple field value anonymization
+// strategies for sharing production-like datasets.
+//
+// The supported strategies are:
+//
+//   - mask - hides all string characters except the first and last one
+//     (for emails only the local part is masked)
+//   - hash - replaces the value with its keyed (HMAC-SHA256) hash
+//   - fake - replaces the value with a fake one with the same shape
+//     (eg. email, plain string, number or bool)
+//
+// The hash and fake strategies are deterministic for the same salt, so
+// that the same original values (eg. relation ids) remain consistent
+// across the different anonymized records.
+package anonymize
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cast"
+)
+
+// List of the supported anonymization strategies.
+const (
+	StrategyMask = "mask"
+	StrategyHash = "hash"
+	StrategyFake = "fake"
+)
+
+// Strategies returns the list of all supported strategies.
+func Strategies() []string {
+	return []string{StrategyMask, StrategyHash, StrategyFake}
+}
+
+// Apply anonymizes in place the data fields listed in the provided
+// fields map (field name -> strategy).
+//
+// Missing data fields and unknown strategies are ignored.
+func Apply(data map[string]any, fields map[string]string, salt string) {
+	for field, strategy := range fields {
+		if v, ok := data[field]; ok {
+			data[field] = Value(strategy, field, v, salt)
+		}
+	}
+}
+
+// Value anonymizes a single field value with the specified strategy.
+//
+// Empty values are returned as they are since there is nothing to hide.
+// Slice values are anonymized element by element.
+func Value(strategy string, field string, value any, salt string) any {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		if v == "" {
+			return v
+		}
+	case []string:
+		result := make([]string, len(v))
+		for i, item := range v {
+			result[i] = cast.ToString(Value(strategy, field, item, salt))
+		}
+		return result
+	case []any:
+		result := make([]any, len(v))
+		for i, item := range v {
+			result[i] = Value(strategy, field, item, salt)
+		}
+		return result
+	}
+
+	switch strategy {
+	case StrategyMask:
+		if str, ok := value.(string); ok {
+			return Mask(str)
+		}
+		// there is no meaningful way to partially hide a number or bool
+		return nil
+	case StrategyHash:
+		return Hash(cast.ToString(value), salt)
+	case StrategyFake:
+		return Fake(field, value, salt)
+	default:
+		return value
+	}
+}
+
+// Mask hides all characters of the provided string except the first
+// and the last one (values with less than 3 characters are fully hidden).
+//
+// For emails only the local part is masked, eg. "j**n@example.com".
+func Mask(value string) string {
+	if at := strings.LastIndex(value, "@"); at > 0 {
+		return Mask(value[:at]) + value[at:]
+	}
+
+	chars := []rune(value)
+	if len(chars) < 3 {
+		return strings.Repeat("*", len(chars))
+	}
+
+	return string(chars[0]) + strings.Repeat("*", len(chars)-2) + string(chars[len(chars)-1])
+}
+
+// Hash returns the hex encoded keyed hash of the provided value
+// (the first 16 bytes of its HMAC-SHA256 with the salt as key).
+func Hash(value string, salt string) string {
+	return hex.EncodeToString(sum(value, salt)[:16])
+}
+
+// Fake returns a deterministic fake value with the same shape
+// as the provided one:
+//
+//   - email strings are replaced with "user_{hash}@example.com"
+//   - other strings are replaced with "{field}_{hash}"
+//   - numbers are replaced with a number in the range [0, 100000)
+//   - bools are replaced with a bool derived from the hash
+func Fake(field string, value any, salt string) any {
+	raw := cast.ToString(value)
+	hash := sum(raw, salt)
+	n := binary.BigEndian.Uint32(hash[:4])
+	short := hex.EncodeToString(hash[:5])
+
+	switch v := value.(type) {
+	case string:
+		if strings.Contains(v, "@") {
+			return fmt.Sprintf("user_%s@example.com", short)
+		}
+		return fmt.Sprintf("%s_%s", field, short)
+	case bool:
+		return n%2 == 0
+	case float32, float64:
+		return float64(n % 100000)
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return int(n % 100000)
+	default:
+		return fmt.Sprintf("%s_%s", field, short)
+	}
+}
+
+func sum(value string, salt string) []byte {
+	h := hmac.New(sha256.New, []byte(salt))
+	h.Write([]byte(value))
+
+	return h.Sum(nil)
+}
@@ -0,0 +1,138 @@
+package anonymize_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/anonymize"
+)
+
+func TestMask(t *testing.T) {
+	scenarios := []struct {
+		value    string
+		expected string
+	}{
+		{"", ""},
+		{"a", "*"},
+		{"ab", "**"},
+		{"abc", "a*c"},
+		{"john doe", "j******e"},
+		{"тест", "т**т"},
+		{"john@example.com", "j**n@example.com"},
+		{"jo@example.com", "**@example.com"},
+		{"@example.com", "@**********m"},
+	}
+
+	for i, s := range scenarios {
+		result := anonymize.Mask(s.value)
+		if result != s.expected {
+			t.Errorf("(%d) Expected %q, got %q", i, s.expected, result)
+		}
+	}
+}
+
+func TestHash(t *testing.T) {
+	h1 := anonymize.Hash("test", "salt1")
+
+	if len(h1) != 32 {
+		t.Fatalf("Expected 32 characters hash, got %q", h1)
+	}
+
+	if h1 != anonymize.Hash("test", "salt1") {
+		t.Fatal("Expected the same hash for the same value and salt")
+	}
+
+	if h1 == anonymize.Hash("test", "salt2") {
+		t.Fatal("Expected different hash for different salt")
+	}
+
+	if h1 == anonymize.Hash("test2", "salt1") {
+		t.Fatal("Expected different hash for different value")
+	}
+}
+
+func TestFake(t *testing.T) {
+	email, _ := anonymize.Fake("email", "john@example.com", "salt").(string)
+	if !strings.HasPrefix(email, "user_") || !strings.HasSuffix(email, "@example.com") || email == "john@example.com" {
+		t.Fatalf("Expected fake email, got %q", email)
+	}
+
+	name, _ := anonymize.Fake("name", "John", "salt").(string)
+	if !strings.HasPrefix(name, "name_") {
+		t.Fatalf("Expected fake name, got %q", name)
+	}
+	if name != anonymize.Fake("name", "John", "salt") {
+		t.Fatal("Expected the same fake value for the same value and salt")
+	}
+
+	number, ok := anonymize.Fake("age", 30.0, "salt").(float64)
+	if !ok || number < 0 || number >= 100000 {
+		t.Fatalf("Expected fake float64 number, got %v", number)
+	}
+
+	integer, ok := anonymize.Fake("age", 30, "salt").(int)
+	if !ok || integer < 0 || integer >= 100000 {
+		t.Fatalf("Expected fake int number, got %v", integer)
+	}
+
+	if _, ok := anonymize.Fake("verified", true, "salt").(bool); !ok {
+		t.Fatal("Expected fake bool")
+	}
+}
+
+func TestValue(t *testing.T) {
+	scenarios := []struct {
+		strategy string
+		value    any
+		expected string
+	}{
+		{anonymize.StrategyMask, nil, `null`},
+		{anonymize.StrategyHash, nil, `null`},
+		{anonymize.StrategyFake, "", `""`},
+		{anonymize.StrategyMask, "john", `"j**n"`},
+		{anonymize.StrategyMask, 123, `null`},
+		{anonymize.StrategyMask, []string{"john", "jane"}, `["j**n","j**e"]`},
+		{anonymize.StrategyMask, []any{"john", 1}, `["j**n",null]`},
+		{anonymize.StrategyHash, "test", `"` + anonymize.Hash("test", "salt") + `"`},
+		{anonymize.StrategyHash, 123, `"` + anonymize.Hash("123", "salt") + `"`},
+		{anonymize.StrategyHash, []string{"test"}, `["` + anonymize.Hash("test", "salt") + `"]`},
+		{"unknown", "test", `"test"`},
+	}
+
+	for i, s := range scenarios {
+		raw, err := json.Marshal(anonymize.Value(s.strategy, "field", s.value, "salt"))
+		if err != nil {
+			t.Fatalf("(%d) %v", i, err)
+		}
+
+		if string(raw) != s.expected {
+			t.Errorf("(%d) Expected %s, got %s", i, s.expected, raw)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	data := map[string]any{
+		"id":    "abc",
+		"name":  "john",
+		"email": "john@example.com",
+		"age":   30.0,
+	}
+
+	anonymize.Apply(data, map[string]string{
+		"name":    anonymize.StrategyMask,
+		"email":   anonymize.StrategyHash,
+		"missing": anonymize.StrategyFake,
+	}, "salt")
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"age":30,"email":"` + anonymize.Hash("john@example.com", "salt") + `","id":"abc","name":"j**n"}`
+	if string(raw) != expected {
+		t.Fatalf("Expected \n%s, got \n%s", expected, raw)
+	}
+}
@@ -0,0 +1,105 @@
+package archive
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+// encryptedHeader is the magic prefix of the encrypted archive files.
+var encryptedHeader = []byte("PBENC\x00\x01\n")
+
+// Encrypt encrypts the archive at src with key (must be valid 32 char aes key)
+// and saves the result in dest path.
+func Encrypt(src string, dest string, key string) error {
+	sf, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer sf.Close()
+
+	return writeDest(dest, func(w io.Writer) error {
+		if _, err := w.Write(encryptedHeader); err != nil {
+			return err
+		}
+
+		return security.EncryptStream(w, sf, key)
+	})
+}
+
+// Decrypt decrypts the encrypted archive at src with key
+// (must be the same key used by [Encrypt]) and saves the result in dest path.
+func Decrypt(src string, dest string, key string) error {
+	sf, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer sf.Close()
+
+	header := make([]byte, len(encryptedHeader))
+	if _, err := io.ReadFull(sf, header); err != nil || !bytes.Equal(header, encryptedHeader) {
+		return errors.New("the archive is not encrypted")
+	}
+
+	return writeDest(dest, func(w io.Writer) error {
+		return security.DecryptStream(w, sf, key)
+	})
+}
+
+// IsEncrypted checks whether the archive at src is encrypted with [Encrypt].
+func IsEncrypted(src string) (bool, error) {
+	sf, err := os.Open(src)
+	if err != nil {
+		return false, err
+	}
+	defer sf.Close()
+
+	return IsEncryptedReader(sf)
+}
+
+// IsEncryptedReader checks whether the archive content read from r
+// starts with the [Encrypt] header.
+func IsEncryptedReader(r io.Reader) (bool, error) {
+	header := make([]byte, len(encryptedHeader))
+	if _, err := io.ReadFull(r, header); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return bytes.Equal(header, encryptedHeader), nil
+}
+
+// writeDest creates the dest file and calls write with it,
+// removing the dest file on failure.
+func writeDest(dest string, write func(w io.Writer) error) error {
+	if err := os.MkdirAll(filepath.Dir(dest), os.ModePerm); err != nil {
+		return err
+	}
+
+	df, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+
+	writeErr := write(df)
+
+	closeErr := df.Close()
+
+	if writeErr != nil || closeErr != nil {
+		// try to cleanup at least the partially written file
+		os.Remove(dest)
+
+		if writeErr != nil {
+			return writeErr
+		}
+		return closeErr
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package archive_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/archive"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	key := "abcdabcdabcdabcdabcdabcdabcdabcd"
+
+	dir, err := os.MkdirTemp("", "pb_encrypt_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	srcPath := filepath.Join(dir, "test.zip")
+	encryptedPath := filepath.Join(dir, "encrypted.zip")
+	decryptedPath := filepath.Join(dir, "decrypted.zip")
+
+	if err := os.WriteFile(srcPath, []byte("test content"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if encrypted, err := archive.IsEncrypted(srcPath); err != nil || encrypted {
+		t.Fatalf("Expected the source file to not be encrypted, got %v (%v)", encrypted, err)
+	}
+
+	// missing src
+	if err := archive.Encrypt(filepath.Join(dir, "missing.zip"), encryptedPath, key); err == nil {
+		t.Fatal("Expected Encrypt to fail due to missing src")
+	}
+
+	// invalid key
+	if err := archive.Encrypt(srcPath, encryptedPath, "test"); err == nil {
+		t.Fatal("Expected Encrypt to fail due to invalid key")
+	}
+	if _, err := os.Stat(encryptedPath); err == nil {
+		t.Fatal("Expected the failed encrypted file to be removed")
+	}
+
+	if err := archive.Encrypt(srcPath, encryptedPath, key); err != nil {
+		t.Fatalf("Failed to encrypt: %v", err)
+	}
+
+	if encrypted, err := archive.IsEncrypted(encryptedPath); err != nil || !encrypted {
+		t.Fatalf("Expected the file to be encrypted, got %v (%v)", encrypted, err)
+	}
+
+	// non encrypted src
+	if err := archive.Decrypt(srcPath, decryptedPath, key); err == nil {
+		t.Fatal("Expected Decrypt to fail due to non encrypted src")
+	}
+
+	// wrong key
+	if err := archive.Decrypt(encryptedPath, decryptedPath, "1234abcdabcdabcdabcdabcdabcdabcd"); err == nil {
+		t.Fatal("Expected Decrypt to fail due to wrong key")
+	}
+	if _, err := os.Stat(decryptedPath); err == nil {
+		t.Fatal("Expected the failed decrypted file to be removed")
+	}
+
+	if err := archive.Decrypt(encryptedPath, decryptedPath, key); err != nil {
+		t.Fatalf("Failed to decrypt: %v", err)
+	}
+
+	content, err := os.ReadFile(decryptedPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(content) != "test content" {
+		t.Fatalf("Expected the decrypted content to match with the original one, got %q", content)
+	}
+}
+
+func TestIsEncryptedReader(t *testing.T) {
+	scenarios := []struct {
+		content  string
+		expected bool
+	}{
+		{"", false},
+		{"PBENC", false},
+		{"PK\x03\x04test", false},
+		{"PBENC\x00\x01\ntest", true},
+	}
+
+	for i, s := range scenarios {
+		result, err := archive.IsEncryptedReader(strings.NewReader(s.content))
+		if err != nil {
+			t.Errorf("(%d) Unexpected error %v", i, err)
+			continue
+		}
+
+		if result != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, result)
+		}
+	}
+}
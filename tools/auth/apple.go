@@ -185,7 +185,8 @@ func (p *Apple) fetchJWK(kid string) (*jwk, error) {
 		return nil, err
 	}
 
-	res, err := http.DefaultClient.Do(req)
+	// use the context http client (if any)
+	res, err := oauth2.NewClient(p.ctx, nil).Do(req)
 	if err != nil {
 		return nil, err
 	}
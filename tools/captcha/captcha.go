@@ -0,0 +1,89 @@
+// Package captcha implements a minimal client for the captcha
+// providers "siteverify" endpoints (hCaptcha, Cloudflare Turnstile
+// and reCAPTCHA share the same request and response format).
+package captcha
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ResponseKeys is the list with the form keys where the
+// captcha widgets store their response token.
+var ResponseKeys = []string{
+	"captchaToken",
+	"h-captcha-response",
+	"cf-turnstile-response",
+	"g-recaptcha-response",
+}
+
+// List of the captcha verification errors.
+var (
+	ErrMissingToken = errors.New("missing captcha response token")
+	ErrInvalidToken = errors.New("invalid captcha response token")
+)
+
+// HttpClient is a base HTTP client interface (usually used for test purposes).
+type HttpClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// verifyResponse is the siteverify endpoint response body.
+type verifyResponse struct {
+	Success    bool     `json:"success"`
+	ErrorCodes []string `json:"error-codes"`
+}
+
+// Verify checks the captcha response token against the provider
+// siteverify endpoint (remoteIp is optional).
+//
+// If client is nil, a default [http.Client] with 30s timeout is used.
+func Verify(ctx context.Context, client HttpClient, verifyUrl string, secret string, token string, remoteIp string) error {
+	if token == "" {
+		return ErrMissingToken
+	}
+
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	form := url.Values{}
+	form.Set("secret", secret)
+	form.Set("response", token)
+	if remoteIp != "" {
+		form.Set("remoteip", remoteIp)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, verifyUrl, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("captcha verification failed with status %d", res.StatusCode)
+	}
+
+	result := &verifyResponse{}
+	if err := json.NewDecoder(res.Body).Decode(result); err != nil {
+		return err
+	}
+
+	if !result.Success {
+		return fmt.Errorf("%w %v", ErrInvalidToken, result.ErrorCodes)
+	}
+
+	return nil
+}
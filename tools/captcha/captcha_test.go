@@ -0,0 +1,58 @@
+package captcha_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/captcha"
+)
+
+func TestVerify(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+
+		if r.PostForm.Get("secret") != "test_secret" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if r.PostForm.Get("response") == "valid" && r.PostForm.Get("remoteip") == "127.0.0.1" {
+			w.Write([]byte(`{"success":true}`))
+		} else {
+			w.Write([]byte(`{"success":false,"error-codes":["invalid-input-response"]}`))
+		}
+	}))
+	defer server.Close()
+
+	scenarios := []struct {
+		name          string
+		secret        string
+		token         string
+		expectedError error
+		expectError   bool
+	}{
+		{"missing token", "test_secret", "", captcha.ErrMissingToken, true},
+		{"invalid secret", "invalid", "valid", nil, true},
+		{"invalid token", "test_secret", "invalid", captcha.ErrInvalidToken, true},
+		{"valid token", "test_secret", "valid", nil, false},
+	}
+
+	for _, s := range scenarios {
+		err := captcha.Verify(context.Background(), server.Client(), server.URL, s.secret, s.token, "127.0.0.1")
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("[%s] Expected hasErr %v, got %v (%v)", s.name, s.expectError, hasErr, err)
+			continue
+		}
+
+		if s.expectedError != nil && !errors.Is(err, s.expectedError) {
+			t.Errorf("[%s] Expected error %v, got %v", s.name, s.expectedError, err)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+// Package diskcache implements a simple size limited
+// least recently used (LRU) files cache stored on the local disk.
+package diskcache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Cache defines a size limited LRU disk cache.
+//
+// The cache entries "last usage" is tracked with the files
+// modification time, so the cache state survives app restarts.
+type Cache struct {
+	mux     sync.Mutex
+	dir     string
+	maxSize int64
+}
+
+// New creates a new Cache instance that stores its entries in dir
+// and keeps their total size under maxSize bytes (non-positive means unlimited).
+func New(dir string, maxSize int64) *Cache {
+	return &Cache{
+		dir:     dir,
+		maxSize: maxSize,
+	}
+}
+
+// SetMaxSize changes the max total size of the cache entries.
+//
+// The new limit is applied on the next [Cache.Set] call.
+func (c *Cache) SetMaxSize(maxSize int64) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.maxSize = maxSize
+}
+
+// Get returns the file path of the cache entry with the specified key
+// and marks it as recently used.
+//
+// The second return value reports whether the entry exists.
+func (c *Cache) Get(key string) (string, bool) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	path := c.path(key)
+
+	if _, err := os.Stat(path); err != nil {
+		return "", false
+	}
+
+	now := time.Now()
+	os.Chtimes(path, now, now)
+
+	return path, true
+}
+
+// Set creates (or replaces) the cache entry with the specified key
+// by calling write with the entry file writer and returns the entry file path.
+//
+// On write error the entry is not stored.
+//
+// After the entry is stored, the least recently used entries are
+// deleted until the total cache size fits in the max size limit.
+func (c *Cache) Set(key string, write func(w io.Writer) error) (string, error) {
+	if err := os.MkdirAll(c.dir, os.ModePerm); err != nil {
+		return "", err
+	}
+
+	// write into a temp file to avoid serving partially written entries
+	tmp, err := os.CreateTemp(c.dir, "tmp_*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := write(tmp); err != nil {
+		tmp.Close()
+		return "", err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	path := c.path(key)
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", err
+	}
+
+	if err := c.evict(path); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
+
+// Delete removes the cache entry with the specified key (if exists).
+func (c *Cache) Delete(key string) error {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	err := os.Remove(c.path(key))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+// Size returns the total size of the cache entries.
+func (c *Cache) Size() (int64, error) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	entries, err := c.entries()
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+	for _, entry := range entries {
+		total += entry.Size()
+	}
+
+	return total, nil
+}
+
+// evict deletes the least recently used entries (except keepPath)
+// until the total cache size fits in the max size limit.
+//
+// note: expects the cache mutex to be locked.
+func (c *Cache) evict(keepPath string) error {
+	if c.maxSize <= 0 {
+		return nil // unlimited
+	}
+
+	entries, err := c.entries()
+	if err != nil {
+		return err
+	}
+
+	var total int64
+	for _, entry := range entries {
+		total += entry.Size()
+	}
+
+	if total <= c.maxSize {
+		return nil
+	}
+
+	// oldest first
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].ModTime().Before(entries[j].ModTime())
+	})
+
+	for _, entry := range entries {
+		if total <= c.maxSize {
+			break
+		}
+
+		path := filepath.Join(c.dir, entry.Name())
+		if path == keepPath {
+			continue
+		}
+
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+
+		total -= entry.Size()
+	}
+
+	return nil
+}
+
+// entries returns the file info of all stored cache entries
+// (the temp files of the in progress writes are excluded).
+//
+// note: expects the cache mutex to be locked.
+func (c *Cache) entries() ([]os.FileInfo, error) {
+	dirEntries, err := os.ReadDir(c.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	result := make([]os.FileInfo, 0, len(dirEntries))
+
+	for _, dirEntry := range dirEntries {
+		if dirEntry.IsDir() || len(dirEntry.Name()) != sha256.Size*2 {
+			continue
+		}
+
+		info, err := dirEntry.Info()
+		if err != nil {
+			continue // deleted in the meantime
+		}
+
+		result = append(result, info)
+	}
+
+	return result, nil
+}
+
+// path returns the entry file path of the specified key.
+func (c *Cache) path(key string) string {
+	hash := sha256.Sum256([]byte(key))
+
+	return filepath.Join(c.dir, hex.EncodeToString(hash[:]))
+}
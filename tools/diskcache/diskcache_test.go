@@ -0,0 +1,137 @@
+package diskcache_test
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/diskcache"
+)
+
+func writeString(str string) func(w io.Writer) error {
+	return func(w io.Writer) error {
+		_, err := io.WriteString(w, str)
+		return err
+	}
+}
+
+func TestCacheSetAndGet(t *testing.T) {
+	dir := t.TempDir()
+
+	c := diskcache.New(dir, 0)
+
+	if _, ok := c.Get("missing"); ok {
+		t.Fatal("Expected missing entry")
+	}
+
+	// failed write
+	if _, err := c.Set("test", func(w io.Writer) error {
+		io.WriteString(w, "partial")
+		return errors.New("test")
+	}); err == nil {
+		t.Fatal("Expected Set error")
+	}
+
+	if _, ok := c.Get("test"); ok {
+		t.Fatal("Expected the failed entry to not be stored")
+	}
+
+	setPath, err := c.Set("test", writeString("abc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	getPath, ok := c.Get("test")
+	if !ok {
+		t.Fatal("Expected the entry to exist")
+	}
+
+	if setPath != getPath {
+		t.Fatalf("Expected path %q, got %q", setPath, getPath)
+	}
+
+	content, err := os.ReadFile(getPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "abc" {
+		t.Fatalf("Expected content %q, got %q", "abc", content)
+	}
+
+	// replace
+	if _, err := c.Set("test", writeString("abcd")); err != nil {
+		t.Fatal(err)
+	}
+
+	if size, _ := c.Size(); size != 4 {
+		t.Fatalf("Expected total size 4, got %d", size)
+	}
+
+	// only the cache entries should remain in the dir
+	files, _ := os.ReadDir(dir)
+	if len(files) != 1 || strings.HasPrefix(files[0].Name(), "tmp_") {
+		t.Fatalf("Expected a single entry file, got %v", files)
+	}
+
+	if err := c.Delete("test"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := c.Get("test"); ok {
+		t.Fatal("Expected the entry to be deleted")
+	}
+
+	if err := c.Delete("test"); err != nil {
+		t.Fatalf("Expected deleting a missing entry to succeed, got %v", err)
+	}
+}
+
+func TestCacheEviction(t *testing.T) {
+	c := diskcache.New(t.TempDir(), 10)
+
+	for i, key := range []string{"a", "b", "c"} {
+		path, err := c.Set(key, writeString("1234"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// simulate different last usage times
+		mtime := time.Now().Add(time.Duration(i-10) * time.Minute)
+		os.Chtimes(path, mtime, mtime)
+	}
+
+	// "a" should have been evicted when storing "c"
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("Expected a to be evicted")
+	}
+
+	// mark "b" as recently used
+	if _, ok := c.Get("b"); !ok {
+		t.Fatal("Expected b to exist")
+	}
+
+	if _, err := c.Set("d", writeString("1234")); err != nil {
+		t.Fatal(err)
+	}
+
+	for key, exists := range map[string]bool{"a": false, "b": true, "c": false, "d": true} {
+		if _, ok := c.Get(key); ok != exists {
+			t.Errorf("Expected %s exists to be %v", key, exists)
+		}
+	}
+
+	// an entry larger than the limit is still stored
+	c.SetMaxSize(2)
+	if _, err := c.Set("e", writeString("12345")); err != nil {
+		t.Fatal(err)
+	}
+
+	for key, exists := range map[string]bool{"b": false, "d": false, "e": true} {
+		if _, ok := c.Get(key); ok != exists {
+			t.Errorf("Expected %s exists to be %v after the limit change", key, exists)
+		}
+	}
+}
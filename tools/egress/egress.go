@@ -0,0 +1,207 @@
+// Package egress implements outgoing HTTP requests restrictions
+// (destination allowlist, private network ranges guard and proxy).
+package egress
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrDestinationNotAllowed is returned for requests to destinations
+// that are not allowed by the egress policy.
+var ErrDestinationNotAllowed = errors.New("egress: destination is not allowed")
+
+// Policy defines the outgoing requests restrictions.
+type Policy struct {
+	// Proxy is an optional proxy url that all requests are sent through
+	// (eg. "http://proxy.internal:3128").
+	//
+	// The environment proxy variables are ignored.
+	Proxy string
+
+	// Allowlist is an optional list with the allowed destinations.
+	//
+	// Each entry could be a hostname ("api.example.com"), a subdomains
+	// wildcard ("*.example.com"), an IP address or a CIDR range.
+	//
+	// If empty, all public destinations are allowed.
+	//
+	// Explicitly allowlisted destinations are permitted
+	// even if they resolve to a private network address.
+	Allowlist []string
+
+	// AllowPrivate allows requests to private, loopback, link-local
+	// and other non-public network addresses (denied by default).
+	AllowPrivate bool
+}
+
+// NewClient creates a new [http.Client] that enforces the provided policy.
+func NewClient(policy Policy, timeout time.Duration) (*http.Client, error) {
+	transport, err := NewTransport(policy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+	}, nil
+}
+
+// NewTransport creates a new [http.RoundTripper] that enforces the provided policy.
+func NewTransport(policy Policy) (http.RoundTripper, error) {
+	var proxyUrl *url.URL
+	if policy.Proxy != "" {
+		var err error
+		proxyUrl, err = url.Parse(policy.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("egress: invalid proxy url: %w", err)
+		}
+	}
+
+	t := &transport{
+		policy:   policy,
+		proxyUrl: proxyUrl,
+		base:     http.DefaultTransport.(*http.Transport).Clone(),
+	}
+
+	t.base.Proxy = nil
+	if proxyUrl != nil {
+		t.base.Proxy = http.ProxyURL(proxyUrl)
+	}
+	t.base.DialContext = t.dialContext
+
+	return t, nil
+}
+
+type trustedKey struct{}
+
+type transport struct {
+	policy   Policy
+	proxyUrl *url.URL
+	base     *http.Transport
+}
+
+// RoundTrip implements the [http.RoundTripper] interface.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Hostname()
+
+	allowlisted := IsAllowlisted(t.policy.Allowlist, host)
+	if len(t.policy.Allowlist) > 0 && !allowlisted {
+		return nil, fmt.Errorf("%w: %s", ErrDestinationNotAllowed, host)
+	}
+
+	if allowlisted {
+		// skip the private range checks on dial
+		req = req.Clone(context.WithValue(req.Context(), trustedKey{}, true))
+	} else if ip := net.ParseIP(host); ip != nil && !t.policy.AllowPrivate && IsPrivateIP(ip) {
+		return nil, fmt.Errorf("%w: %s", ErrDestinationNotAllowed, host)
+	}
+
+	return t.base.RoundTrip(req)
+}
+
+// dialContext checks the resolved destination address before connecting
+// (this also prevents DNS rebinding to private addresses).
+func (t *transport) dialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+
+	trusted, _ := ctx.Value(trustedKey{}).(bool)
+
+	// the connection to the admin configured proxy is always allowed
+	// (the proxy is responsible for resolving the actual destination)
+	isProxy := t.proxyUrl != nil && addr == proxyAddr(t.proxyUrl)
+
+	if !trusted && !isProxy && !t.policy.AllowPrivate {
+		dialer.Control = func(network, address string, c syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			ip := net.ParseIP(host)
+			if ip == nil || (IsPrivateIP(ip) && !IsAllowlisted(t.policy.Allowlist, host)) {
+				return fmt.Errorf("%w: %s", ErrDestinationNotAllowed, address)
+			}
+
+			return nil
+		}
+	}
+
+	return dialer.DialContext(ctx, network, addr)
+}
+
+func proxyAddr(u *url.URL) string {
+	port := u.Port()
+	if port == "" {
+		switch u.Scheme {
+		case "https":
+			port = "443"
+		case "socks5":
+			port = "1080"
+		default:
+			port = "80"
+		}
+	}
+
+	return net.JoinHostPort(u.Hostname(), port)
+}
+
+// IsAllowlisted checks whether the host matches any of the allowlist entries.
+func IsAllowlisted(allowlist []string, host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	ip := net.ParseIP(host)
+
+	for _, entry := range allowlist {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+
+		switch {
+		case entry == "":
+			continue
+		case strings.Contains(entry, "/"):
+			if _, network, err := net.ParseCIDR(entry); err == nil && ip != nil && network.Contains(ip) {
+				return true
+			}
+		case strings.HasPrefix(entry, "*."):
+			if ip == nil && strings.HasSuffix(host, entry[1:]) {
+				return true
+			}
+		case ip != nil:
+			if entryIp := net.ParseIP(entry); entryIp != nil && entryIp.Equal(ip) {
+				return true
+			}
+		case entry == host:
+			return true
+		}
+	}
+
+	return false
+}
+
+// carrierGradeNat is the shared address space range (RFC 6598).
+var carrierGradeNat = &net.IPNet{
+	IP:   net.IPv4(100, 64, 0, 0),
+	Mask: net.CIDRMask(10, 32),
+}
+
+// IsPrivateIP checks whether the ip is a private, loopback, link-local
+// (including the cloud metadata endpoints) or other non-public address.
+func IsPrivateIP(ip net.IP) bool {
+	return ip.IsPrivate() ||
+		ip.IsLoopback() ||
+		ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		carrierGradeNat.Contains(ip)
+}
@@ -0,0 +1,184 @@
+package egress_test
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/egress"
+)
+
+func TestIsPrivateIP(t *testing.T) {
+	scenarios := []struct {
+		ip       string
+		expected bool
+	}{
+		{"8.8.8.8", false},
+		{"2001:4860:4860::8888", false},
+		{"127.0.0.1", true},
+		{"::1", true},
+		{"0.0.0.0", true},
+		{"10.1.2.3", true},
+		{"172.16.0.1", true},
+		{"192.168.1.1", true},
+		{"169.254.169.254", true},
+		{"100.64.0.1", true},
+		{"fc00::1", true},
+		{"fe80::1", true},
+	}
+
+	for _, s := range scenarios {
+		result := egress.IsPrivateIP(net.ParseIP(s.ip))
+		if result != s.expected {
+			t.Errorf("(%s) Expected %v, got %v", s.ip, s.expected, result)
+		}
+	}
+}
+
+func TestIsAllowlisted(t *testing.T) {
+	allowlist := []string{"api.example.com", "*.test.com", "10.0.0.5", "192.168.0.0/16", " "}
+
+	scenarios := []struct {
+		host     string
+		expected bool
+	}{
+		{"api.example.com", true},
+		{"API.example.com.", true},
+		{"example.com", false},
+		{"sub.api.example.com", false},
+		{"a.test.com", true},
+		{"a.b.test.com", true},
+		{"test.com", false},
+		{"eviltest.com", false},
+		{"10.0.0.5", true},
+		{"10.0.0.6", false},
+		{"192.168.10.20", true},
+		{"", false},
+	}
+
+	for _, s := range scenarios {
+		result := egress.IsAllowlisted(allowlist, s.host)
+		if result != s.expected {
+			t.Errorf("(%s) Expected %v, got %v", s.host, s.expected, result)
+		}
+	}
+}
+
+func TestNewTransportInvalidProxy(t *testing.T) {
+	if _, err := egress.NewTransport(egress.Policy{Proxy: "://invalid"}); err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+}
+
+func TestClientPolicy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	// the test server listens on 127.0.0.1:port
+	localhostUrl := strings.Replace(server.URL, "127.0.0.1", "localhost", 1)
+
+	scenarios := []struct {
+		name        string
+		policy      egress.Policy
+		url         string
+		expectError bool
+	}{
+		{"private ip denied by default", egress.Policy{}, server.URL, true},
+		{"private host denied on dial", egress.Policy{}, localhostUrl, true},
+		{"private ip with AllowPrivate", egress.Policy{AllowPrivate: true}, server.URL, false},
+		{"allowlisted ip", egress.Policy{Allowlist: []string{"127.0.0.1"}}, server.URL, false},
+		{"allowlisted cidr", egress.Policy{Allowlist: []string{"127.0.0.0/8"}}, server.URL, false},
+		{"allowlisted hostname resolving to private ip", egress.Policy{Allowlist: []string{"localhost"}}, localhostUrl, false},
+		{"not allowlisted host", egress.Policy{Allowlist: []string{"example.com"}, AllowPrivate: true}, server.URL, true},
+	}
+
+	for _, s := range scenarios {
+		client, err := egress.NewClient(s.policy, 5*time.Second)
+		if err != nil {
+			t.Fatalf("[%s] Failed to create client: %v", s.name, err)
+		}
+
+		res, err := client.Get(s.url)
+		if res != nil {
+			res.Body.Close()
+		}
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("[%s] Expected hasErr %v, got %v (%v)", s.name, s.expectError, hasErr, err)
+			continue
+		}
+
+		if hasErr && !errors.Is(err, egress.ErrDestinationNotAllowed) {
+			t.Errorf("[%s] Expected ErrDestinationNotAllowed, got %v", s.name, err)
+		}
+	}
+}
+
+func TestClientRedirectToPrivate(t *testing.T) {
+	private := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("secret"))
+	}))
+	defer private.Close()
+
+	// the allowlisted server redirects to a not allowlisted private address
+	redirect := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, strings.Replace(private.URL, "127.0.0.1", "localhost", 1), http.StatusFound)
+	}))
+	defer redirect.Close()
+
+	client, err := egress.NewClient(egress.Policy{Allowlist: []string{"127.0.0.1"}}, 5*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := client.Get(redirect.URL)
+	if res != nil {
+		res.Body.Close()
+	}
+
+	if !errors.Is(err, egress.ErrDestinationNotAllowed) {
+		t.Fatalf("Expected ErrDestinationNotAllowed, got %v", err)
+	}
+}
+
+func TestClientProxy(t *testing.T) {
+	var proxied string
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		w.Write([]byte("proxied"))
+	}))
+	defer proxy.Close()
+
+	// the private proxy address itself is always allowed
+	client, err := egress.NewClient(egress.Policy{Proxy: proxy.URL}, 5*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := client.Get("http://example.com/test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if proxied != "http://example.com/test" {
+		t.Fatalf("Expected the request to be sent through the proxy, got %q", proxied)
+	}
+
+	// the destination checks still apply
+	res, err = client.Get("http://10.0.0.1/test")
+	if res != nil {
+		res.Body.Close()
+	}
+	if !errors.Is(err, egress.ErrDestinationNotAllowed) {
+		t.Fatalf("Expected ErrDestinationNotAllowed, got %v", err)
+	}
+}
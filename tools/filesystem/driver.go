@@ -0,0 +1,81 @@
+package filesystem
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"gocloud.dev/blob"
+)
+
+// Driver opens a new storage bucket from the provided driver specific options.
+//
+// Any gocloud.dev/blob backend could be used as a driver (eg. gcsblob, azureblob),
+// as well as custom ones wrapped with [blob.NewBucket].
+type Driver func(ctx context.Context, options map[string]string) (*blob.Bucket, error)
+
+var (
+	driversMux sync.RWMutex
+	drivers    = map[string]Driver{}
+)
+
+// RegisterDriver registers (or replaces) the bucket factory of the named driver, eg.:
+//
+//	filesystem.RegisterDriver("gcs", func(ctx context.Context, options map[string]string) (*blob.Bucket, error) {
+//		return blob.OpenBucket(ctx, "gs://"+options["bucket"])
+//	})
+func RegisterDriver(name string, driver Driver) {
+	driversMux.Lock()
+	defer driversMux.Unlock()
+
+	drivers[name] = driver
+}
+
+// HasDriver checks whether a driver with the provided name is registered.
+func HasDriver(name string) bool {
+	driversMux.RLock()
+	defer driversMux.RUnlock()
+
+	_, ok := drivers[name]
+
+	return ok
+}
+
+// Drivers returns the sorted names of all registered drivers.
+func Drivers() []string {
+	driversMux.RLock()
+	defer driversMux.RUnlock()
+
+	result := make([]string, 0, len(drivers))
+	for name := range drivers {
+		result = append(result, name)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+// NewWithDriver initializes a new filesystem instance
+// from the bucket opened by the named registered driver.
+//
+// NB! Make sure to call `Close()` after you are done working with it.
+func NewWithDriver(name string, options map[string]string) (*System, error) {
+	driversMux.RLock()
+	driver, ok := drivers[name]
+	driversMux.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("missing filesystem driver %q", name)
+	}
+
+	ctx := context.Background() // default context
+
+	bucket, err := driver(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &System{ctx: ctx, bucket: bucket}, nil
+}
@@ -0,0 +1,62 @@
+package filesystem_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/filesystem"
+	"gocloud.dev/blob"
+	"gocloud.dev/blob/memblob"
+)
+
+func TestNewWithDriver(t *testing.T) {
+	var receivedOptions map[string]string
+
+	filesystem.RegisterDriver("test_mem", func(ctx context.Context, options map[string]string) (*blob.Bucket, error) {
+		receivedOptions = options
+		return memblob.OpenBucket(nil), nil
+	})
+	filesystem.RegisterDriver("test_error", func(ctx context.Context, options map[string]string) (*blob.Bucket, error) {
+		return nil, errors.New("test")
+	})
+
+	if !filesystem.HasDriver("test_mem") {
+		t.Fatal("Expected test_mem driver to be registered")
+	}
+
+	if filesystem.HasDriver("test_missing") {
+		t.Fatal("Expected test_missing driver to not be registered")
+	}
+
+	names := filesystem.Drivers()
+	if len(names) < 2 || names[0] != "test_error" || names[1] != "test_mem" {
+		t.Fatalf("Expected sorted registered drivers, got %v", names)
+	}
+
+	if _, err := filesystem.NewWithDriver("test_missing", nil); err == nil {
+		t.Fatal("Expected missing driver error, got nil")
+	}
+
+	if _, err := filesystem.NewWithDriver("test_error", nil); err == nil {
+		t.Fatal("Expected driver error, got nil")
+	}
+
+	fs, err := filesystem.NewWithDriver("test_mem", map[string]string{"bucket": "abc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fs.Close()
+
+	if receivedOptions["bucket"] != "abc" {
+		t.Fatalf("Expected the options to be forwarded to the driver, got %v", receivedOptions)
+	}
+
+	if err := fs.Upload([]byte("test"), "test.txt"); err != nil {
+		t.Fatal(err)
+	}
+
+	if exists, _ := fs.Exists("test.txt"); !exists {
+		t.Fatal("Expected test.txt to be uploaded in the driver bucket")
+	}
+}
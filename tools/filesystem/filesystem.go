@@ -3,6 +3,7 @@ package filesystem
 import (
 	"context"
 	"errors"
+	"fmt"
 	"image"
 	"io"
 	"mime/multipart"
@@ -13,21 +14,27 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/disintegration/imaging"
 	"github.com/gabriel-vasile/mimetype"
 	"github.com/pocketbase/pocketbase/tools/list"
 	"gocloud.dev/blob"
 	"gocloud.dev/blob/fileblob"
 	"gocloud.dev/blob/s3blob"
+	"gocloud.dev/gcerrors"
 )
 
 type System struct {
-	ctx    context.Context
-	bucket *blob.Bucket
+	ctx            context.Context
+	bucket         *blob.Bucket
+	serveChunkSize int64
+	partSize       int64
+	concurrency    int
 }
 
 // NewS3 initializes an S3 filesystem instance.
@@ -40,6 +47,22 @@ func NewS3(
 	accessKey string,
 	secretKey string,
 	s3ForcePathStyle bool,
+) (*System, error) {
+	return NewS3WithHttpClient(bucketName, region, endpoint, accessKey, secretKey, s3ForcePathStyle, nil)
+}
+
+// NewS3WithHttpClient initializes an S3 filesystem instance that sends
+// its requests with the provided http client (nil for the default one).
+//
+// NB! Make sure to call `Close()` after you are done working with it.
+func NewS3WithHttpClient(
+	bucketName string,
+	region string,
+	endpoint string,
+	accessKey string,
+	secretKey string,
+	s3ForcePathStyle bool,
+	httpClient *http.Client,
 ) (*System, error) {
 	ctx := context.Background() // default context
 
@@ -50,6 +73,7 @@ func NewS3(
 		Endpoint:         aws.String(endpoint),
 		Credentials:      cred,
 		S3ForcePathStyle: aws.Bool(s3ForcePathStyle),
+		HTTPClient:       httpClient,
 	})
 	if err != nil {
 		return nil, err
@@ -60,7 +84,12 @@ func NewS3(
 		return nil, err
 	}
 
-	return &System{ctx: ctx, bucket: bucket}, nil
+	return &System{
+		ctx:         ctx,
+		bucket:      bucket,
+		partSize:    DefaultTransferPartSize,
+		concurrency: DefaultTransferConcurrency,
+	}, nil
 }
 
 // NewLocal initializes a new local filesystem instance.
@@ -87,6 +116,13 @@ func (s *System) SetContext(ctx context.Context) {
 	s.ctx = ctx
 }
 
+// SetServeChunkSize sets the max number of bytes (0 for no limit) that are
+// fetched from the storage with a single range read and that are served
+// in response to an open-ended range request (see [System.Serve]).
+func (s *System) SetServeChunkSize(size int64) {
+	s.serveChunkSize = size
+}
+
 // Close releases any resources used for the related filesystem.
 func (s *System) Close() error {
 	return s.bucket.Close()
@@ -139,7 +175,9 @@ func (s *System) List(prefix string) ([]*blob.ListObject, error) {
 // Upload writes content into the fileKey location.
 func (s *System) Upload(content []byte, fileKey string) error {
 	opts := &blob.WriterOptions{
-		ContentType: mimetype.Detect(content).String(),
+		ContentType:    mimetype.Detect(content).String(),
+		BufferSize:     int(s.partSize),
+		MaxConcurrency: s.concurrency,
 	}
 
 	w, writerErr := s.bucket.NewWriter(s.ctx, fileKey, opts)
@@ -182,6 +220,8 @@ func (s *System) UploadFile(file *File, fileKey string) error {
 		Metadata: map[string]string{
 			"original-filename": originalName,
 		},
+		BufferSize:     int(s.partSize),
+		MaxConcurrency: s.concurrency,
 	}
 
 	w, err := s.bucket.NewWriter(s.ctx, fileKey, opts)
@@ -224,6 +264,8 @@ func (s *System) UploadMultipart(fh *multipart.FileHeader, fileKey string) error
 		Metadata: map[string]string{
 			"original-filename": originalName,
 		},
+		BufferSize:     int(s.partSize),
+		MaxConcurrency: s.concurrency,
 	}
 
 	w, err := s.bucket.NewWriter(s.ctx, fileKey, opts)
@@ -322,15 +364,45 @@ var manualExtensionContentTypes = map[string]string{
 }
 
 // Serve serves the file at fileKey location to an HTTP response.
+//
+// Range requests are served with range reads directly from the storage
+// and the open-ended ones (eg. "bytes=100-") are limited to the
+// serve chunk size (if set, see [System.SetServeChunkSize]).
 func (s *System) Serve(res http.ResponseWriter, req *http.Request, fileKey string, name string) error {
-	br, readErr := s.bucket.NewReader(s.ctx, fileKey, nil)
-	if readErr != nil {
-		return readErr
+	var content io.ReadSeeker
+	var realContentType string
+	var modTime time.Time
+
+	if req.Header.Get("Range") != "" {
+		attrs, err := s.bucket.Attributes(s.ctx, fileKey)
+		if err != nil {
+			return err
+		}
+
+		rr := newRangeReader(s.ctx, s.bucket, fileKey, attrs.Size, s.serveChunkSize)
+		defer rr.Close()
+
+		content = rr
+		realContentType = attrs.ContentType
+		modTime = attrs.ModTime
+
+		if limited, ok := limitOpenRange(req.Header.Get("Range"), attrs.Size, s.serveChunkSize); ok {
+			req = req.Clone(req.Context())
+			req.Header.Set("Range", limited)
+		}
+	} else {
+		br, readErr := s.bucket.NewReader(s.ctx, fileKey, nil)
+		if readErr != nil {
+			return readErr
+		}
+		defer br.Close()
+
+		content = br
+		realContentType = br.ContentType()
+		modTime = br.ModTime()
 	}
-	defer br.Close()
 
 	disposition := "attachment"
-	realContentType := br.ContentType()
 	if list.ExistInSlice(realContentType, inlineServeContentTypes) {
 		disposition = "inline"
 	}
@@ -345,17 +417,80 @@ func (s *System) Serve(res http.ResponseWriter, req *http.Request, fileKey strin
 	setHeaderIfMissing(res, "Content-Disposition", disposition+"; filename="+name)
 	setHeaderIfMissing(res, "Content-Type", extContentType)
 	setHeaderIfMissing(res, "Content-Security-Policy", "default-src 'none'; media-src 'self'; style-src 'unsafe-inline'; sandbox")
+	setHeaderIfMissing(res, "X-Content-Type-Options", "nosniff")
 
 	// set a default cache-control header
 	// (valid for 30 days but the cache is allowed to reuse the file for any requests
 	// that are made in the last day while revalidating the res in the background)
 	setHeaderIfMissing(res, "Cache-Control", "max-age=2592000, stale-while-revalidate=86400")
 
-	http.ServeContent(res, req, name, br.ModTime(), br)
+	http.ServeContent(res, req, name, modTime, content)
 
 	return nil
 }
 
+// ErrSignedURLNotSupported is returned by [System.SignedURL] for the
+// storages that don't support pre-signed urls (eg. the local filesystem).
+var ErrSignedURLNotSupported = errors.New("the storage doesn't support pre-signed urls")
+
+// SignedURL returns a time-limited pre-signed url for downloading
+// the file at fileKey location directly from the storage bucket.
+//
+// contentDisposition and contentType are optional response header
+// values that the storage should send with the downloaded file.
+func (s *System) SignedURL(fileKey string, expiry time.Duration, contentDisposition string, contentType string) (string, error) {
+	opts := &blob.SignedURLOptions{
+		Expiry: expiry,
+		Method: http.MethodGet,
+		BeforeSign: func(asFunc func(any) bool) error {
+			var input *s3.GetObjectInput
+			if asFunc(&input) {
+				if contentDisposition != "" {
+					input.ResponseContentDisposition = aws.String(contentDisposition)
+				}
+				if contentType != "" {
+					input.ResponseContentType = aws.String(contentType)
+				}
+			}
+			return nil
+		},
+	}
+
+	url, err := s.bucket.SignedURL(s.ctx, fileKey, opts)
+	if err != nil {
+		if gcerrors.Code(err) == gcerrors.Unimplemented {
+			return "", ErrSignedURLNotSupported
+		}
+		return "", err
+	}
+
+	return url, nil
+}
+
+var openRangeRegex = regexp.MustCompile(`^bytes=(\d+)-$`)
+
+// limitOpenRange limits the open-ended single range header value
+// (eg. "bytes=100-") to max chunkSize bytes.
+//
+// Returns false if the range header doesn't need to be changed.
+func limitOpenRange(rangeHeader string, size int64, chunkSize int64) (string, bool) {
+	if chunkSize <= 0 {
+		return "", false
+	}
+
+	match := openRangeRegex.FindStringSubmatch(strings.TrimSpace(rangeHeader))
+	if len(match) != 2 {
+		return "", false
+	}
+
+	start, err := strconv.ParseInt(match[1], 10, 64)
+	if err != nil || start >= size || size-start <= chunkSize {
+		return "", false
+	}
+
+	return fmt.Sprintf("bytes=%d-%d", start, start+chunkSize-1), true
+}
+
 // note: expects key to be in a canonical form (eg. "accept-encoding" should be "Accept-Encoding").
 func setHeaderIfMissing(res http.ResponseWriter, key string, value string) {
 	if _, ok := res.Header()[key]; !ok {
@@ -376,17 +511,8 @@ var ThumbSizeRegex = regexp.MustCompile(`^(\d+)x(\d+)(t|b|f)?$`)
 // - WxHb (eg. 300x100b) - resize and crop to WxH viewbox (from bottom)
 // - WxHf (eg. 300x100f) - fit inside a WxH viewbox (without cropping)
 func (s *System) CreateThumb(originalKey string, thumbKey, thumbSize string) error {
-	sizeParts := ThumbSizeRegex.FindStringSubmatch(thumbSize)
-	if len(sizeParts) != 4 {
-		return errors.New("Thumb size must be in WxH, WxHt, WxHb or WxHf format.")
-	}
-
-	width, _ := strconv.Atoi(sizeParts[1])
-	height, _ := strconv.Atoi(sizeParts[2])
-	resizeType := sizeParts[3]
-
-	if width == 0 && height == 0 {
-		return errors.New("Thumb width and height cannot be zero at the same time.")
+	if _, _, _, err := parseImageSize(thumbSize); err != nil {
+		return err
 	}
 
 	// fetch the original
@@ -403,26 +529,9 @@ func (s *System) CreateThumb(originalKey string, thumbKey, thumbSize string) err
 		return decodeErr
 	}
 
-	var thumbImg *image.NRGBA
-
-	if width == 0 || height == 0 {
-		// force resize preserving aspect ratio
-		thumbImg = imaging.Resize(img, width, height, imaging.CatmullRom)
-	} else {
-		switch resizeType {
-		case "f":
-			// fit
-			thumbImg = imaging.Fit(img, width, height, imaging.CatmullRom)
-		case "t":
-			// fill and crop from top
-			thumbImg = imaging.Fill(img, width, height, imaging.Top, imaging.CatmullRom)
-		case "b":
-			// fill and crop from bottom
-			thumbImg = imaging.Fill(img, width, height, imaging.Bottom, imaging.CatmullRom)
-		default:
-			// fill and crop from center
-			thumbImg = imaging.Fill(img, width, height, imaging.Center, imaging.CatmullRom)
-		}
+	thumbImg, resizeErr := resizeImage(img, thumbSize)
+	if resizeErr != nil {
+		return resizeErr
 	}
 
 	opts := &blob.WriterOptions{
@@ -451,3 +560,164 @@ func (s *System) CreateThumb(originalKey string, thumbKey, thumbSize string) err
 	// check for close errors to ensure that the thumb was really saved
 	return w.Close()
 }
+
+// ImageTransform defines the options of a single on-the-fly image transformation.
+type ImageTransform struct {
+	// Resize is the optional new image size in the same format as the thumb sizes
+	// (see [System.CreateThumb]).
+	Resize string
+
+	// Format is the optional output image format ("jpeg", "png" or "gif").
+	//
+	// If empty, the format is detected from the original file name
+	// (fallbacks to png on error).
+	Format string
+
+	// Quality is the optional jpeg output quality in the range 1-100
+	// (default to 75).
+	Quality int
+}
+
+// ImageTransformFormats is the list of the supported image transform output formats.
+var ImageTransformFormats = []string{"jpeg", "jpg", "png", "gif"}
+
+// Validate checks whether the transform options are valid.
+func (t ImageTransform) Validate() error {
+	if t.Resize != "" {
+		if _, _, _, err := parseImageSize(t.Resize); err != nil {
+			return err
+		}
+	}
+
+	if t.Format != "" && !list.ExistInSlice(strings.ToLower(t.Format), ImageTransformFormats) {
+		return fmt.Errorf("Unsupported image format %q.", t.Format)
+	}
+
+	if t.Quality < 0 || t.Quality > 100 {
+		return errors.New("Image quality must be in the range 1-100.")
+	}
+
+	return nil
+}
+
+// TransformImage applies the provided transform options to the image
+// at originalKey location and writes the result into w.
+func (s *System) TransformImage(originalKey string, transform ImageTransform, w io.Writer) error {
+	if err := transform.Validate(); err != nil {
+		return err
+	}
+
+	r, readErr := s.bucket.NewReader(s.ctx, originalKey, nil)
+	if readErr != nil {
+		return readErr
+	}
+	defer r.Close()
+
+	// note: only the first frame for animated image formats
+	img, decodeErr := imaging.Decode(r, imaging.AutoOrientation(true))
+	if decodeErr != nil {
+		return decodeErr
+	}
+
+	if transform.Resize != "" {
+		resized, err := resizeImage(img, transform.Resize)
+		if err != nil {
+			return err
+		}
+		img = resized
+	}
+
+	var encodeOpts []imaging.EncodeOption
+	if transform.Quality > 0 {
+		encodeOpts = append(encodeOpts, imaging.JPEGQuality(transform.Quality))
+	}
+
+	return imaging.Encode(w, img, transform.outputFormat(originalKey), encodeOpts...)
+}
+
+// Dimensions returns the width and height of the transform resize option
+// (zeros if the resize option is missing or invalid).
+func (t ImageTransform) Dimensions() (int, int) {
+	width, height, _, _ := parseImageSize(t.Resize)
+
+	return width, height
+}
+
+// ContentType returns the content type of the image produced
+// by transforming the file at originalKey location.
+func (t ImageTransform) ContentType(originalKey string) string {
+	switch t.outputFormat(originalKey) {
+	case imaging.JPEG:
+		return "image/jpeg"
+	case imaging.GIF:
+		return "image/gif"
+	default:
+		return "image/png"
+	}
+}
+
+// outputFormat resolves the transformed image format
+// (fallbacks to png for unsupported formats).
+func (t ImageTransform) outputFormat(originalKey string) imaging.Format {
+	var format imaging.Format
+	var err error
+
+	if t.Format != "" {
+		format, err = imaging.FormatFromExtension(t.Format)
+	} else {
+		format, err = imaging.FormatFromFilename(originalKey)
+	}
+
+	if err != nil || (format != imaging.JPEG && format != imaging.GIF) {
+		return imaging.PNG
+	}
+
+	return format
+}
+
+// parseImageSize extracts the width, height and resize type
+// from an image size string (eg. "300x100t").
+func parseImageSize(size string) (int, int, string, error) {
+	sizeParts := ThumbSizeRegex.FindStringSubmatch(size)
+	if len(sizeParts) != 4 {
+		return 0, 0, "", errors.New("Thumb size must be in WxH, WxHt, WxHb or WxHf format.")
+	}
+
+	width, _ := strconv.Atoi(sizeParts[1])
+	height, _ := strconv.Atoi(sizeParts[2])
+
+	if width == 0 && height == 0 {
+		return 0, 0, "", errors.New("Thumb width and height cannot be zero at the same time.")
+	}
+
+	return width, height, sizeParts[3], nil
+}
+
+// resizeImage resizes img according to the specified image size string
+// (see [System.CreateThumb] for the supported formats).
+func resizeImage(img image.Image, size string) (*image.NRGBA, error) {
+	width, height, resizeType, err := parseImageSize(size)
+	if err != nil {
+		return nil, err
+	}
+
+	if width == 0 || height == 0 {
+		// force resize preserving aspect ratio
+		return imaging.Resize(img, width, height, imaging.CatmullRom), nil
+	}
+
+	switch resizeType {
+	case "f":
+		// fit
+		return imaging.Fit(img, width, height, imaging.CatmullRom), nil
+	case "t":
+		// fill and crop from top
+		return imaging.Fill(img, width, height, imaging.Top, imaging.CatmullRom), nil
+	case "b":
+		// fill and crop from bottom
+		return imaging.Fill(img, width, height, imaging.Bottom, imaging.CatmullRom), nil
+	default:
+		// fill and crop from center
+		return imaging.Fill(img, width, height, imaging.Center, imaging.CatmullRom), nil
+	}
+}
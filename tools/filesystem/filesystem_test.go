@@ -2,7 +2,9 @@ package filesystem_test
 
 import (
 	"bytes"
+	"errors"
 	"image"
+	_ "image/jpeg"
 	"image/png"
 	"mime/multipart"
 	"net/http"
@@ -11,6 +13,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/pocketbase/pocketbase/tools/filesystem"
 )
@@ -309,6 +312,7 @@ func TestFileSystemServe(t *testing.T) {
 				"Content-Length":          "0",
 				"Content-Security-Policy": csp,
 				"Cache-Control":           cacheControl,
+				"X-Content-Type-Options":  "nosniff",
 			},
 		},
 		{
@@ -525,6 +529,111 @@ func TestFileSystemServeMultiRange(t *testing.T) {
 	}
 }
 
+func TestFileSystemServeChunkedRange(t *testing.T) {
+	dir := createTestDir(t)
+	defer os.RemoveAll(dir)
+
+	fs, err := filesystem.NewLocal(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fs.Close()
+
+	if err := fs.Upload([]byte("0123456789abcdefghij"), "data.txt"); err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		name          string
+		chunkSize     int64
+		rangeHeader   string
+		expectedCode  int
+		expectedRange string
+		expectedBody  string
+	}{
+		{"no range", 4, "", http.StatusOK, "", "0123456789abcdefghij"},
+		{"open range without chunk size", 0, "bytes=5-", http.StatusPartialContent, "bytes 5-19/20", "56789abcdefghij"},
+		{"open range with chunk size", 4, "bytes=5-", http.StatusPartialContent, "bytes 5-8/20", "5678"},
+		{"open range smaller than the chunk size", 4, "bytes=18-", http.StatusPartialContent, "bytes 18-19/20", "ij"},
+		{"closed range larger than the chunk size", 4, "bytes=2-13", http.StatusPartialContent, "bytes 2-13/20", "23456789abcd"},
+		{"suffix range", 4, "bytes=-3", http.StatusPartialContent, "bytes 17-19/20", "hij"},
+		{"unsatisfiable range", 4, "bytes=30-", http.StatusRequestedRangeNotSatisfiable, "bytes */20", ""},
+	}
+
+	for _, s := range scenarios {
+		fs.SetServeChunkSize(s.chunkSize)
+
+		res := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/", nil)
+		if s.rangeHeader != "" {
+			req.Header.Set("Range", s.rangeHeader)
+		}
+
+		if err := fs.Serve(res, req, "data.txt", "data.txt"); err != nil {
+			t.Errorf("[%s] Failed to serve the file: %v", s.name, err)
+			continue
+		}
+
+		result := res.Result()
+
+		if result.StatusCode != s.expectedCode {
+			t.Errorf("[%s] Expected StatusCode %d, got %d", s.name, s.expectedCode, result.StatusCode)
+		}
+
+		if cr := result.Header.Get("Content-Range"); cr != s.expectedRange {
+			t.Errorf("[%s] Expected Content-Range %q, got %q", s.name, s.expectedRange, cr)
+		}
+
+		if s.expectedBody != "" && res.Body.String() != s.expectedBody {
+			t.Errorf("[%s] Expected body %q, got %q", s.name, s.expectedBody, res.Body.String())
+		}
+
+		if req.Header.Get("Range") != s.rangeHeader {
+			t.Errorf("[%s] Expected the original request Range header to remain unchanged, got %q", s.name, req.Header.Get("Range"))
+		}
+	}
+}
+
+func TestFileSystemSignedURL(t *testing.T) {
+	dir := createTestDir(t)
+	defer os.RemoveAll(dir)
+
+	local, err := filesystem.NewLocal(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer local.Close()
+
+	if _, err := local.SignedURL("image.png", time.Minute, "", ""); !errors.Is(err, filesystem.ErrSignedURLNotSupported) {
+		t.Fatalf("Expected ErrSignedURLNotSupported for the local filesystem, got %v", err)
+	}
+
+	// the urls are signed locally without sending a request
+	s3, err := filesystem.NewS3("test_bucket", "us-east-1", "https://s3.example.com", "test_key", "test_secret", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s3.Close()
+
+	url, err := s3.SignedURL("test/image.png", time.Minute, "attachment; filename=image.png", "image/png")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedParts := []string{
+		"https://s3.example.com/test_bucket/test/image.png?",
+		"X-Amz-Expires=60",
+		"X-Amz-Signature=",
+		"response-content-disposition=attachment%3B%20filename%3Dimage.png",
+		"response-content-type=image%2Fpng",
+	}
+	for _, part := range expectedParts {
+		if !strings.Contains(url, part) {
+			t.Errorf("Missing %q in the signed url %s", part, url)
+		}
+	}
+}
+
 func TestFileSystemCreateThumb(t *testing.T) {
 	dir := createTestDir(t)
 	defer os.RemoveAll(dir)
@@ -572,6 +681,96 @@ func TestFileSystemCreateThumb(t *testing.T) {
 	}
 }
 
+func TestFileSystemTransformImage(t *testing.T) {
+	dir := createTestDir(t)
+	defer os.RemoveAll(dir)
+
+	fs, err := filesystem.NewLocal(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fs.Close()
+
+	scenarios := []struct {
+		file           string
+		transform      filesystem.ImageTransform
+		expectError    bool
+		expectedFormat string
+		expectedWidth  int
+		expectedHeight int
+	}{
+		// missing
+		{"missing.png", filesystem.ImageTransform{Resize: "10x10"}, true, "", 0, 0},
+		// non-image existing file
+		{"test/sub1.txt", filesystem.ImageTransform{Resize: "10x10"}, true, "", 0, 0},
+		// invalid resize
+		{"image.png", filesystem.ImageTransform{Resize: "0x0"}, true, "", 0, 0},
+		// unsupported format
+		{"image.png", filesystem.ImageTransform{Format: "webp"}, true, "", 0, 0},
+		// invalid quality
+		{"image.png", filesystem.ImageTransform{Quality: 101}, true, "", 0, 0},
+		// resize with the original format
+		{"image.png", filesystem.ImageTransform{Resize: "20x10"}, false, "png", 20, 10},
+		// resize preserving the aspect ratio
+		{"image.png", filesystem.ImageTransform{Resize: "0x5"}, false, "png", 5, 5},
+		// format conversion with quality
+		{"image.png", filesystem.ImageTransform{Format: "jpeg", Quality: 80}, false, "jpeg", 1, 1},
+		// missing extension (fallbacks to png)
+		{"image_! noext", filesystem.ImageTransform{Resize: "3x3"}, false, "png", 3, 3},
+	}
+
+	for i, scenario := range scenarios {
+		var buf bytes.Buffer
+
+		err := fs.TransformImage(scenario.file, scenario.transform, &buf)
+
+		hasErr := err != nil
+		if hasErr != scenario.expectError {
+			t.Errorf("(%d) Expected hasErr to be %v, got %v (%v)", i, scenario.expectError, hasErr, err)
+			continue
+		}
+
+		if scenario.expectError {
+			continue
+		}
+
+		config, format, err := image.DecodeConfig(&buf)
+		if err != nil {
+			t.Errorf("(%d) Failed to decode the transformed image: %v", i, err)
+			continue
+		}
+
+		if format != scenario.expectedFormat {
+			t.Errorf("(%d) Expected format %q, got %q", i, scenario.expectedFormat, format)
+		}
+
+		if config.Width != scenario.expectedWidth || config.Height != scenario.expectedHeight {
+			t.Errorf("(%d) Expected %dx%d image, got %dx%d", i, scenario.expectedWidth, scenario.expectedHeight, config.Width, config.Height)
+		}
+	}
+}
+
+func TestImageTransformContentType(t *testing.T) {
+	scenarios := []struct {
+		transform filesystem.ImageTransform
+		key       string
+		expected  string
+	}{
+		{filesystem.ImageTransform{}, "test.png", "image/png"},
+		{filesystem.ImageTransform{}, "test.JPG", "image/jpeg"},
+		{filesystem.ImageTransform{}, "test.gif", "image/gif"},
+		{filesystem.ImageTransform{}, "test", "image/png"},
+		{filesystem.ImageTransform{Format: "jpg"}, "test.png", "image/jpeg"},
+		{filesystem.ImageTransform{Format: "GIF"}, "test.png", "image/gif"},
+	}
+
+	for i, s := range scenarios {
+		if result := s.transform.ContentType(s.key); result != s.expected {
+			t.Errorf("(%d) Expected %q, got %q", i, s.expected, result)
+		}
+	}
+}
+
 // ---
 
 func createTestDir(t *testing.T) string {
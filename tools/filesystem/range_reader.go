@@ -0,0 +1,113 @@
+package filesystem
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"gocloud.dev/blob"
+)
+
+// rangeReader is a lazy [io.ReadSeeker] that fetches the file content
+// with range reads starting from the current offset.
+//
+// Unlike the regular [blob.Reader], nothing is fetched on initialization
+// and seeking doesn't require reading the entire file from the start
+// (eg. only the requested range is downloaded from S3).
+type rangeReader struct {
+	ctx    context.Context
+	bucket *blob.Bucket
+	key    string
+	size   int64
+
+	// chunkSize is the max number of bytes fetched with a single range read
+	// (0 or negative for no limit).
+	chunkSize int64
+
+	offset int64
+	reader *blob.Reader
+}
+
+func newRangeReader(ctx context.Context, bucket *blob.Bucket, key string, size int64, chunkSize int64) *rangeReader {
+	return &rangeReader{
+		ctx:       ctx,
+		bucket:    bucket,
+		key:       key,
+		size:      size,
+		chunkSize: chunkSize,
+	}
+}
+
+// Read implements [io.Reader] interface.
+func (r *rangeReader) Read(p []byte) (int, error) {
+	if r.offset >= r.size {
+		return 0, io.EOF
+	}
+
+	if r.reader == nil {
+		length := int64(-1)
+		if r.chunkSize > 0 && r.size-r.offset > r.chunkSize {
+			length = r.chunkSize
+		}
+
+		reader, err := r.bucket.NewRangeReader(r.ctx, r.key, r.offset, length, nil)
+		if err != nil {
+			return 0, err
+		}
+		r.reader = reader
+	}
+
+	n, err := r.reader.Read(p)
+	r.offset += int64(n)
+
+	// the current chunk was fully read -> continue with the next one
+	if errors.Is(err, io.EOF) && r.offset < r.size {
+		r.closeReader()
+		err = nil
+	}
+
+	return n, err
+}
+
+// Seek implements [io.Seeker] interface.
+func (r *rangeReader) Seek(offset int64, whence int) (int64, error) {
+	var newOffset int64
+
+	switch whence {
+	case io.SeekStart:
+		newOffset = offset
+	case io.SeekCurrent:
+		newOffset = r.offset + offset
+	case io.SeekEnd:
+		newOffset = r.size + offset
+	default:
+		return 0, errors.New("invalid seek whence")
+	}
+
+	if newOffset < 0 {
+		return 0, errors.New("negative seek offset")
+	}
+
+	if newOffset != r.offset {
+		r.closeReader()
+		r.offset = newOffset
+	}
+
+	return r.offset, nil
+}
+
+// Close implements [io.Closer] interface.
+func (r *rangeReader) Close() error {
+	return r.closeReader()
+}
+
+func (r *rangeReader) closeReader() error {
+	if r.reader == nil {
+		return nil
+	}
+
+	err := r.reader.Close()
+	r.reader = nil
+
+	return err
+}
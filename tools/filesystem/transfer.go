@@ -0,0 +1,139 @@
+package filesystem
+
+import (
+	"context"
+	"io"
+	"sync"
+)
+
+const (
+	// DefaultTransferPartSize is the default size in bytes of the S3
+	// multipart upload parts and of the parallel download ranges.
+	DefaultTransferPartSize int64 = 5 << 20
+
+	// DefaultTransferConcurrency is the default max number
+	// of parts that are uploaded or downloaded in parallel.
+	DefaultTransferConcurrency = 5
+)
+
+// SetTransferOptions sets the part size in bytes and the max number of
+// parallel transferred parts of the uploads and of [System.DownloadTo]
+// (0 for the driver defaults).
+//
+// The uploads part size and concurrency are used only by the
+// drivers that support multipart uploads (eg. S3).
+func (s *System) SetTransferOptions(partSize int64, concurrency int) {
+	s.partSize = partSize
+	s.concurrency = concurrency
+}
+
+// DownloadTo downloads the file with fileKey path into w and returns
+// the number of the written bytes.
+//
+// Files larger than the transfer part size are fetched with multiple
+// concurrent range reads (see [System.SetTransferOptions]).
+func (s *System) DownloadTo(fileKey string, w io.WriterAt) (int64, error) {
+	attrs, err := s.bucket.Attributes(s.ctx, fileKey)
+	if err != nil {
+		return 0, err
+	}
+
+	size := attrs.Size
+
+	if s.partSize <= 0 || s.concurrency <= 1 || size <= s.partSize {
+		r, err := s.bucket.NewReader(s.ctx, fileKey, nil)
+		if err != nil {
+			return 0, err
+		}
+		defer r.Close()
+
+		return io.Copy(&offsetWriter{w: w}, r)
+	}
+
+	ctx, cancel := context.WithCancel(s.ctx)
+	defer cancel()
+
+	offsets := make(chan int64)
+	errs := make(chan error, s.concurrency)
+
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < s.concurrency; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for offset := range offsets {
+				length := s.partSize
+				if offset+length > size {
+					length = size - offset
+				}
+
+				if err := s.downloadPart(ctx, fileKey, w, offset, length); err != nil {
+					errs <- err
+					cancel() // stop the other parts
+					return
+				}
+			}
+		}()
+	}
+
+feed:
+	for offset := int64(0); offset < size; offset += s.partSize {
+		select {
+		case offsets <- offset:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+
+	close(offsets)
+	wg.Wait()
+	close(errs)
+
+	if err := <-errs; err != nil {
+		return 0, err
+	}
+
+	if err := s.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return size, nil
+}
+
+// downloadPart writes the [offset, offset+length) byte range of the fileKey file into w.
+func (s *System) downloadPart(ctx context.Context, fileKey string, w io.WriterAt, offset int64, length int64) error {
+	r, err := s.bucket.NewRangeReader(ctx, fileKey, offset, length, nil)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	n, err := io.Copy(&offsetWriter{w: w, offset: offset}, r)
+	if err != nil {
+		return err
+	}
+
+	if n != length {
+		return io.ErrUnexpectedEOF
+	}
+
+	return nil
+}
+
+// offsetWriter is an [io.Writer] that writes sequentially
+// into the underlying [io.WriterAt] starting from offset.
+type offsetWriter struct {
+	w      io.WriterAt
+	offset int64
+}
+
+// Write implements the [io.Writer] interface.
+func (ow *offsetWriter) Write(p []byte) (int, error) {
+	n, err := ow.w.WriteAt(p, ow.offset)
+	ow.offset += int64(n)
+
+	return n, err
+}
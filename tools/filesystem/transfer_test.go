@@ -0,0 +1,108 @@
+package filesystem_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/filesystem"
+)
+
+func TestFileSystemDownloadTo(t *testing.T) {
+	dir := createTestDir(t)
+	defer os.RemoveAll(dir)
+
+	fs, err := filesystem.NewLocal(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fs.Close()
+
+	content := []byte(strings.Repeat("0123456789abcdef", 100))
+
+	if err := fs.Upload(content, "large.txt"); err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		name        string
+		key         string
+		partSize    int64
+		concurrency int
+		expectError bool
+	}{
+		{"missing file", "missing.txt", 0, 0, true},
+		{"sequential download", "large.txt", 0, 0, false},
+		{"single part", "large.txt", 5000, 4, false},
+		{"parallel download", "large.txt", 7, 3, false},
+		{"parallel download with exact parts", "large.txt", 100, 16, false},
+	}
+
+	for _, s := range scenarios {
+		fs.SetTransferOptions(s.partSize, s.concurrency)
+
+		dest, err := os.Create(filepath.Join(dir, "dest.txt"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		n, err := fs.DownloadTo(s.key, dest)
+		dest.Close()
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Fatalf("[%s] Expected hasErr %v, got %v (%v)", s.name, s.expectError, hasErr, err)
+		}
+
+		if hasErr {
+			continue
+		}
+
+		if n != int64(len(content)) {
+			t.Fatalf("[%s] Expected %d written bytes, got %d", s.name, len(content), n)
+		}
+
+		downloaded, err := os.ReadFile(dest.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(downloaded, content) {
+			t.Fatalf("[%s] The downloaded content doesn't match the original file", s.name)
+		}
+	}
+}
+
+func TestFileSystemDownloadToCanceled(t *testing.T) {
+	dir := createTestDir(t)
+	defer os.RemoveAll(dir)
+
+	fs, err := filesystem.NewLocal(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fs.Close()
+
+	if err := fs.Upload([]byte(strings.Repeat("a", 1000)), "large.txt"); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	fs.SetContext(ctx)
+	fs.SetTransferOptions(10, 4)
+
+	dest, err := os.Create(filepath.Join(dir, "dest.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dest.Close()
+
+	if _, err := fs.DownloadTo("large.txt", dest); err == nil {
+		t.Fatal("Expected error for canceled context, got nil")
+	}
+}
@@ -0,0 +1,160 @@
+// Package flags implements feature flags evaluation with targeting
+// rules and percentage rollouts.
+//
+// A flag is evaluated against a [Subject] (usually the requesting
+// auth record) in the following order:
+//
+//   - a disabled flag is always off
+//   - the first rule that matches the subject attributes decides
+//     the result based on its rollout percentage
+//   - if no rule matches, the flag rollout percentage is applied
+//
+// The percentage rollouts are deterministic - the same subject always
+// falls in the same bucket of the same flag.
+package flags
+
+import (
+	"hash/fnv"
+
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/spf13/cast"
+)
+
+// List of the evaluation result reasons.
+const (
+	ReasonDisabled = "disabled"
+	ReasonRule     = "rule"
+	ReasonRollout  = "rollout"
+)
+
+// Rule defines a single flag targeting rule.
+type Rule struct {
+	// Attribute is the subject attribute to match (eg. "groups" or "email").
+	//
+	// Leave empty to match all subjects.
+	Attribute string `json:"attribute"`
+
+	// Values is the list of the attribute values to match.
+	//
+	// For multiple values attributes (eg. groups) the rule matches
+	// if at least one of the subject values is in the list.
+	Values []string `json:"values"`
+
+	// Percentage is the rollout percentage (0-100)
+	// of the subjects matching the rule.
+	Percentage int `json:"percentage"`
+}
+
+// Matches checks whether the rule targets the provided subject.
+func (r Rule) Matches(subject Subject) bool {
+	if r.Attribute == "" {
+		return true
+	}
+
+	for _, v := range subject.Values(r.Attribute) {
+		if list.ExistInSlice(v, r.Values) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Flag defines a feature flag.
+type Flag struct {
+	Key     string
+	Enabled bool
+
+	// Rollout is the default rollout percentage (0-100)
+	// of the subjects not matching any of the rules.
+	Rollout int
+
+	Rules []Rule
+}
+
+// Subject is the entity that the flags are evaluated for.
+type Subject struct {
+	// Id is the stable subject identifier used for the percentage rollouts.
+	//
+	// Subjects without an id (eg. guests) are included only in the 100% rollouts.
+	Id string
+
+	Attributes map[string]any
+}
+
+// Values returns the string values of the named subject attribute.
+func (s Subject) Values(attribute string) []string {
+	raw, ok := s.Attributes[attribute]
+	if !ok {
+		return nil
+	}
+
+	values := list.ToUniqueStringSlice(raw)
+
+	// scalar non string values (eg. bool or number)
+	if len(values) == 0 {
+		if v := cast.ToString(raw); v != "" {
+			values = []string{v}
+		}
+	}
+
+	return values
+}
+
+// Result is a single flag evaluation result.
+type Result struct {
+	Enabled bool   `json:"enabled"`
+	Reason  string `json:"reason"`
+
+	// Rule is the index of the matched rule (nil if no rule matched).
+	Rule *int `json:"rule,omitempty"`
+}
+
+// Evaluate evaluates the flag for the provided subject.
+func Evaluate(flag Flag, subject Subject) Result {
+	if !flag.Enabled {
+		return Result{Reason: ReasonDisabled}
+	}
+
+	for i, rule := range flag.Rules {
+		if rule.Matches(subject) {
+			index := i
+			return Result{
+				Enabled: InRollout(flag.Key, subject.Id, rule.Percentage),
+				Reason:  ReasonRule,
+				Rule:    &index,
+			}
+		}
+	}
+
+	return Result{
+		Enabled: InRollout(flag.Key, subject.Id, flag.Rollout),
+		Reason:  ReasonRollout,
+	}
+}
+
+// InRollout checks whether the subject falls in the flag rollout percentage.
+func InRollout(key string, subjectId string, percentage int) bool {
+	switch {
+	case percentage >= 100:
+		return true
+	case percentage <= 0 || subjectId == "":
+		return false
+	default:
+		return Bucket(key, subjectId) < percentage
+	}
+}
+
+// Bucket returns the deterministic rollout bucket (0-99)
+// of the subject for the specified flag key.
+//
+// The flag key is part of the hash so that the different flags
+// don't target the same subjects with the same percentage.
+func Bucket(key string, subjectId string) int {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	h.Write([]byte{0})
+	h.Write([]byte(subjectId))
+
+	return int(h.Sum32() % 100)
+}
@@ -0,0 +1,147 @@
+package flags_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/flags"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestRuleMatches(t *testing.T) {
+	subject := flags.Subject{
+		Id: "test",
+		Attributes: map[string]any{
+			"email":    "test@example.com",
+			"verified": true,
+			"age":      30,
+			"groups":   []string{"beta", "staff"},
+			"roles":    types.JsonArray[string]{"editor"},
+			"tags":     `["a","b"]`,
+		},
+	}
+
+	scenarios := []struct {
+		rule     flags.Rule
+		expected bool
+	}{
+		{flags.Rule{}, true},
+		{flags.Rule{Attribute: "missing", Values: []string{"a"}}, false},
+		{flags.Rule{Attribute: "email", Values: []string{"other@example.com"}}, false},
+		{flags.Rule{Attribute: "email", Values: []string{"other@example.com", "test@example.com"}}, true},
+		{flags.Rule{Attribute: "verified", Values: []string{"false"}}, false},
+		{flags.Rule{Attribute: "verified", Values: []string{"true"}}, true},
+		{flags.Rule{Attribute: "age", Values: []string{"30"}}, true},
+		{flags.Rule{Attribute: "groups", Values: []string{"alpha"}}, false},
+		{flags.Rule{Attribute: "groups", Values: []string{"alpha", "staff"}}, true},
+		{flags.Rule{Attribute: "roles", Values: []string{"editor"}}, true},
+		{flags.Rule{Attribute: "tags", Values: []string{"b"}}, true},
+	}
+
+	for i, s := range scenarios {
+		result := s.rule.Matches(subject)
+		if result != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, result)
+		}
+	}
+}
+
+func TestInRollout(t *testing.T) {
+	scenarios := []struct {
+		subjectId  string
+		percentage int
+		expected   bool
+	}{
+		{"", 0, false},
+		{"", 50, false},
+		{"", 100, true},
+		{"test", -1, false},
+		{"test", 0, false},
+		{"test", 100, true},
+		{"test", 101, true},
+	}
+
+	for i, s := range scenarios {
+		result := flags.InRollout("flag", s.subjectId, s.percentage)
+		if result != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, result)
+		}
+	}
+}
+
+func TestBucket(t *testing.T) {
+	// deterministic
+	if flags.Bucket("flag", "test") != flags.Bucket("flag", "test") {
+		t.Fatal("Expected the same bucket for the same flag and subject")
+	}
+
+	// roughly uniform distribution
+	enabled := 0
+	for i := 0; i < 10000; i++ {
+		if flags.InRollout("flag", fmt.Sprintf("subject%d", i), 30) {
+			enabled++
+		}
+	}
+	if enabled < 2700 || enabled > 3300 {
+		t.Fatalf("Expected ~30%% of the subjects to be in the rollout, got %d/10000", enabled)
+	}
+
+	// the buckets of different flags are independent
+	different := 0
+	for i := 0; i < 100; i++ {
+		id := fmt.Sprintf("subject%d", i)
+		if flags.Bucket("flag1", id) != flags.Bucket("flag2", id) {
+			different++
+		}
+	}
+	if different < 50 {
+		t.Fatalf("Expected the flag key to affect the buckets, got only %d different", different)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	beta := flags.Subject{Id: "test", Attributes: map[string]any{"groups": []string{"beta"}}}
+	other := flags.Subject{Id: "test", Attributes: map[string]any{"groups": []string{"other"}}}
+	guest := flags.Subject{}
+
+	rules := []flags.Rule{
+		{Attribute: "groups", Values: []string{"beta"}, Percentage: 100},
+		{Attribute: "groups", Values: []string{"other"}, Percentage: 0},
+	}
+
+	scenarios := []struct {
+		name           string
+		flag           flags.Flag
+		subject        flags.Subject
+		expected       bool
+		expectedReason string
+		expectedRule   int
+	}{
+		{"disabled", flags.Flag{Key: "f", Rollout: 100}, beta, false, flags.ReasonDisabled, -1},
+		{"no rules rollout 0", flags.Flag{Key: "f", Enabled: true}, beta, false, flags.ReasonRollout, -1},
+		{"no rules rollout 100", flags.Flag{Key: "f", Enabled: true, Rollout: 100}, guest, true, flags.ReasonRollout, -1},
+		{"first rule match", flags.Flag{Key: "f", Enabled: true, Rules: rules}, beta, true, flags.ReasonRule, 0},
+		{"second rule match", flags.Flag{Key: "f", Enabled: true, Rollout: 100, Rules: rules}, other, false, flags.ReasonRule, 1},
+		{"no rule match", flags.Flag{Key: "f", Enabled: true, Rollout: 100, Rules: rules}, guest, true, flags.ReasonRollout, -1},
+	}
+
+	for _, s := range scenarios {
+		result := flags.Evaluate(s.flag, s.subject)
+
+		if result.Enabled != s.expected {
+			t.Errorf("[%s] Expected %v, got %v", s.name, s.expected, result.Enabled)
+		}
+
+		if result.Reason != s.expectedReason {
+			t.Errorf("[%s] Expected reason %q, got %q", s.name, s.expectedReason, result.Reason)
+		}
+
+		rule := -1
+		if result.Rule != nil {
+			rule = *result.Rule
+		}
+		if rule != s.expectedRule {
+			t.Errorf("[%s] Expected rule %d, got %d", s.name, s.expectedRule, rule)
+		}
+	}
+}
@@ -0,0 +1,353 @@
+// Package graphql implements a minimal parser of the GraphQL executable
+// documents (queries and mutations) and the helpers to build the
+// GraphQL over HTTP responses.
+//
+// The type system definitions, the schema validation and the
+// introspection queries are not supported, so the fields resolving
+// and the arguments validation are left to the executor.
+//
+// https://spec.graphql.org/October2021/#sec-Executable-Definitions
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cast"
+)
+
+// List with the GraphQL operation types.
+const (
+	OperationQuery        string = "query"
+	OperationMutation     string = "mutation"
+	OperationSubscription string = "subscription"
+)
+
+// MaxDepth is the max allowed nesting level of the selection sets.
+const MaxDepth = 20
+
+// Request is the GraphQL over HTTP request body.
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// Error is a single GraphQL response error.
+type Error struct {
+	Message    string         `json:"message"`
+	Path       []any          `json:"path,omitempty"`
+	Extensions map[string]any `json:"extensions,omitempty"`
+}
+
+// Response is the GraphQL over HTTP response body.
+type Response struct {
+	Data   any      `json:"data"`
+	Errors []*Error `json:"errors,omitempty"`
+}
+
+// Field is a single selected field with its resolved arguments
+// (the fragments are inlined and the variables are replaced).
+type Field struct {
+	Alias      string
+	Name       string
+	Arguments  map[string]any
+	Selections []*Field
+}
+
+// Key returns the field response key (its alias or name).
+func (f *Field) Key() string {
+	if f.Alias != "" {
+		return f.Alias
+	}
+
+	return f.Name
+}
+
+// Operation is a single parsed GraphQL operation.
+type Operation struct {
+	Type   string
+	Name   string
+	Fields []*Field
+}
+
+// Parse parses the query document and returns the operation with the
+// provided name (could be empty if the document has a single operation).
+func Parse(query string, operationName string, variables map[string]any) (*Operation, error) {
+	doc, err := parseDocument(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var op *astOperation
+
+	if operationName == "" {
+		if len(doc.operations) > 1 {
+			return nil, errors.New("the operation name is required when the document contains multiple operations")
+		}
+		op = doc.operations[0]
+	} else {
+		for _, item := range doc.operations {
+			if item.name == operationName {
+				op = item
+				break
+			}
+		}
+		if op == nil {
+			return nil, fmt.Errorf("unknown operation %q", operationName)
+		}
+	}
+
+	vars := make(map[string]any, len(op.variables))
+	for _, v := range op.variables {
+		if value, ok := variables[v.name]; ok {
+			vars[v.name] = value
+			continue
+		}
+
+		if v.defaultValue != nil {
+			value, err := v.defaultValue.resolve(nil)
+			if err != nil {
+				return nil, err
+			}
+			vars[v.name] = value
+		} else {
+			vars[v.name] = nil
+		}
+	}
+
+	c := &collector{fragments: doc.fragments, variables: vars, visiting: map[string]bool{}}
+
+	fields, err := c.collect(op.selections, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Operation{Type: op.operationType, Name: op.name, Fields: fields}, nil
+}
+
+// collector flattens the selection sets into fields.
+type collector struct {
+	fragments map[string]*astFragment
+	variables map[string]any
+	visiting  map[string]bool
+}
+
+func (c *collector) collect(selections []*astSelection, depth int) ([]*Field, error) {
+	if depth > MaxDepth {
+		return nil, fmt.Errorf("the max selection depth of %d is exceeded", MaxDepth)
+	}
+
+	var fields []*Field
+
+	byKey := map[string]*Field{}
+
+	add := func(field *Field) {
+		// merge the selections of the fields with the same response key
+		if existing, ok := byKey[field.Key()]; ok {
+			existing.Selections = append(existing.Selections, field.Selections...)
+			return
+		}
+
+		byKey[field.Key()] = field
+		fields = append(fields, field)
+	}
+
+	for _, selection := range selections {
+		include, err := c.isIncluded(selection.directives)
+		if err != nil {
+			return nil, err
+		}
+		if !include {
+			continue
+		}
+
+		switch selection.kind {
+		case selectionFragmentSpread:
+			fragment, ok := c.fragments[selection.name]
+			if !ok {
+				return nil, fmt.Errorf("unknown fragment %q", selection.name)
+			}
+
+			if c.visiting[selection.name] {
+				return nil, fmt.Errorf("cyclic fragment %q", selection.name)
+			}
+
+			c.visiting[selection.name] = true
+			subfields, err := c.collect(fragment.selections, depth)
+			c.visiting[selection.name] = false
+			if err != nil {
+				return nil, err
+			}
+
+			for _, f := range subfields {
+				add(f)
+			}
+		case selectionInlineFragment:
+			subfields, err := c.collect(selection.selections, depth)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, f := range subfields {
+				add(f)
+			}
+		default:
+			field := &Field{
+				Alias:     selection.alias,
+				Name:      selection.name,
+				Arguments: make(map[string]any, len(selection.args)),
+			}
+
+			for _, arg := range selection.args {
+				value, err := arg.value.resolve(c.variables)
+				if err != nil {
+					return nil, err
+				}
+				field.Arguments[arg.name] = value
+			}
+
+			if len(selection.selections) > 0 {
+				subfields, err := c.collect(selection.selections, depth+1)
+				if err != nil {
+					return nil, err
+				}
+				field.Selections = subfields
+			}
+
+			add(field)
+		}
+	}
+
+	return fields, nil
+}
+
+// isIncluded evaluates the @skip and @include directives.
+func (c *collector) isIncluded(directives []*astDirective) (bool, error) {
+	for _, directive := range directives {
+		if directive.name != "skip" && directive.name != "include" {
+			continue
+		}
+
+		var condition bool
+		for _, arg := range directive.args {
+			if arg.name != "if" {
+				continue
+			}
+
+			value, err := arg.value.resolve(c.variables)
+			if err != nil {
+				return false, err
+			}
+			condition = cast.ToBool(value)
+		}
+
+		if (directive.name == "skip") == condition {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// -------------------------------------------------------------------
+
+// Object is a JSON object that preserves the insertion order of its keys
+// (the GraphQL responses must follow the order of the selected fields).
+type Object struct {
+	keys   []string
+	values map[string]any
+}
+
+// NewObject creates a new empty Object.
+func NewObject() *Object {
+	return &Object{values: map[string]any{}}
+}
+
+// Set sets the value of the provided key (new keys are appended).
+func (o *Object) Set(key string, value any) {
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+
+	o.values[key] = value
+}
+
+// Get returns the value of the provided key.
+func (o *Object) Get(key string) (any, bool) {
+	value, ok := o.values[key]
+
+	return value, ok
+}
+
+// Keys returns the object keys in their insertion order.
+func (o *Object) Keys() []string {
+	return o.keys
+}
+
+// MarshalJSON implements the [json.Marshaler] interface.
+func (o *Object) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteByte('{')
+
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+
+		buf.WriteByte(':')
+
+		v, err := json.Marshal(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(v)
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// Select projects the selected fields of a json-like value
+// (maps, slices and scalars).
+//
+// The values without selections are returned as they are and the
+// missing keys are resolved to nil.
+func Select(value any, fields []*Field) any {
+	if len(fields) == 0 || value == nil {
+		return value
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		result := NewObject()
+		for _, field := range fields {
+			result.Set(field.Key(), Select(v[field.Name], field.Selections))
+		}
+		return result
+	case []map[string]any:
+		result := make([]any, len(v))
+		for i, item := range v {
+			result[i] = Select(item, fields)
+		}
+		return result
+	case []any:
+		result := make([]any, len(v))
+		for i, item := range v {
+			result[i] = Select(item, fields)
+		}
+		return result
+	}
+
+	return value
+}
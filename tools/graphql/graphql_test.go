@@ -0,0 +1,214 @@
+package graphql_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/graphql"
+)
+
+func TestParse(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		query         string
+		operationName string
+		variables     map[string]any
+		expectError   bool
+		expectedType  string
+		expectedJson  string
+	}{
+		{
+			"empty document",
+			"",
+			"",
+			nil,
+			true,
+			"",
+			"",
+		},
+		{
+			"invalid syntax",
+			"{ a(b: ) }",
+			"",
+			nil,
+			true,
+			"",
+			"",
+		},
+		{
+			"unterminated string",
+			`{ a(b: "test) }`,
+			"",
+			nil,
+			true,
+			"",
+			"",
+		},
+		{
+			"multiple operations without name",
+			"query A { a } query B { b }",
+			"",
+			nil,
+			true,
+			"",
+			"",
+		},
+		{
+			"unknown operation",
+			"query A { a }",
+			"B",
+			nil,
+			true,
+			"",
+			"",
+		},
+		{
+			"unknown fragment",
+			"{ ...Missing }",
+			"",
+			nil,
+			true,
+			"",
+			"",
+		},
+		{
+			"cyclic fragments",
+			"{ ...A } fragment A on T { a ...B } fragment B on T { ...A }",
+			"",
+			nil,
+			true,
+			"",
+			"",
+		},
+		{
+			"undefined variable",
+			"{ a(b: $c) }",
+			"",
+			nil,
+			true,
+			"",
+			"",
+		},
+		{
+			"shorthand query",
+			`
+			# comment
+			{ a, b { c } }`,
+			"",
+			nil,
+			false,
+			"query",
+			`[{"Alias":"","Name":"a","Arguments":{},"Selections":null},{"Alias":"","Name":"b","Arguments":{},"Selections":[{"Alias":"","Name":"c","Arguments":{},"Selections":null}]}]`,
+		},
+		{
+			"arguments, aliases and variables",
+			`query Test($id: String!, $limit: Int = 10, $data: JSON) {
+				first: item(id: $id, limit: $limit, data: $data, list: [1, 2.5, "x\nA", true, null, ENUM], obj: {a: {b: """ block """}}) { id }
+			}`,
+			"Test",
+			map[string]any{"id": "test", "data": map[string]any{"title": "a"}},
+			false,
+			"query",
+			`[{"Alias":"first","Name":"item","Arguments":{"data":{"title":"a"},"id":"test","limit":10,"list":[1,2.5,"x\nA",true,null,"ENUM"],"obj":{"a":{"b":"block"}}},"Selections":[{"Alias":"","Name":"id","Arguments":{},"Selections":null}]}]`,
+		},
+		{
+			"mutation with fragments and directives",
+			`mutation M($skip: Boolean = true) {
+				create(data: {}) {
+					...F
+					... on T { b }
+					c @skip(if: $skip)
+					d @include(if: false)
+					a { y }
+				}
+			}
+			fragment F on T { a { x } }`,
+			"M",
+			nil,
+			false,
+			"mutation",
+			`[{"Alias":"","Name":"create","Arguments":{"data":{}},"Selections":[{"Alias":"","Name":"a","Arguments":{},"Selections":[{"Alias":"","Name":"x","Arguments":{},"Selections":null},{"Alias":"","Name":"y","Arguments":{},"Selections":null}]},{"Alias":"","Name":"b","Arguments":{},"Selections":null}]}]`,
+		},
+	}
+
+	for _, s := range scenarios {
+		op, err := graphql.Parse(s.query, s.operationName, s.variables)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("[%s] Expected hasErr %v, got %v (%v)", s.name, s.expectError, hasErr, err)
+			continue
+		}
+
+		if hasErr {
+			continue
+		}
+
+		if op.Type != s.expectedType {
+			t.Errorf("[%s] Expected operation type %q, got %q", s.name, s.expectedType, op.Type)
+		}
+
+		raw, _ := json.Marshal(op.Fields)
+		if string(raw) != s.expectedJson {
+			t.Errorf("[%s] Expected fields \n%s, \ngot \n%s", s.name, s.expectedJson, raw)
+		}
+	}
+}
+
+func TestParseMaxDepth(t *testing.T) {
+	query := ""
+	for i := 0; i <= graphql.MaxDepth+1; i++ {
+		query += "{ a "
+	}
+	for i := 0; i <= graphql.MaxDepth+1; i++ {
+		query += "}"
+	}
+
+	if _, err := graphql.Parse(query, "", nil); err == nil {
+		t.Fatal("Expected max depth error, got nil")
+	}
+}
+
+func TestObjectMarshalJSON(t *testing.T) {
+	obj := graphql.NewObject()
+	obj.Set("b", 1)
+	obj.Set("a", "test")
+	obj.Set("c", nil)
+	obj.Set("b", 2)
+
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"b":2,"a":"test","c":null}`
+	if string(raw) != expected {
+		t.Fatalf("Expected %s, got %s", expected, raw)
+	}
+
+	if v, ok := obj.Get("a"); !ok || v != "test" {
+		t.Fatalf("Expected a to be test, got %v", v)
+	}
+}
+
+func TestSelect(t *testing.T) {
+	op, err := graphql.Parse(`{ root { b, alias: a, missing, nested { y } } }`, "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	value := []any{
+		map[string]any{"a": 1, "b": []any{1, 2}, "c": 3, "nested": map[string]any{"x": 1, "y": 2}},
+		map[string]any{"a": 2, "nested": nil},
+	}
+
+	raw, err := json.Marshal(graphql.Select(value, op.Fields[0].Selections))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `[{"b":[1,2],"alias":1,"missing":null,"nested":{"y":2}},{"b":null,"alias":2,"missing":null,"nested":null}]`
+	if string(raw) != expected {
+		t.Fatalf("Expected \n%s, \ngot \n%s", expected, raw)
+	}
+}
@@ -0,0 +1,625 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+)
+
+type valueKind int
+
+const (
+	valueVariable valueKind = iota
+	valueInt
+	valueFloat
+	valueString
+	valueBoolean
+	valueNull
+	valueEnum
+	valueList
+	valueObject
+)
+
+type astValue struct {
+	kind   valueKind
+	raw    string
+	list   []*astValue
+	fields []*astArgument
+}
+
+type astArgument struct {
+	name  string
+	value *astValue
+}
+
+type astDirective struct {
+	name string
+	args []*astArgument
+}
+
+type selectionKind int
+
+const (
+	selectionField selectionKind = iota
+	selectionFragmentSpread
+	selectionInlineFragment
+)
+
+type astSelection struct {
+	kind       selectionKind
+	alias      string
+	name       string
+	args       []*astArgument
+	directives []*astDirective
+	selections []*astSelection
+}
+
+type astVariable struct {
+	name         string
+	defaultValue *astValue
+}
+
+type astOperation struct {
+	operationType string
+	name          string
+	variables     []*astVariable
+	directives    []*astDirective
+	selections    []*astSelection
+}
+
+type astFragment struct {
+	name       string
+	selections []*astSelection
+}
+
+type astDocument struct {
+	operations []*astOperation
+	fragments  map[string]*astFragment
+}
+
+// parser is a recursive descent parser of the GraphQL executable
+// documents (the type system definitions are not supported).
+type parser struct {
+	lexer *lexer
+	tok   token
+}
+
+func parseDocument(src string) (*astDocument, error) {
+	p := &parser{lexer: &lexer{src: src}}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	doc := &astDocument{fragments: map[string]*astFragment{}}
+
+	for p.tok.kind != tokenEOF {
+		switch {
+		case p.isPunctuator("{"):
+			selections, err := p.parseSelectionSet()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, &astOperation{operationType: OperationQuery, selections: selections})
+		case p.isName(OperationQuery), p.isName(OperationMutation), p.isName(OperationSubscription):
+			op, err := p.parseOperation()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, op)
+		case p.isName("fragment"):
+			fragment, err := p.parseFragment()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := doc.fragments[fragment.name]; ok {
+				return nil, fmt.Errorf("duplicated fragment %q", fragment.name)
+			}
+			doc.fragments[fragment.name] = fragment
+		default:
+			return nil, p.unexpected()
+		}
+	}
+
+	if len(doc.operations) == 0 {
+		return nil, fmt.Errorf("the document must contain at least one operation")
+	}
+
+	return doc, nil
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lexer.next()
+	if err != nil {
+		return err
+	}
+
+	p.tok = tok
+
+	return nil
+}
+
+func (p *parser) isPunctuator(value string) bool {
+	return p.tok.kind == tokenPunctuator && p.tok.value == value
+}
+
+func (p *parser) isName(value string) bool {
+	return p.tok.kind == tokenName && p.tok.value == value
+}
+
+func (p *parser) unexpected() error {
+	if p.tok.kind == tokenEOF {
+		return fmt.Errorf("unexpected end of the document")
+	}
+
+	return fmt.Errorf("unexpected %q at position %d", p.tok.value, p.tok.pos)
+}
+
+func (p *parser) expectPunctuator(value string) error {
+	if !p.isPunctuator(value) {
+		return p.unexpected()
+	}
+
+	return p.advance()
+}
+
+func (p *parser) expectName() (string, error) {
+	if p.tok.kind != tokenName {
+		return "", p.unexpected()
+	}
+
+	name := p.tok.value
+
+	return name, p.advance()
+}
+
+func (p *parser) parseOperation() (*astOperation, error) {
+	op := &astOperation{operationType: p.tok.value}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	if p.tok.kind == tokenName {
+		op.name = p.tok.value
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.isPunctuator("(") {
+		variables, err := p.parseVariableDefinitions()
+		if err != nil {
+			return nil, err
+		}
+		op.variables = variables
+	}
+
+	directives, err := p.parseDirectives()
+	if err != nil {
+		return nil, err
+	}
+	op.directives = directives
+
+	selections, err := p.parseSelectionSet()
+	if err != nil {
+		return nil, err
+	}
+	op.selections = selections
+
+	return op, nil
+}
+
+func (p *parser) parseVariableDefinitions() ([]*astVariable, error) {
+	if err := p.expectPunctuator("("); err != nil {
+		return nil, err
+	}
+
+	var variables []*astVariable
+
+	for !p.isPunctuator(")") {
+		if err := p.expectPunctuator("$"); err != nil {
+			return nil, err
+		}
+
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.expectPunctuator(":"); err != nil {
+			return nil, err
+		}
+
+		if err := p.skipType(); err != nil {
+			return nil, err
+		}
+
+		variable := &astVariable{name: name}
+
+		if p.isPunctuator("=") {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+
+			value, err := p.parseValue(true)
+			if err != nil {
+				return nil, err
+			}
+			variable.defaultValue = value
+		}
+
+		if _, err := p.parseDirectives(); err != nil {
+			return nil, err
+		}
+
+		variables = append(variables, variable)
+	}
+
+	return variables, p.advance()
+}
+
+// skipType consumes a variable type reference (eg. "[String!]!").
+//
+// The variable types are not validated since the field arguments
+// are dynamically typed.
+func (p *parser) skipType() error {
+	if p.isPunctuator("[") {
+		if err := p.advance(); err != nil {
+			return err
+		}
+
+		if err := p.skipType(); err != nil {
+			return err
+		}
+
+		if err := p.expectPunctuator("]"); err != nil {
+			return err
+		}
+	} else if _, err := p.expectName(); err != nil {
+		return err
+	}
+
+	if p.isPunctuator("!") {
+		return p.advance()
+	}
+
+	return nil
+}
+
+func (p *parser) parseFragment() (*astFragment, error) {
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	name, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+
+	if name == "on" {
+		return nil, fmt.Errorf("invalid fragment name %q", name)
+	}
+
+	if !p.isName("on") {
+		return nil, p.unexpected()
+	}
+
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	if _, err := p.expectName(); err != nil {
+		return nil, err
+	}
+
+	if _, err := p.parseDirectives(); err != nil {
+		return nil, err
+	}
+
+	selections, err := p.parseSelectionSet()
+	if err != nil {
+		return nil, err
+	}
+
+	return &astFragment{name: name, selections: selections}, nil
+}
+
+func (p *parser) parseSelectionSet() ([]*astSelection, error) {
+	if err := p.expectPunctuator("{"); err != nil {
+		return nil, err
+	}
+
+	var selections []*astSelection
+
+	for !p.isPunctuator("}") {
+		selection, err := p.parseSelection()
+		if err != nil {
+			return nil, err
+		}
+
+		selections = append(selections, selection)
+	}
+
+	if len(selections) == 0 {
+		return nil, fmt.Errorf("empty selection set at position %d", p.tok.pos)
+	}
+
+	return selections, p.advance()
+}
+
+func (p *parser) parseSelection() (*astSelection, error) {
+	if p.isPunctuator("...") {
+		return p.parseFragmentSelection()
+	}
+
+	selection := &astSelection{kind: selectionField}
+
+	name, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+	selection.name = name
+
+	if p.isPunctuator(":") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+
+		selection.alias = selection.name
+		selection.name = name
+	}
+
+	if p.isPunctuator("(") {
+		args, err := p.parseArguments()
+		if err != nil {
+			return nil, err
+		}
+		selection.args = args
+	}
+
+	directives, err := p.parseDirectives()
+	if err != nil {
+		return nil, err
+	}
+	selection.directives = directives
+
+	if p.isPunctuator("{") {
+		selections, err := p.parseSelectionSet()
+		if err != nil {
+			return nil, err
+		}
+		selection.selections = selections
+	}
+
+	return selection, nil
+}
+
+func (p *parser) parseFragmentSelection() (*astSelection, error) {
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	// fragment spread
+	if p.tok.kind == tokenName && p.tok.value != "on" {
+		selection := &astSelection{kind: selectionFragmentSpread, name: p.tok.value}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		directives, err := p.parseDirectives()
+		if err != nil {
+			return nil, err
+		}
+		selection.directives = directives
+
+		return selection, nil
+	}
+
+	// inline fragment (the type condition is ignored)
+	selection := &astSelection{kind: selectionInlineFragment}
+
+	if p.isName("on") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		if _, err := p.expectName(); err != nil {
+			return nil, err
+		}
+	}
+
+	directives, err := p.parseDirectives()
+	if err != nil {
+		return nil, err
+	}
+	selection.directives = directives
+
+	selections, err := p.parseSelectionSet()
+	if err != nil {
+		return nil, err
+	}
+	selection.selections = selections
+
+	return selection, nil
+}
+
+func (p *parser) parseArguments() ([]*astArgument, error) {
+	if err := p.expectPunctuator("("); err != nil {
+		return nil, err
+	}
+
+	var args []*astArgument
+
+	for !p.isPunctuator(")") {
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.expectPunctuator(":"); err != nil {
+			return nil, err
+		}
+
+		value, err := p.parseValue(false)
+		if err != nil {
+			return nil, err
+		}
+
+		args = append(args, &astArgument{name: name, value: value})
+	}
+
+	return args, p.advance()
+}
+
+func (p *parser) parseDirectives() ([]*astDirective, error) {
+	var directives []*astDirective
+
+	for p.isPunctuator("@") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+
+		directive := &astDirective{name: name}
+
+		if p.isPunctuator("(") {
+			args, err := p.parseArguments()
+			if err != nil {
+				return nil, err
+			}
+			directive.args = args
+		}
+
+		directives = append(directives, directive)
+	}
+
+	return directives, nil
+}
+
+func (p *parser) parseValue(isConst bool) (*astValue, error) {
+	tok := p.tok
+
+	switch {
+	case p.isPunctuator("$") && !isConst:
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+
+		return &astValue{kind: valueVariable, raw: name}, nil
+	case p.isPunctuator("["):
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		value := &astValue{kind: valueList, list: []*astValue{}}
+
+		for !p.isPunctuator("]") {
+			item, err := p.parseValue(isConst)
+			if err != nil {
+				return nil, err
+			}
+			value.list = append(value.list, item)
+		}
+
+		return value, p.advance()
+	case p.isPunctuator("{"):
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		value := &astValue{kind: valueObject, fields: []*astArgument{}}
+
+		for !p.isPunctuator("}") {
+			name, err := p.expectName()
+			if err != nil {
+				return nil, err
+			}
+
+			if err := p.expectPunctuator(":"); err != nil {
+				return nil, err
+			}
+
+			item, err := p.parseValue(isConst)
+			if err != nil {
+				return nil, err
+			}
+
+			value.fields = append(value.fields, &astArgument{name: name, value: item})
+		}
+
+		return value, p.advance()
+	case tok.kind == tokenInt:
+		return &astValue{kind: valueInt, raw: tok.value}, p.advance()
+	case tok.kind == tokenFloat:
+		return &astValue{kind: valueFloat, raw: tok.value}, p.advance()
+	case tok.kind == tokenString:
+		return &astValue{kind: valueString, raw: tok.value}, p.advance()
+	case tok.kind == tokenName:
+		switch tok.value {
+		case "true", "false":
+			return &astValue{kind: valueBoolean, raw: tok.value}, p.advance()
+		case "null":
+			return &astValue{kind: valueNull}, p.advance()
+		default:
+			return &astValue{kind: valueEnum, raw: tok.value}, p.advance()
+		}
+	}
+
+	return nil, p.unexpected()
+}
+
+// resolve converts the value into its Go representation
+// (the variables are replaced with their provided values).
+func (v *astValue) resolve(variables map[string]any) (any, error) {
+	switch v.kind {
+	case valueVariable:
+		value, ok := variables[v.raw]
+		if !ok {
+			return nil, fmt.Errorf("undefined variable $%s", v.raw)
+		}
+		return value, nil
+	case valueInt:
+		return strconv.ParseInt(v.raw, 10, 64)
+	case valueFloat:
+		return strconv.ParseFloat(v.raw, 64)
+	case valueBoolean:
+		return v.raw == "true", nil
+	case valueNull:
+		return nil, nil
+	case valueList:
+		result := make([]any, len(v.list))
+		for i, item := range v.list {
+			resolved, err := item.resolve(variables)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = resolved
+		}
+		return result, nil
+	case valueObject:
+		result := make(map[string]any, len(v.fields))
+		for _, field := range v.fields {
+			resolved, err := field.value.resolve(variables)
+			if err != nil {
+				return nil, err
+			}
+			result[field.name] = resolved
+		}
+		return result, nil
+	}
+
+	// string and enum
+	return v.raw, nil
+}
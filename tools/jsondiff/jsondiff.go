@@ -0,0 +1,89 @@
+// Package jsondiff implements a field level diff between the json
+// representations of two values.
+package jsondiff
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// Change defines a single field change.
+type Change struct {
+	Old any `json:"old"`
+	New any `json:"new"`
+}
+
+// Diff returns the changed fields between the json serialized oldValue
+// and newValue indexed by their json path (eg. "options.minLength").
+//
+// Nested objects are flattened, arrays are compared as a whole.
+// A nil oldValue or newValue is treated as an empty object
+// (eg. on create or delete).
+func Diff(oldValue any, newValue any) (map[string]Change, error) {
+	oldFields, err := Flatten(oldValue)
+	if err != nil {
+		return nil, err
+	}
+
+	newFields, err := Flatten(newValue)
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]Change{}
+
+	for path, newV := range newFields {
+		if oldV, ok := oldFields[path]; !ok || !reflect.DeepEqual(oldV, newV) {
+			result[path] = Change{Old: oldFields[path], New: newV}
+		}
+	}
+
+	for path, oldV := range oldFields {
+		if _, ok := newFields[path]; !ok {
+			result[path] = Change{Old: oldV, New: nil}
+		}
+	}
+
+	return result, nil
+}
+
+// Flatten serializes the provided value into a flat json path -> value map.
+//
+// The value must serialize to a json object (or null).
+func Flatten(value any) (map[string]any, error) {
+	result := map[string]any{}
+
+	if value == nil {
+		return result, nil
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	data := map[string]any{}
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, err
+	}
+
+	flattenMap(data, "", result)
+
+	return result, nil
+}
+
+func flattenMap(data map[string]any, prefix string, result map[string]any) {
+	for k, v := range data {
+		path := k
+		if prefix != "" {
+			path = prefix + "." + k
+		}
+
+		if nested, ok := v.(map[string]any); ok && len(nested) > 0 {
+			flattenMap(nested, path, result)
+			continue
+		}
+
+		result[path] = v
+	}
+}
@@ -0,0 +1,101 @@
+package jsondiff_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/jsondiff"
+)
+
+func TestFlatten(t *testing.T) {
+	scenarios := []struct {
+		value       any
+		expectError bool
+		expected    string
+	}{
+		{nil, false, `{}`},
+		{"invalid", true, `null`},
+		{map[string]any{}, false, `{}`},
+		{
+			map[string]any{"a": 1, "b": map[string]any{"c": "test", "d": map[string]any{}}, "e": []int{1, 2}},
+			false,
+			`{"a":1,"b.c":"test","b.d":{},"e":[1,2]}`,
+		},
+	}
+
+	for i, s := range scenarios {
+		result, err := jsondiff.Flatten(s.value)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("(%d) Expected hasErr to be %v, got %v (%v)", i, s.expectError, hasErr, err)
+			continue
+		}
+
+		raw, _ := json.Marshal(result)
+		if string(raw) != s.expected {
+			t.Errorf("(%d) Expected %s, got %s", i, s.expected, raw)
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	type options struct {
+		Min int `json:"min"`
+		Max int `json:"max"`
+	}
+
+	type item struct {
+		Name    string   `json:"name"`
+		Tags    []string `json:"tags"`
+		Options options  `json:"options"`
+		Secret  string   `json:"-"`
+	}
+
+	old := &item{Name: "a", Tags: []string{"x"}, Options: options{Min: 1, Max: 2}, Secret: "old"}
+
+	scenarios := []struct {
+		name     string
+		old      any
+		new      any
+		expected string
+	}{
+		{
+			"no changes",
+			old,
+			&item{Name: "a", Tags: []string{"x"}, Options: options{Min: 1, Max: 2}, Secret: "new"},
+			`{}`,
+		},
+		{
+			"update",
+			old,
+			&item{Name: "b", Tags: []string{"x", "y"}, Options: options{Min: 1, Max: 3}},
+			`{"name":{"old":"a","new":"b"},"options.max":{"old":2,"new":3},"tags":{"old":["x"],"new":["x","y"]}}`,
+		},
+		{
+			"create",
+			nil,
+			map[string]any{"name": "a"},
+			`{"name":{"old":null,"new":"a"}}`,
+		},
+		{
+			"delete",
+			map[string]any{"name": "a"},
+			nil,
+			`{"name":{"old":"a","new":null}}`,
+		},
+	}
+
+	for _, s := range scenarios {
+		result, err := jsondiff.Diff(s.old, s.new)
+		if err != nil {
+			t.Errorf("[%s] %v", s.name, err)
+			continue
+		}
+
+		raw, _ := json.Marshal(result)
+		if string(raw) != s.expected {
+			t.Errorf("[%s] Expected %s, got %s", s.name, s.expected, raw)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+// Package jsonenc implements allocation free json encoding helpers
+// that append directly to a byte slice.
+//
+// The produced output is the same as the one of the encoding/json v1
+// [json.Marshal] (including its default HTML characters escaping and
+// the \ufffd escaping of the invalid UTF-8 bytes).
+package jsonenc
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// ObjectAppender defines an interface for the types that could append
+// their json object encoding directly to a byte slice, without
+// building intermediate maps.
+type ObjectAppender interface {
+	// AppendJSONObject appends the json object encoding of the value to dst
+	// and returns the extended slice.
+	//
+	// If fields is not empty, only the top level object keys
+	// listed in fields are encoded.
+	AppendJSONObject(dst []byte, fields []string) ([]byte, error)
+}
+
+const hex = "0123456789abcdef"
+
+// AppendString appends the json quoted and escaped s to dst.
+func AppendString(dst []byte, s string) []byte {
+	dst = append(dst, '"')
+
+	start := 0
+	for i := 0; i < len(s); {
+		if b := s[i]; b < utf8.RuneSelf {
+			if b >= 0x20 && b != '"' && b != '\\' && b != '<' && b != '>' && b != '&' {
+				i++
+				continue
+			}
+
+			dst = append(dst, s[start:i]...)
+
+			switch b {
+			case '\\', '"':
+				dst = append(dst, '\\', b)
+			case '\b':
+				dst = append(dst, '\\', 'b')
+			case '\f':
+				dst = append(dst, '\\', 'f')
+			case '\n':
+				dst = append(dst, '\\', 'n')
+			case '\r':
+				dst = append(dst, '\\', 'r')
+			case '\t':
+				dst = append(dst, '\\', 't')
+			default:
+				// the rest of the control characters and <, >, &
+				dst = append(dst, '\\', 'u', '0', '0', hex[b>>4], hex[b&0xF])
+			}
+
+			i++
+			start = i
+			continue
+		}
+
+		c, size := utf8.DecodeRuneInString(s[i:])
+
+		if c == utf8.RuneError && size == 1 {
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, `\ufffd`...)
+			i += size
+			start = i
+			continue
+		}
+
+		// U+2028 and U+2029 are escaped for JSONP compatibility
+		if c == '\u2028' || c == '\u2029' {
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, '\\', 'u', '2', '0', '2', hex[c&0xF])
+			i += size
+			start = i
+			continue
+		}
+
+		i += size
+	}
+
+	dst = append(dst, s[start:]...)
+
+	return append(dst, '"')
+}
+
+// AppendFloat appends the json encoding of f to dst.
+//
+// Returns an error for the NaN and ±Inf values (they are not valid json numbers).
+func AppendFloat(dst []byte, f float64) ([]byte, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return dst, &json.UnsupportedValueError{
+			Str: strconv.FormatFloat(f, 'g', -1, 64),
+		}
+	}
+
+	// use the exponent format only for the very large and small numbers
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 && (abs < 1e-6 || abs >= 1e21) {
+		format = 'e'
+	}
+
+	dst = strconv.AppendFloat(dst, f, format, -1, 64)
+
+	if format == 'e' {
+		// clean up e-09 to e-9
+		n := len(dst)
+		if n >= 4 && dst[n-4] == 'e' && dst[n-3] == '-' && dst[n-2] == '0' {
+			dst[n-2] = dst[n-1]
+			dst = dst[:n-1]
+		}
+	}
+
+	return dst, nil
+}
+
+// AppendDateTime appends the json encoding of d to dst
+// (the zero DateTime is encoded as empty string).
+func AppendDateTime(dst []byte, d types.DateTime) []byte {
+	dst = append(dst, '"')
+
+	if !d.IsZero() {
+		dst = d.Time().UTC().AppendFormat(dst, types.DefaultDateLayout)
+	}
+
+	return append(dst, '"')
+}
+
+// AppendValue appends the json encoding of v to dst.
+//
+// The common scalar values are encoded in place and
+// the rest are delegated to [json.Marshal].
+func AppendValue(dst []byte, v any) ([]byte, error) {
+	switch val := v.(type) {
+	case nil:
+		return append(dst, "null"...), nil
+	case string:
+		return AppendString(dst, val), nil
+	case bool:
+		return strconv.AppendBool(dst, val), nil
+	case float64:
+		return AppendFloat(dst, val)
+	case int:
+		return strconv.AppendInt(dst, int64(val), 10), nil
+	case int64:
+		return strconv.AppendInt(dst, val, 10), nil
+	case types.DateTime:
+		return AppendDateTime(dst, val), nil
+	case []string:
+		if val == nil {
+			return append(dst, "null"...), nil
+		}
+		dst = append(dst, '[')
+		for i, s := range val {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			dst = AppendString(dst, s)
+		}
+		return append(dst, ']'), nil
+	case ObjectAppender:
+		return val.AppendJSONObject(dst, nil)
+	}
+
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return dst, err
+	}
+
+	return append(dst, encoded...), nil
+}
@@ -0,0 +1,141 @@
+package jsonenc_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/jsonenc"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestAppendString(t *testing.T) {
+	scenarios := []struct {
+		value    string
+		expected string
+	}{
+		{"", `""`},
+		{"test", `"test"`},
+		{`"quoted" \ backslash`, `"\"quoted\" \\ backslash"`},
+		{"new\nline\ttab\rreturn\bback\fform", `"new\nline\ttab\rreturn\bback\fform"`},
+		{"\x00\x01\x1f\x7f", `"\u0000\u0001\u001f` + "\x7f" + `"`},
+		{"<script>alert('&')</script>", `"\u003cscript\u003ealert('\u0026')\u003c/script\u003e"`},
+		{"unicode: абв 日本語 🙂", `"unicode: абв 日本語 🙂"`},
+		{"line\u2028separator\u2029", `"line\u2028separator\u2029"`},
+		{"invalid \xff\xfe utf8 \xe2\x82", `"invalid \ufffd\ufffd utf8 \ufffd\ufffd"`},
+	}
+
+	for i, s := range scenarios {
+		result := jsonenc.AppendString([]byte("prefix"), s.value)
+
+		if string(result) != "prefix"+s.expected {
+			t.Errorf("(%d) Expected %s, got %s", i, s.expected, result)
+		}
+	}
+}
+
+func TestAppendFloat(t *testing.T) {
+	scenarios := []float64{
+		0,
+		math.Copysign(0, -1),
+		1,
+		-1.5,
+		123456789,
+		0.000001,
+		0.0000001,
+		-0.00000012345,
+		1e20,
+		1e21,
+		-1.2345e30,
+		math.MaxFloat64,
+		math.SmallestNonzeroFloat64,
+	}
+
+	for i, f := range scenarios {
+		expected, _ := json.Marshal(f)
+
+		result, err := jsonenc.AppendFloat(nil, f)
+		if err != nil {
+			t.Errorf("(%d) Unexpected error %v", i, err)
+			continue
+		}
+
+		if string(result) != string(expected) {
+			t.Errorf("(%d) Expected %s, got %s", i, expected, result)
+		}
+	}
+
+	for _, f := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		if _, err := jsonenc.AppendFloat(nil, f); err == nil {
+			t.Errorf("Expected error for %v, got nil", f)
+		}
+	}
+}
+
+type testAppender struct {
+	value string
+}
+
+func (a *testAppender) AppendJSONObject(dst []byte, fields []string) ([]byte, error) {
+	dst = append(dst, `{"value":`...)
+	dst = jsonenc.AppendString(dst, a.value)
+	return append(dst, '}'), nil
+}
+
+func TestAppendValue(t *testing.T) {
+	date, _ := types.ParseDateTime("2023-01-01 01:02:03.456Z")
+
+	scenarios := []struct {
+		value    any
+		expected string
+	}{
+		{nil, `null`},
+		{"a<b", `"a\u003cb"`},
+		{true, `true`},
+		{false, `false`},
+		{1.5, `1.5`},
+		{-10, `-10`},
+		{int64(123), `123`},
+		{types.DateTime{}, `""`},
+		{date, `"2023-01-01 01:02:03.456Z"`},
+		{[]string(nil), `null`},
+		{[]string{}, `[]`},
+		{[]string{"a", "b&c"}, `["a","b\u0026c"]`},
+		{&testAppender{"test"}, `{"value":"test"}`},
+		{map[string]any{"b": 1, "a": []int{1, 2}}, `{"a":[1,2],"b":1}`},
+		{types.JsonRaw(`{"a": 1}`), `{"a":1}`},
+		{types.GeoPoint{Lat: 1, Lng: 2}, `{"lat":1,"lng":2}`},
+	}
+
+	for i, s := range scenarios {
+		result, err := jsonenc.AppendValue(nil, s.value)
+		if err != nil {
+			t.Errorf("(%d) Unexpected error %v", i, err)
+			continue
+		}
+
+		if string(result) != s.expected {
+			t.Errorf("(%d) Expected %s, got %s", i, s.expected, result)
+		}
+	}
+
+	if _, err := jsonenc.AppendValue(nil, math.NaN()); err == nil {
+		t.Fatal("Expected NaN error, got nil")
+	}
+
+	if _, err := jsonenc.AppendValue(nil, map[string]any{"a": math.Inf(1)}); err == nil {
+		t.Fatal("Expected Inf error, got nil")
+	}
+}
+
+func TestAppendStringAllocs(t *testing.T) {
+	buf := make([]byte, 0, 1024)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		jsonenc.AppendString(buf[:0], "lorem <ipsum> \"dolor\" sit amet")
+	})
+
+	if allocs != 0 {
+		t.Fatalf("Expected 0 allocations, got %v", allocs)
+	}
+}
@@ -0,0 +1,147 @@
+// Package locale implements helpers for working with localized
+// (per-locale) field values, eg. {"en": "Hello", "de": "Hallo"}.
+//
+// Locales are matched as they are (aka. "de-AT" and "de-at" are
+// different locales), so make sure to use a consistent casing.
+package locale
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/tools/types"
+	"github.com/spf13/cast"
+)
+
+// Any is the special locale key used for the locale independent
+// values (eg. a plain string assigned to a localized field).
+//
+// It is always the last entry of a lookup chain.
+const Any = "*"
+
+var localeRegex = regexp.MustCompile(`^[a-zA-Z]{2,3}(-[a-zA-Z0-9]{2,8})*$`)
+
+// IsValid checks whether the provided string is a valid
+// BCP 47 like locale tag (eg. "en", "de-AT", "zh-Hant-TW").
+func IsValid(locale string) bool {
+	return localeRegex.MatchString(locale)
+}
+
+// Parent returns the parent of the provided locale by removing
+// its last subtag (eg. "de-AT" -> "de").
+//
+// Returns empty string if the locale doesn't have a parent.
+func Parent(locale string) string {
+	if i := strings.LastIndex(locale, "-"); i > 0 {
+		return locale[:i]
+	}
+
+	return ""
+}
+
+// Chain returns the values lookup chain of the provided locale.
+//
+// The chain consists of the locale itself, its explicit fallbacks,
+// its parent locales, the default locale and finally [Any], eg.:
+//
+//	Chain("de-AT", map[string][]string{"de-AT": {"de-CH"}}, "en")
+//	// ["de-AT", "de-CH", "de", "en", "*"]
+func Chain(locale string, fallbacks map[string][]string, defaultLocale string) []string {
+	result := []string{}
+	seen := map[string]struct{}{}
+
+	var visit func(l string)
+	visit = func(l string) {
+		if l == "" {
+			return
+		}
+
+		if _, ok := seen[l]; ok {
+			return
+		}
+		seen[l] = struct{}{}
+
+		result = append(result, l)
+
+		for _, fallback := range fallbacks[l] {
+			visit(fallback)
+		}
+
+		visit(Parent(l))
+	}
+
+	visit(locale)
+	visit(defaultLocale)
+
+	if _, ok := seen[Any]; !ok {
+		result = append(result, Any)
+	}
+
+	return result
+}
+
+// Resolve returns the first non-empty value from the provided
+// localized values following the lookup chain order.
+//
+// Returns empty strings if none of the chain locales has a value.
+func Resolve(values map[string]any, chain []string) (value string, matchedLocale string) {
+	for _, l := range chain {
+		if v := cast.ToString(values[l]); v != "" {
+			return v, l
+		}
+	}
+
+	return "", ""
+}
+
+// NormalizeValues converts the provided raw value into a
+// locale -> string values map (empty values are skipped).
+//
+// Plain (non json object) strings are stored under the [Any] locale.
+func NormalizeValues(value any) types.JsonMap {
+	result := types.JsonMap{}
+
+	var raw map[string]any
+
+	switch v := value.(type) {
+	case nil:
+		return result
+	case types.JsonMap:
+		raw = v
+	case map[string]any:
+		raw = v
+	case map[string]string:
+		raw = make(map[string]any, len(v))
+		for k, s := range v {
+			raw[k] = s
+		}
+	default:
+		str := cast.ToString(value)
+		if jsonRaw, ok := value.(types.JsonRaw); ok {
+			str = string(jsonRaw)
+		}
+
+		if str == "" {
+			return result
+		}
+
+		if trimmed := strings.TrimSpace(str); strings.HasPrefix(trimmed, "{") {
+			if err := json.Unmarshal([]byte(trimmed), &raw); err == nil {
+				break
+			}
+		}
+
+		result[Any] = str
+
+		return result
+	}
+
+	for l, v := range raw {
+		if str := cast.ToString(v); str != "" {
+			result[l] = str
+		}
+	}
+
+	return result
+}
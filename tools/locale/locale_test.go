@@ -0,0 +1,140 @@
+package locale_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/locale"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestIsValid(t *testing.T) {
+	scenarios := []struct {
+		locale   string
+		expected bool
+	}{
+		{"", false},
+		{"*", false},
+		{"e", false},
+		{"en_US", false},
+		{"en-", false},
+		{"en-US-", false},
+		{"1en", false},
+		{"en", true},
+		{"fil", true},
+		{"de-AT", true},
+		{"zh-Hant-TW", true},
+		{"es-419", true},
+	}
+
+	for _, s := range scenarios {
+		if v := locale.IsValid(s.locale); v != s.expected {
+			t.Errorf("(%q) Expected %v, got %v", s.locale, s.expected, v)
+		}
+	}
+}
+
+func TestParent(t *testing.T) {
+	scenarios := []struct {
+		locale   string
+		expected string
+	}{
+		{"", ""},
+		{"en", ""},
+		{"de-AT", "de"},
+		{"zh-Hant-TW", "zh-Hant"},
+	}
+
+	for _, s := range scenarios {
+		if v := locale.Parent(s.locale); v != s.expected {
+			t.Errorf("(%q) Expected %q, got %q", s.locale, s.expected, v)
+		}
+	}
+}
+
+func TestChain(t *testing.T) {
+	fallbacks := map[string][]string{
+		"de-AT": {"de-CH"},
+		"de-CH": {"de-AT", "fr"},
+		"pt":    {"es"},
+	}
+
+	scenarios := []struct {
+		locale        string
+		defaultLocale string
+		expected      string
+	}{
+		{"", "", "*"},
+		{"", "en", "en,*"},
+		{"en", "en", "en,*"},
+		{"de", "en", "de,en,*"},
+		{"de-AT", "en", "de-AT,de-CH,fr,de,en,*"},
+		{"pt-BR", "en-US", "pt-BR,pt,es,en-US,en,*"},
+	}
+
+	for _, s := range scenarios {
+		result := strings.Join(locale.Chain(s.locale, fallbacks, s.defaultLocale), ",")
+		if result != s.expected {
+			t.Errorf("(%q) Expected %q, got %q", s.locale, s.expected, result)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	values := map[string]any{
+		"en":       "Hello",
+		"de":       "Hallo",
+		"fr":       "",
+		locale.Any: "Hi",
+	}
+
+	scenarios := []struct {
+		chain          []string
+		expectedValue  string
+		expectedLocale string
+	}{
+		{nil, "", ""},
+		{[]string{"bg"}, "", ""},
+		{[]string{"de"}, "Hallo", "de"},
+		{[]string{"fr", "de", "en"}, "Hallo", "de"},
+		{[]string{"fr", "bg", locale.Any}, "Hi", locale.Any},
+	}
+
+	for i, s := range scenarios {
+		value, matched := locale.Resolve(values, s.chain)
+		if value != s.expectedValue || matched != s.expectedLocale {
+			t.Errorf("(%d) Expected (%q, %q), got (%q, %q)", i, s.expectedValue, s.expectedLocale, value, matched)
+		}
+	}
+}
+
+func TestNormalizeValues(t *testing.T) {
+	scenarios := []struct {
+		value    any
+		expected string
+	}{
+		{nil, `{}`},
+		{"", `{}`},
+		{"test", `{"*":"test"}`},
+		{123, `{"*":"123"}`},
+		{"{invalid", `{"*":"{invalid"}`},
+		{`{"en":"Hello","de":"","fr":123}`, `{"en":"Hello","fr":"123"}`},
+		{[]byte(`{"en":"Hello"}`), `{"en":"Hello"}`},
+		{types.JsonRaw(`{"en":"Hello"}`), `{"en":"Hello"}`},
+		{map[string]string{"en": "Hello", "de": ""}, `{"en":"Hello"}`},
+		{map[string]any{"en": "Hello", "de": nil}, `{"en":"Hello"}`},
+		{types.JsonMap{"bg": "Здравей"}, `{"bg":"Здравей"}`},
+	}
+
+	for i, s := range scenarios {
+		encoded, err := json.Marshal(locale.NormalizeValues(s.value))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(encoded) != s.expected {
+			t.Errorf("(%d) Expected %s, got %s", i, s.expected, encoded)
+		}
+	}
+}
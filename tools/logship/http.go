@@ -0,0 +1,84 @@
+package logship
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+var _ Sink = (*httpSink)(nil)
+
+// httpSink implements [Sink] for a generic HTTP endpoint.
+//
+// Each batch is sent as a json array of entries with a single POST request.
+type httpSink struct {
+	config Config
+}
+
+// Send implements [Sink.Send()].
+func (s *httpSink) Send(ctx context.Context, entries []*Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	items := entries
+	if len(s.config.Labels) > 0 {
+		items = make([]*Entry, len(entries))
+		for i, e := range entries {
+			items[i] = withLabels(e, s.config.Labels)
+		}
+	}
+
+	body, err := json.Marshal(items)
+	if err != nil {
+		return err
+	}
+
+	return postJson(ctx, s.config, body)
+}
+
+// withLabels returns a shallow copy of the entry with the labels
+// added to its data (the existing data keys are preserved).
+func withLabels(e *Entry, labels map[string]string) *Entry {
+	clone := *e
+	clone.Data = make(map[string]any, len(e.Data)+len(labels))
+
+	for k, v := range labels {
+		clone.Data[k] = v
+	}
+	for k, v := range e.Data {
+		clone.Data[k] = v
+	}
+
+	return &clone
+}
+
+// postJson sends a POST request with the provided json body
+// to the sink url and checks for a 2xx response status.
+func postJson(ctx context.Context, config Config, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, config.Url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if config.AuthHeader != "" {
+		req.Header.Set("Authorization", config.AuthHeader)
+	}
+
+	res, err := config.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		raw, _ := io.ReadAll(io.LimitReader(res.Body, 500))
+		return fmt.Errorf("log sink request failed with status %d: %s", res.StatusCode, raw)
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+// Package logship implements asynchronous shipping of log entries
+// to external sinks (Loki push API, syslog or a generic HTTP endpoint).
+package logship
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// List with the supported sink types.
+const (
+	SinkLoki   string = "loki"
+	SinkSyslog string = "syslog"
+	SinkHttp   string = "http"
+)
+
+// Sinks returns the list with all supported sink types.
+func Sinks() []string {
+	return []string{SinkLoki, SinkSyslog, SinkHttp}
+}
+
+// List with the log entry levels.
+const (
+	LevelInfo  string = "info"
+	LevelWarn  string = "warn"
+	LevelError string = "error"
+)
+
+// Entry defines a single shipped log entry.
+type Entry struct {
+	Time    time.Time      `json:"time"`
+	Level   string         `json:"level"`
+	Message string         `json:"message"`
+	Data    map[string]any `json:"data,omitempty"`
+}
+
+// HttpClient is a base HTTP client interface (usually used for test purposes).
+type HttpClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Sink defines a common interface for the external log sinks.
+type Sink interface {
+	// Send delivers the provided batch of log entries to the sink.
+	Send(ctx context.Context, entries []*Entry) error
+}
+
+// Config defines the sink connection options.
+type Config struct {
+	// Url is the sink endpoint address, eg.:
+	//  - "http://localhost:3100/loki/api/v1/push" (loki)
+	//  - "udp://localhost:514" or "tcp://localhost:514" (syslog)
+	//  - "https://example.com/logs" (http)
+	Url string
+
+	// AuthHeader is an optional Authorization header value
+	// of the loki and http sink requests.
+	AuthHeader string
+
+	// Labels are optional static labels attached to each
+	// entry (as loki stream labels or as http entry data).
+	//
+	// The "app" label is used also as syslog app name.
+	Labels map[string]string
+
+	// Client is the HTTP client of the loki and http sinks
+	// (if nil, a default [http.Client] with 30s timeout is used).
+	Client HttpClient
+}
+
+// NewSink creates a new Sink of the specified type.
+func NewSink(sinkType string, config Config) (Sink, error) {
+	if config.Client == nil {
+		config.Client = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	switch sinkType {
+	case SinkLoki:
+		return &loki{config}, nil
+	case SinkSyslog:
+		return newSyslog(config)
+	case SinkHttp:
+		return &httpSink{config}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported log sink %q", sinkType)
+}
@@ -0,0 +1,349 @@
+package logship_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/logship"
+)
+
+type recordedRequest struct {
+	url     string
+	headers http.Header
+	body    string
+}
+
+type testClient struct {
+	mux      sync.Mutex
+	requests []*recordedRequest
+	status   int
+}
+
+func (c *testClient) Do(req *http.Request) (*http.Response, error) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	r := &recordedRequest{
+		url:     req.URL.String(),
+		headers: req.Header,
+	}
+
+	if req.Body != nil {
+		raw, _ := io.ReadAll(req.Body)
+		r.body = string(raw)
+	}
+
+	c.requests = append(c.requests, r)
+
+	status := c.status
+	if status == 0 {
+		status = 204
+	}
+
+	return &http.Response{
+		StatusCode: status,
+		Body:       io.NopCloser(bytes.NewReader(nil)),
+	}, nil
+}
+
+func (c *testClient) total() int {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	return len(c.requests)
+}
+
+func testEntries() []*logship.Entry {
+	return []*logship.Entry{
+		{
+			Time:    time.Date(2026, 10, 15, 10, 0, 0, 0, time.UTC),
+			Level:   logship.LevelInfo,
+			Message: "GET /api/test1",
+			Data:    map[string]any{"status": 200},
+		},
+		{
+			Time:    time.Date(2026, 10, 15, 10, 0, 1, 0, time.UTC),
+			Level:   logship.LevelError,
+			Message: "POST /api/test2",
+			Data:    map[string]any{"status": 500},
+		},
+	}
+}
+
+func TestNewSink(t *testing.T) {
+	if _, err := logship.NewSink("missing", logship.Config{Url: "http://example.com"}); err == nil {
+		t.Fatal("Expected unsupported sink error, got nil")
+	}
+
+	if _, err := logship.NewSink(logship.SinkSyslog, logship.Config{Url: "http://example.com"}); err == nil {
+		t.Fatal("Expected unsupported syslog network error, got nil")
+	}
+
+	urls := map[string]string{
+		logship.SinkLoki:   "http://example.com/loki/api/v1/push",
+		logship.SinkSyslog: "udp://127.0.0.1:514",
+		logship.SinkHttp:   "http://example.com/logs",
+	}
+
+	for _, sinkType := range logship.Sinks() {
+		sink, err := logship.NewSink(sinkType, logship.Config{Url: urls[sinkType]})
+		if err != nil {
+			t.Fatalf("[%s] Unexpected error: %v", sinkType, err)
+		}
+
+		if sink == nil {
+			t.Fatalf("[%s] Expected non-nil sink", sinkType)
+		}
+	}
+}
+
+func TestLokiSink(t *testing.T) {
+	client := &testClient{}
+
+	sink, _ := logship.NewSink(logship.SinkLoki, logship.Config{
+		Url:        "http://example.com/loki/api/v1/push",
+		AuthHeader: "Bearer test",
+		Labels:     map[string]string{"app": "test"},
+		Client:     client,
+	})
+
+	if err := sink.Send(context.Background(), testEntries()); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(client.requests) != 1 {
+		t.Fatalf("Expected 1 request, got %d", len(client.requests))
+	}
+
+	r := client.requests[0]
+
+	if r.url != "http://example.com/loki/api/v1/push" {
+		t.Fatalf("Unexpected request url %q", r.url)
+	}
+
+	if v := r.headers.Get("Authorization"); v != "Bearer test" {
+		t.Fatalf("Expected Authorization header %q, got %q", "Bearer test", v)
+	}
+
+	expectedBody := `{"streams":[` +
+		`{"stream":{"app":"test","level":"error"},"values":[["1792058401000000000","{\"data\":{\"status\":500},\"message\":\"POST /api/test2\"}"]]},` +
+		`{"stream":{"app":"test","level":"info"},"values":[["1792058400000000000","{\"data\":{\"status\":200},\"message\":\"GET /api/test1\"}"]]}` +
+		`]}`
+	if r.body != expectedBody {
+		t.Fatalf("Expected body \n%s\ngot\n%s", expectedBody, r.body)
+	}
+}
+
+func TestHttpSink(t *testing.T) {
+	client := &testClient{}
+
+	sink, _ := logship.NewSink(logship.SinkHttp, logship.Config{
+		Url:    "http://example.com/logs",
+		Labels: map[string]string{"app": "test", "status": "label"},
+		Client: client,
+	})
+
+	if err := sink.Send(context.Background(), testEntries()); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(client.requests) != 1 {
+		t.Fatalf("Expected 1 request, got %d", len(client.requests))
+	}
+
+	// the entry data should have priority over the labels
+	expectedBody := `[` +
+		`{"time":"2026-10-15T10:00:00Z","level":"info","message":"GET /api/test1","data":{"app":"test","status":200}},` +
+		`{"time":"2026-10-15T10:00:01Z","level":"error","message":"POST /api/test2","data":{"app":"test","status":500}}` +
+		`]`
+	if client.requests[0].body != expectedBody {
+		t.Fatalf("Expected body \n%s\ngot\n%s", expectedBody, client.requests[0].body)
+	}
+
+	// failure status
+	client.status = 500
+	if err := sink.Send(context.Background(), testEntries()); err == nil {
+		t.Fatal("Expected error for the failure response status, got nil")
+	}
+}
+
+func TestSyslogSink(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	sink, err := logship.NewSink(logship.SinkSyslog, logship.Config{
+		Url:    "udp://" + conn.LocalAddr().String(),
+		Labels: map[string]string{"app": "test"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := sink.Send(context.Background(), testEntries()); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedMessages := []struct {
+		prefix string
+		suffix string
+	}{
+		{"<134>1 2026-10-15T10:00:00Z ", ` test - - - GET /api/test1 {"status":200}`},
+		{"<131>1 2026-10-15T10:00:01Z ", ` test - - - POST /api/test2 {"status":500}`},
+	}
+
+	buf := make([]byte, 1024)
+	for i, expected := range expectedMessages {
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		msg := string(buf[:n])
+		if !strings.HasPrefix(msg, expected.prefix) || !strings.HasSuffix(msg, expected.suffix) {
+			t.Fatalf("(%d) Unexpected syslog message %q", i, msg)
+		}
+	}
+}
+
+type testSink struct {
+	mux     sync.Mutex
+	batches [][]string
+	block   chan struct{}
+	err     error
+}
+
+func (s *testSink) Send(ctx context.Context, entries []*logship.Entry) error {
+	if s.block != nil {
+		<-s.block
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	batch := make([]string, len(entries))
+	for i, e := range entries {
+		batch[i] = e.Message
+	}
+	s.batches = append(s.batches, batch)
+
+	return s.err
+}
+
+func (s *testSink) result() [][]string {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	return s.batches
+}
+
+func TestShipperBatching(t *testing.T) {
+	sink := &testSink{err: errors.New("test")}
+
+	var errorsMux sync.Mutex
+	totalErrors := 0
+
+	shipper := logship.NewShipper(sink, logship.ShipperOptions{
+		BatchSize:     2,
+		FlushInterval: time.Hour,
+		OnError: func(err error) {
+			errorsMux.Lock()
+			totalErrors++
+			errorsMux.Unlock()
+		},
+	})
+
+	for _, msg := range []string{"a", "b", "c"} {
+		if !shipper.Push(&logship.Entry{Message: msg}) {
+			t.Fatalf("Expected %q to be queued", msg)
+		}
+	}
+
+	// flush the remaining partial batch
+	if err := shipper.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	batches := sink.result()
+	if len(batches) != 2 || strings.Join(batches[0], ",") != "a,b" || strings.Join(batches[1], ",") != "c" {
+		t.Fatalf("Unexpected batches %v", batches)
+	}
+
+	if totalErrors != 2 {
+		t.Fatalf("Expected OnError to be called 2 times, got %d", totalErrors)
+	}
+
+	if shipper.Push(&logship.Entry{Message: "d"}) {
+		t.Fatal("Expected the entry to be rejected after close")
+	}
+}
+
+func TestShipperFlushInterval(t *testing.T) {
+	sink := &testSink{}
+
+	shipper := logship.NewShipper(sink, logship.ShipperOptions{
+		BatchSize:     100,
+		FlushInterval: 10 * time.Millisecond,
+	})
+	defer shipper.Close(context.Background())
+
+	shipper.Push(&logship.Entry{Message: "a"})
+
+	for i := 0; i < 100; i++ {
+		if len(sink.result()) > 0 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Fatal("Expected the partial batch to be sent after the flush interval")
+}
+
+func TestShipperBackpressure(t *testing.T) {
+	sink := &testSink{block: make(chan struct{})}
+
+	shipper := logship.NewShipper(sink, logship.ShipperOptions{
+		BatchSize:     1,
+		FlushInterval: time.Hour,
+		QueueSize:     2,
+	})
+
+	// the first entry could be picked by the (blocked) sending
+	// goroutine, so at most 3 entries could be accepted
+	accepted := 0
+	for i := 0; i < 10; i++ {
+		if shipper.Push(&logship.Entry{Message: "test"}) {
+			accepted++
+		}
+	}
+
+	if accepted < 2 || accepted > 3 {
+		t.Fatalf("Expected 2 or 3 accepted entries, got %d", accepted)
+	}
+
+	if v := shipper.Dropped(); v != int64(10-accepted) {
+		t.Fatalf("Expected %d dropped entries, got %d", 10-accepted, v)
+	}
+
+	close(sink.block)
+
+	if err := shipper.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if v := len(sink.result()); v != accepted {
+		t.Fatalf("Expected %d sent batches, got %d", accepted, v)
+	}
+}
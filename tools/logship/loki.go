@@ -0,0 +1,82 @@
+package logship
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+)
+
+var _ Sink = (*loki)(nil)
+
+// loki implements [Sink] for the Grafana Loki push API.
+//
+// The entries are grouped in streams by their level
+// (the configured labels are added to each stream).
+//
+// https://grafana.com/docs/loki/latest/reference/loki-http-api/#ingest-logs
+type loki struct {
+	config Config
+}
+
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+// Send implements [Sink.Send()].
+func (l *loki) Send(ctx context.Context, entries []*Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	streams := map[string]*lokiStream{}
+
+	for _, e := range entries {
+		stream, ok := streams[e.Level]
+		if !ok {
+			labels := make(map[string]string, len(l.config.Labels)+1)
+			for k, v := range l.config.Labels {
+				labels[k] = v
+			}
+			labels["level"] = e.Level
+
+			stream = &lokiStream{Stream: labels}
+			streams[e.Level] = stream
+		}
+
+		line, err := json.Marshal(map[string]any{
+			"message": e.Message,
+			"data":    e.Data,
+		})
+		if err != nil {
+			return err
+		}
+
+		stream.Values = append(stream.Values, [2]string{
+			strconv.FormatInt(e.Time.UnixNano(), 10),
+			string(line),
+		})
+	}
+
+	// sort for consistent payloads
+	levels := make([]string, 0, len(streams))
+	for level := range streams {
+		levels = append(levels, level)
+	}
+	sort.Strings(levels)
+
+	payload := struct {
+		Streams []*lokiStream `json:"streams"`
+	}{}
+	for _, level := range levels {
+		payload.Streams = append(payload.Streams, streams[level])
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return postJson(ctx, l.config, body)
+}
@@ -0,0 +1,173 @@
+package logship
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Default shipper options.
+const (
+	DefaultBatchSize     = 100
+	DefaultFlushInterval = 5 * time.Second
+	DefaultQueueSize     = 10000
+	DefaultSendTimeout   = 30 * time.Second
+)
+
+// ShipperOptions defines the [Shipper] batching and queue options
+// (the zero values fallback to their related Default* constant).
+type ShipperOptions struct {
+	// BatchSize is the max number of entries sent with a single sink request.
+	BatchSize int
+
+	// FlushInterval is the max duration that a queued entry
+	// waits before being sent (even if the batch is not full).
+	FlushInterval time.Duration
+
+	// QueueSize is the max number of the queued (not sent yet) entries.
+	//
+	// New entries are dropped when the queue is full to prevent
+	// a slow or unavailable sink from blocking the app.
+	QueueSize int
+
+	// SendTimeout is the max duration of a single sink request.
+	SendTimeout time.Duration
+
+	// OnError is an optional callback that is invoked when a batch fails to be sent.
+	OnError func(err error)
+}
+
+// Shipper queues log entries and sends them in batches
+// to a [Sink] from a single background goroutine.
+type Shipper struct {
+	sink    Sink
+	options ShipperOptions
+	queue   chan *Entry
+	stop    chan struct{}
+	done    chan struct{}
+	once    sync.Once
+	dropped int64
+}
+
+// NewShipper creates a new Shipper for the provided sink
+// and starts its background sending goroutine.
+//
+// Call [Shipper.Close()] to flush the queued entries and stop the shipper.
+func NewShipper(sink Sink, options ShipperOptions) *Shipper {
+	if options.BatchSize <= 0 {
+		options.BatchSize = DefaultBatchSize
+	}
+	if options.FlushInterval <= 0 {
+		options.FlushInterval = DefaultFlushInterval
+	}
+	if options.QueueSize <= 0 {
+		options.QueueSize = DefaultQueueSize
+	}
+	if options.SendTimeout <= 0 {
+		options.SendTimeout = DefaultSendTimeout
+	}
+
+	s := &Shipper{
+		sink:    sink,
+		options: options,
+		queue:   make(chan *Entry, options.QueueSize),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+
+	go s.run()
+
+	return s
+}
+
+// Push adds the entry to the shipper queue without blocking.
+//
+// It returns false if the entry was dropped because the
+// queue is full or the shipper was closed.
+func (s *Shipper) Push(entry *Entry) bool {
+	select {
+	case <-s.stop:
+		return false
+	default:
+	}
+
+	select {
+	case s.queue <- entry:
+		return true
+	default:
+		atomic.AddInt64(&s.dropped, 1)
+		return false
+	}
+}
+
+// Dropped returns the total number of the entries dropped due to a full queue.
+func (s *Shipper) Dropped() int64 {
+	return atomic.LoadInt64(&s.dropped)
+}
+
+// Close stops accepting new entries and waits until the
+// already queued ones are sent or the context is done.
+func (s *Shipper) Close(ctx context.Context) error {
+	s.once.Do(func() {
+		close(s.stop)
+	})
+
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Shipper) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.options.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*Entry, 0, s.options.BatchSize)
+
+	add := func(e *Entry) {
+		batch = append(batch, e)
+		if len(batch) >= s.options.BatchSize {
+			s.send(batch)
+			batch = batch[:0]
+		}
+	}
+
+	for {
+		select {
+		case e := <-s.queue:
+			add(e)
+		case <-ticker.C:
+			s.send(batch)
+			batch = batch[:0]
+		case <-s.stop:
+			// drain the remaining queued entries
+			for {
+				select {
+				case e := <-s.queue:
+					add(e)
+				default:
+					s.send(batch)
+					return
+				}
+			}
+		}
+	}
+}
+
+func (s *Shipper) send(batch []*Entry) {
+	if len(batch) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.options.SendTimeout)
+	defer cancel()
+
+	if err := s.sink.Send(ctx, batch); err != nil && s.options.OnError != nil {
+		s.options.OnError(err)
+	}
+}
@@ -0,0 +1,133 @@
+package logship
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+)
+
+var _ Sink = (*syslog)(nil)
+
+// syslogFacility is the used syslog facility (local0).
+const syslogFacility = 16
+
+// syslog implements [Sink] for a remote syslog server
+// (RFC 5424 messages over UDP or TCP with octet counting framing).
+type syslog struct {
+	network  string
+	address  string
+	appName  string
+	hostname string
+}
+
+func newSyslog(config Config) (*syslog, error) {
+	u, err := url.Parse(config.Url)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme != "udp" && u.Scheme != "tcp" {
+		return nil, fmt.Errorf("unsupported syslog network %q", u.Scheme)
+	}
+
+	if u.Host == "" {
+		return nil, fmt.Errorf("missing syslog server address")
+	}
+
+	appName := config.Labels["app"]
+	if appName == "" {
+		appName = "pocketbase"
+	}
+
+	hostname, _ := os.Hostname()
+	if hostname == "" {
+		hostname = "-"
+	}
+
+	return &syslog{
+		network:  u.Scheme,
+		address:  u.Host,
+		appName:  appName,
+		hostname: hostname,
+	}, nil
+}
+
+// Send implements [Sink.Send()].
+func (s *syslog) Send(ctx context.Context, entries []*Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, s.network, s.address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetWriteDeadline(deadline)
+	}
+
+	for _, e := range entries {
+		msg, err := s.format(e)
+		if err != nil {
+			return err
+		}
+
+		// octet counting framing (RFC 6587)
+		if s.network == "tcp" {
+			msg = append([]byte(strconv.Itoa(len(msg))+" "), msg...)
+		}
+
+		if _, err := conn.Write(msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// format returns the RFC 5424 message of the provided entry.
+func (s *syslog) format(e *Entry) ([]byte, error) {
+	buf := new(bytes.Buffer)
+
+	fmt.Fprintf(
+		buf,
+		"<%d>1 %s %s %s - - - %s",
+		syslogFacility*8+syslogSeverity(e.Level),
+		e.Time.UTC().Format(time.RFC3339Nano),
+		s.hostname,
+		s.appName,
+		e.Message,
+	)
+
+	if len(e.Data) > 0 {
+		data, err := json.Marshal(e.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		buf.WriteByte(' ')
+		buf.Write(data)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func syslogSeverity(level string) int {
+	switch level {
+	case LevelError:
+		return 3
+	case LevelWarn:
+		return 4
+	default:
+		return 6
+	}
+}
@@ -0,0 +1,349 @@
+// Package metrics implements a lightweight in-memory registry of
+// counter and histogram metrics exposed in the Prometheus text format.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ContentType is the content type of the Prometheus text exposition format.
+const ContentType string = "text/plain; version=0.0.4; charset=utf-8"
+
+// DefaultBuckets are the default histogram buckets (in seconds).
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+type metric interface {
+	write(w io.Writer) error
+}
+
+// Registry is a concurrent safe collection of named metrics.
+type Registry struct {
+	mux     sync.RWMutex
+	metrics map[string]metric
+}
+
+// NewRegistry creates a new empty metrics Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		metrics: map[string]metric{},
+	}
+}
+
+// Counter returns the registered counter with the specified name
+// or registers a new one if missing.
+//
+// It panics if a different metric type with the same name is already registered.
+func (r *Registry) Counter(name string, help string, labelNames ...string) *Counter {
+	return register(r, name, func() *Counter {
+		return &Counter{
+			vec:    newVec(name, help, labelNames),
+			values: map[string]float64{},
+		}
+	})
+}
+
+// Histogram returns the registered histogram with the specified name
+// or registers a new one if missing.
+//
+// If buckets is empty, DefaultBuckets are used.
+//
+// It panics if a different metric type with the same name is already registered.
+func (r *Registry) Histogram(name string, help string, buckets []float64, labelNames ...string) *Histogram {
+	return register(r, name, func() *Histogram {
+		if len(buckets) == 0 {
+			buckets = DefaultBuckets
+		}
+
+		sorted := append([]float64{}, buckets...)
+		sort.Float64s(sorted)
+
+		return &Histogram{
+			vec:     newVec(name, help, labelNames),
+			buckets: sorted,
+			values:  map[string]*histogramValue{},
+		}
+	})
+}
+
+// Export writes all registered metrics (sorted by their name)
+// in the Prometheus text exposition format.
+func (r *Registry) Export(w io.Writer) error {
+	r.mux.RLock()
+	names := make([]string, 0, len(r.metrics))
+	for name := range r.metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	metrics := make([]metric, len(names))
+	for i, name := range names {
+		metrics[i] = r.metrics[name]
+	}
+	r.mux.RUnlock()
+
+	for _, m := range metrics {
+		if err := m.write(w); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func register[T metric](r *Registry, name string, factory func() T) T {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	if existing, ok := r.metrics[name]; ok {
+		m, ok := existing.(T)
+		if !ok {
+			panic(fmt.Sprintf("metric %q is already registered with a different type", name))
+		}
+		return m
+	}
+
+	m := factory()
+	r.metrics[name] = m
+
+	return m
+}
+
+// -------------------------------------------------------------------
+
+type vec struct {
+	name       string
+	help       string
+	labelNames []string
+}
+
+func newVec(name string, help string, labelNames []string) vec {
+	return vec{name: name, help: help, labelNames: labelNames}
+}
+
+// key returns the serialized labels pairs of the provided label values
+// (the missing values are treated as empty strings).
+func (v vec) key(labelValues []string) string {
+	if len(v.labelNames) == 0 {
+		return ""
+	}
+
+	pairs := make([]string, len(v.labelNames))
+	for i, name := range v.labelNames {
+		var value string
+		if i < len(labelValues) {
+			value = labelValues[i]
+		}
+		pairs[i] = name + `="` + escapeLabelValue(value) + `"`
+	}
+
+	return strings.Join(pairs, ",")
+}
+
+func (v vec) writeHeader(w io.Writer, typ string) error {
+	_, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", v.name, escapeHelp(v.help), v.name, typ)
+	return err
+}
+
+// -------------------------------------------------------------------
+
+// Counter is a monotonically increasing metric partitioned by its labels.
+type Counter struct {
+	vec
+
+	mux    sync.Mutex
+	values map[string]float64
+}
+
+// Inc increments the counter with the provided label values by 1.
+func (c *Counter) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add increments the counter with the provided label values by delta
+// (negative deltas are ignored).
+func (c *Counter) Add(delta float64, labelValues ...string) {
+	if delta < 0 {
+		return
+	}
+
+	key := c.key(labelValues)
+
+	c.mux.Lock()
+	c.values[key] += delta
+	c.mux.Unlock()
+}
+
+// Value returns the current counter value of the provided label values.
+func (c *Counter) Value(labelValues ...string) float64 {
+	key := c.key(labelValues)
+
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	return c.values[key]
+}
+
+func (c *Counter) write(w io.Writer) error {
+	if err := c.writeHeader(w, "counter"); err != nil {
+		return err
+	}
+
+	c.mux.Lock()
+	keys := sortedKeys(c.values)
+	lines := make([]string, len(keys))
+	for i, key := range keys {
+		lines[i] = c.name + wrapLabels(key) + " " + formatFloat(c.values[key]) + "\n"
+	}
+	c.mux.Unlock()
+
+	_, err := io.WriteString(w, strings.Join(lines, ""))
+	return err
+}
+
+// -------------------------------------------------------------------
+
+type histogramValue struct {
+	counts []uint64 // non-cumulative per bucket counts
+	count  uint64
+	sum    float64
+}
+
+// Histogram samples observations into configurable buckets
+// and it is partitioned by its labels.
+type Histogram struct {
+	vec
+
+	buckets []float64
+
+	mux    sync.Mutex
+	values map[string]*histogramValue
+}
+
+// Observe registers a single observation with the provided label values.
+func (h *Histogram) Observe(value float64, labelValues ...string) {
+	key := h.key(labelValues)
+
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	v, ok := h.values[key]
+	if !ok {
+		v = &histogramValue{counts: make([]uint64, len(h.buckets))}
+		h.values[key] = v
+	}
+
+	for i, upper := range h.buckets {
+		if value <= upper {
+			v.counts[i]++
+			break
+		}
+	}
+	v.count++
+	v.sum += value
+}
+
+// Count returns the total number of observations with the provided label values.
+func (h *Histogram) Count(labelValues ...string) uint64 {
+	key := h.key(labelValues)
+
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	if v, ok := h.values[key]; ok {
+		return v.count
+	}
+
+	return 0
+}
+
+func (h *Histogram) write(w io.Writer) error {
+	if err := h.writeHeader(w, "histogram"); err != nil {
+		return err
+	}
+
+	var sb strings.Builder
+
+	h.mux.Lock()
+	for _, key := range sortedKeys(h.values) {
+		v := h.values[key]
+
+		var cumulative uint64
+		for i, upper := range h.buckets {
+			cumulative += v.counts[i]
+			sb.WriteString(h.name + "_bucket" + wrapLabels(joinLabels(key, `le="`+formatFloat(upper)+`"`)))
+			sb.WriteString(" " + strconv.FormatUint(cumulative, 10) + "\n")
+		}
+		sb.WriteString(h.name + "_bucket" + wrapLabels(joinLabels(key, `le="+Inf"`)))
+		sb.WriteString(" " + strconv.FormatUint(v.count, 10) + "\n")
+
+		sb.WriteString(h.name + "_sum" + wrapLabels(key) + " " + formatFloat(v.sum) + "\n")
+		sb.WriteString(h.name + "_count" + wrapLabels(key) + " " + strconv.FormatUint(v.count, 10) + "\n")
+	}
+	h.mux.Unlock()
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// -------------------------------------------------------------------
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func joinLabels(labels ...string) string {
+	nonEmpty := make([]string, 0, len(labels))
+	for _, l := range labels {
+		if l != "" {
+			nonEmpty = append(nonEmpty, l)
+		}
+	}
+
+	return strings.Join(nonEmpty, ",")
+}
+
+func wrapLabels(labels string) string {
+	if labels == "" {
+		return ""
+	}
+
+	return "{" + labels + "}"
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+}
+
+var (
+	labelValueReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+	helpReplacer       = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+)
+
+func escapeLabelValue(value string) string {
+	return labelValueReplacer.Replace(value)
+}
+
+func escapeHelp(help string) string {
+	return helpReplacer.Replace(help)
+}
@@ -0,0 +1,109 @@
+package metrics_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/metrics"
+)
+
+func TestRegistryGetOrCreate(t *testing.T) {
+	r := metrics.NewRegistry()
+
+	c1 := r.Counter("test_total", "test", "a")
+	c2 := r.Counter("test_total", "other", "b")
+	if c1 != c2 {
+		t.Fatal("Expected the same counter instance")
+	}
+
+	h1 := r.Histogram("test_seconds", "test", nil)
+	h2 := r.Histogram("test_seconds", "test", []float64{1})
+	if h1 != h2 {
+		t.Fatal("Expected the same histogram instance")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Expected panic for metric with different type")
+		}
+	}()
+	r.Histogram("test_total", "test", nil)
+}
+
+func TestCounter(t *testing.T) {
+	r := metrics.NewRegistry()
+
+	c := r.Counter("test_total", "test", "method", "status")
+	c.Inc("GET", "200")
+	c.Inc("GET", "200")
+	c.Add(3, "POST", "400")
+	c.Add(-1, "POST", "400") // should be ignored
+
+	scenarios := []struct {
+		labels   []string
+		expected float64
+	}{
+		{[]string{"GET", "200"}, 2},
+		{[]string{"POST", "400"}, 3},
+		{[]string{"GET", "404"}, 0},
+	}
+
+	for i, s := range scenarios {
+		if v := c.Value(s.labels...); v != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, v)
+		}
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	r := metrics.NewRegistry()
+
+	h := r.Histogram("test_seconds", "test", []float64{1, 0.1}, "route")
+	h.Observe(0.05, "/a")
+	h.Observe(0.5, "/a")
+	h.Observe(5, "/a")
+
+	if c := h.Count("/a"); c != 3 {
+		t.Fatalf("Expected count 3, got %d", c)
+	}
+
+	if c := h.Count("/b"); c != 0 {
+		t.Fatalf("Expected count 0, got %d", c)
+	}
+}
+
+func TestRegistryExport(t *testing.T) {
+	r := metrics.NewRegistry()
+
+	r.Histogram("b_seconds", "Test histogram.", []float64{0.1, 1}, "route").Observe(0.5, `/a"b`)
+	c := r.Counter("a_total", "Test\ncounter.", "method")
+	c.Inc("POST")
+	c.Inc("GET")
+	r.Counter("c_total", "No labels.").Inc()
+
+	var sb strings.Builder
+	if err := r.Export(&sb); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := strings.Join([]string{
+		`# HELP a_total Test\ncounter.`,
+		`# TYPE a_total counter`,
+		`a_total{method="GET"} 1`,
+		`a_total{method="POST"} 1`,
+		`# HELP b_seconds Test histogram.`,
+		`# TYPE b_seconds histogram`,
+		`b_seconds_bucket{route="/a\"b",le="0.1"} 0`,
+		`b_seconds_bucket{route="/a\"b",le="1"} 1`,
+		`b_seconds_bucket{route="/a\"b",le="+Inf"} 1`,
+		`b_seconds_sum{route="/a\"b"} 0.5`,
+		`b_seconds_count{route="/a\"b"} 1`,
+		`# HELP c_total No labels.`,
+		`# TYPE c_total counter`,
+		`c_total 1`,
+	}, "\n") + "\n"
+
+	if sb.String() != expected {
+		t.Fatalf("Expected\n%s\ngot\n%s", expected, sb.String())
+	}
+}
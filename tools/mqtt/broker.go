@@ -0,0 +1,332 @@
+// Package mqtt implements a minimal embedded MQTT 3.1.1 broker that
+// supports only server-to-client QoS 0 publishing (useful for
+// streaming events to constrained devices).
+//
+// Inbound client PUBLISH packets, QoS 1/2 and retained messages
+// are not supported.
+package mqtt
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+// ErrBadCredentials could be returned by an [AuthenticateFunc] to
+// reject a client connection with "bad username or password" CONNACK code.
+var ErrBadCredentials = errors.New("mqtt: bad username or password")
+
+// AuthenticateFunc authenticates a newly connected client.
+//
+// Returning an error rejects the client connection.
+type AuthenticateFunc func(client *Client, username string, password string) error
+
+// Broker defines a minimal publish-only MQTT broker.
+type Broker struct {
+	// Authenticate is an optional client CONNECT credentials handler.
+	//
+	// If not set, all client connections are accepted.
+	Authenticate AuthenticateFunc
+
+	// QueueSize is the max number of pending outbound packets per client
+	// (default to 100).
+	QueueSize int
+
+	// ConnectTimeout is the max duration to wait for the client CONNECT
+	// packet after opening the connection (default to 10s).
+	ConnectTimeout time.Duration
+
+	mux       sync.RWMutex
+	clients   map[*Client]struct{}
+	listeners map[net.Listener]struct{}
+	closed    bool
+}
+
+// NewBroker initializes and returns a new Broker instance.
+func NewBroker() *Broker {
+	return &Broker{
+		QueueSize:      100,
+		ConnectTimeout: 10 * time.Second,
+		clients:        map[*Client]struct{}{},
+		listeners:      map[net.Listener]struct{}{},
+	}
+}
+
+// Clients returns a shallow copy of all currently connected clients.
+func (b *Broker) Clients() []*Client {
+	b.mux.RLock()
+	defer b.mux.RUnlock()
+
+	result := make([]*Client, 0, len(b.clients))
+	for c := range b.clients {
+		result = append(result, c)
+	}
+
+	return result
+}
+
+// TotalClients returns the number of currently connected clients.
+func (b *Broker) TotalClients() int {
+	b.mux.RLock()
+	defer b.mux.RUnlock()
+
+	return len(b.clients)
+}
+
+// Publish sends the payload to all clients subscribed to a topic
+// filter matching the provided topic name.
+//
+// The optional allow callback is invoked for each subscribed
+// client and could be used to restrict the message delivery.
+//
+// Returns the number of clients that the message was queued for.
+func (b *Broker) Publish(topic string, payload []byte, allow func(c *Client) bool) int {
+	var data []byte
+	var total int
+
+	for _, c := range b.Clients() {
+		if !c.HasMatchingSubscription(topic) {
+			continue
+		}
+
+		if allow != nil && !allow(c) {
+			continue
+		}
+
+		if data == nil {
+			data = encodePublish(topic, payload)
+		}
+
+		if c.send(data) {
+			total++
+		}
+	}
+
+	return total
+}
+
+// Serve accepts and handles the incoming client connections on the
+// listener until the listener or the broker is closed.
+func (b *Broker) Serve(ln net.Listener) error {
+	b.mux.Lock()
+	if b.closed {
+		b.mux.Unlock()
+		ln.Close()
+		return net.ErrClosed
+	}
+	b.listeners[ln] = struct{}{}
+	b.mux.Unlock()
+
+	defer func() {
+		b.mux.Lock()
+		delete(b.listeners, ln)
+		b.mux.Unlock()
+	}()
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return err
+		}
+
+		go b.handleConn(conn)
+	}
+}
+
+// Close closes all broker listeners and client connections.
+func (b *Broker) Close() error {
+	b.mux.Lock()
+	b.closed = true
+	listeners := b.listeners
+	clients := b.clients
+	b.listeners = map[net.Listener]struct{}{}
+	b.clients = map[*Client]struct{}{}
+	b.mux.Unlock()
+
+	var lastErr error
+
+	for ln := range listeners {
+		if err := ln.Close(); err != nil {
+			lastErr = err
+		}
+	}
+
+	for c := range clients {
+		c.Close()
+	}
+
+	return lastErr
+}
+
+func (b *Broker) handleConn(conn net.Conn) {
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+
+	conn.SetReadDeadline(time.Now().Add(b.ConnectTimeout))
+
+	client, keepAlive, err := b.connect(conn, r)
+	if err != nil {
+		return
+	}
+
+	b.mux.Lock()
+	if b.closed {
+		b.mux.Unlock()
+		return
+	}
+	b.clients[client] = struct{}{}
+	b.mux.Unlock()
+
+	defer func() {
+		b.mux.Lock()
+		delete(b.clients, client)
+		b.mux.Unlock()
+
+		client.Close()
+	}()
+
+	go client.writeLoop()
+
+	for {
+		// the client is considered disconnected if no packet is
+		// received within one and a half times the keep alive period
+		if keepAlive > 0 {
+			conn.SetReadDeadline(time.Now().Add(keepAlive * 3 / 2))
+		} else {
+			conn.SetReadDeadline(time.Time{})
+		}
+
+		p, err := readPacket(r)
+		if err != nil {
+			return
+		}
+
+		if !b.handlePacket(client, p) {
+			return
+		}
+	}
+}
+
+// connect reads and handles the initial client CONNECT packet.
+func (b *Broker) connect(conn net.Conn, r *bufio.Reader) (*Client, time.Duration, error) {
+	p, err := readPacket(r)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if p.kind != packetConnect {
+		return nil, 0, errors.New("mqtt: expected CONNECT packet")
+	}
+
+	d := &decoder{data: p.body}
+
+	protocol := d.string()
+	level := d.byte()
+	flags := d.byte()
+	keepAlive := time.Duration(d.uint16()) * time.Second
+	clientId := d.string()
+
+	// will message (ignored)
+	if flags&0x04 != 0 {
+		d.string()
+		d.bytes()
+	}
+
+	var username, password string
+	if flags&0x80 != 0 {
+		username = d.string()
+	}
+	if flags&0x40 != 0 {
+		password = string(d.bytes())
+	}
+
+	if d.err != nil {
+		return nil, 0, d.err
+	}
+
+	if !(protocol == "MQTT" && level == 4) && !(protocol == "MQIsdp" && level == 3) {
+		conn.Write(encodePacket(packetConnack, 0, []byte{0, connackUnacceptableVersion}))
+		return nil, 0, errors.New("mqtt: unsupported protocol version")
+	}
+
+	if clientId == "" {
+		if flags&0x02 == 0 {
+			// an empty client id is allowed only with clean session
+			conn.Write(encodePacket(packetConnack, 0, []byte{0, connackIdentifierRejected}))
+			return nil, 0, errors.New("mqtt: empty client identifier")
+		}
+
+		clientId = security.RandomString(23)
+	}
+
+	client := newClient(clientId, conn, b.QueueSize)
+
+	if b.Authenticate != nil {
+		if err := b.Authenticate(client, username, password); err != nil {
+			conn.Write(encodePacket(packetConnack, 0, []byte{0, connackBadCredentials}))
+			return nil, 0, err
+		}
+	}
+
+	if _, err := conn.Write(encodePacket(packetConnack, 0, []byte{0, connackAccepted})); err != nil {
+		return nil, 0, err
+	}
+
+	return client, keepAlive, nil
+}
+
+// handlePacket handles a single client packet and reports
+// whether the connection should be kept open.
+func (b *Broker) handlePacket(client *Client, p *packet) bool {
+	switch p.kind {
+	case packetSubscribe:
+		d := &decoder{data: p.body}
+		packetId := d.uint16()
+
+		body := []byte{byte(packetId >> 8), byte(packetId)}
+		filters := []string{}
+
+		for d.err == nil && d.remaining() > 0 {
+			filter := d.string()
+			d.byte() // requested qos (always downgraded to 0)
+
+			if d.err == nil && ValidateFilter(filter) {
+				filters = append(filters, filter)
+				body = append(body, 0)
+			} else {
+				body = append(body, subackFailure)
+			}
+		}
+
+		if d.err != nil || len(body) == 2 {
+			return false
+		}
+
+		client.subscribe(filters...)
+
+		return client.send(encodePacket(packetSuback, 0, body))
+	case packetUnsubscribe:
+		d := &decoder{data: p.body}
+		packetId := d.uint16()
+
+		for d.err == nil && d.remaining() > 0 {
+			client.unsubscribe(d.string())
+		}
+
+		if d.err != nil {
+			return false
+		}
+
+		return client.send(encodePacket(packetUnsuback, 0, []byte{byte(packetId >> 8), byte(packetId)}))
+	case packetPingreq:
+		return client.send(encodePacket(packetPingresp, 0, nil))
+	default:
+		// DISCONNECT, inbound PUBLISH (not supported) or protocol violation
+		return false
+	}
+}
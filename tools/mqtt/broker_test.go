@@ -0,0 +1,269 @@
+package mqtt
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+type testConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func startTestBroker(t *testing.T, b *Broker) string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go b.Serve(ln)
+
+	return ln.Addr().String()
+}
+
+func dialTestConn(t *testing.T, addr string, clientId string, password string) (*testConn, byte) {
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	flags := byte(0x02) // clean session
+	body := appendString(nil, "MQTT")
+	body = append(body, 4)
+	if password != "" {
+		flags |= 0x80 | 0x40
+	}
+	body = append(body, flags, 0, 0)
+	body = appendString(body, clientId)
+	if password != "" {
+		body = appendString(body, "test")
+		body = appendString(body, password)
+	}
+
+	if _, err := conn.Write(encodePacket(packetConnect, 0, body)); err != nil {
+		t.Fatal(err)
+	}
+
+	c := &testConn{Conn: conn, r: bufio.NewReader(conn)}
+
+	p := c.read(t)
+	if p.kind != packetConnack || len(p.body) != 2 {
+		t.Fatalf("Expected CONNACK packet, got %v", p)
+	}
+
+	return c, p.body[1]
+}
+
+func (c *testConn) read(t *testing.T) *packet {
+	p, err := readPacket(c.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func (c *testConn) subscribe(t *testing.T, filters ...string) []byte {
+	body := []byte{0, 1}
+	for _, f := range filters {
+		body = appendString(body, f)
+		body = append(body, 1)
+	}
+
+	if _, err := c.Write(encodePacket(packetSubscribe, 0x02, body)); err != nil {
+		t.Fatal(err)
+	}
+
+	p := c.read(t)
+	if p.kind != packetSuback {
+		t.Fatalf("Expected SUBACK packet, got %v", p)
+	}
+
+	return p.body[2:]
+}
+
+func waitForClients(t *testing.T, b *Broker, total int) {
+	for i := 0; i < 100; i++ {
+		if b.TotalClients() == total {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("Expected %d clients, got %d", total, b.TotalClients())
+}
+
+func TestBrokerConnectAuthenticate(t *testing.T) {
+	b := NewBroker()
+	defer b.Close()
+
+	b.Authenticate = func(c *Client, username, password string) error {
+		if password != "" && password != "secret" {
+			return ErrBadCredentials
+		}
+		c.Set("auth", password)
+		return nil
+	}
+
+	addr := startTestBroker(t, b)
+
+	scenarios := []struct {
+		password     string
+		expectedCode byte
+	}{
+		{"", connackAccepted},
+		{"secret", connackAccepted},
+		{"invalid", connackBadCredentials},
+	}
+
+	for i, s := range scenarios {
+		c, code := dialTestConn(t, addr, "", s.password)
+		c.Close()
+
+		if code != s.expectedCode {
+			t.Errorf("(%d) Expected CONNACK code %d, got %d", i, s.expectedCode, code)
+		}
+	}
+}
+
+func TestBrokerUnsupportedProtocol(t *testing.T) {
+	b := NewBroker()
+	defer b.Close()
+
+	addr := startTestBroker(t, b)
+
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	body := appendString(nil, "MQTT")
+	body = append(body, 5, 0x02, 0, 0)
+	body = appendString(body, "test")
+	conn.Write(encodePacket(packetConnect, 0, body))
+
+	p, err := readPacket(bufio.NewReader(conn))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if p.kind != packetConnack || p.body[1] != connackUnacceptableVersion {
+		t.Fatalf("Expected unacceptable version CONNACK, got %v", p)
+	}
+}
+
+func TestBrokerPublish(t *testing.T) {
+	b := NewBroker()
+	defer b.Close()
+
+	b.Authenticate = func(c *Client, username, password string) error {
+		c.Set("allowed", password == "secret")
+		return nil
+	}
+
+	addr := startTestBroker(t, b)
+
+	c1, _ := dialTestConn(t, addr, "c1", "secret")
+	defer c1.Close()
+
+	c2, _ := dialTestConn(t, addr, "c2", "other")
+	defer c2.Close()
+
+	c3, _ := dialTestConn(t, addr, "c3", "secret")
+	defer c3.Close()
+
+	codes := c1.subscribe(t, "pb/+/+/create", "pb/#/invalid")
+	if len(codes) != 2 || codes[0] != 0 || codes[1] != subackFailure {
+		t.Fatalf("Unexpected SUBACK codes %v", codes)
+	}
+	c2.subscribe(t, "pb/#")
+	c3.subscribe(t, "pb/demo/+/update")
+
+	waitForClients(t, b, 3)
+
+	allow := func(c *Client) bool {
+		allowed, _ := c.Get("allowed").(bool)
+		return allowed
+	}
+
+	// c2 is not allowed and c3 is not subscribed
+	if total := b.Publish("pb/demo/1/create", []byte("hello"), allow); total != 1 {
+		t.Fatalf("Expected the message to be queued for 1 client, got %d", total)
+	}
+
+	p := c1.read(t)
+	if p.kind != packetPublish {
+		t.Fatalf("Expected PUBLISH packet, got %v", p)
+	}
+
+	d := &decoder{data: p.body}
+	if topic := d.string(); topic != "pb/demo/1/create" {
+		t.Fatalf("Expected topic %q, got %q", "pb/demo/1/create", topic)
+	}
+	if payload := string(d.data); payload != "hello" {
+		t.Fatalf("Expected payload %q, got %q", "hello", payload)
+	}
+
+	// ping
+	c3.Write(encodePacket(packetPingreq, 0, nil))
+	if p := c3.read(t); p.kind != packetPingresp {
+		t.Fatalf("Expected PINGRESP packet, got %v", p)
+	}
+
+	// unsubscribe
+	c3.Write(encodePacket(packetUnsubscribe, 0x02, appendString([]byte{0, 2}, "pb/demo/+/update")))
+	if p := c3.read(t); p.kind != packetUnsuback {
+		t.Fatalf("Expected UNSUBACK packet, got %v", p)
+	}
+	if total := b.Publish("pb/demo/1/update", []byte("hello"), nil); total != 1 {
+		t.Fatalf("Expected the message to be queued only for c2, got %d", total)
+	}
+
+	// disconnect
+	c1.Write(encodePacket(packetDisconnect, 0, nil))
+	waitForClients(t, b, 2)
+}
+
+func TestBrokerClose(t *testing.T) {
+	b := NewBroker()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- b.Serve(ln)
+	}()
+
+	c, _ := dialTestConn(t, ln.Addr().String(), "test", "")
+	defer c.Close()
+
+	waitForClients(t, b, 1)
+
+	if err := b.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-serveErr:
+		if !errors.Is(err, net.ErrClosed) {
+			t.Fatalf("Expected net.ErrClosed, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Serve didn't return after Close")
+	}
+
+	if _, err := readPacket(c.r); err == nil {
+		t.Fatal("Expected the client connection to be closed")
+	}
+
+	if b.TotalClients() != 0 {
+		t.Fatalf("Expected no clients, got %d", b.TotalClients())
+	}
+}
@@ -0,0 +1,151 @@
+package mqtt
+
+import (
+	"net"
+	"sync"
+)
+
+// Client defines a single connected MQTT client.
+type Client struct {
+	mux           sync.RWMutex
+	id            string
+	conn          net.Conn
+	store         map[string]any
+	subscriptions map[string]struct{}
+	outbound      chan []byte
+	done          chan struct{}
+	closeOnce     sync.Once
+}
+
+func newClient(id string, conn net.Conn, queueSize int) *Client {
+	return &Client{
+		id:            id,
+		conn:          conn,
+		store:         map[string]any{},
+		subscriptions: map[string]struct{}{},
+		outbound:      make(chan []byte, queueSize),
+		done:          make(chan struct{}),
+	}
+}
+
+// Id returns the client identifier sent with the CONNECT packet.
+func (c *Client) Id() string {
+	return c.id
+}
+
+// RemoteAddr returns the remote network address of the client.
+func (c *Client) RemoteAddr() net.Addr {
+	return c.conn.RemoteAddr()
+}
+
+// Set stores any value to the client's context.
+func (c *Client) Set(key string, value any) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.store[key] = value
+}
+
+// Get retrieves the key value from the client's context.
+func (c *Client) Get(key string) any {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+
+	return c.store[key]
+}
+
+// Subscriptions returns a copy of all client topic filters.
+func (c *Client) Subscriptions() []string {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+
+	result := make([]string, 0, len(c.subscriptions))
+	for filter := range c.subscriptions {
+		result = append(result, filter)
+	}
+
+	return result
+}
+
+// HasMatchingSubscription checks if any of the client topic
+// filters matches the provided topic name.
+func (c *Client) HasMatchingSubscription(topic string) bool {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+
+	for filter := range c.subscriptions {
+		if MatchTopic(filter, topic) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *Client) subscribe(filters ...string) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	for _, filter := range filters {
+		c.subscriptions[filter] = struct{}{}
+	}
+}
+
+func (c *Client) unsubscribe(filters ...string) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	for _, filter := range filters {
+		delete(c.subscriptions, filter)
+	}
+}
+
+// send enqueues the encoded packet for delivery.
+//
+// The packet is dropped if the client outbound queue is full
+// (QoS 0 "at most once" delivery) so that a single slow device
+// couldn't block the publisher.
+func (c *Client) send(data []byte) bool {
+	select {
+	case <-c.done:
+		return false
+	default:
+	}
+
+	select {
+	case c.outbound <- data:
+		return true
+	default:
+		return false
+	}
+}
+
+// writeLoop writes the enqueued packets to the client connection
+// until the client is closed.
+func (c *Client) writeLoop() {
+	for {
+		select {
+		case <-c.done:
+			return
+		case data := <-c.outbound:
+			if _, err := c.conn.Write(data); err != nil {
+				c.Close()
+				return
+			}
+		}
+	}
+}
+
+// Close closes the client network connection.
+//
+// It is safe to call Close() multiple times.
+func (c *Client) Close() error {
+	var err error
+
+	c.closeOnce.Do(func() {
+		close(c.done)
+		err = c.conn.Close()
+	})
+
+	return err
+}
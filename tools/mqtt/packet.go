@@ -0,0 +1,178 @@
+package mqtt
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// List of the supported MQTT 3.1.1 control packet types.
+const (
+	packetConnect     byte = 1
+	packetConnack     byte = 2
+	packetPublish     byte = 3
+	packetSubscribe   byte = 8
+	packetSuback      byte = 9
+	packetUnsubscribe byte = 10
+	packetUnsuback    byte = 11
+	packetPingreq     byte = 12
+	packetPingresp    byte = 13
+	packetDisconnect  byte = 14
+)
+
+// List of the CONNACK return codes.
+const (
+	connackAccepted            byte = 0
+	connackUnacceptableVersion byte = 1
+	connackIdentifierRejected  byte = 2
+	connackBadCredentials      byte = 4
+)
+
+// subackFailure is the SUBACK return code for a rejected subscription.
+const subackFailure byte = 0x80
+
+// maxPacketSize is the max allowed remaining length of a single inbound packet.
+const maxPacketSize = 256 * 1024
+
+var errMalformedPacket = errors.New("mqtt: malformed packet")
+
+// packet is a single decoded control packet.
+type packet struct {
+	kind  byte
+	flags byte
+	body  []byte
+}
+
+// readPacket reads and returns the next control packet from r.
+func readPacket(r *bufio.Reader) (*packet, error) {
+	header, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+
+	// the remaining length is encoded in up to 4 bytes
+	// where the most significant bit is a continuation flag
+	length := 0
+	multiplier := 1
+	for i := 0; ; i++ {
+		if i == 4 {
+			return nil, errMalformedPacket
+		}
+
+		b, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+
+		length += int(b&127) * multiplier
+		multiplier *= 128
+
+		if b&128 == 0 {
+			break
+		}
+	}
+
+	if length > maxPacketSize {
+		return nil, errors.New("mqtt: packet too large")
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, err
+	}
+
+	return &packet{
+		kind:  header >> 4,
+		flags: header & 0x0f,
+		body:  body,
+	}, nil
+}
+
+// encodePacket encodes a single control packet with the provided body.
+func encodePacket(kind byte, flags byte, body []byte) []byte {
+	result := make([]byte, 0, len(body)+5)
+
+	result = append(result, kind<<4|flags)
+
+	length := len(body)
+	for {
+		b := byte(length % 128)
+		length /= 128
+		if length > 0 {
+			b |= 128
+		}
+		result = append(result, b)
+		if length == 0 {
+			break
+		}
+	}
+
+	return append(result, body...)
+}
+
+// encodePublish encodes a QoS 0 PUBLISH packet.
+func encodePublish(topic string, payload []byte) []byte {
+	body := make([]byte, 0, len(topic)+len(payload)+2)
+	body = appendString(body, topic)
+	body = append(body, payload...)
+
+	return encodePacket(packetPublish, 0, body)
+}
+
+// appendString appends a length prefixed MQTT string to dst.
+func appendString(dst []byte, s string) []byte {
+	dst = append(dst, byte(len(s)>>8), byte(len(s)))
+	return append(dst, s...)
+}
+
+// decoder is a helper for reading sequential packet body fields.
+type decoder struct {
+	data []byte
+	err  error
+}
+
+func (d *decoder) remaining() int {
+	return len(d.data)
+}
+
+func (d *decoder) byte() byte {
+	if d.err != nil || len(d.data) < 1 {
+		d.err = errMalformedPacket
+		return 0
+	}
+
+	b := d.data[0]
+	d.data = d.data[1:]
+
+	return b
+}
+
+func (d *decoder) uint16() uint16 {
+	if d.err != nil || len(d.data) < 2 {
+		d.err = errMalformedPacket
+		return 0
+	}
+
+	v := binary.BigEndian.Uint16(d.data)
+	d.data = d.data[2:]
+
+	return v
+}
+
+func (d *decoder) bytes() []byte {
+	n := int(d.uint16())
+	if d.err != nil || len(d.data) < n {
+		d.err = errMalformedPacket
+		return nil
+	}
+
+	v := d.data[:n]
+	d.data = d.data[n:]
+
+	return v
+}
+
+func (d *decoder) string() string {
+	return string(d.bytes())
+}
@@ -0,0 +1,57 @@
+package mqtt
+
+import "strings"
+
+// ValidateFilter checks whether the provided string is a valid
+// MQTT topic filter.
+//
+// The "+" wildcard must occupy an entire level and the "#" wildcard
+// must occupy an entire level and be the last character of the filter.
+func ValidateFilter(filter string) bool {
+	if filter == "" {
+		return false
+	}
+
+	levels := strings.Split(filter, "/")
+
+	for i, level := range levels {
+		if strings.Contains(level, "#") && (level != "#" || i != len(levels)-1) {
+			return false
+		}
+
+		if strings.Contains(level, "+") && level != "+" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// MatchTopic checks whether the topic name matches the provided topic filter
+// (single level "+" and multi level "#" wildcards are supported).
+//
+// Filters starting with a wildcard don't match topics starting with "$".
+func MatchTopic(filter string, topic string) bool {
+	if strings.HasPrefix(topic, "$") && (strings.HasPrefix(filter, "+") || strings.HasPrefix(filter, "#")) {
+		return false
+	}
+
+	filterLevels := strings.Split(filter, "/")
+	topicLevels := strings.Split(topic, "/")
+
+	for i, level := range filterLevels {
+		if level == "#" {
+			return true
+		}
+
+		if i >= len(topicLevels) {
+			return false
+		}
+
+		if level != "+" && level != topicLevels[i] {
+			return false
+		}
+	}
+
+	return len(filterLevels) == len(topicLevels)
+}
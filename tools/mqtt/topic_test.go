@@ -0,0 +1,65 @@
+package mqtt_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/mqtt"
+)
+
+func TestValidateFilter(t *testing.T) {
+	scenarios := []struct {
+		filter   string
+		expected bool
+	}{
+		{"", false},
+		{"a", true},
+		{"a/b/c", true},
+		{"#", true},
+		{"+", true},
+		{"a/+/c", true},
+		{"a/b/#", true},
+		{"+/+/#", true},
+		{"a/#/c", false},
+		{"a/b#", false},
+		{"a/b+", false},
+		{"a/+b/c", false},
+	}
+
+	for _, s := range scenarios {
+		result := mqtt.ValidateFilter(s.filter)
+		if result != s.expected {
+			t.Errorf("(%q) Expected %v, got %v", s.filter, s.expected, result)
+		}
+	}
+}
+
+func TestMatchTopic(t *testing.T) {
+	scenarios := []struct {
+		filter   string
+		topic    string
+		expected bool
+	}{
+		{"a/b", "a/b", true},
+		{"a/b", "a/c", false},
+		{"a/b", "a/b/c", false},
+		{"a/b/c", "a/b", false},
+		{"a/+", "a/b", true},
+		{"a/+", "a/b/c", false},
+		{"a/+/c", "a/b/c", true},
+		{"+/+/+", "a/b/c", true},
+		{"a/#", "a", true},
+		{"a/#", "a/b/c", true},
+		{"a/#", "b/c", false},
+		{"#", "a/b/c", true},
+		{"#", "$SYS/a", false},
+		{"+/a", "$SYS/a", false},
+		{"$SYS/#", "$SYS/a", true},
+	}
+
+	for _, s := range scenarios {
+		result := mqtt.MatchTopic(s.filter, s.topic)
+		if result != s.expected {
+			t.Errorf("(%q - %q) Expected %v, got %v", s.filter, s.topic, s.expected, result)
+		}
+	}
+}
@@ -0,0 +1,563 @@
+// Package parquet implements a minimal streaming writer of
+// Apache Parquet files with flat (non-nested) schemas.
+//
+// All columns are optional (nullable) and their values are stored
+// with the PLAIN encoding in gzip compressed data pages.
+// The rows are buffered in memory only until a row group is full,
+// which allows generating large files with bounded memory usage.
+//
+// Example:
+//
+//	w, err := parquet.NewWriter(out, []parquet.Column{
+//		{Name: "name", Type: parquet.TypeString},
+//		{Name: "age", Type: parquet.TypeInt64},
+//	})
+//	...
+//	w.WriteRow("test", 30)
+//	...
+//	err = w.Close()
+//
+// https://parquet.apache.org/docs/file-format/
+package parquet
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"github.com/spf13/cast"
+)
+
+// Supported column types.
+const (
+	// TypeString is stored as BYTE_ARRAY annotated as UTF8.
+	TypeString = "string"
+
+	// TypeInt64 is stored as INT64.
+	TypeInt64 = "int64"
+
+	// TypeDouble is stored as DOUBLE.
+	TypeDouble = "double"
+
+	// TypeBool is stored as BOOLEAN.
+	TypeBool = "bool"
+
+	// TypeTimestamp is stored as INT64 annotated as TIMESTAMP_MILLIS (UTC).
+	TypeTimestamp = "timestamp"
+)
+
+// DefaultRowGroupSize is the default max number of rows in a single row group.
+const DefaultRowGroupSize = 10000
+
+// CreatedBy is the writer application name stored in the file metadata.
+const CreatedBy = "pocketbase"
+
+var magic = []byte("PAR1")
+
+// parquet physical types
+const (
+	physicalBoolean   int32 = 0
+	physicalInt64     int32 = 2
+	physicalDouble    int32 = 5
+	physicalByteArray int32 = 6
+)
+
+// parquet converted types
+const (
+	convertedUtf8            int32 = 0
+	convertedTimestampMillis int32 = 9
+)
+
+// parquet enums
+const (
+	repetitionOptional int32 = 1
+	encodingPlain      int32 = 0
+	encodingRle        int32 = 3
+	codecGzip          int32 = 2
+	pageTypeData       int32 = 0
+)
+
+// Column describes a single file column.
+type Column struct {
+	Name string
+
+	// Type is the column values type (TypeString, TypeInt64, TypeDouble, TypeBool or TypeTimestamp).
+	Type string
+}
+
+func (c Column) physicalType() int32 {
+	switch c.Type {
+	case TypeInt64, TypeTimestamp:
+		return physicalInt64
+	case TypeDouble:
+		return physicalDouble
+	case TypeBool:
+		return physicalBoolean
+	default:
+		return physicalByteArray
+	}
+}
+
+// Writer is a streaming Parquet file writer.
+//
+// Writer is not safe for concurrent use.
+type Writer struct {
+	w       *countingWriter
+	columns []Column
+	closed  bool
+
+	// RowGroupSize is the max number of rows in a single row group
+	// (could be changed before writing the first row).
+	RowGroupSize int
+
+	chunks    []*columnChunk
+	groupRows int
+	totalRows int64
+	rowGroups []*rowGroupMeta
+}
+
+type columnChunk struct {
+	defLevels []bool
+	values    bytes.Buffer
+	boolBits  []bool
+}
+
+type rowGroupMeta struct {
+	numRows   int64
+	totalSize int64
+	columns   []*columnMeta
+}
+
+type columnMeta struct {
+	offset           int64
+	numValues        int64
+	uncompressedSize int64
+	compressedSize   int64
+}
+
+// NewWriter creates a new Parquet Writer with the provided columns
+// and writes the file header to w.
+//
+// Call [Writer.Close()] to flush the buffered rows and write the file footer.
+func NewWriter(w io.Writer, columns []Column) (*Writer, error) {
+	if len(columns) == 0 {
+		return nil, errors.New("parquet: at least one column is required")
+	}
+
+	names := make(map[string]struct{}, len(columns))
+	for _, c := range columns {
+		if c.Name == "" {
+			return nil, errors.New("parquet: column name is required")
+		}
+		if _, ok := names[c.Name]; ok {
+			return nil, fmt.Errorf("parquet: duplicated column %q", c.Name)
+		}
+		names[c.Name] = struct{}{}
+
+		switch c.Type {
+		case TypeString, TypeInt64, TypeDouble, TypeBool, TypeTimestamp:
+		default:
+			return nil, fmt.Errorf("parquet: unsupported column %q type %q", c.Name, c.Type)
+		}
+	}
+
+	result := &Writer{
+		w:            &countingWriter{w: w},
+		columns:      columns,
+		RowGroupSize: DefaultRowGroupSize,
+	}
+
+	result.resetChunks()
+
+	if _, err := result.w.Write(magic); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// WriteRow converts the provided values to their column type
+// and adds them to the current row group.
+//
+// Nil, empty string and zero time values are stored as null.
+//
+// Returns an error if the number of values doesn't match the
+// number of columns or if a value can't be converted.
+func (w *Writer) WriteRow(values ...any) error {
+	if w.closed {
+		return errors.New("parquet: writer is closed")
+	}
+
+	if len(values) != len(w.columns) {
+		return fmt.Errorf("parquet: expected %d values, got %d", len(w.columns), len(values))
+	}
+
+	// encode all values first to avoid partially written rows
+	cells := make([]cell, len(values))
+	for i, value := range values {
+		v, err := encodeCell(w.columns[i], value)
+		if err != nil {
+			return fmt.Errorf("parquet: invalid column %q value: %w", w.columns[i].Name, err)
+		}
+		cells[i] = v
+	}
+
+	for i, v := range cells {
+		w.chunks[i].add(w.columns[i], v)
+	}
+
+	w.groupRows++
+
+	if w.RowGroupSize > 0 && w.groupRows >= w.RowGroupSize {
+		return w.flushRowGroup()
+	}
+
+	return nil
+}
+
+// Close flushes the buffered rows and writes the file footer.
+//
+// Close doesn't close the underlying writer.
+func (w *Writer) Close() error {
+	if w.closed {
+		return nil
+	}
+
+	if err := w.flushRowGroup(); err != nil {
+		return err
+	}
+
+	w.closed = true
+
+	footer := w.fileMetadata()
+
+	if _, err := w.w.Write(footer); err != nil {
+		return err
+	}
+
+	size := make([]byte, 4)
+	binary.LittleEndian.PutUint32(size, uint32(len(footer)))
+	if _, err := w.w.Write(size); err != nil {
+		return err
+	}
+
+	_, err := w.w.Write(magic)
+
+	return err
+}
+
+func (w *Writer) resetChunks() {
+	w.chunks = make([]*columnChunk, len(w.columns))
+	for i := range w.chunks {
+		w.chunks[i] = &columnChunk{}
+	}
+	w.groupRows = 0
+}
+
+// flushRowGroup writes the buffered rows as a new row group
+// with a single data page per column.
+func (w *Writer) flushRowGroup() error {
+	if w.groupRows == 0 {
+		return nil
+	}
+
+	group := &rowGroupMeta{numRows: int64(w.groupRows)}
+
+	for i, chunk := range w.chunks {
+		meta, err := w.writeColumnChunk(w.columns[i], chunk)
+		if err != nil {
+			return err
+		}
+
+		group.totalSize += meta.uncompressedSize
+		group.columns = append(group.columns, meta)
+	}
+
+	w.rowGroups = append(w.rowGroups, group)
+	w.totalRows += group.numRows
+
+	w.resetChunks()
+
+	return nil
+}
+
+func (w *Writer) writeColumnChunk(column Column, chunk *columnChunk) (*columnMeta, error) {
+	page := new(bytes.Buffer)
+
+	// definition levels (max level 1) prefixed with their byte length
+	levels := encodeBitPackedRun(chunk.defLevels)
+	binary.Write(page, binary.LittleEndian, uint32(len(levels)))
+	page.Write(levels)
+
+	// values
+	if column.Type == TypeBool {
+		page.Write(packBits(chunk.boolBits))
+	} else {
+		page.Write(chunk.values.Bytes())
+	}
+
+	compressed := new(bytes.Buffer)
+	zw := gzip.NewWriter(compressed)
+	if _, err := zw.Write(page.Bytes()); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	header := &thriftWriter{}
+	header.StructBegin()
+	header.I32Field(1, pageTypeData)
+	header.I32Field(2, int32(page.Len()))
+	header.I32Field(3, int32(compressed.Len()))
+	header.StructField(5)
+	header.I32Field(1, int32(len(chunk.defLevels)))
+	header.I32Field(2, encodingPlain)
+	header.I32Field(3, encodingRle)
+	header.I32Field(4, encodingRle)
+	header.StructEnd()
+	header.StructEnd()
+
+	meta := &columnMeta{
+		offset:           w.w.n,
+		numValues:        int64(len(chunk.defLevels)),
+		uncompressedSize: int64(len(header.Bytes()) + page.Len()),
+		compressedSize:   int64(len(header.Bytes()) + compressed.Len()),
+	}
+
+	if _, err := w.w.Write(header.Bytes()); err != nil {
+		return nil, err
+	}
+
+	if _, err := w.w.Write(compressed.Bytes()); err != nil {
+		return nil, err
+	}
+
+	return meta, nil
+}
+
+func (w *Writer) fileMetadata() []byte {
+	t := &thriftWriter{}
+	t.StructBegin()
+
+	// version
+	t.I32Field(1, 1)
+
+	// schema (the root element followed by the columns)
+	t.ListField(2, thriftStruct, len(w.columns)+1)
+	t.StructBegin()
+	t.StringField(4, "schema")
+	t.I32Field(5, int32(len(w.columns)))
+	t.StructEnd()
+	for _, c := range w.columns {
+		t.StructBegin()
+		t.I32Field(1, c.physicalType())
+		t.I32Field(3, repetitionOptional)
+		t.StringField(4, c.Name)
+		switch c.Type {
+		case TypeString:
+			t.I32Field(6, convertedUtf8)
+		case TypeTimestamp:
+			t.I32Field(6, convertedTimestampMillis)
+		}
+		t.StructEnd()
+	}
+
+	// num_rows
+	t.I64Field(3, w.totalRows)
+
+	// row_groups
+	t.ListField(4, thriftStruct, len(w.rowGroups))
+	for _, group := range w.rowGroups {
+		t.StructBegin()
+
+		t.ListField(1, thriftStruct, len(group.columns))
+		for i, c := range group.columns {
+			t.StructBegin()
+			t.I64Field(2, c.offset)
+
+			t.StructField(3)
+			t.I32Field(1, w.columns[i].physicalType())
+			t.I32ListField(2, []int32{encodingPlain, encodingRle})
+			t.StringListField(3, []string{w.columns[i].Name})
+			t.I32Field(4, codecGzip)
+			t.I64Field(5, c.numValues)
+			t.I64Field(6, c.uncompressedSize)
+			t.I64Field(7, c.compressedSize)
+			t.I64Field(9, c.offset)
+			t.StructEnd()
+
+			t.StructEnd()
+		}
+
+		t.I64Field(2, group.totalSize)
+		t.I64Field(3, group.numRows)
+		t.StructEnd()
+	}
+
+	// created_by
+	t.StringField(6, CreatedBy)
+
+	t.StructEnd()
+
+	return t.Bytes()
+}
+
+// cell is a single encoded column value.
+type cell struct {
+	null bool
+	bit  bool   // the boolean value
+	raw  []byte // the PLAIN encoded non-boolean value
+}
+
+// encodeCell converts the provided value to the column type
+// and encodes it (empty values are encoded as null).
+func encodeCell(column Column, value any) (cell, error) {
+	if isNull(value) {
+		return cell{null: true}, nil
+	}
+
+	raw := new(bytes.Buffer)
+
+	switch column.Type {
+	case TypeInt64:
+		v, err := cast.ToInt64E(value)
+		if err != nil {
+			return cell{}, err
+		}
+		binary.Write(raw, binary.LittleEndian, v)
+	case TypeDouble:
+		v, err := cast.ToFloat64E(value)
+		if err != nil {
+			return cell{}, err
+		}
+		binary.Write(raw, binary.LittleEndian, math.Float64bits(v))
+	case TypeBool:
+		v, err := cast.ToBoolE(value)
+		if err != nil {
+			return cell{}, err
+		}
+		return cell{bit: v}, nil
+	case TypeTimestamp:
+		v, err := toTime(value)
+		if err != nil {
+			return cell{}, err
+		}
+		if v.IsZero() {
+			return cell{null: true}, nil
+		}
+		binary.Write(raw, binary.LittleEndian, v.UnixMilli())
+	default:
+		v, err := toString(value)
+		if err != nil {
+			return cell{}, err
+		}
+		binary.Write(raw, binary.LittleEndian, uint32(len(v)))
+		raw.WriteString(v)
+	}
+
+	return cell{raw: raw.Bytes()}, nil
+}
+
+// add appends the provided encoded value to the column chunk.
+func (c *columnChunk) add(column Column, v cell) {
+	c.defLevels = append(c.defLevels, !v.null)
+
+	if v.null {
+		return
+	}
+
+	if column.Type == TypeBool {
+		c.boolBits = append(c.boolBits, v.bit)
+	} else {
+		c.values.Write(v.raw)
+	}
+}
+
+func isNull(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case fmt.Stringer:
+		return v.String() == ""
+	}
+
+	return false
+}
+
+func toTime(value any) (time.Time, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case *time.Time:
+		if v == nil {
+			return time.Time{}, nil
+		}
+		return *v, nil
+	case fmt.Stringer:
+		return cast.ToTimeE(v.String())
+	}
+
+	return cast.ToTimeE(value)
+}
+
+// toString normalizes the provided value into a single string
+// (non-scalar values are json encoded).
+func toString(value any) (string, error) {
+	switch v := value.(type) {
+	case string, bool, int, int64, float64:
+		return cast.ToString(v), nil
+	case fmt.Stringer:
+		return v.String(), nil
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	return string(raw), nil
+}
+
+// encodeBitPackedRun encodes the provided 1 bit width values
+// as a single bit-packed run of the RLE/bit-packing hybrid encoding.
+func encodeBitPackedRun(values []bool) []byte {
+	groups := (len(values) + 7) / 8
+
+	var header [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(header[:], uint64(groups<<1|1))
+
+	return append(header[:n], packBits(values)...)
+}
+
+// packBits packs the provided values in LSB-first order.
+func packBits(values []bool) []byte {
+	result := make([]byte, (len(values)+7)/8)
+
+	for i, v := range values {
+		if v {
+			result[i/8] |= 1 << (i % 8)
+		}
+	}
+
+	return result
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
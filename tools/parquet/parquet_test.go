@@ -0,0 +1,308 @@
+package parquet_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"io"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/parquet"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestNewWriterValidation(t *testing.T) {
+	scenarios := []struct {
+		name    string
+		columns []parquet.Column
+	}{
+		{"no columns", nil},
+		{"empty name", []parquet.Column{{Type: parquet.TypeString}}},
+		{"duplicated name", []parquet.Column{{Name: "a", Type: parquet.TypeString}, {Name: "a", Type: parquet.TypeBool}}},
+		{"invalid type", []parquet.Column{{Name: "a", Type: "missing"}}},
+	}
+
+	for _, s := range scenarios {
+		if _, err := parquet.NewWriter(io.Discard, s.columns); err == nil {
+			t.Errorf("[%s] Expected error, got nil", s.name)
+		}
+	}
+}
+
+func TestWriter(t *testing.T) {
+	buf := new(bytes.Buffer)
+
+	w, err := parquet.NewWriter(buf, []parquet.Column{
+		{Name: "name", Type: parquet.TypeString},
+		{Name: "total", Type: parquet.TypeInt64},
+		{Name: "price", Type: parquet.TypeDouble},
+		{Name: "active", Type: parquet.TypeBool},
+		{Name: "created", Type: parquet.TypeTimestamp},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.RowGroupSize = 2
+
+	created, _ := types.ParseDateTime("2023-01-07 12:00:00.123Z")
+
+	rows := [][]any{
+		{"a", 1, 1.5, true, created},
+		{"", "2", nil, "false", types.DateTime{}},
+		{[]string{"x"}, nil, 3, nil, time.Date(2023, 1, 8, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, row := range rows {
+		if err := w.WriteRow(row...); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := w.WriteRow("a"); err == nil {
+		t.Fatal("Expected values count error, got nil")
+	}
+
+	if err := w.WriteRow("a", "invalid", nil, nil, nil); err == nil {
+		t.Fatal("Expected invalid int64 error, got nil")
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := w.WriteRow("a", 1, 1, true, nil); err == nil {
+		t.Fatal("Expected error after close, got nil")
+	}
+
+	content := buf.Bytes()
+
+	if !bytes.HasPrefix(content, []byte("PAR1")) || !bytes.HasSuffix(content, []byte("PAR1")) {
+		t.Fatal("Missing the parquet magic bytes")
+	}
+
+	footerSize := int(binary.LittleEndian.Uint32(content[len(content)-8:]))
+	footer := content[len(content)-8-footerSize : len(content)-8]
+
+	meta := newThriftReader(footer).readStruct()
+
+	if v := meta[3]; v != int64(3) {
+		t.Fatalf("Expected 3 rows, got %v", v)
+	}
+
+	if v := meta[6]; v != parquet.CreatedBy {
+		t.Fatalf("Expected created_by %q, got %v", parquet.CreatedBy, v)
+	}
+
+	// schema
+	expectedSchema := []struct {
+		name          string
+		physicalType  any
+		convertedType any
+	}{
+		{"schema", nil, nil},
+		{"name", int64(6), int64(0)},
+		{"total", int64(2), nil},
+		{"price", int64(5), nil},
+		{"active", int64(0), nil},
+		{"created", int64(2), int64(9)},
+	}
+	schema := meta[2].([]any)
+	if len(schema) != len(expectedSchema) {
+		t.Fatalf("Expected %d schema elements, got %d", len(expectedSchema), len(schema))
+	}
+	for i, expected := range expectedSchema {
+		element := schema[i].(map[int16]any)
+		if element[4] != expected.name || element[1] != expected.physicalType || element[6] != expected.convertedType {
+			t.Fatalf("(%d) Unexpected schema element %v", i, element)
+		}
+	}
+
+	// row groups
+	rowGroups := meta[4].([]any)
+	if len(rowGroups) != 2 {
+		t.Fatalf("Expected 2 row groups, got %d", len(rowGroups))
+	}
+
+	readColumn := func(group int, column int) (*pageData, map[int16]any) {
+		chunk := rowGroups[group].(map[int16]any)[1].([]any)[column].(map[int16]any)
+		chunkMeta := chunk[3].(map[int16]any)
+		return readPage(t, content, chunkMeta[9].(int64)), chunkMeta
+	}
+
+	// first row group
+	// ---
+	page, chunkMeta := readColumn(0, 0)
+	if chunkMeta[5] != int64(2) || page.numValues != 2 {
+		t.Fatalf("Expected 2 name values, got %v (%d)", chunkMeta[5], page.numValues)
+	}
+	if page.levels != "10" {
+		t.Fatalf("Expected name levels 10, got %s", page.levels)
+	}
+	if !bytes.Equal(page.values, []byte{1, 0, 0, 0, 'a'}) {
+		t.Fatalf("Unexpected name values %v", page.values)
+	}
+
+	page, _ = readColumn(0, 1)
+	if page.levels != "11" || binary.LittleEndian.Uint64(page.values) != 1 || binary.LittleEndian.Uint64(page.values[8:]) != 2 {
+		t.Fatalf("Unexpected total page %v", page)
+	}
+
+	page, _ = readColumn(0, 2)
+	if page.levels != "10" || math.Float64frombits(binary.LittleEndian.Uint64(page.values)) != 1.5 {
+		t.Fatalf("Unexpected price page %v", page)
+	}
+
+	page, _ = readColumn(0, 3)
+	if page.levels != "11" || !bytes.Equal(page.values, []byte{1}) {
+		t.Fatalf("Unexpected active page %v", page)
+	}
+
+	page, _ = readColumn(0, 4)
+	if page.levels != "10" || int64(binary.LittleEndian.Uint64(page.values)) != created.Time().UnixMilli() {
+		t.Fatalf("Unexpected created page %v", page)
+	}
+
+	// second row group
+	// ---
+	page, _ = readColumn(1, 0)
+	if page.levels != "1" || !bytes.Equal(page.values, []byte{5, 0, 0, 0, '[', '"', 'x', '"', ']'}) {
+		t.Fatalf("Unexpected name page %v", page)
+	}
+
+	page, _ = readColumn(1, 1)
+	if page.levels != "0" || len(page.values) != 0 {
+		t.Fatalf("Unexpected total page %v", page)
+	}
+}
+
+type pageData struct {
+	numValues int
+	levels    string
+	values    []byte
+}
+
+// readPage reads and decompresses the data page at the specified offset.
+func readPage(t *testing.T, content []byte, offset int64) *pageData {
+	r := newThriftReader(content[offset:])
+	header := r.readStruct()
+
+	dataHeader := header[5].(map[int16]any)
+	numValues := int(dataHeader[1].(int64))
+
+	compressedSize := int(header[3].(int64))
+	compressed := content[int(offset)+r.pos : int(offset)+r.pos+compressedSize]
+
+	zr, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v := int(header[2].(int64)); v != len(raw) {
+		t.Fatalf("Expected uncompressed page size %d, got %d", v, len(raw))
+	}
+
+	levelsSize := int(binary.LittleEndian.Uint32(raw))
+	levels := raw[4 : 4+levelsSize]
+
+	// single bit-packed run
+	runHeader, n := binary.Uvarint(levels)
+	if runHeader&1 != 1 {
+		t.Fatalf("Expected bit-packed run, got %d", runHeader)
+	}
+
+	result := &pageData{numValues: numValues, values: raw[4+levelsSize:]}
+	for i := 0; i < numValues; i++ {
+		if levels[n+i/8]&(1<<(i%8)) != 0 {
+			result.levels += "1"
+		} else {
+			result.levels += "0"
+		}
+	}
+
+	return result
+}
+
+// thriftReader is a minimal Thrift compact protocol decoder
+// (structs are decoded as field id -> value maps).
+type thriftReader struct {
+	data []byte
+	pos  int
+}
+
+func newThriftReader(data []byte) *thriftReader {
+	return &thriftReader{data: data}
+}
+
+func (r *thriftReader) readByte() byte {
+	b := r.data[r.pos]
+	r.pos++
+	return b
+}
+
+func (r *thriftReader) readUvarint() uint64 {
+	v, n := binary.Uvarint(r.data[r.pos:])
+	r.pos += n
+	return v
+}
+
+func (r *thriftReader) readVarint() int64 {
+	v := r.readUvarint()
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+func (r *thriftReader) readValue(fieldType byte) any {
+	switch fieldType {
+	case 1:
+		return true
+	case 2:
+		return false
+	case 5, 6:
+		return r.readVarint()
+	case 8:
+		size := int(r.readUvarint())
+		v := string(r.data[r.pos : r.pos+size])
+		r.pos += size
+		return v
+	case 9:
+		header := r.readByte()
+		size := int(header >> 4)
+		if size == 15 {
+			size = int(r.readUvarint())
+		}
+		items := make([]any, size)
+		for i := range items {
+			items[i] = r.readValue(header & 0x0f)
+		}
+		return items
+	case 12:
+		return r.readStruct()
+	}
+
+	panic("unsupported thrift type")
+}
+
+func (r *thriftReader) readStruct() map[int16]any {
+	result := map[int16]any{}
+
+	var lastId int16
+	for {
+		header := r.readByte()
+		if header == 0 {
+			return result
+		}
+
+		fieldType := header & 0x0f
+		if delta := int16(header >> 4); delta != 0 {
+			lastId += delta
+		} else {
+			lastId = int16(r.readVarint())
+		}
+
+		result[lastId] = r.readValue(fieldType)
+	}
+}
@@ -0,0 +1,119 @@
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// thrift compact protocol field types
+const (
+	thriftI32    byte = 5
+	thriftI64    byte = 6
+	thriftBinary byte = 8
+	thriftList   byte = 9
+	thriftStruct byte = 12
+)
+
+// thriftWriter is a minimal Thrift compact protocol encoder
+// that covers only the Parquet metadata structures used by the [Writer].
+//
+// https://github.com/apache/thrift/blob/master/doc/specs/thrift-compact-protocol.md
+type thriftWriter struct {
+	buf bytes.Buffer
+
+	lastId  int16
+	idStack []int16
+}
+
+func (t *thriftWriter) Bytes() []byte {
+	return t.buf.Bytes()
+}
+
+func (t *thriftWriter) fieldHeader(id int16, fieldType byte) {
+	delta := id - t.lastId
+	if delta > 0 && delta <= 15 {
+		t.buf.WriteByte(byte(delta)<<4 | fieldType)
+	} else {
+		t.buf.WriteByte(fieldType)
+		t.writeVarint(int64(id))
+	}
+
+	t.lastId = id
+}
+
+func (t *thriftWriter) writeUvarint(v uint64) {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(b[:], v)
+	t.buf.Write(b[:n])
+}
+
+// writeVarint writes a zigzag encoded varint.
+func (t *thriftWriter) writeVarint(v int64) {
+	t.writeUvarint(uint64((v << 1) ^ (v >> 63)))
+}
+
+func (t *thriftWriter) writeString(v string) {
+	t.writeUvarint(uint64(len(v)))
+	t.buf.WriteString(v)
+}
+
+func (t *thriftWriter) I32Field(id int16, v int32) {
+	t.fieldHeader(id, thriftI32)
+	t.writeVarint(int64(v))
+}
+
+func (t *thriftWriter) I64Field(id int16, v int64) {
+	t.fieldHeader(id, thriftI64)
+	t.writeVarint(v)
+}
+
+func (t *thriftWriter) StringField(id int16, v string) {
+	t.fieldHeader(id, thriftBinary)
+	t.writeString(v)
+}
+
+// StructField starts a nested struct field (must be closed with [thriftWriter.StructEnd()]).
+func (t *thriftWriter) StructField(id int16) {
+	t.fieldHeader(id, thriftStruct)
+	t.StructBegin()
+}
+
+// StructBegin starts a new struct (or a struct list element).
+func (t *thriftWriter) StructBegin() {
+	t.idStack = append(t.idStack, t.lastId)
+	t.lastId = 0
+}
+
+// StructEnd writes the struct stop marker.
+func (t *thriftWriter) StructEnd() {
+	t.buf.WriteByte(0)
+
+	t.lastId = t.idStack[len(t.idStack)-1]
+	t.idStack = t.idStack[:len(t.idStack)-1]
+}
+
+// ListField writes a list field header (the list elements must follow).
+func (t *thriftWriter) ListField(id int16, elemType byte, size int) {
+	t.fieldHeader(id, thriftList)
+
+	if size < 15 {
+		t.buf.WriteByte(byte(size)<<4 | elemType)
+	} else {
+		t.buf.WriteByte(0xf0 | elemType)
+		t.writeUvarint(uint64(size))
+	}
+}
+
+func (t *thriftWriter) I32ListField(id int16, values []int32) {
+	t.ListField(id, thriftI32, len(values))
+	for _, v := range values {
+		t.writeVarint(int64(v))
+	}
+}
+
+func (t *thriftWriter) StringListField(id int16, values []string) {
+	t.ListField(id, thriftBinary, len(values))
+	for _, v := range values {
+		t.writeString(v)
+	}
+}
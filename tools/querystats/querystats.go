@@ -0,0 +1,175 @@
+// Package querystats implements a lightweight in-memory tracker
+// for the executed SQL queries and their durations.
+package querystats
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCapacity is the default number of the most recent query
+// executions kept by a Tracker.
+const DefaultCapacity int = 5000
+
+var (
+	stringLiteralRegex = regexp.MustCompile(`'(?:[^']|'')*'`)
+	blobLiteralRegex   = regexp.MustCompile(`\b0x[0-9a-fA-F]+\b`)
+	numberLiteralRegex = regexp.MustCompile(`\b\d+(?:\.\d+)?\b`)
+	valuesListRegex    = regexp.MustCompile(`\(\s*\?(?:\s*,\s*\?)+\s*\)`)
+	whitespaceRegex    = regexp.MustCompile(`\s+`)
+)
+
+// Normalize replaces the literal values of the provided SQL statement
+// with "?" placeholders so that the executions of the same query
+// with different params could be grouped together.
+func Normalize(sql string) string {
+	sql = stringLiteralRegex.ReplaceAllString(sql, "?")
+	sql = blobLiteralRegex.ReplaceAllString(sql, "?")
+	sql = numberLiteralRegex.ReplaceAllString(sql, "?")
+	sql = valuesListRegex.ReplaceAllString(sql, "(?)")
+	sql = whitespaceRegex.ReplaceAllString(sql, " ")
+
+	return strings.TrimSpace(sql)
+}
+
+// Stat defines the aggregated execution stats of a single normalized query.
+//
+// All durations are in milliseconds.
+type Stat struct {
+	Query    string    `json:"query"`
+	Count    int       `json:"count"`
+	Avg      float64   `json:"avg"`
+	P95      float64   `json:"p95"`
+	Max      float64   `json:"max"`
+	LastExec time.Time `json:"lastExec"`
+}
+
+type sample struct {
+	query    string
+	duration time.Duration
+	time     time.Time
+}
+
+// Tracker is a concurrent safe ring buffer with the most recent
+// query executions.
+type Tracker struct {
+	mux     sync.RWMutex
+	samples []sample
+	next    int
+	full    bool
+}
+
+// NewTracker creates a new Tracker that keeps the last capacity
+// query executions (fallbacks to DefaultCapacity if capacity is <= 0).
+func NewTracker(capacity int) *Tracker {
+	if capacity <= 0 {
+		capacity = DefaultCapacity
+	}
+
+	return &Tracker{
+		samples: make([]sample, capacity),
+	}
+}
+
+// Track normalizes and registers a single query execution,
+// overwriting the oldest one if the buffer is full.
+func (t *Tracker) Track(sql string, duration time.Duration) {
+	query := Normalize(sql)
+	if query == "" {
+		return
+	}
+
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	t.samples[t.next] = sample{query: query, duration: duration, time: time.Now()}
+
+	t.next++
+	if t.next == len(t.samples) {
+		t.next = 0
+		t.full = true
+	}
+}
+
+// Reset removes all tracked query executions.
+func (t *Tracker) Reset() {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	t.samples = make([]sample, len(t.samples))
+	t.next = 0
+	t.full = false
+}
+
+// Slowest returns up to limit aggregated query stats
+// sorted by their p95 duration in descending order.
+//
+// A non-positive limit returns the stats of all tracked queries.
+func (t *Tracker) Slowest(limit int) []*Stat {
+	durations := map[string][]time.Duration{}
+	stats := map[string]*Stat{}
+
+	t.mux.RLock()
+	total := t.next
+	if t.full {
+		total = len(t.samples)
+	}
+	for _, s := range t.samples[:total] {
+		stat, ok := stats[s.query]
+		if !ok {
+			stat = &Stat{Query: s.query}
+			stats[s.query] = stat
+		}
+		if s.time.After(stat.LastExec) {
+			stat.LastExec = s.time
+		}
+		durations[s.query] = append(durations[s.query], s.duration)
+	}
+	t.mux.RUnlock()
+
+	result := make([]*Stat, 0, len(stats))
+
+	for query, stat := range stats {
+		list := durations[query]
+
+		sort.Slice(list, func(i, j int) bool {
+			return list[i] < list[j]
+		})
+
+		var sum time.Duration
+		for _, d := range list {
+			sum += d
+		}
+
+		// nearest-rank percentile
+		p95Index := int(math.Ceil(0.95*float64(len(list)))) - 1
+
+		stat.Count = len(list)
+		stat.Avg = toMilliseconds(sum / time.Duration(len(list)))
+		stat.P95 = toMilliseconds(list[p95Index])
+		stat.Max = toMilliseconds(list[len(list)-1])
+
+		result = append(result, stat)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].P95 == result[j].P95 {
+			return result[i].Query < result[j].Query
+		}
+		return result[i].P95 > result[j].P95
+	})
+
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+
+	return result
+}
+
+func toMilliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
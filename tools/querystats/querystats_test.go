@@ -0,0 +1,94 @@
+package querystats_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/querystats"
+)
+
+func TestNormalize(t *testing.T) {
+	scenarios := []struct {
+		sql      string
+		expected string
+	}{
+		{"", ""},
+		{"  SELECT\n\t*   FROM `demo2`  ", "SELECT * FROM `demo2`"},
+		{"SELECT * FROM `demo2` WHERE `title`='it''s' AND `id`=123", "SELECT * FROM `demo2` WHERE `title`=? AND `id`=?"},
+		{"SELECT * FROM `demo2` WHERE `total` > 1.5 AND `data` = 0x0aF1", "SELECT * FROM `demo2` WHERE `total` > ? AND `data` = ?"},
+		{"SELECT * FROM `demo2` WHERE `id` IN ('a', 'b',  'c')", "SELECT * FROM `demo2` WHERE `id` IN (?)"},
+		{"SELECT * FROM `demo2` LIMIT 10 OFFSET 20", "SELECT * FROM `demo2` LIMIT ? OFFSET ?"},
+	}
+
+	for i, s := range scenarios {
+		result := querystats.Normalize(s.sql)
+		if result != s.expected {
+			t.Errorf("(%d) Expected %q, got %q", i, s.expected, result)
+		}
+	}
+}
+
+func TestTrackerSlowest(t *testing.T) {
+	tracker := querystats.NewTracker(0)
+
+	if stats := tracker.Slowest(0); len(stats) != 0 {
+		t.Fatalf("Expected no stats, got %v", stats)
+	}
+
+	for i := 1; i <= 20; i++ {
+		tracker.Track("SELECT * FROM a WHERE id = 'test'", time.Duration(i)*time.Millisecond)
+	}
+	tracker.Track("SELECT * FROM b WHERE id = 1", 15*time.Millisecond)
+	tracker.Track("SELECT * FROM b WHERE id = 2", 25*time.Millisecond)
+	tracker.Track("   ", 100*time.Millisecond) // ignored
+
+	stats := tracker.Slowest(0)
+	if len(stats) != 2 {
+		t.Fatalf("Expected 2 stats, got %d", len(stats))
+	}
+
+	if stats[0].Query != "SELECT * FROM b WHERE id = ?" || stats[0].Count != 2 || stats[0].P95 != 25 || stats[0].Max != 25 || stats[0].Avg != 20 {
+		t.Fatalf("Unexpected first stat %+v", stats[0])
+	}
+
+	if stats[1].Query != "SELECT * FROM a WHERE id = ?" || stats[1].Count != 20 || stats[1].P95 != 19 || stats[1].Max != 20 || stats[1].Avg != 10.5 {
+		t.Fatalf("Unexpected second stat %+v", stats[1])
+	}
+
+	if stats[0].LastExec.IsZero() {
+		t.Fatal("Expected LastExec to be set")
+	}
+
+	if limited := tracker.Slowest(1); len(limited) != 1 || limited[0].Query != stats[0].Query {
+		t.Fatalf("Expected only the slowest stat, got %v", limited)
+	}
+
+	tracker.Reset()
+
+	if stats := tracker.Slowest(0); len(stats) != 0 {
+		t.Fatalf("Expected no stats after reset, got %v", stats)
+	}
+}
+
+func TestTrackerCapacity(t *testing.T) {
+	tracker := querystats.NewTracker(3)
+
+	tracker.Track("SELECT 'a'", 100*time.Millisecond)
+	tracker.Track("SELECT a", time.Millisecond)
+	tracker.Track("SELECT b", 2*time.Millisecond)
+	tracker.Track("SELECT c", 3*time.Millisecond) // overwrites the first one
+
+	stats := tracker.Slowest(0)
+
+	expected := []string{"SELECT c", "SELECT b", "SELECT a"}
+
+	if len(stats) != len(expected) {
+		t.Fatalf("Expected %d stats, got %d", len(expected), len(stats))
+	}
+
+	for i, query := range expected {
+		if stats[i].Query != query {
+			t.Errorf("(%d) Expected query %q, got %q", i, query, stats[i].Query)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// Package ratelimit implements a simple concurrent safe
+// in memory fixed window rate limiter.
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// Usage defines the current consumption of a single rate limit key.
+type Usage struct {
+	// Limit is the max number of allowed requests per window (0 means unlimited).
+	Limit int `json:"limit"`
+
+	// Used is the number of consumed requests in the current window.
+	Used int `json:"used"`
+
+	// Remaining is the number of requests left in the current window
+	// (-1 if the limit is unlimited).
+	Remaining int `json:"remaining"`
+
+	// ResetAt is the end of the current window (zero if there is no active window).
+	ResetAt time.Time `json:"resetAt"`
+}
+
+type window struct {
+	used    int
+	resetAt time.Time
+}
+
+// Limiter defines a fixed window rate limiter.
+type Limiter struct {
+	mux         sync.Mutex
+	windows     map[string]*window
+	nextCleanup time.Time
+}
+
+// New creates a new Limiter instance.
+func New() *Limiter {
+	return &Limiter{
+		windows: map[string]*window{},
+	}
+}
+
+// Allow consumes a single request for the provided key and reports
+// whether it fits in the limit of max requests per duration window.
+//
+// A non-positive max is treated as unlimited.
+func (l *Limiter) Allow(key string, max int, duration time.Duration) (bool, Usage) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	now := time.Now()
+
+	l.cleanup(now)
+
+	w := l.windows[key]
+	if w == nil || !now.Before(w.resetAt) {
+		w = &window{resetAt: now.Add(duration)}
+		l.windows[key] = w
+	}
+
+	allowed := max <= 0 || w.used < max
+	if allowed {
+		w.used++
+	}
+
+	return allowed, newUsage(w, max)
+}
+
+// Usage returns the current consumption of the provided key
+// without consuming a request.
+func (l *Limiter) Usage(key string, max int) Usage {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	w := l.windows[key]
+	if w == nil || !time.Now().Before(w.resetAt) {
+		w = &window{}
+	}
+
+	return newUsage(w, max)
+}
+
+// Reset clears the current window of the provided key.
+func (l *Limiter) Reset(key string) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	delete(l.windows, key)
+}
+
+// cleanup removes the expired windows (at most once per minute).
+func (l *Limiter) cleanup(now time.Time) {
+	if now.Before(l.nextCleanup) {
+		return
+	}
+
+	for key, w := range l.windows {
+		if !now.Before(w.resetAt) {
+			delete(l.windows, key)
+		}
+	}
+
+	l.nextCleanup = now.Add(time.Minute)
+}
+
+func newUsage(w *window, max int) Usage {
+	usage := Usage{
+		Limit:     max,
+		Used:      w.used,
+		Remaining: -1,
+		ResetAt:   w.resetAt,
+	}
+
+	if max > 0 {
+		usage.Remaining = max - w.used
+		if usage.Remaining < 0 {
+			usage.Remaining = 0
+		}
+	}
+
+	return usage
+}
@@ -0,0 +1,89 @@
+package ratelimit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/ratelimit"
+)
+
+func TestLimiterAllow(t *testing.T) {
+	l := ratelimit.New()
+
+	scenarios := []struct {
+		key               string
+		max               int
+		expectedAllowed   bool
+		expectedUsed      int
+		expectedRemaining int
+	}{
+		{"a", 2, true, 1, 1},
+		{"a", 2, true, 2, 0},
+		{"a", 2, false, 2, 0},
+		{"b", 2, true, 1, 1},
+		{"c", 0, true, 1, -1},
+		{"c", 0, true, 2, -1},
+	}
+
+	for i, s := range scenarios {
+		allowed, usage := l.Allow(s.key, s.max, time.Minute)
+
+		if allowed != s.expectedAllowed {
+			t.Errorf("(%d) Expected allowed %v, got %v", i, s.expectedAllowed, allowed)
+		}
+
+		if usage.Used != s.expectedUsed {
+			t.Errorf("(%d) Expected used %d, got %d", i, s.expectedUsed, usage.Used)
+		}
+
+		if usage.Remaining != s.expectedRemaining {
+			t.Errorf("(%d) Expected remaining %d, got %d", i, s.expectedRemaining, usage.Remaining)
+		}
+
+		if usage.ResetAt.IsZero() {
+			t.Errorf("(%d) Expected non-zero resetAt", i)
+		}
+	}
+}
+
+func TestLimiterWindowExpiration(t *testing.T) {
+	l := ratelimit.New()
+
+	if allowed, _ := l.Allow("test", 1, 10*time.Millisecond); !allowed {
+		t.Fatal("Expected the first request to be allowed")
+	}
+
+	if allowed, _ := l.Allow("test", 1, 10*time.Millisecond); allowed {
+		t.Fatal("Expected the second request to be rejected")
+	}
+
+	time.Sleep(15 * time.Millisecond)
+
+	if allowed, usage := l.Allow("test", 1, 10*time.Millisecond); !allowed || usage.Used != 1 {
+		t.Fatalf("Expected the request in the new window to be allowed, got %v (%v)", allowed, usage)
+	}
+}
+
+func TestLimiterUsageAndReset(t *testing.T) {
+	l := ratelimit.New()
+
+	usage := l.Usage("test", 5)
+	if usage.Used != 0 || usage.Remaining != 5 || !usage.ResetAt.IsZero() {
+		t.Fatalf("Expected empty usage, got %v", usage)
+	}
+
+	l.Allow("test", 5, time.Minute)
+	l.Allow("test", 5, time.Minute)
+
+	usage = l.Usage("test", 5)
+	if usage.Used != 2 || usage.Remaining != 3 {
+		t.Fatalf("Expected 2 used and 3 remaining requests, got %v", usage)
+	}
+
+	l.Reset("test")
+
+	usage = l.Usage("test", 5)
+	if usage.Used != 0 {
+		t.Fatalf("Expected reset usage, got %v", usage)
+	}
+}
@@ -26,13 +26,23 @@ func (s *Serializer) Serialize(c echo.Context, i any, indent string) error {
 	}
 
 	param := c.QueryParam(fieldsParam)
-	if param == "" {
-		return s.DefaultJSONSerializer.Serialize(c, i, indent)
+
+	var fields []string
+	if param != "" {
+		fields = strings.Split(param, ",")
+		for i, f := range fields {
+			fields[i] = strings.TrimSpace(f)
+		}
+	}
+
+	if indent == "" {
+		if handled, err := streamSearchResult(c, i, fields); handled {
+			return err
+		}
 	}
 
-	fields := strings.Split(param, ",")
-	for i, f := range fields {
-		fields[i] = strings.TrimSpace(f)
+	if len(fields) == 0 {
+		return s.DefaultJSONSerializer.Serialize(c, i, indent)
 	}
 
 	encoded, err := json.Marshal(i)
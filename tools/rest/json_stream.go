@@ -0,0 +1,137 @@
+package rest
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/tools/jsonenc"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+// streamFlushSize is the size of the buffered search result json
+// after which it is flushed to the response.
+const streamFlushSize = 32 << 10
+
+// streamMaxPooledSize is the max capacity of the stream buffers kept for reuse.
+const streamMaxPooledSize = 1 << 20
+
+var streamBufferPool = sync.Pool{
+	New: func() any {
+		buf := make([]byte, 0, streamFlushSize+4<<10)
+		return &buf
+	},
+}
+
+var objectAppenderType = reflect.TypeOf((*jsonenc.ObjectAppender)(nil)).Elem()
+
+// streamSearchResult writes the provided search result directly to the
+// response if its items are a slice of [jsonenc.ObjectAppender]
+// (eg. []*models.Record), without building the intermediate item maps.
+//
+// The items are encoded in a pooled buffer that is flushed to the response
+// on every streamFlushSize bytes, so the full response is never kept in memory.
+//
+// It returns false if the value can't be streamed (eg. non search result,
+// other item types or nested fields picking) and it is left unhandled.
+func streamSearchResult(c echo.Context, i any, fields []string) (bool, error) {
+	var result *search.Result
+
+	switch v := i.(type) {
+	case search.Result:
+		result = &v
+	case *search.Result:
+		result = v
+	}
+
+	if result == nil {
+		return false, nil
+	}
+
+	items := reflect.ValueOf(result.Items)
+	if items.Kind() != reflect.Slice || !items.Type().Elem().Implements(objectAppenderType) {
+		return false, nil
+	}
+
+	for _, f := range fields {
+		if strings.Contains(f, ".") {
+			return false, nil // nested fields are picked only by the generic serializer
+		}
+	}
+
+	bufPtr := streamBufferPool.Get().(*[]byte)
+	buf := (*bufPtr)[:0]
+	defer func() {
+		// don't keep in the pool the buffers grown by too large items
+		if cap(buf) <= streamMaxPooledSize {
+			*bufPtr = buf[:0]
+			streamBufferPool.Put(bufPtr)
+		}
+	}()
+
+	// note: the generic fields picker serializes the envelope keys sorted
+	if len(fields) == 0 {
+		buf = append(buf, '{')
+		buf = appendSearchResultMeta(buf, result)
+		buf = append(buf, `,"items":`...)
+	} else {
+		buf = append(buf, `{"items":`...)
+	}
+
+	if items.IsNil() {
+		buf = append(buf, "null"...)
+	} else {
+		buf = append(buf, '[')
+
+		var err error
+		for j := 0; j < items.Len(); j++ {
+			if j > 0 {
+				buf = append(buf, ',')
+			}
+
+			item, _ := items.Index(j).Interface().(jsonenc.ObjectAppender)
+			if item == nil {
+				buf = append(buf, "null"...)
+			} else if buf, err = item.AppendJSONObject(buf, fields); err != nil {
+				return true, err
+			}
+
+			if len(buf) >= streamFlushSize {
+				if _, err := c.Response().Write(buf); err != nil {
+					return true, err
+				}
+				buf = buf[:0]
+			}
+		}
+
+		buf = append(buf, ']')
+	}
+
+	if len(fields) > 0 {
+		buf = append(buf, ',')
+		buf = appendSearchResultMeta(buf, result)
+	}
+
+	// trailing new line for consistency with the default json.Encoder output
+	buf = append(buf, '}', '\n')
+
+	_, err := c.Response().Write(buf)
+
+	return true, err
+}
+
+// appendSearchResultMeta appends to dst the search result pagination
+// fields as json object members (aka. without the surrounding brackets).
+func appendSearchResultMeta(dst []byte, result *search.Result) []byte {
+	dst = append(dst, `"page":`...)
+	dst = strconv.AppendInt(dst, int64(result.Page), 10)
+	dst = append(dst, `,"perPage":`...)
+	dst = strconv.AppendInt(dst, int64(result.PerPage), 10)
+	dst = append(dst, `,"totalItems":`...)
+	dst = strconv.AppendInt(dst, int64(result.TotalItems), 10)
+	dst = append(dst, `,"totalPages":`...)
+	dst = strconv.AppendInt(dst, int64(result.TotalPages), 10)
+	return dst
+}
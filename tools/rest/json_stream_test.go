@@ -0,0 +1,199 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/rest"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+func newTestListRecords(total int) []*models.Record {
+	collection := &models.Collection{
+		Name: "demo",
+		Type: models.CollectionTypeBase,
+		Schema: schema.NewSchema(
+			&schema.SchemaField{Name: "title", Type: schema.FieldTypeText},
+			&schema.SchemaField{Name: "description", Type: schema.FieldTypeEditor},
+			&schema.SchemaField{Name: "total", Type: schema.FieldTypeNumber},
+			&schema.SchemaField{Name: "active", Type: schema.FieldTypeBool},
+			&schema.SchemaField{Name: "published", Type: schema.FieldTypeDate},
+			&schema.SchemaField{
+				Name:    "tags",
+				Type:    schema.FieldTypeSelect,
+				Options: &schema.SelectOptions{MaxSelect: 3, Values: []string{"a", "b", "c"}},
+			},
+		),
+	}
+	collection.Id = "demo_id"
+
+	records := make([]*models.Record, total)
+
+	for i := range records {
+		r := models.NewRecord(collection)
+		r.Id = fmt.Sprintf("record%010d", i)
+		r.RefreshCreated()
+		r.RefreshUpdated()
+		r.Set("title", fmt.Sprintf("Title <%d> & \"quoted\"", i))
+		r.Set("description", "<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit.</p>")
+		r.Set("total", float64(i)*1.5)
+		r.Set("active", i%2 == 0)
+		r.Set("published", "2023-01-01 10:00:00.123Z")
+		r.Set("tags", []string{"a", "c"})
+		records[i] = r
+	}
+
+	return records
+}
+
+func serializeTestData(t testing.TB, data any, query string) string {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.URL.RawQuery = query
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	serializer := rest.Serializer{}
+	if err := serializer.Serialize(c, data, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	return rec.Body.String()
+}
+
+func TestSerializeStreamSearchResult(t *testing.T) {
+	// large enough to be flushed multiple times
+	records := newTestListRecords(1000)
+
+	// the []any items are not streamed and are used for comparison
+	anyItems := make([]any, len(records))
+	for i, r := range records {
+		anyItems[i] = r
+	}
+
+	newResult := func(items any) search.Result {
+		return search.Result{
+			Page:       2,
+			PerPage:    1000,
+			TotalItems: 5000,
+			TotalPages: 5,
+			Items:      items,
+		}
+	}
+
+	scenarios := []struct {
+		name  string
+		query string
+	}{
+		{"without fields", ""},
+		{"with fields", "fields=id,title,missing"},
+		{"with nested fields", "fields=id,title.a,expand.rel.id"},
+	}
+
+	for _, s := range scenarios {
+		expected := serializeTestData(t, newResult(anyItems), s.query)
+
+		streamed := serializeTestData(t, newResult(records), s.query)
+		if streamed != expected {
+			t.Errorf("[%s] Expected the streamed result to match the default one", s.name)
+		}
+
+		streamedPtr := serializeTestData(t, &[]search.Result{newResult(records)}[0], s.query)
+		if streamedPtr != expected {
+			t.Errorf("[%s] Expected the streamed *search.Result to match the default one", s.name)
+		}
+	}
+}
+
+func TestSerializeStreamSearchResultNilItems(t *testing.T) {
+	scenarios := []struct {
+		items    []*models.Record
+		expected string
+	}{
+		{nil, `{"page":1,"perPage":0,"totalItems":0,"totalPages":0,"items":null}` + "\n"},
+		{[]*models.Record{}, `{"page":1,"perPage":0,"totalItems":0,"totalPages":0,"items":[]}` + "\n"},
+		{[]*models.Record{nil}, `{"page":1,"perPage":0,"totalItems":0,"totalPages":0,"items":[null]}` + "\n"},
+	}
+
+	for i, s := range scenarios {
+		result := serializeTestData(t, search.Result{Page: 1, Items: s.items}, "")
+
+		if result != s.expected {
+			t.Errorf("(%d) Expected %s, got %s", i, s.expected, result)
+		}
+
+		// ensure that it is the same as the default serialization
+		expected, _ := json.Marshal(search.Result{Page: 1, Items: s.items})
+		if result != string(expected)+"\n" {
+			t.Errorf("(%d) Expected %s, got %s", i, expected, result)
+		}
+	}
+}
+
+// discardResponseWriter is a no-op [http.ResponseWriter]
+// used to benchmark only the response serialization.
+type discardResponseWriter struct {
+	header http.Header
+}
+
+func (w *discardResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *discardResponseWriter) Write(b []byte) (int, error) {
+	return len(b), nil
+}
+
+func (w *discardResponseWriter) WriteHeader(statusCode int) {}
+
+func benchmarkSerialize(b *testing.B, items any, query string) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.URL.RawQuery = query
+
+	serializer := rest.Serializer{}
+	result := search.Result{Page: 1, PerPage: 10000, TotalItems: 10000, TotalPages: 1, Items: items}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		c := e.NewContext(req, &discardResponseWriter{header: http.Header{}})
+
+		if err := serializer.Serialize(c, result, ""); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSerializeRecordsList(b *testing.B) {
+	records := newTestListRecords(10000)
+
+	// []any items fallback to the default json encoding
+	anyItems := make([]any, len(records))
+	for i, r := range records {
+		anyItems[i] = r
+	}
+
+	b.Run("10k default", func(b *testing.B) {
+		benchmarkSerialize(b, anyItems, "")
+	})
+
+	b.Run("10k stream", func(b *testing.B) {
+		benchmarkSerialize(b, records, "")
+	})
+
+	b.Run("10k default with fields", func(b *testing.B) {
+		benchmarkSerialize(b, anyItems, "fields=id,title,total")
+	})
+
+	b.Run("10k stream with fields", func(b *testing.B) {
+		benchmarkSerialize(b, records, "fields=id,title,total")
+	})
+}
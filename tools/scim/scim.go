@@ -0,0 +1,337 @@
+// Package scim implements the subset of the SCIM 2.0 protocol
+// (RFC 7643 and RFC 7644) types and expressions needed to provision
+// users and groups from identity providers like Okta and Azure AD.
+//
+// Only the "eq" filter operator (optionally joined with "and") and the
+// simple `attr[subAttr eq "value"]` PATCH value paths are supported,
+// which is what the common identity providers send.
+package scim
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ContentType is the SCIM protocol media type.
+const ContentType = "application/scim+json"
+
+// List of the supported SCIM schema URNs.
+const (
+	SchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SchemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SchemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SchemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SchemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+	SchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+)
+
+// List of the SCIM error types (RFC 7644 section 3.12).
+const (
+	ErrorTypeInvalidFilter = "invalidFilter"
+	ErrorTypeInvalidPath   = "invalidPath"
+	ErrorTypeInvalidValue  = "invalidValue"
+	ErrorTypeInvalidSyntax = "invalidSyntax"
+	ErrorTypeUniqueness    = "uniqueness"
+	ErrorTypeNoTarget      = "noTarget"
+)
+
+// List of the PATCH operations.
+const (
+	OpAdd     = "add"
+	OpRemove  = "remove"
+	OpReplace = "replace"
+)
+
+// MaxCount is the max number of resources returned by a single list request.
+const MaxCount = 500
+
+// Error is the SCIM error response body.
+type Error struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+// NewError creates a new SCIM error response body.
+func NewError(status int, scimType string, detail string) *Error {
+	return &Error{
+		Schemas:  []string{SchemaError},
+		Status:   strconv.Itoa(status),
+		ScimType: scimType,
+		Detail:   detail,
+	}
+}
+
+// Meta holds the common resource metadata.
+type Meta struct {
+	ResourceType string    `json:"resourceType"`
+	Created      time.Time `json:"created"`
+	LastModified time.Time `json:"lastModified"`
+	Location     string    `json:"location,omitempty"`
+}
+
+// MultiValue is a single item of a multi-valued attribute (eg. emails, members).
+type MultiValue struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+// User is the SCIM User resource.
+type User struct {
+	Schemas  []string     `json:"schemas"`
+	Id       string       `json:"id,omitempty"`
+	UserName string       `json:"userName"`
+	Emails   []MultiValue `json:"emails,omitempty"`
+	Active   *bool        `json:"active,omitempty"`
+	Groups   []MultiValue `json:"groups,omitempty"`
+	Meta     *Meta        `json:"meta,omitempty"`
+
+	// Password is write only and it is never returned.
+	Password string `json:"password,omitempty"`
+}
+
+// PrimaryEmail returns the primary (or the first) user email value.
+func (u *User) PrimaryEmail() string {
+	for _, email := range u.Emails {
+		if email.Primary {
+			return email.Value
+		}
+	}
+
+	if len(u.Emails) > 0 {
+		return u.Emails[0].Value
+	}
+
+	return ""
+}
+
+// Group is the SCIM Group resource.
+type Group struct {
+	Schemas     []string     `json:"schemas"`
+	Id          string       `json:"id,omitempty"`
+	DisplayName string       `json:"displayName"`
+	Members     []MultiValue `json:"members,omitempty"`
+	Meta        *Meta        `json:"meta,omitempty"`
+}
+
+// ListResponse is the SCIM resources list response body.
+type ListResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    any      `json:"Resources"`
+}
+
+// NewListResponse creates a new list response from the page resources.
+func NewListResponse(resources any, itemsPerPage int, startIndex int, totalResults int) *ListResponse {
+	return &ListResponse{
+		Schemas:      []string{SchemaListResponse},
+		TotalResults: totalResults,
+		StartIndex:   startIndex,
+		ItemsPerPage: itemsPerPage,
+		Resources:    resources,
+	}
+}
+
+// Page holds the list request pagination query parameters
+// (startIndex is 1-based).
+type Page struct {
+	StartIndex int `query:"startIndex"`
+	Count      int `query:"count"`
+}
+
+// Normalize applies the default page values and limits
+// (a missing or non-positive count defaults to [MaxCount]).
+func (p *Page) Normalize() {
+	if p.StartIndex < 1 {
+		p.StartIndex = 1
+	}
+
+	if p.Count <= 0 || p.Count > MaxCount {
+		p.Count = MaxCount
+	}
+}
+
+// Offset returns the number of items to skip for the current page.
+func (p *Page) Offset() int {
+	return p.StartIndex - 1
+}
+
+// PatchRequest is the SCIM PATCH request body.
+type PatchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []PatchOperation `json:"Operations"`
+}
+
+// PatchOperation is a single SCIM PATCH operation.
+type PatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// NormalizedOp returns the lowercased operation name
+// (Azure AD sends them capitalized, eg. "Replace").
+func (op *PatchOperation) NormalizedOp() string {
+	return strings.ToLower(op.Op)
+}
+
+// Condition is a single `attr eq "value"` expression.
+type Condition struct {
+	Attr  string
+	Value string
+}
+
+// ParseFilter parses a list filter expression into its conditions,
+// eg. `userName eq "john" and active eq true`.
+//
+// The attribute names are returned as they are (SCIM attribute names
+// are case insensitive, so they should be compared with [strings.EqualFold]).
+func ParseFilter(filter string) ([]Condition, error) {
+	filter = strings.TrimSpace(filter)
+	if filter == "" {
+		return nil, nil
+	}
+
+	result := []Condition{}
+
+	for filter != "" {
+		condition, rest, err := parseCondition(filter)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, condition)
+
+		rest = strings.TrimSpace(rest)
+		if rest == "" {
+			break
+		}
+
+		if len(rest) < 4 || !strings.EqualFold(rest[:4], "and ") {
+			return nil, fmt.Errorf("unsupported filter expression %q", rest)
+		}
+
+		filter = strings.TrimSpace(rest[4:])
+	}
+
+	return result, nil
+}
+
+// parseCondition parses the leading `attr eq value` expression
+// and returns the remaining unparsed string.
+func parseCondition(expr string) (Condition, string, error) {
+	parts := strings.SplitN(strings.TrimSpace(expr), " ", 3)
+	if len(parts) != 3 || parts[0] == "" {
+		return Condition{}, "", fmt.Errorf("invalid filter expression %q", expr)
+	}
+
+	if !strings.EqualFold(parts[1], "eq") {
+		return Condition{}, "", fmt.Errorf("unsupported filter operator %q", parts[1])
+	}
+
+	value := strings.TrimSpace(parts[2])
+
+	// quoted string
+	if strings.HasPrefix(value, `"`) {
+		end := 1
+		for end < len(value) {
+			if value[end] == '\\' {
+				end += 2
+				continue
+			}
+			if value[end] == '"' {
+				break
+			}
+			end++
+		}
+
+		if end >= len(value) {
+			return Condition{}, "", fmt.Errorf("unterminated filter value %q", value)
+		}
+
+		var str string
+		if err := json.Unmarshal([]byte(value[:end+1]), &str); err != nil {
+			return Condition{}, "", fmt.Errorf("invalid filter value %q", value[:end+1])
+		}
+
+		return Condition{Attr: parts[0], Value: str}, value[end+1:], nil
+	}
+
+	// bool, number or null literal
+	literal, rest, _ := strings.Cut(value, " ")
+	if literal == "" {
+		return Condition{}, "", fmt.Errorf("missing filter value in %q", expr)
+	}
+
+	return Condition{Attr: parts[0], Value: literal}, rest, nil
+}
+
+// ValuePath is a parsed PATCH operation path.
+type ValuePath struct {
+	// Attr is the target attribute name (eg. "members").
+	Attr string
+
+	// Filter is the optional multi-valued attribute item
+	// filter (eg. `members[value eq "123"]`).
+	Filter *Condition
+
+	// SubAttr is the optional filtered item sub-attribute
+	// (eg. "value" for `emails[type eq "work"].value`).
+	SubAttr string
+}
+
+// ParseValuePath parses a PATCH operation path, eg. `active`,
+// `members`, `members[value eq "123"]` or `emails[type eq "work"].value`.
+//
+// The schema URN prefix (if any) is removed from the attribute name.
+func ParseValuePath(path string) (*ValuePath, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return nil, errors.New("missing path")
+	}
+
+	// remove the schema URN prefix (eg. "urn:...:User:active")
+	if strings.HasPrefix(strings.ToLower(path), "urn:") {
+		if i := strings.LastIndex(path, ":"); i >= 0 {
+			path = path[i+1:]
+		}
+	}
+
+	start := strings.Index(path, "[")
+	if start == -1 {
+		return &ValuePath{Attr: path}, nil
+	}
+
+	end := strings.LastIndex(path, "]")
+	if end < start || start == 0 {
+		return nil, fmt.Errorf("invalid path %q", path)
+	}
+
+	subAttr := path[end+1:]
+	if subAttr != "" {
+		if !strings.HasPrefix(subAttr, ".") || len(subAttr) == 1 {
+			return nil, fmt.Errorf("invalid path %q", path)
+		}
+		subAttr = subAttr[1:]
+	}
+
+	condition, rest, err := parseCondition(path[start+1 : end])
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(rest) != "" {
+		return nil, fmt.Errorf("unsupported path filter %q", path)
+	}
+
+	return &ValuePath{Attr: path[:start], Filter: &condition, SubAttr: subAttr}, nil
+}
@@ -0,0 +1,150 @@
+package scim_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/scim"
+)
+
+func TestParseFilter(t *testing.T) {
+	scenarios := []struct {
+		filter      string
+		expectError bool
+		expected    []scim.Condition
+	}{
+		{"", false, nil},
+		{"userName", true, nil},
+		{"userName eq", true, nil},
+		{`userName co "john"`, true, nil},
+		{`userName eq "john`, true, nil},
+		{`userName eq "john" or active eq true`, true, nil},
+		{`userName eq "john"`, false, []scim.Condition{{"userName", "john"}}},
+		{`userName EQ "jo\"hn doe"`, false, []scim.Condition{{"userName", `jo"hn doe`}}},
+		{`displayName eq "a" and active eq true`, false, []scim.Condition{{"displayName", "a"}, {"active", "true"}}},
+		{`active eq false AND userName eq "b"`, false, []scim.Condition{{"active", "false"}, {"userName", "b"}}},
+	}
+
+	for i, s := range scenarios {
+		result, err := scim.ParseFilter(s.filter)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("(%d) Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+			continue
+		}
+
+		if len(result) != len(s.expected) {
+			t.Errorf("(%d) Expected conditions %v, got %v", i, s.expected, result)
+			continue
+		}
+
+		for j, c := range result {
+			if c != s.expected[j] {
+				t.Errorf("(%d) Expected condition %v, got %v", i, s.expected[j], c)
+			}
+		}
+	}
+}
+
+func TestParseValuePath(t *testing.T) {
+	scenarios := []struct {
+		path           string
+		expectError    bool
+		expectedAttr   string
+		expectedFilter *scim.Condition
+		expectedSub    string
+	}{
+		{"", true, "", nil, ""},
+		{"[value eq \"1\"]", true, "", nil, ""},
+		{"members[value eq \"1\"", true, "", nil, ""},
+		{"members[value gt \"1\"]", true, "", nil, ""},
+		{`emails[type eq "work"]value`, true, "", nil, ""},
+		{`emails[type eq "work"].`, true, "", nil, ""},
+		{"active", false, "active", nil, ""},
+		{"urn:ietf:params:scim:schemas:core:2.0:User:active", false, "active", nil, ""},
+		{`members[value eq "123"]`, false, "members", &scim.Condition{"value", "123"}, ""},
+		{`emails[type eq "work"].value`, false, "emails", &scim.Condition{"type", "work"}, "value"},
+	}
+
+	for i, s := range scenarios {
+		result, err := scim.ParseValuePath(s.path)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("(%d) Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+			continue
+		}
+
+		if hasErr {
+			continue
+		}
+
+		if result.Attr != s.expectedAttr {
+			t.Errorf("(%d) Expected attr %q, got %q", i, s.expectedAttr, result.Attr)
+		}
+
+		if result.SubAttr != s.expectedSub {
+			t.Errorf("(%d) Expected sub attr %q, got %q", i, s.expectedSub, result.SubAttr)
+		}
+
+		if (result.Filter == nil) != (s.expectedFilter == nil) ||
+			(result.Filter != nil && *result.Filter != *s.expectedFilter) {
+			t.Errorf("(%d) Expected filter %v, got %v", i, s.expectedFilter, result.Filter)
+		}
+	}
+}
+
+func TestPageNormalize(t *testing.T) {
+	scenarios := []struct {
+		page           scim.Page
+		expectedStart  int
+		expectedCount  int
+		expectedOffset int
+	}{
+		{scim.Page{}, 1, scim.MaxCount, 0},
+		{scim.Page{StartIndex: -1, Count: -1}, 1, scim.MaxCount, 0},
+		{scim.Page{StartIndex: 11, Count: 10}, 11, 10, 10},
+		{scim.Page{StartIndex: 1, Count: 1000}, 1, scim.MaxCount, 0},
+	}
+
+	for i, s := range scenarios {
+		page := s.page
+		page.Normalize()
+
+		if page.StartIndex != s.expectedStart || page.Count != s.expectedCount || page.Offset() != s.expectedOffset {
+			t.Errorf("(%d) Expected %d/%d/%d, got %d/%d/%d", i, s.expectedStart, s.expectedCount, s.expectedOffset, page.StartIndex, page.Count, page.Offset())
+		}
+	}
+}
+
+func TestUserPrimaryEmail(t *testing.T) {
+	scenarios := []struct {
+		emails   []scim.MultiValue
+		expected string
+	}{
+		{nil, ""},
+		{[]scim.MultiValue{{Value: "a@example.com"}, {Value: "b@example.com"}}, "a@example.com"},
+		{[]scim.MultiValue{{Value: "a@example.com"}, {Value: "b@example.com", Primary: true}}, "b@example.com"},
+	}
+
+	for i, s := range scenarios {
+		user := scim.User{Emails: s.emails}
+
+		if email := user.PrimaryEmail(); email != s.expected {
+			t.Errorf("(%d) Expected %q, got %q", i, s.expected, email)
+		}
+	}
+}
+
+func TestNewError(t *testing.T) {
+	raw, err := json.Marshal(scim.NewError(409, scim.ErrorTypeUniqueness, "duplicated"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"schemas":["urn:ietf:params:scim:api:messages:2.0:Error"],"status":"409","scimType":"uniqueness","detail":"duplicated"}`
+	if string(raw) != expected {
+		t.Fatalf("Expected %s, got %s", expected, raw)
+	}
+}
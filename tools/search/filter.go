@@ -84,6 +84,77 @@ func (f FilterData) build(data []fexpr.ExprGroup, fieldResolver FieldResolver) (
 	return result, nil
 }
 
+// Conditions parses the current filter data and returns its individual
+// comparison expressions (flattened from all nested groups) as standalone filters.
+//
+// Example:
+//
+//	FilterData("a = 1 && (b = 'x' || c > 2)").Conditions() // ["a = 1", "b = 'x'", "c > 2"]
+func (f FilterData) Conditions() ([]FilterData, error) {
+	data, err := fexpr.Parse(string(f))
+	if err != nil {
+		return nil, err
+	}
+
+	result := []FilterData{}
+
+	walkFilterExprs(data, func(expr fexpr.Expr) {
+		result = append(result, FilterData(
+			tokenToFilterString(expr.Left)+" "+string(expr.Op)+" "+tokenToFilterString(expr.Right),
+		))
+	})
+
+	return result, nil
+}
+
+// Identifiers parses the current filter data and returns the
+// unique identifier operands (fields, variables, etc.) in order of appearance.
+func (f FilterData) Identifiers() ([]string, error) {
+	data, err := fexpr.Parse(string(f))
+	if err != nil {
+		return nil, err
+	}
+
+	result := []string{}
+	existing := map[string]struct{}{}
+
+	walkFilterExprs(data, func(expr fexpr.Expr) {
+		for _, token := range []fexpr.Token{expr.Left, expr.Right} {
+			if token.Type != fexpr.TokenIdentifier {
+				continue
+			}
+			if _, ok := existing[token.Literal]; ok {
+				continue
+			}
+			existing[token.Literal] = struct{}{}
+			result = append(result, token.Literal)
+		}
+	})
+
+	return result, nil
+}
+
+func walkFilterExprs(data []fexpr.ExprGroup, fn func(expr fexpr.Expr)) {
+	for _, group := range data {
+		switch item := group.Item.(type) {
+		case fexpr.Expr:
+			fn(item)
+		case fexpr.ExprGroup:
+			walkFilterExprs([]fexpr.ExprGroup{item}, fn)
+		case []fexpr.ExprGroup:
+			walkFilterExprs(item, fn)
+		}
+	}
+}
+
+func tokenToFilterString(token fexpr.Token) string {
+	if token.Type == fexpr.TokenText {
+		return "'" + strings.ReplaceAll(token.Literal, "'", `\'`) + "'"
+	}
+
+	return token.Literal
+}
+
 func (f FilterData) resolveTokenizedExpr(expr fexpr.Expr, fieldResolver FieldResolver) (dbx.Expression, error) {
 	lResult, lErr := resolveToken(expr.Left, fieldResolver)
 	if lErr != nil || lResult.Identifier == "" {
@@ -1,6 +1,8 @@
 package search_test
 
 import (
+	"bytes"
+	"encoding/json"
 	"regexp"
 	"testing"
 
@@ -190,3 +192,98 @@ func TestFilterDataBuildExpr(t *testing.T) {
 		}
 	}
 }
+
+func TestFilterDataConditions(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		filterData  search.FilterData
+		expectError bool
+		expected    string
+	}{
+		{"empty", "", true, ""},
+		{"invalid format", "(test1 > 1", true, ""},
+		{"single", "test1>1", false, `["test1 > 1"]`},
+		{
+			"nested groups",
+			`test1 = "a'b" && (test2 ?~ 'c' || (test3 != null && @request.auth.id = test4))`,
+			false,
+			`["test1 = 'a\\'b'","test2 ?~ 'c'","test3 != null","@request.auth.id = test4"]`,
+		},
+	}
+
+	for _, s := range scenarios {
+		result, err := s.filterData.Conditions()
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("[%s] Expected hasErr %v, got %v (%v)", s.name, s.expectError, hasErr, err)
+			continue
+		}
+
+		if hasErr {
+			continue
+		}
+
+		encoded, _ := marshalNoEscape(result)
+		if string(encoded) != s.expected {
+			t.Errorf("[%s] Expected \n%s, \ngot \n%s", s.name, s.expected, encoded)
+		}
+
+		// each condition must be a valid standalone filter
+		for _, condition := range result {
+			if _, err := condition.BuildExpr(search.NewSimpleFieldResolver("test1", "test2", "test3", "test4", "@request.auth.id")); err != nil {
+				t.Errorf("[%s] Failed to build condition %q: %v", s.name, condition, err)
+			}
+		}
+	}
+}
+
+func TestFilterDataIdentifiers(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		filterData  search.FilterData
+		expectError bool
+		expected    string
+	}{
+		{"empty", "", true, ""},
+		{"invalid format", "(test1 > 1", true, ""},
+		{
+			"unique identifiers",
+			`test1 = 'test2' && (test2 > 1 || test1 = @request.auth.id) && @request.auth.id != ""`,
+			false,
+			`["test1","test2","@request.auth.id"]`,
+		},
+	}
+
+	for _, s := range scenarios {
+		result, err := s.filterData.Identifiers()
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("[%s] Expected hasErr %v, got %v (%v)", s.name, s.expectError, hasErr, err)
+			continue
+		}
+
+		if hasErr {
+			continue
+		}
+
+		encoded, _ := marshalNoEscape(result)
+		if string(encoded) != s.expected {
+			t.Errorf("[%s] Expected \n%s, \ngot \n%s", s.name, s.expected, encoded)
+		}
+	}
+}
+
+func marshalNoEscape(v any) ([]byte, error) {
+	var buf bytes.Buffer
+
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSpace(buf.Bytes()), nil
+}
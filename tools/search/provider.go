@@ -21,6 +21,10 @@ const (
 	PerPageQueryParam string = "perPage"
 	SortQueryParam    string = "sort"
 	FilterQueryParam  string = "filter"
+
+	// SkipTotalQueryParam disables the total items counting
+	// (the result TotalItems and TotalPages are set to -1).
+	SkipTotalQueryParam string = "skipTotal"
 )
 
 // Result defines the returned search result structure.
@@ -32,10 +36,15 @@ type Result struct {
 	Items      any `json:"items"`
 }
 
+// CountFunc returns the total number of items matching the provided count query.
+type CountFunc func(countQuery *dbx.SelectQuery) (int64, error)
+
 // Provider represents a single configured search provider instance.
 type Provider struct {
 	fieldResolver FieldResolver
 	query         *dbx.SelectQuery
+	countFunc     CountFunc
+	skipTotal     bool
 	page          int
 	perPage       int
 	sort          []SortField
@@ -85,6 +94,23 @@ func (s *Provider) PerPage(perPage int) *Provider {
 	return s
 }
 
+// SkipTotal sets the `skipTotal` field of the current search provider.
+//
+// When enabled the total items count query is not executed and the
+// result TotalItems and TotalPages are set to -1, which could speed up
+// significantly the pagination of large datasets.
+func (s *Provider) SkipTotal(skipTotal bool) *Provider {
+	s.skipTotal = skipTotal
+	return s
+}
+
+// CountFunc sets a custom function to calculate the total items count
+// (eg. to serve cached counts) instead of executing the count query.
+func (s *Provider) CountFunc(fn CountFunc) *Provider {
+	s.countFunc = fn
+	return s
+}
+
 // Sort sets the `sort` field of the current search provider.
 func (s *Provider) Sort(sort []SortField) *Provider {
 	s.sort = sort
@@ -139,6 +165,14 @@ func (s *Provider) Parse(urlQuery string) error {
 		s.PerPage(perPage)
 	}
 
+	if rawSkipTotal := params.Get(SkipTotalQueryParam); rawSkipTotal != "" {
+		skipTotal, err := strconv.ParseBool(rawSkipTotal)
+		if err != nil {
+			return err
+		}
+		s.SkipTotal(skipTotal)
+	}
+
 	if rawSort := params.Get(SortQueryParam); rawSort != "" {
 		for _, sortField := range ParseSortFromString(rawSort) {
 			s.AddSort(sortField)
@@ -189,20 +223,6 @@ func (s *Provider) Exec(items any) (*Result, error) {
 		return nil, err
 	}
 
-	queryInfo := modelsQuery.Info()
-
-	// count
-	var totalCount int64
-	var baseTable string
-	if len(queryInfo.From) > 0 {
-		baseTable = queryInfo.From[0]
-	}
-	clone := modelsQuery
-	countQuery := clone.Select("COUNT(DISTINCT [[" + baseTable + ".id]])").OrderBy()
-	if err := countQuery.Row(&totalCount); err != nil {
-		return nil, err
-	}
-
 	// normalize perPage
 	if s.perPage <= 0 {
 		s.perPage = DefaultPerPage
@@ -210,13 +230,41 @@ func (s *Provider) Exec(items any) (*Result, error) {
 		s.perPage = MaxPerPage
 	}
 
-	totalPages := int(math.Ceil(float64(totalCount) / float64(s.perPage)))
+	totalCount := int64(-1)
+	totalPages := -1
+
+	if s.skipTotal {
+		if s.page <= 0 {
+			s.page = 1
+		}
+	} else {
+		// count
+		queryInfo := modelsQuery.Info()
+		var baseTable string
+		if len(queryInfo.From) > 0 {
+			baseTable = queryInfo.From[0]
+		}
+		clone := modelsQuery
+		countQuery := clone.Select("COUNT(DISTINCT [[" + baseTable + ".id]])").OrderBy()
+
+		var err error
+		if s.countFunc != nil {
+			totalCount, err = s.countFunc(countQuery)
+		} else {
+			err = countQuery.Row(&totalCount)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		totalPages = int(math.Ceil(float64(totalCount) / float64(s.perPage)))
 
-	// normalize page according to the total count
-	if s.page <= 0 || totalCount == 0 {
-		s.page = 1
-	} else if s.page > totalPages {
-		s.page = totalPages
+		// normalize page according to the total count
+		if s.page <= 0 || totalCount <= 0 {
+			s.page = 1
+		} else if s.page > totalPages {
+			s.page = totalPages
+		}
 	}
 
 	// apply pagination
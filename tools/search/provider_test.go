@@ -103,6 +103,7 @@ func TestProviderParse(t *testing.T) {
 		expectPerPage int
 		expectSort    string
 		expectFilter  string
+		expectSkip    bool
 	}{
 		// empty
 		{
@@ -112,6 +113,7 @@ func TestProviderParse(t *testing.T) {
 			initialPerPage,
 			`[{"name":"test1","direction":"ASC"},{"name":"test2","direction":"ASC"}]`,
 			`["test1","test2"]`,
+			false,
 		},
 		// invalid query
 		{
@@ -121,6 +123,7 @@ func TestProviderParse(t *testing.T) {
 			initialPerPage,
 			`[{"name":"test1","direction":"ASC"},{"name":"test2","direction":"ASC"}]`,
 			`["test1","test2"]`,
+			false,
 		},
 		// invalid page
 		{
@@ -130,6 +133,7 @@ func TestProviderParse(t *testing.T) {
 			initialPerPage,
 			`[{"name":"test1","direction":"ASC"},{"name":"test2","direction":"ASC"}]`,
 			`["test1","test2"]`,
+			false,
 		},
 		// invalid perPage
 		{
@@ -139,15 +143,27 @@ func TestProviderParse(t *testing.T) {
 			initialPerPage,
 			`[{"name":"test1","direction":"ASC"},{"name":"test2","direction":"ASC"}]`,
 			`["test1","test2"]`,
+			false,
+		},
+		// invalid skipTotal
+		{
+			"skipTotal=a",
+			true,
+			initialPage,
+			initialPerPage,
+			`[{"name":"test1","direction":"ASC"},{"name":"test2","direction":"ASC"}]`,
+			`["test1","test2"]`,
+			false,
 		},
 		// valid query parameters
 		{
-			"page=3&perPage=456&filter=test3&sort=-a,b,+c&other=123",
+			"page=3&perPage=456&filter=test3&sort=-a,b,+c&skipTotal=1&other=123",
 			false,
 			3,
 			456,
 			`[{"name":"test1","direction":"ASC"},{"name":"test2","direction":"ASC"},{"name":"a","direction":"DESC"},{"name":"b","direction":"ASC"},{"name":"c","direction":"ASC"}]`,
 			`["test1","test2","test3"]`,
+			true,
 		},
 	}
 
@@ -175,6 +191,10 @@ func TestProviderParse(t *testing.T) {
 			t.Errorf("(%d) Expected perPage %v, got %v", i, s.expectPerPage, p.perPage)
 		}
 
+		if p.skipTotal != s.expectSkip {
+			t.Errorf("(%d) Expected skipTotal %v, got %v", i, s.expectSkip, p.skipTotal)
+		}
+
 		encodedSort, _ := json.Marshal(p.sort)
 		if string(encodedSort) != s.expectSort {
 			t.Errorf("(%d) Expected sort %v, got \n%v", i, s.expectSort, string(encodedSort))
@@ -351,6 +371,93 @@ func TestProviderExecNonEmptyQuery(t *testing.T) {
 	}
 }
 
+func TestProviderExecSkipTotalAndCountFunc(t *testing.T) {
+	testDB, err := createTestDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer testDB.Close()
+
+	query := testDB.Select("*").
+		From("test").
+		Where(dbx.Not(dbx.HashExp{"test1": nil})).
+		OrderBy("test1 ASC")
+
+	var countFuncQuery string
+	countFunc := func(countQuery *dbx.SelectQuery) (int64, error) {
+		countFuncQuery = countQuery.Build().SQL()
+		return 10, nil
+	}
+
+	scenarios := []struct {
+		name          string
+		page          int
+		skipTotal     bool
+		countFunc     CountFunc
+		expectResult  string
+		expectQueries []string
+	}{
+		{
+			"skip total",
+			3, // not normalized
+			true,
+			countFunc,
+			`{"page":3,"perPage":1,"totalItems":-1,"totalPages":-1,"items":[]}`,
+			[]string{
+				"SELECT * FROM `test` WHERE NOT (`test1` IS NULL) ORDER BY `test1` ASC LIMIT 1 OFFSET 2",
+			},
+		},
+		{
+			"custom count func",
+			3,
+			false,
+			countFunc,
+			`{"page":3,"perPage":1,"totalItems":10,"totalPages":10,"items":[]}`,
+			[]string{
+				"SELECT * FROM `test` WHERE NOT (`test1` IS NULL) ORDER BY `test1` ASC LIMIT 1 OFFSET 2",
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		testDB.CalledQueries = []string{} // reset
+		countFuncQuery = ""
+
+		p := NewProvider(&testFieldResolver{}).
+			Query(query).
+			Page(s.page).
+			PerPage(1).
+			SkipTotal(s.skipTotal).
+			CountFunc(s.countFunc)
+
+		result, err := p.Exec(&[]testTableStruct{})
+		if err != nil {
+			t.Errorf("[%s] Expected nil error, got %v", s.name, err)
+			continue
+		}
+
+		encoded, _ := json.Marshal(result)
+		if string(encoded) != s.expectResult {
+			t.Errorf("[%s] Expected result %v, got \n%v", s.name, s.expectResult, string(encoded))
+		}
+
+		if s.skipTotal == (countFuncQuery != "") {
+			t.Errorf("[%s] Expected the count func to be called only without skipTotal, got query %q", s.name, countFuncQuery)
+		}
+
+		if len(s.expectQueries) != len(testDB.CalledQueries) {
+			t.Errorf("[%s] Expected %d queries, got %d: \n%v", s.name, len(s.expectQueries), len(testDB.CalledQueries), testDB.CalledQueries)
+			continue
+		}
+
+		for _, q := range testDB.CalledQueries {
+			if !list.ExistInSliceWithRegex(q, s.expectQueries) {
+				t.Fatalf("[%s] Didn't expect query \n%v \nin \n%v", s.name, q, s.expectQueries)
+			}
+		}
+	}
+}
+
 func TestProviderParseAndExec(t *testing.T) {
 	testDB, err := createTestDB()
 	if err != nil {
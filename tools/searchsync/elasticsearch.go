@@ -0,0 +1,105 @@
+package searchsync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+)
+
+var _ Indexer = (*elasticsearch)(nil)
+
+// elasticsearch implements [Indexer] for the Elasticsearch (and OpenSearch) bulk API.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/docs-bulk.html
+type elasticsearch struct {
+	*baseIndexer
+}
+
+func (e *elasticsearch) headers() map[string]string {
+	if e.apiKey == "" {
+		return nil
+	}
+
+	return map[string]string{"Authorization": "ApiKey " + e.apiKey}
+}
+
+// Upsert implements [Indexer.Upsert()].
+func (e *elasticsearch) Upsert(ctx context.Context, index string, docs []map[string]any) error {
+	if len(docs) == 0 {
+		return nil
+	}
+
+	values := make([]any, 0, 2*len(docs))
+	for _, doc := range docs {
+		id, err := docId(doc)
+		if err != nil {
+			return err
+		}
+
+		values = append(
+			values,
+			map[string]any{"index": map[string]any{"_index": index, "_id": id}},
+			doc,
+		)
+	}
+
+	return e.bulk(ctx, values)
+}
+
+// Delete implements [Indexer.Delete()].
+func (e *elasticsearch) Delete(ctx context.Context, index string, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	values := make([]any, len(ids))
+	for i, id := range ids {
+		values[i] = map[string]any{"delete": map[string]any{"_index": index, "_id": id}}
+	}
+
+	return e.bulk(ctx, values)
+}
+
+// Clear implements [Indexer.Clear()].
+func (e *elasticsearch) Clear(ctx context.Context, index string) error {
+	body, err := jsonBody(map[string]any{
+		"query": map[string]any{"match_all": map[string]any{}},
+	})
+	if err != nil {
+		return err
+	}
+
+	path := "/" + url.PathEscape(index) + "/_delete_by_query?refresh=true"
+
+	_, err = e.send(ctx, http.MethodPost, path, "application/json", body, e.headers(), true)
+
+	return err
+}
+
+func (e *elasticsearch) bulk(ctx context.Context, values []any) error {
+	body, err := ndjsonBody(values...)
+	if err != nil {
+		return err
+	}
+
+	raw, err := e.send(ctx, http.MethodPost, "/_bulk", "application/x-ndjson", body, e.headers(), false)
+	if err != nil {
+		return err
+	}
+
+	result := struct {
+		Errors bool `json:"errors"`
+	}{}
+
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return err
+	}
+
+	if result.Errors {
+		return errors.New("elasticsearch bulk request completed with errors")
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package searchsync
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+var _ Indexer = (*meilisearch)(nil)
+
+// meilisearch implements [Indexer] for the Meilisearch documents API.
+//
+// https://www.meilisearch.com/docs/reference/api/documents
+type meilisearch struct {
+	*baseIndexer
+}
+
+func (m *meilisearch) headers() map[string]string {
+	if m.apiKey == "" {
+		return nil
+	}
+
+	return map[string]string{"Authorization": "Bearer " + m.apiKey}
+}
+
+// Upsert implements [Indexer.Upsert()].
+func (m *meilisearch) Upsert(ctx context.Context, index string, docs []map[string]any) error {
+	if len(docs) == 0 {
+		return nil
+	}
+
+	body, err := jsonBody(docs)
+	if err != nil {
+		return err
+	}
+
+	path := "/indexes/" + url.PathEscape(index) + "/documents?primaryKey=id"
+
+	_, err = m.send(ctx, http.MethodPost, path, "application/json", body, m.headers(), false)
+
+	return err
+}
+
+// Delete implements [Indexer.Delete()].
+func (m *meilisearch) Delete(ctx context.Context, index string, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	body, err := jsonBody(ids)
+	if err != nil {
+		return err
+	}
+
+	path := "/indexes/" + url.PathEscape(index) + "/documents/delete-batch"
+
+	_, err = m.send(ctx, http.MethodPost, path, "application/json", body, m.headers(), true)
+
+	return err
+}
+
+// Clear implements [Indexer.Clear()].
+func (m *meilisearch) Clear(ctx context.Context, index string) error {
+	path := "/indexes/" + url.PathEscape(index) + "/documents"
+
+	_, err := m.send(ctx, http.MethodDelete, path, "", nil, m.headers(), true)
+
+	return err
+}
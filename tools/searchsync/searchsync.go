@@ -0,0 +1,179 @@
+// Package searchsync implements simple HTTP clients for pushing
+// documents to external search engines (Meilisearch, Typesense, Elasticsearch).
+package searchsync
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// List with the supported search engine providers.
+const (
+	ProviderMeilisearch   string = "meilisearch"
+	ProviderTypesense     string = "typesense"
+	ProviderElasticsearch string = "elasticsearch"
+)
+
+// Providers returns the list with all supported search engine providers.
+func Providers() []string {
+	return []string{ProviderMeilisearch, ProviderTypesense, ProviderElasticsearch}
+}
+
+// HttpClient is a base HTTP client interface (usually used for test purposes).
+type HttpClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Indexer defines a common interface for the external search engine clients.
+type Indexer interface {
+	// Upsert creates or replaces the provided documents in the specified index.
+	//
+	// Each document is expected to have an "id" key.
+	Upsert(ctx context.Context, index string, docs []map[string]any) error
+
+	// Delete removes the documents with the specified ids from the index.
+	Delete(ctx context.Context, index string, ids []string) error
+
+	// Clear removes all documents from the specified index.
+	Clear(ctx context.Context, index string) error
+}
+
+// New creates a new Indexer for the specified provider.
+//
+// If client is nil, a default [http.Client] with 30s timeout is used.
+func New(provider string, host string, apiKey string, client HttpClient) (Indexer, error) {
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	base := &baseIndexer{
+		host:   strings.TrimRight(host, "/"),
+		apiKey: apiKey,
+		client: client,
+	}
+
+	switch provider {
+	case ProviderMeilisearch:
+		return &meilisearch{base}, nil
+	case ProviderTypesense:
+		return &typesense{base}, nil
+	case ProviderElasticsearch:
+		return &elasticsearch{base}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported search sync provider %q", provider)
+}
+
+// -------------------------------------------------------------------
+
+type baseIndexer struct {
+	host   string
+	apiKey string
+	client HttpClient
+}
+
+// send executes a single HTTP request against the search engine host
+// and returns the raw response body.
+//
+// Responses with 404 status code are considered successful if ignoreNotFound is set.
+func (b *baseIndexer) send(
+	ctx context.Context,
+	method string,
+	path string,
+	contentType string,
+	body io.Reader,
+	headers map[string]string,
+	ignoreNotFound bool,
+) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, b.host+path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	res, err := b.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	rawBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if ignoreNotFound && res.StatusCode == http.StatusNotFound {
+		return rawBody, nil
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, fmt.Errorf(
+			"search sync request %s %s failed with status %d: %s",
+			method,
+			path,
+			res.StatusCode,
+			truncate(rawBody, 500),
+		)
+	}
+
+	return rawBody, nil
+}
+
+func truncate(raw []byte, max int) string {
+	if len(raw) > max {
+		return string(raw[:max]) + "..."
+	}
+
+	return string(raw)
+}
+
+// jsonBody encodes the provided value as json.
+func jsonBody(value any) (io.Reader, error) {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.NewReader(raw), nil
+}
+
+// ndjsonBody encodes the provided values as new line delimited json.
+func ndjsonBody(values ...any) (io.Reader, error) {
+	buf := new(bytes.Buffer)
+
+	enc := json.NewEncoder(buf) // Encode() always appends a new line
+
+	for _, v := range values {
+		if err := enc.Encode(v); err != nil {
+			return nil, err
+		}
+	}
+
+	return buf, nil
+}
+
+var errMissingDocId = errors.New("search sync document is missing an id")
+
+// docId extracts the "id" key of the provided document.
+func docId(doc map[string]any) (string, error) {
+	id, _ := doc["id"].(string)
+	if id == "" {
+		return "", errMissingDocId
+	}
+
+	return id, nil
+}
@@ -0,0 +1,224 @@
+package searchsync_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/searchsync"
+)
+
+type recordedRequest struct {
+	method  string
+	url     string
+	headers http.Header
+	body    string
+}
+
+type testClient struct {
+	requests []*recordedRequest
+	status   int
+	response string
+}
+
+func (c *testClient) Do(req *http.Request) (*http.Response, error) {
+	r := &recordedRequest{
+		method:  req.Method,
+		url:     req.URL.String(),
+		headers: req.Header,
+	}
+
+	if req.Body != nil {
+		raw, _ := io.ReadAll(req.Body)
+		r.body = string(raw)
+	}
+
+	c.requests = append(c.requests, r)
+
+	status := c.status
+	if status == 0 {
+		status = 200
+	}
+
+	return &http.Response{
+		StatusCode: status,
+		Body:       io.NopCloser(bytes.NewReader([]byte(c.response))),
+	}, nil
+}
+
+func TestNewUnsupportedProvider(t *testing.T) {
+	indexer, err := searchsync.New("missing", "http://example.com", "", nil)
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+
+	if indexer != nil {
+		t.Fatalf("Expected nil indexer, got %v", indexer)
+	}
+}
+
+func TestProviders(t *testing.T) {
+	for _, p := range searchsync.Providers() {
+		indexer, err := searchsync.New(p, "http://example.com", "", nil)
+		if err != nil {
+			t.Fatalf("[%s] Unexpected error: %v", p, err)
+		}
+
+		if indexer == nil {
+			t.Fatalf("[%s] Expected non-nil indexer", p)
+		}
+	}
+}
+
+func TestMeilisearch(t *testing.T) {
+	client := &testClient{}
+
+	indexer, _ := searchsync.New(searchsync.ProviderMeilisearch, "http://example.com/", "test_key", client)
+
+	if err := indexer.Upsert(context.Background(), "demo", []map[string]any{{"id": "a", "title": "test"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := indexer.Delete(context.Background(), "demo", []string{"a", "b"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := indexer.Clear(context.Background(), "demo"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []recordedRequest{
+		{method: "POST", url: "http://example.com/indexes/demo/documents?primaryKey=id", body: `[{"id":"a","title":"test"}]`},
+		{method: "POST", url: "http://example.com/indexes/demo/documents/delete-batch", body: `["a","b"]`},
+		{method: "DELETE", url: "http://example.com/indexes/demo/documents"},
+	}
+
+	checkRequests(t, client, expected)
+
+	for _, r := range client.requests {
+		if v := r.headers.Get("Authorization"); v != "Bearer test_key" {
+			t.Fatalf("Expected Bearer auth header, got %q", v)
+		}
+	}
+}
+
+func TestTypesense(t *testing.T) {
+	client := &testClient{response: `{"success":true}`}
+
+	indexer, _ := searchsync.New(searchsync.ProviderTypesense, "http://example.com", "test_key", client)
+
+	if err := indexer.Upsert(context.Background(), "demo", []map[string]any{{"id": "a"}, {"id": "b"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := indexer.Delete(context.Background(), "demo", []string{"a", "b"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := indexer.Clear(context.Background(), "demo"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []recordedRequest{
+		{method: "POST", url: "http://example.com/collections/demo/documents/import?action=upsert", body: "{\"id\":\"a\"}\n{\"id\":\"b\"}\n"},
+		{method: "DELETE", url: "http://example.com/collections/demo/documents?filter_by=id%3A%5Ba%2Cb%5D"},
+		{method: "DELETE", url: "http://example.com/collections/demo"},
+		{method: "POST", url: "http://example.com/collections", body: `{"fields":[{"name":".*","type":"auto"}],"name":"demo"}`},
+	}
+
+	checkRequests(t, client, expected)
+
+	for _, r := range client.requests {
+		if v := r.headers.Get("X-TYPESENSE-API-KEY"); v != "test_key" {
+			t.Fatalf("Expected api key header, got %q", v)
+		}
+	}
+
+	// import with failed line
+	failClient := &testClient{response: "{\"success\":true}\n{\"success\":false,\"error\":\"test\"}"}
+	failIndexer, _ := searchsync.New(searchsync.ProviderTypesense, "http://example.com", "", failClient)
+	if err := failIndexer.Upsert(context.Background(), "demo", []map[string]any{{"id": "a"}, {"id": "b"}}); err == nil {
+		t.Fatal("Expected import error, got nil")
+	}
+}
+
+func TestElasticsearch(t *testing.T) {
+	client := &testClient{response: `{"errors":false}`}
+
+	indexer, _ := searchsync.New(searchsync.ProviderElasticsearch, "http://example.com", "", client)
+
+	if err := indexer.Upsert(context.Background(), "demo", []map[string]any{{"id": "a"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := indexer.Delete(context.Background(), "demo", []string{"a"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := indexer.Clear(context.Background(), "demo"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []recordedRequest{
+		{method: "POST", url: "http://example.com/_bulk", body: "{\"index\":{\"_id\":\"a\",\"_index\":\"demo\"}}\n{\"id\":\"a\"}\n"},
+		{method: "POST", url: "http://example.com/_bulk", body: "{\"delete\":{\"_id\":\"a\",\"_index\":\"demo\"}}\n"},
+		{method: "POST", url: "http://example.com/demo/_delete_by_query?refresh=true", body: `{"query":{"match_all":{}}}`},
+	}
+
+	checkRequests(t, client, expected)
+
+	// missing document id
+	if err := indexer.Upsert(context.Background(), "demo", []map[string]any{{"title": "a"}}); err == nil {
+		t.Fatal("Expected missing id error, got nil")
+	}
+
+	// bulk with errors
+	failClient := &testClient{response: `{"errors":true}`}
+	failIndexer, _ := searchsync.New(searchsync.ProviderElasticsearch, "http://example.com", "", failClient)
+	if err := failIndexer.Delete(context.Background(), "demo", []string{"a"}); err == nil {
+		t.Fatal("Expected bulk error, got nil")
+	}
+}
+
+func TestFailureStatus(t *testing.T) {
+	client := &testClient{status: 500, response: "test_error"}
+
+	indexer, _ := searchsync.New(searchsync.ProviderMeilisearch, "http://example.com", "", client)
+
+	err := indexer.Upsert(context.Background(), "demo", []map[string]any{{"id": "a"}})
+	if err == nil || !strings.Contains(err.Error(), "test_error") {
+		t.Fatalf("Expected status error, got %v", err)
+	}
+
+	// 404 on delete is ignored
+	notFoundClient := &testClient{status: 404}
+	notFoundIndexer, _ := searchsync.New(searchsync.ProviderMeilisearch, "http://example.com", "", notFoundClient)
+	if err := notFoundIndexer.Clear(context.Background(), "demo"); err != nil {
+		t.Fatalf("Expected 404 to be ignored, got %v", err)
+	}
+}
+
+func checkRequests(t *testing.T, client *testClient, expected []recordedRequest) {
+	if len(client.requests) != len(expected) {
+		t.Fatalf("Expected %d requests, got %d", len(expected), len(client.requests))
+	}
+
+	for i, e := range expected {
+		r := client.requests[i]
+
+		if r.method != e.method {
+			t.Errorf("[%d] Expected method %q, got %q", i, e.method, r.method)
+		}
+
+		if r.url != e.url {
+			t.Errorf("[%d] Expected url %q, got %q", i, e.url, r.url)
+		}
+
+		if r.body != e.body {
+			t.Errorf("[%d] Expected body %q, got %q", i, e.body, r.body)
+		}
+	}
+}
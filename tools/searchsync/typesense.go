@@ -0,0 +1,111 @@
+package searchsync
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+var _ Indexer = (*typesense)(nil)
+
+// typesense implements [Indexer] for the Typesense documents API.
+//
+// Typesense requires the collection to exist before importing documents,
+// so [typesense.Clear()] (re)creates it with an auto detected schema.
+//
+// https://typesense.org/docs/latest/api/documents.html
+type typesense struct {
+	*baseIndexer
+}
+
+func (t *typesense) headers() map[string]string {
+	if t.apiKey == "" {
+		return nil
+	}
+
+	return map[string]string{"X-TYPESENSE-API-KEY": t.apiKey}
+}
+
+// Upsert implements [Indexer.Upsert()].
+func (t *typesense) Upsert(ctx context.Context, index string, docs []map[string]any) error {
+	if len(docs) == 0 {
+		return nil
+	}
+
+	values := make([]any, len(docs))
+	for i, doc := range docs {
+		values[i] = doc
+	}
+
+	body, err := ndjsonBody(values...)
+	if err != nil {
+		return err
+	}
+
+	path := "/collections/" + url.PathEscape(index) + "/documents/import?action=upsert"
+
+	raw, err := t.send(ctx, http.MethodPost, path, "text/plain", body, t.headers(), false)
+	if err != nil {
+		return err
+	}
+
+	// the import endpoint responds with 200 even on failure
+	// and reports the status of each document on a separate line
+	for _, line := range bytes.Split(bytes.TrimSpace(raw), []byte("\n")) {
+		result := struct {
+			Success bool   `json:"success"`
+			Error   string `json:"error"`
+		}{}
+
+		if err := json.Unmarshal(line, &result); err != nil {
+			return err
+		}
+
+		if !result.Success {
+			return fmt.Errorf("typesense import failure: %s", result.Error)
+		}
+	}
+
+	return nil
+}
+
+// Delete implements [Indexer.Delete()].
+func (t *typesense) Delete(ctx context.Context, index string, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	filter := "id:[" + strings.Join(ids, ",") + "]"
+
+	path := "/collections/" + url.PathEscape(index) + "/documents?filter_by=" + url.QueryEscape(filter)
+
+	_, err := t.send(ctx, http.MethodDelete, path, "", nil, t.headers(), true)
+
+	return err
+}
+
+// Clear implements [Indexer.Clear()].
+func (t *typesense) Clear(ctx context.Context, index string) error {
+	_, err := t.send(ctx, http.MethodDelete, "/collections/"+url.PathEscape(index), "", nil, t.headers(), true)
+	if err != nil {
+		return err
+	}
+
+	body, err := jsonBody(map[string]any{
+		"name": index,
+		"fields": []map[string]any{
+			{"name": ".*", "type": "auto"},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = t.send(ctx, http.MethodPost, "/collections", "application/json", body, t.headers(), false)
+
+	return err
+}
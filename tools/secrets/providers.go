@@ -0,0 +1,203 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+)
+
+// EnvProvider resolves the secrets from the process environment variables.
+//
+// The path could be either a variable name ("SMTP_PASSWORD") or a template
+// with "${NAME}" placeholders ("${SMTP_USER}:${SMTP_PASSWORD}").
+type EnvProvider struct{}
+
+// Resolve implements the [Provider] interface.
+func (p *EnvProvider) Resolve(ctx context.Context, path string, key string) (string, error) {
+	var missing []string
+
+	var value string
+	if strings.Contains(path, "$") {
+		value = os.Expand(path, func(name string) string {
+			v, ok := os.LookupEnv(name)
+			if !ok {
+				missing = append(missing, name)
+			}
+			return v
+		})
+	} else {
+		v, ok := os.LookupEnv(path)
+		if !ok {
+			missing = append(missing, path)
+		}
+		value = v
+	}
+
+	if len(missing) > 0 {
+		return "", fmt.Errorf("secrets: missing environment variable(s) %s", strings.Join(missing, ", "))
+	}
+
+	return extractKey(value, key)
+}
+
+// -------------------------------------------------------------------
+
+// VaultProvider resolves the secrets from a HashiCorp Vault KV
+// secrets engine (both v1 and v2 are supported).
+//
+// The path is the full api path after "/v1/", eg. "secret/data/pocketbase".
+type VaultProvider struct {
+	Address   string
+	Token     string
+	Namespace string
+
+	// Client is an optional http client (default to one with 10s timeout).
+	Client *http.Client
+}
+
+// NewVaultProviderFromEnv creates a new VaultProvider from the standard
+// VAULT_ADDR, VAULT_TOKEN and VAULT_NAMESPACE environment variables.
+//
+// Returns nil if VAULT_ADDR is not set.
+func NewVaultProviderFromEnv() *VaultProvider {
+	address := os.Getenv("VAULT_ADDR")
+	if address == "" {
+		return nil
+	}
+
+	return &VaultProvider{
+		Address:   address,
+		Token:     os.Getenv("VAULT_TOKEN"),
+		Namespace: os.Getenv("VAULT_NAMESPACE"),
+	}
+}
+
+// Resolve implements the [Provider] interface.
+func (p *VaultProvider) Resolve(ctx context.Context, path string, key string) (string, error) {
+	client := p.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	url := strings.TrimRight(p.Address, "/") + "/v1/" + strings.TrimLeft(path, "/")
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("X-Vault-Token", p.Token)
+	if p.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.Namespace)
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	rawBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", err
+	}
+
+	// http.Client doesn't treat non 2xx responses as error
+	if res.StatusCode >= 400 {
+		return "", fmt.Errorf("secrets: failed to read vault secret %q (%d)", path, res.StatusCode)
+	}
+
+	body := struct {
+		Data map[string]any `json:"data"`
+	}{}
+	if err := json.Unmarshal(rawBody, &body); err != nil {
+		return "", err
+	}
+
+	data := body.Data
+
+	// KV v2 nests the secret data under data.data
+	if nested, ok := data["data"].(map[string]any); ok {
+		if _, hasMeta := data["metadata"]; hasMeta {
+			data = nested
+		}
+	}
+
+	if key == "" {
+		encoded, err := json.Marshal(data)
+		return string(encoded), err
+	}
+
+	return keyValue(data, key)
+}
+
+// -------------------------------------------------------------------
+
+// AwsProvider resolves the secrets from AWS Secrets Manager.
+//
+// The path is the secret name or ARN.
+type AwsProvider struct {
+	// Region is the AWS region of the secrets
+	// (fallbacks to the default session region resolution).
+	Region string
+
+	mux    sync.Mutex
+	client *secretsmanager.SecretsManager
+}
+
+// Resolve implements the [Provider] interface.
+func (p *AwsProvider) Resolve(ctx context.Context, path string, key string) (string, error) {
+	client, err := p.initClient()
+	if err != nil {
+		return "", err
+	}
+
+	out, err := client.GetSecretValueWithContext(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(path),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if out.SecretString == nil {
+		return "", fmt.Errorf("secrets: aws secret %q has no string value", path)
+	}
+
+	return extractKey(*out.SecretString, key)
+}
+
+// initClient lazily initializes the Secrets Manager client
+// (the AWS credentials are resolved from the default chain).
+func (p *AwsProvider) initClient() (*secretsmanager.SecretsManager, error) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	if p.client == nil {
+		config := aws.NewConfig()
+		if p.Region != "" {
+			config = config.WithRegion(p.Region)
+		}
+
+		sess, err := session.NewSessionWithOptions(session.Options{
+			Config:            *config,
+			SharedConfigState: session.SharedConfigEnable,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		p.client = secretsmanager.New(sess)
+	}
+
+	return p.client, nil
+}
@@ -0,0 +1,244 @@
+// Package secrets implements resolving of settings secret references
+// from external secret stores (environment variables, Vault, AWS Secrets Manager).
+//
+// A reference has the following format:
+//
+//	secret://{provider}/{path}[#{key}]
+//
+// For example:
+//
+//	secret://env/SMTP_PASSWORD
+//	secret://vault/secret/data/pocketbase#smtpPassword
+//	secret://aws/prod/pocketbase#smtpPassword
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Scheme is the prefix of the secret references.
+const Scheme = "secret://"
+
+// List of the builtin provider names.
+const (
+	ProviderEnv   = "env"
+	ProviderVault = "vault"
+	ProviderAws   = "aws"
+)
+
+// Provider resolves secret values from a single secret store.
+type Provider interface {
+	// Resolve returns the secret value stored at path.
+	//
+	// If key is not empty, the secret is expected to be a JSON
+	// object and only the value of its key field is returned.
+	Resolve(ctx context.Context, path string, key string) (string, error)
+}
+
+// Reference is a parsed secret reference.
+type Reference struct {
+	Provider string
+	Path     string
+	Key      string
+}
+
+// IsReference checks whether the provided value is a secret reference.
+func IsReference(value string) bool {
+	return strings.HasPrefix(value, Scheme)
+}
+
+// ParseReference parses the provided secret reference.
+func ParseReference(value string) (*Reference, error) {
+	if !IsReference(value) {
+		return nil, errors.New("secrets: missing " + Scheme + " prefix")
+	}
+
+	rest := strings.TrimPrefix(value, Scheme)
+
+	ref := &Reference{}
+
+	if i := strings.LastIndex(rest, "#"); i >= 0 {
+		ref.Key = rest[i+1:]
+		rest = rest[:i]
+	}
+
+	parts := strings.SplitN(rest, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("secrets: invalid reference %q", value)
+	}
+
+	ref.Provider = parts[0]
+	ref.Path = parts[1]
+
+	return ref, nil
+}
+
+// Status describes the resolve state of a single referenced field.
+type Status struct {
+	Field      string    `json:"field"`
+	Reference  string    `json:"reference"`
+	Provider   string    `json:"provider"`
+	Resolved   bool      `json:"resolved"`
+	Error      string    `json:"error,omitempty"`
+	ResolvedAt time.Time `json:"resolvedAt"`
+}
+
+// Manager resolves and caches the secret references.
+type Manager struct {
+	mux       sync.RWMutex
+	providers map[string]Provider
+	values    map[string]string
+	statuses  map[string]*Status
+}
+
+// NewManager creates a new Manager with the env provider registered.
+func NewManager() *Manager {
+	return &Manager{
+		providers: map[string]Provider{
+			ProviderEnv: &EnvProvider{},
+		},
+		values:   map[string]string{},
+		statuses: map[string]*Status{},
+	}
+}
+
+// Register registers (or replaces) the named provider.
+func (m *Manager) Register(name string, provider Provider) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	m.providers[name] = provider
+}
+
+// Load resolves the secret references of the provided fields
+// (map with field name and value pairs) and replaces the previously
+// cached values (the fields without reference values are ignored).
+//
+// The resolve failures are not returned and could be
+// inspected with [Manager.Status].
+func (m *Manager) Load(ctx context.Context, fields map[string]string) {
+	values := map[string]string{}
+	statuses := map[string]*Status{}
+
+	m.mux.RLock()
+	providers := make(map[string]Provider, len(m.providers))
+	for name, provider := range m.providers {
+		providers[name] = provider
+	}
+	m.mux.RUnlock()
+
+	for field, value := range fields {
+		if !IsReference(value) {
+			continue
+		}
+
+		status := &Status{
+			Field:      field,
+			Reference:  value,
+			ResolvedAt: time.Now(),
+		}
+		statuses[field] = status
+
+		ref, err := ParseReference(value)
+		if err != nil {
+			status.Error = err.Error()
+			continue
+		}
+		status.Provider = ref.Provider
+
+		if _, ok := values[value]; ok {
+			// already resolved by another field
+			status.Resolved = true
+			continue
+		}
+
+		provider, ok := providers[ref.Provider]
+		if !ok {
+			status.Error = fmt.Sprintf("unregistered secrets provider %q", ref.Provider)
+			continue
+		}
+
+		resolved, err := provider.Resolve(ctx, ref.Path, ref.Key)
+		if err != nil {
+			status.Error = err.Error()
+			continue
+		}
+
+		status.Resolved = true
+		values[value] = resolved
+	}
+
+	m.mux.Lock()
+	m.values = values
+	m.statuses = statuses
+	m.mux.Unlock()
+}
+
+// Resolve returns the loaded secret value of the provided reference.
+//
+// Non reference values are returned as they are.
+// Not loaded or failed references are resolved to empty string.
+func (m *Manager) Resolve(value string) string {
+	if !IsReference(value) {
+		return value
+	}
+
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+
+	return m.values[value]
+}
+
+// Status returns the resolve state of all loaded references (sorted by field).
+func (m *Manager) Status() []Status {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+
+	result := make([]Status, 0, len(m.statuses))
+	for _, s := range m.statuses {
+		result = append(result, *s)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Field < result[j].Field
+	})
+
+	return result
+}
+
+// extractKey returns the key field value of the JSON object secret
+// (or the secret itself if key is empty).
+func extractKey(secret string, key string) (string, error) {
+	if key == "" {
+		return secret, nil
+	}
+
+	data := map[string]any{}
+	if err := json.Unmarshal([]byte(secret), &data); err != nil {
+		return "", fmt.Errorf("secrets: the secret is not a JSON object: %w", err)
+	}
+
+	return keyValue(data, key)
+}
+
+func keyValue(data map[string]any, key string) (string, error) {
+	raw, ok := data[key]
+	if !ok {
+		return "", fmt.Errorf("secrets: missing secret key %q", key)
+	}
+
+	switch v := raw.(type) {
+	case string:
+		return v, nil
+	default:
+		encoded, err := json.Marshal(v)
+		return string(encoded), err
+	}
+}
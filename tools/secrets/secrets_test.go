@@ -0,0 +1,221 @@
+package secrets_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/secrets"
+)
+
+type testProvider struct {
+	calls int
+}
+
+func (p *testProvider) Resolve(ctx context.Context, path string, key string) (string, error) {
+	p.calls++
+
+	if path == "fail" {
+		return "", errors.New("test failure")
+	}
+
+	return path + "|" + key, nil
+}
+
+func TestParseReference(t *testing.T) {
+	scenarios := []struct {
+		value       string
+		expectError bool
+		expected    secrets.Reference
+	}{
+		{"", true, secrets.Reference{}},
+		{"plain", true, secrets.Reference{}},
+		{"secret://", true, secrets.Reference{}},
+		{"secret://env", true, secrets.Reference{}},
+		{"secret://env/", true, secrets.Reference{}},
+		{"secret:///path", true, secrets.Reference{}},
+		{"secret://env/NAME", false, secrets.Reference{Provider: "env", Path: "NAME"}},
+		{"secret://vault/secret/data/pb#smtp", false, secrets.Reference{Provider: "vault", Path: "secret/data/pb", Key: "smtp"}},
+		{"secret://aws/a#b/c#key", false, secrets.Reference{Provider: "aws", Path: "a#b/c", Key: "key"}},
+	}
+
+	for _, s := range scenarios {
+		ref, err := secrets.ParseReference(s.value)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("(%q) Expected hasErr %v, got %v (%v)", s.value, s.expectError, hasErr, err)
+			continue
+		}
+
+		if !hasErr && *ref != s.expected {
+			t.Errorf("(%q) Expected %v, got %v", s.value, s.expected, *ref)
+		}
+	}
+}
+
+func TestManager(t *testing.T) {
+	t.Setenv("PB_SECRETS_TEST_A", "a_value")
+	t.Setenv("PB_SECRETS_TEST_B", `{"key":"b_value"}`)
+
+	provider := &testProvider{}
+
+	m := secrets.NewManager()
+	m.Register("test", provider)
+
+	m.Load(context.Background(), map[string]string{
+		"plain":       "plain_value",
+		"env":         "secret://env/PB_SECRETS_TEST_A",
+		"envKey":      "secret://env/PB_SECRETS_TEST_B#key",
+		"envMissing":  "secret://env/PB_SECRETS_TEST_MISSING",
+		"custom1":     "secret://test/path#key",
+		"custom2":     "secret://test/path#key",
+		"customFail":  "secret://test/fail",
+		"unknown":     "secret://unknown/path",
+		"invalid":     "secret://invalid",
+		"emptyString": "",
+	})
+
+	// the same reference should be resolved only once
+	if provider.calls != 2 {
+		t.Fatalf("Expected 2 provider calls, got %d", provider.calls)
+	}
+
+	resolveScenarios := map[string]string{
+		"plain_value":                          "plain_value",
+		"":                                     "",
+		"secret://env/PB_SECRETS_TEST_A":       "a_value",
+		"secret://env/PB_SECRETS_TEST_B#key":   "b_value",
+		"secret://env/PB_SECRETS_TEST_MISSING": "",
+		"secret://test/path#key":               "path|key",
+		"secret://test/fail":                   "",
+		"secret://test/not_loaded":             "",
+	}
+	for value, expected := range resolveScenarios {
+		if result := m.Resolve(value); result != expected {
+			t.Errorf("(%q) Expected resolved value %q, got %q", value, expected, result)
+		}
+	}
+
+	statuses := m.Status()
+
+	expectedStatuses := []struct {
+		field    string
+		resolved bool
+		error    string
+	}{
+		{"custom1", true, ""},
+		{"custom2", true, ""},
+		{"customFail", false, "test failure"},
+		{"env", true, ""},
+		{"envKey", true, ""},
+		{"envMissing", false, "missing environment variable"},
+		{"invalid", false, "invalid reference"},
+		{"unknown", false, "unregistered secrets provider"},
+	}
+
+	if len(statuses) != len(expectedStatuses) {
+		t.Fatalf("Expected %d statuses, got %v", len(expectedStatuses), statuses)
+	}
+
+	for i, expected := range expectedStatuses {
+		status := statuses[i]
+
+		if status.Field != expected.field || status.Resolved != expected.resolved {
+			t.Errorf("(%d) Expected %s (resolved %v), got %s (resolved %v)", i, expected.field, expected.resolved, status.Field, status.Resolved)
+		}
+
+		if !strings.Contains(status.Error, expected.error) || (expected.error == "" && status.Error != "") {
+			t.Errorf("(%d) Expected error %q, got %q", i, expected.error, status.Error)
+		}
+	}
+
+	// reload replaces the previous values
+	m.Load(context.Background(), map[string]string{})
+	if len(m.Status()) != 0 {
+		t.Fatalf("Expected no statuses after reload, got %v", m.Status())
+	}
+	if v := m.Resolve("secret://env/PB_SECRETS_TEST_A"); v != "" {
+		t.Fatalf("Expected the reloaded value to be empty, got %q", v)
+	}
+}
+
+func TestEnvProviderTemplate(t *testing.T) {
+	t.Setenv("PB_SECRETS_TEST_USER", "user")
+	t.Setenv("PB_SECRETS_TEST_PASS", "pass")
+
+	p := &secrets.EnvProvider{}
+
+	value, err := p.Resolve(context.Background(), "${PB_SECRETS_TEST_USER}:${PB_SECRETS_TEST_PASS}", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != "user:pass" {
+		t.Fatalf("Expected %q, got %q", "user:pass", value)
+	}
+
+	if _, err := p.Resolve(context.Background(), "${PB_SECRETS_TEST_USER}:${PB_SECRETS_TEST_MISSING}", ""); err == nil {
+		t.Fatal("Expected missing variable error, got nil")
+	}
+}
+
+func TestVaultProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "test_token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v1/secret/data/pb":
+			w.Write([]byte(`{"data":{"data":{"smtp":"kv2_value","port":25},"metadata":{"version":1}}}`))
+		case "/v1/kv/pb":
+			w.Write([]byte(`{"data":{"smtp":"kv1_value"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	p := &secrets.VaultProvider{
+		Address: server.URL + "/",
+		Token:   "test_token",
+	}
+
+	scenarios := []struct {
+		path        string
+		key         string
+		expected    string
+		expectError bool
+	}{
+		{"secret/data/pb", "smtp", "kv2_value", false},
+		{"secret/data/pb", "port", "25", false},
+		{"secret/data/pb", "", `{"port":25,"smtp":"kv2_value"}`, false},
+		{"secret/data/pb", "missing", "", true},
+		{"/kv/pb", "smtp", "kv1_value", false},
+		{"missing", "smtp", "", true},
+	}
+
+	for _, s := range scenarios {
+		value, err := p.Resolve(context.Background(), s.path, s.key)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("(%s#%s) Expected hasErr %v, got %v (%v)", s.path, s.key, s.expectError, hasErr, err)
+			continue
+		}
+
+		if value != s.expected {
+			t.Errorf("(%s#%s) Expected %q, got %q", s.path, s.key, s.expected, value)
+		}
+	}
+
+	// invalid token
+	p.Token = "invalid"
+	if _, err := p.Resolve(context.Background(), "secret/data/pb", "smtp"); err == nil {
+		t.Fatal("Expected forbidden error, got nil")
+	}
+}
@@ -3,9 +3,11 @@ package security
 import (
 	"crypto/aes"
 	"crypto/cipher"
+	"crypto/hmac"
 	crand "crypto/rand"
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"io"
 	"strings"
 )
@@ -68,3 +70,10 @@ func Decrypt(cipherText string, key string) ([]byte, error) {
 	nonce, cipherByteClean := cipherByte[:nonceSize], cipherByte[nonceSize:]
 	return gcm.Open(nil, nonce, cipherByteClean, nil)
 }
+
+// HS256 creates a hex encoded HMAC-SHA256 hash of text using the provided secret.
+func HS256(text string, secret string) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(text))
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -91,3 +91,22 @@ func TestDecrypt(t *testing.T) {
 		}
 	}
 }
+
+func TestHS256(t *testing.T) {
+	scenarios := []struct {
+		text     string
+		secret   string
+		expected string
+	}{
+		{"", "", "b613679a0814d9ec772f95d778c35fc5ff1697c493715653c6c712144292c5ad"},
+		{"123", "test", "14a2715d35b254070cb215ebb7013ed988d382945c2b36ddb5991214ef79c796"},
+	}
+
+	for i, scenario := range scenarios {
+		result := security.HS256(scenario.text, scenario.secret)
+
+		if result != scenario.expected {
+			t.Errorf("(%d) Expected %q, got %q", i, scenario.expected, result)
+		}
+	}
+}
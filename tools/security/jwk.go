@@ -0,0 +1,187 @@
+package security
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	crand "crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// List of the supported JWT signing algorithms.
+const (
+	AlgorithmHS256 string = "HS256"
+	AlgorithmRS256 string = "RS256"
+	AlgorithmEdDSA string = "EdDSA"
+)
+
+// rsaKeyBits is the size of the generated RS256 keys.
+const rsaKeyBits = 2048
+
+// GenerateSigningKey generates a new PKCS #8 PEM encoded
+// private key for the specified asymmetric algorithm (RS256 or EdDSA).
+func GenerateSigningKey(algorithm string) (string, error) {
+	var key any
+	var err error
+
+	switch algorithm {
+	case AlgorithmRS256:
+		key, err = rsa.GenerateKey(crand.Reader, rsaKeyBits)
+	case AlgorithmEdDSA:
+		_, key, err = ed25519.GenerateKey(crand.Reader)
+	default:
+		return "", fmt.Errorf("unsupported signing algorithm %q", algorithm)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})), nil
+}
+
+// ParseSigningKey parses a PEM encoded (PKCS #8 or PKCS #1 for RSA)
+// private key and checks that it matches the specified algorithm.
+func ParseSigningKey(algorithm string, privateKey string) (crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(strings.TrimSpace(privateKey)))
+	if block == nil {
+		return nil, errors.New("invalid PEM encoded private key")
+	}
+
+	var key any
+	var err error
+	if block.Type == "RSA PRIVATE KEY" {
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	} else {
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		if algorithm == AlgorithmRS256 {
+			return k, nil
+		}
+	case ed25519.PrivateKey:
+		if algorithm == AlgorithmEdDSA {
+			return k, nil
+		}
+	}
+
+	return nil, fmt.Errorf("the private key is not a valid %s key", algorithm)
+}
+
+// NewSignedToken generates and returns new JWT token signed with
+// the provided asymmetric private key.
+//
+// The keyId is stored in the "kid" token header so that the
+// token could be verified with the matching public JWK.
+func NewSignedToken(
+	payload jwt.MapClaims,
+	algorithm string,
+	keyId string,
+	signingKey crypto.Signer,
+	secondsDuration int64,
+) (string, error) {
+	method := jwt.GetSigningMethod(algorithm)
+	if method == nil || algorithm == AlgorithmHS256 {
+		return "", fmt.Errorf("unsupported signing algorithm %q", algorithm)
+	}
+
+	seconds := time.Duration(secondsDuration) * time.Second
+
+	claims := jwt.MapClaims{
+		"exp": time.Now().Add(seconds).Unix(),
+	}
+
+	for k, v := range payload {
+		claims[k] = v
+	}
+
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = keyId
+
+	return token.SignedString(signingKey)
+}
+
+// ParseSignedJWT verifies and parses an asymmetric signed
+// JWT token and returns its claims.
+func ParseSignedJWT(token string, algorithm string, publicKey crypto.PublicKey) (jwt.MapClaims, error) {
+	parser := jwt.NewParser(jwt.WithValidMethods([]string{algorithm}))
+
+	parsedToken, err := parser.Parse(token, func(t *jwt.Token) (any, error) {
+		return publicKey, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if claims, ok := parsedToken.Claims.(jwt.MapClaims); ok && parsedToken.Valid {
+		return claims, nil
+	}
+
+	return nil, errors.New("Unable to parse token.")
+}
+
+// ParseUnverifiedJWTHeader parses JWT token and returns its header
+// but DOES NOT verify the signature or the claims.
+func ParseUnverifiedJWTHeader(token string) (map[string]any, error) {
+	parsedToken, _, err := (&jwt.Parser{}).ParseUnverified(token, jwt.MapClaims{})
+	if err != nil {
+		return nil, err
+	}
+
+	return parsedToken.Header, nil
+}
+
+// JWK defines a single public JSON Web Key (RFC 7517).
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+
+	// RSA specific
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+
+	// OKP (Ed25519) specific
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// NewJWK creates a new signature verification JWK
+// from the provided RSA or Ed25519 public key.
+func NewJWK(keyId string, algorithm string, publicKey crypto.PublicKey) (*JWK, error) {
+	jwk := &JWK{Use: "sig", Alg: algorithm, Kid: keyId}
+
+	switch k := publicKey.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(k.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(k)
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", publicKey)
+	}
+
+	return jwk, nil
+}
@@ -0,0 +1,148 @@
+package security_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+func TestGenerateAndParseSigningKey(t *testing.T) {
+	if _, err := security.GenerateSigningKey(security.AlgorithmHS256); err == nil {
+		t.Fatal("Expected HS256 key generation error, got nil")
+	}
+
+	rsaKey, err := security.GenerateSigningKey(security.AlgorithmRS256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	edKey, err := security.GenerateSigningKey(security.AlgorithmEdDSA)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		algorithm   string
+		key         string
+		expectError bool
+	}{
+		{security.AlgorithmRS256, "", true},
+		{security.AlgorithmRS256, "invalid", true},
+		{security.AlgorithmRS256, edKey, true},
+		{security.AlgorithmRS256, rsaKey, false},
+		{security.AlgorithmEdDSA, rsaKey, true},
+		{security.AlgorithmEdDSA, edKey, false},
+		{security.AlgorithmHS256, edKey, true},
+	}
+
+	for i, s := range scenarios {
+		signer, err := security.ParseSigningKey(s.algorithm, s.key)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("(%d) Expected hasErr to be %v, got %v (%v)", i, s.expectError, hasErr, err)
+			continue
+		}
+
+		if !hasErr && signer == nil {
+			t.Errorf("(%d) Expected non-nil signer", i)
+		}
+	}
+}
+
+func TestNewSignedTokenAndParseSignedJWT(t *testing.T) {
+	for _, algorithm := range []string{security.AlgorithmRS256, security.AlgorithmEdDSA} {
+		rawKey, err := security.GenerateSigningKey(algorithm)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		signer, err := security.ParseSigningKey(algorithm, rawKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		token, err := security.NewSignedToken(jwt.MapClaims{"name": "test"}, algorithm, "test_kid", signer, 100)
+		if err != nil {
+			t.Fatalf("[%s] %v", algorithm, err)
+		}
+
+		header, err := security.ParseUnverifiedJWTHeader(token)
+		if err != nil {
+			t.Fatalf("[%s] %v", algorithm, err)
+		}
+		if header["kid"] != "test_kid" || header["alg"] != algorithm {
+			t.Fatalf("[%s] Unexpected token header %v", algorithm, header)
+		}
+
+		claims, err := security.ParseSignedJWT(token, algorithm, signer.Public())
+		if err != nil {
+			t.Fatalf("[%s] %v", algorithm, err)
+		}
+		if claims["name"] != "test" {
+			t.Fatalf("[%s] Expected name claim test, got %v", algorithm, claims)
+		}
+
+		// other key
+		otherRawKey, _ := security.GenerateSigningKey(algorithm)
+		otherSigner, _ := security.ParseSigningKey(algorithm, otherRawKey)
+		if _, err := security.ParseSignedJWT(token, algorithm, otherSigner.Public()); err == nil {
+			t.Fatalf("[%s] Expected verification error with other key", algorithm)
+		}
+
+		// expired
+		expired, _ := security.NewSignedToken(jwt.MapClaims{"name": "test"}, algorithm, "test_kid", signer, -100)
+		if _, err := security.ParseSignedJWT(expired, algorithm, signer.Public()); err == nil {
+			t.Fatalf("[%s] Expected expired token error", algorithm)
+		}
+
+		// HS256 downgrade
+		hsToken, _ := security.NewToken(jwt.MapClaims{"name": "test"}, "test", 100)
+		if _, err := security.ParseSignedJWT(hsToken, algorithm, signer.Public()); err == nil {
+			t.Fatalf("[%s] Expected HS256 token error", algorithm)
+		}
+	}
+
+	if _, err := security.NewSignedToken(jwt.MapClaims{}, security.AlgorithmHS256, "test", nil, 100); err == nil {
+		t.Fatal("Expected HS256 signing error, got nil")
+	}
+}
+
+func TestNewJWK(t *testing.T) {
+	rsaRawKey, _ := security.GenerateSigningKey(security.AlgorithmRS256)
+	rsaKey, _ := security.ParseSigningKey(security.AlgorithmRS256, rsaRawKey)
+
+	jwk, err := security.NewJWK("a", security.AlgorithmRS256, rsaKey.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jwk.Kty != "RSA" || jwk.Kid != "a" || jwk.Alg != security.AlgorithmRS256 || jwk.Use != "sig" ||
+		jwk.E != "AQAB" || jwk.N == "" || jwk.X != "" {
+		t.Fatalf("Unexpected RSA jwk %v", jwk)
+	}
+	if _, ok := rsaKey.Public().(*rsa.PublicKey); !ok {
+		t.Fatal("Expected RSA public key")
+	}
+
+	edRawKey, _ := security.GenerateSigningKey(security.AlgorithmEdDSA)
+	edKey, _ := security.ParseSigningKey(security.AlgorithmEdDSA, edRawKey)
+
+	jwk, err = security.NewJWK("b", security.AlgorithmEdDSA, edKey.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 32 bytes base64url encoded without padding
+	if jwk.Kty != "OKP" || jwk.Crv != "Ed25519" || jwk.Kid != "b" || len(jwk.X) != 43 || jwk.N != "" {
+		t.Fatalf("Unexpected Ed25519 jwk %v", jwk)
+	}
+	if _, ok := edKey.Public().(ed25519.PublicKey); !ok {
+		t.Fatal("Expected Ed25519 public key")
+	}
+
+	if _, err := security.NewJWK("c", security.AlgorithmHS256, "invalid"); err == nil {
+		t.Fatal("Expected unsupported key error, got nil")
+	}
+}
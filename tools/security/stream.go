@@ -0,0 +1,155 @@
+package security
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	crand "crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+)
+
+// streamChunkSize is the max plaintext size of a single encrypted stream chunk.
+const streamChunkSize = 64 * 1024
+
+// streamNoncePrefixSize is the size of the random per stream nonce prefix
+// (the remaining 4 bytes of the nonce are used for the chunk counter).
+const streamNoncePrefixSize = 8
+
+// EncryptStream encrypts the src reader content with key
+// (must be valid 32 char aes key) and writes the result to dst.
+//
+// The data is encrypted with AES-GCM in chunks of 64KiB, allowing
+// large files to be processed without loading them in memory.
+// Each chunk is authenticated together with its position and whether it
+// is the final one, so reordered or truncated streams fail to decrypt.
+func EncryptStream(dst io.Writer, src io.Reader, key string) error {
+	gcm, err := newStreamGCM(key)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(crand.Reader, nonce[:streamNoncePrefixSize]); err != nil {
+		return err
+	}
+
+	if _, err := dst.Write(nonce[:streamNoncePrefixSize]); err != nil {
+		return err
+	}
+
+	reader := bufio.NewReader(src)
+	buf := make([]byte, streamChunkSize)
+	out := make([]byte, 0, streamChunkSize+gcm.Overhead())
+
+	for counter := uint64(0); ; counter++ {
+		if counter > math.MaxUint32 {
+			return errors.New("the stream is too large")
+		}
+
+		n, err := io.ReadFull(reader, buf)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return err
+		}
+
+		isLast := err != nil
+		if !isLast {
+			// check whether there is more data to read
+			if _, peekErr := reader.Peek(1); peekErr == io.EOF {
+				isLast = true
+			} else if peekErr != nil {
+				return peekErr
+			}
+		}
+
+		binary.BigEndian.PutUint32(nonce[streamNoncePrefixSize:], uint32(counter))
+
+		out = gcm.Seal(out[:0], nonce, buf[:n], streamChunkAad(isLast))
+
+		if _, err := dst.Write(out); err != nil {
+			return err
+		}
+
+		if isLast {
+			return nil
+		}
+	}
+}
+
+// DecryptStream decrypts the src reader content previously
+// encrypted with [EncryptStream] and writes the result to dst.
+//
+// Note that dst may receive partial data if the decryption
+// fails midway (eg. on corrupted or truncated stream).
+func DecryptStream(dst io.Writer, src io.Reader, key string) error {
+	gcm, err := newStreamGCM(key)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(src, nonce[:streamNoncePrefixSize]); err != nil {
+		return errors.New("invalid or truncated encrypted stream")
+	}
+
+	reader := bufio.NewReader(src)
+	buf := make([]byte, streamChunkSize+gcm.Overhead())
+	out := make([]byte, 0, streamChunkSize)
+
+	for counter := uint64(0); ; counter++ {
+		if counter > math.MaxUint32 {
+			return errors.New("the stream is too large")
+		}
+
+		n, err := io.ReadFull(reader, buf)
+		if err == io.EOF {
+			return errors.New("invalid or truncated encrypted stream")
+		}
+		if err != nil && err != io.ErrUnexpectedEOF {
+			return err
+		}
+
+		isLast := err != nil
+		if !isLast {
+			if _, peekErr := reader.Peek(1); peekErr == io.EOF {
+				isLast = true
+			} else if peekErr != nil {
+				return peekErr
+			}
+		}
+
+		binary.BigEndian.PutUint32(nonce[streamNoncePrefixSize:], uint32(counter))
+
+		out, err = gcm.Open(out[:0], nonce, buf[:n], streamChunkAad(isLast))
+		if err != nil {
+			return err
+		}
+
+		if _, err := dst.Write(out); err != nil {
+			return err
+		}
+
+		if isLast {
+			return nil
+		}
+	}
+}
+
+func newStreamGCM(key string) (cipher.AEAD, error) {
+	block, err := aes.NewCipher([]byte(key))
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+func streamChunkAad(isLast bool) []byte {
+	if isLast {
+		return []byte{1}
+	}
+
+	return []byte{0}
+}
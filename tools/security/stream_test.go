@@ -0,0 +1,84 @@
+package security_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+func TestEncryptDecryptStream(t *testing.T) {
+	key := "abcdabcdabcdabcdabcdabcdabcdabcd"
+
+	scenarios := []struct {
+		name string
+		data string
+	}{
+		{"empty", ""},
+		{"small", "123"},
+		{"exactly one chunk", strings.Repeat("a", 64*1024)},
+		{"multiple chunks", strings.Repeat("abc", 64*1024)},
+	}
+
+	for _, s := range scenarios {
+		encrypted := new(bytes.Buffer)
+		if err := security.EncryptStream(encrypted, strings.NewReader(s.data), key); err != nil {
+			t.Fatalf("[%s] Failed to encrypt: %v", s.name, err)
+		}
+
+		if s.data != "" && bytes.Contains(encrypted.Bytes(), []byte(s.data)) {
+			t.Fatalf("[%s] Expected the encrypted stream to not contain the original data", s.name)
+		}
+
+		decrypted := new(bytes.Buffer)
+		if err := security.DecryptStream(decrypted, bytes.NewReader(encrypted.Bytes()), key); err != nil {
+			t.Fatalf("[%s] Failed to decrypt: %v", s.name, err)
+		}
+
+		if decrypted.String() != s.data {
+			t.Fatalf("[%s] Expected the decrypted data to match with the original one (%d vs %d bytes)", s.name, decrypted.Len(), len(s.data))
+		}
+	}
+}
+
+func TestEncryptStreamInvalidKey(t *testing.T) {
+	if err := security.EncryptStream(new(bytes.Buffer), strings.NewReader("123"), "test"); err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+}
+
+func TestDecryptStreamFailure(t *testing.T) {
+	key := "abcdabcdabcdabcdabcdabcdabcdabcd"
+
+	encrypted := new(bytes.Buffer)
+	if err := security.EncryptStream(encrypted, strings.NewReader(strings.Repeat("abc", 64*1024)), key); err != nil {
+		t.Fatal(err)
+	}
+	raw := encrypted.Bytes()
+
+	tampered := append([]byte{}, raw...)
+	tampered[len(tampered)-1] ^= 1
+
+	// remove the last chunk
+	truncated := raw[:8+64*1024+16]
+
+	scenarios := []struct {
+		name string
+		data []byte
+		key  string
+	}{
+		{"invalid key", raw, "test"},
+		{"wrong key", raw, "1234abcdabcdabcdabcdabcdabcdabcd"},
+		{"empty stream", nil, key},
+		{"missing chunks", raw[:8], key},
+		{"tampered stream", tampered, key},
+		{"truncated stream", truncated, key},
+	}
+
+	for _, s := range scenarios {
+		if err := security.DecryptStream(new(bytes.Buffer), bytes.NewReader(s.data), s.key); err == nil {
+			t.Errorf("[%s] Expected error, got nil", s.name)
+		}
+	}
+}
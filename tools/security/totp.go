@@ -0,0 +1,112 @@
+package security
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// TOTPPeriod is the TOTP time step in seconds.
+	TOTPPeriod = 30
+
+	// TOTPDigits is the number of digits of the generated TOTP codes.
+	TOTPDigits = 6
+
+	// totpSkew is the number of the previous and next time steps
+	// that are also accepted to compensate the clients clock drift.
+	totpSkew = 1
+)
+
+const totpSecretAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZ234567"
+
+// NewTOTPSecret generates a new random base32 encoded (160 bits) TOTP secret.
+func NewTOTPSecret() string {
+	return RandomStringWithAlphabet(32, totpSecretAlphabet)
+}
+
+// TOTPCode generates the RFC 6238 TOTP code (HMAC-SHA1) of the
+// provided base32 encoded secret for the specified time.
+func TOTPCode(secret string, t time.Time) (string, error) {
+	return totpCode(secret, t.Unix()/TOTPPeriod)
+}
+
+// ValidateTOTP reports whether code is a valid TOTP code of the provided
+// base32 encoded secret for the specified time (incl. the adjacent time steps).
+func ValidateTOTP(secret string, code string, t time.Time) bool {
+	_, ok := MatchTOTP(secret, code, t)
+
+	return ok
+}
+
+// MatchTOTP is similar to [ValidateTOTP] but also returns the
+// time step of the matched code (eg. to prevent its reuse).
+func MatchTOTP(secret string, code string, t time.Time) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != TOTPDigits {
+		return 0, false
+	}
+
+	step := t.Unix() / TOTPPeriod
+
+	for i := int64(-totpSkew); i <= totpSkew; i++ {
+		expected, err := totpCode(secret, step+i)
+		if err != nil {
+			return 0, false
+		}
+
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step + i, true
+		}
+	}
+
+	return 0, false
+}
+
+// TOTPAuthURI returns the "otpauth://" key URI of the provided secret
+// that could be encoded as QR code and scanned by the authenticator apps.
+func TOTPAuthURI(issuer string, account string, secret string) string {
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprint(TOTPDigits))
+	params.Set("period", fmt.Sprint(TOTPPeriod))
+
+	label := url.PathEscape(issuer) + ":" + url.PathEscape(account)
+
+	return "otpauth://totp/" + label + "?" + params.Encode()
+}
+
+func totpCode(secret string, step int64) (string, error) {
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(
+		strings.ToUpper(strings.TrimRight(strings.TrimSpace(secret), "=")),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	// dynamic truncation
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < TOTPDigits; i++ {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", TOTPDigits, value%mod), nil
+}
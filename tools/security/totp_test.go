@@ -0,0 +1,136 @@
+package security_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+// RFC 6238 SHA1 test secret ("12345678901234567890" base32 encoded)
+const testTOTPSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestNewTOTPSecret(t *testing.T) {
+	secret := security.NewTOTPSecret()
+
+	if len(secret) != 32 {
+		t.Fatalf("Expected secret with length 32, got %d", len(secret))
+	}
+
+	if _, err := security.TOTPCode(secret, time.Now()); err != nil {
+		t.Fatalf("Expected valid base32 secret, got %v", err)
+	}
+
+	if secret == security.NewTOTPSecret() {
+		t.Fatal("Expected different secrets")
+	}
+}
+
+func TestTOTPCode(t *testing.T) {
+	scenarios := []struct {
+		unix     int64
+		expected string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, s := range scenarios {
+		code, err := security.TOTPCode(testTOTPSecret, time.Unix(s.unix, 0))
+		if err != nil {
+			t.Fatalf("(%d) %v", s.unix, err)
+		}
+
+		if code != s.expected {
+			t.Errorf("(%d) Expected code %q, got %q", s.unix, s.expected, code)
+		}
+	}
+
+	if _, err := security.TOTPCode("invalid!", time.Now()); err == nil {
+		t.Fatal("Expected error for the invalid secret, got nil")
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+
+	scenarios := []struct {
+		name     string
+		secret   string
+		code     string
+		expected bool
+	}{
+		{"empty code", testTOTPSecret, "", false},
+		{"invalid code", testTOTPSecret, "123456", false},
+		{"invalid secret", "invalid!", "005924", false},
+		{"current step", testTOTPSecret, "005924", true},
+		{"current step (with whitespaces)", testTOTPSecret, " 005924 ", true},
+		{"previous step", testTOTPSecret, mustTOTPCode(t, now.Add(-security.TOTPPeriod*time.Second)), true},
+		{"next step", testTOTPSecret, mustTOTPCode(t, now.Add(security.TOTPPeriod*time.Second)), true},
+		{"outside of the allowed skew", testTOTPSecret, mustTOTPCode(t, now.Add(-3*security.TOTPPeriod*time.Second)), false},
+	}
+
+	for _, s := range scenarios {
+		if v := security.ValidateTOTP(s.secret, s.code, now); v != s.expected {
+			t.Errorf("[%s] Expected %v, got %v", s.name, s.expected, v)
+		}
+	}
+}
+
+func TestMatchTOTP(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	step := now.Unix() / security.TOTPPeriod
+
+	scenarios := []struct {
+		name          string
+		code          string
+		expectedStep  int64
+		expectedMatch bool
+	}{
+		{"invalid code", "123456", 0, false},
+		{"current step", "005924", step, true},
+		{"previous step", mustTOTPCode(t, now.Add(-security.TOTPPeriod*time.Second)), step - 1, true},
+		{"next step", mustTOTPCode(t, now.Add(security.TOTPPeriod*time.Second)), step + 1, true},
+	}
+
+	for _, s := range scenarios {
+		resultStep, match := security.MatchTOTP(testTOTPSecret, s.code, now)
+		if match != s.expectedMatch {
+			t.Errorf("[%s] Expected match %v, got %v", s.name, s.expectedMatch, match)
+		}
+		if resultStep != s.expectedStep {
+			t.Errorf("[%s] Expected step %d, got %d", s.name, s.expectedStep, resultStep)
+		}
+	}
+}
+
+func TestTOTPAuthURI(t *testing.T) {
+	uri := security.TOTPAuthURI("Acme App", "test@example.com", testTOTPSecret)
+
+	expectedParts := []string{
+		"otpauth://totp/Acme%20App:test@example.com?",
+		"secret=" + testTOTPSecret,
+		"issuer=Acme+App",
+		"digits=6",
+		"period=30",
+	}
+
+	for _, part := range expectedParts {
+		if !strings.Contains(uri, part) {
+			t.Errorf("Expected %q to contain %q", uri, part)
+		}
+	}
+}
+
+func mustTOTPCode(t *testing.T, tm time.Time) string {
+	code, err := security.TOTPCode(testTOTPSecret, tm)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return code
+}
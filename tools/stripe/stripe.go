@@ -0,0 +1,158 @@
+// Package stripe implements the Stripe webhook events signature
+// verification and the subset of the event objects needed to
+// sync the subscriptions state.
+//
+// https://stripe.com/docs/webhooks#verify-manually
+package stripe
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SignatureHeader is the name of the Stripe webhook request signature header.
+const SignatureHeader = "Stripe-Signature"
+
+// DefaultTolerance is the max allowed difference between the
+// signature timestamp and the current time (to prevent replay attacks).
+const DefaultTolerance = 5 * time.Minute
+
+// List of the handled Stripe event types.
+const (
+	EventCheckoutSessionCompleted    = "checkout.session.completed"
+	EventCustomerSubscriptionCreated = "customer.subscription.created"
+	EventCustomerSubscriptionUpdated = "customer.subscription.updated"
+	EventCustomerSubscriptionDeleted = "customer.subscription.deleted"
+)
+
+// List of the signature verification errors.
+var (
+	ErrMissingSignature = errors.New("missing or malformed signature header")
+	ErrInvalidSignature = errors.New("the signature doesn't match the payload")
+	ErrExpiredSignature = errors.New("the signature timestamp is outside of the tolerance window")
+)
+
+// ComputeSignature returns the hex encoded v1 signature of the
+// payload sent at the provided time with the endpoint secret.
+func ComputeSignature(payload []byte, t time.Time, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(t.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// SignatureHeaderValue returns the signature header value of the
+// payload sent at the provided time (eg. "t=1680000000,v1=abc...").
+//
+// It is useful mostly for testing the webhook receivers.
+func SignatureHeaderValue(payload []byte, t time.Time, secret string) string {
+	return fmt.Sprintf("t=%d,v1=%s", t.Unix(), ComputeSignature(payload, t, secret))
+}
+
+// VerifySignature checks whether the signature header is a valid
+// signature of the payload with the endpoint secret and its
+// timestamp is within the tolerance window from now.
+//
+// The header could have multiple v1 signatures (eg. during a secret
+// rotation) and it is enough for one of them to match.
+func VerifySignature(payload []byte, header string, secret string, tolerance time.Duration, now time.Time) error {
+	var timestamp int64
+	var signatures []string
+
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+
+		switch key {
+		case "t":
+			timestamp, _ = strconv.ParseInt(value, 10, 64)
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+
+	if timestamp <= 0 || len(signatures) == 0 {
+		return ErrMissingSignature
+	}
+
+	signedAt := time.Unix(timestamp, 0)
+
+	if tolerance > 0 && (now.Sub(signedAt) > tolerance || signedAt.Sub(now) > tolerance) {
+		return ErrExpiredSignature
+	}
+
+	expected := []byte(ComputeSignature(payload, signedAt, secret))
+
+	for _, signature := range signatures {
+		if hmac.Equal(expected, []byte(signature)) {
+			return nil
+		}
+	}
+
+	return ErrInvalidSignature
+}
+
+// Event is the Stripe webhook event body.
+type Event struct {
+	Id      string `json:"id"`
+	Type    string `json:"type"`
+	Created int64  `json:"created"`
+	Data    struct {
+		Object json.RawMessage `json:"object"`
+	} `json:"data"`
+}
+
+// UnmarshalObject decodes the event data object into v
+// (eg. [CheckoutSession] or [Subscription]).
+func (e *Event) UnmarshalObject(v any) error {
+	if len(e.Data.Object) == 0 {
+		return errors.New("missing event data object")
+	}
+
+	return json.Unmarshal(e.Data.Object, v)
+}
+
+// CheckoutSession is the "checkout.session.completed" event object.
+type CheckoutSession struct {
+	Id                string            `json:"id"`
+	Customer          string            `json:"customer"`
+	ClientReferenceId string            `json:"client_reference_id"`
+	Subscription      string            `json:"subscription"`
+	Metadata          map[string]string `json:"metadata"`
+}
+
+// Subscription is the "customer.subscription.*" events object.
+type Subscription struct {
+	Id               string            `json:"id"`
+	Customer         string            `json:"customer"`
+	Status           string            `json:"status"`
+	CurrentPeriodEnd int64             `json:"current_period_end"`
+	Metadata         map[string]string `json:"metadata"`
+	Items            struct {
+		Data []struct {
+			Price struct {
+				Id string `json:"id"`
+			} `json:"price"`
+		} `json:"data"`
+	} `json:"items"`
+}
+
+// PriceId returns the price id of the first subscription item (if any).
+func (s *Subscription) PriceId() string {
+	if len(s.Items.Data) == 0 {
+		return ""
+	}
+
+	return s.Items.Data[0].Price.Id
+}
@@ -0,0 +1,70 @@
+package stripe_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/stripe"
+)
+
+func TestVerifySignature(t *testing.T) {
+	payload := []byte(`{"id":"evt_test"}`)
+	secret := "whsec_test"
+	now := time.Unix(1680000000, 0)
+
+	valid := stripe.ComputeSignature(payload, now, secret)
+
+	scenarios := []struct {
+		name        string
+		header      string
+		tolerance   time.Duration
+		expectedErr error
+	}{
+		{"empty header", "", stripe.DefaultTolerance, stripe.ErrMissingSignature},
+		{"missing timestamp", "v1=" + valid, stripe.DefaultTolerance, stripe.ErrMissingSignature},
+		{"missing signature", "t=1680000000,v0=" + valid, stripe.DefaultTolerance, stripe.ErrMissingSignature},
+		{"invalid signature", "t=1680000000,v1=abc", stripe.DefaultTolerance, stripe.ErrInvalidSignature},
+		{"signature for different timestamp", "t=1680000001,v1=" + valid, stripe.DefaultTolerance, stripe.ErrInvalidSignature},
+		{"expired timestamp", stripe.SignatureHeaderValue(payload, now.Add(-10*time.Minute), secret), stripe.DefaultTolerance, stripe.ErrExpiredSignature},
+		{"future timestamp", stripe.SignatureHeaderValue(payload, now.Add(10*time.Minute), secret), stripe.DefaultTolerance, stripe.ErrExpiredSignature},
+		{"expired timestamp without tolerance", stripe.SignatureHeaderValue(payload, now.Add(-10*time.Minute), secret), 0, nil},
+		{"valid signature", stripe.SignatureHeaderValue(payload, now, secret), stripe.DefaultTolerance, nil},
+		{"one of multiple signatures", "t=1680000000, v1=abc, v1=" + valid, stripe.DefaultTolerance, nil},
+	}
+
+	for _, s := range scenarios {
+		err := stripe.VerifySignature(payload, s.header, secret, s.tolerance, now)
+
+		if !errors.Is(err, s.expectedErr) {
+			t.Errorf("[%s] Expected error %v, got %v", s.name, s.expectedErr, err)
+		}
+	}
+}
+
+func TestEventUnmarshalObject(t *testing.T) {
+	event := stripe.Event{}
+
+	if err := event.UnmarshalObject(&stripe.Subscription{}); err == nil {
+		t.Fatal("Expected error for missing data object, got nil")
+	}
+
+	event.Data.Object = []byte(`{"id":"sub_1","customer":"cus_1","status":"active","current_period_end":1680000000,"items":{"data":[{"price":{"id":"price_1"}}]}}`)
+
+	sub := &stripe.Subscription{}
+	if err := event.UnmarshalObject(sub); err != nil {
+		t.Fatal(err)
+	}
+
+	if sub.Id != "sub_1" || sub.Customer != "cus_1" || sub.Status != "active" || sub.CurrentPeriodEnd != 1680000000 {
+		t.Fatalf("Unexpected subscription %v", sub)
+	}
+
+	if price := sub.PriceId(); price != "price_1" {
+		t.Fatalf("Expected price_1, got %q", price)
+	}
+
+	if price := (&stripe.Subscription{}).PriceId(); price != "" {
+		t.Fatalf("Expected empty price, got %q", price)
+	}
+}
@@ -9,15 +9,22 @@ import (
 type Broker struct {
 	mux     sync.RWMutex
 	clients map[string]Client
+	replay  *ReplayBuffer
 }
 
 // NewBroker initializes and returns a new Broker instance.
 func NewBroker() *Broker {
 	return &Broker{
 		clients: make(map[string]Client),
+		replay:  NewReplayBuffer(),
 	}
 }
 
+// Replay returns the broker events replay buffer.
+func (b *Broker) Replay() *ReplayBuffer {
+	return b.replay
+}
+
 // Clients returns a shallow copy of all registered clients indexed
 // with their connection id.
 func (b *Broker) Clients() map[string]Client {
@@ -91,3 +91,11 @@ func TestUnregister(t *testing.T) {
 		t.Fatalf("Expected client with id %s, got error %v", clientB.Id(), err)
 	}
 }
+
+func TestReplay(t *testing.T) {
+	b := subscriptions.NewBroker()
+
+	if b.Replay() == nil {
+		t.Fatal("Expected the replay buffer to be initialized")
+	}
+}
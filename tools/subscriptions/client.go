@@ -10,6 +10,9 @@ import (
 type Message struct {
 	Name string
 	Data string
+
+	// Id is an optional event id (see [ReplayBuffer]).
+	Id string
 }
 
 // Client is an interface for a generic subscription client.
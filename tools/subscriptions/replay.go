@@ -0,0 +1,157 @@
+package subscriptions
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ReplayFunc builds the message of a buffered event for the specified
+// client and topic.
+//
+// It should return false if the client is not allowed to receive the event.
+type ReplayFunc func(client Client, topic string) (Message, bool)
+
+// ReplayEvent defines a single buffered topic event.
+type ReplayEvent struct {
+	Id    string
+	Topic string
+
+	created time.Time
+	seq     uint64
+	fn      ReplayFunc
+}
+
+// Message builds the event message for the specified client
+// (the returned message Id is always set to the event id).
+func (e *ReplayEvent) Message(client Client) (Message, bool) {
+	msg, ok := e.fn(client, e.Topic)
+
+	msg.Id = e.Id
+
+	return msg, ok
+}
+
+// ReplayBuffer defines a concurrent safe bounded per topic buffer with
+// the recently broadcasted events, allowing the reconnecting clients
+// to receive the events that were sent while they were offline.
+type ReplayBuffer struct {
+	mux       sync.RWMutex
+	seq       uint64
+	topics    map[string][]*ReplayEvent
+	lastPrune time.Time
+}
+
+// NewReplayBuffer creates and returns a new ReplayBuffer instance.
+func NewReplayBuffer() *ReplayBuffer {
+	return &ReplayBuffer{
+		// seed the sequence with the current time so that the event ids
+		// could be compared even after app restart
+		seq:    uint64(time.Now().UnixNano()),
+		topics: map[string][]*ReplayEvent{},
+	}
+}
+
+// Add registers a new event for each of the provided topics and
+// returns its id (the same for all topics).
+//
+// The topic buffers keep up to maxSize events that are not older than ttl.
+// If maxSize or ttl are not positive, the event is not buffered and
+// an empty string is returned.
+func (b *ReplayBuffer) Add(topics []string, fn ReplayFunc, maxSize int, ttl time.Duration) string {
+	if maxSize <= 0 || ttl <= 0 || len(topics) == 0 {
+		return ""
+	}
+
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	now := time.Now()
+
+	b.seq++
+	id := strconv.FormatUint(b.seq, 10)
+
+	for _, topic := range topics {
+		events := append(b.topics[topic], &ReplayEvent{
+			Id:      id,
+			Topic:   topic,
+			created: now,
+			seq:     b.seq,
+			fn:      fn,
+		})
+
+		if len(events) > maxSize {
+			events = events[len(events)-maxSize:]
+		}
+
+		b.topics[topic] = events
+	}
+
+	// periodically remove the expired events from all topics
+	// (the record topics could be too many to keep them forever)
+	if now.Sub(b.lastPrune) >= time.Second {
+		b.prune(now.Add(-ttl))
+		b.lastPrune = now
+	}
+
+	return id
+}
+
+// Since returns the buffered events of the provided topics that
+// were added after the lastEventId event (sorted by their id).
+//
+// Returns nil if lastEventId is not a valid event id.
+func (b *ReplayBuffer) Since(lastEventId string, topics []string, ttl time.Duration) []*ReplayEvent {
+	lastSeq, err := strconv.ParseUint(lastEventId, 10, 64)
+	if err != nil {
+		return nil
+	}
+
+	b.mux.RLock()
+	defer b.mux.RUnlock()
+
+	minCreated := time.Now().Add(-ttl)
+
+	result := []*ReplayEvent{}
+
+	for _, topic := range topics {
+		for _, e := range b.topics[topic] {
+			if e.seq > lastSeq && e.created.After(minCreated) {
+				result = append(result, e)
+			}
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].seq < result[j].seq
+	})
+
+	return result
+}
+
+// Clear removes all buffered events.
+func (b *ReplayBuffer) Clear() {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	b.topics = map[string][]*ReplayEvent{}
+}
+
+// prune removes all events created before minCreated.
+//
+// Note: the caller must hold the write lock.
+func (b *ReplayBuffer) prune(minCreated time.Time) {
+	for topic, events := range b.topics {
+		// the events are sorted by their creation time
+		i := sort.Search(len(events), func(i int) bool {
+			return events[i].created.After(minCreated)
+		})
+
+		if i == len(events) {
+			delete(b.topics, topic)
+		} else if i > 0 {
+			b.topics[topic] = events[i:]
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package subscriptions_test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/subscriptions"
+)
+
+func TestReplayBufferAdd(t *testing.T) {
+	b := subscriptions.NewReplayBuffer()
+
+	fn := func(client subscriptions.Client, topic string) (subscriptions.Message, bool) {
+		return subscriptions.Message{Name: topic, Data: "test"}, true
+	}
+
+	// disabled
+	if id := b.Add([]string{"a"}, fn, 0, time.Minute); id != "" {
+		t.Fatalf("Expected empty id for zero maxSize, got %q", id)
+	}
+	if id := b.Add([]string{"a"}, fn, 10, 0); id != "" {
+		t.Fatalf("Expected empty id for zero ttl, got %q", id)
+	}
+	if id := b.Add(nil, fn, 10, time.Minute); id != "" {
+		t.Fatalf("Expected empty id for no topics, got %q", id)
+	}
+
+	id1 := b.Add([]string{"a", "b"}, fn, 2, time.Minute)
+	id2 := b.Add([]string{"a"}, fn, 2, time.Minute)
+	id3 := b.Add([]string{"a"}, fn, 2, time.Minute) // should evict id1 from "a"
+
+	if id1 == "" || id2 == "" || id3 == "" || id1 == id2 || id2 == id3 {
+		t.Fatalf("Expected unique non-empty ids, got %q, %q, %q", id1, id2, id3)
+	}
+
+	seq1, _ := strconv.ParseUint(id1, 10, 64)
+	before := strconv.FormatUint(seq1-1, 10)
+
+	events := b.Since(before, []string{"a", "b", "missing"}, time.Minute)
+
+	expected := []string{id1 + ":b", id2 + ":a", id3 + ":a"}
+
+	if len(events) != len(expected) {
+		t.Fatalf("Expected %d events, got %d", len(expected), len(events))
+	}
+
+	for i, e := range events {
+		if e.Id+":"+e.Topic != expected[i] {
+			t.Fatalf("(%d) Expected event %q, got %q", i, expected[i], e.Id+":"+e.Topic)
+		}
+
+		msg, ok := e.Message(subscriptions.NewDefaultClient())
+		if !ok || msg.Id != e.Id || msg.Name != e.Topic || msg.Data != "test" {
+			t.Fatalf("(%d) Unexpected message %v (%v)", i, msg, ok)
+		}
+	}
+
+	// only the events after id2
+	if events := b.Since(id2, []string{"a", "b"}, time.Minute); len(events) != 1 || events[0].Id != id3 {
+		t.Fatalf("Expected only %q event, got %v", id3, events)
+	}
+
+	// invalid last event id
+	if events := b.Since("invalid", []string{"a"}, time.Minute); events != nil {
+		t.Fatalf("Expected nil events, got %v", events)
+	}
+
+	b.Clear()
+
+	if events := b.Since(before, []string{"a", "b"}, time.Minute); len(events) != 0 {
+		t.Fatalf("Expected no events after clear, got %v", events)
+	}
+}
+
+func TestReplayBufferTTL(t *testing.T) {
+	b := subscriptions.NewReplayBuffer()
+
+	fn := func(client subscriptions.Client, topic string) (subscriptions.Message, bool) {
+		return subscriptions.Message{Name: topic}, true
+	}
+
+	id := b.Add([]string{"a"}, fn, 10, time.Minute)
+
+	seq, _ := strconv.ParseUint(id, 10, 64)
+	before := strconv.FormatUint(seq-1, 10)
+
+	time.Sleep(5 * time.Millisecond)
+
+	if events := b.Since(before, []string{"a"}, time.Minute); len(events) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(events))
+	}
+
+	if events := b.Since(before, []string{"a"}, time.Millisecond); len(events) != 0 {
+		t.Fatalf("Expected the event to be expired, got %d", len(events))
+	}
+}
@@ -0,0 +1,128 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cast"
+)
+
+// ParseGeoPoint creates a new GeoPoint from the provided value
+// (could be GeoPoint, *GeoPoint, map with "lat" and "lng" keys,
+// json encoded object string/[]byte or "lat,lng" string).
+func ParseGeoPoint(value any) (GeoPoint, error) {
+	p := GeoPoint{}
+	err := p.Scan(value)
+	return p, err
+}
+
+// GeoPoint defines a geographic coordinates pair (in decimal degrees)
+// that is safe for json and db read/write.
+type GeoPoint struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+// IsValid checks whether the current point coordinates are
+// within the valid latitude [-90, 90] and longitude [-180, 180] ranges.
+func (p GeoPoint) IsValid() bool {
+	return p.Lat >= -90 && p.Lat <= 90 && p.Lng >= -180 && p.Lng <= 180
+}
+
+// String serializes the current GeoPoint instance into a "lat,lng" string.
+func (p GeoPoint) String() string {
+	return strconv.FormatFloat(p.Lat, 'f', -1, 64) + "," + strconv.FormatFloat(p.Lng, 'f', -1, 64)
+}
+
+// Value implements the [driver.Valuer] interface.
+func (p GeoPoint) Value() (driver.Value, error) {
+	data, err := json.Marshal(p)
+
+	return string(data), err
+}
+
+// Scan implements [sql.Scanner] interface to scan the provided value
+// into the current GeoPoint instance.
+func (p *GeoPoint) Scan(value any) error {
+	switch v := value.(type) {
+	case GeoPoint:
+		*p = v
+		return nil
+	case *GeoPoint:
+		if v == nil {
+			return errors.New("Failed to scan nil GeoPoint value.")
+		}
+		*p = *v
+		return nil
+	case JsonMap:
+		return p.scanMap(v)
+	case map[string]any:
+		return p.scanMap(v)
+	case []byte:
+		return p.scanString(string(v))
+	case string:
+		return p.scanString(v)
+	default:
+		return fmt.Errorf("Failed to scan GeoPoint value: %q.", value)
+	}
+}
+
+func (p *GeoPoint) scanString(value string) error {
+	value = strings.TrimSpace(value)
+
+	if strings.HasPrefix(value, "{") {
+		data := map[string]any{}
+		if err := json.Unmarshal([]byte(value), &data); err != nil {
+			return err
+		}
+
+		return p.scanMap(data)
+	}
+
+	parts := strings.Split(value, ",")
+	if len(parts) != 2 {
+		return fmt.Errorf("Invalid GeoPoint string %q (expected \"lat,lng\").", value)
+	}
+
+	lat, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil {
+		return err
+	}
+
+	lng, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil {
+		return err
+	}
+
+	p.Lat = lat
+	p.Lng = lng
+
+	return nil
+}
+
+func (p *GeoPoint) scanMap(value map[string]any) error {
+	rawLat, hasLat := value["lat"]
+	rawLng, hasLng := value["lng"]
+	if !hasLat || !hasLng {
+		return errors.New("Missing GeoPoint lat or lng coordinate.")
+	}
+
+	lat, err := cast.ToFloat64E(rawLat)
+	if err != nil {
+		return err
+	}
+
+	lng, err := cast.ToFloat64E(rawLng)
+	if err != nil {
+		return err
+	}
+
+	p.Lat = lat
+	p.Lng = lng
+
+	return nil
+}
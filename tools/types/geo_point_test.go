@@ -0,0 +1,93 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestParseGeoPoint(t *testing.T) {
+	point := types.GeoPoint{Lat: 1.5, Lng: -2}
+
+	scenarios := []struct {
+		value       any
+		expectError bool
+		expected    types.GeoPoint
+	}{
+		{nil, true, types.GeoPoint{}},
+		{"", true, types.GeoPoint{}},
+		{123, true, types.GeoPoint{}},
+		{"invalid", true, types.GeoPoint{}},
+		{"1,2,3", true, types.GeoPoint{}},
+		{"1,abc", true, types.GeoPoint{}},
+		{`{"lat":1}`, true, types.GeoPoint{}},
+		{`{"lat":"abc","lng":2}`, true, types.GeoPoint{}},
+		{`{invalid`, true, types.GeoPoint{}},
+		{(*types.GeoPoint)(nil), true, types.GeoPoint{}},
+		{point, false, point},
+		{&point, false, point},
+		{" 1.5, -2 ", false, point},
+		{[]byte("1.5,-2"), false, point},
+		{`{"lat":1.5,"lng":-2}`, false, point},
+		{map[string]any{"lat": "1.5", "lng": -2}, false, point},
+		{types.JsonMap{"lat": 1.5, "lng": -2.0}, false, point},
+		{"100,200", false, types.GeoPoint{Lat: 100, Lng: 200}},
+	}
+
+	for i, s := range scenarios {
+		result, err := types.ParseGeoPoint(s.value)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("(%d) Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+			continue
+		}
+
+		if !hasErr && result != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, result)
+		}
+	}
+}
+
+func TestGeoPointIsValid(t *testing.T) {
+	scenarios := []struct {
+		point    types.GeoPoint
+		expected bool
+	}{
+		{types.GeoPoint{}, true},
+		{types.GeoPoint{Lat: 90, Lng: 180}, true},
+		{types.GeoPoint{Lat: -90, Lng: -180}, true},
+		{types.GeoPoint{Lat: 90.1, Lng: 0}, false},
+		{types.GeoPoint{Lat: -90.1, Lng: 0}, false},
+		{types.GeoPoint{Lat: 0, Lng: 180.1}, false},
+		{types.GeoPoint{Lat: 0, Lng: -180.1}, false},
+	}
+
+	for i, s := range scenarios {
+		if result := s.point.IsValid(); result != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, result)
+		}
+	}
+}
+
+func TestGeoPointString(t *testing.T) {
+	point := types.GeoPoint{Lat: 42.6977, Lng: -23.3219}
+
+	if result := point.String(); result != "42.6977,-23.3219" {
+		t.Fatalf("Expected 42.6977,-23.3219, got %s", result)
+	}
+}
+
+func TestGeoPointValue(t *testing.T) {
+	point := types.GeoPoint{Lat: 42.6977, Lng: -23}
+
+	result, err := point.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"lat":42.6977,"lng":-23}`
+	if result != expected {
+		t.Fatalf("Expected %s, got %v", expected, result)
+	}
+}
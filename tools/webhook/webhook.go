@@ -0,0 +1,96 @@
+// Package webhook implements the signing and the signature
+// verification of the app webhook deliveries.
+//
+// The signature format is compatible with the Stripe one, aka.
+// "t=<unix timestamp>,v1=<hex HMAC-SHA256 of "timestamp.payload">".
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// List of the webhook delivery request headers.
+const (
+	SignatureHeader = "X-Webhook-Signature"
+	IdHeader        = "X-Webhook-Id"
+	EventHeader     = "X-Webhook-Event"
+)
+
+// DefaultTolerance is the max allowed difference between the
+// signature timestamp and the current time (to prevent replay attacks).
+const DefaultTolerance = 5 * time.Minute
+
+// List of the signature verification errors.
+var (
+	ErrMissingSignature = errors.New("missing or malformed signature header")
+	ErrInvalidSignature = errors.New("the signature doesn't match the payload")
+	ErrExpiredSignature = errors.New("the signature timestamp is outside of the tolerance window")
+)
+
+// ComputeSignature returns the hex encoded v1 signature of the
+// payload sent at the provided time with the webhook secret.
+func ComputeSignature(payload []byte, t time.Time, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(t.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// SignatureHeaderValue returns the signature header value of the
+// payload sent at the provided time (eg. "t=1680000000,v1=abc...").
+func SignatureHeaderValue(payload []byte, t time.Time, secret string) string {
+	return fmt.Sprintf("t=%d,v1=%s", t.Unix(), ComputeSignature(payload, t, secret))
+}
+
+// VerifySignature checks whether the signature header is a valid
+// signature of the payload with the webhook secret and its
+// timestamp is within the tolerance window from now.
+//
+// It is intended to be used by the webhook receivers written in Go.
+func VerifySignature(payload []byte, header string, secret string, tolerance time.Duration, now time.Time) error {
+	var timestamp int64
+	var signatures []string
+
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+
+		switch key {
+		case "t":
+			timestamp, _ = strconv.ParseInt(value, 10, 64)
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+
+	if timestamp <= 0 || len(signatures) == 0 {
+		return ErrMissingSignature
+	}
+
+	signedAt := time.Unix(timestamp, 0)
+
+	if tolerance > 0 && (now.Sub(signedAt) > tolerance || signedAt.Sub(now) > tolerance) {
+		return ErrExpiredSignature
+	}
+
+	expected := []byte(ComputeSignature(payload, signedAt, secret))
+
+	for _, signature := range signatures {
+		if hmac.Equal(expected, []byte(signature)) {
+			return nil
+		}
+	}
+
+	return ErrInvalidSignature
+}
@@ -0,0 +1,40 @@
+package webhook_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/webhook"
+)
+
+func TestVerifySignature(t *testing.T) {
+	payload := []byte(`{"id":"test"}`)
+	secret := "test_secret"
+	now := time.Unix(1680000000, 0)
+
+	valid := webhook.ComputeSignature(payload, now, secret)
+
+	scenarios := []struct {
+		name        string
+		header      string
+		tolerance   time.Duration
+		expectedErr error
+	}{
+		{"empty header", "", webhook.DefaultTolerance, webhook.ErrMissingSignature},
+		{"missing timestamp", "v1=" + valid, webhook.DefaultTolerance, webhook.ErrMissingSignature},
+		{"invalid signature", "t=1680000000,v1=abc", webhook.DefaultTolerance, webhook.ErrInvalidSignature},
+		{"signature with different secret", webhook.SignatureHeaderValue(payload, now, "other"), webhook.DefaultTolerance, webhook.ErrInvalidSignature},
+		{"expired timestamp", webhook.SignatureHeaderValue(payload, now.Add(-10*time.Minute), secret), webhook.DefaultTolerance, webhook.ErrExpiredSignature},
+		{"expired timestamp without tolerance", webhook.SignatureHeaderValue(payload, now.Add(-10*time.Minute), secret), 0, nil},
+		{"valid signature", webhook.SignatureHeaderValue(payload, now, secret), webhook.DefaultTolerance, nil},
+	}
+
+	for _, s := range scenarios {
+		err := webhook.VerifySignature(payload, s.header, secret, s.tolerance, now)
+
+		if !errors.Is(err, s.expectedErr) {
+			t.Errorf("[%s] Expected error %v, got %v", s.name, s.expectedErr, err)
+		}
+	}
+}
@@ -0,0 +1,492 @@
+// Package xlsx implements a minimal streaming writer of
+// Office Open XML spreadsheet (.xlsx) files.
+//
+// The sheet rows are written directly to the underlying zip archive,
+// which allows generating large spreadsheets without holding their
+// content in memory. Only the workbook metadata (the sheet names)
+// is kept until [Writer.Close()].
+//
+// Example:
+//
+//	w := xlsx.NewWriter(out)
+//
+//	sheet, err := w.AddSheet("users", []xlsx.Column{
+//		{Name: "name"},
+//		{Name: "age", Type: xlsx.TypeNumber},
+//	})
+//	...
+//	sheet.WriteRow("test", 30)
+//	...
+//	err = w.Close()
+package xlsx
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cast"
+)
+
+// Supported column types.
+const (
+	TypeString = "string"
+	TypeNumber = "number"
+	TypeBool   = "bool"
+	TypeDate   = "date"
+)
+
+// SheetNameMaxLength is the max length of a sheet name allowed by Excel.
+const SheetNameMaxLength = 31
+
+// cell styles defined in the stylesXml (the index of their cellXfs entry)
+const (
+	styleDate   = 1
+	styleHeader = 2
+)
+
+// Column describes a single sheet column.
+type Column struct {
+	// Name is the column header label.
+	Name string
+
+	// Type is the type of the column cells (TypeString, TypeNumber, TypeBool or TypeDate).
+	//
+	// The row values are converted to the column type and
+	// fallback to a string cell if the conversion fails.
+	// Empty Type is the same as TypeString.
+	Type string
+}
+
+// Writer is a streaming xlsx file writer.
+//
+// Writer is not safe for concurrent use.
+type Writer struct {
+	zw      *zip.Writer
+	sheets  []string
+	current *Sheet
+	closed  bool
+}
+
+// NewWriter creates a new xlsx Writer that writes to w.
+//
+// Call [Writer.Close()] to finish the file.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{zw: zip.NewWriter(w)}
+}
+
+// AddSheet finishes the previous sheet (if any) and starts a new one
+// with a header row for the provided columns.
+//
+// The sheet name is normalized to satisfy the Excel requirements
+// (max 31 characters, without []:*?/\ and unique within the workbook).
+func (w *Writer) AddSheet(name string, columns []Column) (*Sheet, error) {
+	if w.closed {
+		return nil, errors.New("xlsx: writer is closed")
+	}
+
+	if err := w.finishSheet(); err != nil {
+		return nil, err
+	}
+
+	w.sheets = append(w.sheets, w.uniqueSheetName(name))
+
+	entry, err := w.zw.Create(fmt.Sprintf("xl/worksheets/sheet%d.xml", len(w.sheets)))
+	if err != nil {
+		return nil, err
+	}
+
+	sheet := &Sheet{
+		w:       bufio.NewWriter(entry),
+		columns: columns,
+	}
+
+	sheet.w.WriteString(xml.Header)
+	sheet.w.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+
+	header := make([]any, len(columns))
+	for i, c := range columns {
+		header[i] = c.Name
+	}
+	if err := sheet.writeRow(header, true); err != nil {
+		return nil, err
+	}
+
+	w.current = sheet
+
+	return sheet, nil
+}
+
+// Close finishes the last sheet and writes the workbook metadata.
+//
+// A workbook must have at least one sheet, so an empty "Sheet1"
+// sheet is added if no sheets were created.
+//
+// Close doesn't close the underlying writer.
+func (w *Writer) Close() error {
+	if w.closed {
+		return nil
+	}
+
+	if len(w.sheets) == 0 {
+		if _, err := w.AddSheet("Sheet1", nil); err != nil {
+			return err
+		}
+	}
+
+	if err := w.finishSheet(); err != nil {
+		return err
+	}
+
+	w.closed = true
+
+	files := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", w.contentTypesXml()},
+		{"_rels/.rels", rootRelsXml},
+		{"xl/workbook.xml", w.workbookXml()},
+		{"xl/_rels/workbook.xml.rels", w.workbookRelsXml()},
+		{"xl/styles.xml", stylesXml},
+	}
+
+	for _, f := range files {
+		entry, err := w.zw.Create(f.name)
+		if err != nil {
+			return err
+		}
+
+		if _, err := io.WriteString(entry, f.content); err != nil {
+			return err
+		}
+	}
+
+	return w.zw.Close()
+}
+
+func (w *Writer) finishSheet() error {
+	if w.current == nil {
+		return nil
+	}
+
+	sheet := w.current
+	w.current = nil
+
+	if sheet.err != nil {
+		return sheet.err
+	}
+
+	sheet.w.WriteString(`</sheetData></worksheet>`)
+
+	return sheet.w.Flush()
+}
+
+func (w *Writer) uniqueSheetName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '_'
+		}
+		return r
+	}, strings.Trim(name, "'"))
+
+	if name == "" {
+		name = "Sheet" + strconv.Itoa(len(w.sheets)+1)
+	}
+
+	base := truncateName(name, SheetNameMaxLength)
+	result := base
+
+	for i := 2; w.hasSheet(result); i++ {
+		suffix := " (" + strconv.Itoa(i) + ")"
+		result = truncateName(base, SheetNameMaxLength-len(suffix)) + suffix
+	}
+
+	return result
+}
+
+// hasSheet reports whether a sheet with the provided (case-insensitive) name exists.
+func (w *Writer) hasSheet(name string) bool {
+	for _, existing := range w.sheets {
+		if strings.EqualFold(existing, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func truncateName(name string, max int) string {
+	runes := []rune(name)
+	if len(runes) > max {
+		return string(runes[:max])
+	}
+
+	return name
+}
+
+// Sheet is a single workbook sheet created with [Writer.AddSheet()].
+//
+// The sheet rows can be written only until the next sheet is added
+// or the writer is closed.
+type Sheet struct {
+	w       *bufio.Writer
+	columns []Column
+	rows    int
+	err     error
+}
+
+// WriteRow writes a single row with the provided values
+// (converted to their column type).
+//
+// The values without a column are written as strings.
+func (s *Sheet) WriteRow(values ...any) error {
+	return s.writeRow(values, false)
+}
+
+func (s *Sheet) writeRow(values []any, isHeader bool) error {
+	if s.err != nil {
+		return s.err
+	}
+
+	s.rows++
+	row := strconv.Itoa(s.rows)
+
+	s.w.WriteString(`<row r="` + row + `">`)
+
+	for i, value := range values {
+		column := Column{}
+		if !isHeader && i < len(s.columns) {
+			column = s.columns[i]
+		}
+
+		ref := ColumnName(i) + row
+
+		var err error
+		if isHeader {
+			err = s.writeString(ref, cast.ToString(value), styleHeader)
+		} else {
+			err = s.writeCell(ref, column.Type, value)
+		}
+		if err != nil {
+			s.err = err
+			return err
+		}
+	}
+
+	if _, err := s.w.WriteString(`</row>`); err != nil {
+		s.err = err
+		return err
+	}
+
+	return nil
+}
+
+func (s *Sheet) writeCell(ref string, columnType string, value any) error {
+	if value == nil {
+		return nil // empty cell
+	}
+
+	switch columnType {
+	case TypeNumber:
+		if n, err := cast.ToFloat64E(value); err == nil && !math.IsNaN(n) && !math.IsInf(n, 0) {
+			s.w.WriteString(`<c r="` + ref + `"><v>` + strconv.FormatFloat(n, 'f', -1, 64) + `</v></c>`)
+			return nil
+		}
+	case TypeBool:
+		if b, err := cast.ToBoolE(value); err == nil {
+			v := "0"
+			if b {
+				v = "1"
+			}
+			s.w.WriteString(`<c r="` + ref + `" t="b"><v>` + v + `</v></c>`)
+			return nil
+		}
+	case TypeDate:
+		t, err := toTime(value)
+		if err == nil && t.IsZero() {
+			return nil // empty cell
+		}
+		if err == nil {
+			s.w.WriteString(`<c r="` + ref + `" s="` + strconv.Itoa(styleDate) + `"><v>` + excelDate(t) + `</v></c>`)
+			return nil
+		}
+	}
+
+	str, err := toString(value)
+	if err != nil {
+		return err
+	}
+
+	return s.writeString(ref, str, 0)
+}
+
+func (s *Sheet) writeString(ref string, value string, style int) error {
+	if value == "" {
+		return nil // empty cell
+	}
+
+	s.w.WriteString(`<c r="` + ref + `" t="inlineStr"`)
+	if style > 0 {
+		s.w.WriteString(` s="` + strconv.Itoa(style) + `"`)
+	}
+	s.w.WriteString(`><is><t xml:space="preserve">`)
+
+	if err := xml.EscapeText(s.w, []byte(value)); err != nil {
+		return err
+	}
+
+	_, err := s.w.WriteString(`</t></is></c>`)
+
+	return err
+}
+
+// ColumnName returns the spreadsheet column letters
+// of the provided zero-based column index (0 -> A, 26 -> AA, etc.).
+func ColumnName(index int) string {
+	name := ""
+
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+
+	return name
+}
+
+// excelEpoch is the base date of the Excel 1900 date system serial numbers.
+var excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// excelDate returns the Excel serial date number of the provided time
+// (the fractional part is the time of the day).
+func excelDate(t time.Time) string {
+	t = t.UTC()
+	days := t.Sub(excelEpoch).Hours() / 24
+
+	return strconv.FormatFloat(days, 'f', -1, 64)
+}
+
+func toTime(value any) (time.Time, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case *time.Time:
+		if v == nil {
+			return time.Time{}, nil
+		}
+		return *v, nil
+	case fmt.Stringer:
+		str := v.String()
+		if str == "" {
+			return time.Time{}, nil
+		}
+		return cast.ToTimeE(str)
+	case string:
+		if v == "" {
+			return time.Time{}, nil
+		}
+	}
+
+	return cast.ToTimeE(value)
+}
+
+// toString normalizes the provided value into a single string cell
+// (non-scalar values are json encoded).
+func toString(value any) (string, error) {
+	switch v := value.(type) {
+	case string, bool, int, int64, float64:
+		return cast.ToString(v), nil
+	case fmt.Stringer:
+		return v.String(), nil
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	return string(raw), nil
+}
+
+func (w *Writer) contentTypesXml() string {
+	var b strings.Builder
+
+	b.WriteString(xml.Header)
+	b.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	b.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	b.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+	b.WriteString(`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	b.WriteString(`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	for i := range w.sheets {
+		b.WriteString(fmt.Sprintf(
+			`<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`,
+			i+1,
+		))
+	}
+	b.WriteString(`</Types>`)
+
+	return b.String()
+}
+
+func (w *Writer) workbookXml() string {
+	var b strings.Builder
+
+	b.WriteString(xml.Header)
+	b.WriteString(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	for i, name := range w.sheets {
+		b.WriteString(`<sheet name="`)
+		xml.EscapeText(&b, []byte(name))
+		b.WriteString(fmt.Sprintf(`" sheetId="%d" r:id="rId%d"/>`, i+1, i+1))
+	}
+	b.WriteString(`</sheets></workbook>`)
+
+	return b.String()
+}
+
+func (w *Writer) workbookRelsXml() string {
+	var b strings.Builder
+
+	b.WriteString(xml.Header)
+	b.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for i := range w.sheets {
+		b.WriteString(fmt.Sprintf(
+			`<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`,
+			i+1, i+1,
+		))
+	}
+	b.WriteString(fmt.Sprintf(
+		`<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`,
+		len(w.sheets)+1,
+	))
+	b.WriteString(`</Relationships>`)
+
+	return b.String()
+}
+
+const rootRelsXml = xml.Header +
+	`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+// stylesXml defines the default, date (yyyy-mm-dd hh:mm:ss) and bold header cell styles.
+const stylesXml = xml.Header +
+	`<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<numFmts count="1"><numFmt numFmtId="164" formatCode="yyyy-mm-dd hh:mm:ss"/></numFmts>` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="3">` +
+	`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+	`</cellXfs>` +
+	`</styleSheet>`
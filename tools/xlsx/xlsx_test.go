@@ -0,0 +1,168 @@
+package xlsx_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/types"
+	"github.com/pocketbase/pocketbase/tools/xlsx"
+)
+
+func TestColumnName(t *testing.T) {
+	scenarios := []struct {
+		index    int
+		expected string
+	}{
+		{0, "A"},
+		{25, "Z"},
+		{26, "AA"},
+		{51, "AZ"},
+		{52, "BA"},
+		{701, "ZZ"},
+		{702, "AAA"},
+	}
+
+	for _, s := range scenarios {
+		if v := xlsx.ColumnName(s.index); v != s.expected {
+			t.Errorf("(%d) Expected %q, got %q", s.index, s.expected, v)
+		}
+	}
+}
+
+func TestWriterEmpty(t *testing.T) {
+	buf := new(bytes.Buffer)
+
+	if err := xlsx.NewWriter(buf).Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	files := readXlsx(t, buf.Bytes())
+
+	if !strings.Contains(files["xl/workbook.xml"], `<sheet name="Sheet1" sheetId="1" r:id="rId1"/>`) {
+		t.Fatalf("Expected the default sheet, got \n%s", files["xl/workbook.xml"])
+	}
+}
+
+func TestWriter(t *testing.T) {
+	buf := new(bytes.Buffer)
+
+	w := xlsx.NewWriter(buf)
+
+	sheet1, err := w.AddSheet("a/b:c", []xlsx.Column{
+		{Name: "name"},
+		{Name: "total", Type: xlsx.TypeNumber},
+		{Name: "active", Type: xlsx.TypeBool},
+		{Name: "created", Type: xlsx.TypeDate},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	created, _ := types.ParseDateTime("2023-01-07 12:00:00.000Z")
+
+	rows := [][]any{
+		{"a <&> b", 1.5, true, created},
+		{"", "invalid", "false", time.Date(1900, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{[]string{"x", "y"}, nil, nil, types.DateTime{}, "extra"},
+	}
+	for _, row := range rows {
+		if err := sheet1.WriteRow(row...); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// duplicated (case-insensitive) and long sheet names
+	if _, err := w.AddSheet("A_B_C", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.AddSheet(strings.Repeat("x", 40), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := w.AddSheet("test", nil); err == nil {
+		t.Fatal("Expected error after close, got nil")
+	}
+
+	files := readXlsx(t, buf.Bytes())
+
+	for _, name := range []string{
+		"[Content_Types].xml",
+		"_rels/.rels",
+		"xl/workbook.xml",
+		"xl/_rels/workbook.xml.rels",
+		"xl/styles.xml",
+		"xl/worksheets/sheet1.xml",
+		"xl/worksheets/sheet2.xml",
+		"xl/worksheets/sheet3.xml",
+	} {
+		if _, ok := files[name]; !ok {
+			t.Fatalf("Missing file %q", name)
+		}
+	}
+
+	expectedSheets := []string{
+		`<sheet name="a_b_c" sheetId="1" r:id="rId1"/>`,
+		`<sheet name="A_B_C (2)" sheetId="2" r:id="rId2"/>`,
+		`<sheet name="` + strings.Repeat("x", 31) + `" sheetId="3" r:id="rId3"/>`,
+	}
+	for _, sheet := range expectedSheets {
+		if !strings.Contains(files["xl/workbook.xml"], sheet) {
+			t.Fatalf("Expected %s in \n%s", sheet, files["xl/workbook.xml"])
+		}
+	}
+
+	expectedRows := []string{
+		`<row r="1"><c r="A1" t="inlineStr" s="2"><is><t xml:space="preserve">name</t></is></c>` +
+			`<c r="B1" t="inlineStr" s="2"><is><t xml:space="preserve">total</t></is></c>` +
+			`<c r="C1" t="inlineStr" s="2"><is><t xml:space="preserve">active</t></is></c>` +
+			`<c r="D1" t="inlineStr" s="2"><is><t xml:space="preserve">created</t></is></c></row>`,
+		`<row r="2"><c r="A2" t="inlineStr"><is><t xml:space="preserve">a &lt;&amp;&gt; b</t></is></c>` +
+			`<c r="B2"><v>1.5</v></c>` +
+			`<c r="C2" t="b"><v>1</v></c>` +
+			`<c r="D2" s="1"><v>44933.5</v></c></row>`,
+		`<row r="3"><c r="B3" t="inlineStr"><is><t xml:space="preserve">invalid</t></is></c>` +
+			`<c r="C3" t="b"><v>0</v></c>` +
+			`<c r="D3" s="1"><v>61</v></c></row>`,
+		`<row r="4"><c r="A4" t="inlineStr"><is><t xml:space="preserve">[&#34;x&#34;,&#34;y&#34;]</t></is></c>` +
+			`<c r="E4" t="inlineStr"><is><t xml:space="preserve">extra</t></is></c></row>`,
+	}
+	for _, row := range expectedRows {
+		if !strings.Contains(files["xl/worksheets/sheet1.xml"], row) {
+			t.Fatalf("Expected %s in \n%s", row, files["xl/worksheets/sheet1.xml"])
+		}
+	}
+}
+
+func readXlsx(t *testing.T, content []byte) map[string]string {
+	r, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := map[string]string{}
+
+	for _, f := range r.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		raw, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		result[f.Name] = string(raw)
+	}
+
+	return result
+}